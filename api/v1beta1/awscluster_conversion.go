@@ -46,11 +46,113 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.S3Bucket = restored.Spec.S3Bucket
 	if restored.Status.Bastion != nil {
 		dst.Status.Bastion.InstanceMetadataOptions = restored.Status.Bastion.InstanceMetadataOptions
+		dst.Status.Bastion.AdditionalNetworkInterfaces = restored.Status.Bastion.AdditionalNetworkInterfaces
+		dst.Status.Bastion.CPUOptions = restored.Status.Bastion.CPUOptions
+		dst.Status.Bastion.EnclaveOptions = restored.Status.Bastion.EnclaveOptions
+		dst.Status.Bastion.HibernationOptions = restored.Status.Bastion.HibernationOptions
+		dst.Status.Bastion.CreditSpecification = restored.Status.Bastion.CreditSpecification
 	}
+	dst.Status.BastionConnection = restored.Status.BastionConnection
+	dst.Status.BillableResources = restored.Status.BillableResources
+
+	dst.Spec.Bastion.AllowedPrefixListIDs = restored.Spec.Bastion.AllowedPrefixListIDs
+	dst.Spec.Bastion.IAMInstanceProfile = restored.Spec.Bastion.IAMInstanceProfile
+	dst.Spec.Bastion.ElasticIPPool = restored.Spec.Bastion.ElasticIPPool
+	dst.Spec.NetworkSpec.VPC.NATGatewayElasticIPPool = restored.Spec.NetworkSpec.VPC.NATGatewayElasticIPPool
+	dst.Spec.Bastion.Placement = restored.Spec.Bastion.Placement
+	dst.Spec.Bastion.AMILookup = restored.Spec.Bastion.AMILookup
+	dst.Spec.Bastion.RootVolume = restored.Spec.Bastion.RootVolume
+	dst.Spec.Bastion.AdditionalSecurityGroups = restored.Spec.Bastion.AdditionalSecurityGroups
+	dst.Spec.Bastion.UserData = restored.Spec.Bastion.UserData
+	dst.Spec.Bastion.Type = restored.Spec.Bastion.Type
+	dst.Spec.NetworkSpec.VPC.NATGatewayPlacement = restored.Spec.NetworkSpec.VPC.NATGatewayPlacement
+	dst.Spec.NetworkSpec.VPC.NATStrategy = restored.Spec.NetworkSpec.VPC.NATStrategy
+	dst.Spec.NetworkSpec.VPC.NATInstance = restored.Spec.NetworkSpec.VPC.NATInstance
+	dst.Spec.NetworkSpec.VPC.DHCPOptions = restored.Spec.NetworkSpec.VPC.DHCPOptions
+	dst.Spec.NetworkSpec.VPC.FlowLogs = restored.Spec.NetworkSpec.VPC.FlowLogs
+	dst.Spec.NetworkSpec.VPC.SubnetSchema = restored.Spec.NetworkSpec.VPC.SubnetSchema
+	dst.Spec.NetworkSpec.VPC.AvailabilityZones = restored.Spec.NetworkSpec.VPC.AvailabilityZones
+	dst.Spec.NetworkSpec.AdditionalControlPlaneIngressRules = restored.Spec.NetworkSpec.AdditionalControlPlaneIngressRules
+	dst.Spec.NetworkSpec.AdditionalNodeIngressRules = restored.Spec.NetworkSpec.AdditionalNodeIngressRules
+	dst.Spec.NetworkSpec.NodeEgressRules = restored.Spec.NetworkSpec.NodeEgressRules
+	dst.Spec.NetworkSpec.PrivateIPv6Egress = restored.Spec.NetworkSpec.PrivateIPv6Egress
+	dst.Spec.SecurityProfile = restored.Spec.SecurityProfile
+	dst.Spec.InstanceNameTemplate = restored.Spec.InstanceNameTemplate
+	dst.Spec.ResourceNaming = restored.Spec.ResourceNaming
+	dst.Spec.ResourceRetentionPolicy = restored.Spec.ResourceRetentionPolicy
+	dst.Spec.ServiceEndpoints = restored.Spec.ServiceEndpoints
+	dst.Spec.AdditionalTagsFrom = restored.Spec.AdditionalTagsFrom
+	dst.Spec.Proxy = restored.Spec.Proxy
+	dst.Spec.Monitoring = restored.Spec.Monitoring
+	dst.Spec.DefaultEBSKMSKeyID = restored.Spec.DefaultEBSKMSKeyID
+	dst.Spec.Konnectivity = restored.Spec.Konnectivity
+	dst.Spec.DeletionProtection = restored.Spec.DeletionProtection
+	dst.Spec.Partition = restored.Spec.Partition
+	RestoreCNISpec(restored.Spec.NetworkSpec.CNI, dst.Spec.NetworkSpec.CNI)
+	RestoreSubnets(restored.Spec.NetworkSpec.Subnets, dst.Spec.NetworkSpec.Subnets)
+	RestoreSecurityGroups(restored.Status.Network.SecurityGroups, dst.Status.Network.SecurityGroups)
+	RestoreNetworkStatus(&restored.Status.Network, &dst.Status.Network)
 
 	return nil
 }
 
+// RestoreCNISpec manually restores the CNI ingress rules data.
+// Rules are matched by position, as they are kept in order during conversion.
+func RestoreCNISpec(restored, dst *infrav1.CNISpec) {
+	if restored == nil || dst == nil {
+		return
+	}
+	for i := range dst.CNIIngressRules {
+		if i >= len(restored.CNIIngressRules) {
+			return
+		}
+		dst.CNIIngressRules[i].SourcePrefixListIDs = restored.CNIIngressRules[i].SourcePrefixListIDs
+	}
+}
+
+// RestoreSubnets manually restores the subnets data that doesn't exist in v1beta1.
+// Subnets are matched by position, as they are kept in order during conversion, and
+// only restored if their ID is unchanged.
+func RestoreSubnets(restored, dst infrav1.Subnets) {
+	for i := range dst {
+		if i >= len(restored) {
+			return
+		}
+		if restored[i].ID == dst[i].ID {
+			dst[i].OutpostARN = restored[i].OutpostARN
+			dst[i].NatInstanceID = restored[i].NatInstanceID
+		}
+	}
+}
+
+// RestoreSecurityGroups manually restores the ingress rules data of the security groups status.
+func RestoreSecurityGroups(restored, dst map[infrav1.SecurityGroupRole]infrav1.SecurityGroup) {
+	for role, sg := range dst {
+		restoredSG, ok := restored[role]
+		if !ok {
+			continue
+		}
+		for i := range sg.IngressRules {
+			if i >= len(restoredSG.IngressRules) {
+				break
+			}
+			sg.IngressRules[i].SourcePrefixListIDs = restoredSG.IngressRules[i].SourcePrefixListIDs
+		}
+	}
+}
+
+// RestoreNetworkStatus manually restores the network status data that doesn't exist in v1beta1.
+// Assumes restored and dst are non-nil.
+func RestoreNetworkStatus(restored, dst *infrav1.NetworkStatus) {
+	dst.InternetGatewayID = restored.InternetGatewayID
+	dst.InternetGatewayAttachmentState = restored.InternetGatewayAttachmentState
+	dst.EgressOnlyInternetGatewayID = restored.EgressOnlyInternetGatewayID
+	dst.RouteTables = restored.RouteTables
+	dst.NatGateways = restored.NatGateways
+	dst.VPCEndpoints = restored.VPCEndpoints
+	dst.ComputedSubnets = restored.ComputedSubnets
+}
+
 // restoreControlPlaneLoadBalancerStatus manually restores the control plane loadbalancer status data.
 // Assumes restored and dst are non-nil.
 func restoreControlPlaneLoadBalancerStatus(restored, dst *infrav1.LoadBalancer) {
@@ -58,6 +160,9 @@ func restoreControlPlaneLoadBalancerStatus(restored, dst *infrav1.LoadBalancer)
 	dst.LoadBalancerType = restored.LoadBalancerType
 	dst.ELBAttributes = restored.ELBAttributes
 	dst.ELBListeners = restored.ELBListeners
+	dst.WebACLARN = restored.WebACLARN
+	dst.ShieldProtectionID = restored.ShieldProtectionID
+	dst.ClassicElbAttributes.ConnectionDrainingTimeout = restored.ClassicElbAttributes.ConnectionDrainingTimeout
 }
 
 // restoreControlPlaneLoadBalancer manually restores the control plane loadbalancer data.
@@ -65,9 +170,16 @@ func restoreControlPlaneLoadBalancerStatus(restored, dst *infrav1.LoadBalancer)
 func restoreControlPlaneLoadBalancer(restored, dst *infrav1.AWSLoadBalancerSpec) {
 	dst.Name = restored.Name
 	dst.HealthCheckProtocol = restored.HealthCheckProtocol
+	dst.HealthCheck = restored.HealthCheck
 	dst.LoadBalancerType = restored.LoadBalancerType
 	dst.DisableHostsRewrite = restored.DisableHostsRewrite
 	dst.PreserveClientIP = restored.PreserveClientIP
+	dst.WebACLARN = restored.WebACLARN
+	dst.ShieldAdvanced = restored.ShieldAdvanced
+	dst.AdditionalListeners = restored.AdditionalListeners
+	dst.ConnectionDrainingTimeout = restored.ConnectionDrainingTimeout
+	dst.IdleTimeout = restored.IdleTimeout
+	dst.Route53 = restored.Route53
 }
 
 // ConvertFrom converts the v1beta1 AWSCluster receiver to a v1beta1 AWSCluster.
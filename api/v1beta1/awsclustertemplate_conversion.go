@@ -37,6 +37,47 @@ func (r *AWSClusterTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.Template.ObjectMeta = restored.Spec.Template.ObjectMeta
+	dst.Spec.Template.Spec.Bastion.AllowedPrefixListIDs = restored.Spec.Template.Spec.Bastion.AllowedPrefixListIDs
+	dst.Spec.Template.Spec.Bastion.IAMInstanceProfile = restored.Spec.Template.Spec.Bastion.IAMInstanceProfile
+	dst.Spec.Template.Spec.Bastion.ElasticIPPool = restored.Spec.Template.Spec.Bastion.ElasticIPPool
+	dst.Spec.Template.Spec.NetworkSpec.VPC.NATGatewayElasticIPPool = restored.Spec.Template.Spec.NetworkSpec.VPC.NATGatewayElasticIPPool
+	dst.Spec.Template.Spec.Bastion.Placement = restored.Spec.Template.Spec.Bastion.Placement
+	dst.Spec.Template.Spec.Bastion.AMILookup = restored.Spec.Template.Spec.Bastion.AMILookup
+	dst.Spec.Template.Spec.Bastion.RootVolume = restored.Spec.Template.Spec.Bastion.RootVolume
+	dst.Spec.Template.Spec.Bastion.AdditionalSecurityGroups = restored.Spec.Template.Spec.Bastion.AdditionalSecurityGroups
+	dst.Spec.Template.Spec.Bastion.UserData = restored.Spec.Template.Spec.Bastion.UserData
+	dst.Spec.Template.Spec.Bastion.Type = restored.Spec.Template.Spec.Bastion.Type
+	dst.Spec.Template.Spec.NetworkSpec.VPC.NATGatewayPlacement = restored.Spec.Template.Spec.NetworkSpec.VPC.NATGatewayPlacement
+	dst.Spec.Template.Spec.NetworkSpec.VPC.NATStrategy = restored.Spec.Template.Spec.NetworkSpec.VPC.NATStrategy
+	dst.Spec.Template.Spec.NetworkSpec.VPC.NATInstance = restored.Spec.Template.Spec.NetworkSpec.VPC.NATInstance
+	dst.Spec.Template.Spec.NetworkSpec.VPC.DHCPOptions = restored.Spec.Template.Spec.NetworkSpec.VPC.DHCPOptions
+	dst.Spec.Template.Spec.NetworkSpec.VPC.FlowLogs = restored.Spec.Template.Spec.NetworkSpec.VPC.FlowLogs
+	dst.Spec.Template.Spec.NetworkSpec.VPC.SubnetSchema = restored.Spec.Template.Spec.NetworkSpec.VPC.SubnetSchema
+	dst.Spec.Template.Spec.NetworkSpec.VPC.AvailabilityZones = restored.Spec.Template.Spec.NetworkSpec.VPC.AvailabilityZones
+	dst.Spec.Template.Spec.NetworkSpec.AdditionalControlPlaneIngressRules = restored.Spec.Template.Spec.NetworkSpec.AdditionalControlPlaneIngressRules
+	dst.Spec.Template.Spec.NetworkSpec.AdditionalNodeIngressRules = restored.Spec.Template.Spec.NetworkSpec.AdditionalNodeIngressRules
+	dst.Spec.Template.Spec.NetworkSpec.NodeEgressRules = restored.Spec.Template.Spec.NetworkSpec.NodeEgressRules
+	dst.Spec.Template.Spec.NetworkSpec.PrivateIPv6Egress = restored.Spec.Template.Spec.NetworkSpec.PrivateIPv6Egress
+	dst.Spec.Template.Spec.SecurityProfile = restored.Spec.Template.Spec.SecurityProfile
+	dst.Spec.Template.Spec.InstanceNameTemplate = restored.Spec.Template.Spec.InstanceNameTemplate
+	dst.Spec.Template.Spec.ResourceNaming = restored.Spec.Template.Spec.ResourceNaming
+	dst.Spec.Template.Spec.ResourceRetentionPolicy = restored.Spec.Template.Spec.ResourceRetentionPolicy
+	dst.Spec.Template.Spec.ServiceEndpoints = restored.Spec.Template.Spec.ServiceEndpoints
+	dst.Spec.Template.Spec.AdditionalTagsFrom = restored.Spec.Template.Spec.AdditionalTagsFrom
+	dst.Spec.Template.Spec.Proxy = restored.Spec.Template.Spec.Proxy
+	dst.Spec.Template.Spec.Monitoring = restored.Spec.Template.Spec.Monitoring
+	dst.Spec.Template.Spec.DefaultEBSKMSKeyID = restored.Spec.Template.Spec.DefaultEBSKMSKeyID
+	dst.Spec.Template.Spec.Konnectivity = restored.Spec.Template.Spec.Konnectivity
+	dst.Spec.Template.Spec.DeletionProtection = restored.Spec.Template.Spec.DeletionProtection
+	dst.Spec.Template.Spec.Partition = restored.Spec.Template.Spec.Partition
+	if restored.Spec.Template.Spec.ControlPlaneLoadBalancer != nil {
+		if dst.Spec.Template.Spec.ControlPlaneLoadBalancer == nil {
+			dst.Spec.Template.Spec.ControlPlaneLoadBalancer = &infrav1.AWSLoadBalancerSpec{}
+		}
+		restoreControlPlaneLoadBalancer(restored.Spec.Template.Spec.ControlPlaneLoadBalancer, dst.Spec.Template.Spec.ControlPlaneLoadBalancer)
+	}
+	RestoreCNISpec(restored.Spec.Template.Spec.NetworkSpec.CNI, dst.Spec.Template.Spec.NetworkSpec.CNI)
+	RestoreSubnets(restored.Spec.Template.Spec.NetworkSpec.Subnets, dst.Spec.Template.Spec.NetworkSpec.Subnets)
 
 	return nil
 }
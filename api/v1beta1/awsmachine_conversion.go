@@ -36,7 +36,21 @@ func (src *AWSMachine) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.Ignition = restored.Spec.Ignition
+	dst.Spec.Bottlerocket = restored.Spec.Bottlerocket
+	dst.Spec.PreBootstrapCommands = restored.Spec.PreBootstrapCommands
+	dst.Spec.PostBootstrapCommands = restored.Spec.PostBootstrapCommands
 	dst.Spec.InstanceMetadataOptions = restored.Spec.InstanceMetadataOptions
+	dst.Spec.InstanceNameTemplate = restored.Spec.InstanceNameTemplate
+	dst.Spec.OutpostARN = restored.Spec.OutpostARN
+	dst.Spec.AdditionalNetworkInterfaces = restored.Spec.AdditionalNetworkInterfaces
+	dst.Spec.SnapshotOnDelete = restored.Spec.SnapshotOnDelete
+	dst.Spec.SnapshotDeviceNames = restored.Spec.SnapshotDeviceNames
+	dst.Spec.CleanupPolicy = restored.Spec.CleanupPolicy
+	dst.Spec.CPUOptions = restored.Spec.CPUOptions
+	dst.Spec.EnclaveOptions = restored.Spec.EnclaveOptions
+	dst.Spec.HibernationOptions = restored.Spec.HibernationOptions
+	dst.Spec.PrivateIPAddress = restored.Spec.PrivateIPAddress
+	dst.Spec.CreditSpecification = restored.Spec.CreditSpecification
 
 	return nil
 }
@@ -82,7 +96,21 @@ func (r *AWSMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.Template.ObjectMeta = restored.Spec.Template.ObjectMeta
 	dst.Spec.Template.Spec.Ignition = restored.Spec.Template.Spec.Ignition
+	dst.Spec.Template.Spec.Bottlerocket = restored.Spec.Template.Spec.Bottlerocket
+	dst.Spec.Template.Spec.PreBootstrapCommands = restored.Spec.Template.Spec.PreBootstrapCommands
+	dst.Spec.Template.Spec.PostBootstrapCommands = restored.Spec.Template.Spec.PostBootstrapCommands
 	dst.Spec.Template.Spec.InstanceMetadataOptions = restored.Spec.Template.Spec.InstanceMetadataOptions
+	dst.Spec.Template.Spec.InstanceNameTemplate = restored.Spec.Template.Spec.InstanceNameTemplate
+	dst.Spec.Template.Spec.OutpostARN = restored.Spec.Template.Spec.OutpostARN
+	dst.Spec.Template.Spec.AdditionalNetworkInterfaces = restored.Spec.Template.Spec.AdditionalNetworkInterfaces
+	dst.Spec.Template.Spec.SnapshotOnDelete = restored.Spec.Template.Spec.SnapshotOnDelete
+	dst.Spec.Template.Spec.SnapshotDeviceNames = restored.Spec.Template.Spec.SnapshotDeviceNames
+	dst.Spec.Template.Spec.CleanupPolicy = restored.Spec.Template.Spec.CleanupPolicy
+	dst.Spec.Template.Spec.CPUOptions = restored.Spec.Template.Spec.CPUOptions
+	dst.Spec.Template.Spec.EnclaveOptions = restored.Spec.Template.Spec.EnclaveOptions
+	dst.Spec.Template.Spec.HibernationOptions = restored.Spec.Template.Spec.HibernationOptions
+	dst.Spec.Template.Spec.PrivateIPAddress = restored.Spec.Template.Spec.PrivateIPAddress
+	dst.Spec.Template.Spec.CreditSpecification = restored.Spec.Template.Spec.CreditSpecification
 
 	return nil
 }
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// AcceleratorType is the kind of accelerator the instances of an instance type have, which the AMI of the
+// instances needs the drivers of.
+type AcceleratorType string
+
+const (
+	// AcceleratorTypeNone is the accelerator type of instance types without accelerators.
+	AcceleratorTypeNone AcceleratorType = ""
+	// AcceleratorTypeNVIDIAGPU is the accelerator type of instance types with NVIDIA GPUs.
+	AcceleratorTypeNVIDIAGPU AcceleratorType = "NVIDIAGPU"
+	// AcceleratorTypeAMDGPU is the accelerator type of instance types with AMD GPUs.
+	AcceleratorTypeAMDGPU AcceleratorType = "AMDGPU"
+	// AcceleratorTypeNeuron is the accelerator type of instance types with AWS Inferentia or Trainium accelerators.
+	AcceleratorTypeNeuron AcceleratorType = "Neuron"
+)
+
+// acceleratedInstanceFamilies are the accelerator types of the instance families with accelerators.
+var acceleratedInstanceFamilies = map[string]AcceleratorType{
+	"p2":    AcceleratorTypeNVIDIAGPU,
+	"p3":    AcceleratorTypeNVIDIAGPU,
+	"p3dn":  AcceleratorTypeNVIDIAGPU,
+	"p4d":   AcceleratorTypeNVIDIAGPU,
+	"p4de":  AcceleratorTypeNVIDIAGPU,
+	"p5":    AcceleratorTypeNVIDIAGPU,
+	"g3":    AcceleratorTypeNVIDIAGPU,
+	"g3s":   AcceleratorTypeNVIDIAGPU,
+	"g4dn":  AcceleratorTypeNVIDIAGPU,
+	"g5":    AcceleratorTypeNVIDIAGPU,
+	"g5g":   AcceleratorTypeNVIDIAGPU,
+	"g4ad":  AcceleratorTypeAMDGPU,
+	"inf1":  AcceleratorTypeNeuron,
+	"inf2":  AcceleratorTypeNeuron,
+	"trn1":  AcceleratorTypeNeuron,
+	"trn1n": AcceleratorTypeNeuron,
+}
+
+// InstanceTypeAccelerator returns the accelerator type of the instances of an instance type, from its family.
+func InstanceTypeAccelerator(instanceType string) AcceleratorType {
+	family, _, _ := strings.Cut(instanceType, ".")
+	return acceleratedInstanceFamilies[family]
+}
+
+// IsArm64InstanceType returns whether the instances of an instance type have AWS Graviton processors, from its family:
+// the a1 family, and the families with a g right after their generation, such as m6g, c7gn or g5g.
+func IsArm64InstanceType(instanceType string) bool {
+	family, _, _ := strings.Cut(instanceType, ".")
+	if family == "a1" {
+		return true
+	}
+	generation := strings.IndexAny(family, "0123456789")
+	if generation < 0 {
+		return false
+	}
+	attributes := strings.TrimLeft(family[generation:], "0123456789")
+	return strings.HasPrefix(attributes, "g")
+}
+
+// ValidateAccelerator rejects an EKS optimized AMI lookup that can't find an AMI able to run the instances of an
+// instance type. Instance types left empty aren't checked.
+func (r *AMIReference) ValidateAccelerator(instanceType string, fldPath *field.Path) field.ErrorList {
+	if r == nil || r.EKSOptimizedLookupType == nil || *r.EKSOptimizedLookupType != AmazonLinuxGPU || instanceType == "" {
+		return nil
+	}
+
+	lookupTypePath := fldPath.Child("eksLookupType")
+	if IsArm64InstanceType(instanceType) {
+		return field.ErrorList{field.Forbidden(lookupTypePath, fmt.Sprintf("the EKS optimized %s AMI is only published for x86_64 instance types, not for arm64 instance type %q", AmazonLinuxGPU, instanceType))}
+	}
+	if InstanceTypeAccelerator(instanceType) == AcceleratorTypeAMDGPU {
+		return field.ErrorList{field.Forbidden(lookupTypePath, fmt.Sprintf("the EKS optimized %s AMI only has drivers for NVIDIA GPUs and AWS Inferentia and Trainium accelerators, not for the AMD GPUs of instance type %q", AmazonLinuxGPU, instanceType))}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestIsArm64InstanceType(t *testing.T) {
+	tests := []struct {
+		instanceType string
+		want         bool
+	}{
+		{instanceType: "m5.large"},
+		{instanceType: "g4dn.xlarge"},
+		{instanceType: "a1.medium", want: true},
+		{instanceType: "m6g.large", want: true},
+		{instanceType: "c7gn.xlarge", want: true},
+		{instanceType: "g5g.xlarge", want: true},
+		{instanceType: "t4g.medium", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instanceType, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(IsArm64InstanceType(tt.instanceType)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestAMIReferenceValidateAccelerator(t *testing.T) {
+	gpuAMI := AmazonLinuxGPU
+	defaultAMI := AmazonLinux
+	tests := []struct {
+		name         string
+		ami          *AMIReference
+		instanceType string
+		wantErr      bool
+	}{
+		{
+			name:         "no AMI reference",
+			instanceType: "g4ad.xlarge",
+		},
+		{
+			name:         "AmazonLinux lookup for an AMD GPU instance type",
+			ami:          &AMIReference{EKSOptimizedLookupType: &defaultAMI},
+			instanceType: "g4ad.xlarge",
+		},
+		{
+			name:         "AmazonLinuxGPU lookup for an NVIDIA GPU instance type",
+			ami:          &AMIReference{EKSOptimizedLookupType: &gpuAMI},
+			instanceType: "g4dn.xlarge",
+		},
+		{
+			name:         "AmazonLinuxGPU lookup for an Inferentia instance type",
+			ami:          &AMIReference{EKSOptimizedLookupType: &gpuAMI},
+			instanceType: "inf2.xlarge",
+		},
+		{
+			name: "AmazonLinuxGPU lookup without instance type",
+			ami:  &AMIReference{EKSOptimizedLookupType: &gpuAMI},
+		},
+		{
+			name:         "AmazonLinuxGPU lookup for an arm64 instance type",
+			ami:          &AMIReference{EKSOptimizedLookupType: &gpuAMI},
+			instanceType: "g5g.xlarge",
+			wantErr:      true,
+		},
+		{
+			name:         "AmazonLinuxGPU lookup for an AMD GPU instance type",
+			ami:          &AMIReference{EKSOptimizedLookupType: &gpuAMI},
+			instanceType: "g4ad.xlarge",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.ami.ValidateAccelerator(tt.instanceType, field.NewPath("spec", "ami"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -39,6 +39,12 @@ type AWSClusterSpec struct {
 	// The AWS Region the cluster lives in.
 	Region string `json:"region,omitempty"`
 
+	// Partition is the AWS partition the cluster lives in, e.g. aws, aws-cn or aws-us-gov. It is used to
+	// build the ARNs of the resources of the cluster, and is derived from the region when unset.
+	// +kubebuilder:validation:Enum=aws;aws-cn;aws-us-gov;aws-iso;aws-iso-b
+	// +optional
+	Partition string `json:"partition,omitempty"`
+
 	// SSHKeyName is the name of the ssh key to attach to the bastion host. Valid values are empty string (do not use SSH keys), a valid SSH key name, or omitted (use the default SSH key name)
 	// +optional
 	SSHKeyName *string `json:"sshKeyName,omitempty"`
@@ -52,6 +58,12 @@ type AWSClusterSpec struct {
 	// +optional
 	AdditionalTags Tags `json:"additionalTags,omitempty"`
 
+	// AdditionalTagsFrom references a source of additional tags, e.g. to share tag sets maintained in one
+	// place between clusters. The tags are read on each reconcile, and AdditionalTags take precedence over
+	// them for the same keys.
+	// +optional
+	AdditionalTagsFrom *AdditionalTagsSource `json:"additionalTagsFrom,omitempty"`
+
 	// ControlPlaneLoadBalancer is optional configuration for customizing control plane behavior.
 	// +optional
 	ControlPlaneLoadBalancer *AWSLoadBalancerSpec `json:"controlPlaneLoadBalancer,omitempty"`
@@ -97,6 +109,138 @@ type AWSClusterSpec struct {
 	// BootstrapFormatIgnition feature flag to be enabled).
 	// +optional
 	S3Bucket *S3Bucket `json:"s3Bucket,omitempty"`
+
+	// SecurityProfile enforces a preset of security settings on the instances of the cluster.
+	// The baseline profile requires IMDSv2 and encrypted EBS volumes on all instances, including the
+	// bastion host, forbids public IP addresses on machines (publicIP: true), and forbids SSH access to
+	// the bastion host from any address (0.0.0.0/0 or ::/0).
+	// Both profiles also set a TLS 1.2+ security policy on the TLS listeners of network load balancers.
+	// The strict profile additionally keeps machines out of the subnets that assign public IP addresses
+	// on launch, which machines selecting their subnet by ID or filters would otherwise be placed in.
+	// Spec values that violate the profile are rejected.
+	// +kubebuilder:validation:Enum=baseline;strict
+	// +optional
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// InstanceNameTemplate is a Go text/template used to generate the value of the
+	// Name tag of the EC2 instances of the cluster, e.g. "{{.ClusterName}}-{{.MachineDeployment}}-{{.Index}}".
+	// See InstanceNameTemplateData for the available fields. It can be overridden per machine
+	// with AWSMachineSpec.InstanceNameTemplate. Defaults to the name of the AWSMachine.
+	// +optional
+	InstanceNameTemplate string `json:"instanceNameTemplate,omitempty"`
+
+	// ResourceNaming configures how the names of the security groups and of the control plane
+	// load balancer of the cluster are generated. It cannot be changed once the cluster is created.
+	// +optional
+	ResourceNaming *ResourceNaming `json:"resourceNaming,omitempty"`
+
+	// ResourceRetentionPolicy defines which classes of resources are kept in the AWS account
+	// when the cluster, or the machines they belong to, are deleted.
+	// +optional
+	ResourceRetentionPolicy *ResourceRetentionPolicy `json:"resourceRetentionPolicy,omitempty"`
+
+	// ServiceEndpoints override the endpoints of AWS services for the cluster, e.g. to reach them through
+	// VPC endpoints. They take precedence over the endpoints set with the --service-endpoints flag of the
+	// controller for the same services.
+	// +optional
+	ServiceEndpoints AWSServiceEndpoints `json:"serviceEndpoints,omitempty"`
+
+	// Proxy configures the HTTP proxy and the additional trusted CA certificates of the nodes of the
+	// cluster. They are rendered into the user data of the instances whose bootstrap data is in the
+	// cloud-config format or which run Bottlerocket.
+	// +optional
+	Proxy *ProxySpec `json:"proxy,omitempty"`
+
+	// Monitoring enables CloudWatch alarms on the infrastructure managed by the provider: port
+	// allocation errors of the NAT gateways, unhealthy hosts behind the control plane load balancer
+	// and failed status checks of the bastion host. Alarms are deleted when it is unset.
+	// +optional
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+
+	// DefaultEBSKMSKeyID is the ID or ARN of the AWS KMS customer managed key used to encrypt the
+	// root and non root volumes of the instances of the cluster, including the instances of machine
+	// pools and the bastion host, which don't set their own encryption key. Volumes explicitly not
+	// encrypted are left unencrypted.
+	// +optional
+	DefaultEBSKMSKeyID string `json:"defaultEBSKMSKeyID,omitempty"`
+
+	// Konnectivity declares that the cluster runs the konnectivity service, e.g. for the egress selector
+	// of the API server. The ports of the konnectivity servers and agents are opened in the security
+	// groups of the control plane and of the nodes, and the agent port is forwarded by the control plane
+	// load balancer.
+	// +optional
+	Konnectivity *KonnectivitySpec `json:"konnectivity,omitempty"`
+
+	// DeletionProtection prevents the deletion of the AWS resources of the cluster, such as its VPC and load
+	// balancers. While it is set, the deletion of the AWSCluster is blocked, which is reported by the
+	// DeletionBlocked condition, and it resumes once it is unset.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+}
+
+// SecurityProfile is a preset of security settings enforced on the instances of a cluster.
+type SecurityProfile string
+
+var (
+	// SecurityProfileBaseline requires IMDSv2 and encrypted EBS volumes, forbids public IP addresses on machines,
+	// and restricts SSH access to the bastion host.
+	SecurityProfileBaseline = SecurityProfile("baseline")
+
+	// SecurityProfileStrict enforces SecurityProfileBaseline and forbids placing machines in the subnets that
+	// assign public IP addresses on launch.
+	SecurityProfileStrict = SecurityProfile("strict")
+)
+
+// RequiresIMDSv2 returns true if the profile requires instances to use IMDSv2.
+func (p SecurityProfile) RequiresIMDSv2() bool {
+	return p == SecurityProfileBaseline || p == SecurityProfileStrict
+}
+
+// RequiresEncryptedVolumes returns true if the profile requires the EBS volumes of instances to be encrypted.
+func (p SecurityProfile) RequiresEncryptedVolumes() bool {
+	return p == SecurityProfileBaseline || p == SecurityProfileStrict
+}
+
+// RestrictsSSH returns true if the profile forbids SSH access to the bastion host from any address.
+func (p SecurityProfile) RestrictsSSH() bool {
+	return p == SecurityProfileBaseline || p == SecurityProfileStrict
+}
+
+// AllowsPublicIP returns true if the profile allows machines to request a public IP address.
+func (p SecurityProfile) AllowsPublicIP() bool {
+	return p != SecurityProfileBaseline && p != SecurityProfileStrict
+}
+
+// AllowsPublicSubnets returns true if the profile allows machines in the subnets that assign public
+// IP addresses on launch.
+func (p SecurityProfile) AllowsPublicSubnets() bool {
+	return p != SecurityProfileStrict
+}
+
+// NLBTLSPolicy returns the security policy required by the profile on the TLS listeners of
+// network load balancers, or an empty string if the profile doesn't require one.
+func (p SecurityProfile) NLBTLSPolicy() string {
+	if p == SecurityProfileBaseline || p == SecurityProfileStrict {
+		return "ELBSecurityPolicy-TLS13-1-2-2021-06"
+	}
+	return ""
+}
+
+// ApplyToInstanceMetadataOptions returns the instance metadata options with the settings required
+// by the profile applied. The given options are not modified.
+func (p SecurityProfile) ApplyToInstanceMetadataOptions(options *InstanceMetadataOptions) *InstanceMetadataOptions {
+	if !p.RequiresIMDSv2() {
+		return options
+	}
+
+	res := &InstanceMetadataOptions{}
+	if options != nil {
+		res = options.DeepCopy()
+	} else {
+		res.SetDefaults()
+	}
+	res.HTTPTokens = HTTPTokensStateRequired
+	return res
 }
 
 // AWSIdentityKind defines allowed AWS identity types.
@@ -111,6 +255,9 @@ var (
 
 	// ClusterStaticIdentityKind defines identity reference kind as AWSClusterStaticIdentity.
 	ClusterStaticIdentityKind = AWSIdentityKind("AWSClusterStaticIdentity")
+
+	// ClusterWebIdentityKind defines identity reference kind as AWSClusterWebIdentity.
+	ClusterWebIdentityKind = AWSIdentityKind("AWSClusterWebIdentity")
 )
 
 // AWSIdentityReference specifies a identity.
@@ -120,10 +267,22 @@ type AWSIdentityReference struct {
 	Name string `json:"name"`
 
 	// Kind of the identity.
-	// +kubebuilder:validation:Enum=AWSClusterControllerIdentity;AWSClusterRoleIdentity;AWSClusterStaticIdentity
+	// +kubebuilder:validation:Enum=AWSClusterControllerIdentity;AWSClusterRoleIdentity;AWSClusterStaticIdentity;AWSClusterWebIdentity
 	Kind AWSIdentityKind `json:"kind"`
 }
 
+// BastionType is the kind of resource providing access to the private network of a cluster.
+type BastionType string
+
+var (
+	// BastionTypeInstance is a bastion host instance with a public IP in a public subnet.
+	BastionTypeInstance = BastionType("instance")
+
+	// BastionTypeInstanceConnectEndpoint is an EC2 Instance Connect Endpoint in a private subnet,
+	// which opens SSH connections to the instances of the VPC without any bastion host instance.
+	BastionTypeInstanceConnectEndpoint = BastionType("ec2-instance-connect-endpoint")
+)
+
 // Bastion defines a bastion host.
 type Bastion struct {
 	// Enabled allows this provider to create a bastion host instance
@@ -131,16 +290,30 @@ type Bastion struct {
 	// +optional
 	Enabled bool `json:"enabled"`
 
+	// Type is the kind of bastion to create, either a bastion host instance (the default) or an
+	// EC2 Instance Connect Endpoint. An endpoint is created in a private subnet and has a security
+	// group without ingress rules, so AllowedCIDRBlocks and AllowedPrefixListIDs are ignored and
+	// the settings of the bastion host instance cannot be set.
+	// +kubebuilder:validation:Enum=instance;ec2-instance-connect-endpoint
+	// +optional
+	Type BastionType `json:"type,omitempty"`
+
 	// DisableIngressRules will ensure there are no Ingress rules in the bastion host's security group.
-	// Requires AllowedCIDRBlocks to be empty.
+	// Requires AllowedCIDRBlocks and AllowedPrefixListIDs to be empty.
 	// +optional
 	DisableIngressRules bool `json:"disableIngressRules,omitempty"`
 
 	// AllowedCIDRBlocks is a list of CIDR blocks allowed to access the bastion host.
-	// They are set as ingress rules for the Bastion host's Security Group (defaults to 0.0.0.0/0).
+	// They are set as ingress rules for the Bastion host's Security Group (defaults to 0.0.0.0/0,
+	// unless AllowedPrefixListIDs is set).
 	// +optional
 	AllowedCIDRBlocks []string `json:"allowedCIDRBlocks,omitempty"`
 
+	// AllowedPrefixListIDs is a list of managed prefix list IDs allowed to access the bastion host.
+	// They are set as ingress rules for the Bastion host's Security Group, in addition to AllowedCIDRBlocks.
+	// +optional
+	AllowedPrefixListIDs []string `json:"allowedPrefixListIDs,omitempty"`
+
 	// InstanceType will use the specified instance type for the bastion. If not specified,
 	// Cluster API Provider AWS will use t3.micro for all regions except us-east-1, where t2.micro
 	// will be the default.
@@ -150,6 +323,144 @@ type Bastion struct {
 	// the AMI will default to one picked out in public space.
 	// +optional
 	AMI string `json:"ami,omitempty"`
+
+	// IAMInstanceProfile is the name of an IAM instance profile to attach to the bastion.
+	// The SSM agent of the bastion only registers with Session Manager when the profile
+	// grants it the permissions of the AmazonSSMManagedInstanceCore policy, which is
+	// required to open port forwards through the bastion.
+	// +optional
+	IAMInstanceProfile string `json:"iamInstanceProfile,omitempty"`
+
+	// ElasticIPPool selects a pre-allocated Elastic IP to associate with the bastion host,
+	// so that its public IP stays the same when the bastion or the cluster is recreated.
+	// The Elastic IP is not released when the bastion is deleted.
+	// +optional
+	ElasticIPPool *ElasticIPPool `json:"elasticIPPool,omitempty"`
+
+	// Placement restricts the public subnets the bastion host is created in, e.g. to keep it out of
+	// Local Zones. The first matching public subnet is used. An existing bastion host is not moved.
+	// The private subnets are restricted instead for an EC2 Instance Connect Endpoint.
+	// +optional
+	Placement *SubnetPlacement `json:"placement,omitempty"`
+
+	// AMILookup looks up the latest available AMI matching a name pattern and owners to boot the bastion,
+	// e.g. to use a hardened image. Mutually exclusive with AMI.
+	// +optional
+	AMILookup *BastionAMILookup `json:"amiLookup,omitempty"`
+
+	// RootVolume configures the root volume of the bastion, e.g. its size or encryption.
+	// Defaults to the root volume of the AMI.
+	// +optional
+	RootVolume *Volume `json:"rootVolume,omitempty"`
+
+	// AdditionalSecurityGroups are security groups attached to the bastion in addition to the
+	// bastion security group managed by the provider.
+	// +optional
+	AdditionalSecurityGroups []AWSResourceReference `json:"additionalSecurityGroups,omitempty"`
+
+	// UserData is the user data of the bastion, used instead of the user data generated by the provider,
+	// e.g. to install an SSM agent. It is base64 encoded by the provider.
+	// Changing the bastion configuration does not replace an existing bastion host.
+	// +kubebuilder:validation:MaxLength=12288
+	// +optional
+	UserData string `json:"userData,omitempty"`
+}
+
+// BastionAMILookup defines how to look up the AMI of the bastion host.
+type BastionAMILookup struct {
+	// Name is the name of the AMI, which may contain the * and ? wildcards.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Owners are the IDs or aliases of the accounts owning the AMI, e.g. amazon or self.
+	// +kubebuilder:validation:MinItems=1
+	Owners []string `json:"owners"`
+
+	// Architecture is the architecture of the AMI, which must match the instance type of the bastion.
+	// +kubebuilder:validation:Enum=x86_64;arm64
+	// +kubebuilder:default=x86_64
+	// +optional
+	Architecture string `json:"architecture,omitempty"`
+}
+
+// BastionConnection describes how to reach the private network of the cluster through the bastion host.
+type BastionConnection struct {
+	// InstanceID is the ID of the bastion instance.
+	// +optional
+	InstanceID string `json:"instanceID,omitempty"`
+
+	// InstanceConnectEndpointID is the ID of the EC2 Instance Connect Endpoint, used to open SSH
+	// connections to the instances of the cluster with `aws ec2-instance-connect ssh`.
+	// +optional
+	InstanceConnectEndpointID string `json:"instanceConnectEndpointID,omitempty"`
+
+	// AvailabilityZone is the availability zone the bastion instance or endpoint runs in.
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// PublicIP is the public IP address of the bastion instance, used for SSH access.
+	// +optional
+	PublicIP string `json:"publicIP,omitempty"`
+
+	// PrivateIP is the private IP address of the bastion instance.
+	// +optional
+	PrivateIP string `json:"privateIP,omitempty"`
+
+	// SSMAvailable is true when the SSM agent on the bastion instance is registered
+	// and online, meaning sessions and port forwards can be started through it.
+	// +optional
+	SSMAvailable bool `json:"ssmAvailable"`
+}
+
+// BillableResources estimates the billable AWS resources managed for a cluster, to help spot
+// expensive topology choices such as a NAT gateway per availability zone on a development cluster.
+type BillableResources struct {
+	// NATGateways is the number of pending and available NAT gateways of the managed VPC.
+	// +optional
+	NATGateways int32 `json:"natGateways,omitempty"`
+
+	// ElasticIPs is the number of Elastic IPs associated with the NAT gateways of the managed VPC.
+	// +optional
+	ElasticIPs int32 `json:"elasticIPs,omitempty"`
+
+	// LoadBalancers is the number of load balancers created for the API server.
+	// +optional
+	LoadBalancers int32 `json:"loadBalancers,omitempty"`
+
+	// Instances is the number of pending and running instances of the cluster by instance type,
+	// including the bastion host and the instances of machine pools.
+	// +optional
+	Instances map[string]int32 `json:"instances,omitempty"`
+
+	// EBSVolumes is the size in GiB of the EBS volumes of the running instances by volume type,
+	// for the volumes whose size is set. Volumes without a type are counted as gp2.
+	// +optional
+	EBSVolumes map[string]int32 `json:"ebsVolumes,omitempty"`
+
+	// EstimatedCost is the approximate on-demand cost of the billable resources, computed from the
+	// AWS Price List when the CostEstimation feature gate is enabled.
+	// +optional
+	EstimatedCost *CostEstimate `json:"estimatedCost,omitempty"`
+}
+
+// CostEstimate is the approximate on-demand cost of the billable resources of a cluster. Prices are
+// expressed in USD as decimal strings, e.g. "0.0416".
+type CostEstimate struct {
+	// HourlyUSD is the approximate hourly cost of the priced resources.
+	HourlyUSD string `json:"hourlyUSD"`
+
+	// HourlyUSDByResource breaks the hourly cost down by kind of resource: instances, natGateways,
+	// loadBalancers and ebsVolumes.
+	// +optional
+	HourlyUSDByResource map[string]string `json:"hourlyUSDByResource,omitempty"`
+
+	// Unpriced lists the resources whose price couldn't be found, e.g. instance types, which are
+	// not part of the estimate.
+	// +optional
+	Unpriced []string `json:"unpriced,omitempty"`
+
+	// LastUpdated is the time the estimate was computed.
+	LastUpdated metav1.Time `json:"lastUpdated"`
 }
 
 type LoadBalancerType string
@@ -189,7 +500,10 @@ type AWSLoadBalancerSpec struct {
 	// +optional
 	CrossZoneLoadBalancing bool `json:"crossZoneLoadBalancing"`
 
-	// Subnets sets the subnets that should be applied to the control plane load balancer (defaults to discovered subnets for managed VPCs or an empty set for unmanaged VPCs)
+	// Subnets sets the subnets that should be applied to the control plane load balancer (defaults to discovered subnets for managed VPCs or an empty set for unmanaged VPCs).
+	// Each entry is either a subnet ID or the full ARN of a subnet shared with the cluster's account
+	// through AWS Resource Access Manager, e.g. arn:aws:ec2:us-east-1:123456789012:subnet/subnet-0123456789abcdef0.
+	// ARNs allow the load balancer to be placed in centrally managed edge or DMZ subnets owned by another account.
 	// +optional
 	Subnets []string `json:"subnets,omitempty"`
 
@@ -199,6 +513,14 @@ type AWSLoadBalancerSpec struct {
 	// +optional
 	HealthCheckProtocol *ELBProtocol `json:"healthCheckProtocol,omitempty"`
 
+	// HealthCheck configures the health check of the API server, which defaults to a check of port 6443
+	// over the HealthCheckProtocol for classic load balancers and over TCP for network load balancers.
+	// HTTP and HTTPS checks default to the /readyz path, so that control plane instances are only
+	// considered healthy once the API server is serving. Only supported by load balancers of type
+	// classic and nlb.
+	// +optional
+	HealthCheck *TargetGroupHealthCheckSpec `json:"healthCheck,omitempty"`
+
 	// AdditionalSecurityGroups sets the security groups used by the load balancer. Expected to be security group IDs
 	// This is optional - if not provided new security groups will be created for the load balancer
 	// +optional
@@ -214,8 +536,136 @@ type AWSLoadBalancerSpec struct {
 	DisableHostsRewrite bool `json:"disableHostsRewrite,omitempty"`
 
 	// PreserveClientIP lets the user control if preservation of client ips must be retained or not.
-	// If this is enabled 6443 will be opened to 0.0.0.0/0.
+	// If this is enabled 6443 will be opened to 0.0.0.0/0. Changes are applied to the target groups of
+	// existing load balancers of type nlb.
 	PreserveClientIP bool `json:"preserveClientIP,omitempty"`
+
+	// ConnectionDrainingTimeout is how long the load balancer keeps the connections to a deregistered control
+	// plane instance open. When a control plane machine is deleted, its instance is deregistered from the load
+	// balancer and only terminated once the timeout has elapsed, so that in-flight API requests can complete.
+	// It applies to the target groups created after it is set. Instances are terminated right after being
+	// deregistered when unset. Must be at most 1 hour.
+	// +optional
+	ConnectionDrainingTimeout *metav1.Duration `json:"connectionDrainingTimeout,omitempty"`
+
+	// IdleTimeout is how long a connection through the load balancer can be idle before it is closed, which
+	// also closes long-running API requests like watches or kubectl exec sessions without traffic. Defaults
+	// to 10 minutes for load balancers of type classic and to 60 seconds for load balancers of type alb.
+	// Not supported by load balancers of type nlb. Must be between 1s and 4000s.
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+
+	// WebACLARN is the ARN of a regional WAFv2 web ACL to associate with the load balancer.
+	// Only supported by load balancers of type alb.
+	// +optional
+	WebACLARN *string `json:"webACLARN,omitempty"`
+
+	// ShieldAdvanced enables AWS Shield Advanced protection of the load balancer. The account
+	// must be subscribed to Shield Advanced. Only supported by load balancers of type alb.
+	// +optional
+	ShieldAdvanced bool `json:"shieldAdvanced,omitempty"`
+
+	// AdditionalListeners sets the additional listeners of the load balancer, forwarding ports other
+	// than the API server port to the control plane instances, e.g. 8132 for konnectivity or 22623 for
+	// the ignition configuration. Only supported by load balancers of type classic and nlb.
+	// +listType=map
+	// +listMapKey=port
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	AdditionalListeners []AdditionalListenerSpec `json:"additionalListeners,omitempty"`
+
+	// Route53 configures a Route53 record as the host of the control plane endpoint, resolving to the
+	// load balancer. The endpoint remains stable when the load balancer is replaced, which allows the
+	// scheme of load balancers of type nlb and alb to be changed. Once set, the value cannot be changed.
+	// +optional
+	Route53 *Route53EndpointSpec `json:"route53,omitempty"`
+}
+
+// Route53EndpointSpec defines the Route53 record of the control plane endpoint.
+type Route53EndpointSpec struct {
+	// HostedZoneID is the ID of the Route53 hosted zone the record is created in.
+	// +kubebuilder:validation:MinLength=1
+	HostedZoneID string `json:"hostedZoneID"`
+
+	// RecordName is the fully qualified domain name of the record, e.g. api.cluster.example.com.
+	// The record is a CNAME to the load balancer, so it can't be the apex of the hosted zone.
+	// +kubebuilder:validation:MinLength=1
+	RecordName string `json:"recordName"`
+}
+
+// IsReplaceable returns true if the load balancer can be replaced by another one without changing the
+// control plane endpoint, e.g. to change its scheme.
+func (lb *AWSLoadBalancerSpec) IsReplaceable() bool {
+	if lb == nil || lb.Route53 == nil || lb.Name != nil {
+		return false
+	}
+	return lb.LoadBalancerType == LoadBalancerTypeNLB || lb.LoadBalancerType == LoadBalancerTypeALB
+}
+
+// AdditionalListenerSpec defines an additional listener of the control plane load balancer.
+type AdditionalListenerSpec struct {
+	// Port is the port of the listener, which is forwarded to the same port of the control plane instances.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int64 `json:"port"`
+
+	// Protocol is the protocol of the listener. Only TCP is supported.
+	// +kubebuilder:validation:Enum=TCP
+	// +kubebuilder:default=TCP
+	// +optional
+	Protocol ELBProtocol `json:"protocol,omitempty"`
+
+	// HealthCheck configures the health check of the target group of the listener, which defaults to a
+	// TCP check of the port of the listener. Only supported by load balancers of type nlb, classic load
+	// balancers only check the API server.
+	// +optional
+	HealthCheck *TargetGroupHealthCheckSpec `json:"healthCheck,omitempty"`
+}
+
+// TargetGroupHealthCheckSpec configures the health check of a target group.
+type TargetGroupHealthCheckSpec struct {
+	// Protocol is the protocol of the health check.
+	// +kubebuilder:validation:Enum=TCP;HTTP;HTTPS
+	// +optional
+	Protocol *string `json:"protocol,omitempty"`
+
+	// Path is the destination of HTTP and HTTPS health checks.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// Port is the port of the health check, which defaults to the port of the listener.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int64 `json:"port,omitempty"`
+
+	// IntervalSeconds is the interval between health checks.
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	IntervalSeconds *int64 `json:"intervalSeconds,omitempty"`
+
+	// TimeoutSeconds is the time after which a health check fails.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=120
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
+	// ThresholdCount is the number of consecutive successful health checks needed to consider a target
+	// healthy, and of consecutive failed health checks needed to consider it unhealthy unless
+	// UnhealthyThresholdCount is set.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	ThresholdCount *int64 `json:"thresholdCount,omitempty"`
+
+	// UnhealthyThresholdCount is the number of consecutive failed health checks needed to consider a
+	// target unhealthy.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	UnhealthyThresholdCount *int64 `json:"unhealthyThresholdCount,omitempty"`
 }
 
 // AWSClusterStatus defines the observed state of AWSCluster.
@@ -225,7 +675,13 @@ type AWSClusterStatus struct {
 	Network        NetworkStatus            `json:"networkStatus,omitempty"`
 	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
 	Bastion        *Instance                `json:"bastion,omitempty"`
-	Conditions     clusterv1.Conditions     `json:"conditions,omitempty"`
+	// BastionConnection holds the details needed to reach the cluster through the bastion host.
+	// +optional
+	BastionConnection *BastionConnection `json:"bastionConnection,omitempty"`
+	// BillableResources estimates the billable AWS resources managed for the cluster.
+	// +optional
+	BillableResources *BillableResources   `json:"billableResources,omitempty"`
+	Conditions        clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 type S3Bucket struct {
@@ -17,13 +17,20 @@ limitations under the License.
 package v1beta2
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/google/go-cmp/cmp"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -33,20 +40,82 @@ import (
 // log is for logging in this package.
 var _ = ctrl.Log.WithName("awscluster-resource")
 
-func (r *AWSCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (r *AWSClusterWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
+		For(&AWSCluster{}).
+		WithValidator(r).
 		Complete()
 }
 
+// AWSClusterWebhook implements the validation webhook for AWSCluster. It runs the validation of the
+// AWSCluster itself, then the checks reading other objects, such as the principal allow-list.
+// +kubebuilder:object:generate=false
+type AWSClusterWebhook struct {
+	// Client reads the identities referenced by the clusters. It defaults to the client of the manager.
+	Client client.Reader
+
+	// EnforcePrincipalAllowList makes the identities deny-by-default: the identity of every AWSCluster
+	// must explicitly list or select the namespace of the cluster, and an empty allowedNamespaces no
+	// longer allows every namespace. It is set from the --enforce-principal-allow-list flag.
+	EnforcePrincipalAllowList bool
+}
+
 // +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awscluster,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,versions=v1beta2,name=validation.awscluster.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta2-awscluster,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,versions=v1beta2,name=default.awscluster.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
 var (
-	_ webhook.Validator = &AWSCluster{}
-	_ webhook.Defaulter = &AWSCluster{}
+	_ webhook.Validator       = &AWSCluster{}
+	_ webhook.Defaulter       = &AWSCluster{}
+	_ webhook.CustomValidator = &AWSClusterWebhook{}
 )
 
+// ValidateCreate validates the AWSCluster, then checks its identity against the principal allow-list.
+func (r *AWSClusterWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	c, ok := obj.(*AWSCluster)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an AWSCluster but got a %T", obj))
+	}
+
+	if err := c.ValidateCreate(); err != nil {
+		return err
+	}
+
+	return aggregateObjErrors(c.GroupVersionKind().GroupKind(), c.Name,
+		r.validatePrincipalAllowList(ctx, c.Namespace, c.Spec.IdentityRef, field.NewPath("spec", "identityRef")))
+}
+
+// ValidateUpdate validates the AWSCluster, then checks a changed identity against the principal allow-list.
+func (r *AWSClusterWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	c, ok := newObj.(*AWSCluster)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an AWSCluster but got a %T", newObj))
+	}
+	oldC, ok := oldObj.(*AWSCluster)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an AWSCluster but got a %T", oldObj))
+	}
+
+	if err := c.ValidateUpdate(oldC); err != nil {
+		return err
+	}
+
+	// Only a change of identity is checked against the allow-list, so that existing clusters can still be
+	// updated, and deleted, when their identity stops allowing their namespace.
+	if cmp.Equal(oldC.Spec.IdentityRef, c.Spec.IdentityRef) {
+		return nil
+	}
+	return aggregateObjErrors(c.GroupVersionKind().GroupKind(), c.Name,
+		r.validatePrincipalAllowList(ctx, c.Namespace, c.Spec.IdentityRef, field.NewPath("spec", "identityRef")))
+}
+
+// ValidateDelete allows every deletion.
+func (r *AWSClusterWebhook) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (r *AWSCluster) ValidateCreate() error {
 	var allErrs field.ErrorList
@@ -56,6 +125,22 @@ func (r *AWSCluster) ValidateCreate() error {
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.Spec.S3Bucket.Validate()...)
 	allErrs = append(allErrs, r.validateNetwork()...)
+	allErrs = append(allErrs, r.validateControlPlaneLoadBalancerSubnets()...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerProtection(r.Spec.ControlPlaneLoadBalancer, r.Spec.Region)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerAdditionalListeners(r.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerHealthCheck(r.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerConnectionDraining(r.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerIdleTimeout(r.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerRoute53(r.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateSecurityProfile(&r.Spec)...)
+	allErrs = append(allErrs, validateInstanceNameTemplate(r.Spec.InstanceNameTemplate, field.NewPath("spec", "instanceNameTemplate"))...)
+	allErrs = append(allErrs, validateResourceNaming(&r.Spec, r.Namespace, r.Name, field.NewPath("spec", "resourceNaming"))...)
+	allErrs = append(allErrs, r.Spec.ServiceEndpoints.Validate(field.NewPath("spec", "serviceEndpoints"))...)
+	allErrs = append(allErrs, r.Spec.AdditionalTagsFrom.Validate(field.NewPath("spec", "additionalTagsFrom"))...)
+	allErrs = append(allErrs, r.Spec.Proxy.Validate(field.NewPath("spec", "proxy"))...)
+	allErrs = append(allErrs, r.Spec.Monitoring.Validate(field.NewPath("spec", "monitoring"))...)
+	allErrs = append(allErrs, r.Spec.Konnectivity.Validate(r.Spec.ControlPlaneLoadBalancer, field.NewPath("spec", "konnectivity"))...)
+	allErrs = append(allErrs, validatePartition(r.Spec.Region, r.Spec.Partition, field.NewPath("spec", "partition"))...)
 
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -80,6 +165,12 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) error {
 		)
 	}
 
+	if r.Spec.Partition != oldC.Spec.Partition {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "partition"), r.Spec.Partition, "field is immutable"),
+		)
+	}
+
 	newLoadBalancer := &AWSLoadBalancerSpec{}
 	existingLoadBalancer := &AWSLoadBalancerSpec{}
 
@@ -99,11 +190,12 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) error {
 			)
 		}
 	} else {
-		// If old scheme was not nil, the new scheme should be the same.
-		if !cmp.Equal(existingLoadBalancer.Scheme, newLoadBalancer.Scheme) {
+		// If old scheme was not nil, the new scheme should be the same, unless the load balancer
+		// can be replaced without changing the control plane endpoint.
+		if !cmp.Equal(existingLoadBalancer.Scheme, newLoadBalancer.Scheme) && !newLoadBalancer.IsReplaceable() {
 			allErrs = append(allErrs,
 				field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "scheme"),
-					r.Spec.ControlPlaneLoadBalancer.Scheme, "field is immutable"),
+					r.Spec.ControlPlaneLoadBalancer.Scheme, "field is immutable unless the load balancer is of type nlb or alb, has no name and has a route53 endpoint"),
 			)
 		}
 		// The name must be defined when the AWSCluster is created. If it is not defined,
@@ -117,6 +209,14 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) error {
 		}
 	}
 
+	// The record is the host of the control plane endpoint, which can't be changed.
+	if !cmp.Equal(existingLoadBalancer.Route53, newLoadBalancer.Route53) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "route53"),
+				newLoadBalancer.Route53, "field is immutable"),
+		)
+	}
+
 	// Block the update for Protocol :
 	// - if it was not set in old spec but added in new spec
 	// - if it was set in old spec but changed in new spec
@@ -147,6 +247,24 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) error {
 		}
 	}
 
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateNATStrategyUpdate(&oldC.Spec.NetworkSpec.VPC, field.NewPath("spec", "network", "vpc"))...)
+
+	// Removing the DHCP options would leave the VPC with a DHCP options set that is no longer reconciled.
+	if oldC.Spec.NetworkSpec.VPC.DHCPOptions != nil && r.Spec.NetworkSpec.VPC.DHCPOptions == nil {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "network", "vpc", "dhcpOptions"),
+				r.Spec.NetworkSpec.VPC.DHCPOptions, "field cannot be removed once set"),
+		)
+	}
+
+	// Removing the flow logs would leave a flow log in place that is no longer reconciled.
+	if oldC.Spec.NetworkSpec.VPC.FlowLogs != nil && r.Spec.NetworkSpec.VPC.FlowLogs == nil {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "network", "vpc", "flowLogs"),
+				r.Spec.NetworkSpec.VPC.FlowLogs, "field cannot be removed once set"),
+		)
+	}
+
 	// If a identityRef is already set, do not allow removal of it.
 	if oldC.Spec.IdentityRef != nil && r.Spec.IdentityRef == nil {
 		allErrs = append(allErrs,
@@ -155,6 +273,13 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) error {
 		)
 	}
 
+	// Changing the naming of the resources would orphan the existing security groups and load balancer.
+	if !cmp.Equal(oldC.Spec.ResourceNaming, r.Spec.ResourceNaming) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "resourceNaming"), r.Spec.ResourceNaming, "field is immutable"),
+		)
+	}
+
 	if annotations.IsExternallyManaged(oldC) && !annotations.IsExternallyManaged(r) {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("metadata", "annotations"),
@@ -165,6 +290,30 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) error {
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.Spec.S3Bucket.Validate()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.NATGatewayElasticIPPool.Validate(field.NewPath("spec", "network", "vpc", "natGatewayElasticIPPool"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.DHCPOptions.Validate(field.NewPath("spec", "network", "vpc", "dhcpOptions"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.FlowLogs.Validate(field.NewPath("spec", "network", "vpc", "flowLogs"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateAvailabilityZoneSelection(field.NewPath("spec", "network", "vpc"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateNATStrategy(field.NewPath("spec", "network", "vpc"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateSubnetSchema(field.NewPath("spec", "network", "vpc"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.AdditionalControlPlaneIngressRules.Validate(field.NewPath("spec", "network", "additionalControlPlaneIngressRules"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.AdditionalNodeIngressRules.Validate(field.NewPath("spec", "network", "additionalNodeIngressRules"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.NodeEgressRules.Validate(field.NewPath("spec", "network", "nodeEgressRules"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.Subnets.ValidateOutposts(field.NewPath("spec", "network", "subnets"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidatePrivateIPv6Egress(field.NewPath("spec", "network"))...)
+	allErrs = append(allErrs, r.validateControlPlaneLoadBalancerSubnets()...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerProtection(r.Spec.ControlPlaneLoadBalancer, r.Spec.Region)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerAdditionalListeners(r.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerHealthCheck(r.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerConnectionDraining(r.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerIdleTimeout(r.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateSecurityProfile(&r.Spec)...)
+	allErrs = append(allErrs, validateInstanceNameTemplate(r.Spec.InstanceNameTemplate, field.NewPath("spec", "instanceNameTemplate"))...)
+	allErrs = append(allErrs, r.Spec.ServiceEndpoints.Validate(field.NewPath("spec", "serviceEndpoints"))...)
+	allErrs = append(allErrs, r.Spec.AdditionalTagsFrom.Validate(field.NewPath("spec", "additionalTagsFrom"))...)
+	allErrs = append(allErrs, r.Spec.Proxy.Validate(field.NewPath("spec", "proxy"))...)
+	allErrs = append(allErrs, r.Spec.Monitoring.Validate(field.NewPath("spec", "monitoring"))...)
+	allErrs = append(allErrs, r.Spec.Konnectivity.Validate(r.Spec.ControlPlaneLoadBalancer, field.NewPath("spec", "konnectivity"))...)
 
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -188,5 +337,282 @@ func (r *AWSCluster) validateNetwork() field.ErrorList {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("subnets"), r.Spec.NetworkSpec.Subnets, "IPv6 cannot be used with unmanaged clusters at this time."))
 		}
 	}
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.NATGatewayElasticIPPool.Validate(field.NewPath("spec", "network", "vpc", "natGatewayElasticIPPool"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.DHCPOptions.Validate(field.NewPath("spec", "network", "vpc", "dhcpOptions"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.FlowLogs.Validate(field.NewPath("spec", "network", "vpc", "flowLogs"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateAvailabilityZoneSelection(field.NewPath("spec", "network", "vpc"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateNATStrategy(field.NewPath("spec", "network", "vpc"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateSubnetSchema(field.NewPath("spec", "network", "vpc"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.AdditionalControlPlaneIngressRules.Validate(field.NewPath("spec", "network", "additionalControlPlaneIngressRules"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.AdditionalNodeIngressRules.Validate(field.NewPath("spec", "network", "additionalNodeIngressRules"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.NodeEgressRules.Validate(field.NewPath("spec", "network", "nodeEgressRules"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.Subnets.ValidateOutposts(field.NewPath("spec", "network", "subnets"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidatePrivateIPv6Egress(field.NewPath("spec", "network"))...)
+	return allErrs
+}
+
+func (r *AWSCluster) validateControlPlaneLoadBalancerSubnets() field.ErrorList {
+	return validateControlPlaneLoadBalancerSubnets(r.Spec.ControlPlaneLoadBalancer, r.Spec.Region)
+}
+
+// validateSecurityProfile rejects the cluster settings that violate the security profile.
+// Settings of the instances themselves are enforced by the controllers when creating them.
+func validateSecurityProfile(spec *AWSClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.SecurityProfile.RestrictsSSH() && spec.Bastion.Enabled && !spec.Bastion.DisableIngressRules && !spec.Bastion.IsInstanceConnectEndpoint() {
+		for i, cidr := range spec.Bastion.AllowedCIDRBlocks {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				// Invalid CIDR blocks are reported by the bastion validation.
+				continue
+			}
+			if ones, _ := ipNet.Mask.Size(); ones == 0 {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "bastion", "allowedCIDRBlocks").Index(i),
+					fmt.Sprintf("SSH access to the bastion host from any address is not allowed by the %q security profile, "+
+						"set spec.bastion.allowedCIDRBlocks or spec.bastion.allowedPrefixListIDs to restrict it", spec.SecurityProfile)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateControlPlaneLoadBalancerSubnets ensures that subnets referenced by ARN can be shared
+// with the cluster's account, i.e. they are EC2 subnets living in the cluster's region.
+func validateControlPlaneLoadBalancerSubnets(lb *AWSLoadBalancerSpec, region string) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "controlPlaneLoadBalancer", "subnets")
+	for i, subnet := range lb.Subnets {
+		if !arn.IsARN(subnet) {
+			continue
+		}
+		parsed, err := arn.Parse(subnet)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), subnet, fmt.Sprintf("invalid subnet ARN: %v", err)))
+			continue
+		}
+		if parsed.Service != "ec2" || !strings.HasPrefix(parsed.Resource, "subnet/subnet-") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), subnet, "ARN must reference an EC2 subnet, e.g. arn:aws:ec2:<region>:<account-id>:subnet/<subnet-id>"))
+			continue
+		}
+		if parsed.AccountID == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), subnet, "ARN must include the ID of the account owning the subnet"))
+		}
+		if region != "" && parsed.Region != region {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), subnet, fmt.Sprintf("subnet must be in the cluster region %q", region)))
+		}
+	}
+	return allErrs
+}
+
+// validateControlPlaneLoadBalancerProtection ensures that WAF and Shield Advanced protection is only
+// requested for application load balancers, and that the web ACL can be associated with them.
+func validateControlPlaneLoadBalancerProtection(lb *AWSLoadBalancerSpec, region string) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "controlPlaneLoadBalancer")
+	if lb.LoadBalancerType != LoadBalancerTypeALB {
+		if lb.WebACLARN != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("webACLARN"), "web ACLs can only be associated with load balancers of type alb"))
+		}
+		if lb.ShieldAdvanced {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("shieldAdvanced"), "Shield Advanced protection is only supported for load balancers of type alb"))
+		}
+	}
+
+	if lb.WebACLARN != nil {
+		parsed, err := arn.Parse(*lb.WebACLARN)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("webACLARN"), *lb.WebACLARN, fmt.Sprintf("invalid web ACL ARN: %v", err)))
+		case parsed.Service != "wafv2" || !strings.HasPrefix(parsed.Resource, "regional/webacl/"):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("webACLARN"), *lb.WebACLARN, "ARN must reference a regional WAFv2 web ACL, e.g. arn:aws:wafv2:<region>:<account-id>:regional/webacl/<name>/<id>"))
+		case region != "" && parsed.Region != region:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("webACLARN"), *lb.WebACLARN, fmt.Sprintf("web ACL must be in the cluster region %q", region)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateControlPlaneLoadBalancerRoute53 ensures that the record of the control plane endpoint is a
+// valid domain name.
+func validateControlPlaneLoadBalancerRoute53(lb *AWSLoadBalancerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb == nil || lb.Route53 == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "controlPlaneLoadBalancer", "route53", "recordName")
+	recordName := strings.TrimSuffix(lb.Route53.RecordName, ".")
+	for _, msg := range validation.IsDNS1123Subdomain(strings.ToLower(recordName)) {
+		allErrs = append(allErrs, field.Invalid(fldPath, lb.Route53.RecordName, msg))
+	}
+
+	return allErrs
+}
+
+// validateControlPlaneLoadBalancerAdditionalListeners ensures that the additional listeners of the control
+// plane load balancer don't conflict with the API server listener, nor with each other.
+func validateControlPlaneLoadBalancerAdditionalListeners(lb *AWSLoadBalancerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb == nil || len(lb.AdditionalListeners) == 0 {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "controlPlaneLoadBalancer", "additionalListeners")
+	if lb.LoadBalancerType != "" && lb.LoadBalancerType != LoadBalancerTypeClassic && lb.LoadBalancerType != LoadBalancerTypeNLB {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "additional listeners are only supported by load balancers of type classic and nlb"))
+	}
+
+	ports := map[int64]struct{}{}
+	for i, listener := range lb.AdditionalListeners {
+		listenerPath := fldPath.Index(i)
+		if listener.Port == DefaultAPIServerPort {
+			allErrs = append(allErrs, field.Invalid(listenerPath.Child("port"), listener.Port, "port is used by the API server listener"))
+		}
+		if _, ok := ports[listener.Port]; ok {
+			allErrs = append(allErrs, field.Duplicate(listenerPath.Child("port"), listener.Port))
+		}
+		ports[listener.Port] = struct{}{}
+
+		if listener.HealthCheck == nil {
+			continue
+		}
+		if lb.LoadBalancerType != LoadBalancerTypeNLB {
+			allErrs = append(allErrs, field.Forbidden(listenerPath.Child("healthCheck"), "health checks of additional listeners are only supported by load balancers of type nlb"))
+		}
+		protocol := string(ELBProtocolTCP)
+		if listener.HealthCheck.Protocol != nil {
+			protocol = *listener.HealthCheck.Protocol
+		}
+		allErrs = append(allErrs, validateHealthCheck(listener.HealthCheck, protocol, listenerPath.Child("healthCheck"))...)
+	}
+
+	return allErrs
+}
+
+// validateControlPlaneLoadBalancerHealthCheck ensures that the health check of the API server is only
+// configured for load balancers supporting it, and that its settings are consistent.
+func validateControlPlaneLoadBalancerHealthCheck(lb *AWSLoadBalancerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb == nil || lb.HealthCheck == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "controlPlaneLoadBalancer", "healthCheck")
+	if lb.LoadBalancerType != "" && lb.LoadBalancerType != LoadBalancerTypeClassic && lb.LoadBalancerType != LoadBalancerTypeNLB {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "health checks are only supported by load balancers of type classic and nlb"))
+	}
+
+	protocol := string(ELBProtocolTCP)
+	if lb.LoadBalancerType == "" || lb.LoadBalancerType == LoadBalancerTypeClassic {
+		protocol = string(ELBProtocolSSL)
+		if lb.HealthCheckProtocol != nil {
+			protocol = string(*lb.HealthCheckProtocol)
+		}
+		if lb.HealthCheck.TimeoutSeconds != nil && *lb.HealthCheck.TimeoutSeconds > 60 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), *lb.HealthCheck.TimeoutSeconds, "must be at most 60 for classic load balancers"))
+		}
+	}
+	if lb.HealthCheck.Protocol != nil {
+		if lb.HealthCheckProtocol != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("protocol"), "cannot be set together with spec.controlPlaneLoadBalancer.healthCheckProtocol"))
+		}
+		protocol = *lb.HealthCheck.Protocol
+	}
+	allErrs = append(allErrs, validateHealthCheck(lb.HealthCheck, protocol, fldPath)...)
+
+	return allErrs
+}
+
+// validateControlPlaneLoadBalancerConnectionDraining ensures that the connection draining timeout is within the
+// range supported by both classic load balancers and target groups.
+func validateControlPlaneLoadBalancerConnectionDraining(lb *AWSLoadBalancerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb == nil || lb.ConnectionDrainingTimeout == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "controlPlaneLoadBalancer", "connectionDrainingTimeout")
+	if timeout := lb.ConnectionDrainingTimeout.Duration; timeout < 0 || timeout > time.Hour {
+		allErrs = append(allErrs, field.Invalid(fldPath, lb.ConnectionDrainingTimeout.Duration.String(), "must be between 0s and 1h"))
+	}
+
+	return allErrs
+}
+
+// validateControlPlaneLoadBalancerIdleTimeout ensures that the idle timeout is only set for load balancers
+// supporting it, and within the range they support.
+func validateControlPlaneLoadBalancerIdleTimeout(lb *AWSLoadBalancerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb == nil || lb.IdleTimeout == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "controlPlaneLoadBalancer", "idleTimeout")
+	if lb.LoadBalancerType == LoadBalancerTypeNLB {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "idle timeout is not supported by load balancers of type nlb"))
+	}
+	if timeout := lb.IdleTimeout.Duration; timeout < time.Second || timeout > 4000*time.Second {
+		allErrs = append(allErrs, field.Invalid(fldPath, lb.IdleTimeout.Duration.String(), "must be between 1s and 4000s"))
+	}
+
+	return allErrs
+}
+
+// validateHealthCheck ensures that a path is only set for HTTP and HTTPS health checks, and that health
+// checks time out before the next one is due.
+func validateHealthCheck(hc *TargetGroupHealthCheckSpec, protocol string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if hc.Path != nil && protocol != string(ELBProtocolHTTP) && protocol != string(ELBProtocolHTTPS) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("path"), "path is only supported by HTTP and HTTPS health checks"))
+	}
+	if hc.TimeoutSeconds != nil && hc.IntervalSeconds != nil && *hc.TimeoutSeconds >= *hc.IntervalSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), *hc.TimeoutSeconds, "must be less than intervalSeconds"))
+	}
+	return allErrs
+}
+
+// validateResourceNaming ensures that the name of the control plane load balancer fits in the
+// 32 characters allowed by AWS when the stable naming scheme prevents it from being hashed.
+// Names derived from the cluster are only checked when name is set, as cluster templates
+// don't know the name of the clusters created from them.
+func validateResourceNaming(spec *AWSClusterSpec, namespace, name string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !spec.ResourceNaming.IsStable() {
+		return allErrs
+	}
+
+	lb := spec.ControlPlaneLoadBalancer
+	if lb != nil && lb.Name != nil {
+		return allErrs
+	}
+
+	baseName := spec.ResourceNaming.BaseName
+	if baseName == "" {
+		if name == "" {
+			return allErrs
+		}
+		baseName = name
+		if lb != nil && lb.LoadBalancerType != "" && lb.LoadBalancerType != LoadBalancerTypeClassic {
+			baseName = fmt.Sprintf("%s-%s", namespace, name)
+		}
+	}
+
+	lbName := fmt.Sprintf("%s-%s", strings.ReplaceAll(baseName, ".", "-"), APIServerRoleTagValue)
+	if len(lbName) > 32 {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.ResourceNaming,
+			fmt.Sprintf("the control plane load balancer would be named %q, which is longer than the 32 characters allowed by AWS; "+
+				"set a shorter spec.resourceNaming.baseName or spec.controlPlaneLoadBalancer.name", lbName)))
+	}
 	return allErrs
 }
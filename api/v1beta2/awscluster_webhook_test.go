@@ -251,6 +251,340 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "accepts control plane load balancer subnets referenced by ID or shared subnet ARN",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region: "us-east-1",
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Subnets: []string{
+							"subnet-1",
+							"arn:aws:ec2:us-east-1:123456789012:subnet/subnet-0123456789abcdef0",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects control plane load balancer subnet ARNs not referencing a subnet",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region: "us-east-1",
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Subnets: []string{"arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123456789abcdef0"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects control plane load balancer subnet ARNs without an account ID",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region: "us-east-1",
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Subnets: []string{"arn:aws:ec2:us-east-1::subnet/subnet-0123456789abcdef0"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects control plane load balancer subnet ARNs in a different region",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region: "us-east-1",
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Subnets: []string{"arn:aws:ec2:eu-west-1:123456789012:subnet/subnet-0123456789abcdef0"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts web ACL and Shield Advanced on an application load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region: "us-east-1",
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeALB,
+						WebACLARN:        aws.String("arn:aws:wafv2:us-east-1:123456789012:regional/webacl/capa/1234"),
+						ShieldAdvanced:   true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects web ACL on a network load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region: "us-east-1",
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						WebACLARN:        aws.String("arn:aws:wafv2:us-east-1:123456789012:regional/webacl/capa/1234"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects Shield Advanced on a classic load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ShieldAdvanced: true,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects global web ACLs",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region: "us-east-1",
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeALB,
+						WebACLARN:        aws.String("arn:aws:wafv2:us-east-1:123456789012:global/webacl/capa/1234"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects web ACLs in a different region",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region: "us-east-1",
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeALB,
+						WebACLARN:        aws.String("arn:aws:wafv2:eu-west-1:123456789012:regional/webacl/capa/1234"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts additional listeners of NLBs with health checks",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 8132},
+							{
+								Port: 22623,
+								HealthCheck: &TargetGroupHealthCheckSpec{
+									Protocol: aws.String("HTTPS"),
+									Path:     aws.String("/healthz"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects additional listeners on the API server port",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						AdditionalListeners: []AdditionalListenerSpec{{Port: 6443}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects additional listeners of ALBs",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:    LoadBalancerTypeALB,
+						AdditionalListeners: []AdditionalListenerSpec{{Port: 8132}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects health checks of additional listeners of classic load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						AdditionalListeners: []AdditionalListenerSpec{{Port: 8132, HealthCheck: &TargetGroupHealthCheckSpec{IntervalSeconds: aws.Int64(10)}}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects paths of TCP health checks of additional listeners",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:    LoadBalancerTypeNLB,
+						AdditionalListeners: []AdditionalListenerSpec{{Port: 8132, HealthCheck: &TargetGroupHealthCheckSpec{Path: aws.String("/healthz")}}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts HTTPS health checks of the API server",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						HealthCheck: &TargetGroupHealthCheckSpec{
+							Protocol:        aws.String("HTTPS"),
+							Path:            aws.String("/readyz"),
+							IntervalSeconds: aws.Int64(10),
+							TimeoutSeconds:  aws.Int64(5),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects health checks of the API server timing out after their interval",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						HealthCheck: &TargetGroupHealthCheckSpec{
+							IntervalSeconds: aws.Int64(10),
+							TimeoutSeconds:  aws.Int64(10),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects health checks of the API server setting the protocol twice",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						HealthCheckProtocol: &ELBProtocolTCP,
+						HealthCheck:         &TargetGroupHealthCheckSpec{Protocol: aws.String("HTTPS")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects paths of SSL health checks of the API server",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						HealthCheck: &TargetGroupHealthCheckSpec{Path: aws.String("/readyz")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects health checks of the API server of ALBs",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeALB,
+						HealthCheck:      &TargetGroupHealthCheckSpec{IntervalSeconds: aws.Int64(10)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts the stable naming scheme with a short base name",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ResourceNaming: &ResourceNaming{BaseName: "shared", Scheme: ResourceNamingSchemeStable},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects the stable naming scheme when the load balancer name would be too long",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ResourceNaming: &ResourceNaming{BaseName: "a-very-long-base-name-for-lbs", Scheme: ResourceNamingSchemeStable},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts the stable naming scheme with a long base name when the load balancer name is set",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{Name: aws.String("apiserver")},
+					ResourceNaming:           &ResourceNaming{BaseName: "a-very-long-base-name-for-lbs", Scheme: ResourceNamingSchemeStable},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "accepts a route53 endpoint of the control plane load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Route53:          &Route53EndpointSpec{HostedZoneID: "Z0123456789", RecordName: "api.cluster.example.com."},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects route53 endpoints with an invalid record name",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Route53:          &Route53EndpointSpec{HostedZoneID: "Z0123456789", RecordName: "api_cluster.example.com"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts an idle timeout of the control plane load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						IdleTimeout: &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects idle timeouts longer than 4000s",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						IdleTimeout: &metav1.Duration{Duration: 2 * time.Hour},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects idle timeouts of network load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IdleTimeout:      &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -310,6 +644,32 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "privateIPv6Egress can't be enabled for a VPC without IPv6",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{PrivateIPv6Egress: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "privateIPv6Egress can be enabled for an IPv6 VPC",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{VPC: VPCSpec{IPv6: &IPv6{}}},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{VPC: VPCSpec{IPv6: &IPv6{}}, PrivateIPv6Egress: true},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "controlPlaneLoadBalancer name is immutable",
 			oldCluster: &AWSCluster{
@@ -392,6 +752,92 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "controlPlaneLoadBalancer scheme is mutable for unnamed NLBs with a route53 endpoint",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Scheme:           &ELBSchemeInternetFacing,
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Route53:          &Route53EndpointSpec{HostedZoneID: "Z0123456789", RecordName: "api.cluster.example.com"},
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Scheme:           &ELBSchemeInternal,
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Route53:          &Route53EndpointSpec{HostedZoneID: "Z0123456789", RecordName: "api.cluster.example.com"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "controlPlaneLoadBalancer scheme is immutable for named NLBs",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             aws.String("apiserver"),
+						Scheme:           &ELBSchemeInternetFacing,
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Route53:          &Route53EndpointSpec{HostedZoneID: "Z0123456789", RecordName: "api.cluster.example.com"},
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             aws.String("apiserver"),
+						Scheme:           &ELBSchemeInternal,
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Route53:          &Route53EndpointSpec{HostedZoneID: "Z0123456789", RecordName: "api.cluster.example.com"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "controlPlaneLoadBalancer scheme is immutable for classic load balancers",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Scheme:  &ELBSchemeInternetFacing,
+						Route53: &Route53EndpointSpec{HostedZoneID: "Z0123456789", RecordName: "api.cluster.example.com"},
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Scheme:  &ELBSchemeInternal,
+						Route53: &Route53EndpointSpec{HostedZoneID: "Z0123456789", RecordName: "api.cluster.example.com"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "controlPlaneLoadBalancer route53 endpoint is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Route53:          &Route53EndpointSpec{HostedZoneID: "Z0123456789", RecordName: "api.cluster.example.com"},
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Route53:          &Route53EndpointSpec{HostedZoneID: "Z0123456789", RecordName: "kube.cluster.example.com"},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "controlPlaneLoadBalancer crossZoneLoadBalancer is mutable",
 			oldCluster: &AWSCluster{
@@ -571,6 +1017,20 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "resourceNaming is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ResourceNaming: &ResourceNaming{BaseName: "shared"},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ResourceNaming: &ResourceNaming{BaseName: "other"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -778,6 +1238,46 @@ func TestAWSClusterValidateAllowedCIDRBlocks(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "allow valid prefix list IDs",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Bastion: Bastion{
+						AllowedPrefixListIDs: []string{
+							"pl-0123456789abcdef0",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "disableIngressRules not allowed with prefix list IDs",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Bastion: Bastion{
+						AllowedPrefixListIDs: []string{
+							"pl-0123456789abcdef0",
+						},
+						DisableIngressRules: true,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid prefix list ID",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Bastion: Bastion{
+						AllowedPrefixListIDs: []string{
+							"sg-0123456789abcdef0",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -794,6 +1294,123 @@ func TestAWSClusterValidateAllowedCIDRBlocks(t *testing.T) {
 	}
 }
 
+func TestAWSClusterValidateSecurityProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		awsc    *AWSCluster
+		wantErr bool
+	}{
+		{
+			name: "bastion open to any address is not allowed by the baseline profile",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SecurityProfile: SecurityProfileBaseline,
+					Bastion: Bastion{
+						Enabled:           true,
+						AllowedCIDRBlocks: []string{"0.0.0.0/0"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bastion defaulted to open access is not allowed by the baseline profile",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SecurityProfile: SecurityProfileBaseline,
+					Bastion: Bastion{
+						Enabled: true,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bastion open to any IPv6 address is not allowed by the strict profile",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SecurityProfile: SecurityProfileStrict,
+					Bastion: Bastion{
+						Enabled:           true,
+						AllowedCIDRBlocks: []string{"10.0.0.0/8", "::/0"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bastion restricted to CIDR blocks is allowed by the baseline profile",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SecurityProfile: SecurityProfileBaseline,
+					Bastion: Bastion{
+						Enabled:           true,
+						AllowedCIDRBlocks: []string{"10.0.0.0/8"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "bastion restricted to prefix lists is allowed by the strict profile",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SecurityProfile: SecurityProfileStrict,
+					Bastion: Bastion{
+						Enabled:              true,
+						AllowedPrefixListIDs: []string{"pl-0123456789abcdef0"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "bastion without ingress rules is allowed by the strict profile",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SecurityProfile: SecurityProfileStrict,
+					Bastion: Bastion{
+						Enabled:             true,
+						DisableIngressRules: true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "disabled bastion is allowed by the baseline profile",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SecurityProfile: SecurityProfileBaseline,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown profile",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SecurityProfile: SecurityProfile("lax"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			cluster := tt.awsc.DeepCopy()
+			cluster.ObjectMeta = metav1.ObjectMeta{
+				GenerateName: "cluster-",
+				Namespace:    "default",
+			}
+			if err := testEnv.Create(ctx, cluster); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSecurityProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestAWSClusterDefaultAllowedCIDRBlocks(t *testing.T) {
 	g := NewWithT(t)
 	tests := []struct {
@@ -816,6 +1433,23 @@ func TestAWSClusterDefaultAllowedCIDRBlocks(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "empty AllowedCIDRBlocks is not defaulted when prefix lists are set",
+			beforeCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Bastion: Bastion{
+						AllowedPrefixListIDs: []string{"pl-0123456789abcdef0"},
+					},
+				},
+			},
+			afterCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Bastion: Bastion{
+						AllowedPrefixListIDs: []string{"pl-0123456789abcdef0"},
+					},
+				},
+			},
+		},
 		{
 			name: "AllowedCIDRBlocks change not allowed if DisableIngressRules is true",
 			beforeCluster: &AWSCluster{
@@ -18,6 +18,8 @@ package v1beta2
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -59,6 +61,10 @@ func (r *AWSClusterRoleIdentity) ValidateCreate() error {
 		}
 	}
 
+	if errs := r.validateSessionTags(); len(errs) > 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("AWSClusterRoleIdentity").GroupKind(), r.Name, errs)
+	}
+
 	return nil
 }
 
@@ -88,9 +94,58 @@ func (r *AWSClusterRoleIdentity) ValidateUpdate(old runtime.Object) error {
 		}
 	}
 
+	if errs := r.validateSessionTags(); len(errs) > 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("AWSClusterRoleIdentity").GroupKind(), r.Name, errs)
+	}
+
 	return nil
 }
 
+// validateSessionTags checks the session tags against the limits STS places on them.
+func (r *AWSClusterRoleIdentity) validateSessionTags() field.ErrorList {
+	// Defines the maximum number of session tags which can be passed when assuming a role.
+	const maxSessionTags = 50
+	var allErrs field.ErrorList
+	tagsPath := field.NewPath("spec", "sessionTags")
+
+	if len(r.Spec.SessionTags) > maxSessionTags {
+		allErrs = append(allErrs, field.TooMany(tagsPath, len(r.Spec.SessionTags), maxSessionTags))
+	}
+
+	sortedKeys := make([]string, 0, len(r.Spec.SessionTags))
+	for k := range r.Spec.SessionTags {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	// STS treats tag keys as case-insensitive.
+	keys := make(map[string]struct{}, len(r.Spec.SessionTags))
+	for _, k := range sortedKeys {
+		v := r.Spec.SessionTags[k]
+		if len(k) < 1 || len(k) > 128 {
+			allErrs = append(allErrs, field.Invalid(tagsPath, k, "key must be between 1 and 128 characters long"))
+		}
+		if len(v) > 256 {
+			allErrs = append(allErrs, field.Invalid(tagsPath.Key(k), v, "value cannot be longer than 256 characters"))
+		}
+		if wrongUserTagNomenclature(k) {
+			allErrs = append(allErrs, field.Invalid(tagsPath, k, "key cannot have prefix aws:"))
+		}
+		if _, ok := keys[strings.ToLower(k)]; ok {
+			allErrs = append(allErrs, field.Duplicate(tagsPath, k))
+		}
+		keys[strings.ToLower(k)] = struct{}{}
+	}
+
+	for i, k := range r.Spec.TransitiveTagKeys {
+		if _, ok := keys[strings.ToLower(k)]; !ok {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "transitiveTagKeys").Index(i), k, "must be the key of one of the session tags"))
+		}
+	}
+
+	return allErrs
+}
+
 // Default will set default values for the AWSClusterRoleIdentity.
 func (r *AWSClusterRoleIdentity) Default() {
 	SetDefaults_Labels(&r.ObjectMeta)
@@ -56,6 +56,57 @@ func TestAWSClusterRoleValidateCreate(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "successfully create AWSClusterRoleIdentity with session tags",
+			identity: &AWSClusterRoleIdentity{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "role",
+				},
+				Spec: AWSClusterRoleIdentitySpec{
+					SourceIdentityRef: &AWSIdentityReference{
+						Name: "another-role",
+						Kind: ClusterRoleIdentityKind,
+					},
+					SessionTags:       Tags{"team": "platform"},
+					TransitiveTagKeys: []string{"team"},
+					SourceIdentity:    "capa-controller",
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "do not allow session tag keys only differing by case",
+			identity: &AWSClusterRoleIdentity{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "role",
+				},
+				Spec: AWSClusterRoleIdentitySpec{
+					SourceIdentityRef: &AWSIdentityReference{
+						Name: "another-role",
+						Kind: ClusterRoleIdentityKind,
+					},
+					SessionTags: Tags{"team": "platform", "Team": "platform"},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "do not allow transitive tag keys without a session tag",
+			identity: &AWSClusterRoleIdentity{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "role",
+				},
+				Spec: AWSClusterRoleIdentitySpec{
+					SourceIdentityRef: &AWSIdentityReference{
+						Name: "another-role",
+						Kind: ClusterRoleIdentityKind,
+					},
+					SessionTags:       Tags{"team": "platform"},
+					TransitiveTagKeys: []string{"cost-center"},
+				},
+			},
+			wantError: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
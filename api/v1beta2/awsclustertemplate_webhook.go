@@ -47,7 +47,26 @@ func (r *AWSClusterTemplate) ValidateCreate() error {
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, r.Spec.Template.Spec.Bastion.Validate()...)
+	allErrs = append(allErrs, r.Spec.Template.Spec.NetworkSpec.VPC.NATGatewayElasticIPPool.Validate(field.NewPath("spec", "template", "spec", "network", "vpc", "natGatewayElasticIPPool"))...)
+	allErrs = append(allErrs, r.Spec.Template.Spec.NetworkSpec.VPC.DHCPOptions.Validate(field.NewPath("spec", "template", "spec", "network", "vpc", "dhcpOptions"))...)
+	allErrs = append(allErrs, r.Spec.Template.Spec.NetworkSpec.VPC.FlowLogs.Validate(field.NewPath("spec", "template", "spec", "network", "vpc", "flowLogs"))...)
+	allErrs = append(allErrs, r.Spec.Template.Spec.NetworkSpec.AdditionalControlPlaneIngressRules.Validate(field.NewPath("spec", "template", "spec", "network", "additionalControlPlaneIngressRules"))...)
+	allErrs = append(allErrs, r.Spec.Template.Spec.NetworkSpec.AdditionalNodeIngressRules.Validate(field.NewPath("spec", "template", "spec", "network", "additionalNodeIngressRules"))...)
+	allErrs = append(allErrs, r.Spec.Template.Spec.NetworkSpec.NodeEgressRules.Validate(field.NewPath("spec", "template", "spec", "network", "nodeEgressRules"))...)
 	allErrs = append(allErrs, validateSSHKeyName(r.Spec.Template.Spec.SSHKeyName)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerSubnets(r.Spec.Template.Spec.ControlPlaneLoadBalancer, r.Spec.Template.Spec.Region)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerProtection(r.Spec.Template.Spec.ControlPlaneLoadBalancer, r.Spec.Template.Spec.Region)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerAdditionalListeners(r.Spec.Template.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerHealthCheck(r.Spec.Template.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerConnectionDraining(r.Spec.Template.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateControlPlaneLoadBalancerIdleTimeout(r.Spec.Template.Spec.ControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, validateSecurityProfile(&r.Spec.Template.Spec)...)
+	allErrs = append(allErrs, validateResourceNaming(&r.Spec.Template.Spec, "", "", field.NewPath("spec", "template", "spec", "resourceNaming"))...)
+	allErrs = append(allErrs, r.Spec.Template.Spec.ServiceEndpoints.Validate(field.NewPath("spec", "template", "spec", "serviceEndpoints"))...)
+	allErrs = append(allErrs, r.Spec.Template.Spec.AdditionalTagsFrom.Validate(field.NewPath("spec", "template", "spec", "additionalTagsFrom"))...)
+	allErrs = append(allErrs, r.Spec.Template.Spec.Proxy.Validate(field.NewPath("spec", "template", "spec", "proxy"))...)
+	allErrs = append(allErrs, r.Spec.Template.Spec.Monitoring.Validate(field.NewPath("spec", "template", "spec", "monitoring"))...)
+	allErrs = append(allErrs, validatePartition(r.Spec.Template.Spec.Region, r.Spec.Template.Spec.Partition, field.NewPath("spec", "template", "spec", "partition"))...)
 
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
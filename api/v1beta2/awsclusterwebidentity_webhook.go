@@ -0,0 +1,182 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// DefaultWebIdentityAudience is the audience of the service account tokens exchanged for credentials by default.
+const DefaultWebIdentityAudience = "sts.amazonaws.com"
+
+// log is for logging in this package.
+var _ = ctrl.Log.WithName("awsclusterwebidentity-resource")
+
+func (r *AWSClusterWebIdentity) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awsclusterwebidentity,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsclusterwebidentities,versions=v1beta2,name=validation.awsclusterwebidentity.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta2-awsclusterwebidentity,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsclusterwebidentities,versions=v1beta2,name=default.awsclusterwebidentity.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+var (
+	_ webhook.Validator = &AWSClusterWebIdentity{}
+	_ webhook.Defaulter = &AWSClusterWebIdentity{}
+)
+
+// ValidateCreate will do any extra validation when creating an AWSClusterWebIdentity.
+func (r *AWSClusterWebIdentity) ValidateCreate() error {
+	if errs := r.validateSpec(); len(errs) > 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind(string(ClusterWebIdentityKind)).GroupKind(), r.Name, errs)
+	}
+
+	return nil
+}
+
+// ValidateDelete allows you to add any extra validation when deleting an AWSClusterWebIdentity.
+func (r *AWSClusterWebIdentity) ValidateDelete() error {
+	return nil
+}
+
+// ValidateUpdate will do any extra validation when updating an AWSClusterWebIdentity.
+func (r *AWSClusterWebIdentity) ValidateUpdate(old runtime.Object) error {
+	oldP, ok := old.(*AWSClusterWebIdentity)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an AWSClusterWebIdentity but got a %T", old))
+	}
+
+	allErrs := r.validateSpec()
+
+	// The secret is owned by the identity for 'clusterctl move', so it cannot be swapped for another one.
+	if oldP.Spec.RolesAnywhere != nil && r.Spec.RolesAnywhere != nil &&
+		oldP.Spec.RolesAnywhere.CertificateSecretRef != r.Spec.RolesAnywhere.CertificateSecretRef {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "rolesAnywhere", "certificateSecretRef"),
+			r.Spec.RolesAnywhere.CertificateSecretRef, "field cannot be updated"))
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind(string(ClusterWebIdentityKind)).GroupKind(), r.Name, allErrs)
+	}
+
+	return nil
+}
+
+// Default should return the default AWSClusterWebIdentity.
+func (r *AWSClusterWebIdentity) Default() {
+	SetDefaults_Labels(&r.ObjectMeta)
+	if r.Spec.WebIdentity != nil && r.Spec.WebIdentity.ServiceAccountName != "" && r.Spec.WebIdentity.Audience == "" {
+		r.Spec.WebIdentity.Audience = DefaultWebIdentityAudience
+	}
+}
+
+func (r *AWSClusterWebIdentity) validateSpec() field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	// Validate selector parses as Selector
+	if r.Spec.AllowedNamespaces != nil {
+		_, err := metav1.LabelSelectorAsSelector(&r.Spec.AllowedNamespaces.Selector)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("allowedNamespaces", "selector"), r.Spec.AllowedNamespaces.Selector, err.Error()))
+		}
+	}
+
+	switch {
+	case r.Spec.WebIdentity == nil && r.Spec.RolesAnywhere == nil:
+		allErrs = append(allErrs, field.Required(specPath, "one of webIdentity or rolesAnywhere must be set"))
+	case r.Spec.WebIdentity != nil && r.Spec.RolesAnywhere != nil:
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("rolesAnywhere"), "cannot be set together with webIdentity"))
+	case r.Spec.WebIdentity != nil:
+		allErrs = append(allErrs, r.validateWebIdentity(specPath)...)
+	default:
+		allErrs = append(allErrs, r.validateRolesAnywhere(specPath)...)
+	}
+
+	return allErrs
+}
+
+func (r *AWSClusterWebIdentity) validateWebIdentity(specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	source := r.Spec.WebIdentity
+	sourcePath := specPath.Child("webIdentity")
+
+	switch {
+	case source.ServiceAccountName == "" && source.TokenFile == "":
+		allErrs = append(allErrs, field.Required(sourcePath, "one of serviceAccountName or tokenFile must be set"))
+	case source.ServiceAccountName != "" && source.TokenFile != "":
+		allErrs = append(allErrs, field.Forbidden(sourcePath.Child("tokenFile"), "cannot be set together with serviceAccountName"))
+	}
+
+	// AssumeRoleWithWebIdentity is called without an inline session policy.
+	if r.Spec.InlinePolicy != "" {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("inlinePolicy"), "is not supported with webIdentity"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSClusterWebIdentity) validateRolesAnywhere(specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	source := r.Spec.RolesAnywhere
+	sourcePath := specPath.Child("rolesAnywhere")
+
+	if err := validateRolesAnywhereARN(source.TrustAnchorARN, "trust-anchor/"); err != nil {
+		allErrs = append(allErrs, field.Invalid(sourcePath.Child("trustAnchorARN"), source.TrustAnchorARN, err.Error()))
+	}
+	if err := validateRolesAnywhereARN(source.ProfileARN, "profile/"); err != nil {
+		allErrs = append(allErrs, field.Invalid(sourcePath.Child("profileARN"), source.ProfileARN, err.Error()))
+	}
+	if source.CertificateSecretRef == "" {
+		allErrs = append(allErrs, field.Required(sourcePath.Child("certificateSecretRef"), "must be set"))
+	}
+
+	// The session policies and session name of IAM Roles Anywhere sessions are set by the profile and the certificate.
+	if r.Spec.InlinePolicy != "" {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("inlinePolicy"), "is not supported with rolesAnywhere, set the session policy on the profile instead"))
+	}
+	if len(r.Spec.PolicyARNs) > 0 {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("policyARNs"), "is not supported with rolesAnywhere, set the managed policies on the profile instead"))
+	}
+	if r.Spec.SessionName != "" {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("sessionName"), "is not supported with rolesAnywhere"))
+	}
+
+	return allErrs
+}
+
+// validateRolesAnywhereARN checks s is the ARN of an IAM Roles Anywhere resource of the given type.
+func validateRolesAnywhereARN(s, resourcePrefix string) error {
+	parsed, err := arn.Parse(s)
+	if err != nil {
+		return err
+	}
+	if parsed.Service != "rolesanywhere" || parsed.Region == "" || !strings.HasPrefix(parsed.Resource, resourcePrefix) {
+		return fmt.Errorf("must be the ARN of an IAM Roles Anywhere %s", strings.TrimSuffix(resourcePrefix, "/"))
+	}
+	return nil
+}
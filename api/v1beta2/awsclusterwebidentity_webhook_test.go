@@ -0,0 +1,184 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testTrustAnchorARN = "arn:aws:rolesanywhere:us-east-1:123456789012:trust-anchor/4579702c-9abb-47c2-88b2-c734e0b29539"
+	testProfileARN     = "arn:aws:rolesanywhere:us-east-1:123456789012:profile/6ba4ab5e-2a2a-4f66-8bba-bcd1e2d8b3ec"
+)
+
+func TestCreateAWSClusterWebIdentityValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      AWSClusterWebIdentitySpec
+		wantError bool
+	}{
+		{
+			name: "should not return error for a service account web identity",
+			spec: AWSClusterWebIdentitySpec{
+				WebIdentity: &WebIdentityTokenSource{ServiceAccountName: "capa-web-identity"},
+			},
+			wantError: false,
+		},
+		{
+			name: "should not return error for a token file web identity",
+			spec: AWSClusterWebIdentitySpec{
+				WebIdentity: &WebIdentityTokenSource{TokenFile: "/var/run/secrets/tokens/capa"},
+			},
+			wantError: false,
+		},
+		{
+			name: "should not return error for an IAM Roles Anywhere identity",
+			spec: AWSClusterWebIdentitySpec{
+				RolesAnywhere: &RolesAnywhereSource{
+					TrustAnchorARN:       testTrustAnchorARN,
+					ProfileARN:           testProfileARN,
+					CertificateSecretRef: "capa-certificate",
+				},
+			},
+			wantError: false,
+		},
+		{
+			name:      "should return error when no credential source is set",
+			spec:      AWSClusterWebIdentitySpec{},
+			wantError: true,
+		},
+		{
+			name: "should return error when both credential sources are set",
+			spec: AWSClusterWebIdentitySpec{
+				WebIdentity: &WebIdentityTokenSource{ServiceAccountName: "capa-web-identity"},
+				RolesAnywhere: &RolesAnywhereSource{
+					TrustAnchorARN:       testTrustAnchorARN,
+					ProfileARN:           testProfileARN,
+					CertificateSecretRef: "capa-certificate",
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "should return error when both a service account and a token file are set",
+			spec: AWSClusterWebIdentitySpec{
+				WebIdentity: &WebIdentityTokenSource{ServiceAccountName: "capa-web-identity", TokenFile: "/var/run/secrets/tokens/capa"},
+			},
+			wantError: true,
+		},
+		{
+			name: "should return error for an inline policy with a web identity",
+			spec: AWSClusterWebIdentitySpec{
+				AWSRoleSpec: AWSRoleSpec{InlinePolicy: `{"Version":"2012-10-17"}`},
+				WebIdentity: &WebIdentityTokenSource{ServiceAccountName: "capa-web-identity"},
+			},
+			wantError: true,
+		},
+		{
+			name: "should return error when the trust anchor is not an IAM Roles Anywhere ARN",
+			spec: AWSClusterWebIdentitySpec{
+				RolesAnywhere: &RolesAnywhereSource{
+					TrustAnchorARN:       "arn:aws:iam::123456789012:role/capa",
+					ProfileARN:           testProfileARN,
+					CertificateSecretRef: "capa-certificate",
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "should return error for managed policies with IAM Roles Anywhere",
+			spec: AWSClusterWebIdentitySpec{
+				AWSRoleSpec: AWSRoleSpec{PolicyARNs: []string{"arn:aws:iam::aws:policy/ReadOnlyAccess"}},
+				RolesAnywhere: &RolesAnywhereSource{
+					TrustAnchorARN:       testTrustAnchorARN,
+					ProfileARN:           testProfileARN,
+					CertificateSecretRef: "capa-certificate",
+				},
+			},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := &AWSClusterWebIdentity{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: GroupVersion.String(),
+					Kind:       string(ClusterWebIdentityKind),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "web",
+				},
+				Spec: tt.spec,
+			}
+			identity.Spec.RoleArn = "arn:aws:iam::123456789012:role/capa"
+
+			ctx := context.TODO()
+			if err := testEnv.Create(ctx, identity); (err != nil) != tt.wantError {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantError)
+			}
+			testEnv.Delete(ctx, identity)
+		})
+	}
+}
+
+func TestAWSClusterWebIdentityDefault(t *testing.T) {
+	g := NewWithT(t)
+	identity := &AWSClusterWebIdentity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web-default",
+		},
+		Spec: AWSClusterWebIdentitySpec{
+			AWSRoleSpec: AWSRoleSpec{RoleArn: "arn:aws:iam::123456789012:role/capa"},
+			WebIdentity: &WebIdentityTokenSource{ServiceAccountName: "capa-web-identity"},
+		},
+	}
+
+	ctx := context.TODO()
+	defer testEnv.Delete(ctx, identity)
+
+	g.Expect(testEnv.Create(ctx, identity)).To(Succeed())
+	g.Expect(identity.Spec.WebIdentity.Audience).To(Equal(DefaultWebIdentityAudience))
+}
+
+func TestAWSClusterWebIdentityValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+	identity := &AWSClusterWebIdentity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web-update",
+		},
+		Spec: AWSClusterWebIdentitySpec{
+			AWSRoleSpec: AWSRoleSpec{RoleArn: "arn:aws:iam::123456789012:role/capa"},
+			RolesAnywhere: &RolesAnywhereSource{
+				TrustAnchorARN:       testTrustAnchorARN,
+				ProfileARN:           testProfileARN,
+				CertificateSecretRef: "capa-certificate",
+			},
+		},
+	}
+
+	ctx := context.TODO()
+	defer testEnv.Delete(ctx, identity)
+
+	g.Expect(testEnv.Create(ctx, identity)).To(Succeed())
+
+	identity.Spec.RolesAnywhere.CertificateSecretRef = "another-certificate"
+	g.Expect(testEnv.Update(ctx, identity)).NotTo(Succeed())
+}
@@ -149,6 +149,31 @@ type AWSClusterRoleIdentitySpec struct {
 	// SourceIdentityRef is a reference to another identity which will be chained to do
 	// role assumption. All identity types are accepted.
 	SourceIdentityRef *AWSIdentityReference `json:"sourceIdentityRef,omitempty"`
+
+	// SessionTags are passed as session tags when assuming the role. Their keys
+	// must be unique regardless of case.
+	// +optional
+	SessionTags Tags `json:"sessionTags,omitempty"`
+
+	// TransitiveTagKeys are the keys of the session tags which are passed on to
+	// the sessions of the role identities chained after this one.
+	// +optional
+	TransitiveTagKeys []string `json:"transitiveTagKeys,omitempty"`
+
+	// SourceIdentity is set on the role session when assuming the role. Once set,
+	// it is kept by all the sessions of the role identities chained after this one.
+	// +kubebuilder:validation:MinLength:=2
+	// +kubebuilder:validation:MaxLength:=64
+	// +kubebuilder:validation:Pattern:=`^[\w+=,.@-]*$`
+	// +optional
+	SourceIdentity string `json:"sourceIdentity,omitempty"`
+
+	// STSRegion is the region of the regional STS endpoint the role is assumed with, e.g. to keep the
+	// requests in a region for data residency. When not set, the STS region of the controller is used,
+	// or else the global STS endpoint.
+	// +kubebuilder:validation:Pattern:=`^[a-z]{2}(-[a-z]+)+-[0-9]+$`
+	// +optional
+	STSRegion string `json:"stsRegion,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -181,6 +206,83 @@ type AWSClusterControllerIdentitySpec struct {
 	AWSClusterIdentitySpec `json:",inline"`
 }
 
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=awsclusterwebidentities,scope=Cluster,categories=cluster-api,shortName=awswi
+// +kubebuilder:storageversion
+// +k8s:defaulter-gen=true
+
+// AWSClusterWebIdentity is the Schema for the awsclusterwebidentities API
+// It is used to assume a role with a service account token of the management cluster,
+// or with an IAM Roles Anywhere certificate.
+type AWSClusterWebIdentity struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec for this AWSClusterWebIdentity.
+	Spec AWSClusterWebIdentitySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:defaulter-gen=true
+
+// AWSClusterWebIdentityList contains a list of AWSClusterWebIdentity.
+type AWSClusterWebIdentityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AWSClusterWebIdentity `json:"items"`
+}
+
+// AWSClusterWebIdentitySpec defines the specifications for AWSClusterWebIdentity.
+// Exactly one of WebIdentity and RolesAnywhere must be set.
+type AWSClusterWebIdentitySpec struct {
+	AWSClusterIdentitySpec `json:",inline"`
+	AWSRoleSpec            `json:",inline"`
+
+	// WebIdentity assumes the role with AssumeRoleWithWebIdentity, using a service account
+	// token of the management cluster. The OIDC issuer of the management cluster must be
+	// registered as an identity provider in IAM and trusted by the role.
+	// +optional
+	WebIdentity *WebIdentityTokenSource `json:"webIdentity,omitempty"`
+
+	// RolesAnywhere assumes the role through IAM Roles Anywhere, using an X.509 certificate
+	// issued by a trust anchor.
+	// +optional
+	RolesAnywhere *RolesAnywhereSource `json:"rolesAnywhere,omitempty"`
+}
+
+// WebIdentityTokenSource defines where the web identity tokens exchanged for credentials come from.
+// Exactly one of ServiceAccountName and TokenFile must be set.
+type WebIdentityTokenSource struct {
+	// ServiceAccountName is the name of a service account in the namespace of the controller.
+	// Tokens are requested for it with the TokenRequest API.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// Audience of the tokens requested for ServiceAccountName.
+	// +kubebuilder:default=sts.amazonaws.com
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// TokenFile is the path of a projected service account token mounted into the controller.
+	// +optional
+	TokenFile string `json:"tokenFile,omitempty"`
+}
+
+// RolesAnywhereSource defines the IAM Roles Anywhere resources used to create sessions.
+type RolesAnywhereSource struct {
+	// TrustAnchorARN is the ARN of the trust anchor which issued the certificate.
+	// The sessions are created in the region of the trust anchor.
+	TrustAnchorARN string `json:"trustAnchorARN"`
+
+	// ProfileARN is the ARN of the profile, which must list the role.
+	ProfileARN string `json:"profileARN"`
+
+	// CertificateSecretRef is the name of a secret in the namespace of the controller, of type
+	// kubernetes.io/tls. The tls.crt key may hold intermediate certificates after the certificate
+	// of the controller.
+	CertificateSecretRef string `json:"certificateSecretRef"`
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&AWSClusterStaticIdentity{},
@@ -189,5 +291,7 @@ func init() {
 		&AWSClusterRoleIdentityList{},
 		&AWSClusterControllerIdentity{},
 		&AWSClusterControllerIdentityList{},
+		&AWSClusterWebIdentity{},
+		&AWSClusterWebIdentityList{},
 	)
 }
@@ -30,6 +30,16 @@ const (
 
 	// DefaultIgnitionVersion represents default Ignition version generated for machine userdata.
 	DefaultIgnitionVersion = "2.3"
+
+	// MaxSecurityGroupsPerNetworkInterface is the largest number of security groups AWS allows on a
+	// network interface when the account quota is raised to its maximum. The default quota is 5, the
+	// controllers check the actual quota of the account before creating or updating instances.
+	MaxSecurityGroupsPerNetworkInterface = 16
+
+	// HibernateOnDeleteAnnotation requests that the instance of an AWSMachine with hibernation
+	// configured is hibernated instead of terminated when the AWSMachine is deleted. The hibernated
+	// instance is left in the stopped state and is no longer managed by the controllers.
+	HibernateOnDeleteAnnotation = "aws.cluster.x-k8s.io/hibernate-on-delete"
 )
 
 // SecretBackend defines variants for backend secret storage.
@@ -102,10 +112,21 @@ type AWSMachineSpec struct {
 	// +optional
 	PublicIP *bool `json:"publicIP,omitempty"`
 
+	// PrivateIPAddress is the primary private IPv4 address of the instance, e.g. so that the addresses
+	// of control plane nodes can be registered in firewalls or DNS ahead of time. It must be in the CIDR
+	// block of the subnet of the instance, and can't be used by another machine of the cluster. It can't
+	// be set together with NetworkInterfaces, nor on AWSMachineTemplates, whose machines would all get
+	// the same address.
+	// +optional
+	PrivateIPAddress *string `json:"privateIPAddress,omitempty"`
+
 	// AdditionalSecurityGroups is an array of references to security groups that should be applied to the
 	// instance. These security groups would be set in addition to any security groups defined
 	// at the cluster level or in the actuator. It is possible to specify either IDs of Filters. Using Filters
 	// will cause additional requests to AWS API and if tags change the attached security groups might change too.
+	// Groups matched by Filters are ordered by ID and duplicates are dropped. Together with the security groups
+	// managed by the provider, the instance must not end up with more security groups than AWS allows per
+	// network interface.
 	// +optional
 	AdditionalSecurityGroups []AWSResourceReference `json:"additionalSecurityGroups,omitempty"`
 
@@ -126,12 +147,35 @@ type AWSMachineSpec struct {
 	// +optional
 	NonRootVolumes []Volume `json:"nonRootVolumes,omitempty"`
 
+	// SnapshotOnDelete creates an EBS snapshot of the root volume of the instance, or of the volumes
+	// listed in SnapshotDeviceNames, before the instance is terminated, e.g. to retain them for forensic
+	// analysis. The snapshots are tagged like the instance and named after the AWSMachine and the device.
+	// +optional
+	SnapshotOnDelete bool `json:"snapshotOnDelete,omitempty"`
+
+	// SnapshotDeviceNames are the device names of the volumes snapshotted when SnapshotOnDelete is set,
+	// e.g. "/dev/sdb". Defaults to the root volume of the instance.
+	// +optional
+	SnapshotDeviceNames []string `json:"snapshotDeviceNames,omitempty"`
+
+	// CleanupPolicy defines whether the non root EBS volumes and the secondary network interfaces of the
+	// instance are deleted, retained or snapshotted when the AWSMachine is deleted.
+	// +optional
+	CleanupPolicy *CleanupPolicy `json:"cleanupPolicy,omitempty"`
+
 	// NetworkInterfaces is a list of ENIs to associate with the instance.
 	// A maximum of 2 may be specified.
 	// +optional
 	// +kubebuilder:validation:MaxItems=2
 	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
 
+	// AdditionalNetworkInterfaces are network interfaces created and attached to the instance when
+	// it is launched, in addition to its primary network interface, e.g. for data plane traffic in
+	// other subnets. They can't be combined with NetworkInterfaces nor with a public IP, as AWS doesn't
+	// assign public IPs to instances launched with several network interfaces.
+	// +optional
+	AdditionalNetworkInterfaces []NetworkInterfaceSpec `json:"additionalNetworkInterfaces,omitempty"`
+
 	// UncompressedUserData specify whether the user data is gzip-compressed before it is sent to ec2 instance.
 	// cloud-init has built-in support for gzip-compressed user data
 	// user data stored in aws secret manager is always gzip-compressed.
@@ -148,6 +192,23 @@ type AWSMachineSpec struct {
 	// +optional
 	Ignition *Ignition `json:"ignition,omitempty"`
 
+	// Bottlerocket defines options related to machines running Bottlerocket OS. When set,
+	// the userdata of the instance is rendered as Bottlerocket TOML settings.
+	// +optional
+	Bottlerocket *Bottlerocket `json:"bottlerocket,omitempty"`
+
+	// PreBootstrapCommands are commands run on the instance before the commands of the bootstrap
+	// data, e.g. to install a proxy CA certificate. They are merged into the cloud-init cloud config
+	// of the bootstrap data, and cannot be used with Ignition or Bottlerocket.
+	// +optional
+	PreBootstrapCommands []string `json:"preBootstrapCommands,omitempty"`
+
+	// PostBootstrapCommands are commands run on the instance after the commands of the bootstrap
+	// data, e.g. to install an agent. They are merged into the cloud-init cloud config of the
+	// bootstrap data, and cannot be used with Ignition or Bottlerocket.
+	// +optional
+	PostBootstrapCommands []string `json:"postBootstrapCommands,omitempty"`
+
 	// SpotMarketOptions allows users to configure instances to be run using AWS Spot instances.
 	// +optional
 	SpotMarketOptions *SpotMarketOptions `json:"spotMarketOptions,omitempty"`
@@ -156,6 +217,43 @@ type AWSMachineSpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum:=default;dedicated;host
 	Tenancy string `json:"tenancy,omitempty"`
+
+	// CPUOptions sets the number of CPU cores and threads per core of the instance, e.g. to reduce
+	// the number of licensed cores or to disable multithreading. Defaults to the CPU options of the
+	// instance type.
+	// +optional
+	CPUOptions *CPUOptions `json:"cpuOptions,omitempty"`
+
+	// EnclaveOptions enables AWS Nitro Enclaves on the instance, for isolated processing of sensitive
+	// data. The instance type must support Nitro Enclaves.
+	// +optional
+	EnclaveOptions *EnclaveOptions `json:"enclaveOptions,omitempty"`
+
+	// HibernationOptions enables hibernation of the instance. The root volume must be encrypted and
+	// large enough to store the RAM of the instance in addition to the operating system. Hibernation
+	// can only be enabled when the instance is launched.
+	// +optional
+	HibernationOptions *HibernationOptions `json:"hibernationOptions,omitempty"`
+
+	// CreditSpecification sets the credit option for CPU usage of a burstable performance instance,
+	// e.g. standard to avoid paying for surplus credits of t3 instances, which are unlimited by
+	// default. It can only be set for burstable performance instance types.
+	// +optional
+	CreditSpecification *CreditSpecification `json:"creditSpecification,omitempty"`
+
+	// OutpostARN is the ARN of the AWS Outpost on which the instance is launched. The instance is
+	// placed in a subnet of the cluster on the Outpost, or in the subnet set in Subnet, which must
+	// be on the Outpost. The instance type must be available on the Outpost.
+	// Instances are placed in subnets which are not on an Outpost when empty.
+	// +optional
+	OutpostARN string `json:"outpostArn,omitempty"`
+
+	// InstanceNameTemplate is a Go text/template used to generate the value of the
+	// Name tag of the EC2 instance, e.g. "{{.ClusterName}}-{{.MachineDeployment}}-{{.Index}}".
+	// See InstanceNameTemplateData for the available fields. It overrides the template set on
+	// the AWSCluster. Defaults to the name of the AWSMachine.
+	// +optional
+	InstanceNameTemplate string `json:"instanceNameTemplate,omitempty"`
 }
 
 // CloudInit defines options related to the bootstrapping systems where
@@ -195,6 +293,55 @@ type Ignition struct {
 	Version string `json:"version,omitempty"`
 }
 
+// Bottlerocket defines options related to machines running Bottlerocket OS.
+type Bottlerocket struct {
+	// Variant is the Bottlerocket variant used to look up the AMI when none is set,
+	// e.g. aws-k8s-1.26. Defaults to the aws-k8s variant matching the Kubernetes
+	// version of the machine.
+	// +optional
+	Variant string `json:"variant,omitempty"`
+
+	// BootstrapContainerSource is the image of the bootstrap container that runs the
+	// bootstrap data of the machine on first boot. The container receives the bootstrap
+	// data as its user data, or the script fetching it when a secure secrets backend is used.
+	// +kubebuilder:validation:MinLength:=1
+	BootstrapContainerSource string `json:"bootstrapContainerSource"`
+
+	// AdminContainer configures the admin host container, which gives privileged
+	// access to the host over SSH.
+	// +optional
+	AdminContainer *BottlerocketHostContainer `json:"adminContainer,omitempty"`
+
+	// KernelSysctls are the kernel parameters to set, keyed by name.
+	// +optional
+	KernelSysctls map[string]string `json:"kernelSysctls,omitempty"`
+
+	// RegistryMirrors are the mirrors the container runtime pulls images from.
+	// +optional
+	RegistryMirrors []BottlerocketRegistryMirror `json:"registryMirrors,omitempty"`
+}
+
+// BottlerocketHostContainer defines a Bottlerocket host container.
+type BottlerocketHostContainer struct {
+	// Enabled starts the container when the host boots.
+	Enabled bool `json:"enabled"`
+
+	// Source is the image of the container. Defaults to the image shipped with the variant.
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
+// BottlerocketRegistryMirror defines the mirrors of a container registry.
+type BottlerocketRegistryMirror struct {
+	// Registry is the registry being mirrored, e.g. docker.io.
+	// +kubebuilder:validation:MinLength:=1
+	Registry string `json:"registry"`
+
+	// Endpoints are the URLs of the mirrors, tried in order.
+	// +kubebuilder:validation:MinItems:=1
+	Endpoints []string `json:"endpoints"`
+}
+
 // AWSMachineStatus defines the observed state of AWSMachine.
 type AWSMachineStatus struct {
 	// Ready is true when the provider resource is ready.
@@ -17,12 +17,16 @@ limitations under the License.
 package v1beta2
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
@@ -31,20 +35,71 @@ import (
 // log is for logging in this package.
 var log = ctrl.Log.WithName("awsmachine-resource")
 
-func (r *AWSMachine) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (r *AWSMachineWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
+		For(&AWSMachine{}).
+		WithValidator(r).
 		Complete()
 }
 
+// AWSMachineWebhook implements the validation webhook for AWSMachine. It runs the validation of the
+// AWSMachine itself, then the checks reading the cluster of the machine, such as its security profile.
+// +kubebuilder:object:generate=false
+type AWSMachineWebhook struct {
+	// Client reads the clusters of the machines. It defaults to the client of the manager.
+	Client client.Reader
+
+	// EnforceVolumeEncryption rejects the machines of every cluster with volumes that aren't
+	// explicitly encrypted. It is set from the --enforce-volume-encryption flag.
+	EnforceVolumeEncryption bool
+}
+
 // +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awsmachine,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,versions=v1beta2,name=validation.awsmachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta2-awsmachine,mutating=true,failurePolicy=fail,groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,versions=v1beta2,name=mawsmachine.kb.io,name=mutation.awsmachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
 var (
-	_ webhook.Validator = &AWSMachine{}
-	_ webhook.Defaulter = &AWSMachine{}
+	_ webhook.Validator       = &AWSMachine{}
+	_ webhook.Defaulter       = &AWSMachine{}
+	_ webhook.CustomValidator = &AWSMachineWebhook{}
 )
 
+// ValidateCreate validates the AWSMachine, then checks it against the security profile and the volume
+// encryption policy of its cluster, and the private IP addresses of the other machines of the cluster.
+func (r *AWSMachineWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	m, ok := obj.(*AWSMachine)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an AWSMachine but got a %T", obj))
+	}
+
+	if err := m.ValidateCreate(); err != nil {
+		return err
+	}
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateMachineSecurityProfile(ctx, r.Client, m, &m.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateMachineVolumeEncryption(ctx, r.Client, r.EnforceVolumeEncryption, m, &m.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateUniquePrivateIPAddress(ctx, r.Client, m)...)
+
+	return aggregateObjErrors(m.GroupVersionKind().GroupKind(), m.Name, allErrs)
+}
+
+// ValidateUpdate validates the AWSMachine. The spec checked against the security profile is immutable.
+func (r *AWSMachineWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) error {
+	m, ok := newObj.(*AWSMachine)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an AWSMachine but got a %T", newObj))
+	}
+	return m.ValidateUpdate(oldObj)
+}
+
+// ValidateDelete allows every deletion.
+func (r *AWSMachineWebhook) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (r *AWSMachine) ValidateCreate() error {
 	var allErrs field.ErrorList
@@ -55,7 +110,16 @@ func (r *AWSMachine) ValidateCreate() error {
 	allErrs = append(allErrs, r.validateNonRootVolumes()...)
 	allErrs = append(allErrs, r.validateSSHKeyName()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, validateAdditionalNetworkInterfaces(&r.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateSnapshotOnDelete(&r.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateHibernationOptions(&r.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, r.Spec.Bottlerocket.Validate(field.NewPath("spec", "bottlerocket"))...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, validateInstanceNameTemplate(r.Spec.InstanceNameTemplate, field.NewPath("spec", "instanceNameTemplate"))...)
+	allErrs = append(allErrs, validateOutpostARN(r.Spec.OutpostARN, field.NewPath("spec", "outpostArn"))...)
+	allErrs = append(allErrs, validatePrivateIPAddress(&r.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, r.Spec.CreditSpecification.Validate(r.Spec.InstanceType, field.NewPath("spec", "creditSpecification"))...)
+	allErrs = append(allErrs, r.Spec.AMI.ValidateAccelerator(r.Spec.InstanceType, field.NewPath("spec", "ami"))...)
 
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -79,6 +143,7 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) error {
 
 	allErrs = append(allErrs, r.validateCloudInitSecret()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, validateSnapshotOnDelete(&r.Spec, field.NewPath("spec"))...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
 	newAWSMachineSpec := newAWSMachine["spec"].(map[string]interface{})
@@ -100,6 +165,17 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) error {
 	delete(oldAWSMachineSpec, "additionalSecurityGroups")
 	delete(newAWSMachineSpec, "additionalSecurityGroups")
 
+	// allow changes to snapshotOnDelete and snapshotDeviceNames, so that the volumes of a machine
+	// can still be retained before it is deleted
+	delete(oldAWSMachineSpec, "snapshotOnDelete")
+	delete(newAWSMachineSpec, "snapshotOnDelete")
+	delete(oldAWSMachineSpec, "snapshotDeviceNames")
+	delete(newAWSMachineSpec, "snapshotDeviceNames")
+
+	// allow changes to cleanupPolicy, which is read when the machine is deleted
+	delete(oldAWSMachineSpec, "cleanupPolicy")
+	delete(newAWSMachineSpec, "cleanupPolicy")
+
 	// allow changes to secretPrefix, secretCount, and secureSecretsBackend
 	if cloudInit, ok := oldAWSMachineSpec["cloudInit"].(map[string]interface{}); ok {
 		delete(cloudInit, "secretPrefix")
@@ -170,6 +246,36 @@ func (r *AWSMachine) validateIgnitionAndCloudInit() field.ErrorList {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cloudInit"), "cannot be set if spec.ignition is set"))
 	}
 
+	if r.ignitionEnabled() && r.Spec.Bottlerocket != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "bottlerocket"), "cannot be set if spec.ignition is set"))
+	}
+
+	allErrs = append(allErrs, ValidateBootstrapCommands(r.Spec.PreBootstrapCommands, r.Spec.PostBootstrapCommands, r.Spec.Ignition, r.Spec.Bottlerocket, field.NewPath("spec"))...)
+
+	return allErrs
+}
+
+// ValidateBootstrapCommands checks that the bootstrap commands are only set for cloud-init bootstrap data,
+// which they are merged into. path is the path of the fields, ignition and bottlerocket are nil if unset.
+func ValidateBootstrapCommands(preBootstrapCommands, postBootstrapCommands []string, ignition *Ignition, bottlerocket *Bottlerocket, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	commands := map[string][]string{
+		"preBootstrapCommands":  preBootstrapCommands,
+		"postBootstrapCommands": postBootstrapCommands,
+	}
+	for _, name := range []string{"preBootstrapCommands", "postBootstrapCommands"} {
+		if len(commands[name]) == 0 {
+			continue
+		}
+		if ignition != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child(name), "cannot be set if "+path.Child("ignition").String()+" is set"))
+		}
+		if bottlerocket != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child(name), "cannot be set if "+path.Child("bottlerocket").String()+" is set"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -193,6 +299,8 @@ func (r *AWSMachine) validateRootVolume() field.ErrorList {
 		}
 	}
 
+	allErrs = append(allErrs, r.Spec.RootVolume.ValidatePerformance(field.NewPath("spec", "rootVolume"))...)
+
 	if r.Spec.RootVolume.DeviceName != "" {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
@@ -203,7 +311,7 @@ func (r *AWSMachine) validateRootVolume() field.ErrorList {
 func (r *AWSMachine) validateNonRootVolumes() field.ErrorList {
 	var allErrs field.ErrorList
 
-	for _, volume := range r.Spec.NonRootVolumes {
+	for i, volume := range r.Spec.NonRootVolumes {
 		if VolumeTypesProvisioned.Has(string(volume.Type)) && volume.IOPS == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
 		}
@@ -220,6 +328,8 @@ func (r *AWSMachine) validateNonRootVolumes() field.ErrorList {
 		if volume.DeviceName == "" {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.deviceName"), "non root volume should have device name"))
 		}
+
+		allErrs = append(allErrs, volume.ValidatePerformance(field.NewPath("spec", "nonRootVolumes").Index(i))...)
 	}
 
 	return allErrs
@@ -247,13 +357,115 @@ func (r *AWSMachine) Default() {
 }
 
 func (r *AWSMachine) validateAdditionalSecurityGroups() field.ErrorList {
+	return validateAdditionalSecurityGroups(r.Spec.AdditionalSecurityGroups, field.NewPath("spec.additionalSecurityGroups"))
+}
+
+func validateAdditionalSecurityGroups(securityGroups []AWSResourceReference, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
-	for _, additionalSecurityGroup := range r.Spec.AdditionalSecurityGroups {
+	if len(securityGroups) > MaxSecurityGroupsPerNetworkInterface {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(securityGroups), MaxSecurityGroupsPerNetworkInterface))
+	}
+
+	ids := map[string]struct{}{}
+	for i, additionalSecurityGroup := range securityGroups {
 		if len(additionalSecurityGroup.Filters) > 0 && additionalSecurityGroup.ID != nil {
-			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec.additionalSecurityGroups"), "only one of ID or Filters may be specified, specifying both is forbidden"))
+			allErrs = append(allErrs, field.Forbidden(fldPath, "only one of ID or Filters may be specified, specifying both is forbidden"))
+		}
+		if additionalSecurityGroup.ID != nil {
+			if _, ok := ids[*additionalSecurityGroup.ID]; ok {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("id"), *additionalSecurityGroup.ID))
+			}
+			ids[*additionalSecurityGroup.ID] = struct{}{}
+		}
+	}
+	return allErrs
+}
+
+// validateAdditionalNetworkInterfaces validates the additional network interfaces of the machine spec at fldPath.
+func validateAdditionalNetworkInterfaces(spec *AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(spec.AdditionalNetworkInterfaces) == 0 {
+		return allErrs
+	}
+
+	interfacesPath := fldPath.Child("additionalNetworkInterfaces")
+	if len(spec.NetworkInterfaces) > 0 {
+		allErrs = append(allErrs, field.Forbidden(interfacesPath, "cannot be set together with networkInterfaces"))
+	}
+	if spec.PublicIP != nil && *spec.PublicIP {
+		allErrs = append(allErrs, field.Forbidden(interfacesPath, "cannot be set together with publicIP, AWS doesn't assign public IPs to instances with several network interfaces"))
+	}
+
+	deviceIndexes := map[int64]struct{}{}
+	for i, networkInterface := range spec.AdditionalNetworkInterfaces {
+		if _, ok := deviceIndexes[networkInterface.DeviceIndex]; ok {
+			allErrs = append(allErrs, field.Duplicate(interfacesPath.Index(i).Child("deviceIndex"), networkInterface.DeviceIndex))
+		}
+		deviceIndexes[networkInterface.DeviceIndex] = struct{}{}
+
+		if networkInterface.Subnet != nil && networkInterface.Subnet.ID != nil && len(networkInterface.Subnet.Filters) > 0 {
+			allErrs = append(allErrs, field.Forbidden(interfacesPath.Index(i).Child("subnet"), "only one of ID or Filters may be specified, specifying both is forbidden"))
+		}
+		allErrs = append(allErrs, validateAdditionalSecurityGroups(networkInterface.SecurityGroups, interfacesPath.Index(i).Child("securityGroups"))...)
+	}
+
+	return allErrs
+}
+
+// validateSnapshotOnDelete validates the volumes snapshotted before the deletion of the machine spec at fldPath.
+func validateSnapshotOnDelete(spec *AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(spec.SnapshotDeviceNames) == 0 {
+		return allErrs
+	}
+
+	deviceNamesPath := fldPath.Child("snapshotDeviceNames")
+	if !spec.SnapshotOnDelete {
+		allErrs = append(allErrs, field.Forbidden(deviceNamesPath, "can only be set together with snapshotOnDelete"))
+	}
+
+	deviceNames := map[string]struct{}{}
+	for i, deviceName := range spec.SnapshotDeviceNames {
+		if deviceName == "" {
+			allErrs = append(allErrs, field.Required(deviceNamesPath.Index(i), "device name must not be empty"))
+			continue
+		}
+		if _, ok := deviceNames[deviceName]; ok {
+			allErrs = append(allErrs, field.Duplicate(deviceNamesPath.Index(i), deviceName))
 		}
+		deviceNames[deviceName] = struct{}{}
+	}
+
+	return allErrs
+}
+
+// validateHibernationOptions validates that the root volume of the machine spec at fldPath can store
+// the RAM of a hibernated instance. AWS requires the root volume to be an encrypted EBS volume, and its
+// size to be set explicitly since the default size of the AMI is rarely large enough.
+func validateHibernationOptions(spec *AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.HibernationOptions == nil || !spec.HibernationOptions.Configured {
+		return allErrs
+	}
+
+	rootVolumePath := fldPath.Child("rootVolume")
+	if spec.RootVolume == nil {
+		allErrs = append(allErrs, field.Required(rootVolumePath, "root volume must be set when hibernation is configured"))
+		return allErrs
 	}
+
+	if spec.RootVolume.Encrypted == nil || !*spec.RootVolume.Encrypted {
+		allErrs = append(allErrs, field.Invalid(rootVolumePath.Child("encrypted"), spec.RootVolume.Encrypted, "root volume must be encrypted when hibernation is configured"))
+	}
+
+	if spec.RootVolume.Size == 0 {
+		allErrs = append(allErrs, field.Required(rootVolumePath.Child("size"), "root volume size must be large enough to store the RAM of the instance when hibernation is configured"))
+	}
+
 	return allErrs
 }
 
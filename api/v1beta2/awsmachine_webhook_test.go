@@ -18,6 +18,7 @@ package v1beta2
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -26,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	utildefaulting "sigs.k8s.io/cluster-api/util/defaulting"
 )
 
@@ -79,6 +81,35 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ensure root volume throughput is within gp3 limits",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Type:       "gp3",
+						Size:       100,
+						IOPS:       4000,
+						Throughput: aws.Int64(1000),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure root volume iops are within io2 limits",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Type: "io2",
+						Size: 100,
+						IOPS: 200000,
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "ensure root volume with device name works (for clusterctl move)",
 			machine: &AWSMachine{
@@ -202,6 +233,185 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "additional security groups can't repeat an id",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AdditionalSecurityGroups: []AWSResourceReference{
+						{
+							ID: aws.String("sg-1"),
+						},
+						{
+							ID: aws.String("sg-1"),
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional security groups can't exceed the per network interface limit",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AdditionalSecurityGroups: func() []AWSResourceReference {
+						refs := []AWSResourceReference{}
+						for i := 0; i <= MaxSecurityGroupsPerNetworkInterface; i++ {
+							refs = append(refs, AWSResourceReference{ID: aws.String(fmt.Sprintf("sg-%d", i))})
+						}
+						return refs
+					}(),
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional network interfaces with distinct device indexes are accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AdditionalNetworkInterfaces: []NetworkInterfaceSpec{
+						{DeviceIndex: 1, Subnet: &AWSResourceReference{ID: aws.String("subnet-1")}},
+						{DeviceIndex: 2, SecurityGroups: []AWSResourceReference{{ID: aws.String("sg-1")}}},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "additional network interfaces can't share a device index",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AdditionalNetworkInterfaces: []NetworkInterfaceSpec{
+						{DeviceIndex: 1},
+						{DeviceIndex: 1},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional network interfaces can't use the device index of the primary network interface",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AdditionalNetworkInterfaces: []NetworkInterfaceSpec{
+						{DeviceIndex: 0},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional network interfaces can't be combined with network interfaces",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NetworkInterfaces: []string{"eni-1"},
+					AdditionalNetworkInterfaces: []NetworkInterfaceSpec{
+						{DeviceIndex: 1},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional network interfaces can't be combined with a public IP",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					PublicIP: aws.Bool(true),
+					AdditionalNetworkInterfaces: []NetworkInterfaceSpec{
+						{DeviceIndex: 1},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "snapshot device names are accepted with snapshot on delete",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SnapshotOnDelete:    true,
+					SnapshotDeviceNames: []string{"/dev/sda1", "/dev/sdb"},
+					InstanceType:        "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "snapshot device names can't be set without snapshot on delete",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SnapshotDeviceNames: []string{"/dev/sda1"},
+					InstanceType:        "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "snapshot device names can't be duplicated",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SnapshotOnDelete:    true,
+					SnapshotDeviceNames: []string{"/dev/sdb", "/dev/sdb"},
+					InstanceType:        "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "hibernation is accepted with an encrypted root volume",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					HibernationOptions: &HibernationOptions{Configured: true},
+					RootVolume: &Volume{
+						Size:      64,
+						Encrypted: aws.Bool(true),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "hibernation requires a root volume",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					HibernationOptions: &HibernationOptions{Configured: true},
+					InstanceType:       "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "hibernation requires an encrypted root volume",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					HibernationOptions: &HibernationOptions{Configured: true},
+					RootVolume: &Volume{
+						Size: 64,
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unencrypted root volume is accepted when hibernation is not configured",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					HibernationOptions: &HibernationOptions{Configured: false},
+					RootVolume: &Volume{
+						Size: 64,
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid additional tags are accepted",
 			machine: &AWSMachine{
@@ -248,6 +458,113 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid instance name template is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceNameTemplate: "{{.ClusterName}}-{{.MachineDeployment}}-{{.MachineName}}",
+					InstanceType:         "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "instance name template with unknown field returns error",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceNameTemplate: "{{.Unknown}}",
+					InstanceType:         "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid bottlerocket settings are accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					Bottlerocket: &Bottlerocket{
+						BootstrapContainerSource: "example.com/bootstrap:v1",
+						KernelSysctls:            map[string]string{"net.ipv4.ip_forward": "1"},
+						RegistryMirrors: []BottlerocketRegistryMirror{
+							{Registry: "docker.io", Endpoints: []string{"https://mirror.example.com"}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "bottlerocket registry mirrors can't repeat a registry",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					Bottlerocket: &Bottlerocket{
+						BootstrapContainerSource: "example.com/bootstrap:v1",
+						RegistryMirrors: []BottlerocketRegistryMirror{
+							{Registry: "docker.io", Endpoints: []string{"https://mirror-a.example.com"}},
+							{Registry: "docker.io", Endpoints: []string{"https://mirror-b.example.com"}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bootstrap commands are accepted with cloud-init",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "test",
+					PreBootstrapCommands:  []string{"update-ca-certificates"},
+					PostBootstrapCommands: []string{"systemctl enable --now agent"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "bootstrap commands can't be set with bottlerocket",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:         "test",
+					PreBootstrapCommands: []string{"update-ca-certificates"},
+					Bottlerocket: &Bottlerocket{
+						BootstrapContainerSource: "example.com/bootstrap:v1",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "private IP address is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:     "test",
+					PrivateIPAddress: aws.String("10.0.0.10"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "private IP address must be an IPv4 address",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:     "test",
+					PrivateIPAddress: aws.String("2001:db8::10"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "private IP address can't be combined with network interfaces",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:      "test",
+					PrivateIPAddress:  aws.String("10.0.0.10"),
+					NetworkInterfaces: []string{"eni-1"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -265,6 +582,35 @@ func TestAWSMachineCreate(t *testing.T) {
 	}
 }
 
+func TestAWSMachineCreateDuplicatePrivateIPAddress(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	newMachine := func(clusterName, ip string) *AWSMachine {
+		return &AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "machine-",
+				Namespace:    "default",
+				Labels:       map[string]string{clusterv1.ClusterNameLabel: clusterName},
+			},
+			Spec: AWSMachineSpec{
+				InstanceType:     "test",
+				PrivateIPAddress: aws.String(ip),
+			},
+		}
+	}
+
+	machine := newMachine("private-ip-cluster", "10.0.0.10")
+	g.Expect(testEnv.Create(ctx, machine)).To(Succeed())
+	defer testEnv.Delete(ctx, machine)
+
+	g.Expect(testEnv.Create(ctx, newMachine("private-ip-cluster", "10.0.0.10"))).NotTo(Succeed())
+
+	other := newMachine("other-cluster", "10.0.0.10")
+	g.Expect(testEnv.Create(ctx, other)).To(Succeed())
+	defer testEnv.Delete(ctx, other)
+}
+
 func TestAWSMachineUpdate(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -329,6 +675,37 @@ func TestAWSMachineUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "change in snapshot on delete",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SnapshotOnDelete:    true,
+					SnapshotDeviceNames: []string{"/dev/sdb"},
+					InstanceType:        "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "change in cleanup policy",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CleanupPolicy: &CleanupPolicy{Volumes: CleanupActionSnapshot, NetworkInterfaces: CleanupActionRetain},
+					InstanceType:  "test",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "change in tags adding invalid ones",
 			oldMachine: &AWSMachine{
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -33,6 +34,9 @@ import (
 )
 
 func (r *AWSMachineTemplateWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&AWSMachineTemplate{}).
 		WithValidator(r).
@@ -42,7 +46,14 @@ func (r *AWSMachineTemplateWebhook) SetupWebhookWithManager(mgr ctrl.Manager) er
 // AWSMachineTemplateWebhook implements a custom validation webhook for AWSMachineTemplate.
 // Note: we use a custom validator to access the request context for SSA of AWSMachineTemplate.
 // +kubebuilder:object:generate=false
-type AWSMachineTemplateWebhook struct{}
+type AWSMachineTemplateWebhook struct {
+	// Client reads the clusters of the machine templates. It defaults to the client of the manager.
+	Client client.Reader
+
+	// EnforceVolumeEncryption rejects the machine templates of every cluster with volumes that aren't
+	// explicitly encrypted. It is set from the --enforce-volume-encryption flag.
+	EnforceVolumeEncryption bool
+}
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awsmachinetemplate,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsmachinetemplates,versions=v1beta2,name=validation.awsmachinetemplate.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
@@ -69,6 +80,8 @@ func (r *AWSMachineTemplate) validateRootVolume() field.ErrorList {
 		}
 	}
 
+	allErrs = append(allErrs, spec.RootVolume.ValidatePerformance(field.NewPath("spec", "template", "spec", "rootVolume"))...)
+
 	if spec.RootVolume.DeviceName != "" {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
@@ -81,7 +94,7 @@ func (r *AWSMachineTemplate) validateNonRootVolumes() field.ErrorList {
 
 	spec := r.Spec.Template.Spec
 
-	for _, volume := range spec.NonRootVolumes {
+	for i, volume := range spec.NonRootVolumes {
 		if VolumeTypesProvisioned.Has(string(volume.Type)) && volume.IOPS == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
 		}
@@ -98,22 +111,15 @@ func (r *AWSMachineTemplate) validateNonRootVolumes() field.ErrorList {
 		if volume.DeviceName == "" {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.deviceName"), "non root volume should have device name"))
 		}
+
+		allErrs = append(allErrs, volume.ValidatePerformance(field.NewPath("spec", "template", "spec", "nonRootVolumes").Index(i))...)
 	}
 
 	return allErrs
 }
 
 func (r *AWSMachineTemplate) validateAdditionalSecurityGroups() field.ErrorList {
-	var allErrs field.ErrorList
-
-	spec := r.Spec.Template.Spec
-
-	for _, additionalSecurityGroup := range spec.AdditionalSecurityGroups {
-		if len(additionalSecurityGroup.Filters) > 0 && additionalSecurityGroup.ID != nil {
-			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "additionalSecurityGroups"), "only one of ID or Filters may be specified, specifying both is forbidden"))
-		}
-	}
-	return allErrs
+	return validateAdditionalSecurityGroups(r.Spec.Template.Spec.AdditionalSecurityGroups, field.NewPath("spec", "template", "spec", "additionalSecurityGroups"))
 }
 
 func (r *AWSMachineTemplate) validateCloudInitSecret() field.ErrorList {
@@ -169,6 +175,14 @@ func (r *AWSMachineTemplate) validateIgnitionAndCloudInit() field.ErrorList {
 			"cannot be set if spec.template.spec.ignition is set"))
 	}
 
+	if r.ignitionEnabled() && r.Spec.Template.Spec.Bottlerocket != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "bottlerocket"),
+			"cannot be set if spec.template.spec.ignition is set"))
+	}
+
+	spec := &r.Spec.Template.Spec
+	allErrs = append(allErrs, ValidateBootstrapCommands(spec.PreBootstrapCommands, spec.PostBootstrapCommands, spec.Ignition, spec.Bottlerocket, field.NewPath("spec", "template", "spec"))...)
+
 	return allErrs
 }
 func (r *AWSMachineTemplate) validateSSHKeyName() field.ErrorList {
@@ -176,7 +190,7 @@ func (r *AWSMachineTemplate) validateSSHKeyName() field.ErrorList {
 }
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
-func (r *AWSMachineTemplateWebhook) ValidateCreate(_ context.Context, raw runtime.Object) error {
+func (r *AWSMachineTemplateWebhook) ValidateCreate(ctx context.Context, raw runtime.Object) error {
 	var allErrs field.ErrorList
 	obj, ok := raw.(*AWSMachineTemplate)
 	if !ok {
@@ -197,13 +211,27 @@ func (r *AWSMachineTemplateWebhook) ValidateCreate(_ context.Context, raw runtim
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "providerID"), "cannot be set in templates"))
 	}
 
+	if spec.PrivateIPAddress != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "privateIPAddress"), "cannot be set in templates, the machines would all get the same address"))
+	}
+
 	allErrs = append(allErrs, obj.validateCloudInitSecret()...)
 	allErrs = append(allErrs, obj.validateIgnitionAndCloudInit()...)
 	allErrs = append(allErrs, obj.validateRootVolume()...)
 	allErrs = append(allErrs, obj.validateNonRootVolumes()...)
 	allErrs = append(allErrs, obj.validateSSHKeyName()...)
 	allErrs = append(allErrs, obj.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, validateAdditionalNetworkInterfaces(&spec, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, validateSnapshotOnDelete(&spec, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, validateHibernationOptions(&spec, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, spec.Bottlerocket.Validate(field.NewPath("spec", "template", "spec", "bottlerocket"))...)
 	allErrs = append(allErrs, obj.Spec.Template.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, validateInstanceNameTemplate(spec.InstanceNameTemplate, field.NewPath("spec", "template", "spec", "instanceNameTemplate"))...)
+	allErrs = append(allErrs, validateOutpostARN(spec.OutpostARN, field.NewPath("spec", "template", "spec", "outpostArn"))...)
+	allErrs = append(allErrs, validateMachineSecurityProfile(ctx, r.Client, obj, &spec, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, validateMachineVolumeEncryption(ctx, r.Client, r.EnforceVolumeEncryption, obj, &spec, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, spec.CreditSpecification.Validate(spec.InstanceType, field.NewPath("spec", "template", "spec", "creditSpecification"))...)
+	allErrs = append(allErrs, spec.AMI.ValidateAccelerator(spec.InstanceType, field.NewPath("spec", "template", "spec", "ami"))...)
 
 	return aggregateObjErrors(obj.GroupVersionKind().GroupKind(), obj.Name, allErrs)
 }
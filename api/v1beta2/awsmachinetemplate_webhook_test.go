@@ -45,6 +45,20 @@ func TestAWSMachineTemplateValidateCreate(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			name: "don't allow privateIPAddress",
+			inputTemplate: &AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: AWSMachineTemplateSpec{
+					Template: AWSMachineTemplateResource{
+						Spec: AWSMachineSpec{
+							PrivateIPAddress: pointer.String("10.0.0.10"),
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
 		{
 			name: "don't allow secretARN",
 			inputTemplate: &AWSMachineTemplate{
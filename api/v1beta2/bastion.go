@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -39,6 +40,13 @@ func (b *Bastion) Validate() []*field.Error {
 		return errs
 	}
 
+	if b.DisableIngressRules && len(b.AllowedPrefixListIDs) > 0 {
+		errs = append(errs,
+			field.Forbidden(field.NewPath("spec", "bastion", "allowedPrefixListIDs"), "cannot be set if spec.bastion.disableIngressRules is true"),
+		)
+		return errs
+	}
+
 	for i, cidr := range b.AllowedCIDRBlocks {
 		if _, _, err := net.ParseCIDR(cidr); err != nil {
 			errs = append(errs,
@@ -46,6 +54,72 @@ func (b *Bastion) Validate() []*field.Error {
 			)
 		}
 	}
+
+	for i, id := range b.AllowedPrefixListIDs {
+		if !strings.HasPrefix(id, "pl-") {
+			errs = append(errs,
+				field.Invalid(field.NewPath("spec", "bastion", fmt.Sprintf("allowedPrefixListIDs[%d]", i)), id, "must be a valid managed prefix list ID"),
+			)
+		}
+	}
+
+	errs = append(errs, b.ElasticIPPool.Validate(field.NewPath("spec", "bastion", "elasticIPPool"))...)
+
+	if b.AMI != "" && b.AMILookup != nil {
+		errs = append(errs,
+			field.Forbidden(field.NewPath("spec", "bastion", "amiLookup"), "cannot be set if spec.bastion.ami is set"),
+		)
+	}
+
+	if b.RootVolume != nil {
+		rootVolumePath := field.NewPath("spec", "bastion", "rootVolume")
+		if VolumeTypesProvisioned.Has(string(b.RootVolume.Type)) && b.RootVolume.IOPS == 0 {
+			errs = append(errs, field.Required(rootVolumePath.Child("iops"), "iops required if type is 'io1' or 'io2'"))
+		}
+		if b.RootVolume.Throughput != nil && b.RootVolume.Type != VolumeTypeGP3 {
+			errs = append(errs, field.Invalid(rootVolumePath.Child("throughput"), *b.RootVolume.Throughput, "throughput is valid only for type 'gp3'"))
+		}
+		errs = append(errs, b.RootVolume.ValidatePerformance(rootVolumePath)...)
+	}
+
+	errs = append(errs, validateAdditionalSecurityGroups(b.AdditionalSecurityGroups, field.NewPath("spec", "bastion", "additionalSecurityGroups"))...)
+
+	if b.IsInstanceConnectEndpoint() {
+		errs = append(errs, b.validateInstanceConnectEndpoint()...)
+	}
+	return errs
+}
+
+// IsInstanceConnectEndpoint returns true if the bastion is an EC2 Instance Connect Endpoint
+// rather than a bastion host instance.
+func (b *Bastion) IsInstanceConnectEndpoint() bool {
+	return b.Type == BastionTypeInstanceConnectEndpoint
+}
+
+// validateInstanceConnectEndpoint rejects the settings of the bastion host instance, which have
+// no effect on an EC2 Instance Connect Endpoint.
+func (b *Bastion) validateInstanceConnectEndpoint() field.ErrorList {
+	var errs field.ErrorList
+
+	instanceFields := []struct {
+		name string
+		set  bool
+	}{
+		{"instanceType", b.InstanceType != ""},
+		{"ami", b.AMI != ""},
+		{"amiLookup", b.AMILookup != nil},
+		{"iamInstanceProfile", b.IAMInstanceProfile != ""},
+		{"elasticIPPool", b.ElasticIPPool != nil},
+		{"rootVolume", b.RootVolume != nil},
+		{"userData", b.UserData != ""},
+	}
+	for _, f := range instanceFields {
+		if f.set {
+			errs = append(errs,
+				field.Forbidden(field.NewPath("spec", "bastion", f.name), fmt.Sprintf("cannot be set if spec.bastion.type is %q", BastionTypeInstanceConnectEndpoint)),
+			)
+		}
+	}
 	return errs
 }
 
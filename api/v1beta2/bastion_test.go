@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	. "github.com/onsi/gomega"
+)
+
+func TestBastionValidateCustomization(t *testing.T) {
+	tests := []struct {
+		name    string
+		bastion Bastion
+		wantErr bool
+	}{
+		{
+			name: "AMI lookup, root volume, additional security groups and user data",
+			bastion: Bastion{
+				AMILookup:                &BastionAMILookup{Name: "hardened-*", Owners: []string{"self"}},
+				RootVolume:               &Volume{Size: 20, Type: VolumeTypeGP3, Throughput: aws.Int64(250)},
+				AdditionalSecurityGroups: []AWSResourceReference{{ID: aws.String("sg-1")}},
+				UserData:                 "#!/bin/bash",
+			},
+		},
+		{
+			name:    "AMI and AMI lookup",
+			bastion: Bastion{AMI: "ami-1", AMILookup: &BastionAMILookup{Name: "hardened-*", Owners: []string{"self"}}},
+			wantErr: true,
+		},
+		{
+			name:    "provisioned root volume without iops",
+			bastion: Bastion{RootVolume: &Volume{Size: 20, Type: VolumeTypeIO2}},
+			wantErr: true,
+		},
+		{
+			name:    "throughput of a gp2 root volume",
+			bastion: Bastion{RootVolume: &Volume{Size: 20, Type: VolumeTypeGP2, Throughput: aws.Int64(250)}},
+			wantErr: true,
+		},
+		{
+			name: "additional security group with an ID and filters",
+			bastion: Bastion{AdditionalSecurityGroups: []AWSResourceReference{{
+				ID:      aws.String("sg-1"),
+				Filters: []Filter{{Name: "tag:role", Values: []string{"audit"}}},
+			}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.bastion.Validate()
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestBastionValidateInstanceConnectEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		bastion Bastion
+		wantErr bool
+	}{
+		{
+			name: "endpoint with placement and additional security groups",
+			bastion: Bastion{
+				Enabled:                  true,
+				Type:                     BastionTypeInstanceConnectEndpoint,
+				Placement:                &SubnetPlacement{AvailabilityZones: []string{"us-east-1a"}},
+				AdditionalSecurityGroups: []AWSResourceReference{{ID: aws.String("sg-1")}},
+			},
+		},
+		{
+			name: "endpoint with defaulted CIDR blocks",
+			bastion: Bastion{
+				Enabled:           true,
+				Type:              BastionTypeInstanceConnectEndpoint,
+				AllowedCIDRBlocks: []string{"0.0.0.0/0"},
+			},
+		},
+		{
+			name:    "endpoint with an instance type",
+			bastion: Bastion{Enabled: true, Type: BastionTypeInstanceConnectEndpoint, InstanceType: "t3.micro"},
+			wantErr: true,
+		},
+		{
+			name:    "endpoint with user data",
+			bastion: Bastion{Enabled: true, Type: BastionTypeInstanceConnectEndpoint, UserData: "#!/bin/bash"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.bastion.Validate()
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestSetDefaultsBastionInstanceConnectEndpoint(t *testing.T) {
+	g := NewWithT(t)
+
+	bastion := &Bastion{Enabled: true, Type: BastionTypeInstanceConnectEndpoint}
+	SetDefaults_Bastion(bastion)
+	g.Expect(bastion.AllowedCIDRBlocks).To(BeEmpty())
+
+	bastion = &Bastion{Enabled: true}
+	SetDefaults_Bastion(bastion)
+	g.Expect(bastion.AllowedCIDRBlocks).To(Equal([]string{"0.0.0.0/0"}))
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate validates the Bottlerocket settings, which are rendered as TOML
+// tables keyed by sysctl name and registry.
+func (b *Bottlerocket) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if b == nil {
+		return allErrs
+	}
+
+	if _, ok := b.KernelSysctls[""]; ok {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("kernelSysctls"), "", "sysctl names must not be empty"))
+	}
+
+	registries := map[string]struct{}{}
+	for i, mirror := range b.RegistryMirrors {
+		if _, ok := registries[mirror.Registry]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("registryMirrors").Index(i).Child("registry"), mirror.Registry))
+		}
+		registries[mirror.Registry] = struct{}{}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+// CleanupAction defines what happens to a resource attached to an instance when its AWSMachine is deleted.
+type CleanupAction string
+
+var (
+	// CleanupActionDelete deletes the resource with the instance.
+	CleanupActionDelete = CleanupAction("Delete")
+
+	// CleanupActionRetain detaches the resource from the instance and keeps it in the AWS account.
+	CleanupActionRetain = CleanupAction("Retain")
+
+	// CleanupActionSnapshot creates an EBS snapshot of the volume before deleting it with the instance.
+	CleanupActionSnapshot = CleanupAction("Snapshot")
+)
+
+// CleanupPolicy defines what happens to the non root EBS volumes and the secondary network interfaces of
+// an instance when its AWSMachine is deleted, including those which weren't created by CAPA, e.g. because
+// they're part of the AMI or were attached later. The policy is read at deletion time.
+type CleanupPolicy struct {
+	// Volumes defines what happens to the non root EBS volumes attached to the instance. When unset, the
+	// volumes are deleted or retained according to their DeleteOnTermination attribute and the
+	// resourceRetentionPolicy of the AWSCluster.
+	// +kubebuilder:validation:Enum=Delete;Retain;Snapshot
+	// +optional
+	Volumes CleanupAction `json:"volumes,omitempty"`
+
+	// NetworkInterfaces defines what happens to the network interfaces attached to the instance, besides
+	// its primary network interface. When unset, the network interfaces are deleted or retained according
+	// to their DeleteOnTermination attribute.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	NetworkInterfaces CleanupAction `json:"networkInterfaces,omitempty"`
+}
+
+// VolumesAction returns the cleanup action of the non root volumes, or an empty action if it isn't set.
+func (p *CleanupPolicy) VolumesAction() CleanupAction {
+	if p == nil {
+		return ""
+	}
+	return p.Volumes
+}
+
+// NetworkInterfacesAction returns the cleanup action of the secondary network interfaces, or an empty
+// action if it isn't set.
+func (p *CleanupPolicy) NetworkInterfacesAction() CleanupAction {
+	if p == nil {
+		return ""
+	}
+	return p.NetworkInterfaces
+}
@@ -34,6 +34,25 @@ const (
 	// SourcePrincipalUsageUnauthorizedReason used when AWSCluster is not in the intersection of source identity allowed namespaces
 	// and allowed namespaces of the identities that source identity depends to.
 	SourcePrincipalUsageUnauthorizedReason = "SourcePrincipalUsageUnauthorized"
+	// PrincipalIdentityRefRequiredReason used when AWSCluster doesn't reference an identity while the principal allow-list is enforced.
+	PrincipalIdentityRefRequiredReason = "PrincipalIdentityRefRequired"
+	// PrincipalNotFoundReason used when the identity referenced by the AWSCluster, or one of its source identities, doesn't exist.
+	PrincipalNotFoundReason = "PrincipalNotFound"
+)
+
+const (
+	// DeletionCompletedReason used when all the resources of a component of a cluster being deleted were deleted,
+	// so that their deletion is skipped when the deletion of the cluster is retried.
+	DeletionCompletedReason = "DeletionCompleted"
+
+	// DeletionBlockedCondition reports that the deletion of the AWS resources of a cluster being deleted is
+	// blocked by its deletion protection.
+	DeletionBlockedCondition clusterv1.ConditionType = "DeletionBlocked"
+)
+
+const (
+	// QuotaExceededReason used when a resource can't be created because an AWS service quota has been reached.
+	QuotaExceededReason = "QuotaExceeded"
 )
 
 const (
@@ -44,6 +63,10 @@ const (
 	VpcCreationStartedReason = "VpcCreationStarted"
 	// VpcReconciliationFailedReason used when errors occur during VPC reconciliation.
 	VpcReconciliationFailedReason = "VpcReconciliationFailed"
+	// DHCPOptionsReconciliationFailedReason used when errors occur during reconciliation of the DHCP options set of the VPC.
+	DHCPOptionsReconciliationFailedReason = "DHCPOptionsReconciliationFailed"
+	// FlowLogsReconciliationFailedReason used when errors occur during reconciliation of the flow logs of the VPC.
+	FlowLogsReconciliationFailedReason = "FlowLogsReconciliationFailed"
 )
 
 const (
@@ -77,6 +100,10 @@ const (
 	NatGatewaysCreationStartedReason = "NatGatewaysCreationStarted"
 	// NatGatewaysReconciliationFailedReason used when any errors occur during reconciliation of NAT gateways.
 	NatGatewaysReconciliationFailedReason = "NatGatewaysReconciliationFailed"
+	// NatInstancesPendingReason used while NAT instances are starting, before they can forward traffic.
+	NatInstancesPendingReason = "NatInstancesPending"
+	// NatInstancesStoppedReason used when NAT instances are stopped and don't forward traffic.
+	NatInstancesStoppedReason = "NatInstancesStopped"
 )
 
 const (
@@ -95,6 +122,16 @@ const (
 	SecondaryCidrReconciliationFailedReason = "SecondaryCidrReconciliationFailed"
 )
 
+const (
+	// VpcEndpointsReadyCondition reports whether the VPC endpoints of the VPC are available.
+	// Only applicable to managed clusters.
+	VpcEndpointsReadyCondition clusterv1.ConditionType = "VpcEndpointsReady"
+	// VpcEndpointsNotAvailableReason used when some VPC endpoints of the VPC are not available, e.g. pending or failed.
+	VpcEndpointsNotAvailableReason = "VpcEndpointsNotAvailable"
+	// VpcEndpointsReconciliationFailedReason used when the VPC endpoints of the VPC could not be described.
+	VpcEndpointsReconciliationFailedReason = "VpcEndpointsReconciliationFailed"
+)
+
 const (
 	// ClusterSecurityGroupsReadyCondition reports successful reconciliation of security groups.
 	ClusterSecurityGroupsReadyCondition clusterv1.ConditionType = "ClusterSecurityGroupsReady"
@@ -123,6 +160,21 @@ const (
 	LoadBalancerFailedReason = "LoadBalancerFailed"
 )
 
+const (
+	// LoadBalancerSchemeReadyCondition reports on the replacement of the control plane load balancer after
+	// its scheme changed. It is true once the control plane endpoint resolves to a load balancer of the new scheme
+	// and the previous load balancer was deleted.
+	LoadBalancerSchemeReadyCondition clusterv1.ConditionType = "LoadBalancerSchemeReady"
+	// WaitForReplacementTargetsReason used while waiting for the control plane instances to be healthy
+	// in the replacement load balancer.
+	WaitForReplacementTargetsReason = "WaitForReplacementTargets"
+	// WaitForEndpointPropagationReason used while the previous load balancer is kept until clients stopped
+	// resolving the control plane endpoint to it.
+	WaitForEndpointPropagationReason = "WaitForEndpointPropagation"
+	// LoadBalancerReplacementFailedReason used when an error occurs while replacing the load balancer.
+	LoadBalancerReplacementFailedReason = "LoadBalancerReplacementFailed"
+)
+
 const (
 	// InstanceReadyCondition reports on current status of the EC2 instance. Ready indicates the instance is in a Running state.
 	InstanceReadyCondition clusterv1.ConditionType = "InstanceReady"
@@ -164,6 +216,9 @@ const (
 	ELBAttachFailedReason = "ELBAttachFailed"
 	// ELBDetachFailedReason used when a control plane node fails to detach from an ELB.
 	ELBDetachFailedReason = "ELBDetachFailed"
+	// ELBConnectionDrainingReason used while a deregistered control plane node waits for in-flight
+	// connections to drain before it is terminated.
+	ELBConnectionDrainingReason = "ConnectionDraining"
 )
 
 const (
@@ -173,3 +228,26 @@ const (
 	// S3BucketFailedReason is used when any errors occur during reconciliation of an S3 bucket.
 	S3BucketFailedReason = "S3BucketCreationFailed"
 )
+
+const (
+	// AlarmsReadyCondition reports on the CloudWatch alarms of the infrastructure of a cluster with monitoring enabled.
+	AlarmsReadyCondition clusterv1.ConditionType = "AlarmsReady"
+
+	// AlarmsReconciliationFailedReason is used when any errors occur during reconciliation of the CloudWatch alarms.
+	AlarmsReconciliationFailedReason = "AlarmsReconciliationFailed"
+)
+
+const (
+	// IAMRoleNotFoundReason used when an IAM role doesn't exist and can't be created.
+	IAMRoleNotFoundReason = "IAMRoleNotFound"
+	// IAMAccessDeniedReason used when the IAM API denies access to the controller.
+	IAMAccessDeniedReason = "IAMAccessDenied"
+	// IAMLimitExceededReason used when an IAM quota, such as the number of roles of the account, has been reached.
+	IAMLimitExceededReason = "IAMLimitExceeded"
+	// IAMMalformedPolicyDocumentReason used when the IAM API rejects a trust relationship or policy document.
+	IAMMalformedPolicyDocumentReason = "IAMMalformedPolicyDocument"
+	// IAMAdditionalPoliciesNotAllowedReason used when additional policies are set but not allowed by the controller.
+	IAMAdditionalPoliciesNotAllowedReason = "IAMAdditionalPoliciesNotAllowed"
+	// IAMRoleReconciliationFailedReason used for any other failure while reconciling an IAM role.
+	IAMRoleReconciliationFailedReason = "IAMRoleReconciliationFailed"
+)
@@ -52,6 +52,12 @@ func (*AWSClusterControllerIdentity) Hub() {}
 // Hub marks AWSClusterControllerIdentityList as a conversion hub.
 func (*AWSClusterControllerIdentityList) Hub() {}
 
+// Hub marks AWSClusterWebIdentity as a conversion hub.
+func (*AWSClusterWebIdentity) Hub() {}
+
+// Hub marks AWSClusterWebIdentityList as a conversion hub.
+func (*AWSClusterWebIdentityList) Hub() {}
+
 // Hub marks AWSClusterTemplate as a conversion hub.
 func (*AWSClusterTemplate) Hub() {}
 
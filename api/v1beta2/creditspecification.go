@@ -0,0 +1,42 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// burstableInstanceFamilies are the instance families supporting a credit specification.
+var burstableInstanceFamilies = []string{"t2", "t3", "t3a", "t4g"}
+
+// Validate rejects a credit specification set for an instance type that isn't a burstable
+// performance instance type. Instance types left empty aren't checked.
+func (c *CreditSpecification) Validate(instanceType string, fldPath *field.Path) field.ErrorList {
+	if c == nil || instanceType == "" {
+		return nil
+	}
+	family, _, _ := strings.Cut(instanceType, ".")
+	for _, burstable := range burstableInstanceFamilies {
+		if family == burstable {
+			return nil
+		}
+	}
+	return field.ErrorList{field.Forbidden(fldPath, fmt.Sprintf("can only be set for burstable performance instance types %v, not %q", burstableInstanceFamilies, instanceType))}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestCreditSpecificationValidate(t *testing.T) {
+	tests := []struct {
+		name                string
+		creditSpecification *CreditSpecification
+		instanceType        string
+		wantErr             bool
+	}{
+		{
+			name:         "no credit specification",
+			instanceType: "m5.large",
+		},
+		{
+			name:                "burstable instance type",
+			creditSpecification: &CreditSpecification{CPUCredits: CPUCreditsStandard},
+			instanceType:        "t3.large",
+		},
+		{
+			name:                "graviton burstable instance type",
+			creditSpecification: &CreditSpecification{CPUCredits: CPUCreditsUnlimited},
+			instanceType:        "t4g.medium",
+		},
+		{
+			name:                "instance type not set",
+			creditSpecification: &CreditSpecification{CPUCredits: CPUCreditsStandard},
+		},
+		{
+			name:                "instance type that isn't burstable",
+			creditSpecification: &CreditSpecification{CPUCredits: CPUCreditsStandard},
+			instanceType:        "m5.large",
+			wantErr:             true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.creditSpecification.Validate(tt.instanceType, field.NewPath("spec", "creditSpecification"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -24,8 +24,9 @@ import (
 
 // SetDefaults_Bastion is used by defaulter-gen.
 func SetDefaults_Bastion(obj *Bastion) { //nolint:golint,stylecheck
-	// Default to allow open access to the bastion host if no CIDR Blocks have been set
-	if len(obj.AllowedCIDRBlocks) == 0 && !obj.DisableIngressRules {
+	// Default to allow open access to the bastion host if no CIDR Blocks or prefix lists have been set.
+	// EC2 Instance Connect Endpoints don't accept inbound connections, so they are left alone.
+	if len(obj.AllowedCIDRBlocks) == 0 && len(obj.AllowedPrefixListIDs) == 0 && !obj.DisableIngressRules && !obj.IsInstanceConnectEndpoint() {
 		obj.AllowedCIDRBlocks = []string{"0.0.0.0/0"}
 	}
 }
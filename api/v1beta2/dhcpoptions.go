@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// AmazonProvidedDNS selects the DNS server provided by AWS in DHCPOptions.DomainNameServers.
+	AmazonProvidedDNS = "AmazonProvidedDNS"
+
+	// DHCPOptionsDomainNameKey is the key of the domain name in a DHCP options set.
+	DHCPOptionsDomainNameKey = "domain-name"
+	// DHCPOptionsDomainNameServersKey is the key of the domain name servers in a DHCP options set.
+	DHCPOptionsDomainNameServersKey = "domain-name-servers"
+	// DHCPOptionsNTPServersKey is the key of the NTP servers in a DHCP options set.
+	DHCPOptionsNTPServersKey = "ntp-servers"
+)
+
+// Validate validates that at least one option is set and that servers are IPv4 addresses.
+func (o *DHCPOptions) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if o == nil {
+		return allErrs
+	}
+
+	if (o.DomainName == nil || *o.DomainName == "") && len(o.DomainNameServers) == 0 && len(o.NTPServers) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one of domainName, domainNameServers and ntpServers must be set"))
+	}
+
+	for i, server := range o.DomainNameServers {
+		if server == AmazonProvidedDNS {
+			continue
+		}
+		if ip := net.ParseIP(server); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("domainNameServers").Index(i), server, "must be an IPv4 address or "+AmazonProvidedDNS))
+		}
+	}
+
+	for i, server := range o.NTPServers {
+		if ip := net.ParseIP(server); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ntpServers").Index(i), server, "must be an IPv4 address"))
+		}
+	}
+
+	return allErrs
+}
+
+// Configurations returns the values of the options by key, in the format of DHCP options sets.
+func (o *DHCPOptions) Configurations() map[string][]string {
+	configurations := map[string][]string{}
+	if o.DomainName != nil && *o.DomainName != "" {
+		configurations[DHCPOptionsDomainNameKey] = []string{*o.DomainName}
+	}
+	if len(o.DomainNameServers) > 0 {
+		configurations[DHCPOptionsDomainNameServersKey] = o.DomainNameServers
+	}
+	if len(o.NTPServers) > 0 {
+		configurations[DHCPOptionsNTPServersKey] = o.NTPServers
+	}
+	return configurations
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestDHCPOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *DHCPOptions
+		wantErr bool
+	}{
+		{
+			name: "nil options",
+		},
+		{
+			name: "all options",
+			options: &DHCPOptions{
+				DomainName:        aws.String("corp.example.com"),
+				DomainNameServers: []string{"10.0.0.10", "10.0.0.11", AmazonProvidedDNS},
+				NTPServers:        []string{"169.254.169.123"},
+			},
+		},
+		{
+			name:    "no options",
+			options: &DHCPOptions{DomainName: aws.String("")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid domain name server",
+			options: &DHCPOptions{DomainNameServers: []string{"dc1.corp.example.com"}},
+			wantErr: true,
+		},
+		{
+			name:    "IPv6 NTP server",
+			options: &DHCPOptions{NTPServers: []string{"fd00:ec2::123"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.options.Validate(field.NewPath("spec", "network", "vpc", "dhcpOptions"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestDHCPOptionsConfigurations(t *testing.T) {
+	g := NewWithT(t)
+
+	options := &DHCPOptions{
+		DomainName:        aws.String("corp.example.com"),
+		DomainNameServers: []string{"10.0.0.10", "10.0.0.11"},
+	}
+	g.Expect(options.Configurations()).To(Equal(map[string][]string{
+		DHCPOptionsDomainNameKey:        {"corp.example.com"},
+		DHCPOptionsDomainNameServersKey: {"10.0.0.10", "10.0.0.11"},
+	}))
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate validates that the pool selects Elastic IPs either by allocation ID or by tags.
+func (p *ElasticIPPool) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if p == nil {
+		return allErrs
+	}
+
+	switch {
+	case len(p.AllocationIDs) == 0 && len(p.Tags) == 0:
+		allErrs = append(allErrs, field.Required(fldPath, "either allocationIDs or tags must be set"))
+	case len(p.AllocationIDs) > 0 && len(p.Tags) > 0:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("tags"), "cannot be set together with allocationIDs"))
+	}
+
+	ids := map[string]struct{}{}
+	for i, id := range p.AllocationIDs {
+		if !strings.HasPrefix(id, "eipalloc-") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allocationIDs").Index(i), id, "must be a valid Elastic IP allocation ID"))
+		}
+		if _, ok := ids[id]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("allocationIDs").Index(i), id))
+		}
+		ids[id] = struct{}{}
+	}
+
+	if _, ok := p.Tags[""]; ok {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tags"), "", "tag keys must not be empty"))
+	}
+
+	return allErrs
+}
+
+// SortAllocationIDs sorts allocation IDs selected by the pool in the order they are used:
+// the order of AllocationIDs if they are listed, the lexical order otherwise.
+func (p *ElasticIPPool) SortAllocationIDs(ids []string) {
+	position := make(map[string]int, len(p.AllocationIDs))
+	for i, id := range p.AllocationIDs {
+		position[id] = i
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		pi, iok := position[ids[i]]
+		pj, jok := position[ids[j]]
+		if iok && jok {
+			return pi < pj
+		}
+		if iok != jok {
+			return iok
+		}
+		return ids[i] < ids[j]
+	})
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestElasticIPPoolValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    *ElasticIPPool
+		wantErr bool
+	}{
+		{
+			name: "nil pool",
+		},
+		{
+			name: "allocation IDs",
+			pool: &ElasticIPPool{AllocationIDs: []string{"eipalloc-0123456789abcdef0", "eipalloc-0123456789abcdef1"}},
+		},
+		{
+			name: "tags",
+			pool: &ElasticIPPool{Tags: Tags{"egress": "partners"}},
+		},
+		{
+			name:    "empty pool",
+			pool:    &ElasticIPPool{},
+			wantErr: true,
+		},
+		{
+			name:    "allocation IDs and tags",
+			pool:    &ElasticIPPool{AllocationIDs: []string{"eipalloc-0123456789abcdef0"}, Tags: Tags{"egress": "partners"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid allocation ID",
+			pool:    &ElasticIPPool{AllocationIDs: []string{"203.0.113.10"}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate allocation ID",
+			pool:    &ElasticIPPool{AllocationIDs: []string{"eipalloc-0123456789abcdef0", "eipalloc-0123456789abcdef0"}},
+			wantErr: true,
+		},
+		{
+			name:    "empty tag key",
+			pool:    &ElasticIPPool{Tags: Tags{"": "partners"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.pool.Validate(field.NewPath("spec", "bastion", "elasticIPPool"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
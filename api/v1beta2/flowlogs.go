@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// flowLogsDestinationServices are the services of the ARNs of the destinations of flow logs by destination type.
+var flowLogsDestinationServices = map[FlowLogsDestinationType]string{
+	FlowLogsDestinationTypeS3:             "s3",
+	FlowLogsDestinationTypeCloudWatchLogs: "logs",
+}
+
+// Validate ensures that the destination of the flow logs matches its type, and that the IAM role
+// publishing to CloudWatch Logs is only set for that destination type.
+func (f *VPCFlowLogs) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if f == nil {
+		return allErrs
+	}
+
+	if destination, err := arn.Parse(f.DestinationARN); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("destinationARN"), f.DestinationARN, "must be an ARN"))
+	} else if service, ok := flowLogsDestinationServices[f.DestinationType]; ok && destination.Service != service {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("destinationARN"), f.DestinationARN,
+			"must be the ARN of a "+service+" resource for destination type "+string(f.DestinationType)))
+	}
+
+	switch f.DestinationType {
+	case FlowLogsDestinationTypeCloudWatchLogs:
+		if f.DeliverLogsPermissionARN == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("deliverLogsPermissionARN"), "required for destination type "+string(f.DestinationType)))
+		} else if !arn.IsARN(*f.DeliverLogsPermissionARN) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("deliverLogsPermissionARN"), *f.DeliverLogsPermissionARN, "must be an ARN"))
+		}
+	case FlowLogsDestinationTypeS3:
+		if f.DeliverLogsPermissionARN != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("deliverLogsPermissionARN"), "not supported for destination type "+string(f.DestinationType)))
+		}
+	}
+
+	if f.LogFormat != nil && *f.LogFormat == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("logFormat"), *f.LogFormat, "must not be empty"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestVPCFlowLogsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		flowLogs *VPCFlowLogs
+		wantErr  bool
+	}{
+		{
+			name: "nil flow logs",
+		},
+		{
+			name: "s3 destination",
+			flowLogs: &VPCFlowLogs{
+				DestinationType: FlowLogsDestinationTypeS3,
+				DestinationARN:  "arn:aws:s3:::flow-logs/cluster",
+				LogFormat:       aws.String("${srcaddr} ${dstaddr} ${action}"),
+			},
+		},
+		{
+			name: "cloud-watch-logs destination",
+			flowLogs: &VPCFlowLogs{
+				DestinationType:          FlowLogsDestinationTypeCloudWatchLogs,
+				DestinationARN:           "arn:aws:logs:us-east-1:123456789012:log-group:flow-logs",
+				DeliverLogsPermissionARN: aws.String("arn:aws:iam::123456789012:role/flow-logs"),
+			},
+		},
+		{
+			name: "destination is not an ARN",
+			flowLogs: &VPCFlowLogs{
+				DestinationType: FlowLogsDestinationTypeS3,
+				DestinationARN:  "flow-logs",
+			},
+			wantErr: true,
+		},
+		{
+			name: "destination does not match the destination type",
+			flowLogs: &VPCFlowLogs{
+				DestinationType:          FlowLogsDestinationTypeCloudWatchLogs,
+				DestinationARN:           "arn:aws:s3:::flow-logs",
+				DeliverLogsPermissionARN: aws.String("arn:aws:iam::123456789012:role/flow-logs"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "cloud-watch-logs destination without role",
+			flowLogs: &VPCFlowLogs{
+				DestinationType: FlowLogsDestinationTypeCloudWatchLogs,
+				DestinationARN:  "arn:aws:logs:us-east-1:123456789012:log-group:flow-logs",
+			},
+			wantErr: true,
+		},
+		{
+			name: "s3 destination with role",
+			flowLogs: &VPCFlowLogs{
+				DestinationType:          FlowLogsDestinationTypeS3,
+				DestinationARN:           "arn:aws:s3:::flow-logs",
+				DeliverLogsPermissionARN: aws.String("arn:aws:iam::123456789012:role/flow-logs"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty log format",
+			flowLogs: &VPCFlowLogs{
+				DestinationType: FlowLogsDestinationTypeS3,
+				DestinationARN:  "arn:aws:s3:::flow-logs",
+				LogFormat:       aws.String(""),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.flowLogs.Validate(field.NewPath("spec", "network", "vpc", "flowLogs"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate validates that the rules don't combine managed prefix lists with other sources,
+// as AWS describes prefix lists as separate permissions.
+func (i IngressRules) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for index, rule := range i {
+		rulePath := fldPath.Index(index)
+		if len(rule.SourcePrefixListIDs) == 0 {
+			continue
+		}
+
+		if len(rule.CidrBlocks) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("cidrBlocks"), "cannot be set together with sourcePrefixListIds"))
+		}
+		if len(rule.IPv6CidrBlocks) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("ipv6CidrBlocks"), "cannot be set together with sourcePrefixListIds"))
+		}
+		if len(rule.SourceSecurityGroupIDs) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("sourceSecurityGroupIds"), "cannot be set together with sourcePrefixListIds"))
+		}
+
+		for j, id := range rule.SourcePrefixListIDs {
+			if !strings.HasPrefix(id, "pl-") {
+				allErrs = append(allErrs, field.Invalid(rulePath.Child("sourcePrefixListIds").Index(j), id, "must be a valid managed prefix list ID"))
+			}
+		}
+	}
+
+	return allErrs
+}
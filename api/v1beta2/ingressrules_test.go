@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestIngressRulesValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   IngressRules
+		wantErr bool
+	}{
+		{
+			name: "CIDR blocks only",
+			rules: IngressRules{
+				{Protocol: SecurityGroupProtocolTCP, FromPort: 6443, ToPort: 6443, CidrBlocks: []string{"10.0.0.0/8"}},
+			},
+		},
+		{
+			name: "prefix lists only",
+			rules: IngressRules{
+				{Protocol: SecurityGroupProtocolTCP, FromPort: 6443, ToPort: 6443, SourcePrefixListIDs: []string{"pl-12345678"}},
+			},
+		},
+		{
+			name: "prefix lists with CIDR blocks",
+			rules: IngressRules{
+				{Protocol: SecurityGroupProtocolTCP, FromPort: 6443, ToPort: 6443, SourcePrefixListIDs: []string{"pl-12345678"}, CidrBlocks: []string{"10.0.0.0/8"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "prefix lists with IPv6 CIDR blocks",
+			rules: IngressRules{
+				{Protocol: SecurityGroupProtocolTCP, FromPort: 6443, ToPort: 6443, SourcePrefixListIDs: []string{"pl-12345678"}, IPv6CidrBlocks: []string{"::/0"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "prefix lists with security groups",
+			rules: IngressRules{
+				{Protocol: SecurityGroupProtocolTCP, FromPort: 6443, ToPort: 6443, SourcePrefixListIDs: []string{"pl-12345678"}, SourceSecurityGroupIDs: []string{"sg-12345678"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid prefix list ID",
+			rules: IngressRules{
+				{Protocol: SecurityGroupProtocolTCP, FromPort: 6443, ToPort: 6443, SourcePrefixListIDs: []string{"sg-12345678"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.rules.Validate(field.NewPath("spec", "network", "additionalControlPlaneIngressRules"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
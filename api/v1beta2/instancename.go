@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// maxTagValueLength is the maximum length of an EC2 tag value.
+const maxTagValueLength = 256
+
+// InstanceNameTemplateData is the data available to an instance name template.
+// +kubebuilder:object:generate=false
+type InstanceNameTemplateData struct {
+	// ClusterName is the name of the Cluster API cluster.
+	ClusterName string
+	// Namespace is the namespace of the machine.
+	Namespace string
+	// MachineName is the name of the AWSMachine.
+	MachineName string
+	// MachineDeployment is the name of the MachineDeployment owning the machine, if any.
+	MachineDeployment string
+	// MachineSet is the name of the MachineSet owning the machine, if any.
+	MachineSet string
+	// Role is the role of the machine, either "control-plane" or "node".
+	Role string
+	// Index is the suffix Cluster API appends to the name of the Machine to make it unique,
+	// e.g. "x7k2p" for the machine "md-0-6b4f8-x7k2p". It is stable for the life of the machine.
+	Index string
+}
+
+// RenderInstanceName renders an instance name template with the given data.
+func RenderInstanceName(nameTemplate string, data InstanceNameTemplateData) (string, error) {
+	tmpl, err := template.New("instanceName").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parsing instance name template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering instance name template: %w", err)
+	}
+
+	name := buf.String()
+	switch {
+	case name == "":
+		return "", fmt.Errorf("instance name template rendered an empty name")
+	case len(name) > maxTagValueLength:
+		return "", fmt.Errorf("instance name template rendered a name longer than %d characters", maxTagValueLength)
+	}
+
+	return name, nil
+}
+
+// validateInstanceNameTemplate checks that an instance name template can be rendered.
+func validateInstanceNameTemplate(nameTemplate string, fldPath *field.Path) field.ErrorList {
+	if nameTemplate == "" {
+		return nil
+	}
+
+	_, err := RenderInstanceName(nameTemplate, InstanceNameTemplateData{
+		ClusterName:       "cluster",
+		Namespace:         "default",
+		MachineName:       "machine",
+		MachineDeployment: "machine-deployment",
+		MachineSet:        "machine-set",
+		Role:              "node",
+		Index:             "abcde",
+	})
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, nameTemplate, err.Error())}
+	}
+
+	return nil
+}
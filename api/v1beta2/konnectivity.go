@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultKonnectivityAgentPort is the default port of the konnectivity server which the konnectivity agents connect to.
+const DefaultKonnectivityAgentPort = 8132
+
+// KonnectivitySpec declares that the cluster runs the konnectivity service, which tunnels the traffic of the
+// API servers to the nodes through connections opened by the konnectivity agents to the konnectivity servers,
+// e.g. as configured by the egress selector of the API server.
+type KonnectivitySpec struct {
+	// AgentPort is the port of the konnectivity servers which the konnectivity agents connect to.
+	// It is opened to the nodes and control plane instances in the control plane security group, and
+	// forwarded to the control plane instances by an additional listener of the control plane load balancer.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=8132
+	// +optional
+	AgentPort int64 `json:"agentPort,omitempty"`
+
+	// ServerPort is the port of the konnectivity servers which the API servers connect to, e.g. 8131, when
+	// the konnectivity servers don't listen on a unix domain socket. It is opened to the control plane
+	// instances in the control plane security group.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	ServerPort int64 `json:"serverPort,omitempty"`
+
+	// AgentHealthPorts are the ports of the konnectivity agents reached from the control plane instances,
+	// e.g. 8093 and 8094 for their health and admin servers. They are opened to the control plane instances
+	// in the node security group.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	AgentHealthPorts []int64 `json:"agentHealthPorts,omitempty"`
+}
+
+// GetAgentPort returns the port of the konnectivity servers which the konnectivity agents connect to.
+func (k *KonnectivitySpec) GetAgentPort() int64 {
+	if k.AgentPort == 0 {
+		return DefaultKonnectivityAgentPort
+	}
+	return k.AgentPort
+}
+
+// WithAgentListener returns the additional listeners of the control plane load balancer, with a listener
+// forwarding the agent port of the konnectivity servers unless one of the listeners already forwards it.
+func (k *KonnectivitySpec) WithAgentListener(listeners []AdditionalListenerSpec) []AdditionalListenerSpec {
+	if k == nil {
+		return listeners
+	}
+	for _, listener := range listeners {
+		if listener.Port == k.GetAgentPort() {
+			return listeners
+		}
+	}
+	return append(append([]AdditionalListenerSpec{}, listeners...), AdditionalListenerSpec{
+		Port:     k.GetAgentPort(),
+		Protocol: ELBProtocolTCP,
+	})
+}
+
+// Validate validates the KonnectivitySpec against the control plane load balancer of the cluster.
+func (k *KonnectivitySpec) Validate(lb *AWSLoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	if k == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if lb != nil && lb.LoadBalancerType != "" && lb.LoadBalancerType != LoadBalancerTypeClassic && lb.LoadBalancerType != LoadBalancerTypeNLB {
+		errs = append(errs, field.Forbidden(fldPath, "konnectivity is only supported with control plane load balancers of type classic and nlb"))
+	}
+	if k.GetAgentPort() == DefaultAPIServerPort {
+		errs = append(errs, field.Invalid(fldPath.Child("agentPort"), k.AgentPort, "port is used by the API server"))
+	}
+	if k.ServerPort == DefaultAPIServerPort || k.ServerPort == k.GetAgentPort() {
+		errs = append(errs, field.Invalid(fldPath.Child("serverPort"), k.ServerPort, "port is used by the API server or by the konnectivity agents"))
+	}
+	ports := map[int64]struct{}{}
+	for i, port := range k.AgentHealthPorts {
+		if port < 1 || port > 65535 {
+			errs = append(errs, field.Invalid(fldPath.Child("agentHealthPorts").Index(i), port, "must be a valid port number"))
+		}
+		if _, ok := ports[port]; ok {
+			errs = append(errs, field.Duplicate(fldPath.Child("agentHealthPorts").Index(i), port))
+		}
+		ports[port] = struct{}{}
+	}
+	return errs
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestKonnectivitySpecValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		konnectivity *KonnectivitySpec
+		lb           *AWSLoadBalancerSpec
+		wantErr      bool
+	}{
+		{
+			name: "no konnectivity",
+		},
+		{
+			name:         "konnectivity with default ports",
+			konnectivity: &KonnectivitySpec{},
+			lb:           &AWSLoadBalancerSpec{LoadBalancerType: LoadBalancerTypeNLB},
+		},
+		{
+			name:         "konnectivity with server and agent health ports",
+			konnectivity: &KonnectivitySpec{AgentPort: 8132, ServerPort: 8131, AgentHealthPorts: []int64{8093, 8094}},
+		},
+		{
+			name:         "konnectivity with an application load balancer",
+			konnectivity: &KonnectivitySpec{},
+			lb:           &AWSLoadBalancerSpec{LoadBalancerType: LoadBalancerTypeALB},
+			wantErr:      true,
+		},
+		{
+			name:         "agent port of the API server",
+			konnectivity: &KonnectivitySpec{AgentPort: 6443},
+			wantErr:      true,
+		},
+		{
+			name:         "server port of the agents",
+			konnectivity: &KonnectivitySpec{ServerPort: 8132},
+			wantErr:      true,
+		},
+		{
+			name:         "duplicate agent health ports",
+			konnectivity: &KonnectivitySpec{AgentHealthPorts: []int64{8093, 8093}},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.konnectivity.Validate(tt.lb, field.NewPath("spec", "konnectivity"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestKonnectivitySpecWithAgentListener(t *testing.T) {
+	g := NewWithT(t)
+
+	var none *KonnectivitySpec
+	g.Expect(none.WithAgentListener([]AdditionalListenerSpec{{Port: 22623}})).To(Equal([]AdditionalListenerSpec{{Port: 22623}}))
+
+	konnectivity := &KonnectivitySpec{}
+	g.Expect(konnectivity.WithAgentListener([]AdditionalListenerSpec{{Port: 22623}})).To(Equal([]AdditionalListenerSpec{
+		{Port: 22623},
+		{Port: 8132, Protocol: ELBProtocolTCP},
+	}))
+	g.Expect(konnectivity.WithAgentListener([]AdditionalListenerSpec{{Port: 8132}})).To(Equal([]AdditionalListenerSpec{{Port: 8132}}))
+}
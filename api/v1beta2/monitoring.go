@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// MonitoringSpec configures the CloudWatch alarms of the infrastructure of a cluster.
+type MonitoringSpec struct {
+	// AlarmActions are the ARNs of the actions, e.g. SNS topics, executed when an alarm enters
+	// the ALARM state.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	AlarmActions []string `json:"alarmActions,omitempty"`
+
+	// OKActions are the ARNs of the actions executed when an alarm returns to the OK state.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	OKActions []string `json:"okActions,omitempty"`
+}
+
+// Validate ensures that the actions of the alarms are referenced by ARNs.
+func (m *MonitoringSpec) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if m == nil {
+		return allErrs
+	}
+
+	for i, action := range m.AlarmActions {
+		if !arn.IsARN(action) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("alarmActions").Index(i), action, "must be an ARN"))
+		}
+	}
+	for i, action := range m.OKActions {
+		if !arn.IsARN(action) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("okActions").Index(i), action, "must be an ARN"))
+		}
+	}
+	return allErrs
+}
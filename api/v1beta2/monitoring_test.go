@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestMonitoringSpecValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		monitoring *MonitoringSpec
+		wantErr    bool
+	}{
+		{
+			name: "no monitoring",
+		},
+		{
+			name:       "no actions",
+			monitoring: &MonitoringSpec{},
+		},
+		{
+			name: "actions",
+			monitoring: &MonitoringSpec{
+				AlarmActions: []string{"arn:aws:sns:us-east-1:123456789012:alarms"},
+				OKActions:    []string{"arn:aws:sns:us-east-1:123456789012:alarms"},
+			},
+		},
+		{
+			name:       "alarm action which isn't an ARN",
+			monitoring: &MonitoringSpec{AlarmActions: []string{"alarms"}},
+			wantErr:    true,
+		},
+		{
+			name:       "OK action which isn't an ARN",
+			monitoring: &MonitoringSpec{OKActions: []string{"alarms"}},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.monitoring.Validate(field.NewPath("spec", "monitoring"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -18,8 +18,13 @@ package v1beta2
 
 import (
 	"fmt"
+	"net"
 	"sort"
+	"strings"
 	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 const (
@@ -36,6 +41,123 @@ type NetworkStatus struct {
 
 	// APIServerELB is the Kubernetes api server load balancer.
 	APIServerELB LoadBalancer `json:"apiServerElb,omitempty"`
+
+	// InternetGatewayID is the id of the internet gateway attached to the managed VPC.
+	// +optional
+	InternetGatewayID *string `json:"internetGatewayId,omitempty"`
+
+	// InternetGatewayAttachmentState is the state of the attachment of the internet gateway
+	// to the managed VPC. AWS reports an attached internet gateway as available.
+	// +optional
+	InternetGatewayAttachmentState string `json:"internetGatewayAttachmentState,omitempty"`
+
+	// EgressOnlyInternetGatewayID is the id of the egress only internet gateway attached to the managed VPC.
+	// +optional
+	EgressOnlyInternetGatewayID *string `json:"egressOnlyInternetGatewayId,omitempty"`
+
+	// RouteTables lists the route tables associated with the subnets of the managed VPC.
+	// +optional
+	RouteTables []RouteTableStatus `json:"routeTables,omitempty"`
+
+	// NatGateways lists the pending and available NAT gateways of the managed VPC.
+	// +optional
+	NatGateways []NatGatewayStatus `json:"natGateways,omitempty"`
+
+	// VPCEndpoints lists the VPC endpoints of the managed VPC.
+	// +optional
+	VPCEndpoints []VPCEndpointStatus `json:"vpcEndpoints,omitempty"`
+
+	// ComputedSubnets lists the subnets computed from the subnet schema of the managed VPC, so that
+	// they are computed only once, whatever the availability zones and the subnets of the VPC later.
+	// +optional
+	ComputedSubnets []ComputedSubnet `json:"computedSubnets,omitempty"`
+}
+
+// ComputedSubnet describes a subnet computed from the subnet schema of a managed VPC.
+type ComputedSubnet struct {
+	// AvailabilityZone is the availability zone of the subnet.
+	AvailabilityZone string `json:"availabilityZone"`
+
+	// CidrBlock is the IPv4 CIDR block of the subnet.
+	CidrBlock string `json:"cidrBlock"`
+
+	// IsPublic is true for the public subnets.
+	// +optional
+	IsPublic bool `json:"isPublic,omitempty"`
+}
+
+// RouteTableStatus describes a route table associated with a subnet.
+type RouteTableStatus struct {
+	// ID is the id of the route table.
+	ID string `json:"id"`
+
+	// SubnetID is the id of the subnet the route table is associated with.
+	SubnetID string `json:"subnetId"`
+
+	// Routes lists the routes of the route table.
+	// +optional
+	Routes []RouteStatus `json:"routes,omitempty"`
+}
+
+// RouteStatus describes a route of a route table.
+type RouteStatus struct {
+	// DestinationCIDRBlock is the IPv4 CIDR block used for the destination match.
+	// +optional
+	DestinationCIDRBlock string `json:"destinationCidrBlock,omitempty"`
+
+	// DestinationIPv6CIDRBlock is the IPv6 CIDR block used for the destination match.
+	// +optional
+	DestinationIPv6CIDRBlock string `json:"destinationIpv6CidrBlock,omitempty"`
+
+	// TargetID is the id of the gateway, NAT gateway, network interface or
+	// other resource traffic matching the route is sent to.
+	// +optional
+	TargetID string `json:"targetId,omitempty"`
+
+	// State is the state of the route, either active or blackhole when its target is unavailable.
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+// NatGatewayStatus describes a NAT gateway.
+type NatGatewayStatus struct {
+	// ID is the id of the NAT gateway.
+	ID string `json:"id"`
+
+	// SubnetID is the id of the subnet the NAT gateway lives in.
+	SubnetID string `json:"subnetId"`
+
+	// State is the state of the NAT gateway, e.g. pending, available or failed.
+	State string `json:"state"`
+
+	// FailureMessage explains why the NAT gateway failed, if it did.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+
+	// PublicIP is the Elastic IP address associated with the NAT gateway.
+	// +optional
+	PublicIP string `json:"publicIp,omitempty"`
+
+	// AllocationID is the allocation id of the Elastic IP address associated with the NAT gateway.
+	// +optional
+	AllocationID string `json:"allocationId,omitempty"`
+}
+
+// VPCEndpointStatus describes a VPC endpoint.
+type VPCEndpointStatus struct {
+	// ID is the id of the VPC endpoint.
+	ID string `json:"id"`
+
+	// ServiceName is the name of the service the VPC endpoint connects to.
+	ServiceName string `json:"serviceName"`
+
+	// Type is the type of the VPC endpoint, e.g. Interface or Gateway.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// State is the state of the VPC endpoint, e.g. pending or available.
+	// +optional
+	State string `json:"state,omitempty"`
 }
 
 // ELBScheme defines the scheme of a load balancer.
@@ -79,19 +201,21 @@ var (
 
 // TargetGroupHealthCheck defines health check settings for the target group.
 type TargetGroupHealthCheck struct {
-	Protocol        *string `json:"protocol,omitempty"`
-	Path            *string `json:"path,omitempty"`
-	Port            *string `json:"port,omitempty"`
-	IntervalSeconds *int64  `json:"intervalSeconds,omitempty"`
-	TimeoutSeconds  *int64  `json:"timeoutSeconds,omitempty"`
-	ThresholdCount  *int64  `json:"thresholdCount,omitempty"`
+	Protocol                *string `json:"protocol,omitempty"`
+	Path                    *string `json:"path,omitempty"`
+	Port                    *string `json:"port,omitempty"`
+	IntervalSeconds         *int64  `json:"intervalSeconds,omitempty"`
+	TimeoutSeconds          *int64  `json:"timeoutSeconds,omitempty"`
+	ThresholdCount          *int64  `json:"thresholdCount,omitempty"`
+	UnhealthyThresholdCount *int64  `json:"unhealthyThresholdCount,omitempty"`
 }
 
 // TargetGroupAttribute defines attribute key values for V2 Load Balancer Attributes.
 type TargetGroupAttribute string
 
 var (
-	TargetGroupAttributeEnablePreserveClientIP = "preserve_client_ip.enabled"
+	TargetGroupAttributeEnablePreserveClientIP            = "preserve_client_ip.enabled"
+	TargetGroupAttributeDeregistrationDelayTimeoutSeconds = "deregistration_delay.timeout_seconds"
 )
 
 // LoadBalancerAttribute defines a set of attributes for a V2 load balancer
@@ -170,6 +294,14 @@ type LoadBalancer struct {
 	// LoadBalancerType sets the type for a load balancer. The default type is classic.
 	// +kubebuilder:validation:Enum:=classic;elb;alb;nlb
 	LoadBalancerType LoadBalancerType `json:"loadBalancerType,omitempty"`
+
+	// WebACLARN is the ARN of the WAFv2 web ACL associated with the load balancer by the controller.
+	// +optional
+	WebACLARN string `json:"webACLARN,omitempty"`
+
+	// ShieldProtectionID is the ID of the AWS Shield Advanced protection created for the load balancer by the controller.
+	// +optional
+	ShieldProtectionID string `json:"shieldProtectionID,omitempty"`
 }
 
 // IsUnmanaged returns true if the Classic ELB is unmanaged.
@@ -191,6 +323,11 @@ type ClassicELBAttributes struct {
 	// CrossZoneLoadBalancing enables the classic load balancer load balancing.
 	// +optional
 	CrossZoneLoadBalancing bool `json:"crossZoneLoadBalancing,omitempty"`
+
+	// ConnectionDrainingTimeout is how long the load balancer keeps the connections to deregistered
+	// instances open. Connection draining is disabled when zero.
+	// +optional
+	ConnectionDrainingTimeout time.Duration `json:"connectionDrainingTimeout,omitempty"`
 }
 
 // ClassicELBListener defines an AWS classic load balancer listener.
@@ -228,6 +365,30 @@ type NetworkSpec struct {
 	// This is optional - if not provided new security groups will be created for the cluster
 	// +optional
 	SecurityGroupOverrides map[SecurityGroupRole]string `json:"securityGroupOverrides,omitempty"`
+
+	// AdditionalControlPlaneIngressRules is an optional set of ingress rules to add to the control plane security group,
+	// e.g. to allow access to the API server from managed prefix lists.
+	// +optional
+	AdditionalControlPlaneIngressRules IngressRules `json:"additionalControlPlaneIngressRules,omitempty"`
+
+	// AdditionalNodeIngressRules is an optional set of ingress rules to add to the node security group,
+	// e.g. to allow traffic to node ports from a managed prefix list only.
+	// +optional
+	AdditionalNodeIngressRules IngressRules `json:"additionalNodeIngressRules,omitempty"`
+
+	// NodeEgressRules replaces the default rule of the node security group allowing all outbound traffic.
+	// When set, outbound traffic not matching one of the rules is denied, so the rules must at least allow
+	// the nodes to reach the control plane, the AWS APIs and the container registries they use.
+	// When not set, the egress rules of the node security group are left untouched.
+	// +optional
+	NodeEgressRules EgressRules `json:"nodeEgressRules,omitempty"`
+
+	// PrivateIPv6Egress lets the private subnets of an IPv6 VPC without public subnets reach the internet
+	// over IPv6, e.g. to pull images from container registries: the route tables of the private subnets
+	// route ::/0 through the egress only internet gateway of the VPC, and NAT gateways, which need public
+	// subnets, are not required. Their IPv4 traffic can then only reach the VPC and its endpoints.
+	// +optional
+	PrivateIPv6Egress bool `json:"privateIPv6Egress,omitempty"`
 }
 
 // IPv6 contains ipv6 specific settings for the network.
@@ -260,6 +421,8 @@ type VPCSpec struct {
 	IPv6 *IPv6 `json:"ipv6,omitempty"`
 
 	// InternetGatewayID is the id of the internet gateway associated with the VPC.
+	// When set for a managed VPC to an internet gateway created outside of the provider, the internet
+	// gateway is attached to the VPC instead of creating one, and it is not deleted with the cluster.
 	// +optional
 	InternetGatewayID *string `json:"internetGatewayId,omitempty"`
 
@@ -275,13 +438,241 @@ type VPCSpec struct {
 	AvailabilityZoneUsageLimit *int `json:"availabilityZoneUsageLimit,omitempty"`
 
 	// AvailabilityZoneSelection specifies how AZs should be selected if there are more AZs
-	// in a region than specified by AvailabilityZoneUsageLimit. There are 2 selection schemes:
+	// in a region than specified by AvailabilityZoneUsageLimit. There are 3 selection schemes:
 	// Ordered - selects based on alphabetical order
 	// Random - selects AZs randomly in a region
+	// Explicit - selects the AZs listed in AvailabilityZones
 	// Defaults to Ordered
 	// +kubebuilder:default=Ordered
-	// +kubebuilder:validation:Enum=Ordered;Random
+	// +kubebuilder:validation:Enum=Ordered;Random;Explicit
 	AvailabilityZoneSelection *AZSelectionScheme `json:"availabilityZoneSelection,omitempty"`
+
+	// AvailabilityZones lists the AZs in which the default subnets, and their NAT gateways, are created
+	// when AvailabilityZoneSelection is Explicit, e.g. to use the AZs of existing capacity reservations.
+	// It can list at most AvailabilityZoneUsageLimit AZs, which must all be available in the region.
+	// Like the other selection schemes, it only applies when the subnets are first created.
+	// +optional
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
+	// NATGatewayElasticIPPool selects pre-allocated Elastic IPs for the NAT gateways of a managed VPC,
+	// so that the egress IPs of the cluster stay the same when it is recreated. When set, the
+	// NAT gateways are only created once enough unassociated Elastic IPs of the pool are available,
+	// and the Elastic IPs are not released when the cluster is deleted.
+	// +optional
+	NATGatewayElasticIPPool *ElasticIPPool `json:"natGatewayElasticIPPool,omitempty"`
+
+	// NATGatewayPlacement restricts the public subnets NAT gateways are created in, e.g. to keep them out of
+	// Local Zones. Private subnets in availability zones without a NAT gateway then route their traffic through
+	// the NAT gateway of the first availability zone, in alphabetical order, that has one.
+	// Existing NAT gateways are not moved.
+	// +optional
+	NATGatewayPlacement *SubnetPlacement `json:"natGatewayPlacement,omitempty"`
+
+	// NATStrategy selects how the private subnets of a managed VPC reach the internet: through NAT gateways,
+	// the default, or through NAT instances, which cost far less but neither scale with the traffic nor fail
+	// over, e.g. for development clusters. NAT instances are placed like NAT gateways, one per public subnet
+	// matching NATGatewayPlacement, and use the Elastic IPs of NATGatewayElasticIPPool when set.
+	// It cannot be changed once set.
+	// +kubebuilder:validation:Enum=nat-gateway;nat-instance
+	// +optional
+	NATStrategy NATStrategy `json:"natStrategy,omitempty"`
+
+	// NATInstance configures the NAT instances. It can only be set when NATStrategy is nat-instance.
+	// +optional
+	NATInstance *NATInstanceSpec `json:"natInstance,omitempty"`
+
+	// DHCPOptions configures a DHCP options set for a managed VPC, e.g. to resolve the names of an
+	// Active Directory domain. When not set, the VPC uses the default DHCP options set of the region.
+	// It can be changed, in which case the DHCP options set is replaced, but it cannot be removed.
+	// +optional
+	DHCPOptions *DHCPOptions `json:"dhcpOptions,omitempty"`
+
+	// FlowLogs configures flow logs capturing the IP traffic of a managed VPC. Flow logs cannot be
+	// modified, so the flow log is replaced when the spec changes. It cannot be removed once set.
+	// +optional
+	FlowLogs *VPCFlowLogs `json:"flowLogs,omitempty"`
+
+	// SubnetSchema describes the default subnets of a managed VPC by their size and number per availability
+	// zone instead of their CIDR blocks, which are computed from the CIDR block of the VPC. Like the selection
+	// of the availability zones, it only applies when no subnets are specified, and the subnets are first created.
+	// +optional
+	SubnetSchema *SubnetSchema `json:"subnetSchema,omitempty"`
+}
+
+// SubnetSchema describes the default subnets of a managed VPC in each availability zone.
+type SubnetSchema struct {
+	// PublicSubnetPrefixLength is the prefix length of the CIDR blocks of the public subnets, e.g. 24 for /24.
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=28
+	PublicSubnetPrefixLength int `json:"publicSubnetPrefixLength"`
+
+	// PrivateSubnetPrefixLength is the prefix length of the CIDR blocks of the private subnets, e.g. 20 for /20.
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=28
+	PrivateSubnetPrefixLength int `json:"privateSubnetPrefixLength"`
+
+	// PublicSubnetsPerZone is the number of public subnets in each availability zone. Defaults to 1.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8
+	// +optional
+	PublicSubnetsPerZone int `json:"publicSubnetsPerZone,omitempty"`
+
+	// PrivateSubnetsPerZone is the number of private subnets in each availability zone. Defaults to 1.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8
+	// +optional
+	PrivateSubnetsPerZone int `json:"privateSubnetsPerZone,omitempty"`
+}
+
+// GetPublicSubnetsPerZone returns the number of public subnets in each availability zone.
+func (s *SubnetSchema) GetPublicSubnetsPerZone() int {
+	if s.PublicSubnetsPerZone < 1 {
+		return 1
+	}
+	return s.PublicSubnetsPerZone
+}
+
+// GetPrivateSubnetsPerZone returns the number of private subnets in each availability zone.
+func (s *SubnetSchema) GetPrivateSubnetsPerZone() int {
+	if s.PrivateSubnetsPerZone < 1 {
+		return 1
+	}
+	return s.PrivateSubnetsPerZone
+}
+
+// NATStrategy is how the private subnets of a managed VPC reach the internet.
+type NATStrategy string
+
+const (
+	// NATStrategyNATGateway routes the traffic of the private subnets through NAT gateways.
+	NATStrategyNATGateway = NATStrategy("nat-gateway")
+	// NATStrategyNATInstance routes the traffic of the private subnets through NAT instances.
+	NATStrategyNATInstance = NATStrategy("nat-instance")
+)
+
+// NATInstanceSpec configures the NAT instances of a managed VPC.
+type NATInstanceSpec struct {
+	// InstanceType is the type of the NAT instances. Defaults to t3.micro.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// AMI is the ID of the image of the NAT instances. It must run Linux with cloud-init, systemd and iptables,
+	// the instances forwarding and masquerading the traffic of the VPC from their user data.
+	// Defaults to the latest Amazon Linux 2 image for the architecture of InstanceType.
+	// +optional
+	AMI string `json:"ami,omitempty"`
+}
+
+// FlowLogsDestinationType is the type of destination flow logs are published to.
+type FlowLogsDestinationType string
+
+const (
+	// FlowLogsDestinationTypeS3 publishes flow logs to an S3 bucket.
+	FlowLogsDestinationTypeS3 = FlowLogsDestinationType("s3")
+	// FlowLogsDestinationTypeCloudWatchLogs publishes flow logs to a CloudWatch Logs log group.
+	FlowLogsDestinationTypeCloudWatchLogs = FlowLogsDestinationType("cloud-watch-logs")
+)
+
+// FlowLogsTrafficType is the type of traffic captured by flow logs.
+type FlowLogsTrafficType string
+
+const (
+	// FlowLogsTrafficTypeAccept captures the accepted traffic.
+	FlowLogsTrafficTypeAccept = FlowLogsTrafficType("ACCEPT")
+	// FlowLogsTrafficTypeReject captures the rejected traffic.
+	FlowLogsTrafficTypeReject = FlowLogsTrafficType("REJECT")
+	// FlowLogsTrafficTypeAll captures the accepted and rejected traffic.
+	FlowLogsTrafficTypeAll = FlowLogsTrafficType("ALL")
+)
+
+// VPCFlowLogs configures the flow logs of a VPC.
+type VPCFlowLogs struct {
+	// DestinationType is the type of destination flow logs are published to.
+	// +kubebuilder:validation:Enum=s3;cloud-watch-logs
+	DestinationType FlowLogsDestinationType `json:"destinationType"`
+
+	// DestinationARN is the ARN of the S3 bucket, optionally followed by a folder, or of the CloudWatch Logs
+	// log group flow logs are published to.
+	DestinationARN string `json:"destinationARN"`
+
+	// DeliverLogsPermissionARN is the ARN of the IAM role that allows publishing flow logs to the CloudWatch
+	// Logs log group. It is required for the cloud-watch-logs destination type, and must not be set for s3.
+	// +optional
+	DeliverLogsPermissionARN *string `json:"deliverLogsPermissionARN,omitempty"`
+
+	// TrafficType is the type of traffic captured.
+	// +kubebuilder:default=ALL
+	// +kubebuilder:validation:Enum=ACCEPT;REJECT;ALL
+	// +optional
+	TrafficType FlowLogsTrafficType `json:"trafficType,omitempty"`
+
+	// MaxAggregationInterval is the maximum interval of time, in seconds, during which a flow of packets
+	// is captured and aggregated into a flow log record. Defaults to 600.
+	// +kubebuilder:validation:Enum=60;600
+	// +optional
+	MaxAggregationInterval *int64 `json:"maxAggregationInterval,omitempty"`
+
+	// LogFormat is a custom format of the flow log records, as a space-separated list of fields such as
+	// "${srcaddr} ${dstaddr} ${action}". Defaults to the default format of flow logs.
+	// +optional
+	LogFormat *string `json:"logFormat,omitempty"`
+}
+
+// DHCPOptions configures the DHCP options set of a VPC. At least one option must be set.
+type DHCPOptions struct {
+	// DomainName is the domain name instances use to complete unqualified DNS host names.
+	// +optional
+	DomainName *string `json:"domainName,omitempty"`
+
+	// DomainNameServers are the IPv4 addresses of up to four domain name servers, or AmazonProvidedDNS.
+	// +kubebuilder:validation:MaxItems=4
+	// +optional
+	DomainNameServers []string `json:"domainNameServers,omitempty"`
+
+	// NTPServers are the IPv4 addresses of up to four NTP servers.
+	// +kubebuilder:validation:MaxItems=4
+	// +optional
+	NTPServers []string `json:"ntpServers,omitempty"`
+}
+
+// ElasticIPPool selects Elastic IPs that were allocated outside of the provider.
+// Exactly one of AllocationIDs and Tags must be set.
+type ElasticIPPool struct {
+	// AllocationIDs is a list of Elastic IP allocation IDs, used in the order they are listed.
+	// +optional
+	AllocationIDs []string `json:"allocationIDs,omitempty"`
+
+	// Tags selects the Elastic IPs carrying all of the given tags, used in the order of their allocation IDs.
+	// +optional
+	Tags Tags `json:"tags,omitempty"`
+}
+
+// SubnetPlacement restricts the subnets shared infrastructure, such as the bastion host and NAT gateways,
+// is placed in. A subnet must match both lists when both are set.
+type SubnetPlacement struct {
+	// AvailabilityZones restricts placement to subnets in the given availability zones.
+	// +optional
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
+	// SubnetIDs restricts placement to the given subnets.
+	// +optional
+	SubnetIDs []string `json:"subnetIDs,omitempty"`
+}
+
+// Matches returns true if shared infrastructure can be placed in the subnet.
+func (p *SubnetPlacement) Matches(sn *SubnetSpec) bool {
+	if p == nil {
+		return true
+	}
+	if len(p.AvailabilityZones) > 0 && !sets.NewString(p.AvailabilityZones...).Has(sn.AvailabilityZone) {
+		return false
+	}
+	if len(p.SubnetIDs) > 0 && !sets.NewString(p.SubnetIDs...).Has(sn.ID) {
+		return false
+	}
+	return true
 }
 
 // String returns a string representation of the VPC.
@@ -304,6 +695,14 @@ func (v *VPCSpec) IsIPv6Enabled() bool {
 	return v.IPv6 != nil
 }
 
+// GetNATStrategy returns the NAT strategy of the VPC, defaulting to NAT gateways.
+func (v *VPCSpec) GetNATStrategy() NATStrategy {
+	if v.NATStrategy == "" {
+		return NATStrategyNATGateway
+	}
+	return v.NATStrategy
+}
+
 // SubnetSpec configures an AWS Subnet.
 type SubnetSpec struct {
 	// ID defines a unique identifier to reference this resource.
@@ -321,6 +720,11 @@ type SubnetSpec struct {
 	// AvailabilityZone defines the availability zone to use for this subnet in the cluster's region.
 	AvailabilityZone string `json:"availabilityZone,omitempty"`
 
+	// OutpostARN is the ARN of the AWS Outpost on which the subnet is created, or resides for subnets
+	// created outside of the provider. The availability zone must be the one the Outpost is anchored to.
+	// +optional
+	OutpostARN string `json:"outpostArn,omitempty"`
+
 	// IsPublic defines the subnet as a public subnet. A subnet is public when it is associated with a route table that has a route to an internet gateway.
 	// +optional
 	IsPublic bool `json:"isPublic"`
@@ -331,6 +735,9 @@ type SubnetSpec struct {
 	IsIPv6 bool `json:"isIpv6,omitempty"`
 
 	// RouteTableID is the routing table id associated with the subnet.
+	// When set for a subnet of a managed VPC to a route table created outside of the provider, the route
+	// table is associated with the subnet instead of creating one. Its routes are not modified, and it is
+	// not deleted with the cluster.
 	// +optional
 	RouteTableID *string `json:"routeTableId,omitempty"`
 
@@ -339,7 +746,13 @@ type SubnetSpec struct {
 	// +optional
 	NatGatewayID *string `json:"natGatewayId,omitempty"`
 
-	// Tags is a collection of tags describing the resource.
+	// NatInstanceID is the ID of the NAT instance in the subnet, when the NAT strategy of the VPC is nat-instance.
+	// Like NatGatewayID, it is set on public subnets and used to determine the routes of private subnets.
+	// +optional
+	NatInstanceID *string `json:"natInstanceId,omitempty"`
+
+	// Tags is a collection of tags describing the resource. They are applied to both managed and unmanaged
+	// subnets, except for tags with the reserved aws: prefix.
 	Tags Tags `json:"tags,omitempty"`
 }
 
@@ -415,6 +828,16 @@ func (s Subnets) FilterPublic() (res Subnets) {
 	return
 }
 
+// FilterPlacement returns the subnets that match the placement, or all subnets if the placement is nil.
+func (s Subnets) FilterPlacement(placement *SubnetPlacement) (res Subnets) {
+	for _, x := range s {
+		if placement.Matches(&x) {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
 // FilterByZone returns a slice containing all subnets that live in the availability zone specified.
 func (s Subnets) FilterByZone(zone string) (res Subnets) {
 	for _, x := range s {
@@ -425,6 +848,17 @@ func (s Subnets) FilterByZone(zone string) (res Subnets) {
 	return
 }
 
+// FilterByOutpost returns a slice containing all subnets on the Outpost specified,
+// or all subnets in the region, and not on an Outpost, if outpostARN is empty.
+func (s Subnets) FilterByOutpost(outpostARN string) (res Subnets) {
+	for _, x := range s {
+		if x.OutpostARN == outpostARN {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
 // GetUniqueZones returns a slice containing the unique zones of the subnets.
 func (s Subnets) GetUniqueZones() []string {
 	keys := make(map[string]bool)
@@ -454,6 +888,11 @@ type CNIIngressRule struct {
 	Protocol    SecurityGroupProtocol `json:"protocol"`
 	FromPort    int64                 `json:"fromPort"`
 	ToPort      int64                 `json:"toPort"`
+
+	// SourcePrefixListIDs is a list of managed prefix list IDs to allow access from,
+	// in addition to the control plane and worker node security groups.
+	// +optional
+	SourcePrefixListIDs []string `json:"sourcePrefixListIds,omitempty"`
 }
 
 // RouteTable defines an AWS routing table.
@@ -546,6 +985,11 @@ type IngressRule struct {
 	// The security group id to allow access from. Cannot be specified with CidrBlocks.
 	// +optional
 	SourceSecurityGroupIDs []string `json:"sourceSecurityGroupIds,omitempty"`
+
+	// List of managed prefix list IDs to allow access from. Cannot be specified with CidrBlocks
+	// or SourceSecurityGroupIDs.
+	// +optional
+	SourcePrefixListIDs []string `json:"sourcePrefixListIds,omitempty"`
 }
 
 // String returns a string representation of the ingress rule.
@@ -619,6 +1063,19 @@ func (i *IngressRule) Equals(o *IngressRule) bool {
 		}
 	}
 
+	if len(i.SourcePrefixListIDs) != len(o.SourcePrefixListIDs) {
+		return false
+	}
+
+	sort.Strings(i.SourcePrefixListIDs)
+	sort.Strings(o.SourcePrefixListIDs)
+
+	for i, v := range i.SourcePrefixListIDs {
+		if v != o.SourcePrefixListIDs[i] {
+			return false
+		}
+	}
+
 	if i.Description != o.Description || i.Protocol != o.Protocol {
 		return false
 	}
@@ -638,3 +1095,177 @@ func (i *IngressRule) Equals(o *IngressRule) bool {
 
 	return true
 }
+
+// EgressRule defines an AWS egress rule for security groups.
+type EgressRule struct {
+	Description string                `json:"description"`
+	Protocol    SecurityGroupProtocol `json:"protocol"`
+	FromPort    int64                 `json:"fromPort"`
+	ToPort      int64                 `json:"toPort"`
+
+	// List of CIDR blocks to allow access to.
+	// +optional
+	CidrBlocks []string `json:"cidrBlocks,omitempty"`
+
+	// List of IPv6 CIDR blocks to allow access to.
+	// +optional
+	IPv6CidrBlocks []string `json:"ipv6CidrBlocks,omitempty"`
+
+	// The security group ids to allow access to.
+	// +optional
+	DestinationSecurityGroupIDs []string `json:"destinationSecurityGroupIds,omitempty"`
+
+	// List of managed prefix list IDs to allow access to. Cannot be specified with CidrBlocks
+	// or DestinationSecurityGroupIDs.
+	// +optional
+	DestinationPrefixListIDs []string `json:"destinationPrefixListIds,omitempty"`
+}
+
+// String returns a string representation of the egress rule.
+func (e EgressRule) String() string {
+	return fmt.Sprintf("protocol=%s/range=[%d-%d]/description=%s", e.Protocol, e.FromPort, e.ToPort, e.Description)
+}
+
+// EgressRules is a slice of AWS egress rules for security groups.
+type EgressRules []EgressRule
+
+// Difference returns the difference between this slice and the other slice.
+func (e EgressRules) Difference(o EgressRules) (out EgressRules) {
+	for index := range e {
+		x := e[index]
+		found := false
+		for oIndex := range o {
+			y := o[oIndex]
+			if x.Equals(&y) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			out = append(out, x)
+		}
+	}
+
+	return
+}
+
+// Equals returns true if two EgressRule are equal.
+func (e *EgressRule) Equals(o *EgressRule) bool {
+	// Egress and ingress rules describe the same AWS permissions, with destinations instead of sources.
+	x, y := e.asIngressRule(), o.asIngressRule()
+	return x.Equals(&y)
+}
+
+func (e *EgressRule) asIngressRule() IngressRule {
+	return IngressRule{
+		Description:            e.Description,
+		Protocol:               e.Protocol,
+		FromPort:               e.FromPort,
+		ToPort:                 e.ToPort,
+		CidrBlocks:             e.CidrBlocks,
+		IPv6CidrBlocks:         e.IPv6CidrBlocks,
+		SourceSecurityGroupIDs: e.DestinationSecurityGroupIDs,
+		SourcePrefixListIDs:    e.DestinationPrefixListIDs,
+	}
+}
+
+// ValidatePrivateIPv6Egress checks that the private IPv6 egress is only enabled for IPv6 VPCs.
+func (n *NetworkSpec) ValidatePrivateIPv6Egress(fldPath *field.Path) field.ErrorList {
+	if n.PrivateIPv6Egress && !n.VPC.IsIPv6Enabled() {
+		return field.ErrorList{field.Forbidden(fldPath.Child("privateIPv6Egress"), "can only be set if IPv6 is enabled for the VPC")}
+	}
+	return nil
+}
+
+// ValidateAvailabilityZoneSelection validates the selection of the availability zones of the default subnets.
+func (v *VPCSpec) ValidateAvailabilityZoneSelection(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	explicit := v.AvailabilityZoneSelection != nil && *v.AvailabilityZoneSelection == AZSelectionSchemeExplicit
+	if !explicit {
+		if len(v.AvailabilityZones) > 0 {
+			errs = append(errs, field.Forbidden(fldPath.Child("availabilityZones"), "can only be set if availabilityZoneSelection is Explicit"))
+		}
+		return errs
+	}
+	if len(v.AvailabilityZones) == 0 {
+		errs = append(errs, field.Required(fldPath.Child("availabilityZones"), "is required if availabilityZoneSelection is Explicit"))
+	}
+	if v.AvailabilityZoneUsageLimit != nil && len(v.AvailabilityZones) > *v.AvailabilityZoneUsageLimit {
+		errs = append(errs, field.TooMany(fldPath.Child("availabilityZones"), len(v.AvailabilityZones), *v.AvailabilityZoneUsageLimit))
+	}
+	zones := map[string]struct{}{}
+	for i, zone := range v.AvailabilityZones {
+		if zone == "" {
+			errs = append(errs, field.Required(fldPath.Child("availabilityZones").Index(i), "availability zone is required"))
+		}
+		if _, ok := zones[zone]; ok {
+			errs = append(errs, field.Duplicate(fldPath.Child("availabilityZones").Index(i), zone))
+		}
+		zones[zone] = struct{}{}
+	}
+	return errs
+}
+
+// ValidateSubnetSchema checks that the subnets described by the subnet schema fit in the CIDR block of the VPC,
+// in as many availability zones as can be selected.
+func (v *VPCSpec) ValidateSubnetSchema(fldPath *field.Path) field.ErrorList {
+	if v.SubnetSchema == nil || v.CidrBlock == "" {
+		return nil
+	}
+	_, vpcCidr, err := net.ParseCIDR(v.CidrBlock)
+	if err != nil || vpcCidr.IP.To4() == nil {
+		return nil
+	}
+	vpcPrefixLength, _ := vpcCidr.Mask.Size()
+
+	var errs field.ErrorList
+	schema := v.SubnetSchema
+	if schema.PublicSubnetPrefixLength < vpcPrefixLength {
+		errs = append(errs, field.Invalid(fldPath.Child("subnetSchema", "publicSubnetPrefixLength"), schema.PublicSubnetPrefixLength,
+			fmt.Sprintf("must not be less than the prefix length of the VPC CIDR block %s", v.CidrBlock)))
+	}
+	if schema.PrivateSubnetPrefixLength < vpcPrefixLength {
+		errs = append(errs, field.Invalid(fldPath.Child("subnetSchema", "privateSubnetPrefixLength"), schema.PrivateSubnetPrefixLength,
+			fmt.Sprintf("must not be less than the prefix length of the VPC CIDR block %s", v.CidrBlock)))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	zones := 3
+	if v.AvailabilityZoneUsageLimit != nil {
+		zones = *v.AvailabilityZoneUsageLimit
+	}
+	if v.AvailabilityZoneSelection != nil && *v.AvailabilityZoneSelection == AZSelectionSchemeExplicit && len(v.AvailabilityZones) > 0 {
+		zones = len(v.AvailabilityZones)
+	}
+	size := uint64(zones) * (uint64(schema.GetPublicSubnetsPerZone())<<(32-schema.PublicSubnetPrefixLength) +
+		uint64(schema.GetPrivateSubnetsPerZone())<<(32-schema.PrivateSubnetPrefixLength))
+	if size > uint64(1)<<(32-vpcPrefixLength) {
+		errs = append(errs, field.Invalid(fldPath.Child("subnetSchema"), *schema,
+			fmt.Sprintf("the subnets of %d availability zones don't fit in the VPC CIDR block %s", zones, v.CidrBlock)))
+	}
+	return errs
+}
+
+// ValidateNATStrategy validates the NAT instances of the VPC.
+func (v *VPCSpec) ValidateNATStrategy(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if v.NATInstance != nil && v.GetNATStrategy() != NATStrategyNATInstance {
+		errs = append(errs, field.Forbidden(fldPath.Child("natInstance"), "can only be set if natStrategy is nat-instance"))
+	}
+	if v.NATInstance != nil && v.NATInstance.AMI != "" && !strings.HasPrefix(v.NATInstance.AMI, "ami-") {
+		errs = append(errs, field.Invalid(fldPath.Child("natInstance", "ami"), v.NATInstance.AMI, "must be a valid AMI ID"))
+	}
+	return errs
+}
+
+// ValidateNATStrategyUpdate rejects changes of the NAT strategy of the VPC, which would leave the NAT gateways
+// or instances of the previous strategy in place.
+func (v *VPCSpec) ValidateNATStrategyUpdate(old *VPCSpec, fldPath *field.Path) field.ErrorList {
+	if old.GetNATStrategy() != v.GetNATStrategy() {
+		return field.ErrorList{field.Invalid(fldPath.Child("natStrategy"), v.NATStrategy, "field is immutable")}
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func TestSGDifference(t *testing.T) {
@@ -94,6 +95,50 @@ func TestSGDifference(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "rules with different prefix lists",
+			self: IngressRules{
+				{
+					Description:         "SSH",
+					Protocol:            SecurityGroupProtocolTCP,
+					FromPort:            22,
+					ToPort:              22,
+					SourcePrefixListIDs: []string{"pl-2", "pl-1"},
+				},
+				{
+					Description:         "bgp (calico)",
+					Protocol:            SecurityGroupProtocolTCP,
+					FromPort:            179,
+					ToPort:              179,
+					SourcePrefixListIDs: []string{"pl-1"},
+				},
+			},
+			input: IngressRules{
+				{
+					Description:         "SSH",
+					Protocol:            SecurityGroupProtocolTCP,
+					FromPort:            22,
+					ToPort:              22,
+					SourcePrefixListIDs: []string{"pl-1", "pl-2"},
+				},
+				{
+					Description:         "bgp (calico)",
+					Protocol:            SecurityGroupProtocolTCP,
+					FromPort:            179,
+					ToPort:              179,
+					SourcePrefixListIDs: []string{"pl-3"},
+				},
+			},
+			expected: IngressRules{
+				{
+					Description:         "bgp (calico)",
+					Protocol:            SecurityGroupProtocolTCP,
+					FromPort:            179,
+					ToPort:              179,
+					SourcePrefixListIDs: []string{"pl-1"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -105,3 +150,104 @@ func TestSGDifference(t *testing.T) {
 		})
 	}
 }
+
+func TestVPCSpecValidateAvailabilityZoneSelection(t *testing.T) {
+	explicit := AZSelectionSchemeExplicit
+	limit := 2
+	tests := []struct {
+		name    string
+		vpc     VPCSpec
+		wantErr bool
+	}{
+		{
+			name: "default selection",
+			vpc:  VPCSpec{},
+		},
+		{
+			name: "explicit zones",
+			vpc:  VPCSpec{AvailabilityZoneSelection: &explicit, AvailabilityZoneUsageLimit: &limit, AvailabilityZones: []string{"us-east-1a", "us-east-1c"}},
+		},
+		{
+			name:    "zones without explicit selection",
+			vpc:     VPCSpec{AvailabilityZones: []string{"us-east-1a"}},
+			wantErr: true,
+		},
+		{
+			name:    "explicit selection without zones",
+			vpc:     VPCSpec{AvailabilityZoneSelection: &explicit},
+			wantErr: true,
+		},
+		{
+			name:    "more zones than the usage limit",
+			vpc:     VPCSpec{AvailabilityZoneSelection: &explicit, AvailabilityZoneUsageLimit: &limit, AvailabilityZones: []string{"us-east-1a", "us-east-1b", "us-east-1c"}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate zones",
+			vpc:     VPCSpec{AvailabilityZoneSelection: &explicit, AvailabilityZones: []string{"us-east-1a", "us-east-1a"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.vpc.ValidateAvailabilityZoneSelection(field.NewPath("spec", "network", "vpc"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestVPCSpecValidateSubnetSchema(t *testing.T) {
+	explicit := AZSelectionSchemeExplicit
+	limit := 4
+	tests := []struct {
+		name    string
+		vpc     VPCSpec
+		wantErr bool
+	}{
+		{
+			name: "no subnet schema",
+			vpc:  VPCSpec{CidrBlock: "10.0.0.0/16"},
+		},
+		{
+			name: "subnets fitting in 3 zones",
+			vpc:  VPCSpec{CidrBlock: "10.0.0.0/16", SubnetSchema: &SubnetSchema{PublicSubnetPrefixLength: 24, PrivateSubnetPrefixLength: 18}},
+		},
+		{
+			name:    "subnets not fitting in 4 zones",
+			vpc:     VPCSpec{CidrBlock: "10.0.0.0/16", AvailabilityZoneUsageLimit: &limit, SubnetSchema: &SubnetSchema{PublicSubnetPrefixLength: 24, PrivateSubnetPrefixLength: 18}},
+			wantErr: true,
+		},
+		{
+			name: "subnets fitting in the explicit zones",
+			vpc: VPCSpec{
+				CidrBlock:                 "10.0.0.0/16",
+				AvailabilityZoneSelection: &explicit,
+				AvailabilityZones:         []string{"us-east-1a", "us-east-1b"},
+				SubnetSchema:              &SubnetSchema{PublicSubnetPrefixLength: 24, PrivateSubnetPrefixLength: 19, PrivateSubnetsPerZone: 2},
+			},
+		},
+		{
+			name:    "subnets larger than the VPC",
+			vpc:     VPCSpec{CidrBlock: "10.0.0.0/20", SubnetSchema: &SubnetSchema{PublicSubnetPrefixLength: 24, PrivateSubnetPrefixLength: 19}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.vpc.ValidateSubnetSchema(field.NewPath("spec", "network", "vpc"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
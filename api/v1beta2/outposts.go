@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateOutposts ensures that the Outposts of the subnets are referenced by valid ARNs.
+func (s Subnets) ValidateOutposts(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, subnet := range s {
+		allErrs = append(allErrs, validateOutpostARN(subnet.OutpostARN, fldPath.Index(i).Child("outpostArn"))...)
+	}
+	return allErrs
+}
+
+// validateOutpostARN ensures that the value, if set, is the ARN of an Outpost,
+// e.g. arn:aws:outposts:<region>:<account-id>:outpost/op-<id>.
+func validateOutpostARN(value string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if value == "" {
+		return allErrs
+	}
+
+	parsed, err := arn.Parse(value)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("invalid Outpost ARN: %v", err)))
+	}
+	if parsed.Service != "outposts" || !strings.HasPrefix(parsed.Resource, "outpost/op-") {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "ARN must reference an Outpost, e.g. arn:aws:outposts:<region>:<account-id>:outpost/op-<id>"))
+	}
+	return allErrs
+}
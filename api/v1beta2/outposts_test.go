@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestSubnetsValidateOutposts(t *testing.T) {
+	tests := []struct {
+		name       string
+		outpostARN string
+		wantErr    bool
+	}{
+		{
+			name: "no outpost",
+		},
+		{
+			name:       "outpost",
+			outpostARN: "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0",
+		},
+		{
+			name:       "not an ARN",
+			outpostARN: "op-0123456789abcdef0",
+			wantErr:    true,
+		},
+		{
+			name:       "ARN of another resource",
+			outpostARN: "arn:aws:ec2:us-east-1:123456789012:subnet/subnet-0123456789abcdef0",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			subnets := Subnets{{ID: "subnet-1"}, {ID: "subnet-2", OutpostARN: tt.outpostARN}}
+			errs := subnets.ValidateOutposts(field.NewPath("spec", "network", "subnets"))
+			if tt.wantErr {
+				g.Expect(errs).To(HaveLen(1))
+				g.Expect(errs[0].Field).To(Equal("spec.network.subnets[1].outpostArn"))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestSubnetsFilterByOutpost(t *testing.T) {
+	g := NewWithT(t)
+	outpostARN := "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"
+	subnets := Subnets{{ID: "subnet-1"}, {ID: "subnet-2", OutpostARN: outpostARN}}
+
+	g.Expect(subnets.FilterByOutpost("")).To(Equal(Subnets{{ID: "subnet-1"}}))
+	g.Expect(subnets.FilterByOutpost(outpostARN)).To(Equal(Subnets{{ID: "subnet-2", OutpostARN: outpostARN}}))
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// PartitionForRegion returns the AWS partition of a region, e.g. aws-us-gov for us-gov-west-1.
+// Regions unknown to the SDK are assumed to be in the aws partition.
+func PartitionForRegion(region string) string {
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		return p.ID()
+	}
+	return endpoints.AwsPartitionID
+}
+
+// DNSSuffixForRegion returns the DNS suffix of the partition of a region, e.g. amazonaws.com.cn for cn-north-1.
+// It is the suffix of the hostnames of service endpoints, such as S3 and ECR, in rendered artifacts.
+// It is empty for regions unknown to the SDK, whose instances can discover it from the instance metadata service.
+func DNSSuffixForRegion(region string) string {
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		return p.DNSSuffix()
+	}
+	return ""
+}
+
+// validatePartition ensures that the region of a cluster belongs to its partition, if set.
+// Regions unknown to the SDK can't be checked and are accepted with any partition.
+func validatePartition(region, partition string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if region == "" || partition == "" {
+		return allErrs
+	}
+
+	p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if ok && p.ID() != partition {
+		allErrs = append(allErrs, field.Invalid(fldPath, partition, fmt.Sprintf("region %q is in the %q partition", region, p.ID())))
+	}
+	return allErrs
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestPartitionForRegion(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(PartitionForRegion("us-east-1")).To(Equal("aws"))
+	g.Expect(PartitionForRegion("us-gov-west-1")).To(Equal("aws-us-gov"))
+	g.Expect(PartitionForRegion("cn-north-1")).To(Equal("aws-cn"))
+	g.Expect(PartitionForRegion("")).To(Equal("aws"))
+}
+
+func TestDNSSuffixForRegion(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(DNSSuffixForRegion("us-east-1")).To(Equal("amazonaws.com"))
+	g.Expect(DNSSuffixForRegion("us-gov-west-1")).To(Equal("amazonaws.com"))
+	g.Expect(DNSSuffixForRegion("cn-north-1")).To(Equal("amazonaws.com.cn"))
+	g.Expect(DNSSuffixForRegion("us-iso-east-1")).To(Equal("c2s.ic.gov"))
+	g.Expect(DNSSuffixForRegion("xx-unknown-1")).To(BeEmpty())
+}
+
+func TestValidatePartition(t *testing.T) {
+	tests := []struct {
+		name      string
+		region    string
+		partition string
+		wantErr   bool
+	}{
+		{
+			name:   "no partition",
+			region: "us-gov-west-1",
+		},
+		{
+			name:      "GovCloud region in the GovCloud partition",
+			region:    "us-gov-west-1",
+			partition: "aws-us-gov",
+		},
+		{
+			name:      "China region in the China partition",
+			region:    "cn-northwest-1",
+			partition: "aws-cn",
+		},
+		{
+			name:      "region unknown to the SDK",
+			region:    "xx-unknown-1",
+			partition: "aws-iso",
+		},
+		{
+			name:      "GovCloud region in the commercial partition",
+			region:    "us-gov-east-1",
+			partition: "aws",
+			wantErr:   true,
+		},
+		{
+			name:      "commercial region in the China partition",
+			region:    "eu-west-1",
+			partition: "aws-cn",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validatePartition(tt.region, tt.partition, field.NewPath("spec", "partition"))
+			if tt.wantErr {
+				g.Expect(errs).To(HaveLen(1))
+				g.Expect(errs[0].Field).To(Equal("spec.partition"))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
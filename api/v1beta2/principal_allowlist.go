@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AllowsNamespace reports whether AWSClusters in namespace are allowed to use the identity n belongs to.
+// A nil value allows no namespace, and an empty value allows every namespace unless enforcePrincipalAllowList
+// is set, in which case the identity must explicitly list or select the namespace.
+func (n *AllowedNamespaces) AllowsNamespace(ctx context.Context, c client.Reader, namespace string, enforcePrincipalAllowList bool) (bool, error) {
+	// nil value does not match with any namespaces
+	if n == nil {
+		return false, nil
+	}
+
+	// empty value matches with all namespaces
+	if cmp.Equal(*n, AllowedNamespaces{}) {
+		return !enforcePrincipalAllowList, nil
+	}
+
+	for _, v := range n.NamespaceList {
+		if v == namespace {
+			return true, nil
+		}
+	}
+
+	// Check if namespace is in the namespaces selected by the identity's allowedNamespaces selector.
+	selector, err := metav1.LabelSelectorAsSelector(&n.Selector)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get label selector from spec selector")
+	}
+
+	// If a Selector has a nil or empty selector, it should match nothing, not everything.
+	if selector.Empty() {
+		return false, nil
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := c.List(ctx, namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return false, errors.Wrap(err, "failed to list namespaces")
+	}
+
+	for i := range namespaces.Items {
+		if namespaces.Items[i].Name == namespace {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NotAllowedReason explains why AllowsNamespace rejected namespace, for the conditions and
+// admission errors of the clusters that aren't allowed to use the identity.
+func (n *AllowedNamespaces) NotAllowedReason(namespace string) string {
+	switch {
+	case n == nil:
+		return "the identity does not set allowedNamespaces, so it cannot be used from any namespace"
+	case cmp.Equal(*n, AllowedNamespaces{}):
+		return "the identity allows every namespace, which is not permitted while the principal allow-list is enforced"
+	default:
+		return fmt.Sprintf("namespace %q is neither in the list nor matched by the selector of the identity's allowedNamespaces", namespace)
+	}
+}
+
+// IdentityAllowedNamespaces returns the allowed namespaces of the identity ref refers to.
+func IdentityAllowedNamespaces(ctx context.Context, c client.Reader, ref *AWSIdentityReference) (*AllowedNamespaces, error) {
+	key := client.ObjectKey{Name: ref.Name}
+	switch ref.Kind {
+	case ControllerIdentityKind:
+		identity := &AWSClusterControllerIdentity{}
+		if err := c.Get(ctx, key, identity); err != nil {
+			return nil, err
+		}
+		return identity.Spec.AllowedNamespaces, nil
+	case ClusterRoleIdentityKind:
+		identity := &AWSClusterRoleIdentity{}
+		if err := c.Get(ctx, key, identity); err != nil {
+			return nil, err
+		}
+		return identity.Spec.AllowedNamespaces, nil
+	case ClusterStaticIdentityKind:
+		identity := &AWSClusterStaticIdentity{}
+		if err := c.Get(ctx, key, identity); err != nil {
+			return nil, err
+		}
+		return identity.Spec.AllowedNamespaces, nil
+	case ClusterWebIdentityKind:
+		identity := &AWSClusterWebIdentity{}
+		if err := c.Get(ctx, key, identity); err != nil {
+			return nil, err
+		}
+		return identity.Spec.AllowedNamespaces, nil
+	default:
+		return nil, errors.Errorf("unknown identity kind %q", ref.Kind)
+	}
+}
+
+// validatePrincipalAllowList rejects a cluster whose identity doesn't explicitly allow its namespace while
+// EnforcePrincipalAllowList is set. Clusters without an identity were defaulted to the controller identity, which
+// must then allow the namespace like any other identity. Source identities are only checked by the controllers.
+func (r *AWSClusterWebhook) validatePrincipalAllowList(ctx context.Context, namespace string, ref *AWSIdentityReference, fldPath *field.Path) field.ErrorList {
+	if !r.EnforcePrincipalAllowList {
+		return nil
+	}
+
+	// Fail closed: without a client the identity can't be checked.
+	if r.Client == nil {
+		return field.ErrorList{field.InternalError(fldPath, errors.New("the principal allow-list is enforced but identities cannot be read"))}
+	}
+
+	allowedNs, err := IdentityAllowedNamespaces(ctx, r.Client, ref)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.ErrorList{field.NotFound(fldPath, fmt.Sprintf("%s/%s", ref.Kind, ref.Name))}
+		}
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+
+	allowed, err := allowedNs.AllowsNamespace(ctx, r.Client, namespace, true)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+	if !allowed {
+		return field.ErrorList{field.Forbidden(fldPath,
+			fmt.Sprintf("namespace %q is not allowed to use %s %q: %s", namespace, ref.Kind, ref.Name, allowedNs.NotAllowedReason(namespace)))}
+	}
+	return nil
+}
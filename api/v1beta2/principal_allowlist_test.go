@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidatePrincipalAllowList(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		&AWSClusterControllerIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: AWSClusterControllerIdentityName},
+			Spec: AWSClusterControllerIdentitySpec{
+				AWSClusterIdentitySpec: AWSClusterIdentitySpec{AllowedNamespaces: &AllowedNamespaces{}},
+			},
+		},
+		&AWSClusterRoleIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+			Spec: AWSClusterRoleIdentitySpec{
+				AWSClusterIdentitySpec: AWSClusterIdentitySpec{AllowedNamespaces: &AllowedNamespaces{
+					Selector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				}},
+			},
+		},
+		&AWSClusterStaticIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-b"},
+			Spec: AWSClusterStaticIdentitySpec{
+				AWSClusterIdentitySpec: AWSClusterIdentitySpec{AllowedNamespaces: &AllowedNamespaces{NamespaceList: []string{"team-b"}}},
+			},
+		},
+	).Build()
+
+	tests := []struct {
+		name      string
+		enforce   bool
+		namespace string
+		noClient  bool
+		ref       *AWSIdentityReference
+		wantErr   field.ErrorType
+	}{
+		{
+			name:      "anything is allowed when the allow-list is not enforced",
+			namespace: "team-b",
+			ref:       &AWSIdentityReference{Kind: ClusterRoleIdentityKind, Name: "missing"},
+		},
+		{
+			name:      "the allow-list fails closed without a client",
+			enforce:   true,
+			noClient:  true,
+			namespace: "team-b",
+			ref:       &AWSIdentityReference{Kind: ClusterStaticIdentityKind, Name: "team-b"},
+			wantErr:   field.ErrorTypeInternal,
+		},
+		{
+			name:      "the identity must exist",
+			enforce:   true,
+			namespace: "team-a",
+			ref:       &AWSIdentityReference{Kind: ClusterRoleIdentityKind, Name: "missing"},
+			wantErr:   field.ErrorTypeNotFound,
+		},
+		{
+			name:      "the defaulted controller identity allowing all namespaces is rejected",
+			enforce:   true,
+			namespace: "team-a",
+			ref:       &AWSIdentityReference{Kind: ControllerIdentityKind, Name: AWSClusterControllerIdentityName},
+			wantErr:   field.ErrorTypeForbidden,
+		},
+		{
+			name:      "a namespace selected by the identity is allowed",
+			enforce:   true,
+			namespace: "team-a",
+			ref:       &AWSIdentityReference{Kind: ClusterRoleIdentityKind, Name: "team-a"},
+		},
+		{
+			name:      "a namespace not selected by the identity is rejected",
+			enforce:   true,
+			namespace: "team-b",
+			ref:       &AWSIdentityReference{Kind: ClusterRoleIdentityKind, Name: "team-a"},
+			wantErr:   field.ErrorTypeForbidden,
+		},
+		{
+			name:      "a namespace listed by the identity is allowed",
+			enforce:   true,
+			namespace: "team-b",
+			ref:       &AWSIdentityReference{Kind: ClusterStaticIdentityKind, Name: "team-b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			w := &AWSClusterWebhook{Client: client, EnforcePrincipalAllowList: tt.enforce}
+			if tt.noClient {
+				w.Client = nil
+			}
+
+			errs := w.validatePrincipalAllowList(context.TODO(), tt.namespace, tt.ref, field.NewPath("spec", "identityRef"))
+			if tt.wantErr == "" {
+				g.Expect(errs).To(BeEmpty())
+				return
+			}
+			g.Expect(errs).To(HaveLen(1))
+			g.Expect(errs[0].Type).To(Equal(tt.wantErr))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// ValidatePrivateIPAddressInSubnet ensures that ip is an address of the subnet with the given CIDR block
+// that AWS lets instances use: the first four and the last address of a subnet are reserved by AWS.
+func ValidatePrivateIPAddressInSubnet(ip, cidrBlock string) error {
+	_, subnet, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return fmt.Errorf("invalid subnet CIDR block %q: %w", cidrBlock, err)
+	}
+	addr := net.ParseIP(ip)
+	if addr == nil || addr.To4() == nil {
+		return fmt.Errorf("%q is not an IPv4 address", ip)
+	}
+	if !subnet.Contains(addr) {
+		return fmt.Errorf("private IP address %s is not in the CIDR block %s of the subnet", ip, subnet)
+	}
+
+	ones, bits := subnet.Mask.Size()
+	offset := new(big.Int).Sub(new(big.Int).SetBytes(addr.To4()), new(big.Int).SetBytes(subnet.IP.To4()))
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	if offset.Cmp(big.NewInt(4)) < 0 || offset.Cmp(new(big.Int).Sub(size, big.NewInt(1))) == 0 {
+		return fmt.Errorf("private IP address %s is reserved by AWS in the CIDR block %s of the subnet", ip, subnet)
+	}
+	return nil
+}
+
+// validatePrivateIPAddress validates the private IP address of the machine spec at fldPath.
+func validatePrivateIPAddress(spec *AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.PrivateIPAddress == nil {
+		return allErrs
+	}
+
+	ipPath := fldPath.Child("privateIPAddress")
+	if ip := net.ParseIP(*spec.PrivateIPAddress); ip == nil || ip.To4() == nil {
+		allErrs = append(allErrs, field.Invalid(ipPath, *spec.PrivateIPAddress, "must be an IPv4 address"))
+	}
+	if len(spec.NetworkInterfaces) > 0 {
+		allErrs = append(allErrs, field.Forbidden(ipPath, "cannot be set together with networkInterfaces, the instance gets the address of the first network interface"))
+	}
+
+	return allErrs
+}
+
+// validateUniquePrivateIPAddress rejects a private IP address already used by another AWSMachine of the
+// cluster of machine, found with c through the cluster name label of the machines.
+func validateUniquePrivateIPAddress(ctx context.Context, c client.Reader, machine *AWSMachine) field.ErrorList {
+	clusterName := machine.Labels[clusterv1.ClusterNameLabel]
+	if machine.Spec.PrivateIPAddress == nil || clusterName == "" {
+		return nil
+	}
+
+	ipPath := field.NewPath("spec", "privateIPAddress")
+	machines := &AWSMachineList{}
+	if err := c.List(ctx, machines, client.InNamespace(machine.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName}); err != nil {
+		return field.ErrorList{field.InternalError(ipPath, fmt.Errorf("failed to list the machines of cluster %q: %w", clusterName, err))}
+	}
+
+	for _, other := range machines.Items {
+		if other.Name != machine.Name && other.Spec.PrivateIPAddress != nil && *other.Spec.PrivateIPAddress == *machine.Spec.PrivateIPAddress {
+			return field.ErrorList{field.Duplicate(ipPath, *machine.Spec.PrivateIPAddress)}
+		}
+	}
+	return nil
+}
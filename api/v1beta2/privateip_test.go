@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestValidatePrivateIPAddressInSubnet(t *testing.T) {
+	tests := []struct {
+		name      string
+		ip        string
+		cidrBlock string
+		wantErr   bool
+	}{
+		{
+			name:      "address in the subnet is accepted",
+			ip:        "10.0.1.10",
+			cidrBlock: "10.0.1.0/24",
+		},
+		{
+			name:      "first usable address of the subnet is accepted",
+			ip:        "10.0.1.4",
+			cidrBlock: "10.0.1.0/24",
+		},
+		{
+			name:      "address outside of the subnet is rejected",
+			ip:        "10.0.2.10",
+			cidrBlock: "10.0.1.0/24",
+			wantErr:   true,
+		},
+		{
+			name:      "address reserved at the start of the subnet is rejected",
+			ip:        "10.0.1.3",
+			cidrBlock: "10.0.1.0/24",
+			wantErr:   true,
+		},
+		{
+			name:      "broadcast address of the subnet is rejected",
+			ip:        "10.0.1.255",
+			cidrBlock: "10.0.1.0/24",
+			wantErr:   true,
+		},
+		{
+			name:      "IPv6 address is rejected",
+			ip:        "2001:db8::10",
+			cidrBlock: "10.0.1.0/24",
+			wantErr:   true,
+		},
+		{
+			name:      "invalid subnet CIDR block is rejected",
+			ip:        "10.0.1.10",
+			cidrBlock: "10.0.1.0",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidatePrivateIPAddressInSubnet(tt.ip, tt.cidrBlock)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateUniquePrivateIPAddress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = AddToScheme(scheme)
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "existing",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "test"},
+			},
+			Spec: AWSMachineSpec{PrivateIPAddress: pointer.String("10.0.1.10")},
+		},
+	).Build()
+
+	tests := []struct {
+		name    string
+		cluster string
+		ip      string
+		wantErr bool
+	}{
+		{
+			name:    "address used by another machine of the cluster is rejected",
+			cluster: "test",
+			ip:      "10.0.1.10",
+			wantErr: true,
+		},
+		{
+			name:    "unused address is accepted",
+			cluster: "test",
+			ip:      "10.0.1.11",
+		},
+		{
+			name:    "address used by a machine of another cluster is accepted",
+			cluster: "other",
+			ip:      "10.0.1.10",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "machine",
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: tt.cluster},
+				},
+				Spec: AWSMachineSpec{PrivateIPAddress: pointer.String(tt.ip)},
+			}
+			errs := validateUniquePrivateIPAddress(context.TODO(), client, machine)
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
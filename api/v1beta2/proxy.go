@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ProxyTrustedCAKey is the key of the trusted CA bundle in the Secret referenced by ProxySpec.TrustedCASecretRef.
+const ProxyTrustedCAKey = "ca.crt"
+
+// proxyForbiddenChars are the characters which are not allowed in proxy settings, as they are rendered
+// in single quotes in the environment files of the nodes.
+const proxyForbiddenChars = "' \t\r\n"
+
+// ProxySpec configures the HTTP proxy used by the nodes of a cluster.
+type ProxySpec struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy lists the hosts, domains, IP addresses and CIDR blocks that are reached without the proxy,
+	// e.g. the CIDR block of the VPC, the instance metadata endpoint and the domain of the cluster.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// TrustedCASecretRef references a Secret in the namespace of the cluster whose ca.crt key holds
+	// a bundle of PEM encoded CA certificates trusted by the nodes, e.g. the CA of a TLS
+	// intercepting proxy.
+	// +optional
+	TrustedCASecretRef *corev1.LocalObjectReference `json:"trustedCASecretRef,omitempty"`
+}
+
+// Validate validates the ProxySpec.
+func (p *ProxySpec) Validate(fldPath *field.Path) field.ErrorList {
+	if p == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if p.HTTPProxy == "" && p.HTTPSProxy == "" {
+		errs = append(errs, field.Required(fldPath, "at least one of httpProxy and httpsProxy is required"))
+	}
+	errs = append(errs, validateProxyURL(p.HTTPProxy, fldPath.Child("httpProxy"))...)
+	errs = append(errs, validateProxyURL(p.HTTPSProxy, fldPath.Child("httpsProxy"))...)
+	for i, host := range p.NoProxy {
+		if host == "" || strings.ContainsAny(host, proxyForbiddenChars+",") {
+			errs = append(errs, field.Invalid(fldPath.Child("noProxy").Index(i), host, "must be a non-empty host, domain, IP address or CIDR block"))
+		}
+	}
+	if p.TrustedCASecretRef != nil && p.TrustedCASecretRef.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("trustedCASecretRef", "name"), "name of the Secret is required"))
+	}
+	return errs
+}
+
+func validateProxyURL(value string, fldPath *field.Path) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.ContainsAny(value, proxyForbiddenChars) {
+		return field.ErrorList{field.Invalid(fldPath, value, "must be an http or https URL, with special characters percent-encoded")}
+	}
+	return nil
+}
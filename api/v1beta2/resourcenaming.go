@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+// ResourceNamingScheme defines how names exceeding the length limits of AWS are handled.
+type ResourceNamingScheme string
+
+var (
+	// ResourceNamingSchemeHashed replaces names exceeding the length limits of AWS by a hash of the base name.
+	ResourceNamingSchemeHashed = ResourceNamingScheme("Hashed")
+
+	// ResourceNamingSchemeStable never hashes names, clusters whose names would exceed the limits are rejected.
+	ResourceNamingSchemeStable = ResourceNamingScheme("Stable")
+)
+
+// ResourceNaming configures how the names of the AWS resources of a cluster are generated.
+// Launch templates are named after their machine pool and are not affected.
+type ResourceNaming struct {
+	// BaseName is used instead of the cluster name at the start of the names of the security groups
+	// and of the control plane load balancer, so that they don't depend on the name and namespace
+	// of the cluster. Load balancers of type nlb, alb and elb are otherwise named after the namespace
+	// and name of the cluster, other resources after the name of the cluster.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`
+	// +optional
+	BaseName string `json:"baseName,omitempty"`
+
+	// Scheme defines how names exceeding the length limits of AWS are handled.
+	// With Hashed, the default, they are replaced by a hash of the base name.
+	// With Stable, they are never hashed, and clusters whose names would exceed the limits are rejected.
+	// +kubebuilder:validation:Enum=Hashed;Stable
+	// +optional
+	Scheme ResourceNamingScheme `json:"scheme,omitempty"`
+}
+
+// IsStable returns true if generated names must never be hashed.
+func (n *ResourceNaming) IsStable() bool {
+	return n != nil && n.Scheme == ResourceNamingSchemeStable
+}
+
+// BaseNameOrDefault returns the base name of generated names, or defaultName if none is set.
+func (n *ResourceNaming) BaseNameOrDefault(defaultName string) string {
+	if n == nil || n.BaseName == "" {
+		return defaultName
+	}
+	return n.BaseName
+}
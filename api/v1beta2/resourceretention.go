@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+// RetentionPolicy defines whether a resource is deleted or retained when its owner is deleted.
+type RetentionPolicy string
+
+var (
+	// RetentionPolicyDelete deletes the resource with its owner.
+	RetentionPolicyDelete = RetentionPolicy("Delete")
+
+	// RetentionPolicyRetain keeps the resource in the AWS account when its owner is deleted.
+	RetentionPolicyRetain = RetentionPolicy("Retain")
+)
+
+// ResourceRetentionPolicy defines which classes of resources are retained when the cluster, or the
+// machines they belong to, are deleted. The policy is read at deletion time, so changing it also
+// applies to existing resources.
+type ResourceRetentionPolicy struct {
+	// S3Bucket defines whether the S3 bucket used to store bootstrap data is deleted with the cluster.
+	// The bucket is only deleted when it is empty. Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	S3Bucket RetentionPolicy `json:"s3Bucket,omitempty"`
+
+	// NonRootVolumes defines whether the non root EBS volumes of a machine are deleted when its
+	// instance is terminated. Root volumes are always deleted. Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	NonRootVolumes RetentionPolicy `json:"nonRootVolumes,omitempty"`
+}
+
+// RetainsS3Bucket returns true if the S3 bucket must be kept when the cluster is deleted.
+func (p *ResourceRetentionPolicy) RetainsS3Bucket() bool {
+	return p != nil && p.S3Bucket == RetentionPolicyRetain
+}
+
+// RetainsNonRootVolumes returns true if the non root volumes of machines must be kept when their instances are terminated.
+func (p *ResourceRetentionPolicy) RetainsNonRootVolumes() bool {
+	return p != nil && p.NonRootVolumes == RetentionPolicyRetain
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// ClusterSecurityProfile returns the security profile of the AWSCluster owning obj, found
+// through the cluster name label of obj. It returns an empty profile if obj isn't labelled
+// with its cluster yet, or if the cluster doesn't exist or isn't backed by an AWSCluster.
+func ClusterSecurityProfile(ctx context.Context, c client.Reader, obj metav1.Object) (SecurityProfile, error) {
+	clusterName := obj.GetLabels()[clusterv1.ClusterNameLabel]
+	if c == nil || clusterName == "" {
+		return "", nil
+	}
+
+	cluster := &clusterv1.Cluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: clusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get cluster %q: %w", clusterName, err)
+	}
+
+	ref := cluster.Spec.InfrastructureRef
+	if ref == nil || ref.Kind != "AWSCluster" {
+		return "", nil
+	}
+
+	awsCluster := &AWSCluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: ref.Name}, awsCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get AWSCluster %q: %w", ref.Name, err)
+	}
+
+	return awsCluster.Spec.SecurityProfile, nil
+}
+
+// ValidateMachineSpec rejects the machine settings that violate the profile. The settings
+// left unset are enforced by the controllers when creating the instances.
+func (p SecurityProfile) ValidateMachineSpec(spec *AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if p.RequiresIMDSv2() && spec.InstanceMetadataOptions != nil && spec.InstanceMetadataOptions.HTTPTokens == HTTPTokensStateOptional {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("instanceMetadataOptions", "httpTokens"),
+			fmt.Sprintf("IMDSv2 is required by the %q security profile", p)))
+	}
+
+	if !p.AllowsPublicIP() && spec.PublicIP != nil && *spec.PublicIP {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("publicIP"),
+			fmt.Sprintf("public IPs are not allowed by the %q security profile", p)))
+	}
+
+	allErrs = append(allErrs, p.ValidateVolume(spec.RootVolume, fldPath.Child("rootVolume"))...)
+	for i := range spec.NonRootVolumes {
+		allErrs = append(allErrs, p.ValidateVolume(&spec.NonRootVolumes[i], fldPath.Child("nonRootVolumes").Index(i))...)
+	}
+
+	return allErrs
+}
+
+// ValidateVolume rejects an unencrypted volume if the profile requires encrypted volumes.
+func (p SecurityProfile) ValidateVolume(v *Volume, fldPath *field.Path) field.ErrorList {
+	if !p.RequiresEncryptedVolumes() || v == nil || v.Encrypted == nil || *v.Encrypted {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(fldPath.Child("encrypted"),
+		fmt.Sprintf("volumes must be encrypted by the %q security profile", p))}
+}
+
+// validateMachineSecurityProfile checks the machine spec of obj against the security profile of its cluster.
+func validateMachineSecurityProfile(ctx context.Context, c client.Reader, obj metav1.Object, spec *AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	profile, err := ClusterSecurityProfile(ctx, c, obj)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+	return profile.ValidateMachineSpec(spec, fldPath)
+}
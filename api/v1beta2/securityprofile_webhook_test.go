@@ -0,0 +1,206 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestClusterSecurityProfile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "aws"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{Kind: "AWSCluster", Name: "aws-infra"},
+			},
+		},
+		&AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "aws-infra"},
+			Spec:       AWSClusterSpec{SecurityProfile: SecurityProfileStrict},
+		},
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "eks"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{Kind: "AWSManagedCluster", Name: "eks"},
+			},
+		},
+	).Build()
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   SecurityProfile
+	}{
+		{
+			name:   "profile of the AWSCluster of the cluster",
+			labels: map[string]string{clusterv1.ClusterNameLabel: "aws"},
+			want:   SecurityProfileStrict,
+		},
+		{
+			name: "no cluster label",
+		},
+		{
+			name:   "cluster not found",
+			labels: map[string]string{clusterv1.ClusterNameLabel: "missing"},
+		},
+		{
+			name:   "cluster not backed by an AWSCluster",
+			labels: map[string]string{clusterv1.ClusterNameLabel: "eks"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := &AWSMachine{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "machine", Labels: tt.labels}}
+			profile, err := ClusterSecurityProfile(context.TODO(), client, machine)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(profile).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSecurityProfileValidateMachineSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile SecurityProfile
+		spec    AWSMachineSpec
+		wantErr bool
+	}{
+		{
+			name:    "no profile allows anything",
+			spec:    AWSMachineSpec{PublicIP: pointer.Bool(true), RootVolume: &Volume{Encrypted: pointer.Bool(false)}},
+			wantErr: false,
+		},
+		{
+			name:    "baseline allows unset settings",
+			profile: SecurityProfileBaseline,
+			spec:    AWSMachineSpec{RootVolume: &Volume{Size: 10}},
+			wantErr: false,
+		},
+		{
+			name:    "baseline rejects optional IMDSv2",
+			profile: SecurityProfileBaseline,
+			spec:    AWSMachineSpec{InstanceMetadataOptions: &InstanceMetadataOptions{HTTPTokens: HTTPTokensStateOptional}},
+			wantErr: true,
+		},
+		{
+			name:    "baseline rejects an unencrypted non root volume",
+			profile: SecurityProfileBaseline,
+			spec:    AWSMachineSpec{NonRootVolumes: []Volume{{DeviceName: "/dev/sdb", Encrypted: pointer.Bool(false)}}},
+			wantErr: true,
+		},
+		{
+			name:    "baseline rejects a public IP",
+			profile: SecurityProfileBaseline,
+			spec:    AWSMachineSpec{PublicIP: pointer.Bool(true)},
+			wantErr: true,
+		},
+		{
+			name:    "baseline allows a subnet selected by filters",
+			profile: SecurityProfileBaseline,
+			spec:    AWSMachineSpec{Subnet: &AWSResourceReference{Filters: []Filter{{Name: "tag:Name", Values: []string{"nodes"}}}}},
+			wantErr: false,
+		},
+		{
+			name:    "strict rejects a public IP",
+			profile: SecurityProfileStrict,
+			spec:    AWSMachineSpec{PublicIP: pointer.Bool(true)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.profile.ValidateMachineSpec(&tt.spec, field.NewPath("spec"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAWSMachineWebhookSecurityProfile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "strict"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{Kind: "AWSCluster", Name: "strict"},
+			},
+		},
+		&AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "strict"},
+			Spec:       AWSClusterSpec{SecurityProfile: SecurityProfileStrict},
+		},
+	).Build()
+
+	tests := []struct {
+		name    string
+		cluster string
+		wantErr bool
+	}{
+		{
+			name:    "public IP rejected in a strict cluster",
+			cluster: "strict",
+			wantErr: true,
+		},
+		{
+			name:    "public IP allowed in a cluster without profile",
+			cluster: "missing",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "machine",
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: tt.cluster},
+				},
+				Spec: AWSMachineSpec{InstanceType: "t3.small", PublicIP: pointer.Bool(true)},
+			}
+			err := (&AWSMachineWebhook{Client: client}).ValidateCreate(context.TODO(), machine)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
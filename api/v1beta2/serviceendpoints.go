@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// AWSServiceEndpoint overrides the endpoint an AWS service is reached at, e.g. a VPC endpoint or the
+// endpoint of a partition the AWS SDK doesn't know about.
+type AWSServiceEndpoint struct {
+	// ServiceID is the endpoints ID of the service in the AWS SDK, e.g. "ec2", "elasticloadbalancing" or "sts".
+	// +kubebuilder:validation:MinLength=1
+	ServiceID string `json:"serviceID"`
+
+	// URL is the URL requests to the service are sent to.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// SigningRegion is the region requests to the service are signed for. Defaults to the region of the cluster.
+	// +optional
+	SigningRegion string `json:"signingRegion,omitempty"`
+}
+
+// AWSServiceEndpoints is a list of AWSServiceEndpoint.
+type AWSServiceEndpoints []AWSServiceEndpoint
+
+// Validate validates the service endpoints at fldPath.
+func (e AWSServiceEndpoints) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	serviceIDs := map[string]struct{}{}
+	for i, endpoint := range e {
+		if _, ok := serviceIDs[endpoint.ServiceID]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("serviceID"), endpoint.ServiceID))
+		}
+		serviceIDs[endpoint.ServiceID] = struct{}{}
+
+		if _, err := url.ParseRequestURI(endpoint.URL); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("url"), endpoint.URL, "must be a valid URL"))
+		}
+	}
+
+	return allErrs
+}
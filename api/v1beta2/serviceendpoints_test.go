@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestAWSServiceEndpointsValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints AWSServiceEndpoints
+		wantErr   bool
+	}{
+		{
+			name: "no endpoints",
+		},
+		{
+			name: "endpoints",
+			endpoints: AWSServiceEndpoints{
+				{ServiceID: "ec2", URL: "https://vpce-0123456789abcdef0.ec2.us-east-1.vpce.amazonaws.com"},
+				{ServiceID: "sts", URL: "https://sts.us-gov-west-1.amazonaws.com", SigningRegion: "us-gov-west-1"},
+			},
+		},
+		{
+			name: "duplicate service ID",
+			endpoints: AWSServiceEndpoints{
+				{ServiceID: "ec2", URL: "https://ec2.example.com"},
+				{ServiceID: "ec2", URL: "https://ec2.example.org"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid URL",
+			endpoints: AWSServiceEndpoints{
+				{ServiceID: "ec2", URL: "ec2.example.com"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.endpoints.Validate(field.NewPath("spec", "serviceEndpoints"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
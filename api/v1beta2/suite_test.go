@@ -58,10 +58,10 @@ func setup() {
 	if err != nil {
 		panic(err)
 	}
-	if err := (&AWSCluster{}).SetupWebhookWithManager(testEnv); err != nil {
+	if err := (&AWSClusterWebhook{}).SetupWebhookWithManager(testEnv); err != nil {
 		panic(fmt.Sprintf("Unable to setup AWSCluster webhook: %v", err))
 	}
-	if err := (&AWSMachine{}).SetupWebhookWithManager(testEnv); err != nil {
+	if err := (&AWSMachineWebhook{}).SetupWebhookWithManager(testEnv); err != nil {
 		panic(fmt.Sprintf("Unable to setup AWSMachine webhook: %v", err))
 	}
 	if err := (&AWSMachineTemplateWebhook{}).SetupWebhookWithManager(testEnv); err != nil {
@@ -76,6 +76,9 @@ func setup() {
 	if err := (&AWSClusterStaticIdentity{}).SetupWebhookWithManager(testEnv); err != nil {
 		panic(fmt.Sprintf("Unable to setup AWSClusterStaticIdentity webhook: %v", err))
 	}
+	if err := (&AWSClusterWebIdentity{}).SetupWebhookWithManager(testEnv); err != nil {
+		panic(fmt.Sprintf("Unable to setup AWSClusterWebIdentity webhook: %v", err))
+	}
 
 	go func() {
 		fmt.Println("Starting the manager")
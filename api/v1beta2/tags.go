@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"regexp"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -29,6 +30,24 @@ import (
 // Tags defines a map of tags.
 type Tags map[string]string
 
+// AdditionalTagsSource references a source of additional tags maintained outside of the cluster spec.
+type AdditionalTagsSource struct {
+	// ConfigMapRef references a ConfigMap in the namespace of the cluster whose data is used as additional tags.
+	ConfigMapRef corev1.LocalObjectReference `json:"configMapRef"`
+}
+
+// Validate validates the AdditionalTagsSource.
+func (s *AdditionalTagsSource) Validate(fldPath *field.Path) field.ErrorList {
+	if s == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if s.ConfigMapRef.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("configMapRef", "name"), "name of the ConfigMap is required"))
+	}
+	return errs
+}
+
 // HasOwned returns true if the tags contains a tag that marks the resource as owned by the cluster from the perspective of this management tooling.
 func (t Tags) HasOwned(cluster string) bool {
 	value, ok := t[ClusterTagKey(cluster)]
@@ -170,6 +189,11 @@ const (
 	// dedicated to this cluster api provider implementation.
 	NameAWSSubnetAssociation = NameAWSProviderPrefix + "association"
 
+	// NameAWSAdditionalNetworkInterface is the tag name we use to mark the network interfaces
+	// created from the additional network interfaces of a machine, whose security groups are
+	// not managed together with the ones of the machine. The tag value is the device index.
+	NameAWSAdditionalNetworkInterface = NameAWSProviderPrefix + "additional-network-interface"
+
 	// SecondarySubnetTagValue is the secondary subnet tag constant value.
 	SecondarySubnetTagValue = "secondary"
 
@@ -188,6 +212,9 @@ const (
 	// PrivateRoleTagValue describes the value for the private role.
 	PrivateRoleTagValue = "private"
 
+	// NATInstanceRoleTagValue describes the value for the NAT instance role.
+	NATInstanceRoleTagValue = "nat-instance"
+
 	// MachineNameTagKey is the key for machine name.
 	MachineNameTagKey = "MachineName"
 )
@@ -79,6 +79,9 @@ var (
 
 	// AZSelectionSchemeRandom will select AZs randomly.
 	AZSelectionSchemeRandom = AZSelectionScheme("Random")
+
+	// AZSelectionSchemeExplicit will select the AZs listed in the VPC spec.
+	AZSelectionSchemeExplicit = AZSelectionScheme("Explicit")
 )
 
 // InstanceState describes the state of an AWS instance.
@@ -185,6 +188,11 @@ type Instance struct {
 	// Specifies ENIs attached to instance
 	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
 
+	// AdditionalNetworkInterfaces are the network interfaces created and attached to the instance
+	// when it is launched, with their subnet and security groups resolved to IDs.
+	// +optional
+	AdditionalNetworkInterfaces []NetworkInterfaceSpec `json:"additionalNetworkInterfaces,omitempty"`
+
 	// The tags associated with the instance.
 	Tags map[string]string `json:"tags,omitempty"`
 
@@ -205,6 +213,22 @@ type Instance struct {
 	// InstanceMetadataOptions is the metadata options for the EC2 instance.
 	// +optional
 	InstanceMetadataOptions *InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+
+	// CPUOptions are the number of CPU cores and threads per core of the instance.
+	// +optional
+	CPUOptions *CPUOptions `json:"cpuOptions,omitempty"`
+
+	// EnclaveOptions are the AWS Nitro Enclaves settings of the instance.
+	// +optional
+	EnclaveOptions *EnclaveOptions `json:"enclaveOptions,omitempty"`
+
+	// HibernationOptions are the hibernation settings of the instance.
+	// +optional
+	HibernationOptions *HibernationOptions `json:"hibernationOptions,omitempty"`
+
+	// CreditSpecification is the credit option for CPU usage the instance is launched with.
+	// +optional
+	CreditSpecification *CreditSpecification `json:"creditSpecification,omitempty"`
 }
 
 // InstanceMetadataState describes the state of InstanceMetadataOptions.HttpEndpoint and InstanceMetadataOptions.InstanceMetadataTags
@@ -311,10 +335,13 @@ type Volume struct {
 	Type VolumeType `json:"type,omitempty"`
 
 	// IOPS is the number of IOPS requested for the disk. Not applicable to all types.
+	// It is validated against the limits of the volume type, e.g. up to 500 IOPS per GiB for gp3,
+	// 50 for io1 and 1000 for io2, which is created as io2 Block Express.
 	// +optional
 	IOPS int64 `json:"iops,omitempty"`
 
-	// Throughput to provision in MiB/s supported for the volume type. Not applicable to all types.
+	// Throughput to provision in MiB/s supported for the volume type. Only applicable to gp3 volumes,
+	// for which it must be between 125 and 1000 MiB/s and at most 0.25 MiB/s per provisioned IOPS.
 	// +optional
 	Throughput *int64 `json:"throughput,omitempty"`
 
@@ -359,6 +386,37 @@ var (
 	)
 )
 
+// NetworkInterfaceSpec defines a network interface created and attached to an instance
+// in addition to its primary network interface.
+type NetworkInterfaceSpec struct {
+	// DeviceIndex is the position of the network interface in the attachment order of the instance.
+	// The primary network interface is attached at index 0.
+	// +kubebuilder:validation:Minimum=1
+	DeviceIndex int64 `json:"deviceIndex"`
+
+	// Subnet is a reference to the subnet of the network interface. It must be in the
+	// availability zone of the instance. If not specified, the subnet of the instance is used.
+	// +optional
+	Subnet *AWSResourceReference `json:"subnet,omitempty"`
+
+	// SecondaryPrivateIPAddressCount is the number of secondary private IPv4 addresses
+	// assigned to the network interface, in addition to its primary private IPv4 address.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SecondaryPrivateIPAddressCount *int64 `json:"secondaryPrivateIPAddressCount,omitempty"`
+
+	// SecurityGroups are references to the security groups of the network interface.
+	// If not specified, the security groups managed by the provider for the instance are used.
+	// The security groups of additional network interfaces aren't updated after the instance is created.
+	// +optional
+	SecurityGroups []AWSResourceReference `json:"securityGroups,omitempty"`
+
+	// DeleteOnTermination is whether the network interface is deleted when the instance is terminated.
+	// Defaults to true.
+	// +optional
+	DeleteOnTermination *bool `json:"deleteOnTermination,omitempty"`
+}
+
 // SpotMarketOptions defines the options available to a user when configuring
 // Machines to run on Spot instances.
 // Most users should provide an empty struct.
@@ -369,6 +427,49 @@ type SpotMarketOptions struct {
 	MaxPrice *string `json:"maxPrice,omitempty"`
 }
 
+// CPUOptions defines the number of CPU cores and threads per core of an instance.
+type CPUOptions struct {
+	// CoreCount is the number of CPU cores of the instance. It must be supported by the instance type.
+	// +kubebuilder:validation:Minimum=1
+	CoreCount int64 `json:"coreCount"`
+
+	// ThreadsPerCore is the number of threads per CPU core. Set it to 1 to disable multithreading.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2
+	ThreadsPerCore int64 `json:"threadsPerCore"`
+}
+
+// EnclaveOptions defines the AWS Nitro Enclaves settings of an instance.
+type EnclaveOptions struct {
+	// Enabled enables AWS Nitro Enclaves on the instance.
+	Enabled bool `json:"enabled"`
+}
+
+// HibernationOptions defines the hibernation settings of an instance.
+type HibernationOptions struct {
+	// Configured enables hibernation of the instance.
+	Configured bool `json:"configured"`
+}
+
+// CPUCredits describes the credit option for CPU usage of a burstable performance instance.
+type CPUCredits string
+
+const (
+	// CPUCreditsStandard limits the CPU usage of the instance to its baseline once its earned
+	// credits are spent.
+	CPUCreditsStandard = CPUCredits("standard")
+	// CPUCreditsUnlimited lets the instance burst above its baseline for as long as needed,
+	// surplus credits being charged.
+	CPUCreditsUnlimited = CPUCredits("unlimited")
+)
+
+// CreditSpecification defines the credit option for CPU usage of a burstable performance instance.
+type CreditSpecification struct {
+	// CPUCredits is the credit option for CPU usage of the instance.
+	// +kubebuilder:validation:Enum=standard;unlimited
+	CPUCredits CPUCredits `json:"cpuCredits"`
+}
+
 // EKSAMILookupType specifies which AWS AMI to use for a AWSMachine and AWSMachinePool.
 type EKSAMILookupType string
 
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// volumeLimits describes the performance limits of an EBS volume type.
+// See: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ebs-volume-types.html
+type volumeLimits struct {
+	minIOPS, maxIOPS int64
+	// maxIOPSPerGiB is the maximum ratio of provisioned IOPS to volume size.
+	maxIOPSPerGiB int64
+	// maxSize is the maximum volume size in GiB.
+	maxSize int64
+}
+
+var volumeTypeLimits = map[VolumeType]volumeLimits{
+	VolumeTypeGP3: {minIOPS: 3000, maxIOPS: 16000, maxIOPSPerGiB: 500, maxSize: 16384},
+	VolumeTypeIO1: {minIOPS: 100, maxIOPS: 64000, maxIOPSPerGiB: 50, maxSize: 16384},
+	// io2 volumes are created as io2 Block Express volumes.
+	VolumeTypeIO2: {minIOPS: 100, maxIOPS: 256000, maxIOPSPerGiB: 1000, maxSize: 65536},
+}
+
+const (
+	// gp3MinThroughput and gp3MaxThroughput are the throughput limits of gp3 volumes, in MiB/s.
+	gp3MinThroughput = 125
+	gp3MaxThroughput = 1000
+	// gp3BaselineIOPS is the IOPS of a gp3 volume which doesn't provision any.
+	gp3BaselineIOPS = 3000
+	// gp3MaxThroughputPerIOPS is the maximum ratio of provisioned throughput to IOPS of gp3 volumes.
+	gp3MaxThroughputPerIOPS = 0.25
+)
+
+// ValidatePerformance validates the size, IOPS and throughput of the volume against
+// the limits of its volume type.
+func (v *Volume) ValidatePerformance(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if v == nil {
+		return allErrs
+	}
+
+	limits, ok := volumeTypeLimits[v.Type]
+	if !ok {
+		return allErrs
+	}
+
+	if v.Size > limits.maxSize {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), v.Size,
+			fmt.Sprintf("must be at most %d GiB for type '%s'", limits.maxSize, v.Type)))
+	}
+
+	if v.IOPS != 0 {
+		if v.IOPS < limits.minIOPS || v.IOPS > limits.maxIOPS {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("iops"), v.IOPS,
+				fmt.Sprintf("must be between %d and %d for type '%s'", limits.minIOPS, limits.maxIOPS, v.Type)))
+		}
+		// The size of a root volume can be left unset to use the size of the image.
+		if v.Size != 0 && v.IOPS > v.Size*limits.maxIOPSPerGiB {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("iops"), v.IOPS,
+				fmt.Sprintf("must be at most %d IOPS per GiB of volume size for type '%s'", limits.maxIOPSPerGiB, v.Type)))
+		}
+	}
+
+	if v.Type == VolumeTypeGP3 && v.Throughput != nil {
+		throughput := *v.Throughput
+		if throughput < gp3MinThroughput || throughput > gp3MaxThroughput {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("throughput"), throughput,
+				fmt.Sprintf("must be between %d and %d MiB/s for type '%s'", gp3MinThroughput, gp3MaxThroughput, v.Type)))
+		}
+
+		iops := v.IOPS
+		if iops == 0 {
+			iops = gp3BaselineIOPS
+		}
+		if float64(throughput) > float64(iops)*gp3MaxThroughputPerIOPS {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("throughput"), throughput,
+				fmt.Sprintf("must be at most %v MiB/s per provisioned IOPS for type '%s'", gp3MaxThroughputPerIOPS, v.Type)))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// EnforceVolumeEncryptionAnnotation enforces the encryption of the volumes of the machines of a cluster
+// when set to "true" on its Cluster.
+const EnforceVolumeEncryptionAnnotation = "aws.cluster.x-k8s.io/enforce-volume-encryption"
+
+// VolumeEncryptionEnforced reports whether the volumes of obj must be encrypted, either because
+// enforceAll is set from the --enforce-volume-encryption flag, or because the Cluster of obj, found
+// with c through the cluster name label of obj, has the EnforceVolumeEncryptionAnnotation.
+func VolumeEncryptionEnforced(ctx context.Context, c client.Reader, enforceAll bool, obj metav1.Object) (bool, error) {
+	if enforceAll {
+		return true, nil
+	}
+
+	clusterName := obj.GetLabels()[clusterv1.ClusterNameLabel]
+	if c == nil || clusterName == "" {
+		return false, nil
+	}
+
+	cluster := &clusterv1.Cluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: clusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get cluster %q: %w", clusterName, err)
+	}
+	return cluster.Annotations[EnforceVolumeEncryptionAnnotation] == "true", nil
+}
+
+// ValidateEncryptedRootVolume rejects a root volume that isn't explicitly encrypted. The root volume
+// must be set, as it is otherwise created from the snapshot of the AMI, which may not be encrypted.
+func ValidateEncryptedRootVolume(v *Volume, fldPath *field.Path) field.ErrorList {
+	if v == nil {
+		return field.ErrorList{field.Required(fldPath, "must be set with encrypted: true when volume encryption is enforced")}
+	}
+	return ValidateEncryptedVolume(v, fldPath)
+}
+
+// ValidateEncryptedVolume rejects a volume that isn't explicitly encrypted.
+func ValidateEncryptedVolume(v *Volume, fldPath *field.Path) field.ErrorList {
+	if v.Encrypted == nil || !*v.Encrypted {
+		return field.ErrorList{field.Required(fldPath.Child("encrypted"), "must be true when volume encryption is enforced")}
+	}
+	return nil
+}
+
+// validateMachineVolumeEncryption rejects the unencrypted volumes of the machine spec of obj when
+// volume encryption is enforced for obj.
+func validateMachineVolumeEncryption(ctx context.Context, c client.Reader, enforceAll bool, obj metav1.Object, spec *AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	enforced, err := VolumeEncryptionEnforced(ctx, c, enforceAll, obj)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+	if !enforced {
+		return nil
+	}
+
+	allErrs := ValidateEncryptedRootVolume(spec.RootVolume, fldPath.Child("rootVolume"))
+	for i := range spec.NonRootVolumes {
+		allErrs = append(allErrs, ValidateEncryptedVolume(&spec.NonRootVolumes[i], fldPath.Child("nonRootVolumes").Index(i))...)
+	}
+	return allErrs
+}
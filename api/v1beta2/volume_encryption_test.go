@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestVolumeEncryptionEnforced(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "enforced",
+				Annotations: map[string]string{EnforceVolumeEncryptionAnnotation: "true"},
+			},
+		},
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "not-enforced"},
+		},
+	).Build()
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		flag   bool
+		want   bool
+	}{
+		{
+			name:   "annotated cluster",
+			labels: map[string]string{clusterv1.ClusterNameLabel: "enforced"},
+			want:   true,
+		},
+		{
+			name:   "cluster without the annotation",
+			labels: map[string]string{clusterv1.ClusterNameLabel: "not-enforced"},
+		},
+		{
+			name:   "cluster not found",
+			labels: map[string]string{clusterv1.ClusterNameLabel: "missing"},
+		},
+		{
+			name: "no cluster label",
+		},
+		{
+			name: "enforced for every cluster",
+			flag: true,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := &AWSMachine{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "machine", Labels: tt.labels}}
+			enforced, err := VolumeEncryptionEnforced(context.TODO(), client, tt.flag, machine)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(enforced).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestValidateMachineVolumeEncryption(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     AWSMachineSpec
+		wantErrs []string
+	}{
+		{
+			name: "encrypted volumes",
+			spec: AWSMachineSpec{
+				RootVolume:     &Volume{Size: 8, Encrypted: pointer.Bool(true)},
+				NonRootVolumes: []Volume{{DeviceName: "/dev/sdb", Size: 8, Encrypted: pointer.Bool(true)}},
+			},
+		},
+		{
+			name:     "no root volume",
+			spec:     AWSMachineSpec{},
+			wantErrs: []string{"spec.rootVolume"},
+		},
+		{
+			name: "unencrypted root volume",
+			spec: AWSMachineSpec{
+				RootVolume: &Volume{Size: 8, Encrypted: pointer.Bool(false)},
+			},
+			wantErrs: []string{"spec.rootVolume.encrypted"},
+		},
+		{
+			name: "additional volume without encryption",
+			spec: AWSMachineSpec{
+				RootVolume:     &Volume{Size: 8, Encrypted: pointer.Bool(true)},
+				NonRootVolumes: []Volume{{DeviceName: "/dev/sdb", Size: 8}},
+			},
+			wantErrs: []string{"spec.nonRootVolumes[0].encrypted"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := &AWSMachine{Spec: tt.spec}
+			errs := validateMachineVolumeEncryption(context.TODO(), nil, true, machine, &machine.Spec, field.NewPath("spec"))
+			fields := []string{}
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tt.wantErrs))
+		})
+	}
+}
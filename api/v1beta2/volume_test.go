@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestVolumeValidatePerformance(t *testing.T) {
+	tests := []struct {
+		name    string
+		volume  *Volume
+		wantErr bool
+	}{
+		{
+			name:   "nil volume",
+			volume: nil,
+		},
+		{
+			name:   "gp3 with valid throughput and iops",
+			volume: &Volume{Type: VolumeTypeGP3, Size: 100, IOPS: 4000, Throughput: aws.Int64(1000)},
+		},
+		{
+			name:   "gp3 with throughput within the baseline iops ratio",
+			volume: &Volume{Type: VolumeTypeGP3, Size: 100, Throughput: aws.Int64(750)},
+		},
+		{
+			name:    "gp3 with throughput above the baseline iops ratio",
+			volume:  &Volume{Type: VolumeTypeGP3, Size: 100, Throughput: aws.Int64(1000)},
+			wantErr: true,
+		},
+		{
+			name:    "gp3 with throughput below minimum",
+			volume:  &Volume{Type: VolumeTypeGP3, Size: 100, Throughput: aws.Int64(100)},
+			wantErr: true,
+		},
+		{
+			name:    "gp3 with iops above maximum",
+			volume:  &Volume{Type: VolumeTypeGP3, Size: 100, IOPS: 20000},
+			wantErr: true,
+		},
+		{
+			name:    "gp3 with iops above size ratio",
+			volume:  &Volume{Type: VolumeTypeGP3, Size: 8, IOPS: 5000},
+			wantErr: true,
+		},
+		{
+			name:    "io1 with iops above size ratio",
+			volume:  &Volume{Type: VolumeTypeIO1, Size: 100, IOPS: 6000},
+			wantErr: true,
+		},
+		{
+			name:   "io2 block express with high iops",
+			volume: &Volume{Type: VolumeTypeIO2, Size: 256, IOPS: 256000},
+		},
+		{
+			name:    "io2 with iops above size ratio",
+			volume:  &Volume{Type: VolumeTypeIO2, Size: 100, IOPS: 200000},
+			wantErr: true,
+		},
+		{
+			name:    "io2 with size above maximum",
+			volume:  &Volume{Type: VolumeTypeIO2, Size: 70000, IOPS: 1000},
+			wantErr: true,
+		},
+		{
+			name:   "root volume without size",
+			volume: &Volume{Type: VolumeTypeIO2, IOPS: 64000},
+		},
+		{
+			name:   "gp2 is not validated",
+			volume: &Volume{Type: VolumeTypeGP2, Size: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.volume.ValidatePerformance(field.NewPath("spec", "rootVolume"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -61,6 +61,9 @@ func (r *EKSConfig) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.NTP != nil {
 		dst.Spec.NTP = restored.Spec.NTP
 	}
+	if restored.Spec.RegistryMirrors != nil {
+		dst.Spec.RegistryMirrors = restored.Spec.RegistryMirrors
+	}
 
 	return nil
 }
@@ -128,6 +131,9 @@ func (r *EKSConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.Template.Spec.NTP != nil {
 		dst.Spec.Template.Spec.NTP = restored.Spec.Template.Spec.NTP
 	}
+	if restored.Spec.Template.Spec.RegistryMirrors != nil {
+		dst.Spec.Template.Spec.RegistryMirrors = restored.Spec.Template.Spec.RegistryMirrors
+	}
 
 	return nil
 }
@@ -74,6 +74,12 @@ type EKSConfigSpec struct {
 	// NTP specifies NTP configuration
 	// +optional
 	NTP *NTP `json:"ntp,omitempty"`
+	// RegistryMirrors configures containerd to pull images for the given registries
+	// through mirror endpoints, for example an ECR pull through cache.
+	// +optional
+	// +listType=map
+	// +listMapKey=registry
+	RegistryMirrors []RegistryMirror `json:"registryMirrors,omitempty"`
 }
 
 // PauseContainer contains details of pause container.
@@ -84,6 +90,19 @@ type PauseContainer struct {
 	Version string `json:"version"`
 }
 
+// RegistryMirror defines the mirror endpoints containerd uses to pull images of a registry.
+type RegistryMirror struct {
+	// Registry is the host of the upstream registry, e.g. "docker.io" or "public.ecr.aws".
+	// +kubebuilder:validation:MinLength=1
+	Registry string `json:"registry"`
+
+	// Endpoints is the ordered list of mirror URLs tried before falling back to the
+	// upstream registry. URLs with a path must include the /v2 API root,
+	// e.g. "https://111122223333.dkr.ecr.us-west-2.amazonaws.com/v2/docker-hub".
+	// +kubebuilder:validation:MinItems=1
+	Endpoints []string `json:"endpoints"`
+}
+
 // EKSConfigStatus defines the observed state of the Amazon EKS Bootstrap Configuration.
 type EKSConfigStatus struct {
 	// Ready indicates the BootstrapData secret is ready to be consumed
@@ -30,7 +30,7 @@ const (
 	defaultBootstrapCommand = "/etc/eks/bootstrap.sh"
 
 	nodeUserData = `#cloud-config
-{{template "files" .Files}}
+{{template "files" .AllFiles}}
 runcmd:
 {{- template "commands" .PreBootstrapCommands }}
   - {{ .BootstrapCommand }} {{.ClusterName}} {{- template "args" . }}
@@ -66,6 +66,7 @@ type NodeInput struct {
 	Mounts                   []eksbootstrapv1.MountPoints
 	Users                    []eksbootstrapv1.User
 	NTP                      *eksbootstrapv1.NTP
+	RegistryMirrors          []eksbootstrapv1.RegistryMirror
 }
 
 func (ni *NodeInput) DockerConfigJSONEscaped() string {
@@ -84,6 +85,13 @@ func (ni *NodeInput) BootstrapCommand() string {
 	return defaultBootstrapCommand
 }
 
+// AllFiles returns the files to write on the node, including the containerd
+// configuration of any registry mirrors.
+func (ni *NodeInput) AllFiles() []eksbootstrapv1.File {
+	files := append([]eksbootstrapv1.File{}, ni.Files...)
+	return append(files, registryMirrorFiles(ni.RegistryMirrors)...)
+}
+
 // NewNode returns the user data string to be used on a node instance.
 func NewNode(input *NodeInput) ([]byte, error) {
 	tm := template.New("Node").Funcs(defaultTemplateFuncMap)
@@ -369,6 +369,33 @@ runcmd:
 users:
   - name: testuser
     shell: /bin/bash
+`),
+		},
+		{
+			name: "with registry mirrors",
+			args: args{
+				input: &NodeInput{
+					ClusterName: "test-cluster",
+					RegistryMirrors: []eksbootstrapv1.RegistryMirror{
+						{
+							Registry:  "docker.io",
+							Endpoints: []string{"https://111122223333.dkr.ecr.us-west-2.amazonaws.com/v2/docker-hub"},
+						},
+					},
+				},
+			},
+			expectedBytes: []byte(`#cloud-config
+write_files:
+  - path: /etc/containerd/certs.d/docker.io/hosts.toml
+    owner: root:root
+    permissions: '0644'
+    content: |
+      server = "https://registry-1.docker.io"
+      [host."https://111122223333.dkr.ecr.us-west-2.amazonaws.com/v2/docker-hub"]
+        capabilities = ["pull", "resolve"]
+        override_path = true
+runcmd:
+  - /etc/eks/bootstrap.sh test-cluster
 `),
 		},
 	}
@@ -0,0 +1,65 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+const (
+	containerdCertsDir = "/etc/containerd/certs.d"
+	dockerHubRegistry  = "docker.io"
+	dockerHubServer    = "https://registry-1.docker.io"
+)
+
+// registryMirrorFiles returns the containerd hosts.toml files configuring the
+// mirror endpoints of each registry.
+func registryMirrorFiles(mirrors []eksbootstrapv1.RegistryMirror) []eksbootstrapv1.File {
+	files := make([]eksbootstrapv1.File, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		server := "https://" + mirror.Registry
+		if mirror.Registry == dockerHubRegistry {
+			server = dockerHubServer
+		}
+
+		lines := []string{fmt.Sprintf("server = %q", server)}
+		for _, endpoint := range mirror.Endpoints {
+			lines = append(lines,
+				fmt.Sprintf("[host.%q]", endpoint),
+				`  capabilities = ["pull", "resolve"]`,
+			)
+			// Endpoints with a path, such as an ECR pull through cache prefix,
+			// already include the /v2 API root.
+			if u, err := url.Parse(endpoint); err == nil && strings.Trim(u.Path, "/") != "" {
+				lines = append(lines, "  override_path = true")
+			}
+		}
+
+		files = append(files, eksbootstrapv1.File{
+			Path:        path.Join(containerdCertsDir, mirror.Registry, "hosts.toml"),
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     strings.Join(lines, "\n"),
+		})
+	}
+	return files
+}
@@ -18,21 +18,39 @@ package ami
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	amiv1 "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/ami/v1beta1"
 	ec2service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api/util"
 )
 
+const (
+	// SourceImageIDTag is the tag recording the ID of the AMI a copied AMI was created from.
+	SourceImageIDTag = "sigs.k8s.io/cluster-api-provider-aws/ami/source-image-id"
+
+	// SourceRegionTag is the tag recording the region of the AMI a copied AMI was created from.
+	SourceRegionTag = "sigs.k8s.io/cluster-api-provider-aws/ami/source-region"
+
+	// OSTag is the tag recording the operating system of a copied AMI.
+	OSTag = "sigs.k8s.io/cluster-api-provider-aws/ami/os"
+
+	// KubernetesVersionTag is the tag recording the Kubernetes version of a copied AMI.
+	KubernetesVersionTag = "sigs.k8s.io/cluster-api-provider-aws/ami/kubernetes-version"
+)
+
 // CopyInput defines input that can be copied to create an AWSAMI.
 type CopyInput struct {
 	SourceRegion      string
@@ -87,6 +105,7 @@ func Copy(input CopyInput) (*amiv1.AWSAMI, error) {
 			sourceRegion: input.SourceRegion,
 			image:        image,
 			dryRun:       input.DryRun,
+			kmsKeyID:     input.KmsKeyID,
 			sess:         destSession,
 			log:          input.Log,
 		})
@@ -96,6 +115,21 @@ func Copy(input CopyInput) (*amiv1.AWSAMI, error) {
 		return nil, err
 	}
 
+	var newImageOwnerID string
+	if !input.DryRun {
+		// Tag the copy with the metadata of the source AMI, as the name of the copy, looked up by the
+		// controllers, doesn't record where it was copied from.
+		if err := tagImage(ec2.New(destSession), newImageID, copyTags(input, image)); err != nil {
+			return nil, err
+		}
+
+		identity, err := sts.New(destSession).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the account of the copied AMI")
+		}
+		newImageOwnerID = aws.StringValue(identity.Account)
+	}
+
 	ami := amiv1.AWSAMI{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:              newImageName,
@@ -110,6 +144,7 @@ func Copy(input CopyInput) (*amiv1.AWSAMI, error) {
 			Region:            input.DestinationRegion,
 			ImageID:           newImageID,
 			KubernetesVersion: input.KubernetesVersion,
+			OwnerID:           newImageOwnerID,
 		},
 	}
 
@@ -120,8 +155,60 @@ func Copy(input CopyInput) (*amiv1.AWSAMI, error) {
 	return &ami, err
 }
 
+// MachineTemplate returns an AWSMachineTemplate looking up the given copied AMI, which can be used as a
+// starting point for the machine templates of the clusters using it.
+func MachineTemplate(ami *amiv1.AWSAMI) *infrav1.AWSMachineTemplate {
+	return &infrav1.AWSMachineTemplate{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "AWSMachineTemplate",
+			APIVersion: infrav1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(fmt.Sprintf("%s-%s", ami.Spec.OS, ami.Spec.KubernetesVersion)),
+		},
+		Spec: infrav1.AWSMachineTemplateSpec{
+			Template: infrav1.AWSMachineTemplateResource{
+				Spec: infrav1.AWSMachineSpec{
+					ImageLookupOrg:    ami.Spec.OwnerID,
+					ImageLookupBaseOS: ami.Spec.OS,
+				},
+			},
+		},
+	}
+}
+
+func copyTags(input CopyInput, image *ec2.Image) map[string]string {
+	return map[string]string{
+		SourceImageIDTag:     aws.StringValue(image.ImageId),
+		SourceRegionTag:      input.SourceRegion,
+		OSTag:                input.OperatingSystem,
+		KubernetesVersionTag: input.KubernetesVersion,
+	}
+}
+
+func tagImage(ec2Client *ec2.EC2, imageID string, tags map[string]string) error {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	input := &ec2.CreateTagsInput{
+		Resources: []*string{aws.String(imageID)},
+	}
+	for _, k := range keys {
+		input.Tags = append(input.Tags, &ec2.Tag{Key: aws.String(k), Value: aws.String(tags[k])})
+	}
+
+	if _, err := ec2Client.CreateTags(input); err != nil {
+		return errors.Wrapf(err, "failed to tag AMI %q", imageID)
+	}
+	return nil
+}
+
 type copyWithoutSnapshotInput struct {
 	sourceRegion string
+	kmsKeyID     string
 	dryRun       bool
 	log          logr.Logger
 	sess         *session.Session
@@ -138,6 +225,10 @@ func copyWithoutSnapshot(input copyWithoutSnapshotInput) (string, string, error)
 		SourceImageId: input.image.ImageId,
 		SourceRegion:  aws.String(input.sourceRegion),
 	}
+	if input.kmsKeyID != "" {
+		in2.Encrypted = aws.Bool(true)
+		in2.KmsKeyId = aws.String(input.kmsKeyID)
+	}
 	log := input.log.WithValues("imageName", imgName)
 	log.Info("Copying the retrieved image", "imageID", aws.StringValue(input.image.ImageId), "ownerID", aws.StringValue(input.image.OwnerId))
 	out, err := ec2Client.CopyImage(in2)
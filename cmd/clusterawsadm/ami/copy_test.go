@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ami
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	amiv1 "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/ami/v1beta1"
+)
+
+func TestMachineTemplate(t *testing.T) {
+	ami := &amiv1.AWSAMI{
+		Spec: amiv1.AWSAMISpec{
+			OS:                "ubuntu-20.04",
+			Region:            "eu-west-1",
+			ImageID:           "ami-1234567890",
+			KubernetesVersion: "v1.25.3",
+			OwnerID:           "123456789012",
+		},
+	}
+
+	got := MachineTemplate(ami)
+	if got.Name != "ubuntu-20.04-v1.25.3" {
+		t.Errorf("MachineTemplate() name = %q, want %q", got.Name, "ubuntu-20.04-v1.25.3")
+	}
+	want := infrav1.AWSMachineSpec{
+		ImageLookupOrg:    "123456789012",
+		ImageLookupBaseOS: "ubuntu-20.04",
+	}
+	if !cmp.Equal(got.Spec.Template.Spec, want) {
+		t.Errorf("MachineTemplate() spec = %v, want %v", got.Spec.Template.Spec, want)
+	}
+}
@@ -122,6 +122,7 @@ func List(input ListInput) (*amiv1.AWSAMIList, error) {
 						Region:            region,
 						ImageID:           aws.StringValue(image.ImageId),
 						KubernetesVersion: version,
+						OwnerID:           aws.StringValue(image.OwnerId),
 					},
 				})
 			}
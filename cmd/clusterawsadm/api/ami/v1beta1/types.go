@@ -36,6 +36,7 @@ type AWSAMISpec struct {
 	Region            string `json:"region"`
 	ImageID           string `json:"imageID"`
 	KubernetesVersion string `json:"kubernetesVersion"`
+	OwnerID           string `json:"ownerID,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -170,6 +170,27 @@ type S3Buckets struct {
 	NamePrefix string `json:"namePrefix"`
 }
 
+// ECRConfig controls the Amazon Elastic Container Registry permissions granted to
+// the instance profiles of machines created by Kubernetes Cluster API Provider AWS.
+// When set, the nodes role is always granted registry access.
+type ECRConfig struct {
+	// ControlPlane controls whether the control plane role is granted read access to ECR,
+	// in addition to the nodes role. Defaults to false.
+	// +optional
+	ControlPlane bool `json:"controlPlane,omitempty"`
+
+	// PolicyARNs is a list of IAM policy ARNs granting access to ECR. When set, they are
+	// attached in place of the AmazonEC2ContainerRegistryReadOnly managed policy, which
+	// allows registry access to be scoped, for example to repositories in another account.
+	// +optional
+	PolicyARNs []string `json:"policyARNs,omitempty"`
+
+	// PullThroughCache controls whether the roles are granted the permissions needed to
+	// create repositories and import images through ECR pull through cache rules.
+	// +optional
+	PullThroughCache bool `json:"pullThroughCache,omitempty"`
+}
+
 // AWSIAMConfigurationSpec defines the specification of the AWSIAMConfiguration.
 type AWSIAMConfigurationSpec struct {
 	// NamePrefix will be prepended to every AWS IAM role, user and policy created by clusterawsadm. Defaults to "".
@@ -179,6 +200,16 @@ type AWSIAMConfigurationSpec struct {
 	// ".cluster-api-provider-aws.sigs.k8s.io".
 	NameSuffix *string `json:"nameSuffix,omitempty"`
 
+	// PermissionsBoundary is the ARN of a managed policy set as the permissions boundary of every AWS IAM
+	// role created by clusterawsadm. Defaults to no permissions boundary.
+	// +optional
+	PermissionsBoundary string `json:"permissionsBoundary,omitempty"`
+
+	// Path is the AWS IAM path of every role and instance profile created by clusterawsadm, e.g. "/capa/".
+	// It must begin and end with a slash. Defaults to "/".
+	// +optional
+	Path string `json:"path,omitempty"`
+
 	// ControlPlane controls the configuration of the AWS IAM role for a Kubernetes cluster's control plane nodes.
 	ControlPlane ControlPlane `json:"controlPlane,omitempty"`
 
@@ -224,6 +255,11 @@ type AWSIAMConfigurationSpec struct {
 	// TODO: This field could be a pointer, but it seems it breaks setting default values?
 	// +optional
 	S3Buckets S3Buckets `json:"s3Buckets,omitempty"`
+
+	// ECR controls the Amazon ECR permissions granted to the control plane and nodes
+	// roles, so that machines can pull images from private registries.
+	// +optional
+	ECR *ECRConfig `json:"ecr,omitempty"`
 }
 
 // GetObjectKind returns the AAWSIAMConfiguration's TypeMeta.
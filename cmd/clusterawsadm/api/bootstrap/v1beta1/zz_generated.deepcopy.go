@@ -87,6 +87,11 @@ func (in *AWSIAMConfigurationSpec) DeepCopyInto(out *AWSIAMConfigurationSpec) {
 		copy(*out, *in)
 	}
 	out.S3Buckets = in.S3Buckets
+	if in.ECR != nil {
+		in, out := &in.ECR, &out.ECR
+		*out = new(ECRConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSIAMConfigurationSpec.
@@ -216,6 +221,26 @@ func (in *ControlPlane) DeepCopy() *ControlPlane {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ECRConfig) DeepCopyInto(out *ECRConfig) {
+	*out = *in
+	if in.PolicyARNs != nil {
+		in, out := &in.PolicyARNs, &out.PolicyARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ECRConfig.
+func (in *ECRConfig) DeepCopy() *ECRConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ECRConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EKSConfig) DeepCopyInto(out *EKSConfig) {
 	*out = *in
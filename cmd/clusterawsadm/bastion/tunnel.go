@@ -0,0 +1,189 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bastion provides a way to reach the API server of private clusters through their bastion host.
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"k8s.io/apimachinery/pkg/runtime"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/exec"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// PortForwardDocument is the SSM document used to forward a local port to a host reachable from the bastion.
+	PortForwardDocument = "AWS-StartPortForwardingSessionToRemoteHost"
+
+	// SessionManagerPlugin is the name of the binary that streams SSM sessions to the local machine.
+	SessionManagerPlugin = "session-manager-plugin"
+)
+
+var (
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+	_ = ekscontrolplanev1.AddToScheme(scheme)
+}
+
+// Target is the API server of a cluster and the bastion host it can be reached through.
+type Target struct {
+	InstanceID string
+	Host       string
+	Port       int32
+}
+
+// NewClient creates a client for the management cluster from the given kubeconfig.
+func NewClient(kubeconfigPath string) (client.Client, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("building client config: %w", err)
+	}
+
+	cl, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("creating new client: %w", err)
+	}
+
+	return cl, nil
+}
+
+// GetTarget looks up the API server endpoint and the bastion connection details of a cluster.
+// The connection details are read from the AWSManagedControlPlane for EKS clusters and from
+// the AWSCluster otherwise.
+func GetTarget(ctx context.Context, cl client.Client, namespace, clusterName string) (*Target, error) {
+	cluster := &clusterv1.Cluster{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, cluster); err != nil {
+		return nil, fmt.Errorf("getting capi cluster %s/%s: %w", namespace, clusterName, err)
+	}
+
+	var connection *infrav1.BastionConnection
+	switch {
+	case cluster.Spec.ControlPlaneRef != nil && cluster.Spec.ControlPlaneRef.Kind == "AWSManagedControlPlane":
+		controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+		key := client.ObjectKey{Namespace: namespace, Name: cluster.Spec.ControlPlaneRef.Name}
+		if err := cl.Get(ctx, key, controlPlane); err != nil {
+			return nil, fmt.Errorf("getting managed control plane %s/%s: %w", key.Namespace, key.Name, err)
+		}
+		connection = controlPlane.Status.BastionConnection
+	case cluster.Spec.InfrastructureRef != nil && cluster.Spec.InfrastructureRef.Kind == "AWSCluster":
+		awsCluster := &infrav1.AWSCluster{}
+		key := client.ObjectKey{Namespace: namespace, Name: cluster.Spec.InfrastructureRef.Name}
+		if err := cl.Get(ctx, key, awsCluster); err != nil {
+			return nil, fmt.Errorf("getting infra cluster %s/%s: %w", key.Namespace, key.Name, err)
+		}
+		connection = awsCluster.Status.BastionConnection
+	default:
+		return nil, fmt.Errorf("cluster %s/%s is not backed by an AWSCluster or AWSManagedControlPlane", namespace, clusterName)
+	}
+
+	if connection == nil {
+		return nil, fmt.Errorf("cluster %s/%s has no bastion host, set spec.bastion.enabled to create one", namespace, clusterName)
+	}
+	if connection.InstanceConnectEndpointID != "" {
+		return nil, fmt.Errorf("cluster %s/%s uses EC2 Instance Connect Endpoint %s, which only connects to instances, use `aws ec2-instance-connect ssh` instead", namespace, clusterName, connection.InstanceConnectEndpointID)
+	}
+	if !connection.SSMAvailable {
+		return nil, fmt.Errorf("the SSM agent of bastion host %s isn't online, make sure spec.bastion.iamInstanceProfile grants it access to Session Manager", connection.InstanceID)
+	}
+
+	endpoint := cluster.Spec.ControlPlaneEndpoint
+	if !endpoint.IsValid() {
+		return nil, fmt.Errorf("cluster %s/%s has no control plane endpoint yet", namespace, clusterName)
+	}
+
+	return &Target{
+		InstanceID: connection.InstanceID,
+		Host:       endpoint.Host,
+		Port:       endpoint.Port,
+	}, nil
+}
+
+// StartSessionInput returns the request for an SSM session forwarding localPort to the API server.
+func (t *Target) StartSessionInput(localPort int) *ssm.StartSessionInput {
+	return &ssm.StartSessionInput{
+		Target:       aws.String(t.InstanceID),
+		DocumentName: aws.String(PortForwardDocument),
+		Parameters: map[string][]*string{
+			"host":            aws.StringSlice([]string{t.Host}),
+			"portNumber":      aws.StringSlice([]string{strconv.Itoa(int(t.Port))}),
+			"localPortNumber": aws.StringSlice([]string{strconv.Itoa(localPort)}),
+		},
+	}
+}
+
+// Tunnel starts an SSM port forwarding session and hands it over to the session manager
+// plugin, which keeps the tunnel open until it is interrupted. The session is terminated
+// once the plugin exits.
+func Tunnel(ctx context.Context, ssmClient ssmiface.SSMAPI, region, endpoint string, input *ssm.StartSessionInput) error {
+	pluginPath, err := exec.LookPath(SessionManagerPlugin)
+	if err != nil {
+		return fmt.Errorf("%s must be installed to open a tunnel, see https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager-working-with-install-plugin.html: %w", SessionManagerPlugin, err)
+	}
+
+	session, err := ssmClient.StartSessionWithContext(ctx, input)
+	if err != nil {
+		return fmt.Errorf("starting session to %s: %w", aws.StringValue(input.Target), err)
+	}
+	defer func() {
+		_, _ = ssmClient.TerminateSession(&ssm.TerminateSessionInput{SessionId: session.SessionId})
+	}()
+
+	sessionJSON, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("marshalling session: %w", err)
+	}
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("marshalling session input: %w", err)
+	}
+
+	// The plugin closes the session itself when interrupted, so keep interrupts
+	// from ending this process before the session has been terminated.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+
+	// The plugin takes the same positional arguments the AWS CLI passes to it.
+	plugin := exec.CommandContext(ctx, pluginPath, string(sessionJSON), region, "StartSession", "", string(inputJSON), endpoint) //nolint:gosec
+	plugin.Stdin = os.Stdin
+	plugin.Stdout = os.Stdout
+	plugin.Stderr = os.Stderr
+	if err := plugin.Run(); err != nil {
+		return fmt.Errorf("running %s: %w", SessionManagerPlugin, err)
+	}
+
+	return nil
+}
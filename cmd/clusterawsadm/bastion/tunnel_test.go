@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bastion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestGetTarget(t *testing.T) {
+	online := &infrav1.BastionConnection{InstanceID: "i-bastion", SSMAvailable: true}
+	endpoint := clusterv1.APIEndpoint{Host: "internal-api.example.com", Port: 6443}
+
+	testCases := []struct {
+		name         string
+		existingObjs []client.Object
+		expected     *Target
+		expectError  bool
+	}{
+		{
+			name:        "no capi cluster",
+			expectError: true,
+		},
+		{
+			name:         "awscluster without bastion",
+			existingObjs: newUnmanagedCluster(nil, endpoint),
+			expectError:  true,
+		},
+		{
+			name:         "awscluster with ssm agent offline",
+			existingObjs: newUnmanagedCluster(&infrav1.BastionConnection{InstanceID: "i-bastion"}, endpoint),
+			expectError:  true,
+		},
+		{
+			name:         "awscluster with ec2 instance connect endpoint",
+			existingObjs: newUnmanagedCluster(&infrav1.BastionConnection{InstanceConnectEndpointID: "eice-123"}, endpoint),
+			expectError:  true,
+		},
+		{
+			name:         "awscluster without control plane endpoint",
+			existingObjs: newUnmanagedCluster(online, clusterv1.APIEndpoint{}),
+			expectError:  true,
+		},
+		{
+			name:         "awscluster with bastion",
+			existingObjs: newUnmanagedCluster(online, endpoint),
+			expected:     &Target{InstanceID: "i-bastion", Host: "internal-api.example.com", Port: 6443},
+		},
+		{
+			name:         "managed control plane with bastion",
+			existingObjs: newManagedCluster(online, clusterv1.APIEndpoint{Host: "abc.eks.amazonaws.com", Port: 443}),
+			expected:     &Target{InstanceID: "i-bastion", Host: "abc.eks.amazonaws.com", Port: 443},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.existingObjs...).Build()
+
+			target, err := GetTarget(context.TODO(), cl, "default", "test-cluster")
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(target).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestStartSessionInput(t *testing.T) {
+	g := NewWithT(t)
+
+	target := &Target{InstanceID: "i-bastion", Host: "internal-api.example.com", Port: 6443}
+	input := target.StartSessionInput(8443)
+
+	g.Expect(aws.StringValue(input.Target)).To(Equal("i-bastion"))
+	g.Expect(aws.StringValue(input.DocumentName)).To(Equal(PortForwardDocument))
+	g.Expect(aws.StringValueSlice(input.Parameters["host"])).To(ConsistOf("internal-api.example.com"))
+	g.Expect(aws.StringValueSlice(input.Parameters["portNumber"])).To(ConsistOf("6443"))
+	g.Expect(aws.StringValueSlice(input.Parameters["localPortNumber"])).To(ConsistOf("8443"))
+}
+
+func newUnmanagedCluster(connection *infrav1.BastionConnection, endpoint clusterv1.APIEndpoint) []client.Object {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: endpoint,
+			InfrastructureRef: &corev1.ObjectReference{
+				Kind:      "AWSCluster",
+				Name:      "test-cluster",
+				Namespace: "default",
+			},
+		},
+	}
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Status:     infrav1.AWSClusterStatus{BastionConnection: connection},
+	}
+	return []client.Object{cluster, awsCluster}
+}
+
+func newManagedCluster(connection *infrav1.BastionConnection, endpoint clusterv1.APIEndpoint) []client.Object {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: endpoint,
+			ControlPlaneRef: &corev1.ObjectReference{
+				Kind:      "AWSManagedControlPlane",
+				Name:      "test-cluster-control-plane",
+				Namespace: "default",
+			},
+			InfrastructureRef: &corev1.ObjectReference{
+				Kind:      "AWSManagedCluster",
+				Name:      "test-cluster",
+				Namespace: "default",
+			},
+		},
+	}
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-control-plane", Namespace: "default"},
+		Status:     ekscontrolplanev1.AWSManagedControlPlaneStatus{BastionConnection: connection},
+	}
+	return []client.Object{cluster, controlPlane}
+}
@@ -87,10 +87,15 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:AssignIpv6Addresses",
 				"ec2:AssignPrivateIpAddresses",
 				"ec2:UnassignPrivateIpAddresses",
+				"ec2:AssociateAddress",
+				"ec2:AssociateDhcpOptions",
 				"ec2:AssociateRouteTable",
 				"ec2:AttachInternetGateway",
 				"ec2:AuthorizeSecurityGroupIngress",
+				"ec2:AuthorizeSecurityGroupEgress",
+				"ec2:CreateDhcpOptions",
 				"ec2:CreateInternetGateway",
+				"ec2:CreateInstanceConnectEndpoint",
 				"ec2:CreateEgressOnlyInternetGateway",
 				"ec2:CreateNatGateway",
 				"ec2:CreateNetworkInterface",
@@ -101,7 +106,9 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:CreateTags",
 				"ec2:CreateVpc",
 				"ec2:ModifyVpcAttribute",
+				"ec2:DeleteDhcpOptions",
 				"ec2:DeleteInternetGateway",
+				"ec2:DeleteInstanceConnectEndpoint",
 				"ec2:DeleteEgressOnlyInternetGateway",
 				"ec2:DeleteNatGateway",
 				"ec2:DeleteRouteTable",
@@ -113,7 +120,10 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DescribeAccountAttributes",
 				"ec2:DescribeAddresses",
 				"ec2:DescribeAvailabilityZones",
+				"ec2:DescribeCapacityReservations",
+				"ec2:DescribeDhcpOptions",
 				"ec2:DescribeInstances",
+				"ec2:DescribeInstanceConnectEndpoints",
 				"ec2:DescribeInstanceTypes",
 				"ec2:DescribeInternetGateways",
 				"ec2:DescribeEgressOnlyInternetGateways",
@@ -127,6 +137,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DescribeSubnets",
 				"ec2:DescribeVpcs",
 				"ec2:DescribeVpcAttribute",
+				"ec2:DescribeVpcEndpoints",
 				"ec2:DescribeVolumes",
 				"ec2:DescribeTags",
 				"ec2:DetachInternetGateway",
@@ -137,6 +148,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:ModifySubnetAttribute",
 				"ec2:ReleaseAddress",
 				"ec2:RevokeSecurityGroupIngress",
+				"ec2:RevokeSecurityGroupEgress",
 				"ec2:RunInstances",
 				"ec2:TerminateInstances",
 				"tag:GetResources",
@@ -148,14 +160,38 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"elasticloadbalancing:DescribeLoadBalancers",
 				"elasticloadbalancing:DescribeLoadBalancerAttributes",
 				"elasticloadbalancing:DescribeTargetGroups",
+				"elasticloadbalancing:DescribeTargetGroupAttributes",
 				"elasticloadbalancing:ApplySecurityGroupsToLoadBalancer",
 				"elasticloadbalancing:DescribeTags",
 				"elasticloadbalancing:ModifyLoadBalancerAttributes",
 				"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
 				"elasticloadbalancing:DeregisterInstancesFromLoadBalancer",
 				"elasticloadbalancing:RemoveTags",
+				"elasticloadbalancing:SetWebACL",
+				"elasticloadbalancing:CreateLoadBalancerListeners",
+				"elasticloadbalancing:DeleteLoadBalancerListeners",
+				"elasticloadbalancing:CreateListener",
+				"elasticloadbalancing:DeleteListener",
+				"elasticloadbalancing:DescribeListeners",
+				"elasticloadbalancing:CreateTargetGroup",
+				"elasticloadbalancing:ModifyTargetGroup",
+				"elasticloadbalancing:ModifyTargetGroupAttributes",
+				"elasticloadbalancing:DescribeTargetHealth",
+				"elasticloadbalancing:RegisterTargets",
+				"elasticloadbalancing:ModifyListener",
+				"elasticloadbalancing:SetSecurityGroups",
+				"wafv2:GetWebACLForResource",
+				"wafv2:AssociateWebACL",
+				"wafv2:DisassociateWebACL",
+				"shield:DescribeProtection",
+				"shield:CreateProtection",
+				"shield:DeleteProtection",
+				"route53:ListResourceRecordSets",
+				"route53:ChangeResourceRecordSets",
 				"autoscaling:DescribeAutoScalingGroups",
 				"autoscaling:DescribeInstanceRefreshes",
+				"autoscaling:DescribeScalingActivities",
+				"autoscaling:DescribePolicies",
 				"ec2:CreateLaunchTemplate",
 				"ec2:CreateLaunchTemplateVersion",
 				"ec2:DescribeLaunchTemplates",
@@ -163,6 +199,22 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DeleteLaunchTemplate",
 				"ec2:DeleteLaunchTemplateVersions",
 				"ec2:DescribeKeyPairs",
+				"servicequotas:GetServiceQuota",
+				"servicequotas:GetAWSDefaultServiceQuota",
+				"pricing:GetProducts",
+				"outposts:GetOutpostInstanceTypes",
+				"cloudwatch:PutMetricAlarm",
+				"cloudwatch:DescribeAlarms",
+				"cloudwatch:DeleteAlarms",
+				"cloudwatch:TagResource",
+				"ssm:DescribeInstanceInformation",
+				"ec2:CreateSnapshot",
+				"ec2:DescribeSnapshots",
+				"ec2:CreateFlowLogs",
+				"ec2:DescribeFlowLogs",
+				"ec2:DeleteFlowLogs",
+				"logs:CreateLogDelivery",
+				"logs:DeleteLogDelivery",
 			},
 		},
 		{
@@ -177,6 +229,13 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"autoscaling:StartInstanceRefresh",
 				"autoscaling:DeleteAutoScalingGroup",
 				"autoscaling:DeleteTags",
+				"autoscaling:EnableMetricsCollection",
+				"autoscaling:DisableMetricsCollection",
+				"autoscaling:AttachLoadBalancerTargetGroups",
+				"autoscaling:DetachLoadBalancerTargetGroups",
+				"autoscaling:TerminateInstanceInAutoScalingGroup",
+				"autoscaling:PutScalingPolicy",
+				"autoscaling:DeletePolicy",
 			},
 		},
 		{
@@ -215,6 +274,18 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				iamv1.StringLike: map[string]string{"iam:AWSServiceName": "spot.amazonaws.com"},
 			},
 		},
+		{
+			Effect: iamv1.EffectAllow,
+			Action: iamv1.Actions{
+				"iam:CreateServiceLinkedRole",
+			},
+			Resource: iamv1.Resources{
+				"arn:*:iam::*:role/aws-service-role/ec2-instance-connect.amazonaws.com/AWSServiceRoleForEC2InstanceConnect",
+			},
+			Condition: iamv1.Conditions{
+				iamv1.StringLike: map[string]string{"iam:AWSServiceName": "ec2-instance-connect.amazonaws.com"},
+			},
+		},
 		{
 			Effect:   iamv1.EffectAllow,
 			Resource: t.allowedEC2InstanceProfiles(),
@@ -222,6 +293,15 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"iam:PassRole",
 			},
 		},
+		{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				"arn:*:ssm:*:*:parameter/aws/service/bottlerocket/*",
+			},
+			Action: iamv1.Actions{
+				"ssm:GetParameter",
+			},
+		},
 	}
 	for _, secureSecretBackend := range t.Spec.SecureSecretsBackends {
 		switch secureSecretBackend {
@@ -405,6 +485,7 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 				"eks:DescribeNodegroup",
 				"eks:DeleteNodegroup",
 				"eks:UpdateNodegroupConfig",
+				"eks:DescribeUpdate",
 				"eks:CreateNodegroup",
 				"eks:AssociateEncryptionConfig",
 				"eks:ListIdentityProviderConfigs",
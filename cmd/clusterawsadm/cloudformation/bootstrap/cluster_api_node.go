@@ -74,8 +74,8 @@ func (t Template) nodeManagedPolicies() []string {
 		)
 	}
 
-	if t.Spec.Nodes.EC2ContainerRegistryReadOnly {
-		policies = append(policies, t.generateAWSManagedPolicyARN("AmazonEC2ContainerRegistryReadOnly"))
+	if t.Spec.Nodes.EC2ContainerRegistryReadOnly || t.Spec.ECR != nil {
+		policies = append(policies, t.ecrPolicyARNs()...)
 	}
 
 	return policies
@@ -36,6 +36,16 @@ func (t Template) controlPlanePolicies() []cfn_iam.Role_Policy {
 			},
 		)
 	}
+	return append(policies, t.ecrPullThroughCachePolicies()...)
+}
+
+func (t Template) controlPlaneManagedPolicies() []string {
+	policies := t.Spec.ControlPlane.ExtraPolicyAttachments
+
+	if t.Spec.ECR != nil && t.Spec.ECR.ControlPlane {
+		policies = append(policies, t.ecrPolicyARNs()...)
+	}
+
 	return policies
 }
 
@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/awslabs/goformation/v4/cloudformation"
+
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+)
+
+// PolicyDiff is the difference between the actions allowed by a policy of a generated
+// CloudFormation template and by the same policy of a deployed stack.
+type PolicyDiff struct {
+	// Name identifies the policy, by the logical ID of its managed policy resource, or by the
+	// logical ID of its role followed by the name of the inline policy.
+	Name string
+	// Missing are the actions allowed by the generated policy but not by the deployed one.
+	Missing []string
+	// Extra are the actions allowed by the deployed policy but not by the generated one.
+	Extra []string
+}
+
+// DiffTemplates compares the actions allowed by the managed and inline role policies of a generated
+// template with the ones of a deployed template, and returns the policies that differ sorted by name.
+func DiffTemplates(generated, deployed *cloudformation.Template) ([]PolicyDiff, error) {
+	generatedActions, err := templatePolicyActions(generated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the policies of the generated template: %w", err)
+	}
+	deployedActions, err := templatePolicyActions(deployed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the policies of the deployed template: %w", err)
+	}
+
+	names := map[string]struct{}{}
+	for name := range generatedActions {
+		names[name] = struct{}{}
+	}
+	for name := range deployedActions {
+		names[name] = struct{}{}
+	}
+
+	diffs := []PolicyDiff{}
+	for name := range names {
+		diff := PolicyDiff{
+			Name:    name,
+			Missing: actionsDifference(generatedActions[name], deployedActions[name]),
+			Extra:   actionsDifference(deployedActions[name], generatedActions[name]),
+		}
+		if len(diff.Missing) > 0 || len(diff.Extra) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+	return diffs, nil
+}
+
+// templatePolicyActions returns the actions allowed by each policy of a template.
+func templatePolicyActions(t *cloudformation.Template) (map[string]map[string]struct{}, error) {
+	policies := map[string]map[string]struct{}{}
+	for id, policy := range t.GetAllIAMManagedPolicyResources() {
+		actions, err := allowedActions(policy.PolicyDocument)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy document of %q: %w", id, err)
+		}
+		policies[id] = actions
+	}
+	for id, role := range t.GetAllIAMRoleResources() {
+		for _, policy := range role.Policies {
+			name := fmt.Sprintf("%s/%s", id, policy.PolicyName)
+			actions, err := allowedActions(policy.PolicyDocument)
+			if err != nil {
+				return nil, fmt.Errorf("invalid policy document of %q: %w", name, err)
+			}
+			policies[name] = actions
+		}
+	}
+	return policies, nil
+}
+
+// allowedActions returns the actions of the Allow statements of a policy document, which is either an
+// iamv1.PolicyDocument or its JSON representation parsed from a deployed template.
+func allowedActions(doc interface{}) (map[string]struct{}, error) {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	policy := struct {
+		Statement []struct {
+			Effect iamv1.Effect  `json:"Effect"`
+			Action iamv1.Actions `json:"Action"`
+		} `json:"Statement"`
+	}{}
+	if err := json.Unmarshal(raw, &policy); err != nil {
+		return nil, err
+	}
+
+	actions := map[string]struct{}{}
+	for _, statement := range policy.Statement {
+		if statement.Effect != iamv1.EffectAllow {
+			continue
+		}
+		for _, action := range statement.Action {
+			actions[action] = struct{}{}
+		}
+	}
+	return actions, nil
+}
+
+// actionsDifference returns the sorted actions of a that aren't in b.
+func actionsDifference(a, b map[string]struct{}) []string {
+	var out []string
+	for action := range a {
+		if _, ok := b[action]; !ok {
+			out = append(out, action)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/awslabs/goformation/v4"
+	. "github.com/onsi/gomega"
+)
+
+func TestDiffTemplates(t *testing.T) {
+	fixture, err := os.ReadFile(path.Join("fixtures", "default.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		deployed func(string) string
+		want     []PolicyDiff
+	}{
+		{
+			name:     "no differences with the deployed stack",
+			deployed: func(s string) string { return s },
+			want:     []PolicyDiff{},
+		},
+		{
+			name: "missing and extra permissions of the deployed stack",
+			deployed: func(s string) string {
+				s = strings.Replace(s, "          - ec2:AllocateAddress\n", "", 1)
+				return strings.Replace(s, "          - ec2:AttachNetworkInterface\n", "          - ec2:AttachNetworkInterface\n          - ec2:DeprecatedAction\n", 1)
+			},
+			want: []PolicyDiff{{
+				Name:    "AWSIAMManagedPolicyControllers",
+				Missing: []string{"ec2:AllocateAddress"},
+				Extra:   []string{"ec2:DeprecatedAction"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			deployed, err := goformation.ParseYAML([]byte(tt.deployed(string(fixture))))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			diffs, err := DiffTemplates(NewTemplate().RenderCloudFormation(), deployed)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(diffs).To(Equal(tt.want))
+		})
+	}
+}
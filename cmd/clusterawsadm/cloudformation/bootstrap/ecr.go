@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	cfn_iam "github.com/awslabs/goformation/v4/cloudformation/iam"
+
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+)
+
+// ecrPolicyARNs returns the policies granting registry access to machine roles.
+func (t Template) ecrPolicyARNs() []string {
+	if t.Spec.ECR != nil && len(t.Spec.ECR.PolicyARNs) > 0 {
+		return t.Spec.ECR.PolicyARNs
+	}
+	return []string{t.generateAWSManagedPolicyARN("AmazonEC2ContainerRegistryReadOnly")}
+}
+
+// ecrPullThroughCachePolicies returns the inline policy allowing images to be
+// imported through ECR pull through cache rules, if enabled.
+func (t Template) ecrPullThroughCachePolicies() []cfn_iam.Role_Policy {
+	if t.Spec.ECR == nil || !t.Spec.ECR.PullThroughCache {
+		return nil
+	}
+	return []cfn_iam.Role_Policy{
+		{
+			PolicyName: t.NewManagedName("ecr-pull-through-cache"),
+			PolicyDocument: iamv1.PolicyDocument{
+				Version: iamv1.CurrentVersion,
+				Statement: []iamv1.StatementEntry{
+					{
+						Effect:   iamv1.EffectAllow,
+						Resource: iamv1.Resources{iamv1.Any},
+						Action: iamv1.Actions{
+							"ecr:BatchImportUpstreamImage",
+							"ecr:CreateRepository",
+						},
+					},
+				},
+			},
+		},
+	}
+}
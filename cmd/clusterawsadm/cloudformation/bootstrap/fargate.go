@@ -21,8 +21,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks"
 )
 
-func fargateProfilePolicies(roleSpec *bootstrapv1.AWSIAMRoleSpec) []string {
-	policies := eks.FargateRolePolicies()
+func fargateProfilePolicies(partition string, roleSpec *bootstrapv1.AWSIAMRoleSpec) []string {
+	policies := eks.FargateRolePolicies(partition)
 	if roleSpec.ExtraPolicyAttachments != nil {
 		policies = append(policies, roleSpec.ExtraPolicyAttachments...)
 	}
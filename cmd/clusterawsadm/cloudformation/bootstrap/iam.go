@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sort"
+	"strings"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
 	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
@@ -75,3 +77,43 @@ func (t Template) policyFunctionMap() map[PolicyName]func() *iamv1.PolicyDocumen
 func (t Template) GetPolicyDocFromPolicyName(policyName PolicyName) *iamv1.PolicyDocument {
 	return t.policyFunctionMap()[policyName]()
 }
+
+// ServicePolicyDocument returns the statements of a policy document restricted to the actions of an
+// AWS service, identified by the prefix of its actions, e.g. ec2 or eks. Statements without any
+// action of the service are dropped.
+func ServicePolicyDocument(doc iamv1.PolicyDocument, service string) iamv1.PolicyDocument {
+	prefix := strings.ToLower(service) + ":"
+	out := iamv1.PolicyDocument{Version: doc.Version, ID: doc.ID}
+	for _, statement := range doc.Statement {
+		var actions iamv1.Actions
+		for _, action := range statement.Action {
+			if strings.HasPrefix(strings.ToLower(action), prefix) {
+				actions = append(actions, action)
+			}
+		}
+		if len(actions) == 0 {
+			continue
+		}
+		statement.Action = actions
+		out.Statement = append(out.Statement, statement)
+	}
+	return out
+}
+
+// PolicyDocumentServices returns the sorted AWS services whose actions are used in a policy document.
+func PolicyDocumentServices(doc iamv1.PolicyDocument) []string {
+	services := map[string]struct{}{}
+	for _, statement := range doc.Statement {
+		for _, action := range statement.Action {
+			if service, _, ok := strings.Cut(action, ":"); ok {
+				services[strings.ToLower(service)] = struct{}{}
+			}
+		}
+	}
+	out := make([]string, 0, len(services))
+	for service := range services {
+		out = append(out, service)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+)
+
+func TestServicePolicyDocument(t *testing.T) {
+	g := NewWithT(t)
+
+	doc := iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		Statement: []iamv1.StatementEntry{
+			{
+				Effect:   iamv1.EffectAllow,
+				Action:   iamv1.Actions{"ec2:DescribeInstances", "eks:DescribeCluster"},
+				Resource: iamv1.Resources{iamv1.Any},
+			},
+			{
+				Effect:   iamv1.EffectAllow,
+				Action:   iamv1.Actions{"iam:PassRole"},
+				Resource: iamv1.Resources{"arn:*:iam::*:role/*"},
+			},
+		},
+	}
+
+	g.Expect(ServicePolicyDocument(doc, "EC2")).To(Equal(iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		Statement: []iamv1.StatementEntry{
+			{
+				Effect:   iamv1.EffectAllow,
+				Action:   iamv1.Actions{"ec2:DescribeInstances"},
+				Resource: iamv1.Resources{iamv1.Any},
+			},
+		},
+	}))
+	g.Expect(ServicePolicyDocument(doc, "s3").Statement).To(BeEmpty())
+	g.Expect(PolicyDocumentServices(doc)).To(Equal([]string{"ec2", "eks", "iam"}))
+}
@@ -19,7 +19,7 @@ package bootstrap
 import "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks"
 
 func (t Template) eksMachinePoolPolicies() []string {
-	policies := eks.NodegroupRolePolicies()
+	policies := eks.NodegroupRolePolicies(t.Spec.Partition)
 	if t.Spec.EKS.ManagedMachinePool.ExtraPolicyAttachments != nil {
 		policies = append(policies, t.Spec.EKS.ManagedMachinePool.ExtraPolicyAttachments...)
 	}
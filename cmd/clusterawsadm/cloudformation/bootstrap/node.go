@@ -35,7 +35,7 @@ func (t Template) nodePolicies() []cfn_iam.Role_Policy {
 			},
 		)
 	}
-	return policies
+	return append(policies, t.ecrPullThroughCachePolicies()...)
 }
 
 func (t Template) nodeTrustPolicy() *iamv1.PolicyDocument {
@@ -136,14 +136,18 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 
 	template.Resources[AWSIAMRoleControlPlane] = &cfn_iam.Role{
 		RoleName:                 t.NewManagedName("control-plane"),
+		Path:                     t.Spec.Path,
+		PermissionsBoundary:      t.Spec.PermissionsBoundary,
 		AssumeRolePolicyDocument: t.controlPlaneTrustPolicy(),
-		ManagedPolicyArns:        t.Spec.ControlPlane.ExtraPolicyAttachments,
+		ManagedPolicyArns:        t.controlPlaneManagedPolicies(),
 		Policies:                 t.controlPlanePolicies(),
 		Tags:                     converters.MapToCloudFormationTags(t.Spec.ControlPlane.Tags),
 	}
 
 	template.Resources[AWSIAMRoleControllers] = &cfn_iam.Role{
 		RoleName:                 t.NewManagedName("controllers"),
+		Path:                     t.Spec.Path,
+		PermissionsBoundary:      t.Spec.PermissionsBoundary,
 		AssumeRolePolicyDocument: t.controllersTrustPolicy(),
 		Policies:                 t.controllersRolePolicy(),
 		Tags:                     converters.MapToCloudFormationTags(t.Spec.ClusterAPIControllers.Tags),
@@ -151,6 +155,8 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 
 	template.Resources[AWSIAMRoleNodes] = &cfn_iam.Role{
 		RoleName:                 t.NewManagedName("nodes"),
+		Path:                     t.Spec.Path,
+		PermissionsBoundary:      t.Spec.PermissionsBoundary,
 		AssumeRolePolicyDocument: t.nodeTrustPolicy(),
 		ManagedPolicyArns:        t.nodeManagedPolicies(),
 		Policies:                 t.nodePolicies(),
@@ -159,6 +165,7 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 
 	template.Resources[AWSIAMInstanceProfileControlPlane] = &cfn_iam.InstanceProfile{
 		InstanceProfileName: t.NewManagedName("control-plane"),
+		Path:                t.Spec.Path,
 		Roles: []string{
 			cloudformation.Ref(AWSIAMRoleControlPlane),
 		},
@@ -166,6 +173,7 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 
 	template.Resources[AWSIAMInstanceProfileControllers] = &cfn_iam.InstanceProfile{
 		InstanceProfileName: t.NewManagedName("controllers"),
+		Path:                t.Spec.Path,
 		Roles: []string{
 			cloudformation.Ref(AWSIAMRoleControllers),
 		},
@@ -173,6 +181,7 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 
 	template.Resources[AWSIAMInstanceProfileNodes] = &cfn_iam.InstanceProfile{
 		InstanceProfileName: t.NewManagedName("nodes"),
+		Path:                t.Spec.Path,
 		Roles: []string{
 			cloudformation.Ref(AWSIAMRoleNodes),
 		},
@@ -181,6 +190,8 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 	if !t.Spec.EKS.DefaultControlPlaneRole.Disable && !t.Spec.EKS.Disable {
 		template.Resources[AWSIAMRoleEKSControlPlane] = &cfn_iam.Role{
 			RoleName:                 ekscontrolplanev1.DefaultEKSControlPlaneRole,
+			Path:                     t.Spec.Path,
+			PermissionsBoundary:      t.Spec.PermissionsBoundary,
 			AssumeRolePolicyDocument: AssumeRolePolicy(iamv1.PrincipalService, []string{"eks.amazonaws.com"}),
 			ManagedPolicyArns:        t.eksControlPlanePolicies(),
 			Tags:                     converters.MapToCloudFormationTags(t.Spec.EKS.DefaultControlPlaneRole.Tags),
@@ -190,6 +201,8 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 	if !t.Spec.EKS.ManagedMachinePool.Disable && !t.Spec.EKS.Disable {
 		template.Resources[AWSIAMRoleEKSNodegroup] = &cfn_iam.Role{
 			RoleName:                 expinfrav1.DefaultEKSNodegroupRole,
+			Path:                     t.Spec.Path,
+			PermissionsBoundary:      t.Spec.PermissionsBoundary,
 			AssumeRolePolicyDocument: AssumeRolePolicy(iamv1.PrincipalService, []string{"ec2.amazonaws.com", "eks.amazonaws.com"}),
 			ManagedPolicyArns:        t.eksMachinePoolPolicies(),
 			Tags:                     converters.MapToCloudFormationTags(t.Spec.EKS.ManagedMachinePool.Tags),
@@ -199,8 +212,10 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 	if !t.Spec.EKS.Fargate.Disable && !t.Spec.EKS.Disable {
 		template.Resources[AWSIAMRoleEKSFargate] = &cfn_iam.Role{
 			RoleName:                 expinfrav1.DefaultEKSFargateRole,
+			Path:                     t.Spec.Path,
+			PermissionsBoundary:      t.Spec.PermissionsBoundary,
 			AssumeRolePolicyDocument: AssumeRolePolicy(iamv1.PrincipalService, []string{eksiam.EKSFargateService}),
-			ManagedPolicyArns:        fargateProfilePolicies(t.Spec.EKS.Fargate),
+			ManagedPolicyArns:        fargateProfilePolicies(t.Spec.Partition, t.Spec.EKS.Fargate),
 			Tags:                     converters.MapToCloudFormationTags(t.Spec.EKS.Fargate.Tags),
 		}
 	}
@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	bootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/bootstrap/v1beta1"
 	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
 )
 
@@ -174,6 +175,28 @@ func TestRenderCloudformation(t *testing.T) {
 				return t
 			},
 		},
+		{
+			fixture: "with_ecr",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.ECR = &bootstrapv1.ECRConfig{
+					ControlPlane:     true,
+					PolicyARNs:       []string{"arn:aws:iam::123456789012:policy/shared-registry-read"},
+					PullThroughCache: true,
+				}
+				return t
+			},
+		},
+		{
+			fixture: "with_permissions_boundary",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.PermissionsBoundary = "arn:aws:iam::123456789012:policy/capa-boundary"
+				t.Spec.Path = "/capa/"
+				t.Spec.EKS.DefaultControlPlaneRole.Disable = false
+				return t
+			},
+		},
 	}
 
 	for _, c := range cases {
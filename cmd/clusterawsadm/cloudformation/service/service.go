@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/awslabs/goformation/v4"
 	go_cfn "github.com/awslabs/goformation/v4/cloudformation"
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
@@ -175,6 +176,24 @@ func (s *Service) DeleteStack(stackName string, retainResources []*string) error
 	return nil
 }
 
+// GetStackTemplate returns the template the stack was created or last updated with.
+func (s *Service) GetStackTemplate(stackName string) (*go_cfn.Template, error) {
+	out, err := s.CFN.GetTemplate(&cfn.GetTemplateInput{
+		StackName:     aws.String(stackName),
+		TemplateStage: aws.String(cfn.TemplateStageOriginal),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the template of AWS CloudFormation stack %q", stackName)
+	}
+
+	// JSON templates are valid YAML, so both formats can be parsed as YAML.
+	t, err := goformation.ParseYAML([]byte(aws.StringValue(out.TemplateBody)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the template of AWS CloudFormation stack %q", stackName)
+	}
+	return t, nil
+}
+
 // ShowStackResources prints out in tabular format the resources in the
 // stack.
 func (s *Service) ShowStackResources(stackName string) error {
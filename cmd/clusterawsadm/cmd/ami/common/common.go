@@ -23,7 +23,10 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/ami"
+	amiv1 "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/ami/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/flags"
+	cmdout "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/printers"
 	ec2service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 )
 
@@ -72,3 +75,25 @@ func addOwnerIDFlag(c *cobra.Command) {
 func addDryRunFlag(c *cobra.Command) {
 	c.Flags().Bool("dry-run", false, "Check if AMI exists and can be copied")
 }
+
+func addMachineTemplateFlag(c *cobra.Command) {
+	c.Flags().Bool("machine-template", false, "Also output an AWSMachineTemplate looking up the copied AMI")
+}
+
+// printCopiedAMI prints the copied AMI, followed by a machine template looking it up if requested.
+func printCopiedAMI(c *cobra.Command, printer cmdout.Printer, copied *amiv1.AWSAMI) error {
+	if err := printer.Print(copied); err != nil {
+		return err
+	}
+
+	machineTemplate, err := c.Flags().GetBool("machine-template")
+	if err != nil {
+		return err
+	}
+	if !machineTemplate {
+		return nil
+	}
+
+	fmt.Fprintln(os.Stdout, "---")
+	return printer.Print(ami.MachineTemplate(copied))
+}
@@ -37,6 +37,8 @@ func CopyAMICmd() *cobra.Command {
 		Long: cmd.LongDesc(`
 			Copy AMIs based on Kubernetes version, OS, region from an AWS account where AMIs are stored
             to the current AWS account (use case: air-gapped deployments)
+			The copies are encrypted with the KMS key set with --kms-key-id, and tagged with the source AMI,
+			OS and Kubernetes version.
 		`),
 		Example: cmd.Examples(`
 		# Copy AMI from the default AWS account where AMIs are stored.
@@ -48,6 +50,11 @@ func CopyAMICmd() *cobra.Command {
 
 		# copy from us-east-1 to us-east-2
 		clusterawsadm ami copy --os centos-7 --kubernetes-version=v1.19.4 --region us-east-2 --source-region us-east-1
+
+		# copy from us-east-1 to us-east-2, encrypting the copy with a customer managed KMS key, and output
+		# an AWSMachineTemplate looking up the copied AMI
+		clusterawsadm ami copy --os ubuntu-20.04 --kubernetes-version=v1.19.4 --region us-east-2 --source-region us-east-1 \
+		  --kms-key-id=arn:aws:kms:us-east-2:012345678910:key/abcd1234-a123-456a-a12b-a123b4cd56ef --machine-template
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -74,6 +81,7 @@ func CopyAMICmd() *cobra.Command {
 			ami, err := ami.Copy(ami.CopyInput{
 				DestinationRegion: region,
 				DryRun:            dryRun,
+				KmsKeyID:          kmsKeyID,
 				KubernetesVersion: kubernetesVersion,
 				Log:               log,
 				OperatingSystem:   opSystem,
@@ -87,10 +95,7 @@ func CopyAMICmd() *cobra.Command {
 				return err
 			}
 
-			printer.Print(ami)
-
-			// klog.V(0).Infof("Completed copying %v\n", *image.ImageId)
-			return nil
+			return printCopiedAMI(cmd, printer, ami)
 		},
 	}
 
@@ -99,6 +104,8 @@ func CopyAMICmd() *cobra.Command {
 	addKubernetesVersionFlag(newCmd)
 	addDryRunFlag(newCmd)
 	addOwnerIDFlag(newCmd)
+	addKmsKeyIDFlag(newCmd)
 	addSourceRegion(newCmd)
+	addMachineTemplateFlag(newCmd)
 	return newCmd
 }
@@ -36,8 +36,9 @@ var (
 // EncryptedCopyAMICmd is a command to encrypt and copy AMI snapshots, then create an AMI with that snapshot.
 func EncryptedCopyAMICmd() *cobra.Command {
 	newCmd := &cobra.Command{
-		Use:   "encrypted-copy",
-		Short: "Encrypt and copy AMI snapshot, then create an AMI with that snapshot",
+		Use:     "encrypted-copy",
+		Aliases: []string{"encrypt"},
+		Short:   "Encrypt and copy AMI snapshot, then create an AMI with that snapshot",
 		Long: cmd.LongDesc(`
 			Find the AMI based on Kubernetes version, OS, region in the AWS account where AMIs are stored.
 			Encrypt and copy the snapshot of the AMI to the current AWS account.
@@ -106,9 +107,7 @@ func EncryptedCopyAMICmd() *cobra.Command {
 				return err
 			}
 
-			printer.Print(ami)
-
-			return nil
+			return printCopiedAMI(cmd, printer, ami)
 		},
 	}
 
@@ -119,6 +118,7 @@ func EncryptedCopyAMICmd() *cobra.Command {
 	addOwnerIDFlag(newCmd)
 	addKmsKeyIDFlag(newCmd)
 	addSourceRegion(newCmd)
+	addMachineTemplateFlag(newCmd)
 	return newCmd
 }
 
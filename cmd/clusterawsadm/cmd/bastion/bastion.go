@@ -0,0 +1,40 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bastion
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// RootCmd is the root of the `bastion command`.
+func RootCmd() *cobra.Command {
+	newCmd := &cobra.Command{
+		Use:   "bastion [command]",
+		Short: "Commands related to the bastion host of clusters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmd.Help(); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+
+	newCmd.AddCommand(newTunnelCmd())
+
+	return newCmd
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bastion
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/util/homedir"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/bastion"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/flags"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+)
+
+func newTunnelCmd() *cobra.Command {
+	var (
+		clusterName       string
+		namespace         string
+		kubeConfig        string
+		kubeConfigDefault string
+		localPort         int
+	)
+
+	if home := homedir.HomeDir(); home != "" {
+		kubeConfigDefault = filepath.Join(home, ".kube", "config")
+	}
+
+	newCmd := &cobra.Command{
+		Use:   "tunnel",
+		Short: "Forward a local port to the API server of a cluster through its bastion host",
+		Long: cmd.LongDesc(`
+			This command opens an AWS Systems Manager port forwarding session through the
+			bastion host of the given cluster, making the API server of private clusters
+			reachable on a local port. The bastion host must have the SSM agent online, as
+			reported in the bastionConnection status of the cluster, and the Session Manager
+			plugin must be installed locally. The tunnel stays open until interrupted.
+		`),
+		Example: cmd.Examples(`
+			# Open a tunnel to the API server of a cluster on local port 6443
+			clusterawsadm bastion tunnel --cluster-name=test-cluster --region=us-east-1
+
+			# Open a tunnel on another local port using a kubeconfig for the management cluster
+			clusterawsadm bastion tunnel --cluster-name=test-cluster --local-port=8443 --kubeconfig=mgmt.kubeconfig
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			region, err := flags.GetRegionWithError(cmd)
+			if err != nil {
+				return err
+			}
+
+			cl, err := bastion.NewClient(kubeConfig)
+			if err != nil {
+				return err
+			}
+			target, err := bastion.GetTarget(cmd.Context(), cl, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			sess, err := session.NewSessionWithOptions(session.Options{
+				SharedConfigState: session.SharedConfigEnable,
+				Config:            aws.Config{Region: aws.String(region)},
+			})
+			if err != nil {
+				return err
+			}
+			ssmClient := ssm.New(sess)
+
+			fmt.Printf("Forwarding 127.0.0.1:%d to %s:%d through bastion host %s\n", localPort, target.Host, target.Port, target.InstanceID)
+			if err := bastion.Tunnel(cmd.Context(), ssmClient, region, ssmClient.Endpoint, target.StartSessionInput(localPort)); err != nil {
+				return flags.ResolveAWSError(err)
+			}
+
+			return nil
+		},
+	}
+
+	flags.AddRegionFlag(newCmd)
+	newCmd.Flags().StringVar(&clusterName, "cluster-name", "", "The name of the CAPA cluster")
+	newCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "The namespace for the cluster definition")
+	newCmd.Flags().StringVar(&kubeConfig, "kubeconfig", kubeConfigDefault, "Path to the kubeconfig file to use")
+	newCmd.Flags().IntVar(&localPort, "local-port", 6443, "The local port to forward to the API server")
+
+	newCmd.MarkFlagRequired("cluster-name") //nolint: errcheck
+
+	return newCmd
+}
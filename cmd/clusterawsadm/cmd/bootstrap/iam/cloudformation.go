@@ -123,6 +123,88 @@ func createCloudFormationStackCmd() *cobra.Command {
 	return newCmd
 }
 
+func diffCloudFormationStackCmd() *cobra.Command {
+	newCmd := &cobra.Command{
+		Use:     "diff-cloudformation-stack",
+		Aliases: []string{"diff"},
+		Short:   "Compare the IAM policies of an AWS CloudFormation stack with the generated ones",
+		Args:    cobra.NoArgs,
+		Long: cmd.LongDesc(`
+	Compare the AWS Identity and Access Management (IAM) policies of the deployed AWS
+	CloudFormation stack with the ones of the template generated by this version of
+	clusterawsadm, and show the permissions missing from the stack, e.g. after upgrading
+	Kubernetes Cluster API Provider AWS. To use this command, there must be AWS
+	credentials loaded in this environment.
+		` + credentials.CredentialHelp),
+		Example: cmd.Examples(`
+		# Show the permissions missing from the deployed AWS CloudFormation stack.
+		clusterawsadm bootstrap iam diff-cloudformation-stack
+
+		# Show the permissions missing from the deployed AWS CloudFormation stack with a custom configuration.
+		clusterawsadm bootstrap iam diff-cloudformation-stack --config bootstrap_config.yaml
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := getBootstrapTemplate(cmd)
+			if err != nil {
+				return err
+			}
+
+			if err := resolveTemplateRegion(t, cmd); err != nil {
+				fmt.Println("AWS_REGION env not set and --region flag not provided, default configuration will be used")
+			}
+
+			sess, err := session.NewSessionWithOptions(session.Options{
+				SharedConfigState: session.SharedConfigEnable,
+				Config:            aws.Config{Region: aws.String(t.Spec.Region)},
+			})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return err
+			}
+
+			cfnSvc := cloudformation.NewService(cfn.New(sess))
+
+			deployed, err := cfnSvc.GetStackTemplate(t.Spec.StackName)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return err
+			}
+
+			diffs, err := bootstrap.DiffTemplates(t.RenderCloudFormation(), deployed)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return err
+			}
+
+			printPolicyDiffs(t.Spec.StackName, diffs)
+			return nil
+		},
+	}
+	addConfigFlag(newCmd)
+	flags.AddRegionFlag(newCmd)
+	return newCmd
+}
+
+func printPolicyDiffs(stackName string, diffs []bootstrap.PolicyDiff) {
+	if len(diffs) == 0 {
+		fmt.Printf("The IAM policies of AWS CloudFormation stack %s are up to date\n", stackName)
+		return
+	}
+
+	fmt.Printf("The IAM policies of AWS CloudFormation stack %s differ from the generated ones, permissions\n", stackName)
+	fmt.Print("prefixed with + are missing from the stack and permissions prefixed with - are no longer needed:\n\n")
+	for _, diff := range diffs {
+		fmt.Printf("%s:\n", diff.Name)
+		for _, action := range diff.Missing {
+			fmt.Printf("  + %s\n", action)
+		}
+		for _, action := range diff.Extra {
+			fmt.Printf("  - %s\n", action)
+		}
+	}
+	fmt.Print("\nRun clusterawsadm bootstrap iam update-cloudformation-stack to update the stack.\n")
+}
+
 func deleteCloudFormationStackCmd() *cobra.Command {
 	newCmd := &cobra.Command{
 		Use:   "delete-cloudformation-stack",
@@ -51,6 +51,9 @@ func printPolicyCmd() *cobra.Command {
 
 		# Print out the IAM policy for the Kubernetes AWS EBS CSI Driver Controller.
 		clusterawsadm bootstrap iam print-policy --document AWSEBSCSIPolicyController
+
+		# Print out the EC2 permissions of the IAM policy for the Kubernetes Cluster API Provider AWS Controller.
+		clusterawsadm bootstrap iam print-policy --document AWSIAMManagedPolicyControllers --service ec2
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -65,6 +68,15 @@ func printPolicyCmd() *cobra.Command {
 			}
 
 			policyDocument := template.GetPolicyDocFromPolicyName(policyName)
+			if service := cmd.Flags().Lookup("service").Value.String(); service != "" {
+				servicePolicyDocument := bootstrap.ServicePolicyDocument(*policyDocument, service)
+				if len(servicePolicyDocument.Statement) == 0 {
+					return fmt.Errorf("document %s has no permissions for service %q, use one of: %+v",
+						policyName, service, bootstrap.PolicyDocumentServices(*policyDocument))
+				}
+				policyDocument = &servicePolicyDocument
+			}
+
 			str, err := converters.IAMPolicyDocumentToJSON(*policyDocument)
 			if err != nil {
 				return err
@@ -76,6 +88,7 @@ func printPolicyCmd() *cobra.Command {
 	}
 	addConfigFlag(newCmd)
 	newCmd.Flags().String("document", "", fmt.Sprintf("which document to show: %+v", bootstrap.ManagedIAMPolicyNames))
+	newCmd.Flags().String("service", "", "only show the permissions of an AWS service of the document, e.g. ec2 or eks")
 	return newCmd
 }
 
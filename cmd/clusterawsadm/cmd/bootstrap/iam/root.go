@@ -42,6 +42,7 @@ func RootCmd() *cobra.Command {
 	newCmd.AddCommand(printConfigCmd())
 	newCmd.AddCommand(printCloudFormationTemplateCmd())
 	newCmd.AddCommand(createCloudFormationStackCmd())
+	newCmd.AddCommand(diffCloudFormationStackCmd())
 	newCmd.AddCommand(deleteCloudFormationStackCmd())
 	return newCmd
 }
@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	awsservicequotas "github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/flags"
+	cmdout "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/printers"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+)
+
+// CheckQuotasCmd is the cmd to check the service quotas consumed by CAPA.
+func CheckQuotasCmd() *cobra.Command {
+	outputPrinterType := ""
+	newCmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check the AWS service quotas consumed by CAPA",
+		Long: cmd.LongDesc(`
+			Check the usage and limits of the regional AWS service quotas consumed by CAPA managed infrastructure,
+			such as VPCs, internet gateways, Elastic IPs, NAT gateways and instances. These are the same checks performed by the controllers
+			when the ServiceQuotaChecks feature gate is enabled.
+		`),
+		Example: cmd.Examples(`
+		# Check the service quotas consumed by CAPA in the given region
+		clusterawsadm quotas check --region=us-east-1
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			region, err := flags.GetRegionWithError(cmd)
+			if err != nil {
+				return err
+			}
+
+			sess, err := session.NewSessionWithOptions(session.Options{
+				SharedConfigState: session.SharedConfigEnable,
+				Config:            aws.Config{Region: aws.String(region)},
+			})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return err
+			}
+
+			svc := &servicequotas.Service{
+				EC2Client:           ec2.New(sess),
+				ServiceQuotasClient: awsservicequotas.New(sess),
+			}
+
+			zones, err := availabilityZones(svc.EC2Client)
+			if err != nil {
+				return flags.ResolveAWSError(err)
+			}
+
+			usages := make([]*servicequotas.Usage, 0, len(servicequotas.Quotas))
+			for _, quota := range servicequotas.Quotas {
+				quotaZones := []string{""}
+				if quota.PerAvailabilityZone {
+					quotaZones = zones
+				}
+				for _, zone := range quotaZones {
+					usage, err := svc.GetUsageInZone(quota, zone)
+					if err != nil {
+						return flags.ResolveAWSError(err)
+					}
+					usages = append(usages, usage)
+				}
+			}
+
+			outputPrinter, err := cmdout.New(outputPrinterType, os.Stdout)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed creating output printer: %s\n", err.Error())
+				return err
+			}
+
+			if outputPrinterType == string(cmdout.PrinterTypeTable) {
+				return outputPrinter.Print(toTable(usages))
+			}
+			return outputPrinter.Print(usages)
+		},
+	}
+
+	flags.AddRegionFlag(newCmd)
+	newCmd.Flags().StringVarP(&outputPrinterType, "output", "o", "table", "The output format of the results. Possible values: table, json, yaml")
+	return newCmd
+}
+
+func toTable(usages []*servicequotas.Usage) *metav1.Table {
+	table := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: metav1.SchemeGroupVersion.String(),
+			Kind:       "Table",
+		},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{
+				Name: "Quota",
+				Type: "string",
+			},
+			{
+				Name: "Code",
+				Type: "string",
+			},
+			{
+				Name: "Zone",
+				Type: "string",
+			},
+			{
+				Name: "Used",
+				Type: "integer",
+			},
+			{
+				Name: "Limit",
+				Type: "integer",
+			},
+			{
+				Name: "Available",
+				Type: "integer",
+			},
+		},
+	}
+
+	for _, usage := range usages {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []interface{}{
+				usage.Quota.Name,
+				fmt.Sprintf("%s/%s", usage.Quota.ServiceCode, usage.Quota.QuotaCode),
+				usage.Zone,
+				usage.Used,
+				usage.Limit,
+				usage.Available(),
+			},
+		})
+	}
+
+	return table
+}
+
+func availabilityZones(ec2Client ec2iface.EC2API) ([]string, error) {
+	out, err := ec2Client.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("state"),
+				Values: aws.StringSlice([]string{ec2.AvailabilityZoneStateAvailable}),
+			},
+			{
+				Name:   aws.String("zone-type"),
+				Values: aws.StringSlice([]string{"availability-zone"}),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]string, 0, len(out.AvailabilityZones))
+	for _, zone := range out.AvailabilityZones {
+		zones = append(zones, aws.StringValue(zone.ZoneName))
+	}
+	return zones, nil
+}
@@ -20,12 +20,15 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -40,21 +43,27 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/internal/requeue"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/billable"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/clusterinfo"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/elb"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/gc"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/monitoring"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	capiannotations "sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
 
@@ -65,18 +74,27 @@ var defaultAWSSecurityGroupRoles = []infrav1.SecurityGroupRole{
 	infrav1.SecurityGroupNode,
 }
 
+// deletionBlockedRequeueAfter is how often the deletion of a cluster blocked by its deletion protection is retried.
+const deletionBlockedRequeueAfter = time.Minute
+
 // AWSClusterReconciler reconciles a AwsCluster object.
 type AWSClusterReconciler struct {
 	client.Client
-	Recorder              record.EventRecorder
-	ec2ServiceFactory     func(scope.EC2Scope) services.EC2Interface
-	networkServiceFactory func(scope.ClusterScope) services.NetworkInterface
-	elbServiceFactory     func(scope.ELBScope) services.ELBInterface
-	securityGroupFactory  func(scope.ClusterScope) services.SecurityGroupInterface
-	Endpoints             []scope.ServiceEndpoint
-	WatchFilterValue      string
-	ExternalResourceGC    bool
-	AlternativeGCStrategy bool
+	Recorder                  record.EventRecorder
+	ec2ServiceFactory         func(scope.EC2Scope) services.EC2Interface
+	networkServiceFactory     func(scope.ClusterScope) services.NetworkInterface
+	elbServiceFactory         func(scope.ELBScope) services.ELBInterface
+	securityGroupFactory      func(scope.ClusterScope) services.SecurityGroupInterface
+	Endpoints                 []scope.ServiceEndpoint
+	WatchFilterValue          string
+	EnforcePrincipalAllowList bool
+	DescribeCacheTTL          time.Duration
+	AuditEventsVerbosity      int
+	ExternalResourceGC        bool
+	AlternativeGCStrategy     bool
+	// SyncPeriod is the interval at which AWSClusters are reconciled when nothing changed.
+	// If zero, the manager's sync period applies.
+	SyncPeriod time.Duration
 }
 
 // getEC2Service factory func is added for testing purpose so that we can inject mocked EC2Service to the AWSClusterReconciler.
@@ -100,7 +118,11 @@ func (r *AWSClusterReconciler) getNetworkService(scope scope.ClusterScope) servi
 	if r.networkServiceFactory != nil {
 		return r.networkServiceFactory(scope)
 	}
-	return network.NewService(&scope)
+	networkSvc := network.NewService(&scope)
+	if feature.Gates.Enabled(feature.ServiceQuotaChecks) {
+		networkSvc.QuotaService = servicequotas.NewService(&scope)
+	}
+	return networkSvc
 }
 
 // securityGroupRolesForCluster returns the security group roles determined by the cluster configuration.
@@ -126,8 +148,10 @@ func (r *AWSClusterReconciler) getSecurityGroupService(scope scope.ClusterScope)
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
-// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusterroleidentities;awsclusterstaticidentities,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusterroleidentities;awsclusterstaticidentities;awsclusterwebidentities,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclustercontrolleridentities,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
 
 func (r *AWSClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := logger.FromContext(ctx)
@@ -169,17 +193,27 @@ func (r *AWSClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Create the scope.
 	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
-		Client:         r.Client,
-		Logger:         log,
-		Cluster:        cluster,
-		AWSCluster:     awsCluster,
-		ControllerName: "awscluster",
-		Endpoints:      r.Endpoints,
+		Client:                    r.Client,
+		Logger:                    log,
+		Cluster:                   cluster,
+		AWSCluster:                awsCluster,
+		ControllerName:            "awscluster",
+		Endpoints:                 r.Endpoints,
+		EnforcePrincipalAllowList: r.EnforcePrincipalAllowList,
+		DescribeCacheTTL:          r.DescribeCacheTTL,
+		AuditEventsVerbosity:      r.AuditEventsVerbosity,
 	})
 	if err != nil {
 		return reconcile.Result{}, errors.Errorf("failed to create scope: %+v", err)
 	}
 
+	// Externally managed clusters are only observed, their spec, finalizers and conditions
+	// are left to the external infrastructure provider.
+	if capiannotations.IsExternallyManaged(awsCluster) {
+		result, err := r.reconcileExternallyManaged(ctx, clusterScope)
+		return requeue.AfterSyncPeriod(r.SyncPeriod, result, err)
+	}
+
 	// Always close the scope when exiting this function so we can persist any AWSCluster changes.
 	defer func() {
 		if err := clusterScope.Close(); err != nil && reterr == nil {
@@ -193,49 +227,66 @@ func (r *AWSClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	// Handle non-deleted clusters
-	return r.reconcileNormal(clusterScope)
+	result, err := r.reconcileNormal(ctx, clusterScope)
+	return requeue.AfterSyncPeriod(r.SyncPeriod, result, err)
 }
 
 func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	clusterScope.Info("Reconciling AWSCluster delete")
 
-	ec2svc := r.getEC2Service(clusterScope)
-	elbsvc := r.getELBService(clusterScope)
+	if clusterScope.AWSCluster.Spec.DeletionProtection {
+		clusterScope.Info("AWSCluster has deletion protection, its AWS resources won't be deleted until it is disabled")
+		conditions.MarkTrue(clusterScope.AWSCluster, infrav1.DeletionBlockedCondition)
+		return reconcile.Result{RequeueAfter: deletionBlockedRequeueAfter}, nil
+	}
+	conditions.Delete(clusterScope.AWSCluster, infrav1.DeletionBlockedCondition)
+
 	networkSvc := r.getNetworkService(*clusterScope)
 	sgService := r.getSecurityGroupService(*clusterScope)
 	s3Service := s3.NewService(clusterScope)
 
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
 		instancestateSvc := instancestate.NewService(clusterScope)
+		if feature.Gates.Enabled(feature.MachinePool) {
+			if err := instancestateSvc.DeleteMachinePoolEvents(); err != nil {
+				clusterScope.Error(err, "non-fatal: failed to delete EventBridge machine pool notifications")
+			}
+		} else if err := instancestateSvc.DeleteSpotInterruptionEvents(); err != nil {
+			clusterScope.Error(err, "non-fatal: failed to delete EventBridge spot interruption notifications")
+		}
 		if err := instancestateSvc.DeleteEC2Events(); err != nil {
 			// Not deleting the events isn't critical to cluster deletion
 			clusterScope.Error(err, "non-fatal: failed to delete EventBridge notifications")
 		}
 	}
 
-	if err := elbsvc.DeleteLoadbalancers(); err != nil {
-		clusterScope.Error(err, "error deleting load balancer")
-		return reconcile.Result{}, err
+	// The deletion of each component is recorded in its condition, so that the components that were deleted are
+	// skipped when the deletion is retried after an error.
+	if clusterScope.Monitoring() != nil || conditions.Has(clusterScope.AWSCluster, infrav1.AlarmsReadyCondition) {
+		if err := deleteComponent(clusterScope.AWSCluster, infrav1.AlarmsReadyCondition, monitoring.NewService(clusterScope).DeleteAlarms); err != nil {
+			clusterScope.Error(err, "error deleting CloudWatch alarms")
+			return reconcile.Result{}, err
+		}
 	}
 
-	if err := ec2svc.DeleteBastion(); err != nil {
-		clusterScope.Error(err, "error deleting bastion")
+	if err := r.deleteBastionAndLoadBalancers(clusterScope); err != nil {
 		return reconcile.Result{}, err
 	}
 
-	if err := sgService.DeleteSecurityGroups(); err != nil {
+	if err := deleteComponent(clusterScope.AWSCluster, infrav1.ClusterSecurityGroupsReadyCondition, sgService.DeleteSecurityGroups); err != nil {
 		clusterScope.Error(err, "error deleting security groups")
 		return reconcile.Result{}, err
 	}
 
-	if r.ExternalResourceGC {
-		gcSvc := gc.NewService(clusterScope, gc.WithGCStrategy(r.AlternativeGCStrategy))
-		if gcErr := gcSvc.ReconcileDelete(ctx); gcErr != nil {
-			return reconcile.Result{}, fmt.Errorf("failed delete reconcile for gc service: %w", gcErr)
+	if err := deleteComponent(clusterScope.AWSCluster, infrav1.VpcReadyCondition, func() error {
+		if r.ExternalResourceGC {
+			gcSvc := gc.NewService(clusterScope, gc.WithGCStrategy(r.AlternativeGCStrategy))
+			if gcErr := gcSvc.ReconcileDelete(ctx); gcErr != nil {
+				return fmt.Errorf("failed delete reconcile for gc service: %w", gcErr)
+			}
 		}
-	}
-
-	if err := networkSvc.DeleteNetwork(); err != nil {
+		return networkSvc.DeleteNetwork()
+	}); err != nil {
 		clusterScope.Error(err, "error deleting network")
 		return reconcile.Result{}, err
 	}
@@ -245,12 +296,69 @@ func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 	}
 
 	// Cluster is deleted so remove the finalizer.
+	billable.ForgetEstimate(clusterScope.Namespace(), clusterScope.Name())
 	controllerutil.RemoveFinalizer(clusterScope.AWSCluster, infrav1.ClusterFinalizer)
 
 	return reconcile.Result{}, nil
 }
 
-func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+// deleteBastionAndLoadBalancers deletes the bastion host and the load balancers of the cluster, which don't depend on
+// each other, in parallel. Each deletion runs against its own fork of the cluster scope so that they don't race on
+// the AWSCluster, and the conditions and status they change are copied back once both are done.
+func (r *AWSClusterReconciler) deleteBastionAndLoadBalancers(clusterScope *scope.ClusterScope) error {
+	bastionScope, err := clusterScope.Fork()
+	if err != nil {
+		return err
+	}
+	lbScope, err := clusterScope.Fork()
+	if err != nil {
+		return err
+	}
+
+	var bastionErr, lbErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		bastionErr = deleteComponent(bastionScope.AWSCluster, infrav1.BastionHostReadyCondition, r.getEC2Service(bastionScope).DeleteBastion)
+		if bastionErr != nil {
+			bastionScope.Error(bastionErr, "error deleting bastion")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		lbErr = deleteComponent(lbScope.AWSCluster, infrav1.LoadBalancerReadyCondition, r.getELBService(lbScope).DeleteLoadbalancers)
+		if lbErr != nil {
+			lbScope.Error(lbErr, "error deleting load balancer")
+		}
+	}()
+	wg.Wait()
+
+	conditions.Set(clusterScope.AWSCluster, conditions.Get(bastionScope.AWSCluster, infrav1.BastionHostReadyCondition))
+	clusterScope.SetBastionInstance(bastionScope.BastionInstance())
+	clusterScope.SetBastionConnection(bastionScope.BastionConnection())
+	conditions.Set(clusterScope.AWSCluster, conditions.Get(lbScope.AWSCluster, infrav1.LoadBalancerReadyCondition))
+	clusterScope.Network().APIServerELB = lbScope.Network().APIServerELB
+
+	return kerrors.NewAggregate([]error{lbErr, bastionErr})
+}
+
+// deleteComponent deletes a component of the cluster, unless its condition records that it was already deleted,
+// and records its deletion in the condition.
+func deleteComponent(awsCluster *infrav1.AWSCluster, condition clusterv1.ConditionType, deleteFn func() error) error {
+	if conditions.GetReason(awsCluster, condition) == infrav1.DeletionCompletedReason {
+		return nil
+	}
+
+	if err := deleteFn(); err != nil {
+		return err
+	}
+
+	conditions.MarkFalse(awsCluster, condition, infrav1.DeletionCompletedReason, clusterv1.ConditionSeverityInfo, "")
+	return nil
+}
+
+func (r *AWSClusterReconciler) reconcileNormal(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	clusterScope.Info("Reconciling AWSCluster")
 
 	awsCluster := clusterScope.AWSCluster
@@ -273,6 +381,10 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		clusterScope.Error(err, "failed to reconcile network")
 		return reconcile.Result{}, err
 	}
+	if network.NatInstancesPending(awsCluster) {
+		clusterScope.Info("Waiting on NAT instances")
+		return reconcile.Result{RequeueAfter: 15 * time.Second}, nil
+	}
 
 	if err := sgService.ReconcileSecurityGroups(); err != nil {
 		clusterScope.Error(err, "failed to reconcile security groups")
@@ -292,6 +404,13 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 			// non fatal error, so we continue
 			clusterScope.Error(err, "non-fatal: failed to set up EventBridge")
 		}
+		if feature.Gates.Enabled(feature.MachinePool) {
+			if err := instancestateSvc.ReconcileMachinePoolEvents(); err != nil {
+				clusterScope.Error(err, "non-fatal: failed to set up EventBridge for machine pools")
+			}
+		} else if err := instancestateSvc.ReconcileSpotInterruptionEvents(); err != nil {
+			clusterScope.Error(err, "non-fatal: failed to set up EventBridge for spot interruptions")
+		}
 	}
 
 	if err := elbService.ReconcileLoadbalancers(); err != nil {
@@ -300,6 +419,31 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		return reconcile.Result{}, err
 	}
 
+	billableResources, err := billable.Summarize(ctx, r.Client, client.ObjectKey{Namespace: clusterScope.Namespace(), Name: clusterScope.Name()}, clusterScope.Network(), awsCluster.Status.Bastion)
+	if err != nil {
+		// non fatal error, the summary is informational only
+		clusterScope.Error(err, "non-fatal: failed to summarize billable resources")
+	} else {
+		if feature.Gates.Enabled(feature.CostEstimation) {
+			if err := billable.EstimateCost(clusterScope, billableResources, awsCluster.Status.BillableResources, clusterScope.Network().APIServerELB.LoadBalancerType); err != nil {
+				clusterScope.Error(err, "non-fatal: failed to estimate the cost of billable resources")
+			}
+		}
+		awsCluster.Status.BillableResources = billableResources
+	}
+
+	if clusterScope.Monitoring() != nil || conditions.Has(awsCluster, infrav1.AlarmsReadyCondition) {
+		if err := monitoring.NewService(clusterScope).ReconcileAlarms(); err != nil {
+			// non fatal error, alarms don't affect the cluster itself
+			clusterScope.Error(err, "non-fatal: failed to reconcile CloudWatch alarms")
+			conditions.MarkFalse(awsCluster, infrav1.AlarmsReadyCondition, infrav1.AlarmsReconciliationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		} else if clusterScope.Monitoring() != nil {
+			conditions.MarkTrue(awsCluster, infrav1.AlarmsReadyCondition)
+		} else {
+			conditions.Delete(awsCluster, infrav1.AlarmsReadyCondition)
+		}
+	}
+
 	if err := s3Service.ReconcileBucket(); err != nil {
 		conditions.MarkFalse(awsCluster, infrav1.S3BucketReadyCondition, infrav1.S3BucketFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile S3 Bucket for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
@@ -324,10 +468,74 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		Host: awsCluster.Status.Network.APIServerELB.DNSName,
 		Port: clusterScope.APIServerPort(),
 	}
+	// The Route53 record stays the endpoint of the cluster when the load balancer is replaced.
+	if lb := awsCluster.Spec.ControlPlaneLoadBalancer; lb != nil && lb.Route53 != nil {
+		awsCluster.Spec.ControlPlaneEndpoint.Host = strings.TrimSuffix(lb.Route53.RecordName, ".")
+	}
+
+	setFailureDomains(clusterScope, clusterScope.Subnets(), awsCluster.Status.Network.APIServerELB.AvailabilityZones)
+
+	awsCluster.Status.Ready = true
+
+	// The cluster info ConfigMap can only be published once the workload
+	// cluster API server is reachable.
+	if feature.Gates.Enabled(feature.ClusterInfoConfigMap) && conditions.IsTrue(clusterScope.Cluster, clusterv1.ControlPlaneInitializedCondition) {
+		if err := clusterinfo.NewService(clusterScope).ReconcileClusterInfo(ctx); err != nil {
+			// non fatal error, the cluster is ready without the ConfigMap
+			clusterScope.Error(err, "non-fatal: failed to reconcile cluster info")
+			return reconcile.Result{RequeueAfter: 15 * time.Second}, nil
+		}
+	}
 
-	for _, subnet := range clusterScope.Subnets().FilterPrivate() {
+	// The replacement of the control plane load balancer progresses as its targets become healthy
+	// and the endpoint record propagates.
+	if conditions.IsFalse(awsCluster, infrav1.LoadBalancerSchemeReadyCondition) {
+		clusterScope.Info("Waiting on the replacement of the control plane load balancer")
+		return reconcile.Result{RequeueAfter: 15 * time.Second}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileExternallyManaged mirrors the network of an externally managed cluster
+// in its status, without modifying any AWS resource nor the spec of the AWSCluster.
+func (r *AWSClusterReconciler) reconcileExternallyManaged(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	awsCluster := clusterScope.AWSCluster
+
+	if !awsCluster.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	clusterScope.Info("Observing externally managed AWSCluster")
+
+	patchHelper, err := patch.NewHelper(awsCluster, r.Client)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	subnets, err := r.getNetworkService(*clusterScope).ObserveNetwork()
+	if err != nil {
+		clusterScope.Error(err, "failed to observe network")
+		return reconcile.Result{}, err
+	}
+
+	// The load balancer of an externally managed cluster isn't known, so the control plane
+	// can be placed in any zone unless the external provider reported the zones of the load balancer.
+	controlPlaneZones := awsCluster.Status.Network.APIServerELB.AvailabilityZones
+	if len(controlPlaneZones) == 0 {
+		controlPlaneZones = subnets.GetUniqueZones()
+	}
+	setFailureDomains(clusterScope, subnets, controlPlaneZones)
+
+	return reconcile.Result{}, patchHelper.Patch(ctx, awsCluster)
+}
+
+// setFailureDomains reports the zones of the private subnets as failure domains,
+// the control plane may only be placed in controlPlaneZones.
+func setFailureDomains(clusterScope *scope.ClusterScope, subnets infrav1.Subnets, controlPlaneZones []string) {
+	for _, subnet := range subnets.FilterPrivate() {
 		found := false
-		for _, az := range awsCluster.Status.Network.APIServerELB.AvailabilityZones {
+		for _, az := range controlPlaneZones {
 			if az == subnet.AvailabilityZone {
 				found = true
 				break
@@ -338,9 +546,6 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 			ControlPlane: found,
 		})
 	}
-
-	awsCluster.Status.Ready = true
-	return reconcile.Result{}, nil
 }
 
 func (r *AWSClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -371,7 +576,6 @@ func (r *AWSClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 				},
 			},
 		).
-		WithEventFilter(predicates.ResourceIsNotExternallyManaged(log.GetLogger())).
 		Build(r)
 	if err != nil {
 		return errors.Wrap(err, "error creating controller")
@@ -380,7 +584,10 @@ func (r *AWSClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 	return controller.Watch(
 		&source.Kind{Type: &clusterv1.Cluster{}},
 		handler.EnqueueRequestsFromMapFunc(r.requeueAWSClusterForUnpausedCluster(ctx, log)),
-		predicates.ClusterUnpaused(log.GetLogger()),
+		predicates.Any(log.GetLogger(),
+			predicates.ClusterUnpaused(log.GetLogger()),
+			predicates.ClusterControlPlaneInitialized(log.GetLogger()),
+		),
 	)
 }
 
@@ -418,11 +625,6 @@ func (r *AWSClusterReconciler) requeueAWSClusterForUnpausedCluster(ctx context.C
 			return nil
 		}
 
-		if capiannotations.IsExternallyManaged(awsCluster) {
-			log.Trace("AWSCluster is externally managed, skipping mapping.")
-			return nil
-		}
-
 		log.Trace("Adding request.", "awsCluster", c.Spec.InfrastructureRef.Name)
 		return []ctrl.Request{
 			{
@@ -44,7 +44,9 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/internal/requeue"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
@@ -52,6 +54,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/secretsmanager"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ssm"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
@@ -79,6 +82,12 @@ type AWSMachineReconciler struct {
 	objectStoreServiceFactory    func(cloud.ClusterScoper) services.ObjectStoreInterface
 	Endpoints                    []scope.ServiceEndpoint
 	WatchFilterValue             string
+	EnforcePrincipalAllowList    bool
+	DescribeCacheTTL             time.Duration
+	AuditEventsVerbosity         int
+	// SyncPeriod is the interval at which AWSMachines are reconciled when nothing changed.
+	// If zero, the manager's sync period applies.
+	SyncPeriod time.Duration
 }
 
 const (
@@ -91,7 +100,11 @@ func (r *AWSMachineReconciler) getEC2Service(scope scope.EC2Scope) services.EC2I
 		return r.ec2ServiceFactory(scope)
 	}
 
-	return ec2.NewService(scope)
+	ec2Svc := ec2.NewService(scope)
+	if feature.Gates.Enabled(feature.ServiceQuotaChecks) {
+		ec2Svc.QuotaService = servicequotas.NewService(scope)
+	}
+	return ec2Svc
 }
 
 func (r *AWSMachineReconciler) getSecretsManagerService(scope cloud.ClusterScoper) services.SecretInterface {
@@ -217,13 +230,15 @@ func (r *AWSMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			return r.reconcileDelete(machineScope, infraScope, infraScope, nil, nil)
 		}
 
-		return r.reconcileNormal(ctx, machineScope, infraScope, infraScope, nil, nil)
+		result, err := r.reconcileNormal(ctx, machineScope, infraScope, infraScope, nil, nil)
+		return requeue.AfterSyncPeriod(r.SyncPeriod, result, err)
 	case *scope.ClusterScope:
 		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
 			return r.reconcileDelete(machineScope, infraScope, infraScope, infraScope, infraScope)
 		}
 
-		return r.reconcileNormal(ctx, machineScope, infraScope, infraScope, infraScope, infraScope)
+		result, err := r.reconcileNormal(ctx, machineScope, infraScope, infraScope, infraScope, infraScope)
+		return requeue.AfterSyncPeriod(r.SyncPeriod, result, err)
 	default:
 		return ctrl.Result{}, errors.New("infraCluster has unknown type")
 	}
@@ -330,12 +345,24 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 	}
 
 	if machineScope.IsControlPlane() {
+		if remaining := r.connectionDrainingRemaining(machineScope, elbScope, instance); remaining > 0 {
+			machineScope.Info("Waiting for control plane load balancer connections to drain", "instance-id", instance.ID, "remaining", remaining)
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 	}
 
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
 		instancestateSvc := instancestate.NewService(ec2Scope)
 		instancestateSvc.RemoveInstanceFromEventPattern(instance.ID)
+		if machineScope.AWSMachine.Spec.SpotMarketOptions != nil {
+			// Best effort, as for the instance state rule.
+			_ = instancestateSvc.UpdateSpotInstancesInEventPattern(nil, []string{instance.ID})
+		}
+	}
+
+	if shouldHibernateOnDelete(machineScope.AWSMachine, instance) {
+		return r.hibernateInstance(machineScope, ec2Service, instance)
 	}
 
 	// Check the instance state. If it's already shutting down or terminated,
@@ -361,6 +388,31 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 			return ctrl.Result{}, err
 		}
 
+		if machineScope.AWSMachine.Spec.SnapshotOnDelete {
+			if err := ec2Service.SnapshotVolumes(machineScope, instance.ID); err != nil {
+				machineScope.Error(err, "failed to snapshot volumes")
+				conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+				return ctrl.Result{}, err
+			}
+		}
+
+		if machineScope.AWSMachine.Spec.CleanupPolicy != nil {
+			if err := ec2Service.ApplyCleanupPolicy(machineScope, instance.ID); err != nil {
+				machineScope.Error(err, "failed to apply cleanup policy")
+				conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+				return ctrl.Result{}, err
+			}
+		}
+
+		// The cleanup policy of the machine takes precedence over the retention policy of the cluster.
+		if machineScope.AWSMachine.Spec.CleanupPolicy.VolumesAction() == "" && ec2Scope.ResourceRetentionPolicy().RetainsNonRootVolumes() {
+			if err := ec2Service.RetainNonRootVolumes(instance.ID, machineScope.AWSMachine.Spec.NonRootVolumes); err != nil {
+				machineScope.Error(err, "failed to retain non root volumes")
+				conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+				return ctrl.Result{}, err
+			}
+		}
+
 		if err := ec2Service.TerminateInstance(instance.ID); err != nil {
 			machineScope.Error(err, "failed to terminate instance")
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
@@ -388,7 +440,11 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 				return ctrl.Result{}, err
 			}
 
-			for _, id := range machineScope.AWSMachine.Spec.NetworkInterfaces {
+			for i, id := range machineScope.AWSMachine.Spec.NetworkInterfaces {
+				// Secondary network interfaces deleted with the instance don't need to be detached.
+				if i > 0 && machineScope.AWSMachine.Spec.CleanupPolicy.NetworkInterfacesAction() == infrav1.CleanupActionDelete {
+					continue
+				}
 				if err := ec2Service.DetachSecurityGroupsFromNetworkInterface(core, id); err != nil {
 					machineScope.Error(err, "failed to detach security groups from instance's network interfaces")
 					conditions.MarkFalse(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
@@ -406,6 +462,54 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 	}
 }
 
+// shouldHibernateOnDelete returns true if the instance of a deleted AWSMachine is to be hibernated
+// instead of terminated. Instances which are already being terminated are not hibernated.
+func shouldHibernateOnDelete(awsMachine *infrav1.AWSMachine, instance *infrav1.Instance) bool {
+	if awsMachine.Annotations[infrav1.HibernateOnDeleteAnnotation] != "true" {
+		return false
+	}
+	if instance.HibernationOptions == nil || !instance.HibernationOptions.Configured {
+		return false
+	}
+	return instance.State != infrav1.InstanceStateShuttingDown && instance.State != infrav1.InstanceStateTerminated
+}
+
+// hibernateInstance hibernates the instance of a deleted AWSMachine and removes the finalizer once the
+// instance is stopped. The hibernated instance is left behind for the user to resume or terminate.
+func (r *AWSMachineReconciler) hibernateInstance(machineScope *scope.MachineScope, ec2Service services.EC2Interface, instance *infrav1.Instance) (ctrl.Result, error) {
+	switch instance.State {
+	case infrav1.InstanceStateStopping:
+		machineScope.Info("EC2 instance is hibernating", "instance-id", instance.ID)
+		// requeue reconciliation until we observe the instance stopped
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	case infrav1.InstanceStateStopped:
+		machineScope.Info("EC2 instance hibernated successfully", "instance-id", instance.ID)
+		controllerutil.RemoveFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer)
+		return ctrl.Result{}, nil
+	default:
+		machineScope.Info("Hibernating EC2 instance", "instance-id", instance.ID)
+
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+		if err := machineScope.PatchObject(); err != nil {
+			machineScope.Error(err, "failed to patch object")
+			return ctrl.Result{}, err
+		}
+
+		if err := ec2Service.HibernateInstance(instance.ID); err != nil {
+			machineScope.Error(err, "failed to hibernate instance")
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedHibernate", "Failed to hibernate instance %q: %v", instance.ID, err)
+			return ctrl.Result{}, err
+		}
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceStoppedReason, clusterv1.ConditionSeverityInfo, "")
+
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulHibernate", "Hibernated instance %q", instance.ID)
+
+		// requeue reconciliation until we observe the instance stopped
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+}
+
 // findInstance queries the EC2 apis and retrieves the instance if it exists.
 // If providerID is empty, finds instance by tags and if it cannot be found, returns empty instance with nil error.
 // If providerID is set, either finds the instance by ID or returns error.
@@ -488,7 +592,7 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	// Create new instance since providerId is nil and instance could not be found by tags.
 	if instance == nil {
 		// Avoid a flickering condition between InstanceProvisionStarted and InstanceProvisionFailed if there's a persistent failure with createInstance
-		if conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition) != infrav1.InstanceProvisionFailedReason {
+		if reason := conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition); reason != infrav1.InstanceProvisionFailedReason && reason != infrav1.QuotaExceededReason {
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionStartedReason, clusterv1.ConditionSeverityInfo, "")
 			if patchErr := machineScope.PatchObject(); err != nil {
 				machineScope.Error(patchErr, "failed to patch conditions")
@@ -505,7 +609,11 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		instance, err = r.createInstance(ec2svc, machineScope, clusterScope, objectStoreSvc)
 		if err != nil {
 			machineScope.Error(err, "unable to create instance")
-			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			reason := infrav1.InstanceProvisionFailedReason
+			if awserrors.IsQuotaExceeded(err) {
+				reason = infrav1.QuotaExceededReason
+			}
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, reason, clusterv1.ConditionSeverityError, err.Error())
 			return ctrl.Result{}, err
 		}
 	}
@@ -514,6 +622,12 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		if err := instancestateSvc.AddInstanceToEventPattern(instance.ID); err != nil {
 			return ctrl.Result{}, errors.Wrap(err, "failed to add instance to Event Bridge instance state rule")
 		}
+		// The spot interruption rule isn't set up for EKS clusters without machine pools.
+		if machineScope.AWSMachine.Spec.SpotMarketOptions != nil {
+			if err := instancestateSvc.UpdateSpotInstancesInEventPattern([]string{instance.ID}, nil); err != nil && !instancestate.IsRuleNotFound(err) {
+				return ctrl.Result{}, errors.Wrap(err, "failed to add instance to Event Bridge spot interruption rule")
+			}
+		}
 	}
 
 	// Make sure Spec.ProviderID and Spec.InstanceID are always set.
@@ -577,6 +691,7 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 
 		if instance != nil {
 			r.ensureStorageTags(ec2svc, instance, machineScope.AWSMachine)
+			r.ensureNetworkTags(ec2svc, instance, machineScope.AWSMachine)
 		}
 
 		if err := r.reconcileLBAttachment(machineScope, elbScope, instance); err != nil {
@@ -615,7 +730,7 @@ func (r *AWSMachineReconciler) reconcileOperationalState(ec2svc services.EC2Inte
 	}
 	conditions.MarkTrue(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition)
 
-	err = r.ensureInstanceMetadataOptions(ec2svc, instance, machineScope.AWSMachine)
+	err = r.ensureInstanceMetadataOptions(ec2svc, instance, machineScope.AWSMachine, machineScope.InfraCluster.SecurityProfile())
 	if err != nil {
 		machineScope.Error(err, "failed to ensure instance metadata options")
 		return err
@@ -680,6 +795,13 @@ func (r *AWSMachineReconciler) resolveUserData(machineScope *scope.MachineScope,
 		return nil, "", err
 	}
 
+	// Bootstrap commands are merged into the bootstrap data, before it is stored in the secure secrets backend.
+	userData, err = userdata.WithBootstrapCommands(userData, machineScope.AWSMachine.Spec.PreBootstrapCommands, machineScope.AWSMachine.Spec.PostBootstrapCommands)
+	if err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedAddBootstrapCommands", err.Error())
+		return nil, "", err
+	}
+
 	if machineScope.UseSecretsManager(userDataFormat) {
 		userData, err = r.cloudInitUserData(machineScope, clusterScope, userData)
 	}
@@ -688,9 +810,66 @@ func (r *AWSMachineReconciler) resolveUserData(machineScope *scope.MachineScope,
 		userData, err = r.ignitionUserData(machineScope, objectStoreSvc, userData)
 	}
 
+	if err != nil {
+		return userData, userDataFormat, err
+	}
+
+	// Proxy settings are only rendered into cloud-init and Bottlerocket user data.
+	var proxy *userdata.ProxyInput
+	if clusterScope.Proxy() != nil && !machineScope.UseIgnition(userDataFormat) {
+		if proxy, err = r.proxyInput(machineScope, clusterScope); err != nil {
+			return userData, userDataFormat, err
+		}
+	}
+
+	if machineScope.UseBottlerocket() {
+		userData, err = r.bottlerocketUserData(machineScope, userData, proxy)
+	} else if proxy != nil {
+		userData, err = r.proxyUserData(machineScope, userData, proxy)
+	}
+
 	return userData, userDataFormat, err
 }
 
+// proxyInput returns the proxy settings of the cluster, with the trusted CA bundle they reference.
+func (r *AWSMachineReconciler) proxyInput(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper) (*userdata.ProxyInput, error) {
+	trustedCA, err := scope.GetProxyTrustedCA(r.Client, clusterScope.Namespace(), clusterScope.Proxy())
+	if err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedGetProxyTrustedCA", err.Error())
+		return nil, err
+	}
+	return &userdata.ProxyInput{
+		Proxy:     *clusterScope.Proxy(),
+		TrustedCA: trustedCA,
+	}, nil
+}
+
+// proxyUserData runs the proxy boothook before the cloud-init user data of the machine, including
+// the script fetching the bootstrap data from the secure secrets backend.
+func (r *AWSMachineReconciler) proxyUserData(machineScope *scope.MachineScope, userData []byte, proxy *userdata.ProxyInput) ([]byte, error) {
+	proxyUserData, err := userdata.WithProxy(userData, proxy)
+	if err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedGenerateProxyUserData", err.Error())
+		return nil, err
+	}
+	return proxyUserData, nil
+}
+
+// bottlerocketUserData renders the Bottlerocket settings of the machine, handing the bootstrap
+// data, or the script fetching it from the secure secrets backend, to the bootstrap container.
+func (r *AWSMachineReconciler) bottlerocketUserData(machineScope *scope.MachineScope, userData []byte, proxy *userdata.ProxyInput) ([]byte, error) {
+	bottlerocketUserData, err := userdata.NewBottlerocket(&userdata.BottlerocketInput{
+		Settings:      *machineScope.AWSMachine.Spec.Bottlerocket,
+		BootstrapData: userData,
+		Proxy:         proxy,
+	})
+	if err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedGenerateBottlerocketSettings", err.Error())
+		return nil, err
+	}
+	return bottlerocketUserData, nil
+}
+
 func (r *AWSMachineReconciler) cloudInitUserData(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, userData []byte) ([]byte, error) {
 	secretSvc, secretBackendErr := r.getSecretService(machineScope, clusterScope)
 	if secretBackendErr != nil {
@@ -804,6 +983,33 @@ func (r *AWSMachineReconciler) deleteIgnitionBootstrapDataFromS3(machineScope *s
 	return nil
 }
 
+// connectionDrainingRemaining returns how long a control plane instance that has just been deregistered from
+// the API server load balancer still has to wait before it can be terminated, so that in-flight requests are
+// not cut off. The start of the wait is recorded as the last transition time of the ELBAttached condition,
+// which is explicitly reset when draining starts since the condition may already have been False for another reason.
+func (r *AWSMachineReconciler) connectionDrainingRemaining(machineScope *scope.MachineScope, elbScope scope.ELBScope, instance *infrav1.Instance) time.Duration {
+	lb := elbScope.ControlPlaneLoadBalancer()
+	if lb == nil || lb.ConnectionDrainingTimeout == nil || lb.ConnectionDrainingTimeout.Duration <= 0 {
+		return 0
+	}
+
+	// Nothing left to drain once the instance is going away.
+	if instance.State == infrav1.InstanceStateShuttingDown || instance.State == infrav1.InstanceStateTerminated {
+		return 0
+	}
+
+	timeout := lb.ConnectionDrainingTimeout.Duration
+	condition := conditions.Get(machineScope.AWSMachine, infrav1.ELBAttachedCondition)
+	if condition == nil || condition.Reason != infrav1.ELBConnectionDrainingReason {
+		conditions.Delete(machineScope.AWSMachine, infrav1.ELBAttachedCondition)
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBConnectionDrainingReason, clusterv1.ConditionSeverityInfo,
+			"Waiting %s for connections to drain", timeout)
+		return timeout
+	}
+
+	return timeout - time.Since(condition.LastTransitionTime.Time)
+}
+
 func (r *AWSMachineReconciler) reconcileLBAttachment(machineScope *scope.MachineScope, elbScope scope.ELBScope, i *infrav1.Instance) error {
 	if !machineScope.IsControlPlane() {
 		return nil
@@ -1032,12 +1238,15 @@ func (r *AWSMachineReconciler) getInfraCluster(ctx context.Context, log *logger.
 		}
 
 		managedControlPlaneScope, err = scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
-			Client:         r.Client,
-			Logger:         log,
-			Cluster:        cluster,
-			ControlPlane:   controlPlane,
-			ControllerName: "awsManagedControlPlane",
-			Endpoints:      r.Endpoints,
+			Client:                    r.Client,
+			Logger:                    log,
+			Cluster:                   cluster,
+			ControlPlane:              controlPlane,
+			ControllerName:            "awsManagedControlPlane",
+			Endpoints:                 r.Endpoints,
+			EnforcePrincipalAllowList: r.EnforcePrincipalAllowList,
+			DescribeCacheTTL:          r.DescribeCacheTTL,
+			AuditEventsVerbosity:      r.AuditEventsVerbosity,
 		})
 		if err != nil {
 			return nil, err
@@ -1060,11 +1269,14 @@ func (r *AWSMachineReconciler) getInfraCluster(ctx context.Context, log *logger.
 
 	// Create the cluster scope
 	clusterScope, err = scope.NewClusterScope(scope.ClusterScopeParams{
-		Client:         r.Client,
-		Logger:         log,
-		Cluster:        cluster,
-		AWSCluster:     awsCluster,
-		ControllerName: "awsmachine",
+		Client:                    r.Client,
+		Logger:                    log,
+		Cluster:                   cluster,
+		AWSCluster:                awsCluster,
+		ControllerName:            "awsmachine",
+		EnforcePrincipalAllowList: r.EnforcePrincipalAllowList,
+		DescribeCacheTTL:          r.DescribeCacheTTL,
+		AuditEventsVerbosity:      r.AuditEventsVerbosity,
 	})
 	if err != nil {
 		return nil, err
@@ -1115,10 +1327,39 @@ func (r *AWSMachineReconciler) ensureStorageTags(ec2svc services.EC2Interface, i
 	}
 }
 
-func (r *AWSMachineReconciler) ensureInstanceMetadataOptions(ec2svc services.EC2Interface, instance *infrav1.Instance, machine *infrav1.AWSMachine) error {
-	if cmp.Equal(machine.Spec.InstanceMetadataOptions, instance.InstanceMetadataOptions) {
+func (r *AWSMachineReconciler) ensureNetworkTags(ec2svc services.EC2Interface, instance *infrav1.Instance, machine *infrav1.AWSMachine) {
+	if len(instance.NetworkInterfaces) == 0 {
+		return
+	}
+
+	annotations, err := r.machineAnnotationJSON(machine, NetworkInterfaceTagsLastAppliedAnnotation)
+	if err != nil {
+		r.Log.Error(err, "Failed to fetch the annotations for network interface tags")
+	}
+	for _, eniID := range instance.NetworkInterfaces {
+		subAnnotation, ok := annotations[eniID].(map[string]interface{})
+		if !ok {
+			subAnnotation = make(map[string]interface{})
+		}
+		newAnnotation, err := r.ensureNetworkInterfaceTags(ec2svc, aws.String(eniID), subAnnotation, machine.Spec.AdditionalTags)
+		if err != nil {
+			r.Log.Error(err, "Failed to update the network interface tags in EC2 instance")
+			continue
+		}
+		annotations[eniID] = newAnnotation
+	}
+
+	// We also need to update the annotation if anything changed.
+	if err := r.updateMachineAnnotationJSON(machine, NetworkInterfaceTagsLastAppliedAnnotation, annotations); err != nil {
+		r.Log.Error(err, "Failed to update the network interface tags annotation")
+	}
+}
+
+func (r *AWSMachineReconciler) ensureInstanceMetadataOptions(ec2svc services.EC2Interface, instance *infrav1.Instance, machine *infrav1.AWSMachine, profile infrav1.SecurityProfile) error {
+	options := profile.ApplyToInstanceMetadataOptions(machine.Spec.InstanceMetadataOptions)
+	if cmp.Equal(options, instance.InstanceMetadataOptions) {
 		return nil
 	}
 
-	return ec2svc.ModifyInstanceMetadataOptions(instance.ID, machine.Spec.InstanceMetadataOptions)
+	return ec2svc.ModifyInstanceMetadataOptions(instance.ID, options)
 }
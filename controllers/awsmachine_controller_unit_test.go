@@ -44,17 +44,20 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	ec2Service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	elbService "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/elb"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const providerID = "aws:////myMachine"
@@ -463,6 +466,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 							ID: pointer.String("sg-2345"),
 						},
 					}
+					ec2Svc.EXPECT().CheckSecurityGroupsPerNetworkInterface(1).Return(nil)
 					ec2Svc.EXPECT().UpdateInstanceSecurityGroups(instance.ID, []string{"sg-2345"})
 					ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return([]string{"sg-2345"}, nil)
 
@@ -540,6 +544,39 @@ func TestAWSMachineReconciler(t *testing.T) {
 					_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 					g.Expect(err).To(BeNil())
 				})
+				t.Run("should tag instance network interfaces and skip protected tags", func(t *testing.T) {
+					g := NewWithT(t)
+					awsMachine := getAWSMachine()
+					setup(t, g, awsMachine)
+					defer teardown(t, g)
+					instanceCreate(t, g)
+					getCoreSecurityGroups(t, g)
+					instance.VolumeIDs = nil
+					instance.NetworkInterfaces = []string{"eni-1"}
+					ms.AWSMachine.Annotations = map[string]string{}
+
+					ms.AWSMachine.Spec.AdditionalTags = infrav1.Tags{
+						"kind":                                "alicorn",
+						"aws:cloudformation:stack":            "stack",
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					}
+
+					ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(nil, nil)
+					ec2Svc.EXPECT().UpdateResourceTags(
+						PointsTo("myMachine"),
+						map[string]string{"kind": "alicorn"},
+						map[string]string{},
+					).Return(nil)
+					ec2Svc.EXPECT().UpdateResourceTags(
+						PointsTo("eni-1"),
+						map[string]string{"kind": "alicorn"},
+						map[string]string{},
+					).Return(nil)
+
+					_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+					g.Expect(err).To(BeNil())
+					g.Expect(ms.AWSMachine.Annotations).To(HaveKeyWithValue(NetworkInterfaceTagsLastAppliedAnnotation, `{"eni-1":{"kind":"alicorn"}}`))
+				})
 			})
 
 			t.Run("temporarily stopping then starting the AWSMachine(stateless)", func(t *testing.T) {
@@ -957,6 +994,43 @@ func TestAWSMachineReconciler(t *testing.T) {
 					g.Expect(ms.AWSMachine.Finalizers).To(ContainElement(infrav1.MachineFinalizer))
 					expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.SecurityGroupsReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityError, infrav1.SecurityGroupsFailedReason}})
 				})
+				t.Run("Should fail when security groups exceed the per network interface quota", func(t *testing.T) {
+					g := NewWithT(t)
+					awsMachine := getAWSMachine()
+					setup(t, g, awsMachine)
+					defer teardown(t, g)
+					id := providerID
+					ms.AWSMachine.Spec.ProviderID = &id
+
+					instanceCreate(t, g)
+					ensureSecurityGroups(t, g)
+					ms.AWSMachine.Spec.AdditionalSecurityGroups = []infrav1.AWSResourceReference{
+						{
+							Filters: []infrav1.Filter{
+								{
+									Name:   "tag:role",
+									Values: []string{"extra"},
+								},
+							},
+						},
+					}
+					additional := []string{}
+					for i := 0; i < 5; i++ {
+						additional = append(additional, fmt.Sprintf("sg-extra-%d", i))
+					}
+
+					ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{"sg-core"}, nil)
+					ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(additional, nil)
+					ec2Svc.EXPECT().CheckSecurityGroupsPerNetworkInterface(6).Return(&servicequotas.QuotaExceededError{
+						Usage:     servicequotas.Usage{Quota: servicequotas.SecurityGroupsPerNetworkInterface, Limit: 5},
+						Requested: 6,
+					})
+
+					_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+					g.Expect(err).To(MatchError(ContainSubstring("instance would have 6 security groups")))
+					g.Expect(awserrors.IsQuotaExceeded(err)).To(BeTrue())
+					expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.SecurityGroupsReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityError, infrav1.SecurityGroupsFailedReason}})
+				})
 				t.Run("Should fail to update security group", func(t *testing.T) {
 					g := NewWithT(t)
 					awsMachine := getAWSMachine()
@@ -980,6 +1054,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 					}
 
 					ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil)
+					ec2Svc.EXPECT().CheckSecurityGroupsPerNetworkInterface(gomock.Any()).Return(nil)
 					ec2Svc.EXPECT().UpdateInstanceSecurityGroups(gomock.Any(), gomock.Any()).Return(errors.New("failed to update security groups"))
 					ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return([]string{"sg-1"}, nil)
 
@@ -1557,6 +1632,69 @@ func TestAWSMachineReconciler(t *testing.T) {
 			g.Expect(buf.String()).To(ContainSubstring("EC2 instance terminated successfully"))
 			g.Expect(ms.AWSMachine.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
 		})
+		t.Run("should hibernate instances with the hibernate on delete annotation", func(t *testing.T) {
+			g := NewWithT(t)
+			awsMachine := getAWSMachine()
+			setup(t, g, awsMachine)
+			defer teardown(t, g)
+			finalizer(t, g)
+			ms.AWSMachine.Annotations = map[string]string{infrav1.HibernateOnDeleteAnnotation: "true"}
+
+			ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(&infrav1.Instance{
+				ID:                 "myMachine",
+				State:              infrav1.InstanceStateRunning,
+				HibernationOptions: &infrav1.HibernationOptions{Configured: true},
+			}, nil)
+			secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).AnyTimes()
+			ec2Svc.EXPECT().HibernateInstance("myMachine").Return(nil)
+			ec2Svc.EXPECT().TerminateInstance(gomock.Any()).Times(0)
+
+			res, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(res.RequeueAfter).To(Equal(time.Minute))
+			g.Expect(ms.AWSMachine.Finalizers).To(ContainElement(infrav1.MachineFinalizer))
+			g.Eventually(recorder.Events).Should(Receive(ContainSubstring("SuccessfulHibernate")))
+		})
+		t.Run("should remove finalizer when the hibernated instance is stopped", func(t *testing.T) {
+			g := NewWithT(t)
+			awsMachine := getAWSMachine()
+			setup(t, g, awsMachine)
+			defer teardown(t, g)
+			finalizer(t, g)
+			ms.AWSMachine.Annotations = map[string]string{infrav1.HibernateOnDeleteAnnotation: "true"}
+
+			ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(&infrav1.Instance{
+				ID:                 "myMachine",
+				State:              infrav1.InstanceStateStopped,
+				HibernationOptions: &infrav1.HibernationOptions{Configured: true},
+			}, nil)
+			secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).AnyTimes()
+			ec2Svc.EXPECT().HibernateInstance(gomock.Any()).Times(0)
+			ec2Svc.EXPECT().TerminateInstance(gomock.Any()).Times(0)
+
+			_, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(ms.AWSMachine.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
+		})
+		t.Run("should terminate instances without hibernation configured despite the hibernate on delete annotation", func(t *testing.T) {
+			g := NewWithT(t)
+			awsMachine := getAWSMachine()
+			setup(t, g, awsMachine)
+			defer teardown(t, g)
+			finalizer(t, g)
+			ms.AWSMachine.Annotations = map[string]string{infrav1.HibernateOnDeleteAnnotation: "true"}
+
+			ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(&infrav1.Instance{
+				ID:    "myMachine",
+				State: infrav1.InstanceStateRunning,
+			}, nil)
+			secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).AnyTimes()
+			ec2Svc.EXPECT().HibernateInstance(gomock.Any()).Times(0)
+			ec2Svc.EXPECT().TerminateInstance("myMachine").Return(nil)
+
+			_, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+			g.Expect(err).To(BeNil())
+		})
 		t.Run("instance not shutting down yet", func(t *testing.T) {
 			id := "aws:////myid"
 			getRunningInstance := func(t *testing.T, g *WithT) {
@@ -1763,6 +1901,107 @@ func TestAWSMachineReconciler(t *testing.T) {
 				g.Expect(ms.AWSMachine.Finalizers).To(ContainElement(metav1.FinalizerDeleteDependents))
 				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.ELBAttachedCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityWarning, "DeletingFailed"}})
 			})
+			t.Run("should wait for connections to drain before terminating the instance", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				finalizer(t, g)
+				ms.Machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabel: ""}
+				cs.AWSCluster.Spec.ControlPlaneLoadBalancer = &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType:          infrav1.LoadBalancerTypeClassic,
+					ConnectionDrainingTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+				}
+				reconciler.elbServiceFactory = func(elbScope scope.ELBScope) services.ELBInterface {
+					return elbSvc
+				}
+
+				ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(&infrav1.Instance{
+					ID:    "myMachine",
+					State: infrav1.InstanceStateRunning,
+				}, nil)
+				secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).AnyTimes()
+				elbSvc.EXPECT().IsInstanceRegisteredWithAPIServerELB(gomock.Any()).Return(true, nil)
+				elbSvc.EXPECT().DeregisterInstanceFromAPIServerELB(gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().TerminateInstance(gomock.Any()).Times(0)
+
+				res, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+				g.Expect(err).To(BeNil())
+				g.Expect(res.RequeueAfter).To(Equal(5 * time.Minute))
+				g.Expect(ms.AWSMachine.Finalizers).To(ContainElement(infrav1.MachineFinalizer))
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.ELBAttachedCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.ELBConnectionDrainingReason}})
+			})
+			t.Run("should start the drain wait when ELBAttached was already false for another reason", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				finalizer(t, g)
+				ms.Machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabel: ""}
+				cs.AWSCluster.Spec.ControlPlaneLoadBalancer = &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType:          infrav1.LoadBalancerTypeClassic,
+					ConnectionDrainingTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+				}
+				ms.AWSMachine.Status.Conditions = clusterv1.Conditions{{
+					Type:               infrav1.ELBAttachedCondition,
+					Status:             corev1.ConditionFalse,
+					Severity:           clusterv1.ConditionSeverityWarning,
+					Reason:             "DeletingFailed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+				}}
+				reconciler.elbServiceFactory = func(elbScope scope.ELBScope) services.ELBInterface {
+					return elbSvc
+				}
+
+				ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(&infrav1.Instance{
+					ID:    "myMachine",
+					State: infrav1.InstanceStateRunning,
+				}, nil)
+				secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).AnyTimes()
+				elbSvc.EXPECT().IsInstanceRegisteredWithAPIServerELB(gomock.Any()).Return(false, nil)
+				ec2Svc.EXPECT().TerminateInstance(gomock.Any()).Times(0)
+
+				res, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+				g.Expect(err).To(BeNil())
+				g.Expect(res.RequeueAfter).To(Equal(5 * time.Minute))
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.ELBAttachedCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.ELBConnectionDrainingReason}})
+				g.Expect(conditions.Get(ms.AWSMachine, infrav1.ELBAttachedCondition).LastTransitionTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+			t.Run("should terminate the instance once connections have drained", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				finalizer(t, g)
+				ms.Machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabel: ""}
+				cs.AWSCluster.Spec.ControlPlaneLoadBalancer = &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType:          infrav1.LoadBalancerTypeClassic,
+					ConnectionDrainingTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+				}
+				ms.AWSMachine.Status.Conditions = clusterv1.Conditions{{
+					Type:               infrav1.ELBAttachedCondition,
+					Status:             corev1.ConditionFalse,
+					Severity:           clusterv1.ConditionSeverityInfo,
+					Reason:             infrav1.ELBConnectionDrainingReason,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+				}}
+				reconciler.elbServiceFactory = func(elbScope scope.ELBScope) services.ELBInterface {
+					return elbSvc
+				}
+
+				ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(&infrav1.Instance{
+					ID:    "myMachine",
+					State: infrav1.InstanceStateRunning,
+				}, nil)
+				secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).AnyTimes()
+				elbSvc.EXPECT().IsInstanceRegisteredWithAPIServerELB(gomock.Any()).Return(false, nil)
+				ec2Svc.EXPECT().TerminateInstance("myMachine").Return(nil)
+
+				res, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+				g.Expect(err).To(BeNil())
+				g.Expect(res.RequeueAfter).To(Equal(time.Minute))
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.ELBAttachedCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, clusterv1.DeletedReason}})
+			})
 			t.Run("should fail if secretPrefix present, but secretCount is not set", func(t *testing.T) {
 				g := NewWithT(t)
 				awsMachine := getAWSMachine()
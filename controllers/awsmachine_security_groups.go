@@ -19,6 +19,8 @@ package controllers
 import (
 	"sort"
 
+	"github.com/pkg/errors"
+
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
@@ -59,6 +61,10 @@ func (r *AWSMachineReconciler) ensureSecurityGroups(ec2svc service.EC2Interface,
 		return false, nil
 	}
 
+	if err := ec2svc.CheckSecurityGroupsPerNetworkInterface(len(ids)); err != nil {
+		return false, errors.Wrapf(err, "instance would have %d security groups (%d managed by the provider, %d additional)", len(ids), len(core), len(ids)-len(core))
+	}
+
 	if err := ec2svc.UpdateInstanceSecurityGroups(*scope.GetInstanceID(), ids); err != nil {
 		return false, err
 	}
@@ -103,6 +109,7 @@ func (r *AWSMachineReconciler) securityGroupsChanged(annotation map[string]inter
 			res = append(res, id)
 		}
 	}
+	sort.Strings(res)
 
 	for _, actual := range existing {
 		if len(actual) != len(res) {
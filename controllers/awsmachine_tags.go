@@ -17,6 +17,8 @@ limitations under the License.
 package controllers
 
 import (
+	"strings"
+
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 )
@@ -33,6 +35,16 @@ const (
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	VolumeTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-last-applied-tags-on-volumes"
+
+	// NetworkInterfaceTagsLastAppliedAnnotation is the key for the network
+	// interfaces annotation which tracks the AdditionalTags in the Machine
+	// Provider Config.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	NetworkInterfaceTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-last-applied-tags-on-network-interfaces"
+
+	// awsReservedTagPrefix is the prefix of tag keys reserved for use by AWS.
+	awsReservedTagPrefix = "aws:"
 )
 
 // Ensure that the tags of the machine are correct
@@ -69,13 +81,25 @@ func (r *AWSMachineReconciler) ensureTags(svc service.EC2Interface, machine *inf
 // Ensure that the tags of the volumes in the machine are correct
 // Returns tags which are being created/updated/deleted and error.
 func (r *AWSMachineReconciler) ensureVolumeTags(svc service.EC2Interface, volumeID *string, annotation map[string]interface{}, additionalTags map[string]string) (map[string]interface{}, error) {
-	// Check if the volume tags were changed. If they were, update them.
+	return r.ensureResourceTags(svc, volumeID, annotation, additionalTags)
+}
+
+// Ensure that the tags of the network interfaces attached to the machine are correct
+// Returns tags which are being created/updated/deleted and error.
+func (r *AWSMachineReconciler) ensureNetworkInterfaceTags(svc service.EC2Interface, eniID *string, annotation map[string]interface{}, additionalTags map[string]string) (map[string]interface{}, error) {
+	return r.ensureResourceTags(svc, eniID, annotation, additionalTags)
+}
+
+// ensureResourceTags updates the tags of a resource attached to the machine,
+// returning the annotation to record for it.
+func (r *AWSMachineReconciler) ensureResourceTags(svc service.EC2Interface, resourceID *string, annotation map[string]interface{}, additionalTags map[string]string) (map[string]interface{}, error) {
+	// Check if the resource tags were changed. If they were, update them.
 	// It would be possible here to only send new/updated tags, but for the
 	// moment we send everything, even if only a single tag was created or
 	// updated.
 	changed, created, deleted, subAnnotation := r.tagsChanged(annotation, additionalTags)
 	if changed {
-		err := svc.UpdateResourceTags(volumeID, created, deleted)
+		err := svc.UpdateResourceTags(resourceID, created, deleted)
 		if err != nil {
 			return nil, err
 		}
@@ -102,6 +126,12 @@ func (r *AWSMachineReconciler) tagsChanged(annotation map[string]interface{}, sr
 	// If an entry is present in annotation but not src, it has been deleted
 	// since last time. We flag this in the deleted map.
 	for t, v := range annotation {
+		// Never delete tags owned by AWS or CAPA, even if a previous
+		// version recorded them in the annotation.
+		if isProtectedTagKey(t) {
+			continue
+		}
+
 		_, ok := src[t]
 
 		// Entry isn't in src, it has been deleted.
@@ -122,6 +152,12 @@ func (r *AWSMachineReconciler) tagsChanged(annotation map[string]interface{}, sr
 	// the value in src differs from that in annotation, the tag has been
 	// updated since last time.
 	for t, v := range src {
+		// Tags reserved by AWS can't be set, and ownership tags are managed
+		// by CAPA itself, so they are left out of the diff.
+		if isProtectedTagKey(t) {
+			continue
+		}
+
 		av, ok := annotation[t]
 
 		// Entries in the src always need to be noted in the newAnnotation. We
@@ -150,3 +186,11 @@ func (r *AWSMachineReconciler) tagsChanged(annotation map[string]interface{}, sr
 	// in dst. Nothing changed.
 	return changed, created, deleted, newAnnotation
 }
+
+// isProtectedTagKey returns true if the tag key is reserved by AWS or used by
+// CAPA and the cloud provider to track resource ownership.
+func isProtectedTagKey(key string) bool {
+	return strings.HasPrefix(key, awsReservedTagPrefix) ||
+		strings.HasPrefix(key, infrav1.NameAWSProviderPrefix) ||
+		strings.HasPrefix(key, infrav1.NameKubernetesAWSCloudProviderPrefix)
+}
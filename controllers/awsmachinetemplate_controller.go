@@ -0,0 +1,228 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// AWSMachineTemplateReconciler reconciles AWSMachineTemplates, setting the capacity of their instance type in their
+// status so that the cluster-autoscaler is able to scale the MachineDeployments using them up from zero.
+type AWSMachineTemplateReconciler struct {
+	client.Client
+	ec2ServiceFactory         func(scope.EC2Scope) services.EC2Interface
+	Endpoints                 []scope.ServiceEndpoint
+	WatchFilterValue          string
+	EnforcePrincipalAllowList bool
+	DescribeCacheTTL          time.Duration
+	AuditEventsVerbosity      int
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinetemplates,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;watch
+
+func (r *AWSMachineTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logger.FromContext(ctx)
+
+	awsMachineTemplate := &infrav1.AWSMachineTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, awsMachineTemplate); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// The capacity only depends on the spec of the template, which is immutable, and can also be set by hand.
+	if len(awsMachineTemplate.Status.Capacity) != 0 {
+		return ctrl.Result{}, nil
+	}
+
+	cluster, err := r.getCluster(ctx, awsMachineTemplate)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		log.Info("AWSMachineTemplate has no owner Cluster or cluster label, capacity can't be resolved")
+		return ctrl.Result{}, nil
+	}
+
+	if annotations.IsPaused(cluster, awsMachineTemplate) {
+		log.Info("AWSMachineTemplate or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", klog.KObj(cluster))
+
+	infraCluster, err := r.getInfraCluster(ctx, log, cluster)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "error getting infra provider cluster or control plane object")
+	}
+	if infraCluster == nil {
+		log.Info("AWSCluster or AWSManagedControlPlane is not ready yet")
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(awsMachineTemplate, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	capacity, err := templateCapacity(&awsMachineTemplate.Spec.Template.Spec, r.getEC2Service(infraCluster))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	awsMachineTemplate.Status.Capacity = capacity
+
+	if err := patchHelper.Patch(ctx, awsMachineTemplate); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to patch AWSMachineTemplate")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *AWSMachineTemplateReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	log := logger.FromContext(ctx)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.AWSMachineTemplate{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log.GetLogger(), r.WatchFilterValue)).
+		Complete(r)
+}
+
+func (r *AWSMachineTemplateReconciler) getEC2Service(scope scope.EC2Scope) services.EC2Interface {
+	if r.ec2ServiceFactory != nil {
+		return r.ec2ServiceFactory(scope)
+	}
+
+	return ec2.NewService(scope)
+}
+
+// getCluster returns the Cluster owning a template, which is set by the MachineDeployments using it, or else the
+// Cluster of its cluster label.
+func (r *AWSMachineTemplateReconciler) getCluster(ctx context.Context, awsMachineTemplate *infrav1.AWSMachineTemplate) (*clusterv1.Cluster, error) {
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, awsMachineTemplate.ObjectMeta)
+	if err != nil || cluster != nil {
+		return cluster, err
+	}
+
+	if _, ok := awsMachineTemplate.Labels[clusterv1.ClusterNameLabel]; !ok {
+		return nil, nil
+	}
+	cluster, err = util.GetClusterFromMetadata(ctx, r.Client, awsMachineTemplate.ObjectMeta)
+	if apierrors.IsNotFound(errors.Cause(err)) {
+		return nil, nil
+	}
+	return cluster, err
+}
+
+func (r *AWSMachineTemplateReconciler) getInfraCluster(ctx context.Context, log *logger.Logger, cluster *clusterv1.Cluster) (scope.EC2Scope, error) {
+	if cluster.Spec.ControlPlaneRef != nil && cluster.Spec.ControlPlaneRef.Kind == AWSManagedControlPlaneRefKind {
+		controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+		controlPlaneName := client.ObjectKey{
+			Namespace: cluster.Namespace,
+			Name:      cluster.Spec.ControlPlaneRef.Name,
+		}
+
+		if err := r.Get(ctx, controlPlaneName, controlPlane); err != nil {
+			// AWSManagedControlPlane is not ready
+			return nil, nil //nolint:nilerr
+		}
+
+		return scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+			Client:                    r.Client,
+			Logger:                    log,
+			Cluster:                   cluster,
+			ControlPlane:              controlPlane,
+			ControllerName:            "awsmachinetemplate",
+			Endpoints:                 r.Endpoints,
+			EnforcePrincipalAllowList: r.EnforcePrincipalAllowList,
+			DescribeCacheTTL:          r.DescribeCacheTTL,
+			AuditEventsVerbosity:      r.AuditEventsVerbosity,
+		})
+	}
+
+	if cluster.Spec.InfrastructureRef == nil {
+		return nil, nil
+	}
+
+	awsCluster := &infrav1.AWSCluster{}
+	infraClusterName := client.ObjectKey{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+
+	if err := r.Get(ctx, infraClusterName, awsCluster); err != nil {
+		// AWSCluster is not ready
+		return nil, nil //nolint:nilerr
+	}
+
+	return scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:                    r.Client,
+		Logger:                    log,
+		Cluster:                   cluster,
+		AWSCluster:                awsCluster,
+		ControllerName:            "awsmachinetemplate",
+		Endpoints:                 r.Endpoints,
+		EnforcePrincipalAllowList: r.EnforcePrincipalAllowList,
+		DescribeCacheTTL:          r.DescribeCacheTTL,
+		AuditEventsVerbosity:      r.AuditEventsVerbosity,
+	})
+}
+
+// templateCapacity returns the CPU, memory and GPUs of the instance type of a template, and the size of its root
+// volume as ephemeral storage.
+func templateCapacity(spec *infrav1.AWSMachineSpec, ec2Svc services.EC2Interface) (corev1.ResourceList, error) {
+	if spec.InstanceType == "" {
+		return nil, nil
+	}
+
+	capacity, err := ec2Svc.InstanceTypeCapacity(spec.InstanceType)
+	if err != nil {
+		return nil, err
+	}
+	if spec.RootVolume != nil && spec.RootVolume.Size > 0 {
+		capacity[corev1.ResourceEphemeralStorage] = resource.MustParse(fmt.Sprintf("%dGi", spec.RootVolume.Size))
+	}
+
+	return capacity, nil
+}
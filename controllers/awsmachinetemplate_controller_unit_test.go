@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestAWSMachineTemplateReconcilerReconcile(t *testing.T) {
+	ns := "default"
+	instanceCapacity := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("16384Mi"),
+		"nvidia.com/gpu":      resource.MustParse("1"),
+	}
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "capi-test", Namespace: ns},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{Kind: "AWSCluster", Name: "capi-test"},
+		},
+	}
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "capi-test", Namespace: ns},
+		Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
+	}
+
+	testCases := []struct {
+		name         string
+		template     *infrav1.AWSMachineTemplate
+		expect       func(m *mock_services.MockEC2InterfaceMockRecorder)
+		wantCapacity corev1.ResourceList
+	}{
+		{
+			name: "capacity of the instance type of a template with a cluster label",
+			template: &infrav1.AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "md-0",
+					Namespace: ns,
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: "capi-test"},
+				},
+				Spec: infrav1.AWSMachineTemplateSpec{Template: infrav1.AWSMachineTemplateResource{
+					Spec: infrav1.AWSMachineSpec{InstanceType: "g4dn.xlarge"},
+				}},
+			},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.InstanceTypeCapacity("g4dn.xlarge").Return(instanceCapacity.DeepCopy(), nil)
+			},
+			wantCapacity: instanceCapacity,
+		},
+		{
+			name: "root volume of a template owned by its cluster is its ephemeral storage",
+			template: &infrav1.AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "md-0",
+					Namespace: ns,
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: clusterv1.GroupVersion.String(),
+						Kind:       "Cluster",
+						Name:       "capi-test",
+					}},
+				},
+				Spec: infrav1.AWSMachineTemplateSpec{Template: infrav1.AWSMachineTemplateResource{
+					Spec: infrav1.AWSMachineSpec{InstanceType: "g4dn.xlarge", RootVolume: &infrav1.Volume{Size: 100}},
+				}},
+			},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.InstanceTypeCapacity("g4dn.xlarge").Return(instanceCapacity.DeepCopy(), nil)
+			},
+			wantCapacity: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("4"),
+				corev1.ResourceMemory:           resource.MustParse("16384Mi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("100Gi"),
+				"nvidia.com/gpu":                resource.MustParse("1"),
+			},
+		},
+		{
+			name: "capacity set by hand is kept",
+			template: &infrav1.AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "md-0",
+					Namespace: ns,
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: "capi-test"},
+				},
+				Spec: infrav1.AWSMachineTemplateSpec{Template: infrav1.AWSMachineTemplateResource{
+					Spec: infrav1.AWSMachineSpec{InstanceType: "g4dn.xlarge"},
+				}},
+				Status: infrav1.AWSMachineTemplateStatus{
+					Capacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			},
+			expect:       func(m *mock_services.MockEC2InterfaceMockRecorder) {},
+			wantCapacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		},
+		{
+			name: "template without cluster is ignored",
+			template: &infrav1.AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "md-0", Namespace: ns},
+				Spec: infrav1.AWSMachineTemplateSpec{Template: infrav1.AWSMachineTemplateResource{
+					Spec: infrav1.AWSMachineSpec{InstanceType: "g4dn.xlarge"},
+				}},
+			},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+			tc.expect(ec2Svc.EXPECT())
+
+			client := fake.NewClientBuilder().WithObjects(cluster.DeepCopy(), awsCluster.DeepCopy(), tc.template).Build()
+			reconciler := &AWSMachineTemplateReconciler{
+				Client: client,
+				ec2ServiceFactory: func(scope.EC2Scope) services.EC2Interface {
+					return ec2Svc
+				},
+			}
+
+			key := types.NamespacedName{Namespace: ns, Name: tc.template.Name}
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			template := &infrav1.AWSMachineTemplate{}
+			g.Expect(client.Get(context.TODO(), key, template)).To(Succeed())
+			g.Expect(template.Status.Capacity).To(HaveLen(len(tc.wantCapacity)))
+			for name, quantity := range tc.wantCapacity {
+				g.Expect(template.Status.Capacity[name].Equal(quantity)).To(BeTrue(), "capacity of %s", name)
+			}
+		})
+	}
+}
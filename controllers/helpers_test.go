@@ -199,6 +199,7 @@ func mockedCreateLBCalls(t *testing.T, m *mocks.MockELBAPIMockRecorder) {
 		}, nil)
 	m.ModifyLoadBalancerAttributes(gomock.Eq(&elb.ModifyLoadBalancerAttributesInput{
 		LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+			ConnectionDraining:     &elb.ConnectionDraining{Enabled: aws.Bool(false)},
 			ConnectionSettings:     &elb.ConnectionSettings{IdleTimeout: aws.Int64(600)},
 			CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(false)},
 		},
@@ -39,6 +39,40 @@ func (r *AWSManagedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 	dst.Spec.VpcCni.Disable = r.Spec.DisableVPCCNI
+	dst.Spec.VpcCni.CustomNetworking = restored.Spec.VpcCni.CustomNetworking
+	dst.Spec.Bastion.AllowedPrefixListIDs = restored.Spec.Bastion.AllowedPrefixListIDs
+	dst.Spec.Bastion.IAMInstanceProfile = restored.Spec.Bastion.IAMInstanceProfile
+	dst.Spec.Bastion.ElasticIPPool = restored.Spec.Bastion.ElasticIPPool
+	dst.Spec.NetworkSpec.VPC.NATGatewayElasticIPPool = restored.Spec.NetworkSpec.VPC.NATGatewayElasticIPPool
+	dst.Spec.Bastion.Placement = restored.Spec.Bastion.Placement
+	dst.Spec.Bastion.AMILookup = restored.Spec.Bastion.AMILookup
+	dst.Spec.Bastion.RootVolume = restored.Spec.Bastion.RootVolume
+	dst.Spec.Bastion.AdditionalSecurityGroups = restored.Spec.Bastion.AdditionalSecurityGroups
+	dst.Spec.Bastion.UserData = restored.Spec.Bastion.UserData
+	dst.Spec.Bastion.Type = restored.Spec.Bastion.Type
+	dst.Spec.NetworkSpec.VPC.NATGatewayPlacement = restored.Spec.NetworkSpec.VPC.NATGatewayPlacement
+	dst.Spec.NetworkSpec.VPC.NATStrategy = restored.Spec.NetworkSpec.VPC.NATStrategy
+	dst.Spec.NetworkSpec.VPC.NATInstance = restored.Spec.NetworkSpec.VPC.NATInstance
+	dst.Spec.NetworkSpec.VPC.DHCPOptions = restored.Spec.NetworkSpec.VPC.DHCPOptions
+	dst.Spec.NetworkSpec.VPC.FlowLogs = restored.Spec.NetworkSpec.VPC.FlowLogs
+	dst.Spec.NetworkSpec.VPC.SubnetSchema = restored.Spec.NetworkSpec.VPC.SubnetSchema
+	dst.Spec.NetworkSpec.VPC.AvailabilityZones = restored.Spec.NetworkSpec.VPC.AvailabilityZones
+	dst.Spec.NetworkSpec.AdditionalControlPlaneIngressRules = restored.Spec.NetworkSpec.AdditionalControlPlaneIngressRules
+	dst.Spec.NetworkSpec.AdditionalNodeIngressRules = restored.Spec.NetworkSpec.AdditionalNodeIngressRules
+	dst.Spec.NetworkSpec.NodeEgressRules = restored.Spec.NetworkSpec.NodeEgressRules
+	dst.Spec.NetworkSpec.PrivateIPv6Egress = restored.Spec.NetworkSpec.PrivateIPv6Egress
+	dst.Spec.SkipAddonCompatibilityCheck = restored.Spec.SkipAddonCompatibilityCheck
+	dst.Spec.Proxy = restored.Spec.Proxy
+	dst.Spec.ControlPlaneSubnetIDs = restored.Spec.ControlPlaneSubnetIDs
+	dst.Spec.AdditionalSecurityGroupIDs = restored.Spec.AdditionalSecurityGroupIDs
+	dst.Spec.Karpenter = restored.Spec.Karpenter
+	dst.Status.BastionConnection = restored.Status.BastionConnection
+	dst.Status.BillableResources = restored.Status.BillableResources
+	dst.Status.Karpenter = restored.Status.Karpenter
+	infrav1beta1.RestoreCNISpec(restored.Spec.NetworkSpec.CNI, dst.Spec.NetworkSpec.CNI)
+	infrav1beta1.RestoreSubnets(restored.Spec.NetworkSpec.Subnets, dst.Spec.NetworkSpec.Subnets)
+	infrav1beta1.RestoreSecurityGroups(restored.Status.Network.SecurityGroups, dst.Status.Network.SecurityGroups)
+	infrav1beta1.RestoreNetworkStatus(&restored.Status.Network, &dst.Status.Network)
 
 	return nil
 }
@@ -107,6 +141,14 @@ func Convert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControlPlan
 	return autoConvert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControlPlaneSpec(in, out, s)
 }
 
+func Convert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in *ekscontrolplanev1.AWSManagedControlPlaneSpec, out *AWSManagedControlPlaneSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in, out, s)
+}
+
 func Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(in *ekscontrolplanev1.VpcCni, out *VpcCni, s apiconversion.Scope) error {
 	return autoConvert_v1beta2_VpcCni_To_v1beta1_VpcCni(in, out, s)
 }
+
+func Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in *ekscontrolplanev1.AWSManagedControlPlaneStatus, out *AWSManagedControlPlaneStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in, out, s)
+}
@@ -52,6 +52,18 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	SecondaryCidrBlock *string `json:"secondaryCidrBlock,omitempty"`
 
+	// ControlPlaneSubnetIDs are the IDs of the subnets, among the subnets of the network, in which EKS places
+	// the network interfaces of the control plane. All the subnets of the network are used when empty.
+	// It can only be set when using an existing VPC, and requires subnets in at least 2 availability zones.
+	// +optional
+	ControlPlaneSubnetIDs []string `json:"controlPlaneSubnetIDs,omitempty"`
+
+	// AdditionalSecurityGroupIDs are the IDs of existing security groups of the VPC to attach to the network
+	// interfaces of the control plane, in addition to the security group EKS creates for the cluster.
+	// They aren't managed by the provider.
+	// +optional
+	AdditionalSecurityGroupIDs []string `json:"additionalSecurityGroupIDs,omitempty"`
+
 	// The AWS Region the cluster lives in.
 	Region string `json:"region,omitempty"`
 
@@ -158,6 +170,13 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	Addons *[]Addon `json:"addons,omitempty"`
 
+	// SkipAddonCompatibilityCheck allows Kubernetes version upgrades to go ahead even
+	// when some of the addons, or the versions they are pinned to, aren't compatible with
+	// the next Kubernetes version.
+	// Those addons may stop working until they are upgraded.
+	// +optional
+	SkipAddonCompatibilityCheck bool `json:"skipAddonCompatibilityCheck,omitempty"`
+
 	// IdentityProviderconfig is used to specify the oidc provider config
 	// to be attached with this eks cluster
 	// +optional
@@ -169,6 +188,20 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 
 	// KubeProxy defines managed attributes of the kube-proxy daemonset
 	KubeProxy KubeProxy `json:"kubeProxy,omitempty"`
+
+	// Proxy configures the HTTP proxy and the additional trusted CA certificates of the nodes of the
+	// cluster. They are rendered into the user data of the instances whose bootstrap data is in the
+	// cloud-config format or which run Bottlerocket, and the proxy is set in the environment of the
+	// aws-node daemonset of the Amazon VPC CNI.
+	// +optional
+	Proxy *infrav1.ProxySpec `json:"proxy,omitempty"`
+
+	// Karpenter creates the AWS resources needed to install Karpenter into the cluster: the IAM role and
+	// instance profile of the nodes it launches and the SQS queue and EventBridge rules of its interruption
+	// handling. Their identifiers are reported in the karpenter status, and the node role is mapped in the
+	// aws-iam-authenticator configuration so that the nodes can join the cluster. Removing it deletes them.
+	// +optional
+	Karpenter *KarpenterSpec `json:"karpenter,omitempty"`
 }
 
 // KubeProxy specifies how the kube-proxy daemonset is managed.
@@ -194,6 +227,12 @@ type VpcCni struct {
 	// Env defines a list of environment variables to apply to the `aws-node` DaemonSet
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
+	// CustomNetworking configures the VPC CNI to assign the IPs of the pods from the subnets of the
+	// SecondaryCidrBlock, through the ENIConfig created for the availability zone of each node. It is
+	// applied to the `aws-node` DaemonSet and, if the vpc-cni addon is installed, to its configuration
+	// values. Requires SecondaryCidrBlock to be set.
+	// +optional
+	CustomNetworking bool `json:"customNetworking,omitempty"`
 }
 
 // EndpointAccess specifies how control plane endpoints are accessible.
@@ -201,7 +240,10 @@ type EndpointAccess struct {
 	// Public controls whether control plane endpoints are publicly accessible
 	// +optional
 	Public *bool `json:"public,omitempty"`
-	// PublicCIDRs specifies which blocks can access the public endpoint
+	// PublicCIDRs specifies which blocks can access the public endpoint. All IPv4 addresses
+	// can access it when empty. Changes are applied to existing clusters, which EKS can take
+	// 10 minutes or more to complete.
+	// +kubebuilder:validation:MaxItems=40
 	// +optional
 	PublicCIDRs []*string `json:"publicCIDRs,omitempty"`
 	// Private points VPC-internal control plane access to the private endpoint
@@ -244,6 +286,12 @@ type AWSManagedControlPlaneStatus struct {
 	// Bastion holds details of the instance that is used as a bastion jump box
 	// +optional
 	Bastion *infrav1.Instance `json:"bastion,omitempty"`
+	// BastionConnection holds the details needed to reach the cluster through the bastion host
+	// +optional
+	BastionConnection *infrav1.BastionConnection `json:"bastionConnection,omitempty"`
+	// BillableResources estimates the billable AWS resources managed for the cluster
+	// +optional
+	BillableResources *infrav1.BillableResources `json:"billableResources,omitempty"`
 	// OIDCProvider holds the status of the identity provider for this cluster
 	// +optional
 	OIDCProvider OIDCProviderStatus `json:"oidcProvider,omitempty"`
@@ -272,6 +320,9 @@ type AWSManagedControlPlaneStatus struct {
 	// associated identity provider
 	// +optional
 	IdentityProviderStatus IdentityProviderStatus `json:"identityProviderStatus,omitempty"`
+	// Karpenter holds the identifiers of the AWS resources created for Karpenter.
+	// +optional
+	Karpenter *KarpenterStatus `json:"karpenter,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -19,11 +19,14 @@ package v1beta2
 import (
 	"fmt"
 	"net"
+	"net/url"
+	"strings"
 
 	"github.com/apparentlymart/go-cidr/cidr"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/klog/v2"
@@ -39,6 +42,9 @@ const (
 	minKubeVersionForIPv6   = "v1.21.0"
 	minVpcCniVersionForIPv6 = "1.10.2"
 	maxClusterNameLength    = 100
+
+	maxRequiredClaimKeyLength   = 63
+	maxRequiredClaimValueLength = 253
 )
 
 // log is for logging in this package.
@@ -89,9 +95,15 @@ func (r *AWSManagedControlPlane) ValidateCreate() error {
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
+	allErrs = append(allErrs, r.validateVPCCNICustomNetworking()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
+	allErrs = append(allErrs, r.validateOIDCIdentityProviderConfig()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.Spec.Proxy.Validate(field.NewPath("spec", "proxy"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.Subnets.ValidateOutposts(field.NewPath("spec", "network", "subnets"))...)
 	allErrs = append(allErrs, r.validateNetwork()...)
+	allErrs = append(allErrs, r.validateVPCConfig()...)
+	allErrs = append(allErrs, r.validateEndpointAccess()...)
 
 	if len(allErrs) == 0 {
 		return nil
@@ -123,8 +135,57 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) error {
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
+	allErrs = append(allErrs, r.validateVPCCNICustomNetworking()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
+	allErrs = append(allErrs, r.validateOIDCIdentityProviderConfig()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.Spec.Proxy.Validate(field.NewPath("spec", "proxy"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.Subnets.ValidateOutposts(field.NewPath("spec", "network", "subnets"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.NATGatewayElasticIPPool.Validate(field.NewPath("spec", "network", "vpc", "natGatewayElasticIPPool"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.DHCPOptions.Validate(field.NewPath("spec", "network", "vpc", "dhcpOptions"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.FlowLogs.Validate(field.NewPath("spec", "network", "vpc", "flowLogs"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateAvailabilityZoneSelection(field.NewPath("spec", "network", "vpc"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateNATStrategy(field.NewPath("spec", "network", "vpc"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateSubnetSchema(field.NewPath("spec", "network", "vpc"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidatePrivateIPv6Egress(field.NewPath("spec", "network"))...)
+	allErrs = append(allErrs, r.validateVPCConfig()...)
+	allErrs = append(allErrs, r.validateEndpointAccess()...)
+
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateNATStrategyUpdate(&oldAWSManagedControlplane.Spec.NetworkSpec.VPC, field.NewPath("spec", "network", "vpc"))...)
+
+	if oldAWSManagedControlplane.Spec.NetworkSpec.VPC.DHCPOptions != nil && r.Spec.NetworkSpec.VPC.DHCPOptions == nil {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "network", "vpc", "dhcpOptions"),
+				r.Spec.NetworkSpec.VPC.DHCPOptions, "field cannot be removed once set"),
+		)
+	}
+
+	if oldAWSManagedControlplane.Spec.NetworkSpec.VPC.FlowLogs != nil && r.Spec.NetworkSpec.VPC.FlowLogs == nil {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "network", "vpc", "flowLogs"),
+				r.Spec.NetworkSpec.VPC.FlowLogs, "field cannot be removed once set"),
+		)
+	}
+
+	// EKS doesn't allow changing the subnets and security groups of a cluster once created.
+	if !sets.NewString(r.Spec.ControlPlaneSubnetIDs...).Equal(sets.NewString(oldAWSManagedControlplane.Spec.ControlPlaneSubnetIDs...)) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneSubnetIDs"), r.Spec.ControlPlaneSubnetIDs, "field is immutable"),
+		)
+	}
+	if !sets.NewString(r.Spec.AdditionalSecurityGroupIDs...).Equal(sets.NewString(oldAWSManagedControlplane.Spec.AdditionalSecurityGroupIDs...)) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "additionalSecurityGroupIDs"), r.Spec.AdditionalSecurityGroupIDs, "field is immutable"),
+		)
+	}
+
+	// The Karpenter node role can't be renamed, as nodes and EC2NodeClasses refer to it.
+	if old, cur := oldAWSManagedControlplane.Spec.Karpenter, r.Spec.Karpenter; old != nil && cur != nil &&
+		old.NodeRoleName != "" && cur.NodeRoleName != old.NodeRoleName {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "karpenter", "nodeRoleName"), cur.NodeRoleName, "field is immutable"),
+		)
+	}
 
 	if r.Spec.Region != oldAWSManagedControlplane.Spec.Region {
 		allErrs = append(allErrs,
@@ -146,10 +207,33 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) error {
 		oldAWSManagedControlplane.Spec.EncryptionConfig.Provider != nil &&
 		*r.Spec.EncryptionConfig.Provider != *oldAWSManagedControlplane.Spec.EncryptionConfig.Provider {
 		allErrs = append(allErrs,
-			field.Invalid(field.NewPath("spec", "encryptionConfig", "provider"), r.Spec.EncryptionConfig.Provider, "changing EKS encryption is not allowed after it has been enabled"),
+			field.Invalid(field.NewPath("spec", "encryptionConfig", "provider"), r.Spec.EncryptionConfig.Provider, "changing EKS encryption is not allowed after it has been enabled, enable automatic rotation of the KMS key instead"),
+		)
+	}
+
+	// If encryptionConfig is already set, do not allow change in resources
+	if r.Spec.EncryptionConfig != nil &&
+		oldAWSManagedControlplane.Spec.EncryptionConfig != nil &&
+		!encryptionResourcesEqual(r.Spec.EncryptionConfig.Resources, oldAWSManagedControlplane.Spec.EncryptionConfig.Resources) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "encryptionConfig", "resources"), r.Spec.EncryptionConfig.Resources, "changing EKS encryption is not allowed after it has been enabled"),
 		)
 	}
 
+	// If encryptionConfig is added to an existing cluster, it must be complete to be associated
+	if oldAWSManagedControlplane.Spec.EncryptionConfig == nil && r.Spec.EncryptionConfig != nil {
+		if r.Spec.EncryptionConfig.Provider == nil || *r.Spec.EncryptionConfig.Provider == "" {
+			allErrs = append(allErrs,
+				field.Required(field.NewPath("spec", "encryptionConfig", "provider"), "provider is required to enable EKS encryption on an existing cluster"),
+			)
+		}
+		if len(r.Spec.EncryptionConfig.Resources) == 0 {
+			allErrs = append(allErrs,
+				field.Required(field.NewPath("spec", "encryptionConfig", "resources"), "resources are required to enable EKS encryption on an existing cluster"),
+			)
+		}
+	}
+
 	// If a identityRef is already set, do not allow removal of it.
 	if oldAWSManagedControlplane.Spec.IdentityRef != nil && r.Spec.IdentityRef == nil {
 		allErrs = append(allErrs,
@@ -366,6 +450,60 @@ func (r *AWSManagedControlPlane) validateKubeProxy() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateOIDCIdentityProviderConfig() field.ErrorList {
+	var allErrs field.ErrorList
+
+	config := r.Spec.OIDCIdentityProviderConfig
+	if config == nil {
+		return nil
+	}
+	configField := field.NewPath("spec", "oidcIdentityProviderConfig")
+
+	if config.ClientID == "" {
+		allErrs = append(allErrs, field.Required(configField.Child("clientId"), "clientId is required"))
+	}
+	if config.IdentityProviderConfigName == "" {
+		allErrs = append(allErrs, field.Required(configField.Child("identityProviderConfigName"), "identityProviderConfigName is required"))
+	}
+
+	issuerField := configField.Child("issuerUrl")
+	if config.IssuerURL == "" {
+		allErrs = append(allErrs, field.Required(issuerField, "issuerUrl is required"))
+	} else if issuer, err := url.Parse(config.IssuerURL); err != nil {
+		allErrs = append(allErrs, field.Invalid(issuerField, config.IssuerURL, fmt.Sprintf("must be a valid URL: %v", err)))
+	} else {
+		if issuer.Scheme != "https" || issuer.Host == "" {
+			allErrs = append(allErrs, field.Invalid(issuerField, config.IssuerURL, "must begin with https:// followed by a host"))
+		}
+		if issuer.RawQuery != "" || issuer.Fragment != "" {
+			allErrs = append(allErrs, field.Invalid(issuerField, config.IssuerURL, "must not have query parameters or a fragment"))
+		}
+	}
+
+	for key, value := range config.RequiredClaims {
+		claimField := configField.Child("requiredClaims").Key(key)
+		if key == "" || len(key) > maxRequiredClaimKeyLength {
+			allErrs = append(allErrs, field.Invalid(claimField, key, fmt.Sprintf("claim names must be between 1 and %d characters", maxRequiredClaimKeyLength)))
+		}
+		if value == "" || len(value) > maxRequiredClaimValueLength {
+			allErrs = append(allErrs, field.Invalid(claimField, value, fmt.Sprintf("claim values must be between 1 and %d characters", maxRequiredClaimValueLength)))
+		}
+	}
+
+	// EKS reserves the system: prefix for the groups and users of Kubernetes
+	if config.GroupsPrefix != nil && strings.HasPrefix(*config.GroupsPrefix, "system:") {
+		allErrs = append(allErrs, field.Invalid(configField.Child("groupsPrefix"), *config.GroupsPrefix, "must not start with system:"))
+	}
+	if config.UsernamePrefix != nil && strings.HasPrefix(*config.UsernamePrefix, "system:") {
+		allErrs = append(allErrs, field.Invalid(configField.Child("usernamePrefix"), *config.UsernamePrefix, "must not start with system:"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateDisableVPCCNI() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -388,6 +526,26 @@ func (r *AWSManagedControlPlane) validateDisableVPCCNI() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateVPCCNICustomNetworking() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.VpcCni.CustomNetworking {
+		customNetworkingField := field.NewPath("spec", "vpcCni", "customNetworking")
+
+		if r.Spec.SecondaryCidrBlock == nil {
+			allErrs = append(allErrs, field.Invalid(customNetworkingField, r.Spec.VpcCni.CustomNetworking, "custom networking requires a secondaryCidrBlock"))
+		}
+		if r.Spec.VpcCni.Disable {
+			allErrs = append(allErrs, field.Invalid(customNetworkingField, r.Spec.VpcCni.CustomNetworking, "cannot enable custom networking if the vpc cni is disabled"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateNetwork() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -396,6 +554,114 @@ func (r *AWSManagedControlPlane) validateNetwork() field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(poolField, r.Spec.NetworkSpec.VPC.IPv6.PoolID, "poolId cannot be empty if cidrBlock is set"))
 	}
 
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.NATGatewayElasticIPPool.Validate(field.NewPath("spec", "network", "vpc", "natGatewayElasticIPPool"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.DHCPOptions.Validate(field.NewPath("spec", "network", "vpc", "dhcpOptions"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.FlowLogs.Validate(field.NewPath("spec", "network", "vpc", "flowLogs"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateAvailabilityZoneSelection(field.NewPath("spec", "network", "vpc"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateNATStrategy(field.NewPath("spec", "network", "vpc"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.VPC.ValidateSubnetSchema(field.NewPath("spec", "network", "vpc"))...)
+
+	// The control plane of EKS clusters is managed by AWS, there is no control plane security group to add rules to.
+	if len(r.Spec.NetworkSpec.AdditionalControlPlaneIngressRules) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "network", "additionalControlPlaneIngressRules"), "is not supported for EKS clusters"))
+	}
+	// EKS nodes are in the cluster security group created by EKS, which allows all outbound traffic.
+	if len(r.Spec.NetworkSpec.AdditionalNodeIngressRules) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "network", "additionalNodeIngressRules"), "is not supported for EKS clusters"))
+	}
+	if len(r.Spec.NetworkSpec.NodeEgressRules) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "network", "nodeEgressRules"), "is not supported for EKS clusters"))
+	}
+
+	return allErrs
+}
+
+// validateVPCConfig checks that the control plane subnets are subnets of the existing VPC the cluster uses,
+// and that the additional security groups are listed once.
+func (r *AWSManagedControlPlane) validateVPCConfig() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(r.Spec.ControlPlaneSubnetIDs) > 0 {
+		subnetsField := field.NewPath("spec", "controlPlaneSubnetIDs")
+		if r.Spec.NetworkSpec.VPC.ID == "" {
+			allErrs = append(allErrs, field.Forbidden(subnetsField, "can only be set when using an existing VPC"))
+		}
+
+		subnets := r.Spec.NetworkSpec.Subnets.ToMap()
+		seen := sets.NewString()
+		zones := sets.NewString()
+		zonesKnown := true
+		for i, id := range r.Spec.ControlPlaneSubnetIDs {
+			if seen.Has(id) {
+				allErrs = append(allErrs, field.Duplicate(subnetsField.Index(i), id))
+				continue
+			}
+			seen.Insert(id)
+
+			subnet, ok := subnets[id]
+			if !ok {
+				allErrs = append(allErrs, field.NotFound(subnetsField.Index(i), id))
+				continue
+			}
+			if subnet.AvailabilityZone == "" {
+				zonesKnown = false
+			}
+			zones.Insert(subnet.AvailabilityZone)
+		}
+		// The availability zones of subnets referenced only by ID are discovered when reconciling the network,
+		// the requirement is checked then.
+		if seen.Len() < 2 {
+			allErrs = append(allErrs, field.Invalid(subnetsField, r.Spec.ControlPlaneSubnetIDs, "at least 2 subnets are required"))
+		} else if zonesKnown && zones.Len() < 2 {
+			allErrs = append(allErrs, field.Invalid(subnetsField, r.Spec.ControlPlaneSubnetIDs, "subnets in at least 2 different availability zones are required"))
+		}
+	}
+
+	seen := sets.NewString()
+	for i, id := range r.Spec.AdditionalSecurityGroupIDs {
+		if seen.Has(id) {
+			allErrs = append(allErrs, field.Duplicate(field.NewPath("spec", "additionalSecurityGroupIDs").Index(i), id))
+		}
+		seen.Insert(id)
+	}
+
+	return allErrs
+}
+
+// maxPublicAccessCIDRs is the maximum number of CIDR blocks EKS allows to access the public endpoint of a cluster.
+const maxPublicAccessCIDRs = 40
+
+// validateEndpointAccess checks that the CIDR blocks allowed to access the public endpoint are IPv4 CIDR blocks
+// within the EKS limit, and that they don't open the endpoint to all addresses when public access is disabled.
+func (r *AWSManagedControlPlane) validateEndpointAccess() field.ErrorList {
+	var allErrs field.ErrorList
+
+	endpointAccess := r.Spec.EndpointAccess
+	cidrsField := field.NewPath("spec", "endpointAccess", "publicCIDRs")
+	if len(endpointAccess.PublicCIDRs) > maxPublicAccessCIDRs {
+		allErrs = append(allErrs, field.TooMany(cidrsField, len(endpointAccess.PublicCIDRs), maxPublicAccessCIDRs))
+	}
+
+	privateOnly := endpointAccess.Public != nil && !*endpointAccess.Public
+	for i, publicCIDR := range endpointAccess.PublicCIDRs {
+		if publicCIDR == nil {
+			allErrs = append(allErrs, field.Required(cidrsField.Index(i), "cannot be null"))
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(*publicCIDR)
+		if err != nil || ipNet.IP.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(cidrsField.Index(i), *publicCIDR, "must be an IPv4 CIDR block"))
+			continue
+		}
+		if ones, _ := ipNet.Mask.Size(); privateOnly && ones == 0 {
+			allErrs = append(allErrs, field.Forbidden(cidrsField.Index(i), "cannot allow all addresses when public endpoint access is disabled"))
+		}
+	}
+
+	if privateOnly && endpointAccess.Private != nil && !*endpointAccess.Private {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "endpointAccess"), "public and private endpoint access cannot both be disabled"))
+	}
+
 	return allErrs
 }
 
@@ -425,3 +691,15 @@ func (r *AWSManagedControlPlane) Default() {
 	infrav1.SetDefaults_Bastion(&r.Spec.Bastion)
 	infrav1.SetDefaults_NetworkSpec(&r.Spec.NetworkSpec)
 }
+
+func encryptionResourcesEqual(a, b []*string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if (a[i] == nil) != (b[i] == nil) || (a[i] != nil && *a[i] != *b[i]) {
+			return false
+		}
+	}
+	return true
+}
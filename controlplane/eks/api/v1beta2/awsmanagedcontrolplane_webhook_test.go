@@ -253,6 +253,32 @@ func TestWebhookCreate(t *testing.T) {
 			vpcCNI:         VpcCni{Disable: true},
 			secondaryCidr:  aws.String("100.64.0.0/10"),
 		},
+		{
+			name:           "vpc cni custom networking allowed with secondary cidr",
+			eksClusterName: "default_cluster1",
+			eksVersion:     "v1.19",
+			expectError:    false,
+			hasAddons:      true,
+			vpcCNI:         VpcCni{CustomNetworking: true},
+			secondaryCidr:  aws.String("100.64.0.0/16"),
+		},
+		{
+			name:           "vpc cni custom networking not allowed without secondary cidr",
+			eksClusterName: "default_cluster1",
+			eksVersion:     "v1.19",
+			expectError:    true,
+			hasAddons:      false,
+			vpcCNI:         VpcCni{CustomNetworking: true},
+		},
+		{
+			name:           "vpc cni custom networking not allowed with disabled vpc cni",
+			eksClusterName: "default_cluster1",
+			eksVersion:     "v1.19",
+			expectError:    true,
+			hasAddons:      false,
+			vpcCNI:         VpcCni{Disable: true, CustomNetworking: true},
+			secondaryCidr:  aws.String("100.64.0.0/16"),
+		},
 		{
 			name:           "invalid tags not allowed",
 			eksClusterName: "default_cluster1",
@@ -432,6 +458,30 @@ func TestWebhookCreateIPv6Details(t *testing.T) {
 			},
 			err: "poolId cannot be empty if cidrBlock is set",
 		},
+		{
+			name:        "private ipv6 egress with ipv6",
+			kubeVersion: "v1.22",
+			addons: []Addon{
+				{
+					Name:    vpcCniAddon,
+					Version: "1.11.0",
+				},
+			},
+			networkSpec: infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					IPv6: &infrav1.IPv6{},
+				},
+				PrivateIPv6Egress: true,
+			},
+		},
+		{
+			name:        "private ipv6 egress without ipv6",
+			kubeVersion: "v1.22",
+			networkSpec: infrav1.NetworkSpec{
+				PrivateIPv6Egress: true,
+			},
+			err: "can only be set if IPv6 is enabled for the VPC",
+		},
 	}
 
 	for _, tc := range tests {
@@ -601,6 +651,37 @@ func TestWebhookUpdate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "change in resources of encryption config",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				EncryptionConfig: &EncryptionConfig{
+					Provider:  pointer.String("provider"),
+					Resources: []*string{pointer.String("foo")},
+				},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				EncryptionConfig: &EncryptionConfig{
+					Provider:  pointer.String("provider"),
+					Resources: []*string{pointer.String("foo"), pointer.String("bar")},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "change in encryption config from nil to encryption-config without resources",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				EncryptionConfig: &EncryptionConfig{
+					Provider: pointer.String("provider"),
+				},
+			},
+			expectError: true,
+		},
 		{
 			name: "ekscluster specified, same name, invalid tags",
 			oldClusterSpec: AWSManagedControlPlaneSpec{
@@ -824,3 +905,361 @@ func TestValidatingWebhookUpdateSecondaryCidr(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatingWebhookCreateVPCConfig(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+		{ID: "subnet-2", AvailabilityZone: "us-east-1b"},
+		{ID: "subnet-3", AvailabilityZone: "us-east-1a"},
+		{ID: "subnet-4"},
+	}
+	tests := []struct {
+		name                       string
+		vpcID                      string
+		controlPlaneSubnetIDs      []string
+		additionalSecurityGroupIDs []string
+		expectError                bool
+	}{
+		{
+			name:                  "control plane subnets of an existing VPC",
+			vpcID:                 "vpc-1",
+			controlPlaneSubnetIDs: []string{"subnet-1", "subnet-2"},
+		},
+		{
+			name:                  "control plane subnets whose availability zones aren't known yet",
+			vpcID:                 "vpc-1",
+			controlPlaneSubnetIDs: []string{"subnet-1", "subnet-4"},
+		},
+		{
+			name:                  "control plane subnets of a managed VPC",
+			controlPlaneSubnetIDs: []string{"subnet-1", "subnet-2"},
+			expectError:           true,
+		},
+		{
+			name:                  "control plane subnet that isn't a subnet of the network",
+			vpcID:                 "vpc-1",
+			controlPlaneSubnetIDs: []string{"subnet-1", "subnet-5"},
+			expectError:           true,
+		},
+		{
+			name:                  "single control plane subnet",
+			vpcID:                 "vpc-1",
+			controlPlaneSubnetIDs: []string{"subnet-1", "subnet-1"},
+			expectError:           true,
+		},
+		{
+			name:                  "control plane subnets in a single availability zone",
+			vpcID:                 "vpc-1",
+			controlPlaneSubnetIDs: []string{"subnet-1", "subnet-3"},
+			expectError:           true,
+		},
+		{
+			name:                       "additional security groups",
+			additionalSecurityGroupIDs: []string{"sg-1", "sg-2"},
+		},
+		{
+			name:                       "duplicate additional security groups",
+			additionalSecurityGroupIDs: []string{"sg-1", "sg-1"},
+			expectError:                true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName: "default_cluster1",
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC:     infrav1.VPCSpec{ID: tc.vpcID},
+						Subnets: subnets,
+					},
+					ControlPlaneSubnetIDs:      tc.controlPlaneSubnetIDs,
+					AdditionalSecurityGroupIDs: tc.additionalSecurityGroupIDs,
+				},
+			}
+			err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestValidatingWebhookUpdateVPCConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldSpec     AWSManagedControlPlaneSpec
+		newSpec     AWSManagedControlPlaneSpec
+		expectError bool
+	}{
+		{
+			name:    "reordered control plane subnets",
+			oldSpec: AWSManagedControlPlaneSpec{ControlPlaneSubnetIDs: []string{"subnet-1", "subnet-2"}},
+			newSpec: AWSManagedControlPlaneSpec{ControlPlaneSubnetIDs: []string{"subnet-2", "subnet-1"}},
+		},
+		{
+			name:        "changed control plane subnets",
+			oldSpec:     AWSManagedControlPlaneSpec{ControlPlaneSubnetIDs: []string{"subnet-1", "subnet-2"}},
+			newSpec:     AWSManagedControlPlaneSpec{ControlPlaneSubnetIDs: []string{"subnet-1", "subnet-3"}},
+			expectError: true,
+		},
+		{
+			name:        "added additional security group",
+			oldSpec:     AWSManagedControlPlaneSpec{},
+			newSpec:     AWSManagedControlPlaneSpec{AdditionalSecurityGroupIDs: []string{"sg-1"}},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			subnets := infrav1.Subnets{
+				{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-2", AvailabilityZone: "us-east-1b"},
+				{ID: "subnet-3", AvailabilityZone: "us-east-1c"},
+			}
+			for _, spec := range []*AWSManagedControlPlaneSpec{&tc.oldSpec, &tc.newSpec} {
+				spec.EKSClusterName = "default_cluster1"
+				spec.NetworkSpec = infrav1.NetworkSpec{VPC: infrav1.VPCSpec{ID: "vpc-1"}, Subnets: subnets}
+			}
+			oldMCP := &AWSManagedControlPlane{Spec: tc.oldSpec}
+			newMCP := &AWSManagedControlPlane{Spec: tc.newSpec}
+
+			err := newMCP.ValidateUpdate(oldMCP)
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestWebhookUpdateKarpenter(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldSpec     *KarpenterSpec
+		newSpec     *KarpenterSpec
+		expectError bool
+	}{
+		{
+			name:    "enabled",
+			newSpec: &KarpenterSpec{},
+		},
+		{
+			name:    "node role name defaulted",
+			oldSpec: &KarpenterSpec{},
+			newSpec: &KarpenterSpec{NodeRoleName: "default_cluster1-karpenter-node"},
+		},
+		{
+			name:        "node role name changed",
+			oldSpec:     &KarpenterSpec{NodeRoleName: "default_cluster1-karpenter-node"},
+			newSpec:     &KarpenterSpec{NodeRoleName: "karpenter-node"},
+			expectError: true,
+		},
+		{
+			name:    "disabled",
+			oldSpec: &KarpenterSpec{NodeRoleName: "default_cluster1-karpenter-node"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			oldMCP := &AWSManagedControlPlane{Spec: AWSManagedControlPlaneSpec{EKSClusterName: "default_cluster1", Karpenter: tc.oldSpec}}
+			newMCP := &AWSManagedControlPlane{Spec: AWSManagedControlPlaneSpec{EKSClusterName: "default_cluster1", Karpenter: tc.newSpec}}
+
+			err := newMCP.ValidateUpdate(oldMCP)
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestValidatingWebhookCreateEndpointAccess(t *testing.T) {
+	tooManyCIDRs := make([]*string, 0, 41)
+	for i := 0; i < 41; i++ {
+		tooManyCIDRs = append(tooManyCIDRs, aws.String(fmt.Sprintf("10.0.%d.0/24", i)))
+	}
+	tests := []struct {
+		name           string
+		endpointAccess EndpointAccess
+		expectError    bool
+	}{
+		{
+			name: "public access cidrs",
+			endpointAccess: EndpointAccess{
+				PublicCIDRs: []*string{aws.String("10.0.0.0/24"), aws.String("192.168.0.1/32")},
+			},
+		},
+		{
+			name:           "too many public access cidrs",
+			endpointAccess: EndpointAccess{PublicCIDRs: tooManyCIDRs},
+			expectError:    true,
+		},
+		{
+			name: "invalid public access cidr",
+			endpointAccess: EndpointAccess{
+				PublicCIDRs: []*string{aws.String("10.0.0.0")},
+			},
+			expectError: true,
+		},
+		{
+			name: "ipv6 public access cidr",
+			endpointAccess: EndpointAccess{
+				PublicCIDRs: []*string{aws.String("2001:db8::/32")},
+			},
+			expectError: true,
+		},
+		{
+			name: "all addresses with public access",
+			endpointAccess: EndpointAccess{
+				Public:      aws.Bool(true),
+				PublicCIDRs: []*string{aws.String("0.0.0.0/0")},
+			},
+		},
+		{
+			name: "all addresses with private access only",
+			endpointAccess: EndpointAccess{
+				Public:      aws.Bool(false),
+				Private:     aws.Bool(true),
+				PublicCIDRs: []*string{aws.String("0.0.0.0/0")},
+			},
+			expectError: true,
+		},
+		{
+			name: "no endpoint access",
+			endpointAccess: EndpointAccess{
+				Public:  aws.Bool(false),
+				Private: aws.Bool(false),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName: "default_cluster1",
+					EndpointAccess: tc.endpointAccess,
+				},
+			}
+			err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestValidatingWebhookCreateOIDCIdentityProviderConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *OIDCIdentityProviderConfig
+		expectError bool
+	}{
+		{
+			name: "valid config",
+			config: &OIDCIdentityProviderConfig{
+				ClientID:                   "kubernetes",
+				IdentityProviderConfigName: "dex",
+				IssuerURL:                  "https://dex.example.com/dex",
+				RequiredClaims:             map[string]string{"hd": "example.com"},
+				GroupsPrefix:               aws.String("oidc:"),
+			},
+		},
+		{
+			name: "missing client id",
+			config: &OIDCIdentityProviderConfig{
+				IdentityProviderConfigName: "dex",
+				IssuerURL:                  "https://dex.example.com",
+			},
+			expectError: true,
+		},
+		{
+			name: "missing config name",
+			config: &OIDCIdentityProviderConfig{
+				ClientID:  "kubernetes",
+				IssuerURL: "https://dex.example.com",
+			},
+			expectError: true,
+		},
+		{
+			name: "http issuer url",
+			config: &OIDCIdentityProviderConfig{
+				ClientID:                   "kubernetes",
+				IdentityProviderConfigName: "dex",
+				IssuerURL:                  "http://dex.example.com",
+			},
+			expectError: true,
+		},
+		{
+			name: "issuer url with query parameters",
+			config: &OIDCIdentityProviderConfig{
+				ClientID:                   "kubernetes",
+				IdentityProviderConfigName: "dex",
+				IssuerURL:                  "https://dex.example.com?tenant=1",
+			},
+			expectError: true,
+		},
+		{
+			name: "required claim name too long",
+			config: &OIDCIdentityProviderConfig{
+				ClientID:                   "kubernetes",
+				IdentityProviderConfigName: "dex",
+				IssuerURL:                  "https://dex.example.com",
+				RequiredClaims:             map[string]string{strings.Repeat("c", 64): "value"},
+			},
+			expectError: true,
+		},
+		{
+			name: "reserved username prefix",
+			config: &OIDCIdentityProviderConfig{
+				ClientID:                   "kubernetes",
+				IdentityProviderConfigName: "dex",
+				IssuerURL:                  "https://dex.example.com",
+				UsernamePrefix:             aws.String("system:oidc:"),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:             "default_cluster1",
+					OIDCIdentityProviderConfig: tc.config,
+				},
+			}
+			err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
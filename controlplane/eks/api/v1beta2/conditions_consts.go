@@ -29,6 +29,11 @@ const (
 	EKSControlPlaneUpdatingCondition clusterv1.ConditionType = "EKSControlPlaneUpdating"
 	// EKSControlPlaneReconciliationFailedReason used to report failures while reconciling EKS control plane.
 	EKSControlPlaneReconciliationFailedReason = "EKSControlPlaneReconciliationFailed"
+	// EKSControlPlaneSubnetsInvalidReason used to report that the cluster subnets don't meet the EKS requirements.
+	EKSControlPlaneSubnetsInvalidReason = "EKSControlPlaneSubnetsInvalid"
+	// EKSControlPlaneSecurityGroupsInvalidReason used to report that the additional security groups of the cluster
+	// don't meet the EKS requirements.
+	EKSControlPlaneSecurityGroupsInvalidReason = "EKSControlPlaneSecurityGroupsInvalid"
 )
 
 const (
@@ -36,6 +41,9 @@ const (
 	IAMControlPlaneRolesReadyCondition clusterv1.ConditionType = "IAMControlPlaneRolesReady"
 	// IAMControlPlaneRolesReconciliationFailedReason used to report failures while reconciling EKS control plane iam roles.
 	IAMControlPlaneRolesReconciliationFailedReason = "IAMControlPlaneRolesReconciliationFailed"
+	// IAMClusterRoleReadyCondition condition reports on the successful reconciliation of the EKS cluster iam role.
+	// The reason of a failure is derived from the error returned by the IAM API, e.g. IAMAccessDenied.
+	IAMClusterRoleReadyCondition clusterv1.ConditionType = "IAMClusterRoleReady"
 )
 
 const (
@@ -52,9 +60,54 @@ const (
 	EKSAddonsConfiguredFailedReason = "EKSAddonsConfiguredFailed"
 )
 
+const (
+	// EKSAddonsCompatibleCondition condition reports on whether the EKS addons have a version
+	// compatible with the Kubernetes version the control plane is upgraded to next.
+	EKSAddonsCompatibleCondition clusterv1.ConditionType = "EKSAddonsCompatible"
+	// EKSAddonsIncompatibleReason used to report that a Kubernetes version upgrade is blocked by EKS addons.
+	EKSAddonsIncompatibleReason = "EKSAddonsIncompatible"
+)
+
+const (
+	// EKSEncryptionConfigAssociatedCondition condition reports on whether the encryption config of the
+	// control plane is associated with the EKS cluster.
+	EKSEncryptionConfigAssociatedCondition clusterv1.ConditionType = "EKSEncryptionConfigAssociated"
+	// EKSEncryptionConfigAssociatingReason used to report that the encryption config is being associated
+	// with an existing EKS cluster.
+	EKSEncryptionConfigAssociatingReason = "EKSEncryptionConfigAssociating"
+	// EKSEncryptionConfigAssociationFailedReason used to report failures while associating the encryption config.
+	EKSEncryptionConfigAssociationFailedReason = "EKSEncryptionConfigAssociationFailed"
+)
+
 const (
 	// EKSIdentityProviderConfiguredCondition condition reports on the successful association of identity provider config.
 	EKSIdentityProviderConfiguredCondition clusterv1.ConditionType = "EKSIdentityProviderConfigured"
 	// EKSIdentityProviderConfiguredFailedReason used to report failures while reconciling the identity provider config association.
 	EKSIdentityProviderConfiguredFailedReason = "EKSIdentityProviderConfiguredFailed"
+	// EKSIdentityProviderAssociatingReason used to report that EKS is associating the identity provider config.
+	EKSIdentityProviderAssociatingReason = "EKSIdentityProviderAssociating"
+	// EKSIdentityProviderDisassociatingReason used to report that EKS is disassociating the identity provider config.
+	EKSIdentityProviderDisassociatingReason = "EKSIdentityProviderDisassociating"
+)
+
+const (
+	// EKSEndpointAccessReconciledCondition condition reports on whether the endpoint access of the EKS cluster
+	// matches the endpoint access of the control plane.
+	EKSEndpointAccessReconciledCondition clusterv1.ConditionType = "EKSEndpointAccessReconciled"
+	// EKSEndpointAccessUpdatingReason used to report that EKS is updating the endpoint access of the cluster.
+	EKSEndpointAccessUpdatingReason = "EKSEndpointAccessUpdating"
+	// EKSEndpointAccessUpdateFailedReason used to report failures while updating the endpoint access of the cluster.
+	EKSEndpointAccessUpdateFailedReason = "EKSEndpointAccessUpdateFailed"
+)
+
+const (
+	// KarpenterResourcesReadyCondition condition reports on the successful reconciliation of the AWS
+	// resources created for Karpenter.
+	KarpenterResourcesReadyCondition clusterv1.ConditionType = "KarpenterResourcesReady"
+	// KarpenterResourcesReconciliationFailedReason used to report failures while reconciling the AWS
+	// resources created for Karpenter.
+	KarpenterResourcesReconciliationFailedReason = "KarpenterResourcesReconciliationFailed"
+	// KarpenterResourcesDeletionFailedReason used to report failures while deleting the AWS
+	// resources created for Karpenter.
+	KarpenterResourcesDeletionFailedReason = "KarpenterResourcesDeletionFailed"
 )
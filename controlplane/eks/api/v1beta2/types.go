@@ -276,3 +276,51 @@ type OIDCIdentityProviderConfig struct {
 	// +optional
 	Tags infrav1.Tags `json:"tags,omitempty"`
 }
+
+// KarpenterSpec configures the AWS resources created for Karpenter.
+type KarpenterSpec struct {
+	// NodeRoleName is the name of the IAM role of the nodes launched by Karpenter, which is also the
+	// name of their instance profile. If the role doesn't exist it is created, which requires the
+	// EKSEnableIAM feature gate. Defaults to a name based on the name of the EKS cluster.
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	NodeRoleName string `json:"nodeRoleName,omitempty"`
+
+	// DisableInterruptionQueue disables the creation of the SQS queue and EventBridge rules through
+	// which Karpenter is notified of spot interruptions, rebalance recommendations, scheduled
+	// maintenance and state changes of its instances.
+	// +optional
+	DisableInterruptionQueue bool `json:"disableInterruptionQueue,omitempty"`
+}
+
+// KarpenterStatus holds the identifiers of the AWS resources created for Karpenter, as expected
+// by its settings and EC2NodeClasses.
+type KarpenterStatus struct {
+	// NodeRoleName is the name of the IAM role of the nodes, the role of EC2NodeClasses.
+	// +optional
+	NodeRoleName string `json:"nodeRoleName,omitempty"`
+
+	// NodeRoleARN is the ARN of the IAM role of the nodes.
+	// +optional
+	NodeRoleARN string `json:"nodeRoleARN,omitempty"`
+
+	// InstanceProfileName is the name of the instance profile of the nodes, the
+	// instanceProfile of EC2NodeClasses.
+	// +optional
+	InstanceProfileName string `json:"instanceProfileName,omitempty"`
+
+	// InterruptionQueueName is the name of the SQS interruption queue, the
+	// settings.interruptionQueue of Karpenter.
+	// +optional
+	InterruptionQueueName string `json:"interruptionQueueName,omitempty"`
+
+	// SubnetIDs are the IDs of the private subnets of the cluster, for the
+	// subnetSelectorTerms of EC2NodeClasses.
+	// +optional
+	SubnetIDs []string `json:"subnetIDs,omitempty"`
+
+	// SecurityGroupIDs are the IDs of the security groups of the nodes of the cluster, for
+	// the securityGroupSelectorTerms of EC2NodeClasses.
+	// +optional
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
+}
@@ -41,14 +41,18 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/awsnode"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/billable"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/clusterinfo"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/gc"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/karpenter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/kubeproxy"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
@@ -62,6 +66,9 @@ const (
 	// has dependencies during deletion.
 	deleteRequeueAfter = 20 * time.Second
 
+	// clusterInfoRequeueAfter is how long to wait before trying again to publish the cluster info ConfigMap.
+	clusterInfoRequeueAfter = 15 * time.Second
+
 	awsManagedControlPlaneKind = "AWSManagedControlPlane"
 )
 
@@ -87,12 +94,17 @@ type AWSManagedControlPlaneReconciler struct {
 	Recorder  record.EventRecorder
 	Endpoints []scope.ServiceEndpoint
 
-	EnableIAM             bool
-	AllowAdditionalRoles  bool
-	WatchFilterValue      string
-	ExternalResourceGC    bool
-	AlternativeGCStrategy bool
-	WaitInfraPeriod       time.Duration
+	EnableIAM                 bool
+	AllowAdditionalRoles      bool
+	IAMPermissionsBoundary    string
+	IAMPath                   string
+	WatchFilterValue          string
+	EnforcePrincipalAllowList bool
+	DescribeCacheTTL          time.Duration
+	AuditEventsVerbosity      int
+	ExternalResourceGC        bool
+	AlternativeGCStrategy     bool
+	WaitInfraPeriod           time.Duration
 }
 
 // SetupWithManager is used to setup the controller.
@@ -140,7 +152,8 @@ func (r *AWSManagedControlPlaneReconciler) SetupWithManager(ctx context.Context,
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools;awsmachinepools/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusterroleidentities;awsclusterstaticidentities;awsclustercontrolleridentities,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusterroleidentities;awsclusterstaticidentities;awsclustercontrolleridentities;awsclusterwebidentities,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmanagedclusters;awsmanagedclusters/status,verbs=get;list;watch
 
 // Reconcile will reconcile AWSManagedControlPlane Resources.
@@ -173,13 +186,18 @@ func (r *AWSManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 	}
 
 	managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
-		Client:               r.Client,
-		Cluster:              cluster,
-		ControlPlane:         awsControlPlane,
-		ControllerName:       strings.ToLower(awsManagedControlPlaneKind),
-		EnableIAM:            r.EnableIAM,
-		AllowAdditionalRoles: r.AllowAdditionalRoles,
-		Endpoints:            r.Endpoints,
+		Client:                    r.Client,
+		Cluster:                   cluster,
+		ControlPlane:              awsControlPlane,
+		ControllerName:            strings.ToLower(awsManagedControlPlaneKind),
+		EnableIAM:                 r.EnableIAM,
+		AllowAdditionalRoles:      r.AllowAdditionalRoles,
+		IAMPermissionsBoundary:    r.IAMPermissionsBoundary,
+		IAMPath:                   r.IAMPath,
+		Endpoints:                 r.Endpoints,
+		EnforcePrincipalAllowList: r.EnforcePrincipalAllowList,
+		DescribeCacheTTL:          r.DescribeCacheTTL,
+		AuditEventsVerbosity:      r.AuditEventsVerbosity,
 	})
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to create scope: %w", err)
@@ -190,6 +208,7 @@ func (r *AWSManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 		applicableConditions := []clusterv1.ConditionType{
 			ekscontrolplanev1.EKSControlPlaneReadyCondition,
 			ekscontrolplanev1.IAMControlPlaneRolesReadyCondition,
+			ekscontrolplanev1.IAMClusterRoleReadyCondition,
 			ekscontrolplanev1.IAMAuthenticatorConfiguredCondition,
 			ekscontrolplanev1.EKSAddonsConfiguredCondition,
 			infrav1.VpcReadyCondition,
@@ -251,6 +270,9 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 
 	ec2Service := ec2.NewService(managedScope)
 	networkSvc := network.NewService(managedScope)
+	if feature.Gates.Enabled(feature.ServiceQuotaChecks) {
+		networkSvc.QuotaService = servicequotas.NewService(managedScope)
+	}
 	ekssvc := eks.NewService(managedScope)
 	sgService := securitygroup.NewService(managedScope, securityGroupRolesForControlPlane(managedScope))
 	authService := iamauth.NewService(managedScope, iamauth.BackendTypeConfigMap, managedScope.Client)
@@ -260,6 +282,10 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 	if err := networkSvc.ReconcileNetwork(); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile network for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
 	}
+	if network.NatInstancesPending(awsManagedControlPlane) {
+		managedScope.Info("Waiting on NAT instances")
+		return reconcile.Result{RequeueAfter: 15 * time.Second}, nil
+	}
 
 	if err := sgService.ReconcileSecurityGroups(); err != nil {
 		conditions.MarkFalse(awsManagedControlPlane, infrav1.ClusterSecurityGroupsReadyCondition, infrav1.ClusterSecurityGroupReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -271,6 +297,19 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile bastion host for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
 	}
 
+	billableResources, err := billable.Summarize(ctx, r.Client, client.ObjectKey{Namespace: managedScope.Namespace(), Name: managedScope.Name()}, managedScope.Network(), awsManagedControlPlane.Status.Bastion)
+	if err != nil {
+		// non fatal error, the summary is informational only
+		managedScope.Error(err, "non-fatal: failed to summarize billable resources")
+	} else {
+		if feature.Gates.Enabled(feature.CostEstimation) {
+			if err := billable.EstimateCost(managedScope, billableResources, awsManagedControlPlane.Status.BillableResources, ""); err != nil {
+				managedScope.Error(err, "non-fatal: failed to estimate the cost of billable resources")
+			}
+		}
+		awsManagedControlPlane.Status.BillableResources = billableResources
+	}
+
 	if err := ekssvc.ReconcileControlPlane(ctx); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile control plane for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
 	}
@@ -284,12 +323,36 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile control plane for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
 	}
 
+	if err := karpenter.NewService(managedScope).ReconcileKarpenter(); err != nil {
+		conditions.MarkFalse(awsManagedControlPlane, ekscontrolplanev1.KarpenterResourcesReadyCondition, ekscontrolplanev1.KarpenterResourcesReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return reconcile.Result{}, fmt.Errorf("failed to reconcile Karpenter resources for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
+	}
+	if awsManagedControlPlane.Spec.Karpenter != nil {
+		conditions.MarkTrue(awsManagedControlPlane, ekscontrolplanev1.KarpenterResourcesReadyCondition)
+	} else {
+		conditions.Delete(awsManagedControlPlane, ekscontrolplanev1.KarpenterResourcesReadyCondition)
+	}
+
+	var result reconcile.Result
+	if feature.Gates.Enabled(feature.ClusterInfoConfigMap) {
+		if err := clusterinfo.NewService(managedScope).ReconcileClusterInfo(ctx); err != nil {
+			// non fatal error, the control plane is ready without the ConfigMap
+			managedScope.Error(err, "non-fatal: failed to reconcile cluster info")
+			result = reconcile.Result{RequeueAfter: clusterInfoRequeueAfter}
+		}
+	}
+
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
 		instancestateSvc := instancestate.NewService(managedScope)
 		if err := instancestateSvc.ReconcileEC2Events(); err != nil {
 			// non fatal error, so we continue
 			managedScope.Error(err, "non-fatal: failed to set up EventBridge")
 		}
+		if feature.Gates.Enabled(feature.MachinePool) {
+			if err := instancestateSvc.ReconcileMachinePoolEvents(); err != nil {
+				managedScope.Error(err, "non-fatal: failed to set up EventBridge for machine pools")
+			}
+		}
 	}
 	if err := authService.ReconcileIAMAuthenticator(ctx); err != nil {
 		conditions.MarkFalse(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition, ekscontrolplanev1.IAMAuthenticatorConfigurationFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -303,7 +366,7 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 		})
 	}
 
-	return reconcile.Result{}, nil
+	return result, nil
 }
 
 func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context, managedScope *scope.ManagedControlPlaneScope) (_ ctrl.Result, reterr error) {
@@ -329,6 +392,18 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 	networkSvc := network.NewService(managedScope)
 	sgService := securitygroup.NewService(managedScope, securityGroupRolesForControlPlane(managedScope))
 
+	if feature.Gates.Enabled(feature.EventBridgeInstanceState) && feature.Gates.Enabled(feature.MachinePool) {
+		if err := instancestate.NewService(managedScope).DeleteMachinePoolEvents(); err != nil {
+			managedScope.Error(err, "non-fatal: failed to delete EventBridge machine pool notifications")
+		}
+	}
+
+	if err := karpenter.NewService(managedScope).DeleteKarpenter(); err != nil {
+		conditions.MarkFalse(controlPlane, ekscontrolplanev1.KarpenterResourcesReadyCondition, ekscontrolplanev1.KarpenterResourcesDeletionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		log.Error(err, "error deleting Karpenter resources for AWSManagedControlPlane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
+		return reconcile.Result{}, err
+	}
+
 	if err := ekssvc.DeleteControlPlane(); err != nil {
 		log.Error(err, "error deleting EKS cluster for EKS control plane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
 		return reconcile.Result{}, err
@@ -356,6 +431,7 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 		return reconcile.Result{}, err
 	}
 
+	billable.ForgetEstimate(managedScope.Namespace(), managedScope.Name())
 	controllerutil.RemoveFinalizer(controlPlane, ekscontrolplanev1.ManagedControlPlaneFinalizer)
 
 	return reconcile.Result{}, nil
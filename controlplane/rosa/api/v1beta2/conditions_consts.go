@@ -0,0 +1,32 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+const (
+	// ROSAControlPlaneReadyCondition condition reports on the successful reconciliation of the ROSA control plane.
+	ROSAControlPlaneReadyCondition clusterv1.ConditionType = "ROSAControlPlaneReady"
+	// ROSAControlPlaneCreatingReason is used while OCM is installing the cluster.
+	ROSAControlPlaneCreatingReason = "Creating"
+	// ROSAControlPlaneDeletingReason is used while OCM is uninstalling the cluster.
+	ROSAControlPlaneDeletingReason = "Deleting"
+	// ROSAControlPlaneInstallFailedReason is used when OCM reports that the installation failed.
+	ROSAControlPlaneInstallFailedReason = "InstallFailed"
+	// ROSAControlPlaneReconciliationFailedReason used to report failures while reconciling the ROSA control plane.
+	ROSAControlPlaneReconciliationFailedReason = "ReconciliationFailed"
+)
@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// ROSAControlPlaneFinalizer allows the controller to clean up resources on delete.
+	ROSAControlPlaneFinalizer = "rosacontrolplane.controlplane.cluster.x-k8s.io"
+
+	// OCMTokenKey is the key in the credentials secret holding the offline OCM token.
+	OCMTokenKey = "ocmToken"
+
+	// OCMAPIURLKey is the key in the credentials secret holding the OCM API URL.
+	// It is optional and defaults to the production OCM environment.
+	OCMAPIURLKey = "ocmApiUrl"
+)
+
+// RosaControlPlaneSpec defines the desired state of ROSAControlPlane.
+type RosaControlPlaneSpec struct { //nolint: maligned
+	// RosaClusterName is the name of the cluster in OCM. It defaults to the
+	// namespace and name of the control plane, truncated to 15 characters.
+	// +kubebuilder:validation:MaxLength:=15
+	// +kubebuilder:validation:Pattern:=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	RosaClusterName string `json:"rosaClusterName,omitempty"`
+
+	// Subnets are the IDs of the existing subnets the cluster is deployed into.
+	// +kubebuilder:validation:MinItems:=1
+	Subnets []string `json:"subnets"`
+
+	// AvailabilityZones are the availability zones of the subnets.
+	// +optional
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
+	// Region is the AWS region the cluster is deployed into.
+	Region string `json:"region"`
+
+	// Version is the OpenShift version of the cluster, e.g. 4.12.5.
+	// +kubebuilder:validation:Pattern:=`^\d+\.\d+\.\d+$`
+	Version string `json:"version"`
+
+	// InstallerRoleARN is the ARN of the account wide installer role. The
+	// AWS account of the cluster is taken from this ARN.
+	InstallerRoleARN string `json:"installerRoleARN"`
+
+	// SupportRoleARN is the ARN of the account wide role used by Red Hat SRE.
+	SupportRoleARN string `json:"supportRoleARN"`
+
+	// WorkerRoleARN is the ARN of the account wide role attached to worker instances.
+	WorkerRoleARN string `json:"workerRoleARN"`
+
+	// OIDCID is the ID of the OIDC configuration used by the cluster operators.
+	OIDCID string `json:"oidcID"`
+
+	// RolesRef holds the ARNs of the operator roles.
+	RolesRef AWSRolesRef `json:"rolesRef"`
+
+	// CredentialsSecretRef references a secret in the namespace of the control
+	// plane holding the OCM credentials under the "ocmToken" key and,
+	// optionally, the OCM API URL under the "ocmApiUrl" key.
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
+	// +optional
+	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
+}
+
+// AWSRolesRef holds the ARNs of the IAM roles assumed by the cluster operators.
+type AWSRolesRef struct {
+	// IngressARN is the role used by the ingress operator to manage DNS records and load balancers.
+	IngressARN string `json:"ingressARN"`
+
+	// ImageRegistryARN is the role used by the image registry operator to manage its S3 bucket.
+	ImageRegistryARN string `json:"imageRegistryARN"`
+
+	// StorageARN is the role used by the AWS EBS CSI driver.
+	StorageARN string `json:"storageARN"`
+
+	// NetworkARN is the role used by the cloud network config controller.
+	NetworkARN string `json:"networkARN"`
+
+	// KubeCloudControllerARN is the role used by the kube cloud controller manager.
+	KubeCloudControllerARN string `json:"kubeCloudControllerARN"`
+
+	// NodePoolManagementARN is the role used to manage the instances of the node pools.
+	NodePoolManagementARN string `json:"nodePoolManagementARN"`
+
+	// ControlPlaneOperatorARN is the role used by the hosted control plane operator.
+	ControlPlaneOperatorARN string `json:"controlPlaneOperatorARN"`
+
+	// KMSProviderARN is the role used to encrypt etcd with a customer managed KMS key.
+	KMSProviderARN string `json:"kmsProviderARN"`
+}
+
+// RosaControlPlaneStatus defines the observed state of ROSAControlPlane.
+type RosaControlPlaneStatus struct {
+	// ExternalManagedControlPlane indicates to cluster-api that the control plane
+	// is managed by an external service such as AKS, EKS, GKE, etc.
+	// +kubebuilder:default=true
+	ExternalManagedControlPlane *bool `json:"externalManagedControlPlane,omitempty"`
+	// Initialized denotes whether or not the control plane has the
+	// uploaded kubernetes config-map.
+	// +optional
+	Initialized bool `json:"initialized"`
+	// Ready denotes that the ROSAControlPlane API Server is ready to
+	// receive requests.
+	// +kubebuilder:default=false
+	Ready bool `json:"ready"`
+	// FailureMessage will be set in the event that there is a terminal problem
+	// reconciling the state and will be set to a descriptive error message.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+	// Conditions specifies the conditions for the managed control plane
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// ID is the cluster ID given by OCM.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// ConsoleURL is the URL of the OpenShift web console.
+	// +optional
+	ConsoleURL string `json:"consoleURL,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=rosacontrolplanes,shortName=rosacp,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this ROSAControlPlane belongs"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Control plane infrastructure is ready for worker nodes"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="Cluster ID given by OCM"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.controlPlaneEndpoint.host",description="API Endpoint",priority=1
+
+// ROSAControlPlane is the schema for the Red Hat OpenShift Service on AWS Control Plane API.
+type ROSAControlPlane struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RosaControlPlaneSpec   `json:"spec,omitempty"`
+	Status RosaControlPlaneStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ROSAControlPlaneList contains a list of ROSAControlPlane.
+type ROSAControlPlaneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ROSAControlPlane `json:"items"`
+}
+
+// GetConditions returns the control planes conditions.
+func (r *ROSAControlPlane) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the status conditions for the ROSAControlPlane.
+func (r *ROSAControlPlane) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&ROSAControlPlane{}, &ROSAControlPlaneList{})
+}
@@ -0,0 +1,443 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controllers provides a way to reconcile ROSA resources.
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	capiannotations "sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+const (
+	rosaControlPlaneKind = "ROSAControlPlane"
+
+	// rosaCreatingRequeueAfter is how long to wait before checking again on a cluster
+	// that OCM is still installing or uninstalling.
+	rosaCreatingRequeueAfter = time.Minute
+
+	// defaultAPIServerPort is used when the API URL reported by OCM has no port.
+	defaultAPIServerPort = 443
+)
+
+// ROSAControlPlaneReconciler reconciles a ROSAControlPlane object.
+type ROSAControlPlaneReconciler struct {
+	client.Client
+	Recorder         record.EventRecorder
+	WatchFilterValue string
+
+	// NewOCMClient creates the OCM client used for each reconciliation.
+	// It defaults to rosa.NewClient.
+	NewOCMClient func(params rosa.ClientParams) (rosa.Client, error)
+}
+
+// SetupWithManager is used to setup the controller.
+func (r *ROSAControlPlaneReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	log := logger.FromContext(ctx)
+
+	if r.NewOCMClient == nil {
+		r.NewOCMClient = rosa.NewClient
+	}
+
+	rosaControlPlane := &rosacontrolplanev1.ROSAControlPlane{}
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		For(rosaControlPlane).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log.GetLogger(), r.WatchFilterValue)).
+		Build(r)
+
+	if err != nil {
+		return fmt.Errorf("failed setting up the ROSAControlPlane controller manager: %w", err)
+	}
+
+	if err = c.Watch(
+		&source.Kind{Type: &clusterv1.Cluster{}},
+		handler.EnqueueRequestsFromMapFunc(r.ClusterToROSAControlPlane),
+		predicates.ClusterUnpaused(log.GetLogger()),
+	); err != nil {
+		return fmt.Errorf("failed adding a watch for ready clusters: %w", err)
+	}
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=rosamachinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=rosacontrolplanes,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=rosacontrolplanes/status,verbs=get;update;patch
+
+// Reconcile will reconcile ROSAControlPlane Resources.
+func (r *ROSAControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reterr error) {
+	log := logger.FromContext(ctx)
+
+	// Get the control plane instance
+	rosaControlPlane := &rosacontrolplanev1.ROSAControlPlane{}
+	if err := r.Client.Get(ctx, req.NamespacedName, rosaControlPlane); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Get the cluster
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, rosaControlPlane.ObjectMeta)
+	if err != nil {
+		log.Error(err, "Failed to retrieve owner Cluster from the API Server")
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		log.Info("Cluster Controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+
+	if capiannotations.IsPaused(cluster, rosaControlPlane) {
+		log.Info("Reconciliation is paused for this object")
+		return ctrl.Result{}, nil
+	}
+
+	rosaScope, err := scope.NewROSAControlPlaneScope(scope.ROSAControlPlaneScopeParams{
+		Client:         r.Client,
+		Cluster:        cluster,
+		ControlPlane:   rosaControlPlane,
+		ControllerName: strings.ToLower(rosaControlPlaneKind),
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create scope: %w", err)
+	}
+
+	// Always close the scope
+	defer func() {
+		if err := rosaScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	if !rosaControlPlane.ObjectMeta.DeletionTimestamp.IsZero() {
+		// Handle deletion reconciliation loop.
+		return r.reconcileDelete(ctx, rosaScope)
+	}
+
+	// Handle normal reconciliation loop.
+	return r.reconcileNormal(ctx, rosaScope)
+}
+
+func (r *ROSAControlPlaneReconciler) reconcileNormal(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (ctrl.Result, error) {
+	rosaScope.Info("Reconciling ROSAControlPlane")
+
+	controlPlane := rosaScope.ControlPlane
+	controlPlane.Status.ExternalManagedControlPlane = pointer.Bool(true)
+
+	if controllerutil.AddFinalizer(controlPlane, rosacontrolplanev1.ROSAControlPlaneFinalizer) {
+		if err := rosaScope.PatchObject(); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	ocmClient, err := r.ocmClient(ctx, rosaScope)
+	if err != nil {
+		conditions.MarkFalse(controlPlane, rosacontrolplanev1.ROSAControlPlaneReadyCondition, rosacontrolplanev1.ROSAControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	cluster, err := ocmClient.GetCluster(ctx, rosaScope.RosaClusterName())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if cluster == nil {
+		ocmCluster, err := buildOCMCluster(rosaScope.RosaClusterName(), controlPlane)
+		if err != nil {
+			conditions.MarkFalse(controlPlane, rosacontrolplanev1.ROSAControlPlaneReadyCondition, rosacontrolplanev1.ROSAControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		cluster, err = ocmClient.CreateCluster(ctx, ocmCluster)
+		if err != nil {
+			conditions.MarkFalse(controlPlane, rosacontrolplanev1.ROSAControlPlaneReadyCondition, rosacontrolplanev1.ROSAControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			r.Recorder.Eventf(controlPlane, "Warning", "FailedCreateROSACluster", "Failed to create ROSA cluster %q: %v", rosaScope.RosaClusterName(), err)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(controlPlane, "Normal", "SuccessfulCreateROSACluster", "Created ROSA cluster %q with id %s", cluster.Name, cluster.ID)
+	}
+
+	controlPlane.Status.ID = cluster.ID
+	if cluster.Console != nil {
+		controlPlane.Status.ConsoleURL = cluster.Console.URL
+	}
+
+	switch cluster.State {
+	case rosa.ClusterStateReady:
+	case rosa.ClusterStateError:
+		message := fmt.Sprintf("ROSA cluster %q failed to install", cluster.Name)
+		if cluster.Status != nil && cluster.Status.ProvisionErrorMessage != "" {
+			message = fmt.Sprintf("%s: %s", message, cluster.Status.ProvisionErrorMessage)
+		}
+		controlPlane.Status.FailureMessage = &message
+		controlPlane.Status.Ready = false
+		conditions.MarkFalse(controlPlane, rosacontrolplanev1.ROSAControlPlaneReadyCondition, rosacontrolplanev1.ROSAControlPlaneInstallFailedReason, clusterv1.ConditionSeverityError, message)
+		return ctrl.Result{}, nil
+	default:
+		rosaScope.Info("Waiting for ROSA cluster to be ready", "state", cluster.State)
+		conditions.MarkFalse(controlPlane, rosacontrolplanev1.ROSAControlPlaneReadyCondition, rosacontrolplanev1.ROSAControlPlaneCreatingReason, clusterv1.ConditionSeverityInfo, "cluster is %s", cluster.State)
+		return ctrl.Result{RequeueAfter: rosaCreatingRequeueAfter}, nil
+	}
+
+	if cluster.API == nil || cluster.API.URL == "" {
+		return ctrl.Result{RequeueAfter: rosaCreatingRequeueAfter}, nil
+	}
+
+	endpoint, err := apiEndpointFromURL(cluster.API.URL)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	controlPlane.Spec.ControlPlaneEndpoint = endpoint
+	controlPlane.Status.FailureMessage = nil
+	controlPlane.Status.Ready = true
+	conditions.MarkTrue(controlPlane, rosacontrolplanev1.ROSAControlPlaneReadyCondition)
+
+	if err := r.reconcileKubeconfig(ctx, rosaScope, ocmClient, cluster); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile kubeconfig: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *ROSAControlPlaneReconciler) reconcileDelete(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (ctrl.Result, error) {
+	rosaScope.Info("Reconciling ROSAControlPlane delete")
+
+	controlPlane := rosaScope.ControlPlane
+
+	numDependencies, err := r.dependencyCount(ctx, rosaScope)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if numDependencies > 0 {
+		rosaScope.Info("ROSA cluster still has dependencies - requeue needed", "dependencyCount", numDependencies)
+		return ctrl.Result{RequeueAfter: rosaCreatingRequeueAfter}, nil
+	}
+
+	ocmClient, err := r.ocmClient(ctx, rosaScope)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	cluster, err := ocmClient.GetCluster(ctx, rosaScope.RosaClusterName())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if cluster == nil {
+		controllerutil.RemoveFinalizer(controlPlane, rosacontrolplanev1.ROSAControlPlaneFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	if cluster.State != rosa.ClusterStateUninstalling {
+		if err := ocmClient.DeleteCluster(ctx, cluster.ID); err != nil {
+			r.Recorder.Eventf(controlPlane, "Warning", "FailedDeleteROSACluster", "Failed to delete ROSA cluster %q: %v", cluster.Name, err)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(controlPlane, "Normal", "SuccessfulDeleteROSACluster", "Started deletion of ROSA cluster %q", cluster.Name)
+	}
+
+	conditions.MarkFalse(controlPlane, rosacontrolplanev1.ROSAControlPlaneReadyCondition, rosacontrolplanev1.ROSAControlPlaneDeletingReason, clusterv1.ConditionSeverityInfo, "")
+
+	return ctrl.Result{RequeueAfter: rosaCreatingRequeueAfter}, nil
+}
+
+func (r *ROSAControlPlaneReconciler) ocmClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (rosa.Client, error) {
+	params, err := rosaScope.OCMClientParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.NewOCMClient(params)
+}
+
+func (r *ROSAControlPlaneReconciler) reconcileKubeconfig(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, ocmClient rosa.Client, cluster *rosa.Cluster) error {
+	clusterRef := types.NamespacedName{
+		Name:      rosaScope.Cluster.Name,
+		Namespace: rosaScope.Cluster.Namespace,
+	}
+
+	_, err := secret.GetFromNamespacedName(ctx, rosaScope.Client, clusterRef, secret.Kubeconfig)
+	if err == nil {
+		rosaScope.ControlPlane.Status.Initialized = true
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "failed to get kubeconfig secret")
+	}
+
+	out, err := ocmClient.GetKubeconfig(ctx, cluster.ID)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		rosaScope.Info("OCM has not returned a kubeconfig for the cluster yet")
+		return nil
+	}
+
+	controllerOwnerRef := *metav1.NewControllerRef(rosaScope.ControlPlane, rosacontrolplanev1.GroupVersion.WithKind(rosaControlPlaneKind))
+	kubeconfigSecret := kubeconfig.GenerateSecretWithOwner(clusterRef, []byte(out), controllerOwnerRef)
+	if err := rosaScope.Client.Create(ctx, kubeconfigSecret); err != nil {
+		return errors.Wrap(err, "failed to create kubeconfig secret")
+	}
+
+	r.Recorder.Eventf(rosaScope.ControlPlane, "Normal", "SucessfulCreateKubeconfig", "Created kubeconfig for cluster %q", rosaScope.Name())
+	rosaScope.ControlPlane.Status.Initialized = true
+
+	return nil
+}
+
+func (r *ROSAControlPlaneReconciler) dependencyCount(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (int, error) {
+	clusterName := rosaScope.Name()
+	namespace := rosaScope.Namespace()
+
+	listOptions := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels(map[string]string{clusterv1.ClusterNameLabel: clusterName}),
+	}
+
+	machinePools := &expinfrav1.ROSAMachinePoolList{}
+	if err := r.Client.List(ctx, machinePools, listOptions...); err != nil {
+		return 0, fmt.Errorf("failed to list rosa machine pools for cluster %s/%s: %w", namespace, clusterName, err)
+	}
+
+	return len(machinePools.Items), nil
+}
+
+// ClusterToROSAControlPlane is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for ROSAControlPlane based on updates to a Cluster.
+func (r *ROSAControlPlaneReconciler) ClusterToROSAControlPlane(o client.Object) []ctrl.Request {
+	c, ok := o.(*clusterv1.Cluster)
+	if !ok {
+		klog.Errorf("Expected a Cluster but got a %T", o)
+		return nil
+	}
+
+	if !c.ObjectMeta.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	controlPlaneRef := c.Spec.ControlPlaneRef
+	if controlPlaneRef != nil && controlPlaneRef.Kind == rosaControlPlaneKind {
+		return []ctrl.Request{{NamespacedName: client.ObjectKey{Namespace: controlPlaneRef.Namespace, Name: controlPlaneRef.Name}}}
+	}
+
+	return nil
+}
+
+// buildOCMCluster converts the control plane spec into the cluster object sent to OCM.
+func buildOCMCluster(name string, controlPlane *rosacontrolplanev1.ROSAControlPlane) (*rosa.Cluster, error) {
+	spec := controlPlane.Spec
+
+	installerRole, err := arn.Parse(spec.InstallerRoleARN)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid installer role ARN %q", spec.InstallerRoleARN)
+	}
+
+	roles := spec.RolesRef
+	operatorRoles := []rosa.OperatorIAMRole{
+		{Name: "cloud-credentials", Namespace: "openshift-ingress-operator", RoleARN: roles.IngressARN},
+		{Name: "installer-cloud-credentials", Namespace: "openshift-image-registry", RoleARN: roles.ImageRegistryARN},
+		{Name: "ebs-cloud-credentials", Namespace: "openshift-cluster-csi-drivers", RoleARN: roles.StorageARN},
+		{Name: "cloud-credentials", Namespace: "openshift-cloud-network-config-controller", RoleARN: roles.NetworkARN},
+		{Name: "kube-controller-manager", Namespace: "kube-system", RoleARN: roles.KubeCloudControllerARN},
+		{Name: "capa-controller-manager", Namespace: "kube-system", RoleARN: roles.NodePoolManagementARN},
+		{Name: "control-plane-operator", Namespace: "kube-system", RoleARN: roles.ControlPlaneOperatorARN},
+		{Name: "kms-provider", Namespace: "kube-system", RoleARN: roles.KMSProviderARN},
+	}
+
+	return &rosa.Cluster{
+		Name:          name,
+		Product:       &rosa.ObjectRef{ID: "rosa"},
+		CloudProvider: &rosa.ObjectRef{ID: "aws"},
+		Region:        &rosa.ObjectRef{ID: spec.Region},
+		Version:       &rosa.ObjectRef{ID: "openshift-v" + spec.Version},
+		MultiAZ:       len(spec.AvailabilityZones) > 1,
+		CCS:           &rosa.CCS{Enabled: true},
+		Hypershift:    &rosa.Hypershift{Enabled: true},
+		AWS: &rosa.ClusterAWS{
+			AccountID: installerRole.AccountID,
+			SubnetIDs: spec.Subnets,
+			STS: &rosa.STS{
+				RoleARN:          spec.InstallerRoleARN,
+				SupportRoleARN:   spec.SupportRoleARN,
+				InstanceIAMRoles: &rosa.InstanceIAMRoles{WorkerRoleARN: spec.WorkerRoleARN},
+				OperatorIAMRoles: operatorRoles,
+				OIDCConfig:       &rosa.ObjectRef{ID: spec.OIDCID},
+			},
+		},
+		Nodes: &rosa.ClusterNodes{
+			AvailabilityZones: spec.AvailabilityZones,
+		},
+	}, nil
+}
+
+// apiEndpointFromURL converts the API URL reported by OCM into a cluster API endpoint.
+func apiEndpointFromURL(apiURL string) (clusterv1.APIEndpoint, error) {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return clusterv1.APIEndpoint{}, errors.Wrapf(err, "failed to parse API URL %q", apiURL)
+	}
+
+	host, portStr, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		return clusterv1.APIEndpoint{Host: u.Host, Port: defaultAPIServerPort}, nil //nolint:nilerr
+	}
+
+	port, err := strconv.ParseInt(portStr, 10, 32)
+	if err != nil {
+		return clusterv1.APIEndpoint{}, errors.Wrapf(err, "invalid port in API URL %q", apiURL)
+	}
+
+	return clusterv1.APIEndpoint{Host: host, Port: int32(port)}, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestBuildOCMCluster(t *testing.T) {
+	g := NewWithT(t)
+
+	controlPlane := &rosacontrolplanev1.ROSAControlPlane{
+		Spec: rosacontrolplanev1.RosaControlPlaneSpec{
+			Subnets:           []string{"subnet-1", "subnet-2"},
+			AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+			Region:            "us-east-1",
+			Version:           "4.12.5",
+			InstallerRoleARN:  "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+			SupportRoleARN:    "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role",
+			WorkerRoleARN:     "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role",
+			OIDCID:            "oidc-1",
+			RolesRef: rosacontrolplanev1.AWSRolesRef{
+				IngressARN:     "arn:aws:iam::123456789012:role/ingress",
+				NetworkARN:     "arn:aws:iam::123456789012:role/network",
+				KMSProviderARN: "arn:aws:iam::123456789012:role/kms",
+			},
+		},
+	}
+
+	cluster, err := buildOCMCluster("test", controlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cluster.Name).To(Equal("test"))
+	g.Expect(cluster.Version.ID).To(Equal("openshift-v4.12.5"))
+	g.Expect(cluster.Region.ID).To(Equal("us-east-1"))
+	g.Expect(cluster.MultiAZ).To(BeTrue())
+	g.Expect(cluster.Hypershift.Enabled).To(BeTrue())
+	g.Expect(cluster.AWS.AccountID).To(Equal("123456789012"))
+	g.Expect(cluster.AWS.SubnetIDs).To(Equal([]string{"subnet-1", "subnet-2"}))
+	g.Expect(cluster.AWS.STS.OIDCConfig.ID).To(Equal("oidc-1"))
+	g.Expect(cluster.AWS.STS.InstanceIAMRoles.WorkerRoleARN).To(Equal(controlPlane.Spec.WorkerRoleARN))
+	g.Expect(cluster.AWS.STS.OperatorIAMRoles).To(ContainElements(
+		rosa.OperatorIAMRole{Name: "cloud-credentials", Namespace: "openshift-ingress-operator", RoleARN: "arn:aws:iam::123456789012:role/ingress"},
+		rosa.OperatorIAMRole{Name: "cloud-credentials", Namespace: "openshift-cloud-network-config-controller", RoleARN: "arn:aws:iam::123456789012:role/network"},
+		rosa.OperatorIAMRole{Name: "kms-provider", Namespace: "kube-system", RoleARN: "arn:aws:iam::123456789012:role/kms"},
+	))
+
+	controlPlane.Spec.InstallerRoleARN = "not-an-arn"
+	_, err = buildOCMCluster("test", controlPlane)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestAPIEndpointFromURL(t *testing.T) {
+	testCases := []struct {
+		name    string
+		apiURL  string
+		want    clusterv1.APIEndpoint
+		wantErr bool
+	}{
+		{
+			name:   "url with port",
+			apiURL: "https://api.test.abcd.p1.openshiftapps.com:443",
+			want:   clusterv1.APIEndpoint{Host: "api.test.abcd.p1.openshiftapps.com", Port: 443},
+		},
+		{
+			name:   "url without port, should default to 443",
+			apiURL: "https://api.test.abcd.p1.openshiftapps.com",
+			want:   clusterv1.APIEndpoint{Host: "api.test.abcd.p1.openshiftapps.com", Port: 443},
+		},
+		{
+			name:    "url with invalid port",
+			apiURL:  "https://api.test:abc",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			endpoint, err := apiEndpointFromURL(tc.apiURL)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(endpoint).To(Equal(tc.want))
+		})
+	}
+}
@@ -43,7 +43,23 @@ func (src *AWSMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	}
 	if dst.Spec.RefreshPreferences != nil && restored.Spec.RefreshPreferences != nil {
 		dst.Spec.RefreshPreferences.Disable = restored.Spec.RefreshPreferences.Disable
+		dst.Spec.RefreshPreferences.RollingUpdate = restored.Spec.RefreshPreferences.RollingUpdate
 	}
+	dst.Spec.AWSLaunchTemplate.Bottlerocket = restored.Spec.AWSLaunchTemplate.Bottlerocket
+	dst.Spec.AWSLaunchTemplate.PreBootstrapCommands = restored.Spec.AWSLaunchTemplate.PreBootstrapCommands
+	dst.Spec.AWSLaunchTemplate.PostBootstrapCommands = restored.Spec.AWSLaunchTemplate.PostBootstrapCommands
+	dst.Spec.AWSLaunchTemplate.MarketType = restored.Spec.AWSLaunchTemplate.MarketType
+	dst.Spec.AWSLaunchTemplate.CapacityReservationID = restored.Spec.AWSLaunchTemplate.CapacityReservationID
+	dst.Spec.AWSLaunchTemplate.CreditSpecification = restored.Spec.AWSLaunchTemplate.CreditSpecification
+	dst.Spec.AvailabilityZoneFailurePolicy = restored.Spec.AvailabilityZoneFailurePolicy
+	dst.Spec.FilterSubnetsByFailureDomains = restored.Spec.FilterSubnetsByFailureDomains
+	dst.Spec.AvailabilityZoneDistribution = restored.Spec.AvailabilityZoneDistribution
+	dst.Spec.MetricsCollection = restored.Spec.MetricsCollection
+	dst.Spec.ScalingPolicies = restored.Spec.ScalingPolicies
+	dst.Status.SuspendedAvailabilityZones = restored.Status.SuspendedAvailabilityZones
+	dst.Status.RollingUpdate = restored.Status.RollingUpdate
+	dst.Status.ScalingPolicies = restored.Status.ScalingPolicies
+	dst.Status.Capacity = restored.Status.Capacity
 
 	return nil
 }
@@ -79,6 +95,24 @@ func (src *AWSManagedMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 
+	// Manually restore data.
+	restored := &infrav1exp.AWSManagedMachinePool{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	if dst.Spec.AWSLaunchTemplate != nil && restored.Spec.AWSLaunchTemplate != nil {
+		dst.Spec.AWSLaunchTemplate.Bottlerocket = restored.Spec.AWSLaunchTemplate.Bottlerocket
+		dst.Spec.AWSLaunchTemplate.PreBootstrapCommands = restored.Spec.AWSLaunchTemplate.PreBootstrapCommands
+		dst.Spec.AWSLaunchTemplate.PostBootstrapCommands = restored.Spec.AWSLaunchTemplate.PostBootstrapCommands
+		dst.Spec.AWSLaunchTemplate.MarketType = restored.Spec.AWSLaunchTemplate.MarketType
+		dst.Spec.AWSLaunchTemplate.CapacityReservationID = restored.Spec.AWSLaunchTemplate.CapacityReservationID
+		dst.Spec.AWSLaunchTemplate.CreditSpecification = restored.Spec.AWSLaunchTemplate.CreditSpecification
+	}
+	dst.Spec.NodeRepairConfig = restored.Spec.NodeRepairConfig
+	dst.Status.Capacity = restored.Status.Capacity
+	dst.Status.ConfigUpdate = restored.Status.ConfigUpdate
+
 	return nil
 }
 
@@ -90,7 +124,7 @@ func (r *AWSManagedMachinePool) ConvertFrom(srcRaw conversion.Hub) error {
 		return err
 	}
 
-	return nil
+	return utilconversion.MarshalData(src, r)
 }
 
 // Convert_v1beta2_AWSManagedMachinePoolSpec_To_v1beta1_AWSManagedMachinePoolSpec is a conversion function.
@@ -114,14 +148,35 @@ func (r *AWSManagedMachinePoolList) ConvertFrom(srcRaw conversion.Hub) error {
 // ConvertTo converts the v1beta1 AWSFargateProfile receiver to a v1beta2 AWSFargateProfile.
 func (src *AWSFargateProfile) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*infrav1exp.AWSFargateProfile)
-	return Convert_v1beta1_AWSFargateProfile_To_v1beta2_AWSFargateProfile(src, dst, nil)
+	if err := Convert_v1beta1_AWSFargateProfile_To_v1beta2_AWSFargateProfile(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &infrav1exp.AWSFargateProfile{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.PodExecutionRoleARN = restored.Spec.PodExecutionRoleARN
+
+	return nil
 }
 
 // ConvertFrom converts the v1beta2 AWSFargateProfile receiver to v1beta1 AWSFargateProfile.
 func (r *AWSFargateProfile) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1exp.AWSFargateProfile)
 
-	return Convert_v1beta2_AWSFargateProfile_To_v1beta1_AWSFargateProfile(src, r, nil)
+	if err := Convert_v1beta2_AWSFargateProfile_To_v1beta1_AWSFargateProfile(src, r, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalData(src, r)
+}
+
+// Convert_v1beta2_FargateProfileSpec_To_v1beta1_FargateProfileSpec is a conversion function.
+func Convert_v1beta2_FargateProfileSpec_To_v1beta1_FargateProfileSpec(in *infrav1exp.FargateProfileSpec, out *FargateProfileSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_FargateProfileSpec_To_v1beta1_FargateProfileSpec(in, out, s)
 }
 
 // ConvertTo converts the v1beta1 AWSFargateProfileList receiver to a v1beta2 AWSFargateProfileList.
@@ -170,6 +225,16 @@ func Convert_v1beta2_AWSMachinePoolSpec_To_v1beta1_AWSMachinePoolSpec(in *infrav
 	return autoConvert_v1beta2_AWSMachinePoolSpec_To_v1beta1_AWSMachinePoolSpec(in, out, s)
 }
 
+// Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus is a conversion function.
+func Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in *infrav1exp.AWSMachinePoolStatus, out *AWSMachinePoolStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in, out, s)
+}
+
+// Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus is a conversion function.
+func Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in *infrav1exp.AWSManagedMachinePoolStatus, out *AWSManagedMachinePoolStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in, out, s)
+}
+
 func Convert_v1beta1_AutoScalingGroup_To_v1beta2_AutoScalingGroup(in *AutoScalingGroup, out *infrav1exp.AutoScalingGroup, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_AutoScalingGroup_To_v1beta2_AutoScalingGroup(in, out, s)
 }
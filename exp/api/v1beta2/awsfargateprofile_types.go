@@ -60,6 +60,13 @@ type FargateProfileSpec struct {
 	// +optional
 	RoleName string `json:"roleName,omitempty"`
 
+	// PodExecutionRoleARN is the ARN of an existing IAM role to use as the pod execution role of the
+	// profile, instead of a role named by RoleName. The role is used as is: it is neither created,
+	// updated nor deleted, and must trust the eks-fargate-pods.amazonaws.com service principal.
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$`
+	// +optional
+	PodExecutionRoleARN *string `json:"podExecutionRoleARN,omitempty"`
+
 	// Selectors specify fargate pod selectors.
 	Selectors []FargateSelector `json:"selectors,omitempty"`
 }
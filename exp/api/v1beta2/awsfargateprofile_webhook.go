@@ -137,6 +137,12 @@ func (r *AWSFargateProfile) ValidateCreate() error {
 
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
+	if r.Spec.PodExecutionRoleARN != nil && r.Spec.RoleName != "" {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec", "podExecutionRoleARN"), "cannot be set together with spec.roleName"),
+		)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -175,6 +176,27 @@ func TestAWSFargateProfileValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "profile with an existing pod execution role is accepted",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName:         "cluster-1",
+					PodExecutionRoleARN: aws.String("arn:aws:iam::123456789012:role/fargate-pods"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "profile with both a role name and a pod execution role is rejected",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName:         "cluster-1",
+					RoleName:            "fargate-role",
+					PodExecutionRoleARN: aws.String("arn:aws:iam::123456789012:role/fargate-pods"),
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,218 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// AWSLoadBalancerSpec defines the desired state of AWSLoadBalancer.
+type AWSLoadBalancerSpec struct {
+	// ClusterName is the name of the Cluster this object belongs to. The load balancer is
+	// provisioned into the VPC of the cluster.
+	// +kubebuilder:validation:MinLength=1
+	ClusterName string `json:"clusterName"`
+
+	// LoadBalancerType is the type of the load balancer, alb or nlb.
+	// +kubebuilder:validation:Enum=alb;nlb
+	// +kubebuilder:default=alb
+	// +optional
+	LoadBalancerType infrav1.LoadBalancerType `json:"loadBalancerType,omitempty"`
+
+	// Scheme sets the scheme of the load balancer.
+	// +kubebuilder:validation:Enum=internet-facing;internal
+	// +kubebuilder:default=internet-facing
+	// +optional
+	Scheme *infrav1.ELBScheme `json:"scheme,omitempty"`
+
+	// Subnets are the IDs of the subnets of the load balancer. They default to the public subnets
+	// of the cluster for internet-facing load balancers, and to its private subnets for internal ones.
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+
+	// IngressCIDRBlocks are the CIDR blocks allowed to reach the listeners of an application load
+	// balancer, through the security group created for it. Defaults to 0.0.0.0/0.
+	// Network load balancers have no security group.
+	// +optional
+	IngressCIDRBlocks []string `json:"ingressCIDRBlocks,omitempty"`
+
+	// AdditionalSecurityGroups are the IDs of security groups attached to an application load
+	// balancer in addition to the one created for it.
+	// +optional
+	AdditionalSecurityGroups []string `json:"additionalSecurityGroups,omitempty"`
+
+	// Listeners are the listeners of the load balancer.
+	// +listType=map
+	// +listMapKey=port
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	Listeners []AWSLoadBalancerListener `json:"listeners"`
+
+	// AdditionalTags is an optional set of tags to add to the AWS resources created for the load balancer,
+	// in addition to the ones added by default.
+	// +optional
+	AdditionalTags infrav1.Tags `json:"additionalTags,omitempty"`
+}
+
+// AWSLoadBalancerListener defines a listener of an AWSLoadBalancer, forwarding to a target group.
+type AWSLoadBalancerListener struct {
+	// Port is the port of the listener.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int64 `json:"port"`
+
+	// Protocol is the protocol of the listener: HTTP or HTTPS for application load balancers,
+	// TCP, TLS or UDP for network load balancers.
+	// +kubebuilder:validation:Enum=HTTP;HTTPS;TCP;TLS;UDP
+	Protocol infrav1.ELBProtocol `json:"protocol"`
+
+	// CertificateARN is the ARN of the certificate of HTTPS and TLS listeners.
+	// +optional
+	CertificateARN string `json:"certificateARN,omitempty"`
+
+	// SSLPolicy is the security policy of HTTPS and TLS listeners, which defaults to the one chosen by AWS.
+	// +optional
+	SSLPolicy string `json:"sslPolicy,omitempty"`
+
+	// TargetGroup is the target group the listener forwards to.
+	TargetGroup AWSLoadBalancerTargetGroup `json:"targetGroup"`
+}
+
+// AWSLoadBalancerTargetGroup defines the target group of a listener, whose targets are the
+// instances of machine pools.
+type AWSLoadBalancerTargetGroup struct {
+	// Port is the port of the targets.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int64 `json:"port"`
+
+	// Protocol is the protocol of the targets, which defaults to the protocol of the listener, or to
+	// HTTP for HTTPS listeners and TCP for TLS listeners.
+	// +kubebuilder:validation:Enum=HTTP;HTTPS;TCP;TLS;UDP
+	// +optional
+	Protocol infrav1.ELBProtocol `json:"protocol,omitempty"`
+
+	// HealthCheck configures the health check of the target group.
+	// +optional
+	HealthCheck *infrav1.TargetGroupHealthCheckSpec `json:"healthCheck,omitempty"`
+
+	// MachinePools are the names of the AWSMachinePools, in the namespace of the load balancer,
+	// whose auto scaling groups are attached to the target group.
+	// +optional
+	MachinePools []string `json:"machinePools,omitempty"`
+}
+
+// AWSLoadBalancerStatus defines the observed state of AWSLoadBalancer.
+type AWSLoadBalancerStatus struct {
+	// Ready denotes that the load balancer is provisioned and its target groups are attached
+	// to the machine pools.
+	// +kubebuilder:default=false
+	Ready bool `json:"ready"`
+
+	// Name is the name of the load balancer in AWS.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// ARN is the ARN of the load balancer.
+	// +optional
+	ARN string `json:"arn,omitempty"`
+
+	// DNSName is the DNS name of the load balancer.
+	// +optional
+	DNSName string `json:"dnsName,omitempty"`
+
+	// SecurityGroupID is the ID of the security group created for an application load balancer.
+	// The machine pools must allow traffic from it on the ports of the target groups.
+	// +optional
+	SecurityGroupID string `json:"securityGroupID,omitempty"`
+
+	// TargetGroups are the target groups of the listeners of the load balancer.
+	// +optional
+	TargetGroups []AWSLoadBalancerTargetGroupStatus `json:"targetGroups,omitempty"`
+
+	// Conditions defines current state of the load balancer.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// AWSLoadBalancerTargetGroupStatus reports the target group of a listener.
+type AWSLoadBalancerTargetGroupStatus struct {
+	// ListenerPort is the port of the listener forwarding to the target group.
+	ListenerPort int64 `json:"listenerPort"`
+
+	// ARN is the ARN of the target group.
+	ARN string `json:"arn"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=awsloadbalancers,scope=Namespaced,categories=cluster-api,shortName=awslb
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster to which this AWSLoadBalancer belongs"
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.loadBalancerType",description="Type of the load balancer"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="AWSLoadBalancer ready status"
+// +kubebuilder:printcolumn:name="DNSName",type="string",JSONPath=".status.dnsName",description="DNS name of the load balancer"
+
+// AWSLoadBalancer is the Schema for the awsloadbalancers API.
+// It declares an application or network load balancer in the VPC of a cluster, forwarding to machine pools.
+type AWSLoadBalancer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AWSLoadBalancerSpec   `json:"spec,omitempty"`
+	Status AWSLoadBalancerStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the observations of the operational state of the AWSLoadBalancer resource.
+func (r *AWSLoadBalancer) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the AWSLoadBalancer to the predescribed clusterv1.Conditions.
+func (r *AWSLoadBalancer) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// TargetProtocol returns the protocol of the targets of the listener.
+func (l *AWSLoadBalancerListener) TargetProtocol() infrav1.ELBProtocol {
+	switch {
+	case l.TargetGroup.Protocol != "":
+		return l.TargetGroup.Protocol
+	case l.Protocol == infrav1.ELBProtocolHTTPS:
+		return infrav1.ELBProtocolHTTP
+	case l.Protocol == infrav1.ELBProtocolTLS:
+		return infrav1.ELBProtocolTCP
+	default:
+		return l.Protocol
+	}
+}
+
+// +kubebuilder:object:root=true
+
+// AWSLoadBalancerList contains a list of AWSLoadBalancers.
+type AWSLoadBalancerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AWSLoadBalancer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AWSLoadBalancer{}, &AWSLoadBalancerList{})
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"net"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// SetupWebhookWithManager will setup the webhooks for the AWSLoadBalancer.
+func (r *AWSLoadBalancer) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta2-awsloadbalancer,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsloadbalancers,versions=v1beta2,name=default.awsloadbalancer.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awsloadbalancer,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsloadbalancers,versions=v1beta2,name=validation.awsloadbalancer.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+var _ webhook.Defaulter = &AWSLoadBalancer{}
+var _ webhook.Validator = &AWSLoadBalancer{}
+
+// Default will set default values for the AWSLoadBalancer.
+func (r *AWSLoadBalancer) Default() {
+	if r.Labels == nil {
+		r.Labels = make(map[string]string)
+	}
+	r.Labels[clusterv1.ClusterNameLabel] = r.Spec.ClusterName
+
+	if r.Spec.LoadBalancerType == "" {
+		r.Spec.LoadBalancerType = infrav1.LoadBalancerTypeALB
+	}
+	if r.Spec.Scheme == nil {
+		scheme := infrav1.ELBSchemeInternetFacing
+		r.Spec.Scheme = &scheme
+	}
+	if r.Spec.LoadBalancerType == infrav1.LoadBalancerTypeALB && len(r.Spec.IngressCIDRBlocks) == 0 {
+		r.Spec.IngressCIDRBlocks = []string{"0.0.0.0/0"}
+	}
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *AWSLoadBalancer) ValidateCreate() error {
+	allErrs := r.validateSpec()
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		r.GroupVersionKind().GroupKind(),
+		r.Name,
+		allErrs,
+	)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *AWSLoadBalancer) ValidateUpdate(oldObj runtime.Object) error {
+	gv := r.GroupVersionKind().GroupKind()
+	old, ok := oldObj.(*AWSLoadBalancer)
+	if !ok {
+		return apierrors.NewInvalid(gv, r.Name, field.ErrorList{
+			field.InternalError(nil, errors.Errorf("failed to convert old %s to object", gv.Kind)),
+		})
+	}
+
+	allErrs := r.validateSpec()
+
+	if old.Spec.ClusterName != r.Spec.ClusterName {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "clusterName"), "field is immutable"))
+	}
+	if old.Spec.LoadBalancerType != r.Spec.LoadBalancerType {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "loadBalancerType"), "field is immutable"))
+	}
+	if !cmp.Equal(old.Spec.Scheme, r.Spec.Scheme) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "scheme"), "field is immutable"))
+	}
+	if !cmp.Equal(old.Spec.Subnets, r.Spec.Subnets) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "subnets"), "field is immutable"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(gv, r.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *AWSLoadBalancer) ValidateDelete() error {
+	return nil
+}
+
+func (r *AWSLoadBalancer) validateSpec() field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+
+	isALB := r.Spec.LoadBalancerType == infrav1.LoadBalancerTypeALB
+	switch r.Spec.LoadBalancerType {
+	case infrav1.LoadBalancerTypeALB, infrav1.LoadBalancerTypeNLB:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "loadBalancerType"), r.Spec.LoadBalancerType,
+			[]string{string(infrav1.LoadBalancerTypeALB), string(infrav1.LoadBalancerTypeNLB)}))
+	}
+
+	if !isALB {
+		if len(r.Spec.IngressCIDRBlocks) > 0 {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "ingressCIDRBlocks"), "is only supported by application load balancers"))
+		}
+		if len(r.Spec.AdditionalSecurityGroups) > 0 {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "additionalSecurityGroups"), "is only supported by application load balancers"))
+		}
+	}
+	for i, cidr := range r.Spec.IngressCIDRBlocks {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "ingressCIDRBlocks").Index(i), cidr, "must be a valid CIDR block"))
+		}
+	}
+
+	listenersPath := field.NewPath("spec", "listeners")
+	if len(r.Spec.Listeners) == 0 {
+		allErrs = append(allErrs, field.Required(listenersPath, "at least one listener is required"))
+	}
+	ports := map[int64]bool{}
+	for i, listener := range r.Spec.Listeners {
+		path := listenersPath.Index(i)
+		if ports[listener.Port] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("port"), listener.Port))
+		}
+		ports[listener.Port] = true
+
+		if !loadBalancerSupportsProtocol(isALB, listener.Protocol) {
+			allErrs = append(allErrs, field.Invalid(path.Child("protocol"), listener.Protocol,
+				"is not supported by the load balancer type"))
+		}
+		if listener.TargetGroup.Protocol != "" && !loadBalancerSupportsProtocol(isALB, listener.TargetGroup.Protocol) {
+			allErrs = append(allErrs, field.Invalid(path.Child("targetGroup", "protocol"), listener.TargetGroup.Protocol,
+				"is not supported by the load balancer type"))
+		}
+
+		secure := listener.Protocol == infrav1.ELBProtocolHTTPS || listener.Protocol == infrav1.ELBProtocolTLS
+		if secure && listener.CertificateARN == "" {
+			allErrs = append(allErrs, field.Required(path.Child("certificateARN"), "is required for HTTPS and TLS listeners"))
+		}
+		if !secure && listener.CertificateARN != "" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("certificateARN"), "is only supported by HTTPS and TLS listeners"))
+		}
+		if !secure && listener.SSLPolicy != "" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("sslPolicy"), "is only supported by HTTPS and TLS listeners"))
+		}
+	}
+
+	return allErrs
+}
+
+func loadBalancerSupportsProtocol(isALB bool, protocol infrav1.ELBProtocol) bool {
+	switch protocol {
+	case infrav1.ELBProtocolHTTP, infrav1.ELBProtocolHTTPS:
+		return isALB
+	case infrav1.ELBProtocolTCP, infrav1.ELBProtocolTLS, infrav1.ELBProtocolUDP:
+		return !isALB
+	default:
+		return false
+	}
+}
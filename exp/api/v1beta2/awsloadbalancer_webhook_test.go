@@ -0,0 +1,235 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	utildefaulting "sigs.k8s.io/cluster-api/util/defaulting"
+)
+
+func TestAWSLoadBalancerDefault(t *testing.T) {
+	lb := &AWSLoadBalancer{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: AWSLoadBalancerSpec{
+			ClusterName: "clustername",
+			Listeners: []AWSLoadBalancerListener{
+				{Port: 80, Protocol: infrav1.ELBProtocolHTTP, TargetGroup: AWSLoadBalancerTargetGroup{Port: 30080}},
+			},
+		},
+	}
+	t.Run("for AWSLoadBalancer", utildefaulting.DefaultValidateTest(lb))
+	lb.Default()
+	g := NewWithT(t)
+	g.Expect(lb.GetLabels()[clusterv1.ClusterNameLabel]).To(Equal("clustername"))
+	g.Expect(lb.Spec.LoadBalancerType).To(Equal(infrav1.LoadBalancerTypeALB))
+	g.Expect(*lb.Spec.Scheme).To(Equal(infrav1.ELBSchemeInternetFacing))
+	g.Expect(lb.Spec.IngressCIDRBlocks).To(Equal([]string{"0.0.0.0/0"}))
+}
+
+func TestAWSLoadBalancerValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    AWSLoadBalancerSpec
+		wantErr bool
+	}{
+		{
+			name: "valid application load balancer",
+			spec: AWSLoadBalancerSpec{
+				LoadBalancerType:  infrav1.LoadBalancerTypeALB,
+				IngressCIDRBlocks: []string{"10.0.0.0/8"},
+				Listeners: []AWSLoadBalancerListener{
+					{Port: 80, Protocol: infrav1.ELBProtocolHTTP, TargetGroup: AWSLoadBalancerTargetGroup{Port: 30080}},
+					{Port: 443, Protocol: infrav1.ELBProtocolHTTPS, CertificateARN: "arn:aws:acm:us-east-1:123456789012:certificate/abc", TargetGroup: AWSLoadBalancerTargetGroup{Port: 30080}},
+				},
+			},
+		},
+		{
+			name: "valid network load balancer",
+			spec: AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				Listeners: []AWSLoadBalancerListener{
+					{Port: 53, Protocol: infrav1.ELBProtocolUDP, TargetGroup: AWSLoadBalancerTargetGroup{Port: 30053}},
+					{Port: 443, Protocol: infrav1.ELBProtocolTLS, CertificateARN: "arn:aws:acm:us-east-1:123456789012:certificate/abc", TargetGroup: AWSLoadBalancerTargetGroup{Port: 30443}},
+				},
+			},
+		},
+		{
+			name: "no listeners",
+			spec: AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeALB,
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate listener ports",
+			spec: AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeALB,
+				Listeners: []AWSLoadBalancerListener{
+					{Port: 80, Protocol: infrav1.ELBProtocolHTTP, TargetGroup: AWSLoadBalancerTargetGroup{Port: 30080}},
+					{Port: 80, Protocol: infrav1.ELBProtocolHTTP, TargetGroup: AWSLoadBalancerTargetGroup{Port: 30081}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "TCP listener on an application load balancer",
+			spec: AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeALB,
+				Listeners: []AWSLoadBalancerListener{
+					{Port: 80, Protocol: infrav1.ELBProtocolTCP, TargetGroup: AWSLoadBalancerTargetGroup{Port: 30080}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "HTTP target group on a network load balancer",
+			spec: AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				Listeners: []AWSLoadBalancerListener{
+					{Port: 80, Protocol: infrav1.ELBProtocolTCP, TargetGroup: AWSLoadBalancerTargetGroup{Port: 30080, Protocol: infrav1.ELBProtocolHTTP}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "HTTPS listener without certificate",
+			spec: AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeALB,
+				Listeners: []AWSLoadBalancerListener{
+					{Port: 443, Protocol: infrav1.ELBProtocolHTTPS, TargetGroup: AWSLoadBalancerTargetGroup{Port: 30080}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "certificate on an HTTP listener",
+			spec: AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeALB,
+				Listeners: []AWSLoadBalancerListener{
+					{Port: 80, Protocol: infrav1.ELBProtocolHTTP, CertificateARN: "arn:aws:acm:us-east-1:123456789012:certificate/abc", TargetGroup: AWSLoadBalancerTargetGroup{Port: 30080}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ingress CIDR blocks on a network load balancer",
+			spec: AWSLoadBalancerSpec{
+				LoadBalancerType:  infrav1.LoadBalancerTypeNLB,
+				IngressCIDRBlocks: []string{"0.0.0.0/0"},
+				Listeners: []AWSLoadBalancerListener{
+					{Port: 80, Protocol: infrav1.ELBProtocolTCP, TargetGroup: AWSLoadBalancerTargetGroup{Port: 30080}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid ingress CIDR block",
+			spec: AWSLoadBalancerSpec{
+				LoadBalancerType:  infrav1.LoadBalancerTypeALB,
+				IngressCIDRBlocks: []string{"10.0.0.0"},
+				Listeners: []AWSLoadBalancerListener{
+					{Port: 80, Protocol: infrav1.ELBProtocolHTTP, TargetGroup: AWSLoadBalancerTargetGroup{Port: 30080}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			tt.spec.ClusterName = "clustername"
+			lb := &AWSLoadBalancer{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec:       tt.spec,
+			}
+			err := lb.ValidateCreate()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestAWSLoadBalancerValidateUpdate(t *testing.T) {
+	newLB := func(mutate func(*AWSLoadBalancer)) *AWSLoadBalancer {
+		lb := &AWSLoadBalancer{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Spec: AWSLoadBalancerSpec{
+				ClusterName:      "clustername",
+				LoadBalancerType: infrav1.LoadBalancerTypeALB,
+				Scheme:           (*infrav1.ELBScheme)(pointer.String("internet-facing")),
+				Subnets:          []string{"subnet-1", "subnet-2"},
+				Listeners: []AWSLoadBalancerListener{
+					{Port: 80, Protocol: infrav1.ELBProtocolHTTP, TargetGroup: AWSLoadBalancerTargetGroup{Port: 30080}},
+				},
+			},
+		}
+		if mutate != nil {
+			mutate(lb)
+		}
+		return lb
+	}
+
+	tests := []struct {
+		name    string
+		new     *AWSLoadBalancer
+		wantErr bool
+	}{
+		{
+			name: "listeners and machine pools can change",
+			new: newLB(func(lb *AWSLoadBalancer) {
+				lb.Spec.Listeners[0].Port = 8080
+				lb.Spec.Listeners[0].TargetGroup.MachinePools = []string{"pool-1"}
+			}),
+		},
+		{
+			name:    "cluster name is immutable",
+			new:     newLB(func(lb *AWSLoadBalancer) { lb.Spec.ClusterName = "other" }),
+			wantErr: true,
+		},
+		{
+			name:    "scheme is immutable",
+			new:     newLB(func(lb *AWSLoadBalancer) { lb.Spec.Scheme = (*infrav1.ELBScheme)(pointer.String("internal")) }),
+			wantErr: true,
+		},
+		{
+			name:    "subnets are immutable",
+			new:     newLB(func(lb *AWSLoadBalancer) { lb.Spec.Subnets = []string{"subnet-1"} }),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tt.new.ValidateUpdate(newLB(nil))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
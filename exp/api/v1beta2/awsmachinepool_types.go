@@ -18,7 +18,9 @@ package v1beta2
 
 import (
 	"reflect"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -56,6 +58,17 @@ type AWSMachinePoolSpec struct {
 	// +optional
 	Subnets []infrav1.AWSResourceReference `json:"subnets,omitempty"`
 
+	// FilterSubnetsByFailureDomains restricts the subnets selected by Subnets to the availability zones of
+	// AvailabilityZones or, if that is empty, to the failure domains of the MachinePool. Subnets are used as
+	// is by default.
+	// +optional
+	FilterSubnetsByFailureDomains bool `json:"filterSubnetsByFailureDomains,omitempty"`
+
+	// AvailabilityZoneDistribution controls whether the ASG rebalances its instances across its availability
+	// zones, e.g. after subnets are added to or removed from the pool.
+	// +optional
+	AvailabilityZoneDistribution *AvailabilityZoneDistribution `json:"availabilityZoneDistribution,omitempty"`
+
 	// AdditionalTags is an optional set of tags to add to an instance, in addition to the ones added by default by the
 	// AWS provider.
 	// +optional
@@ -89,6 +102,333 @@ type AWSMachinePoolSpec struct {
 	// SuspendProcesses defines a list of processes to suspend for the given ASG. This is constantly reconciled.
 	// If a process is removed from this list it will automatically be resumed.
 	SuspendProcesses *SuspendProcessesTypes `json:"suspendProcesses,omitempty"`
+
+	// AvailabilityZoneFailurePolicy temporarily removes the subnets of an availability zone from the ASG
+	// when instances repeatedly fail to launch there for lack of capacity, so that scale-ups continue in
+	// the other availability zones.
+	// +optional
+	AvailabilityZoneFailurePolicy *AvailabilityZoneFailurePolicy `json:"availabilityZoneFailurePolicy,omitempty"`
+
+	// MetricsCollection enables the collection of the group metrics of the ASG in CloudWatch, e.g.
+	// GroupDesiredCapacity or GroupInServiceInstances. This is constantly reconciled: metrics removed
+	// from the list, or all metrics if MetricsCollection is removed, are disabled.
+	// +optional
+	MetricsCollection *MetricsCollection `json:"metricsCollection,omitempty"`
+
+	// ScalingPolicies are the scaling policies of the ASG, which scale it on CloudWatch metrics instead
+	// of the cluster-autoscaler. The MachinePool must then let the ASG manage its replicas, with the
+	// cluster.x-k8s.io/replicas-managed-by annotation set to external-autoscaler. This is constantly
+	// reconciled: policies removed from the list are deleted, along with their alarms.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	ScalingPolicies []ScalingPolicy `json:"scalingPolicies,omitempty"`
+}
+
+// ScalingPolicy is a scaling policy of an ASG. Exactly one of TargetTracking and StepScaling must be set.
+type ScalingPolicy struct {
+	// Name is the name of the policy, unique within the ASG.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=200
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.-]+$`
+	Name string `json:"name"`
+
+	// TargetTracking keeps a metric of the ASG close to a target value.
+	// +optional
+	TargetTracking *TargetTrackingScalingPolicy `json:"targetTracking,omitempty"`
+
+	// StepScaling scales the ASG by steps when a CloudWatch alarm created for the policy fires.
+	// +optional
+	StepScaling *StepScalingPolicy `json:"stepScaling,omitempty"`
+}
+
+// PredefinedMetricType is a metric of an ASG predefined by Amazon EC2 Auto Scaling for target tracking.
+// +kubebuilder:validation:Enum=ASGAverageCPUUtilization;ASGAverageNetworkIn;ASGAverageNetworkOut;ALBRequestCountPerTarget
+type PredefinedMetricType string
+
+const (
+	// PredefinedMetricTypeASGAverageCPUUtilization is the average CPU utilization of the ASG, in percent.
+	PredefinedMetricTypeASGAverageCPUUtilization = PredefinedMetricType("ASGAverageCPUUtilization")
+	// PredefinedMetricTypeASGAverageNetworkIn is the average number of bytes received by an instance of the ASG.
+	PredefinedMetricTypeASGAverageNetworkIn = PredefinedMetricType("ASGAverageNetworkIn")
+	// PredefinedMetricTypeASGAverageNetworkOut is the average number of bytes sent by an instance of the ASG.
+	PredefinedMetricTypeASGAverageNetworkOut = PredefinedMetricType("ASGAverageNetworkOut")
+	// PredefinedMetricTypeALBRequestCountPerTarget is the number of requests completed per target of an
+	// application load balancer target group.
+	PredefinedMetricTypeALBRequestCountPerTarget = PredefinedMetricType("ALBRequestCountPerTarget")
+)
+
+// TargetTrackingScalingPolicy scales an ASG to keep a predefined metric close to a target value.
+type TargetTrackingScalingPolicy struct {
+	// PredefinedMetric is the metric tracked.
+	PredefinedMetric PredefinedMetricType `json:"predefinedMetric"`
+
+	// ResourceLabel identifies the target group of the ALBRequestCountPerTarget metric, in the format
+	// app/<load-balancer-name>/<load-balancer-id>/targetgroup/<target-group-name>/<target-group-id>.
+	// It is required for ALBRequestCountPerTarget, and must not be set for the other metrics.
+	// +kubebuilder:validation:MaxLength=1023
+	// +optional
+	ResourceLabel string `json:"resourceLabel,omitempty"`
+
+	// TargetValue is the value of the metric the ASG is scaled to keep.
+	// +kubebuilder:validation:Minimum=1
+	TargetValue int64 `json:"targetValue"`
+
+	// DisableScaleIn prevents the policy from scaling the ASG in, e.g. to scale it in with another policy.
+	// +optional
+	DisableScaleIn bool `json:"disableScaleIn,omitempty"`
+
+	// EstimatedInstanceWarmup is the time, in seconds, until a new instance contributes to the metric.
+	// Defaults to the default instance warmup, or the default cool down, of the ASG.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	EstimatedInstanceWarmup *int64 `json:"estimatedInstanceWarmup,omitempty"`
+}
+
+// ScalingAdjustmentType is how the scaling adjustment of a step scaling policy changes the capacity of an ASG.
+// +kubebuilder:validation:Enum=ChangeInCapacity;ExactCapacity;PercentChangeInCapacity
+type ScalingAdjustmentType string
+
+const (
+	// ScalingAdjustmentTypeChangeInCapacity adds the scaling adjustment to the capacity.
+	ScalingAdjustmentTypeChangeInCapacity = ScalingAdjustmentType("ChangeInCapacity")
+	// ScalingAdjustmentTypeExactCapacity sets the capacity to the scaling adjustment.
+	ScalingAdjustmentTypeExactCapacity = ScalingAdjustmentType("ExactCapacity")
+	// ScalingAdjustmentTypePercentChangeInCapacity adds the scaling adjustment, in percent of the capacity, to the capacity.
+	ScalingAdjustmentTypePercentChangeInCapacity = ScalingAdjustmentType("PercentChangeInCapacity")
+)
+
+// StepScalingPolicy scales an ASG by steps, depending on how far the metric of its alarm is past the threshold.
+type StepScalingPolicy struct {
+	// AdjustmentType is how the scaling adjustments change the capacity of the ASG.
+	AdjustmentType ScalingAdjustmentType `json:"adjustmentType"`
+
+	// StepAdjustments are the steps of the policy. Their intervals must not overlap.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	StepAdjustments []StepAdjustment `json:"stepAdjustments"`
+
+	// EstimatedInstanceWarmup is the time, in seconds, until a new instance contributes to the metric.
+	// Defaults to the default instance warmup, or the default cool down, of the ASG.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	EstimatedInstanceWarmup *int64 `json:"estimatedInstanceWarmup,omitempty"`
+
+	// Alarm is the CloudWatch alarm triggering the policy. It is created for the policy, on a metric of the ASG.
+	Alarm ScalingAlarm `json:"alarm"`
+}
+
+// StepAdjustment is a step of a step scaling policy. The bounds of its interval are relative to the threshold
+// of the alarm of the policy: the lower bound is inclusive and the upper bound exclusive for a breached alarm
+// whose threshold is a lower bound, and the other way around otherwise. Unset bounds are infinite.
+type StepAdjustment struct {
+	// MetricIntervalLowerBound is the lower bound of the interval of the step.
+	// +optional
+	MetricIntervalLowerBound *int64 `json:"metricIntervalLowerBound,omitempty"`
+
+	// MetricIntervalUpperBound is the upper bound of the interval of the step.
+	// +optional
+	MetricIntervalUpperBound *int64 `json:"metricIntervalUpperBound,omitempty"`
+
+	// ScalingAdjustment is the change of the capacity of the ASG, following the adjustment type of the policy.
+	// Positive values scale the ASG out, and negative values scale it in.
+	ScalingAdjustment int64 `json:"scalingAdjustment"`
+}
+
+// ScalingAlarm is the CloudWatch alarm of a step scaling policy, on a metric of the instances of its ASG.
+type ScalingAlarm struct {
+	// Namespace is the namespace of the metric. Defaults to AWS/EC2.
+	// +kubebuilder:default="AWS/EC2"
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// MetricName is the name of the metric, e.g. CPUUtilization. Its AutoScalingGroupName dimension is the ASG.
+	// +kubebuilder:validation:MinLength=1
+	MetricName string `json:"metricName"`
+
+	// Statistic is the statistic of the metric compared to the threshold. Defaults to Average.
+	// +kubebuilder:default=Average
+	// +kubebuilder:validation:Enum=Average;Sum;Minimum;Maximum;SampleCount
+	// +optional
+	Statistic string `json:"statistic,omitempty"`
+
+	// ComparisonOperator is how the statistic is compared to the threshold.
+	// +kubebuilder:validation:Enum=GreaterThanOrEqualToThreshold;GreaterThanThreshold;LessThanThreshold;LessThanOrEqualToThreshold
+	ComparisonOperator string `json:"comparisonOperator"`
+
+	// Threshold is the value the statistic is compared to.
+	Threshold int64 `json:"threshold"`
+
+	// Period is the length, in seconds, of the periods over which the statistic is computed. Defaults to 60.
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	Period int64 `json:"period,omitempty"`
+
+	// EvaluationPeriods is the number of periods the statistic must breach the threshold for the alarm to fire.
+	// Defaults to 1.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	EvaluationPeriods int64 `json:"evaluationPeriods,omitempty"`
+}
+
+// ScalingPolicyStatus is the status of a scaling policy of an ASG.
+type ScalingPolicyStatus struct {
+	// Name is the name of the policy.
+	Name string `json:"name"`
+
+	// ARN is the ARN of the policy.
+	ARN string `json:"arn"`
+
+	// AlarmARNs are the ARNs of the CloudWatch alarms triggering the policy. The alarms of target tracking
+	// policies are managed by Amazon EC2 Auto Scaling.
+	// +optional
+	AlarmARNs []string `json:"alarmARNs,omitempty"`
+}
+
+// AvailabilityZoneDistributionPolicy defines how an ASG keeps its instances spread across its availability zones.
+type AvailabilityZoneDistributionPolicy string
+
+const (
+	// AvailabilityZoneDistributionPolicyBalanced lets the ASG launch and terminate instances to rebalance its
+	// availability zones whenever they become unbalanced.
+	AvailabilityZoneDistributionPolicyBalanced = AvailabilityZoneDistributionPolicy("Balanced")
+
+	// AvailabilityZoneDistributionPolicyBestEffort suspends the AZRebalance process of the ASG: launches still
+	// favour the availability zones with the fewest instances, but running instances are never replaced to
+	// rebalance the zones.
+	AvailabilityZoneDistributionPolicyBestEffort = AvailabilityZoneDistributionPolicy("BestEffort")
+)
+
+// AvailabilityZoneDistribution defines how the instances of an ASG are distributed across its availability zones.
+type AvailabilityZoneDistribution struct {
+	// Policy is the distribution policy of the ASG, either Balanced or BestEffort. Defaults to Balanced.
+	// +kubebuilder:validation:Enum=Balanced;BestEffort
+	// +kubebuilder:default=Balanced
+	// +optional
+	Policy AvailabilityZoneDistributionPolicy `json:"policy,omitempty"`
+}
+
+// SuspendsAZRebalance reports whether the distribution requires the AZRebalance process of the ASG to be suspended.
+func (d *AvailabilityZoneDistribution) SuspendsAZRebalance() bool {
+	return d != nil && d.Policy == AvailabilityZoneDistributionPolicyBestEffort
+}
+
+// AvailabilityZoneFailurePolicy defines when an availability zone is removed from an ASG, and for how long.
+type AvailabilityZoneFailurePolicy struct {
+	// FailureThreshold is the number of instance launches failing for lack of capacity in an availability
+	// zone within the cool-down period after which the zone is removed from the ASG. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// CoolDown is how long an availability zone is removed from the ASG for. Defaults to 15 minutes.
+	// +optional
+	CoolDown *metav1.Duration `json:"coolDown,omitempty"`
+}
+
+const (
+	// DefaultAvailabilityZoneFailureThreshold is the default AvailabilityZoneFailurePolicy.FailureThreshold.
+	DefaultAvailabilityZoneFailureThreshold = 3
+
+	// DefaultAvailabilityZoneCoolDown is the default AvailabilityZoneFailurePolicy.CoolDown.
+	DefaultAvailabilityZoneCoolDown = 15 * time.Minute
+)
+
+// FailureThresholdOrDefault returns the failure threshold of the policy, or its default if none is set.
+func (p *AvailabilityZoneFailurePolicy) FailureThresholdOrDefault() int {
+	if p.FailureThreshold == nil {
+		return DefaultAvailabilityZoneFailureThreshold
+	}
+	return int(*p.FailureThreshold)
+}
+
+// CoolDownOrDefault returns the cool-down period of the policy, or its default if none is set.
+func (p *AvailabilityZoneFailurePolicy) CoolDownOrDefault() time.Duration {
+	if p.CoolDown == nil {
+		return DefaultAvailabilityZoneCoolDown
+	}
+	return p.CoolDown.Duration
+}
+
+// SuspendedAvailabilityZone is an availability zone removed from an ASG by its AvailabilityZoneFailurePolicy.
+type SuspendedAvailabilityZone struct {
+	// Name is the name of the availability zone.
+	Name string `json:"name"`
+
+	// Until is the time the availability zone is added back to the ASG at.
+	Until metav1.Time `json:"until"`
+}
+
+// MetricsCollection defines the group metrics collected for an ASG.
+type MetricsCollection struct {
+	// Granularity is the frequency at which the metrics are collected. Only "1Minute" is supported.
+	// +kubebuilder:validation:Enum="1Minute"
+	// +kubebuilder:default="1Minute"
+	// +optional
+	Granularity string `json:"granularity,omitempty"`
+
+	// Metrics is the list of group metrics to collect. All group metrics are collected when empty.
+	// +optional
+	Metrics []ASGMetric `json:"metrics,omitempty"`
+}
+
+// ASGMetric is a group metric of an ASG.
+// +kubebuilder:validation:Enum=GroupMinSize;GroupMaxSize;GroupDesiredCapacity;GroupInServiceInstances;GroupPendingInstances;GroupStandbyInstances;GroupTerminatingInstances;GroupTotalInstances;GroupInServiceCapacity;GroupPendingCapacity;GroupStandbyCapacity;GroupTerminatingCapacity;GroupTotalCapacity;WarmPoolDesiredCapacity;WarmPoolWarmedCapacity;WarmPoolPendingCapacity;WarmPoolTerminatingCapacity;WarmPoolTotalCapacity;GroupAndWarmPoolDesiredCapacity;GroupAndWarmPoolTotalCapacity
+type ASGMetric string
+
+// DefaultMetricsCollectionGranularity is the default MetricsCollection.Granularity, and the only one supported by AWS.
+const DefaultMetricsCollectionGranularity = "1Minute"
+
+// AllASGMetrics are the group metrics collected when MetricsCollection.Metrics is empty.
+var AllASGMetrics = []ASGMetric{
+	"GroupMinSize",
+	"GroupMaxSize",
+	"GroupDesiredCapacity",
+	"GroupInServiceInstances",
+	"GroupPendingInstances",
+	"GroupStandbyInstances",
+	"GroupTerminatingInstances",
+	"GroupTotalInstances",
+	"GroupInServiceCapacity",
+	"GroupPendingCapacity",
+	"GroupStandbyCapacity",
+	"GroupTerminatingCapacity",
+	"GroupTotalCapacity",
+	"WarmPoolDesiredCapacity",
+	"WarmPoolWarmedCapacity",
+	"WarmPoolPendingCapacity",
+	"WarmPoolTerminatingCapacity",
+	"WarmPoolTotalCapacity",
+	"GroupAndWarmPoolDesiredCapacity",
+	"GroupAndWarmPoolTotalCapacity",
+}
+
+// GranularityOrDefault returns the granularity of the metrics collection, or its default if none is set.
+func (m *MetricsCollection) GranularityOrDefault() string {
+	if m.Granularity == "" {
+		return DefaultMetricsCollectionGranularity
+	}
+	return m.Granularity
+}
+
+// MetricsOrDefault returns the names of the metrics collected, or of all group metrics if none is set.
+// It returns nil if the metrics collection is not enabled.
+func (m *MetricsCollection) MetricsOrDefault() []string {
+	if m == nil {
+		return nil
+	}
+	metrics := m.Metrics
+	if len(metrics) == 0 {
+		metrics = AllASGMetrics
+	}
+	names := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		names = append(names, string(metric))
+	}
+	return names
 }
 
 // SuspendProcessesTypes contains user friendly auto-completable values for suspended process names.
@@ -160,6 +500,102 @@ type RefreshPreferences struct {
 	// during an instance refresh. The default is 90.
 	// +optional
 	MinHealthyPercentage *int64 `json:"minHealthyPercentage,omitempty"`
+
+	// RollingUpdate, when set, makes the controller replace the instances launched from an older version
+	// of the launch template itself, one availability zone at a time, instead of starting an ASG instance
+	// refresh. The nodes of the instances are cordoned and drained before the instances are terminated,
+	// so that pod disruption budgets are honored.
+	// +optional
+	RollingUpdate *MachinePoolRollingUpdate `json:"rollingUpdate,omitempty"`
+}
+
+// MachinePoolRollingUpdate defines how the instances of an AWSMachinePool are replaced by the controller.
+type MachinePoolRollingUpdate struct {
+	// MaxSurge is the number of instances launched above the replicas of the MachinePool while its
+	// instances are replaced. It is not applied beyond the maximum size of the pool, nor when the replicas
+	// are managed by an external autoscaler. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxSurge *int32 `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the number of instances that can be missing from the replicas of the MachinePool
+	// while its instances are replaced. Defaults to 0. When neither a surge nor an unavailable instance
+	// is possible, one instance is replaced at a time.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+
+	// DrainTimeout is how long the controller waits for the node of an instance to be drained. Once
+	// it has passed, the instance is terminated even though its node isn't drained. Defaults to 1 minute.
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+}
+
+const (
+	// DefaultRollingUpdateMaxSurge is the default MachinePoolRollingUpdate.MaxSurge.
+	DefaultRollingUpdateMaxSurge = 1
+
+	// DefaultRollingUpdateDrainTimeout is the default MachinePoolRollingUpdate.DrainTimeout.
+	DefaultRollingUpdateDrainTimeout = time.Minute
+)
+
+// MaxSurgeOrDefault returns the maximum surge of the rolling update, or its default if none is set.
+func (u *MachinePoolRollingUpdate) MaxSurgeOrDefault() int32 {
+	if u.MaxSurge == nil {
+		return DefaultRollingUpdateMaxSurge
+	}
+	return *u.MaxSurge
+}
+
+// MaxUnavailableOrDefault returns the maximum number of unavailable instances of the rolling update,
+// or its default if none is set.
+func (u *MachinePoolRollingUpdate) MaxUnavailableOrDefault() int32 {
+	if u.MaxUnavailable == nil {
+		return 0
+	}
+	return *u.MaxUnavailable
+}
+
+// DrainTimeoutOrDefault returns the drain timeout of the rolling update, or its default if none is set.
+func (u *MachinePoolRollingUpdate) DrainTimeoutOrDefault() time.Duration {
+	if u.DrainTimeout == nil {
+		return DefaultRollingUpdateDrainTimeout
+	}
+	return u.DrainTimeout.Duration
+}
+
+// RollingUpdateStatus reports on the replacement of the instances of an AWSMachinePool by the controller.
+type RollingUpdateStatus struct {
+	// AvailabilityZone is the availability zone whose instances are being replaced.
+	AvailabilityZone string `json:"availabilityZone"`
+
+	// Surge is the number of instances the desired capacity of the ASG is raised by during the update.
+	// +optional
+	Surge int32 `json:"surge,omitempty"`
+
+	// OutdatedInstances is the number of instances of the ASG not launched from the latest version of
+	// the launch template.
+	OutdatedInstances int32 `json:"outdatedInstances"`
+
+	// DrainingInstances are the outdated instances whose nodes are being drained before they are terminated.
+	// +optional
+	DrainingInstances []DrainingInstance `json:"drainingInstances,omitempty"`
+}
+
+// DrainingInstance is an outdated instance whose node is being drained.
+type DrainingInstance struct {
+	// ID is the ID of the instance.
+	ID string `json:"id"`
+
+	// StartTime is when the controller started draining the node of the instance.
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// RollingUpdateEnabled reports whether the instances of the pool are replaced by the controller
+// rather than by an ASG instance refresh.
+func (r *AWSMachinePool) RollingUpdateEnabled() bool {
+	prefs := r.Spec.RefreshPreferences
+	return prefs != nil && !prefs.Disable && prefs.RollingUpdate != nil
 }
 
 // AWSMachinePoolStatus defines the observed state of AWSMachinePool.
@@ -187,6 +623,12 @@ type AWSMachinePoolStatus struct {
 	// +optional
 	LaunchTemplateVersion *string `json:"launchTemplateVersion,omitempty"`
 
+	// Capacity is the resource capacity of the instances of the pool, resolved from their instance type.
+	// It is used by the cluster-autoscaler to scale the pool up from zero, see
+	// https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20210310-opt-in-autoscaling-from-zero.md
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -226,6 +668,20 @@ type AWSMachinePoolStatus struct {
 	FailureMessage *string `json:"failureMessage,omitempty"`
 
 	ASGStatus *ASGStatus `json:"asgStatus,omitempty"`
+
+	// SuspendedAvailabilityZones are the availability zones currently removed from the ASG by its
+	// AvailabilityZoneFailurePolicy.
+	// +optional
+	SuspendedAvailabilityZones []SuspendedAvailabilityZone `json:"suspendedAvailabilityZones,omitempty"`
+
+	// RollingUpdate reports on the replacement of the instances of the pool by the controller, while
+	// one is in progress.
+	// +optional
+	RollingUpdate *RollingUpdateStatus `json:"rollingUpdate,omitempty"`
+
+	// ScalingPolicies are the scaling policies created for the ASG.
+	// +optional
+	ScalingPolicies []ScalingPolicyStatus `json:"scalingPolicies,omitempty"`
 }
 
 // AWSMachinePoolInstanceStatus defines the status of the AWSMachinePoolInstance.
@@ -17,13 +17,19 @@ limitations under the License.
 package v1beta2
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -32,17 +38,87 @@ import (
 var log = ctrl.Log.WithName("awsmachinepool-resource")
 
 // SetupWebhookWithManager will setup the webhooks for the AWSMachinePool.
-func (r *AWSMachinePool) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (r *AWSMachinePoolWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
+		For(&AWSMachinePool{}).
+		WithValidator(r).
 		Complete()
 }
 
+// AWSMachinePoolWebhook implements the validation webhook for AWSMachinePool. It runs the validation
+// of the AWSMachinePool itself, then the checks reading the cluster of the machine pool, such as its
+// security profile and volume encryption policy.
+// +kubebuilder:object:generate=false
+type AWSMachinePoolWebhook struct {
+	// Client reads the clusters of the machine pools. It defaults to the client of the manager.
+	Client client.Reader
+
+	// EnforceVolumeEncryption rejects the machine pools of every cluster with a root volume that isn't
+	// explicitly encrypted. It is set from the --enforce-volume-encryption flag.
+	EnforceVolumeEncryption bool
+}
+
 // +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awsmachinepool,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,versions=v1beta2,name=validation.awsmachinepool.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta2-awsmachinepool,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,versions=v1beta2,name=default.awsmachinepool.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
 var _ webhook.Defaulter = &AWSMachinePool{}
 var _ webhook.Validator = &AWSMachinePool{}
+var _ webhook.CustomValidator = &AWSMachinePoolWebhook{}
+
+// ValidateCreate validates the AWSMachinePool, then checks it against the security profile and the volume
+// encryption policy of its cluster.
+func (r *AWSMachinePoolWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	pool, ok := obj.(*AWSMachinePool)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an AWSMachinePool but got a %T", obj))
+	}
+
+	if err := pool.ValidateCreate(); err != nil {
+		return err
+	}
+	return r.validateCluster(ctx, pool)
+}
+
+// ValidateUpdate validates the AWSMachinePool, then checks it against the security profile and the volume
+// encryption policy of its cluster.
+func (r *AWSMachinePoolWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	pool, ok := newObj.(*AWSMachinePool)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an AWSMachinePool but got a %T", newObj))
+	}
+
+	if err := pool.ValidateUpdate(oldObj); err != nil {
+		return err
+	}
+	return r.validateCluster(ctx, pool)
+}
+
+// ValidateDelete allows every deletion.
+func (r *AWSMachinePoolWebhook) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+// validateCluster checks the launch template of the pool against the security profile and the volume
+// encryption policy of its cluster. Only the root volume can be checked against the profile: the launch
+// template doesn't expose the instance metadata options, which are set from the profile when the launch
+// template is created.
+func (r *AWSMachinePoolWebhook) validateCluster(ctx context.Context, pool *AWSMachinePool) error {
+	profile, err := v1beta2.ClusterSecurityProfile(ctx, r.Client, pool)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	ltPath := field.NewPath("spec", "awsLaunchTemplate")
+	allErrs := profile.ValidateVolume(pool.Spec.AWSLaunchTemplate.RootVolume, ltPath.Child("rootVolume"))
+	allErrs = append(allErrs, validateVolumeEncryption(ctx, r.Client, r.EnforceVolumeEncryption, pool, &pool.Spec.AWSLaunchTemplate, ltPath)...)
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(pool.GroupVersionKind().GroupKind(), pool.Name, allErrs)
+}
 
 func (r *AWSMachinePool) validateDefaultCoolDown() field.ErrorList {
 	var allErrs field.ErrorList
@@ -54,6 +130,128 @@ func (r *AWSMachinePool) validateDefaultCoolDown() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSMachinePool) validateAvailabilityZoneFailurePolicy() field.ErrorList {
+	var allErrs field.ErrorList
+
+	policy := r.Spec.AvailabilityZoneFailurePolicy
+	if policy == nil {
+		return allErrs
+	}
+
+	if policy.CoolDown != nil && policy.CoolDown.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "availabilityZoneFailurePolicy", "coolDown"), policy.CoolDown.Duration.String(), "must be greater than zero"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateRollingUpdate() field.ErrorList {
+	var allErrs field.ErrorList
+
+	prefs := r.Spec.RefreshPreferences
+	if prefs == nil || prefs.RollingUpdate == nil {
+		return allErrs
+	}
+
+	rollingUpdatePath := field.NewPath("spec", "refreshPreferences", "rollingUpdate")
+	if prefs.Disable {
+		allErrs = append(allErrs, field.Forbidden(rollingUpdatePath, "cannot be set when refreshPreferences.disable is true"))
+	}
+	if timeout := prefs.RollingUpdate.DrainTimeout; timeout != nil && timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(rollingUpdatePath.Child("drainTimeout"), timeout.Duration.String(), "must be greater than zero"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateAvailabilityZoneDistribution() field.ErrorList {
+	var allErrs field.ErrorList
+
+	distribution := r.Spec.AvailabilityZoneDistribution
+	if distribution == nil || distribution.Policy != AvailabilityZoneDistributionPolicyBalanced {
+		return allErrs
+	}
+
+	for _, process := range r.Spec.SuspendProcesses.DeepCopy().ConvertSetValuesToStringSlice() {
+		if process == "AZRebalance" {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "availabilityZoneDistribution", "policy"), "the Balanced policy requires the AZRebalance process not to be suspended"))
+			break
+		}
+	}
+
+	return allErrs
+}
+
+// validateScalingPolicies checks that each scaling policy is either a target tracking or a step scaling policy,
+// and that the intervals of the steps of step scaling policies don't overlap.
+func (r *AWSMachinePool) validateScalingPolicies() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, policy := range r.Spec.ScalingPolicies {
+		policyPath := field.NewPath("spec", "scalingPolicies").Index(i)
+		if (policy.TargetTracking == nil) == (policy.StepScaling == nil) {
+			allErrs = append(allErrs, field.Invalid(policyPath, policy.Name, "exactly one of targetTracking and stepScaling must be set"))
+			continue
+		}
+
+		if tt := policy.TargetTracking; tt != nil {
+			alb := tt.PredefinedMetric == PredefinedMetricTypeALBRequestCountPerTarget
+			if alb && tt.ResourceLabel == "" {
+				allErrs = append(allErrs, field.Required(policyPath.Child("targetTracking", "resourceLabel"), "is required for the ALBRequestCountPerTarget metric"))
+			}
+			if !alb && tt.ResourceLabel != "" {
+				allErrs = append(allErrs, field.Forbidden(policyPath.Child("targetTracking", "resourceLabel"), "can only be set for the ALBRequestCountPerTarget metric"))
+			}
+		}
+
+		if ss := policy.StepScaling; ss != nil {
+			allErrs = append(allErrs, validateStepAdjustments(ss, policyPath.Child("stepScaling", "stepAdjustments"))...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateStepAdjustments checks that the intervals of the steps of a step scaling policy are not empty and
+// don't overlap, and that exact capacities are not negative.
+func validateStepAdjustments(policy *StepScalingPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	steps := make([]int, 0, len(policy.StepAdjustments))
+	for i, step := range policy.StepAdjustments {
+		if step.MetricIntervalLowerBound != nil && step.MetricIntervalUpperBound != nil && *step.MetricIntervalLowerBound >= *step.MetricIntervalUpperBound {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("metricIntervalUpperBound"), *step.MetricIntervalUpperBound, "must be greater than metricIntervalLowerBound"))
+			continue
+		}
+		if policy.AdjustmentType == ScalingAdjustmentTypeExactCapacity && step.ScalingAdjustment < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("scalingAdjustment"), step.ScalingAdjustment, "must not be negative for the ExactCapacity adjustment type"))
+		}
+		steps = append(steps, i)
+	}
+
+	// Unset lower bounds are minus infinity, and unset upper bounds plus infinity.
+	lower := func(i int) int64 {
+		if b := policy.StepAdjustments[i].MetricIntervalLowerBound; b != nil {
+			return *b
+		}
+		return math.MinInt64
+	}
+	upper := func(i int) int64 {
+		if b := policy.StepAdjustments[i].MetricIntervalUpperBound; b != nil {
+			return *b
+		}
+		return math.MaxInt64
+	}
+	sort.SliceStable(steps, func(a, b int) bool { return lower(steps[a]) < lower(steps[b]) })
+	for j := 1; j < len(steps); j++ {
+		if lower(steps[j]) < upper(steps[j-1]) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(steps[j]), fmt.Sprintf("its interval overlaps the interval of step %d", steps[j-1])))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSMachinePool) validateRootVolume() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -74,6 +272,8 @@ func (r *AWSMachinePool) validateRootVolume() field.ErrorList {
 		}
 	}
 
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.RootVolume.ValidatePerformance(field.NewPath("spec", "awsLaunchTemplate", "rootVolume"))...)
+
 	if r.Spec.AWSLaunchTemplate.RootVolume.DeviceName != "" {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
@@ -108,6 +308,76 @@ func (r *AWSMachinePool) validateAdditionalSecurityGroups() field.ErrorList {
 	return allErrs
 }
 
+// validateCreditSpecification checks that the instance types of the launch template and of the mixed
+// instances policy of pools with a credit specification are burstable performance instance types.
+func (r *AWSMachinePool) validateCreditSpecification() field.ErrorList {
+	creditSpecification := r.Spec.AWSLaunchTemplate.CreditSpecification
+	path := field.NewPath("spec", "awsLaunchTemplate", "creditSpecification")
+
+	allErrs := creditSpecification.Validate(r.Spec.AWSLaunchTemplate.InstanceType, path)
+	if r.Spec.MixedInstancesPolicy != nil {
+		for _, override := range r.Spec.MixedInstancesPolicy.Overrides {
+			allErrs = append(allErrs, creditSpecification.Validate(override.InstanceType, path)...)
+		}
+	}
+	return allErrs
+}
+
+// validateAMIAccelerator checks that the AMI lookup of the launch template can find an AMI able to run the
+// instance types of the launch template and of the mixed instances policy.
+func (r *AWSMachinePool) validateAMIAccelerator() field.ErrorList {
+	ami := &r.Spec.AWSLaunchTemplate.AMI
+	path := field.NewPath("spec", "awsLaunchTemplate", "ami")
+
+	allErrs := ami.ValidateAccelerator(r.Spec.AWSLaunchTemplate.InstanceType, path)
+	if r.Spec.MixedInstancesPolicy != nil {
+		for _, override := range r.Spec.MixedInstancesPolicy.Overrides {
+			allErrs = append(allErrs, ami.ValidateAccelerator(override.InstanceType, path)...)
+		}
+	}
+	return allErrs
+}
+
+// validateCapacityReservation checks that pools running in Capacity Blocks target their reservation with a
+// single instance type, as instances in a Capacity Block can't be Spot instances nor of other types.
+func (r *AWSMachinePool) validateCapacityReservation() field.ErrorList {
+	var allErrs field.ErrorList
+
+	lt := r.Spec.AWSLaunchTemplate
+	if lt.MarketType != MarketTypeCapacityBlock {
+		return allErrs
+	}
+
+	path := field.NewPath("spec", "awsLaunchTemplate")
+	if lt.CapacityReservationID == nil {
+		allErrs = append(allErrs, field.Required(path.Child("capacityReservationID"), "is required if marketType is "+string(MarketTypeCapacityBlock)))
+	}
+	if lt.InstanceType == "" {
+		allErrs = append(allErrs, field.Required(path.Child("instanceType"), "is required if marketType is "+string(MarketTypeCapacityBlock)))
+	}
+	if lt.SpotMarketOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("spotMarketOptions"), "cannot be set if marketType is "+string(MarketTypeCapacityBlock)))
+	}
+	if r.Spec.MixedInstancesPolicy != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "mixedInstancesPolicy"), "cannot be set if marketType is "+string(MarketTypeCapacityBlock)))
+	}
+
+	return allErrs
+}
+
+// validateVolumeEncryption rejects the unencrypted root volume of the launch template of obj when
+// volume encryption is enforced for obj.
+func validateVolumeEncryption(ctx context.Context, c client.Reader, enforceAll bool, obj metav1.Object, lt *AWSLaunchTemplate, path *field.Path) field.ErrorList {
+	enforced, err := v1beta2.VolumeEncryptionEnforced(ctx, c, enforceAll, obj)
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	if !enforced {
+		return nil
+	}
+	return v1beta2.ValidateEncryptedRootVolume(lt.RootVolume, path.Child("rootVolume"))
+}
+
 // ValidateCreate will do any extra validation when creating a AWSMachinePool.
 func (r *AWSMachinePool) ValidateCreate() error {
 	log.Info("AWSMachinePool validate create", "machine-pool", klog.KObj(r))
@@ -115,10 +385,19 @@ func (r *AWSMachinePool) ValidateCreate() error {
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, r.validateDefaultCoolDown()...)
+	allErrs = append(allErrs, r.validateAvailabilityZoneFailurePolicy()...)
+	allErrs = append(allErrs, r.validateRollingUpdate()...)
+	allErrs = append(allErrs, r.validateAvailabilityZoneDistribution()...)
+	allErrs = append(allErrs, r.validateScalingPolicies()...)
 	allErrs = append(allErrs, r.validateRootVolume()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.Bottlerocket.Validate(field.NewPath("spec", "awsLaunchTemplate", "bottlerocket"))...)
+	allErrs = append(allErrs, v1beta2.ValidateBootstrapCommands(r.Spec.AWSLaunchTemplate.PreBootstrapCommands, r.Spec.AWSLaunchTemplate.PostBootstrapCommands, nil, r.Spec.AWSLaunchTemplate.Bottlerocket, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, r.validateCapacityReservation()...)
+	allErrs = append(allErrs, r.validateCreditSpecification()...)
+	allErrs = append(allErrs, r.validateAMIAccelerator()...)
 
 	if len(allErrs) == 0 {
 		return nil
@@ -136,9 +415,18 @@ func (r *AWSMachinePool) ValidateUpdate(old runtime.Object) error {
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, r.validateDefaultCoolDown()...)
+	allErrs = append(allErrs, r.validateAvailabilityZoneFailurePolicy()...)
+	allErrs = append(allErrs, r.validateRollingUpdate()...)
+	allErrs = append(allErrs, r.validateAvailabilityZoneDistribution()...)
+	allErrs = append(allErrs, r.validateScalingPolicies()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.Bottlerocket.Validate(field.NewPath("spec", "awsLaunchTemplate", "bottlerocket"))...)
+	allErrs = append(allErrs, v1beta2.ValidateBootstrapCommands(r.Spec.AWSLaunchTemplate.PreBootstrapCommands, r.Spec.AWSLaunchTemplate.PostBootstrapCommands, nil, r.Spec.AWSLaunchTemplate.Bottlerocket, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, r.validateCapacityReservation()...)
+	allErrs = append(allErrs, r.validateCreditSpecification()...)
+	allErrs = append(allErrs, r.validateAMIAccelerator()...)
 
 	if len(allErrs) == 0 {
 		return nil
@@ -17,8 +17,10 @@ limitations under the License.
 package v1beta2
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
@@ -139,6 +141,275 @@ func TestAWSMachinePoolValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Should pass with an availability zone failure policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AvailabilityZoneFailurePolicy: &AvailabilityZoneFailurePolicy{
+						FailureThreshold: pointer.Int32(2),
+						CoolDown:         &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the availability zone cool-down isn't positive",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AvailabilityZoneFailurePolicy: &AvailabilityZoneFailurePolicy{
+						CoolDown: &metav1.Duration{},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with a rolling update",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						RollingUpdate: &MachinePoolRollingUpdate{
+							MaxSurge:       pointer.Int32(2),
+							MaxUnavailable: pointer.Int32(1),
+							DrainTimeout:   &metav1.Duration{Duration: 5 * time.Minute},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail with a rolling update when instance refresh is disabled",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						Disable:       true,
+						RollingUpdate: &MachinePoolRollingUpdate{},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the rolling update drain timeout isn't positive",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						RollingUpdate: &MachinePoolRollingUpdate{DrainTimeout: &metav1.Duration{}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with the BestEffort availability zone distribution and AZRebalance suspended",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AvailabilityZoneDistribution: &AvailabilityZoneDistribution{Policy: AvailabilityZoneDistributionPolicyBestEffort},
+					SuspendProcesses:             &SuspendProcessesTypes{Processes: &Processes{AZRebalance: pointer.Bool(true)}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail with the Balanced availability zone distribution and AZRebalance suspended",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AvailabilityZoneDistribution: &AvailabilityZoneDistribution{Policy: AvailabilityZoneDistributionPolicyBalanced},
+					SuspendProcesses:             &SuspendProcessesTypes{All: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with target tracking and step scaling policies",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []ScalingPolicy{
+						{
+							Name:           "cpu",
+							TargetTracking: &TargetTrackingScalingPolicy{PredefinedMetric: PredefinedMetricTypeASGAverageCPUUtilization, TargetValue: 50},
+						},
+						{
+							Name: "requests",
+							TargetTracking: &TargetTrackingScalingPolicy{
+								PredefinedMetric: PredefinedMetricTypeALBRequestCountPerTarget,
+								ResourceLabel:    "app/my-alb/0123456789abcdef/targetgroup/my-tg/0123456789abcdef",
+								TargetValue:      1000,
+							},
+						},
+						{
+							Name: "memory",
+							StepScaling: &StepScalingPolicy{
+								AdjustmentType: ScalingAdjustmentTypeChangeInCapacity,
+								StepAdjustments: []StepAdjustment{
+									{MetricIntervalLowerBound: aws.Int64(10), ScalingAdjustment: 2},
+									{MetricIntervalLowerBound: aws.Int64(0), MetricIntervalUpperBound: aws.Int64(10), ScalingAdjustment: 1},
+								},
+								Alarm: ScalingAlarm{MetricName: "mem_used_percent", Namespace: "CWAgent", ComparisonOperator: "GreaterThanOrEqualToThreshold", Threshold: 80},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail with a scaling policy both target tracking and step scaling",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []ScalingPolicy{{
+						Name:           "cpu",
+						TargetTracking: &TargetTrackingScalingPolicy{PredefinedMetric: PredefinedMetricTypeASGAverageCPUUtilization, TargetValue: 50},
+						StepScaling: &StepScalingPolicy{
+							AdjustmentType:  ScalingAdjustmentTypeChangeInCapacity,
+							StepAdjustments: []StepAdjustment{{ScalingAdjustment: 1}},
+						},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail with the ALBRequestCountPerTarget metric without a resource label",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []ScalingPolicy{{
+						Name:           "requests",
+						TargetTracking: &TargetTrackingScalingPolicy{PredefinedMetric: PredefinedMetricTypeALBRequestCountPerTarget, TargetValue: 1000},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail with overlapping steps",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []ScalingPolicy{{
+						Name: "cpu",
+						StepScaling: &StepScalingPolicy{
+							AdjustmentType: ScalingAdjustmentTypeChangeInCapacity,
+							StepAdjustments: []StepAdjustment{
+								{MetricIntervalLowerBound: aws.Int64(0), ScalingAdjustment: 1},
+								{MetricIntervalLowerBound: aws.Int64(10), ScalingAdjustment: 2},
+							},
+							Alarm: ScalingAlarm{MetricName: "CPUUtilization", ComparisonOperator: "GreaterThanOrEqualToThreshold", Threshold: 70},
+						},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with bootstrap commands",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PreBootstrapCommands:  []string{"update-ca-certificates"},
+						PostBootstrapCommands: []string{"systemctl enable --now agent"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail with bootstrap commands and bottlerocket",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PostBootstrapCommands: []string{"systemctl enable --now agent"},
+						Bottlerocket: &infrav1.Bottlerocket{
+							BootstrapContainerSource: "example.com/bootstrap:v1",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with a capacity block and its reservation",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:          "p5.48xlarge",
+						MarketType:            MarketTypeCapacityBlock,
+						CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail with a capacity block without a reservation",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType: "p5.48xlarge",
+						MarketType:   MarketTypeCapacityBlock,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail with a capacity block and spot market options",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:          "p5.48xlarge",
+						MarketType:            MarketTypeCapacityBlock,
+						CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+						SpotMarketOptions:     &infrav1.SpotMarketOptions{},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should succeed with a credit specification and a burstable instance type",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:        "t3.large",
+						CreditSpecification: &infrav1.CreditSpecification{CPUCredits: infrav1.CPUCreditsStandard},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail with a credit specification and a mixed instances policy override that isn't burstable",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:        "t3.large",
+						CreditSpecification: &infrav1.CreditSpecification{CPUCredits: infrav1.CPUCreditsStandard},
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "m5.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail with a capacity block and a mixed instances policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:          "p5.48xlarge",
+						MarketType:            MarketTypeCapacityBlock,
+						CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "p4d.24xlarge"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -152,6 +423,44 @@ func TestAWSMachinePoolValidateCreate(t *testing.T) {
 	}
 }
 
+func TestAWSMachinePoolValidateCreateVolumeEncryption(t *testing.T) {
+	tests := []struct {
+		name       string
+		rootVolume *infrav1.Volume
+		wantErr    bool
+	}{
+		{
+			name:       "Should succeed with an encrypted root volume",
+			rootVolume: &infrav1.Volume{Size: 8, Encrypted: aws.Bool(true)},
+		},
+		{
+			name:       "Should fail with an unencrypted root volume",
+			rootVolume: &infrav1.Volume{Size: 8},
+			wantErr:    true,
+		},
+		{
+			name:    "Should fail without a root volume",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			pool := &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{RootVolume: tt.rootVolume},
+				},
+			}
+			err := (&AWSMachinePoolWebhook{EnforceVolumeEncryption: true}).ValidateCreate(context.TODO(), pool)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
 func TestAWSMachinePoolValidateUpdate(t *testing.T) {
 	g := NewWithT(t)
 
@@ -19,6 +19,7 @@ package v1beta2
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -145,6 +146,11 @@ type AWSManagedMachinePoolSpec struct {
 	// +optional
 	UpdateConfig *UpdateConfig `json:"updateConfig,omitempty"`
 
+	// NodeRepairConfig holds the optional node auto repair configuration of the nodegroup.
+	// When unset, the node auto repair setting of the nodegroup is left unchanged.
+	// +optional
+	NodeRepairConfig *NodeRepairConfig `json:"nodeRepairConfig,omitempty"`
+
 	// AWSLaunchTemplate specifies the launch template to use to create the managed node group.
 	// If AWSLaunchTemplate is specified, certain node group configuraions outside of launch template
 	// are prohibited (https://docs.aws.amazon.com/eks/latest/userguide/launch-templates.html).
@@ -190,6 +196,17 @@ type AWSManagedMachinePoolStatus struct {
 	// +optional
 	LaunchTemplateVersion *string `json:"launchTemplateVersion,omitempty"`
 
+	// Capacity is the resource capacity of the instances of the pool, resolved from their instance type.
+	// It is used by the cluster-autoscaler to scale the pool up from zero, see
+	// https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20210310-opt-in-autoscaling-from-zero.md
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// ConfigUpdate is the most recent update of the labels, taints, scaling or update configuration
+	// of the nodegroup. No new configuration update is started while it is in progress.
+	// +optional
+	ConfigUpdate *NodegroupUpdateStatus `json:"configUpdate,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the MachinePool and will contain a succinct value suitable
 	// for machine interpretation.
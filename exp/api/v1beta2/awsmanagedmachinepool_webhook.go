@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -28,8 +29,10 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks"
 )
 
@@ -41,17 +44,82 @@ const (
 var mmpLog = ctrl.Log.WithName("awsmanagedmachinepool-resource")
 
 // SetupWebhookWithManager will setup the webhooks for the AWSManagedMachinePool.
-func (r *AWSManagedMachinePool) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (r *AWSManagedMachinePoolWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
+		For(&AWSManagedMachinePool{}).
+		WithValidator(r).
 		Complete()
 }
 
+// AWSManagedMachinePoolWebhook implements the validation webhook for AWSManagedMachinePool. It runs the
+// validation of the AWSManagedMachinePool itself, then checks its launch template against the volume
+// encryption policy of its cluster.
+// +kubebuilder:object:generate=false
+type AWSManagedMachinePoolWebhook struct {
+	// Client reads the clusters of the machine pools. It defaults to the client of the manager.
+	Client client.Reader
+
+	// EnforceVolumeEncryption rejects the launch templates of every cluster with a root volume that
+	// isn't explicitly encrypted. It is set from the --enforce-volume-encryption flag.
+	EnforceVolumeEncryption bool
+}
+
 // +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awsmanagedmachinepool,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsmanagedmachinepools,versions=v1beta2,name=validation.awsmanagedmachinepool.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta2-awsmanagedmachinepool,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsmanagedmachinepools,versions=v1beta2,name=default.awsmanagedmachinepool.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
 var _ webhook.Defaulter = &AWSManagedMachinePool{}
 var _ webhook.Validator = &AWSManagedMachinePool{}
+var _ webhook.CustomValidator = &AWSManagedMachinePoolWebhook{}
+
+// ValidateCreate validates the AWSManagedMachinePool, then checks it against the volume encryption policy
+// of its cluster.
+func (r *AWSManagedMachinePoolWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	pool, ok := obj.(*AWSManagedMachinePool)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an AWSManagedMachinePool but got a %T", obj))
+	}
+
+	if err := pool.ValidateCreate(); err != nil {
+		return err
+	}
+	return r.validateVolumeEncryption(ctx, pool)
+}
+
+// ValidateUpdate validates the AWSManagedMachinePool, then checks it against the volume encryption policy
+// of its cluster.
+func (r *AWSManagedMachinePoolWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	pool, ok := newObj.(*AWSManagedMachinePool)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an AWSManagedMachinePool but got a %T", newObj))
+	}
+
+	if err := pool.ValidateUpdate(oldObj); err != nil {
+		return err
+	}
+	return r.validateVolumeEncryption(ctx, pool)
+}
+
+// ValidateDelete allows every deletion.
+func (r *AWSManagedMachinePoolWebhook) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+// validateVolumeEncryption checks the launch template of the pool, if any, against the volume encryption
+// policy of its cluster.
+func (r *AWSManagedMachinePoolWebhook) validateVolumeEncryption(ctx context.Context, pool *AWSManagedMachinePool) error {
+	if pool.Spec.AWSLaunchTemplate == nil {
+		return nil
+	}
+
+	allErrs := validateVolumeEncryption(ctx, r.Client, r.EnforceVolumeEncryption, pool, pool.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(pool.GroupVersionKind().GroupKind(), pool.Name, allErrs)
+}
 
 func (r *AWSManagedMachinePool) validateScaling() field.ErrorList {
 	var allErrs field.ErrorList
@@ -134,6 +202,10 @@ func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "AWSLaunchTemplate", "IamInstanceProfile"), r.Spec.AWSLaunchTemplate.IamInstanceProfile, "IAM instance profile in launch template is prohibited in EKS managed node group"))
 	}
 
+	allErrs = append(allErrs, v1beta2.ValidateBootstrapCommands(r.Spec.AWSLaunchTemplate.PreBootstrapCommands, r.Spec.AWSLaunchTemplate.PostBootstrapCommands, nil, r.Spec.AWSLaunchTemplate.Bottlerocket, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.CreditSpecification.Validate(r.Spec.AWSLaunchTemplate.InstanceType, field.NewPath("spec", "awsLaunchTemplate", "creditSpecification"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.AMI.ValidateAccelerator(r.Spec.AWSLaunchTemplate.InstanceType, field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
+
 	return allErrs
 }
 
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -152,6 +153,57 @@ func TestAWSManagedMachinePoolValidateCreate(t *testing.T) {
 	}
 }
 
+func TestAWSManagedMachinePoolValidateCreateVolumeEncryption(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    *AWSManagedMachinePool
+		wantErr bool
+	}{
+		{
+			name: "launch template with an encrypted root volume is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-1",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						RootVolume: &infrav1.Volume{Size: 20, Encrypted: aws.Bool(true)},
+					},
+				},
+			},
+		},
+		{
+			name: "launch template with an unencrypted root volume is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-1",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						RootVolume: &infrav1.Volume{Size: 20, Encrypted: aws.Bool(false)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "node group without a launch template is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := (&AWSManagedMachinePoolWebhook{EnforceVolumeEncryption: true}).ValidateCreate(context.TODO(), tt.pool)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
 func TestAWSManagedMachinePoolValidateUpdate(t *testing.T) {
 	g := NewWithT(t)
 
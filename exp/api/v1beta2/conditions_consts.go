@@ -36,6 +36,8 @@ const (
 	LaunchTemplateCreateFailedReason = "LaunchTemplateCreateFailed"
 	// LaunchTemplateReconcileFailedReason used for failures during Launch Template reconciliation.
 	LaunchTemplateReconcileFailedReason = "LaunchTemplateReconcileFailed"
+	// InvalidCapacityReservationReason used when the capacity reservation of a Launch Template can't be used.
+	InvalidCapacityReservationReason = "InvalidCapacityReservation"
 
 	// PreLaunchTemplateUpdateCheckCondition reports if all prerequisite are met for launch template update.
 	PreLaunchTemplateUpdateCheckCondition clusterv1.ConditionType = "PreLaunchTemplateUpdateCheckSuccess"
@@ -54,6 +56,23 @@ const (
 	InstanceRefreshNotReadyReason = "InstanceRefreshNotReady"
 	// InstanceRefreshFailedReason used to report when there instance refresh is not initiated.
 	InstanceRefreshFailedReason = "InstanceRefreshFailed"
+
+	// InstancesUpToDateCondition reports on whether all instances were launched from the latest version of the
+	// launch template, when they are replaced by a rolling update of the controller.
+	InstancesUpToDateCondition clusterv1.ConditionType = "InstancesUpToDate"
+	// RollingUpdateInProgressReason used while outdated instances are being replaced.
+	RollingUpdateInProgressReason = "RollingUpdateInProgress"
+	// NodeDrainFailedReason used when the node of an outdated instance could not be drained.
+	NodeDrainFailedReason = "NodeDrainFailed"
+
+	// ScalingPoliciesReadyCondition reports on whether the scaling policies of an AWSMachinePool are reconciled
+	// and able to change the desired capacity of its ASG.
+	ScalingPoliciesReadyCondition clusterv1.ConditionType = "ScalingPoliciesReady"
+	// ScalingPoliciesReconciliationFailedReason used for failures while reconciling the scaling policies.
+	ScalingPoliciesReconciliationFailedReason = "ScalingPoliciesReconciliationFailed"
+	// ReplicasNotExternallyManagedReason used when the replicas of the MachinePool aren't externally managed,
+	// so the desired capacity set by the scaling policies is reverted.
+	ReplicasNotExternallyManagedReason = "ReplicasNotExternallyManaged"
 )
 
 const (
@@ -86,6 +105,16 @@ const (
 	EKSFargateDeletedReason = "Deleted"
 	// EKSFargateFailedReason used when the profile failed.
 	EKSFargateFailedReason = "Failed"
+	// EKSFargateInvalidParameterReason used when the EKS API rejects the profile, e.g. because
+	// of a subnet or a pod execution role that can't be used.
+	EKSFargateInvalidParameterReason = "InvalidParameter"
+	// EKSFargateLimitExceededReason used when the limit of fargate profiles of the cluster is reached.
+	EKSFargateLimitExceededReason = "LimitExceeded"
+	// EKSFargateUnsupportedAvailabilityZoneReason used when a subnet of the profile is in an
+	// availability zone that doesn't support fargate.
+	EKSFargateUnsupportedAvailabilityZoneReason = "UnsupportedAvailabilityZone"
+	// EKSFargateAccessDeniedReason used when the controller isn't authorized to manage the profile.
+	EKSFargateAccessDeniedReason = "AccessDenied"
 )
 
 const (
@@ -101,4 +130,37 @@ const (
 	// IAMFargateRolesReconciliationFailedReason used to report failures while
 	// reconciling EKS nodegroup iam roles.
 	IAMFargateRolesReconciliationFailedReason = "IAMFargateRolesReconciliationFailed"
+	// IAMNodeRoleReadyCondition condition reports on the successful reconciliation of the
+	// EKS nodegroup iam role. The reason of a failure is derived from the error returned
+	// by the IAM API, e.g. IAMAccessDenied.
+	IAMNodeRoleReadyCondition clusterv1.ConditionType = "IAMNodeRoleReady"
+	// FargateExecutionRoleReadyCondition condition reports on the successful reconciliation
+	// of the EKS fargate pod execution iam role. The reason of a failure is derived from the
+	// error returned by the IAM API, e.g. IAMAccessDenied.
+	FargateExecutionRoleReadyCondition clusterv1.ConditionType = "FargateExecutionRoleReady"
+)
+
+const (
+	// RosaMachinePoolReadyCondition condition reports on the successful reconciliation of the ROSA node pool.
+	RosaMachinePoolReadyCondition clusterv1.ConditionType = "RosaMachinePoolReady"
+	// RosaMachinePoolReconciliationFailedReason used to report failures while reconciling the ROSA node pool.
+	RosaMachinePoolReconciliationFailedReason = "RosaMachinePoolReconciliationFailed"
+	// WaitingForRosaControlPlaneReason used when the machine pool is waiting for
+	// the ROSA control plane to be ready before proceeding.
+	WaitingForRosaControlPlaneReason = "WaitingForRosaControlPlane"
+)
+
+const (
+	// AWSLoadBalancerReadyCondition condition reports on the successful reconciliation of an AWSLoadBalancer.
+	AWSLoadBalancerReadyCondition clusterv1.ConditionType = "AWSLoadBalancerReady"
+	// AWSLoadBalancerReconciliationFailedReason used to report failures while reconciling the load balancer.
+	AWSLoadBalancerReconciliationFailedReason = "AWSLoadBalancerReconciliationFailed"
+	// AWSLoadBalancerDeletionFailedReason used to report failures while deleting the load balancer.
+	AWSLoadBalancerDeletionFailedReason = "AWSLoadBalancerDeletionFailed"
+	// WaitingForClusterInfrastructureReason used when the load balancer is waiting for
+	// the VPC and subnets of the cluster to be ready before proceeding.
+	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
+	// MachinePoolTargetsNotFoundReason used when a machine pool referenced by a target group
+	// has no auto scaling group yet.
+	MachinePoolTargetsNotFoundReason = "MachinePoolTargetsNotFound"
 )
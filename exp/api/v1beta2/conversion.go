@@ -33,3 +33,9 @@ func (*AWSFargateProfile) Hub() {}
 
 // Hub marks AWSFargateProfileList as a conversion hub.
 func (*AWSFargateProfileList) Hub() {}
+
+// Hub marks AWSLoadBalancer as a conversion hub.
+func (*AWSLoadBalancer) Hub() {}
+
+// Hub marks AWSLoadBalancerList as a conversion hub.
+func (*AWSLoadBalancerList) Hub() {}
@@ -122,8 +122,53 @@ type AWSLaunchTemplate struct {
 
 	// SpotMarketOptions are options for configuring AWSMachinePool instances to be run using AWS Spot instances.
 	SpotMarketOptions *infrav1.SpotMarketOptions `json:"spotMarketOptions,omitempty"`
+
+	// MarketType is the market the instances are purchased from. Set it to capacity-block to launch the
+	// instances into the EC2 Capacity Block for ML referenced by CapacityReservationID. Defaults to on-demand
+	// instances, or to spot instances when SpotMarketOptions is set.
+	// +kubebuilder:validation:Enum=capacity-block
+	// +optional
+	MarketType MarketType `json:"marketType,omitempty"`
+
+	// CapacityReservationID is the ID of the capacity reservation the instances are launched into, e.g. the
+	// reservation of a Capacity Block. The instance type of the launch template must match the reservation.
+	// +kubebuilder:validation:Pattern=`^cr-[0-9a-f]+$`
+	// +optional
+	CapacityReservationID *string `json:"capacityReservationID,omitempty"`
+
+	// CreditSpecification sets the credit option for CPU usage of burstable performance instances,
+	// e.g. standard to avoid paying for surplus credits of t3 instances, which are unlimited by
+	// default. It can only be set for burstable performance instance types.
+	// +optional
+	CreditSpecification *infrav1.CreditSpecification `json:"creditSpecification,omitempty"`
+
+	// Bottlerocket defines options related to instances running Bottlerocket OS. When set,
+	// the userdata of the launch template is rendered as Bottlerocket TOML settings.
+	// +optional
+	Bottlerocket *infrav1.Bottlerocket `json:"bottlerocket,omitempty"`
+
+	// PreBootstrapCommands are commands run on the instances before the commands of the bootstrap
+	// data, e.g. to install a proxy CA certificate. They are merged into the cloud-init cloud config
+	// of the bootstrap data, and cannot be used with Bottlerocket.
+	// +optional
+	PreBootstrapCommands []string `json:"preBootstrapCommands,omitempty"`
+
+	// PostBootstrapCommands are commands run on the instances after the commands of the bootstrap
+	// data, e.g. to install an agent. They are merged into the cloud-init cloud config of the
+	// bootstrap data, and cannot be used with Bottlerocket.
+	// +optional
+	PostBootstrapCommands []string `json:"postBootstrapCommands,omitempty"`
 }
 
+// MarketType describes the market the instances of a launch template are purchased from.
+type MarketType string
+
+var (
+	// MarketTypeCapacityBlock launches the instances into an EC2 Capacity Block for ML, i.e. GPU capacity
+	// reserved for a fixed period of time.
+	MarketTypeCapacityBlock = MarketType("capacity-block")
+)
+
 // Overrides are used to override the instance type specified by the launch template with multiple
 // instance types that can be used to launch On-Demand Instances and Spot Instances.
 type Overrides struct {
@@ -197,6 +242,7 @@ type AutoScalingGroup struct {
 	Status                    ASGStatus
 	Instances                 []infrav1.Instance `json:"instances,omitempty"`
 	CurrentlySuspendProcesses []string           `json:"currentlySuspendProcesses,omitempty"`
+	EnabledMetrics            []string           `json:"enabledMetrics,omitempty"`
 }
 
 // ASGStatus is a status string returned by the autoscaling API.
@@ -262,6 +308,17 @@ func (t *Taints) Contains(taint *Taint) bool {
 	return false
 }
 
+// ContainsKeyAndEffect checks for existence of a taint with the same key and effect, regardless of its value.
+func (t *Taints) ContainsKeyAndEffect(taint *Taint) bool {
+	for _, t := range *t {
+		if t.Key == taint.Key && t.Effect == taint.Effect {
+			return true
+		}
+	}
+
+	return false
+}
+
 // UpdateConfig is the configuration options for updating a nodegroup. Only one of MaxUnavailable
 // and MaxUnavailablePercentage should be specified.
 type UpdateConfig struct {
@@ -279,3 +336,39 @@ type UpdateConfig struct {
 	// +kubebuilder:validation:Minimum=1
 	MaxUnavailablePercentage *int `json:"maxUnavailablePercentage,omitempty"`
 }
+
+// NodeRepairConfig is the node auto repair configuration of a nodegroup.
+type NodeRepairConfig struct {
+	// Enabled specifies whether EKS monitors the health of the nodes of the nodegroup
+	// and automatically repairs them when they are unhealthy.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// NodegroupUpdateStatusType is the status of an update of a nodegroup.
+type NodegroupUpdateStatusType string
+
+var (
+	// NodegroupUpdateStatusInProgress is the status of an update that is still being applied.
+	NodegroupUpdateStatusInProgress = NodegroupUpdateStatusType("InProgress")
+	// NodegroupUpdateStatusFailed is the status of an update that failed.
+	NodegroupUpdateStatusFailed = NodegroupUpdateStatusType("Failed")
+	// NodegroupUpdateStatusCancelled is the status of an update that was cancelled.
+	NodegroupUpdateStatusCancelled = NodegroupUpdateStatusType("Cancelled")
+	// NodegroupUpdateStatusSuccessful is the status of an update that was applied successfully.
+	NodegroupUpdateStatusSuccessful = NodegroupUpdateStatusType("Successful")
+)
+
+// NodegroupUpdateStatus describes an update of a nodegroup.
+type NodegroupUpdateStatus struct {
+	// ID is the ID of the update.
+	ID string `json:"id"`
+
+	// Status is the status of the update.
+	// +kubebuilder:validation:Enum=InProgress;Failed;Cancelled;Successful
+	Status NodegroupUpdateStatusType `json:"status"`
+
+	// Errors are the errors reported for a failed update.
+	// +optional
+	Errors []string `json:"errors,omitempty"`
+}
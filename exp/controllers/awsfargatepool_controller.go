@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -45,10 +46,15 @@ import (
 // AWSFargateProfileReconciler reconciles a AWSFargateProfile object.
 type AWSFargateProfileReconciler struct {
 	client.Client
-	Recorder         record.EventRecorder
-	Endpoints        []scope.ServiceEndpoint
-	EnableIAM        bool
-	WatchFilterValue string
+	Recorder                  record.EventRecorder
+	Endpoints                 []scope.ServiceEndpoint
+	EnableIAM                 bool
+	IAMPermissionsBoundary    string
+	IAMPath                   string
+	WatchFilterValue          string
+	EnforcePrincipalAllowList bool
+	DescribeCacheTTL          time.Duration
+	AuditEventsVerbosity      int
 }
 
 // SetupWithManager is used to setup the controller.
@@ -102,13 +108,18 @@ func (r *AWSFargateProfileReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	fargateProfileScope, err := scope.NewFargateProfileScope(scope.FargateProfileScopeParams{
-		Client:         r.Client,
-		ControllerName: "awsfargateprofile",
-		Cluster:        cluster,
-		ControlPlane:   controlPlane,
-		FargateProfile: fargateProfile,
-		EnableIAM:      r.EnableIAM,
-		Endpoints:      r.Endpoints,
+		Client:                    r.Client,
+		ControllerName:            "awsfargateprofile",
+		Cluster:                   cluster,
+		ControlPlane:              controlPlane,
+		FargateProfile:            fargateProfile,
+		EnableIAM:                 r.EnableIAM,
+		IAMPermissionsBoundary:    r.IAMPermissionsBoundary,
+		IAMPath:                   r.IAMPath,
+		Endpoints:                 r.Endpoints,
+		EnforcePrincipalAllowList: r.EnforcePrincipalAllowList,
+		DescribeCacheTTL:          r.DescribeCacheTTL,
+		AuditEventsVerbosity:      r.AuditEventsVerbosity,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "failed to create scope")
@@ -117,6 +128,7 @@ func (r *AWSFargateProfileReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	defer func() {
 		applicableConditions := []clusterv1.ConditionType{
 			expinfrav1.IAMFargateRolesReadyCondition,
+			expinfrav1.FargateExecutionRoleReadyCondition,
 			expinfrav1.EKSFargateProfileReadyCondition,
 		}
 
@@ -0,0 +1,316 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/loadbalancer"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// loadBalancerRequeueAfter is how long to wait before reconciling a load balancer waiting for the
+// infrastructure of its cluster or the auto scaling groups of its machine pools.
+const loadBalancerRequeueAfter = time.Minute
+
+// AWSLoadBalancerReconciler reconciles a AWSLoadBalancer object.
+type AWSLoadBalancerReconciler struct {
+	client.Client
+	Recorder                  record.EventRecorder
+	WatchFilterValue          string
+	EnforcePrincipalAllowList bool
+	DescribeCacheTTL          time.Duration
+	AuditEventsVerbosity      int
+}
+
+// SetupWithManager is used to setup the controller.
+func (r *AWSLoadBalancerReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&expinfrav1.AWSLoadBalancer{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(logger.FromContext(ctx).GetLogger(), r.WatchFilterValue)).
+		Watches(
+			&source.Kind{Type: &expinfrav1.AWSMachinePool{}},
+			handler.EnqueueRequestsFromMapFunc(awsMachinePoolToLoadBalancerMapFunc(r.Client, logger.FromContext(ctx))),
+		).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes;awsmanagedcontrolplanes/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsloadbalancers,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsloadbalancers/status,verbs=get;update;patch
+
+// Reconcile reconciles AWSLoadBalancers.
+func (r *AWSLoadBalancerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := logger.FromContext(ctx)
+
+	awsLoadBalancer := &expinfrav1.AWSLoadBalancer{}
+	if err := r.Get(ctx, req.NamespacedName, awsLoadBalancer); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	cluster, err := util.GetClusterByName(ctx, r.Client, awsLoadBalancer.Namespace, awsLoadBalancer.Spec.ClusterName)
+	if err != nil {
+		log.Info("Failed to retrieve Cluster from AWSLoadBalancer")
+		return reconcile.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", klog.KObj(cluster))
+
+	if annotations.IsPaused(cluster, awsLoadBalancer) {
+		log.Info("AWSLoadBalancer or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	// The load balancer must be deleted before the VPC of the cluster can be.
+	if !cluster.DeletionTimestamp.IsZero() && awsLoadBalancer.DeletionTimestamp.IsZero() {
+		log.Info("Cluster is being deleted, deleting AWSLoadBalancer")
+		if err := r.Delete(ctx, awsLoadBalancer); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, errors.Wrap(err, "failed to delete AWSLoadBalancer of deleted cluster")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	infraCluster, err := r.getInfraCluster(ctx, log, cluster)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "error getting infra provider cluster or control plane object")
+	}
+	if infraCluster == nil {
+		log.Info("AWSCluster or AWSManagedControlPlane is not ready yet")
+		return ctrl.Result{RequeueAfter: loadBalancerRequeueAfter}, nil
+	}
+
+	machinePools, err := r.getMachinePools(ctx, awsLoadBalancer)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	loadBalancerScope, err := scope.NewAWSLoadBalancerScope(scope.AWSLoadBalancerScopeParams{
+		Client:          r.Client,
+		Logger:          log,
+		Cluster:         cluster,
+		InfraCluster:    infraCluster,
+		AWSLoadBalancer: awsLoadBalancer,
+		MachinePools:    machinePools,
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create scope")
+	}
+
+	defer func() {
+		conditions.SetSummary(loadBalancerScope.AWSLoadBalancer, conditions.WithConditions(expinfrav1.AWSLoadBalancerReadyCondition))
+
+		if err := loadBalancerScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	if !awsLoadBalancer.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, loadBalancerScope)
+	}
+
+	return r.reconcileNormal(ctx, loadBalancerScope)
+}
+
+func (r *AWSLoadBalancerReconciler) reconcileNormal(_ context.Context, loadBalancerScope *scope.AWSLoadBalancerScope) (ctrl.Result, error) {
+	loadBalancerScope.Info("Reconciling AWSLoadBalancer")
+
+	awsLoadBalancer := loadBalancerScope.AWSLoadBalancer
+	if controllerutil.AddFinalizer(awsLoadBalancer, expinfrav1.LoadBalancerFinalizer) {
+		if err := loadBalancerScope.PatchObject(); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	infraCluster := loadBalancerScope.InfraCluster.InfraCluster()
+	if !conditions.IsTrue(infraCluster, infrav1.VpcReadyCondition) || !conditions.IsTrue(infraCluster, infrav1.SubnetsReadyCondition) {
+		loadBalancerScope.Info("Waiting for the VPC and subnets of the cluster")
+		conditions.MarkFalse(awsLoadBalancer, expinfrav1.AWSLoadBalancerReadyCondition, expinfrav1.WaitingForClusterInfrastructureReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{RequeueAfter: loadBalancerRequeueAfter}, nil
+	}
+
+	if err := loadbalancer.NewService(loadBalancerScope).ReconcileLoadBalancer(); err != nil {
+		conditions.MarkFalse(awsLoadBalancer, expinfrav1.AWSLoadBalancerReadyCondition, expinfrav1.AWSLoadBalancerReconciliationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile load balancer for AWSLoadBalancer %s/%s", awsLoadBalancer.Namespace, awsLoadBalancer.Name)
+	}
+
+	if !awsLoadBalancer.Status.Ready {
+		return ctrl.Result{RequeueAfter: loadBalancerRequeueAfter}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *AWSLoadBalancerReconciler) reconcileDelete(_ context.Context, loadBalancerScope *scope.AWSLoadBalancerScope) (ctrl.Result, error) {
+	loadBalancerScope.Info("Reconciling deletion of AWSLoadBalancer")
+
+	awsLoadBalancer := loadBalancerScope.AWSLoadBalancer
+	if err := loadbalancer.NewService(loadBalancerScope).DeleteLoadBalancer(); err != nil {
+		conditions.MarkFalse(awsLoadBalancer, expinfrav1.AWSLoadBalancerReadyCondition, expinfrav1.AWSLoadBalancerDeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, errors.Wrapf(err, "failed to delete load balancer for AWSLoadBalancer %s/%s", awsLoadBalancer.Namespace, awsLoadBalancer.Name)
+	}
+
+	controllerutil.RemoveFinalizer(awsLoadBalancer, expinfrav1.LoadBalancerFinalizer)
+	return ctrl.Result{}, nil
+}
+
+// getMachinePools returns the AWSMachinePools referenced by the target groups of the load balancer that exist, by name.
+func (r *AWSLoadBalancerReconciler) getMachinePools(ctx context.Context, awsLoadBalancer *expinfrav1.AWSLoadBalancer) (map[string]*expinfrav1.AWSMachinePool, error) {
+	machinePools := map[string]*expinfrav1.AWSMachinePool{}
+	for _, ln := range awsLoadBalancer.Spec.Listeners {
+		for _, name := range ln.TargetGroup.MachinePools {
+			if _, ok := machinePools[name]; ok {
+				continue
+			}
+			awsMachinePool := &expinfrav1.AWSMachinePool{}
+			if err := r.Get(ctx, client.ObjectKey{Namespace: awsLoadBalancer.Namespace, Name: name}, awsMachinePool); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, errors.Wrapf(err, "failed to get AWSMachinePool %q", name)
+			}
+			machinePools[name] = awsMachinePool
+		}
+	}
+	return machinePools, nil
+}
+
+func (r *AWSLoadBalancerReconciler) getInfraCluster(ctx context.Context, log *logger.Logger, cluster *clusterv1.Cluster) (scope.EC2Scope, error) {
+	if cluster.Spec.ControlPlaneRef != nil && cluster.Spec.ControlPlaneRef.Kind == controllers.AWSManagedControlPlaneRefKind {
+		controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+		controlPlaneName := client.ObjectKey{
+			Namespace: cluster.Namespace,
+			Name:      cluster.Spec.ControlPlaneRef.Name,
+		}
+
+		if err := r.Get(ctx, controlPlaneName, controlPlane); err != nil {
+			// AWSManagedControlPlane is not ready
+			return nil, nil //nolint:nilerr
+		}
+
+		return scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+			Client:                    r.Client,
+			Logger:                    log,
+			Cluster:                   cluster,
+			ControlPlane:              controlPlane,
+			ControllerName:            "awsloadbalancer",
+			EnforcePrincipalAllowList: r.EnforcePrincipalAllowList,
+			DescribeCacheTTL:          r.DescribeCacheTTL,
+			AuditEventsVerbosity:      r.AuditEventsVerbosity,
+		})
+	}
+
+	if cluster.Spec.InfrastructureRef == nil {
+		return nil, nil
+	}
+
+	awsCluster := &infrav1.AWSCluster{}
+	infraClusterName := client.ObjectKey{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+
+	if err := r.Get(ctx, infraClusterName, awsCluster); err != nil {
+		// AWSCluster is not ready
+		return nil, nil //nolint:nilerr
+	}
+
+	return scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:                    r.Client,
+		Logger:                    log,
+		Cluster:                   cluster,
+		AWSCluster:                awsCluster,
+		ControllerName:            "awsloadbalancer",
+		EnforcePrincipalAllowList: r.EnforcePrincipalAllowList,
+		DescribeCacheTTL:          r.DescribeCacheTTL,
+		AuditEventsVerbosity:      r.AuditEventsVerbosity,
+	})
+}
+
+// awsMachinePoolToLoadBalancerMapFunc enqueues the AWSLoadBalancers whose target groups reference an AWSMachinePool,
+// so that its auto scaling group is attached once it is created.
+func awsMachinePoolToLoadBalancerMapFunc(c client.Client, log logger.Wrapper) handler.MapFunc {
+	return func(o client.Object) []ctrl.Request {
+		ctx := context.Background()
+
+		awsMachinePool, ok := o.(*expinfrav1.AWSMachinePool)
+		if !ok {
+			klog.Errorf("Expected a AWSMachinePool but got a %T", o)
+			return nil
+		}
+
+		loadBalancers := expinfrav1.AWSLoadBalancerList{}
+		if err := c.List(ctx, &loadBalancers, client.InNamespace(awsMachinePool.Namespace)); err != nil {
+			log.Error(err, "couldn't list load balancers")
+			return nil
+		}
+
+		var results []ctrl.Request
+		for i := range loadBalancers.Items {
+			lb := loadBalancers.Items[i]
+			if !loadBalancerTargetsMachinePool(&lb, awsMachinePool.Name) {
+				continue
+			}
+			results = append(results, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: lb.Namespace,
+					Name:      lb.Name,
+				},
+			})
+		}
+
+		return results
+	}
+}
+
+func loadBalancerTargetsMachinePool(lb *expinfrav1.AWSLoadBalancer, name string) bool {
+	for _, ln := range lb.Spec.Listeners {
+		for _, pool := range ln.TargetGroup.MachinePools {
+			if pool == name {
+				return true
+			}
+		}
+	}
+	return false
+}
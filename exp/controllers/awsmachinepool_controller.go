@@ -19,14 +19,18 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -41,11 +45,14 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/internal/requeue"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	asg "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -57,10 +64,17 @@ import (
 // AWSMachinePoolReconciler reconciles a AWSMachinePool object.
 type AWSMachinePoolReconciler struct {
 	client.Client
-	Recorder          record.EventRecorder
-	WatchFilterValue  string
-	asgServiceFactory func(cloud.ClusterScoper) services.ASGInterface
-	ec2ServiceFactory func(scope.EC2Scope) services.EC2Interface
+	Recorder                  record.EventRecorder
+	WatchFilterValue          string
+	EnforcePrincipalAllowList bool
+	DescribeCacheTTL          time.Duration
+	AuditEventsVerbosity      int
+	asgServiceFactory         func(cloud.ClusterScoper) services.ASGInterface
+	ec2ServiceFactory         func(scope.EC2Scope) services.EC2Interface
+	kubeClientFactory         func(context.Context, *scope.MachinePoolScope) (kubernetes.Interface, error)
+	// SyncPeriod is the interval at which AWSMachinePools are reconciled when nothing changed.
+	// If zero, the manager's sync period applies.
+	SyncPeriod time.Duration
 }
 
 func (r *AWSMachinePoolReconciler) getASGService(scope cloud.ClusterScoper) services.ASGInterface {
@@ -81,6 +95,7 @@ func (r *AWSMachinePoolReconciler) getEC2Service(scope scope.EC2Scope) services.
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
@@ -166,13 +181,15 @@ func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return r.reconcileDelete(machinePoolScope, infraScope, infraScope)
 		}
 
-		return r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		result, err := r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		return requeue.AfterSyncPeriod(r.SyncPeriod, result, err)
 	case *scope.ClusterScope:
 		if !awsMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
 			return r.reconcileDelete(machinePoolScope, infraScope, infraScope)
 		}
 
-		return r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		result, err := r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		return requeue.AfterSyncPeriod(r.SyncPeriod, result, err)
 	default:
 		return ctrl.Result{}, errors.New("infraCluster has unknown type")
 	}
@@ -230,6 +247,10 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		// If there is a change: before changing the template, check if there exist an ongoing instance refresh,
 		// because only 1 instance refresh can be "InProgress". If template is updated when refresh cannot be started,
 		// that change will not trigger a refresh. Do not start an instance refresh if only userdata changed.
+		// The rolling update of the controller picks up the latest version of the template at any time.
+		if machinePoolScope.AWSMachinePool.RollingUpdateEnabled() {
+			return true, nil
+		}
 		return asgsvc.CanStartASGInstanceRefresh(machinePoolScope)
 	}
 	runPostLaunchTemplateUpdateOperation := func() error {
@@ -238,6 +259,11 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 			machinePoolScope.Debug("instance refresh disabled, skipping instance refresh")
 			return nil
 		}
+		// the controller replaces the outdated instances itself when the rolling update is enabled
+		if machinePoolScope.AWSMachinePool.RollingUpdateEnabled() {
+			machinePoolScope.Debug("rolling update enabled, skipping instance refresh")
+			return nil
+		}
 		// After creating a new version of launch template, instance refresh is required
 		// to trigger a rolling replacement of all previously launched instances.
 		// If ONLY the userdata changed, previously launched instances continue to use the old launch
@@ -250,6 +276,12 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		machinePoolScope.Info("starting instance refresh", "number of instances", machinePoolScope.MachinePool.Spec.Replicas)
 		return asgsvc.StartASGInstanceRefresh(machinePoolScope)
 	}
+	if err := validateCapacityReservation(machinePoolScope.AWSMachinePool, ec2Svc); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "InvalidCapacityReservation", "Invalid capacity reservation: %v", err)
+		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition, expinfrav1.InvalidCapacityReservationReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
+
 	if err := ec2Svc.ReconcileLaunchTemplate(machinePoolScope, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
 		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
 		machinePoolScope.Error(err, "failed to reconcile launch template")
@@ -259,6 +291,11 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 	// set the LaunchTemplateReady condition
 	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition)
 
+	if err := reconcileCapacity(machinePoolScope, ec2Svc); err != nil {
+		machinePoolScope.Error(err, "failed to reconcile capacity")
+		return ctrl.Result{}, err
+	}
+
 	// Find existing ASG
 	asg, err := r.findASG(machinePoolScope, asgsvc)
 	if err != nil {
@@ -325,12 +362,85 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 	machinePoolScope.AWSMachinePool.Status.Ready = true
 	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition)
 
+	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
+		if err := r.reconcileInstanceStateEvents(machinePoolScope, ec2Scope, asg); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	err = machinePoolScope.UpdateInstanceStatuses(ctx, asg.Instances)
 	if err != nil {
 		machinePoolScope.Info("Failed updating instances", "instances", asg.Instances)
 	}
 
-	return ctrl.Result{}, nil
+	rollingUpdateResult, err := r.reconcileRollingUpdate(ctx, machinePoolScope, asgsvc, asg)
+	if err != nil {
+		machinePoolScope.Error(err, "failed to reconcile rolling update")
+		return ctrl.Result{}, err
+	}
+
+	return util.LowestNonZeroResult(rollingUpdateResult, availabilityZoneFailurePolicyResult(machinePoolScope.AWSMachinePool, asg)), nil
+}
+
+// reconcileCapacity sets the capacity of the instances of the machine pool in its status, so that the
+// cluster-autoscaler is able to scale the pool up from zero.
+func reconcileCapacity(machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface) error {
+	launchTemplate := machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate
+	instanceType := launchTemplate.InstanceType
+	if mixedInstancesPolicy := machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy; instanceType == "" && mixedInstancesPolicy != nil && len(mixedInstancesPolicy.Overrides) > 0 {
+		instanceType = mixedInstancesPolicy.Overrides[0].InstanceType
+	}
+	if instanceType == "" {
+		machinePoolScope.AWSMachinePool.Status.Capacity = nil
+		return nil
+	}
+
+	capacity, err := ec2Svc.InstanceTypeCapacity(instanceType)
+	if err != nil {
+		return err
+	}
+	if launchTemplate.RootVolume != nil && launchTemplate.RootVolume.Size > 0 {
+		capacity[corev1.ResourceEphemeralStorage] = resource.MustParse(fmt.Sprintf("%dGi", launchTemplate.RootVolume.Size))
+	}
+	machinePoolScope.AWSMachinePool.Status.Capacity = capacity
+
+	return nil
+}
+
+// validateCapacityReservation verifies that the capacity reservation of the launch template, if any, reserves
+// instances of the launch template's type, enough of them for the pool to scale up to its maximum size.
+func validateCapacityReservation(awsMachinePool *expinfrav1.AWSMachinePool, ec2Svc services.EC2Interface) error {
+	launchTemplate := awsMachinePool.Spec.AWSLaunchTemplate
+	if launchTemplate.CapacityReservationID == nil {
+		return nil
+	}
+
+	return ec2Svc.ValidateCapacityReservation(*launchTemplate.CapacityReservationID, launchTemplate.InstanceType, int64(awsMachinePool.Spec.MaxSize))
+}
+
+// availabilityZoneFailurePolicyResult requeues machine pools with an availability zone failure policy while
+// their ASG is scaling up, to detect failing availability zones early, and when a suspended availability
+// zone is due to be added back.
+func availabilityZoneFailurePolicyResult(awsMachinePool *expinfrav1.AWSMachinePool, asg *expinfrav1.AutoScalingGroup) ctrl.Result {
+	if awsMachinePool.Spec.AvailabilityZoneFailurePolicy == nil {
+		return ctrl.Result{}
+	}
+
+	var requeueAfter time.Duration
+	if asg.DesiredCapacity != nil && len(asg.Instances) < int(*asg.DesiredCapacity) {
+		requeueAfter = time.Minute
+	}
+	for _, zone := range awsMachinePool.Status.SuspendedAvailabilityZones {
+		until := time.Until(zone.Until.Time)
+		if until < time.Second {
+			until = time.Second
+		}
+		if requeueAfter == 0 || until < requeueAfter {
+			requeueAfter = until
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}
 }
 
 func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) (ctrl.Result, error) {
@@ -365,6 +475,25 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 		}
 	}
 
+	// The scaling policies are deleted with the ASG, but not the alarms of the step scaling policies.
+	if len(machinePoolScope.AWSMachinePool.Status.ScalingPolicies) > 0 {
+		if err := asgSvc.DeleteScalingPolicyAlarms(machinePoolScope); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to delete the alarms of the scaling policies")
+		}
+	}
+
+	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
+		instancestateSvc := instancestate.NewService(ec2Scope)
+		if asg != nil {
+			instancestateSvc.RemoveAutoScalingGroupFromEventPattern(asg.Name)
+		}
+		if instanceIDs := machinePoolInstanceIDs(machinePoolScope.AWSMachinePool); len(instanceIDs) > 0 {
+			if err := instancestateSvc.UpdateSpotInstancesInEventPattern(nil, instanceIDs); err != nil {
+				machinePoolScope.Error(err, "non-fatal: failed to remove instances from Event Bridge spot interruption rule")
+			}
+		}
+	}
+
 	launchTemplateID := machinePoolScope.AWSMachinePool.Status.LaunchTemplateID
 	launchTemplate, _, err := ec2Svc.GetLaunchTemplate(machinePoolScope.LaunchTemplateName())
 	if err != nil {
@@ -392,9 +521,66 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 	return ctrl.Result{}, nil
 }
 
+// reconcileInstanceStateEvents adds the ASG to the Event Bridge lifecycle rule and, for
+// pools that can run spot instances, keeps the spot interruption rule in step with the
+// instances of the ASG.
+func (r *AWSMachinePoolReconciler) reconcileInstanceStateEvents(machinePoolScope *scope.MachinePoolScope, ec2Scope scope.EC2Scope, asg *expinfrav1.AutoScalingGroup) error {
+	instancestateSvc := instancestate.NewService(ec2Scope)
+	if err := instancestateSvc.AddAutoScalingGroupToEventPattern(asg.Name); err != nil {
+		if !instancestate.IsRuleNotFound(err) {
+			return errors.Wrap(err, "failed to add ASG to Event Bridge lifecycle rule")
+		}
+		// The rules are created by the controller of the infrastructure cluster, which may not have
+		// reconciled them yet or may have failed to. The pool is added to them on the next reconciliation.
+		machinePoolScope.Info("Event Bridge machine pool rules not found, skipping", "error", err.Error())
+		return nil
+	}
+
+	if !usesSpotInstances(machinePoolScope.AWSMachinePool) {
+		return nil
+	}
+
+	previous := sets.NewString(machinePoolInstanceIDs(machinePoolScope.AWSMachinePool)...)
+	current := sets.NewString()
+	for _, instance := range asg.Instances {
+		current.Insert(instance.ID)
+	}
+	if err := instancestateSvc.UpdateSpotInstancesInEventPattern(current.Difference(previous).List(), previous.Difference(current).List()); err != nil && !instancestate.IsRuleNotFound(err) {
+		return errors.Wrap(err, "failed to update instances in Event Bridge spot interruption rule")
+	}
+	return nil
+}
+
+func machinePoolInstanceIDs(awsMachinePool *expinfrav1.AWSMachinePool) []string {
+	ids := make([]string, 0, len(awsMachinePool.Status.Instances))
+	for _, instance := range awsMachinePool.Status.Instances {
+		ids = append(ids, instance.InstanceID)
+	}
+	return ids
+}
+
+// usesSpotInstances returns true if the pool may launch spot instances, either through
+// the launch template or through the spot share of its mixed instances policy.
+func usesSpotInstances(awsMachinePool *expinfrav1.AWSMachinePool) bool {
+	if awsMachinePool.Spec.AWSLaunchTemplate.SpotMarketOptions != nil {
+		return true
+	}
+	policy := awsMachinePool.Spec.MixedInstancesPolicy
+	if policy == nil || policy.InstancesDistribution == nil || policy.InstancesDistribution.OnDemandPercentageAboveBaseCapacity == nil {
+		return false
+	}
+	return *policy.InstancesDistribution.OnDemandPercentageAboveBaseCapacity < 100
+}
+
 func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, existingASG *expinfrav1.AutoScalingGroup) error {
 	asgSvc := r.getASGService(clusterScope)
 
+	if machinePoolScope.AWSMachinePool.Spec.AvailabilityZoneFailurePolicy != nil || len(machinePoolScope.AWSMachinePool.Status.SuspendedAvailabilityZones) > 0 {
+		if err := asgSvc.ReconcileSuspendedAvailabilityZones(machinePoolScope); err != nil {
+			return errors.Wrap(err, "failed to reconcile suspended availability zones")
+		}
+	}
+
 	subnetIDs, err := asgSvc.SubnetIDs(machinePoolScope)
 	if err != nil {
 		return errors.Wrapf(err, "fail to get subnets for ASG")
@@ -414,7 +600,7 @@ func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoo
 		}
 	}
 
-	suspendedProcessesSlice := machinePoolScope.AWSMachinePool.Spec.SuspendProcesses.ConvertSetValuesToStringSlice()
+	suspendedProcessesSlice := desiredSuspendedProcesses(machinePoolScope.AWSMachinePool)
 	if !cmp.Equal(existingASG.CurrentlySuspendProcesses, suspendedProcessesSlice) {
 		clusterScope.Info("reconciling processes", "suspend-processes", suspendedProcessesSlice)
 		var (
@@ -466,6 +652,74 @@ func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoo
 			}
 		}
 	}
+
+	if err := r.reconcileMetricsCollection(machinePoolScope, asgSvc, existingASG); err != nil {
+		return err
+	}
+
+	return r.reconcileScalingPolicies(machinePoolScope, asgSvc)
+}
+
+// reconcileScalingPolicies reconciles the scaling policies of the ASG when the AWSMachinePool has or had some.
+func (r *AWSMachinePoolReconciler) reconcileScalingPolicies(machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	if len(awsMachinePool.Spec.ScalingPolicies) == 0 && len(awsMachinePool.Status.ScalingPolicies) == 0 {
+		conditions.Delete(awsMachinePool, expinfrav1.ScalingPoliciesReadyCondition)
+		return nil
+	}
+
+	if err := asgSvc.ReconcileScalingPolicies(machinePoolScope); err != nil {
+		conditions.MarkFalse(awsMachinePool, expinfrav1.ScalingPoliciesReadyCondition, expinfrav1.ScalingPoliciesReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return errors.Wrapf(err, "failed to reconcile scaling policies while trying update pool")
+	}
+
+	switch {
+	case len(awsMachinePool.Spec.ScalingPolicies) == 0:
+		conditions.Delete(awsMachinePool, expinfrav1.ScalingPoliciesReadyCondition)
+	case !scope.ReplicasExternallyManaged(machinePoolScope.MachinePool):
+		// The desired capacity set by the policies is reverted to the replicas of the MachinePool otherwise.
+		conditions.MarkFalse(awsMachinePool, expinfrav1.ScalingPoliciesReadyCondition, expinfrav1.ReplicasNotExternallyManagedReason, clusterv1.ConditionSeverityWarning,
+			"the replicas of MachinePool %q are not externally managed", machinePoolScope.MachinePool.Name)
+	default:
+		conditions.MarkTrue(awsMachinePool, expinfrav1.ScalingPoliciesReadyCondition)
+	}
+	return nil
+}
+
+// desiredSuspendedProcesses returns the processes of the ASG to suspend: the ones of SuspendProcesses, and
+// AZRebalance if the availability zone distribution policy of the pool requires it.
+func desiredSuspendedProcesses(awsMachinePool *expinfrav1.AWSMachinePool) []string {
+	processes := awsMachinePool.Spec.SuspendProcesses.ConvertSetValuesToStringSlice()
+	if !awsMachinePool.Spec.AvailabilityZoneDistribution.SuspendsAZRebalance() {
+		return processes
+	}
+	for _, p := range processes {
+		if p == "AZRebalance" {
+			return processes
+		}
+	}
+	return append(processes, "AZRebalance")
+}
+
+// reconcileMetricsCollection enables the group metrics of the AWSMachinePool which aren't collected yet,
+// and disables the ones collected which aren't part of the AWSMachinePool anymore.
+func (r *AWSMachinePoolReconciler) reconcileMetricsCollection(machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup) error {
+	metricsCollection := machinePoolScope.AWSMachinePool.Spec.MetricsCollection
+	desired := sets.NewString(metricsCollection.MetricsOrDefault()...)
+	enabled := sets.NewString(existingASG.EnabledMetrics...)
+
+	if toBeDisabled := enabled.Difference(desired); toBeDisabled.Len() > 0 {
+		machinePoolScope.Info("disabling metrics collection", "metrics", toBeDisabled.List())
+		if err := asgSvc.DisableMetricsCollection(existingASG.Name, toBeDisabled.List()); err != nil {
+			return errors.Wrapf(err, "failed to disable metrics collection while trying update pool")
+		}
+	}
+	if toBeEnabled := desired.Difference(enabled); toBeEnabled.Len() > 0 {
+		machinePoolScope.Info("enabling metrics collection", "metrics", toBeEnabled.List())
+		if err := asgSvc.EnableMetricsCollection(existingASG.Name, metricsCollection.GranularityOrDefault(), toBeEnabled.List()); err != nil {
+			return errors.Wrapf(err, "failed to enable metrics collection while trying update pool")
+		}
+	}
 	return nil
 }
 
@@ -495,8 +749,8 @@ func (r *AWSMachinePoolReconciler) findASG(machinePoolScope *scope.MachinePoolSc
 // asgNeedsUpdates compares incoming AWSMachinePool and compares against existing ASG.
 func asgNeedsUpdates(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) bool {
 	if !scope.ReplicasExternallyManaged(machinePoolScope.MachinePool) {
-		if machinePoolScope.MachinePool.Spec.Replicas != nil {
-			if existingASG.DesiredCapacity == nil || *machinePoolScope.MachinePool.Spec.Replicas != *existingASG.DesiredCapacity {
+		if desiredCapacity := machinePoolScope.DesiredCapacity(); desiredCapacity != nil {
+			if existingASG.DesiredCapacity == nil || *desiredCapacity != *existingASG.DesiredCapacity {
 				return true
 			}
 		} else if existingASG.DesiredCapacity != nil {
@@ -594,11 +848,14 @@ func (r *AWSMachinePoolReconciler) getInfraCluster(ctx context.Context, log *log
 		}
 
 		managedControlPlaneScope, err = scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
-			Client:         r.Client,
-			Logger:         log,
-			Cluster:        cluster,
-			ControlPlane:   controlPlane,
-			ControllerName: "awsManagedControlPlane",
+			Client:                    r.Client,
+			Logger:                    log,
+			Cluster:                   cluster,
+			ControlPlane:              controlPlane,
+			ControllerName:            "awsManagedControlPlane",
+			EnforcePrincipalAllowList: r.EnforcePrincipalAllowList,
+			DescribeCacheTTL:          r.DescribeCacheTTL,
+			AuditEventsVerbosity:      r.AuditEventsVerbosity,
 		})
 		if err != nil {
 			return nil, err
@@ -621,11 +878,14 @@ func (r *AWSMachinePoolReconciler) getInfraCluster(ctx context.Context, log *log
 
 	// Create the cluster scope
 	clusterScope, err = scope.NewClusterScope(scope.ClusterScopeParams{
-		Client:         r.Client,
-		Logger:         log,
-		Cluster:        cluster,
-		AWSCluster:     awsCluster,
-		ControllerName: "awsmachine",
+		Client:                    r.Client,
+		Logger:                    log,
+		Cluster:                   cluster,
+		AWSCluster:                awsCluster,
+		ControllerName:            "awsmachine",
+		EnforcePrincipalAllowList: r.EnforcePrincipalAllowList,
+		DescribeCacheTTL:          r.DescribeCacheTTL,
+		AuditEventsVerbosity:      r.AuditEventsVerbosity,
 	})
 	if err != nil {
 		return nil, err
@@ -28,6 +28,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -352,6 +353,51 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			})
 		})
 
+		t.Run("there are group metrics already collected", func(t *testing.T) {
+			t.Run("it should enable and disable metrics that are desired to be collected and no longer desired", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				ms.AWSMachinePool.Spec.MetricsCollection = &expinfrav1.MetricsCollection{
+					Metrics: []expinfrav1.ASGMetric{"GroupDesiredCapacity", "GroupInServiceInstances"},
+				}
+
+				ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
+					Name:           "name",
+					EnabledMetrics: []string{"GroupDesiredCapacity", "GroupMaxSize"},
+				}, nil)
+				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
+				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().EnableMetricsCollection("name", "1Minute", []string{"GroupInServiceInstances"}).Return(nil).Times(1)
+				asgSvc.EXPECT().DisableMetricsCollection("name", []string{"GroupMaxSize"}).Return(nil).Times(1)
+
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				g.Expect(err).To(Succeed())
+			})
+			t.Run("it should disable all metrics when metrics collection is removed", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
+					Name:           "name",
+					EnabledMetrics: []string{"GroupMaxSize", "GroupDesiredCapacity"},
+				}, nil)
+				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
+				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().EnableMetricsCollection(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				asgSvc.EXPECT().DisableMetricsCollection("name", []string{"GroupDesiredCapacity", "GroupMaxSize"}).Return(nil).Times(1)
+
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				g.Expect(err).To(Succeed())
+			})
+		})
+
 		t.Run("externally managed annotation", func(t *testing.T) {
 			g := NewWithT(t)
 			setup(t, g)
@@ -536,6 +582,73 @@ func setupCluster(clusterName string) (*scope.ClusterScope, error) {
 	})
 }
 
+func TestReconcileCapacity(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           expinfrav1.AWSMachinePoolSpec
+		expectInstance string
+		expect         corev1.ResourceList
+	}{
+		{
+			name: "should clear the capacity without instance type",
+		},
+		{
+			name: "should set the capacity of the launch template instance type and root volume",
+			spec: expinfrav1.AWSMachinePoolSpec{
+				AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{
+					InstanceType: "m5.large",
+					RootVolume:   &infrav1.Volume{Size: 50},
+				},
+			},
+			expectInstance: "m5.large",
+			expect: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("2"),
+				corev1.ResourceMemory:           resource.MustParse("8192Mi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("50Gi"),
+			},
+		},
+		{
+			name: "should fall back to the first instance type override",
+			spec: expinfrav1.AWSMachinePoolSpec{
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					Overrides: []expinfrav1.Overrides{{InstanceType: "m5.large"}, {InstanceType: "m5.xlarge"}},
+				},
+			},
+			expectInstance: "m5.large",
+			expect: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("8192Mi"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+			if tt.expectInstance != "" {
+				ec2Svc.EXPECT().InstanceTypeCapacity(tt.expectInstance).Return(corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("8192Mi"),
+				}, nil)
+			}
+
+			machinePoolScope := &scope.MachinePoolScope{
+				AWSMachinePool: &expinfrav1.AWSMachinePool{
+					Spec: tt.spec,
+					Status: expinfrav1.AWSMachinePoolStatus{
+						Capacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				},
+			}
+			g.Expect(reconcileCapacity(machinePoolScope, ec2Svc)).To(Succeed())
+			g.Expect(machinePoolScope.AWSMachinePool.Status.Capacity).To(Equal(tt.expect))
+		})
+	}
+}
+
 func TestASGNeedsUpdates(t *testing.T) {
 	type args struct {
 		machinePoolScope *scope.MachinePoolScope
@@ -830,3 +943,45 @@ func TestASGNeedsUpdates(t *testing.T) {
 		})
 	}
 }
+
+func TestDesiredSuspendedProcesses(t *testing.T) {
+	tests := []struct {
+		name string
+		spec expinfrav1.AWSMachinePoolSpec
+		want []string
+	}{
+		{
+			name: "no suspended processes by default",
+		},
+		{
+			name: "the balanced distribution doesn't suspend AZRebalance",
+			spec: expinfrav1.AWSMachinePoolSpec{
+				AvailabilityZoneDistribution: &expinfrav1.AvailabilityZoneDistribution{Policy: expinfrav1.AvailabilityZoneDistributionPolicyBalanced},
+				SuspendProcesses:             &expinfrav1.SuspendProcessesTypes{Processes: &expinfrav1.Processes{Launch: pointer.Bool(true)}},
+			},
+			want: []string{"Launch"},
+		},
+		{
+			name: "the best effort distribution suspends AZRebalance",
+			spec: expinfrav1.AWSMachinePoolSpec{
+				AvailabilityZoneDistribution: &expinfrav1.AvailabilityZoneDistribution{Policy: expinfrav1.AvailabilityZoneDistributionPolicyBestEffort},
+				SuspendProcesses:             &expinfrav1.SuspendProcessesTypes{Processes: &expinfrav1.Processes{Launch: pointer.Bool(true)}},
+			},
+			want: []string{"Launch", "AZRebalance"},
+		},
+		{
+			name: "AZRebalance is not suspended twice",
+			spec: expinfrav1.AWSMachinePoolSpec{
+				AvailabilityZoneDistribution: &expinfrav1.AvailabilityZoneDistribution{Policy: expinfrav1.AvailabilityZoneDistributionPolicyBestEffort},
+				SuspendProcesses:             &expinfrav1.SuspendProcessesTypes{Processes: &expinfrav1.Processes{AZRebalance: pointer.Bool(true)}},
+			},
+			want: []string{"AZRebalance"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(desiredSuspendedProcesses(&expinfrav1.AWSMachinePool{Spec: tt.spec})).To(Equal(tt.want))
+		})
+	}
+}
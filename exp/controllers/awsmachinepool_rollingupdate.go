@@ -0,0 +1,359 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// rollingUpdateRequeuePeriod is how often a rolling update is reconciled while it is in progress.
+const rollingUpdateRequeuePeriod = 30 * time.Second
+
+// reconcileRollingUpdate replaces the instances of the ASG not launched from the latest version of the launch
+// template, when the rolling update of the pool is enabled. The instances are replaced one availability zone
+// at a time, and their nodes are cordoned and drained before they are terminated.
+func (r *AWSMachinePoolReconciler) reconcileRollingUpdate(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, asg *expinfrav1.AutoScalingGroup) (ctrl.Result, error) {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	if !awsMachinePool.RollingUpdateEnabled() {
+		awsMachinePool.Status.RollingUpdate = nil
+		conditions.Delete(awsMachinePool, expinfrav1.InstancesUpToDateCondition)
+		return ctrl.Result{}, nil
+	}
+
+	outdated, err := asgsvc.OutdatedInstances(machinePoolScope, asg)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if len(outdated) == 0 {
+		if awsMachinePool.Status.RollingUpdate != nil {
+			r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "SuccessfulRollingUpdate", "Replaced all outdated instances of ASG %q", machinePoolScope.Name())
+			awsMachinePool.Status.RollingUpdate = nil
+		}
+		conditions.MarkTrue(awsMachinePool, expinfrav1.InstancesUpToDateCondition)
+		return ctrl.Result{}, nil
+	}
+
+	status := awsMachinePool.Status.RollingUpdate
+	if status == nil {
+		status = &expinfrav1.RollingUpdateStatus{Surge: rollingUpdateSurge(machinePoolScope)}
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "StartedRollingUpdate", "Replacing %d outdated instances of ASG %q", len(outdated), machinePoolScope.Name())
+	}
+	status.AvailabilityZone = rollingUpdateAvailabilityZone(status.AvailabilityZone, outdated)
+	status.OutdatedInstances = int32(len(outdated))
+	awsMachinePool.Status.RollingUpdate = status
+	conditions.MarkFalse(awsMachinePool, expinfrav1.InstancesUpToDateCondition, expinfrav1.RollingUpdateInProgressReason, clusterv1.ConditionSeverityInfo,
+		"%d outdated instances, replacing the ones in availability zone %s", len(outdated), status.AvailabilityZone)
+
+	// Wait for the ASG to be scaled up by the surge before taking instances out of service.
+	if desiredCapacity := machinePoolScope.DesiredCapacity(); desiredCapacity == nil || asg.DesiredCapacity == nil || *asg.DesiredCapacity != *desiredCapacity {
+		return ctrl.Result{RequeueAfter: rollingUpdateRequeuePeriod}, nil
+	}
+
+	var replicas int32
+	if machinePoolScope.MachinePool.Spec.Replicas != nil {
+		replicas = *machinePoolScope.MachinePool.Spec.Replicas
+	}
+	rollingUpdate := awsMachinePool.Spec.RefreshPreferences.RollingUpdate
+	budget := rollingUpdateBudget(asg.Instances, replicas, status.Surge, rollingUpdate.MaxUnavailableOrDefault())
+
+	// Drains carry on across reconciliations: the instances already being drained come first, and the others
+	// start draining while the budget allows it.
+	candidates := make([]infrav1.Instance, 0, len(outdated))
+	for _, instance := range outdated {
+		if instance.AvailabilityZone == status.AvailabilityZone && instance.State == asgInstanceInService {
+			candidates = append(candidates, instance)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return drainStartTime(status, candidates[i].ID) != nil && drainStartTime(status, candidates[j].ID) == nil
+	})
+
+	draining := make([]expinfrav1.DrainingInstance, 0, len(status.DrainingInstances))
+	var kubeClient kubernetes.Interface
+	for i, instance := range candidates {
+		if budget <= 0 {
+			break
+		}
+
+		if kubeClient == nil {
+			if kubeClient, err = r.workloadClusterClient(ctx, machinePoolScope); err != nil {
+				r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedDrainNode", "Failed to drain the node of instance %q: %v", instance.ID, err)
+				conditions.MarkFalse(awsMachinePool, expinfrav1.InstancesUpToDateCondition, expinfrav1.NodeDrainFailedReason, clusterv1.ConditionSeverityWarning,
+					"failed to drain the node of instance %s: %v", instance.ID, err)
+				return ctrl.Result{RequeueAfter: rollingUpdateRequeuePeriod}, nil
+			}
+		}
+
+		// The start of a drain is kept across reconciliations, so that the drain timeout bounds the drain as a whole.
+		startTime := drainStartTime(status, instance.ID)
+		drained, err := drainInstanceNode(ctx, kubeClient, instance.ID)
+		timedOut := startTime != nil && time.Since(startTime.Time) > rollingUpdate.DrainTimeoutOrDefault()
+		switch {
+		case drained:
+			machinePoolScope.Info("Drained node of outdated instance", "instance", instance.ID)
+		case timedOut:
+			// The instance is terminated even though its node isn't drained, so that a pod disruption budget
+			// that never allows the eviction doesn't hold the rolling update forever.
+			r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "ForcedDrainNode", "Terminating instance %q whose node wasn't drained within %s", instance.ID, rollingUpdate.DrainTimeoutOrDefault())
+			machinePoolScope.Info("Timed out draining node of outdated instance, terminating it", "instance", instance.ID, "error", err)
+		case err != nil:
+			r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedDrainNode", "Failed to drain the node of instance %q: %v", instance.ID, err)
+			conditions.MarkFalse(awsMachinePool, expinfrav1.InstancesUpToDateCondition, expinfrav1.NodeDrainFailedReason, clusterv1.ConditionSeverityWarning,
+				"failed to drain the node of instance %s: %v", instance.ID, err)
+			// A drain that failed to start doesn't take the instance out of service, and leaves the budget
+			// to the next instances; a drain already under way is retried at the next reconciliation.
+			if startTime != nil {
+				budget--
+				draining = append(draining, expinfrav1.DrainingInstance{ID: instance.ID, StartTime: *startTime})
+			}
+			continue
+		default:
+			budget--
+			if startTime == nil {
+				now := metav1.Now()
+				startTime = &now
+			}
+			draining = append(draining, expinfrav1.DrainingInstance{ID: instance.ID, StartTime: *startTime})
+			continue
+		}
+		budget--
+
+		// The last outdated instances are terminated without a replacement, which takes the surge back off
+		// the desired capacity of the ASG.
+		decrementDesiredCapacity := status.OutdatedInstances <= status.Surge
+		if err := asgsvc.TerminateASGInstance(machinePoolScope, instance.ID, decrementDesiredCapacity); err != nil {
+			// The drains not reconciled yet keep their start time.
+			for _, remaining := range candidates[i:] {
+				if startTime := drainStartTime(status, remaining.ID); startTime != nil {
+					draining = append(draining, expinfrav1.DrainingInstance{ID: remaining.ID, StartTime: *startTime})
+				}
+			}
+			status.DrainingInstances = draining
+			return ctrl.Result{}, err
+		}
+		if decrementDesiredCapacity {
+			status.Surge--
+		}
+		status.OutdatedInstances--
+	}
+	status.DrainingInstances = draining
+
+	if len(draining) > 0 {
+		return ctrl.Result{RequeueAfter: drainRequeuePeriod}, nil
+	}
+	return ctrl.Result{RequeueAfter: rollingUpdateRequeuePeriod}, nil
+}
+
+// asgInstanceInService is the lifecycle state of the instances of an ASG that are in service.
+const asgInstanceInService = infrav1.InstanceState(autoscaling.LifecycleStateInService)
+
+// rollingUpdateSurge returns the number of instances the ASG is scaled up by while its outdated instances are
+// replaced. The ASG isn't scaled beyond its maximum size, nor when its replicas are managed externally.
+func rollingUpdateSurge(machinePoolScope *scope.MachinePoolScope) int32 {
+	replicas := machinePoolScope.MachinePool.Spec.Replicas
+	if replicas == nil || scope.ReplicasExternallyManaged(machinePoolScope.MachinePool) {
+		return 0
+	}
+
+	surge := machinePoolScope.AWSMachinePool.Spec.RefreshPreferences.RollingUpdate.MaxSurgeOrDefault()
+	if headroom := machinePoolScope.AWSMachinePool.Spec.MaxSize - *replicas; surge > headroom {
+		surge = headroom
+	}
+	if surge < 0 {
+		return 0
+	}
+	return surge
+}
+
+// rollingUpdateAvailabilityZone returns the availability zone whose outdated instances are replaced: the
+// current one until all its instances are replaced, then the first remaining one by name.
+func rollingUpdateAvailabilityZone(current string, outdated []infrav1.Instance) string {
+	zones := make([]string, 0, len(outdated))
+	for _, instance := range outdated {
+		if instance.AvailabilityZone == current {
+			return current
+		}
+		zones = append(zones, instance.AvailabilityZone)
+	}
+	sort.Strings(zones)
+	return zones[0]
+}
+
+// rollingUpdateBudget returns how many instances can be taken out of service, so that the in-service instances
+// don't fall below the replicas of the pool minus the maximum number of unavailable instances. When neither a
+// surge nor an unavailable instance is possible, one instance at a time can be taken out of service.
+func rollingUpdateBudget(instances []infrav1.Instance, replicas, surge, maxUnavailable int32) int32 {
+	var inService int32
+	for _, instance := range instances {
+		if instance.State == asgInstanceInService {
+			inService++
+		}
+	}
+
+	minAvailable := replicas - maxUnavailable
+	if surge == 0 && maxUnavailable == 0 {
+		minAvailable = replicas - 1
+	}
+	return inService - minAvailable
+}
+
+// drainRequeuePeriod is how often a rolling update is reconciled while nodes are being drained.
+const drainRequeuePeriod = 5 * time.Second
+
+// drainStartTime returns when the node of an instance started being drained, or nil if it isn't being drained.
+func drainStartTime(status *expinfrav1.RollingUpdateStatus, instanceID string) *metav1.Time {
+	for i := range status.DrainingInstances {
+		if status.DrainingInstances[i].ID == instanceID {
+			return &status.DrainingInstances[i].StartTime
+		}
+	}
+	return nil
+}
+
+// workloadClusterClient returns a client of the workload cluster of a machine pool.
+func (r *AWSMachinePoolReconciler) workloadClusterClient(ctx context.Context, machinePoolScope *scope.MachinePoolScope) (kubernetes.Interface, error) {
+	if r.kubeClientFactory != nil {
+		return r.kubeClientFactory(ctx, machinePoolScope)
+	}
+
+	restConfig, err := remote.RESTConfig(ctx, "awsmachinepool", r.Client, util.ObjectKey(machinePoolScope.Cluster))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a client for the workload cluster")
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a client for the workload cluster")
+	}
+	return kubeClient, nil
+}
+
+// drainInstanceNode cordons the node of an instance in the workload cluster and requests the eviction of its
+// pods, so that their pod disruption budgets are honored, without waiting for them to go away. It reports
+// whether the node is drained; instances without a node have nothing to drain.
+func drainInstanceNode(ctx context.Context, kubeClient kubernetes.Interface, instanceID string) (bool, error) {
+	node, err := findInstanceNode(ctx, kubeClient, instanceID)
+	if err != nil || node == nil {
+		return err == nil, err
+	}
+
+	if !node.Spec.Unschedulable {
+		patch := []byte(`{"spec":{"unschedulable":true}}`)
+		if _, err := kubeClient.CoreV1().Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			return false, errors.Wrapf(err, "failed to cordon node %q", node.Name)
+		}
+	}
+
+	pods, err := drainablePods(ctx, kubeClient, node.Name)
+	if err != nil {
+		return false, err
+	}
+	if len(pods) == 0 {
+		return true, nil
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+		// Evictions that would violate a pod disruption budget are rejected, and retried at the next reconciliation.
+		if err := kubeClient.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction); err != nil && !apierrors.IsNotFound(err) && !apierrors.IsTooManyRequests(err) {
+			return false, errors.Wrapf(err, "failed to evict pod %s", klog.KObj(pod))
+		}
+	}
+	return false, nil
+}
+
+// drainablePods returns the pods of a node that are evicted when the node is drained: all but the completed
+// pods, the mirror pods of static pods and the pods of DaemonSets, which would be scheduled again on the node.
+func drainablePods(ctx context.Context, kubeClient kubernetes.Interface, nodeName string) ([]corev1.Pod, error) {
+	pods, err := kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list pods of node %q", nodeName)
+	}
+
+	var drainable []corev1.Pod
+	for _, pod := range pods.Items {
+		if isDrainablePod(&pod) {
+			drainable = append(drainable, pod)
+		}
+	}
+	return drainable, nil
+}
+
+func isDrainablePod(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	if controllerRef := metav1.GetControllerOf(pod); controllerRef != nil && controllerRef.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
+
+// findInstanceNode returns the node of the workload cluster with the provider ID of an instance, if any.
+func findInstanceNode(ctx context.Context, kubeClient kubernetes.Interface, instanceID string) (*corev1.Node, error) {
+	opts := metav1.ListOptions{}
+	for {
+		nodes, err := kubeClient.CoreV1().Nodes().List(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list nodes")
+		}
+
+		for i := range nodes.Items {
+			if strings.HasSuffix(nodes.Items[i].Spec.ProviderID, "/"+instanceID) {
+				return &nodes.Items[i], nil
+			}
+		}
+
+		if nodes.Continue == "" {
+			return nil, nil
+		}
+		opts.Continue = nodes.Continue
+	}
+}
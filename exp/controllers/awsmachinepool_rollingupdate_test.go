@@ -0,0 +1,474 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileRollingUpdate(t *testing.T) {
+	newMachinePoolScope := func(rollingUpdate *expinfrav1.MachinePoolRollingUpdate, status *expinfrav1.RollingUpdateStatus) *scope.MachinePoolScope {
+		return &scope.MachinePoolScope{
+			MachinePool: &expclusterv1.MachinePool{
+				Spec: expclusterv1.MachinePoolSpec{Replicas: pointer.Int32(2)},
+			},
+			AWSMachinePool: &expinfrav1.AWSMachinePool{
+				Spec: expinfrav1.AWSMachinePoolSpec{
+					MaxSize:            4,
+					RefreshPreferences: &expinfrav1.RefreshPreferences{RollingUpdate: rollingUpdate},
+				},
+				Status: expinfrav1.AWSMachinePoolStatus{RollingUpdate: status},
+			},
+		}
+	}
+
+	t.Run("should clear the status when the rolling update is disabled", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		machinePoolScope := newMachinePoolScope(nil, &expinfrav1.RollingUpdateStatus{Surge: 1})
+		r := &AWSMachinePoolReconciler{Recorder: record.NewFakeRecorder(1)}
+		result, err := r.reconcileRollingUpdate(context.TODO(), machinePoolScope, mock_services.NewMockASGInterface(mockCtrl), &expinfrav1.AutoScalingGroup{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(BeZero())
+		g.Expect(machinePoolScope.AWSMachinePool.Status.RollingUpdate).To(BeNil())
+	})
+
+	t.Run("should complete the rolling update when no instance is outdated", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		machinePoolScope := newMachinePoolScope(&expinfrav1.MachinePoolRollingUpdate{}, &expinfrav1.RollingUpdateStatus{AvailabilityZone: "us-east-1a"})
+		asg := &expinfrav1.AutoScalingGroup{}
+		asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+		asgSvc.EXPECT().OutdatedInstances(machinePoolScope, asg).Return(nil, nil)
+
+		r := &AWSMachinePoolReconciler{Recorder: record.NewFakeRecorder(1)}
+		result, err := r.reconcileRollingUpdate(context.TODO(), machinePoolScope, asgSvc, asg)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(BeZero())
+		g.Expect(machinePoolScope.AWSMachinePool.Status.RollingUpdate).To(BeNil())
+		g.Expect(conditions.IsTrue(machinePoolScope.AWSMachinePool, expinfrav1.InstancesUpToDateCondition)).To(BeTrue())
+	})
+
+	t.Run("should start the rolling update and wait for the surge", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		machinePoolScope := newMachinePoolScope(&expinfrav1.MachinePoolRollingUpdate{MaxSurge: pointer.Int32(1)}, nil)
+		asg := &expinfrav1.AutoScalingGroup{DesiredCapacity: pointer.Int32(2)}
+		asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+		asgSvc.EXPECT().OutdatedInstances(machinePoolScope, asg).Return([]infrav1.Instance{
+			{ID: "i-1", AvailabilityZone: "us-east-1b"},
+			{ID: "i-2", AvailabilityZone: "us-east-1a"},
+		}, nil)
+
+		r := &AWSMachinePoolReconciler{Recorder: record.NewFakeRecorder(1)}
+		result, err := r.reconcileRollingUpdate(context.TODO(), machinePoolScope, asgSvc, asg)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(rollingUpdateRequeuePeriod))
+		g.Expect(machinePoolScope.AWSMachinePool.Status.RollingUpdate).To(Equal(&expinfrav1.RollingUpdateStatus{
+			AvailabilityZone:  "us-east-1a",
+			Surge:             1,
+			OutdatedInstances: 2,
+		}))
+		g.Expect(machinePoolScope.DesiredCapacity()).To(Equal(pointer.Int32(3)))
+		g.Expect(conditions.GetReason(machinePoolScope.AWSMachinePool, expinfrav1.InstancesUpToDateCondition)).To(Equal(expinfrav1.RollingUpdateInProgressReason))
+	})
+
+	t.Run("should drain the nodes across reconciliations before terminating their instances", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		machinePoolScope := newMachinePoolScope(&expinfrav1.MachinePoolRollingUpdate{}, &expinfrav1.RollingUpdateStatus{AvailabilityZone: "us-east-1a", Surge: 1})
+		outdated := []infrav1.Instance{
+			{ID: "i-1", AvailabilityZone: "us-east-1a", State: asgInstanceInService},
+			{ID: "i-2", AvailabilityZone: "us-east-1a", State: asgInstanceInService},
+		}
+		asg := &expinfrav1.AutoScalingGroup{
+			DesiredCapacity: pointer.Int32(3),
+			Instances:       append(outdated, infrav1.Instance{ID: "i-3", AvailabilityZone: "us-east-1a", State: asgInstanceInService}),
+		}
+		asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+		asgSvc.EXPECT().OutdatedInstances(machinePoolScope, asg).Return(outdated, nil).Times(2)
+
+		kubeClient := kubefake.NewSimpleClientset(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-1"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}, Spec: corev1.PodSpec{NodeName: "node-1"}},
+		)
+		var evictions int
+		kubeClient.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "eviction" {
+				return false, nil, nil
+			}
+			evictions++
+			return true, nil, nil
+		})
+
+		r := &AWSMachinePoolReconciler{
+			Recorder: record.NewFakeRecorder(1),
+			kubeClientFactory: func(context.Context, *scope.MachinePoolScope) (kubernetes.Interface, error) {
+				return kubeClient, nil
+			},
+		}
+
+		// The node is cordoned and its pods are evicted, without waiting for them to go away.
+		result, err := r.reconcileRollingUpdate(context.TODO(), machinePoolScope, asgSvc, asg)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(drainRequeuePeriod))
+		g.Expect(evictions).To(Equal(1))
+		status := machinePoolScope.AWSMachinePool.Status.RollingUpdate
+		g.Expect(status.DrainingInstances).To(HaveLen(1))
+		g.Expect(status.DrainingInstances[0].ID).To(Equal("i-1"))
+		node, err := kubeClient.CoreV1().Nodes().Get(context.TODO(), "node-1", metav1.GetOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(node.Spec.Unschedulable).To(BeTrue())
+
+		// Once the pods are gone, the instance is terminated.
+		g.Expect(kubeClient.CoreV1().Pods("default").Delete(context.TODO(), "app", metav1.DeleteOptions{})).To(Succeed())
+		asgSvc.EXPECT().TerminateASGInstance(machinePoolScope, "i-1", false).Return(nil)
+
+		result, err = r.reconcileRollingUpdate(context.TODO(), machinePoolScope, asgSvc, asg)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(rollingUpdateRequeuePeriod))
+		g.Expect(status.DrainingInstances).To(BeEmpty())
+		g.Expect(status.OutdatedInstances).To(Equal(int32(1)))
+	})
+
+	t.Run("should terminate the instance when its drain times out", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		machinePoolScope := newMachinePoolScope(&expinfrav1.MachinePoolRollingUpdate{}, &expinfrav1.RollingUpdateStatus{
+			AvailabilityZone:  "us-east-1a",
+			Surge:             1,
+			OutdatedInstances: 1,
+			DrainingInstances: []expinfrav1.DrainingInstance{{ID: "i-1", StartTime: metav1.NewTime(time.Now().Add(-2 * expinfrav1.DefaultRollingUpdateDrainTimeout))}},
+		})
+		outdated := []infrav1.Instance{{ID: "i-1", AvailabilityZone: "us-east-1a", State: asgInstanceInService}}
+		asg := &expinfrav1.AutoScalingGroup{
+			DesiredCapacity: pointer.Int32(3),
+			Instances: append(outdated,
+				infrav1.Instance{ID: "i-2", AvailabilityZone: "us-east-1a", State: asgInstanceInService},
+				infrav1.Instance{ID: "i-3", AvailabilityZone: "us-east-1a", State: asgInstanceInService}),
+		}
+		asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+		asgSvc.EXPECT().OutdatedInstances(machinePoolScope, asg).Return(outdated, nil)
+		asgSvc.EXPECT().TerminateASGInstance(machinePoolScope, "i-1", true).Return(nil)
+
+		kubeClient := kubefake.NewSimpleClientset(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-1"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}, Spec: corev1.PodSpec{NodeName: "node-1"}},
+		)
+		kubeClient.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "eviction" {
+				return false, nil, nil
+			}
+			return true, nil, apierrors.NewTooManyRequests("the pod disruption budget doesn't allow the eviction", 10)
+		})
+
+		recorder := record.NewFakeRecorder(1)
+		r := &AWSMachinePoolReconciler{
+			Recorder: recorder,
+			kubeClientFactory: func(context.Context, *scope.MachinePoolScope) (kubernetes.Interface, error) {
+				return kubeClient, nil
+			},
+		}
+		result, err := r.reconcileRollingUpdate(context.TODO(), machinePoolScope, asgSvc, asg)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(rollingUpdateRequeuePeriod))
+		status := machinePoolScope.AWSMachinePool.Status.RollingUpdate
+		g.Expect(status.DrainingInstances).To(BeEmpty())
+		g.Expect(status.OutdatedInstances).To(BeZero())
+		g.Expect(status.Surge).To(BeZero())
+		g.Expect(<-recorder.Events).To(ContainSubstring("ForcedDrainNode"))
+	})
+
+	t.Run("should keep the start of a drain that fails and not count failed drains against the budget", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		startTime := metav1.NewTime(time.Now().Add(-expinfrav1.DefaultRollingUpdateDrainTimeout / 2).Truncate(time.Second))
+		machinePoolScope := newMachinePoolScope(&expinfrav1.MachinePoolRollingUpdate{}, &expinfrav1.RollingUpdateStatus{
+			AvailabilityZone:  "us-east-1a",
+			Surge:             1,
+			DrainingInstances: []expinfrav1.DrainingInstance{{ID: "i-1", StartTime: startTime}},
+		})
+		outdated := []infrav1.Instance{
+			{ID: "i-1", AvailabilityZone: "us-east-1a", State: asgInstanceInService},
+			{ID: "i-2", AvailabilityZone: "us-east-1a", State: asgInstanceInService},
+			{ID: "i-3", AvailabilityZone: "us-east-1a", State: asgInstanceInService},
+		}
+		asg := &expinfrav1.AutoScalingGroup{
+			DesiredCapacity: pointer.Int32(3),
+			Instances:       append(outdated, infrav1.Instance{ID: "i-4", AvailabilityZone: "us-east-1a", State: asgInstanceInService}),
+		}
+		asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+		asgSvc.EXPECT().OutdatedInstances(machinePoolScope, asg).Return(outdated, nil)
+
+		kubeClient := kubefake.NewSimpleClientset(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-1"}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-2"}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-3"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-3"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app-3", Namespace: "default"}, Spec: corev1.PodSpec{NodeName: "node-3"}},
+		)
+		kubeClient.PrependReactor("patch", "nodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if name := action.(clienttesting.PatchAction).GetName(); name != "node-3" {
+				return true, nil, errors.Errorf("failed to patch node %s", name)
+			}
+			return false, nil, nil
+		})
+		kubeClient.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return action.GetSubresource() == "eviction", nil, nil
+		})
+
+		r := &AWSMachinePoolReconciler{
+			Recorder: record.NewFakeRecorder(2),
+			kubeClientFactory: func(context.Context, *scope.MachinePoolScope) (kubernetes.Interface, error) {
+				return kubeClient, nil
+			},
+		}
+
+		// The budget allows two instances out of service: the failed drain of i-1 keeps its start time and
+		// counts, the one of i-2 fails to start and doesn't, which leaves room for i-3.
+		result, err := r.reconcileRollingUpdate(context.TODO(), machinePoolScope, asgSvc, asg)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(drainRequeuePeriod))
+		draining := machinePoolScope.AWSMachinePool.Status.RollingUpdate.DrainingInstances
+		g.Expect(draining).To(HaveLen(2))
+		g.Expect(draining[0]).To(Equal(expinfrav1.DrainingInstance{ID: "i-1", StartTime: startTime}))
+		g.Expect(draining[1].ID).To(Equal("i-3"))
+		g.Expect(conditions.GetReason(machinePoolScope.AWSMachinePool, expinfrav1.InstancesUpToDateCondition)).To(Equal(expinfrav1.NodeDrainFailedReason))
+	})
+}
+
+func TestRollingUpdateSurge(t *testing.T) {
+	tests := []struct {
+		name     string
+		replicas *int32
+		maxSize  int32
+		maxSurge *int32
+		external bool
+		want     int32
+	}{
+		{
+			name:     "should default to a surge of one instance",
+			replicas: pointer.Int32(2),
+			maxSize:  4,
+			want:     1,
+		},
+		{
+			name:     "should not surge beyond the maximum size",
+			replicas: pointer.Int32(3),
+			maxSize:  4,
+			maxSurge: pointer.Int32(3),
+			want:     1,
+		},
+		{
+			name:     "should not surge at the maximum size",
+			replicas: pointer.Int32(4),
+			maxSize:  4,
+			want:     0,
+		},
+		{
+			name:     "should not surge when the replicas are externally managed",
+			replicas: pointer.Int32(2),
+			maxSize:  4,
+			external: true,
+			want:     0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machinePool := &expclusterv1.MachinePool{Spec: expclusterv1.MachinePoolSpec{Replicas: tt.replicas}}
+			if tt.external {
+				machinePool.Annotations = map[string]string{scope.ReplicasManagedByAnnotation: scope.ExternalAutoscalerReplicasManagedByAnnotationValue}
+			}
+			machinePoolScope := &scope.MachinePoolScope{
+				MachinePool: machinePool,
+				AWSMachinePool: &expinfrav1.AWSMachinePool{
+					Spec: expinfrav1.AWSMachinePoolSpec{
+						MaxSize: tt.maxSize,
+						RefreshPreferences: &expinfrav1.RefreshPreferences{
+							RollingUpdate: &expinfrav1.MachinePoolRollingUpdate{MaxSurge: tt.maxSurge},
+						},
+					},
+				},
+			}
+			g.Expect(rollingUpdateSurge(machinePoolScope)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestRollingUpdateAvailabilityZone(t *testing.T) {
+	outdated := []infrav1.Instance{
+		{ID: "i-1", AvailabilityZone: "us-east-1c"},
+		{ID: "i-2", AvailabilityZone: "us-east-1b"},
+		{ID: "i-3", AvailabilityZone: "us-east-1c"},
+	}
+
+	g := NewWithT(t)
+	g.Expect(rollingUpdateAvailabilityZone("", outdated)).To(Equal("us-east-1b"))
+	g.Expect(rollingUpdateAvailabilityZone("us-east-1c", outdated)).To(Equal("us-east-1c"))
+	g.Expect(rollingUpdateAvailabilityZone("us-east-1a", outdated)).To(Equal("us-east-1b"))
+}
+
+func TestRollingUpdateBudget(t *testing.T) {
+	instances := func(inService, pending int) []infrav1.Instance {
+		var result []infrav1.Instance
+		for i := 0; i < inService; i++ {
+			result = append(result, infrav1.Instance{State: asgInstanceInService})
+		}
+		for i := 0; i < pending; i++ {
+			result = append(result, infrav1.Instance{State: infrav1.InstanceState("Pending")})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name           string
+		instances      []infrav1.Instance
+		surge          int32
+		maxUnavailable int32
+		want           int32
+	}{
+		{
+			name:      "should replace as many instances as surged",
+			instances: instances(4, 0),
+			surge:     1,
+			want:      1,
+		},
+		{
+			name:      "should wait for the surged instances to be in service",
+			instances: instances(3, 1),
+			surge:     1,
+			want:      0,
+		},
+		{
+			name:           "should add the maximum number of unavailable instances",
+			instances:      instances(4, 0),
+			surge:          1,
+			maxUnavailable: 1,
+			want:           2,
+		},
+		{
+			name:      "should replace one instance at a time without surge nor unavailable instances",
+			instances: instances(3, 0),
+			want:      1,
+		},
+		{
+			name:      "should wait for the replacement without surge nor unavailable instances",
+			instances: instances(2, 1),
+			want:      0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(rollingUpdateBudget(tt.instances, 3, tt.surge, tt.maxUnavailable)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestIsDrainablePod(t *testing.T) {
+	isController := true
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{
+			name: "should drain a running pod",
+			pod:  &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+			want: true,
+		},
+		{
+			name: "should not drain a completed pod",
+			pod:  &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+		},
+		{
+			name: "should not drain a mirror pod",
+			pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{corev1.MirrorPodAnnotationKey: "hash"},
+			}},
+		},
+		{
+			name: "should not drain a DaemonSet pod",
+			pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "daemon", Controller: &isController}},
+			}},
+		},
+		{
+			name: "should drain a ReplicaSet pod",
+			pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "app", Controller: &isController}},
+			}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(isDrainablePod(tt.pod)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestFindInstanceNode(t *testing.T) {
+	g := NewWithT(t)
+	kubeClient := kubefake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-1"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-12"}},
+	)
+
+	node, err := findInstanceNode(context.TODO(), kubeClient, "i-12")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(node.Name).To(Equal("node-2"))
+
+	node, err = findInstanceNode(context.TODO(), kubeClient, "i-2")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(node).To(BeNil())
+}
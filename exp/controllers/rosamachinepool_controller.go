@@ -0,0 +1,473 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/internal/requeue"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// rosaNodePoolLabel is the label set on the nodes of the workload cluster with the node pool they belong to.
+const rosaNodePoolLabel = "hypershift.openshift.io/nodePool"
+
+// rosaNodePoolRequeueAfter is how long to wait before checking again on a node
+// pool that is still scaling or being deleted.
+const rosaNodePoolRequeueAfter = 30 * time.Second
+
+// ROSAMachinePoolReconciler reconciles a ROSAMachinePool object.
+type ROSAMachinePoolReconciler struct {
+	client.Client
+	Recorder         record.EventRecorder
+	WatchFilterValue string
+
+	// SyncPeriod is the interval at which ROSAMachinePools are reconciled when nothing changed.
+	// If zero, the manager's sync period applies.
+	SyncPeriod time.Duration
+
+	// NewOCMClient creates the OCM client used for each reconciliation.
+	// It defaults to rosa.NewClient.
+	NewOCMClient func(params rosa.ClientParams) (rosa.Client, error)
+
+	// Tracker provides the clients of the workload clusters used to list the nodes of the node pools.
+	Tracker *remote.ClusterCacheTracker
+}
+
+// SetupWithManager is used to setup the controller.
+func (r *ROSAMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	log := logger.FromContext(ctx)
+
+	if r.NewOCMClient == nil {
+		r.NewOCMClient = rosa.NewClient
+	}
+
+	gvk, err := apiutil.GVKForObject(new(expinfrav1.ROSAMachinePool), mgr.GetScheme())
+	if err != nil {
+		return errors.Wrapf(err, "failed to find GVK for ROSAMachinePool")
+	}
+	rosaControlPlaneToRosaMachinePoolMap := rosaControlPlaneToRosaMachinePoolMapFunc(r.Client, gvk, log)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&expinfrav1.ROSAMachinePool{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log.GetLogger(), r.WatchFilterValue)).
+		Watches(
+			&source.Kind{Type: &expclusterv1.MachinePool{}},
+			handler.EnqueueRequestsFromMapFunc(machinePoolToInfrastructureMapFunc(gvk)),
+		).
+		Watches(
+			&source.Kind{Type: &rosacontrolplanev1.ROSAControlPlane{}},
+			handler.EnqueueRequestsFromMapFunc(rosaControlPlaneToRosaMachinePoolMap),
+		).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=rosacontrolplanes;rosacontrolplanes/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=rosamachinepools,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=rosamachinepools/status,verbs=get;update;patch
+
+// Reconcile reconciles ROSAMachinePools.
+func (r *ROSAMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := logger.FromContext(ctx)
+
+	rosaMachinePool := &expinfrav1.ROSAMachinePool{}
+	if err := r.Get(ctx, req.NamespacedName, rosaMachinePool); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	machinePool, err := getOwnerMachinePool(ctx, r.Client, rosaMachinePool.ObjectMeta)
+	if err != nil {
+		log.Error(err, "Failed to retrieve owner MachinePool from the API Server")
+		return ctrl.Result{}, err
+	}
+	if machinePool == nil {
+		log.Info("MachinePool Controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("MachinePool", klog.KObj(machinePool))
+
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machinePool.ObjectMeta)
+	if err != nil {
+		log.Info("Failed to retrieve Cluster from MachinePool")
+		return reconcile.Result{}, nil
+	}
+
+	if annotations.IsPaused(cluster, rosaMachinePool) {
+		log.Info("Reconciliation is paused for this object")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", klog.KObj(cluster))
+
+	controlPlaneKey := client.ObjectKey{
+		Namespace: rosaMachinePool.Namespace,
+		Name:      cluster.Spec.ControlPlaneRef.Name,
+	}
+	controlPlane := &rosacontrolplanev1.ROSAControlPlane{}
+	if err := r.Client.Get(ctx, controlPlaneKey, controlPlane); err != nil {
+		log.Info("Failed to retrieve ControlPlane from MachinePool")
+		return reconcile.Result{}, nil
+	}
+
+	machinePoolScope, err := scope.NewRosaMachinePoolScope(scope.RosaMachinePoolScopeParams{
+		Client:          r.Client,
+		Logger:          log,
+		ControllerName:  "rosamachinepool",
+		Cluster:         cluster,
+		ControlPlane:    controlPlane,
+		MachinePool:     machinePool,
+		RosaMachinePool: rosaMachinePool,
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create scope")
+	}
+
+	defer func() {
+		conditions.SetSummary(machinePoolScope.RosaMachinePool, conditions.WithConditions(expinfrav1.RosaMachinePoolReadyCondition), conditions.WithStepCounter())
+
+		if err := machinePoolScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	if !rosaMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, machinePoolScope)
+	}
+
+	if !controlPlane.Status.Ready {
+		log.Info("Control plane is not ready yet")
+		conditions.MarkFalse(rosaMachinePool, expinfrav1.RosaMachinePoolReadyCondition, expinfrav1.WaitingForRosaControlPlaneReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, nil
+	}
+
+	result, err := r.reconcileNormal(ctx, machinePoolScope)
+	return requeue.AfterSyncPeriod(r.SyncPeriod, result, err)
+}
+
+func (r *ROSAMachinePoolReconciler) reconcileNormal(ctx context.Context, machinePoolScope *scope.RosaMachinePoolScope) (ctrl.Result, error) {
+	machinePoolScope.Info("Reconciling ROSAMachinePool")
+
+	rosaMachinePool := machinePoolScope.RosaMachinePool
+
+	if controllerutil.AddFinalizer(rosaMachinePool, expinfrav1.RosaMachinePoolFinalizer) {
+		if err := machinePoolScope.PatchObject(); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	ocmClient, err := r.ocmClient(ctx, machinePoolScope)
+	if err != nil {
+		conditions.MarkFalse(rosaMachinePool, expinfrav1.RosaMachinePoolReadyCondition, expinfrav1.RosaMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	desired, err := nodePoolFromSpec(machinePoolScope)
+	if err != nil {
+		conditions.MarkFalse(rosaMachinePool, expinfrav1.RosaMachinePoolReadyCondition, expinfrav1.RosaMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	nodePool, err := ocmClient.GetNodePool(ctx, machinePoolScope.ClusterID(), machinePoolScope.NodePoolName())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if nodePool == nil {
+		nodePool, err = ocmClient.CreateNodePool(ctx, machinePoolScope.ClusterID(), desired)
+		if err != nil {
+			conditions.MarkFalse(rosaMachinePool, expinfrav1.RosaMachinePoolReadyCondition, expinfrav1.RosaMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			r.Recorder.Eventf(rosaMachinePool, corev1.EventTypeWarning, "FailedCreateNodePool", "Failed to create node pool %q: %v", desired.ID, err)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(rosaMachinePool, corev1.EventTypeNormal, "SuccessfulCreateNodePool", "Created node pool %q", nodePool.ID)
+	} else if update := nodePoolUpdate(nodePool, desired); update != nil {
+		nodePool, err = ocmClient.UpdateNodePool(ctx, machinePoolScope.ClusterID(), update)
+		if err != nil {
+			conditions.MarkFalse(rosaMachinePool, expinfrav1.RosaMachinePoolReadyCondition, expinfrav1.RosaMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	rosaMachinePool.Status.ID = nodePool.ID
+
+	currentReplicas := 0
+	if nodePool.Status != nil {
+		currentReplicas = nodePool.Status.CurrentReplicas
+	}
+	rosaMachinePool.Status.Replicas = int32(currentReplicas)
+
+	var result ctrl.Result
+	if err := r.reconcileProviderIDList(ctx, machinePoolScope, nodePool); err != nil {
+		// The workload cluster may not be reachable yet, this shouldn't block the node pool.
+		machinePoolScope.Error(err, "failed to reconcile the provider ID list")
+		result = ctrl.Result{RequeueAfter: rosaNodePoolRequeueAfter}
+	}
+
+	wantReplicas := 0
+	switch {
+	case desired.Autoscaling != nil:
+		wantReplicas = desired.Autoscaling.MinReplica
+	case desired.Replicas != nil:
+		wantReplicas = *desired.Replicas
+	}
+
+	if currentReplicas < wantReplicas {
+		rosaMachinePool.Status.Ready = false
+		conditions.MarkFalse(rosaMachinePool, expinfrav1.RosaMachinePoolReadyCondition, "Scaling", clusterv1.ConditionSeverityInfo, "%d of %d replicas available", currentReplicas, wantReplicas)
+		return ctrl.Result{RequeueAfter: rosaNodePoolRequeueAfter}, nil
+	}
+
+	rosaMachinePool.Status.Ready = true
+	conditions.MarkTrue(rosaMachinePool, expinfrav1.RosaMachinePoolReadyCondition)
+
+	return result, nil
+}
+
+// reconcileProviderIDList sets the provider IDs of the nodes of the node pool on the
+// ROSAMachinePool, so that the MachinePool is able to match them with its nodes.
+func (r *ROSAMachinePoolReconciler) reconcileProviderIDList(ctx context.Context, machinePoolScope *scope.RosaMachinePoolScope, nodePool *rosa.NodePool) error {
+	remoteClient, err := r.Tracker.GetClient(ctx, util.ObjectKey(machinePoolScope.Cluster))
+	if err != nil {
+		return errors.Wrap(err, "failed to get the workload cluster client")
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := remoteClient.List(ctx, nodes, client.HasLabels{rosaNodePoolLabel}); err != nil {
+		return errors.Wrap(err, "failed to list the nodes of the workload cluster")
+	}
+
+	// HyperShift prefixes the name of the node pool with the name of the cluster.
+	label := fmt.Sprintf("%s-%s", machinePoolScope.RosaClusterName(), nodePool.ID)
+
+	var providerIDs []string
+	for _, node := range nodes.Items {
+		if node.Labels[rosaNodePoolLabel] != label || node.Spec.ProviderID == "" {
+			continue
+		}
+		providerIDs = append(providerIDs, node.Spec.ProviderID)
+	}
+	sort.Strings(providerIDs)
+
+	machinePoolScope.RosaMachinePool.Spec.ProviderIDList = providerIDs
+	return nil
+}
+
+func (r *ROSAMachinePoolReconciler) reconcileDelete(ctx context.Context, machinePoolScope *scope.RosaMachinePoolScope) (ctrl.Result, error) {
+	machinePoolScope.Info("Reconciling deletion of ROSAMachinePool")
+
+	rosaMachinePool := machinePoolScope.RosaMachinePool
+
+	if machinePoolScope.ClusterID() == "" {
+		controllerutil.RemoveFinalizer(rosaMachinePool, expinfrav1.RosaMachinePoolFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	ocmClient, err := r.ocmClient(ctx, machinePoolScope)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	nodePool, err := ocmClient.GetNodePool(ctx, machinePoolScope.ClusterID(), machinePoolScope.NodePoolName())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if nodePool == nil {
+		controllerutil.RemoveFinalizer(rosaMachinePool, expinfrav1.RosaMachinePoolFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	if err := ocmClient.DeleteNodePool(ctx, machinePoolScope.ClusterID(), nodePool.ID); err != nil {
+		r.Recorder.Eventf(rosaMachinePool, corev1.EventTypeWarning, "FailedDeleteNodePool", "Failed to delete node pool %q: %v", nodePool.ID, err)
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Eventf(rosaMachinePool, corev1.EventTypeNormal, "SuccessfulDeleteNodePool", "Started deletion of node pool %q", nodePool.ID)
+
+	return ctrl.Result{RequeueAfter: rosaNodePoolRequeueAfter}, nil
+}
+
+func (r *ROSAMachinePoolReconciler) ocmClient(ctx context.Context, machinePoolScope *scope.RosaMachinePoolScope) (rosa.Client, error) {
+	params, err := machinePoolScope.OCMClientParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.NewOCMClient(params)
+}
+
+// nodePoolFromSpec converts the machine pool spec into the node pool object sent to OCM.
+func nodePoolFromSpec(machinePoolScope *scope.RosaMachinePoolScope) (*rosa.NodePool, error) {
+	spec := machinePoolScope.RosaMachinePool.Spec
+
+	nodePool := &rosa.NodePool{
+		ID:               machinePoolScope.NodePoolName(),
+		AWSNodePool:      &rosa.AWSNodePool{InstanceType: spec.InstanceType},
+		AvailabilityZone: spec.AvailabilityZone,
+		Subnet:           spec.Subnet,
+		Labels:           spec.Labels,
+		AutoRepair:       pointer.Bool(spec.AutoRepair),
+	}
+
+	for _, taint := range spec.Taints {
+		effect, err := converters.TaintEffectToKubernetes(taint.Effect)
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting taint %s", taint.Key)
+		}
+		nodePool.Taints = append(nodePool.Taints, rosa.NodePoolTaint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: string(effect),
+		})
+	}
+
+	if spec.Autoscaling != nil {
+		nodePool.Autoscaling = &rosa.NodePoolAutoscaling{
+			MinReplica: spec.Autoscaling.MinReplicas,
+			MaxReplica: spec.Autoscaling.MaxReplicas,
+		}
+	} else {
+		replicas := 1
+		if machinePoolScope.MachinePool.Spec.Replicas != nil {
+			replicas = int(*machinePoolScope.MachinePool.Spec.Replicas)
+		}
+		nodePool.Replicas = &replicas
+	}
+
+	return nodePool, nil
+}
+
+// nodePoolUpdate returns the patch needed to bring the observed node pool to
+// the desired state, or nil if the mutable fields already match.
+func nodePoolUpdate(observed, desired *rosa.NodePool) *rosa.NodePool {
+	update := &rosa.NodePool{ID: observed.ID}
+	needsUpdate := false
+
+	if desired.Autoscaling != nil {
+		// The replicas of an autoscaled node pool are owned by the autoscaler.
+		if !reflect.DeepEqual(observed.Autoscaling, desired.Autoscaling) {
+			update.Autoscaling = desired.Autoscaling
+			needsUpdate = true
+		}
+	} else if observed.Autoscaling != nil || !reflect.DeepEqual(observed.Replicas, desired.Replicas) {
+		update.Replicas = desired.Replicas
+		needsUpdate = true
+	}
+	if len(observed.Labels) != len(desired.Labels) || (len(desired.Labels) > 0 && !reflect.DeepEqual(observed.Labels, desired.Labels)) {
+		update.Labels = desired.Labels
+		if update.Labels == nil {
+			update.Labels = map[string]string{}
+		}
+		needsUpdate = true
+	}
+	if len(observed.Taints) != len(desired.Taints) || (len(desired.Taints) > 0 && !reflect.DeepEqual(observed.Taints, desired.Taints)) {
+		update.Taints = desired.Taints
+		if update.Taints == nil {
+			update.Taints = []rosa.NodePoolTaint{}
+		}
+		needsUpdate = true
+	}
+	if desired.AutoRepair != nil && (observed.AutoRepair == nil || *observed.AutoRepair != *desired.AutoRepair) {
+		update.AutoRepair = desired.AutoRepair
+		needsUpdate = true
+	}
+
+	if !needsUpdate {
+		return nil
+	}
+	return update
+}
+
+func rosaControlPlaneToRosaMachinePoolMapFunc(c client.Client, gvk schema.GroupVersionKind, log logger.Wrapper) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		ctx := context.Background()
+		rosaControlPlane, ok := o.(*rosacontrolplanev1.ROSAControlPlane)
+		if !ok {
+			klog.Errorf("Expected a ROSAControlPlane but got a %T", o)
+			return nil
+		}
+
+		if !rosaControlPlane.ObjectMeta.DeletionTimestamp.IsZero() {
+			return nil
+		}
+
+		clusterKey, err := GetOwnerClusterKey(rosaControlPlane.ObjectMeta)
+		if err != nil {
+			log.Error(err, "couldn't get ROSA control plane owner ObjectKey")
+			return nil
+		}
+		if clusterKey == nil {
+			return nil
+		}
+
+		machinePoolList := expclusterv1.MachinePoolList{}
+		if err := c.List(
+			ctx, &machinePoolList, client.InNamespace(clusterKey.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: clusterKey.Name},
+		); err != nil {
+			log.Error(err, "couldn't list pools for cluster")
+			return nil
+		}
+
+		mapFunc := machinePoolToInfrastructureMapFunc(gvk)
+
+		var results []ctrl.Request
+		for i := range machinePoolList.Items {
+			rosaMachinePool := mapFunc(&machinePoolList.Items[i])
+			results = append(results, rosaMachinePool...)
+		}
+
+		return results
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+func TestNodePoolUpdate(t *testing.T) {
+	two, three := 2, 3
+
+	testCases := []struct {
+		name     string
+		observed *rosa.NodePool
+		desired  *rosa.NodePool
+		want     *rosa.NodePool
+	}{
+		{
+			name: "node pool is up to date, should not update",
+			observed: &rosa.NodePool{
+				ID:         "workers",
+				Replicas:   &two,
+				Labels:     map[string]string{"role": "worker"},
+				AutoRepair: pointer.Bool(true),
+				Status:     &rosa.NodePoolStatus{CurrentReplicas: 2},
+			},
+			desired: &rosa.NodePool{
+				ID:         "workers",
+				Replicas:   &two,
+				Labels:     map[string]string{"role": "worker"},
+				AutoRepair: pointer.Bool(true),
+			},
+		},
+		{
+			name:     "replicas changed, should only update replicas",
+			observed: &rosa.NodePool{ID: "workers", Replicas: &two},
+			desired:  &rosa.NodePool{ID: "workers", Replicas: &three},
+			want:     &rosa.NodePool{ID: "workers", Replicas: &three},
+		},
+		{
+			name:     "autoscaling enabled, should clear replicas and set autoscaling",
+			observed: &rosa.NodePool{ID: "workers", Replicas: &two},
+			desired:  &rosa.NodePool{ID: "workers", Autoscaling: &rosa.NodePoolAutoscaling{MinReplica: 1, MaxReplica: 5}},
+			want:     &rosa.NodePool{ID: "workers", Autoscaling: &rosa.NodePoolAutoscaling{MinReplica: 1, MaxReplica: 5}},
+		},
+		{
+			name: "autoscaled node pool with observed replicas, should not update",
+			observed: &rosa.NodePool{
+				ID:          "workers",
+				Replicas:    &three,
+				Autoscaling: &rosa.NodePoolAutoscaling{MinReplica: 1, MaxReplica: 5},
+			},
+			desired: &rosa.NodePool{ID: "workers", Autoscaling: &rosa.NodePoolAutoscaling{MinReplica: 1, MaxReplica: 5}},
+		},
+		{
+			name:     "autoscaling disabled, should set replicas",
+			observed: &rosa.NodePool{ID: "workers", Replicas: &two, Autoscaling: &rosa.NodePoolAutoscaling{MinReplica: 1, MaxReplica: 5}},
+			desired:  &rosa.NodePool{ID: "workers", Replicas: &two},
+			want:     &rosa.NodePool{ID: "workers", Replicas: &two},
+		},
+		{
+			name: "taints removed, should send an empty list",
+			observed: &rosa.NodePool{
+				ID:       "workers",
+				Replicas: &two,
+				Taints:   []rosa.NodePoolTaint{{Key: "dedicated", Value: "infra", Effect: "NoSchedule"}},
+			},
+			desired: &rosa.NodePool{ID: "workers", Replicas: &two},
+			want:    &rosa.NodePool{ID: "workers", Taints: []rosa.NodePoolTaint{}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(nodePoolUpdate(tc.observed, tc.desired)).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestReconcileProviderIDList(t *testing.T) {
+	g := NewWithT(t)
+
+	node := func(name, nodePool, providerID string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{rosaNodePoolLabel: nodePool}},
+			Spec:       corev1.NodeSpec{ProviderID: providerID},
+		}
+	}
+	remoteClient := fake.NewClientBuilder().WithObjects(
+		node("node-b", "test-workers", "aws:///us-east-1b/i-b"),
+		node("node-a", "test-workers", "aws:///us-east-1a/i-a"),
+		node("node-c", "test-infra", "aws:///us-east-1a/i-c"),
+		node("node-d", "test-workers", ""),
+		node("node-e", "test-gpu-workers", "aws:///us-east-1a/i-e"),
+	).Build()
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	r := &ROSAMachinePoolReconciler{
+		Tracker: remote.NewTestClusterCacheTracker(logr.Discard(), remoteClient, scheme.Scheme, util.ObjectKey(cluster)),
+	}
+	machinePoolScope := &scope.RosaMachinePoolScope{
+		Cluster: cluster,
+		ControlPlane: &rosacontrolplanev1.ROSAControlPlane{
+			Spec: rosacontrolplanev1.RosaControlPlaneSpec{RosaClusterName: "test"},
+		},
+		RosaMachinePool: &expinfrav1.ROSAMachinePool{},
+	}
+
+	g.Expect(r.reconcileProviderIDList(context.TODO(), machinePoolScope, &rosa.NodePool{ID: "workers"})).To(Succeed())
+	g.Expect(machinePoolScope.RosaMachinePool.Spec.ProviderIDList).To(Equal([]string{"aws:///us-east-1a/i-a", "aws:///us-east-1b/i-b"}))
+}
@@ -19,14 +19,16 @@ package instancestate
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -36,15 +38,42 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
 
-// Ec2InstanceStateLabelKey defines an ec2 instance state label.
-const Ec2InstanceStateLabelKey = "ec2-instance-state"
+const (
+	// Ec2InstanceStateLabelKey defines an ec2 instance state label.
+	Ec2InstanceStateLabelKey = "ec2-instance-state"
+
+	// Ec2InstanceTerminationNoticeAnnotationKey records the latest instance of an AWSMachinePool
+	// that was reported as terminating or about to be interrupted.
+	Ec2InstanceTerminationNoticeAnnotationKey = "ec2-instance-termination-notice"
+
+	// Ec2SpotInterruptionNoticeAnnotationKey records the time an AWSMachine's spot instance
+	// was reported as about to be interrupted.
+	Ec2SpotInterruptionNoticeAnnotationKey = "ec2-spot-interruption-notice"
+
+	// InterruptionImminentKey is the label and annotation set on the Machines whose spot instance is about
+	// to be interrupted. The label selects them, e.g. in drain rules, the annotation records when the
+	// interruption warning was received.
+	InterruptionImminentKey = "aws.infrastructure.cluster.x-k8s.io/interruption-imminent"
+
+	// RebalanceRecommendedKey is the label and annotation set on the Machines whose spot instance is at an
+	// elevated risk of being interrupted, so that they can be drained and replaced first.
+	RebalanceRecommendedKey = "aws.infrastructure.cluster.x-k8s.io/rebalance-recommended"
+
+	// MachinePoolInstanceIDIndex defines the AWSMachinePool index on the IDs of the pool's instances.
+	MachinePoolInstanceIDIndex = ".status.instances.instanceID"
+)
 
 // AwsInstanceStateReconciler reconciles a AwsInstanceState object.
 type AwsInstanceStateReconciler struct {
@@ -57,7 +86,9 @@ type AwsInstanceStateReconciler struct {
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch
-// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;patch;delete
 
 func (r *AwsInstanceStateReconciler) getSQSService(region string) (sqsiface.SQSAPI, error) {
 	if r.sqsServiceFactory != nil {
@@ -111,6 +142,16 @@ func (r *AwsInstanceStateReconciler) Reconcile(ctx context.Context, req ctrl.Req
 }
 
 func (r *AwsInstanceStateReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	if feature.Gates.Enabled(feature.MachinePool) {
+		// Add index to AWSMachinePool to find the pool an instance belongs to
+		if err := mgr.GetFieldIndexer().IndexField(ctx, &expinfrav1.AWSMachinePool{},
+			MachinePoolInstanceIDIndex,
+			r.indexAWSMachinePoolByInstanceID,
+		); err != nil {
+			return errors.Wrap(err, "error setting index fields")
+		}
+	}
+
 	go func() {
 		r.watchQueuesForInstanceEvents()
 	}()
@@ -173,12 +214,30 @@ func (r *AwsInstanceStateReconciler) watchQueuesForInstanceEvents() {
 	}
 }
 
-// processMessage triggers a reconcile on an AWSMachine if its EC2 instance state changed.
+// processMessage dispatches an EventBridge message to the handler for its event type.
 func (r *AwsInstanceStateReconciler) processMessage(ctx context.Context, msg message) {
-	if msg.Source != "aws.ec2" || msg.DetailType != instancestate.Ec2StateChangeNotification || msg.MessageDetail == nil {
+	if msg.MessageDetail == nil {
 		return
 	}
 
+	switch {
+	case msg.Source == "aws.ec2" && msg.DetailType == instancestate.Ec2StateChangeNotification:
+		r.processInstanceStateChange(ctx, msg)
+	case msg.Source == "aws.ec2" && msg.DetailType == instancestate.Ec2SpotInterruptionWarning:
+		// Mark the machines first, so that they are drained accordingly once they are deleted.
+		r.markInstanceMachines(ctx, msg.MessageDetail.InstanceID, InterruptionImminentKey)
+		r.processSpotInterruption(ctx, msg.MessageDetail.InstanceID)
+		r.processMachinePoolInstanceTermination(ctx, msg.MessageDetail.InstanceID)
+	case msg.Source == "aws.ec2" && msg.DetailType == instancestate.Ec2InstanceRebalanceRecommendation:
+		r.processRebalanceRecommendation(ctx, msg.MessageDetail.InstanceID)
+	case msg.Source == "aws.autoscaling" && (msg.DetailType == instancestate.AutoScalingTerminateLifecycleAction ||
+		msg.DetailType == instancestate.AutoScalingTerminateSuccessful):
+		r.processMachinePoolInstanceTermination(ctx, msg.MessageDetail.EC2InstanceID)
+	}
+}
+
+// processInstanceStateChange triggers a reconcile on an AWSMachine if its EC2 instance state changed.
+func (r *AwsInstanceStateReconciler) processInstanceStateChange(ctx context.Context, msg message) {
 	// Fetch the awsMachine instance by InstanceID
 	awsMachines := &infrav1.AWSMachineList{}
 	err := r.List(ctx, awsMachines, client.MatchingFields{controllers.InstanceIDIndex: msg.MessageDetail.InstanceID})
@@ -212,6 +271,242 @@ func (r *AwsInstanceStateReconciler) processMessage(ctx context.Context, msg mes
 	}
 }
 
+// processSpotInterruption annotates the AWSMachine of an interrupted spot instance and deletes
+// its owning Machine, so that the node is drained and replaced before the instance is reclaimed.
+func (r *AwsInstanceStateReconciler) processSpotInterruption(ctx context.Context, instanceID string) {
+	if instanceID == "" {
+		return
+	}
+
+	awsMachines := &infrav1.AWSMachineList{}
+	if err := r.List(ctx, awsMachines, client.MatchingFields{controllers.InstanceIDIndex: instanceID}); err != nil {
+		r.Log.Error(err, "unable to list machines by instance ID", "instanceID", instanceID)
+		return
+	}
+
+	for i := range awsMachines.Items {
+		awsMachine := &awsMachines.Items[i]
+		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if _, ok := awsMachine.Annotations[Ec2SpotInterruptionNoticeAnnotationKey]; ok {
+			// The interruption was already handled.
+			continue
+		}
+
+		patchHelper, err := patch.NewHelper(awsMachine, r.Client)
+		if err != nil {
+			r.Log.Error(err, "unable to create patch helper")
+			continue
+		}
+		annotations := awsMachine.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[Ec2SpotInterruptionNoticeAnnotationKey] = time.Now().UTC().Format(time.RFC3339)
+		awsMachine.SetAnnotations(annotations)
+
+		if err := patchHelper.Patch(ctx, awsMachine); err != nil {
+			r.Log.Error(err, "unable to patch AWS machine")
+			continue
+		}
+		spotInterruptions.WithLabelValues(awsMachine.Namespace, awsMachine.Labels[clusterv1.ClusterNameLabel]).Inc()
+
+		machine, err := util.GetOwnerMachine(ctx, r.Client, awsMachine.ObjectMeta)
+		if err != nil {
+			r.Log.Error(err, "unable to get owner machine", "awsMachine", klog.KObj(awsMachine))
+			continue
+		}
+		if machine == nil || !machine.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to delete machine", "machine", klog.KObj(machine))
+			continue
+		}
+		r.Log.Info("Deleted machine of interrupted spot instance", "machine", klog.KObj(machine), "instanceID", instanceID)
+	}
+}
+
+// processRebalanceRecommendation marks the Machines of a spot instance at an elevated risk of being
+// interrupted. The Machines aren't deleted, as the instance may keep running.
+func (r *AwsInstanceStateReconciler) processRebalanceRecommendation(ctx context.Context, instanceID string) {
+	for _, machine := range r.markInstanceMachines(ctx, instanceID, RebalanceRecommendedKey) {
+		rebalanceRecommendations.WithLabelValues(machine.Namespace, machine.Spec.ClusterName).Inc()
+	}
+}
+
+// markInstanceMachines sets key as label and annotation on the Machines running on the instance, so that
+// drain logic can select and prioritize them, and returns the Machines that were newly marked.
+func (r *AwsInstanceStateReconciler) markInstanceMachines(ctx context.Context, instanceID, key string) []*clusterv1.Machine {
+	if instanceID == "" {
+		return nil
+	}
+
+	var marked []*clusterv1.Machine
+	for _, machine := range r.getInstanceMachines(ctx, instanceID) {
+		if !machine.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if _, ok := machine.Labels[key]; ok {
+			continue
+		}
+
+		patchHelper, err := patch.NewHelper(machine, r.Client)
+		if err != nil {
+			r.Log.Error(err, "unable to create patch helper")
+			continue
+		}
+		labels := machine.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[key] = "true"
+		machine.SetLabels(labels)
+		annotations := machine.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[key] = time.Now().UTC().Format(time.RFC3339)
+		machine.SetAnnotations(annotations)
+
+		if err := patchHelper.Patch(ctx, machine); err != nil {
+			r.Log.Error(err, "unable to patch machine", "machine", klog.KObj(machine))
+			continue
+		}
+		r.Log.Info("Marked machine of spot instance", "machine", klog.KObj(machine), "instanceID", instanceID, "key", key)
+		marked = append(marked, machine)
+	}
+	return marked
+}
+
+// getInstanceMachines returns the Machines running on the instance, either through an AWSMachine or
+// through an AWSMachinePool.
+func (r *AwsInstanceStateReconciler) getInstanceMachines(ctx context.Context, instanceID string) []*clusterv1.Machine {
+	var machines []*clusterv1.Machine
+
+	awsMachines := &infrav1.AWSMachineList{}
+	if err := r.List(ctx, awsMachines, client.MatchingFields{controllers.InstanceIDIndex: instanceID}); err != nil {
+		r.Log.Error(err, "unable to list machines by instance ID", "instanceID", instanceID)
+	}
+	for i := range awsMachines.Items {
+		machine, err := util.GetOwnerMachine(ctx, r.Client, awsMachines.Items[i].ObjectMeta)
+		if err != nil {
+			r.Log.Error(err, "unable to get owner machine", "awsMachine", klog.KObj(&awsMachines.Items[i]))
+			continue
+		}
+		if machine != nil {
+			machines = append(machines, machine)
+		}
+	}
+
+	if !feature.Gates.Enabled(feature.MachinePool) {
+		return machines
+	}
+
+	awsMachinePools := &expinfrav1.AWSMachinePoolList{}
+	if err := r.List(ctx, awsMachinePools, client.MatchingFields{MachinePoolInstanceIDIndex: instanceID}); err != nil {
+		r.Log.Error(err, "unable to list machine pools by instance ID", "instanceID", instanceID)
+		return machines
+	}
+	for i := range awsMachinePools.Items {
+		machines = append(machines, r.getMachinePoolInstanceMachines(ctx, &awsMachinePools.Items[i], instanceID)...)
+	}
+	return machines
+}
+
+// processMachinePoolInstanceTermination marks the machines backed by a terminating machine pool
+// instance for deletion and triggers a reconcile on the AWSMachinePool owning the instance.
+func (r *AwsInstanceStateReconciler) processMachinePoolInstanceTermination(ctx context.Context, instanceID string) {
+	if instanceID == "" || !feature.Gates.Enabled(feature.MachinePool) {
+		return
+	}
+
+	awsMachinePools := &expinfrav1.AWSMachinePoolList{}
+	if err := r.List(ctx, awsMachinePools, client.MatchingFields{MachinePoolInstanceIDIndex: instanceID}); err != nil {
+		r.Log.Error(err, "unable to list machine pools by instance ID", "instanceID", instanceID)
+		return
+	}
+
+	for i := range awsMachinePools.Items {
+		awsMachinePool := &awsMachinePools.Items[i]
+		if !awsMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		r.markMachinesForDeletion(ctx, awsMachinePool, instanceID)
+
+		patchHelper, err := patch.NewHelper(awsMachinePool, r.Client)
+		if err != nil {
+			r.Log.Error(err, "unable to create patch helper")
+			continue
+		}
+		annotations := awsMachinePool.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[Ec2InstanceTerminationNoticeAnnotationKey] = instanceID
+		awsMachinePool.SetAnnotations(annotations)
+
+		if err := patchHelper.Patch(ctx, awsMachinePool); err != nil {
+			r.Log.Error(err, "unable to patch AWS machine pool")
+		}
+	}
+}
+
+// markMachinesForDeletion sets the delete machine annotation on the machines of the pool's
+// cluster that run on the instance, so that they are the first to be removed.
+func (r *AwsInstanceStateReconciler) markMachinesForDeletion(ctx context.Context, awsMachinePool *expinfrav1.AWSMachinePool, instanceID string) {
+	for _, machine := range r.getMachinePoolInstanceMachines(ctx, awsMachinePool, instanceID) {
+		if !machine.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if _, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]; ok {
+			continue
+		}
+
+		patchHelper, err := patch.NewHelper(machine, r.Client)
+		if err != nil {
+			r.Log.Error(err, "unable to create patch helper")
+			continue
+		}
+		annotations := machine.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[clusterv1.DeleteMachineAnnotation] = "true"
+		machine.SetAnnotations(annotations)
+
+		if err := patchHelper.Patch(ctx, machine); err != nil {
+			r.Log.Error(err, "unable to patch machine", "machine", klog.KObj(machine))
+		}
+	}
+}
+
+// getMachinePoolInstanceMachines returns the machines of the pool's cluster that run on the instance.
+func (r *AwsInstanceStateReconciler) getMachinePoolInstanceMachines(ctx context.Context, awsMachinePool *expinfrav1.AWSMachinePool, instanceID string) []*clusterv1.Machine {
+	clusterName, ok := awsMachinePool.Labels[clusterv1.ClusterNameLabel]
+	if !ok {
+		return nil
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := r.List(ctx, machines, client.InNamespace(awsMachinePool.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName}); err != nil {
+		r.Log.Error(err, "unable to list machines", "cluster", klog.KRef(awsMachinePool.Namespace, clusterName))
+		return nil
+	}
+
+	var instanceMachines []*clusterv1.Machine
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		if machine.Spec.ProviderID == nil || !strings.HasSuffix(*machine.Spec.ProviderID, "/"+instanceID) {
+			continue
+		}
+		instanceMachines = append(instanceMachines, machine)
+	}
+	return instanceMachines
+}
+
 // getQueueURL retrieves the SQS queue URL for a given cluster.
 func (r *AwsInstanceStateReconciler) getQueueURL(cluster *infrav1.AWSCluster) (string, error) {
 	sqsSvs, err := r.getSQSService(cluster.Spec.Region)
@@ -228,13 +523,22 @@ func (r *AwsInstanceStateReconciler) getQueueURL(cluster *infrav1.AWSCluster) (s
 	return *resp.QueueUrl, nil
 }
 
-func queueNotFoundError(err error) bool {
-	if aerr, ok := err.(awserr.Error); ok {
-		if aerr.Code() == sqs.ErrCodeQueueDoesNotExist {
-			return true
-		}
+func (r *AwsInstanceStateReconciler) indexAWSMachinePoolByInstanceID(o client.Object) []string {
+	awsMachinePool, ok := o.(*expinfrav1.AWSMachinePool)
+	if !ok {
+		r.Log.Error(errors.New("incorrect type"), "expected an AWSMachinePool", "type", fmt.Sprintf("%T", o))
+		return nil
+	}
+
+	instanceIDs := make([]string, 0, len(awsMachinePool.Status.Instances))
+	for _, instance := range awsMachinePool.Status.Instances {
+		instanceIDs = append(instanceIDs, instance.InstanceID)
 	}
-	return false
+	return instanceIDs
+}
+
+func queueNotFoundError(err error) bool {
+	return awserrors.IsNotFound(err)
 }
 
 type queueParams struct {
@@ -249,6 +553,8 @@ type message struct {
 }
 
 type messageDetail struct {
-	InstanceID string                `json:"instance-id,omitempty"`
-	State      infrav1.InstanceState `json:"state,omitempty"`
+	InstanceID           string                `json:"instance-id,omitempty"`
+	State                infrav1.InstanceState `json:"state,omitempty"`
+	EC2InstanceID        string                `json:"EC2InstanceId,omitempty"`
+	AutoScalingGroupName string                `json:"AutoScalingGroupName,omitempty"`
 }
@@ -0,0 +1,38 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var spotInterruptions = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "aws",
+	Name:      "spot_interruptions_total",
+	Help:      "Total number of spot interruption warnings handled for AWSMachines",
+}, []string{"namespace", "cluster"})
+
+var rebalanceRecommendations = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "aws",
+	Name:      "rebalance_recommendations_total",
+	Help:      "Total number of rebalance recommendations handled for Machines",
+}, []string{"namespace", "cluster"})
+
+func init() {
+	metrics.Registry.MustRegister(spotInterruptions, rebalanceRecommendations)
+}
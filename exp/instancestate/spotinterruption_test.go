@@ -0,0 +1,187 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestProcessSpotInterruption(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	machine := &clusterv1.Machine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Machine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-1",
+			Namespace: "default",
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "cluster-1",
+		},
+	}
+	awsMachine := &infrav1.AWSMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aws-machine-1",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster-1"},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Machine",
+					Name:       machine.Name,
+				},
+			},
+		},
+		Spec: infrav1.AWSMachineSpec{
+			InstanceID:        pointer.String("i-0123456789abcdef0"),
+			SpotMarketOptions: &infrav1.SpotMarketOptions{},
+		},
+	}
+
+	r := &AwsInstanceStateReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(machine, awsMachine).
+			WithIndex(&infrav1.AWSMachine{}, controllers.InstanceIDIndex, func(o client.Object) []string {
+				m := o.(*infrav1.AWSMachine)
+				if m.Spec.InstanceID == nil {
+					return nil
+				}
+				return []string{*m.Spec.InstanceID}
+			}).
+			Build(),
+		Log: ctrl.Log.WithName("controllers").WithName("AWSInstanceState"),
+	}
+
+	r.processSpotInterruption(context.TODO(), "i-0123456789abcdef0")
+
+	g.Expect(r.Get(context.TODO(), client.ObjectKeyFromObject(awsMachine), awsMachine)).To(Succeed())
+	g.Expect(awsMachine.Annotations).To(HaveKey(Ec2SpotInterruptionNoticeAnnotationKey))
+
+	err := r.Get(context.TODO(), client.ObjectKeyFromObject(machine), &clusterv1.Machine{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestProcessSpotInterruptionMessages(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	testCases := []struct {
+		name        string
+		detailType  string
+		expectedKey string
+	}{
+		{
+			name:        "marks the machine of an interrupted instance before deleting it",
+			detailType:  instancestate.Ec2SpotInterruptionWarning,
+			expectedKey: InterruptionImminentKey,
+		},
+		{
+			name:        "marks the machine of an instance with a rebalance recommendation",
+			detailType:  instancestate.Ec2InstanceRebalanceRecommendation,
+			expectedKey: RebalanceRecommendedKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Machine",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "machine-1",
+					Namespace:  "default",
+					Finalizers: []string{clusterv1.MachineFinalizer},
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName: "cluster-1",
+				},
+			}
+			awsMachine := &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "aws-machine-1",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster-1"},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: clusterv1.GroupVersion.String(),
+							Kind:       "Machine",
+							Name:       machine.Name,
+						},
+					},
+				},
+				Spec: infrav1.AWSMachineSpec{
+					InstanceID:        pointer.String("i-0123456789abcdef0"),
+					SpotMarketOptions: &infrav1.SpotMarketOptions{},
+				},
+			}
+
+			r := &AwsInstanceStateReconciler{
+				Client: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(machine, awsMachine).
+					WithIndex(&infrav1.AWSMachine{}, controllers.InstanceIDIndex, func(o client.Object) []string {
+						m := o.(*infrav1.AWSMachine)
+						if m.Spec.InstanceID == nil {
+							return nil
+						}
+						return []string{*m.Spec.InstanceID}
+					}).
+					Build(),
+				Log: ctrl.Log.WithName("controllers").WithName("AWSInstanceState"),
+			}
+
+			r.processMessage(context.TODO(), message{
+				Source:        "aws.ec2",
+				DetailType:    tc.detailType,
+				MessageDetail: &messageDetail{InstanceID: "i-0123456789abcdef0"},
+			})
+
+			g.Expect(r.Get(context.TODO(), client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+			g.Expect(machine.Labels).To(HaveKeyWithValue(tc.expectedKey, "true"))
+			g.Expect(machine.Annotations).To(HaveKey(tc.expectedKey))
+			g.Expect(machine.DeletionTimestamp.IsZero()).To(Equal(tc.expectedKey == RebalanceRecommendedKey))
+		})
+	}
+}
@@ -75,6 +75,29 @@ const (
 	// owner: @wyike
 	// alpha: v2.0
 	AlternativeGCStrategy featuregate.Feature = "AlternativeGCStrategy"
+
+	// ServiceQuotaChecks will verify AWS service quotas before creating VPCs, internet gateways, Elastic IPs, NAT gateways and instances,
+	// reporting a QuotaExceeded reason on the corresponding condition instead of failing on AWS errors mid-reconcile.
+	// alpha: v2.1
+	ServiceQuotaChecks featuregate.Feature = "ServiceQuotaChecks"
+
+	// ROSA is used to enable ROSA support
+	// alpha: v2.1
+	ROSA featuregate.Feature = "ROSA"
+
+	// ClusterInfoConfigMap will publish the AWS environment of a cluster into the aws-cluster-info ConfigMap of the workload cluster.
+	// alpha: v2.1
+	ClusterInfoConfigMap featuregate.Feature = "ClusterInfoConfigMap"
+
+	// AWSLoadBalancer will enable the AWSLoadBalancer controller, which provisions application and network load balancers
+	// into the VPC of a cluster and attaches their target groups to the auto scaling groups of machine pools.
+	// alpha: v2.1
+	AWSLoadBalancer featuregate.Feature = "AWSLoadBalancer"
+
+	// CostEstimation will estimate the hourly on-demand cost of the billable resources of clusters with the AWS Price List,
+	// and publish it in their status and as a metric.
+	// alpha: v2.1
+	CostEstimation featuregate.Feature = "CostEstimation"
 )
 
 func init() {
@@ -95,4 +118,9 @@ var defaultCAPAFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	BootstrapFormatIgnition:       {Default: false, PreRelease: featuregate.Alpha},
 	ExternalResourceGC:            {Default: false, PreRelease: featuregate.Alpha},
 	AlternativeGCStrategy:         {Default: false, PreRelease: featuregate.Alpha},
+	ServiceQuotaChecks:            {Default: false, PreRelease: featuregate.Alpha},
+	ROSA:                          {Default: false, PreRelease: featuregate.Alpha},
+	ClusterInfoConfigMap:          {Default: false, PreRelease: featuregate.Alpha},
+	AWSLoadBalancer:               {Default: false, PreRelease: featuregate.Alpha},
+	CostEstimation:                {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -0,0 +1,57 @@
+#!/bin/bash
+
+# Copyright 2023 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+################################################################################
+# usage: test-localstack.sh [GO TEST FLAGS]
+#  This program runs the integration tests in test/localstack against a
+#  LocalStack server. If LOCALSTACK_ENDPOINT is not set, a LocalStack container
+#  is started with Docker for the duration of the tests.
+################################################################################
+
+REPO_ROOT=$(dirname "${BASH_SOURCE[0]}")/..
+cd "${REPO_ROOT}" || exit 1
+
+LOCALSTACK_IMAGE=${LOCALSTACK_IMAGE:-localstack/localstack:2.1}
+LOCALSTACK_CONTAINER=capa-localstack
+
+if [ -z "${LOCALSTACK_ENDPOINT:-}" ]; then
+  command -v docker >/dev/null 2>&1 || \
+    { echo "docker not found, set LOCALSTACK_ENDPOINT to use a running LocalStack server" 1>&2; exit 1; }
+
+  docker run --detach --rm --name "${LOCALSTACK_CONTAINER}" --publish 4566:4566 "${LOCALSTACK_IMAGE}" >/dev/null
+  trap 'docker stop "${LOCALSTACK_CONTAINER}" >/dev/null' EXIT
+  LOCALSTACK_ENDPOINT=http://localhost:4566
+
+  echo "Waiting for LocalStack to be ready"
+  for _ in $(seq 60); do
+    if curl --silent --fail "${LOCALSTACK_ENDPOINT}/_localstack/health" >/dev/null; then
+      break
+    fi
+    sleep 2
+  done
+fi
+
+# LocalStack accepts any credentials, make sure real ones are never used.
+export AWS_ACCESS_KEY_ID=test
+export AWS_SECRET_ACCESS_KEY=test
+unset AWS_SESSION_TOKEN AWS_PROFILE
+export LOCALSTACK_ENDPOINT
+
+go test -tags=localstack -count=1 "$@" ./test/localstack/...
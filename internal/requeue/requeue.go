@@ -0,0 +1,34 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package requeue contains helpers shared by the reconcilers to decide when to requeue.
+package requeue
+
+import (
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// AfterSyncPeriod requeues successful reconciles that didn't ask for a requeue after
+// syncPeriod, so a controller can resync more often than the manager does.
+// A zero syncPeriod leaves the result untouched.
+func AfterSyncPeriod(syncPeriod time.Duration, result ctrl.Result, err error) (ctrl.Result, error) {
+	if err == nil && result.IsZero() && syncPeriod > 0 {
+		result.RequeueAfter = syncPeriod
+	}
+	return result, err
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requeue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestAfterSyncPeriod(t *testing.T) {
+	testCases := []struct {
+		name           string
+		syncPeriod     time.Duration
+		result         ctrl.Result
+		err            error
+		expectedResult ctrl.Result
+	}{
+		{
+			name:           "Should not requeue when no sync period is set",
+			result:         ctrl.Result{},
+			expectedResult: ctrl.Result{},
+		},
+		{
+			name:           "Should requeue after the sync period on success",
+			syncPeriod:     2 * time.Minute,
+			result:         ctrl.Result{},
+			expectedResult: ctrl.Result{RequeueAfter: 2 * time.Minute},
+		},
+		{
+			name:           "Should keep the requested requeue",
+			syncPeriod:     2 * time.Minute,
+			result:         ctrl.Result{RequeueAfter: 15 * time.Second},
+			expectedResult: ctrl.Result{RequeueAfter: 15 * time.Second},
+		},
+		{
+			name:           "Should not requeue after the sync period on error",
+			syncPeriod:     2 * time.Minute,
+			err:            errors.New("failed"),
+			expectedResult: ctrl.Result{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			result, err := AfterSyncPeriod(tc.syncPeriod, tc.result, tc.err)
+			if tc.err != nil {
+				g.Expect(err).To(MatchError(tc.err))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			g.Expect(result).To(Equal(tc.expectedResult))
+		})
+	}
+}
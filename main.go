@@ -50,18 +50,24 @@ import (
 	ekscontrolplanev1beta1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta1"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	ekscontrolplanecontrollers "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/controllers"
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	rosacontrolplanecontrollers "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/controllers"
 	expinfrav1beta1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta1"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/exp/controlleridentitycreator"
 	expcontrollers "sigs.k8s.io/cluster-api-provider-aws/v2/exp/controllers"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/exp/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/auditevents"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/endpoints"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/identity"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/sts"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 )
 
@@ -82,25 +88,42 @@ func init() {
 	_ = infrav1beta1.AddToScheme(scheme)
 	_ = expinfrav1beta1.AddToScheme(scheme)
 	_ = expinfrav1.AddToScheme(scheme)
+	_ = rosacontrolplanev1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
 var (
-	metricsBindAddr          string
-	enableLeaderElection     bool
-	leaderElectionNamespace  string
-	watchNamespace           string
-	watchFilterValue         string
-	profilerAddress          string
-	awsClusterConcurrency    int
-	instanceStateConcurrency int
-	awsMachineConcurrency    int
-	waitInfraPeriod          time.Duration
-	syncPeriod               time.Duration
-	webhookPort              int
-	webhookCertDir           string
-	healthAddr               string
-	serviceEndpoints         string
+	metricsBindAddr           string
+	enableLeaderElection      bool
+	leaderElectionNamespace   string
+	watchNamespace            string
+	watchFilterValue          string
+	profilerAddress           string
+	awsClusterConcurrency     int
+	instanceStateConcurrency  int
+	awsMachineConcurrency     int
+	awsMachinePoolConcurrency int
+	waitInfraPeriod           time.Duration
+	syncPeriod                time.Duration
+	awsClusterSyncPeriod      time.Duration
+	awsMachineSyncPeriod      time.Duration
+	awsMachinePoolSyncPeriod  time.Duration
+	webhookPort               int
+	webhookCertDir            string
+	healthAddr                string
+	serviceEndpoints          string
+	localStackEndpoint        string
+	enforcePrincipalAllowList bool
+	enforceVolumeEncryption   bool
+	describeCacheTTL          time.Duration
+	awsAPIEventsVerbosity     int
+	iamPermissionsBoundary    string
+	iamPath                   string
+	awsReadinessCheck         bool
+	awsReadinessCheckRegion   string
+	awsReadinessCheckInterval time.Duration
+	stsRegion                 string
+	stsRegionalEndpoints      bool
 
 	// maxEKSSyncPeriod is the maximum allowed duration for the sync-period flag when using EKS. It is set to 10 minutes
 	// because during resync it will create a new AWS auth token which can a maximum life of 15 minutes and this ensures
@@ -108,6 +131,7 @@ var (
 	maxEKSSyncPeriod         = time.Minute * 10
 	errMaxSyncPeriodExceeded = errors.New("sync period greater than maximum allowed")
 	errEKSInvalidFlags       = errors.New("invalid EKS flag combination")
+	errInvalidEventVerbosity = errors.New("invalid AWS API events verbosity")
 
 	logOptions = logs.NewOptions()
 )
@@ -176,6 +200,37 @@ func main() {
 
 	setupLog.Info(fmt.Sprintf("feature gates: %+v\n", feature.Gates))
 
+	if describeCacheTTL > 0 {
+		setupLog.Info("caching the results of EC2 describe calls", "ttl", describeCacheTTL)
+	}
+
+	switch awsAPIEventsVerbosity {
+	case auditevents.VerbosityNone:
+	case auditevents.VerbosityLifecycle, auditevents.VerbosityAll:
+		setupLog.Info("publishing events for the AWS calls changing resources", "verbosity", awsAPIEventsVerbosity)
+	default:
+		setupLog.Error(errInvalidEventVerbosity, "aws-api-events-verbosity must be 0, 1 or 2", "aws-api-events-verbosity", awsAPIEventsVerbosity)
+		os.Exit(1)
+	}
+
+	if iamPermissionsBoundary != "" || iamPath != "" {
+		setupLog.Info("creating IAM roles with a permissions boundary and path", "permissions-boundary", iamPermissionsBoundary, "path", iamPath)
+	}
+
+	if enforcePrincipalAllowList {
+		setupLog.Info("enforcing the principal allow-list: AWSClusters must reference an identity explicitly allowing their namespace")
+	}
+
+	if stsRegion != "" || stsRegionalEndpoints {
+		setupLog.Info("calling regional STS endpoints", "sts-region", stsRegion)
+		identity.STSRegion = stsRegion
+		identity.STSRegionalEndpoints = stsRegionalEndpoints
+	}
+
+	if enforceVolumeEncryption {
+		setupLog.Info("enforcing volume encryption: machines and launch templates must only have encrypted volumes")
+	}
+
 	externalResourceGC := false
 	alternativeGCStrategy := false
 	if feature.Gates.Enabled(feature.ExternalResourceGC) {
@@ -197,12 +252,24 @@ func main() {
 		setupLog.Error(err, "unable to parse service endpoints", "controller", "AWSCluster")
 		os.Exit(1)
 	}
+	awsServiceEndpoints, err = endpoints.LocalStack(localStackEndpoint, awsServiceEndpoints)
+	if err != nil {
+		setupLog.Error(err, "unable to parse LocalStack endpoint")
+		os.Exit(1)
+	}
+	if localStackEndpoint != "" {
+		setupLog.Info("Sending all AWS requests to LocalStack, this is meant for development and testing only", "endpoint", localStackEndpoint)
+	}
 
 	setupReconcilersAndWebhooks(ctx, mgr, awsServiceEndpoints, externalResourceGC, alternativeGCStrategy)
 	if feature.Gates.Enabled(feature.EKS) {
 		setupEKSReconcilersAndWebhooks(ctx, mgr, awsServiceEndpoints, externalResourceGC, alternativeGCStrategy, waitInfraPeriod)
 	}
 
+	if feature.Gates.Enabled(feature.ROSA) {
+		setupROSAReconcilers(ctx, mgr)
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
@@ -215,6 +282,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	if awsReadinessCheck {
+		setupLog.Info("checking the AWS credentials of the controller in the readiness probe", "region", awsReadinessCheckRegion, "interval", awsReadinessCheckInterval)
+		globalScope, err := scope.NewGlobalScope(scope.GlobalScopeParams{
+			ControllerName: "readiness",
+			Region:         awsReadinessCheckRegion,
+			Endpoints:      awsServiceEndpoints,
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to create AWS session for the readiness check")
+			os.Exit(1)
+		}
+		checker := sts.NewCredentialsChecker(scope.NewGlobalSTSClient(globalScope, globalScope), awsReadinessCheckInterval)
+		if err := mgr.AddReadyzCheck("aws-credentials", checker.Check); err != nil {
+			setupLog.Error(err, "unable to create ready check")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting manager", "version", version.Get().String())
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -226,23 +311,43 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 	externalResourceGC, alternativeGCStrategy bool,
 ) {
 	if err := (&controllers.AWSMachineReconciler{
-		Client:           mgr.GetClient(),
-		Log:              ctrl.Log.WithName("controllers").WithName("AWSMachine"),
-		Recorder:         mgr.GetEventRecorderFor("awsmachine-controller"),
-		Endpoints:        awsServiceEndpoints,
-		WatchFilterValue: watchFilterValue,
+		Client:                    mgr.GetClient(),
+		Log:                       ctrl.Log.WithName("controllers").WithName("AWSMachine"),
+		Recorder:                  mgr.GetEventRecorderFor("awsmachine-controller"),
+		Endpoints:                 awsServiceEndpoints,
+		WatchFilterValue:          watchFilterValue,
+		SyncPeriod:                awsMachineSyncPeriod,
+		EnforcePrincipalAllowList: enforcePrincipalAllowList,
+		DescribeCacheTTL:          describeCacheTTL,
+		AuditEventsVerbosity:      awsAPIEventsVerbosity,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachineConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSMachine")
 		os.Exit(1)
 	}
 
+	if err := (&controllers.AWSMachineTemplateReconciler{
+		Client:                    mgr.GetClient(),
+		Endpoints:                 awsServiceEndpoints,
+		WatchFilterValue:          watchFilterValue,
+		EnforcePrincipalAllowList: enforcePrincipalAllowList,
+		DescribeCacheTTL:          describeCacheTTL,
+		AuditEventsVerbosity:      awsAPIEventsVerbosity,
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachineConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AWSMachineTemplate")
+		os.Exit(1)
+	}
+
 	if err := (&controllers.AWSClusterReconciler{
-		Client:                mgr.GetClient(),
-		Recorder:              mgr.GetEventRecorderFor("awscluster-controller"),
-		Endpoints:             awsServiceEndpoints,
-		WatchFilterValue:      watchFilterValue,
-		ExternalResourceGC:    externalResourceGC,
-		AlternativeGCStrategy: alternativeGCStrategy,
+		Client:                    mgr.GetClient(),
+		Recorder:                  mgr.GetEventRecorderFor("awscluster-controller"),
+		Endpoints:                 awsServiceEndpoints,
+		WatchFilterValue:          watchFilterValue,
+		ExternalResourceGC:        externalResourceGC,
+		AlternativeGCStrategy:     alternativeGCStrategy,
+		SyncPeriod:                awsClusterSyncPeriod,
+		EnforcePrincipalAllowList: enforcePrincipalAllowList,
+		DescribeCacheTTL:          describeCacheTTL,
+		AuditEventsVerbosity:      awsAPIEventsVerbosity,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSCluster")
 		os.Exit(1)
@@ -251,20 +356,44 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 	if feature.Gates.Enabled(feature.MachinePool) {
 		setupLog.Debug("enabling machine pool controller and webhook")
 		if err := (&expcontrollers.AWSMachinePoolReconciler{
-			Client:           mgr.GetClient(),
-			Recorder:         mgr.GetEventRecorderFor("awsmachinepool-controller"),
-			WatchFilterValue: watchFilterValue,
-		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: instanceStateConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
+			Client:                    mgr.GetClient(),
+			Recorder:                  mgr.GetEventRecorderFor("awsmachinepool-controller"),
+			WatchFilterValue:          watchFilterValue,
+			SyncPeriod:                awsMachinePoolSyncPeriod,
+			EnforcePrincipalAllowList: enforcePrincipalAllowList,
+			DescribeCacheTTL:          describeCacheTTL,
+			AuditEventsVerbosity:      awsAPIEventsVerbosity,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachinePoolConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSMachinePool")
 			os.Exit(1)
 		}
 
-		if err := (&expinfrav1.AWSMachinePool{}).SetupWebhookWithManager(mgr); err != nil {
+		if err := (&expinfrav1.AWSMachinePoolWebhook{EnforceVolumeEncryption: enforceVolumeEncryption}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachinePool")
 			os.Exit(1)
 		}
 	}
 
+	if feature.Gates.Enabled(feature.AWSLoadBalancer) {
+		setupLog.Debug("enabling load balancer controller and webhook")
+		if err := (&expcontrollers.AWSLoadBalancerReconciler{
+			Client:                    mgr.GetClient(),
+			Recorder:                  mgr.GetEventRecorderFor("awsloadbalancer-controller"),
+			WatchFilterValue:          watchFilterValue,
+			EnforcePrincipalAllowList: enforcePrincipalAllowList,
+			DescribeCacheTTL:          describeCacheTTL,
+			AuditEventsVerbosity:      awsAPIEventsVerbosity,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "AWSLoadBalancer")
+			os.Exit(1)
+		}
+
+		if err := (&expinfrav1.AWSLoadBalancer{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AWSLoadBalancer")
+			os.Exit(1)
+		}
+	}
+
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
 		setupLog.Info("EventBridge notifications enabled. enabling AWSInstanceStateController")
 		if err := (&instancestate.AwsInstanceStateReconciler{
@@ -291,11 +420,11 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		}
 	}
 
-	if err := (&infrav1.AWSMachineTemplateWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&infrav1.AWSMachineTemplateWebhook{EnforceVolumeEncryption: enforceVolumeEncryption}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachineTemplate")
 		os.Exit(1)
 	}
-	if err := (&infrav1.AWSCluster{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&infrav1.AWSClusterWebhook{EnforcePrincipalAllowList: enforcePrincipalAllowList}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "AWSCluster")
 		os.Exit(1)
 	}
@@ -315,7 +444,11 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		setupLog.Error(err, "unable to create webhook", "webhook", "AWSClusterStaticIdentity")
 		os.Exit(1)
 	}
-	if err := (&infrav1.AWSMachine{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&infrav1.AWSClusterWebIdentity{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "AWSClusterWebIdentity")
+		os.Exit(1)
+	}
+	if err := (&infrav1.AWSMachineWebhook{EnforceVolumeEncryption: enforceVolumeEncryption}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachine")
 		os.Exit(1)
 	}
@@ -342,14 +475,19 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 
 	setupLog.Debug("enabling EKS control plane controller")
 	if err := (&ekscontrolplanecontrollers.AWSManagedControlPlaneReconciler{
-		Client:                mgr.GetClient(),
-		EnableIAM:             enableIAM,
-		AllowAdditionalRoles:  allowAddRoles,
-		Endpoints:             awsServiceEndpoints,
-		WatchFilterValue:      watchFilterValue,
-		ExternalResourceGC:    externalResourceGC,
-		AlternativeGCStrategy: alternativeGCStrategy,
-		WaitInfraPeriod:       waitInfraPeriod,
+		Client:                    mgr.GetClient(),
+		EnableIAM:                 enableIAM,
+		AllowAdditionalRoles:      allowAddRoles,
+		IAMPermissionsBoundary:    iamPermissionsBoundary,
+		IAMPath:                   iamPath,
+		Endpoints:                 awsServiceEndpoints,
+		WatchFilterValue:          watchFilterValue,
+		ExternalResourceGC:        externalResourceGC,
+		AlternativeGCStrategy:     alternativeGCStrategy,
+		WaitInfraPeriod:           waitInfraPeriod,
+		EnforcePrincipalAllowList: enforcePrincipalAllowList,
+		DescribeCacheTTL:          describeCacheTTL,
+		AuditEventsVerbosity:      awsAPIEventsVerbosity,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSManagedControlPlane")
 		os.Exit(1)
@@ -377,11 +515,16 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 	if feature.Gates.Enabled(feature.EKSFargate) {
 		setupLog.Debug("enabling EKS fargate profile controller")
 		if err := (&expcontrollers.AWSFargateProfileReconciler{
-			Client:           mgr.GetClient(),
-			Recorder:         mgr.GetEventRecorderFor("awsfargateprofile-reconciler"),
-			EnableIAM:        enableIAM,
-			Endpoints:        awsServiceEndpoints,
-			WatchFilterValue: watchFilterValue,
+			Client:                    mgr.GetClient(),
+			Recorder:                  mgr.GetEventRecorderFor("awsfargateprofile-reconciler"),
+			EnableIAM:                 enableIAM,
+			IAMPermissionsBoundary:    iamPermissionsBoundary,
+			IAMPath:                   iamPath,
+			Endpoints:                 awsServiceEndpoints,
+			WatchFilterValue:          watchFilterValue,
+			EnforcePrincipalAllowList: enforcePrincipalAllowList,
+			DescribeCacheTTL:          describeCacheTTL,
+			AuditEventsVerbosity:      awsAPIEventsVerbosity,
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSFargateProfile")
 		}
@@ -395,18 +538,24 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 	if feature.Gates.Enabled(feature.MachinePool) {
 		setupLog.Debug("enabling EKS managed machine pool controller")
 		if err := (&expcontrollers.AWSManagedMachinePoolReconciler{
-			AllowAdditionalRoles: allowAddRoles,
-			Client:               mgr.GetClient(),
-			EnableIAM:            enableIAM,
-			Endpoints:            awsServiceEndpoints,
-			Recorder:             mgr.GetEventRecorderFor("awsmanagedmachinepool-reconciler"),
-			WatchFilterValue:     watchFilterValue,
-		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: instanceStateConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
+			AllowAdditionalRoles:      allowAddRoles,
+			Client:                    mgr.GetClient(),
+			EnableIAM:                 enableIAM,
+			IAMPermissionsBoundary:    iamPermissionsBoundary,
+			IAMPath:                   iamPath,
+			Endpoints:                 awsServiceEndpoints,
+			Recorder:                  mgr.GetEventRecorderFor("awsmanagedmachinepool-reconciler"),
+			WatchFilterValue:          watchFilterValue,
+			SyncPeriod:                awsMachinePoolSyncPeriod,
+			EnforcePrincipalAllowList: enforcePrincipalAllowList,
+			DescribeCacheTTL:          describeCacheTTL,
+			AuditEventsVerbosity:      awsAPIEventsVerbosity,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachinePoolConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSManagedMachinePool")
 			os.Exit(1)
 		}
 
-		if err := (&expinfrav1.AWSManagedMachinePool{}).SetupWebhookWithManager(mgr); err != nil {
+		if err := (&expinfrav1.AWSManagedMachinePoolWebhook{EnforceVolumeEncryption: enforceVolumeEncryption}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "AWSManagedMachinePool")
 			os.Exit(1)
 		}
@@ -418,6 +567,59 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 	}
 }
 
+func setupROSAReconcilers(ctx context.Context, mgr ctrl.Manager) {
+	setupLog.Info("enabling ROSA controllers")
+
+	setupLog.Debug("enabling ROSA control plane controller")
+	if err := (&rosacontrolplanecontrollers.ROSAControlPlaneReconciler{
+		Client:           mgr.GetClient(),
+		Recorder:         mgr.GetEventRecorderFor("rosacontrolplane-reconciler"),
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ROSAControlPlane")
+		os.Exit(1)
+	}
+
+	setupLog.Debug("enabling ROSA cluster controller")
+	if err := (&expcontrollers.ROSAClusterReconciler{
+		Client:           mgr.GetClient(),
+		Recorder:         mgr.GetEventRecorderFor("rosacluster-controller"),
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ROSACluster")
+		os.Exit(1)
+	}
+
+	if feature.Gates.Enabled(feature.MachinePool) {
+		log := ctrl.Log.WithName("remote").WithName("ClusterCacheTracker")
+		tracker, err := remote.NewClusterCacheTracker(mgr, remote.ClusterCacheTrackerOptions{Log: &log})
+		if err != nil {
+			setupLog.Error(err, "unable to create cluster cache tracker")
+			os.Exit(1)
+		}
+		if err := (&remote.ClusterCacheReconciler{
+			Client:           mgr.GetClient(),
+			Tracker:          tracker,
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterCacheReconciler")
+			os.Exit(1)
+		}
+
+		setupLog.Debug("enabling ROSA machine pool controller")
+		if err := (&expcontrollers.ROSAMachinePoolReconciler{
+			Client:           mgr.GetClient(),
+			Recorder:         mgr.GetEventRecorderFor("rosamachinepool-reconciler"),
+			WatchFilterValue: watchFilterValue,
+			SyncPeriod:       awsMachinePoolSyncPeriod,
+			Tracker:          tracker,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachinePoolConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ROSAMachinePool")
+			os.Exit(1)
+		}
+	}
+}
+
 func initFlags(fs *pflag.FlagSet) {
 	fs.StringVar(
 		&metricsBindAddr,
@@ -472,6 +674,12 @@ func initFlags(fs *pflag.FlagSet) {
 		"Number of AWSMachines to process simultaneously",
 	)
 
+	fs.IntVar(&awsMachinePoolConcurrency,
+		"awsmachinepool-concurrency",
+		5,
+		"Number of AWSMachinePools, AWSManagedMachinePools and ROSAMachinePools to process simultaneously",
+	)
+
 	fs.DurationVar(&waitInfraPeriod,
 		"wait-infra-period",
 		1*time.Minute,
@@ -484,6 +692,24 @@ func initFlags(fs *pflag.FlagSet) {
 		fmt.Sprintf("The minimum interval at which watched resources are reconciled. If EKS is enabled the maximum allowed is %s", maxEKSSyncPeriod),
 	)
 
+	fs.DurationVar(&awsClusterSyncPeriod,
+		"awscluster-sync-period",
+		0,
+		"The interval at which AWSClusters are reconciled when nothing changed. Only takes effect when shorter than --sync-period; 0 uses --sync-period.",
+	)
+
+	fs.DurationVar(&awsMachineSyncPeriod,
+		"awsmachine-sync-period",
+		0,
+		"The interval at which AWSMachines are reconciled when nothing changed. Only takes effect when shorter than --sync-period; 0 uses --sync-period.",
+	)
+
+	fs.DurationVar(&awsMachinePoolSyncPeriod,
+		"awsmachinepool-sync-period",
+		0,
+		"The interval at which AWSMachinePools, AWSManagedMachinePools and ROSAMachinePools are reconciled when nothing changed. Only takes effect when shorter than --sync-period; 0 uses --sync-period.",
+	)
+
 	fs.IntVar(&webhookPort,
 		"webhook-port",
 		9443,
@@ -505,6 +731,78 @@ func initFlags(fs *pflag.FlagSet) {
 		"Set custom AWS service endpoins in semi-colon separated format: ${SigningRegion1}:${ServiceID1}=${URL},${ServiceID2}=${URL};${SigningRegion2}...",
 	)
 
+	fs.StringVar(&localStackEndpoint,
+		"localstack-endpoint",
+		"",
+		"URL of a LocalStack or moto server all AWS services are sent to, for development and testing only. Endpoints set with --service-endpoints take precedence.",
+	)
+
+	fs.DurationVar(&describeCacheTTL,
+		"aws-describe-cache-ttl",
+		0,
+		"How long the results of EC2 describe calls for instances, subnets, security groups and availability zones are reused across reconciliations. Writes made by the controller invalidate them. 0 disables the cache.",
+	)
+
+	fs.IntVar(&awsAPIEventsVerbosity,
+		"aws-api-events-verbosity",
+		auditevents.VerbosityNone,
+		"Publish a Kubernetes event on the reconciled object, listing the resource IDs, for every successful AWS call changing resources. 0 publishes none, 1 the calls creating or deleting resources, 2 every call changing resources including their tags.",
+	)
+
+	fs.BoolVar(&enforcePrincipalAllowList,
+		"enforce-principal-allow-list",
+		false,
+		"Require every AWSCluster to reference an identity whose allowedNamespaces explicitly lists or selects the namespace of the cluster. Identities allowing all namespaces with an empty allowedNamespaces are then not usable.",
+	)
+
+	fs.BoolVar(&enforceVolumeEncryption,
+		"enforce-volume-encryption",
+		false,
+		"Reject the AWSMachines, AWSMachineTemplates, AWSMachinePools and AWSManagedMachinePools of every cluster whose root or additional volumes don't set encrypted to true. It can be enforced for a single cluster with the aws.cluster.x-k8s.io/enforce-volume-encryption annotation on its Cluster.",
+	)
+
+	fs.StringVar(&iamPermissionsBoundary,
+		"iam-permissions-boundary",
+		"",
+		"ARN of the managed policy set as the permissions boundary of the IAM roles created by the controller, such as EKS control plane, node group and fargate roles.",
+	)
+
+	fs.StringVar(&iamPath,
+		"iam-path",
+		"",
+		"IAM path of the roles created by the controller, e.g. /capa/. It must begin and end with a slash. Defaults to /.",
+	)
+
+	fs.BoolVar(&awsReadinessCheck,
+		"aws-readiness-check",
+		false,
+		"Make the readiness probe fail when the default AWS credentials of the controller are rejected by STS GetCallerIdentity or STS can't be reached.",
+	)
+
+	fs.StringVar(&awsReadinessCheckRegion,
+		"aws-readiness-check-region",
+		"us-east-1",
+		"The AWS region STS is called in by the readiness check enabled with --aws-readiness-check.",
+	)
+
+	fs.DurationVar(&awsReadinessCheckInterval,
+		"aws-readiness-check-interval",
+		time.Minute,
+		"The minimum interval between two calls to STS made by the readiness check. Probes in between reuse the result of the last call.",
+	)
+
+	fs.StringVar(&stsRegion,
+		"sts-region",
+		"",
+		"The AWS region whose regional STS endpoint the roles of the AWSClusterRoleIdentities not setting stsRegion are assumed with. When not set, they are assumed with the global STS endpoint, unless --sts-regional-endpoints is set.",
+	)
+
+	fs.BoolVar(&stsRegionalEndpoints,
+		"sts-regional-endpoints",
+		false,
+		"Call the regional STS endpoint of the region of the session instead of the global STS endpoint, including for the roles assumed for a cluster when neither their identity nor --sts-region set an STS region.",
+	)
+
 	fs.StringVar(
 		&watchFilterValue,
 		"watch-filter",
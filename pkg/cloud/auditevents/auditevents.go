@@ -0,0 +1,246 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auditevents publishes a Kubernetes event for every successful AWS call changing resources,
+// so that the changes made by the controller to the account of a cluster can be audited with
+// kubectl describe instead of the controller logs.
+package auditevents
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+const (
+	// VerbosityNone publishes no event, it is the default.
+	VerbosityNone = 0
+	// VerbosityLifecycle publishes an event for the calls creating or deleting resources.
+	VerbosityLifecycle = 1
+	// VerbosityAll publishes an event for every call changing resources, including their tags.
+	VerbosityAll = 2
+)
+
+// maxResources bounds the resources listed in the message of an event.
+const maxResources = 10
+
+// verb is an action an operation name starts with.
+type verb struct {
+	past      string
+	verbosity int
+}
+
+// verbs are the actions of the operations changing resources. The other operations, such as
+// describe, get and list ones, don't publish events.
+var verbs = map[string]verb{
+	"Allocate":     {"Allocated", VerbosityLifecycle},
+	"Copy":         {"Copied", VerbosityLifecycle},
+	"Create":       {"Created", VerbosityLifecycle},
+	"Delete":       {"Deleted", VerbosityLifecycle},
+	"Deregister":   {"Deregistered", VerbosityLifecycle},
+	"Import":       {"Imported", VerbosityLifecycle},
+	"Register":     {"Registered", VerbosityLifecycle},
+	"Release":      {"Released", VerbosityLifecycle},
+	"Run":          {"Ran", VerbosityLifecycle},
+	"Terminate":    {"Terminated", VerbosityLifecycle},
+	"Add":          {"Added", VerbosityAll},
+	"Apply":        {"Applied", VerbosityAll},
+	"Assign":       {"Assigned", VerbosityAll},
+	"Associate":    {"Associated", VerbosityAll},
+	"Attach":       {"Attached", VerbosityAll},
+	"Authorize":    {"Authorized", VerbosityAll},
+	"Cancel":       {"Cancelled", VerbosityAll},
+	"Change":       {"Changed", VerbosityAll},
+	"Complete":     {"Completed", VerbosityAll},
+	"Detach":       {"Detached", VerbosityAll},
+	"Disable":      {"Disabled", VerbosityAll},
+	"Disassociate": {"Disassociated", VerbosityAll},
+	"Enable":       {"Enabled", VerbosityAll},
+	"Modify":       {"Modified", VerbosityAll},
+	"Put":          {"Put", VerbosityAll},
+	"Reboot":       {"Rebooted", VerbosityAll},
+	"Remove":       {"Removed", VerbosityAll},
+	"Replace":      {"Replaced", VerbosityAll},
+	"Reset":        {"Reset", VerbosityAll},
+	"Resume":       {"Resumed", VerbosityAll},
+	"Revoke":       {"Revoked", VerbosityAll},
+	"Set":          {"Set", VerbosityAll},
+	"Start":        {"Started", VerbosityAll},
+	"Stop":         {"Stopped", VerbosityAll},
+	"Suspend":      {"Suspended", VerbosityAll},
+	"Tag":          {"Tagged", VerbosityAll},
+	"Unassign":     {"Unassigned", VerbosityAll},
+	"Untag":        {"Untagged", VerbosityAll},
+	"Update":       {"Updated", VerbosityAll},
+}
+
+// ignoredOperations change no resource of the cluster but are called on every reconciliation.
+var ignoredOperations = map[string]bool{
+	"ChangeMessageVisibility": true,
+	"DeleteMessage":           true,
+	"DeleteMessageBatch":      true,
+}
+
+// ignoredFields identify the account or the request rather than a resource.
+var ignoredFields = map[string]bool{
+	"OwnerId":     true,
+	"RequesterId": true,
+	"RequestId":   true,
+}
+
+// recordEvent is replaced by tests.
+var recordEvent = record.Event
+
+// RecordMutatingCall returns a request handler publishing an event on target for every successful
+// call changing resources selected by verbosity, set from the --aws-api-events-verbosity flag of the
+// controller. The reason of the event is the operation in the
+// past tense, e.g. CreatedNatGateway, and its message lists the IDs, ARNs and names of the resources
+// of the call, e.g. NatGatewayId=nat-0abc, SubnetId=subnet-123.
+func RecordMutatingCall(target runtime.Object, verbosity int) func(r *request.Request) {
+	return func(r *request.Request) {
+		if verbosity == VerbosityNone || r.Error != nil || r.Operation == nil {
+			return
+		}
+		reason, ok := reasonFor(r.Operation.Name, verbosity)
+		if !ok {
+			return
+		}
+
+		resources := newResourceList()
+		resources.collect("", reflect.ValueOf(r.Data), 0)
+		resources.collect("", reflect.ValueOf(r.Params), 0)
+		message := fmt.Sprintf("%s %s succeeded", r.ClientInfo.ServiceID, r.Operation.Name)
+		if len(resources.items) > 0 {
+			message = fmt.Sprintf("%s %s: %s", r.ClientInfo.ServiceID, r.Operation.Name, strings.Join(resources.items, ", "))
+		}
+		recordEvent(target, reason, message)
+	}
+}
+
+// reasonFor returns the event reason of an operation, or false when no event is published for it
+// with the given verbosity.
+func reasonFor(operation string, verbosity int) (string, bool) {
+	if ignoredOperations[operation] {
+		return "", false
+	}
+	end := 1
+	for end < len(operation) && !unicode.IsUpper(rune(operation[end])) {
+		end++
+	}
+	v, ok := verbs[operation[:end]]
+	if !ok {
+		return "", false
+	}
+	// Tagging calls only matter at the highest verbosity, whatever their verb.
+	required := v.verbosity
+	if strings.Contains(operation, "Tag") {
+		required = VerbosityAll
+	}
+	if verbosity < required {
+		return "", false
+	}
+	return v.past + operation[end:], true
+}
+
+// resourceList collects the unique resources of a call.
+type resourceList struct {
+	seen  map[string]bool
+	items []string
+}
+
+func newResourceList() *resourceList {
+	return &resourceList{seen: map[string]bool{}}
+}
+
+func (l *resourceList) add(name, value string) {
+	if value == "" || l.seen[value] || len(l.items) >= maxResources {
+		return
+	}
+	l.seen[value] = true
+	l.items = append(l.items, fmt.Sprintf("%s=%s", name, value))
+}
+
+// collect adds the identifying fields of v, the input or output of a call, walking nested structures
+// down to the resources returned by the call, e.g. CreateNatGatewayOutput.NatGateway.NatGatewayId.
+func (l *resourceList) collect(name string, v reflect.Value, depth int) {
+	if depth > 2 || !v.IsValid() {
+		return
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		if permission, ok := v.Interface().(*ec2.IpPermission); ok {
+			l.add(name, ipPermission(permission))
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.IsExported() && !ignoredFields[field.Name] {
+				l.collect(field.Name, v.Field(i), depth+1)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			l.collect(name, v.Index(i), depth)
+		}
+	case reflect.String:
+		if isIdentifier(name) {
+			l.add(name, v.String())
+		}
+	}
+}
+
+// isIdentifier reports whether a field holds the ID, ARN or name of resources. Resources holds the
+// IDs of the resources of the EC2 tagging calls.
+func isIdentifier(field string) bool {
+	if field == "Resources" {
+		return true
+	}
+	for _, suffix := range []string{"Id", "Ids", "Arn", "Arns", "Name", "Names"} {
+		if strings.HasSuffix(field, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ipPermission formats a security group rule as its protocol and port range, e.g. tcp/6443.
+func ipPermission(p *ec2.IpPermission) string {
+	protocol := "all"
+	if p.IpProtocol != nil && *p.IpProtocol != "-1" {
+		protocol = *p.IpProtocol
+	}
+	if p.FromPort == nil || p.ToPort == nil || protocol == "all" {
+		return protocol
+	}
+	if *p.FromPort == *p.ToPort {
+		return fmt.Sprintf("%s/%d", protocol, *p.FromPort)
+	}
+	return fmt.Sprintf("%s/%d-%d", protocol, *p.FromPort, *p.ToPort)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditevents
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+type event struct {
+	reason  string
+	message string
+}
+
+func TestRecordMutatingCall(t *testing.T) {
+	tests := []struct {
+		name      string
+		verbosity int
+		operation string
+		params    interface{}
+		data      interface{}
+		err       error
+		want      []event
+	}{
+		{
+			name:      "creation with the ID of the created resource first",
+			verbosity: VerbosityLifecycle,
+			operation: "CreateNatGateway",
+			params:    &ec2.CreateNatGatewayInput{SubnetId: aws.String("subnet-123"), AllocationId: aws.String("eipalloc-1")},
+			data: &ec2.CreateNatGatewayOutput{NatGateway: &ec2.NatGateway{
+				NatGatewayId: aws.String("nat-0abc"),
+				SubnetId:     aws.String("subnet-123"),
+			}},
+			want: []event{{"CreatedNatGateway", "EC2 CreateNatGateway: NatGatewayId=nat-0abc, SubnetId=subnet-123, AllocationId=eipalloc-1"}},
+		},
+		{
+			name:      "security group rules with their protocol and ports",
+			verbosity: VerbosityAll,
+			operation: "AuthorizeSecurityGroupIngress",
+			params: &ec2.AuthorizeSecurityGroupIngressInput{
+				GroupId: aws.String("sg-xyz"),
+				IpPermissions: []*ec2.IpPermission{
+					{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(6443), ToPort: aws.Int64(6443)},
+					{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(30000), ToPort: aws.Int64(32767)},
+					{IpProtocol: aws.String("-1")},
+				},
+			},
+			data: &ec2.AuthorizeSecurityGroupIngressOutput{},
+			want: []event{{"AuthorizedSecurityGroupIngress", "EC2 AuthorizeSecurityGroupIngress: GroupId=sg-xyz, IpPermissions=tcp/6443, IpPermissions=tcp/30000-32767, IpPermissions=all"}},
+		},
+		{
+			name:      "modification not published for lifecycle verbosity",
+			verbosity: VerbosityLifecycle,
+			operation: "ModifyInstanceAttribute",
+			params:    &ec2.ModifyInstanceAttributeInput{InstanceId: aws.String("i-1")},
+		},
+		{
+			name:      "tagging not published for lifecycle verbosity",
+			verbosity: VerbosityLifecycle,
+			operation: "CreateTags",
+			params:    &ec2.CreateTagsInput{Resources: aws.StringSlice([]string{"i-1"})},
+		},
+		{
+			name:      "tagging published for all verbosity",
+			verbosity: VerbosityAll,
+			operation: "DeleteTags",
+			params:    &ec2.DeleteTagsInput{Resources: aws.StringSlice([]string{"i-1"})},
+			want:      []event{{"DeletedTags", "EC2 DeleteTags: Resources=i-1"}},
+		},
+		{
+			name:      "read not published",
+			verbosity: VerbosityAll,
+			operation: "DescribeInstances",
+			params:    &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1"})},
+		},
+		{
+			name:      "failed call not published",
+			verbosity: VerbosityAll,
+			operation: "TerminateInstances",
+			params:    &ec2.TerminateInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1"})},
+			err:       errors.New("failed"),
+		},
+		{
+			name:      "nothing published by default",
+			verbosity: VerbosityNone,
+			operation: "TerminateInstances",
+			params:    &ec2.TerminateInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1"})},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var events []event
+			defaultRecordEvent := recordEvent
+			recordEvent = func(_ runtime.Object, reason, message string) {
+				events = append(events, event{reason, message})
+			}
+			defer func() {
+				recordEvent = defaultRecordEvent
+			}()
+
+			r := &request.Request{
+				ClientInfo: metadata.ClientInfo{ServiceID: ec2.ServiceID},
+				Operation:  &request.Operation{Name: tt.operation},
+				Params:     tt.params,
+				Data:       tt.data,
+				Error:      tt.err,
+			}
+			RecordMutatingCall(&infrav1.AWSCluster{}, tt.verbosity)(r)
+			g.Expect(events).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestReasonFor(t *testing.T) {
+	g := NewWithT(t)
+
+	reason, ok := reasonFor("RunInstances", VerbosityLifecycle)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(reason).To(Equal("RanInstances"))
+
+	reason, ok = reasonFor("DisassociateRouteTable", VerbosityAll)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(reason).To(Equal("DisassociatedRouteTable"))
+
+	_, ok = reasonFor("DeleteMessage", VerbosityAll)
+	g.Expect(ok).To(BeFalse())
+
+	_, ok = reasonFor("AssumeRole", VerbosityAll)
+	g.Expect(ok).To(BeFalse())
+}
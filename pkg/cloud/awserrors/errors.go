@@ -17,6 +17,7 @@ limitations under the License.
 package awserrors
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -28,6 +29,7 @@ const (
 	AssociationIDNotFound             = "InvalidAssociationID.NotFound"
 	AuthFailure                       = "AuthFailure"
 	BucketAlreadyOwnedByYou           = "BucketAlreadyOwnedByYou"
+	BucketNotEmpty                    = "BucketNotEmpty"
 	EIPNotFound                       = "InvalidElasticIpID.NotFound"
 	GatewayNotFound                   = "InvalidGatewayID.NotFound"
 	GroupNotFound                     = "InvalidGroup.NotFound"
@@ -48,6 +50,7 @@ const (
 	ResourceExists                          = "ResourceExistsException"
 	ResourceNotFound                        = "InvalidResourceID.NotFound"
 	RouteTableNotFound                      = "InvalidRouteTableID.NotFound"
+	SecurityGroupsPerInterfaceLimitExceeded = "SecurityGroupsPerInterfaceLimitExceeded"
 	SubnetNotFound                          = "InvalidSubnetID.NotFound"
 	UnrecognizedClientException             = "UnrecognizedClientException"
 	VPCNotFound                             = "InvalidVpcID.NotFound"
@@ -57,18 +60,20 @@ const (
 
 var _ error = &EC2Error{}
 
-// Code returns the AWS error code as a string.
+// Code returns the AWS error code as a string. Wrapped errors are unwrapped.
 func Code(err error) (string, bool) {
-	if awserr, ok := err.(awserr.Error); ok {
-		return awserr.Code(), true
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code(), true
 	}
 	return "", false
 }
 
-// Message returns the AWS error message as a string.
+// Message returns the AWS error message as a string. Wrapped errors are unwrapped.
 func Message(err error) string {
-	if awserr, ok := err.(awserr.Error); ok {
-		return awserr.Message()
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Message()
 	}
 	return ""
 }
@@ -108,6 +113,14 @@ func IsBucketAlreadyOwnedByYou(err error) bool {
 	return false
 }
 
+// IsBucketNotEmpty checks if the bucket can't be deleted because it still contains objects.
+func IsBucketNotEmpty(err error) bool {
+	if code, ok := Code(err); ok {
+		return code == BucketNotEmpty
+	}
+	return false
+}
+
 // IsResourceExists checks the state of the resource.
 func IsResourceExists(err error) bool {
 	if code, ok := Code(err); ok {
@@ -137,23 +150,20 @@ func IsFailedDependency(err error) bool {
 	return ReasonForError(err) == http.StatusFailedDependency
 }
 
-// IsNotFound returns true if the error was created by NewNotFound.
+// IsNotFound returns true if the error was created by NewNotFound or is an AWS error of KindNotFound.
 func IsNotFound(err error) bool {
-	if ReasonForError(err) == http.StatusNotFound {
-		return true
-	}
-	return IsInvalidNotFoundError(err)
+	return KindOf(err) == KindNotFound
 }
 
-// IsConflict returns true if the error was created by NewConflict.
+// IsConflict returns true if the error was created by NewConflict or is an AWS error of KindConflict.
 func IsConflict(err error) bool {
-	return ReasonForError(err) == http.StatusConflict
+	return KindOf(err) == KindConflict
 }
 
 // IsSDKError returns true if the error is of type awserr.Error.
-func IsSDKError(err error) (ok bool) {
-	_, ok = err.(awserr.Error)
-	return
+func IsSDKError(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr)
 }
 
 // IsInvalidNotFoundError tests for common aws not found errors.
@@ -176,7 +186,8 @@ func IsInvalidNotFoundError(err error) bool {
 
 // ReasonForError returns the HTTP status for a particular error.
 func ReasonForError(err error) int {
-	if t, ok := err.(*EC2Error); ok {
+	var t *EC2Error
+	if errors.As(err, &t) {
 		return t.Code
 	}
 
@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awserrors
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Kind classifies the errors returned by the AWS APIs independently of the service and of the
+// operation that returned them, so that callers don't need to match the error codes of each service.
+type Kind string
+
+const (
+	// KindUnknown is the Kind of the errors that are not classified, including the errors that were
+	// not returned by AWS.
+	KindUnknown = Kind("")
+
+	// KindThrottled is the Kind of the errors returned when requests are throttled.
+	KindThrottled = Kind("Throttled")
+
+	// KindNotFound is the Kind of the errors returned when a resource doesn't exist.
+	KindNotFound = Kind("NotFound")
+
+	// KindPermissionDenied is the Kind of the errors returned when the credentials are invalid or
+	// lack the permissions required by a request.
+	KindPermissionDenied = Kind("PermissionDenied")
+
+	// KindQuotaExceeded is the Kind of the errors returned when a request would exceed a service quota.
+	KindQuotaExceeded = Kind("QuotaExceeded")
+
+	// KindConflict is the Kind of the errors returned when a request conflicts with the current state
+	// of a resource, e.g. because the resource already exists or is in use.
+	KindConflict = Kind("Conflict")
+)
+
+// kindsByCode classifies the error codes that can't be classified by their prefix or suffix.
+var kindsByCode = map[string]Kind{
+	"BandwidthLimitExceeded":                 KindThrottled,
+	"DependencyThrottle":                     KindThrottled,
+	"EC2ThrottledException":                  KindThrottled,
+	"PriorRequestNotComplete":                KindThrottled,
+	"ProvisionedThroughputExceededException": KindThrottled,
+	"RequestLimitExceeded":                   KindThrottled,
+	"RequestThrottled":                       KindThrottled,
+	"RequestThrottledException":              KindThrottled,
+	"SlowDown":                               KindThrottled,
+	"ThrottledException":                     KindThrottled,
+	"Throttling":                             KindThrottled,
+	"ThrottlingException":                    KindThrottled,
+	"TooManyRequestsException":               KindThrottled,
+
+	"AWS.SimpleQueueService.NonExistentQueue": KindNotFound,
+	"InvalidInstance":                         KindNotFound,
+
+	"AccessDenied":                  KindPermissionDenied,
+	"AccessDeniedException":         KindPermissionDenied,
+	AuthFailure:                     KindPermissionDenied,
+	"ExpiredToken":                  KindPermissionDenied,
+	"ExpiredTokenException":         KindPermissionDenied,
+	InvalidAccessKeyID:              KindPermissionDenied,
+	InvalidClientTokenID:            KindPermissionDenied,
+	NoCredentialProviders:           KindPermissionDenied,
+	"OptInRequired":                 KindPermissionDenied,
+	"SignatureDoesNotMatch":         KindPermissionDenied,
+	"UnauthorizedOperation":         KindPermissionDenied,
+	UnrecognizedClientException:     KindPermissionDenied,
+	"ServiceQuotaExceededException": KindQuotaExceeded,
+	"TooManyLoadBalancers":          KindQuotaExceeded,
+	"TooManyTargetGroups":           KindQuotaExceeded,
+	"TooManyRules":                  KindQuotaExceeded,
+	"TooManyTags":                   KindQuotaExceeded,
+
+	BucketAlreadyOwnedByYou:                 KindConflict,
+	BucketNotEmpty:                          KindConflict,
+	"ConcurrentModificationException":       KindConflict,
+	"ConflictException":                     KindConflict,
+	"DependencyViolation":                   KindConflict,
+	"EntityAlreadyExists":                   KindConflict,
+	"IncorrectInstanceState":                KindConflict,
+	"IncorrectState":                        KindConflict,
+	ErrCodeRepositoryAlreadyExistsException: KindConflict,
+	ResourceExists:                          KindConflict,
+	"ResourceInUse":                         KindConflict,
+	"ResourceInUseException":                KindConflict,
+}
+
+// kinded is implemented by the errors of the services of this library that aren't returned by the
+// AWS APIs, such as the errors of the service quota checks, to report their Kind.
+type kinded interface {
+	Kind() Kind
+}
+
+// KindOf returns the Kind of an error returned by the AWS APIs or by the services of this library.
+// Wrapped errors are unwrapped.
+func KindOf(err error) Kind {
+	var k kinded
+	if errors.As(err, &k) {
+		return k.Kind()
+	}
+
+	switch ReasonForError(err) {
+	case http.StatusNotFound:
+		return KindNotFound
+	case http.StatusConflict:
+		return KindConflict
+	}
+
+	code, ok := Code(err)
+	if !ok {
+		return KindUnknown
+	}
+	if kind, ok := kindsByCode[code]; ok {
+		return kind
+	}
+
+	switch {
+	case strings.HasSuffix(code, "NotFound"), strings.HasSuffix(code, "NotFoundException"), strings.HasPrefix(code, "NoSuch"):
+		return KindNotFound
+	case strings.HasSuffix(code, "LimitExceeded"), strings.HasSuffix(code, "LimitExceededException"):
+		return KindQuotaExceeded
+	case strings.HasSuffix(code, ".Duplicate"), strings.HasSuffix(code, ".InUse"), strings.HasSuffix(code, "AlreadyExists"):
+		return KindConflict
+	}
+
+	return KindUnknown
+}
+
+// IsThrottled returns true if the request that returned the error was throttled.
+func IsThrottled(err error) bool {
+	return KindOf(err) == KindThrottled
+}
+
+// IsPermissionDenied returns true if the error was caused by invalid credentials or missing permissions.
+func IsPermissionDenied(err error) bool {
+	return KindOf(err) == KindPermissionDenied
+}
+
+// IsQuotaExceeded returns true if the error was caused by a service quota, either returned by the AWS
+// APIs or by the service quota checks made before creating resources.
+func IsQuotaExceeded(err error) bool {
+	return KindOf(err) == KindQuotaExceeded
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awserrors
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+type kindedError struct {
+	kind Kind
+}
+
+func (e *kindedError) Error() string { return string(e.kind) }
+
+func (e *kindedError) Kind() Kind { return e.kind }
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{
+			name: "nil error",
+			want: KindUnknown,
+		},
+		{
+			name: "error not returned by AWS",
+			err:  errors.New("boom"),
+			want: KindUnknown,
+		},
+		{
+			name: "error created by NewNotFound",
+			err:  NewNotFound("missing"),
+			want: KindNotFound,
+		},
+		{
+			name: "wrapped error created by NewConflict",
+			err:  errors.Wrap(NewConflict("conflict"), "failed"),
+			want: KindConflict,
+		},
+		{
+			name: "throttling",
+			err:  awserr.New("Throttling", "Rate exceeded", nil),
+			want: KindThrottled,
+		},
+		{
+			name: "request limit exceeded is throttling, not a quota",
+			err:  awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil),
+			want: KindThrottled,
+		},
+		{
+			name: "EC2 not found",
+			err:  awserr.New(SubnetNotFound, "The subnet ID does not exist", nil),
+			want: KindNotFound,
+		},
+		{
+			name: "wrapped EKS not found",
+			err:  errors.Wrap(awserr.New(eks.ErrCodeResourceNotFoundException, "No cluster found", nil), "failed to describe cluster"),
+			want: KindNotFound,
+		},
+		{
+			name: "IAM not found",
+			err:  awserr.New(iam.ErrCodeNoSuchEntityException, "The role cannot be found", nil),
+			want: KindNotFound,
+		},
+		{
+			name: "SQS not found",
+			err:  awserr.New(sqs.ErrCodeQueueDoesNotExist, "The specified queue does not exist", nil),
+			want: KindNotFound,
+		},
+		{
+			name: "access denied",
+			err:  awserr.New("AccessDenied", "User is not authorized", nil),
+			want: KindPermissionDenied,
+		},
+		{
+			name: "unauthorized operation",
+			err:  awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation", nil),
+			want: KindPermissionDenied,
+		},
+		{
+			name: "EC2 quota",
+			err:  awserr.New("VcpuLimitExceeded", "You have requested more vCPU capacity than your current vCPU limit", nil),
+			want: KindQuotaExceeded,
+		},
+		{
+			name: "IAM quota",
+			err:  awserr.New(iam.ErrCodeLimitExceededException, "Cannot exceed quota for RolesPerAccount", nil),
+			want: KindQuotaExceeded,
+		},
+		{
+			name: "wrapped error of a service classifying itself",
+			err:  errors.Wrap(&kindedError{kind: KindQuotaExceeded}, "failed to create instance"),
+			want: KindQuotaExceeded,
+		},
+		{
+			name: "S3 bucket not empty",
+			err:  awserr.New("BucketNotEmpty", "The bucket you tried to delete is not empty", nil),
+			want: KindConflict,
+		},
+		{
+			name: "dependency violation",
+			err:  awserr.New("DependencyViolation", "The vpc has dependencies and cannot be deleted", nil),
+			want: KindConflict,
+		},
+		{
+			name: "duplicate",
+			err:  awserr.New("InvalidPermission.Duplicate", "The specified rule already exists", nil),
+			want: KindConflict,
+		},
+		{
+			name: "unclassified code",
+			err:  awserr.New(InvalidSubnet, "The subnet is invalid", nil),
+			want: KindUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(KindOf(tt.err)).To(Equal(tt.want))
+		})
+	}
+}
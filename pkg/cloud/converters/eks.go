@@ -22,6 +22,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/eks"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
@@ -146,6 +147,20 @@ func TaintEffectFromSDK(effect string) (expinfrav1.TaintEffect, error) {
 	}
 }
 
+// TaintEffectToKubernetes is used to convert a TaintEffect to the Kubernetes taint effect.
+func TaintEffectToKubernetes(effect expinfrav1.TaintEffect) (corev1.TaintEffect, error) {
+	switch effect {
+	case expinfrav1.TaintEffectNoExecute:
+		return corev1.TaintEffectNoExecute, nil
+	case expinfrav1.TaintEffectPreferNoSchedule:
+		return corev1.TaintEffectPreferNoSchedule, nil
+	case expinfrav1.TaintEffectNoSchedule:
+		return corev1.TaintEffectNoSchedule, nil
+	default:
+		return "", ErrUnknowTaintEffect
+	}
+}
+
 func ConvertSDKToIdentityProvider(in *ekscontrolplanev1.OIDCIdentityProviderConfig) *identityprovider.OidcIdentityProviderConfig {
 	if in != nil {
 		if in.RequiredClaims == nil {
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// GPUResourceNames maps the GPU manufacturers reported by EC2 to the extended
+// resource name their device plugin advertises.
+var GPUResourceNames = map[string]corev1.ResourceName{
+	"NVIDIA": "nvidia.com/gpu",
+	"AMD":    "amd.com/gpu",
+}
+
+// InstanceTypeInfoToResourceList converts the vCPUs, memory and GPUs of an ec2.InstanceTypeInfo
+// into the capacity of the nodes of that instance type.
+func InstanceTypeInfoToResourceList(info *ec2.InstanceTypeInfo) corev1.ResourceList {
+	capacity := corev1.ResourceList{}
+	if info == nil {
+		return capacity
+	}
+
+	if info.VCpuInfo != nil && aws.Int64Value(info.VCpuInfo.DefaultVCpus) > 0 {
+		capacity[corev1.ResourceCPU] = *resource.NewQuantity(aws.Int64Value(info.VCpuInfo.DefaultVCpus), resource.DecimalSI)
+	}
+	if info.MemoryInfo != nil && aws.Int64Value(info.MemoryInfo.SizeInMiB) > 0 {
+		capacity[corev1.ResourceMemory] = resource.MustParse(fmt.Sprintf("%dMi", aws.Int64Value(info.MemoryInfo.SizeInMiB)))
+	}
+	if info.GpuInfo != nil {
+		gpus := map[corev1.ResourceName]int64{}
+		for _, gpu := range info.GpuInfo.Gpus {
+			name, ok := GPUResourceNames[aws.StringValue(gpu.Manufacturer)]
+			if !ok {
+				continue
+			}
+			gpus[name] += aws.Int64Value(gpu.Count)
+		}
+		for name, count := range gpus {
+			capacity[name] = *resource.NewQuantity(count, resource.DecimalSI)
+		}
+	}
+
+	return capacity
+}
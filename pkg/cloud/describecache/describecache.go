@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package describecache provides a short-lived cache of the results of the AWS describe calls
+// that are repeated by every reconciliation of a cluster, such as describing its instances,
+// subnets, security groups and availability zones.
+package describecache
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// Cache stores the results of describe calls for its TTL. A cache is created with each AWS session of a
+// cluster, so that the clients created for every reconciliation of the cluster share the results of the
+// previous ones. It is invalidated entirely by any other call made by the clients of the session, as the
+// writes of the controller may change any of the results.
+type Cache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+type entry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// New returns an empty cache keeping results for ttl.
+func New(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]entry{},
+	}
+}
+
+// Get copies the result cached for key into out, which must be a pointer to the type of the
+// cached result, and reports whether a result was found.
+func (c *Cache) Get(key string, out interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	if c.now().After(e.expires) {
+		delete(c.entries, key)
+		return false
+	}
+	awsutil.Copy(out, e.value)
+	return true
+}
+
+// Set caches a copy of value, which must be a pointer, for key.
+func (c *Cache) Set(key string, value interface{}) {
+	cp := reflect.New(reflect.TypeOf(value).Elem()).Interface()
+	awsutil.Copy(cp, value)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry{value: cp, expires: c.now().Add(c.ttl)}
+}
+
+// Invalidate drops all the cached results.
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]entry{}
+}
+
+// InvalidateOnWrite is a request handler invalidating the cache after any call that may change
+// the resources of the account, whether it succeeded or not.
+func (c *Cache) InvalidateOnWrite(r *request.Request) {
+	if !isRead(r.Operation.Name) {
+		c.Invalidate()
+	}
+}
+
+func isRead(operation string) bool {
+	for _, prefix := range []string{"Describe", "Get", "List"} {
+		if strings.HasPrefix(operation, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package describecache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestEC2ClientCachesDescribeCalls(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	now := time.Now()
+	cache := New(time.Minute)
+	cache.now = func() time.Time { return now }
+	client := NewEC2Client(ec2Mock, cache)
+
+	input := &ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{"subnet-1"})}
+	ec2Mock.EXPECT().DescribeSubnets(input).Return(&ec2.DescribeSubnetsOutput{
+		Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-1"), AvailabilityZone: aws.String("us-east-1a")}},
+	}, nil).Times(2)
+
+	out, err := client.DescribeSubnets(input)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.Subnets).To(HaveLen(1))
+
+	// Callers get their own copy of the cached result.
+	out.Subnets[0].AvailabilityZone = aws.String("changed")
+	out, err = client.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{"subnet-1"})})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(aws.StringValue(out.Subnets[0].AvailabilityZone)).To(Equal("us-east-1a"))
+
+	// Results expire after the TTL.
+	now = now.Add(2 * time.Minute)
+	_, err = client.DescribeSubnets(input)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestEC2ClientDoesNotCacheErrors(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	client := NewEC2Client(ec2Mock, New(time.Minute))
+
+	input := &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1"})}
+	gomock.InOrder(
+		ec2Mock.EXPECT().DescribeInstances(input).Return(nil, errors.New("throttled")),
+		ec2Mock.EXPECT().DescribeInstances(input).Return(&ec2.DescribeInstancesOutput{}, nil),
+	)
+
+	_, err := client.DescribeInstances(input)
+	g.Expect(err).To(HaveOccurred())
+	_, err = client.DescribeInstances(input)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = client.DescribeInstances(input)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestEC2ClientCachesDescribePages(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	client := NewEC2Client(ec2Mock, New(time.Minute))
+
+	input := &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1", "i-2"})}
+	pages := []*ec2.DescribeInstancesOutput{
+		{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{{InstanceId: aws.String("i-1")}}}}},
+		{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{{InstanceId: aws.String("i-2")}}}}},
+	}
+	describe := func(_ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+		for i, page := range pages {
+			if !fn(page, i == len(pages)-1) {
+				break
+			}
+		}
+		return nil
+	}
+	ec2Mock.EXPECT().DescribeInstancesPages(input, gomock.Any()).DoAndReturn(describe).Times(2)
+
+	instanceIDs := func() []string {
+		var ids []string
+		err := client.DescribeInstancesPages(input, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+			for _, r := range out.Reservations {
+				for _, i := range r.Instances {
+					ids = append(ids, aws.StringValue(i.InstanceId))
+				}
+			}
+			return true
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		return ids
+	}
+
+	// A call stopping at the first page isn't cached.
+	err := client.DescribeInstancesPages(input, func(*ec2.DescribeInstancesOutput, bool) bool { return false })
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Complete results are cached, and served page by page.
+	g.Expect(instanceIDs()).To(Equal([]string{"i-1", "i-2"}))
+	g.Expect(instanceIDs()).To(Equal([]string{"i-1", "i-2"}))
+}
+
+func TestInvalidateOnWrite(t *testing.T) {
+	tests := []struct {
+		operation       string
+		wantInvalidated bool
+	}{
+		{operation: "DescribeSecurityGroups"},
+		{operation: "GetConsoleOutput"},
+		{operation: "RunInstances", wantInvalidated: true},
+		{operation: "AuthorizeSecurityGroupIngress", wantInvalidated: true},
+		{operation: "CreateTags", wantInvalidated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			g := NewWithT(t)
+			cache := New(time.Minute)
+			cache.Set("key", &ec2.DescribeSecurityGroupsOutput{})
+
+			cache.InvalidateOnWrite(&request.Request{Operation: &request.Operation{Name: tt.operation}})
+
+			g.Expect(cache.Get("key", &ec2.DescribeSecurityGroupsOutput{})).To(Equal(!tt.wantInvalidated))
+		})
+	}
+}
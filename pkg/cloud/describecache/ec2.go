@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package describecache
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// ec2Client serves the cached describe calls of an EC2 client from its cache.
+type ec2Client struct {
+	ec2iface.EC2API
+	cache *Cache
+}
+
+// NewEC2Client wraps client so that its instances, subnets, security groups and availability zones
+// are described from cache, including by the paged calls. The writes of client must invalidate cache,
+// see Cache.InvalidateOnWrite.
+func NewEC2Client(client ec2iface.EC2API, cache *Cache) ec2iface.EC2API {
+	return &ec2Client{EC2API: client, cache: cache}
+}
+
+func (c *ec2Client) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	key := "DescribeInstances/" + input.String()
+	out := &ec2.DescribeInstancesOutput{}
+	if c.cache.Get(key, out) {
+		return out, nil
+	}
+	out, err := c.EC2API.DescribeInstances(input)
+	if err == nil {
+		c.cache.Set(key, out)
+	}
+	return out, err
+}
+
+// describeInstancesPages holds all the pages of a DescribeInstancesPages call. Only complete results are
+// cached, so that the pages the callback of a call stopped at aren't missing from the next ones.
+type describeInstancesPages struct {
+	Pages []*ec2.DescribeInstancesOutput
+}
+
+func (c *ec2Client) DescribeInstancesPages(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	key := "DescribeInstancesPages/" + input.String()
+	cached := &describeInstancesPages{}
+	if c.cache.Get(key, cached) {
+		for i, page := range cached.Pages {
+			if !fn(page, i == len(cached.Pages)-1) {
+				break
+			}
+		}
+		return nil
+	}
+
+	all := &describeInstancesPages{}
+	complete := true
+	err := c.EC2API.DescribeInstancesPages(input, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		// Keep a copy, as fn may change the page.
+		cp := &ec2.DescribeInstancesOutput{}
+		awsutil.Copy(cp, page)
+		all.Pages = append(all.Pages, cp)
+		complete = fn(page, lastPage)
+		return complete
+	})
+	if err == nil && complete {
+		c.cache.Set(key, all)
+	}
+	return err
+}
+
+func (c *ec2Client) DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	key := "DescribeSubnets/" + input.String()
+	out := &ec2.DescribeSubnetsOutput{}
+	if c.cache.Get(key, out) {
+		return out, nil
+	}
+	out, err := c.EC2API.DescribeSubnets(input)
+	if err == nil {
+		c.cache.Set(key, out)
+	}
+	return out, err
+}
+
+func (c *ec2Client) DescribeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	key := "DescribeSecurityGroups/" + input.String()
+	out := &ec2.DescribeSecurityGroupsOutput{}
+	if c.cache.Get(key, out) {
+		return out, nil
+	}
+	out, err := c.EC2API.DescribeSecurityGroups(input)
+	if err == nil {
+		c.cache.Set(key, out)
+	}
+	return out, err
+}
+
+// describeSecurityGroupsPages holds all the pages of a DescribeSecurityGroupsPages call, see describeInstancesPages.
+type describeSecurityGroupsPages struct {
+	Pages []*ec2.DescribeSecurityGroupsOutput
+}
+
+func (c *ec2Client) DescribeSecurityGroupsPages(input *ec2.DescribeSecurityGroupsInput, fn func(*ec2.DescribeSecurityGroupsOutput, bool) bool) error {
+	key := "DescribeSecurityGroupsPages/" + input.String()
+	cached := &describeSecurityGroupsPages{}
+	if c.cache.Get(key, cached) {
+		for i, page := range cached.Pages {
+			if !fn(page, i == len(cached.Pages)-1) {
+				break
+			}
+		}
+		return nil
+	}
+
+	all := &describeSecurityGroupsPages{}
+	complete := true
+	err := c.EC2API.DescribeSecurityGroupsPages(input, func(page *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool {
+		// Keep a copy, as fn may change the page.
+		cp := &ec2.DescribeSecurityGroupsOutput{}
+		awsutil.Copy(cp, page)
+		all.Pages = append(all.Pages, cp)
+		complete = fn(page, lastPage)
+		return complete
+	})
+	if err == nil && complete {
+		c.cache.Set(key, all)
+	}
+	return err
+}
+
+func (c *ec2Client) DescribeAvailabilityZones(input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	key := "DescribeAvailabilityZones/" + input.String()
+	out := &ec2.DescribeAvailabilityZonesOutput{}
+	if c.cache.Get(key, out) {
+		return out, nil
+	}
+	out, err := c.EC2API.DescribeAvailabilityZones(input)
+	if err == nil {
+		c.cache.Set(key, out)
+	}
+	return out, err
+}
@@ -139,3 +139,51 @@ func endpointsEqual(a, b []scope.ServiceEndpoint) bool {
 	}
 	return true
 }
+
+func TestLocalStack(t *testing.T) {
+	existing := []scope.ServiceEndpoint{
+		{
+			ServiceID:     "ec2",
+			URL:           "https://ec2.example.com",
+			SigningRegion: "us-iso",
+		},
+	}
+
+	t.Run("no localstack endpoint", func(t *testing.T) {
+		out, err := LocalStack("", existing)
+		if err != nil {
+			t.Fatalf("did not expect error: %v", err)
+		}
+		if !endpointsEqual(out, existing) {
+			t.Fatalf("did not expect correct output: got %v, expected %v", out, existing)
+		}
+	})
+
+	t.Run("non-valid URI", func(t *testing.T) {
+		_, err := LocalStack("localstack", nil)
+		if !errors.Is(err, errServiceEndpointURL) {
+			t.Fatalf("did not expect correct error: got %v, expected %v", err, errServiceEndpointURL)
+		}
+	})
+
+	t.Run("existing service endpoints take precedence", func(t *testing.T) {
+		out, err := LocalStack("http://localhost:4566", existing)
+		if err != nil {
+			t.Fatalf("did not expect error: %v", err)
+		}
+		if len(out) != len(localStackServiceIDs) {
+			t.Fatalf("expected %d service endpoints, got %d", len(localStackServiceIDs), len(out))
+		}
+		if out[0] != existing[0] {
+			t.Fatalf("expected existing service endpoint first, got %v", out[0])
+		}
+		for _, e := range out[1:] {
+			if e.ServiceID == "ec2" {
+				t.Fatalf("did not expect a second ec2 service endpoint: %v", e)
+			}
+			if e.URL != "http://localhost:4566" || e.SigningRegion != "" {
+				t.Fatalf("did not expect service endpoint %v", e)
+			}
+		}
+	})
+}
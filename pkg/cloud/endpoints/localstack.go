@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// localStackServiceIDs are the services used by the controllers. Classic and v2 load
+// balancers share the elasticloadbalancing endpoint.
+var localStackServiceIDs = []string{
+	autoscaling.EndpointsID,
+	ec2.EndpointsID,
+	eks.EndpointsID,
+	elbv2.EndpointsID,
+	eventbridge.EndpointsID,
+	iam.EndpointsID,
+	resourcegroupstaggingapi.EndpointsID,
+	s3.EndpointsID,
+	secretsmanager.EndpointsID,
+	servicequotas.EndpointsID,
+	shield.EndpointsID,
+	sqs.EndpointsID,
+	ssm.EndpointsID,
+	sts.EndpointsID,
+	wafv2.EndpointsID,
+}
+
+// LocalStack returns service endpoints pointing every service used by the controllers at a single
+// URL, such as the one of a LocalStack or moto server. The endpoints have no signing region, so that
+// requests are signed for the region of the cluster.
+//
+// Service endpoints that are already defined in existing are kept and take precedence.
+func LocalStack(localStackURL string, existing []scope.ServiceEndpoint) ([]scope.ServiceEndpoint, error) {
+	if localStackURL == "" {
+		return existing, nil
+	}
+	URL, err := url.ParseRequestURI(localStackURL)
+	if err != nil {
+		return nil, errServiceEndpointURL
+	}
+
+	endpoints := append([]scope.ServiceEndpoint{}, existing...)
+	for _, serviceID := range localStackServiceIDs {
+		if containsServiceEndpoint(existing, serviceID) {
+			continue
+		}
+		endpoints = append(endpoints, scope.ServiceEndpoint{
+			ServiceID: serviceID,
+			URL:       URL.String(),
+		})
+	}
+
+	return endpoints, nil
+}
+
+func containsServiceEndpoint(endpoints []scope.ServiceEndpoint, serviceID string) bool {
+	for _, e := range endpoints {
+		if e.ServiceID == serviceID {
+			return true
+		}
+	}
+
+	return false
+}
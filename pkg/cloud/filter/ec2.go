@@ -18,9 +18,11 @@ package filter
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/apimachinery/pkg/types"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 )
@@ -31,6 +33,7 @@ const (
 	filterNameState         = "state"
 	filterNameVpcAttachment = "attachment.vpc-id"
 	filterAvailabilityZone  = "availability-zone"
+	filterAllocationID      = "allocation-id"
 )
 
 // EC2 exposes the ec2 sdk related filters.
@@ -54,6 +57,14 @@ func (ec2Filters) Name(name string) *ec2.Filter {
 	}
 }
 
+// MachineName returns a filter based on the namespaced name of the machine the resource was created for.
+func (ec2Filters) MachineName(machine types.NamespacedName) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String(fmt.Sprintf("tag:%s", infrav1.MachineNameTagKey)),
+		Values: aws.StringSlice([]string{machine.String()}),
+	}
+}
+
 // ClusterOwned returns a filter using the Cluster API per-cluster tag where
 // the resource is owned.
 func (ec2Filters) ClusterOwned(clusterName string) *ec2.Filter {
@@ -120,6 +131,14 @@ func (ec2Filters) NATGatewayStates(states ...string) *ec2.Filter {
 	}
 }
 
+// InstanceConnectEndpointStates returns a filter based on the list of states passed in.
+func (ec2Filters) InstanceConnectEndpointStates(states ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("state"),
+		Values: aws.StringSlice(states),
+	}
+}
+
 // InstanceStates returns a filter based on the list of states passed in.
 func (ec2Filters) InstanceStates(states ...string) *ec2.Filter {
 	return &ec2.Filter{
@@ -128,6 +147,14 @@ func (ec2Filters) InstanceStates(states ...string) *ec2.Filter {
 	}
 }
 
+// InstanceIDs returns a filter selecting the resources of the instances passed in.
+func (ec2Filters) InstanceIDs(ids ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("instance-id"),
+		Values: aws.StringSlice(ids),
+	}
+}
+
 // VPCStates returns a filter based on the list of states passed in.
 func (ec2Filters) VPCStates(states ...string) *ec2.Filter {
 	return &ec2.Filter{
@@ -157,3 +184,28 @@ func (ec2Filters) IgnoreLocalZones() *ec2.Filter {
 		Values: aws.StringSlice([]string{"opt-in-not-required"}),
 	}
 }
+
+// ElasticIPPool returns the filters selecting the Elastic IPs of the pool.
+func (ec2Filters) ElasticIPPool(pool *infrav1.ElasticIPPool) []*ec2.Filter {
+	if len(pool.AllocationIDs) > 0 {
+		return []*ec2.Filter{{
+			Name:   aws.String(filterAllocationID),
+			Values: aws.StringSlice(pool.AllocationIDs),
+		}}
+	}
+
+	keys := make([]string, 0, len(pool.Tags))
+	for key := range pool.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	filters := make([]*ec2.Filter, 0, len(keys))
+	for _, key := range keys {
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String(fmt.Sprintf("tag:%s", key)),
+			Values: aws.StringSlice([]string{pool.Tags[key]}),
+		})
+	}
+	return filters
+}
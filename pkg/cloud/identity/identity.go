@@ -20,12 +20,16 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/gob"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	corev1 "k8s.io/api/core/v1"
 
@@ -33,6 +37,38 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 )
 
+// STSRegion is the region of the regional STS endpoint the roles are assumed with when their identity
+// doesn't set one.
+var STSRegion string
+
+// STSRegionalEndpoints makes the roles whose identity doesn't set an STS region, when STSRegion isn't set
+// either, be assumed with the regional STS endpoint of the region of the cluster instead of the global one.
+var STSRegionalEndpoints bool
+
+// stsRegion returns the region of the STS endpoint a role is assumed with: the STS region of its identity,
+// else STSRegion, else the region of the cluster with STSRegionalEndpoints. It is empty for the global endpoint.
+func stsRegion(identityRegion, clusterRegion string) string {
+	switch {
+	case identityRegion != "":
+		return identityRegion
+	case STSRegion != "":
+		return STSRegion
+	case STSRegionalEndpoints:
+		return clusterRegion
+	default:
+		return ""
+	}
+}
+
+// withSTSEndpoint sends the STS requests made with config to the regional STS endpoint of region, and leaves
+// the endpoint of config unchanged when region is empty.
+func withSTSEndpoint(config *aws.Config, region string) *aws.Config {
+	if region == "" {
+		return config
+	}
+	return config.WithRegion(region).WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint)
+}
+
 // AWSPrincipalTypeProvider defines the interface for AWS Principal Type Provider.
 type AWSPrincipalTypeProvider interface {
 	credentials.Provider
@@ -70,21 +106,71 @@ func GetAssumeRoleCredentials(roleIdentityProvider *AWSRolePrincipalTypeProvider
 			p.Policy = aws.String(roleIdentityProvider.Principal.Spec.InlinePolicy)
 		}
 		p.Duration = time.Duration(roleIdentityProvider.Principal.Spec.DurationSeconds) * time.Second
+		p.Tags = sessionTags(roleIdentityProvider.Principal.Spec.SessionTags)
+		if len(roleIdentityProvider.Principal.Spec.TransitiveTagKeys) > 0 {
+			p.TransitiveTagKeys = aws.StringSlice(roleIdentityProvider.Principal.Spec.TransitiveTagKeys)
+		}
 		// For testing
 		if roleIdentityProvider.stsClient != nil {
 			p.Client = roleIdentityProvider.stsClient
 		}
+		if sourceIdentity := roleIdentityProvider.Principal.Spec.SourceIdentity; sourceIdentity != "" {
+			if client, ok := p.Client.(stsiface.STSAPI); ok {
+				p.Client = &sourceIdentityAssumeRoler{STSAPI: client, sourceIdentity: sourceIdentity}
+			}
+		}
 	})
 	return creds
 }
 
+// sessionTags returns the tags in a stable order, so that the requests made
+// for an identity don't change between credential refreshes.
+func sessionTags(tags infrav1.Tags) []*sts.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	stsTags := make([]*sts.Tag, 0, len(keys))
+	for _, k := range keys {
+		stsTags = append(stsTags, &sts.Tag{Key: aws.String(k), Value: aws.String(tags[k])})
+	}
+	return stsTags
+}
+
+// sourceIdentityAssumeRoler sets the source identity of the role sessions, which
+// stscreds.AssumeRoleProvider has no option for.
+type sourceIdentityAssumeRoler struct {
+	stsiface.STSAPI
+	sourceIdentity string
+}
+
+// AssumeRole assumes a role with the source identity set.
+func (r *sourceIdentityAssumeRoler) AssumeRole(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
+	input.SourceIdentity = aws.String(r.sourceIdentity)
+	return r.STSAPI.AssumeRole(input)
+}
+
+// AssumeRoleWithContext assumes a role with the source identity set.
+func (r *sourceIdentityAssumeRoler) AssumeRoleWithContext(ctx aws.Context, input *sts.AssumeRoleInput, opts ...request.Option) (*sts.AssumeRoleOutput, error) {
+	input.SourceIdentity = aws.String(r.sourceIdentity)
+	return r.STSAPI.AssumeRoleWithContext(ctx, input, opts...)
+}
+
 // NewAWSRolePrincipalTypeProvider will create a new AWSRolePrincipalTypeProvider from an AWSClusterRoleIdentity.
-func NewAWSRolePrincipalTypeProvider(identity *infrav1.AWSClusterRoleIdentity, sourceProvider *AWSPrincipalTypeProvider, log logger.Wrapper) *AWSRolePrincipalTypeProvider {
+// region is the region of the cluster, whose STS endpoint is used with STSRegionalEndpoints.
+func NewAWSRolePrincipalTypeProvider(identity *infrav1.AWSClusterRoleIdentity, sourceProvider *AWSPrincipalTypeProvider, region string, log logger.Wrapper) *AWSRolePrincipalTypeProvider {
 	return &AWSRolePrincipalTypeProvider{
 		credentials:    nil,
 		stsClient:      nil,
 		Principal:      identity,
 		sourceProvider: sourceProvider,
+		region:         region,
 		log:            log.WithName("AWSRolePrincipalTypeProvider"),
 	}
 }
@@ -130,17 +216,20 @@ type AWSRolePrincipalTypeProvider struct {
 	Principal      *infrav1.AWSClusterRoleIdentity
 	credentials    *credentials.Credentials
 	sourceProvider *AWSPrincipalTypeProvider
+	region         string
 	log            logger.Wrapper
 	stsClient      stsiface.STSAPI
 }
 
-// Hash returns the byte encoded AWSRolePrincipalTypeProvider.
+// Hash returns the byte encoded AWSRolePrincipalTypeProvider. The region of the STS endpoint the role is
+// assumed with is part of the hash, so that the clusters of other regions don't share the provider.
 func (p *AWSRolePrincipalTypeProvider) Hash() (string, error) {
 	var roleIdentityValue bytes.Buffer
 	err := gob.NewEncoder(&roleIdentityValue).Encode(p)
 	if err != nil {
 		return "", err
 	}
+	roleIdentityValue.WriteString(stsRegion(p.Principal.Spec.STSRegion, p.region))
 	hash := sha256.New()
 	return string(hash.Sum(roleIdentityValue.Bytes())), nil
 }
@@ -161,6 +250,7 @@ func (p *AWSRolePrincipalTypeProvider) Retrieve() (credentials.Value, error) {
 			}
 			awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentialsFromCreds(sourceCreds))
 		}
+		awsConfig = withSTSEndpoint(awsConfig, stsRegion(p.Principal.Spec.STSRegion, p.region))
 
 		creds := GetAssumeRoleCredentials(p, awsConfig)
 		// Update credentials
@@ -22,6 +22,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
@@ -199,3 +200,186 @@ func TestAWSStaticPrincipalTypeProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestAWSRolePrincipalTypeProviderSessionTags(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			"AccessKeyID":     []byte("static-AccessKeyID"),
+			"SecretAccessKey": []byte("static-SecretAccessKey"),
+		},
+	}
+	var staticProvider AWSPrincipalTypeProvider = NewAWSStaticPrincipalTypeProvider(&infrav1.AWSClusterStaticIdentity{}, secret)
+
+	stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+	orgIdentity := &infrav1.AWSClusterRoleIdentity{
+		Spec: infrav1.AWSClusterRoleIdentitySpec{
+			AWSRoleSpec: infrav1.AWSRoleSpec{
+				RoleArn:         "arn:*:iam::*:role/org-access",
+				SessionName:     "org-access-session",
+				DurationSeconds: 900,
+			},
+			ExternalID:        "org-external-id",
+			SessionTags:       infrav1.Tags{"team": "platform", "cost-center": "1234"},
+			TransitiveTagKeys: []string{"team"},
+			SourceIdentity:    "capa-controller",
+		},
+	}
+	var orgProvider AWSPrincipalTypeProvider = &AWSRolePrincipalTypeProvider{
+		Principal:      orgIdentity,
+		sourceProvider: &staticProvider,
+		stsClient:      stsMock,
+	}
+	deploymentIdentity := &infrav1.AWSClusterRoleIdentity{
+		Spec: infrav1.AWSClusterRoleIdentitySpec{
+			AWSRoleSpec: infrav1.AWSRoleSpec{
+				RoleArn:         "arn:*:iam::*:role/deployment",
+				SessionName:     "deployment-session",
+				DurationSeconds: 900,
+			},
+			ExternalID:  "deployment-external-id",
+			SessionTags: infrav1.Tags{"cluster": "test"},
+		},
+	}
+	deploymentProvider := &AWSRolePrincipalTypeProvider{
+		Principal:      deploymentIdentity,
+		sourceProvider: &orgProvider,
+		stsClient:      stsMock,
+	}
+
+	stsMock.EXPECT().AssumeRoleWithContext(gomock.Any(), &sts.AssumeRoleInput{
+		RoleArn:         aws.String(orgIdentity.Spec.RoleArn),
+		RoleSessionName: aws.String(orgIdentity.Spec.SessionName),
+		DurationSeconds: pointer.Int64(int64(orgIdentity.Spec.DurationSeconds)),
+		ExternalId:      aws.String("org-external-id"),
+		Tags: []*sts.Tag{
+			{Key: aws.String("cost-center"), Value: aws.String("1234")},
+			{Key: aws.String("team"), Value: aws.String("platform")},
+		},
+		TransitiveTagKeys: aws.StringSlice([]string{"team"}),
+		SourceIdentity:    aws.String("capa-controller"),
+	}).Return(&sts.AssumeRoleOutput{
+		Credentials: &sts.Credentials{
+			AccessKeyId:     aws.String("orgAccessKeyId"),
+			SecretAccessKey: aws.String("orgSecretAccessKey"),
+			SessionToken:    aws.String("orgSessionToken"),
+			Expiration:      aws.Time(time.Now().AddDate(+1, 0, 0)),
+		},
+	}, nil)
+	stsMock.EXPECT().AssumeRoleWithContext(gomock.Any(), &sts.AssumeRoleInput{
+		RoleArn:         aws.String(deploymentIdentity.Spec.RoleArn),
+		RoleSessionName: aws.String(deploymentIdentity.Spec.SessionName),
+		DurationSeconds: pointer.Int64(int64(deploymentIdentity.Spec.DurationSeconds)),
+		ExternalId:      aws.String("deployment-external-id"),
+		Tags: []*sts.Tag{
+			{Key: aws.String("cluster"), Value: aws.String("test")},
+		},
+	}).Return(&sts.AssumeRoleOutput{
+		Credentials: &sts.Credentials{
+			AccessKeyId:     aws.String("deploymentAccessKeyId"),
+			SecretAccessKey: aws.String("deploymentSecretAccessKey"),
+			SessionToken:    aws.String("deploymentSessionToken"),
+			Expiration:      aws.Time(time.Now().AddDate(+1, 0, 0)),
+		},
+	}, nil)
+
+	value, err := deploymentProvider.Retrieve()
+	g.Expect(err).To(BeNil())
+	g.Expect(value.AccessKeyID).To(Equal("deploymentAccessKeyId"))
+}
+
+func TestSTSEndpoint(t *testing.T) {
+	tests := []struct {
+		name                 string
+		identityRegion       string
+		stsRegion            string
+		stsRegionalEndpoints bool
+		clusterRegion        string
+		wantEndpoint         string
+	}{
+		{
+			name:          "global endpoint by default",
+			clusterRegion: "us-east-1",
+			wantEndpoint:  "https://sts.amazonaws.com",
+		},
+		{
+			name:           "STS region of the identity",
+			identityRegion: "eu-central-1",
+			stsRegion:      "eu-west-1",
+			clusterRegion:  "us-east-1",
+			wantEndpoint:   "https://sts.eu-central-1.amazonaws.com",
+		},
+		{
+			name:                 "STS region of the controller",
+			stsRegion:            "eu-west-1",
+			stsRegionalEndpoints: true,
+			clusterRegion:        "us-east-1",
+			wantEndpoint:         "https://sts.eu-west-1.amazonaws.com",
+		},
+		{
+			name:                 "regional endpoint of the region of the cluster",
+			stsRegionalEndpoints: true,
+			clusterRegion:        "us-east-1",
+			wantEndpoint:         "https://sts.us-east-1.amazonaws.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			defer func(region string, regional bool) {
+				STSRegion, STSRegionalEndpoints = region, regional
+			}(STSRegion, STSRegionalEndpoints)
+			STSRegion, STSRegionalEndpoints = tt.stsRegion, tt.stsRegionalEndpoints
+
+			config := aws.NewConfig().WithRegion(tt.clusterRegion).WithCredentials(credentials.AnonymousCredentials)
+			sess := session.Must(session.NewSession(withSTSEndpoint(config, stsRegion(tt.identityRegion, tt.clusterRegion))))
+			g.Expect(sts.New(sess).Endpoint).To(Equal(tt.wantEndpoint))
+		})
+	}
+}
+
+func TestPrincipalTypeProviderHashPerSTSRegion(t *testing.T) {
+	g := NewWithT(t)
+	defer func(region string, regional bool) {
+		STSRegion, STSRegionalEndpoints = region, regional
+	}(STSRegion, STSRegionalEndpoints)
+	STSRegion, STSRegionalEndpoints = "", true
+
+	roleHash := func(identitySTSRegion, region string) string {
+		hash, err := (&AWSRolePrincipalTypeProvider{
+			Principal: &infrav1.AWSClusterRoleIdentity{
+				Spec: infrav1.AWSClusterRoleIdentitySpec{
+					AWSRoleSpec: infrav1.AWSRoleSpec{RoleArn: "arn:aws:iam::111111111111:role/capa"},
+					STSRegion:   identitySTSRegion,
+				},
+			},
+			region: region,
+		}).Hash()
+		g.Expect(err).NotTo(HaveOccurred())
+		return hash
+	}
+	webIdentityHash := func(region string) string {
+		hash, err := (&AWSWebIdentityPrincipalTypeProvider{
+			Principal: &infrav1.AWSClusterWebIdentity{
+				Spec: infrav1.AWSClusterWebIdentitySpec{AWSRoleSpec: infrav1.AWSRoleSpec{RoleArn: "arn:aws:iam::111111111111:role/capa"}},
+			},
+			region: region,
+		}).Hash()
+		g.Expect(err).NotTo(HaveOccurred())
+		return hash
+	}
+
+	// The same identity used in two regions assumes its role with two regional STS endpoints.
+	g.Expect(roleHash("", "us-east-1")).NotTo(Equal(roleHash("", "us-west-2")))
+	g.Expect(webIdentityHash("us-east-1")).NotTo(Equal(webIdentityHash("us-west-2")))
+	// The STS region of the identity is used in every region.
+	g.Expect(roleHash("eu-west-1", "us-east-1")).To(Equal(roleHash("eu-west-1", "us-west-2")))
+
+	// The global STS endpoint is used in every region.
+	STSRegionalEndpoints = false
+	g.Expect(roleHash("", "us-east-1")).To(Equal(roleHash("", "us-west-2")))
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+const (
+	rolesAnywhereService        = "rolesanywhere"
+	rolesAnywhereProviderName   = "RolesAnywhereProvider"
+	rolesAnywhereRSAAlgorithm   = "AWS4-X509-RSA-SHA256"
+	rolesAnywhereECDSAAlgorithm = "AWS4-X509-ECDSA-SHA256"
+	rolesAnywhereRequestTimeout = 30 * time.Second
+)
+
+// rolesAnywhereProvider retrieves credentials with the CreateSession API of IAM Roles Anywhere, which
+// the SDK has no client for. The requests are signed with the private key of the certificate, as
+// described in https://docs.aws.amazon.com/rolesanywhere/latest/userguide/authentication-sign-process.html.
+type rolesAnywhereProvider struct {
+	credentials.Expiry
+
+	client   *http.Client
+	endpoint string
+	region   string
+	now      func() time.Time
+
+	trustAnchorARN  string
+	profileARN      string
+	roleARN         string
+	durationSeconds int32
+
+	certificate *x509.Certificate
+	chain       []*x509.Certificate
+	signer      crypto.Signer
+	algorithm   string
+}
+
+type rolesAnywhereCreateSessionInput struct {
+	DurationSeconds *int32 `json:"durationSeconds,omitempty"`
+	ProfileArn      string `json:"profileArn"`
+	RoleArn         string `json:"roleArn"`
+	TrustAnchorArn  string `json:"trustAnchorArn"`
+}
+
+type rolesAnywhereCreateSessionOutput struct {
+	CredentialSet []struct {
+		Credentials struct {
+			AccessKeyID     string    `json:"accessKeyId"`
+			SecretAccessKey string    `json:"secretAccessKey"`
+			SessionToken    string    `json:"sessionToken"`
+			Expiration      time.Time `json:"expiration"`
+		} `json:"credentials"`
+	} `json:"credentialSet"`
+}
+
+func newRolesAnywhereProvider(source *infrav1.RolesAnywhereSource, roleARN string, durationSeconds int32, certificatePEM, keyPEM []byte) (*rolesAnywhereProvider, error) {
+	keyPair, err := tls.X509KeyPair(certificatePEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := make([]*x509.Certificate, 0, len(keyPair.Certificate))
+	for _, der := range keyPair.Certificate {
+		certificate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	p := &rolesAnywhereProvider{
+		client:          &http.Client{Timeout: rolesAnywhereRequestTimeout},
+		now:             time.Now,
+		trustAnchorARN:  source.TrustAnchorARN,
+		profileARN:      source.ProfileARN,
+		roleARN:         roleARN,
+		durationSeconds: durationSeconds,
+		certificate:     certificates[0],
+		chain:           certificates[1:],
+	}
+
+	switch key := keyPair.PrivateKey.(type) {
+	case *rsa.PrivateKey:
+		p.signer, p.algorithm = key, rolesAnywhereRSAAlgorithm
+	case *ecdsa.PrivateKey:
+		p.signer, p.algorithm = key, rolesAnywhereECDSAAlgorithm
+	default:
+		return nil, errors.Errorf("unsupported private key type %T, only RSA and ECDSA keys are supported", keyPair.PrivateKey)
+	}
+
+	trustAnchor, err := arn.Parse(source.TrustAnchorARN)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the trust anchor ARN")
+	}
+	p.region = trustAnchor.Region
+
+	endpoint, err := endpoints.DefaultResolver().EndpointFor(rolesAnywhereService, p.region)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve the IAM Roles Anywhere endpoint of region %s", p.region)
+	}
+	p.endpoint = endpoint.URL
+
+	return p, nil
+}
+
+// Retrieve creates a session with IAM Roles Anywhere.
+func (p *rolesAnywhereProvider) Retrieve() (credentials.Value, error) {
+	input := rolesAnywhereCreateSessionInput{
+		ProfileArn:     p.profileARN,
+		RoleArn:        p.roleARN,
+		TrustAnchorArn: p.trustAnchorARN,
+	}
+	if p.durationSeconds != 0 {
+		input.DurationSeconds = &p.durationSeconds
+	}
+	body, err := json.Marshal(input)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(p.endpoint, "/")+"/sessions", bytes.NewReader(body))
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	if err := p.sign(req, body); err != nil {
+		return credentials.Value{}, errors.Wrap(err, "failed to sign the IAM Roles Anywhere request")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return credentials.Value{}, errors.Wrap(err, "failed to create an IAM Roles Anywhere session")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return credentials.Value{}, errors.Wrap(err, "failed to read the IAM Roles Anywhere session")
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return credentials.Value{}, errors.Errorf("failed to create an IAM Roles Anywhere session: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	output := &rolesAnywhereCreateSessionOutput{}
+	if err := json.Unmarshal(respBody, output); err != nil {
+		return credentials.Value{}, errors.Wrap(err, "failed to decode the IAM Roles Anywhere session")
+	}
+	if len(output.CredentialSet) == 0 {
+		return credentials.Value{}, errors.New("IAM Roles Anywhere returned no credentials")
+	}
+
+	creds := output.CredentialSet[0].Credentials
+	p.SetExpiration(creds.Expiration, 0)
+	return credentials.Value{
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		ProviderName:    rolesAnywhereProviderName,
+	}, nil
+}
+
+// sign sets the headers authenticating req with the certificate of the provider.
+func (p *rolesAnywhereProvider) sign(req *http.Request, body []byte) error {
+	amzDate := p.now().UTC().Format("20060102T150405Z")
+	scope := strings.Join([]string{amzDate[:8], p.region, rolesAnywhereService, "aws4_request"}, "/")
+
+	headers := map[string]string{
+		"content-type": "application/json",
+		"host":         req.URL.Host,
+		"x-amz-date":   amzDate,
+		"x-amz-x509":   base64.StdEncoding.EncodeToString(p.certificate.Raw),
+	}
+	if len(p.chain) > 0 {
+		chain := make([]string, 0, len(p.chain))
+		for _, certificate := range p.chain {
+			chain = append(chain, base64.StdEncoding.EncodeToString(certificate.Raw))
+		}
+		headers["x-amz-x509-chain"] = strings.Join(chain, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, headers[name])
+		if name != "host" {
+			req.Header.Set(name, headers[name])
+		}
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256(body),
+	}, "\n")
+	stringToSign := strings.Join([]string{p.algorithm, amzDate, scope, hexSHA256([]byte(canonicalRequest))}, "\n")
+
+	digest := sha256.Sum256([]byte(stringToSign))
+	signature, err := p.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		p.algorithm, p.certificate.SerialNumber.String(), scope, signedHeaders, hex.EncodeToString(signature)))
+	return nil
+}
+
+func hexSHA256(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
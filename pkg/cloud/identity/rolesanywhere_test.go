@@ -0,0 +1,223 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+func TestAWSRolesAnywherePrincipalTypeProvider(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name      string
+		key       crypto.Signer
+		algorithm string
+	}{
+		{
+			name:      "Signs requests with an RSA key",
+			key:       rsaKey,
+			algorithm: rolesAnywhereRSAAlgorithm,
+		},
+		{
+			name:      "Signs requests with an ECDSA key",
+			key:       ecdsaKey,
+			algorithm: rolesAnywhereECDSAAlgorithm,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			certificate, secret := newRolesAnywhereSecret(t, tc.key)
+			now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+			expiration := now.Add(time.Hour)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				g.Expect(err).To(BeNil())
+				g.Expect(r.Method).To(Equal(http.MethodPost))
+				g.Expect(r.URL.Path).To(Equal("/sessions"))
+				g.Expect(r.Header.Get("X-Amz-X509")).To(Equal(base64.StdEncoding.EncodeToString(certificate.Raw)))
+				verifyRolesAnywhereSignature(g, r, body, certificate, tc.algorithm)
+
+				input := &rolesAnywhereCreateSessionInput{}
+				g.Expect(json.Unmarshal(body, input)).To(Succeed())
+				g.Expect(input.TrustAnchorArn).To(Equal("arn:aws:rolesanywhere:eu-west-1:123456789012:trust-anchor/anchor"))
+				g.Expect(input.ProfileArn).To(Equal("arn:aws:rolesanywhere:eu-west-1:123456789012:profile/profile"))
+				g.Expect(input.RoleArn).To(Equal("arn:aws:iam::123456789012:role/capa"))
+				g.Expect(*input.DurationSeconds).To(Equal(int32(3600)))
+
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"credentialSet":[{"credentials":{"accessKeyId":"raAccessKeyId","secretAccessKey":"raSecretAccessKey","sessionToken":"raSessionToken","expiration":%q}}]}`,
+					expiration.Format(time.RFC3339))
+			}))
+			defer server.Close()
+
+			webIdentity := &infrav1.AWSClusterWebIdentity{
+				Spec: infrav1.AWSClusterWebIdentitySpec{
+					AWSRoleSpec: infrav1.AWSRoleSpec{
+						RoleArn:         "arn:aws:iam::123456789012:role/capa",
+						DurationSeconds: 3600,
+					},
+					RolesAnywhere: &infrav1.RolesAnywhereSource{
+						TrustAnchorARN:       "arn:aws:rolesanywhere:eu-west-1:123456789012:trust-anchor/anchor",
+						ProfileARN:           "arn:aws:rolesanywhere:eu-west-1:123456789012:profile/profile",
+						CertificateSecretRef: "roles-anywhere-certificate",
+					},
+				},
+			}
+			provider, err := NewAWSRolesAnywherePrincipalTypeProvider(webIdentity, secret)
+			g.Expect(err).To(BeNil())
+			g.Expect(provider.rolesAnywhere.endpoint).To(Equal("https://rolesanywhere.eu-west-1.amazonaws.com"))
+			provider.rolesAnywhere.endpoint = server.URL
+			provider.rolesAnywhere.now = func() time.Time { return now }
+
+			value, err := provider.Retrieve()
+			g.Expect(err).To(BeNil())
+			g.Expect(value.AccessKeyID).To(Equal("raAccessKeyId"))
+			g.Expect(value.SecretAccessKey).To(Equal("raSecretAccessKey"))
+			g.Expect(value.SessionToken).To(Equal("raSessionToken"))
+			g.Expect(provider.rolesAnywhere.ExpiresAt()).To(Equal(expiration))
+		})
+	}
+}
+
+func TestAWSRolesAnywherePrincipalTypeProviderHash(t *testing.T) {
+	g := NewWithT(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).To(BeNil())
+	_, secret := newRolesAnywhereSecret(t, key)
+	_, renewedSecret := newRolesAnywhereSecret(t, key)
+
+	webIdentity := &infrav1.AWSClusterWebIdentity{
+		Spec: infrav1.AWSClusterWebIdentitySpec{
+			RolesAnywhere: &infrav1.RolesAnywhereSource{
+				TrustAnchorARN: "arn:aws:rolesanywhere:eu-west-1:123456789012:trust-anchor/anchor",
+				ProfileARN:     "arn:aws:rolesanywhere:eu-west-1:123456789012:profile/profile",
+			},
+		},
+	}
+	provider, err := NewAWSRolesAnywherePrincipalTypeProvider(webIdentity, secret)
+	g.Expect(err).To(BeNil())
+	renewedProvider, err := NewAWSRolesAnywherePrincipalTypeProvider(webIdentity, renewedSecret)
+	g.Expect(err).To(BeNil())
+
+	hash, err := provider.Hash()
+	g.Expect(err).To(BeNil())
+	renewedHash, err := renewedProvider.Hash()
+	g.Expect(err).To(BeNil())
+	g.Expect(renewedHash).NotTo(Equal(hash))
+}
+
+func newRolesAnywhereSecret(t *testing.T, key crypto.Signer) (*x509.Certificate, *corev1.Secret) {
+	t.Helper()
+
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: "capa-controller"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certificate, &corev1.Secret{
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
+
+// verifyRolesAnywhereSignature rebuilds the string to sign of r from its headers, and checks the signature
+// of the Authorization header against the public key of certificate.
+func verifyRolesAnywhereSignature(g *WithT, r *http.Request, body []byte, certificate *x509.Certificate, algorithm string) {
+	authorization := r.Header.Get("Authorization")
+	g.Expect(authorization).To(HavePrefix(algorithm + " Credential=" + certificate.SerialNumber.String() + "/20230501/eu-west-1/rolesanywhere/aws4_request, "))
+
+	fields := map[string]string{}
+	for _, field := range strings.Split(strings.TrimPrefix(authorization, algorithm+" "), ", ") {
+		kv := strings.SplitN(field, "=", 2)
+		fields[kv[0]] = kv[1]
+	}
+	g.Expect(fields["SignedHeaders"]).To(Equal("content-type;host;x-amz-date;x-amz-x509"))
+
+	canonicalRequest := fmt.Sprintf("POST\n/sessions\n\ncontent-type:%s\nhost:%s\nx-amz-date:%s\nx-amz-x509:%s\n\n%s\n%s",
+		r.Header.Get("Content-Type"), r.Host, r.Header.Get("X-Amz-Date"), r.Header.Get("X-Amz-X509"), fields["SignedHeaders"], hexSHA256(body))
+	stringToSign := fmt.Sprintf("%s\n%s\n%s\n%s", algorithm, r.Header.Get("X-Amz-Date"),
+		strings.SplitN(fields["Credential"], "/", 2)[1], hexSHA256([]byte(canonicalRequest)))
+	digest := sha256.Sum256([]byte(stringToSign))
+
+	signature, err := hex.DecodeString(fields["Signature"])
+	g.Expect(err).To(BeNil())
+	switch publicKey := certificate.PublicKey.(type) {
+	case *rsa.PublicKey:
+		g.Expect(rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature)).To(Succeed())
+	case *ecdsa.PublicKey:
+		g.Expect(ecdsa.VerifyASN1(publicKey, digest[:], signature)).To(BeTrue())
+	}
+}
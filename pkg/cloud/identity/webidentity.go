@@ -0,0 +1,172 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// serviceAccountTokenExpiration is the lifetime of the service account tokens requested for web identities.
+// The tokens are only used once, to assume the role.
+const serviceAccountTokenExpiration = 10 * time.Minute
+
+// NewAWSWebIdentityPrincipalTypeProvider will create a new AWSWebIdentityPrincipalTypeProvider from an
+// AWSClusterWebIdentity using web identity tokens. The role is assumed with the STS endpoint of region.
+func NewAWSWebIdentityPrincipalTypeProvider(identity *infrav1.AWSClusterWebIdentity, tokenFetcher stscreds.TokenFetcher, region string) *AWSWebIdentityPrincipalTypeProvider {
+	return &AWSWebIdentityPrincipalTypeProvider{
+		Principal:    identity,
+		tokenFetcher: tokenFetcher,
+		region:       region,
+	}
+}
+
+// NewAWSRolesAnywherePrincipalTypeProvider will create a new AWSWebIdentityPrincipalTypeProvider from an
+// AWSClusterWebIdentity using IAM Roles Anywhere, with the certificate and key stored in secret.
+func NewAWSRolesAnywherePrincipalTypeProvider(identity *infrav1.AWSClusterWebIdentity, secret *corev1.Secret) (*AWSWebIdentityPrincipalTypeProvider, error) {
+	certificate := secret.Data[corev1.TLSCertKey]
+	rolesAnywhere, err := newRolesAnywhereProvider(identity.Spec.RolesAnywhere, identity.Spec.RoleArn, identity.Spec.DurationSeconds,
+		certificate, secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load the certificate of secret %s/%s", secret.Namespace, secret.Name)
+	}
+
+	return &AWSWebIdentityPrincipalTypeProvider{
+		Principal:     identity,
+		Certificate:   certificate,
+		rolesAnywhere: rolesAnywhere,
+	}, nil
+}
+
+// AWSWebIdentityPrincipalTypeProvider defines the specs for an AWSPrincipalTypeProvider with a web identity.
+type AWSWebIdentityPrincipalTypeProvider struct {
+	Principal *infrav1.AWSClusterWebIdentity
+	// Certificate is part of the hash, so that renewed IAM Roles Anywhere certificates are picked up.
+	Certificate []byte
+
+	credentials   *credentials.Credentials
+	tokenFetcher  stscreds.TokenFetcher
+	region        string
+	rolesAnywhere *rolesAnywhereProvider
+	stsClient     stsiface.STSAPI
+}
+
+// Hash returns the byte encoded AWSWebIdentityPrincipalTypeProvider. The region of the STS endpoint the role
+// is assumed with is part of the hash, so that the clusters of other regions don't share the provider.
+func (p *AWSWebIdentityPrincipalTypeProvider) Hash() (string, error) {
+	var webIdentityValue bytes.Buffer
+	err := gob.NewEncoder(&webIdentityValue).Encode(p)
+	if err != nil {
+		return "", err
+	}
+	webIdentityValue.WriteString(stsRegion("", p.region))
+	hash := sha256.New()
+	return string(hash.Sum(webIdentityValue.Bytes())), nil
+}
+
+// Name returns the name of the AWSWebIdentityPrincipalTypeProvider.
+func (p *AWSWebIdentityPrincipalTypeProvider) Name() string {
+	return p.Principal.Name
+}
+
+// Retrieve returns the credential values for the AWSWebIdentityPrincipalTypeProvider.
+func (p *AWSWebIdentityPrincipalTypeProvider) Retrieve() (credentials.Value, error) {
+	if p.credentials == nil {
+		if p.rolesAnywhere != nil {
+			p.credentials = credentials.NewCredentials(p.rolesAnywhere)
+		} else {
+			p.credentials = credentials.NewCredentials(p.webIdentityRoleProvider())
+		}
+	}
+	return p.credentials.Get()
+}
+
+// IsExpired checks the expiration state of the AWSWebIdentityPrincipalTypeProvider.
+func (p *AWSWebIdentityPrincipalTypeProvider) IsExpired() bool {
+	return p.credentials == nil || p.credentials.IsExpired()
+}
+
+func (p *AWSWebIdentityPrincipalTypeProvider) webIdentityRoleProvider() *stscreds.WebIdentityRoleProvider {
+	stsClient := p.stsClient
+	if stsClient == nil {
+		// AssumeRoleWithWebIdentity requests are not signed.
+		config := aws.NewConfig().WithRegion(p.region).WithCredentials(credentials.AnonymousCredentials)
+		sess := session.Must(session.NewSession(withSTSEndpoint(config, stsRegion("", p.region))))
+		stsClient = sts.New(sess)
+	}
+
+	spec := p.Principal.Spec
+	return stscreds.NewWebIdentityRoleProviderWithOptions(stsClient, spec.RoleArn, spec.SessionName, p.tokenFetcher, func(wp *stscreds.WebIdentityRoleProvider) {
+		wp.Duration = time.Duration(spec.DurationSeconds) * time.Second
+		for _, policyARN := range spec.PolicyARNs {
+			wp.PolicyArns = append(wp.PolicyArns, &sts.PolicyDescriptorType{Arn: aws.String(policyARN)})
+		}
+	})
+}
+
+// NewServiceAccountTokenFetcher returns a stscreds.TokenFetcher requesting tokens for the service account
+// key with the TokenRequest API.
+func NewServiceAccountTokenFetcher(k8sClient client.Client, key client.ObjectKey, audience string) stscreds.TokenFetcher {
+	return &serviceAccountTokenFetcher{
+		client:   k8sClient,
+		key:      key,
+		audience: audience,
+	}
+}
+
+type serviceAccountTokenFetcher struct {
+	client   client.Client
+	key      client.ObjectKey
+	audience string
+}
+
+// FetchToken requests a new token for the service account.
+func (f *serviceAccountTokenFetcher) FetchToken(ctx credentials.Context) ([]byte, error) {
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      f.key.Name,
+			Namespace: f.key.Namespace,
+		},
+	}
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{f.audience},
+			ExpirationSeconds: pointer.Int64(int64(serviceAccountTokenExpiration / time.Second)),
+		},
+	}
+	if err := f.client.SubResource("token").Create(ctx, serviceAccount, tokenRequest); err != nil {
+		return nil, errors.Wrapf(err, "failed to request a token for service account %s", f.key)
+	}
+	return []byte(tokenRequest.Status.Token), nil
+}
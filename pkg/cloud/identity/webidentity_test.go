@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+type staticTokenFetcher string
+
+func (f staticTokenFetcher) FetchToken(credentials.Context) ([]byte, error) {
+	return []byte(f), nil
+}
+
+func TestAWSWebIdentityPrincipalTypeProvider(t *testing.T) {
+	g := NewWithT(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		g.Expect(r.ParseForm()).To(Succeed())
+		g.Expect(r.Form.Get("Action")).To(Equal("AssumeRoleWithWebIdentity"))
+		g.Expect(r.Form.Get("RoleArn")).To(Equal("arn:aws:iam::123456789012:role/capa"))
+		g.Expect(r.Form.Get("RoleSessionName")).To(Equal("capa-session"))
+		g.Expect(r.Form.Get("WebIdentityToken")).To(Equal("service-account-token"))
+		g.Expect(r.Form.Get("DurationSeconds")).To(Equal("900"))
+		g.Expect(r.Form.Get("PolicyArns.member.1.arn")).To(Equal("arn:aws:iam::aws:policy/ReadOnlyAccess"))
+		g.Expect(r.Header.Get("Authorization")).To(BeEmpty())
+
+		_, _ = w.Write([]byte(`<AssumeRoleWithWebIdentityResponse><AssumeRoleWithWebIdentityResult><Credentials>
+<AccessKeyId>webAccessKeyId</AccessKeyId><SecretAccessKey>webSecretAccessKey</SecretAccessKey>
+<SessionToken>webSessionToken</SessionToken><Expiration>2099-01-01T00:00:00Z</Expiration>
+</Credentials></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`))
+	}))
+	defer server.Close()
+
+	webIdentity := &infrav1.AWSClusterWebIdentity{
+		Spec: infrav1.AWSClusterWebIdentitySpec{
+			AWSRoleSpec: infrav1.AWSRoleSpec{
+				RoleArn:         "arn:aws:iam::123456789012:role/capa",
+				SessionName:     "capa-session",
+				DurationSeconds: 900,
+				PolicyARNs:      []string{"arn:aws:iam::aws:policy/ReadOnlyAccess"},
+			},
+			WebIdentity: &infrav1.WebIdentityTokenSource{
+				ServiceAccountName: "capa-web-identity",
+			},
+		},
+	}
+	provider := NewAWSWebIdentityPrincipalTypeProvider(webIdentity, staticTokenFetcher("service-account-token"), "us-east-1")
+	sess := session.Must(session.NewSession(aws.NewConfig().
+		WithRegion("us-east-1").
+		WithEndpoint(server.URL).
+		WithCredentials(credentials.AnonymousCredentials)))
+	provider.stsClient = sts.New(sess)
+
+	g.Expect(provider.IsExpired()).To(BeTrue())
+
+	value, err := provider.Retrieve()
+	g.Expect(err).To(BeNil())
+	g.Expect(value.AccessKeyID).To(Equal("webAccessKeyId"))
+	g.Expect(value.SecretAccessKey).To(Equal("webSecretAccessKey"))
+	g.Expect(value.SessionToken).To(Equal("webSessionToken"))
+	g.Expect(provider.IsExpired()).To(BeFalse())
+
+	// The credentials are cached until they expire.
+	_, err = provider.Retrieve()
+	g.Expect(err).To(BeNil())
+	g.Expect(requests).To(Equal(1))
+}
@@ -58,6 +58,8 @@ type ClusterScoper interface {
 	InfraClusterName() string
 	// Region returns the cluster region.
 	Region() string
+	// Partition returns the AWS partition of the cluster, e.g. aws or aws-us-gov, used to build ARNs.
+	Partition() string
 	// KubernetesClusterName is the name of the Kubernetes cluster. For EKS this
 	// will differ to the CAPI cluster name
 	KubernetesClusterName() string
@@ -77,6 +79,8 @@ type ClusterScoper interface {
 	APIServerPort() int32
 	// AdditionalTags returns any tags that you would like to attach to AWS resources. The returned value will never be nil.
 	AdditionalTags() infrav1.Tags
+	// Proxy returns the HTTP proxy settings of the nodes of the cluster, if any.
+	Proxy() *infrav1.ProxySpec
 	// SetFailureDomain sets the infrastructure provider failure domain key to the spec given as input.
 	SetFailureDomain(id string, spec clusterv1.FailureDomainSpec)
 
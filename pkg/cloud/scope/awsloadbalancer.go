@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+
+	awsclient "github.com/aws/aws-sdk-go/aws/client"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/describecache"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// AWSLoadBalancerScopeParams defines the input parameters used to create a new AWSLoadBalancerScope.
+type AWSLoadBalancerScopeParams struct {
+	Client          client.Client
+	Logger          *logger.Logger
+	Cluster         *clusterv1.Cluster
+	InfraCluster    EC2Scope
+	AWSLoadBalancer *expinfrav1.AWSLoadBalancer
+
+	// MachinePools are the AWSMachinePools referenced by the target groups of the load balancer
+	// that exist, by name.
+	MachinePools map[string]*expinfrav1.AWSMachinePool
+}
+
+// NewAWSLoadBalancerScope creates a new AWSLoadBalancerScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewAWSLoadBalancerScope(params AWSLoadBalancerScopeParams) (*AWSLoadBalancerScope, error) {
+	if params.Client == nil {
+		return nil, errors.New("client is required when creating an AWSLoadBalancerScope")
+	}
+	if params.Cluster == nil {
+		return nil, errors.New("cluster is required when creating an AWSLoadBalancerScope")
+	}
+	if params.AWSLoadBalancer == nil {
+		return nil, errors.New("aws load balancer is required when creating an AWSLoadBalancerScope")
+	}
+	if params.InfraCluster == nil {
+		return nil, errors.New("aws cluster is required when creating an AWSLoadBalancerScope")
+	}
+	if params.Logger == nil {
+		log := klog.Background()
+		params.Logger = logger.NewLogger(log)
+	}
+
+	helper, err := patch.NewHelper(params.AWSLoadBalancer, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init AWSLoadBalancer patch helper")
+	}
+
+	return &AWSLoadBalancerScope{
+		Logger:          *params.Logger,
+		Client:          params.Client,
+		Cluster:         params.Cluster,
+		InfraCluster:    params.InfraCluster,
+		AWSLoadBalancer: params.AWSLoadBalancer,
+		MachinePools:    params.MachinePools,
+		patchHelper:     helper,
+	}, nil
+}
+
+// AWSLoadBalancerScope defines the basic context for an actuator to operate upon.
+type AWSLoadBalancerScope struct {
+	logger.Logger
+	Client      client.Client
+	patchHelper *patch.Helper
+
+	Cluster         *clusterv1.Cluster
+	InfraCluster    EC2Scope
+	AWSLoadBalancer *expinfrav1.AWSLoadBalancer
+	MachinePools    map[string]*expinfrav1.AWSMachinePool
+}
+
+// Name returns the name of the AWSLoadBalancer.
+func (s *AWSLoadBalancerScope) Name() string {
+	return s.AWSLoadBalancer.Name
+}
+
+// Namespace returns the namespace of the AWSLoadBalancer.
+func (s *AWSLoadBalancerScope) Namespace() string {
+	return s.AWSLoadBalancer.Namespace
+}
+
+// ClusterName returns the name of the cluster of the load balancer.
+func (s *AWSLoadBalancerScope) ClusterName() string {
+	return s.Cluster.Name
+}
+
+// Session returns the AWS SDK session of the cluster. Used for creating clients.
+func (s *AWSLoadBalancerScope) Session() awsclient.ConfigProvider {
+	return s.InfraCluster.Session()
+}
+
+// ServiceLimiter returns the AWS SDK session of the cluster. Used for creating clients.
+func (s *AWSLoadBalancerScope) ServiceLimiter(service string) *throttle.ServiceLimiter {
+	return s.InfraCluster.ServiceLimiter(service)
+}
+
+// DescribeCache returns the cache of the EC2 describe calls of the session of the cluster, or nil if it is disabled.
+func (s *AWSLoadBalancerScope) DescribeCache() *describecache.Cache {
+	return describeCacheOf(s.InfraCluster)
+}
+
+// AuditEventsVerbosity returns the verbosity of the events published for the AWS calls of the session of the cluster.
+func (s *AWSLoadBalancerScope) AuditEventsVerbosity() int {
+	return auditEventsVerbosityOf(s.InfraCluster)
+}
+
+// ControllerName returns the name of the controller that created the scope.
+func (s *AWSLoadBalancerScope) ControllerName() string {
+	return "awsloadbalancer"
+}
+
+// VPC returns the VPC of the cluster.
+func (s *AWSLoadBalancerScope) VPC() *infrav1.VPCSpec {
+	return s.InfraCluster.VPC()
+}
+
+// Subnets returns the subnets of the cluster.
+func (s *AWSLoadBalancerScope) Subnets() infrav1.Subnets {
+	return s.InfraCluster.Subnets()
+}
+
+// AdditionalTags returns the tags of the cluster merged with the ones of the load balancer.
+// The returned value will never be nil.
+func (s *AWSLoadBalancerScope) AdditionalTags() infrav1.Tags {
+	tags := s.InfraCluster.AdditionalTags()
+	tags.Merge(s.AWSLoadBalancer.Spec.AdditionalTags)
+	return tags
+}
+
+// PatchObject persists the load balancer spec and status.
+func (s *AWSLoadBalancerScope) PatchObject() error {
+	return s.patchHelper.Patch(
+		context.TODO(),
+		s.AWSLoadBalancer,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			expinfrav1.AWSLoadBalancerReadyCondition,
+		}})
+}
+
+// Close closes the current scope persisting the load balancer spec and status.
+func (s *AWSLoadBalancerScope) Close() error {
+	return s.PatchObject()
+}
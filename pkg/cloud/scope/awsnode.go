@@ -17,6 +17,8 @@ limitations under the License.
 package scope
 
 import (
+	"context"
+
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -29,7 +31,7 @@ type AWSNodeScope interface {
 	cloud.ClusterScoper
 
 	// RemoteClient returns the Kubernetes client for connecting to the workload cluster.
-	RemoteClient() (client.Client, error)
+	RemoteClient(ctx context.Context) (client.Client, error)
 	// Subnets returns the cluster subnets.
 	Subnets() infrav1.Subnets
 	// SecondaryCidrBlock returns the optional secondary CIDR block to use for pod IPs
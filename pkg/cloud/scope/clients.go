@@ -18,10 +18,12 @@ package scope
 
 import (
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/eks"
@@ -34,21 +36,36 @@ import (
 	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/outposts"
+	"github.com/aws/aws-sdk-go/service/outposts/outpostsiface"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/shield/shieldiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/auditevents"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/describecache"
 	awslogs "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/logs"
 	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
@@ -62,10 +79,26 @@ func NewASGClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logg
 	asgClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
 	asgClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	asgClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	asgClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
+	// The auto scaling groups launch and terminate instances.
+	if cache := describeCacheOf(session); cache != nil {
+		asgClient.Handlers.Complete.PushBack(cache.InvalidateOnWrite)
+	}
 
 	return asgClient
 }
 
+// NewCloudWatchClient creates a new CloudWatch API client for a given session.
+func NewCloudWatchClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logger.Wrapper, target runtime.Object) cloudwatchiface.CloudWatchAPI {
+	cloudWatchClient := cloudwatch.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger.GetLogger())).WithLogger(awslogs.NewWrapLogr(logger.GetLogger())))
+	cloudWatchClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	cloudWatchClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	cloudWatchClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	cloudWatchClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
+
+	return cloudWatchClient
+}
+
 // NewEC2Client creates a new EC2 API client for a given session.
 func NewEC2Client(scopeUser cloud.ScopeUsage, session cloud.Session, logger logger.Wrapper, target runtime.Object) ec2iface.EC2API {
 	ec2Client := ec2.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger.GetLogger())).WithLogger(awslogs.NewWrapLogr(logger.GetLogger())))
@@ -78,6 +111,12 @@ func NewEC2Client(scopeUser cloud.ScopeUsage, session cloud.Session, logger logg
 		ec2Client.Handlers.CompleteAttempt.PushFront(session.ServiceLimiter(ec2.ServiceID).ReviewResponse)
 	}
 	ec2Client.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	ec2Client.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
+
+	if cache := describeCacheOf(session); cache != nil {
+		ec2Client.Handlers.Complete.PushBack(cache.InvalidateOnWrite)
+		return describecache.NewEC2Client(ec2Client, cache)
+	}
 
 	return ec2Client
 }
@@ -90,6 +129,11 @@ func NewELBClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logg
 	elbClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	elbClient.Handlers.CompleteAttempt.PushFront(session.ServiceLimiter(elb.ServiceID).ReviewResponse)
 	elbClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	elbClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
+	// The load balancers place network interfaces in the subnets of the cluster, using their addresses.
+	if cache := describeCacheOf(session); cache != nil {
+		elbClient.Handlers.Complete.PushBack(cache.InvalidateOnWrite)
+	}
 
 	return elbClient
 }
@@ -102,6 +146,11 @@ func NewELBv2Client(scopeUser cloud.ScopeUsage, session cloud.Session, logger lo
 	elbClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	elbClient.Handlers.CompleteAttempt.PushFront(session.ServiceLimiter(elbv2.ServiceID).ReviewResponse)
 	elbClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	elbClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
+	// The load balancers place network interfaces in the subnets of the cluster, using their addresses.
+	if cache := describeCacheOf(session); cache != nil {
+		elbClient.Handlers.Complete.PushBack(cache.InvalidateOnWrite)
+	}
 
 	return elbClient
 }
@@ -112,6 +161,7 @@ func NewEventBridgeClient(scopeUser cloud.ScopeUsage, session cloud.Session, tar
 	eventBridgeClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
 	eventBridgeClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	eventBridgeClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	eventBridgeClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
 
 	return eventBridgeClient
 }
@@ -122,6 +172,7 @@ func NewSQSClient(scopeUser cloud.ScopeUsage, session cloud.Session, target runt
 	SQSClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
 	SQSClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	SQSClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	SQSClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
 
 	return SQSClient
 }
@@ -135,6 +186,15 @@ func NewGlobalSQSClient(scopeUser cloud.ScopeUsage, session cloud.Session) sqsif
 	return SQSClient
 }
 
+// NewGlobalSTSClient creates a new STS API client for a given session, not bound to a cluster.
+func NewGlobalSTSClient(scopeUser cloud.ScopeUsage, session cloud.Session) stsiface.STSAPI {
+	stsClient := sts.New(session.Session())
+	stsClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	stsClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+
+	return stsClient
+}
+
 // NewResourgeTaggingClient creates a new Resource Tagging API client for a given session.
 func NewResourgeTaggingClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logger.Wrapper, target runtime.Object) resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI {
 	resourceTagging := resourcegroupstaggingapi.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger.GetLogger())).WithLogger(awslogs.NewWrapLogr(logger.GetLogger())))
@@ -143,6 +203,7 @@ func NewResourgeTaggingClient(scopeUser cloud.ScopeUsage, session cloud.Session,
 	resourceTagging.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	resourceTagging.Handlers.CompleteAttempt.PushFront(session.ServiceLimiter(resourceTagging.ServiceID).ReviewResponse)
 	resourceTagging.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	resourceTagging.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
 
 	return resourceTagging
 }
@@ -155,6 +216,7 @@ func NewSecretsManagerClient(scopeUser cloud.ScopeUsage, session cloud.Session,
 	secretsClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	secretsClient.Handlers.CompleteAttempt.PushFront(session.ServiceLimiter(secretsClient.ServiceID).ReviewResponse)
 	secretsClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	secretsClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
 
 	return secretsClient
 }
@@ -165,6 +227,11 @@ func NewEKSClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logg
 	eksClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
 	eksClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	eksClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	eksClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
+	// The EKS clusters and node groups create security groups and instances.
+	if cache := describeCacheOf(session); cache != nil {
+		eksClient.Handlers.Complete.PushBack(cache.InvalidateOnWrite)
+	}
 
 	return eksClient
 }
@@ -175,6 +242,7 @@ func NewIAMClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logg
 	iamClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
 	iamClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	iamClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	iamClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
 
 	return iamClient
 }
@@ -185,6 +253,7 @@ func NewSTSClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logg
 	stsClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
 	stsClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	stsClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	stsClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
 
 	return stsClient
 }
@@ -195,27 +264,102 @@ func NewSSMClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logg
 	ssmClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
 	ssmClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	ssmClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	ssmClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
 
 	return ssmClient
 }
 
+// NewOutpostsClient creates a new Outposts API client for a given session.
+func NewOutpostsClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logger.Wrapper, target runtime.Object) outpostsiface.OutpostsAPI {
+	outpostsClient := outposts.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger.GetLogger())).WithLogger(awslogs.NewWrapLogr(logger.GetLogger())))
+	outpostsClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	outpostsClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	outpostsClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	outpostsClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
+
+	return outpostsClient
+}
+
 // NewS3Client creates a new S3 API client for a given session.
 func NewS3Client(scopeUser cloud.ScopeUsage, session cloud.Session, logger logger.Wrapper, target runtime.Object) s3iface.S3API {
 	s3Client := s3.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger.GetLogger())).WithLogger(awslogs.NewWrapLogr(logger.GetLogger())))
 	s3Client.Handlers.Build.PushFrontNamed(getUserAgentHandler())
 	s3Client.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
 	s3Client.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	s3Client.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
 
 	return s3Client
 }
 
+// pricingRegion is the region of the Price List API endpoint, which only exists in a few regions of the aws partition.
+const pricingRegion = "us-east-1"
+
+// NewPricingClient creates a new Price List API client for a given session, sending its requests to us-east-1.
+func NewPricingClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logger.Wrapper, target runtime.Object) pricingiface.PricingAPI {
+	pricingClient := pricing.New(session.Session(), aws.NewConfig().WithRegion(pricingRegion).WithLogLevel(awslogs.GetAWSLogLevel(logger.GetLogger())).WithLogger(awslogs.NewWrapLogr(logger.GetLogger())))
+	pricingClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	pricingClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	pricingClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+
+	return pricingClient
+}
+
+// NewServiceQuotasClient creates a new Service Quotas API client for a given session.
+func NewServiceQuotasClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logger.Wrapper, target runtime.Object) servicequotasiface.ServiceQuotasAPI {
+	serviceQuotasClient := servicequotas.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger.GetLogger())).WithLogger(awslogs.NewWrapLogr(logger.GetLogger())))
+	serviceQuotasClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	if session.ServiceLimiter(servicequotas.ServiceID) != nil {
+		serviceQuotasClient.Handlers.Sign.PushFront(session.ServiceLimiter(servicequotas.ServiceID).LimitRequest)
+	}
+	serviceQuotasClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	if session.ServiceLimiter(servicequotas.ServiceID) != nil {
+		serviceQuotasClient.Handlers.CompleteAttempt.PushFront(session.ServiceLimiter(servicequotas.ServiceID).ReviewResponse)
+	}
+	serviceQuotasClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	serviceQuotasClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
+
+	return serviceQuotasClient
+}
+
+// NewWAFv2Client creates a new WAFv2 API client for a given session.
+func NewWAFv2Client(scopeUser cloud.ScopeUsage, session cloud.Session, logger logger.Wrapper, target runtime.Object) wafv2iface.WAFV2API {
+	wafClient := wafv2.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger.GetLogger())).WithLogger(awslogs.NewWrapLogr(logger.GetLogger())))
+	wafClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	wafClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	wafClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	wafClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
+
+	return wafClient
+}
+
+// NewShieldClient creates a new Shield API client for a given session.
+// The Shield Advanced API is only served from us-east-1, whatever the region of the protected resources.
+func NewShieldClient(scopeUser cloud.ScopeUsage, session cloud.Session, logger logger.Wrapper, target runtime.Object) shieldiface.ShieldAPI {
+	shieldClient := shield.New(session.Session(), aws.NewConfig().WithRegion(endpoints.UsEast1RegionID).WithLogLevel(awslogs.GetAWSLogLevel(logger.GetLogger())).WithLogger(awslogs.NewWrapLogr(logger.GetLogger())))
+	shieldClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	shieldClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	shieldClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	shieldClient.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
+
+	return shieldClient
+}
+
+// NewRoute53Client creates a new Route53 API client for a given session.
+func NewRoute53Client(scopeUser cloud.ScopeUsage, session cloud.Session, logger logger.Wrapper, target runtime.Object) route53iface.Route53API {
+	route53Client := route53.New(session.Session(), aws.NewConfig().WithLogLevel(awslogs.GetAWSLogLevel(logger.GetLogger())).WithLogger(awslogs.NewWrapLogr(logger.GetLogger())))
+	route53Client.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	route53Client.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	route53Client.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+	route53Client.Handlers.Complete.PushBack(auditevents.RecordMutatingCall(target, auditEventsVerbosityOf(session)))
+
+	return route53Client
+}
+
 func recordAWSPermissionsIssue(target runtime.Object) func(r *request.Request) {
 	return func(r *request.Request) {
-		if awsErr, ok := r.Error.(awserr.Error); ok {
-			switch awsErr.Code() {
-			case "AuthFailure", "UnauthorizedOperation", "NoCredentialProviders":
-				record.Warnf(target, awsErr.Code(), "Operation %s failed with a credentials or permission issue", r.Operation.Name)
-			}
+		if awserrors.IsPermissionDenied(r.Error) {
+			code, _ := awserrors.Code(r.Error)
+			record.Warnf(target, code, "Operation %s failed with a credentials or permission issue", r.Operation.Name)
 		}
 	}
 }
@@ -235,3 +379,31 @@ type AWSClients struct {
 	SecretsManager  secretsmanageriface.SecretsManagerAPI
 	ResourceTagging resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
 }
+
+// describeCacheProvider is implemented by the scopes whose session caches the results of EC2 describe calls.
+type describeCacheProvider interface {
+	DescribeCache() *describecache.Cache
+}
+
+// describeCacheOf returns the cache of the EC2 describe calls of session, or nil if it has none. The clients of
+// the other services invalidate it too, as their writes create, change or delete the EC2 resources it caches.
+func describeCacheOf(session cloud.Session) *describecache.Cache {
+	if p, ok := session.(describeCacheProvider); ok {
+		return p.DescribeCache()
+	}
+	return nil
+}
+
+// auditEventsVerbosityProvider is implemented by the scopes publishing events for the AWS calls changing resources.
+type auditEventsVerbosityProvider interface {
+	AuditEventsVerbosity() int
+}
+
+// auditEventsVerbosityOf returns the verbosity of the events published for the AWS calls of session, or
+// auditevents.VerbosityNone if it publishes none.
+func auditEventsVerbosityOf(session cloud.Session) int {
+	if p, ok := session.(auditEventsVerbosityProvider); ok {
+		return p.AuditEventsVerbosity()
+	}
+	return auditevents.VerbosityNone
+}
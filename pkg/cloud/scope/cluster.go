@@ -19,17 +19,24 @@ package scope
 import (
 	"context"
 	"fmt"
+	"time"
 
 	awsclient "github.com/aws/aws-sdk-go/aws/client"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/describecache"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 )
@@ -43,6 +50,17 @@ type ClusterScopeParams struct {
 	ControllerName string
 	Endpoints      []ServiceEndpoint
 	Session        awsclient.ConfigProvider
+
+	// EnforcePrincipalAllowList makes the identities deny-by-default, see the --enforce-principal-allow-list flag.
+	EnforcePrincipalAllowList bool
+
+	// DescribeCacheTTL is how long the EC2 clients of the session reuse the results of describe calls, see the
+	// --aws-describe-cache-ttl flag. 0 disables the cache.
+	DescribeCacheTTL time.Duration
+
+	// AuditEventsVerbosity selects the AWS calls changing resources an event is published for, see the
+	// --aws-api-events-verbosity flag. 0 publishes no event.
+	AuditEventsVerbosity int
 }
 
 // NewClusterScope creates a new Scope from the supplied parameters.
@@ -68,7 +86,14 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 		controllerName: params.ControllerName,
 	}
 
-	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, clusterScope, params.AWSCluster.Spec.Region, params.Endpoints, params.Logger)
+	additionalTagsFrom, err := getAdditionalTagsFrom(params.Client, params.AWSCluster)
+	if err != nil {
+		return nil, err
+	}
+	clusterScope.additionalTagsFrom = additionalTagsFrom
+
+	session, serviceLimiters, describeCache, principalKey, err := sessionForClusterWithRegion(params.Client, clusterScope, params.AWSCluster.Spec.Region,
+		withClusterServiceEndpoints(params.Endpoints, params.AWSCluster.Spec.ServiceEndpoints), params.EnforcePrincipalAllowList, params.DescribeCacheTTL, params.Logger)
 	if err != nil {
 		return nil, errors.Errorf("failed to create aws session: %v", err)
 	}
@@ -81,6 +106,9 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 	clusterScope.patchHelper = helper
 	clusterScope.session = session
 	clusterScope.serviceLimiters = serviceLimiters
+	clusterScope.describeCache = describeCache
+	clusterScope.auditEventsVerbosity = params.AuditEventsVerbosity
+	clusterScope.principalKey = principalKey
 
 	return clusterScope, nil
 }
@@ -94,9 +122,38 @@ type ClusterScope struct {
 	Cluster    *clusterv1.Cluster
 	AWSCluster *infrav1.AWSCluster
 
-	session         awsclient.ConfigProvider
-	serviceLimiters throttle.ServiceLimiters
-	controllerName  string
+	session              awsclient.ConfigProvider
+	serviceLimiters      throttle.ServiceLimiters
+	describeCache        *describecache.Cache
+	auditEventsVerbosity int
+	principalKey         string
+	controllerName       string
+
+	additionalTagsFrom infrav1.Tags
+}
+
+// getAdditionalTagsFrom reads the tags of the source referenced by the AdditionalTagsFrom of the AWSCluster.
+func getAdditionalTagsFrom(c client.Client, awsCluster *infrav1.AWSCluster) (infrav1.Tags, error) {
+	source := awsCluster.Spec.AdditionalTagsFrom
+	if source == nil {
+		return nil, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: awsCluster.Namespace, Name: source.ConfigMapRef.Name}
+	if err := c.Get(context.TODO(), key, configMap); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ConfigMap %s with additional tags", key)
+	}
+
+	tags := infrav1.Tags{}
+	for k, v := range configMap.Data {
+		tags[k] = v
+	}
+	if errs := field.ErrorList(tags.Validate()); len(errs) > 0 {
+		return nil, errors.Errorf("invalid additional tags in ConfigMap %s: %v", key, errs.ToAggregate())
+	}
+
+	return tags, nil
 }
 
 // Network returns the cluster network object.
@@ -132,6 +189,26 @@ func (s *ClusterScope) CNIIngressRules() infrav1.CNIIngressRules {
 	return infrav1.CNIIngressRules{}
 }
 
+// AdditionalControlPlaneIngressRules returns the additional ingress rules of the control plane security group.
+func (s *ClusterScope) AdditionalControlPlaneIngressRules() infrav1.IngressRules {
+	return s.AWSCluster.Spec.NetworkSpec.AdditionalControlPlaneIngressRules
+}
+
+// AdditionalNodeIngressRules returns the additional ingress rules of the node security group.
+func (s *ClusterScope) AdditionalNodeIngressRules() infrav1.IngressRules {
+	return s.AWSCluster.Spec.NetworkSpec.AdditionalNodeIngressRules
+}
+
+// NodeEgressRules returns the egress rules of the node security group.
+func (s *ClusterScope) NodeEgressRules() infrav1.EgressRules {
+	return s.AWSCluster.Spec.NetworkSpec.NodeEgressRules
+}
+
+// PrivateIPv6Egress returns whether the private subnets reach the internet over IPv6 without NAT gateways.
+func (s *ClusterScope) PrivateIPv6Egress() bool {
+	return s.AWSCluster.Spec.NetworkSpec.PrivateIPv6Egress
+}
+
 // SecurityGroupOverrides returns the cluster security group overrides.
 func (s *ClusterScope) SecurityGroupOverrides() map[infrav1.SecurityGroupRole]string {
 	return s.AWSCluster.Spec.NetworkSpec.SecurityGroupOverrides
@@ -167,6 +244,14 @@ func (s *ClusterScope) Region() string {
 	return s.AWSCluster.Spec.Region
 }
 
+// Partition returns the AWS partition of the cluster, derived from its region when not set.
+func (s *ClusterScope) Partition() string {
+	if s.AWSCluster.Spec.Partition != "" {
+		return s.AWSCluster.Spec.Partition
+	}
+	return infrav1.PartitionForRegion(s.Region())
+}
+
 // KubernetesClusterName is the name of the Kubernetes cluster. For the cluster
 // scope this is the same as the CAPI cluster name.
 func (s *ClusterScope) KubernetesClusterName() string {
@@ -178,6 +263,16 @@ func (s *ClusterScope) ControlPlaneLoadBalancer() *infrav1.AWSLoadBalancerSpec {
 	return s.AWSCluster.Spec.ControlPlaneLoadBalancer
 }
 
+// ResourceNaming returns how the names of the AWS resources of the cluster are generated.
+func (s *ClusterScope) ResourceNaming() *infrav1.ResourceNaming {
+	return s.AWSCluster.Spec.ResourceNaming
+}
+
+// Konnectivity returns the konnectivity settings of the cluster.
+func (s *ClusterScope) Konnectivity() *infrav1.KonnectivitySpec {
+	return s.AWSCluster.Spec.Konnectivity
+}
+
 // ControlPlaneLoadBalancerScheme returns the Classic ELB scheme (public or internal facing).
 func (s *ClusterScope) ControlPlaneLoadBalancerScheme() infrav1.ELBScheme {
 	if s.ControlPlaneLoadBalancer() != nil && s.ControlPlaneLoadBalancer().Scheme != nil {
@@ -256,26 +351,61 @@ func (s *ClusterScope) PatchObject() error {
 			infrav1.EgressOnlyInternetGatewayReadyCondition,
 			infrav1.NatGatewaysReadyCondition,
 			infrav1.RouteTablesReadyCondition,
+			infrav1.SecondaryCidrsReadyCondition,
+			infrav1.VpcEndpointsReadyCondition,
 			infrav1.ClusterSecurityGroupsReadyCondition,
 			infrav1.BastionHostReadyCondition,
 			infrav1.LoadBalancerReadyCondition,
 			infrav1.PrincipalUsageAllowedCondition,
 			infrav1.PrincipalCredentialRetrievedCondition,
+			infrav1.DeletionBlockedCondition,
 		}})
 }
 
+// Fork returns a scope on a copy of the AWSCluster with its own patch helper, so that services can reconcile
+// the AWSCluster concurrently with this scope, as long as they change different conditions and status fields.
+// Changes made through the fork are not reflected in this scope's AWSCluster.
+func (s *ClusterScope) Fork() (*ClusterScope, error) {
+	awsCluster := s.AWSCluster.DeepCopy()
+	helper, err := patch.NewHelper(awsCluster, s.client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	fork := *s
+	fork.AWSCluster = awsCluster
+	fork.patchHelper = helper
+	return &fork, nil
+}
+
 // Close closes the current scope persisting the cluster configuration and status.
 func (s *ClusterScope) Close() error {
 	return s.PatchObject()
 }
 
-// AdditionalTags returns AdditionalTags from the scope's AWSCluster. The returned value will never be nil.
+// AdditionalTags merges the tags read from the AdditionalTagsFrom source and the AdditionalTags of the scope's
+// AWSCluster. If the same key is present in both, the value from AdditionalTags takes precedence.
+// The returned value will never be nil.
 func (s *ClusterScope) AdditionalTags() infrav1.Tags {
 	if s.AWSCluster.Spec.AdditionalTags == nil {
 		s.AWSCluster.Spec.AdditionalTags = infrav1.Tags{}
 	}
 
-	return s.AWSCluster.Spec.AdditionalTags.DeepCopy()
+	tags := infrav1.Tags{}
+	tags.Merge(s.additionalTagsFrom)
+	tags.Merge(s.AWSCluster.Spec.AdditionalTags)
+
+	return tags
+}
+
+// Proxy returns the HTTP proxy settings of the nodes of the cluster, if any.
+func (s *ClusterScope) Proxy() *infrav1.ProxySpec {
+	return s.AWSCluster.Spec.Proxy
+}
+
+// Monitoring returns the configuration of the CloudWatch alarms of the cluster, if enabled.
+func (s *ClusterScope) Monitoring() *infrav1.MonitoringSpec {
+	return s.AWSCluster.Spec.Monitoring
 }
 
 // APIServerPort returns the APIServerPort to use when creating the load balancer.
@@ -317,6 +447,22 @@ func (s *ClusterScope) ServiceLimiter(service string) *throttle.ServiceLimiter {
 	return nil
 }
 
+// DescribeCache returns the cache of the EC2 describe calls of the session, or nil if it is disabled.
+func (s *ClusterScope) DescribeCache() *describecache.Cache {
+	return s.describeCache
+}
+
+// AuditEventsVerbosity returns the verbosity of the events published for the AWS calls changing resources.
+func (s *ClusterScope) AuditEventsVerbosity() int {
+	return s.auditEventsVerbosity
+}
+
+// PrincipalKey returns the key of the AWS principal and region of the session, shared by the sessions using
+// the same account in the same region.
+func (s *ClusterScope) PrincipalKey() string {
+	return s.principalKey
+}
+
 // Bastion returns the bastion details.
 func (s *ClusterScope) Bastion() *infrav1.Bastion {
 	return &s.AWSCluster.Spec.Bastion
@@ -327,11 +473,31 @@ func (s *ClusterScope) SetBastionInstance(instance *infrav1.Instance) {
 	s.AWSCluster.Status.Bastion = instance
 }
 
+// BastionInstance returns the bastion host of the cluster, if any.
+func (s *ClusterScope) BastionInstance() *infrav1.Instance {
+	return s.AWSCluster.Status.Bastion
+}
+
+// SetBastionConnection sets the bastion connection details in the status of the cluster.
+func (s *ClusterScope) SetBastionConnection(connection *infrav1.BastionConnection) {
+	s.AWSCluster.Status.BastionConnection = connection
+}
+
+// BastionConnection returns the bastion connection details in the status of the cluster, if any.
+func (s *ClusterScope) BastionConnection() *infrav1.BastionConnection {
+	return s.AWSCluster.Status.BastionConnection
+}
+
 // SSHKeyName returns the SSH key name to use for instances.
 func (s *ClusterScope) SSHKeyName() *string {
 	return s.AWSCluster.Spec.SSHKeyName
 }
 
+// RemoteClient returns the Kubernetes client for connecting to the workload cluster.
+func (s *ClusterScope) RemoteClient(ctx context.Context) (client.Client, error) {
+	return remote.NewClusterClient(ctx, s.controllerName, s.client, util.ObjectKey(s.Cluster))
+}
+
 // ControllerName returns the name of the controller that
 // created the ClusterScope.
 func (s *ClusterScope) ControllerName() string {
@@ -352,3 +518,30 @@ func (s *ClusterScope) ImageLookupOrg() string {
 func (s *ClusterScope) ImageLookupBaseOS() string {
 	return s.AWSCluster.Spec.ImageLookupBaseOS
 }
+
+// SecurityProfile returns the security profile enforced on the instances of the cluster.
+func (s *ClusterScope) SecurityProfile() infrav1.SecurityProfile {
+	return s.AWSCluster.Spec.SecurityProfile
+}
+
+// DefaultEBSKMSKeyID returns the KMS key used to encrypt the volumes of the instances of the cluster
+// which don't set their own encryption key.
+func (s *ClusterScope) DefaultEBSKMSKeyID() string {
+	return s.AWSCluster.Spec.DefaultEBSKMSKeyID
+}
+
+// InstanceNameTemplate returns the template used to generate the Name tag of the instances of the cluster.
+func (s *ClusterScope) InstanceNameTemplate() string {
+	return s.AWSCluster.Spec.InstanceNameTemplate
+}
+
+// ResourceRetentionPolicy returns the classes of resources retained when they are deleted.
+func (s *ClusterScope) ResourceRetentionPolicy() *infrav1.ResourceRetentionPolicy {
+	return s.AWSCluster.Spec.ResourceRetentionPolicy
+}
+
+// KarpenterStatus returns the identifiers of the AWS resources created for Karpenter.
+// They are only created for EKS clusters, so it always returns nil.
+func (s *ClusterScope) KarpenterStatus() *ekscontrolplanev1.KarpenterStatus {
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+func TestClusterScopeAdditionalTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		configMap   *corev1.ConfigMap
+		source      *infrav1.AdditionalTagsSource
+		tags        infrav1.Tags
+		expected    infrav1.Tags
+		expectError bool
+	}{
+		{
+			name:     "should return the additional tags without a source",
+			tags:     infrav1.Tags{"owner": "team-a"},
+			expected: infrav1.Tags{"owner": "team-a"},
+		},
+		{
+			name: "should merge the tags of the ConfigMap with the additional tags taking precedence",
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "org-tags"},
+				Data:       map[string]string{"owner": "platform", "environment": "production"},
+			},
+			source:   &infrav1.AdditionalTagsSource{ConfigMapRef: corev1.LocalObjectReference{Name: "org-tags"}},
+			tags:     infrav1.Tags{"owner": "team-a"},
+			expected: infrav1.Tags{"owner": "team-a", "environment": "production"},
+		},
+		{
+			name:        "should fail if the ConfigMap doesn't exist",
+			source:      &infrav1.AdditionalTagsSource{ConfigMapRef: corev1.LocalObjectReference{Name: "org-tags"}},
+			expectError: true,
+		},
+		{
+			name: "should fail if the ConfigMap contains invalid tags",
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "org-tags"},
+				Data:       map[string]string{"aws:owner": "platform"},
+			},
+			source:      &infrav1.AdditionalTagsSource{ConfigMapRef: corev1.LocalObjectReference{Name: "org-tags"}},
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.configMap != nil {
+				builder = builder.WithObjects(tt.configMap)
+			}
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster"},
+				Spec: infrav1.AWSClusterSpec{
+					AdditionalTags:     tt.tags,
+					AdditionalTagsFrom: tt.source,
+				},
+			}
+			additionalTagsFrom, err := getAdditionalTagsFrom(builder.Build(), awsCluster)
+			if tt.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			s := &ClusterScope{AWSCluster: awsCluster, additionalTagsFrom: additionalTagsFrom}
+			g.Expect(s.AdditionalTags()).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestClusterScopeFork(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster"},
+		Status: infrav1.AWSClusterStatus{
+			Bastion: &infrav1.Instance{ID: "i-bastion"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).Build()
+	helper, err := patch.NewHelper(awsCluster, c)
+	g.Expect(err).ToNot(HaveOccurred())
+	s := &ClusterScope{
+		client:      c,
+		patchHelper: helper,
+		Cluster:     &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster"}},
+		AWSCluster:  awsCluster,
+	}
+
+	fork, err := s.Fork()
+	g.Expect(err).ToNot(HaveOccurred())
+	fork.SetBastionInstance(nil)
+	g.Expect(fork.PatchObject()).To(Succeed())
+
+	g.Expect(s.BastionInstance()).ToNot(BeNil())
+	patched := &infrav1.AWSCluster{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(awsCluster), patched)).To(Succeed())
+	g.Expect(patched.Status.Bastion).To(BeNil())
+
+	// The scope doesn't revert the changes of the fork when it is patched.
+	g.Expect(s.PatchObject()).To(Succeed())
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(awsCluster), patched)).To(Succeed())
+	g.Expect(patched.Status.Bastion).To(BeNil())
+}
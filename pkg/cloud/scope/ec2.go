@@ -43,6 +43,12 @@ type EC2Scope interface {
 	// SetBastionInstance sets the bastion instance in the status of the cluster.
 	SetBastionInstance(instance *infrav1.Instance)
 
+	// SetBastionConnection sets the bastion connection details in the status of the cluster.
+	SetBastionConnection(connection *infrav1.BastionConnection)
+
+	// BastionConnection returns the bastion connection details in the status of the cluster, if any.
+	BastionConnection() *infrav1.BastionConnection
+
 	// SSHKeyName returns the SSH key name to use for instances.
 	SSHKeyName() *string
 
@@ -54,4 +60,17 @@ type EC2Scope interface {
 
 	// ImageLookupBaseOS returns the base operating system name to use when looking up AMIs
 	ImageLookupBaseOS() string
+
+	// SecurityProfile returns the security profile enforced on the instances of the cluster.
+	SecurityProfile() infrav1.SecurityProfile
+
+	// DefaultEBSKMSKeyID returns the KMS key used to encrypt the volumes of the instances of the cluster
+	// which don't set their own encryption key.
+	DefaultEBSKMSKeyID() string
+
+	// InstanceNameTemplate returns the template used to generate the Name tag of the instances of the cluster.
+	InstanceNameTemplate() string
+
+	// ResourceRetentionPolicy returns the classes of resources retained when they are deleted.
+	ResourceRetentionPolicy() *infrav1.ResourceRetentionPolicy
 }
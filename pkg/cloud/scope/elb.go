@@ -49,4 +49,13 @@ type ELBScope interface {
 
 	// ControlPlaneEndpoint returns AWSCluster control plane endpoint
 	ControlPlaneEndpoint() clusterv1.APIEndpoint
+
+	// ResourceNaming returns how the name of the control plane load balancer is generated.
+	ResourceNaming() *infrav1.ResourceNaming
+
+	// Konnectivity returns the konnectivity settings of the cluster, or nil if it doesn't run konnectivity.
+	Konnectivity() *infrav1.KonnectivitySpec
+
+	// SecurityProfile returns the security profile enforced on the load balancers of the cluster.
+	SecurityProfile() infrav1.SecurityProfile
 }
@@ -18,7 +18,9 @@ package scope
 
 import (
 	"context"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	awsclient "github.com/aws/aws-sdk-go/aws/client"
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
@@ -28,6 +30,7 @@ import (
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/describecache"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -47,6 +50,24 @@ type FargateProfileScopeParams struct {
 	Session        awsclient.ConfigProvider
 
 	EnableIAM bool
+
+	// IAMPermissionsBoundary is the ARN of the permissions boundary of the created IAM roles, see the
+	// --iam-permissions-boundary flag.
+	IAMPermissionsBoundary string
+
+	// IAMPath is the IAM path of the created IAM roles, see the --iam-path flag.
+	IAMPath string
+
+	// EnforcePrincipalAllowList makes the identities deny-by-default, see the --enforce-principal-allow-list flag.
+	EnforcePrincipalAllowList bool
+
+	// DescribeCacheTTL is how long the EC2 clients of the session reuse the results of describe calls, see the
+	// --aws-describe-cache-ttl flag. 0 disables the cache.
+	DescribeCacheTTL time.Duration
+
+	// AuditEventsVerbosity selects the AWS calls changing resources an event is published for, see the
+	// --aws-api-events-verbosity flag. 0 publishes no event.
+	AuditEventsVerbosity int
 }
 
 // NewFargateProfileScope creates a new Scope from the supplied parameters.
@@ -68,7 +89,7 @@ func NewFargateProfileScope(params FargateProfileScopeParams) (*FargateProfileSc
 		controllerName: params.ControllerName,
 	}
 
-	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, managedScope, params.ControlPlane.Spec.Region, params.Endpoints, params.Logger)
+	session, serviceLimiters, describeCache, _, err := sessionForClusterWithRegion(params.Client, managedScope, params.ControlPlane.Spec.Region, params.Endpoints, params.EnforcePrincipalAllowList, params.DescribeCacheTTL, params.Logger)
 	if err != nil {
 		return nil, errors.Errorf("failed to create aws session: %v", err)
 	}
@@ -79,16 +100,20 @@ func NewFargateProfileScope(params FargateProfileScopeParams) (*FargateProfileSc
 	}
 
 	return &FargateProfileScope{
-		Logger:          *params.Logger,
-		Client:          params.Client,
-		Cluster:         params.Cluster,
-		ControlPlane:    params.ControlPlane,
-		FargateProfile:  params.FargateProfile,
-		patchHelper:     helper,
-		session:         session,
-		serviceLimiters: serviceLimiters,
-		controllerName:  params.ControllerName,
-		enableIAM:       params.EnableIAM,
+		Logger:                 *params.Logger,
+		Client:                 params.Client,
+		Cluster:                params.Cluster,
+		ControlPlane:           params.ControlPlane,
+		FargateProfile:         params.FargateProfile,
+		patchHelper:            helper,
+		session:                session,
+		serviceLimiters:        serviceLimiters,
+		describeCache:          describeCache,
+		auditEventsVerbosity:   params.AuditEventsVerbosity,
+		controllerName:         params.ControllerName,
+		enableIAM:              params.EnableIAM,
+		iamPermissionsBoundary: params.IAMPermissionsBoundary,
+		iamPath:                params.IAMPath,
 	}, nil
 }
 
@@ -102,11 +127,15 @@ type FargateProfileScope struct {
 	ControlPlane   *ekscontrolplanev1.AWSManagedControlPlane
 	FargateProfile *expinfrav1.AWSFargateProfile
 
-	session         awsclient.ConfigProvider
-	serviceLimiters throttle.ServiceLimiters
-	controllerName  string
+	session              awsclient.ConfigProvider
+	serviceLimiters      throttle.ServiceLimiters
+	describeCache        *describecache.Cache
+	auditEventsVerbosity int
+	controllerName       string
 
-	enableIAM bool
+	enableIAM              bool
+	iamPermissionsBoundary string
+	iamPath                string
 }
 
 // ManagedPoolName returns the managed machine pool name.
@@ -122,16 +151,41 @@ func (s *FargateProfileScope) ServiceLimiter(service string) *throttle.ServiceLi
 	return nil
 }
 
+// DescribeCache returns the cache of the EC2 describe calls of the session, or nil if it is disabled.
+func (s *FargateProfileScope) DescribeCache() *describecache.Cache {
+	return s.describeCache
+}
+
+// AuditEventsVerbosity returns the verbosity of the events published for the AWS calls changing resources.
+func (s *FargateProfileScope) AuditEventsVerbosity() int {
+	return s.auditEventsVerbosity
+}
+
 // ClusterName returns the cluster name.
 func (s *FargateProfileScope) ClusterName() string {
 	return s.Cluster.Name
 }
 
+// Partition returns the AWS partition of the control plane of the profile.
+func (s *FargateProfileScope) Partition() string {
+	return infrav1.PartitionForRegion(s.ControlPlane.Spec.Region)
+}
+
 // EnableIAM indicates that reconciliation should create IAM roles.
 func (s *FargateProfileScope) EnableIAM() bool {
 	return s.enableIAM
 }
 
+// IAMPermissionsBoundary returns the ARN of the permissions boundary of the created IAM roles.
+func (s *FargateProfileScope) IAMPermissionsBoundary() string {
+	return s.iamPermissionsBoundary
+}
+
+// IAMPath returns the IAM path of the created IAM roles.
+func (s *FargateProfileScope) IAMPath() string {
+	return s.iamPath
+}
+
 // AdditionalTags returns AdditionalTags from the scope's FargateProfile
 // The returned value will never be nil.
 func (s *FargateProfileScope) AdditionalTags() infrav1.Tags {
@@ -147,6 +201,11 @@ func (s *FargateProfileScope) RoleName() string {
 	return s.FargateProfile.Spec.RoleName
 }
 
+// PodExecutionRoleARN returns the ARN of the existing pod execution role of the profile, if any.
+func (s *FargateProfileScope) PodExecutionRoleARN() string {
+	return aws.StringValue(s.FargateProfile.Spec.PodExecutionRoleARN)
+}
+
 // ControlPlaneSubnets returns the control plane subnets.
 func (s *FargateProfileScope) ControlPlaneSubnets() *infrav1.Subnets {
 	return &s.ControlPlane.Spec.NetworkSpec.Subnets
@@ -171,6 +230,13 @@ func (s *FargateProfileScope) IAMReadyFalse(reason string, err string) error {
 		severity,
 		err,
 	)
+	conditions.MarkFalse(
+		s.FargateProfile,
+		expinfrav1.FargateExecutionRoleReadyCondition,
+		reason,
+		severity,
+		err,
+	)
 	if err := s.PatchObject(); err != nil {
 		return errors.Wrap(err, "failed to mark role not ready")
 	}
@@ -187,6 +253,7 @@ func (s *FargateProfileScope) PatchObject() error {
 			expinfrav1.EKSFargateCreatingCondition,
 			expinfrav1.EKSFargateDeletingCondition,
 			expinfrav1.IAMFargateRolesReadyCondition,
+			expinfrav1.FargateExecutionRoleReadyCondition,
 		}})
 }
 
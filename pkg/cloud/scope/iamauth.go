@@ -17,6 +17,8 @@ limitations under the License.
 package scope
 
 import (
+	"context"
+
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
@@ -28,7 +30,9 @@ type IAMAuthScope interface {
 	cloud.ClusterScoper
 
 	// RemoteClient returns the Kubernetes client for connecting to the workload cluster.
-	RemoteClient() (client.Client, error)
+	RemoteClient(ctx context.Context) (client.Client, error)
 	// IAMAuthConfig returns the IAM authenticator config
 	IAMAuthConfig() *ekscontrolplanev1.IAMAuthenticatorConfig
+	// KarpenterStatus returns the identifiers of the AWS resources created for Karpenter, or nil.
+	KarpenterStatus() *ekscontrolplanev1.KarpenterStatus
 }
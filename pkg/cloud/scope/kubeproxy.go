@@ -17,6 +17,8 @@ limitations under the License.
 package scope
 
 import (
+	"context"
+
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
@@ -27,7 +29,7 @@ type KubeProxyScope interface {
 	cloud.ClusterScoper
 
 	// RemoteClient returns the Kubernetes client for connecting to the workload cluster.
-	RemoteClient() (client.Client, error)
+	RemoteClient(ctx context.Context) (client.Client, error)
 	// DisableKubeProxy returns whether kube-proxy daemonset is to be disabled
 	DisableKubeProxy() bool
 }
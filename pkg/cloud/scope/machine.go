@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -112,6 +113,34 @@ func (m *MachineScope) Namespace() string {
 	return m.AWSMachine.Namespace
 }
 
+// InstanceName returns the value of the Name tag of the machine instance. It is
+// generated from the instance name template of the machine, or of the cluster if the
+// machine doesn't set one, and defaults to the name of the AWSMachine.
+func (m *MachineScope) InstanceName() (string, error) {
+	nameTemplate := m.AWSMachine.Spec.InstanceNameTemplate
+	if nameTemplate == "" {
+		nameTemplate = m.InfraCluster.InstanceNameTemplate()
+	}
+	if nameTemplate == "" {
+		return m.Name(), nil
+	}
+
+	return infrav1.RenderInstanceName(nameTemplate, infrav1.InstanceNameTemplateData{
+		ClusterName:       m.Cluster.Name,
+		Namespace:         m.Namespace(),
+		MachineName:       m.Name(),
+		MachineDeployment: m.Machine.Labels[clusterv1.MachineDeploymentNameLabel],
+		MachineSet:        m.Machine.Labels[clusterv1.MachineSetNameLabel],
+		Role:              m.Role(),
+		Index:             machineNameSuffix(m.Machine.Name),
+	})
+}
+
+// machineNameSuffix returns the part of the machine name after the last dash.
+func machineNameSuffix(name string) string {
+	return name[strings.LastIndex(name, "-")+1:]
+}
+
 // IsControlPlane returns true if the machine is a control plane.
 func (m *MachineScope) IsControlPlane() bool {
 	return util.IsControlPlaneMachine(m.Machine)
@@ -201,6 +230,11 @@ func (m *MachineScope) UseIgnition(userDataFormat string) bool {
 	return userDataFormat == "ignition" || (m.AWSMachine.Spec.Ignition != nil)
 }
 
+// UseBottlerocket returns whether the userdata of the instance is rendered as Bottlerocket settings.
+func (m *MachineScope) UseBottlerocket() bool {
+	return m.AWSMachine.Spec.Bottlerocket != nil
+}
+
 // SecureSecretsBackend returns the chosen secret backend.
 func (m *MachineScope) SecureSecretsBackend() infrav1.SecretBackend {
 	return m.AWSMachine.Spec.CloudInit.SecureSecretsBackend
@@ -209,7 +243,7 @@ func (m *MachineScope) SecureSecretsBackend() infrav1.SecretBackend {
 // CompressUserData returns the computed value of whether or not
 // userdata should be compressed using gzip.
 func (m *MachineScope) CompressUserData(userDataFormat string) bool {
-	if m.UseIgnition(userDataFormat) {
+	if m.UseIgnition(userDataFormat) || m.UseBottlerocket() {
 		return false
 	}
 
@@ -341,3 +341,66 @@ func TestSetProviderID(t *testing.T) {
 		t.Fatalf("Expected providerID %s, got %s", expectedProviderID, providerID)
 	}
 }
+
+func TestInstanceName(t *testing.T) {
+	testCases := []struct {
+		name            string
+		clusterTemplate string
+		machineTemplate string
+		want            string
+		wantErr         bool
+	}{
+		{
+			name: "no template, should default to the AWSMachine name",
+			want: "my-machine-0",
+		},
+		{
+			name:            "cluster template",
+			clusterTemplate: "{{.ClusterName}}-{{.MachineDeployment}}-{{.MachineName}}",
+			want:            "my-cluster-md-0-my-machine-0",
+		},
+		{
+			name:            "machine template overrides cluster template",
+			clusterTemplate: "{{.ClusterName}}-{{.MachineName}}",
+			machineTemplate: "{{.Role}}-{{.MachineSet}}",
+			want:            "node-md-0-abcde",
+		},
+		{
+			name:            "index is the suffix of the machine name",
+			machineTemplate: "{{.ClusterName}}-{{.MachineDeployment}}-{{.Index}}",
+			want:            "my-cluster-md-0-0",
+		},
+		{
+			name:            "unknown field",
+			machineTemplate: "{{.Ordinal}}",
+			wantErr:         true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, err := setupMachineScope()
+			if err != nil {
+				t.Fatal(err)
+			}
+			scope.Machine.Labels[clusterv1.MachineDeploymentNameLabel] = "md-0"
+			scope.Machine.Labels[clusterv1.MachineSetNameLabel] = "md-0-abcde"
+			scope.InfraCluster.(*ClusterScope).AWSCluster.Spec.InstanceNameTemplate = tc.clusterTemplate
+			scope.AWSMachine.Spec.InstanceNameTemplate = tc.machineTemplate
+
+			name, err := scope.InstanceName()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name != tc.want {
+				t.Fatalf("Expected instance name %s, got %s", tc.want, name)
+			}
+		})
+	}
+}
@@ -388,6 +388,19 @@ func nodeIsReady(node corev1.Node) bool {
 	return false
 }
 
+// DesiredCapacity returns the desired capacity of the ASG of the machine pool: the replicas of the
+// MachinePool, plus the surge of the rolling update of its instances while one is in progress.
+func (m *MachinePoolScope) DesiredCapacity() *int32 {
+	replicas := m.MachinePool.Spec.Replicas
+	if replicas == nil || m.AWSMachinePool == nil {
+		return replicas
+	}
+	if rollingUpdate := m.AWSMachinePool.Status.RollingUpdate; rollingUpdate != nil && rollingUpdate.Surge > 0 {
+		return pointer.Int32(*replicas + rollingUpdate.Surge)
+	}
+	return replicas
+}
+
 func (m *MachinePoolScope) GetLaunchTemplate() *expinfrav1.AWSLaunchTemplate {
 	return &m.AWSMachinePool.Spec.AWSLaunchTemplate
 }
@@ -34,6 +34,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/describecache"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -64,6 +65,24 @@ type ManagedControlPlaneScopeParams struct {
 
 	EnableIAM            bool
 	AllowAdditionalRoles bool
+
+	// IAMPermissionsBoundary is the ARN of the permissions boundary of the created IAM roles, see the
+	// --iam-permissions-boundary flag.
+	IAMPermissionsBoundary string
+
+	// IAMPath is the IAM path of the created IAM roles and instance profiles, see the --iam-path flag.
+	IAMPath string
+
+	// EnforcePrincipalAllowList makes the identities deny-by-default, see the --enforce-principal-allow-list flag.
+	EnforcePrincipalAllowList bool
+
+	// DescribeCacheTTL is how long the EC2 clients of the session reuse the results of describe calls, see the
+	// --aws-describe-cache-ttl flag. 0 disables the cache.
+	DescribeCacheTTL time.Duration
+
+	// AuditEventsVerbosity selects the AWS calls changing resources an event is published for, see the
+	// --aws-api-events-verbosity flag. 0 publishes no event.
+	AuditEventsVerbosity int
 }
 
 // NewManagedControlPlaneScope creates a new Scope from the supplied parameters.
@@ -81,24 +100,29 @@ func NewManagedControlPlaneScope(params ManagedControlPlaneScopeParams) (*Manage
 	}
 
 	managedScope := &ManagedControlPlaneScope{
-		Logger:               *params.Logger,
-		Client:               params.Client,
-		Cluster:              params.Cluster,
-		ControlPlane:         params.ControlPlane,
-		patchHelper:          nil,
-		session:              nil,
-		serviceLimiters:      nil,
-		controllerName:       params.ControllerName,
-		allowAdditionalRoles: params.AllowAdditionalRoles,
-		enableIAM:            params.EnableIAM,
+		Logger:                 *params.Logger,
+		Client:                 params.Client,
+		Cluster:                params.Cluster,
+		ControlPlane:           params.ControlPlane,
+		patchHelper:            nil,
+		session:                nil,
+		serviceLimiters:        nil,
+		controllerName:         params.ControllerName,
+		allowAdditionalRoles:   params.AllowAdditionalRoles,
+		enableIAM:              params.EnableIAM,
+		iamPermissionsBoundary: params.IAMPermissionsBoundary,
+		iamPath:                params.IAMPath,
 	}
-	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, managedScope, params.ControlPlane.Spec.Region, params.Endpoints, params.Logger)
+	session, serviceLimiters, describeCache, principalKey, err := sessionForClusterWithRegion(params.Client, managedScope, params.ControlPlane.Spec.Region, params.Endpoints, params.EnforcePrincipalAllowList, params.DescribeCacheTTL, params.Logger)
 	if err != nil {
 		return nil, errors.Errorf("failed to create aws session: %v", err)
 	}
 
 	managedScope.session = session
 	managedScope.serviceLimiters = serviceLimiters
+	managedScope.describeCache = describeCache
+	managedScope.auditEventsVerbosity = params.AuditEventsVerbosity
+	managedScope.principalKey = principalKey
 
 	helper, err := patch.NewHelper(params.ControlPlane, params.Client)
 	if err != nil {
@@ -118,22 +142,27 @@ type ManagedControlPlaneScope struct {
 	Cluster      *clusterv1.Cluster
 	ControlPlane *ekscontrolplanev1.AWSManagedControlPlane
 
-	session         awsclient.ConfigProvider
-	serviceLimiters throttle.ServiceLimiters
-	controllerName  string
+	session              awsclient.ConfigProvider
+	serviceLimiters      throttle.ServiceLimiters
+	describeCache        *describecache.Cache
+	auditEventsVerbosity int
+	principalKey         string
+	controllerName       string
 
-	enableIAM            bool
-	allowAdditionalRoles bool
+	enableIAM              bool
+	allowAdditionalRoles   bool
+	iamPermissionsBoundary string
+	iamPath                string
 }
 
 // RemoteClient returns the Kubernetes client for connecting to the workload cluster.
-func (s *ManagedControlPlaneScope) RemoteClient() (client.Client, error) {
+func (s *ManagedControlPlaneScope) RemoteClient(ctx context.Context) (client.Client, error) {
 	clusterKey := client.ObjectKey{
 		Name:      s.Name(),
 		Namespace: s.Namespace(),
 	}
 
-	restConfig, err := remote.RESTConfig(context.Background(), s.ControlPlane.Name, s.Client, clusterKey)
+	restConfig, err := remote.RESTConfig(ctx, s.ControlPlane.Name, s.Client, clusterKey)
 	if err != nil {
 		return nil, fmt.Errorf("getting remote rest config for %s/%s: %w", s.Namespace(), s.Name(), err)
 	}
@@ -160,11 +189,42 @@ func (s *ManagedControlPlaneScope) ServiceLimiter(service string) *throttle.Serv
 	return nil
 }
 
+// DescribeCache returns the cache of the EC2 describe calls of the session, or nil if it is disabled.
+func (s *ManagedControlPlaneScope) DescribeCache() *describecache.Cache {
+	return s.describeCache
+}
+
+// AuditEventsVerbosity returns the verbosity of the events published for the AWS calls changing resources.
+func (s *ManagedControlPlaneScope) AuditEventsVerbosity() int {
+	return s.auditEventsVerbosity
+}
+
+// PrincipalKey returns the key of the AWS principal and region of the session, shared by the sessions using
+// the same account in the same region.
+func (s *ManagedControlPlaneScope) PrincipalKey() string {
+	return s.principalKey
+}
+
 // Subnets returns the control plane subnets.
 func (s *ManagedControlPlaneScope) Subnets() infrav1.Subnets {
 	return s.ControlPlane.Spec.NetworkSpec.Subnets
 }
 
+// ControlPlaneSubnets returns the subnets in which EKS places the network interfaces of the control plane.
+func (s *ManagedControlPlaneScope) ControlPlaneSubnets() infrav1.Subnets {
+	if len(s.ControlPlane.Spec.ControlPlaneSubnetIDs) == 0 {
+		return s.Subnets()
+	}
+
+	subnets := infrav1.Subnets{}
+	for _, id := range s.ControlPlane.Spec.ControlPlaneSubnetIDs {
+		if subnet := s.Subnets().FindByID(id); subnet != nil {
+			subnets = append(subnets, *subnet)
+		}
+	}
+	return subnets
+}
+
 // IdentityRef returns the cluster identityRef.
 func (s *ManagedControlPlaneScope) IdentityRef() *infrav1.AWSIdentityReference {
 	return s.ControlPlane.Spec.IdentityRef
@@ -183,6 +243,26 @@ func (s *ManagedControlPlaneScope) CNIIngressRules() infrav1.CNIIngressRules {
 	return infrav1.CNIIngressRules{}
 }
 
+// AdditionalControlPlaneIngressRules returns the additional ingress rules of the control plane security group.
+func (s *ManagedControlPlaneScope) AdditionalControlPlaneIngressRules() infrav1.IngressRules {
+	return s.ControlPlane.Spec.NetworkSpec.AdditionalControlPlaneIngressRules
+}
+
+// AdditionalNodeIngressRules returns the additional ingress rules of the node security group.
+func (s *ManagedControlPlaneScope) AdditionalNodeIngressRules() infrav1.IngressRules {
+	return s.ControlPlane.Spec.NetworkSpec.AdditionalNodeIngressRules
+}
+
+// NodeEgressRules returns the egress rules of the node security group.
+func (s *ManagedControlPlaneScope) NodeEgressRules() infrav1.EgressRules {
+	return s.ControlPlane.Spec.NetworkSpec.NodeEgressRules
+}
+
+// PrivateIPv6Egress returns whether the private subnets reach the internet over IPv6 without NAT gateways.
+func (s *ManagedControlPlaneScope) PrivateIPv6Egress() bool {
+	return s.ControlPlane.Spec.NetworkSpec.PrivateIPv6Egress
+}
+
 // SecurityGroups returns the control plane security groups as a map, it creates the map if empty.
 func (s *ManagedControlPlaneScope) SecurityGroups() map[infrav1.SecurityGroupRole]infrav1.SecurityGroup {
 	return s.ControlPlane.Status.Network.SecurityGroups
@@ -218,6 +298,11 @@ func (s *ManagedControlPlaneScope) Region() string {
 	return s.ControlPlane.Spec.Region
 }
 
+// Partition returns the AWS partition of the control plane, derived from its region.
+func (s *ManagedControlPlaneScope) Partition() string {
+	return infrav1.PartitionForRegion(s.Region())
+}
+
 // ListOptionsLabelSelector returns a ListOptions with a label selector for clusterName.
 func (s *ManagedControlPlaneScope) ListOptionsLabelSelector() client.ListOption {
 	return client.MatchingLabels(map[string]string{
@@ -237,12 +322,18 @@ func (s *ManagedControlPlaneScope) PatchObject() error {
 			infrav1.InternetGatewayReadyCondition,
 			infrav1.NatGatewaysReadyCondition,
 			infrav1.RouteTablesReadyCondition,
+			infrav1.SecondaryCidrsReadyCondition,
+			infrav1.VpcEndpointsReadyCondition,
 			infrav1.BastionHostReadyCondition,
 			infrav1.EgressOnlyInternetGatewayReadyCondition,
 			ekscontrolplanev1.EKSControlPlaneCreatingCondition,
 			ekscontrolplanev1.EKSControlPlaneReadyCondition,
 			ekscontrolplanev1.EKSControlPlaneUpdatingCondition,
+			ekscontrolplanev1.EKSAddonsCompatibleCondition,
+			ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition,
 			ekscontrolplanev1.IAMControlPlaneRolesReadyCondition,
+			ekscontrolplanev1.IAMClusterRoleReadyCondition,
+			ekscontrolplanev1.KarpenterResourcesReadyCondition,
 		}})
 }
 
@@ -260,6 +351,11 @@ func (s *ManagedControlPlaneScope) AdditionalTags() infrav1.Tags {
 	return s.ControlPlane.Spec.AdditionalTags.DeepCopy()
 }
 
+// Proxy returns the HTTP proxy settings of the nodes of the cluster, if any.
+func (s *ManagedControlPlaneScope) Proxy() *infrav1.ProxySpec {
+	return s.ControlPlane.Spec.Proxy
+}
+
 // APIServerPort returns the port to use when communicating with the API server.
 func (s *ManagedControlPlaneScope) APIServerPort() int32 {
 	return 443
@@ -298,6 +394,16 @@ func (s *ManagedControlPlaneScope) SetBastionInstance(instance *infrav1.Instance
 	s.ControlPlane.Status.Bastion = instance
 }
 
+// SetBastionConnection sets the bastion connection details in the status of the cluster.
+func (s *ManagedControlPlaneScope) SetBastionConnection(connection *infrav1.BastionConnection) {
+	s.ControlPlane.Status.BastionConnection = connection
+}
+
+// BastionConnection returns the bastion connection details in the status of the cluster, if any.
+func (s *ManagedControlPlaneScope) BastionConnection() *infrav1.BastionConnection {
+	return s.ControlPlane.Status.BastionConnection
+}
+
 // SSHKeyName returns the SSH key name to use for instances.
 func (s *ManagedControlPlaneScope) SSHKeyName() *string {
 	return s.ControlPlane.Spec.SSHKeyName
@@ -334,6 +440,16 @@ func (s *ManagedControlPlaneScope) AllowAdditionalRoles() bool {
 	return s.allowAdditionalRoles
 }
 
+// IAMPermissionsBoundary returns the ARN of the permissions boundary of the created IAM roles.
+func (s *ManagedControlPlaneScope) IAMPermissionsBoundary() string {
+	return s.iamPermissionsBoundary
+}
+
+// IAMPath returns the IAM path of the created IAM roles and instance profiles.
+func (s *ManagedControlPlaneScope) IAMPath() string {
+	return s.iamPath
+}
+
 // ImageLookupFormat returns the format string to use when looking up AMIs.
 func (s *ManagedControlPlaneScope) ImageLookupFormat() string {
 	return s.ControlPlane.Spec.ImageLookupFormat
@@ -349,6 +465,31 @@ func (s *ManagedControlPlaneScope) ImageLookupBaseOS() string {
 	return s.ControlPlane.Spec.ImageLookupBaseOS
 }
 
+// SecurityProfile returns the security profile enforced on the instances of the cluster.
+// Security profiles are only supported for AWSCluster, so none is enforced for EKS clusters.
+func (s *ManagedControlPlaneScope) SecurityProfile() infrav1.SecurityProfile {
+	return ""
+}
+
+// DefaultEBSKMSKeyID returns the KMS key used to encrypt the volumes of the instances of the cluster
+// which don't set their own encryption key. It is only supported for AWSCluster, so volumes of EKS
+// clusters are encrypted with their own key or the default AWS managed key.
+func (s *ManagedControlPlaneScope) DefaultEBSKMSKeyID() string {
+	return ""
+}
+
+// InstanceNameTemplate returns the template used to generate the Name tag of the instances of the cluster.
+// EKS clusters don't have a cluster-wide template, so it can only be set on the machines.
+func (s *ManagedControlPlaneScope) InstanceNameTemplate() string {
+	return ""
+}
+
+// ResourceRetentionPolicy returns the classes of resources retained when they are deleted.
+// Retention policies are only supported for AWSCluster, so all resources are deleted for EKS clusters.
+func (s *ManagedControlPlaneScope) ResourceRetentionPolicy() *infrav1.ResourceRetentionPolicy {
+	return nil
+}
+
 // IAMAuthConfig returns the IAM authenticator config. The returned value will never be nil.
 func (s *ManagedControlPlaneScope) IAMAuthConfig() *ekscontrolplanev1.IAMAuthenticatorConfig {
 	if s.ControlPlane.Spec.IAMAuthenticatorConfig == nil {
@@ -357,6 +498,12 @@ func (s *ManagedControlPlaneScope) IAMAuthConfig() *ekscontrolplanev1.IAMAuthent
 	return s.ControlPlane.Spec.IAMAuthenticatorConfig
 }
 
+// KarpenterStatus returns the identifiers of the AWS resources created for Karpenter, or nil
+// if they haven't been created.
+func (s *ManagedControlPlaneScope) KarpenterStatus() *ekscontrolplanev1.KarpenterStatus {
+	return s.ControlPlane.Status.Karpenter
+}
+
 // Addons returns the list of addons for a EKS cluster.
 func (s *ManagedControlPlaneScope) Addons() []ekscontrolplanev1.Addon {
 	if s.ControlPlane.Spec.Addons == nil {
@@ -401,3 +548,14 @@ func (s *ManagedControlPlaneScope) ServiceCidrs() *clusterv1.NetworkRanges {
 func (s *ManagedControlPlaneScope) ControlPlaneLoadBalancer() *infrav1.AWSLoadBalancerSpec {
 	return nil
 }
+
+// ResourceNaming returns nil, the names of the AWS resources of managed control planes are always generated
+// from the cluster name.
+func (s *ManagedControlPlaneScope) ResourceNaming() *infrav1.ResourceNaming {
+	return nil
+}
+
+// Konnectivity returns nil, the control planes of EKS clusters are managed by AWS.
+func (s *ManagedControlPlaneScope) Konnectivity() *infrav1.KonnectivitySpec {
+	return nil
+}
@@ -19,6 +19,7 @@ package scope
 import (
 	"context"
 	"fmt"
+	"time"
 
 	awsclient "github.com/aws/aws-sdk-go/aws/client"
 	"github.com/pkg/errors"
@@ -33,6 +34,7 @@ import (
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/describecache"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -56,6 +58,24 @@ type ManagedMachinePoolScopeParams struct {
 	EnableIAM            bool
 	AllowAdditionalRoles bool
 
+	// IAMPermissionsBoundary is the ARN of the permissions boundary of the created IAM roles, see the
+	// --iam-permissions-boundary flag.
+	IAMPermissionsBoundary string
+
+	// IAMPath is the IAM path of the created IAM roles and instance profiles, see the --iam-path flag.
+	IAMPath string
+
+	// EnforcePrincipalAllowList makes the identities deny-by-default, see the --enforce-principal-allow-list flag.
+	EnforcePrincipalAllowList bool
+
+	// DescribeCacheTTL is how long the EC2 clients of the session reuse the results of describe calls, see the
+	// --aws-describe-cache-ttl flag. 0 disables the cache.
+	DescribeCacheTTL time.Duration
+
+	// AuditEventsVerbosity selects the AWS calls changing resources an event is published for, see the
+	// --aws-api-events-verbosity flag. 0 publishes no event.
+	AuditEventsVerbosity int
+
 	InfraCluster EC2Scope
 }
 
@@ -83,7 +103,7 @@ func NewManagedMachinePoolScope(params ManagedMachinePoolScopeParams) (*ManagedM
 		ControlPlane:   params.ControlPlane,
 		controllerName: params.ControllerName,
 	}
-	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, managedScope, params.ControlPlane.Spec.Region, params.Endpoints, params.Logger)
+	session, serviceLimiters, describeCache, _, err := sessionForClusterWithRegion(params.Client, managedScope, params.ControlPlane.Spec.Region, params.Endpoints, params.EnforcePrincipalAllowList, params.DescribeCacheTTL, params.Logger)
 	if err != nil {
 		return nil, errors.Errorf("failed to create aws session: %v", err)
 	}
@@ -103,16 +123,20 @@ func NewManagedMachinePoolScope(params ManagedMachinePoolScopeParams) (*ManagedM
 		patchHelper:                ammpHelper,
 		capiMachinePoolPatchHelper: mpHelper,
 
-		Cluster:              params.Cluster,
-		ControlPlane:         params.ControlPlane,
-		ManagedMachinePool:   params.ManagedMachinePool,
-		MachinePool:          params.MachinePool,
-		EC2Scope:             params.InfraCluster,
-		session:              session,
-		serviceLimiters:      serviceLimiters,
-		controllerName:       params.ControllerName,
-		enableIAM:            params.EnableIAM,
-		allowAdditionalRoles: params.AllowAdditionalRoles,
+		Cluster:                params.Cluster,
+		ControlPlane:           params.ControlPlane,
+		ManagedMachinePool:     params.ManagedMachinePool,
+		MachinePool:            params.MachinePool,
+		EC2Scope:               params.InfraCluster,
+		session:                session,
+		serviceLimiters:        serviceLimiters,
+		describeCache:          describeCache,
+		auditEventsVerbosity:   params.AuditEventsVerbosity,
+		controllerName:         params.ControllerName,
+		enableIAM:              params.EnableIAM,
+		allowAdditionalRoles:   params.AllowAdditionalRoles,
+		iamPermissionsBoundary: params.IAMPermissionsBoundary,
+		iamPath:                params.IAMPath,
 	}, nil
 }
 
@@ -129,12 +153,16 @@ type ManagedMachinePoolScope struct {
 	MachinePool        *expclusterv1.MachinePool
 	EC2Scope           EC2Scope
 
-	session         awsclient.ConfigProvider
-	serviceLimiters throttle.ServiceLimiters
-	controllerName  string
+	session              awsclient.ConfigProvider
+	serviceLimiters      throttle.ServiceLimiters
+	describeCache        *describecache.Cache
+	auditEventsVerbosity int
+	controllerName       string
 
-	enableIAM            bool
-	allowAdditionalRoles bool
+	enableIAM              bool
+	allowAdditionalRoles   bool
+	iamPermissionsBoundary string
+	iamPath                string
 }
 
 // ManagedPoolName returns the managed machine pool name.
@@ -150,11 +178,26 @@ func (s *ManagedMachinePoolScope) ServiceLimiter(service string) *throttle.Servi
 	return nil
 }
 
+// DescribeCache returns the cache of the EC2 describe calls of the session, or nil if it is disabled.
+func (s *ManagedMachinePoolScope) DescribeCache() *describecache.Cache {
+	return s.describeCache
+}
+
+// AuditEventsVerbosity returns the verbosity of the events published for the AWS calls changing resources.
+func (s *ManagedMachinePoolScope) AuditEventsVerbosity() int {
+	return s.auditEventsVerbosity
+}
+
 // ClusterName returns the cluster name.
 func (s *ManagedMachinePoolScope) ClusterName() string {
 	return s.ControlPlane.Spec.EKSClusterName
 }
 
+// Partition returns the AWS partition of the control plane of the node group.
+func (s *ManagedMachinePoolScope) Partition() string {
+	return infrav1.PartitionForRegion(s.ControlPlane.Spec.Region)
+}
+
 // EnableIAM indicates that reconciliation should create IAM roles.
 func (s *ManagedMachinePoolScope) EnableIAM() bool {
 	return s.enableIAM
@@ -165,6 +208,16 @@ func (s *ManagedMachinePoolScope) AllowAdditionalRoles() bool {
 	return s.allowAdditionalRoles
 }
 
+// IAMPermissionsBoundary returns the ARN of the permissions boundary of the created IAM roles.
+func (s *ManagedMachinePoolScope) IAMPermissionsBoundary() string {
+	return s.iamPermissionsBoundary
+}
+
+// IAMPath returns the IAM path of the created IAM roles.
+func (s *ManagedMachinePoolScope) IAMPath() string {
+	return s.iamPath
+}
+
 // IdentityRef returns the cluster identityRef.
 func (s *ManagedMachinePoolScope) IdentityRef() *infrav1.AWSIdentityReference {
 	return s.ControlPlane.Spec.IdentityRef
@@ -247,6 +300,13 @@ func (s *ManagedMachinePoolScope) IAMReadyFalse(reason string, err string) error
 		severity,
 		err,
 	)
+	conditions.MarkFalse(
+		s.ManagedMachinePool,
+		expinfrav1.IAMNodeRoleReadyCondition,
+		reason,
+		severity,
+		err,
+	)
 	if err := s.PatchObject(); err != nil {
 		return errors.Wrap(err, "failed to mark nodegroup role not ready")
 	}
@@ -261,6 +321,7 @@ func (s *ManagedMachinePoolScope) PatchObject() error {
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			expinfrav1.EKSNodegroupReadyCondition,
 			expinfrav1.IAMNodegroupRolesReadyCondition,
+			expinfrav1.IAMNodeRoleReadyCondition,
 		}})
 }
 
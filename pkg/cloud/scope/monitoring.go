@@ -0,0 +1,36 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+)
+
+// MonitoringScope is the interface for the scope to be used with the monitoring service.
+type MonitoringScope interface {
+	cloud.ClusterScoper
+
+	// Monitoring returns the configuration of the CloudWatch alarms, or nil if monitoring is disabled.
+	Monitoring() *infrav1.MonitoringSpec
+	// Network returns the network status of the cluster.
+	Network() *infrav1.NetworkStatus
+	// Subnets returns the subnets of the cluster.
+	Subnets() infrav1.Subnets
+	// BastionInstance returns the bastion host of the cluster, or nil if there is none.
+	BastionInstance() *infrav1.Instance
+}
@@ -39,6 +39,8 @@ type NetworkScope interface {
 	SecurityGroups() map[infrav1.SecurityGroupRole]infrav1.SecurityGroup
 	// SecondaryCidrBlock returns the optional secondary CIDR block to use for pod IPs
 	SecondaryCidrBlock() *string
+	// PrivateIPv6Egress returns whether the private subnets reach the internet over IPv6 without NAT gateways.
+	PrivateIPv6Egress() bool
 
 	// Bastion returns the bastion details for the cluster.
 	Bastion() *infrav1.Bastion
@@ -0,0 +1,48 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// GetProxyTrustedCA returns the CA bundle of the Secret referenced by the proxy settings of a cluster,
+// or nil if they don't reference one.
+func GetProxyTrustedCA(c client.Client, namespace string, proxy *infrav1.ProxySpec) ([]byte, error) {
+	if proxy == nil || proxy.TrustedCASecretRef == nil {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: namespace, Name: proxy.TrustedCASecretRef.Name}
+	if err := c.Get(context.TODO(), key, secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Secret %s with the trusted CA of the proxy", key)
+	}
+
+	ca, ok := secret.Data[infrav1.ProxyTrustedCAKey]
+	if !ok || len(ca) == 0 {
+		return nil, errors.Errorf("Secret %s has no %s key with the trusted CA of the proxy", key, infrav1.ProxyTrustedCAKey)
+	}
+
+	return ca, nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// maxRosaClusterNameLength is the maximum length of a cluster name accepted by OCM.
+const maxRosaClusterNameLength = 15
+
+// ROSAControlPlaneScopeParams defines the input parameters used to create a new ROSAControlPlaneScope.
+type ROSAControlPlaneScopeParams struct {
+	Client         client.Client
+	Logger         *logger.Logger
+	Cluster        *clusterv1.Cluster
+	ControlPlane   *rosacontrolplanev1.ROSAControlPlane
+	ControllerName string
+}
+
+// NewROSAControlPlaneScope creates a new ROSAControlPlaneScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewROSAControlPlaneScope(params ROSAControlPlaneScopeParams) (*ROSAControlPlaneScope, error) {
+	if params.Cluster == nil {
+		return nil, errors.New("failed to generate new scope from nil Cluster")
+	}
+	if params.ControlPlane == nil {
+		return nil, errors.New("failed to generate new scope from nil ROSAControlPlane")
+	}
+	if params.Logger == nil {
+		log := klog.Background()
+		params.Logger = logger.NewLogger(log)
+	}
+
+	helper, err := patch.NewHelper(params.ControlPlane, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &ROSAControlPlaneScope{
+		Logger:         *params.Logger,
+		Client:         params.Client,
+		Cluster:        params.Cluster,
+		ControlPlane:   params.ControlPlane,
+		patchHelper:    helper,
+		controllerName: params.ControllerName,
+	}, nil
+}
+
+// ROSAControlPlaneScope defines the basic context for an actuator to operate upon.
+type ROSAControlPlaneScope struct {
+	logger.Logger
+	Client      client.Client
+	patchHelper *patch.Helper
+
+	Cluster      *clusterv1.Cluster
+	ControlPlane *rosacontrolplanev1.ROSAControlPlane
+
+	controllerName string
+}
+
+// Name returns the CAPI cluster name.
+func (s *ROSAControlPlaneScope) Name() string {
+	return s.Cluster.Name
+}
+
+// Namespace returns the cluster namespace.
+func (s *ROSAControlPlaneScope) Namespace() string {
+	return s.Cluster.Namespace
+}
+
+// RosaClusterName returns the name of the cluster in OCM.
+func (s *ROSAControlPlaneScope) RosaClusterName() string {
+	return rosaClusterName(s.ControlPlane)
+}
+
+func rosaClusterName(controlPlane *rosacontrolplanev1.ROSAControlPlane) string {
+	if controlPlane.Spec.RosaClusterName != "" {
+		return controlPlane.Spec.RosaClusterName
+	}
+
+	name := fmt.Sprintf("%s-%s", controlPlane.Namespace, controlPlane.Name)
+	if len(name) > maxRosaClusterNameLength {
+		name = name[:maxRosaClusterNameLength]
+	}
+
+	return strings.TrimRight(name, "-")
+}
+
+// ControllerName returns the name of the controller that
+// created the ROSAControlPlane.
+func (s *ROSAControlPlaneScope) ControllerName() string {
+	return s.controllerName
+}
+
+// OCMClientParams returns the parameters used to create an OCM client from the
+// credentials secret referenced by the control plane.
+func (s *ROSAControlPlaneScope) OCMClientParams(ctx context.Context) (rosa.ClientParams, error) {
+	return ocmClientParams(ctx, s.Client, s.ControlPlane)
+}
+
+// PatchObject persists the control plane configuration and status.
+func (s *ROSAControlPlaneScope) PatchObject() error {
+	return s.patchHelper.Patch(
+		context.TODO(),
+		s.ControlPlane,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			rosacontrolplanev1.ROSAControlPlaneReadyCondition,
+		}})
+}
+
+// Close closes the current scope persisting the control plane configuration and status.
+func (s *ROSAControlPlaneScope) Close() error {
+	return s.PatchObject()
+}
+
+func ocmClientParams(ctx context.Context, c client.Client, controlPlane *rosacontrolplanev1.ROSAControlPlane) (rosa.ClientParams, error) {
+	ref := controlPlane.Spec.CredentialsSecretRef
+	if ref == nil || ref.Name == "" {
+		return rosa.ClientParams{}, errors.New("credentialsSecretRef is not set")
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: controlPlane.Namespace, Name: ref.Name}
+	if err := c.Get(ctx, key, secret); err != nil {
+		return rosa.ClientParams{}, errors.Wrapf(err, "failed to get OCM credentials secret %s", key)
+	}
+
+	token := string(secret.Data[rosacontrolplanev1.OCMTokenKey])
+	if token == "" {
+		return rosa.ClientParams{}, errors.Errorf("OCM credentials secret %s has no %q key", key, rosacontrolplanev1.OCMTokenKey)
+	}
+
+	return rosa.ClientParams{
+		Token:  token,
+		APIURL: string(secret.Data[rosacontrolplanev1.OCMAPIURLKey]),
+	}, nil
+}
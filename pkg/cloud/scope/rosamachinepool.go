@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// RosaMachinePoolScopeParams defines the input parameters used to create a new RosaMachinePoolScope.
+type RosaMachinePoolScopeParams struct {
+	Client          client.Client
+	Logger          *logger.Logger
+	Cluster         *clusterv1.Cluster
+	ControlPlane    *rosacontrolplanev1.ROSAControlPlane
+	RosaMachinePool *expinfrav1.ROSAMachinePool
+	MachinePool     *expclusterv1.MachinePool
+	ControllerName  string
+}
+
+// NewRosaMachinePoolScope creates a new RosaMachinePoolScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewRosaMachinePoolScope(params RosaMachinePoolScopeParams) (*RosaMachinePoolScope, error) {
+	if params.ControlPlane == nil {
+		return nil, errors.New("failed to generate new scope from nil ROSAControlPlane")
+	}
+	if params.MachinePool == nil {
+		return nil, errors.New("failed to generate new scope from nil MachinePool")
+	}
+	if params.RosaMachinePool == nil {
+		return nil, errors.New("failed to generate new scope from nil RosaMachinePool")
+	}
+	if params.Logger == nil {
+		log := klog.Background()
+		params.Logger = logger.NewLogger(log)
+	}
+
+	rmpHelper, err := patch.NewHelper(params.RosaMachinePool, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init RosaMachinePool patch helper")
+	}
+
+	mpHelper, err := patch.NewHelper(params.MachinePool, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init MachinePool patch helper")
+	}
+
+	return &RosaMachinePoolScope{
+		Logger:                     *params.Logger,
+		Client:                     params.Client,
+		Cluster:                    params.Cluster,
+		ControlPlane:               params.ControlPlane,
+		RosaMachinePool:            params.RosaMachinePool,
+		MachinePool:                params.MachinePool,
+		patchHelper:                rmpHelper,
+		capiMachinePoolPatchHelper: mpHelper,
+		controllerName:             params.ControllerName,
+	}, nil
+}
+
+// RosaMachinePoolScope defines the basic context for an actuator to operate upon.
+type RosaMachinePoolScope struct {
+	logger.Logger
+	client.Client
+	patchHelper                *patch.Helper
+	capiMachinePoolPatchHelper *patch.Helper
+
+	Cluster         *clusterv1.Cluster
+	ControlPlane    *rosacontrolplanev1.ROSAControlPlane
+	RosaMachinePool *expinfrav1.ROSAMachinePool
+	MachinePool     *expclusterv1.MachinePool
+
+	controllerName string
+}
+
+// NodePoolName returns the name of the node pool in OCM.
+func (s *RosaMachinePoolScope) NodePoolName() string {
+	if s.RosaMachinePool.Status.ID != "" {
+		return s.RosaMachinePool.Status.ID
+	}
+	return s.RosaMachinePool.Spec.NodePoolName
+}
+
+// ClusterID returns the OCM id of the cluster the node pool belongs to.
+func (s *RosaMachinePoolScope) ClusterID() string {
+	return s.ControlPlane.Status.ID
+}
+
+// RosaClusterName returns the name of the cluster in OCM the node pool belongs to.
+func (s *RosaMachinePoolScope) RosaClusterName() string {
+	return rosaClusterName(s.ControlPlane)
+}
+
+// ControllerName returns the name of the controller that
+// created the RosaMachinePool.
+func (s *RosaMachinePoolScope) ControllerName() string {
+	return s.controllerName
+}
+
+// OCMClientParams returns the parameters used to create an OCM client from the
+// credentials secret referenced by the owning control plane.
+func (s *RosaMachinePoolScope) OCMClientParams(ctx context.Context) (rosa.ClientParams, error) {
+	return ocmClientParams(ctx, s.Client, s.ControlPlane)
+}
+
+// PatchObject persists the machine pool configuration and status.
+func (s *RosaMachinePoolScope) PatchObject() error {
+	return s.patchHelper.Patch(
+		context.TODO(),
+		s.RosaMachinePool,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			expinfrav1.RosaMachinePoolReadyCondition,
+		}})
+}
+
+// PatchCAPIMachinePoolObject persists the capi machinepool configuration and status.
+func (s *RosaMachinePoolScope) PatchCAPIMachinePoolObject(ctx context.Context) error {
+	return s.capiMachinePoolPatchHelper.Patch(
+		ctx,
+		s.MachinePool,
+	)
+}
+
+// Close closes the current scope persisting the machine pool configuration and status.
+func (s *RosaMachinePoolScope) Close() error {
+	return s.PatchObject()
+}
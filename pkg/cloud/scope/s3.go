@@ -26,4 +26,7 @@ type S3Scope interface {
 	cloud.ClusterScoper
 
 	Bucket() *infrav1.S3Bucket
+
+	// ResourceRetentionPolicy returns the classes of resources retained when they are deleted.
+	ResourceRetentionPolicy() *infrav1.ResourceRetentionPolicy
 }
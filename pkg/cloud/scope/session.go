@@ -20,9 +20,12 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -30,14 +33,17 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/describecache"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/identity"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
@@ -49,7 +55,8 @@ import (
 )
 
 const (
-	notPermittedError = "Namespace is not permitted to use %s: %s"
+	notPermittedError        = "Namespace is not permitted to use %s: %s"
+	identityRefRequiredError = "an identityRef is required while the principal allow-list is enforced"
 )
 
 // ServiceEndpoint defines a tuple containing AWS Service resolution information.
@@ -62,15 +69,69 @@ type ServiceEndpoint struct {
 var sessionCache sync.Map
 var providerCache sync.Map
 
+// serviceLimitersCache holds the service limiters of each AWS principal and region, so that the clusters using
+// the same account share its API budget, while the clusters of other accounts aren't throttled by them.
+var serviceLimitersCache sync.Map
+
+// controllerPrincipal identifies the credentials of the controller in the service limiters cache.
+const controllerPrincipal = "controller"
+
 type sessionCacheEntry struct {
 	session         *session.Session
 	serviceLimiters throttle.ServiceLimiters
+	describeCache   *describecache.Cache
+	principalKey    string
+	endpoints       []ServiceEndpoint
 }
 
 // SessionInterface is the interface for AWSCluster and ManagedCluster to be used to get session using identityRef.
 var SessionInterface interface {
 }
 
+// resolvedServiceEndpoint returns the endpoint of a custom service endpoint. Requests are signed
+// for the region of the session when the service endpoint doesn't define a signing region.
+func resolvedServiceEndpoint(s ServiceEndpoint, region string) endpoints.ResolvedEndpoint {
+	signingRegion := s.SigningRegion
+	if signingRegion == "" {
+		signingRegion = region
+	}
+	return endpoints.ResolvedEndpoint{
+		URL:           s.URL,
+		SigningRegion: signingRegion,
+	}
+}
+
+// withClusterServiceEndpoints returns the service endpoints of the controller overridden by the service
+// endpoints of a cluster, which take precedence for the same services.
+func withClusterServiceEndpoints(endpoints []ServiceEndpoint, clusterEndpoints infrav1.AWSServiceEndpoints) []ServiceEndpoint {
+	if len(clusterEndpoints) == 0 {
+		return endpoints
+	}
+
+	merged := make([]ServiceEndpoint, 0, len(endpoints)+len(clusterEndpoints))
+	for _, e := range clusterEndpoints {
+		merged = append(merged, ServiceEndpoint{
+			ServiceID:     e.ServiceID,
+			URL:           e.URL,
+			SigningRegion: e.SigningRegion,
+		})
+	}
+	for _, e := range endpoints {
+		overridden := false
+		for _, c := range clusterEndpoints {
+			if c.ServiceID == e.ServiceID {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, e)
+		}
+	}
+
+	return merged
+}
+
 func sessionForRegion(region string, endpoint []ServiceEndpoint) (*session.Session, throttle.ServiceLimiters, error) {
 	if s, ok := sessionCache.Load(region); ok {
 		entry := s.(*sessionCacheEntry)
@@ -80,23 +141,20 @@ func sessionForRegion(region string, endpoint []ServiceEndpoint) (*session.Sessi
 	resolver := func(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
 		for _, s := range endpoint {
 			if service == s.ServiceID {
-				return endpoints.ResolvedEndpoint{
-					URL:           s.URL,
-					SigningRegion: s.SigningRegion,
-				}, nil
+				return resolvedServiceEndpoint(s, region), nil
 			}
 		}
 		return endpoints.DefaultResolver().EndpointFor(service, region, optFns...)
 	}
-	ns, err := session.NewSession(&aws.Config{
+	ns, err := session.NewSession(withSTSRegionalEndpoints(&aws.Config{
 		Region:           aws.String(region),
 		EndpointResolver: endpoints.ResolverFunc(resolver),
-	})
+	}))
 	if err != nil {
 		return nil, nil, err
 	}
 
-	sl := newServiceLimiters()
+	sl := serviceLimitersFor(principalKeyFor(controllerPrincipal, region))
 	sessionCache.Store(region, &sessionCacheEntry{
 		session:         ns,
 		serviceLimiters: sl,
@@ -104,27 +162,30 @@ func sessionForRegion(region string, endpoint []ServiceEndpoint) (*session.Sessi
 	return ns, sl, nil
 }
 
-func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.ClusterScoper, region string, endpoint []ServiceEndpoint, log logger.Wrapper) (*session.Session, throttle.ServiceLimiters, error) {
+// sessionForClusterWithRegion returns a session using the identity of a cluster. With enforcePrincipalAllowList,
+// set from the --enforce-principal-allow-list flag of the controller, the identities are deny-by-default: every
+// cluster must reference an identity, and that identity and its source identities must explicitly list or select
+// the namespace of the cluster. The session is returned with its service limiters, the cache of its EC2 describe
+// calls, which is nil when describeCacheTTL, set from the --aws-describe-cache-ttl flag, is 0, and the key of its
+// principal and region, see principalKeyFor.
+func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.ClusterScoper, region string, endpoint []ServiceEndpoint, enforcePrincipalAllowList bool, describeCacheTTL time.Duration, log logger.Wrapper) (*session.Session, throttle.ServiceLimiters, *describecache.Cache, string, error) {
 	log = log.WithName("identity")
 	log.Trace("Creating an AWS Session")
 
 	resolver := func(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
 		for _, s := range endpoint {
 			if service == s.ServiceID {
-				return endpoints.ResolvedEndpoint{
-					URL:           s.URL,
-					SigningRegion: s.SigningRegion,
-				}, nil
+				return resolvedServiceEndpoint(s, region), nil
 			}
 		}
 		return endpoints.DefaultResolver().EndpointFor(service, region, optFns...)
 	}
 
-	providers, err := getProvidersForCluster(context.Background(), k8sClient, clusterScoper, log)
+	providers, err := getProvidersForCluster(context.Background(), k8sClient, clusterScoper, enforcePrincipalAllowList, log)
 	if err != nil {
 		// could not get providers and retrieve the credentials
 		conditions.MarkFalse(clusterScoper.InfraCluster(), infrav1.PrincipalCredentialRetrievedCondition, infrav1.PrincipalCredentialRetrievalFailedReason, clusterv1.ConditionSeverityError, err.Error())
-		return nil, nil, errors.Wrap(err, "Failed to get providers for cluster")
+		return nil, nil, nil, "", errors.Wrap(err, "Failed to get providers for cluster")
 	}
 
 	isChanged := false
@@ -133,7 +194,7 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Cl
 		// load an existing matching providers from the cache if such a providers exists
 		providerHash, err := provider.Hash()
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "Failed to calculate provider hash")
+			return nil, nil, nil, "", errors.Wrap(err, "Failed to calculate provider hash")
 		}
 		cachedProvider, ok := providerCache.Load(providerHash)
 		if ok {
@@ -147,15 +208,16 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Cl
 	}
 
 	if !isChanged {
-		if s, ok := sessionCache.Load(getSessionName(region, clusterScoper)); ok {
+		// Sessions are only reused as long as the service endpoints of the cluster don't change.
+		if s, ok := sessionCache.Load(getSessionName(region, clusterScoper)); ok && cmp.Equal(s.(*sessionCacheEntry).endpoints, endpoint) {
 			entry := s.(*sessionCacheEntry)
-			return entry.session, entry.serviceLimiters, nil
+			return entry.session, entry.serviceLimiters, entry.describeCache, entry.principalKey, nil
 		}
 	}
-	awsConfig := &aws.Config{
+	awsConfig := withSTSRegionalEndpoints(&aws.Config{
 		Region:           aws.String(region),
 		EndpointResolver: endpoints.ResolverFunc(resolver),
-	}
+	})
 
 	if len(providers) > 0 {
 		// Check if identity credentials can be retrieved. One reason this will fail is that source identity is not authorized for assume role.
@@ -166,7 +228,7 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Cl
 			// delete the existing session from cache. Otherwise, we give back a defective session on next method invocation with same cluster scope
 			sessionCache.Delete(getSessionName(region, clusterScoper))
 
-			return nil, nil, errors.Wrap(err, "Failed to retrieve identity credentials")
+			return nil, nil, nil, "", errors.Wrap(err, "Failed to retrieve identity credentials")
 		}
 		awsConfig = awsConfig.WithCredentials(credentials.NewChainCredentials(awsProviders))
 	}
@@ -175,21 +237,75 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Cl
 
 	ns, err := session.NewSession(awsConfig)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "Failed to create a new AWS session")
+		return nil, nil, nil, "", errors.Wrap(err, "Failed to create a new AWS session")
+	}
+	principalKey := principalKeyFor(principalForProviders(providers), region)
+	sl := serviceLimitersFor(principalKey)
+	// The describe cache is created with the session, so that the results of the describe calls made with the
+	// previous credentials or endpoints of the cluster are dropped with its previous session.
+	var dc *describecache.Cache
+	if describeCacheTTL > 0 {
+		dc = describecache.New(describeCacheTTL)
 	}
-	sl := newServiceLimiters()
 	sessionCache.Store(getSessionName(region, clusterScoper), &sessionCacheEntry{
 		session:         ns,
 		serviceLimiters: sl,
+		describeCache:   dc,
+		principalKey:    principalKey,
+		endpoints:       endpoint,
 	})
 
-	return ns, sl, nil
+	return ns, sl, dc, principalKey, nil
+}
+
+// withSTSRegionalEndpoints sends the STS requests of the sessions, such as the GetCallerIdentity requests made
+// with the credentials of the controller, to the regional STS endpoint of their region when enabled.
+func withSTSRegionalEndpoints(config *aws.Config) *aws.Config {
+	if identity.STSRegionalEndpoints {
+		config.STSRegionalEndpoint = endpoints.RegionalSTSEndpoint
+	}
+	return config
 }
 
 func getSessionName(region string, clusterScoper cloud.ClusterScoper) string {
 	return fmt.Sprintf("%s-%s-%s", region, clusterScoper.InfraClusterName(), clusterScoper.Namespace())
 }
 
+// principalKeyFor returns the key identifying an AWS principal in a region. The sessions with the same key share
+// the API request budget and the service quotas of the account in that region.
+func principalKeyFor(principal, region string) string {
+	return principal + "/" + region
+}
+
+// serviceLimitersFor returns the service limiters shared by the sessions of a principal key, see principalKeyFor.
+func serviceLimitersFor(principalKey string) throttle.ServiceLimiters {
+	sl, _ := serviceLimitersCache.LoadOrStore(principalKey, newServiceLimiters())
+	return sl.(throttle.ServiceLimiters)
+}
+
+// principalForProviders returns the AWS principal of the credentials of a cluster. AWS throttles API requests
+// per account and region, so role based identities are identified by the account of their role. Static
+// identities are identified by their access key, as their account can't be known without calling STS.
+func principalForProviders(providers []identity.AWSPrincipalTypeProvider) string {
+	if len(providers) == 0 {
+		return controllerPrincipal
+	}
+
+	var roleARN string
+	switch p := providers[0].(type) {
+	case *identity.AWSRolePrincipalTypeProvider:
+		roleARN = p.Principal.Spec.RoleArn
+	case *identity.AWSWebIdentityPrincipalTypeProvider:
+		roleARN = p.Principal.Spec.RoleArn
+	case *identity.AWSStaticPrincipalTypeProvider:
+		return "access-key/" + p.AccessKeyID
+	}
+	if parsed, err := arn.Parse(roleARN); err == nil && parsed.AccountID != "" {
+		return "account/" + parsed.AccountID
+	}
+	return "identity/" + providers[0].Name()
+}
+
 func newServiceLimiters() throttle.ServiceLimiters {
 	return throttle.ServiceLimiters{
 		ec2.ServiceID:                      newEC2ServiceLimiter(),
@@ -197,6 +313,7 @@ func newServiceLimiters() throttle.ServiceLimiters {
 		elbv2.ServiceID:                    newGenericServiceLimiter(),
 		resourcegroupstaggingapi.ServiceID: newGenericServiceLimiter(),
 		secretsmanager.ServiceID:           newGenericServiceLimiter(),
+		servicequotas.ServiceID:            newGenericServiceLimiter(),
 	}
 }
 
@@ -256,8 +373,14 @@ func buildProvidersForRef(
 	k8sClient client.Client,
 	clusterScoper cloud.ClusterScoper,
 	ref *infrav1.AWSIdentityReference,
+	chain map[string]struct{},
+	enforcePrincipalAllowList bool,
 	log logger.Wrapper) ([]identity.AWSPrincipalTypeProvider, error) {
 	if ref == nil {
+		if enforcePrincipalAllowList {
+			conditions.MarkFalse(clusterScoper.InfraCluster(), infrav1.PrincipalUsageAllowedCondition, infrav1.PrincipalIdentityRefRequiredReason, clusterv1.ConditionSeverityError, identityRefRequiredError)
+			return providers, errors.New(identityRefRequiredError)
+		}
 		log.Trace("AWSCluster does not have a IdentityRef specified")
 		return providers, nil
 	}
@@ -269,14 +392,20 @@ func buildProvidersForRef(
 
 	switch ref.Kind {
 	case infrav1.ControllerIdentityKind:
-		err := buildAWSClusterControllerIdentity(ctx, identityObjectKey, k8sClient, clusterScoper)
+		err := buildAWSClusterControllerIdentity(ctx, identityObjectKey, k8sClient, clusterScoper, enforcePrincipalAllowList)
 		if err != nil {
 			return providers, err
 		}
 		// returning empty provider list to default to Controller Principal.
 		return []identity.AWSPrincipalTypeProvider{}, nil
 	case infrav1.ClusterStaticIdentityKind:
-		provider, err := buildAWSClusterStaticIdentity(ctx, identityObjectKey, k8sClient, clusterScoper)
+		provider, err := buildAWSClusterStaticIdentity(ctx, identityObjectKey, k8sClient, clusterScoper, enforcePrincipalAllowList)
+		if err != nil {
+			return providers, err
+		}
+		providers = append(providers, provider)
+	case infrav1.ClusterWebIdentityKind:
+		provider, err := buildAWSClusterWebIdentity(ctx, identityObjectKey, k8sClient, clusterScoper, enforcePrincipalAllowList)
 		if err != nil {
 			return providers, err
 		}
@@ -285,21 +414,27 @@ func buildProvidersForRef(
 		roleIdentity := &infrav1.AWSClusterRoleIdentity{}
 		err := k8sClient.Get(ctx, identityObjectKey, roleIdentity)
 		if err != nil {
+			setPrincipalNotFoundCondition(err, infrav1.ClusterRoleIdentityKind, identityObjectKey, clusterScoper)
 			return providers, err
 		}
 		log.Trace("Principal retrieved")
-		canUse, err := isClusterPermittedToUsePrincipal(k8sClient, roleIdentity.Spec.AllowedNamespaces, clusterScoper.Namespace())
+		canUse, err := isClusterPermittedToUsePrincipal(k8sClient, roleIdentity.Spec.AllowedNamespaces, clusterScoper.Namespace(), enforcePrincipalAllowList)
 		if err != nil {
 			return providers, err
 		}
 		if !canUse {
-			setPrincipalUsageNotAllowedCondition(infrav1.ClusterRoleIdentityKind, identityObjectKey, clusterScoper)
-			return providers, errors.Errorf(notPermittedError, infrav1.ClusterRoleIdentityKind, roleIdentity.Name)
+			return providers, setPrincipalUsageNotAllowedCondition(infrav1.ClusterRoleIdentityKind, identityObjectKey, roleIdentity.Spec.AllowedNamespaces, clusterScoper)
 		}
 		setPrincipalUsageAllowedCondition(clusterScoper)
 
+		// Each role identity can only appear once in a chain, otherwise the chain never ends.
+		if _, ok := chain[roleIdentity.Name]; ok {
+			return providers, errors.Errorf("%s %s is part of a cycle of source identities", infrav1.ClusterRoleIdentityKind, roleIdentity.Name)
+		}
+		chain[roleIdentity.Name] = struct{}{}
+
 		if roleIdentity.Spec.SourceIdentityRef != nil {
-			providers, err = buildProvidersForRef(ctx, providers, k8sClient, clusterScoper, roleIdentity.Spec.SourceIdentityRef, log)
+			providers, err = buildProvidersForRef(ctx, providers, k8sClient, clusterScoper, roleIdentity.Spec.SourceIdentityRef, chain, enforcePrincipalAllowList, log)
 			if err != nil {
 				return providers, err
 			}
@@ -314,9 +449,9 @@ func buildProvidersForRef(
 		}
 
 		if sourceProvider != nil {
-			provider = identity.NewAWSRolePrincipalTypeProvider(roleIdentity, &sourceProvider, log)
+			provider = identity.NewAWSRolePrincipalTypeProvider(roleIdentity, &sourceProvider, clusterScoper.Region(), log)
 		} else {
-			provider = identity.NewAWSRolePrincipalTypeProvider(roleIdentity, nil, log)
+			provider = identity.NewAWSRolePrincipalTypeProvider(roleIdentity, nil, clusterScoper.Region(), log)
 		}
 		providers = append(providers, provider)
 	default:
@@ -330,20 +465,34 @@ func setPrincipalUsageAllowedCondition(clusterScoper cloud.ClusterScoper) {
 	conditions.MarkTrue(clusterScoper.InfraCluster(), infrav1.PrincipalUsageAllowedCondition)
 }
 
-func setPrincipalUsageNotAllowedCondition(kind infrav1.AWSIdentityKind, identityObjectKey client.ObjectKey, clusterScoper cloud.ClusterScoper) {
-	errMsg := fmt.Sprintf(notPermittedError, kind, identityObjectKey.Name)
+// setPrincipalUsageNotAllowedCondition marks the principal usage as not allowed, with the reason the namespace of
+// the cluster isn't allowed by the identity, and returns the matching error.
+func setPrincipalUsageNotAllowedCondition(kind infrav1.AWSIdentityKind, identityObjectKey client.ObjectKey, allowedNs *infrav1.AllowedNamespaces, clusterScoper cloud.ClusterScoper) error {
+	errMsg := fmt.Sprintf(notPermittedError, kind, identityObjectKey.Name) + ": " + allowedNs.NotAllowedReason(clusterScoper.Namespace())
 
 	if clusterScoper.IdentityRef().Name == identityObjectKey.Name {
 		conditions.MarkFalse(clusterScoper.InfraCluster(), infrav1.PrincipalUsageAllowedCondition, infrav1.PrincipalUsageUnauthorizedReason, clusterv1.ConditionSeverityError, errMsg)
 	} else {
 		conditions.MarkFalse(clusterScoper.InfraCluster(), infrav1.PrincipalUsageAllowedCondition, infrav1.SourcePrincipalUsageUnauthorizedReason, clusterv1.ConditionSeverityError, errMsg)
 	}
+	return errors.New(errMsg)
+}
+
+// setPrincipalNotFoundCondition marks the principal usage as not allowed when err reports that the identity
+// referenced by the cluster, or one of its source identities, doesn't exist.
+func setPrincipalNotFoundCondition(err error, kind infrav1.AWSIdentityKind, identityObjectKey client.ObjectKey, clusterScoper cloud.ClusterScoper) {
+	if !apierrors.IsNotFound(err) {
+		return
+	}
+	conditions.MarkFalse(clusterScoper.InfraCluster(), infrav1.PrincipalUsageAllowedCondition, infrav1.PrincipalNotFoundReason, clusterv1.ConditionSeverityError,
+		"%s %s referenced by the cluster does not exist", kind, identityObjectKey.Name)
 }
 
-func buildAWSClusterStaticIdentity(ctx context.Context, identityObjectKey client.ObjectKey, k8sClient client.Client, clusterScoper cloud.ClusterScoper) (*identity.AWSStaticPrincipalTypeProvider, error) {
+func buildAWSClusterStaticIdentity(ctx context.Context, identityObjectKey client.ObjectKey, k8sClient client.Client, clusterScoper cloud.ClusterScoper, enforcePrincipalAllowList bool) (*identity.AWSStaticPrincipalTypeProvider, error) {
 	staticPrincipal := &infrav1.AWSClusterStaticIdentity{}
 	err := k8sClient.Get(ctx, identityObjectKey, staticPrincipal)
 	if err != nil {
+		setPrincipalNotFoundCondition(err, infrav1.ClusterStaticIdentityKind, identityObjectKey, clusterScoper)
 		return nil, err
 	}
 	secret := &corev1.Secret{}
@@ -353,36 +502,89 @@ func buildAWSClusterStaticIdentity(ctx context.Context, identityObjectKey client
 	}
 
 	// Set ClusterStaticPrincipal as Secret's owner reference for 'clusterctl move'.
-	patchHelper, err := patch.NewHelper(secret, k8sClient)
+	if err := ensureSecretOwnedByIdentity(ctx, k8sClient, secret, infrav1.ClusterStaticIdentityKind, staticPrincipal); err != nil {
+		return nil, err
+	}
+
+	canUse, err := isClusterPermittedToUsePrincipal(k8sClient, staticPrincipal.Spec.AllowedNamespaces, clusterScoper.Namespace(), enforcePrincipalAllowList)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to init patch helper for secret name:%s namespace:%s", secret.Name, secret.Namespace)
+		return nil, err
 	}
+	if !canUse {
+		return nil, setPrincipalUsageNotAllowedCondition(infrav1.ClusterStaticIdentityKind, identityObjectKey, staticPrincipal.Spec.AllowedNamespaces, clusterScoper)
+	}
+	setPrincipalUsageAllowedCondition(clusterScoper)
 
-	secret.OwnerReferences = util.EnsureOwnerRef(secret.OwnerReferences, metav1.OwnerReference{
-		APIVersion: infrav1.GroupVersion.String(),
-		Kind:       string(infrav1.ClusterStaticIdentityKind),
-		Name:       staticPrincipal.Name,
-		UID:        staticPrincipal.UID,
-	})
+	return identity.NewAWSStaticPrincipalTypeProvider(staticPrincipal, secret), nil
+}
 
-	if err := patchHelper.Patch(ctx, secret); err != nil {
-		return nil, errors.Wrapf(err, "failed to patch secret name:%s namespace:%s", secret.Name, secret.Namespace)
+func buildAWSClusterWebIdentity(ctx context.Context, identityObjectKey client.ObjectKey, k8sClient client.Client, clusterScoper cloud.ClusterScoper, enforcePrincipalAllowList bool) (*identity.AWSWebIdentityPrincipalTypeProvider, error) {
+	webIdentity := &infrav1.AWSClusterWebIdentity{}
+	err := k8sClient.Get(ctx, identityObjectKey, webIdentity)
+	if err != nil {
+		setPrincipalNotFoundCondition(err, infrav1.ClusterWebIdentityKind, identityObjectKey, clusterScoper)
+		return nil, err
 	}
 
-	canUse, err := isClusterPermittedToUsePrincipal(k8sClient, staticPrincipal.Spec.AllowedNamespaces, clusterScoper.Namespace())
+	canUse, err := isClusterPermittedToUsePrincipal(k8sClient, webIdentity.Spec.AllowedNamespaces, clusterScoper.Namespace(), enforcePrincipalAllowList)
 	if err != nil {
 		return nil, err
 	}
 	if !canUse {
-		setPrincipalUsageNotAllowedCondition(infrav1.ClusterStaticIdentityKind, identityObjectKey, clusterScoper)
-		return nil, errors.Errorf(notPermittedError, infrav1.ClusterStaticIdentityKind, identityObjectKey.Name)
+		return nil, setPrincipalUsageNotAllowedCondition(infrav1.ClusterWebIdentityKind, identityObjectKey, webIdentity.Spec.AllowedNamespaces, clusterScoper)
 	}
 	setPrincipalUsageAllowedCondition(clusterScoper)
 
-	return identity.NewAWSStaticPrincipalTypeProvider(staticPrincipal, secret), nil
+	switch {
+	case webIdentity.Spec.RolesAnywhere != nil:
+		secret := &corev1.Secret{}
+		err = k8sClient.Get(ctx, client.ObjectKey{Name: webIdentity.Spec.RolesAnywhere.CertificateSecretRef, Namespace: system.GetManagerNamespace()}, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		// Set ClusterWebIdentity as Secret's owner reference for 'clusterctl move'.
+		if err := ensureSecretOwnedByIdentity(ctx, k8sClient, secret, infrav1.ClusterWebIdentityKind, webIdentity); err != nil {
+			return nil, err
+		}
+
+		return identity.NewAWSRolesAnywherePrincipalTypeProvider(webIdentity, secret)
+	case webIdentity.Spec.WebIdentity != nil:
+		var tokenFetcher stscreds.TokenFetcher
+		if webIdentity.Spec.WebIdentity.TokenFile != "" {
+			tokenFetcher = stscreds.FetchTokenPath(webIdentity.Spec.WebIdentity.TokenFile)
+		} else {
+			serviceAccountKey := client.ObjectKey{Name: webIdentity.Spec.WebIdentity.ServiceAccountName, Namespace: system.GetManagerNamespace()}
+			tokenFetcher = identity.NewServiceAccountTokenFetcher(k8sClient, serviceAccountKey, webIdentity.Spec.WebIdentity.Audience)
+		}
+
+		return identity.NewAWSWebIdentityPrincipalTypeProvider(webIdentity, tokenFetcher, clusterScoper.Region()), nil
+	default:
+		return nil, errors.Errorf("%s %s sets neither webIdentity nor rolesAnywhere", infrav1.ClusterWebIdentityKind, webIdentity.Name)
+	}
+}
+
+// ensureSecretOwnedByIdentity sets the identity as an owner of the secret holding its credentials.
+func ensureSecretOwnedByIdentity(ctx context.Context, k8sClient client.Client, secret *corev1.Secret, kind infrav1.AWSIdentityKind, owner metav1.Object) error {
+	patchHelper, err := patch.NewHelper(secret, k8sClient)
+	if err != nil {
+		return errors.Wrapf(err, "failed to init patch helper for secret name:%s namespace:%s", secret.Name, secret.Namespace)
+	}
+
+	secret.OwnerReferences = util.EnsureOwnerRef(secret.OwnerReferences, metav1.OwnerReference{
+		APIVersion: infrav1.GroupVersion.String(),
+		Kind:       string(kind),
+		Name:       owner.GetName(),
+		UID:        owner.GetUID(),
+	})
+
+	if err := patchHelper.Patch(ctx, secret); err != nil {
+		return errors.Wrapf(err, "failed to patch secret name:%s namespace:%s", secret.Name, secret.Namespace)
+	}
+	return nil
 }
 
-func buildAWSClusterControllerIdentity(ctx context.Context, identityObjectKey client.ObjectKey, k8sClient client.Client, clusterScoper cloud.ClusterScoper) error {
+func buildAWSClusterControllerIdentity(ctx context.Context, identityObjectKey client.ObjectKey, k8sClient client.Client, clusterScoper cloud.ClusterScoper, enforcePrincipalAllowList bool) error {
 	controllerIdentity := &infrav1.AWSClusterControllerIdentity{}
 	controllerIdentity.Kind = string(infrav1.ControllerIdentityKind)
 
@@ -393,24 +595,24 @@ func buildAWSClusterControllerIdentity(ctx context.Context, identityObjectKey cl
 
 	err := k8sClient.Get(ctx, client.ObjectKey{Name: identityObjectKey.Name}, controllerIdentity)
 	if err != nil {
+		setPrincipalNotFoundCondition(err, infrav1.ControllerIdentityKind, identityObjectKey, clusterScoper)
 		return err
 	}
 
-	canUse, err := isClusterPermittedToUsePrincipal(k8sClient, controllerIdentity.Spec.AllowedNamespaces, clusterScoper.Namespace())
+	canUse, err := isClusterPermittedToUsePrincipal(k8sClient, controllerIdentity.Spec.AllowedNamespaces, clusterScoper.Namespace(), enforcePrincipalAllowList)
 	if err != nil {
 		return err
 	}
 	if !canUse {
-		setPrincipalUsageNotAllowedCondition(infrav1.ControllerIdentityKind, identityObjectKey, clusterScoper)
-		return errors.Errorf(notPermittedError, infrav1.ControllerIdentityKind, controllerIdentity.Name)
+		return setPrincipalUsageNotAllowedCondition(infrav1.ControllerIdentityKind, identityObjectKey, controllerIdentity.Spec.AllowedNamespaces, clusterScoper)
 	}
 	setPrincipalUsageAllowedCondition(clusterScoper)
 	return nil
 }
 
-func getProvidersForCluster(ctx context.Context, k8sClient client.Client, clusterScoper cloud.ClusterScoper, log logger.Wrapper) ([]identity.AWSPrincipalTypeProvider, error) {
+func getProvidersForCluster(ctx context.Context, k8sClient client.Client, clusterScoper cloud.ClusterScoper, enforcePrincipalAllowList bool, log logger.Wrapper) ([]identity.AWSPrincipalTypeProvider, error) {
 	providers := make([]identity.AWSPrincipalTypeProvider, 0)
-	providers, err := buildProvidersForRef(ctx, providers, k8sClient, clusterScoper, clusterScoper.IdentityRef(), log)
+	providers, err := buildProvidersForRef(ctx, providers, k8sClient, clusterScoper, clusterScoper.IdentityRef(), map[string]struct{}{}, enforcePrincipalAllowList, log)
 	if err != nil {
 		return nil, err
 	}
@@ -418,44 +620,6 @@ func getProvidersForCluster(ctx context.Context, k8sClient client.Client, cluste
 	return providers, nil
 }
 
-func isClusterPermittedToUsePrincipal(k8sClient client.Client, allowedNs *infrav1.AllowedNamespaces, clusterNamespace string) (bool, error) {
-	// nil value does not match with any namespaces
-	if allowedNs == nil {
-		return false, nil
-	}
-
-	// empty value matches with all namespaces
-	if cmp.Equal(*allowedNs, infrav1.AllowedNamespaces{}) {
-		return true, nil
-	}
-
-	for _, v := range allowedNs.NamespaceList {
-		if v == clusterNamespace {
-			return true, nil
-		}
-	}
-
-	// Check if clusterNamespace is in the namespaces selected by the identity's allowedNamespaces selector.
-	namespaces := &corev1.NamespaceList{}
-	selector, err := metav1.LabelSelectorAsSelector(&allowedNs.Selector)
-	if err != nil {
-		return false, errors.Wrap(err, "failed to get label selector from spec selector")
-	}
-
-	// If a Selector has a nil or empty selector, it should match nothing, not everything.
-	if selector.Empty() {
-		return false, nil
-	}
-
-	if err := k8sClient.List(context.Background(), namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
-		return false, errors.Wrap(err, "failed to list namespaces")
-	}
-
-	for i := range namespaces.Items {
-		n := &namespaces.Items[i]
-		if n.Name == clusterNamespace {
-			return true, nil
-		}
-	}
-	return false, nil
+func isClusterPermittedToUsePrincipal(k8sClient client.Client, allowedNs *infrav1.AllowedNamespaces, clusterNamespace string, enforcePrincipalAllowList bool) (bool, error) {
+	return allowedNs.AllowsNamespace(context.Background(), k8sClient, clusterNamespace, enforcePrincipalAllowList)
 }
@@ -19,7 +19,9 @@ package scope
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/service/ec2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -31,10 +33,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/auditevents"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/identity"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestIsClusterPermittedToUsePrincipal(t *testing.T) {
@@ -42,6 +47,7 @@ func TestIsClusterPermittedToUsePrincipal(t *testing.T) {
 		name             string
 		clusterNamespace string
 		allowedNs        *infrav1.AllowedNamespaces
+		enforce          bool
 		setup            func(*testing.T, client.Client)
 		expectedResult   bool
 		expectErr        bool
@@ -53,6 +59,24 @@ func TestIsClusterPermittedToUsePrincipal(t *testing.T) {
 			expectedResult:   true,
 			expectErr:        false,
 		},
+		{
+			name:             "No clusters are permitted to use identity if allowedNamespaces is empty and the allow-list is enforced",
+			clusterNamespace: "default",
+			allowedNs:        &infrav1.AllowedNamespaces{},
+			enforce:          true,
+			expectedResult:   false,
+			expectErr:        false,
+		},
+		{
+			name:             "A namespace is permitted if allowedNamespaces list has it and the allow-list is enforced",
+			clusterNamespace: "match",
+			allowedNs: &infrav1.AllowedNamespaces{
+				NamespaceList: []string{"match"},
+			},
+			enforce:        true,
+			expectedResult: true,
+			expectErr:      false,
+		},
 		{
 			name:             "No clusters are permitted to use identity if allowedNamespaces is nil",
 			clusterNamespace: "default",
@@ -201,7 +225,7 @@ func TestIsClusterPermittedToUsePrincipal(t *testing.T) {
 			if tc.setup != nil {
 				tc.setup(t, k8sClient)
 			}
-			result, err := isClusterPermittedToUsePrincipal(k8sClient, tc.allowedNs, tc.clusterNamespace)
+			result, err := isClusterPermittedToUsePrincipal(k8sClient, tc.allowedNs, tc.clusterNamespace, tc.enforce)
 			if tc.expectErr {
 				g.Expect(err).ToNot(BeNil())
 			} else {
@@ -477,6 +501,173 @@ func TestPrincipalParsing(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Fails for role Principals chained in a cycle",
+			awsCluster: infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster4",
+					Namespace: "default",
+				},
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       "AWSCluster",
+				},
+				Spec: infrav1.AWSClusterSpec{
+					IdentityRef: &infrav1.AWSIdentityReference{
+						Name: "deployment-role",
+						Kind: infrav1.ClusterRoleIdentityKind,
+					},
+				},
+			},
+			setup: func(t *testing.T, c client.Client) {
+				t.Helper()
+
+				for name, source := range map[string]string{"deployment-role": "org-role", "org-role": "deployment-role"} {
+					identity := &infrav1.AWSClusterRoleIdentity{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: name,
+						},
+						Spec: infrav1.AWSClusterRoleIdentitySpec{
+							AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{
+								AllowedNamespaces: &infrav1.AllowedNamespaces{},
+							},
+							AWSRoleSpec: infrav1.AWSRoleSpec{
+								RoleArn: name + "-arn",
+							},
+							SourceIdentityRef: &infrav1.AWSIdentityReference{
+								Name: source,
+								Kind: infrav1.ClusterRoleIdentityKind,
+							},
+						},
+					}
+					identity.SetGroupVersionKind(infrav1.GroupVersion.WithKind("AWSClusterRoleIdentity"))
+					if err := c.Create(context.Background(), identity); err != nil {
+						t.Fatal(err)
+					}
+				}
+			},
+			expectError: true,
+		},
+		{
+			name: "Can build a chain identity with a web identity source",
+			awsCluster: infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster6",
+					Namespace: "default",
+				},
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       "AWSCluster",
+				},
+				Spec: infrav1.AWSClusterSpec{
+					IdentityRef: &infrav1.AWSIdentityReference{
+						Name: "role-identity",
+						Kind: infrav1.ClusterRoleIdentityKind,
+					},
+				},
+			},
+			setup: func(t *testing.T, c client.Client) {
+				t.Helper()
+
+				webIdentity := &infrav1.AWSClusterWebIdentity{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "web-identity",
+					},
+					Spec: infrav1.AWSClusterWebIdentitySpec{
+						AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{
+							AllowedNamespaces: &infrav1.AllowedNamespaces{},
+						},
+						AWSRoleSpec: infrav1.AWSRoleSpec{
+							RoleArn: "web-identity-role-arn",
+						},
+						WebIdentity: &infrav1.WebIdentityTokenSource{
+							ServiceAccountName: "capa-web-identity",
+							Audience:           infrav1.DefaultWebIdentityAudience,
+						},
+					},
+				}
+				webIdentity.SetGroupVersionKind(infrav1.GroupVersion.WithKind("AWSClusterWebIdentity"))
+				if err := c.Create(context.Background(), webIdentity); err != nil {
+					t.Fatal(err)
+				}
+
+				roleIdentity := &infrav1.AWSClusterRoleIdentity{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "role-identity",
+					},
+					Spec: infrav1.AWSClusterRoleIdentitySpec{
+						AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{
+							AllowedNamespaces: &infrav1.AllowedNamespaces{},
+						},
+						AWSRoleSpec: infrav1.AWSRoleSpec{
+							RoleArn: "role-arn",
+						},
+						SourceIdentityRef: &infrav1.AWSIdentityReference{
+							Name: "web-identity",
+							Kind: infrav1.ClusterWebIdentityKind,
+						},
+					},
+				}
+				roleIdentity.SetGroupVersionKind(infrav1.GroupVersion.WithKind("AWSClusterRoleIdentity"))
+				if err := c.Create(context.Background(), roleIdentity); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expect: func(providers []identity.AWSPrincipalTypeProvider) {
+				if len(providers) != 1 {
+					t.Fatalf("Expected 1 provider, got %v", len(providers))
+				}
+				if _, ok := providers[0].(*identity.AWSRolePrincipalTypeProvider); !ok {
+					t.Fatal("Expected providers to be of type AWSRolePrincipalTypeProvider")
+				}
+			},
+		},
+		{
+			name: "Cannot use a web identity without the certificate secret",
+			awsCluster: infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster7",
+					Namespace: "default",
+				},
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       "AWSCluster",
+				},
+				Spec: infrav1.AWSClusterSpec{
+					IdentityRef: &infrav1.AWSIdentityReference{
+						Name: "roles-anywhere-identity",
+						Kind: infrav1.ClusterWebIdentityKind,
+					},
+				},
+			},
+			setup: func(t *testing.T, c client.Client) {
+				t.Helper()
+
+				webIdentity := &infrav1.AWSClusterWebIdentity{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "roles-anywhere-identity",
+					},
+					Spec: infrav1.AWSClusterWebIdentitySpec{
+						AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{
+							AllowedNamespaces: &infrav1.AllowedNamespaces{},
+						},
+						AWSRoleSpec: infrav1.AWSRoleSpec{
+							RoleArn: "role-arn",
+						},
+						RolesAnywhere: &infrav1.RolesAnywhereSource{
+							TrustAnchorARN:       "arn:aws:rolesanywhere:us-east-1:123456789012:trust-anchor/anchor",
+							ProfileARN:           "arn:aws:rolesanywhere:us-east-1:123456789012:profile/profile",
+							CertificateSecretRef: "missing-certificate",
+						},
+					},
+				}
+				webIdentity.SetGroupVersionKind(infrav1.GroupVersion.WithKind("AWSClusterWebIdentity"))
+				if err := c.Create(context.Background(), webIdentity); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -489,7 +680,7 @@ func TestPrincipalParsing(t *testing.T) {
 			k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
 			tc.setup(t, k8sClient)
 			clusterScope.AWSCluster = &tc.awsCluster
-			providers, err := getProvidersForCluster(context.Background(), k8sClient, clusterScope, logger.NewLogger(klog.Background()))
+			providers, err := getProvidersForCluster(context.Background(), k8sClient, clusterScope, false, logger.NewLogger(klog.Background()))
 			if tc.expectError {
 				if err == nil {
 					t.Fatal("Expected an error but didn't get one")
@@ -503,3 +694,174 @@ func TestPrincipalParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestPrincipalAllowListConditions(t *testing.T) {
+	testCases := []struct {
+		name          string
+		identityRef   *infrav1.AWSIdentityReference
+		identity      client.Object
+		expectReason  string
+		expectMessage string
+	}{
+		{
+			name:          "identityRef is required",
+			expectReason:  infrav1.PrincipalIdentityRefRequiredReason,
+			expectMessage: "identityRef is required",
+		},
+		{
+			name:          "identity must exist",
+			identityRef:   &infrav1.AWSIdentityReference{Kind: infrav1.ClusterRoleIdentityKind, Name: "missing"},
+			expectReason:  infrav1.PrincipalNotFoundReason,
+			expectMessage: "AWSClusterRoleIdentity missing referenced by the cluster does not exist",
+		},
+		{
+			name:        "identity allowing every namespace is not usable",
+			identityRef: &infrav1.AWSIdentityReference{Kind: infrav1.ControllerIdentityKind, Name: infrav1.AWSClusterControllerIdentityName},
+			identity: &infrav1.AWSClusterControllerIdentity{
+				ObjectMeta: metav1.ObjectMeta{Name: infrav1.AWSClusterControllerIdentityName},
+				Spec: infrav1.AWSClusterControllerIdentitySpec{
+					AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{AllowedNamespaces: &infrav1.AllowedNamespaces{}},
+				},
+			},
+			expectReason:  infrav1.PrincipalUsageUnauthorizedReason,
+			expectMessage: "the identity allows every namespace",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tc.identity != nil {
+				builder = builder.WithObjects(tc.identity)
+			}
+			k8sClient := builder.Build()
+
+			clusterScope, err := NewClusterScope(ClusterScopeParams{
+				Client: k8sClient,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			clusterScope.AWSCluster = &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec:       infrav1.AWSClusterSpec{IdentityRef: tc.identityRef},
+			}
+
+			_, err = getProvidersForCluster(context.Background(), k8sClient, clusterScope, true, logger.NewLogger(klog.Background()))
+			g.Expect(err).To(HaveOccurred())
+
+			condition := conditions.Get(clusterScope.AWSCluster, infrav1.PrincipalUsageAllowedCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			g.Expect(condition.Reason).To(Equal(tc.expectReason))
+			g.Expect(condition.Message).To(ContainSubstring(tc.expectMessage))
+		})
+	}
+}
+
+func TestWithClusterServiceEndpoints(t *testing.T) {
+	g := NewWithT(t)
+
+	controllerEndpoints := []ServiceEndpoint{
+		{ServiceID: "ec2", URL: "https://ec2.example.com", SigningRegion: "us-east-1"},
+		{ServiceID: "sts", URL: "https://sts.example.com", SigningRegion: "us-east-1"},
+	}
+
+	g.Expect(withClusterServiceEndpoints(controllerEndpoints, nil)).To(Equal(controllerEndpoints))
+	g.Expect(withClusterServiceEndpoints(controllerEndpoints, infrav1.AWSServiceEndpoints{
+		{ServiceID: "ec2", URL: "https://vpce.ec2.example.com"},
+		{ServiceID: "s3", URL: "https://s3.example.com", SigningRegion: "us-west-2"},
+	})).To(Equal([]ServiceEndpoint{
+		{ServiceID: "ec2", URL: "https://vpce.ec2.example.com"},
+		{ServiceID: "s3", URL: "https://s3.example.com", SigningRegion: "us-west-2"},
+		{ServiceID: "sts", URL: "https://sts.example.com", SigningRegion: "us-east-1"},
+	}))
+}
+
+func TestServiceLimitersPerPrincipal(t *testing.T) {
+	g := NewWithT(t)
+
+	roleIdentity := func(name, roleARN string) identity.AWSPrincipalTypeProvider {
+		return identity.NewAWSRolePrincipalTypeProvider(&infrav1.AWSClusterRoleIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       infrav1.AWSClusterRoleIdentitySpec{AWSRoleSpec: infrav1.AWSRoleSpec{RoleArn: roleARN}},
+		}, nil, "us-east-1", logger.NewLogger(klog.Background()))
+	}
+	tenantA := roleIdentity("tenant-a", "arn:aws:iam::111111111111:role/capa")
+	tenantAOtherRole := roleIdentity("tenant-a-admin", "arn:aws:iam::111111111111:role/admin")
+	tenantB := roleIdentity("tenant-b", "arn:aws:iam::222222222222:role/capa")
+
+	g.Expect(principalForProviders(nil)).To(Equal(controllerPrincipal))
+	g.Expect(principalForProviders([]identity.AWSPrincipalTypeProvider{tenantA})).To(Equal("account/111111111111"))
+	g.Expect(principalForProviders([]identity.AWSPrincipalTypeProvider{roleIdentity("invalid", "capa")})).To(Equal("identity/invalid"))
+	g.Expect(principalForProviders([]identity.AWSPrincipalTypeProvider{&identity.AWSStaticPrincipalTypeProvider{AccessKeyID: "AKIA1"}})).To(Equal("access-key/AKIA1"))
+
+	limiters := func(p identity.AWSPrincipalTypeProvider, region string) *throttle.ServiceLimiter {
+		return serviceLimitersFor(principalKeyFor(principalForProviders([]identity.AWSPrincipalTypeProvider{p}), region))[ec2.ServiceID]
+	}
+	// Identities of the same account share the limiters of the region, other accounts and regions don't.
+	g.Expect(limiters(tenantA, "us-east-1")).To(BeIdenticalTo(limiters(tenantAOtherRole, "us-east-1")))
+	g.Expect(limiters(tenantA, "us-east-1")).ToNot(BeIdenticalTo(limiters(tenantB, "us-east-1")))
+	g.Expect(limiters(tenantA, "us-east-1")).ToNot(BeIdenticalTo(limiters(tenantA, "us-west-2")))
+}
+
+func TestDescribeCachePerSession(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	newScope := func(region string, ttl time.Duration) *ClusterScope {
+		clusterScope, err := NewClusterScope(ClusterScopeParams{
+			Client: k8sClient,
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			},
+			AWSCluster:       &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: region}},
+			DescribeCacheTTL: ttl,
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		return clusterScope
+	}
+
+	g.Expect(newScope("describe-cache-disabled-1", 0).DescribeCache()).To(BeNil())
+
+	// The cache is created with the session and shared by the scopes reusing it.
+	cached := newScope("describe-cache-enabled-1", time.Minute)
+	g.Expect(cached.DescribeCache()).ToNot(BeNil())
+	g.Expect(newScope("describe-cache-enabled-1", time.Minute).DescribeCache()).To(BeIdenticalTo(cached.DescribeCache()))
+	g.Expect(newScope("describe-cache-enabled-2", time.Minute).DescribeCache()).ToNot(BeIdenticalTo(cached.DescribeCache()))
+}
+
+func TestAuditEventsVerbosityOf(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	newScope := func(verbosity int) *ClusterScope {
+		clusterScope, err := NewClusterScope(ClusterScopeParams{
+			Client: k8sClient,
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			},
+			AWSCluster:           &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "us-east-1"}},
+			AuditEventsVerbosity: verbosity,
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		return clusterScope
+	}
+
+	g.Expect(auditEventsVerbosityOf(newScope(auditevents.VerbosityNone))).To(Equal(auditevents.VerbosityNone))
+	g.Expect(auditEventsVerbosityOf(newScope(auditevents.VerbosityAll))).To(Equal(auditevents.VerbosityAll))
+	// The load balancer scopes use the verbosity of their cluster.
+	g.Expect(auditEventsVerbosityOf(&AWSLoadBalancerScope{InfraCluster: newScope(auditevents.VerbosityLifecycle)})).To(Equal(auditevents.VerbosityLifecycle))
+}
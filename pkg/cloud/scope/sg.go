@@ -40,9 +40,24 @@ type SGScope interface {
 	// CNIIngressRules returns the CNI spec ingress rules.
 	CNIIngressRules() infrav1.CNIIngressRules
 
+	// AdditionalControlPlaneIngressRules returns the additional ingress rules of the control plane security group.
+	AdditionalControlPlaneIngressRules() infrav1.IngressRules
+
+	// AdditionalNodeIngressRules returns the additional ingress rules of the node security group.
+	AdditionalNodeIngressRules() infrav1.IngressRules
+
+	// NodeEgressRules returns the egress rules of the node security group.
+	NodeEgressRules() infrav1.EgressRules
+
 	// Bastion returns the bastion details for the cluster.
 	Bastion() *infrav1.Bastion
 
 	// ControlPlaneLoadBalancer returns the load balancer settings that are requested.
 	ControlPlaneLoadBalancer() *infrav1.AWSLoadBalancerSpec
+
+	// ResourceNaming returns how the names of the security groups are generated.
+	ResourceNaming() *infrav1.ResourceNaming
+
+	// Konnectivity returns the konnectivity settings of the cluster, or nil if it doesn't run konnectivity.
+	Konnectivity() *infrav1.KonnectivitySpec
 }
@@ -48,7 +48,10 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 	}
 
 	if v.VPCZoneIdentifier != nil {
-		i.Subnets = strings.Split(*v.VPCZoneIdentifier, ",")
+		// ASGs created by older versions separate their subnets with ", ".
+		for _, subnet := range strings.Split(*v.VPCZoneIdentifier, ",") {
+			i.Subnets = append(i.Subnets, strings.TrimSpace(subnet))
+		}
 	}
 
 	if v.MixedInstancesPolicy != nil {
@@ -103,6 +106,14 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		i.CurrentlySuspendProcesses = currentlySuspendedProcesses
 	}
 
+	if len(v.EnabledMetrics) > 0 {
+		enabledMetrics := make([]string, len(v.EnabledMetrics))
+		for i, metric := range v.EnabledMetrics {
+			enabledMetrics[i] = aws.StringValue(metric.Metric)
+		}
+		i.EnabledMetrics = enabledMetrics
+	}
+
 	return i, nil
 }
 
@@ -221,7 +232,7 @@ func (s *Service) runPool(i *expinfrav1.AutoScalingGroup, launchTemplateID strin
 		AutoScalingGroupName: aws.String(i.Name),
 		MaxSize:              aws.Int64(int64(i.MaxSize)),
 		MinSize:              aws.Int64(int64(i.MinSize)),
-		VPCZoneIdentifier:    aws.String(strings.Join(i.Subnets, ", ")),
+		VPCZoneIdentifier:    aws.String(strings.Join(i.Subnets, ",")),
 		DefaultCooldown:      aws.Int64(int64(i.DefaultCoolDown.Duration.Seconds())),
 		CapacityRebalance:    aws.Bool(i.CapacityRebalance),
 	}
@@ -301,8 +312,8 @@ func (s *Service) UpdateASG(scope *scope.MachinePoolScope) error {
 		CapacityRebalance:    aws.Bool(scope.AWSMachinePool.Spec.CapacityRebalance),
 	}
 
-	if scope.MachinePool.Spec.Replicas != nil {
-		input.DesiredCapacity = aws.Int64(int64(*scope.MachinePool.Spec.Replicas))
+	if desiredCapacity := scope.DesiredCapacity(); desiredCapacity != nil {
+		input.DesiredCapacity = aws.Int64(int64(*desiredCapacity))
 	}
 
 	if scope.AWSMachinePool.Spec.MixedInstancesPolicy != nil {
@@ -489,6 +500,31 @@ func (s *Service) ResumeProcesses(name string, processes []string) error {
 	return nil
 }
 
+// EnableMetricsCollection enables the collection of the given group metrics of an ASG at the given granularity.
+func (s *Service) EnableMetricsCollection(name, granularity string, metrics []string) error {
+	input := autoscaling.EnableMetricsCollectionInput{
+		AutoScalingGroupName: aws.String(name),
+		Granularity:          aws.String(granularity),
+		Metrics:              aws.StringSlice(metrics),
+	}
+	if _, err := s.ASGClient.EnableMetricsCollection(&input); err != nil {
+		return errors.Wrapf(err, "failed to enable metrics collection for AutoScalingGroup: %q", name)
+	}
+	return nil
+}
+
+// DisableMetricsCollection disables the collection of the given group metrics of an ASG.
+func (s *Service) DisableMetricsCollection(name string, metrics []string) error {
+	input := autoscaling.DisableMetricsCollectionInput{
+		AutoScalingGroupName: aws.String(name),
+		Metrics:              aws.StringSlice(metrics),
+	}
+	if _, err := s.ASGClient.DisableMetricsCollection(&input); err != nil {
+		return errors.Wrapf(err, "failed to disable metrics collection for AutoScalingGroup: %q", name)
+	}
+	return nil
+}
+
 func mapToTags(input map[string]string, resourceID *string) []*autoscaling.Tag {
 	tags := make([]*autoscaling.Tag, 0)
 	for k, v := range input {
@@ -522,6 +558,32 @@ func (s *Service) SubnetIDs(scope *scope.MachinePoolScope) ([]string, error) {
 		}
 	}
 
+	if zones := failureDomainFilterZones(scope); len(zones) > 0 && (len(subnetIDs) > 0 || len(inputFilters) > 0) {
+		zoneFilter := &ec2.Filter{Name: aws.String("availability-zone"), Values: aws.StringSlice(zones)}
+		if len(inputFilters) > 0 {
+			inputFilters = append(inputFilters, zoneFilter)
+		}
+		if len(subnetIDs) > 0 {
+			out, err := s.EC2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+				SubnetIds: aws.StringSlice(subnetIDs),
+				Filters:   []*ec2.Filter{zoneFilter},
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			subnetIDs = subnetIDs[:0]
+			for _, subnet := range out.Subnets {
+				subnetIDs = append(subnetIDs, *subnet.SubnetId)
+			}
+			if len(subnetIDs) == 0 && len(inputFilters) == 0 {
+				errMessage := fmt.Sprintf("failed to create ASG %q, none of the subnets is in the availability zones %q", scope.Name(), zones)
+				record.Warnf(scope.AWSMachinePool, "FailedCreate", errMessage)
+				return subnetIDs, awserrors.NewFailedDependency(errMessage)
+			}
+		}
+	}
+
 	if len(inputFilters) > 0 {
 		out, err := s.EC2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{
 			Filters: inputFilters,
@@ -541,5 +603,22 @@ func (s *Service) SubnetIDs(scope *scope.MachinePoolScope) ([]string, error) {
 		}
 	}
 
-	return scope.SubnetIDs(subnetIDs)
+	subnetIDs, err := scope.SubnetIDs(subnetIDs)
+	if err != nil {
+		return subnetIDs, err
+	}
+
+	return s.withoutSuspendedAvailabilityZones(scope, subnetIDs)
+}
+
+// failureDomainFilterZones returns the availability zones the subnets of the AWSMachinePool are restricted to
+// when FilterSubnetsByFailureDomains is set: its AvailabilityZones or, if empty, the failure domains of the MachinePool.
+func failureDomainFilterZones(scope *scope.MachinePoolScope) []string {
+	if !scope.AWSMachinePool.Spec.FilterSubnetsByFailureDomains {
+		return nil
+	}
+	if len(scope.AWSMachinePool.Spec.AvailabilityZones) > 0 {
+		return scope.AWSMachinePool.Spec.AvailabilityZones
+	}
+	return scope.MachinePool.Spec.FailureDomains
 }
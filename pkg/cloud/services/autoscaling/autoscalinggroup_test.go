@@ -301,6 +301,22 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid input - subnets separated with spaces",
+			input: &autoscaling.Group{
+				DesiredCapacity:   aws.Int64(1234),
+				MaxSize:           aws.Int64(1234),
+				MinSize:           aws.Int64(1234),
+				VPCZoneIdentifier: aws.String("subnet-1, subnet-2,subnet-3"),
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+				Subnets:         []string{"subnet-1", "subnet-2", "subnet-3"},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -776,6 +792,96 @@ func TestServiceUpdateASGWithSubnetFilters(t *testing.T) {
 	}
 }
 
+func TestServiceSubnetIDsWithFailureDomainFilters(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name                 string
+		availabilityZones    []string
+		failureDomains       []string
+		awsResourceReference []infrav1.AWSResourceReference
+		want                 []string
+		wantErr              bool
+		expect               func(e *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name:              "subnet IDs are restricted to the availability zones of the pool",
+			availabilityZones: []string{"us-east-1a"},
+			failureDomains:    []string{"us-east-1b"},
+			awsResourceReference: []infrav1.AWSResourceReference{
+				{ID: aws.String("subnet-01")},
+				{ID: aws.String("subnet-02")},
+			},
+			want: []string{"subnet-01"},
+			expect: func(e *mocks.MockEC2APIMockRecorder) {
+				e.DescribeSubnets(&ec2.DescribeSubnetsInput{
+					SubnetIds: aws.StringSlice([]string{"subnet-01", "subnet-02"}),
+					Filters:   []*ec2.Filter{{Name: aws.String("availability-zone"), Values: aws.StringSlice([]string{"us-east-1a"})}},
+				}).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-01")}},
+				}, nil)
+			},
+		},
+		{
+			name:           "subnet filters are restricted to the failure domains of the machine pool",
+			failureDomains: []string{"us-east-1b"},
+			awsResourceReference: []infrav1.AWSResourceReference{
+				{Filters: []infrav1.Filter{{Name: "tag:subnet-role", Values: []string{"nodes"}}}},
+			},
+			want: []string{"subnet-03"},
+			expect: func(e *mocks.MockEC2APIMockRecorder) {
+				e.DescribeSubnets(&ec2.DescribeSubnetsInput{
+					Filters: []*ec2.Filter{
+						{Name: aws.String("tag:subnet-role"), Values: aws.StringSlice([]string{"nodes"})},
+						{Name: aws.String("availability-zone"), Values: aws.StringSlice([]string{"us-east-1b"})},
+					},
+				}).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-03")}},
+				}, nil)
+			},
+		},
+		{
+			name:              "an error is returned when no subnet is in the availability zones",
+			availabilityZones: []string{"us-east-1c"},
+			awsResourceReference: []infrav1.AWSResourceReference{
+				{ID: aws.String("subnet-01")},
+			},
+			wantErr: true,
+			expect: func(e *mocks.MockEC2APIMockRecorder) {
+				e.DescribeSubnets(gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).Return(&ec2.DescribeSubnetsOutput{}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tt.expect(ec2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Spec.Subnets = tt.awsResourceReference
+			mps.AWSMachinePool.Spec.AvailabilityZones = tt.availabilityZones
+			mps.AWSMachinePool.Spec.FilterSubnetsByFailureDomains = true
+			mps.MachinePool.Spec.FailureDomains = tt.failureDomains
+
+			got, err := s.SubnetIDs(mps)
+			checkErr(tt.wantErr, err, g)
+			if !tt.wantErr {
+				g.Expect(got).To(Equal(tt.want))
+			}
+		})
+	}
+}
+
 func TestServiceUpdateResourceTags(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1013,6 +1119,103 @@ func TestServiceDeleteASGAndWait(t *testing.T) {
 	}
 }
 
+func TestServiceEnableMetricsCollection(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:    "should enable the given metrics",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.EnableMetricsCollection(gomock.Eq(&autoscaling.EnableMetricsCollectionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					Granularity:          aws.String("1Minute"),
+					Metrics:              aws.StringSlice([]string{"GroupDesiredCapacity", "GroupInServiceInstances"}),
+				})).
+					Return(&autoscaling.EnableMetricsCollectionOutput{}, nil)
+			},
+		},
+		{
+			name:    "should return error if enabling metrics collection fails",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.EnableMetricsCollection(gomock.Any()).
+					Return(nil, awserrors.NewNotFound("not found"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.EnableMetricsCollection("asgName", "1Minute", []string{"GroupDesiredCapacity", "GroupInServiceInstances"})
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceDisableMetricsCollection(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:    "should disable the given metrics",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DisableMetricsCollection(gomock.Eq(&autoscaling.DisableMetricsCollectionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					Metrics:              aws.StringSlice([]string{"GroupMaxSize"}),
+				})).
+					Return(&autoscaling.DisableMetricsCollectionOutput{}, nil)
+			},
+		},
+		{
+			name:    "should return error if disabling metrics collection fails",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DisableMetricsCollection(gomock.Any()).
+					Return(nil, awserrors.NewNotFound("not found"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.DisableMetricsCollection("asgName", []string{"GroupMaxSize"})
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
 func TestServiceCanStartASGInstanceRefresh(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -0,0 +1,181 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// insufficientCapacityMessages are parts of the status messages of scaling activities that failed
+// because an availability zone lacked capacity for the instances of an ASG.
+var insufficientCapacityMessages = []string{
+	"InsufficientInstanceCapacity",
+	"do not have sufficient",
+	"no Spot capacity available",
+}
+
+// scalingActivityDetails is the JSON document in the details of a scaling activity.
+type scalingActivityDetails struct {
+	AvailabilityZone string `json:"Availability Zone"`
+}
+
+// ReconcileSuspendedAvailabilityZones updates the availability zones removed from the ASG of the machine
+// pool by its AvailabilityZoneFailurePolicy. Zones whose cool-down has expired are added back, and zones
+// in which instance launches failed for lack of capacity at least FailureThreshold times within the
+// cool-down period are removed.
+func (s *Service) ReconcileSuspendedAvailabilityZones(scope *scope.MachinePoolScope) error {
+	policy := scope.AWSMachinePool.Spec.AvailabilityZoneFailurePolicy
+	if policy == nil {
+		scope.AWSMachinePool.Status.SuspendedAvailabilityZones = nil
+		return nil
+	}
+
+	now := time.Now()
+	coolDown := policy.CoolDownOrDefault()
+
+	suspended := []expinfrav1.SuspendedAvailabilityZone{}
+	for _, zone := range scope.AWSMachinePool.Status.SuspendedAvailabilityZones {
+		if zone.Until.Time.After(now) {
+			suspended = append(suspended, zone)
+			continue
+		}
+		record.Eventf(scope.AWSMachinePool, "AvailabilityZoneResumed", "Adding availability zone %q back to ASG %q", zone.Name, scope.Name())
+	}
+
+	// Only failures within the cool-down period are counted, so that the failures which got a zone
+	// removed no longer count once it is added back.
+	failures, err := s.capacityFailuresByAvailabilityZone(scope.Name(), now.Add(-coolDown))
+	if err != nil {
+		return err
+	}
+
+	for zone, count := range failures {
+		if count < policy.FailureThresholdOrDefault() || isAvailabilityZoneSuspended(suspended, zone) {
+			continue
+		}
+		suspended = append(suspended, expinfrav1.SuspendedAvailabilityZone{
+			Name:  zone,
+			Until: metav1.NewTime(now.Add(coolDown)),
+		})
+		record.Warnf(scope.AWSMachinePool, "AvailabilityZoneSuspended", "Removing availability zone %q from ASG %q for %s after %d instance launches failed for lack of capacity", zone, scope.Name(), coolDown, count)
+	}
+
+	if len(suspended) == 0 {
+		scope.AWSMachinePool.Status.SuspendedAvailabilityZones = nil
+		return nil
+	}
+	sort.Slice(suspended, func(i, j int) bool { return suspended[i].Name < suspended[j].Name })
+	scope.AWSMachinePool.Status.SuspendedAvailabilityZones = suspended
+
+	return nil
+}
+
+// capacityFailuresByAvailabilityZone returns the number of scaling activities of the ASG started after since
+// that failed for lack of capacity, by availability zone.
+func (s *Service) capacityFailuresByAvailabilityZone(name string, since time.Time) (map[string]int, error) {
+	out, err := s.ASGClient.DescribeScalingActivities(&autoscaling.DescribeScalingActivitiesInput{
+		AutoScalingGroupName: aws.String(name),
+		MaxRecords:           aws.Int64(100),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe the scaling activities of ASG %q", name)
+	}
+
+	failures := map[string]int{}
+	for _, activity := range out.Activities {
+		if aws.StringValue(activity.StatusCode) != autoscaling.ScalingActivityStatusCodeFailed ||
+			activity.StartTime == nil || !activity.StartTime.After(since) ||
+			!isInsufficientCapacityMessage(aws.StringValue(activity.StatusMessage)) {
+			continue
+		}
+
+		details := scalingActivityDetails{}
+		if err := json.Unmarshal([]byte(aws.StringValue(activity.Details)), &details); err != nil || details.AvailabilityZone == "" {
+			continue
+		}
+		failures[details.AvailabilityZone]++
+	}
+
+	return failures, nil
+}
+
+// withoutSuspendedAvailabilityZones removes the subnets of the availability zones suspended from the ASG of
+// the machine pool. All subnets are kept if every one of them is in a suspended zone.
+func (s *Service) withoutSuspendedAvailabilityZones(scope *scope.MachinePoolScope, subnetIDs []string) ([]string, error) {
+	if scope.AWSMachinePool.Spec.AvailabilityZoneFailurePolicy == nil || len(scope.AWSMachinePool.Status.SuspendedAvailabilityZones) == 0 || len(subnetIDs) == 0 {
+		return subnetIDs, nil
+	}
+
+	out, err := s.EC2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice(subnetIDs),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe subnets %v", subnetIDs)
+	}
+
+	suspendedSubnets := map[string]struct{}{}
+	for _, subnet := range out.Subnets {
+		if isAvailabilityZoneSuspended(scope.AWSMachinePool.Status.SuspendedAvailabilityZones, aws.StringValue(subnet.AvailabilityZone)) {
+			suspendedSubnets[aws.StringValue(subnet.SubnetId)] = struct{}{}
+		}
+	}
+
+	available := make([]string, 0, len(subnetIDs))
+	for _, id := range subnetIDs {
+		if _, ok := suspendedSubnets[id]; !ok {
+			available = append(available, id)
+		}
+	}
+
+	if len(available) == 0 {
+		scope.Debug("All availability zones of the ASG are suspended, keeping all subnets", "subnets", subnetIDs)
+		return subnetIDs, nil
+	}
+
+	return available, nil
+}
+
+func isAvailabilityZoneSuspended(suspended []expinfrav1.SuspendedAvailabilityZone, zone string) bool {
+	for _, z := range suspended {
+		if z.Name == zone {
+			return true
+		}
+	}
+	return false
+}
+
+func isInsufficientCapacityMessage(message string) bool {
+	for _, m := range insufficientCapacityMessages {
+		if strings.Contains(message, m) {
+			return true
+		}
+	}
+	return false
+}
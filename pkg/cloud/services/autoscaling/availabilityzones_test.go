@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func capacityFailure(zone string, startTime time.Time) *autoscaling.Activity {
+	return &autoscaling.Activity{
+		StatusCode:    aws.String(autoscaling.ScalingActivityStatusCodeFailed),
+		StatusMessage: aws.String("We currently do not have sufficient m5.large capacity in the Availability Zone you requested (" + zone + "). Launching EC2 instance failed."),
+		Details:       aws.String(`{"Subnet ID":"subnet-1","Availability Zone":"` + zone + `"}`),
+		StartTime:     aws.Time(startTime),
+	}
+}
+
+func TestServiceReconcileSuspendedAvailabilityZones(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	now := time.Now()
+
+	tests := []struct {
+		name              string
+		policy            *expinfrav1.AvailabilityZoneFailurePolicy
+		suspended         []expinfrav1.SuspendedAvailabilityZone
+		activities        []*autoscaling.Activity
+		wantSuspendedZone []string
+	}{
+		{
+			name:      "should clear suspended zones without a policy",
+			suspended: []expinfrav1.SuspendedAvailabilityZone{{Name: "us-east-1a", Until: metav1.NewTime(now.Add(time.Minute))}},
+		},
+		{
+			name:   "should suspend zones reaching the failure threshold",
+			policy: &expinfrav1.AvailabilityZoneFailurePolicy{FailureThreshold: aws.Int32(2)},
+			activities: []*autoscaling.Activity{
+				capacityFailure("us-east-1a", now.Add(-time.Minute)),
+				capacityFailure("us-east-1a", now.Add(-2*time.Minute)),
+				capacityFailure("us-east-1b", now.Add(-2*time.Minute)),
+			},
+			wantSuspendedZone: []string{"us-east-1a"},
+		},
+		{
+			name:   "should ignore failures before the cool-down period and other failures",
+			policy: &expinfrav1.AvailabilityZoneFailurePolicy{FailureThreshold: aws.Int32(2)},
+			activities: []*autoscaling.Activity{
+				capacityFailure("us-east-1a", now.Add(-time.Minute)),
+				capacityFailure("us-east-1a", now.Add(-time.Hour)),
+				{
+					StatusCode:    aws.String(autoscaling.ScalingActivityStatusCodeFailed),
+					StatusMessage: aws.String("The requested configuration is currently not supported."),
+					Details:       aws.String(`{"Subnet ID":"subnet-1","Availability Zone":"us-east-1a"}`),
+					StartTime:     aws.Time(now.Add(-time.Minute)),
+				},
+			},
+		},
+		{
+			name:   "should add back zones whose cool-down expired",
+			policy: &expinfrav1.AvailabilityZoneFailurePolicy{},
+			suspended: []expinfrav1.SuspendedAvailabilityZone{
+				{Name: "us-east-1a", Until: metav1.NewTime(now.Add(-time.Second))},
+				{Name: "us-east-1b", Until: metav1.NewTime(now.Add(time.Minute))},
+			},
+			wantSuspendedZone: []string{"us-east-1b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			if tt.policy != nil {
+				asgMock.EXPECT().DescribeScalingActivities(gomock.Eq(&autoscaling.DescribeScalingActivitiesInput{
+					AutoScalingGroupName: aws.String("pool"),
+					MaxRecords:           aws.Int64(100),
+				})).Return(&autoscaling.DescribeScalingActivitiesOutput{Activities: tt.activities}, nil)
+			}
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "pool"
+			mps.AWSMachinePool.Spec.AvailabilityZoneFailurePolicy = tt.policy
+			mps.AWSMachinePool.Status.SuspendedAvailabilityZones = tt.suspended
+
+			g.Expect(s.ReconcileSuspendedAvailabilityZones(mps)).To(Succeed())
+
+			var zones []string
+			for _, zone := range mps.AWSMachinePool.Status.SuspendedAvailabilityZones {
+				zones = append(zones, zone.Name)
+			}
+			g.Expect(zones).To(Equal(tt.wantSuspendedZone))
+		})
+	}
+}
+
+func TestServiceSubnetIDsWithSuspendedAvailabilityZones(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name        string
+		suspended   []string
+		wantSubnets []string
+	}{
+		{
+			name:        "should remove the subnets of suspended zones",
+			suspended:   []string{"us-east-1a"},
+			wantSubnets: []string{"subnet-b"},
+		},
+		{
+			name:        "should keep all subnets when all zones are suspended",
+			suspended:   []string{"us-east-1a", "us-east-1b"},
+			wantSubnets: []string{"subnet-a", "subnet-b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeSubnets(gomock.Eq(&ec2.DescribeSubnetsInput{
+				SubnetIds: aws.StringSlice([]string{"subnet-a", "subnet-b"}),
+			})).Return(&ec2.DescribeSubnetsOutput{
+				Subnets: []*ec2.Subnet{
+					{SubnetId: aws.String("subnet-a"), AvailabilityZone: aws.String("us-east-1a")},
+					{SubnetId: aws.String("subnet-b"), AvailabilityZone: aws.String("us-east-1b")},
+				},
+			}, nil)
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Spec.Subnets = []infrav1.AWSResourceReference{{ID: aws.String("subnet-a")}, {ID: aws.String("subnet-b")}}
+			mps.AWSMachinePool.Spec.AvailabilityZoneFailurePolicy = &expinfrav1.AvailabilityZoneFailurePolicy{}
+			for _, zone := range tt.suspended {
+				mps.AWSMachinePool.Status.SuspendedAvailabilityZones = append(mps.AWSMachinePool.Status.SuspendedAvailabilityZones,
+					expinfrav1.SuspendedAvailabilityZone{Name: zone, Until: metav1.NewTime(time.Now().Add(time.Minute))})
+			}
+
+			subnetIDs, err := s.SubnetIDs(mps)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(subnetIDs).To(Equal(tt.wantSubnets))
+		})
+	}
+}
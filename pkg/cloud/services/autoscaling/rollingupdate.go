@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// launchTemplateVersionTag is the tag EC2 sets on the instances launched from a launch template,
+// with the version of the launch template they were launched from.
+const launchTemplateVersionTag = "aws:ec2launchtemplate:version"
+
+// OutdatedInstances returns the instances of the ASG of the machine pool that weren't launched from the
+// latest version of its launch template. Instances without a launch template version are considered up
+// to date, so that they aren't replaced over and over.
+func (s *Service) OutdatedInstances(scope *scope.MachinePoolScope, asg *expinfrav1.AutoScalingGroup) ([]infrav1.Instance, error) {
+	latestVersion := scope.GetLaunchTemplateLatestVersionStatus()
+	if latestVersion == "" || len(asg.Instances) == 0 {
+		return nil, nil
+	}
+
+	instanceIDs := make([]*string, len(asg.Instances))
+	for i, instance := range asg.Instances {
+		instanceIDs[i] = aws.String(instance.ID)
+	}
+
+	versions := map[string]string{}
+	err := s.EC2Client.DescribeInstancesPages(&ec2.DescribeInstancesInput{InstanceIds: instanceIDs}, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				for _, tag := range instance.Tags {
+					if aws.StringValue(tag.Key) == launchTemplateVersionTag {
+						versions[aws.StringValue(instance.InstanceId)] = aws.StringValue(tag.Value)
+					}
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instances of ASG %q", asg.Name)
+	}
+
+	var outdated []infrav1.Instance
+	for _, instance := range asg.Instances {
+		if version, ok := versions[instance.ID]; ok && version != latestVersion {
+			outdated = append(outdated, instance)
+		}
+	}
+
+	return outdated, nil
+}
+
+// TerminateASGInstance terminates an instance of the ASG of the machine pool. Unless the desired capacity of
+// the ASG is decremented with it, the ASG launches a replacement for the instance.
+func (s *Service) TerminateASGInstance(scope *scope.MachinePoolScope, instanceID string, decrementDesiredCapacity bool) error {
+	input := &autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String(instanceID),
+		ShouldDecrementDesiredCapacity: aws.Bool(decrementDesiredCapacity),
+	}
+
+	if _, err := s.ASGClient.TerminateInstanceInAutoScalingGroup(input); err != nil {
+		record.Warnf(scope.AWSMachinePool, "FailedTerminateInstance", "Failed to terminate instance %q of ASG %q: %v", instanceID, scope.Name(), err)
+		return errors.Wrapf(err, "failed to terminate instance %q", instanceID)
+	}
+
+	record.Eventf(scope.AWSMachinePool, "SuccessfulTerminateInstance", "Terminated instance %q of ASG %q", instanceID, scope.Name())
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestServiceOutdatedInstances(t *testing.T) {
+	instanceWithVersion := func(id, version string) *ec2.Instance {
+		instance := &ec2.Instance{InstanceId: aws.String(id)}
+		if version != "" {
+			instance.Tags = []*ec2.Tag{{Key: aws.String(launchTemplateVersionTag), Value: aws.String(version)}}
+		}
+		return instance
+	}
+
+	tests := []struct {
+		name          string
+		latestVersion *string
+		instances     []infrav1.Instance
+		expect        func(e *mocks.MockEC2APIMockRecorder)
+		want          []infrav1.Instance
+		wantErr       bool
+	}{
+		{
+			name:      "should not describe instances without a launch template version",
+			instances: []infrav1.Instance{{ID: "i-1"}},
+			expect:    func(e *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:          "should return the instances launched from another version",
+			latestVersion: aws.String("2"),
+			instances:     []infrav1.Instance{{ID: "i-1"}, {ID: "i-2"}, {ID: "i-3"}},
+			expect: func(e *mocks.MockEC2APIMockRecorder) {
+				e.DescribeInstancesPages(gomock.Eq(&ec2.DescribeInstancesInput{
+					InstanceIds: aws.StringSlice([]string{"i-1", "i-2", "i-3"}),
+				}), gomock.Any()).DoAndReturn(func(_ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+					fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{
+						Instances: []*ec2.Instance{instanceWithVersion("i-1", "1"), instanceWithVersion("i-2", "2"), instanceWithVersion("i-3", "")},
+					}}}, true)
+					return nil
+				})
+			},
+			want: []infrav1.Instance{{ID: "i-1"}},
+		},
+		{
+			name:          "should return an error if the instances can't be described",
+			latestVersion: aws.String("2"),
+			instances:     []infrav1.Instance{{ID: "i-1"}},
+			expect: func(e *mocks.MockEC2APIMockRecorder) {
+				e.DescribeInstancesPages(gomock.Any(), gomock.Any()).Return(awserrors.NewFailedDependency("dependency failure"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tt.expect(ec2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Status.LaunchTemplateVersion = tt.latestVersion
+
+			outdated, err := s.OutdatedInstances(mps, &expinfrav1.AutoScalingGroup{Instances: tt.instances})
+			checkErr(tt.wantErr, err, g)
+			g.Expect(outdated).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestServiceTerminateASGInstance(t *testing.T) {
+	tests := []struct {
+		name                     string
+		decrementDesiredCapacity bool
+		err                      error
+		wantErr                  bool
+	}{
+		{
+			name: "should terminate the instance and let the ASG replace it",
+		},
+		{
+			name:                     "should terminate the instance and decrement the desired capacity",
+			decrementDesiredCapacity: true,
+		},
+		{
+			name:    "should return an error if the instance can't be terminated",
+			err:     awserrors.NewFailedDependency("dependency failure"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			asgMock.EXPECT().TerminateInstanceInAutoScalingGroup(gomock.Eq(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+				InstanceId:                     aws.String("i-1"),
+				ShouldDecrementDesiredCapacity: aws.Bool(tt.decrementDesiredCapacity),
+			})).Return(&autoscaling.TerminateInstanceInAutoScalingGroupOutput{}, tt.err)
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			checkErr(tt.wantErr, s.TerminateASGInstance(mps, "i-1", tt.decrementDesiredCapacity), g)
+		})
+	}
+}
@@ -0,0 +1,413 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+const (
+	targetTrackingScalingPolicyType = "TargetTrackingScaling"
+	stepScalingPolicyType           = "StepScaling"
+
+	// asgNameDimension is the dimension of the metrics of an ASG identifying it.
+	asgNameDimension = "AutoScalingGroupName"
+)
+
+// ReconcileScalingPolicies creates or updates the scaling policies of the ASG of the machine pool, and the
+// CloudWatch alarms of its step scaling policies, and records them in its status as soon as they are created,
+// so a policy is known to be created for the pool even if reconciling another one fails. Policies created for
+// the pool which are no longer part of it are deleted, along with their alarms.
+func (s *Service) ReconcileScalingPolicies(scope *scope.MachinePoolScope) error {
+	asgName := scope.Name()
+	existing, err := s.describeScalingPolicies(asgName)
+	if err != nil {
+		return err
+	}
+
+	previous := scope.AWSMachinePool.Status.ScalingPolicies
+	desired := sets.NewString()
+	statuses := make([]expinfrav1.ScalingPolicyStatus, 0, len(scope.AWSMachinePool.Spec.ScalingPolicies))
+	var alarms []*cloudwatch.PutMetricAlarmInput
+	for _, policy := range scope.AWSMachinePool.Spec.ScalingPolicies {
+		desired.Insert(policy.Name)
+		input := scalingPolicyInput(asgName, policy)
+
+		status := expinfrav1.ScalingPolicyStatus{Name: policy.Name}
+		current, ok := existing[policy.Name]
+		if ok && !scalingPolicyNeedsUpdate(current, input) {
+			status.ARN = aws.StringValue(current.PolicyARN)
+			for _, alarm := range current.Alarms {
+				status.AlarmARNs = append(status.AlarmARNs, aws.StringValue(alarm.AlarmARN))
+			}
+		} else {
+			out, err := s.ASGClient.PutScalingPolicy(input)
+			if err != nil {
+				record.Warnf(scope.AWSMachinePool, "FailedPutScalingPolicy", "Failed to create or update scaling policy %q of ASG %q: %v", policy.Name, asgName, err)
+				return errors.Wrapf(err, "failed to create or update scaling policy %q of ASG %q", policy.Name, asgName)
+			}
+			record.Eventf(scope.AWSMachinePool, "SuccessfulPutScalingPolicy", "Created or updated scaling policy %q of ASG %q", policy.Name, asgName)
+			status.ARN = aws.StringValue(out.PolicyARN)
+			for _, alarm := range out.Alarms {
+				status.AlarmARNs = append(status.AlarmARNs, aws.StringValue(alarm.AlarmARN))
+			}
+			setScalingPolicyStatus(scope.AWSMachinePool, status)
+		}
+
+		if policy.StepScaling != nil {
+			alarm := scalingAlarmInput(asgName, policy, status.ARN, scope.AdditionalTags())
+			alarmARN, err := alarmARN(status.ARN, aws.StringValue(alarm.AlarmName))
+			if err != nil {
+				return err
+			}
+			status.AlarmARNs = []string{alarmARN}
+			alarms = append(alarms, alarm)
+		}
+		setScalingPolicyStatus(scope.AWSMachinePool, status)
+		statuses = append(statuses, status)
+	}
+
+	if err := s.reconcileScalingAlarms(scope, alarms); err != nil {
+		return err
+	}
+
+	// Only the policies created for the pool are deleted, not the ones created outside of CAPA.
+	var staleAlarms []string
+	for _, status := range previous {
+		if policy := findScalingPolicy(scope.AWSMachinePool.Spec.ScalingPolicies, status.Name); policy == nil || policy.StepScaling == nil {
+			staleAlarms = append(staleAlarms, scalingAlarmName(asgName, status.Name))
+		}
+		if desired.Has(status.Name) {
+			continue
+		}
+		if _, ok := existing[status.Name]; !ok {
+			continue
+		}
+		if _, err := s.ASGClient.DeletePolicy(&autoscaling.DeletePolicyInput{
+			AutoScalingGroupName: aws.String(asgName),
+			PolicyName:           aws.String(status.Name),
+		}); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedDeleteScalingPolicy", "Failed to delete scaling policy %q of ASG %q: %v", status.Name, asgName, err)
+			return errors.Wrapf(err, "failed to delete scaling policy %q of ASG %q", status.Name, asgName)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulDeleteScalingPolicy", "Deleted scaling policy %q of ASG %q", status.Name, asgName)
+	}
+	if err := s.deleteScalingAlarms(scope, staleAlarms); err != nil {
+		return err
+	}
+
+	// The policies no longer part of the pool are only dropped from the status once they and their alarms are deleted.
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	scope.AWSMachinePool.Status.ScalingPolicies = statuses
+	return nil
+}
+
+// DeleteScalingPolicyAlarms deletes the CloudWatch alarms of the step scaling policies of the machine pool.
+// The scaling policies themselves are deleted along with the ASG.
+func (s *Service) DeleteScalingPolicyAlarms(scope *scope.MachinePoolScope) error {
+	names := make([]string, 0, len(scope.AWSMachinePool.Status.ScalingPolicies))
+	for _, status := range scope.AWSMachinePool.Status.ScalingPolicies {
+		names = append(names, scalingAlarmName(scope.Name(), status.Name))
+	}
+	if err := s.deleteScalingAlarms(scope, names); err != nil {
+		return err
+	}
+	scope.AWSMachinePool.Status.ScalingPolicies = nil
+	return nil
+}
+
+// describeScalingPolicies returns the scaling policies of an ASG, by name.
+func (s *Service) describeScalingPolicies(asgName string) (map[string]*autoscaling.ScalingPolicy, error) {
+	policies := map[string]*autoscaling.ScalingPolicy{}
+	err := s.ASGClient.DescribePoliciesPages(&autoscaling.DescribePoliciesInput{
+		AutoScalingGroupName: aws.String(asgName),
+	}, func(page *autoscaling.DescribePoliciesOutput, lastPage bool) bool {
+		for _, policy := range page.ScalingPolicies {
+			policies[aws.StringValue(policy.PolicyName)] = policy
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe the scaling policies of ASG %q", asgName)
+	}
+	return policies, nil
+}
+
+// reconcileScalingAlarms creates or updates the alarms of the step scaling policies.
+func (s *Service) reconcileScalingAlarms(scope *scope.MachinePoolScope, alarms []*cloudwatch.PutMetricAlarmInput) error {
+	if len(alarms) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(alarms))
+	for _, alarm := range alarms {
+		names = append(names, aws.StringValue(alarm.AlarmName))
+	}
+	existing, err := s.describeScalingAlarms(names)
+	if err != nil {
+		return err
+	}
+
+	for _, alarm := range alarms {
+		name := aws.StringValue(alarm.AlarmName)
+		if current, ok := existing[name]; ok && !scalingAlarmNeedsUpdate(current, alarm) {
+			continue
+		}
+		if _, err := s.CloudWatchClient.PutMetricAlarm(alarm); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedPutAlarm", "Failed to create or update CloudWatch alarm %q: %v", name, err)
+			return errors.Wrapf(err, "failed to create or update CloudWatch alarm %q", name)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulPutAlarm", "Created or updated CloudWatch alarm %q", name)
+	}
+	return nil
+}
+
+// deleteScalingAlarms deletes the named alarms which exist, as no alarm is deleted if any of them doesn't.
+func (s *Service) deleteScalingAlarms(scope *scope.MachinePoolScope, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	existing, err := s.describeScalingAlarms(names)
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+
+	toDelete := make([]string, 0, len(existing))
+	for name := range existing {
+		toDelete = append(toDelete, name)
+	}
+	sort.Strings(toDelete)
+	if _, err := s.CloudWatchClient.DeleteAlarms(&cloudwatch.DeleteAlarmsInput{AlarmNames: aws.StringSlice(toDelete)}); err != nil {
+		record.Warnf(scope.AWSMachinePool, "FailedDeleteAlarms", "Failed to delete CloudWatch alarms %v: %v", toDelete, err)
+		return errors.Wrapf(err, "failed to delete CloudWatch alarms %v", toDelete)
+	}
+	record.Eventf(scope.AWSMachinePool, "SuccessfulDeleteAlarms", "Deleted CloudWatch alarms %v", toDelete)
+	return nil
+}
+
+// describeScalingAlarms returns the existing metric alarms among the named ones, by name.
+// A machine pool has at most 20 scaling policies, so its alarms are described at once.
+func (s *Service) describeScalingAlarms(names []string) (map[string]*cloudwatch.MetricAlarm, error) {
+	out, err := s.CloudWatchClient.DescribeAlarms(&cloudwatch.DescribeAlarmsInput{
+		AlarmNames: aws.StringSlice(names),
+		AlarmTypes: aws.StringSlice([]string{cloudwatch.AlarmTypeMetricAlarm}),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe CloudWatch alarms")
+	}
+	alarms := map[string]*cloudwatch.MetricAlarm{}
+	for _, alarm := range out.MetricAlarms {
+		alarms[aws.StringValue(alarm.AlarmName)] = alarm
+	}
+	return alarms, nil
+}
+
+// scalingPolicyInput returns the input creating or updating a scaling policy of an ASG.
+func scalingPolicyInput(asgName string, policy expinfrav1.ScalingPolicy) *autoscaling.PutScalingPolicyInput {
+	input := &autoscaling.PutScalingPolicyInput{
+		AutoScalingGroupName: aws.String(asgName),
+		PolicyName:           aws.String(policy.Name),
+	}
+
+	if tt := policy.TargetTracking; tt != nil {
+		input.PolicyType = aws.String(targetTrackingScalingPolicyType)
+		input.EstimatedInstanceWarmup = tt.EstimatedInstanceWarmup
+		metric := &autoscaling.PredefinedMetricSpecification{PredefinedMetricType: aws.String(string(tt.PredefinedMetric))}
+		if tt.ResourceLabel != "" {
+			metric.ResourceLabel = aws.String(tt.ResourceLabel)
+		}
+		input.TargetTrackingConfiguration = &autoscaling.TargetTrackingConfiguration{
+			PredefinedMetricSpecification: metric,
+			TargetValue:                   aws.Float64(float64(tt.TargetValue)),
+			DisableScaleIn:                aws.Bool(tt.DisableScaleIn),
+		}
+		return input
+	}
+
+	ss := policy.StepScaling
+	input.PolicyType = aws.String(stepScalingPolicyType)
+	input.AdjustmentType = aws.String(string(ss.AdjustmentType))
+	input.EstimatedInstanceWarmup = ss.EstimatedInstanceWarmup
+	for _, step := range ss.StepAdjustments {
+		adjustment := &autoscaling.StepAdjustment{ScalingAdjustment: aws.Int64(step.ScalingAdjustment)}
+		if step.MetricIntervalLowerBound != nil {
+			adjustment.MetricIntervalLowerBound = aws.Float64(float64(*step.MetricIntervalLowerBound))
+		}
+		if step.MetricIntervalUpperBound != nil {
+			adjustment.MetricIntervalUpperBound = aws.Float64(float64(*step.MetricIntervalUpperBound))
+		}
+		input.StepAdjustments = append(input.StepAdjustments, adjustment)
+	}
+	return input
+}
+
+// scalingAlarmInput returns the input creating or updating the alarm of a step scaling policy, on the metric
+// of the instances of its ASG.
+func scalingAlarmInput(asgName string, policy expinfrav1.ScalingPolicy, policyARN string, additionalTags infrav1.Tags) *cloudwatch.PutMetricAlarmInput {
+	alarm := policy.StepScaling.Alarm
+	namespace := alarm.Namespace
+	if namespace == "" {
+		namespace = "AWS/EC2"
+	}
+	statistic := alarm.Statistic
+	if statistic == "" {
+		statistic = cloudwatch.StatisticAverage
+	}
+	period := alarm.Period
+	if period == 0 {
+		period = 60
+	}
+	evaluationPeriods := alarm.EvaluationPeriods
+	if evaluationPeriods == 0 {
+		evaluationPeriods = 1
+	}
+
+	name := scalingAlarmName(asgName, policy.Name)
+	input := &cloudwatch.PutMetricAlarmInput{
+		AlarmName:          aws.String(name),
+		AlarmDescription:   aws.String(fmt.Sprintf("Triggers the step scaling policy %s of ASG %s", policy.Name, asgName)),
+		Namespace:          aws.String(namespace),
+		MetricName:         aws.String(alarm.MetricName),
+		Dimensions:         []*cloudwatch.Dimension{{Name: aws.String(asgNameDimension), Value: aws.String(asgName)}},
+		Statistic:          aws.String(statistic),
+		Period:             aws.Int64(period),
+		EvaluationPeriods:  aws.Int64(evaluationPeriods),
+		Threshold:          aws.Float64(float64(alarm.Threshold)),
+		ComparisonOperator: aws.String(alarm.ComparisonOperator),
+		ActionsEnabled:     aws.Bool(true),
+		AlarmActions:       aws.StringSlice([]string{policyARN}),
+	}
+
+	tags := infrav1.Tags{"Name": name}
+	tags.Merge(additionalTags)
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		input.Tags = append(input.Tags, &cloudwatch.Tag{Key: aws.String(key), Value: aws.String(tags[key])})
+	}
+	return input
+}
+
+// scalingAlarmName returns the name of the alarm of a step scaling policy of an ASG.
+func scalingAlarmName(asgName, policyName string) string {
+	return fmt.Sprintf("%s-%s", asgName, policyName)
+}
+
+// alarmARN returns the ARN of a CloudWatch alarm in the account and region of a scaling policy.
+func alarmARN(policyARN, alarmName string) (string, error) {
+	parsed, err := arn.Parse(policyARN)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse the ARN of scaling policy %q", policyARN)
+	}
+	return arn.ARN{
+		Partition: parsed.Partition,
+		Service:   "cloudwatch",
+		Region:    parsed.Region,
+		AccountID: parsed.AccountID,
+		Resource:  "alarm:" + alarmName,
+	}.String(), nil
+}
+
+// setScalingPolicyStatus adds the status of a scaling policy to the status of the machine pool, or replaces it.
+func setScalingPolicyStatus(awsMachinePool *expinfrav1.AWSMachinePool, status expinfrav1.ScalingPolicyStatus) {
+	for i := range awsMachinePool.Status.ScalingPolicies {
+		if awsMachinePool.Status.ScalingPolicies[i].Name == status.Name {
+			awsMachinePool.Status.ScalingPolicies[i] = status
+			return
+		}
+	}
+	awsMachinePool.Status.ScalingPolicies = append(awsMachinePool.Status.ScalingPolicies, status)
+}
+
+func findScalingPolicy(policies []expinfrav1.ScalingPolicy, name string) *expinfrav1.ScalingPolicy {
+	for i := range policies {
+		if policies[i].Name == name {
+			return &policies[i]
+		}
+	}
+	return nil
+}
+
+// scalingPolicyNeedsUpdate returns true if the configuration of the existing scaling policy differs from the desired one.
+func scalingPolicyNeedsUpdate(existing *autoscaling.ScalingPolicy, desired *autoscaling.PutScalingPolicyInput) bool {
+	if aws.StringValue(existing.PolicyType) != aws.StringValue(desired.PolicyType) ||
+		aws.StringValue(existing.AdjustmentType) != aws.StringValue(desired.AdjustmentType) ||
+		aws.Int64Value(existing.EstimatedInstanceWarmup) != aws.Int64Value(desired.EstimatedInstanceWarmup) ||
+		!sets.NewString(stepAdjustmentStrings(existing.StepAdjustments)...).Equal(sets.NewString(stepAdjustmentStrings(desired.StepAdjustments)...)) {
+		return true
+	}
+
+	current, wanted := existing.TargetTrackingConfiguration, desired.TargetTrackingConfiguration
+	if current == nil || wanted == nil {
+		return current != wanted
+	}
+	if current.PredefinedMetricSpecification == nil {
+		return true
+	}
+	return aws.StringValue(current.PredefinedMetricSpecification.PredefinedMetricType) != aws.StringValue(wanted.PredefinedMetricSpecification.PredefinedMetricType) ||
+		aws.StringValue(current.PredefinedMetricSpecification.ResourceLabel) != aws.StringValue(wanted.PredefinedMetricSpecification.ResourceLabel) ||
+		aws.Float64Value(current.TargetValue) != aws.Float64Value(wanted.TargetValue) ||
+		aws.BoolValue(current.DisableScaleIn) != aws.BoolValue(wanted.DisableScaleIn)
+}
+
+func stepAdjustmentStrings(steps []*autoscaling.StepAdjustment) []string {
+	res := make([]string, 0, len(steps))
+	for _, step := range steps {
+		bound := func(b *float64) string {
+			if b == nil {
+				return "*"
+			}
+			return fmt.Sprint(*b)
+		}
+		res = append(res, fmt.Sprintf("[%s,%s]%d", bound(step.MetricIntervalLowerBound), bound(step.MetricIntervalUpperBound), aws.Int64Value(step.ScalingAdjustment)))
+	}
+	return res
+}
+
+// scalingAlarmNeedsUpdate returns true if the configuration of the existing alarm differs from the desired one.
+// Tags are only set when alarms are created.
+func scalingAlarmNeedsUpdate(existing *cloudwatch.MetricAlarm, desired *cloudwatch.PutMetricAlarmInput) bool {
+	return aws.StringValue(existing.Namespace) != aws.StringValue(desired.Namespace) ||
+		aws.StringValue(existing.MetricName) != aws.StringValue(desired.MetricName) ||
+		aws.StringValue(existing.Statistic) != aws.StringValue(desired.Statistic) ||
+		aws.Int64Value(existing.Period) != aws.Int64Value(desired.Period) ||
+		aws.Int64Value(existing.EvaluationPeriods) != aws.Int64Value(desired.EvaluationPeriods) ||
+		aws.Float64Value(existing.Threshold) != aws.Float64Value(desired.Threshold) ||
+		aws.StringValue(existing.ComparisonOperator) != aws.StringValue(desired.ComparisonOperator) ||
+		aws.BoolValue(existing.ActionsEnabled) != aws.BoolValue(desired.ActionsEnabled) ||
+		!sets.NewString(aws.StringValueSlice(existing.AlarmActions)...).Equal(sets.NewString(aws.StringValueSlice(desired.AlarmActions)...))
+}
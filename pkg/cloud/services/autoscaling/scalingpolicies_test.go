@@ -0,0 +1,226 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+const testPolicyARN = "arn:aws:autoscaling:us-east-1:123456789012:scalingPolicy:uuid:autoScalingGroupName/pool:policyName/"
+
+func targetTrackingPolicy() expinfrav1.ScalingPolicy {
+	return expinfrav1.ScalingPolicy{
+		Name: "cpu",
+		TargetTracking: &expinfrav1.TargetTrackingScalingPolicy{
+			PredefinedMetric: expinfrav1.PredefinedMetricTypeASGAverageCPUUtilization,
+			TargetValue:      50,
+		},
+	}
+}
+
+func stepScalingPolicy() expinfrav1.ScalingPolicy {
+	return expinfrav1.ScalingPolicy{
+		Name: "step",
+		StepScaling: &expinfrav1.StepScalingPolicy{
+			AdjustmentType: expinfrav1.ScalingAdjustmentTypeChangeInCapacity,
+			StepAdjustments: []expinfrav1.StepAdjustment{
+				{MetricIntervalLowerBound: aws.Int64(0), ScalingAdjustment: 1},
+			},
+			Alarm: expinfrav1.ScalingAlarm{
+				Namespace:          "AWS/EC2",
+				MetricName:         "CPUUtilization",
+				Statistic:          "Average",
+				ComparisonOperator: "GreaterThanOrEqualToThreshold",
+				Threshold:          80,
+				Period:             60,
+				EvaluationPeriods:  1,
+			},
+		},
+	}
+}
+
+func TestServiceReconcileScalingPolicies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name         string
+		policies     []expinfrav1.ScalingPolicy
+		status       []expinfrav1.ScalingPolicyStatus
+		expect       func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, c *mocks.MockCloudWatchAPIMockRecorder)
+		wantStatuses []expinfrav1.ScalingPolicyStatus
+		wantErr      bool
+	}{
+		{
+			name:     "should create a missing target tracking policy",
+			policies: []expinfrav1.ScalingPolicy{targetTrackingPolicy()},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, c *mocks.MockCloudWatchAPIMockRecorder) {
+				m.DescribePoliciesPages(gomock.Eq(&autoscaling.DescribePoliciesInput{AutoScalingGroupName: aws.String("pool")}), gomock.Any()).Return(nil)
+				m.PutScalingPolicy(gomock.Eq(&autoscaling.PutScalingPolicyInput{
+					AutoScalingGroupName: aws.String("pool"),
+					PolicyName:           aws.String("cpu"),
+					PolicyType:           aws.String("TargetTrackingScaling"),
+					TargetTrackingConfiguration: &autoscaling.TargetTrackingConfiguration{
+						PredefinedMetricSpecification: &autoscaling.PredefinedMetricSpecification{PredefinedMetricType: aws.String("ASGAverageCPUUtilization")},
+						TargetValue:                   aws.Float64(50),
+						DisableScaleIn:                aws.Bool(false),
+					},
+				})).Return(&autoscaling.PutScalingPolicyOutput{
+					PolicyARN: aws.String(testPolicyARN + "cpu"),
+					Alarms:    []*autoscaling.Alarm{{AlarmARN: aws.String("arn:aws:cloudwatch:us-east-1:123456789012:alarm:TargetTracking-pool-AlarmHigh")}},
+				}, nil)
+			},
+			wantStatuses: []expinfrav1.ScalingPolicyStatus{{
+				Name:      "cpu",
+				ARN:       testPolicyARN + "cpu",
+				AlarmARNs: []string{"arn:aws:cloudwatch:us-east-1:123456789012:alarm:TargetTracking-pool-AlarmHigh"},
+			}},
+		},
+		{
+			name:     "should record the created policies when creating another one fails",
+			policies: []expinfrav1.ScalingPolicy{targetTrackingPolicy(), stepScalingPolicy()},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, c *mocks.MockCloudWatchAPIMockRecorder) {
+				m.DescribePoliciesPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.PutScalingPolicy(gomock.Any()).Return(&autoscaling.PutScalingPolicyOutput{PolicyARN: aws.String(testPolicyARN + "cpu")}, nil)
+				m.PutScalingPolicy(gomock.Any()).Return(nil, errors.New("limit exceeded"))
+			},
+			wantStatuses: []expinfrav1.ScalingPolicyStatus{{Name: "cpu", ARN: testPolicyARN + "cpu"}},
+			wantErr:      true,
+		},
+		{
+			name:     "should not update an unchanged target tracking policy",
+			policies: []expinfrav1.ScalingPolicy{targetTrackingPolicy()},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, c *mocks.MockCloudWatchAPIMockRecorder) {
+				m.DescribePoliciesPages(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *autoscaling.DescribePoliciesInput, fn func(*autoscaling.DescribePoliciesOutput, bool) bool) error {
+					fn(&autoscaling.DescribePoliciesOutput{ScalingPolicies: []*autoscaling.ScalingPolicy{{
+						PolicyName: aws.String("cpu"),
+						PolicyARN:  aws.String(testPolicyARN + "cpu"),
+						PolicyType: aws.String("TargetTrackingScaling"),
+						TargetTrackingConfiguration: &autoscaling.TargetTrackingConfiguration{
+							PredefinedMetricSpecification: &autoscaling.PredefinedMetricSpecification{PredefinedMetricType: aws.String("ASGAverageCPUUtilization")},
+							TargetValue:                   aws.Float64(50),
+							DisableScaleIn:                aws.Bool(false),
+						},
+					}}}, true)
+					return nil
+				})
+			},
+			wantStatuses: []expinfrav1.ScalingPolicyStatus{{Name: "cpu", ARN: testPolicyARN + "cpu"}},
+		},
+		{
+			name:     "should create a step scaling policy and its alarm",
+			policies: []expinfrav1.ScalingPolicy{stepScalingPolicy()},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, c *mocks.MockCloudWatchAPIMockRecorder) {
+				m.DescribePoliciesPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.PutScalingPolicy(gomock.Eq(&autoscaling.PutScalingPolicyInput{
+					AutoScalingGroupName: aws.String("pool"),
+					PolicyName:           aws.String("step"),
+					PolicyType:           aws.String("StepScaling"),
+					AdjustmentType:       aws.String("ChangeInCapacity"),
+					StepAdjustments: []*autoscaling.StepAdjustment{
+						{MetricIntervalLowerBound: aws.Float64(0), ScalingAdjustment: aws.Int64(1)},
+					},
+				})).Return(&autoscaling.PutScalingPolicyOutput{PolicyARN: aws.String(testPolicyARN + "step")}, nil)
+				c.DescribeAlarms(gomock.Eq(&cloudwatch.DescribeAlarmsInput{
+					AlarmNames: aws.StringSlice([]string{"pool-step"}),
+					AlarmTypes: aws.StringSlice([]string{cloudwatch.AlarmTypeMetricAlarm}),
+				})).Return(&cloudwatch.DescribeAlarmsOutput{}, nil)
+				c.PutMetricAlarm(gomock.Eq(&cloudwatch.PutMetricAlarmInput{
+					AlarmName:          aws.String("pool-step"),
+					AlarmDescription:   aws.String("Triggers the step scaling policy step of ASG pool"),
+					Namespace:          aws.String("AWS/EC2"),
+					MetricName:         aws.String("CPUUtilization"),
+					Dimensions:         []*cloudwatch.Dimension{{Name: aws.String("AutoScalingGroupName"), Value: aws.String("pool")}},
+					Statistic:          aws.String("Average"),
+					Period:             aws.Int64(60),
+					EvaluationPeriods:  aws.Int64(1),
+					Threshold:          aws.Float64(80),
+					ComparisonOperator: aws.String("GreaterThanOrEqualToThreshold"),
+					ActionsEnabled:     aws.Bool(true),
+					AlarmActions:       aws.StringSlice([]string{testPolicyARN + "step"}),
+					Tags:               []*cloudwatch.Tag{{Key: aws.String("Name"), Value: aws.String("pool-step")}},
+				})).Return(&cloudwatch.PutMetricAlarmOutput{}, nil)
+			},
+			wantStatuses: []expinfrav1.ScalingPolicyStatus{{
+				Name:      "step",
+				ARN:       testPolicyARN + "step",
+				AlarmARNs: []string{"arn:aws:cloudwatch:us-east-1:123456789012:alarm:pool-step"},
+			}},
+		},
+		{
+			name:   "should delete policies removed from the pool and their alarms",
+			status: []expinfrav1.ScalingPolicyStatus{{Name: "step", ARN: testPolicyARN + "step"}},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, c *mocks.MockCloudWatchAPIMockRecorder) {
+				m.DescribePoliciesPages(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *autoscaling.DescribePoliciesInput, fn func(*autoscaling.DescribePoliciesOutput, bool) bool) error {
+					fn(&autoscaling.DescribePoliciesOutput{ScalingPolicies: []*autoscaling.ScalingPolicy{
+						{PolicyName: aws.String("step"), PolicyARN: aws.String(testPolicyARN + "step")},
+						{PolicyName: aws.String("external"), PolicyARN: aws.String(testPolicyARN + "external")},
+					}}, true)
+					return nil
+				})
+				m.DeletePolicy(gomock.Eq(&autoscaling.DeletePolicyInput{
+					AutoScalingGroupName: aws.String("pool"),
+					PolicyName:           aws.String("step"),
+				})).Return(&autoscaling.DeletePolicyOutput{}, nil)
+				c.DescribeAlarms(gomock.Any()).Return(&cloudwatch.DescribeAlarmsOutput{
+					MetricAlarms: []*cloudwatch.MetricAlarm{{AlarmName: aws.String("pool-step")}},
+				}, nil)
+				c.DeleteAlarms(gomock.Eq(&cloudwatch.DeleteAlarmsInput{AlarmNames: aws.StringSlice([]string{"pool-step"})})).Return(&cloudwatch.DeleteAlarmsOutput{}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			cwMock := mocks.NewMockCloudWatchAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT(), cwMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+			s.CloudWatchClient = cwMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "pool"
+			mps.AWSMachinePool.Spec.ScalingPolicies = tt.policies
+			mps.AWSMachinePool.Status.ScalingPolicies = tt.status
+
+			err = s.ReconcileScalingPolicies(mps)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(mps.AWSMachinePool.Status.ScalingPolicies).To(Equal(tt.wantStatuses))
+		})
+	}
+}
@@ -19,6 +19,7 @@ package awsnode
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	amazoncni "github.com/aws/amazon-vpc-cni-k8s/pkg/apis/crd/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -43,11 +44,20 @@ const (
 	awsNodeNamespace = "kube-system"
 )
 
+// CustomNetworkingEnv returns the environment variables configuring the VPC CNI for custom networking: the IPs of
+// the pods of a node are assigned from the subnet of the ENIConfig named after the availability zone of the node.
+func CustomNetworkingEnv() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{Name: "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG", Value: "true"},
+		{Name: "ENI_CONFIG_LABEL_DEF", Value: corev1.LabelTopologyZone},
+	}
+}
+
 // ReconcileCNI will reconcile the CNI of a service.
 func (s *Service) ReconcileCNI(ctx context.Context) error {
 	s.scope.Info("Reconciling aws-node DaemonSet in cluster", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
 
-	remoteClient, err := s.scope.RemoteClient()
+	remoteClient, err := s.scope.RemoteClient(ctx)
 	if err != nil {
 		s.scope.Error(err, "getting client for remote cluster")
 		return fmt.Errorf("getting client for remote cluster: %w", err)
@@ -69,7 +79,7 @@ func (s *Service) ReconcileCNI(ctx context.Context) error {
 	}
 
 	var needsUpdate bool
-	if len(s.scope.VpcCni().Env) > 0 {
+	if len(s.desiredEnvironment()) > 0 {
 		s.scope.Info("updating aws-node daemonset environment variables", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
 
 		for i := range ds.Spec.Template.Spec.Containers {
@@ -186,13 +196,34 @@ func (s *Service) getSecurityGroups() ([]string, error) {
 	return sgs, nil
 }
 
+// desiredEnvironment returns the environment variables to set on the aws-node container: the proxy
+// settings of the cluster and its custom networking configuration, which are overridden by the user
+// provided environment variables.
+func (s *Service) desiredEnvironment() []corev1.EnvVar {
+	var env []corev1.EnvVar
+	if proxy := s.scope.Proxy(); proxy != nil {
+		add := func(name, value string) {
+			if value != "" {
+				env = append(env, corev1.EnvVar{Name: name, Value: value})
+			}
+		}
+		add("HTTP_PROXY", proxy.HTTPProxy)
+		add("HTTPS_PROXY", proxy.HTTPSProxy)
+		add("NO_PROXY", strings.Join(proxy.NoProxy, ","))
+	}
+	if s.scope.VpcCni().CustomNetworking && s.scope.SecondaryCidrBlock() != nil {
+		env = append(env, CustomNetworkingEnv()...)
+	}
+	return append(env, s.scope.VpcCni().Env...)
+}
+
 // applyUserProvidedEnvironmentProperties takes a container environment and applies user provided values to it.
 func (s *Service) applyUserProvidedEnvironmentProperties(containerEnv []corev1.EnvVar) ([]corev1.EnvVar, bool) {
 	var (
 		envVars     = make(map[string]corev1.EnvVar)
 		needsUpdate = false
 	)
-	for _, e := range s.scope.VpcCni().Env {
+	for _, e := range s.desiredEnvironment() {
 		envVars[e.Name] = e
 	}
 	// Handle the case where we overwrite an existing value if it's not already the desired value.
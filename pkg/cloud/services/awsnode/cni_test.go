@@ -38,6 +38,7 @@ func TestReconcileCniVpcCniValues(t *testing.T) {
 	tests := []struct {
 		name       string
 		cniValues  ekscontrolplanev1.VpcCni
+		proxy      *infrav1.ProxySpec
 		daemonSet  *v1.DaemonSet
 		consistsOf []corev1.EnvVar
 	}{
@@ -183,6 +184,53 @@ func TestReconcileCniVpcCniValues(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "proxy settings are set and users can overwrite them",
+			cniValues: ekscontrolplanev1.VpcCni{
+				Env: []corev1.EnvVar{
+					{
+						Name:  "NO_PROXY",
+						Value: "169.254.169.254",
+					},
+				},
+			},
+			proxy: &infrav1.ProxySpec{
+				HTTPSProxy: "http://proxy.example.com:3128",
+				NoProxy:    []string{"10.0.0.0/16"},
+			},
+			daemonSet: &v1.DaemonSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "DaemonSet",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      awsNodeName,
+					Namespace: awsNodeNamespace,
+				},
+				Spec: v1.DaemonSetSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name: awsNodeName,
+									Env:  []corev1.EnvVar{},
+								},
+							},
+						},
+					},
+				},
+			},
+			consistsOf: []corev1.EnvVar{
+				{
+					Name:  "HTTPS_PROXY",
+					Value: "http://proxy.example.com:3128",
+				},
+				{
+					Name:  "NO_PROXY",
+					Value: "169.254.169.254",
+				},
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name+" without secondary cidr", func(t *testing.T) {
@@ -195,6 +243,7 @@ func TestReconcileCniVpcCniValues(t *testing.T) {
 			m := &mockScope{
 				client: mockClient,
 				cni:    tc.cniValues,
+				proxy:  tc.proxy,
 			}
 			s := NewService(m)
 
@@ -219,6 +268,7 @@ func TestReconcileCniVpcCniValues(t *testing.T) {
 			m := &mockScope{
 				client:             mockClient,
 				cni:                tc.cniValues,
+				proxy:              tc.proxy,
 				secondaryCidrBlock: aws.String("100.0.0.1/20"),
 				securityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
 					"node": {
@@ -257,6 +307,72 @@ func TestReconcileCniVpcCniValues(t *testing.T) {
 	}
 }
 
+func TestReconcileCNICustomNetworking(t *testing.T) {
+	tests := []struct {
+		name               string
+		cniValues          ekscontrolplanev1.VpcCni
+		secondaryCidrBlock *string
+		consistsOf         []corev1.EnvVar
+	}{
+		{
+			name:               "custom networking is configured for the secondary cidr",
+			cniValues:          ekscontrolplanev1.VpcCni{CustomNetworking: true},
+			secondaryCidrBlock: aws.String("100.64.0.0/16"),
+			consistsOf: []corev1.EnvVar{
+				{Name: "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG", Value: "true"},
+				{Name: "ENI_CONFIG_LABEL_DEF", Value: "topology.kubernetes.io/zone"},
+			},
+		},
+		{
+			name: "user provided environment variables take precedence",
+			cniValues: ekscontrolplanev1.VpcCni{
+				CustomNetworking: true,
+				Env:              []corev1.EnvVar{{Name: "ENI_CONFIG_LABEL_DEF", Value: "example.com/eniconfig"}},
+			},
+			secondaryCidrBlock: aws.String("100.64.0.0/16"),
+			consistsOf: []corev1.EnvVar{
+				{Name: "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG", Value: "true"},
+				{Name: "ENI_CONFIG_LABEL_DEF", Value: "example.com/eniconfig"},
+			},
+		},
+		{
+			name:       "custom networking is ignored without secondary cidr",
+			cniValues:  ekscontrolplanev1.VpcCni{CustomNetworking: true, Env: []corev1.EnvVar{{Name: "WARM_IP_TARGET", Value: "2"}}},
+			consistsOf: []corev1.EnvVar{{Name: "WARM_IP_TARGET", Value: "2"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockClient := &cachingClient{
+				getValue: &v1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{Name: awsNodeName, Namespace: awsNodeNamespace},
+					Spec: v1.DaemonSetSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: awsNodeName}}},
+						},
+					},
+				},
+			}
+			m := &mockScope{
+				client:             mockClient,
+				cni:                tc.cniValues,
+				secondaryCidrBlock: tc.secondaryCidrBlock,
+				securityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+					"node": {ID: "sg-1234", Name: "node"},
+				},
+			}
+			s := NewService(m)
+
+			g.Expect(s.ReconcileCNI(context.Background())).To(Succeed())
+			g.Expect(mockClient.updateChain).NotTo(BeEmpty())
+			ds, ok := mockClient.updateChain[len(mockClient.updateChain)-1].(*v1.DaemonSet)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(ds.Spec.Template.Spec.Containers[0].Env).To(ConsistOf(tc.consistsOf))
+		})
+	}
+}
+
 type cachingClient struct {
 	client.Client
 	getValue    client.Object
@@ -284,12 +400,13 @@ type mockScope struct {
 	scope.AWSNodeScope
 	client             client.Client
 	cni                ekscontrolplanev1.VpcCni
+	proxy              *infrav1.ProxySpec
 	secondaryCidrBlock *string
 	securityGroups     map[infrav1.SecurityGroupRole]infrav1.SecurityGroup
 	subnets            infrav1.Subnets
 }
 
-func (s *mockScope) RemoteClient() (client.Client, error) {
+func (s *mockScope) RemoteClient(_ context.Context) (client.Client, error) {
 	return s.client, nil
 }
 
@@ -297,6 +414,10 @@ func (s *mockScope) VpcCni() ekscontrolplanev1.VpcCni {
 	return s.cni
 }
 
+func (s *mockScope) Proxy() *infrav1.ProxySpec {
+	return s.proxy
+}
+
 func (s *mockScope) Info(msg string, keysAndValues ...interface{}) {
 
 }
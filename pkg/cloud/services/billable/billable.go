@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package billable estimates the billable AWS resources managed for a cluster.
+package billable
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+var natGatewayBillableStates = sets.NewString("pending", "available")
+
+// Summarize estimates the billable resources of a cluster from its network status and bastion host,
+// and from the machines and machine pools of the cluster. The instances of machine pools are counted
+// by the instance type of their launch template, ignoring the overrides of mixed instances policies.
+func Summarize(ctx context.Context, c client.Client, cluster client.ObjectKey, network *infrav1.NetworkStatus, bastion *infrav1.Instance) (*infrav1.BillableResources, error) {
+	res := &infrav1.BillableResources{}
+
+	allocationIDs := sets.NewString()
+	for _, natGateway := range network.NatGateways {
+		if !natGatewayBillableStates.Has(natGateway.State) {
+			continue
+		}
+		res.NATGateways++
+		if natGateway.AllocationID != "" {
+			allocationIDs.Insert(natGateway.AllocationID)
+		}
+	}
+	res.ElasticIPs = int32(allocationIDs.Len())
+
+	if network.APIServerELB.Name != "" {
+		res.LoadBalancers++
+	}
+
+	instances := map[string]int32{}
+	volumes := map[string]int32{}
+	addVolumes := func(count int32, rootVolume *infrav1.Volume, nonRootVolumes ...infrav1.Volume) {
+		for _, volume := range append([]infrav1.Volume{volumeOrEmpty(rootVolume)}, nonRootVolumes...) {
+			if volume.Size == 0 {
+				continue
+			}
+			volumeType := string(volume.Type)
+			if volumeType == "" {
+				volumeType = string(infrav1.VolumeTypeGP2)
+			}
+			volumes[volumeType] += int32(volume.Size) * count
+		}
+	}
+
+	if bastion != nil && infrav1.InstanceRunningStates.Has(string(bastion.State)) {
+		instances[bastion.Type]++
+		addVolumes(1, bastion.RootVolume)
+	}
+
+	listOptions := []client.ListOption{
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(map[string]string{clusterv1.ClusterNameLabel: cluster.Name}),
+	}
+
+	machines := &infrav1.AWSMachineList{}
+	if err := c.List(ctx, machines, listOptions...); err != nil {
+		return nil, errors.Wrapf(err, "failed to list machines for cluster %s", cluster)
+	}
+	for _, machine := range machines.Items {
+		if machine.Status.InstanceState == nil || !infrav1.InstanceRunningStates.Has(string(*machine.Status.InstanceState)) {
+			continue
+		}
+		instances[machine.Spec.InstanceType]++
+		addVolumes(1, machine.Spec.RootVolume, machine.Spec.NonRootVolumes...)
+	}
+
+	if feature.Gates.Enabled(feature.MachinePool) {
+		machinePools := &expinfrav1.AWSMachinePoolList{}
+		if err := c.List(ctx, machinePools, listOptions...); err != nil {
+			return nil, errors.Wrapf(err, "failed to list machine pools for cluster %s", cluster)
+		}
+		for _, machinePool := range machinePools.Items {
+			if machinePool.Status.Replicas > 0 {
+				instances[machinePool.Spec.AWSLaunchTemplate.InstanceType] += machinePool.Status.Replicas
+				addVolumes(machinePool.Status.Replicas, machinePool.Spec.AWSLaunchTemplate.RootVolume)
+			}
+		}
+
+		managedMachinePools := &expinfrav1.AWSManagedMachinePoolList{}
+		if err := c.List(ctx, managedMachinePools, listOptions...); err != nil {
+			return nil, errors.Wrapf(err, "failed to list managed machine pools for cluster %s", cluster)
+		}
+		for _, machinePool := range managedMachinePools.Items {
+			instanceType := ""
+			switch {
+			case machinePool.Spec.InstanceType != nil:
+				instanceType = *machinePool.Spec.InstanceType
+			case machinePool.Spec.AWSLaunchTemplate != nil:
+				instanceType = machinePool.Spec.AWSLaunchTemplate.InstanceType
+			}
+			// EKS picks the instance type when the pool doesn't set one, so it can't be counted.
+			if instanceType == "" || machinePool.Status.Replicas == 0 {
+				continue
+			}
+			instances[instanceType] += machinePool.Status.Replicas
+			if machinePool.Spec.AWSLaunchTemplate != nil {
+				addVolumes(machinePool.Status.Replicas, machinePool.Spec.AWSLaunchTemplate.RootVolume)
+			} else if machinePool.Spec.DiskSize != nil {
+				addVolumes(machinePool.Status.Replicas, &infrav1.Volume{Size: int64(*machinePool.Spec.DiskSize)})
+			}
+		}
+	}
+
+	if len(instances) > 0 {
+		res.Instances = instances
+	}
+	if len(volumes) > 0 {
+		res.EBSVolumes = volumes
+	}
+	return res, nil
+}
+
+func volumeOrEmpty(volume *infrav1.Volume) infrav1.Volume {
+	if volume == nil {
+		return infrav1.Volume{}
+	}
+	return *volume
+}
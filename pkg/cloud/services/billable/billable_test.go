@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package billable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestSummarize(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePool, true)()
+
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(expinfrav1.AddToScheme(scheme)).To(Succeed())
+
+	clusterLabels := map[string]string{clusterv1.ClusterNameLabel: "test-cluster"}
+	machine := func(name, instanceType string, state infrav1.InstanceState) *infrav1.AWSMachine {
+		return &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: clusterLabels},
+			Spec:       infrav1.AWSMachineSpec{InstanceType: instanceType},
+			Status:     infrav1.AWSMachineStatus{InstanceState: &state},
+		}
+	}
+	controlPlane := machine("control-plane-0", "m5.large", infrav1.InstanceStateRunning)
+	controlPlane.Spec.RootVolume = &infrav1.Volume{Size: 50, Type: infrav1.VolumeTypeGP3}
+	controlPlane.Spec.NonRootVolumes = []infrav1.Volume{{DeviceName: "/dev/sdb", Size: 100}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		controlPlane,
+		machine("worker-0", "t3.medium", infrav1.InstanceStatePending),
+		machine("worker-1", "t3.medium", infrav1.InstanceStateTerminated),
+		&infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-cluster", Namespace: "default", Labels: map[string]string{clusterv1.ClusterNameLabel: "other"}},
+			Spec:       infrav1.AWSMachineSpec{InstanceType: "m5.large"},
+		},
+		&expinfrav1.AWSMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Labels: clusterLabels},
+			Spec: expinfrav1.AWSMachinePoolSpec{AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{
+				InstanceType: "t3.medium",
+				RootVolume:   &infrav1.Volume{Size: 20, Type: infrav1.VolumeTypeGP3},
+			}},
+			Status: expinfrav1.AWSMachinePoolStatus{Replicas: 2},
+		},
+		&expinfrav1.AWSManagedMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "managed-pool", Namespace: "default", Labels: clusterLabels},
+			Spec:       expinfrav1.AWSManagedMachinePoolSpec{InstanceType: aws.String("c5.xlarge"), DiskSize: aws.Int32(30)},
+			Status:     expinfrav1.AWSManagedMachinePoolStatus{Replicas: 3},
+		},
+	).Build()
+
+	network := &infrav1.NetworkStatus{
+		APIServerELB: infrav1.LoadBalancer{Name: "test-cluster-apiserver"},
+		NatGateways: []infrav1.NatGatewayStatus{
+			{ID: "nat-1", State: "available", AllocationID: "eipalloc-1"},
+			{ID: "nat-2", State: "pending", AllocationID: "eipalloc-2"},
+			{ID: "nat-3", State: "failed", AllocationID: "eipalloc-3"},
+		},
+	}
+	bastion := &infrav1.Instance{ID: "i-bastion", Type: "t3.micro", State: infrav1.InstanceStateRunning}
+
+	res, err := Summarize(context.TODO(), c, client.ObjectKey{Namespace: "default", Name: "test-cluster"}, network, bastion)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res).To(Equal(&infrav1.BillableResources{
+		NATGateways:   2,
+		ElasticIPs:    2,
+		LoadBalancers: 1,
+		Instances: map[string]int32{
+			"t3.micro":  1,
+			"m5.large":  1,
+			"t3.medium": 3,
+			"c5.xlarge": 3,
+		},
+		EBSVolumes: map[string]int32{
+			"gp3": 90,
+			"gp2": 190,
+		},
+	}))
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package billable
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+const (
+	// hoursPerMonth converts the monthly prices of EBS volumes to hourly prices.
+	hoursPerMonth = 730
+
+	// priceTTL is how long prices are cached, as the AWS Price List rarely changes.
+	priceTTL = 24 * time.Hour
+
+	ec2ServiceCode = "AmazonEC2"
+	elbServiceCode = "AWSELB"
+)
+
+// Kinds of resources of the breakdown of an estimate.
+const (
+	instancesResource     = "instances"
+	natGatewaysResource   = "natGateways"
+	loadBalancersResource = "loadBalancers"
+	ebsVolumesResource    = "ebsVolumes"
+)
+
+// priceCache holds the prices looked up by region and product, shared by all clusters.
+var priceCache sync.Map
+
+type cachedPrice struct {
+	usd     float64
+	found   bool
+	expires time.Time
+}
+
+// Estimator estimates the on-demand cost of the billable resources of a cluster from the AWS Price List.
+type Estimator struct {
+	PricingClient pricingiface.PricingAPI
+	region        string
+	partition     string
+	now           func() time.Time
+}
+
+// NewEstimator returns a new estimator for the region of the cluster.
+func NewEstimator(clusterScope cloud.ClusterScoper) *Estimator {
+	return &Estimator{
+		PricingClient: scope.NewPricingClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		region:        clusterScope.Region(),
+		partition:     clusterScope.Partition(),
+		now:           time.Now,
+	}
+}
+
+// Estimate returns the approximate hourly on-demand cost of the billable resources, priced with the
+// Linux on-demand price of instances, the hourly price of NAT gateways and of the API server load
+// balancer, and the storage price of EBS volumes. Resources without a known price are listed as
+// unpriced, while failing to look up a price is an error.
+func (e *Estimator) Estimate(res *infrav1.BillableResources, apiServerLoadBalancerType infrav1.LoadBalancerType) (*infrav1.CostEstimate, error) {
+	if e.partition != endpoints.AwsPartitionID {
+		return nil, errors.Errorf("the AWS Price List is not available in partition %q", e.partition)
+	}
+
+	byResource := map[string]float64{}
+	var unpriced []string
+	add := func(resource, product string, quantity float64, price func() (float64, bool, error)) error {
+		if quantity == 0 {
+			return nil
+		}
+		usd, found, err := price()
+		if err != nil {
+			return err
+		}
+		if !found {
+			unpriced = append(unpriced, product)
+			return nil
+		}
+		byResource[resource] += usd * quantity
+		return nil
+	}
+
+	for _, instanceType := range sortedKeys(res.Instances) {
+		instanceType := instanceType
+		if err := add(instancesResource, "instance/"+instanceType, float64(res.Instances[instanceType]), func() (float64, bool, error) {
+			return e.price(ec2ServiceCode, "", []*pricing.Filter{
+				termMatch("instanceType", instanceType),
+				termMatch("operatingSystem", "Linux"),
+				termMatch("tenancy", "Shared"),
+				termMatch("preInstalledSw", "NA"),
+				termMatch("capacitystatus", "Used"),
+			})
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := add(natGatewaysResource, "natGateway", float64(res.NATGateways), func() (float64, bool, error) {
+		return e.price(ec2ServiceCode, "NatGateway-Hours", []*pricing.Filter{termMatch("productFamily", "NAT Gateway")})
+	}); err != nil {
+		return nil, err
+	}
+
+	productFamily := loadBalancerProductFamily(apiServerLoadBalancerType)
+	if err := add(loadBalancersResource, "loadBalancer/"+productFamily, float64(res.LoadBalancers), func() (float64, bool, error) {
+		return e.price(elbServiceCode, "LoadBalancerUsage", []*pricing.Filter{termMatch("productFamily", productFamily)})
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, volumeType := range sortedKeys(res.EBSVolumes) {
+		volumeType := volumeType
+		if err := add(ebsVolumesResource, "ebsVolume/"+volumeType, float64(res.EBSVolumes[volumeType]), func() (float64, bool, error) {
+			monthly, found, err := e.price(ec2ServiceCode, "", []*pricing.Filter{
+				termMatch("productFamily", "Storage"),
+				termMatch("volumeApiName", volumeType),
+			})
+			return monthly / hoursPerMonth, found, err
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	estimate := &infrav1.CostEstimate{
+		Unpriced:    unpriced,
+		LastUpdated: metav1.NewTime(e.now()),
+	}
+	total := 0.0
+	for resource, usd := range byResource {
+		if estimate.HourlyUSDByResource == nil {
+			estimate.HourlyUSDByResource = map[string]string{}
+		}
+		estimate.HourlyUSDByResource[resource] = formatUSD(usd)
+		total += usd
+	}
+	estimate.HourlyUSD = formatUSD(total)
+	return estimate, nil
+}
+
+// price returns the on-demand price in USD of the first product of the service matching the filters in
+// the region of the estimator, and whose usage type ends with the usage type suffix when it is set.
+func (e *Estimator) price(serviceCode, usageTypeSuffix string, filters []*pricing.Filter) (float64, bool, error) {
+	filters = append(filters, termMatch("regionCode", e.region))
+	key := cacheKey(serviceCode, usageTypeSuffix, filters)
+	if cached, ok := priceCache.Load(key); ok && e.now().Before(cached.(*cachedPrice).expires) {
+		return cached.(*cachedPrice).usd, cached.(*cachedPrice).found, nil
+	}
+
+	price := &cachedPrice{expires: e.now().Add(priceTTL)}
+	err := e.PricingClient.GetProductsPages(&pricing.GetProductsInput{
+		ServiceCode: aws.String(serviceCode),
+		Filters:     filters,
+	}, func(page *pricing.GetProductsOutput, lastPage bool) bool {
+		for _, product := range page.PriceList {
+			if usageTypeSuffix != "" && !strings.HasSuffix(stringAt(product, "product", "attributes", "usagetype"), usageTypeSuffix) {
+				continue
+			}
+			if usd, ok := onDemandUSD(product); ok {
+				price.usd, price.found = usd, true
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "failed to get the prices of %s products", serviceCode)
+	}
+	priceCache.Store(key, price)
+	return price.usd, price.found, nil
+}
+
+// onDemandUSD returns the non-zero USD price per unit of the on-demand terms of a product of the price list,
+// skipping the free tiers of tiered prices.
+func onDemandUSD(product aws.JSONValue) (float64, bool) {
+	terms, _ := valueAt(product, "terms", "OnDemand").(map[string]interface{})
+	for _, term := range terms {
+		dimensions, _ := valueAt(term, "priceDimensions").(map[string]interface{})
+		for _, dimension := range dimensions {
+			usd, err := strconv.ParseFloat(stringAt(dimension, "pricePerUnit", "USD"), 64)
+			if err == nil && usd > 0 {
+				return usd, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func loadBalancerProductFamily(lbType infrav1.LoadBalancerType) string {
+	switch lbType {
+	case infrav1.LoadBalancerTypeNLB:
+		return "Load Balancer-Network"
+	case infrav1.LoadBalancerTypeALB:
+		return "Load Balancer-Application"
+	default:
+		return "Load Balancer"
+	}
+}
+
+func termMatch(field, value string) *pricing.Filter {
+	return &pricing.Filter{Type: aws.String(pricing.FilterTypeTermMatch), Field: aws.String(field), Value: aws.String(value)}
+}
+
+func cacheKey(serviceCode, usageTypeSuffix string, filters []*pricing.Filter) string {
+	parts := []string{serviceCode, usageTypeSuffix}
+	for _, f := range filters {
+		parts = append(parts, aws.StringValue(f.Field)+"="+aws.StringValue(f.Value))
+	}
+	return strings.Join(parts, "/")
+}
+
+func valueAt(v interface{}, path ...string) interface{} {
+	for _, key := range path {
+		var m map[string]interface{}
+		switch typed := v.(type) {
+		case aws.JSONValue:
+			m = typed
+		case map[string]interface{}:
+			m = typed
+		default:
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+func stringAt(v interface{}, path ...string) string {
+	s, _ := valueAt(v, path...).(string)
+	return s
+}
+
+func formatUSD(usd float64) string {
+	return strconv.FormatFloat(usd, 'f', 4, 64)
+}
+
+func sortedKeys(m map[string]int32) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// EstimateCost sets the estimated cost of the billable resources of a cluster and publishes it as a metric. The
+// previous estimate is kept when the cost didn't change, so that the status isn't updated on every reconciliation,
+// and when the cost can't be estimated.
+func EstimateCost(clusterScope cloud.ClusterScoper, res, previous *infrav1.BillableResources, apiServerLoadBalancerType infrav1.LoadBalancerType) error {
+	var previousEstimate *infrav1.CostEstimate
+	if previous != nil {
+		previousEstimate = previous.EstimatedCost
+	}
+	res.EstimatedCost = previousEstimate
+
+	estimate, err := NewEstimator(clusterScope).Estimate(res, apiServerLoadBalancerType)
+	if err != nil {
+		return err
+	}
+	if !SameEstimate(estimate, previousEstimate) {
+		res.EstimatedCost = estimate
+	}
+	RecordEstimate(clusterScope.Namespace(), clusterScope.Name(), res.EstimatedCost)
+	return nil
+}
+
+// SameEstimate returns true if the estimates are the same but for the time they were computed.
+func SameEstimate(a, b *infrav1.CostEstimate) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.HourlyUSD == b.HourlyUSD &&
+		reflect.DeepEqual(a.HourlyUSDByResource, b.HourlyUSDByResource) &&
+		reflect.DeepEqual(a.Unpriced, b.Unpriced)
+}
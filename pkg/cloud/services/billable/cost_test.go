@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package billable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func priceListProduct(usageType, usd string) aws.JSONValue {
+	return aws.JSONValue{
+		"product": map[string]interface{}{
+			"attributes": map[string]interface{}{"usagetype": usageType},
+		},
+		"terms": map[string]interface{}{
+			"OnDemand": map[string]interface{}{
+				"SKU.TERM": map[string]interface{}{
+					"priceDimensions": map[string]interface{}{
+						"SKU.TERM.RATE": map[string]interface{}{
+							"pricePerUnit": map[string]interface{}{"USD": usd},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func filterValue(input *pricing.GetProductsInput, field string) string {
+	for _, f := range input.Filters {
+		if aws.StringValue(f.Field) == field {
+			return aws.StringValue(f.Value)
+		}
+	}
+	return ""
+}
+
+func TestEstimate(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	priceCache.Range(func(key, _ interface{}) bool {
+		priceCache.Delete(key)
+		return true
+	})
+
+	pricingMock := mocks.NewMockPricingAPI(mockCtrl)
+	pricingMock.EXPECT().GetProductsPages(gomock.Any(), gomock.Any()).DoAndReturn(func(input *pricing.GetProductsInput, fn func(*pricing.GetProductsOutput, bool) bool) error {
+		g.Expect(filterValue(input, "regionCode")).To(Equal("eu-west-1"))
+		var products []aws.JSONValue
+		switch {
+		case filterValue(input, "instanceType") == "m5.large":
+			products = []aws.JSONValue{priceListProduct("EU-BoxUsage:m5.large", "0.0960000000")}
+		case filterValue(input, "productFamily") == "NAT Gateway":
+			products = []aws.JSONValue{
+				priceListProduct("EU-NatGateway-Bytes", "0.0500000000"),
+				priceListProduct("EU-NatGateway-Hours", "0.0480000000"),
+			}
+		case filterValue(input, "productFamily") == "Load Balancer-Network":
+			g.Expect(aws.StringValue(input.ServiceCode)).To(Equal("AWSELB"))
+			products = []aws.JSONValue{priceListProduct("EU-LoadBalancerUsage", "0.0252000000")}
+		case filterValue(input, "volumeApiName") == "gp3":
+			products = []aws.JSONValue{priceListProduct("EU-EBS:VolumeUsage.gp3", "0.0880000000")}
+		}
+		fn(&pricing.GetProductsOutput{PriceList: products}, true)
+		return nil
+	}).Times(5)
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	e := &Estimator{PricingClient: pricingMock, region: "eu-west-1", partition: "aws", now: func() time.Time { return now }}
+	res := &infrav1.BillableResources{
+		NATGateways:   1,
+		LoadBalancers: 1,
+		Instances:     map[string]int32{"m5.large": 2, "x9.unknown": 1},
+		EBSVolumes:    map[string]int32{"gp3": 100},
+	}
+
+	want := &infrav1.CostEstimate{
+		HourlyUSD: "0.2773",
+		HourlyUSDByResource: map[string]string{
+			"instances":     "0.1920",
+			"natGateways":   "0.0480",
+			"loadBalancers": "0.0252",
+			"ebsVolumes":    "0.0121",
+		},
+		Unpriced:    []string{"instance/x9.unknown"},
+		LastUpdated: metav1.NewTime(now),
+	}
+	estimate, err := e.Estimate(res, infrav1.LoadBalancerTypeNLB)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(estimate).To(Equal(want))
+
+	// Prices, including missing ones, are cached.
+	estimate, err = e.Estimate(res, infrav1.LoadBalancerTypeNLB)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(estimate).To(Equal(want))
+	g.Expect(SameEstimate(estimate, &infrav1.CostEstimate{
+		HourlyUSD:           want.HourlyUSD,
+		HourlyUSDByResource: want.HourlyUSDByResource,
+		Unpriced:            want.Unpriced,
+		LastUpdated:         metav1.NewTime(now.Add(time.Hour)),
+	})).To(BeTrue())
+}
+
+func TestEstimateOutsideAWSPartition(t *testing.T) {
+	g := NewWithT(t)
+
+	e := &Estimator{region: "cn-north-1", partition: "aws-cn", now: time.Now}
+	_, err := e.Estimate(&infrav1.BillableResources{NATGateways: 1}, "")
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package billable
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+var estimatedHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: "aws",
+	Name:      "cluster_estimated_hourly_cost_usd",
+	Help:      "Approximate hourly on-demand cost in USD of the billable resources managed for a cluster",
+}, []string{"namespace", "cluster"})
+
+func init() {
+	metrics.Registry.MustRegister(estimatedHourlyCost)
+}
+
+// RecordEstimate publishes the hourly cost of the estimate of a cluster.
+func RecordEstimate(namespace, cluster string, estimate *infrav1.CostEstimate) {
+	if hourly, err := strconv.ParseFloat(estimate.HourlyUSD, 64); err == nil {
+		estimatedHourlyCost.WithLabelValues(namespace, cluster).Set(hourly)
+	}
+}
+
+// ForgetEstimate stops publishing the cost of a cluster.
+func ForgetEstimate(namespace, cluster string) {
+	estimatedHourlyCost.DeleteLabelValues(namespace, cluster)
+}
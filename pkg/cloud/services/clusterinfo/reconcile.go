@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterinfo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap published into the workload cluster.
+	ConfigMapName = "aws-cluster-info"
+	// ConfigMapNamespace is the namespace of the ConfigMap published into the workload cluster.
+	ConfigMapNamespace = metav1.NamespaceSystem
+
+	regionKey      = "region"
+	partitionKey   = "partition"
+	accountIDKey   = "accountId"
+	vpcIDKey       = "vpcId"
+	clusterNameKey = "clusterName"
+	clusterTagKey  = "clusterTagKey"
+
+	karpenterNodeRoleKey          = "karpenterNodeRole"
+	karpenterInstanceProfileKey   = "karpenterInstanceProfile"
+	karpenterInterruptionQueueKey = "karpenterInterruptionQueue"
+)
+
+// karpenterKeys are the keys of the identifiers of the AWS resources created for Karpenter,
+// which are removed when the resources are.
+var karpenterKeys = []string{karpenterNodeRoleKey, karpenterInstanceProfileKey, karpenterInterruptionQueueKey}
+
+// securityGroupKeys are the keys of the IDs of the security groups published into the workload cluster,
+// by role of the security group.
+var securityGroupKeys = map[infrav1.SecurityGroupRole]string{
+	infrav1.SecurityGroupNode:         "nodeSecurityGroupId",
+	infrav1.SecurityGroupControlPlane: "controlPlaneSecurityGroupId",
+	infrav1.SecurityGroupAPIServerLB:  "apiServerLoadBalancerSecurityGroupId",
+	infrav1.SecurityGroupLB:           "loadBalancerSecurityGroupId",
+}
+
+// ReconcileClusterInfo will publish the AWS environment of the cluster into the
+// workload cluster as a ConfigMap, so that addons can consume it.
+func (s *Service) ReconcileClusterInfo(ctx context.Context) error {
+	s.scope.Debug("Reconciling cluster info ConfigMap in cluster", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+
+	remoteClient, err := s.scope.RemoteClient(ctx)
+	if err != nil {
+		return fmt.Errorf("getting client for remote cluster: %w", err)
+	}
+
+	return s.reconcileConfigMap(ctx, remoteClient)
+}
+
+func (s *Service) reconcileConfigMap(ctx context.Context, remoteClient client.Client) error {
+	existing := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: ConfigMapNamespace, Name: ConfigMapName}
+	if err := remoteClient.Get(ctx, key, existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("getting %s ConfigMap: %w", key, err)
+		}
+		existing = nil
+	}
+
+	// The account a cluster runs in can't change, so only look it up once.
+	accountID := ""
+	if existing != nil {
+		accountID = existing.Data[accountIDKey]
+	}
+	if accountID == "" {
+		output, err := s.STSClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+		if err != nil {
+			return fmt.Errorf("getting account ID: %w", err)
+		}
+		accountID = aws.StringValue(output.Account)
+	}
+
+	data := s.configMapData(accountID)
+
+	if existing == nil {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ConfigMapName,
+				Namespace: ConfigMapNamespace,
+			},
+			Data: data,
+		}
+		if err := remoteClient.Create(ctx, cm); err != nil {
+			return fmt.Errorf("creating %s ConfigMap: %w", key, err)
+		}
+		s.scope.Info("Created cluster info ConfigMap in cluster", "configmap", key)
+		return nil
+	}
+
+	changed := false
+	if existing.Data == nil {
+		existing.Data = map[string]string{}
+	}
+	for k, v := range data {
+		if existing.Data[k] != v {
+			existing.Data[k] = v
+			changed = true
+		}
+	}
+	for _, k := range karpenterKeys {
+		if _, ok := data[k]; !ok {
+			if _, ok := existing.Data[k]; ok {
+				delete(existing.Data, k)
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := remoteClient.Update(ctx, existing); err != nil {
+		return fmt.Errorf("updating %s ConfigMap: %w", key, err)
+	}
+	s.scope.Debug("Updated cluster info ConfigMap in cluster", "configmap", key)
+
+	return nil
+}
+
+func (s *Service) configMapData(accountID string) map[string]string {
+	data := map[string]string{
+		regionKey:      s.scope.Region(),
+		partitionKey:   s.scope.Partition(),
+		accountIDKey:   accountID,
+		vpcIDKey:       s.scope.VPC().ID,
+		clusterNameKey: s.scope.KubernetesClusterName(),
+		clusterTagKey:  infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName()),
+	}
+
+	// Only publish the security groups the cluster has, e.g. EKS clusters have no load balancer security groups.
+	securityGroups := s.scope.SecurityGroups()
+	for role, key := range securityGroupKeys {
+		if sg, ok := securityGroups[role]; ok && sg.ID != "" {
+			data[key] = sg.ID
+		}
+	}
+
+	if karpenter := s.scope.KarpenterStatus(); karpenter != nil {
+		for key, value := range map[string]string{
+			karpenterNodeRoleKey:          karpenter.NodeRoleName,
+			karpenterInstanceProfileKey:   karpenter.InstanceProfileName,
+			karpenterInterruptionQueueKey: karpenter.InterruptionQueueName,
+		} {
+			if value != "" {
+				data[key] = value
+			}
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/sts/mock_stsiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileConfigMap(t *testing.T) {
+	expectedData := map[string]string{
+		regionKey:      "us-gov-west-1",
+		partitionKey:   "aws-us-gov",
+		accountIDKey:   "123456789012",
+		vpcIDKey:       "vpc-1",
+		clusterNameKey: "test-cluster",
+		clusterTagKey:  "kubernetes.io/cluster/test-cluster",
+
+		"nodeSecurityGroupId":                  "sg-node",
+		"controlPlaneSecurityGroupId":          "sg-controlplane",
+		"apiServerLoadBalancerSecurityGroupId": "sg-apiserver-lb",
+	}
+
+	testCases := []struct {
+		name     string
+		existing *corev1.ConfigMap
+		expect   func(m *mock_stsiface.MockSTSAPIMockRecorder)
+	}{
+		{
+			name: "no existing ConfigMap, should create it",
+			expect: func(m *mock_stsiface.MockSTSAPIMockRecorder) {
+				m.GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+			},
+		},
+		{
+			name: "existing ConfigMap with account ID, should update it without looking up the account",
+			existing: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: ConfigMapNamespace},
+				Data: map[string]string{
+					accountIDKey: "123456789012",
+					vpcIDKey:     "vpc-old",
+				},
+			},
+			expect: func(m *mock_stsiface.MockSTSAPIMockRecorder) {},
+		},
+		{
+			name: "existing ConfigMap with extra keys, should keep them",
+			existing: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: ConfigMapNamespace},
+				Data: map[string]string{
+					"custom": "value",
+				},
+			},
+			expect: func(m *mock_stsiface.MockSTSAPIMockRecorder) {
+				m.GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+			},
+		},
+		{
+			name: "existing ConfigMap with Karpenter keys, should remove them",
+			existing: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: ConfigMapNamespace},
+				Data: map[string]string{
+					accountIDKey:                  "123456789012",
+					karpenterNodeRoleKey:          "test-cluster-karpenter-node",
+					karpenterInstanceProfileKey:   "test-cluster-karpenter-node",
+					karpenterInterruptionQueueKey: "test-cluster-karpenter",
+				},
+			},
+			expect: func(m *mock_stsiface.MockSTSAPIMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+			tc.expect(stsMock.EXPECT())
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = corev1.AddToScheme(scheme)
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					Region: "us-gov-west-1",
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{ID: "vpc-1"},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupNode:         {ID: "sg-node"},
+							infrav1.SecurityGroupControlPlane: {ID: "sg-controlplane"},
+							infrav1.SecurityGroupAPIServerLB:  {ID: "sg-apiserver-lb"},
+							infrav1.SecurityGroupBastion:      {ID: "sg-bastion"},
+						},
+					},
+				},
+			}
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "test-cluster"},
+				},
+				AWSCluster: awsCluster,
+				Client:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).Build(),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tc.existing != nil {
+				builder = builder.WithObjects(tc.existing)
+			}
+			remoteClient := builder.Build()
+
+			s := &Service{
+				scope:     clusterScope,
+				STSClient: stsMock,
+			}
+			g.Expect(s.reconcileConfigMap(context.TODO(), remoteClient)).To(Succeed())
+
+			cm := &corev1.ConfigMap{}
+			g.Expect(remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: ConfigMapNamespace, Name: ConfigMapName}, cm)).To(Succeed())
+			for k, v := range expectedData {
+				g.Expect(cm.Data).To(HaveKeyWithValue(k, v))
+			}
+			g.Expect(cm.Data).NotTo(HaveKey("loadBalancerSecurityGroupId"))
+			for _, k := range karpenterKeys {
+				g.Expect(cm.Data).NotTo(HaveKey(k))
+			}
+			if tc.existing != nil {
+				for k, v := range tc.existing.Data {
+					if _, ok := expectedData[k]; !ok && !isKarpenterKey(k) {
+						g.Expect(cm.Data).To(HaveKeyWithValue(k, v))
+					}
+				}
+			}
+
+			// A second reconcile with nothing changed should be a no-op.
+			g.Expect(s.reconcileConfigMap(context.TODO(), remoteClient)).To(Succeed())
+		})
+	}
+}
+
+func isKarpenterKey(key string) bool {
+	for _, k := range karpenterKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func TestConfigMapDataKarpenter(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status *ekscontrolplanev1.KarpenterStatus
+		expect map[string]string
+	}{
+		{
+			name: "Karpenter disabled",
+		},
+		{
+			name: "Karpenter enabled",
+			status: &ekscontrolplanev1.KarpenterStatus{
+				NodeRoleName:          "test-cluster-karpenter-node",
+				InstanceProfileName:   "test-cluster-karpenter-node",
+				InterruptionQueueName: "test-cluster-karpenter",
+			},
+			expect: map[string]string{
+				karpenterNodeRoleKey:          "test-cluster-karpenter-node",
+				karpenterInstanceProfileKey:   "test-cluster-karpenter-node",
+				karpenterInterruptionQueueKey: "test-cluster-karpenter",
+			},
+		},
+		{
+			name: "Karpenter enabled without interruption queue",
+			status: &ekscontrolplanev1.KarpenterStatus{
+				NodeRoleName:        "test-cluster-karpenter-node",
+				InstanceProfileName: "test-cluster-karpenter-node",
+			},
+			expect: map[string]string{
+				karpenterNodeRoleKey:        "test-cluster-karpenter-node",
+				karpenterInstanceProfileKey: "test-cluster-karpenter-node",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "test"},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName: "test-cluster",
+					Region:         "us-east-1",
+				},
+				Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{Karpenter: tc.status},
+			}
+			managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "test-cluster"},
+				},
+				ControlPlane: controlPlane,
+				Client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).Build(),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := &Service{scope: managedScope}
+			data := s.configMapData("123456789012")
+			for _, k := range karpenterKeys {
+				if v, ok := tc.expect[k]; ok {
+					g.Expect(data).To(HaveKeyWithValue(k, v))
+				} else {
+					g.Expect(data).NotTo(HaveKey(k))
+				}
+			}
+		})
+	}
+}
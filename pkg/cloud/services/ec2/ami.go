@@ -65,6 +65,13 @@ const (
 	// 4. a `-` followed by any additional characters.
 	DefaultAmiNameFormat = "capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*"
 
+	// DefaultGPUAmiNameFormat is the name format of the CAPA AMIs with the NVIDIA drivers, which are looked up
+	// first for the instance types with NVIDIA GPUs when no AMI name format is set.
+	DefaultGPUAmiNameFormat = "capa-ami-{{.BaseOS}}-gpu-?{{.K8sVersion}}-*"
+
+	gpuManufacturerNVIDIA = "NVIDIA"
+	gpuManufacturerAMD    = "AMD"
+
 	// Amazon's AMI timestamp format.
 	createDateTimestampFormat = "2006-01-02T15:04:05.000Z"
 
@@ -76,6 +83,12 @@ const (
 
 	// EKS GPU AMI ID SSM Parameter name.
 	eksGPUAmiSSMParameterFormat = "/aws/service/eks/optimized-ami/%s/amazon-linux-2-gpu/recommended/image_id"
+
+	// Bottlerocket AMI ID SSM Parameter name, formatted with the variant and the architecture.
+	bottlerocketAmiSSMParameterFormat = "/aws/service/bottlerocket/%s/%s/latest/image_id"
+
+	// Bottlerocket variant used when none is set, formatted with the Kubernetes version.
+	bottlerocketDefaultVariantFormat = "aws-k8s-%s"
 )
 
 // AMILookup contains the parameters used to template AMI names used for lookup.
@@ -103,18 +116,18 @@ func GenerateAmiName(amiNameFormat, baseOS, kubernetesVersion string) (string, e
 	return templateBytes.String(), nil
 }
 
-// Determine architecture based on instance type.
-func (s *Service) pickArchitectureForInstanceType(instanceType string) (string, error) {
+// Determine architecture and accelerator based on instance type.
+func (s *Service) pickArchitectureForInstanceType(instanceType string) (string, infrav1.AcceleratorType, error) {
 	descInstanceTypeInput := &ec2.DescribeInstanceTypesInput{
 		InstanceTypes: []*string{&instanceType},
 	}
 	describeInstanceTypeResult, err := s.EC2Client.DescribeInstanceTypes(descInstanceTypeInput)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	if len(describeInstanceTypeResult.InstanceTypes) == 0 {
-		return "", fmt.Errorf("instance type result empty for type %q", instanceType)
+		return "", "", fmt.Errorf("instance type result empty for type %q", instanceType)
 	}
 
 	supportedArchs := describeInstanceTypeResult.InstanceTypes[0].ProcessorInfo.SupportedArchitectures
@@ -137,12 +150,33 @@ archCheck:
 	}
 
 	if architecture == "" {
-		return "", fmt.Errorf("unable to find preferred architecture for instance type %q", instanceType)
+		return "", "", fmt.Errorf("unable to find preferred architecture for instance type %q", instanceType)
 	}
 
-	logger.Info("Chosen architecture", "architecture", architecture)
+	accelerator := instanceTypeAccelerator(describeInstanceTypeResult.InstanceTypes[0])
+
+	logger.Info("Chosen architecture", "architecture", architecture, "accelerator", accelerator)
 
-	return architecture, nil
+	return architecture, accelerator, nil
+}
+
+// instanceTypeAccelerator returns the accelerator type of an instance type from the manufacturer of its GPUs, or
+// else from its family for the AWS Inferentia and Trainium accelerators that aren't all described.
+func instanceTypeAccelerator(info *ec2.InstanceTypeInfo) infrav1.AcceleratorType {
+	if info.GpuInfo != nil {
+		for _, gpu := range info.GpuInfo.Gpus {
+			switch aws.StringValue(gpu.Manufacturer) {
+			case gpuManufacturerNVIDIA:
+				return infrav1.AcceleratorTypeNVIDIAGPU
+			case gpuManufacturerAMD:
+				return infrav1.AcceleratorTypeAMDGPU
+			}
+		}
+	}
+	if info.InferenceAcceleratorInfo != nil && len(info.InferenceAcceleratorInfo.Accelerators) > 0 {
+		return infrav1.AcceleratorTypeNeuron
+	}
+	return infrav1.InstanceTypeAccelerator(aws.StringValue(info.InstanceType))
 }
 
 // DefaultAMILookup will do a default AMI lookup.
@@ -201,8 +235,18 @@ func DefaultAMILookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVers
 	return latestImage, nil
 }
 
-// defaultAMIIDLookup returns the default AMI based on region.
-func (s *Service) defaultAMIIDLookup(amiNameFormat, ownerID, baseOS, architecture, kubernetesVersion string) (string, error) {
+// defaultAMIIDLookup returns the default AMI based on region. The CAPA AMIs with the NVIDIA drivers are preferred for
+// the instance types with NVIDIA GPUs when neither the AMI name format nor its owner are set.
+func (s *Service) defaultAMIIDLookup(amiNameFormat, ownerID, baseOS, architecture string, accelerator infrav1.AcceleratorType, kubernetesVersion string) (string, error) {
+	if accelerator == infrav1.AcceleratorTypeNVIDIAGPU && amiNameFormat == "" && ownerID == "" {
+		gpuImage, err := DefaultAMILookup(s.EC2Client, ownerID, baseOS, kubernetesVersion, architecture, DefaultGPUAmiNameFormat)
+		if err == nil {
+			s.scope.Debug("Found and using an existing GPU AMI", "ami-id", aws.StringValue(gpuImage.ImageId))
+			return aws.StringValue(gpuImage.ImageId), nil
+		}
+		s.scope.Debug("No GPU AMI found, falling back to the default AMI", "reason", err.Error())
+	}
+
 	latestImage, err := DefaultAMILookup(s.EC2Client, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat)
 	if err != nil {
 		record.Eventf(s.scope.InfraCluster(), "FailedDescribeImages", "Failed to find ami for OS=%s, Architecture=%s and Kubernetes-version=%s: %v", baseOS, architecture, kubernetesVersion, err)
@@ -285,7 +329,45 @@ func (s *Service) defaultBastionAMILookup() (string, error) {
 	return *latestImage.ImageId, nil
 }
 
-func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, amiType *infrav1.EKSAMILookupType) (string, error) {
+// bastionAMILookup returns the latest available AMI matching the bastion AMI lookup.
+func (s *Service) bastionAMILookup(lookup *infrav1.BastionAMILookup) (string, error) {
+	architecture := lookup.Architecture
+	if architecture == "" {
+		architecture = Amd64ArchitectureTag
+	}
+	out, err := s.EC2Client.DescribeImages(&ec2.DescribeImagesInput{
+		Owners: aws.StringSlice(lookup.Owners),
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("name"),
+				Values: aws.StringSlice([]string{lookup.Name}),
+			},
+			{
+				Name:   aws.String("architecture"),
+				Values: aws.StringSlice([]string{architecture}),
+			},
+			{
+				Name:   aws.String("state"),
+				Values: aws.StringSlice([]string{"available"}),
+			},
+		},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe images named %q within region: %q", lookup.Name, s.scope.Region())
+	}
+	if len(out.Images) == 0 {
+		return "", errors.Errorf("found no AMIs named %q owned by %v within the region: %q", lookup.Name, lookup.Owners, s.scope.Region())
+	}
+	latestImage, err := GetLatestImage(out.Images)
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(latestImage.ImageId), nil
+}
+
+// eksAMILookup returns the EKS optimized AMI of the lookup type, defaulting to the accelerated AMI for the x86_64
+// instance types with NVIDIA GPUs or AWS Inferentia and Trainium accelerators.
+func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, accelerator infrav1.AcceleratorType, amiType *infrav1.EKSAMILookupType) (string, error) {
 	// format ssm parameter path properly
 	formattedVersion, err := formatVersionForEKS(kubernetesVersion)
 	if err != nil {
@@ -296,10 +378,19 @@ func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, am
 
 	if amiType == nil {
 		amiType = new(infrav1.EKSAMILookupType)
+		if architecture == Amd64ArchitectureTag && (accelerator == infrav1.AcceleratorTypeNVIDIAGPU || accelerator == infrav1.AcceleratorTypeNeuron) {
+			*amiType = infrav1.AmazonLinuxGPU
+		}
 	}
 
 	switch *amiType {
 	case infrav1.AmazonLinuxGPU:
+		if architecture != Amd64ArchitectureTag {
+			return "", fmt.Errorf("cannot look up eks-optimized %s image for architecture %q", infrav1.AmazonLinuxGPU, architecture)
+		}
+		if accelerator == infrav1.AcceleratorTypeAMDGPU {
+			return "", fmt.Errorf("cannot look up eks-optimized %s image for instance types with AMD GPUs", infrav1.AmazonLinuxGPU)
+		}
 		paramName = fmt.Sprintf(eksGPUAmiSSMParameterFormat, formattedVersion)
 	default:
 		switch architecture {
@@ -333,6 +424,37 @@ func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, am
 	return id, nil
 }
 
+// bottlerocketAMILookup returns the latest AMI of the Bottlerocket variant, defaulting to
+// the aws-k8s variant of the given Kubernetes version.
+func (s *Service) bottlerocketAMILookup(variant, kubernetesVersion, architecture string) (string, error) {
+	if variant == "" {
+		formattedVersion, err := formatVersionForEKS(kubernetesVersion)
+		if err != nil {
+			return "", err
+		}
+		variant = fmt.Sprintf(bottlerocketDefaultVariantFormat, formattedVersion)
+	}
+
+	paramName := fmt.Sprintf(bottlerocketAmiSSMParameterFormat, variant, architecture)
+	out, err := s.SSMClient.GetParameter(&ssm.GetParameterInput{
+		Name: aws.String(paramName),
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedGetParameter", "Failed to get ami SSM parameter %q: %v", paramName, err)
+
+		return "", errors.Wrapf(err, "failed to get ami SSM parameter: %q", paramName)
+	}
+
+	if out.Parameter == nil || out.Parameter.Value == nil {
+		return "", errors.Errorf("SSM parameter returned with nil value: %q", paramName)
+	}
+
+	id := aws.StringValue(out.Parameter.Value)
+	s.scope.Info("found AMI", "id", id, "variant", variant)
+
+	return id, nil
+}
+
 func formatVersionForEKS(version string) (string, error) {
 	parsed, err := semver.ParseTolerant(version)
 	if err != nil {
@@ -17,6 +17,7 @@ limitations under the License.
 package ec2
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -212,9 +213,10 @@ func TestAMIs(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	testCases := []struct {
-		name   string
-		expect func(m *mocks.MockEC2APIMockRecorder)
-		check  func(g *WithT, id string, err error)
+		name        string
+		accelerator infrav1.AcceleratorType
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		check       func(g *WithT, id string, err error)
 	}{
 		{
 			name: "Should return latest AMI in case of valid inputs",
@@ -268,6 +270,46 @@ func TestAMIs(t *testing.T) {
 				g.Expect(id).Should(BeEmpty())
 			},
 		},
+		{
+			name:        "Should return the GPU AMI for instance types with NVIDIA GPUs",
+			accelerator: infrav1.AcceleratorTypeNVIDIAGPU,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImages(amiNameFilter("capa-ami-base os-baseos version-gpu-?1.11.1-*")).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								ImageId:      aws.String("gpu"),
+								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+			},
+			check: func(g *WithT, id string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(id).Should(Equal("gpu"))
+			},
+		},
+		{
+			name:        "Should fall back to the default AMI when there is no GPU AMI",
+			accelerator: infrav1.AcceleratorTypeNVIDIAGPU,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImages(amiNameFilter("capa-ami-base os-baseos version-gpu-?1.11.1-*")).
+					Return(&ec2.DescribeImagesOutput{}, nil)
+				m.DescribeImages(amiNameFilter("capa-ami-base os-baseos version-?1.11.1-*")).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								ImageId:      aws.String("default"),
+								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+			},
+			check: func(g *WithT, id string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(id).Should(Equal("default"))
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -287,7 +329,7 @@ func TestAMIs(t *testing.T) {
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
 
-			id, err := s.defaultAMIIDLookup("", "", "base os-baseos version", "x86_64", "v1.11.1")
+			id, err := s.defaultAMIIDLookup("", "", "base os-baseos version", "x86_64", tc.accelerator, "v1.11.1")
 			tc.check(g, id, err)
 		})
 	}
@@ -479,14 +521,16 @@ func TestEKSAMILookUp(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	gpuAMI := infrav1.AmazonLinuxGPU
+	defaultAMI := infrav1.AmazonLinux
 	tests := []struct {
-		name       string
-		k8sVersion string
-		arch       string
-		amiType    *infrav1.EKSAMILookupType
-		expect     func(m *mock_ssmiface.MockSSMAPIMockRecorder)
-		want       string
-		wantErr    bool
+		name        string
+		k8sVersion  string
+		arch        string
+		accelerator infrav1.AcceleratorType
+		amiType     *infrav1.EKSAMILookupType
+		expect      func(m *mock_ssmiface.MockSSMAPIMockRecorder)
+		want        string
+		wantErr     bool
 	}{
 		{
 			name:       "Should return an id corresponding to GPU if GPU based AMI type passed",
@@ -521,6 +565,86 @@ func TestEKSAMILookUp(t *testing.T) {
 			want:    "id",
 			wantErr: false,
 		},
+		{
+			name:        "Should return an id corresponding to GPU for instance types with NVIDIA GPUs",
+			k8sVersion:  "v1.23.3",
+			arch:        "x86_64",
+			accelerator: infrav1.AcceleratorTypeNVIDIAGPU,
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String("/aws/service/eks/optimized-ami/1.23/amazon-linux-2-gpu/recommended/image_id"),
+				})).Return(&ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{
+						Value: aws.String("id"),
+					},
+				}, nil)
+			},
+			want: "id",
+		},
+		{
+			name:        "Should return an id corresponding to GPU for instance types with Inferentia accelerators",
+			k8sVersion:  "v1.23.3",
+			arch:        "x86_64",
+			accelerator: infrav1.AcceleratorTypeNeuron,
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String("/aws/service/eks/optimized-ami/1.23/amazon-linux-2-gpu/recommended/image_id"),
+				})).Return(&ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{
+						Value: aws.String("id"),
+					},
+				}, nil)
+			},
+			want: "id",
+		},
+		{
+			name:        "Should return an id corresponding to arm64 for arm64 instance types with NVIDIA GPUs",
+			k8sVersion:  "v1.23.3",
+			arch:        "arm64",
+			accelerator: infrav1.AcceleratorTypeNVIDIAGPU,
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String("/aws/service/eks/optimized-ami/1.23/amazon-linux-2-arm64/recommended/image_id"),
+				})).Return(&ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{
+						Value: aws.String("id"),
+					},
+				}, nil)
+			},
+			want: "id",
+		},
+		{
+			name:        "Should return an id not corresponding to GPU if AmazonLinux AMI type is passed for instance types with GPUs",
+			k8sVersion:  "v1.23.3",
+			arch:        "x86_64",
+			accelerator: infrav1.AcceleratorTypeNVIDIAGPU,
+			amiType:     &defaultAMI,
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String("/aws/service/eks/optimized-ami/1.23/amazon-linux-2/recommended/image_id"),
+				})).Return(&ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{
+						Value: aws.String("id"),
+					},
+				}, nil)
+			},
+			want: "id",
+		},
+		{
+			name:       "Should return an error if GPU based AMI type passed for arm64",
+			k8sVersion: "v1.23.3",
+			arch:       "arm64",
+			amiType:    &gpuAMI,
+			wantErr:    true,
+		},
+		{
+			name:        "Should return an error if GPU based AMI type passed for instance types with AMD GPUs",
+			k8sVersion:  "v1.23.3",
+			arch:        "x86_64",
+			accelerator: infrav1.AcceleratorTypeAMDGPU,
+			amiType:     &gpuAMI,
+			wantErr:     true,
+		},
 		{
 			name:       "Should return an error if GetParameter call fails with some AWS error",
 			k8sVersion: "v1.23.3",
@@ -569,7 +693,96 @@ func TestEKSAMILookUp(t *testing.T) {
 			s := NewService(clusterScope)
 			s.SSMClient = ssmMock
 
-			got, err := s.eksAMILookup(tt.k8sVersion, tt.arch, tt.amiType)
+			got, err := s.eksAMILookup(tt.k8sVersion, tt.arch, tt.accelerator, tt.amiType)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(got).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestBottlerocketAMILookup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name       string
+		variant    string
+		k8sVersion string
+		arch       string
+		expect     func(m *mock_ssmiface.MockSSMAPIMockRecorder)
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "Should default the variant from the Kubernetes version",
+			k8sVersion: "v1.26.4",
+			arch:       "x86_64",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String("/aws/service/bottlerocket/aws-k8s-1.26/x86_64/latest/image_id"),
+				})).Return(&ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{
+						Value: aws.String("id"),
+					},
+				}, nil)
+			},
+			want: "id",
+		},
+		{
+			name:       "Should use the given variant and architecture",
+			variant:    "aws-k8s-1.26-nvidia",
+			k8sVersion: "v1.26.4",
+			arch:       "arm64",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String("/aws/service/bottlerocket/aws-k8s-1.26-nvidia/arm64/latest/image_id"),
+				})).Return(&ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{
+						Value: aws.String("nvidia-id"),
+					},
+				}, nil)
+			},
+			want: "nvidia-id",
+		},
+		{
+			name:       "Should return an error if invalid Kubernetes version passed without a variant",
+			k8sVersion: "__$__",
+			arch:       "x86_64",
+			wantErr:    true,
+		},
+		{
+			name:       "Should return an error if no SSM parameter found",
+			k8sVersion: "v1.26.4",
+			arch:       "x86_64",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Any()).Return(&ssm.GetParameterOutput{}, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+			if tt.expect != nil {
+				tt.expect(ssmMock.EXPECT())
+			}
+
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(clusterScope)
+			s.SSMClient = ssmMock
+
+			got, err := s.bottlerocketAMILookup(tt.variant, tt.k8sVersion, tt.arch)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -578,3 +791,27 @@ func TestEKSAMILookUp(t *testing.T) {
 		})
 	}
 }
+
+// amiNameFilter matches the DescribeImages calls looking up the AMIs with a name.
+func amiNameFilter(name string) gomock.Matcher {
+	return amiNameMatcher(name)
+}
+
+type amiNameMatcher string
+
+func (m amiNameMatcher) Matches(x interface{}) bool {
+	input, ok := x.(*ec2.DescribeImagesInput)
+	if !ok {
+		return false
+	}
+	for _, f := range input.Filters {
+		if aws.StringValue(f.Name) == "name" && len(f.Values) == 1 && aws.StringValue(f.Values[0]) == string(m) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m amiNameMatcher) String() string {
+	return fmt.Sprintf("looks up AMIs named %q", string(m))
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/pkg/errors"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -47,6 +48,10 @@ var (
 func (s *Service) ReconcileBastion() error {
 	if !s.scope.Bastion().Enabled {
 		s.scope.Trace("Skipping bastion reconcile")
+		if err := s.deleteInstanceConnectEndpoint(); err != nil {
+			return err
+		}
+		s.scope.SetBastionConnection(nil)
 		_, err := s.describeBastionInstance()
 		if err != nil {
 			if awserrors.IsNotFound(err) {
@@ -63,8 +68,19 @@ func (s *Service) ReconcileBastion() error {
 	if len(subnets.FilterPrivate()) == 0 {
 		s.scope.Debug("No private subnets available, skipping bastion host")
 		return nil
-	} else if len(subnets.FilterPublic()) == 0 {
+	}
+
+	if s.scope.Bastion().IsInstanceConnectEndpoint() {
+		return s.reconcileInstanceConnectEndpoint()
+	}
+	if err := s.deleteInstanceConnectEndpoint(); err != nil {
+		return err
+	}
+
+	if len(subnets.FilterPublic()) == 0 {
 		return errors.New("failed to reconcile bastion host, no public subnets are available")
+	} else if len(subnets.FilterPublic().FilterPlacement(s.scope.Bastion().Placement)) == 0 {
+		return errors.New("failed to reconcile bastion host, no public subnets match the bastion placement")
 	}
 
 	// Describe bastion instance, if any.
@@ -81,6 +97,10 @@ func (s *Service) ReconcileBastion() error {
 			record.Warnf(s.scope.InfraCluster(), "FailedFetchingBastion", "Failed to fetch default bastion instance: %v", err)
 			return err
 		}
+		if err := applySecurityProfile(s.scope.SecurityProfile(), defaultBastion); err != nil {
+			return errors.Wrap(err, "failed to apply security profile to bastion instance")
+		}
+		applyDefaultEBSKMSKey(s.scope.DefaultEBSKMSKeyID(), defaultBastion)
 		instance, err = s.runInstance("bastion", defaultBastion)
 		if err != nil {
 			record.Warnf(s.scope.InfraCluster(), "FailedCreateBastion", "Failed to create bastion instance: %v", err)
@@ -95,15 +115,31 @@ func (s *Service) ReconcileBastion() error {
 
 	// TODO(vincepri): check for possible changes between the default spec and the instance.
 
+	if pool := s.scope.Bastion().ElasticIPPool; pool != nil {
+		publicIP, err := s.associateBastionAddress(instance, pool)
+		if err != nil {
+			return err
+		}
+		instance.PublicIP = aws.String(publicIP)
+	}
+
 	s.scope.SetBastionInstance(instance.DeepCopy())
+	s.scope.SetBastionConnection(s.bastionConnection(instance))
 	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition)
 	s.scope.Debug("Reconcile bastion completed successfully")
 
 	return nil
 }
 
-// DeleteBastion deletes the Bastion instance.
+// DeleteBastion deletes the Bastion instance and the EC2 Instance Connect Endpoint of the cluster.
 func (s *Service) DeleteBastion() error {
+	if err := s.deleteInstanceConnectEndpoint(); err != nil {
+		return err
+	}
+	return s.deleteBastionInstance()
+}
+
+func (s *Service) deleteBastionInstance() error {
 	instance, err := s.describeBastionInstance()
 	if err != nil {
 		if awserrors.IsNotFound(err) {
@@ -125,6 +161,7 @@ func (s *Service) DeleteBastion() error {
 	}
 
 	s.scope.SetBastionInstance(nil)
+	s.scope.SetBastionConnection(nil)
 
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 	record.Eventf(s.scope.InfraCluster(), "SuccessfulTerminateBastion", "Terminated bastion instance %q", instance.ID)
@@ -133,6 +170,86 @@ func (s *Service) DeleteBastion() error {
 	return nil
 }
 
+// bastionConnection returns the connection details of the bastion instance. SSM
+// availability is only looked up for running instances, and a failed lookup is
+// reported as unavailable rather than failing the reconciliation.
+func (s *Service) bastionConnection(instance *infrav1.Instance) *infrav1.BastionConnection {
+	connection := &infrav1.BastionConnection{
+		InstanceID:       instance.ID,
+		AvailabilityZone: instance.AvailabilityZone,
+		PublicIP:         aws.StringValue(instance.PublicIP),
+		PrivateIP:        aws.StringValue(instance.PrivateIP),
+	}
+	if instance.State != infrav1.InstanceStateRunning {
+		return connection
+	}
+
+	out, err := s.SSMClient.DescribeInstanceInformation(&ssm.DescribeInstanceInformationInput{
+		Filters: []*ssm.InstanceInformationStringFilter{
+			{
+				Key:    aws.String("InstanceIds"),
+				Values: aws.StringSlice([]string{instance.ID}),
+			},
+		},
+	})
+	if err != nil {
+		s.scope.Debug("Unable to check SSM agent status of bastion host", "id", instance.ID, "error", err)
+		return connection
+	}
+	for _, info := range out.InstanceInformationList {
+		if aws.StringValue(info.InstanceId) == instance.ID && aws.StringValue(info.PingStatus) == ssm.PingStatusOnline {
+			connection.SSMAvailable = true
+		}
+	}
+	return connection
+}
+
+// associateBastionAddress associates an unassociated Elastic IP of the pool with the bastion
+// instance, unless one of them already is, and returns its public IP.
+func (s *Service) associateBastionAddress(instance *infrav1.Instance, pool *infrav1.ElasticIPPool) (string, error) {
+	out, err := s.EC2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: filter.EC2.ElasticIPPool(pool),
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeAddresses", "Failed to query addresses of bastion Elastic IP pool: %v", err)
+		return "", errors.Wrap(err, "failed to query addresses of bastion Elastic IP pool")
+	}
+
+	available := map[string]*ec2.Address{}
+	ids := []string{}
+	for _, address := range out.Addresses {
+		if aws.StringValue(address.InstanceId) == instance.ID {
+			return aws.StringValue(address.PublicIp), nil
+		}
+		if address.AssociationId == nil {
+			available[aws.StringValue(address.AllocationId)] = address
+			ids = append(ids, aws.StringValue(address.AllocationId))
+		}
+	}
+	if len(ids) == 0 {
+		record.Warnf(s.scope.InfraCluster(), "InsufficientElasticIPPool", "Bastion Elastic IP pool has no unassociated addresses")
+		return "", errors.New("bastion Elastic IP pool has no unassociated addresses")
+	}
+
+	// Addresses can only be associated with instances that have finished launching.
+	if instance.State != infrav1.InstanceStateRunning && instance.State != infrav1.InstanceStateStopped {
+		return "", errors.Errorf("bastion instance %q is %s, waiting for it to run before associating its Elastic IP", instance.ID, instance.State)
+	}
+
+	pool.SortAllocationIDs(ids)
+	address := available[ids[0]]
+	if _, err := s.EC2Client.AssociateAddress(&ec2.AssociateAddressInput{
+		AllocationId: address.AllocationId,
+		InstanceId:   aws.String(instance.ID),
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAssociateEIP", "Failed to associate Elastic IP %q with bastion instance %q: %v", ids[0], instance.ID, err)
+		return "", errors.Wrapf(err, "failed to associate Elastic IP %q with bastion instance %q", ids[0], instance.ID)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateEIP", "Associated Elastic IP %q with bastion instance %q", ids[0], instance.ID)
+
+	return aws.StringValue(address.PublicIp), nil
+}
+
 func (s *Service) describeBastionInstance() (*infrav1.Instance, error) {
 	input := &ec2.DescribeInstancesInput{
 		Filters: []*ec2.Filter{
@@ -168,7 +285,11 @@ func (s *Service) describeBastionInstance() (*infrav1.Instance, error) {
 
 func (s *Service) getDefaultBastion(instanceType, ami string) (*infrav1.Instance, error) {
 	name := fmt.Sprintf("%s-bastion", s.scope.Name())
-	userData, _ := userdata.NewBastion(&userdata.BastionInput{})
+	bastion := s.scope.Bastion()
+	userData := bastion.UserData
+	if userData == "" {
+		userData, _ = userdata.NewBastion(&userdata.BastionInput{Region: s.scope.Region()})
+	}
 
 	// If SSHKeyName WAS NOT provided, use the defaultSSHKeyName
 	keyName := s.scope.SSHKeyName()
@@ -176,7 +297,7 @@ func (s *Service) getDefaultBastion(instanceType, ami string) (*infrav1.Instance
 		keyName = aws.String(defaultSSHKeyName)
 	}
 
-	subnet := s.scope.Subnets().FilterPublic()[0]
+	subnet := s.scope.Subnets().FilterPublic().FilterPlacement(s.scope.Bastion().Placement)[0]
 
 	if instanceType == "" {
 		if strings.Contains(subnet.AvailabilityZone, "us-east-1") {
@@ -188,21 +309,32 @@ func (s *Service) getDefaultBastion(instanceType, ami string) (*infrav1.Instance
 
 	if ami == "" {
 		var err error
-		ami, err = s.defaultBastionAMILookup()
+		if bastion.AMILookup != nil {
+			ami, err = s.bastionAMILookup(bastion.AMILookup)
+		} else {
+			ami, err = s.defaultBastionAMILookup()
+		}
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	securityGroupIDs := []string{s.scope.Network().SecurityGroups[infrav1.SecurityGroupBastion].ID}
+	additionalIDs, err := s.GetAdditionalSecurityGroupsIDs(bastion.AdditionalSecurityGroups)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get additional security groups of bastion")
+	}
+	securityGroupIDs = append(securityGroupIDs, additionalIDs...)
+
 	i := &infrav1.Instance{
-		Type:       instanceType,
-		SubnetID:   subnet.ID,
-		ImageID:    ami,
-		SSHKeyName: keyName,
-		UserData:   aws.String(base64.StdEncoding.EncodeToString([]byte(userData))),
-		SecurityGroupIDs: []string{
-			s.scope.Network().SecurityGroups[infrav1.SecurityGroupBastion].ID,
-		},
+		Type:             instanceType,
+		SubnetID:         subnet.ID,
+		ImageID:          ami,
+		SSHKeyName:       keyName,
+		IAMProfile:       bastion.IAMInstanceProfile,
+		UserData:         aws.String(base64.StdEncoding.EncodeToString([]byte(userData))),
+		SecurityGroupIDs: securityGroupIDs,
+		RootVolume:       bastion.RootVolume.DeepCopy(),
 		Tags: infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.scope.Name(),
 			Lifecycle:   infrav1.ResourceLifecycleOwned,
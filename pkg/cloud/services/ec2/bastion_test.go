@@ -23,6 +23,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -32,6 +33,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ssm/mock_ssmiface"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -261,11 +263,15 @@ func TestServiceReconcileBastion(t *testing.T) {
 	}
 
 	tests := []struct {
-		name           string
-		bastionEnabled bool
-		expect         func(m *mocks.MockEC2APIMockRecorder)
-		expectError    bool
-		bastionStatus  *infrav1.Instance
+		name              string
+		bastionEnabled    bool
+		expect            func(m *mocks.MockEC2APIMockRecorder)
+		ssmExpect         func(m *mock_ssmiface.MockSSMAPIMockRecorder)
+		expectError       bool
+		bastionStatus     *infrav1.Instance
+		bastionConnection *infrav1.BastionConnection
+		elasticIPPool     *infrav1.ElasticIPPool
+		placement         *infrav1.SubnetPlacement
 	}{
 		{
 			name: "Should ignore reconciliation if instance not found",
@@ -301,6 +307,36 @@ func TestServiceReconcileBastion(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Should report SSM availability of an existing bastion",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.Eq(describeInput)).
+					Return(foundOutput, nil)
+			},
+			ssmExpect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.DescribeInstanceInformation(gomock.Any()).
+					Return(&ssm.DescribeInstanceInformationOutput{
+						InstanceInformationList: []*ssm.InstanceInformation{
+							{
+								InstanceId: aws.String("id123"),
+								PingStatus: aws.String(ssm.PingStatusOnline),
+							},
+						},
+					}, nil)
+			},
+			bastionEnabled: true,
+			bastionStatus: &infrav1.Instance{
+				ID:               "id123",
+				State:            "running",
+				Addresses:        []clusterv1.MachineAddress{},
+				AvailabilityZone: "us-east-1",
+			},
+			bastionConnection: &infrav1.BastionConnection{
+				InstanceID:       "id123",
+				AvailabilityZone: "us-east-1",
+				SSMAvailable:     true,
+			},
+		},
 		{
 			name: "Should create bastion successfully",
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
@@ -367,6 +403,16 @@ func TestServiceReconcileBastion(t *testing.T) {
 						},
 					}, nil)
 			},
+			ssmExpect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.DescribeInstanceInformation(gomock.Eq(&ssm.DescribeInstanceInformationInput{
+					Filters: []*ssm.InstanceInformationStringFilter{
+						{
+							Key:    aws.String("InstanceIds"),
+							Values: aws.StringSlice([]string{"id123"}),
+						},
+					},
+				})).Return(&ssm.DescribeInstanceInformationOutput{}, nil)
+			},
 			bastionEnabled: true,
 			expectError:    false,
 			bastionStatus: &infrav1.Instance{
@@ -380,6 +426,113 @@ func TestServiceReconcileBastion(t *testing.T) {
 				AvailabilityZone: "us-east-1",
 				VolumeIDs:        []string{"volume-1"},
 			},
+			bastionConnection: &infrav1.BastionConnection{
+				InstanceID:       "id123",
+				AvailabilityZone: "us-east-1",
+			},
+		},
+		{
+			name:           "Should fail reconcile if no public subnet matches the bastion placement",
+			bastionEnabled: true,
+			placement:      &infrav1.SubnetPlacement{SubnetIDs: []string{"subnet-3"}},
+			expect:         func(m *mocks.MockEC2APIMockRecorder) {},
+			expectError:    true,
+		},
+		{
+			name:           "Should associate the first unassociated Elastic IP of the pool with the bastion",
+			bastionEnabled: true,
+			elasticIPPool:  &infrav1.ElasticIPPool{AllocationIDs: []string{"eipalloc-1", "eipalloc-2", "eipalloc-3"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.Eq(describeInput)).
+					Return(foundOutput, nil)
+				m.DescribeAddresses(gomock.Eq(&ec2.DescribeAddressesInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("allocation-id"),
+							Values: aws.StringSlice([]string{"eipalloc-1", "eipalloc-2", "eipalloc-3"}),
+						},
+					},
+				})).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{
+						{AllocationId: aws.String("eipalloc-3"), PublicIp: aws.String("203.0.113.3")},
+						{AllocationId: aws.String("eipalloc-2"), PublicIp: aws.String("203.0.113.2")},
+						{AllocationId: aws.String("eipalloc-1"), PublicIp: aws.String("203.0.113.1"), AssociationId: aws.String("eipassoc-1"), InstanceId: aws.String("other")},
+					},
+				}, nil)
+				m.AssociateAddress(gomock.Eq(&ec2.AssociateAddressInput{
+					AllocationId: aws.String("eipalloc-2"),
+					InstanceId:   aws.String("id123"),
+				})).Return(&ec2.AssociateAddressOutput{}, nil)
+			},
+			ssmExpect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.DescribeInstanceInformation(gomock.Any()).
+					Return(&ssm.DescribeInstanceInformationOutput{}, nil)
+			},
+			bastionStatus: &infrav1.Instance{
+				ID:               "id123",
+				State:            "running",
+				Addresses:        []clusterv1.MachineAddress{},
+				AvailabilityZone: "us-east-1",
+				PublicIP:         aws.String("203.0.113.2"),
+			},
+			bastionConnection: &infrav1.BastionConnection{
+				InstanceID:       "id123",
+				AvailabilityZone: "us-east-1",
+				PublicIP:         "203.0.113.2",
+			},
+		},
+		{
+			name:           "Should keep the Elastic IP of the pool already associated with the bastion",
+			bastionEnabled: true,
+			elasticIPPool:  &infrav1.ElasticIPPool{Tags: infrav1.Tags{"egress": "bastion"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.Eq(describeInput)).
+					Return(foundOutput, nil)
+				m.DescribeAddresses(gomock.Eq(&ec2.DescribeAddressesInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("tag:egress"),
+							Values: aws.StringSlice([]string{"bastion"}),
+						},
+					},
+				})).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{
+						{AllocationId: aws.String("eipalloc-1"), PublicIp: aws.String("203.0.113.1")},
+						{AllocationId: aws.String("eipalloc-2"), PublicIp: aws.String("203.0.113.2"), AssociationId: aws.String("eipassoc-2"), InstanceId: aws.String("id123")},
+					},
+				}, nil)
+			},
+			ssmExpect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.DescribeInstanceInformation(gomock.Any()).
+					Return(&ssm.DescribeInstanceInformationOutput{}, nil)
+			},
+			bastionStatus: &infrav1.Instance{
+				ID:               "id123",
+				State:            "running",
+				Addresses:        []clusterv1.MachineAddress{},
+				AvailabilityZone: "us-east-1",
+				PublicIP:         aws.String("203.0.113.2"),
+			},
+			bastionConnection: &infrav1.BastionConnection{
+				InstanceID:       "id123",
+				AvailabilityZone: "us-east-1",
+				PublicIP:         "203.0.113.2",
+			},
+		},
+		{
+			name:           "Should fail reconcile if the Elastic IP pool is exhausted",
+			bastionEnabled: true,
+			elasticIPPool:  &infrav1.ElasticIPPool{AllocationIDs: []string{"eipalloc-1"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.Eq(describeInput)).
+					Return(foundOutput, nil)
+				m.DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{
+						{AllocationId: aws.String("eipalloc-1"), PublicIp: aws.String("203.0.113.1"), AssociationId: aws.String("eipassoc-1"), InstanceId: aws.String("other")},
+					},
+				}, nil)
+			},
+			expectError: true,
 		},
 	}
 
@@ -395,6 +548,7 @@ func TestServiceReconcileBastion(t *testing.T) {
 				defer mockControl.Finish()
 
 				ec2Mock := mocks.NewMockEC2API(mockControl)
+				ssmMock := mock_ssmiface.NewMockSSMAPI(mockControl)
 
 				scheme, err := setupScheme()
 				g.Expect(err).To(BeNil())
@@ -416,7 +570,7 @@ func TestServiceReconcileBastion(t *testing.T) {
 								},
 							},
 						},
-						Bastion: infrav1.Bastion{Enabled: tc.bastionEnabled},
+						Bastion: infrav1.Bastion{Enabled: tc.bastionEnabled, ElasticIPPool: tc.elasticIPPool, Placement: tc.placement},
 					},
 				}
 
@@ -443,8 +597,12 @@ func TestServiceReconcileBastion(t *testing.T) {
 				}
 
 				tc.expect(ec2Mock.EXPECT())
+				if tc.ssmExpect != nil {
+					tc.ssmExpect(ssmMock.EXPECT())
+				}
 				s := NewService(scope)
 				s.EC2Client = ec2Mock
+				s.SSMClient = ssmMock
 
 				err = s.ReconcileBastion()
 				if tc.expectError {
@@ -455,7 +613,80 @@ func TestServiceReconcileBastion(t *testing.T) {
 				g.Expect(err).To(BeNil())
 
 				g.Expect(scope.AWSCluster.Status.Bastion).To(BeEquivalentTo(tc.bastionStatus))
+				g.Expect(scope.AWSCluster.Status.BastionConnection).To(BeEquivalentTo(tc.bastionConnection))
 			})
 		}
 	}
 }
+
+func TestServiceGetDefaultBastionCustomization(t *testing.T) {
+	g := NewWithT(t)
+
+	mockControl := gomock.NewController(t)
+	defer mockControl.Finish()
+	ec2Mock := mocks.NewMockEC2API(mockControl)
+
+	scheme, err := setupScheme()
+	g.Expect(err).To(BeNil())
+
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: infrav1.AWSClusterSpec{
+			NetworkSpec: infrav1.NetworkSpec{
+				Subnets: infrav1.Subnets{{ID: "subnet-1", IsPublic: true, AvailabilityZone: "us-west-2a"}},
+			},
+			Bastion: infrav1.Bastion{
+				Enabled:      true,
+				InstanceType: "t4g.small",
+				AMILookup: &infrav1.BastionAMILookup{
+					Name:         "hardened-al2023-*",
+					Owners:       []string{"self"},
+					Architecture: "arm64",
+				},
+				RootVolume:               &infrav1.Volume{Size: 20, Encrypted: aws.Bool(true)},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-audit")}},
+				UserData:                 "#!/bin/bash\nsystemctl enable --now amazon-ssm-agent\n",
+			},
+		},
+		Status: infrav1.AWSClusterStatus{
+			Network: infrav1.NetworkStatus{
+				SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+					infrav1.SecurityGroupBastion: {ID: "sg-bastion"},
+				},
+			},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cluster"}},
+		AWSCluster: awsCluster,
+		Client:     client,
+	})
+	g.Expect(err).To(BeNil())
+
+	ec2Mock.EXPECT().DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{
+		Owners: aws.StringSlice([]string{"self"}),
+		Filters: []*ec2.Filter{
+			{Name: aws.String("name"), Values: aws.StringSlice([]string{"hardened-al2023-*"})},
+			{Name: aws.String("architecture"), Values: aws.StringSlice([]string{"arm64"})},
+			{Name: aws.String("state"), Values: aws.StringSlice([]string{"available"})},
+		},
+	})).Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{
+		{ImageId: aws.String("ami-old"), CreationDate: aws.String("2024-01-01T00:00:00.000Z")},
+		{ImageId: aws.String("ami-new"), CreationDate: aws.String("2024-06-01T00:00:00.000Z")},
+	}}, nil)
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	bastion := scope.Bastion()
+	instance, err := s.getDefaultBastion(bastion.InstanceType, bastion.AMI)
+	g.Expect(err).To(BeNil())
+	g.Expect(instance.Type).To(Equal("t4g.small"))
+	g.Expect(instance.ImageID).To(Equal("ami-new"))
+	g.Expect(instance.SecurityGroupIDs).To(Equal([]string{"sg-bastion", "sg-audit"}))
+	g.Expect(instance.RootVolume).To(Equal(&infrav1.Volume{Size: 20, Encrypted: aws.Bool(true)}))
+	g.Expect(instance.RootVolume).ToNot(BeIdenticalTo(bastion.RootVolume))
+	g.Expect(aws.StringValue(instance.UserData)).To(Equal("IyEvYmluL2Jhc2gKc3lzdGVtY3RsIGVuYWJsZSAtLW5vdyBhbWF6b24tc3NtLWFnZW50Cg=="))
+}
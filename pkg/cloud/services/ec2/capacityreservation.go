@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+)
+
+// ValidateCapacityReservation verifies that the capacity reservation with the given ID is usable, and that it
+// reserves instances of the given type, at least as many as requested.
+func (s *Service) ValidateCapacityReservation(reservationID, instanceType string, instanceCount int64) error {
+	out, err := s.EC2Client.DescribeCapacityReservations(&ec2.DescribeCapacityReservationsInput{
+		CapacityReservationIds: aws.StringSlice([]string{reservationID}),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe capacity reservation %q", reservationID)
+	}
+	if len(out.CapacityReservations) == 0 {
+		return fmt.Errorf("capacity reservation %q not found", reservationID)
+	}
+
+	reservation := out.CapacityReservations[0]
+	switch state := aws.StringValue(reservation.State); state {
+	case ec2.CapacityReservationStateExpired, ec2.CapacityReservationStateCancelled, ec2.CapacityReservationStateFailed:
+		return fmt.Errorf("capacity reservation %q is %s", reservationID, state)
+	}
+	if reservedType := aws.StringValue(reservation.InstanceType); reservedType != instanceType {
+		return fmt.Errorf("capacity reservation %q reserves instance type %q, not %q", reservationID, reservedType, instanceType)
+	}
+	if total := aws.Int64Value(reservation.TotalInstanceCount); total < instanceCount {
+		return fmt.Errorf("capacity reservation %q reserves %d instances, fewer than the %d requested", reservationID, total, instanceCount)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestValidateCapacityReservation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	reservation := func(state string) []*ec2.CapacityReservation {
+		return []*ec2.CapacityReservation{{
+			CapacityReservationId: aws.String("cr-12345"),
+			InstanceType:          aws.String("p5.48xlarge"),
+			TotalInstanceCount:    aws.Int64(4),
+			State:                 aws.String(state),
+		}}
+	}
+
+	tests := []struct {
+		name          string
+		instanceType  string
+		instanceCount int64
+		reservations  []*ec2.CapacityReservation
+		wantErr       bool
+	}{
+		{
+			name:          "Should succeed with a matching reservation",
+			instanceType:  "p5.48xlarge",
+			instanceCount: 4,
+			reservations:  reservation(ec2.CapacityReservationStateActive),
+		},
+		{
+			name:          "Should succeed with a reservation which hasn't started yet",
+			instanceType:  "p5.48xlarge",
+			instanceCount: 2,
+			reservations:  reservation(ec2.CapacityReservationStatePending),
+		},
+		{
+			name:          "Should fail if the reservation doesn't exist",
+			instanceType:  "p5.48xlarge",
+			instanceCount: 2,
+			wantErr:       true,
+		},
+		{
+			name:          "Should fail if the reservation expired",
+			instanceType:  "p5.48xlarge",
+			instanceCount: 2,
+			reservations:  reservation(ec2.CapacityReservationStateExpired),
+			wantErr:       true,
+		},
+		{
+			name:          "Should fail if the reservation is for another instance type",
+			instanceType:  "p4d.24xlarge",
+			instanceCount: 2,
+			reservations:  reservation(ec2.CapacityReservationStateActive),
+			wantErr:       true,
+		},
+		{
+			name:          "Should fail if the reservation is for fewer instances",
+			instanceType:  "p5.48xlarge",
+			instanceCount: 5,
+			reservations:  reservation(ec2.CapacityReservationStateActive),
+			wantErr:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			clusterScope, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeCapacityReservations(&ec2.DescribeCapacityReservationsInput{
+				CapacityReservationIds: aws.StringSlice([]string{"cr-12345"}),
+			}).Return(&ec2.DescribeCapacityReservationsOutput{CapacityReservations: tc.reservations}, nil)
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err = s.ValidateCapacityReservation("cr-12345", tc.instanceType, tc.instanceCount)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// ApplyCleanupPolicy prepares the non root volumes and the secondary network interfaces of the instance for
+// its termination according to the cleanup policy of the machine: EC2 deletes the resources marked to be
+// deleted on termination with the instance, and detaches the other ones, which are retained.
+func (s *Service) ApplyCleanupPolicy(scope *scope.MachineScope, instanceID string) error {
+	policy := scope.AWSMachine.Spec.CleanupPolicy
+	if policy.VolumesAction() == "" && policy.NetworkInterfacesAction() == "" {
+		return nil
+	}
+
+	instance, err := s.describeInstance(instanceID)
+	if err != nil {
+		return err
+	}
+
+	if action := policy.VolumesAction(); action != "" {
+		if err := s.cleanupNonRootVolumes(scope, instance, action); err != nil {
+			return err
+		}
+	}
+
+	if action := policy.NetworkInterfacesAction(); action != "" {
+		if err := s.cleanupSecondaryNetworkInterfaces(instance, action); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cleanupNonRootVolumes sets the DeleteOnTermination attribute of the non root EBS volumes of the instance
+// according to action, after snapshotting them if requested.
+func (s *Service) cleanupNonRootVolumes(scope *scope.MachineScope, instance *ec2.Instance, action infrav1.CleanupAction) error {
+	instanceID := aws.StringValue(instance.InstanceId)
+	deleteOnTermination := action != infrav1.CleanupActionRetain
+
+	var deviceNames []string
+	input := &ec2.ModifyInstanceAttributeInput{
+		InstanceId: instance.InstanceId,
+	}
+	for _, mapping := range instance.BlockDeviceMappings {
+		if mapping.Ebs == nil || aws.StringValue(mapping.DeviceName) == aws.StringValue(instance.RootDeviceName) {
+			continue
+		}
+		deviceNames = append(deviceNames, aws.StringValue(mapping.DeviceName))
+		if aws.BoolValue(mapping.Ebs.DeleteOnTermination) == deleteOnTermination {
+			continue
+		}
+		input.BlockDeviceMappings = append(input.BlockDeviceMappings, &ec2.InstanceBlockDeviceMappingSpecification{
+			DeviceName: mapping.DeviceName,
+			Ebs: &ec2.EbsInstanceBlockDeviceSpecification{
+				DeleteOnTermination: aws.Bool(deleteOnTermination),
+			},
+		})
+	}
+
+	if action == infrav1.CleanupActionSnapshot && len(deviceNames) > 0 {
+		if err := s.snapshotVolumes(scope, instance, deviceNames); err != nil {
+			return err
+		}
+	}
+
+	if len(input.BlockDeviceMappings) == 0 {
+		return nil
+	}
+
+	if _, err := s.EC2Client.ModifyInstanceAttribute(input); err != nil {
+		return errors.Wrapf(err, "failed to modify the non root volumes of instance %q", instanceID)
+	}
+
+	s.scope.Debug("Applied cleanup policy to non root volumes of instance", "instance-id", instanceID, "action", action)
+	return nil
+}
+
+// cleanupSecondaryNetworkInterfaces sets the DeleteOnTermination attribute of the attachments of the network
+// interfaces of the instance, other than its primary network interface, according to action.
+func (s *Service) cleanupSecondaryNetworkInterfaces(instance *ec2.Instance, action infrav1.CleanupAction) error {
+	instanceID := aws.StringValue(instance.InstanceId)
+	deleteOnTermination := action != infrav1.CleanupActionRetain
+
+	for _, networkInterface := range instance.NetworkInterfaces {
+		attachment := networkInterface.Attachment
+		if attachment == nil || aws.Int64Value(attachment.DeviceIndex) == 0 {
+			continue
+		}
+		if aws.BoolValue(attachment.DeleteOnTermination) == deleteOnTermination {
+			continue
+		}
+
+		if _, err := s.EC2Client.ModifyNetworkInterfaceAttribute(&ec2.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: networkInterface.NetworkInterfaceId,
+			Attachment: &ec2.NetworkInterfaceAttachmentChanges{
+				AttachmentId:        attachment.AttachmentId,
+				DeleteOnTermination: aws.Bool(deleteOnTermination),
+			},
+		}); err != nil {
+			return errors.Wrapf(err, "failed to modify network interface %q of instance %q", aws.StringValue(networkInterface.NetworkInterfaceId), instanceID)
+		}
+	}
+
+	s.scope.Debug("Applied cleanup policy to secondary network interfaces of instance", "instance-id", instanceID, "action", action)
+	return nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestApplyCleanupPolicy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeInstance := func(m *mocks.MockEC2APIMockRecorder) {
+		m.DescribeInstances(gomock.Eq(&ec2.DescribeInstancesInput{
+			InstanceIds: []*string{aws.String("i-exist")},
+		})).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []*ec2.Reservation{{
+				Instances: []*ec2.Instance{{
+					InstanceId:     aws.String("i-exist"),
+					RootDeviceName: aws.String("/dev/sda1"),
+					BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+						{DeviceName: aws.String("/dev/sda1"), Ebs: &ec2.EbsInstanceBlockDevice{VolumeId: aws.String("vol-root"), DeleteOnTermination: aws.Bool(true)}},
+						{DeviceName: aws.String("/dev/sdb"), Ebs: &ec2.EbsInstanceBlockDevice{VolumeId: aws.String("vol-data"), DeleteOnTermination: aws.Bool(true)}},
+						{DeviceName: aws.String("/dev/sdc"), Ebs: &ec2.EbsInstanceBlockDevice{VolumeId: aws.String("vol-attached"), DeleteOnTermination: aws.Bool(false)}},
+					},
+					NetworkInterfaces: []*ec2.InstanceNetworkInterface{
+						{
+							NetworkInterfaceId: aws.String("eni-primary"),
+							Attachment:         &ec2.InstanceNetworkInterfaceAttachment{AttachmentId: aws.String("eni-attach-primary"), DeviceIndex: aws.Int64(0), DeleteOnTermination: aws.Bool(true)},
+						},
+						{
+							NetworkInterfaceId: aws.String("eni-created"),
+							Attachment:         &ec2.InstanceNetworkInterfaceAttachment{AttachmentId: aws.String("eni-attach-created"), DeviceIndex: aws.Int64(1), DeleteOnTermination: aws.Bool(true)},
+						},
+						{
+							NetworkInterfaceId: aws.String("eni-attached"),
+							Attachment:         &ec2.InstanceNetworkInterfaceAttachment{AttachmentId: aws.String("eni-attach-attached"), DeviceIndex: aws.Int64(2), DeleteOnTermination: aws.Bool(false)},
+						},
+					},
+				}},
+			}},
+		}, nil)
+	}
+	modifyVolumes := func(m *mocks.MockEC2APIMockRecorder, deleteOnTermination bool, deviceNames ...string) {
+		input := &ec2.ModifyInstanceAttributeInput{InstanceId: aws.String("i-exist")}
+		for _, deviceName := range deviceNames {
+			input.BlockDeviceMappings = append(input.BlockDeviceMappings, &ec2.InstanceBlockDeviceMappingSpecification{
+				DeviceName: aws.String(deviceName),
+				Ebs:        &ec2.EbsInstanceBlockDeviceSpecification{DeleteOnTermination: aws.Bool(deleteOnTermination)},
+			})
+		}
+		m.ModifyInstanceAttribute(gomock.Eq(input)).Return(&ec2.ModifyInstanceAttributeOutput{}, nil)
+	}
+	modifyNetworkInterface := func(m *mocks.MockEC2APIMockRecorder, id string, deleteOnTermination bool) {
+		m.ModifyNetworkInterfaceAttribute(gomock.Eq(&ec2.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: aws.String(id),
+			Attachment: &ec2.NetworkInterfaceAttachmentChanges{
+				AttachmentId:        aws.String("eni-attach-" + id[len("eni-"):]),
+				DeleteOnTermination: aws.Bool(deleteOnTermination),
+			},
+		})).Return(&ec2.ModifyNetworkInterfaceAttributeOutput{}, nil)
+	}
+	snapshot := func(m *mocks.MockEC2APIMockRecorder, volumeIDs ...string) {
+		var snapshotted []string
+		m.DescribeSnapshots(gomock.Any()).Return(&ec2.DescribeSnapshotsOutput{}, nil).Times(len(volumeIDs))
+		m.CreateSnapshot(gomock.Any()).DoAndReturn(func(input *ec2.CreateSnapshotInput) (*ec2.Snapshot, error) {
+			snapshotted = append(snapshotted, aws.StringValue(input.VolumeId))
+			if len(snapshotted) == len(volumeIDs) && !reflect.DeepEqual(snapshotted, volumeIDs) {
+				t.Errorf("expected snapshots of volumes %v, got %v", volumeIDs, snapshotted)
+			}
+			return &ec2.Snapshot{SnapshotId: input.VolumeId}, nil
+		}).Times(len(volumeIDs))
+	}
+
+	testCases := []struct {
+		name   string
+		policy *infrav1.CleanupPolicy
+		expect func(m *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name:   "does nothing without a cleanup policy",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:   "deletes non root volumes which aren't deleted on termination",
+			policy: &infrav1.CleanupPolicy{Volumes: infrav1.CleanupActionDelete},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeInstance(m)
+				modifyVolumes(m, true, "/dev/sdc")
+			},
+		},
+		{
+			name:   "retains non root volumes which are deleted on termination",
+			policy: &infrav1.CleanupPolicy{Volumes: infrav1.CleanupActionRetain},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeInstance(m)
+				modifyVolumes(m, false, "/dev/sdb")
+			},
+		},
+		{
+			name:   "snapshots non root volumes before deleting them",
+			policy: &infrav1.CleanupPolicy{Volumes: infrav1.CleanupActionSnapshot},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeInstance(m)
+				snapshot(m, "vol-data", "vol-attached")
+				modifyVolumes(m, true, "/dev/sdc")
+			},
+		},
+		{
+			name:   "deletes secondary network interfaces which aren't deleted on termination",
+			policy: &infrav1.CleanupPolicy{NetworkInterfaces: infrav1.CleanupActionDelete},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeInstance(m)
+				modifyNetworkInterface(m, "eni-attached", true)
+			},
+		},
+		{
+			name:   "retains secondary network interfaces which are deleted on termination",
+			policy: &infrav1.CleanupPolicy{NetworkInterfaces: infrav1.CleanupActionRetain},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeInstance(m)
+				modifyNetworkInterface(m, "eni-created", false)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}}
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    cluster,
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       client,
+				Cluster:      cluster,
+				Machine:      &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"}},
+				AWSMachine:   &infrav1.AWSMachine{ObjectMeta: metav1.ObjectMeta{Name: "aws-test-machine", Namespace: "default"}},
+				InfraCluster: clusterScope,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+			machineScope.AWSMachine.Spec.CleanupPolicy = tc.policy
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			if err := s.ApplyCleanupPolicy(machineScope, "i-exist"); err != nil {
+				t.Fatalf("ApplyCleanupPolicy() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileInstanceConnectEndpoint ensures an EC2 Instance Connect Endpoint is created for the cluster
+// in place of a bastion host instance. The endpoint takes a few minutes to be created, which is not
+// waited for so that the rest of the cluster infrastructure can be reconciled in the meantime.
+func (s *Service) reconcileInstanceConnectEndpoint() error {
+	if err := s.deleteBastionInstance(); err != nil {
+		return err
+	}
+
+	s.scope.Debug("Reconciling EC2 Instance Connect Endpoint")
+
+	subnets := s.scope.Subnets().FilterPrivate().FilterPlacement(s.scope.Bastion().Placement)
+	if len(subnets) == 0 {
+		return errors.New("failed to reconcile EC2 Instance Connect Endpoint, no private subnets match the bastion placement")
+	}
+
+	endpoint, err := s.describeInstanceConnectEndpoint()
+	if awserrors.IsNotFound(err) {
+		if !conditions.Has(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition) {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, infrav1.BastionCreationStartedReason, clusterv1.ConditionSeverityInfo, "")
+			if err := s.scope.PatchObject(); err != nil {
+				return errors.Wrap(err, "failed to patch conditions")
+			}
+		}
+		endpoint, err = s.createInstanceConnectEndpoint(subnets[0].ID)
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	id := aws.StringValue(endpoint.InstanceConnectEndpointId)
+	s.scope.SetBastionConnection(&infrav1.BastionConnection{
+		InstanceConnectEndpointID: id,
+		AvailabilityZone:          aws.StringValue(endpoint.AvailabilityZone),
+	})
+
+	switch state := aws.StringValue(endpoint.State); state {
+	case ec2.Ec2InstanceConnectEndpointStateCreateComplete:
+		conditions.MarkTrue(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition)
+		s.scope.Debug("Reconcile EC2 Instance Connect Endpoint completed successfully")
+	case ec2.Ec2InstanceConnectEndpointStateCreateFailed:
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateInstanceConnectEndpoint", "Failed to create EC2 Instance Connect Endpoint %q: %s", id, aws.StringValue(endpoint.StateMessage))
+		// Failed endpoints are deleted, so that the next reconciliation creates a new one.
+		if err := s.deleteInstanceConnectEndpointAndWait(endpoint); err != nil {
+			return err
+		}
+		s.scope.SetBastionConnection(nil)
+		return errors.Errorf("failed to create EC2 Instance Connect Endpoint %q: %s", id, aws.StringValue(endpoint.StateMessage))
+	default:
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, infrav1.BastionCreationStartedReason, clusterv1.ConditionSeverityInfo,
+			"EC2 Instance Connect Endpoint %q is %s", id, state)
+	}
+
+	return nil
+}
+
+// deleteInstanceConnectEndpoint deletes the EC2 Instance Connect Endpoint of the cluster. Only clusters
+// whose bastion is or was an endpoint look it up, so that the other clusters don't need to be allowed
+// to call the EC2 Instance Connect Endpoint API.
+func (s *Service) deleteInstanceConnectEndpoint() error {
+	connection := s.scope.BastionConnection()
+	recorded := connection != nil && connection.InstanceConnectEndpointID != ""
+	if !recorded && !s.scope.Bastion().IsInstanceConnectEndpoint() {
+		return nil
+	}
+
+	endpoint, err := s.describeInstanceConnectEndpoint()
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			s.scope.Trace("EC2 Instance Connect Endpoint does not exist")
+			if recorded {
+				s.scope.SetBastionConnection(nil)
+			}
+			return nil
+		}
+		return err
+	}
+
+	id := aws.StringValue(endpoint.InstanceConnectEndpointId)
+	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+	if err := s.scope.PatchObject(); err != nil {
+		return err
+	}
+
+	if err := s.deleteInstanceConnectEndpointAndWait(endpoint); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteInstanceConnectEndpoint", "Failed to delete EC2 Instance Connect Endpoint %q: %v", id, err)
+		return err
+	}
+
+	s.scope.SetBastionConnection(nil)
+
+	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteInstanceConnectEndpoint", "Deleted EC2 Instance Connect Endpoint %q", id)
+	s.scope.Info("Deleted EC2 Instance Connect Endpoint", "id", id)
+
+	return nil
+}
+
+func (s *Service) createInstanceConnectEndpoint(subnetID string) (*ec2.Ec2InstanceConnectEndpoint, error) {
+	securityGroupIDs := []string{s.scope.Network().SecurityGroups[infrav1.SecurityGroupBastion].ID}
+	additionalIDs, err := s.GetAdditionalSecurityGroupsIDs(s.scope.Bastion().AdditionalSecurityGroups)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get additional security groups of EC2 Instance Connect Endpoint")
+	}
+	securityGroupIDs = append(securityGroupIDs, additionalIDs...)
+
+	out, err := s.EC2Client.CreateInstanceConnectEndpoint(&ec2.CreateInstanceConnectEndpointInput{
+		SubnetId:         aws.String(subnetID),
+		SecurityGroupIds: aws.StringSlice(securityGroupIDs),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeInstanceConnectEndpoint, infrav1.BuildParams{
+				ClusterName: s.scope.Name(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        aws.String(fmt.Sprintf("%s-instance-connect-endpoint", s.scope.Name())),
+				Role:        aws.String(infrav1.BastionRoleTagValue),
+				Additional:  s.scope.AdditionalTags(),
+			}),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateInstanceConnectEndpoint", "Failed to create EC2 Instance Connect Endpoint in subnet %q: %v", subnetID, err)
+		return nil, errors.Wrapf(err, "failed to create EC2 Instance Connect Endpoint in subnet %q", subnetID)
+	}
+
+	endpoint := out.InstanceConnectEndpoint
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateInstanceConnectEndpoint", "Created EC2 Instance Connect Endpoint %q", aws.StringValue(endpoint.InstanceConnectEndpointId))
+	s.scope.Info("Created new EC2 Instance Connect Endpoint", "id", aws.StringValue(endpoint.InstanceConnectEndpointId), "subnet-id", subnetID)
+
+	return endpoint, nil
+}
+
+// deleteInstanceConnectEndpointAndWait deletes the endpoint, unless its deletion is already in progress,
+// and waits for it to be gone so that its security group can be deleted.
+func (s *Service) deleteInstanceConnectEndpointAndWait(endpoint *ec2.Ec2InstanceConnectEndpoint) error {
+	id := aws.StringValue(endpoint.InstanceConnectEndpointId)
+	if aws.StringValue(endpoint.State) != ec2.Ec2InstanceConnectEndpointStateDeleteInProgress {
+		if _, err := s.EC2Client.DeleteInstanceConnectEndpoint(&ec2.DeleteInstanceConnectEndpointInput{
+			InstanceConnectEndpointId: aws.String(id),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to delete EC2 Instance Connect Endpoint %q", id)
+		}
+	}
+
+	s.scope.Debug("Waiting for EC2 Instance Connect Endpoint to be deleted", "id", id)
+
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		endpoint, err := s.describeInstanceConnectEndpoint()
+		if awserrors.IsNotFound(err) {
+			return true, nil
+		} else if err != nil {
+			return false, err
+		}
+		if aws.StringValue(endpoint.State) == ec2.Ec2InstanceConnectEndpointStateDeleteFailed {
+			return false, errors.Errorf("deletion failed: %s", aws.StringValue(endpoint.StateMessage))
+		}
+		return false, nil
+	}); err != nil {
+		return errors.Wrapf(err, "failed to wait for EC2 Instance Connect Endpoint %q deletion", id)
+	}
+
+	return nil
+}
+
+func (s *Service) describeInstanceConnectEndpoint() (*ec2.Ec2InstanceConnectEndpoint, error) {
+	out, err := s.EC2Client.DescribeInstanceConnectEndpoints(&ec2.DescribeInstanceConnectEndpointsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderRole(infrav1.BastionRoleTagValue),
+			filter.EC2.Cluster(s.scope.Name()),
+			filter.EC2.InstanceConnectEndpointStates(
+				ec2.Ec2InstanceConnectEndpointStateCreateInProgress,
+				ec2.Ec2InstanceConnectEndpointStateCreateComplete,
+				ec2.Ec2InstanceConnectEndpointStateCreateFailed,
+				ec2.Ec2InstanceConnectEndpointStateDeleteInProgress,
+				ec2.Ec2InstanceConnectEndpointStateDeleteFailed,
+			),
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeInstanceConnectEndpoint", "Failed to describe EC2 Instance Connect Endpoint: %v", err)
+		return nil, errors.Wrap(err, "failed to describe EC2 Instance Connect Endpoint")
+	}
+
+	if len(out.InstanceConnectEndpoints) == 0 {
+		return nil, awserrors.NewNotFound("EC2 Instance Connect Endpoint not found")
+	}
+	return out.InstanceConnectEndpoints[0], nil
+}
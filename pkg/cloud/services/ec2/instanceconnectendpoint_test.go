@@ -0,0 +1,388 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestServiceReconcileInstanceConnectEndpoint(t *testing.T) {
+	clusterName := "cluster"
+
+	describeInstancesInput := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderRole(infrav1.BastionRoleTagValue),
+			filter.EC2.Cluster(clusterName),
+			filter.EC2.InstanceStates(
+				ec2.InstanceStateNamePending,
+				ec2.InstanceStateNameRunning,
+				ec2.InstanceStateNameStopping,
+				ec2.InstanceStateNameStopped,
+			),
+		},
+	}
+
+	describeInput := &ec2.DescribeInstanceConnectEndpointsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderRole(infrav1.BastionRoleTagValue),
+			filter.EC2.Cluster(clusterName),
+			filter.EC2.InstanceConnectEndpointStates(
+				ec2.Ec2InstanceConnectEndpointStateCreateInProgress,
+				ec2.Ec2InstanceConnectEndpointStateCreateComplete,
+				ec2.Ec2InstanceConnectEndpointStateCreateFailed,
+				ec2.Ec2InstanceConnectEndpointStateDeleteInProgress,
+				ec2.Ec2InstanceConnectEndpointStateDeleteFailed,
+			),
+		},
+	}
+
+	endpoint := func(state string) *ec2.Ec2InstanceConnectEndpoint {
+		return &ec2.Ec2InstanceConnectEndpoint{
+			InstanceConnectEndpointId: aws.String("eice-123"),
+			AvailabilityZone:          aws.String("us-east-1b"),
+			SubnetId:                  aws.String("subnet-2"),
+			State:                     aws.String(state),
+			StateMessage:              aws.String("some message"),
+		}
+	}
+
+	tests := []struct {
+		name              string
+		placement         *infrav1.SubnetPlacement
+		expect            func(m *mocks.MockEC2APIMockRecorder)
+		expectError       bool
+		bastionConnection *infrav1.BastionConnection
+		ready             bool
+	}{
+		{
+			name:      "Should create an endpoint in the first private subnet matching the placement",
+			placement: &infrav1.SubnetPlacement{SubnetIDs: []string{"subnet-2"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.Eq(describeInstancesInput)).
+					Return(&ec2.DescribeInstancesOutput{}, nil)
+				m.DescribeInstanceConnectEndpoints(gomock.Eq(describeInput)).
+					Return(&ec2.DescribeInstanceConnectEndpointsOutput{}, nil)
+				m.CreateInstanceConnectEndpoint(gomock.AssignableToTypeOf(&ec2.CreateInstanceConnectEndpointInput{})).
+					DoAndReturn(func(input *ec2.CreateInstanceConnectEndpointInput) (*ec2.CreateInstanceConnectEndpointOutput, error) {
+						if aws.StringValue(input.SubnetId) != "subnet-2" {
+							return nil, errors.Errorf("unexpected subnet %q", aws.StringValue(input.SubnetId))
+						}
+						if ids := aws.StringValueSlice(input.SecurityGroupIds); len(ids) != 1 || ids[0] != "sg-bastion" {
+							return nil, errors.Errorf("unexpected security groups %v", ids)
+						}
+						if aws.StringValue(input.TagSpecifications[0].ResourceType) != ec2.ResourceTypeInstanceConnectEndpoint {
+							return nil, errors.New("endpoint is not tagged")
+						}
+						return &ec2.CreateInstanceConnectEndpointOutput{
+							InstanceConnectEndpoint: endpoint(ec2.Ec2InstanceConnectEndpointStateCreateInProgress),
+						}, nil
+					})
+			},
+			bastionConnection: &infrav1.BastionConnection{
+				InstanceConnectEndpointID: "eice-123",
+				AvailabilityZone:          "us-east-1b",
+			},
+		},
+		{
+			name: "Should mark an existing endpoint as ready once created",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.Eq(describeInstancesInput)).
+					Return(&ec2.DescribeInstancesOutput{}, nil)
+				m.DescribeInstanceConnectEndpoints(gomock.Eq(describeInput)).
+					Return(&ec2.DescribeInstanceConnectEndpointsOutput{
+						InstanceConnectEndpoints: []*ec2.Ec2InstanceConnectEndpoint{endpoint(ec2.Ec2InstanceConnectEndpointStateCreateComplete)},
+					}, nil)
+			},
+			bastionConnection: &infrav1.BastionConnection{
+				InstanceConnectEndpointID: "eice-123",
+				AvailabilityZone:          "us-east-1b",
+			},
+			ready: true,
+		},
+		{
+			name: "Should delete an endpoint which failed to be created",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.Eq(describeInstancesInput)).
+					Return(&ec2.DescribeInstancesOutput{}, nil)
+				gomock.InOrder(
+					m.DescribeInstanceConnectEndpoints(gomock.Eq(describeInput)).
+						Return(&ec2.DescribeInstanceConnectEndpointsOutput{
+							InstanceConnectEndpoints: []*ec2.Ec2InstanceConnectEndpoint{endpoint(ec2.Ec2InstanceConnectEndpointStateCreateFailed)},
+						}, nil),
+					m.DeleteInstanceConnectEndpoint(gomock.Eq(&ec2.DeleteInstanceConnectEndpointInput{
+						InstanceConnectEndpointId: aws.String("eice-123"),
+					})).
+						Return(&ec2.DeleteInstanceConnectEndpointOutput{}, nil),
+					m.DescribeInstanceConnectEndpoints(gomock.Eq(describeInput)).
+						Return(&ec2.DescribeInstanceConnectEndpointsOutput{}, nil),
+				)
+			},
+			expectError: true,
+		},
+		{
+			name:      "Should fail if no private subnet matches the placement",
+			placement: &infrav1.SubnetPlacement{SubnetIDs: []string{"subnet-3"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.Eq(describeInstancesInput)).
+					Return(&ec2.DescribeInstancesOutput{}, nil)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockControl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).To(BeNil())
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpcID",
+						},
+						Subnets: infrav1.Subnets{
+							{
+								ID: "subnet-1",
+							},
+							{
+								ID: "subnet-2",
+							},
+							{
+								ID:       "subnet-3",
+								IsPublic: true,
+							},
+						},
+					},
+					Bastion: infrav1.Bastion{
+						Enabled:   true,
+						Type:      infrav1.BastionTypeInstanceConnectEndpoint,
+						Placement: tc.placement,
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupBastion: {ID: "sg-bastion"},
+						},
+					},
+				},
+			}
+
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			ctx := context.TODO()
+			client.Create(ctx, awsCluster)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				AWSCluster: awsCluster,
+				Client:     client,
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.ReconcileBastion()
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				g.Expect(scope.AWSCluster.Status.BastionConnection).To(BeNil())
+				return
+			}
+
+			g.Expect(err).To(BeNil())
+
+			g.Expect(scope.AWSCluster.Status.Bastion).To(BeNil())
+			g.Expect(scope.AWSCluster.Status.BastionConnection).To(BeEquivalentTo(tc.bastionConnection))
+			g.Expect(conditions.IsTrue(scope.AWSCluster, infrav1.BastionHostReadyCondition)).To(Equal(tc.ready))
+		})
+	}
+}
+
+func TestServiceDeleteInstanceConnectEndpoint(t *testing.T) {
+	clusterName := "cluster"
+
+	describeInstancesInput := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderRole(infrav1.BastionRoleTagValue),
+			filter.EC2.Cluster(clusterName),
+			filter.EC2.InstanceStates(
+				ec2.InstanceStateNamePending,
+				ec2.InstanceStateNameRunning,
+				ec2.InstanceStateNameStopping,
+				ec2.InstanceStateNameStopped,
+			),
+		},
+	}
+
+	foundOutput := &ec2.DescribeInstanceConnectEndpointsOutput{
+		InstanceConnectEndpoints: []*ec2.Ec2InstanceConnectEndpoint{
+			{
+				InstanceConnectEndpointId: aws.String("eice-123"),
+				State:                     aws.String(ec2.Ec2InstanceConnectEndpointStateCreateComplete),
+			},
+		},
+	}
+
+	recordedConnection := &infrav1.BastionConnection{InstanceConnectEndpointID: "eice-123"}
+
+	tests := []struct {
+		name        string
+		bastionType infrav1.BastionType
+		connection  *infrav1.BastionConnection
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "Should not look up endpoints of clusters which never had one",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.Eq(describeInstancesInput)).
+					Return(&ec2.DescribeInstancesOutput{}, nil)
+			},
+		},
+		{
+			name:        "Should delete the endpoint of the cluster",
+			bastionType: infrav1.BastionTypeInstanceConnectEndpoint,
+			connection:  recordedConnection,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				gomock.InOrder(
+					m.DescribeInstanceConnectEndpoints(gomock.Any()).
+						Return(foundOutput, nil),
+					m.DeleteInstanceConnectEndpoint(gomock.Eq(&ec2.DeleteInstanceConnectEndpointInput{
+						InstanceConnectEndpointId: aws.String("eice-123"),
+					})).
+						Return(&ec2.DeleteInstanceConnectEndpointOutput{}, nil),
+					m.DescribeInstanceConnectEndpoints(gomock.Any()).
+						Return(&ec2.DescribeInstanceConnectEndpointsOutput{}, nil),
+				)
+				m.DescribeInstances(gomock.Eq(describeInstancesInput)).
+					Return(&ec2.DescribeInstancesOutput{}, nil)
+			},
+		},
+		{
+			name:       "Should delete a recorded endpoint after the bastion type changed",
+			connection: recordedConnection,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				gomock.InOrder(
+					m.DescribeInstanceConnectEndpoints(gomock.Any()).
+						Return(foundOutput, nil),
+					m.DeleteInstanceConnectEndpoint(gomock.Any()).
+						Return(&ec2.DeleteInstanceConnectEndpointOutput{}, nil),
+					m.DescribeInstanceConnectEndpoints(gomock.Any()).
+						Return(&ec2.DescribeInstanceConnectEndpointsOutput{}, nil),
+				)
+				m.DescribeInstances(gomock.Eq(describeInstancesInput)).
+					Return(&ec2.DescribeInstancesOutput{}, nil)
+			},
+		},
+		{
+			name:        "Should fail if the endpoint can't be deleted",
+			bastionType: infrav1.BastionTypeInstanceConnectEndpoint,
+			connection:  recordedConnection,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstanceConnectEndpoints(gomock.Any()).
+					Return(foundOutput, nil)
+				m.DeleteInstanceConnectEndpoint(gomock.Any()).
+					Return(nil, errors.New("some error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockControl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).To(BeNil())
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					Bastion: infrav1.Bastion{Enabled: true, Type: tc.bastionType},
+				},
+				Status: infrav1.AWSClusterStatus{
+					BastionConnection: tc.connection.DeepCopy(),
+				},
+			}
+
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			ctx := context.TODO()
+			client.Create(ctx, awsCluster)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				AWSCluster: awsCluster,
+				Client:     client,
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.DeleteBastion()
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+
+			g.Expect(err).To(BeNil())
+			g.Expect(scope.AWSCluster.Status.BastionConnection).To(BeNil())
+		})
+	}
+}
@@ -20,11 +20,15 @@ import (
 	"encoding/base64"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -33,6 +37,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
@@ -42,11 +47,13 @@ import (
 func (s *Service) GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.Instance, error) {
 	s.scope.Debug("Looking for existing machine instance by tags")
 
+	// Look the instance up by the machine it was created for rather than by its Name tag, which is
+	// rendered from an instance name template that may have changed since the instance was created.
 	input := &ec2.DescribeInstancesInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.VPC(s.scope.VPC().ID),
 			filter.EC2.ClusterOwned(s.scope.Name()),
-			filter.EC2.Name(scope.Name()),
+			filter.EC2.MachineName(types.NamespacedName{Namespace: scope.Machine.Namespace, Name: scope.Machine.Name}),
 			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning),
 		},
 	}
@@ -119,19 +126,33 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 		NetworkInterfaces: scope.AWSMachine.Spec.NetworkInterfaces,
 	}
 
+	instanceName, err := scope.InstanceName()
+	if err != nil {
+		record.Warnf(scope.AWSMachine, "FailedCreate", "Failed to generate instance name: %v", err)
+		return nil, errors.Wrap(err, "failed to generate instance name")
+	}
+
 	// Make sure to use the MachineScope here to get the merger of AWSCluster and AWSMachine tags
 	additionalTags := scope.AdditionalTags()
 	input.Tags = infrav1.Build(infrav1.BuildParams{
 		ClusterName: s.scope.KubernetesClusterName(),
 		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(scope.Name()),
+		Name:        aws.String(instanceName),
 		Role:        aws.String(scope.Role()),
 		Additional:  additionalTags,
 	}.WithCloudProvider(s.scope.KubernetesClusterName()).WithMachineName(scope.Machine))
 
-	var err error
+	if !s.scope.SecurityProfile().AllowsPublicIP() && aws.BoolValue(scope.AWSMachine.Spec.PublicIP) {
+		errMessage := fmt.Sprintf("failed to run machine %q, public IPs are not allowed by the %q security profile", scope.Name(), s.scope.SecurityProfile())
+		record.Warnf(scope.AWSMachine, "FailedCreate", errMessage)
+		return nil, errors.New(errMessage)
+	}
+
+	if err := s.checkOutpostInstanceType(scope); err != nil {
+		return nil, err
+	}
 
-	imageArchitecture, err := s.pickArchitectureForInstanceType(input.Type)
+	imageArchitecture, imageAccelerator, err := s.pickArchitectureForInstanceType(input.Type)
 	if err != nil {
 		return nil, err
 	}
@@ -162,13 +183,19 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 			imageLookupBaseOS = scope.InfraCluster.ImageLookupBaseOS()
 		}
 
-		if scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == "" {
-			input.ImageID, err = s.eksAMILookup(*scope.Machine.Spec.Version, imageArchitecture, scope.AWSMachine.Spec.AMI.EKSOptimizedLookupType)
+		switch {
+		case scope.AWSMachine.Spec.Bottlerocket != nil:
+			input.ImageID, err = s.bottlerocketAMILookup(scope.AWSMachine.Spec.Bottlerocket.Variant, *scope.Machine.Spec.Version, imageArchitecture)
 			if err != nil {
 				return nil, err
 			}
-		} else {
-			input.ImageID, err = s.defaultAMIIDLookup(imageLookupFormat, imageLookupOrg, imageLookupBaseOS, imageArchitecture, *scope.Machine.Spec.Version)
+		case scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == "":
+			input.ImageID, err = s.eksAMILookup(*scope.Machine.Spec.Version, imageArchitecture, imageAccelerator, scope.AWSMachine.Spec.AMI.EKSOptimizedLookupType)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			input.ImageID, err = s.defaultAMIIDLookup(imageLookupFormat, imageLookupOrg, imageLookupBaseOS, imageArchitecture, imageAccelerator, *scope.Machine.Spec.Version)
 			if err != nil {
 				return nil, err
 			}
@@ -181,6 +208,13 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	}
 	input.SubnetID = subnetID
 
+	if ip := scope.AWSMachine.Spec.PrivateIPAddress; ip != nil {
+		if err := s.validatePrivateIPAddress(scope, *ip, subnetID); err != nil {
+			return nil, err
+		}
+		input.PrivateIP = ip
+	}
+
 	if !scope.IsExternallyManaged() && !scope.IsEKSManaged() && s.scope.Network().APIServerELB.DNSName == "" {
 		record.Eventf(s.scope.InfraCluster(), "FailedCreateInstance", "Failed to run controlplane, APIServer ELB not available")
 
@@ -235,9 +269,46 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 
 	input.Tenancy = scope.AWSMachine.Spec.Tenancy
 
+	input.CPUOptions = scope.AWSMachine.Spec.CPUOptions
+
+	input.EnclaveOptions = scope.AWSMachine.Spec.EnclaveOptions
+
+	input.HibernationOptions = scope.AWSMachine.Spec.HibernationOptions
+	input.CreditSpecification = scope.AWSMachine.Spec.CreditSpecification
+
+	if err := applySecurityProfile(s.scope.SecurityProfile(), input); err != nil {
+		record.Warnf(scope.AWSMachine, "FailedCreate", "Failed to create instance: %v", err)
+		return nil, err
+	}
+
+	applyDefaultEBSKMSKey(s.scope.DefaultEBSKMSKeyID(), input)
+
+	// The additional security groups are attached once the instance is running, fail early
+	// rather than creating an instance that can't get all of its security groups.
+	additionalIDs, err := s.GetAdditionalSecurityGroupsIDs(scope.AWSMachine.Spec.AdditionalSecurityGroups)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.CheckSecurityGroupsPerNetworkInterface(len(sets.NewString(input.SecurityGroupIDs...).Insert(additionalIDs...))); err != nil {
+		record.Warnf(scope.AWSMachine, "QuotaExceeded", "Unable to create instance: %v", err)
+		return nil, err
+	}
+
+	input.AdditionalNetworkInterfaces, err = s.getAdditionalNetworkInterfaces(scope, input.SubnetID, input.SecurityGroupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaReservation, err := s.reserveInstanceQuota(input)
+	if err != nil {
+		record.Warnf(scope.AWSMachine, "QuotaExceeded", "Unable to create instance: %v", err)
+		return nil, err
+	}
+
 	s.scope.Debug("Running instance", "machine-role", scope.Role())
 	out, err := s.runInstance(scope.Role(), input)
 	if err != nil {
+		quotaReservation.Release()
 		// Only record the failure event if the error is not related to failed dependencies.
 		// This is to avoid spamming failure events since the machine will be requeued by the actuator.
 		if !awserrors.IsFailedDependency(errors.Cause(err)) {
@@ -245,6 +316,7 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 		}
 		return nil, err
 	}
+	quotaReservation.Commit()
 
 	if len(input.NetworkInterfaces) > 0 {
 		for _, id := range input.NetworkInterfaces {
@@ -269,8 +341,16 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	if len(networkInterfaces) > 0 {
 		s.scope.Debug("Attempting to create tags from resource", "resource-id", out.ID)
 		for _, networkInterface := range networkInterfaces {
+			tags := out.Tags
+			if deviceIndex, ok := additionalNetworkInterfaceIndex(input, networkInterface); ok {
+				tags = make(map[string]string, len(out.Tags)+1)
+				for k, v := range out.Tags {
+					tags[k] = v
+				}
+				tags[infrav1.NameAWSAdditionalNetworkInterface] = strconv.FormatInt(deviceIndex, 10)
+			}
 			// Create/Update tags in AWS.
-			if err := s.UpdateResourceTags(networkInterface.NetworkInterfaceId, out.Tags, nil); err != nil {
+			if err := s.UpdateResourceTags(networkInterface.NetworkInterfaceId, tags, nil); err != nil {
 				return nil, errors.Wrapf(err, "failed to create tags for resource %q: ", *networkInterface.NetworkInterfaceId)
 			}
 		}
@@ -280,15 +360,142 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	return out, nil
 }
 
+// additionalNetworkInterfaceIndex returns the device index of a network interface of the instance
+// if it was created from one of its additional network interfaces.
+func additionalNetworkInterfaceIndex(i *infrav1.Instance, networkInterface *ec2.NetworkInterface) (int64, bool) {
+	if networkInterface.Attachment == nil {
+		return 0, false
+	}
+	deviceIndex := aws.Int64Value(networkInterface.Attachment.DeviceIndex)
+	for _, additional := range i.AdditionalNetworkInterfaces {
+		if additional.DeviceIndex == deviceIndex {
+			return deviceIndex, true
+		}
+	}
+	return 0, false
+}
+
+// getAdditionalNetworkInterfaces returns the additional network interfaces of the machine with
+// their subnet and security groups resolved to IDs. Network interfaces without a subnet or security
+// groups get the ones of the instance.
+func (s *Service) getAdditionalNetworkInterfaces(scope *scope.MachineScope, subnetID string, securityGroupIDs []string) ([]infrav1.NetworkInterfaceSpec, error) {
+	var res []infrav1.NetworkInterfaceSpec
+	for _, spec := range scope.AWSMachine.Spec.AdditionalNetworkInterfaces {
+		networkInterface := spec.DeepCopy()
+
+		id, err := s.findNetworkInterfaceSubnet(scope, spec.Subnet, subnetID)
+		if err != nil {
+			return nil, err
+		}
+		networkInterface.Subnet = &infrav1.AWSResourceReference{ID: aws.String(id)}
+
+		groupIDs := securityGroupIDs
+		if len(spec.SecurityGroups) > 0 {
+			groupIDs, err = s.GetAdditionalSecurityGroupsIDs(spec.SecurityGroups)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.CheckSecurityGroupsPerNetworkInterface(len(groupIDs)); err != nil {
+				record.Warnf(scope.AWSMachine, "QuotaExceeded", "Unable to create instance: %v", err)
+				return nil, err
+			}
+		}
+		networkInterface.SecurityGroups = nil
+		for _, groupID := range groupIDs {
+			networkInterface.SecurityGroups = append(networkInterface.SecurityGroups, infrav1.AWSResourceReference{ID: aws.String(groupID)})
+		}
+
+		res = append(res, *networkInterface)
+	}
+	return res, nil
+}
+
+// findNetworkInterfaceSubnet returns the ID of the subnet referenced by an additional network interface,
+// or the subnet of the instance if none is referenced. Subnets matched by filters must be in the
+// availability zone of the instance.
+func (s *Service) findNetworkInterfaceSubnet(scope *scope.MachineScope, ref *infrav1.AWSResourceReference, instanceSubnetID string) (string, error) {
+	switch {
+	case ref == nil || (ref.ID == nil && len(ref.Filters) == 0):
+		return instanceSubnetID, nil
+	case ref.ID != nil:
+		return *ref.ID, nil
+	}
+
+	instanceSubnets, err := s.getFilteredSubnets(&ec2.Filter{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{instanceSubnetID})})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe subnet %q", instanceSubnetID)
+	}
+	if len(instanceSubnets) == 0 {
+		return "", errors.Errorf("failed to find subnet %q", instanceSubnetID)
+	}
+
+	criteria := []*ec2.Filter{
+		filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+		filter.EC2.AvailabilityZone(aws.StringValue(instanceSubnets[0].AvailabilityZone)),
+	}
+	if !scope.IsExternallyManaged() {
+		criteria = append(criteria, filter.EC2.VPC(s.scope.VPC().ID))
+	}
+	for _, f := range ref.Filters {
+		criteria = append(criteria, &ec2.Filter{Name: aws.String(f.Name), Values: aws.StringSlice(f.Values)})
+	}
+
+	subnets, err := s.getFilteredSubnets(criteria...)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to filter subnets for criteria %q", criteria)
+	}
+	if len(subnets) == 0 {
+		errMessage := fmt.Sprintf("failed to run machine %q, no subnets available for its additional network interfaces matching criteria %q",
+			scope.Name(), criteria)
+		record.Warnf(scope.AWSMachine, "FailedCreate", errMessage)
+		return "", awserrors.NewFailedDependency(errMessage)
+	}
+	return aws.StringValue(subnets[0].SubnetId), nil
+}
+
+// validatePrivateIPAddress ensures that the static private IP address of the machine can be assigned
+// to an instance in the subnet with the given ID.
+func (s *Service) validatePrivateIPAddress(scope *scope.MachineScope, ip, subnetID string) error {
+	var cidrBlock string
+	if subnet := s.scope.Subnets().FindByID(subnetID); subnet != nil {
+		cidrBlock = subnet.CidrBlock
+	}
+	if cidrBlock == "" {
+		subnets, err := s.getFilteredSubnets(&ec2.Filter{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{subnetID})})
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe subnet %q", subnetID)
+		}
+		if len(subnets) == 0 {
+			return errors.Errorf("failed to find subnet %q", subnetID)
+		}
+		cidrBlock = aws.StringValue(subnets[0].CidrBlock)
+	}
+
+	if err := infrav1.ValidatePrivateIPAddressInSubnet(ip, cidrBlock); err != nil {
+		errMessage := fmt.Sprintf("failed to run machine %q with private IP address %s in subnet %q: %v", scope.Name(), ip, subnetID, err)
+		record.Warnf(scope.AWSMachine, "FailedCreate", errMessage)
+		return awserrors.NewFailedDependency(errMessage)
+	}
+	return nil
+}
+
 // findSubnet attempts to retrieve a subnet ID in the following order:
 // - subnetID specified in machine configuration,
 // - subnet based on filters in machine configuration
 // - subnet based on the availability zone specified,
 // - default to the first private subnet available.
+// Only subnets on the Outpost of the machine, or not on an Outpost if it has none, are considered.
 func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 	// Check Machine.Spec.FailureDomain first as it's used by KubeadmControlPlane to spread machines across failure domains.
 	failureDomain := scope.Machine.Spec.FailureDomain
 
+	outpostARN := scope.AWSMachine.Spec.OutpostARN
+	clusterSubnets := s.scope.Subnets().FilterByOutpost(outpostARN)
+	onOutpost := ""
+	if outpostARN != "" {
+		onOutpost = fmt.Sprintf(" on Outpost %q", outpostARN)
+	}
+
 	// We basically have 2 sources for subnets:
 	//   1. If subnet.id or subnet.filters are specified, we directly query AWS
 	//   2. All other cases use the subnets provided in the cluster network spec without ever calling AWS
@@ -333,6 +540,18 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 				errMessage += fmt.Sprintf(" subnet %q is a private subnet.", *subnet.SubnetId)
 				continue
 			}
+			if !s.scope.SecurityProfile().AllowsPublicSubnets() && aws.BoolValue(subnet.MapPublicIpOnLaunch) {
+				errMessage += fmt.Sprintf(" subnet %q assigns public IPs on launch, which the %q security profile doesn't allow.", *subnet.SubnetId, s.scope.SecurityProfile())
+				continue
+			}
+			if aws.StringValue(subnet.OutpostArn) != outpostARN {
+				if outpostARN == "" {
+					errMessage += fmt.Sprintf(" subnet %q is on Outpost %q, but the machine has no Outpost.", *subnet.SubnetId, *subnet.OutpostArn)
+				} else {
+					errMessage += fmt.Sprintf(" subnet %q is not on Outpost %q.", *subnet.SubnetId, outpostARN)
+				}
+				continue
+			}
 			filtered = append(filtered, subnet)
 		}
 		if len(filtered) == 0 {
@@ -344,28 +563,28 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 		return *filtered[0].SubnetId, nil
 	case failureDomain != nil:
 		if scope.AWSMachine.Spec.PublicIP != nil && *scope.AWSMachine.Spec.PublicIP {
-			subnets := s.scope.Subnets().FilterPublic().FilterByZone(*failureDomain)
+			subnets := clusterSubnets.FilterPublic().FilterByZone(*failureDomain)
 			if len(subnets) == 0 {
-				errMessage := fmt.Sprintf("failed to run machine %q with public IP, no public subnets available in availability zone %q",
-					scope.Name(), *failureDomain)
+				errMessage := fmt.Sprintf("failed to run machine %q with public IP, no public subnets available in availability zone %q%s",
+					scope.Name(), *failureDomain, onOutpost)
 				record.Warnf(scope.AWSMachine, "FailedCreate", errMessage)
 				return "", awserrors.NewFailedDependency(errMessage)
 			}
 			return subnets[0].ID, nil
 		}
 
-		subnets := s.scope.Subnets().FilterPrivate().FilterByZone(*failureDomain)
+		subnets := clusterSubnets.FilterPrivate().FilterByZone(*failureDomain)
 		if len(subnets) == 0 {
-			errMessage := fmt.Sprintf("failed to run machine %q, no subnets available in availability zone %q",
-				scope.Name(), *failureDomain)
+			errMessage := fmt.Sprintf("failed to run machine %q, no subnets available in availability zone %q%s",
+				scope.Name(), *failureDomain, onOutpost)
 			record.Warnf(scope.AWSMachine, "FailedCreate", errMessage)
 			return "", awserrors.NewFailedDependency(errMessage)
 		}
 		return subnets[0].ID, nil
 	case scope.AWSMachine.Spec.PublicIP != nil && *scope.AWSMachine.Spec.PublicIP:
-		subnets := s.scope.Subnets().FilterPublic()
+		subnets := clusterSubnets.FilterPublic()
 		if len(subnets) == 0 {
-			errMessage := fmt.Sprintf("failed to run machine %q with public IP, no public subnets available", scope.Name())
+			errMessage := fmt.Sprintf("failed to run machine %q with public IP, no public subnets available%s", scope.Name(), onOutpost)
 			record.Eventf(scope.AWSMachine, "FailedCreate", errMessage)
 			return "", awserrors.NewFailedDependency(errMessage)
 		}
@@ -375,9 +594,9 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 		// with control plane machines.
 
 	default:
-		sns := s.scope.Subnets().FilterPrivate()
+		sns := clusterSubnets.FilterPrivate()
 		if len(sns) == 0 {
-			errMessage := fmt.Sprintf("failed to run machine %q, no subnets available", scope.Name())
+			errMessage := fmt.Sprintf("failed to run machine %q, no subnets available%s", scope.Name(), onOutpost)
 			record.Eventf(s.scope.InfraCluster(), "FailedCreateInstance", errMessage)
 			return "", awserrors.NewFailedDependency(errMessage)
 		}
@@ -474,6 +693,141 @@ func (s *Service) TerminateInstance(instanceID string) error {
 	return nil
 }
 
+// HibernateInstance hibernates an EC2 instance with hibernation configured. The contents of its RAM are
+// saved to the root volume and the instance is stopped.
+func (s *Service) HibernateInstance(instanceID string) error {
+	s.scope.Debug("Attempting to hibernate instance", "instance-id", instanceID)
+
+	input := &ec2.StopInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+		Hibernate:   aws.Bool(true),
+	}
+
+	if _, err := s.EC2Client.StopInstances(input); err != nil {
+		return errors.Wrapf(err, "failed to hibernate instance with id %q", instanceID)
+	}
+
+	s.scope.Debug("Hibernated instance", "instance-id", instanceID)
+	return nil
+}
+
+// RetainNonRootVolumes keeps the non root volumes of the instance when it is terminated.
+func (s *Service) RetainNonRootVolumes(instanceID string, volumes []infrav1.Volume) error {
+	if len(volumes) == 0 {
+		return nil
+	}
+
+	input := &ec2.ModifyInstanceAttributeInput{
+		InstanceId: aws.String(instanceID),
+	}
+	for _, v := range volumes {
+		input.BlockDeviceMappings = append(input.BlockDeviceMappings, &ec2.InstanceBlockDeviceMappingSpecification{
+			DeviceName: aws.String(v.DeviceName),
+			Ebs: &ec2.EbsInstanceBlockDeviceSpecification{
+				DeleteOnTermination: aws.Bool(false),
+			},
+		})
+	}
+
+	if _, err := s.EC2Client.ModifyInstanceAttribute(input); err != nil {
+		return errors.Wrapf(err, "failed to retain the non root volumes of instance %q", instanceID)
+	}
+
+	s.scope.Debug("Retaining non root volumes of instance", "instance-id", instanceID)
+	return nil
+}
+
+// SnapshotVolumes creates EBS snapshots of the volumes of the instance selected by the machine, by
+// default its root volume, so that they are retained once the instance is terminated. Volumes that
+// already have a snapshot taken for the machine, e.g. by a previous attempt to delete it, are skipped.
+func (s *Service) SnapshotVolumes(scope *scope.MachineScope, instanceID string) error {
+	instance, err := s.describeInstance(instanceID)
+	if err != nil {
+		return err
+	}
+
+	deviceNames := scope.AWSMachine.Spec.SnapshotDeviceNames
+	if len(deviceNames) == 0 {
+		deviceNames = []string{aws.StringValue(instance.RootDeviceName)}
+	}
+
+	return s.snapshotVolumes(scope, instance, deviceNames)
+}
+
+// describeInstance returns the EC2 description of the instance with the given ID.
+func (s *Service) describeInstance(instanceID string) (*ec2.Instance, error) {
+	out, err := s.EC2Client.DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instance %q", instanceID)
+	}
+	if len(out.Reservations) == 0 || len(out.Reservations[0].Instances) == 0 {
+		return nil, ErrInstanceNotFoundByID
+	}
+	return out.Reservations[0].Instances[0], nil
+}
+
+// snapshotVolumes creates EBS snapshots of the volumes of the instance attached as deviceNames, unless
+// they already have a snapshot taken for the machine.
+func (s *Service) snapshotVolumes(scope *scope.MachineScope, instance *ec2.Instance, deviceNames []string) error {
+	instanceID := aws.StringValue(instance.InstanceId)
+
+	volumeIDs := make(map[string]string, len(instance.BlockDeviceMappings))
+	for _, mapping := range instance.BlockDeviceMappings {
+		if mapping.Ebs != nil {
+			volumeIDs[aws.StringValue(mapping.DeviceName)] = aws.StringValue(mapping.Ebs.VolumeId)
+		}
+	}
+
+	machineName := types.NamespacedName{Namespace: scope.Machine.Namespace, Name: scope.Machine.Name}
+	for _, deviceName := range deviceNames {
+		volumeID, ok := volumeIDs[deviceName]
+		if !ok {
+			return errors.Errorf("failed to find the volume of instance %q attached as %q", instanceID, deviceName)
+		}
+
+		existing, err := s.EC2Client.DescribeSnapshots(&ec2.DescribeSnapshotsInput{
+			OwnerIds: aws.StringSlice([]string{"self"}),
+			Filters: []*ec2.Filter{
+				{Name: aws.String("volume-id"), Values: aws.StringSlice([]string{volumeID})},
+				filter.EC2.ClusterOwned(s.scope.Name()),
+				filter.EC2.MachineName(machineName),
+			},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe the snapshots of volume %q", volumeID)
+		}
+		if len(existing.Snapshots) > 0 {
+			s.scope.Debug("Volume already has a snapshot", "volume-id", volumeID, "snapshot-id", aws.StringValue(existing.Snapshots[0].SnapshotId))
+			continue
+		}
+
+		snapshot, err := s.EC2Client.CreateSnapshot(&ec2.CreateSnapshotInput{
+			VolumeId:    aws.String(volumeID),
+			Description: aws.String(fmt.Sprintf("Volume %s of instance %s, taken before the deletion of machine %s", deviceName, instanceID, machineName)),
+			TagSpecifications: []*ec2.TagSpecification{
+				tags.BuildParamsToTagSpecification(ec2.ResourceTypeSnapshot, infrav1.BuildParams{
+					ClusterName: s.scope.KubernetesClusterName(),
+					Lifecycle:   infrav1.ResourceLifecycleOwned,
+					Name:        aws.String(fmt.Sprintf("%s-%s", scope.Name(), strings.TrimPrefix(deviceName, "/dev/"))),
+					Role:        aws.String(scope.Role()),
+					Additional:  scope.AdditionalTags(),
+				}.WithMachineName(scope.Machine)),
+			},
+		})
+		if err != nil {
+			record.Warnf(scope.AWSMachine, "FailedCreateSnapshot", "Failed to create a snapshot of volume %q: %v", volumeID, err)
+			return errors.Wrapf(err, "failed to create a snapshot of volume %q", volumeID)
+		}
+
+		record.Eventf(scope.AWSMachine, "SuccessfulCreateSnapshot", "Created snapshot %q of volume %q", aws.StringValue(snapshot.SnapshotId), volumeID)
+		s.scope.Debug("Created snapshot of volume", "volume-id", volumeID, "snapshot-id", aws.StringValue(snapshot.SnapshotId))
+	}
+
+	return nil
+}
+
 // TerminateInstanceAndWait terminates and waits
 // for an EC2 instance to terminate.
 func (s *Service) TerminateInstanceAndWait(instanceID string) error {
@@ -518,8 +872,36 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 
 		input.NetworkInterfaces = netInterfaces
+	} else if len(i.AdditionalNetworkInterfaces) > 0 {
+		// The subnet and security groups of the instance can't be set together with network interfaces,
+		// they're set on the primary network interface instead.
+		primary := &ec2.InstanceNetworkInterfaceSpecification{
+			DeviceIndex:      aws.Int64(0),
+			SubnetId:         aws.String(i.SubnetID),
+			PrivateIpAddress: i.PrivateIP,
+		}
+		if len(i.SecurityGroupIDs) > 0 {
+			primary.Groups = aws.StringSlice(i.SecurityGroupIDs)
+		}
+		input.NetworkInterfaces = append(input.NetworkInterfaces, primary)
+
+		for _, networkInterface := range i.AdditionalNetworkInterfaces {
+			spec := &ec2.InstanceNetworkInterfaceSpecification{
+				DeviceIndex:                    aws.Int64(networkInterface.DeviceIndex),
+				SecondaryPrivateIpAddressCount: networkInterface.SecondaryPrivateIPAddressCount,
+				DeleteOnTermination:            aws.Bool(networkInterface.DeleteOnTermination == nil || *networkInterface.DeleteOnTermination),
+			}
+			if networkInterface.Subnet != nil {
+				spec.SubnetId = networkInterface.Subnet.ID
+			}
+			for _, group := range networkInterface.SecurityGroups {
+				spec.Groups = append(spec.Groups, group.ID)
+			}
+			input.NetworkInterfaces = append(input.NetworkInterfaces, spec)
+		}
 	} else {
 		input.SubnetId = aws.String(i.SubnetID)
+		input.PrivateIpAddress = i.PrivateIP
 
 		if len(i.SecurityGroupIDs) > 0 {
 			input.SecurityGroupIds = aws.StringSlice(i.SecurityGroupIDs)
@@ -587,6 +969,27 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 	}
 
+	if i.CPUOptions != nil {
+		input.CpuOptions = &ec2.CpuOptionsRequest{
+			CoreCount:      aws.Int64(i.CPUOptions.CoreCount),
+			ThreadsPerCore: aws.Int64(i.CPUOptions.ThreadsPerCore),
+		}
+	}
+
+	if i.EnclaveOptions != nil {
+		input.EnclaveOptions = &ec2.EnclaveOptionsRequest{
+			Enabled: aws.Bool(i.EnclaveOptions.Enabled),
+		}
+	}
+
+	if i.HibernationOptions != nil {
+		input.HibernationOptions = &ec2.HibernationOptionsRequest{
+			Configured: aws.Bool(i.HibernationOptions.Configured),
+		}
+	}
+
+	input.CreditSpecification = getCreditSpecificationRequest(i.CreditSpecification)
+
 	out, err := s.EC2Client.RunInstances(input)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to run instance")
@@ -599,13 +1002,89 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 	return s.SDKToInstance(out.Instances[0])
 }
 
+// getCreditSpecificationRequest returns the credit specification request of the instances launched with
+// the given credit specification, if any.
+func getCreditSpecificationRequest(creditSpecification *infrav1.CreditSpecification) *ec2.CreditSpecificationRequest {
+	if creditSpecification == nil {
+		return nil
+	}
+	return &ec2.CreditSpecificationRequest{
+		CpuCredits: aws.String(string(creditSpecification.CPUCredits)),
+	}
+}
+
+// applySecurityProfile enforces the security profile of the cluster on the instance to be created.
+// It returns an error if the instance explicitly requests settings forbidden by the profile.
+func applySecurityProfile(profile infrav1.SecurityProfile, i *infrav1.Instance) error {
+	if profile.RequiresEncryptedVolumes() {
+		if i.RootVolume == nil {
+			// Leaving the size unset keeps the size of the AMI's root volume.
+			i.RootVolume = &infrav1.Volume{}
+		}
+		if i.RootVolume.Encrypted != nil && !*i.RootVolume.Encrypted {
+			return errors.Errorf("root volume must be encrypted by the %q security profile", profile)
+		}
+		i.RootVolume.Encrypted = aws.Bool(true)
+
+		// Copy the non root volumes, they may be shared with the machine spec.
+		nonRootVolumes := make([]infrav1.Volume, len(i.NonRootVolumes))
+		for vi, v := range i.NonRootVolumes {
+			if v.Encrypted != nil && !*v.Encrypted {
+				return errors.Errorf("volume %q must be encrypted by the %q security profile", v.DeviceName, profile)
+			}
+			v.Encrypted = aws.Bool(true)
+			nonRootVolumes[vi] = v
+		}
+		i.NonRootVolumes = nonRootVolumes
+	}
+
+	i.InstanceMetadataOptions = profile.ApplyToInstanceMetadataOptions(i.InstanceMetadataOptions)
+
+	return nil
+}
+
+// applyDefaultEBSKMSKey encrypts the volumes of the instance to be created which don't set their own
+// encryption key with the default KMS key of the cluster, if any.
+func applyDefaultEBSKMSKey(keyID string, i *infrav1.Instance) {
+	if keyID == "" {
+		return
+	}
+
+	if i.RootVolume == nil {
+		// Leaving the size unset keeps the size of the AMI's root volume.
+		i.RootVolume = &infrav1.Volume{}
+	}
+	applyDefaultEncryptionKey(keyID, i.RootVolume)
+
+	// Copy the non root volumes, they may be shared with the machine spec.
+	nonRootVolumes := make([]infrav1.Volume, len(i.NonRootVolumes))
+	for vi, v := range i.NonRootVolumes {
+		applyDefaultEncryptionKey(keyID, &v)
+		nonRootVolumes[vi] = v
+	}
+	i.NonRootVolumes = nonRootVolumes
+}
+
+// applyDefaultEncryptionKey sets the encryption key of a volume which doesn't set its own. Volumes explicitly
+// not encrypted are left unchanged.
+func applyDefaultEncryptionKey(keyID string, v *infrav1.Volume) {
+	if v.EncryptionKey != "" || (v.Encrypted != nil && !*v.Encrypted) {
+		return
+	}
+	v.Encrypted = aws.Bool(true)
+	v.EncryptionKey = keyID
+}
+
 func volumeToBlockDeviceMapping(v *infrav1.Volume) *ec2.BlockDeviceMapping {
 	ebsDevice := &ec2.EbsBlockDevice{
 		DeleteOnTermination: aws.Bool(true),
-		VolumeSize:          aws.Int64(v.Size),
 		Encrypted:           v.Encrypted,
 	}
 
+	if v.Size != 0 {
+		ebsDevice.VolumeSize = aws.Int64(v.Size)
+	}
+
 	if v.Throughput != nil {
 		ebsDevice.Throughput = v.Throughput
 	}
@@ -639,6 +1118,9 @@ func (s *Service) GetInstanceSecurityGroups(instanceID string) (map[string][]str
 
 	out := make(map[string][]string)
 	for _, eni := range enis {
+		if isAdditionalNetworkInterface(eni) {
+			continue
+		}
 		var groups []string
 		for _, group := range eni.Groups {
 			groups = append(groups, aws.StringValue(group.GroupId))
@@ -661,6 +1143,9 @@ func (s *Service) UpdateInstanceSecurityGroups(instanceID string, ids []string)
 	s.scope.Debug("Found ENIs on instance", "number-of-enis", len(enis), "instance-id", instanceID)
 
 	for _, eni := range enis {
+		if isAdditionalNetworkInterface(eni) {
+			continue
+		}
 		if err := s.attachSecurityGroupsToNetworkInterface(ids, aws.StringValue(eni.NetworkInterfaceId)); err != nil {
 			return errors.Wrapf(err, "failed to modify network interfaces on instance %q", instanceID)
 		}
@@ -717,6 +1202,17 @@ func (s *Service) UpdateResourceTags(resourceID *string, create, remove map[stri
 	return nil
 }
 
+// isAdditionalNetworkInterface returns true if the network interface was created from one of the
+// additional network interfaces of a machine, its security groups are not managed with the machine's.
+func isAdditionalNetworkInterface(eni *ec2.NetworkInterface) bool {
+	for _, tag := range eni.TagSet {
+		if aws.StringValue(tag.Key) == infrav1.NameAWSAdditionalNetworkInterface {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Service) getInstanceENIs(instanceID string) ([]*ec2.NetworkInterface, error) {
 	input := &ec2.DescribeNetworkInterfacesInput{
 		Filters: []*ec2.Filter{
@@ -825,6 +1321,12 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 		i.VolumeIDs = append(i.VolumeIDs, *volume.Ebs.VolumeId)
 	}
 
+	for _, eni := range v.NetworkInterfaces {
+		if eni.NetworkInterfaceId != nil {
+			i.NetworkInterfaces = append(i.NetworkInterfaces, *eni.NetworkInterfaceId)
+		}
+	}
+
 	if v.MetadataOptions != nil {
 		metadataOptions := &infrav1.InstanceMetadataOptions{}
 		if v.MetadataOptions.HttpEndpoint != nil {
@@ -843,6 +1345,21 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 		i.InstanceMetadataOptions = metadataOptions
 	}
 
+	if v.CpuOptions != nil && v.CpuOptions.CoreCount != nil && v.CpuOptions.ThreadsPerCore != nil {
+		i.CPUOptions = &infrav1.CPUOptions{
+			CoreCount:      *v.CpuOptions.CoreCount,
+			ThreadsPerCore: *v.CpuOptions.ThreadsPerCore,
+		}
+	}
+
+	if v.EnclaveOptions != nil && aws.BoolValue(v.EnclaveOptions.Enabled) {
+		i.EnclaveOptions = &infrav1.EnclaveOptions{Enabled: true}
+	}
+
+	if v.HibernationOptions != nil && aws.BoolValue(v.HibernationOptions.Configured) {
+		i.HibernationOptions = &infrav1.HibernationOptions{Configured: true}
+	}
+
 	return i, nil
 }
 
@@ -922,6 +1439,9 @@ func (s *Service) attachSecurityGroupsToNetworkInterface(groups []string, interf
 	}
 
 	if _, err := s.EC2Client.ModifyNetworkInterfaceAttribute(input); err != nil {
+		if code, _ := awserrors.Code(err); code == awserrors.SecurityGroupsPerInterfaceLimitExceeded {
+			return errors.Wrapf(err, "failed to modify interface %q to have %d security groups, raise the account's security groups per network interface quota or reduce additionalSecurityGroups", interfaceID, len(totalGroups))
+		}
 		return errors.Wrapf(err, "failed to modify interface %q to have security groups %v", interfaceID, totalGroups)
 	}
 	return nil
@@ -964,7 +1484,7 @@ func (s *Service) checkRootVolume(rootVolume *infrav1.Volume, imageID string) (*
 		return nil, errors.Wrapf(err, "failed to get root volume from image %q", imageID)
 	}
 
-	if rootVolume.Size < *snapshotSize {
+	if rootVolume.Size != 0 && rootVolume.Size < *snapshotSize {
 		return nil, errors.Errorf("root volume size (%d) must be greater than or equal to snapshot size (%d)", rootVolume.Size, *snapshotSize)
 	}
 
@@ -1059,3 +1579,18 @@ func getInstanceMetadataOptionsRequest(metadataOptions *infrav1.InstanceMetadata
 
 	return request
 }
+
+// InstanceTypeCapacity returns the CPU, memory and GPU capacity of the instances of an instance type.
+func (s *Service) InstanceTypeCapacity(instanceType string) (corev1.ResourceList, error) {
+	out, err := s.EC2Client.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []*string{aws.String(instanceType)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instance type %q", instanceType)
+	}
+	if len(out.InstanceTypes) == 0 {
+		return nil, errors.Errorf("instance type %q not found", instanceType)
+	}
+
+	return converters.InstanceTypeInfoToResourceList(out.InstanceTypes[0]), nil
+}
@@ -271,6 +271,264 @@ func TestTerminateInstance(t *testing.T) {
 	}
 }
 
+func TestHibernateInstance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name       string
+		instanceID string
+		expect     func(m *mocks.MockEC2APIMockRecorder)
+		check      func(err error)
+	}{
+		{
+			name:       "instance is hibernated",
+			instanceID: "i-exist",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.StopInstances(gomock.Eq(&ec2.StopInstancesInput{
+					InstanceIds: []*string{aws.String("i-exist")},
+					Hibernate:   aws.Bool(true),
+				})).
+					Return(&ec2.StopInstancesOutput{}, nil)
+			},
+			check: func(err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name:       "instance can't be hibernated",
+			instanceID: "i-nohibernation",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.StopInstances(gomock.Eq(&ec2.StopInstancesInput{
+					InstanceIds: []*string{aws.String("i-nohibernation")},
+					Hibernate:   aws.Bool(true),
+				})).
+					Return(nil, errors.New("UnsupportedHibernationConfiguration"))
+			},
+			check: func(err error) {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.HibernateInstance(tc.instanceID)
+			tc.check(err)
+		})
+	}
+}
+
+func TestRetainNonRootVolumes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name    string
+		volumes []infrav1.Volume
+		expect  func(m *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name:    "does nothing without non root volumes",
+			volumes: nil,
+			expect:  func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:    "keeps the non root volumes on termination",
+			volumes: []infrav1.Volume{{DeviceName: "/dev/sdb"}, {DeviceName: "/dev/sdc"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.ModifyInstanceAttribute(gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+					InstanceId: aws.String("i-exist"),
+					BlockDeviceMappings: []*ec2.InstanceBlockDeviceMappingSpecification{
+						{DeviceName: aws.String("/dev/sdb"), Ebs: &ec2.EbsInstanceBlockDeviceSpecification{DeleteOnTermination: aws.Bool(false)}},
+						{DeviceName: aws.String("/dev/sdc"), Ebs: &ec2.EbsInstanceBlockDeviceSpecification{DeleteOnTermination: aws.Bool(false)}},
+					},
+				})).Return(&ec2.ModifyInstanceAttributeOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			if err := s.RetainNonRootVolumes("i-exist", tc.volumes); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSnapshotVolumes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeInstance := func(m *mocks.MockEC2APIMockRecorder) {
+		m.DescribeInstances(gomock.Eq(&ec2.DescribeInstancesInput{
+			InstanceIds: []*string{aws.String("i-exist")},
+		})).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []*ec2.Reservation{{
+				Instances: []*ec2.Instance{{
+					InstanceId:     aws.String("i-exist"),
+					RootDeviceName: aws.String("/dev/sda1"),
+					BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+						{DeviceName: aws.String("/dev/sda1"), Ebs: &ec2.EbsInstanceBlockDevice{VolumeId: aws.String("vol-root")}},
+						{DeviceName: aws.String("/dev/sdb"), Ebs: &ec2.EbsInstanceBlockDevice{VolumeId: aws.String("vol-data")}},
+					},
+				}},
+			}},
+		}, nil)
+	}
+	describeSnapshots := func(m *mocks.MockEC2APIMockRecorder, volumeID string, snapshots ...*ec2.Snapshot) {
+		m.DescribeSnapshots(gomock.Eq(&ec2.DescribeSnapshotsInput{
+			OwnerIds: []*string{aws.String("self")},
+			Filters: []*ec2.Filter{
+				{Name: aws.String("volume-id"), Values: []*string{aws.String(volumeID)}},
+				{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Values: []*string{aws.String("owned")}},
+				{Name: aws.String("tag:MachineName"), Values: []*string{aws.String("default/test-machine")}},
+			},
+		})).Return(&ec2.DescribeSnapshotsOutput{Snapshots: snapshots}, nil)
+	}
+	createSnapshot := func(m *mocks.MockEC2APIMockRecorder, volumeID, deviceName, name string) {
+		m.CreateSnapshot(gomock.Eq(&ec2.CreateSnapshotInput{
+			VolumeId:    aws.String(volumeID),
+			Description: aws.String("Volume " + deviceName + " of instance i-exist, taken before the deletion of machine default/test-machine"),
+			TagSpecifications: []*ec2.TagSpecification{{
+				ResourceType: aws.String(ec2.ResourceTypeSnapshot),
+				Tags: []*ec2.Tag{
+					{Key: aws.String("MachineName"), Value: aws.String("default/test-machine")},
+					{Key: aws.String("Name"), Value: aws.String(name)},
+					{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+					{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/role"), Value: aws.String("node")},
+				},
+			}},
+		})).Return(&ec2.Snapshot{SnapshotId: aws.String("snap-" + volumeID)}, nil)
+	}
+
+	testCases := []struct {
+		name        string
+		deviceNames []string
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "snapshots the root volume by default",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeInstance(m)
+				describeSnapshots(m, "vol-root")
+				createSnapshot(m, "vol-root", "/dev/sda1", "aws-test-machine-sda1")
+			},
+		},
+		{
+			name:        "snapshots the selected volumes",
+			deviceNames: []string{"/dev/sda1", "/dev/sdb"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeInstance(m)
+				describeSnapshots(m, "vol-root")
+				createSnapshot(m, "vol-root", "/dev/sda1", "aws-test-machine-sda1")
+				describeSnapshots(m, "vol-data")
+				createSnapshot(m, "vol-data", "/dev/sdb", "aws-test-machine-sdb")
+			},
+		},
+		{
+			name: "skips volumes that already have a snapshot",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeInstance(m)
+				describeSnapshots(m, "vol-root", &ec2.Snapshot{SnapshotId: aws.String("snap-vol-root")})
+			},
+		},
+		{
+			name:        "fails when a selected volume isn't attached",
+			deviceNames: []string{"/dev/sdc"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeInstance(m)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}}
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    cluster,
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       client,
+				Cluster:      cluster,
+				Machine:      &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"}},
+				AWSMachine:   &infrav1.AWSMachine{ObjectMeta: metav1.ObjectMeta{Name: "aws-test-machine", Namespace: "default"}},
+				InfraCluster: clusterScope,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+			machineScope.AWSMachine.Spec.SnapshotOnDelete = true
+			machineScope.AWSMachine.Spec.SnapshotDeviceNames = tc.deviceNames
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err = s.SnapshotVolumes(machineScope, "i-exist")
+			if (err != nil) != tc.expectError {
+				t.Fatalf("SnapshotVolumes() error = %v, expectError %v", err, tc.expectError)
+			}
+		})
+	}
+}
+
 func TestCreateInstance(t *testing.T) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1253,7 +1511,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "with subnet ID that does not exist",
+			name: "with a private IP address in the subnet",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1270,8 +1528,9 @@ func TestCreateInstance(t *testing.T) {
 				},
 				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("non-matching-subnet"),
+					ID: aws.String("matching-subnet"),
 				},
+				PrivateIPAddress: aws.String("10.0.1.10"),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -1281,7 +1540,8 @@ func TestCreateInstance(t *testing.T) {
 							ID: "vpc-id",
 						},
 						Subnets: infrav1.Subnets{{
-							ID: "subnet-1",
+							ID:        "matching-subnet",
+							CidrBlock: "10.0.1.0/24",
 						}},
 					},
 				},
@@ -1306,11 +1566,22 @@ func TestCreateInstance(t *testing.T) {
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
 				m.
-					DescribeInstanceTypes(gomock.Eq(&ec2.DescribeInstanceTypesInput{
-						InstanceTypes: []*string{
-							aws.String("m5.large"),
+					DescribeSubnets(&ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							filter.EC2.VPC("vpc-id"),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"matching-subnet"})},
 						},
-					})).
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:         aws.String("matching-subnet"),
+							AvailabilityZone: aws.String("us-east-1b"),
+							CidrBlock:        aws.String("10.0.1.0/24"),
+						}},
+					}, nil)
+				m.
+					DescribeInstanceTypes(gomock.Any()).
 					Return(&ec2.DescribeInstanceTypesOutput{
 						InstanceTypes: []*ec2.InstanceTypeInfo{
 							{
@@ -1323,30 +1594,53 @@ func TestCreateInstance(t *testing.T) {
 						},
 					}, nil)
 				m.
-					DescribeSubnets(&ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							filter.EC2.VPC("vpc-id"),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"non-matching-subnet"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{},
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if aws.StringValue(input.PrivateIpAddress) != "10.0.1.10" {
+							t.Fatalf("expected the private IP address 10.0.1.10, got %q", aws.StringValue(input.PrivateIpAddress))
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:       aws.String("two"),
+									InstanceType:     aws.String("m5.large"),
+									SubnetId:         aws.String("matching-subnet"),
+									ImageId:          aws.String("ami-1"),
+									PrivateIpAddress: aws.String("10.0.1.10"),
+									RootDeviceName:   aws.String("device-1"),
+									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+										{
+											DeviceName: aws.String("device-1"),
+											Ebs: &ec2.EbsInstanceBlockDevice{
+												VolumeId: aws.String("volume-1"),
+											},
+										},
+									},
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.
+					DescribeNetworkInterfaces(gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
 					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				expectedErrMsg := "failed to run machine \"aws-test1\", no subnets available matching criteria"
-				if err == nil {
-					t.Fatalf("Expected error, but got nil")
-				}
-
-				if !strings.Contains(err.Error(), expectedErrMsg) {
-					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
 				}
 			},
 		},
 		{
-			name: "with subnet ID that does not belong to Cluster",
+			name: "with a private IP address outside of the subnet",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1365,6 +1659,7 @@ func TestCreateInstance(t *testing.T) {
 				Subnet: &infrav1.AWSResourceReference{
 					ID: aws.String("matching-subnet"),
 				},
+				PrivateIPAddress: aws.String("10.0.2.10"),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -1374,7 +1669,8 @@ func TestCreateInstance(t *testing.T) {
 							ID: "vpc-id",
 						},
 						Subnets: infrav1.Subnets{{
-							ID: "subnet-1",
+							ID:        "matching-subnet",
+							CidrBlock: "10.0.1.0/24",
 						}},
 					},
 				},
@@ -1399,12 +1695,197 @@ func TestCreateInstance(t *testing.T) {
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
 				m.
-					RunInstances(gomock.Any()).
-					Return(&ec2.Reservation{
-						Instances: []*ec2.Instance{
+					DescribeSubnets(&ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							filter.EC2.VPC("vpc-id"),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"matching-subnet"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:         aws.String("matching-subnet"),
+							AvailabilityZone: aws.String("us-east-1b"),
+							CidrBlock:        aws.String("10.0.1.0/24"),
+						}},
+					}, nil)
+				m.
+					DescribeInstanceTypes(gomock.Any()).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
 							{
-								State: &ec2.InstanceState{
-									Name: aws.String(ec2.InstanceStateNamePending),
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				expectedErrMsg := "is not in the CIDR block 10.0.1.0/24 of the subnet"
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
+				}
+			},
+		},
+		{
+			name: "with subnet ID that does not exist",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.String("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("non-matching-subnet"),
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
+						},
+						Subnets: infrav1.Subnets{{
+							ID: "subnet-1",
+						}},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypes(gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeSubnets(&ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							filter.EC2.VPC("vpc-id"),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"non-matching-subnet"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{},
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				expectedErrMsg := "failed to run machine \"aws-test1\", no subnets available matching criteria"
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
+				}
+			},
+		},
+		{
+			name: "with subnet ID that does not belong to Cluster",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.String("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("matching-subnet"),
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
+						},
+						Subnets: infrav1.Subnets{{
+							ID: "subnet-1",
+						}},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					RunInstances(gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
 								},
 								IamInstanceProfile: &ec2.IamInstanceProfile{
 									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
@@ -1881,6 +2362,172 @@ func TestCreateInstance(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "public IP true with the baseline security profile",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.String("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("private-subnet-1"),
+				},
+				PublicIP: aws.Bool(true),
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					SecurityProfile: infrav1.SecurityProfileBaseline,
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
+						},
+						Subnets: infrav1.Subnets{{
+							ID:       "private-subnet-1",
+							IsPublic: false,
+						}},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+			check: func(instance *infrav1.Instance, err error) {
+				expectedErrMsg := "failed to run machine \"aws-test1\", public IPs are not allowed by the \"baseline\" security profile"
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
+				}
+			},
+		},
+		{
+			name: "subnet ID assigning public IPs given with the strict security profile",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.String("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("public-subnet-1"),
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					SecurityProfile: infrav1.SecurityProfileStrict,
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
+						},
+						Subnets: infrav1.Subnets{{
+							ID:       "public-subnet-1",
+							IsPublic: true,
+						}},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeSubnets(&ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							filter.EC2.VPC("vpc-id"),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"public-subnet-1"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:            aws.String("public-subnet-1"),
+							AvailabilityZone:    aws.String("us-east-1b"),
+							MapPublicIpOnLaunch: aws.Bool(true),
+						}},
+					}, nil)
+				m.
+					DescribeInstanceTypes(gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				expectedErrMsg := "failed to run machine \"aws-test1\", found 1 subnets matching criteria but post-filtering failed. subnet \"public-subnet-1\" assigns public IPs on launch, which the \"strict\" security profile doesn't allow."
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
+				}
+			},
+		},
 		{
 			name: "both public IP and subnet filter defined",
 			machine: clusterv1.Machine{
@@ -2243,7 +2890,129 @@ func TestCreateInstance(t *testing.T) {
 				}},
 			},
 			awsCluster: &infrav1.AWSCluster{
-				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypes(gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+									{
+										DeviceName: aws.String("device-2"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-2"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfaces(gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with dedicated tenancy cloud-config",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:    map[string]string{"set": "node"},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.String("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:         "m5.large",
+				Tenancy:              "dedicated",
+				UncompressedUserData: &isUncompressedFalse,
+			},
+			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
@@ -2277,25 +3046,47 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.
-					DescribeInstanceTypes(gomock.Eq(&ec2.DescribeInstanceTypesInput{
-						InstanceTypes: []*string{
-							aws.String("m5.large"),
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Eq(&ec2.RunInstancesInput{
+						ImageId:      aws.String("abc"),
+						InstanceType: aws.String("m5.large"),
+						KeyName:      aws.String("default"),
+						MaxCount:     aws.Int64(1),
+						MinCount:     aws.Int64(1),
+						Placement: &ec2.Placement{
+							Tenancy: &tenancy,
 						},
-					})).
-					Return(&ec2.DescribeInstanceTypesOutput{
-						InstanceTypes: []*ec2.InstanceTypeInfo{
+						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
+						SubnetId:         aws.String("subnet-1"),
+						TagSpecifications: []*ec2.TagSpecification{
 							{
-								ProcessorInfo: &ec2.ProcessorInfo{
-									SupportedArchitectures: []*string{
-										aws.String("x86_64"),
+								ResourceType: aws.String("instance"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("default/machine-aws-test1"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("node"),
 									},
 								},
 							},
 						},
-					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
-					RunInstances(gomock.Any()).
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
+					})).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -2317,15 +3108,27 @@ func TestCreateInstance(t *testing.T) {
 											VolumeId: aws.String("volume-1"),
 										},
 									},
-									{
-										DeviceName: aws.String("device-2"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-2"),
-										},
-									},
 								},
 								Placement: &ec2.Placement{
 									AvailabilityZone: &az,
+									Tenancy:          &tenancy,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeInstanceTypes(gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
 								},
 							},
 						},
@@ -2344,7 +3147,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "with dedicated tenancy cloud-config",
+			name: "with CPU options and Nitro Enclaves",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:    map[string]string{"set": "node"},
@@ -2362,7 +3165,8 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType:         "m5.large",
-				Tenancy:              "dedicated",
+				CPUOptions:           &infrav1.CPUOptions{CoreCount: 1, ThreadsPerCore: 1},
+				EnclaveOptions:       &infrav1.EnclaveOptions{Enabled: true},
 				UncompressedUserData: &isUncompressedFalse,
 			},
 			awsCluster: &infrav1.AWSCluster{
@@ -2406,8 +3210,12 @@ func TestCreateInstance(t *testing.T) {
 						KeyName:      aws.String("default"),
 						MaxCount:     aws.Int64(1),
 						MinCount:     aws.Int64(1),
-						Placement: &ec2.Placement{
-							Tenancy: &tenancy,
+						CpuOptions: &ec2.CpuOptionsRequest{
+							CoreCount:      aws.Int64(1),
+							ThreadsPerCore: aws.Int64(1),
+						},
+						EnclaveOptions: &ec2.EnclaveOptionsRequest{
+							Enabled: aws.Bool(true),
 						},
 						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
 						SubnetId:         aws.String("subnet-1"),
@@ -2464,7 +3272,13 @@ func TestCreateInstance(t *testing.T) {
 								},
 								Placement: &ec2.Placement{
 									AvailabilityZone: &az,
-									Tenancy:          &tenancy,
+								},
+								CpuOptions: &ec2.CpuOptions{
+									CoreCount:      aws.Int64(1),
+									ThreadsPerCore: aws.Int64(1),
+								},
+								EnclaveOptions: &ec2.EnclaveOptions{
+									Enabled: aws.Bool(true),
 								},
 							},
 						},
@@ -2497,6 +3311,12 @@ func TestCreateInstance(t *testing.T) {
 				if err != nil {
 					t.Fatalf("did not expect error: %v", err)
 				}
+				if !cmp.Equal(instance.CPUOptions, &infrav1.CPUOptions{CoreCount: 1, ThreadsPerCore: 1}) {
+					t.Fatalf("expected CPU options of the instance, got %v", instance.CPUOptions)
+				}
+				if !cmp.Equal(instance.EnclaveOptions, &infrav1.EnclaveOptions{Enabled: true}) {
+					t.Fatalf("expected Nitro Enclaves to be enabled, got %v", instance.EnclaveOptions)
+				}
 			},
 		},
 		{
@@ -2657,6 +3477,158 @@ func TestCreateInstance(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "with additional network interfaces",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.String("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				AdditionalNetworkInterfaces: []infrav1.NetworkInterfaceSpec{
+					{
+						DeviceIndex:                    1,
+						Subnet:                         &infrav1.AWSResourceReference{ID: aws.String("subnet-data")},
+						SecondaryPrivateIPAddressCount: aws.Int64(2),
+						DeleteOnTermination:            aws.Bool(false),
+					},
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypes(gomock.Any()).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if input.SubnetId != nil || input.SecurityGroupIds != nil {
+							t.Fatalf("expected the subnet and security groups to be set on the network interfaces, got %v", input)
+						}
+						expected := []*ec2.InstanceNetworkInterfaceSpecification{
+							{
+								DeviceIndex: aws.Int64(0),
+								SubnetId:    aws.String("subnet-1"),
+								Groups:      aws.StringSlice([]string{"2", "3"}),
+							},
+							{
+								DeviceIndex:                    aws.Int64(1),
+								SubnetId:                       aws.String("subnet-data"),
+								Groups:                         aws.StringSlice([]string{"2", "3"}),
+								SecondaryPrivateIpAddressCount: aws.Int64(2),
+								DeleteOnTermination:            aws.Bool(false),
+							},
+						}
+						if !cmp.Equal(input.NetworkInterfaces, expected) {
+							t.Fatalf("unexpected network interfaces: %s", cmp.Diff(expected, input.NetworkInterfaces))
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("ami-1"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+									Tags: []*ec2.Tag{
+										{
+											Key:   aws.String("Name"),
+											Value: aws.String("aws-test1"),
+										},
+									},
+								},
+							},
+						}, nil
+					})
+				m.
+					DescribeNetworkInterfaces(gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{
+							{
+								NetworkInterfaceId: aws.String("eni-primary"),
+								Attachment:         &ec2.NetworkInterfaceAttachment{DeviceIndex: aws.Int64(0)},
+							},
+							{
+								NetworkInterfaceId: aws.String("eni-data"),
+								Attachment:         &ec2.NetworkInterfaceAttachment{DeviceIndex: aws.Int64(1)},
+							},
+						},
+					}, nil)
+				m.
+					CreateTags(gomock.Any()).
+					DoAndReturn(func(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+						marked := false
+						for _, tag := range input.Tags {
+							if aws.StringValue(tag.Key) == infrav1.NameAWSAdditionalNetworkInterface {
+								marked = aws.StringValue(tag.Value) == "1"
+							}
+						}
+						if marked != (aws.StringValue(input.Resources[0]) == "eni-data") {
+							t.Fatalf("expected only the additional network interface to be marked, got %v", input)
+						}
+						return &ec2.CreateTagsOutput{}, nil
+					}).Times(2)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
 		{
 			name: "expect the default SSH key when none is provided",
 			machine: clusterv1.Machine{
@@ -3568,6 +4540,146 @@ func TestGetInstanceMarketOptionsRequest(t *testing.T) {
 	}
 }
 
+func TestApplySecurityProfile(t *testing.T) {
+	testCases := []struct {
+		name          string
+		profile       infrav1.SecurityProfile
+		instance      *infrav1.Instance
+		expected      *infrav1.Instance
+		expectedError bool
+	}{
+		{
+			name:     "with no security profile",
+			instance: &infrav1.Instance{},
+			expected: &infrav1.Instance{},
+		},
+		{
+			name:     "with the baseline security profile and no volumes",
+			profile:  infrav1.SecurityProfileBaseline,
+			instance: &infrav1.Instance{},
+			expected: &infrav1.Instance{
+				RootVolume:     &infrav1.Volume{Encrypted: aws.Bool(true)},
+				NonRootVolumes: []infrav1.Volume{},
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPEndpoint:            infrav1.InstanceMetadataEndpointStateEnabled,
+					HTTPPutResponseHopLimit: 1,
+					HTTPTokens:              infrav1.HTTPTokensStateRequired,
+					InstanceMetadataTags:    infrav1.InstanceMetadataEndpointStateDisabled,
+				},
+			},
+		},
+		{
+			name:    "with the strict security profile and IMDSv1 allowed",
+			profile: infrav1.SecurityProfileStrict,
+			instance: &infrav1.Instance{
+				RootVolume: &infrav1.Volume{Size: 20},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 50},
+				},
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPEndpoint:            infrav1.InstanceMetadataEndpointStateEnabled,
+					HTTPPutResponseHopLimit: 2,
+					HTTPTokens:              infrav1.HTTPTokensStateOptional,
+					InstanceMetadataTags:    infrav1.InstanceMetadataEndpointStateEnabled,
+				},
+			},
+			expected: &infrav1.Instance{
+				RootVolume: &infrav1.Volume{Size: 20, Encrypted: aws.Bool(true)},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 50, Encrypted: aws.Bool(true)},
+				},
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPEndpoint:            infrav1.InstanceMetadataEndpointStateEnabled,
+					HTTPPutResponseHopLimit: 2,
+					HTTPTokens:              infrav1.HTTPTokensStateRequired,
+					InstanceMetadataTags:    infrav1.InstanceMetadataEndpointStateEnabled,
+				},
+			},
+		},
+		{
+			name:    "with the baseline security profile and an unencrypted volume",
+			profile: infrav1.SecurityProfileBaseline,
+			instance: &infrav1.Instance{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 50, Encrypted: aws.Bool(false)},
+				},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := applySecurityProfile(tc.profile, tc.instance)
+			if tc.expectedError {
+				if err == nil {
+					t.Errorf("Case: %s. Expected an error", tc.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Case: %s. Unexpected error: %v", tc.name, err)
+			}
+			if !cmp.Equal(tc.instance, tc.expected) {
+				t.Errorf("Case: %s. Got: %v, expected: %v", tc.name, tc.instance, tc.expected)
+			}
+		})
+	}
+}
+
+func TestApplyDefaultEBSKMSKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keyID    string
+		instance *infrav1.Instance
+		expected *infrav1.Instance
+	}{
+		{
+			name:     "with no default key",
+			instance: &infrav1.Instance{},
+			expected: &infrav1.Instance{},
+		},
+		{
+			name:     "with a default key and no volumes",
+			keyID:    "default-key",
+			instance: &infrav1.Instance{},
+			expected: &infrav1.Instance{
+				RootVolume:     &infrav1.Volume{Encrypted: aws.Bool(true), EncryptionKey: "default-key"},
+				NonRootVolumes: []infrav1.Volume{},
+			},
+		},
+		{
+			name:  "with a default key and volumes",
+			keyID: "default-key",
+			instance: &infrav1.Instance{
+				RootVolume: &infrav1.Volume{Size: 20},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 50, EncryptionKey: "volume-key"},
+					{DeviceName: "/dev/sdc", Size: 50, Encrypted: aws.Bool(false)},
+					{DeviceName: "/dev/sdd", Size: 50, Encrypted: aws.Bool(true)},
+				},
+			},
+			expected: &infrav1.Instance{
+				RootVolume: &infrav1.Volume{Size: 20, Encrypted: aws.Bool(true), EncryptionKey: "default-key"},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 50, EncryptionKey: "volume-key"},
+					{DeviceName: "/dev/sdc", Size: 50, Encrypted: aws.Bool(false)},
+					{DeviceName: "/dev/sdd", Size: 50, Encrypted: aws.Bool(true), EncryptionKey: "default-key"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			applyDefaultEBSKMSKey(tc.keyID, tc.instance)
+			if !cmp.Equal(tc.instance, tc.expected) {
+				t.Errorf("Case: %s. Got: %v, expected: %v", tc.name, tc.instance, tc.expected)
+			}
+		})
+	}
+}
+
 func TestGetFilteredSecurityGroupID(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
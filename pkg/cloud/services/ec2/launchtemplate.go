@@ -58,6 +58,30 @@ func (s *Service) ReconcileLaunchTemplate(
 	bootstrapData, err := scope.GetRawBootstrapData()
 	if err != nil {
 		record.Eventf(scope.GetMachinePool(), corev1.EventTypeWarning, "FailedGetBootstrapData", err.Error())
+	} else {
+		lt := scope.GetLaunchTemplate()
+		bootstrapData, err = userdata.WithBootstrapCommands(bootstrapData, lt.PreBootstrapCommands, lt.PostBootstrapCommands)
+		if err != nil {
+			record.Eventf(scope.GetMachinePool(), corev1.EventTypeWarning, "FailedAddBootstrapCommands", err.Error())
+			return err
+		}
+		proxy, err := proxyInput(scope)
+		if err != nil {
+			record.Eventf(scope.GetMachinePool(), corev1.EventTypeWarning, "FailedGetProxyTrustedCA", err.Error())
+			return err
+		}
+		if bottlerocket := lt.Bottlerocket; bottlerocket != nil {
+			bootstrapData, err = userdata.NewBottlerocket(&userdata.BottlerocketInput{
+				Settings:      *bottlerocket,
+				BootstrapData: bootstrapData,
+				Proxy:         proxy,
+			})
+		} else if proxy != nil {
+			bootstrapData, err = userdata.WithProxy(bootstrapData, proxy)
+		}
+		if err != nil {
+			return err
+		}
 	}
 	bootstrapDataHash := userdata.ComputeHash(bootstrapData)
 
@@ -376,6 +400,20 @@ func (s *Service) GetLaunchTemplateID(launchTemplateName string) (string, error)
 }
 
 // CreateLaunchTemplate generates a launch template to be used with the autoscaling group.
+// proxyInput returns the proxy settings of the cluster of the machine pool, with the trusted CA bundle
+// they reference, or nil if the cluster has none.
+func proxyInput(launchTemplateScope scope.LaunchTemplateScope) (*userdata.ProxyInput, error) {
+	proxy := launchTemplateScope.GetEC2Scope().Proxy()
+	if proxy == nil {
+		return nil, nil
+	}
+	trustedCA, err := scope.GetProxyTrustedCA(launchTemplateScope, launchTemplateScope.GetEC2Scope().Namespace(), proxy)
+	if err != nil {
+		return nil, err
+	}
+	return &userdata.ProxyInput{Proxy: *proxy, TrustedCA: trustedCA}, nil
+}
+
 func (s *Service) CreateLaunchTemplate(scope scope.LaunchTemplateScope, imageID *string, userData []byte) (string, error) {
 	s.scope.Info("Create a new launch template")
 
@@ -493,17 +531,46 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 	data.ImageId = imageID
 
 	data.InstanceMarketOptions = getLaunchTemplateInstanceMarketOptionsRequest(scope.GetLaunchTemplate().SpotMarketOptions)
+	if lt.MarketType == expinfrav1.MarketTypeCapacityBlock {
+		data.InstanceMarketOptions = &ec2.LaunchTemplateInstanceMarketOptionsRequest{
+			MarketType: aws.String(string(expinfrav1.MarketTypeCapacityBlock)),
+		}
+	}
+	data.CapacityReservationSpecification = getLaunchTemplateCapacityReservationSpecificationRequest(lt.CapacityReservationID)
+	data.CreditSpecification = getCreditSpecificationRequest(lt.CreditSpecification)
 
 	// Set up root volume
-	if lt.RootVolume != nil {
-		rootDeviceName, err := s.checkRootVolume(lt.RootVolume, *data.ImageId)
+	rootVolume := lt.RootVolume.DeepCopy()
+	if keyID := s.scope.DefaultEBSKMSKeyID(); keyID != "" {
+		if rootVolume == nil {
+			// Leaving the size unset keeps the size of the AMI's root volume.
+			rootVolume = &infrav1.Volume{}
+		}
+		applyDefaultEncryptionKey(keyID, rootVolume)
+	}
+	profile := s.scope.SecurityProfile()
+	if profile.RequiresEncryptedVolumes() {
+		if rootVolume == nil {
+			rootVolume = &infrav1.Volume{}
+		}
+		if rootVolume.Encrypted != nil && !*rootVolume.Encrypted {
+			return nil, errors.Errorf("root volume must be encrypted by the %q security profile", profile)
+		}
+		rootVolume.Encrypted = aws.Bool(true)
+	}
+	data.MetadataOptions = getLaunchTemplateInstanceMetadataOptionsRequest(profile.ApplyToInstanceMetadataOptions(nil))
+	if rootVolume != nil {
+		rootDeviceName, err := s.checkRootVolume(rootVolume, *data.ImageId)
 		if err != nil {
 			return nil, err
 		}
 
-		lt.RootVolume.DeviceName = aws.StringValue(rootDeviceName)
+		if lt.RootVolume != nil {
+			lt.RootVolume.DeviceName = aws.StringValue(rootDeviceName)
+		}
+		rootVolume.DeviceName = aws.StringValue(rootDeviceName)
 
-		req := volumeToLaunchTemplateBlockDeviceMappingRequest(lt.RootVolume)
+		req := volumeToLaunchTemplateBlockDeviceMappingRequest(rootVolume)
 		data.BlockDeviceMappings = []*ec2.LaunchTemplateBlockDeviceMappingRequest{
 			req,
 		}
@@ -514,13 +581,38 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 	return data, nil
 }
 
+func getLaunchTemplateInstanceMetadataOptionsRequest(metadataOptions *infrav1.InstanceMetadataOptions) *ec2.LaunchTemplateInstanceMetadataOptionsRequest {
+	if metadataOptions == nil {
+		return nil
+	}
+
+	request := &ec2.LaunchTemplateInstanceMetadataOptionsRequest{}
+	if metadataOptions.HTTPEndpoint != "" {
+		request.SetHttpEndpoint(string(metadataOptions.HTTPEndpoint))
+	}
+	if metadataOptions.HTTPPutResponseHopLimit != 0 {
+		request.SetHttpPutResponseHopLimit(metadataOptions.HTTPPutResponseHopLimit)
+	}
+	if metadataOptions.HTTPTokens != "" {
+		request.SetHttpTokens(string(metadataOptions.HTTPTokens))
+	}
+	if metadataOptions.InstanceMetadataTags != "" {
+		request.SetInstanceMetadataTags(string(metadataOptions.InstanceMetadataTags))
+	}
+
+	return request
+}
+
 func volumeToLaunchTemplateBlockDeviceMappingRequest(v *infrav1.Volume) *ec2.LaunchTemplateBlockDeviceMappingRequest {
 	ltEbsDevice := &ec2.LaunchTemplateEbsBlockDeviceRequest{
 		DeleteOnTermination: aws.Bool(true),
-		VolumeSize:          aws.Int64(v.Size),
 		Encrypted:           v.Encrypted,
 	}
 
+	if v.Size != 0 {
+		ltEbsDevice.VolumeSize = aws.Int64(v.Size)
+	}
+
 	if v.Throughput != nil {
 		ltEbsDevice.Throughput = v.Throughput
 	}
@@ -668,6 +760,20 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		i.AdditionalSecurityGroups = append(i.AdditionalSecurityGroups, infrav1.AWSResourceReference{ID: id})
 	}
 
+	if v.InstanceMarketOptions != nil && aws.StringValue(v.InstanceMarketOptions.MarketType) == string(expinfrav1.MarketTypeCapacityBlock) {
+		i.MarketType = expinfrav1.MarketTypeCapacityBlock
+	}
+
+	if v.CapacityReservationSpecification != nil && v.CapacityReservationSpecification.CapacityReservationTarget != nil {
+		i.CapacityReservationID = v.CapacityReservationSpecification.CapacityReservationTarget.CapacityReservationId
+	}
+
+	if v.CreditSpecification != nil && v.CreditSpecification.CpuCredits != nil {
+		i.CreditSpecification = &infrav1.CreditSpecification{
+			CPUCredits: infrav1.CPUCredits(aws.StringValue(v.CreditSpecification.CpuCredits)),
+		}
+	}
+
 	if v.UserData == nil {
 		return i, userdata.ComputeHash(nil), nil
 	}
@@ -692,6 +798,18 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 		return true, nil
 	}
 
+	if incoming.MarketType != existing.MarketType {
+		return true, nil
+	}
+
+	if aws.StringValue(incoming.CapacityReservationID) != aws.StringValue(existing.CapacityReservationID) {
+		return true, nil
+	}
+
+	if !cmp.Equal(incoming.CreditSpecification, existing.CreditSpecification) {
+		return true, nil
+	}
+
 	incomingIDs, err := s.GetAdditionalSecurityGroupsIDs(incoming.AdditionalSecurityGroups)
 	if err != nil {
 		return false, err
@@ -756,29 +874,38 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 	// As specified in the AWS docs https://docs.aws.amazon.com/eks/latest/userguide/launch-templates.html.
 	// We will set the default architecture to `x86_64` as a result.
 	imageArchitecture := Amd64ArchitectureTag
+	imageAccelerator := infrav1.AcceleratorTypeNone
 
 	if instanceType != "" {
-		imageArchitecture, err = s.pickArchitectureForInstanceType(instanceType)
+		imageArchitecture, imageAccelerator, err = s.pickArchitectureForInstanceType(instanceType)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == "" {
+	switch {
+	case lt.Bottlerocket != nil:
+		lookupAMI, err = s.bottlerocketAMILookup(lt.Bottlerocket.Variant, *templateVersion, imageArchitecture)
+		if err != nil {
+			return nil, err
+		}
+	case scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == "":
 		lookupAMI, err = s.eksAMILookup(
 			*templateVersion,
 			imageArchitecture,
+			imageAccelerator,
 			scope.GetLaunchTemplate().AMI.EKSOptimizedLookupType,
 		)
 		if err != nil {
 			return nil, err
 		}
-	} else {
+	default:
 		lookupAMI, err = s.defaultAMIIDLookup(
 			imageLookupFormat,
 			imageLookupOrg,
 			imageLookupBaseOS,
 			imageArchitecture,
+			imageAccelerator,
 			*templateVersion,
 		)
 		if err != nil {
@@ -791,17 +918,28 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 
 func (s *Service) GetAdditionalSecurityGroupsIDs(securityGroups []infrav1.AWSResourceReference) ([]string, error) {
 	var additionalSecurityGroupsIDs []string
+	seen := map[string]struct{}{}
+	add := func(id string) {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			additionalSecurityGroupsIDs = append(additionalSecurityGroupsIDs, id)
+		}
+	}
 
 	for _, sg := range securityGroups {
 		if sg.ID != nil {
-			additionalSecurityGroupsIDs = append(additionalSecurityGroupsIDs, *sg.ID)
+			add(*sg.ID)
 		} else if sg.Filters != nil {
 			ids, err := s.getFilteredSecurityGroupIDs(sg)
 			if err != nil {
 				return nil, err
 			}
 
-			additionalSecurityGroupsIDs = append(additionalSecurityGroupsIDs, ids...)
+			// DescribeSecurityGroups doesn't guarantee an order, sort so the result is stable across reconciles.
+			sort.Strings(ids)
+			for _, id := range ids {
+				add(id)
+			}
 		}
 	}
 
@@ -891,3 +1029,16 @@ func getLaunchTemplateInstanceMarketOptionsRequest(spotMarketOptions *infrav1.Sp
 
 	return launchTemplateInstanceMarketOptionsRequest
 }
+
+func getLaunchTemplateCapacityReservationSpecificationRequest(capacityReservationID *string) *ec2.LaunchTemplateCapacityReservationSpecificationRequest {
+	if aws.StringValue(capacityReservationID) == "" {
+		return nil
+	}
+
+	// Targeting a reservation launches the instances into it, and only into it.
+	return &ec2.LaunchTemplateCapacityReservationSpecificationRequest{
+		CapacityReservationTarget: &ec2.CapacityReservationTarget{
+			CapacityReservationId: capacityReservationID,
+		},
+	}
+}
@@ -340,6 +340,62 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 			},
 			wantHash: testUserDataHash,
 		},
+		{
+			name: "capacity block",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId:      aws.String("foo-image"),
+					InstanceType: aws.String("p5.48xlarge"),
+					InstanceMarketOptions: &ec2.LaunchTemplateInstanceMarketOptions{
+						MarketType: aws.String("capacity-block"),
+					},
+					CapacityReservationSpecification: &ec2.LaunchTemplateCapacityReservationSpecificationResponse{
+						CapacityReservationTarget: &ec2.CapacityReservationTargetResponse{
+							CapacityReservationId: aws.String("cr-12345"),
+						},
+					},
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				InstanceType:          "p5.48xlarge",
+				MarketType:            expinfrav1.MarketTypeCapacityBlock,
+				CapacityReservationID: aws.String("cr-12345"),
+				VersionNumber:         aws.Int64(1),
+			},
+			wantHash: userdata.ComputeHash(nil),
+		},
+		{
+			name: "credit specification",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId:      aws.String("foo-image"),
+					InstanceType: aws.String("t3.large"),
+					CreditSpecification: &ec2.CreditSpecification{
+						CpuCredits: aws.String("standard"),
+					},
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				InstanceType:        "t3.large",
+				CreditSpecification: &infrav1.CreditSpecification{CPUCredits: infrav1.CPUCreditsStandard},
+				VersionNumber:       aws.Int64(1),
+			},
+			wantHash: userdata.ComputeHash(nil),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -441,6 +497,29 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "Should return true if incoming CapacityReservationID is not same as existing CapacityReservationID",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				MarketType:            expinfrav1.MarketTypeCapacityBlock,
+				CapacityReservationID: aws.String("cr-12345"),
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				MarketType:            expinfrav1.MarketTypeCapacityBlock,
+				CapacityReservationID: aws.String("cr-67890"),
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if incoming CreditSpecification is not same as existing CreditSpecification",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				InstanceType:        "t3.large",
+				CreditSpecification: &infrav1.CreditSpecification{CPUCredits: infrav1.CPUCreditsStandard},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				InstanceType: "t3.large",
+			},
+			want: true,
+		},
 		{
 			name: "new additional security group with filters",
 			incoming: &expinfrav1.AWSLaunchTemplate{
@@ -645,6 +724,82 @@ func TestGetLaunchTemplateID(t *testing.T) {
 	}
 }
 
+func TestGetAdditionalSecurityGroupsIDs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name           string
+		securityGroups []infrav1.AWSResourceReference
+		expect         func(m *mocks.MockEC2APIMockRecorder)
+		check          func(g *WithT, ids []string, err error)
+	}{
+		{
+			name:           "Should return IDs in the order they were specified",
+			securityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-2")}, {ID: aws.String("sg-1")}},
+			check: func(g *WithT, ids []string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(ids).To(Equal([]string{"sg-2", "sg-1"}))
+			},
+		},
+		{
+			name: "Should sort groups resolved from filters and drop duplicates",
+			securityGroups: []infrav1.AWSResourceReference{
+				{ID: aws.String("sg-3")},
+				{Filters: []infrav1.Filter{{Name: "tag:role", Values: []string{"extra"}}}},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(gomock.Eq(&ec2.DescribeSecurityGroupsInput{
+					Filters: []*ec2.Filter{{Name: aws.String("tag:role"), Values: aws.StringSlice([]string{"extra"})}},
+				})).Return(&ec2.DescribeSecurityGroupsOutput{
+					SecurityGroups: []*ec2.SecurityGroup{
+						{GroupId: aws.String("sg-2")},
+						{GroupId: aws.String("sg-3")},
+						{GroupId: aws.String("sg-1")},
+					},
+				}, nil)
+			},
+			check: func(g *WithT, ids []string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(ids).To(Equal([]string{"sg-3", "sg-1", "sg-2"}))
+			},
+		},
+		{
+			name:           "Should return error if AWS failed to resolve filters",
+			securityGroups: []infrav1.AWSResourceReference{{Filters: []infrav1.Filter{{Name: "tag:role", Values: []string{"extra"}}}}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(gomock.Any()).Return(nil, awserrors.NewFailedDependency("Dependency issue from AWS"))
+			},
+			check: func(g *WithT, ids []string, err error) {
+				g.Expect(err).To(HaveOccurred())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			mockEC2Client := mocks.NewMockEC2API(mockCtrl)
+
+			s := NewService(cs)
+			s.EC2Client = mockEC2Client
+
+			if tc.expect != nil {
+				tc.expect(mockEC2Client.EXPECT())
+			}
+			ids, err := s.GetAdditionalSecurityGroupsIDs(tc.securityGroups)
+			tc.check(g, ids, err)
+		})
+	}
+}
+
 func TestDeleteLaunchTemplate(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -954,6 +1109,72 @@ func TestLaunchTemplateDataCreation(t *testing.T) {
 	})
 }
 
+func TestLaunchTemplateDataSecurityProfile(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	t.Run("Should require IMDSv2 and encrypt the root volume", func(t *testing.T) {
+		g := NewWithT(t)
+		scheme, err := setupScheme()
+		g.Expect(err).NotTo(HaveOccurred())
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		cs, err := setupClusterScope(client)
+		g.Expect(err).NotTo(HaveOccurred())
+		cs.AWSCluster.Spec.SecurityProfile = infrav1.SecurityProfileBaseline
+
+		ms, err := setupMachinePoolScope(client, cs)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		mockEC2Client := mocks.NewMockEC2API(mockCtrl)
+		mockEC2Client.EXPECT().DescribeImages(gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+			Return(&ec2.DescribeImagesOutput{
+				Images: []*ec2.Image{
+					{
+						RootDeviceName: aws.String("/dev/sda1"),
+						BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+							{
+								DeviceName: aws.String("/dev/sda1"),
+								Ebs:        &ec2.EbsBlockDevice{VolumeSize: aws.Int64(8)},
+							},
+						},
+					},
+				},
+			}, nil).AnyTimes()
+
+		s := NewService(cs)
+		s.EC2Client = mockEC2Client
+
+		data, err := s.createLaunchTemplateData(ms, aws.String("imageID"), nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(data.MetadataOptions).NotTo(BeNil())
+		g.Expect(aws.StringValue(data.MetadataOptions.HttpTokens)).To(Equal(string(infrav1.HTTPTokensStateRequired)))
+		g.Expect(data.BlockDeviceMappings).To(HaveLen(1))
+		g.Expect(aws.BoolValue(data.BlockDeviceMappings[0].Ebs.Encrypted)).To(BeTrue())
+	})
+
+	t.Run("Should reject an unencrypted root volume", func(t *testing.T) {
+		g := NewWithT(t)
+		scheme, err := setupScheme()
+		g.Expect(err).NotTo(HaveOccurred())
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		cs, err := setupClusterScope(client)
+		g.Expect(err).NotTo(HaveOccurred())
+		cs.AWSCluster.Spec.SecurityProfile = infrav1.SecurityProfileBaseline
+
+		ms, err := setupMachinePoolScope(client, cs)
+		g.Expect(err).NotTo(HaveOccurred())
+		ms.AWSMachinePool.Spec.AWSLaunchTemplate.RootVolume = &infrav1.Volume{Encrypted: aws.Bool(false)}
+
+		s := NewService(cs)
+		s.EC2Client = mocks.NewMockEC2API(mockCtrl)
+
+		_, err = s.createLaunchTemplateData(ms, aws.String("imageID"), nil)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
 func TestCreateLaunchTemplateVersion(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
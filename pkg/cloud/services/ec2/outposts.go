@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/outposts"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// checkOutpostInstanceType ensures that the instance type of the machine is available on the Outpost
+// the machine is launched on, as Outposts only provide the capacity they were ordered with.
+func (s *Service) checkOutpostInstanceType(scope *scope.MachineScope) error {
+	outpostARN := scope.AWSMachine.Spec.OutpostARN
+	if outpostARN == "" {
+		return nil
+	}
+
+	instanceType := scope.AWSMachine.Spec.InstanceType
+	available := false
+	if err := s.OutpostsClient.GetOutpostInstanceTypesPages(&outposts.GetOutpostInstanceTypesInput{
+		OutpostId: aws.String(outpostARN),
+	}, func(page *outposts.GetOutpostInstanceTypesOutput, lastPage bool) bool {
+		for _, item := range page.InstanceTypes {
+			if aws.StringValue(item.InstanceType) == instanceType {
+				available = true
+				return false
+			}
+		}
+		return true
+	}); err != nil {
+		return errors.Wrapf(err, "failed to get the instance types of Outpost %q", outpostARN)
+	}
+
+	if !available {
+		errMessage := fmt.Sprintf("failed to run machine %q, instance type %q is not available on Outpost %q", scope.Name(), instanceType, outpostARN)
+		record.Warnf(scope.AWSMachine, "FailedCreate", errMessage)
+		return errors.New(errMessage)
+	}
+
+	return nil
+}
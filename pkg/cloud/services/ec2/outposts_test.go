@@ -0,0 +1,197 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/outposts"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const testOutpostARN = "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"
+
+func newOutpostMachineScope(g *WithT, outpostARN string) (*scope.ClusterScope, *scope.MachineScope) {
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	awsCluster := newAWSCluster()
+	awsCluster.Spec.NetworkSpec.Subnets = infrav1.Subnets{
+		{ID: "subnet-region", AvailabilityZone: "us-east-1a"},
+		{ID: "subnet-outpost", AvailabilityZone: "us-east-1a", OutpostARN: testOutpostARN},
+	}
+	cluster := newCluster()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     client,
+		Cluster:    cluster,
+		AWSCluster: awsCluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:       client,
+		Cluster:      cluster,
+		Machine:      &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"}},
+		AWSMachine:   &infrav1.AWSMachine{ObjectMeta: metav1.ObjectMeta{Name: "aws-test-machine", Namespace: "default"}},
+		InfraCluster: clusterScope,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	machineScope.AWSMachine.Spec.InstanceType = "m5.large"
+	machineScope.AWSMachine.Spec.OutpostARN = outpostARN
+
+	return clusterScope, machineScope
+}
+
+func TestCheckOutpostInstanceType(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name          string
+		outpostARN    string
+		instanceTypes []string
+		expectError   bool
+	}{
+		{
+			name: "Should not check machines without an Outpost",
+		},
+		{
+			name:          "Should succeed when the instance type is available on the Outpost",
+			outpostARN:    testOutpostARN,
+			instanceTypes: []string{"c5.large", "m5.large"},
+		},
+		{
+			name:          "Should fail when the instance type is not available on the Outpost",
+			outpostARN:    testOutpostARN,
+			instanceTypes: []string{"c5.large"},
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope, machineScope := newOutpostMachineScope(g, tc.outpostARN)
+
+			outpostsMock := mocks.NewMockOutpostsAPI(mockCtrl)
+			if tc.outpostARN != "" {
+				outpostsMock.EXPECT().GetOutpostInstanceTypesPages(&outposts.GetOutpostInstanceTypesInput{
+					OutpostId: aws.String(tc.outpostARN),
+				}, gomock.Any()).DoAndReturn(func(_ *outposts.GetOutpostInstanceTypesInput, fn func(*outposts.GetOutpostInstanceTypesOutput, bool) bool) error {
+					page := &outposts.GetOutpostInstanceTypesOutput{}
+					for _, instanceType := range tc.instanceTypes {
+						page.InstanceTypes = append(page.InstanceTypes, &outposts.InstanceTypeItem{InstanceType: aws.String(instanceType)})
+					}
+					fn(page, true)
+					return nil
+				})
+			}
+
+			s := NewService(clusterScope)
+			s.OutpostsClient = outpostsMock
+
+			err := s.checkOutpostInstanceType(machineScope)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestFindSubnetOnOutpost(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		outpostARN     string
+		subnetID       *string
+		expectSubnets  func(m *mocks.MockEC2APIMockRecorder)
+		expectedSubnet string
+		expectError    bool
+	}{
+		{
+			name:           "Should pick a subnet of the region for machines without an Outpost",
+			expectedSubnet: "subnet-region",
+		},
+		{
+			name:           "Should pick a subnet on the Outpost of the machine",
+			outpostARN:     testOutpostARN,
+			expectedSubnet: "subnet-outpost",
+		},
+		{
+			name:       "Should reject an explicit subnet which is not on the Outpost of the machine",
+			outpostARN: testOutpostARN,
+			subnetID:   aws.String("subnet-region"),
+			expectSubnets: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-region"), AvailabilityZone: aws.String("us-east-1a")}},
+				}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:     "Should reject an explicit subnet on an Outpost for machines without an Outpost",
+			subnetID: aws.String("subnet-outpost"),
+			expectSubnets: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-outpost"), AvailabilityZone: aws.String("us-east-1a"), OutpostArn: aws.String(testOutpostARN)}},
+				}, nil)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope, machineScope := newOutpostMachineScope(g, tc.outpostARN)
+			if tc.subnetID != nil {
+				machineScope.AWSMachine.Spec.Subnet = &infrav1.AWSResourceReference{ID: tc.subnetID}
+			}
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			if tc.expectSubnets != nil {
+				tc.expectSubnets(ec2Mock.EXPECT())
+			}
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			subnetID, err := s.findSubnet(machineScope)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(subnetID).To(Equal(tc.expectedSubnet))
+		})
+	}
+}
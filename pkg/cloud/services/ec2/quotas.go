@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
+)
+
+// reserveInstanceQuota verifies that running the instance doesn't exceed the On-Demand vCPU quota, and
+// reserves its vCPUs until the instance is created. The returned reservation must be committed once the
+// instance was created, or released. Spot instances and instance types outside of the standard families
+// are subject to other quotas and are not verified. Failures to look up the quota are not fatal, as they
+// shouldn't prevent the instance from being created.
+func (s *Service) reserveInstanceQuota(i *infrav1.Instance) (*servicequotas.Reservation, error) {
+	if s.QuotaService == nil || i.SpotMarketOptions != nil || !servicequotas.IsStandardInstanceType(i.Type) {
+		return nil, nil
+	}
+
+	vcpus, err := s.QuotaService.InstanceTypeVCPUs(i.Type)
+	if err != nil {
+		s.scope.Error(err, "non-fatal: failed to verify service quota", "quota", servicequotas.OnDemandStandardInstanceVCPUs.Name)
+		return nil, nil
+	}
+
+	reservation, err := s.QuotaService.Reserve(servicequotas.OnDemandStandardInstanceVCPUs, vcpus)
+	switch {
+	case err == nil:
+		return reservation, nil
+	case awserrors.IsQuotaExceeded(err):
+		return nil, err
+	default:
+		s.scope.Error(err, "non-fatal: failed to verify service quota", "quota", servicequotas.OnDemandStandardInstanceVCPUs.Name)
+		return nil, nil
+	}
+}
+
+// CheckSecurityGroupsPerNetworkInterface verifies that the given number of security groups can be attached
+// to the network interfaces of an instance, returning a *servicequotas.QuotaExceededError if they can't.
+// The quota of the account is used when it can be looked up, falling back to the largest quota AWS allows.
+func (s *Service) CheckSecurityGroupsPerNetworkInterface(count int) error {
+	limit := infrav1.MaxSecurityGroupsPerNetworkInterface
+	if s.QuotaService != nil {
+		quotaLimit, err := s.QuotaService.GetLimit(servicequotas.SecurityGroupsPerNetworkInterface)
+		if err != nil {
+			s.scope.Error(err, "non-fatal: failed to verify service quota", "quota", servicequotas.SecurityGroupsPerNetworkInterface.Name)
+		} else {
+			limit = quotaLimit
+		}
+	}
+
+	if count > limit {
+		return &servicequotas.QuotaExceededError{
+			Usage:     servicequotas.Usage{Quota: servicequotas.SecurityGroupsPerNetworkInterface, Limit: limit},
+			Requested: count,
+		}
+	}
+	return nil
+}
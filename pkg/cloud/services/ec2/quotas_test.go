@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsservicequotas "github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas/mock_servicequotasiface"
+)
+
+func TestCheckSecurityGroupsPerNetworkInterface(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name              string
+		count             int
+		noQuotaService    bool
+		expectQuotas      func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder)
+		wantQuotaExceeded bool
+	}{
+		{
+			name:  "Should succeed within the quota of the account",
+			count: 5,
+			expectQuotas: func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder) {
+				m.GetServiceQuota(&awsservicequotas.GetServiceQuotaInput{
+					ServiceCode: aws.String("vpc"),
+					QuotaCode:   aws.String("L-2AFB9258"),
+				}).Return(&awsservicequotas.GetServiceQuotaOutput{Quota: &awsservicequotas.ServiceQuota{Value: aws.Float64(5)}}, nil)
+			},
+		},
+		{
+			name:  "Should return a quota exceeded error above the quota of the account",
+			count: 6,
+			expectQuotas: func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder) {
+				m.GetServiceQuota(gomock.Any()).
+					Return(&awsservicequotas.GetServiceQuotaOutput{Quota: &awsservicequotas.ServiceQuota{Value: aws.Float64(5)}}, nil)
+			},
+			wantQuotaExceeded: true,
+		},
+		{
+			name:  "Should fall back to the largest quota when the quota can't be retrieved",
+			count: 16,
+			expectQuotas: func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder) {
+				m.GetServiceQuota(gomock.Any()).Return(nil, awserr.New("AccessDeniedException", "access denied", nil))
+			},
+		},
+		{
+			name:              "Should fall back to the largest quota without a quota service",
+			count:             17,
+			noQuotaService:    true,
+			wantQuotaExceeded: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			clusterScope, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(clusterScope)
+			if !tc.noQuotaService {
+				quotasMock := mock_servicequotasiface.NewMockServiceQuotasAPI(mockCtrl)
+				tc.expectQuotas(quotasMock.EXPECT())
+				s.QuotaService = &servicequotas.Service{ServiceQuotasClient: quotasMock}
+			}
+
+			err = s.CheckSecurityGroupsPerNetworkInterface(tc.count)
+			if tc.wantQuotaExceeded {
+				g.Expect(awserrors.IsQuotaExceeded(err)).To(BeTrue())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -18,9 +18,11 @@ package ec2
 
 import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/outposts/outpostsiface"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
 )
 
 // Service holds a collection of interfaces.
@@ -32,13 +34,21 @@ type Service struct {
 
 	// SSMClient is used to look up the official EKS AMI ID
 	SSMClient ssmiface.SSMAPI
+
+	// OutpostsClient is used to verify that instance types are available on Outposts
+	OutpostsClient outpostsiface.OutpostsAPI
+
+	// QuotaService is used to verify service quotas before creating instances.
+	// Quotas are not verified when it is nil.
+	QuotaService *servicequotas.Service
 }
 
 // NewService returns a new service given the ec2 api client.
 func NewService(clusterScope scope.EC2Scope) *Service {
 	return &Service{
-		scope:     clusterScope,
-		EC2Client: scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
-		SSMClient: scope.NewSSMClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		scope:          clusterScope,
+		EC2Client:      scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		SSMClient:      scope.NewSSMClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		OutpostsClient: scope.NewOutpostsClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
 	}
 }
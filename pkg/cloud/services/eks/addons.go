@@ -18,6 +18,7 @@ package eks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -26,10 +27,13 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/awsnode"
 	eksaddons "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/addons"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
+const vpcCniAddonName = "vpc-cni"
+
 func (s *Service) reconcileAddons(ctx context.Context) error {
 	s.scope.Info("Reconciling EKS addons")
 
@@ -128,6 +132,7 @@ func (s *Service) getClusterAddonsInstalled(eksClusterName string, addonNames []
 			Tags:                  infrav1.Tags{},
 			Status:                describeOutput.Addon.Status,
 			ServiceAccountRoleARN: describeOutput.Addon.ServiceAccountRoleArn,
+			Configuration:         describeOutput.Addon.ConfigurationValues,
 		}
 		for k, v := range describeOutput.Addon.Tags {
 			installedAddon.Tags[k] = *v
@@ -188,6 +193,54 @@ func (s *Service) listAddons(eksClusterName string) ([]*string, error) {
 	return addons, nil
 }
 
+// incompatibleAddons returns the addons in the spec which aren't compatible with the given
+// Kubernetes version. Addons pinned to a version need that version to be compatible, other
+// addons need any of their versions to be.
+func (s *Service) incompatibleAddons(kubernetesVersion string) ([]string, error) {
+	incompatible := []string{}
+	for _, addon := range s.scope.Addons() {
+		input := &eks.DescribeAddonVersionsInput{
+			AddonName:         aws.String(addon.Name),
+			KubernetesVersion: aws.String(kubernetesVersion),
+		}
+		output, err := s.EKSClient.DescribeAddonVersions(input)
+		if err != nil {
+			return nil, fmt.Errorf("describing versions of eks addon %s: %w", addon.Name, err)
+		}
+
+		compatible := false
+		for _, info := range output.Addons {
+			for _, versionInfo := range info.AddonVersions {
+				if addon.Version != "" && aws.StringValue(versionInfo.AddonVersion) != addon.Version {
+					continue
+				}
+				if addonVersionCompatible(versionInfo, kubernetesVersion) {
+					compatible = true
+					break
+				}
+			}
+		}
+		if !compatible {
+			if addon.Version != "" {
+				incompatible = append(incompatible, fmt.Sprintf("%s %s", addon.Name, addon.Version))
+			} else {
+				incompatible = append(incompatible, addon.Name)
+			}
+		}
+	}
+
+	return incompatible, nil
+}
+
+func addonVersionCompatible(versionInfo *eks.AddonVersionInfo, kubernetesVersion string) bool {
+	for _, compatibility := range versionInfo.Compatibilities {
+		if aws.StringValue(compatibility.ClusterVersion) == kubernetesVersion {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon) []*eksaddons.EKSAddon {
 	converted := []*eksaddons.EKSAddon{}
 
@@ -200,6 +253,9 @@ func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon) []*eksad
 			ResolveConflict:       convertConflictResolution(*addon.ConflictResolution),
 			ServiceAccountRoleARN: addon.ServiceAccountRoleArn,
 		}
+		if addon.Name == vpcCniAddonName && s.scope.VpcCni().CustomNetworking && s.scope.SecondaryCidrBlock() != nil {
+			convertedAddon.Configuration = vpcCniCustomNetworkingConfiguration()
+		}
 
 		converted = append(converted, convertedAddon)
 	}
@@ -207,6 +263,17 @@ func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon) []*eksad
 	return converted
 }
 
+// vpcCniCustomNetworkingConfiguration returns the configuration values of the vpc-cni addon enabling custom networking.
+func vpcCniCustomNetworkingConfiguration() *string {
+	env := map[string]string{}
+	for _, e := range awsnode.CustomNetworkingEnv() {
+		env[e.Name] = e.Value
+	}
+	// Marshalling a map of strings can't fail, and sorts its keys.
+	config, _ := json.Marshal(map[string]interface{}{"env": env})
+	return aws.String(string(config))
+}
+
 func convertConflictResolution(conflict ekscontrolplanev1.AddonResolution) *string {
 	if conflict == ekscontrolplanev1.AddonResolutionNone {
 		return aws.String(eks.ResolveConflictsNone)
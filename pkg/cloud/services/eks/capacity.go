@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+)
+
+const (
+	// autoscalerLabelsAnnotation is the annotation of the MachinePool the cluster-autoscaler reads the
+	// labels of the nodes of a pool scaled to zero from.
+	autoscalerLabelsAnnotation = "capacity.cluster-autoscaler.kubernetes.io/labels"
+	// autoscalerTaintsAnnotation is the annotation of the MachinePool the cluster-autoscaler reads the
+	// taints of the nodes of a pool scaled to zero from.
+	autoscalerTaintsAnnotation = "capacity.cluster-autoscaler.kubernetes.io/taints"
+)
+
+// reconcileCapacity publishes the capacity of the nodes of the managed machine pool in its status, and
+// their labels and taints as annotations of the MachinePool, so that the cluster-autoscaler is able to
+// scale the pool up from zero.
+func (s *NodegroupService) reconcileCapacity(ctx context.Context) error {
+	pool := &s.scope.ManagedMachinePool.Spec
+
+	instanceType, err := s.instanceTypeInfo()
+	if err != nil {
+		return err
+	}
+	if instanceType == nil {
+		s.scope.ManagedMachinePool.Status.Capacity = nil
+	} else {
+		capacity := converters.InstanceTypeInfoToResourceList(instanceType)
+		if diskSize := rootVolumeSize(pool); diskSize > 0 {
+			capacity[corev1.ResourceEphemeralStorage] = resource.MustParse(fmt.Sprintf("%dGi", diskSize))
+		}
+		s.scope.ManagedMachinePool.Status.Capacity = capacity
+	}
+
+	annotations, err := autoscalerAnnotations(pool)
+	if err != nil {
+		return err
+	}
+
+	machinePool := s.scope.MachinePool
+	changed := false
+	for k, v := range annotations {
+		if machinePool.Annotations[k] == v {
+			continue
+		}
+		if machinePool.Annotations == nil {
+			machinePool.Annotations = map[string]string{}
+		}
+		machinePool.Annotations[k] = v
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	return s.scope.PatchCAPIMachinePoolObject(ctx)
+}
+
+// autoscalerAnnotations returns the cluster-autoscaler annotations for the labels and taints of the
+// managed machine pool. Labels and taints set on the MachinePool by users are only overwritten when
+// the managed machine pool defines some.
+func autoscalerAnnotations(pool *expinfrav1.AWSManagedMachinePoolSpec) (map[string]string, error) {
+	annotations := map[string]string{}
+
+	if len(pool.Labels) > 0 {
+		labels := make([]string, 0, len(pool.Labels))
+		for k, v := range pool.Labels {
+			labels = append(labels, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(labels)
+		annotations[autoscalerLabelsAnnotation] = strings.Join(labels, ",")
+	}
+
+	if len(pool.Taints) > 0 {
+		taints := make([]string, 0, len(pool.Taints))
+		for _, taint := range pool.Taints {
+			effect, err := converters.TaintEffectToKubernetes(taint.Effect)
+			if err != nil {
+				return nil, fmt.Errorf("converting taint %s: %w", taint.Key, err)
+			}
+			taints = append(taints, fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, effect))
+		}
+		annotations[autoscalerTaintsAnnotation] = strings.Join(taints, ",")
+	}
+
+	return annotations, nil
+}
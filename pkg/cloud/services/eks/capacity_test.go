@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+func TestAutoscalerAnnotations(t *testing.T) {
+	testCases := []struct {
+		name      string
+		pool      expinfrav1.AWSManagedMachinePoolSpec
+		expect    map[string]string
+		expectErr bool
+	}{
+		{
+			name:   "no labels or taints",
+			pool:   expinfrav1.AWSManagedMachinePoolSpec{},
+			expect: map[string]string{},
+		},
+		{
+			name: "labels and taints",
+			pool: expinfrav1.AWSManagedMachinePoolSpec{
+				Labels: map[string]string{
+					"team":                           "a",
+					"node-role.kubernetes.io/worker": "",
+				},
+				Taints: expinfrav1.Taints{
+					{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoSchedule},
+					{Key: "spot", Value: "true", Effect: expinfrav1.TaintEffectPreferNoSchedule},
+				},
+			},
+			expect: map[string]string{
+				autoscalerLabelsAnnotation: "node-role.kubernetes.io/worker=,team=a",
+				autoscalerTaintsAnnotation: "dedicated=gpu:NoSchedule,spot=true:PreferNoSchedule",
+			},
+		},
+		{
+			name: "unknown taint effect",
+			pool: expinfrav1.AWSManagedMachinePoolSpec{
+				Taints: expinfrav1.Taints{
+					{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffect("unknown")},
+				},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			annotations, err := autoscalerAnnotations(&tc.pool)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(annotations).To(Equal(tc.expect))
+		})
+	}
+}
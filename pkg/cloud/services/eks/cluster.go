@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -55,6 +56,14 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 	}
 
 	if cluster == nil {
+		if err := s.validateClusterSubnets(); err != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedValidateEKSSubnets", "Cluster subnets don't meet EKS requirements: %v", err)
+			return err
+		}
+		if err := s.validateClusterSecurityGroups(); err != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedValidateEKSSecurityGroups", "Cluster security groups don't meet EKS requirements: %v", err)
+			return err
+		}
 		cluster, err = s.createCluster(eksClusterName)
 		if err != nil {
 			return errors.Wrap(err, "failed to create cluster")
@@ -265,7 +274,7 @@ func makeKubernetesNetworkConfig(serviceCidrs *clusterv1.NetworkRanges) (*eks.Ku
 	}, nil
 }
 
-func makeVpcConfig(subnets infrav1.Subnets, endpointAccess ekscontrolplanev1.EndpointAccess, securityGroups map[infrav1.SecurityGroupRole]infrav1.SecurityGroup) (*eks.VpcConfigRequest, error) {
+func makeVpcConfig(subnets infrav1.Subnets, endpointAccess ekscontrolplanev1.EndpointAccess, securityGroups map[infrav1.SecurityGroupRole]infrav1.SecurityGroup, additionalSecurityGroupIDs []string) (*eks.VpcConfigRequest, error) {
 	// TODO: Do we need to just add the private subnets?
 	if len(subnets) < 2 {
 		return nil, awserrors.NewFailedDependency("at least 2 subnets is required")
@@ -304,6 +313,9 @@ func makeVpcConfig(subnets infrav1.Subnets, endpointAccess ekscontrolplanev1.End
 	if ok {
 		vpcConfig.SecurityGroupIds = append(vpcConfig.SecurityGroupIds, &sg.ID)
 	}
+	for _, id := range additionalSecurityGroupIDs {
+		vpcConfig.SecurityGroupIds = append(vpcConfig.SecurityGroupIds, aws.String(id))
+	}
 	return vpcConfig, nil
 }
 
@@ -356,7 +368,7 @@ func makeEksLogging(loggingSpec *ekscontrolplanev1.ControlPlaneLoggingSpec) *eks
 func (s *Service) createCluster(eksClusterName string) (*eks.Cluster, error) {
 	logging := makeEksLogging(s.scope.ControlPlane.Spec.Logging)
 	encryptionConfigs := makeEksEncryptionConfigs(s.scope.ControlPlane.Spec.EncryptionConfig)
-	vpcConfig, err := makeVpcConfig(s.scope.Subnets(), s.scope.ControlPlane.Spec.EndpointAccess, s.scope.SecurityGroups())
+	vpcConfig, err := makeVpcConfig(s.scope.ControlPlaneSubnets(), s.scope.ControlPlane.Spec.EndpointAccess, s.scope.SecurityGroups(), s.scope.ControlPlane.Spec.AdditionalSecurityGroupIDs)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't create vpc config for cluster")
 	}
@@ -414,6 +426,9 @@ func (s *Service) createCluster(eksClusterName string) (*eks.Cluster, error) {
 		return true, nil
 	}, awserrors.ResourceNotFound); err != nil { //TODO: change the error that can be retried
 		record.Warnf(s.scope.ControlPlane, "FailedCreateEKSControlPlane", "Failed to initiate creation of a new EKS control plane: %v", err)
+		if code, _ := awserrors.Code(err); code == eks.ErrCodeUnsupportedAvailabilityZoneException {
+			return nil, errors.Wrap(ErrClusterSubnetsInvalid, awserrors.Message(err))
+		}
 		return nil, errors.Wrapf(err, "failed to create EKS cluster")
 	}
 
@@ -445,24 +460,25 @@ func (s *Service) waitForClusterActive() (*eks.Cluster, error) {
 }
 
 func (s *Service) reconcileClusterConfig(cluster *eks.Cluster) error {
-	var needsUpdate bool
 	input := eks.UpdateClusterConfigInput{Name: aws.String(s.scope.KubernetesClusterName())}
 
-	if updateLogging := s.reconcileLogging(cluster.Logging); updateLogging != nil {
-		needsUpdate = true
-		input.Logging = updateLogging
-	}
-
 	updateVpcConfig, err := s.reconcileVpcConfig(cluster.ResourcesVpcConfig)
 	if err != nil {
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessReconciledCondition, ekscontrolplanev1.EKSEndpointAccessUpdateFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return errors.Wrap(err, "couldn't create vpc config for cluster")
 	}
-	if updateVpcConfig != nil {
-		needsUpdate = true
+	if updateVpcConfig == nil {
+		conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessReconciledCondition)
+	}
+
+	// EKS only accepts one type of update at a time: the vpc config is updated once the logging update completed.
+	if updateLogging := s.reconcileLogging(cluster.Logging); updateLogging != nil {
+		input.Logging = updateLogging
+	} else if updateVpcConfig != nil {
 		input.ResourcesVpcConfig = updateVpcConfig
 	}
 
-	if needsUpdate {
+	if input.Logging != nil || input.ResourcesVpcConfig != nil {
 		if err := input.Validate(); err != nil {
 			return errors.Wrap(err, "created invalid UpdateClusterConfigInput")
 		}
@@ -477,9 +493,16 @@ func (s *Service) reconcileClusterConfig(cluster *eks.Cluster) error {
 			record.Eventf(s.scope.ControlPlane, "InitiatedUpdateEKSControlPlane", "Initiated update of a new EKS control plane %s", s.scope.KubernetesClusterName())
 			return true, nil
 		}); err != nil {
+			if input.ResourcesVpcConfig != nil {
+				conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessReconciledCondition, ekscontrolplanev1.EKSEndpointAccessUpdateFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			}
 			record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "Failed to update the EKS control plane: %v", err)
 			return errors.Wrapf(err, "failed to update EKS cluster")
 		}
+		if input.ResourcesVpcConfig != nil {
+			conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessReconciledCondition, ekscontrolplanev1.EKSEndpointAccessUpdatingReason, clusterv1.ConditionSeverityInfo,
+				"EKS is updating the endpoint access of the cluster, which can take 10 minutes or more")
+		}
 	}
 	return nil
 }
@@ -511,18 +534,25 @@ func publicAccessCIDRsEqual(as []*string, bs []*string) bool {
 
 func (s *Service) reconcileVpcConfig(vpcConfig *eks.VpcConfigResponse) (*eks.VpcConfigRequest, error) {
 	endpointAccess := s.scope.ControlPlane.Spec.EndpointAccess
-	updatedVpcConfig, err := makeVpcConfig(s.scope.Subnets(), endpointAccess, s.scope.SecurityGroups())
+	updatedVpcConfig, err := makeVpcConfig(s.scope.ControlPlaneSubnets(), endpointAccess, s.scope.SecurityGroups(), s.scope.ControlPlane.Spec.AdditionalSecurityGroupIDs)
 	if err != nil {
 		return nil, err
 	}
+	// The CIDR blocks allowed to access the public endpoint only matter while it is enabled.
+	publicAccess := updatedVpcConfig.EndpointPublicAccess == nil || *updatedVpcConfig.EndpointPublicAccess
 	needsUpdate := !tristate.EqualWithDefault(false, vpcConfig.EndpointPrivateAccess, updatedVpcConfig.EndpointPrivateAccess) ||
 		!tristate.EqualWithDefault(true, vpcConfig.EndpointPublicAccess, updatedVpcConfig.EndpointPublicAccess) ||
-		!publicAccessCIDRsEqual(vpcConfig.PublicAccessCidrs, updatedVpcConfig.PublicAccessCidrs)
+		(publicAccess && !publicAccessCIDRsEqual(vpcConfig.PublicAccessCidrs, updatedVpcConfig.PublicAccessCidrs))
 	if needsUpdate {
+		publicAccessCidrs := updatedVpcConfig.PublicAccessCidrs
+		if publicAccess && len(publicAccessCidrs) == 0 {
+			// EKS keeps the current CIDR blocks when none are given, removing them allows all addresses again.
+			publicAccessCidrs = aws.StringSlice([]string{"0.0.0.0/0"})
+		}
 		return &eks.VpcConfigRequest{
 			EndpointPublicAccess:  updatedVpcConfig.EndpointPublicAccess,
 			EndpointPrivateAccess: updatedVpcConfig.EndpointPrivateAccess,
-			PublicAccessCidrs:     updatedVpcConfig.PublicAccessCidrs,
+			PublicAccessCidrs:     publicAccessCidrs,
 		}, nil
 	}
 	return nil, nil
@@ -539,21 +569,31 @@ func (s *Service) reconcileEKSEncryptionConfig(currentClusterConfig []*eks.Encry
 
 	if compareEncryptionConfig(currentClusterConfig, updatedEncryptionConfigs) {
 		s.Debug("encryption configuration unchanged, no action")
+		if len(updatedEncryptionConfigs) == 0 {
+			conditions.Delete(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition)
+		} else {
+			conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition)
+		}
 		return nil
 	}
 
 	if len(currentClusterConfig) == 0 && len(updatedEncryptionConfigs) > 0 {
 		s.Debug("enabling encryption for eks cluster", "cluster", s.scope.KubernetesClusterName())
 		if err := s.updateEncryptionConfig(updatedEncryptionConfigs); err != nil {
+			conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition, ekscontrolplanev1.EKSEncryptionConfigAssociationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 			record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "failed to update the EKS control plane encryption configuration: %v", err)
 			return errors.Wrapf(err, "failed to update EKS cluster")
 		}
 
+		// The encryption config of the cluster is only reported once EKS has encrypted the existing
+		// resources, which keeps the cluster updating until then.
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition, ekscontrolplanev1.EKSEncryptionConfigAssociatingReason, clusterv1.ConditionSeverityInfo, "")
 		return nil
 	}
 
-	record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "failed to update the EKS control plane: disabling EKS encryption is not allowed after it has been enabled")
-	return errors.Errorf("failed to update the EKS control plane: disabling EKS encryption is not allowed after it has been enabled")
+	conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition, ekscontrolplanev1.EKSEncryptionConfigAssociationFailedReason, clusterv1.ConditionSeverityError, "disabling or changing EKS encryption is not allowed after it has been enabled")
+	record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "failed to update the EKS control plane: disabling or changing EKS encryption is not allowed after it has been enabled")
+	return errors.Errorf("failed to update the EKS control plane: disabling or changing EKS encryption is not allowed after it has been enabled")
 }
 
 func parseEKSVersion(raw string) *version.Version {
@@ -574,6 +614,24 @@ func (s *Service) reconcileClusterVersion(cluster *eks.Cluster) error {
 		// need to go 1.14-> 1.15 and then 1.15 -> 1.16.
 		nextVersionString := versionToEKS(clusterVersion.WithMinor(clusterVersion.Minor() + 1))
 
+		if s.scope.ControlPlane.Spec.SkipAddonCompatibilityCheck {
+			conditions.Delete(s.scope.ControlPlane, ekscontrolplanev1.EKSAddonsCompatibleCondition)
+		} else {
+			incompatible, err := s.incompatibleAddons(nextVersionString)
+			if err != nil {
+				return errors.Wrap(err, "failed checking eks addons compatibility")
+			}
+			if len(incompatible) > 0 {
+				// Leave the control plane on its current version rather than failing the
+				// reconciliation, so the rest of the cluster keeps being reconciled.
+				message := fmt.Sprintf("addons %s are not compatible with Kubernetes %s", strings.Join(incompatible, ", "), nextVersionString)
+				conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSAddonsCompatibleCondition, ekscontrolplanev1.EKSAddonsIncompatibleReason, clusterv1.ConditionSeverityWarning, message)
+				record.Warnf(s.scope.ControlPlane, "BlockedUpdateEKSControlPlane", "Blocked update of EKS control plane %s to version %s: %s", s.scope.KubernetesClusterName(), nextVersionString, message)
+				return nil
+			}
+			conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSAddonsCompatibleCondition)
+		}
+
 		input := &eks.UpdateClusterVersionInput{
 			Name:    aws.String(s.scope.KubernetesClusterName()),
 			Version: &nextVersionString,
@@ -605,6 +663,9 @@ func (s *Service) reconcileClusterVersion(cluster *eks.Cluster) error {
 			record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "failed to update the EKS control plane: %v", err)
 			return errors.Wrapf(err, "failed to update EKS cluster")
 		}
+	} else {
+		// The addons compatibility only matters while an upgrade is pending.
+		conditions.Delete(s.scope.ControlPlane, ekscontrolplanev1.EKSAddonsCompatibleCondition)
 	}
 	return nil
 }
@@ -616,16 +677,10 @@ func (s *Service) describeEKSCluster(eksClusterName string) (*eks.Cluster, error
 
 	out, err := s.EKSClient.DescribeCluster(input)
 	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			case eks.ErrCodeResourceNotFoundException:
-				return nil, nil
-			default:
-				return nil, errors.Wrap(err, "failed to describe cluster")
-			}
-		} else {
-			return nil, errors.Wrap(err, "failed to describe cluster")
+		if awserrors.IsNotFound(err) {
+			return nil, nil
 		}
+		return nil, errors.Wrap(err, "failed to describe cluster")
 	}
 
 	return out.Cluster, nil
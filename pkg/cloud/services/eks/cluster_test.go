@@ -25,6 +25,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/version"
@@ -37,6 +38,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestMakeEKSEncryptionConfigs(t *testing.T) {
@@ -138,6 +140,7 @@ func TestMakeVPCConfig(t *testing.T) {
 		subnets        infrav1.Subnets
 		endpointAccess ekscontrolplanev1.EndpointAccess
 		securityGroups map[infrav1.SecurityGroupRole]infrav1.SecurityGroup
+		additionalSGs  []string
 	}
 
 	idOne := "one"
@@ -236,6 +239,36 @@ func TestMakeVPCConfig(t *testing.T) {
 				SecurityGroupIds: []*string{&idOne},
 			},
 		},
+		{
+			name: "additional security groups",
+			input: input{
+				subnets: []infrav1.SubnetSpec{
+					{
+						ID:               idOne,
+						CidrBlock:        "10.0.10.0/24",
+						AvailabilityZone: "us-west-2a",
+						IsPublic:         true,
+					},
+					{
+						ID:               idTwo,
+						CidrBlock:        "10.0.10.0/24",
+						AvailabilityZone: "us-west-2b",
+						IsPublic:         false,
+					},
+				},
+				endpointAccess: ekscontrolplanev1.EndpointAccess{},
+				securityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+					infrav1.SecurityGroupEKSNodeAdditional: {
+						ID: idOne,
+					},
+				},
+				additionalSGs: []string{"sg-custom"},
+			},
+			expect: &eks.VpcConfigRequest{
+				SubnetIds:        []*string{&idOne, &idTwo},
+				SecurityGroupIds: []*string{&idOne, aws.String("sg-custom")},
+			},
+		},
 		{
 			name: "non canonical public access CIDR",
 			input: input{
@@ -266,7 +299,7 @@ func TestMakeVPCConfig(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			g := NewWithT(t)
-			config, err := makeVpcConfig(tc.input.subnets, tc.input.endpointAccess, tc.input.securityGroups)
+			config, err := makeVpcConfig(tc.input.subnets, tc.input.endpointAccess, tc.input.securityGroups, tc.input.additionalSGs)
 			if tc.err {
 				g.Expect(err).To(HaveOccurred())
 			} else {
@@ -357,10 +390,14 @@ func TestMakeEKSLogging(t *testing.T) {
 
 func TestReconcileClusterVersion(t *testing.T) {
 	clusterName := "default.cluster"
+	addons := []ekscontrolplanev1.Addon{{Name: "vpc-cni", Version: "v1.11.0-eksbuild.1"}}
 	tests := []struct {
-		name        string
-		expect      func(m *mock_eksiface.MockEKSAPIMockRecorder)
-		expectError bool
+		name                  string
+		addons                []ekscontrolplanev1.Addon
+		skipAddonCheck        bool
+		expect                func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectError           bool
+		expectAddonsCondition corev1.ConditionStatus
 	}{
 		{
 			name: "no upgrade necessary",
@@ -394,7 +431,8 @@ func TestReconcileClusterVersion(t *testing.T) {
 					UpdateClusterVersion(gomock.AssignableToTypeOf(&eks.UpdateClusterVersionInput{})).
 					Return(&eks.UpdateClusterVersionOutput{}, nil)
 			},
-			expectError: false,
+			expectError:           false,
+			expectAddonsCondition: corev1.ConditionTrue,
 		},
 		{
 			name: "api error",
@@ -413,6 +451,167 @@ func TestReconcileClusterVersion(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name:   "needs upgrade and addons are compatible",
+			addons: addons,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeCluster(gomock.AssignableToTypeOf(&eks.DescribeClusterInput{})).
+					Return(&eks.DescribeClusterOutput{
+						Cluster: &eks.Cluster{
+							Name:    aws.String("default.cluster"),
+							Version: aws.String("1.14"),
+						},
+					}, nil)
+				m.
+					DescribeAddonVersions(&eks.DescribeAddonVersionsInput{
+						AddonName:         aws.String("vpc-cni"),
+						KubernetesVersion: aws.String("1.15"),
+					}).
+					Return(&eks.DescribeAddonVersionsOutput{
+						Addons: []*eks.AddonInfo{{
+							AddonName: aws.String("vpc-cni"),
+							AddonVersions: []*eks.AddonVersionInfo{
+								{
+									AddonVersion:    aws.String("v1.12.0-eksbuild.1"),
+									Compatibilities: []*eks.Compatibility{{ClusterVersion: aws.String("1.15")}},
+								},
+								{
+									AddonVersion:    aws.String("v1.11.0-eksbuild.1"),
+									Compatibilities: []*eks.Compatibility{{ClusterVersion: aws.String("1.15")}},
+								},
+							},
+						}},
+					}, nil)
+				m.WaitUntilClusterUpdating(
+					gomock.AssignableToTypeOf(&eks.DescribeClusterInput{}), gomock.Any(),
+				).Return(nil)
+				m.
+					UpdateClusterVersion(gomock.AssignableToTypeOf(&eks.UpdateClusterVersionInput{})).
+					Return(&eks.UpdateClusterVersionOutput{}, nil)
+			},
+			expectError:           false,
+			expectAddonsCondition: corev1.ConditionTrue,
+		},
+		{
+			name:   "needs upgrade and an addon is incompatible",
+			addons: addons,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeCluster(gomock.AssignableToTypeOf(&eks.DescribeClusterInput{})).
+					Return(&eks.DescribeClusterOutput{
+						Cluster: &eks.Cluster{
+							Name:    aws.String("default.cluster"),
+							Version: aws.String("1.14"),
+						},
+					}, nil)
+				m.
+					DescribeAddonVersions(gomock.AssignableToTypeOf(&eks.DescribeAddonVersionsInput{})).
+					Return(&eks.DescribeAddonVersionsOutput{}, nil)
+			},
+			expectError:           false,
+			expectAddonsCondition: corev1.ConditionFalse,
+		},
+		{
+			name:   "needs upgrade and the pinned addon version is incompatible",
+			addons: addons,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeCluster(gomock.AssignableToTypeOf(&eks.DescribeClusterInput{})).
+					Return(&eks.DescribeClusterOutput{
+						Cluster: &eks.Cluster{
+							Name:    aws.String("default.cluster"),
+							Version: aws.String("1.14"),
+						},
+					}, nil)
+				m.
+					DescribeAddonVersions(gomock.AssignableToTypeOf(&eks.DescribeAddonVersionsInput{})).
+					Return(&eks.DescribeAddonVersionsOutput{
+						Addons: []*eks.AddonInfo{{
+							AddonName: aws.String("vpc-cni"),
+							AddonVersions: []*eks.AddonVersionInfo{{
+								AddonVersion:    aws.String("v1.12.0-eksbuild.1"),
+								Compatibilities: []*eks.Compatibility{{ClusterVersion: aws.String("1.15")}},
+							}},
+						}},
+					}, nil)
+			},
+			expectError:           false,
+			expectAddonsCondition: corev1.ConditionFalse,
+		},
+		{
+			name:   "needs upgrade and an addon without a version has a compatible version",
+			addons: []ekscontrolplanev1.Addon{{Name: "vpc-cni"}},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeCluster(gomock.AssignableToTypeOf(&eks.DescribeClusterInput{})).
+					Return(&eks.DescribeClusterOutput{
+						Cluster: &eks.Cluster{
+							Name:    aws.String("default.cluster"),
+							Version: aws.String("1.14"),
+						},
+					}, nil)
+				m.
+					DescribeAddonVersions(gomock.AssignableToTypeOf(&eks.DescribeAddonVersionsInput{})).
+					Return(&eks.DescribeAddonVersionsOutput{
+						Addons: []*eks.AddonInfo{{
+							AddonName: aws.String("vpc-cni"),
+							AddonVersions: []*eks.AddonVersionInfo{{
+								AddonVersion:    aws.String("v1.12.0-eksbuild.1"),
+								Compatibilities: []*eks.Compatibility{{ClusterVersion: aws.String("1.15")}},
+							}},
+						}},
+					}, nil)
+				m.WaitUntilClusterUpdating(
+					gomock.AssignableToTypeOf(&eks.DescribeClusterInput{}), gomock.Any(),
+				).Return(nil)
+				m.
+					UpdateClusterVersion(gomock.AssignableToTypeOf(&eks.UpdateClusterVersionInput{})).
+					Return(&eks.UpdateClusterVersionOutput{}, nil)
+			},
+			expectError:           false,
+			expectAddonsCondition: corev1.ConditionTrue,
+		},
+		{
+			name:           "needs upgrade and the addon check is skipped",
+			addons:         addons,
+			skipAddonCheck: true,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeCluster(gomock.AssignableToTypeOf(&eks.DescribeClusterInput{})).
+					Return(&eks.DescribeClusterOutput{
+						Cluster: &eks.Cluster{
+							Name:    aws.String("default.cluster"),
+							Version: aws.String("1.14"),
+						},
+					}, nil)
+				m.WaitUntilClusterUpdating(
+					gomock.AssignableToTypeOf(&eks.DescribeClusterInput{}), gomock.Any(),
+				).Return(nil)
+				m.
+					UpdateClusterVersion(gomock.AssignableToTypeOf(&eks.UpdateClusterVersionInput{})).
+					Return(&eks.UpdateClusterVersionOutput{}, nil)
+			},
+			expectError: false,
+		},
+		{
+			name:   "failure checking addons compatibility",
+			addons: addons,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeCluster(gomock.AssignableToTypeOf(&eks.DescribeClusterInput{})).
+					Return(&eks.DescribeClusterOutput{
+						Cluster: &eks.Cluster{
+							Name:    aws.String("default.cluster"),
+							Version: aws.String("1.14"),
+						},
+					}, nil)
+				m.
+					DescribeAddonVersions(gomock.AssignableToTypeOf(&eks.DescribeAddonVersionsInput{})).
+					Return(nil, errors.New(""))
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -438,7 +637,9 @@ func TestReconcileClusterVersion(t *testing.T) {
 				},
 				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
 					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
-						Version: aws.String("1.16"),
+						Version:                     aws.String("1.16"),
+						Addons:                      &tc.addons,
+						SkipAddonCompatibilityCheck: tc.skipAddonCheck,
 					},
 				},
 			})
@@ -457,6 +658,14 @@ func TestReconcileClusterVersion(t *testing.T) {
 				return
 			}
 			g.Expect(err).To(BeNil())
+
+			condition := conditions.Get(scope.ControlPlane, ekscontrolplanev1.EKSAddonsCompatibleCondition)
+			if tc.expectAddonsCondition == "" {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectAddonsCondition))
 		})
 	}
 }
@@ -566,6 +775,7 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 		newEncryptionConfig *ekscontrolplanev1.EncryptionConfig
 		expect              func(m *mock_eksiface.MockEKSAPIMockRecorder)
 		expectError         bool
+		expectCondition     *clusterv1.Condition
 	}{
 		{
 			name:                "no upgrade necessary - encryption disabled",
@@ -586,6 +796,9 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 			},
 			expect:      func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
 			expectError: false,
+			expectCondition: &clusterv1.Condition{
+				Status: corev1.ConditionTrue,
+			},
 		},
 		{
 			name:                "needs upgrade",
@@ -601,6 +814,10 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 				m.AssociateEncryptionConfig(gomock.AssignableToTypeOf(&eks.AssociateEncryptionConfigInput{})).Return(&eks.AssociateEncryptionConfigOutput{}, nil)
 			},
 			expectError: false,
+			expectCondition: &clusterv1.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: ekscontrolplanev1.EKSEncryptionConfigAssociatingReason,
+			},
 		},
 		{
 			name: "upgrade not allowed if encryption config updated as nil",
@@ -611,6 +828,10 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 			newEncryptionConfig: nil,
 			expect:              func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
 			expectError:         true,
+			expectCondition: &clusterv1.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: ekscontrolplanev1.EKSEncryptionConfigAssociationFailedReason,
+			},
 		},
 		{
 			name: "upgrade not allowed if encryption config exists",
@@ -624,6 +845,10 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 			},
 			expect:      func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
 			expectError: true,
+			expectCondition: &clusterv1.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: ekscontrolplanev1.EKSEncryptionConfigAssociationFailedReason,
+			},
 		},
 	}
 
@@ -664,9 +889,196 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 			err = s.reconcileEKSEncryptionConfig(makeEksEncryptionConfigs(tc.oldEncryptionConfig))
 			if tc.expectError {
 				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+
+			condition := conditions.Get(scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition)
+			if tc.expectCondition == nil {
+				g.Expect(condition).To(BeNil())
 				return
 			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectCondition.Status))
+			g.Expect(condition.Reason).To(Equal(tc.expectCondition.Reason))
+		})
+	}
+}
+
+func TestReconcileClusterConfig(t *testing.T) {
+	clusterName := "default.cluster"
+	subnets := infrav1.Subnets{
+		{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+		{ID: "subnet-2", AvailabilityZone: "us-east-1b"},
+	}
+	tests := []struct {
+		name            string
+		endpointAccess  ekscontrolplanev1.EndpointAccess
+		vpcConfig       *eks.VpcConfigResponse
+		logging         *ekscontrolplanev1.ControlPlaneLoggingSpec
+		expect          func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectCondition *clusterv1.Condition
+	}{
+		{
+			name: "no update necessary",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				PublicCIDRs: []*string{aws.String("10.0.0.0/24")},
+			},
+			vpcConfig: &eks.VpcConfigResponse{
+				EndpointPublicAccess: aws.Bool(true),
+				PublicAccessCidrs:    aws.StringSlice([]string{"10.0.0.0/24"}),
+			},
+			expect:          func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectCondition: &clusterv1.Condition{Status: corev1.ConditionTrue},
+		},
+		{
+			name: "public access cidrs changed",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				PublicCIDRs: []*string{aws.String("10.0.0.1/24"), aws.String("10.0.1.0/24")},
+			},
+			vpcConfig: &eks.VpcConfigResponse{
+				EndpointPublicAccess: aws.Bool(true),
+				PublicAccessCidrs:    aws.StringSlice([]string{"10.0.0.0/24"}),
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(gomock.Eq(&eks.UpdateClusterConfigInput{
+					Name: aws.String(clusterName),
+					ResourcesVpcConfig: &eks.VpcConfigRequest{
+						PublicAccessCidrs: aws.StringSlice([]string{"10.0.0.0/24", "10.0.1.0/24"}),
+					},
+				})).Return(&eks.UpdateClusterConfigOutput{}, nil)
+			},
+			expectCondition: &clusterv1.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: ekscontrolplanev1.EKSEndpointAccessUpdatingReason,
+			},
+		},
+		{
+			name:           "public access cidrs removed",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{},
+			vpcConfig: &eks.VpcConfigResponse{
+				EndpointPublicAccess: aws.Bool(true),
+				PublicAccessCidrs:    aws.StringSlice([]string{"10.0.0.0/24"}),
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(gomock.Eq(&eks.UpdateClusterConfigInput{
+					Name: aws.String(clusterName),
+					ResourcesVpcConfig: &eks.VpcConfigRequest{
+						PublicAccessCidrs: aws.StringSlice([]string{"0.0.0.0/0"}),
+					},
+				})).Return(&eks.UpdateClusterConfigOutput{}, nil)
+			},
+			expectCondition: &clusterv1.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: ekscontrolplanev1.EKSEndpointAccessUpdatingReason,
+			},
+		},
+		{
+			name: "public access cidrs ignored while public access is disabled",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				Public:      aws.Bool(false),
+				Private:     aws.Bool(true),
+				PublicCIDRs: []*string{aws.String("10.0.1.0/24")},
+			},
+			vpcConfig: &eks.VpcConfigResponse{
+				EndpointPublicAccess:  aws.Bool(false),
+				EndpointPrivateAccess: aws.Bool(true),
+				PublicAccessCidrs:     aws.StringSlice([]string{"0.0.0.0/0"}),
+			},
+			expect:          func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectCondition: &clusterv1.Condition{Status: corev1.ConditionTrue},
+		},
+		{
+			name: "logging updated before public access cidrs",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				PublicCIDRs: []*string{aws.String("10.0.1.0/24")},
+			},
+			vpcConfig: &eks.VpcConfigResponse{
+				EndpointPublicAccess: aws.Bool(true),
+				PublicAccessCidrs:    aws.StringSlice([]string{"10.0.0.0/24"}),
+			},
+			logging: &ekscontrolplanev1.ControlPlaneLoggingSpec{APIServer: true},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(gomock.AssignableToTypeOf(&eks.UpdateClusterConfigInput{})).DoAndReturn(func(input *eks.UpdateClusterConfigInput) (*eks.UpdateClusterConfigOutput, error) {
+					if input.Logging == nil || input.ResourcesVpcConfig != nil {
+						return nil, errors.New("only one type of update can be allowed")
+					}
+					return &eks.UpdateClusterConfigOutput{}, nil
+				})
+			},
+		},
+		{
+			name: "public access cidrs update rejected",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				PublicCIDRs: []*string{aws.String("10.0.1.0/24")},
+			},
+			vpcConfig: &eks.VpcConfigResponse{
+				EndpointPublicAccess: aws.Bool(true),
+				PublicAccessCidrs:    aws.StringSlice([]string{"10.0.0.0/24"}),
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(gomock.AssignableToTypeOf(&eks.UpdateClusterConfigInput{})).Return(nil, errors.New("invalid parameter"))
+			},
+			expectCondition: &clusterv1.Condition{
+				Status: corev1.ConditionFalse,
+				Reason: ekscontrolplanev1.EKSEndpointAccessUpdateFailedReason,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName: clusterName,
+						Version:        aws.String("1.16"),
+						EndpointAccess: tc.endpointAccess,
+						Logging:        tc.logging,
+						NetworkSpec:    infrav1.NetworkSpec{Subnets: subnets},
+					},
+				},
+			})
 			g.Expect(err).To(BeNil())
+
+			tc.expect(eksMock.EXPECT())
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			logging := &eks.Logging{ClusterLogging: []*eks.LogSetup{{Enabled: aws.Bool(false), Types: aws.StringSlice([]string{eks.LogTypeApi})}}}
+			err = s.reconcileClusterConfig(&eks.Cluster{Logging: logging, ResourcesVpcConfig: tc.vpcConfig})
+			if tc.expectCondition != nil && tc.expectCondition.Reason == ekscontrolplanev1.EKSEndpointAccessUpdateFailedReason {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+
+			condition := conditions.Get(scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessReconciledCondition)
+			if tc.expectCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectCondition.Status))
+			g.Expect(condition.Reason).To(Equal(tc.expectCondition.Reason))
 		})
 	}
 }
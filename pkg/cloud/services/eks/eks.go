@@ -36,14 +36,23 @@ func (s *Service) ReconcileControlPlane(ctx context.Context) error {
 
 	// Control Plane IAM Role
 	if err := s.reconcileControlPlaneIAMRole(); err != nil {
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.IAMClusterRoleReadyCondition, iamRoleReason(err), clusterv1.ConditionSeverityError, err.Error())
 		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.IAMControlPlaneRolesReadyCondition, ekscontrolplanev1.IAMControlPlaneRolesReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return err
 	}
+	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.IAMClusterRoleReadyCondition)
 	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.IAMControlPlaneRolesReadyCondition)
 
 	// EKS Cluster
 	if err := s.reconcileCluster(ctx); err != nil {
-		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition, ekscontrolplanev1.EKSControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		reason := ekscontrolplanev1.EKSControlPlaneReconciliationFailedReason
+		switch {
+		case errors.Is(err, ErrClusterSubnetsInvalid):
+			reason = ekscontrolplanev1.EKSControlPlaneSubnetsInvalidReason
+		case errors.Is(err, ErrClusterSecurityGroupsInvalid):
+			reason = ekscontrolplanev1.EKSControlPlaneSecurityGroupsInvalidReason
+		}
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition, reason, clusterv1.ConditionSeverityError, err.Error())
 		return err
 	}
 	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition)
@@ -60,7 +69,6 @@ func (s *Service) ReconcileControlPlane(ctx context.Context) error {
 		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition, ekscontrolplanev1.EKSIdentityProviderConfiguredFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return errors.Wrap(err, "failed reconciling eks identity provider")
 	}
-	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition)
 
 	s.scope.Debug("Reconcile EKS control plane completed successfully")
 	return nil
@@ -94,6 +102,13 @@ func (s *NodegroupService) ReconcilePool(ctx context.Context) error {
 	s.scope.Debug("Reconciling EKS nodegroup")
 
 	if err := s.reconcileNodegroupIAMRole(); err != nil {
+		conditions.MarkFalse(
+			s.scope.ManagedMachinePool,
+			expinfrav1.IAMNodeRoleReadyCondition,
+			iamRoleReason(err),
+			clusterv1.ConditionSeverityError,
+			err.Error(),
+		)
 		conditions.MarkFalse(
 			s.scope.ManagedMachinePool,
 			expinfrav1.IAMNodegroupRolesReadyCondition,
@@ -103,6 +118,7 @@ func (s *NodegroupService) ReconcilePool(ctx context.Context) error {
 		)
 		return err
 	}
+	conditions.MarkTrue(s.scope.ManagedMachinePool, expinfrav1.IAMNodeRoleReadyCondition)
 	conditions.MarkTrue(s.scope.ManagedMachinePool, expinfrav1.IAMNodegroupRolesReadyCondition)
 
 	if err := s.reconcileNodegroup(ctx); err != nil {
@@ -37,4 +37,8 @@ var (
 	ErrCannotUseAdditionalRoles = errors.New("additional rules cannot be added as this has been disabled")
 	// ErrNoSecurityGroup is an error when no security group is found for an EKS cluster.
 	ErrNoSecurityGroup = errors.New("no security group for EKS cluster")
+	// ErrClusterSubnetsInvalid is an error when the cluster subnets don't meet the EKS requirements.
+	ErrClusterSubnetsInvalid = errors.New("cluster subnets don't meet EKS requirements")
+	// ErrClusterSecurityGroupsInvalid is an error when the additional security groups of the cluster don't meet the EKS requirements.
+	ErrClusterSecurityGroupsInvalid = errors.New("cluster security groups don't meet EKS requirements")
 )
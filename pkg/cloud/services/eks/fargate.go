@@ -21,7 +21,6 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/pkg/errors"
@@ -29,6 +28,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
@@ -49,6 +49,13 @@ func (s *FargateService) Reconcile() (reconcile.Result, error) {
 
 	requeue, err := s.reconcileFargateIAMRole()
 	if err != nil {
+		conditions.MarkFalse(
+			s.scope.FargateProfile,
+			expinfrav1.FargateExecutionRoleReadyCondition,
+			iamRoleReason(err),
+			clusterv1.ConditionSeverityError,
+			err.Error(),
+		)
 		conditions.MarkFalse(
 			s.scope.FargateProfile,
 			expinfrav1.IAMFargateRolesReadyCondition,
@@ -64,10 +71,18 @@ func (s *FargateService) Reconcile() (reconcile.Result, error) {
 		return requeueRoleUpdating(), nil
 	}
 
+	conditions.MarkTrue(s.scope.FargateProfile, expinfrav1.FargateExecutionRoleReadyCondition)
 	conditions.MarkTrue(s.scope.FargateProfile, expinfrav1.IAMFargateRolesReadyCondition)
 
 	requeue, err = s.reconcileFargateProfile()
 	if err != nil {
+		conditions.MarkFalse(
+			s.scope.FargateProfile,
+			expinfrav1.EKSFargateProfileReadyCondition,
+			fargateProfileReason(err),
+			clusterv1.ConditionSeverityError,
+			err.Error(),
+		)
 		conditions.MarkFalse(
 			s.scope.FargateProfile,
 			clusterv1.ReadyCondition,
@@ -134,7 +149,7 @@ func (s *FargateService) handleStatus(profile *eks.FargateProfile) (requeue bool
 		s.scope.FargateProfile.Status.FailureMessage = aws.String(fmt.Sprintf("unexpected profile status: %s", *profile.Status))
 		reason := capierrors.MachineStatusError(expinfrav1.EKSFargateFailedReason)
 		s.scope.FargateProfile.Status.FailureReason = &reason
-		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition, expinfrav1.EKSFargateFailedReason, clusterv1.ConditionSeverityError, "")
+		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition, expinfrav1.EKSFargateFailedReason, clusterv1.ConditionSeverityError, "fargate profile status is %s", *profile.Status)
 	case eks.FargateProfileStatusActive:
 		s.scope.FargateProfile.Status.Ready = true
 		if conditions.IsTrue(s.scope.FargateProfile, expinfrav1.EKSFargateCreatingCondition) {
@@ -180,6 +195,13 @@ func (s *FargateService) ReconcileDelete() (reconcile.Result, error) {
 
 	err = s.deleteFargateIAMRole()
 	if err != nil {
+		conditions.MarkFalse(
+			s.scope.FargateProfile,
+			expinfrav1.FargateExecutionRoleReadyCondition,
+			iamRoleReason(err),
+			clusterv1.ConditionSeverityError,
+			err.Error(),
+		)
 		conditions.MarkFalse(
 			s.scope.FargateProfile,
 			expinfrav1.IAMFargateRolesReadyCondition,
@@ -201,7 +223,7 @@ func (s *FargateService) describeFargateProfile() (*eks.FargateProfile, error) {
 
 	out, err := s.EKSClient.DescribeFargateProfile(input)
 	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eks.ErrCodeResourceNotFoundException {
+		if awserrors.IsNotFound(err) {
 			return nil, nil
 		}
 		return nil, errors.Wrap(err, "failed to describe fargate profile")
@@ -302,6 +324,9 @@ func (s *FargateService) deleteFargateProfile() (requeue bool, err error) {
 }
 
 func (s *FargateService) roleArn() (*string, error) {
+	if roleARN := s.scope.PodExecutionRoleARN(); roleARN != "" {
+		return aws.String(roleARN), nil
+	}
 	var role *iam.Role
 	if s.scope.RoleName() != "" {
 		var err error
@@ -312,3 +337,21 @@ func (s *FargateService) roleArn() (*string, error) {
 	}
 	return role.Arn, nil
 }
+
+// fargateProfileReason returns the reason of the condition of a fargate profile that failed to
+// reconcile, derived from the error returned by the EKS API.
+func fargateProfileReason(err error) string {
+	if awserrors.IsPermissionDenied(err) {
+		return expinfrav1.EKSFargateAccessDeniedReason
+	}
+	code, _ := awserrors.Code(err)
+	switch code {
+	case eks.ErrCodeInvalidParameterException, eks.ErrCodeInvalidRequestException:
+		return expinfrav1.EKSFargateInvalidParameterReason
+	case eks.ErrCodeResourceLimitExceededException:
+		return expinfrav1.EKSFargateLimitExceededReason
+	case eks.ErrCodeUnsupportedAvailabilityZoneException:
+		return expinfrav1.EKSFargateUnsupportedAvailabilityZoneReason
+	}
+	return expinfrav1.EKSFargateReconciliationFailedReason
+}
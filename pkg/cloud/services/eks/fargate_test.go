@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+func TestFargateProfileReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+	}{
+		{
+			name:   "invalid pod execution role",
+			err:    errors.Wrap(awserr.New(eks.ErrCodeInvalidParameterException, "Misconfigured PodExecutionRole Trust Policy", nil), "failed to create fargate profile"),
+			reason: expinfrav1.EKSFargateInvalidParameterReason,
+		},
+		{
+			name:   "profile quota reached",
+			err:    awserr.New(eks.ErrCodeResourceLimitExceededException, "cannot exceed quota for FargateProfilesPerCluster", nil),
+			reason: expinfrav1.EKSFargateLimitExceededReason,
+		},
+		{
+			name:   "subnet in an unsupported availability zone",
+			err:    awserr.New(eks.ErrCodeUnsupportedAvailabilityZoneException, "fargate is not supported in use1-az3", nil),
+			reason: expinfrav1.EKSFargateUnsupportedAvailabilityZoneReason,
+		},
+		{
+			name:   "access denied by the EKS API",
+			err:    errors.Wrap(awserr.New("AccessDeniedException", "not authorized to perform eks:CreateFargateProfile", nil), "failed to create fargate profile"),
+			reason: expinfrav1.EKSFargateAccessDeniedReason,
+		},
+		{
+			name:   "error without EKS error code",
+			err:    errors.New("owned tag not found for this cluster"),
+			reason: expinfrav1.EKSFargateReconciliationFailedReason,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(fargateProfileReason(tc.err)).To(Equal(tc.reason))
+		})
+	}
+}
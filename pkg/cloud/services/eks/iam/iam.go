@@ -47,6 +47,13 @@ type IAMService struct {
 	logger.Wrapper
 	IAMClient iamiface.IAMAPI
 	Client    *http.Client
+
+	// PermissionsBoundary is the ARN of the managed policy set as the permissions boundary of the created
+	// roles, see the --iam-permissions-boundary flag. Empty sets no permissions boundary.
+	PermissionsBoundary string
+
+	// Path is the IAM path of the created roles, see the --iam-path flag. Empty uses the "/" path.
+	Path string
 }
 
 // GetIAMRole will return the IAM role for the IAMService.
@@ -196,6 +203,12 @@ func (s *IAMService) CreateRole(
 		Tags:                     tags,
 		AssumeRolePolicyDocument: aws.String(trustRelationshipJSON),
 	}
+	if s.PermissionsBoundary != "" {
+		input.PermissionsBoundary = aws.String(s.PermissionsBoundary)
+	}
+	if s.Path != "" {
+		input.Path = aws.String(s.Path)
+	}
 
 	out, err := s.IAMClient.CreateRole(input)
 	if err != nil {
@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+)
+
+func TestCreateRole(t *testing.T) {
+	tests := []struct {
+		name                string
+		permissionsBoundary string
+		path                string
+		expect              func(m *mock_iamauth.MockIAMAPIMockRecorder)
+	}{
+		{
+			name: "creates the role without a permissions boundary or path by default",
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.CreateRole(gomock.Any()).DoAndReturn(func(input *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
+					if input.PermissionsBoundary != nil || input.Path != nil {
+						t.Errorf("expected no permissions boundary or path, got %v", input)
+					}
+					return &iam.CreateRoleOutput{Role: &iam.Role{RoleName: input.RoleName}}, nil
+				})
+			},
+		},
+		{
+			name:                "creates the role with the permissions boundary and path of the service",
+			permissionsBoundary: "arn:aws:iam::123456789012:policy/boundary",
+			path:                "/capa/",
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.CreateRole(gomock.Any()).DoAndReturn(func(input *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
+					if aws.StringValue(input.PermissionsBoundary) != "arn:aws:iam::123456789012:policy/boundary" || aws.StringValue(input.Path) != "/capa/" {
+						t.Errorf("expected the permissions boundary and path of the service, got %v", input)
+					}
+					return &iam.CreateRoleOutput{Role: &iam.Role{RoleName: input.RoleName}}, nil
+				})
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			iamMock := mock_iamauth.NewMockIAMAPI(mockCtrl)
+			tc.expect(iamMock.EXPECT())
+
+			s := &IAMService{
+				Wrapper:             logger.NewLogger(logr.Discard()),
+				IAMClient:           iamMock,
+				PermissionsBoundary: tc.permissionsBoundary,
+				Path:                tc.path,
+			}
+			role, err := s.CreateRole("test-role", "test-cluster", NodegroupTrustRelationship(), infrav1.Tags{})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(aws.StringValue(role.RoleName)).To(Equal("test-role"))
+		})
+	}
+}
@@ -27,12 +27,16 @@ import (
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/identityprovider"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func (s *Service) reconcileIdentityProvider(ctx context.Context) error {
 	s.scope.Info("reconciling oidc identity provider")
-	if s.scope.OIDCIdentityProviderConfig() == nil {
+	// an identity provider config removed from the spec is still reported in the status until it is disassociated
+	if s.scope.OIDCIdentityProviderConfig() == nil && s.scope.ControlPlane.Status.IdentityProviderStatus.ARN == "" {
 		s.scope.Info("no oidc provider config, skipping reconcile")
+		conditions.Delete(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition)
 		return nil
 	}
 
@@ -46,7 +50,7 @@ func (s *Service) reconcileIdentityProvider(ctx context.Context) error {
 
 	if desired == nil && current == nil {
 		s.scope.Info("no identity provider required or installed, no action needed")
-		return nil
+		return s.updateIdentityProviderStatus(nil)
 	}
 
 	s.scope.Debug("creating oidc provider plan", "desired", desired, "current", current)
@@ -60,7 +64,7 @@ func (s *Service) reconcileIdentityProvider(ctx context.Context) error {
 
 	// nothing will be done, we can leave
 	if len(procedures) == 0 {
-		return nil
+		return s.updateIdentityProviderStatus(current)
 	}
 
 	s.scope.Debug("computed EKS identity provider plan", "numprocs", len(procedures))
@@ -79,21 +83,45 @@ func (s *Service) reconcileIdentityProvider(ctx context.Context) error {
 		return errors.Wrap(err, "getting associated identity provider")
 	}
 
-	if latest == nil {
-		return nil
+	return s.updateIdentityProviderStatus(latest)
+}
+
+// updateIdentityProviderStatus reports the identity provider config associated with the cluster in the status
+// and in the EKSIdentityProviderConfigured condition.
+func (s *Service) updateIdentityProviderStatus(latest *identityprovider.OidcIdentityProviderConfig) error {
+	status := ekscontrolplanev1.IdentityProviderStatus{}
+	switch {
+	case latest == nil && s.scope.OIDCIdentityProviderConfig() == nil:
+		conditions.Delete(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition)
+	case latest == nil:
+		// the previous config has been disassociated, the desired one is associated on the next reconciliation
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition, ekscontrolplanev1.EKSIdentityProviderAssociatingReason, clusterv1.ConditionSeverityInfo, "")
+	default:
+		status = ekscontrolplanev1.IdentityProviderStatus{
+			ARN:    latest.IdentityProviderConfigArn,
+			Status: latest.Status,
+		}
+		switch latest.Status {
+		case eks.ConfigStatusActive:
+			if s.scope.OIDCIdentityProviderConfig() != nil {
+				conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition)
+			}
+		case eks.ConfigStatusCreating:
+			conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition, ekscontrolplanev1.EKSIdentityProviderAssociatingReason, clusterv1.ConditionSeverityInfo,
+				"EKS is associating identity provider config %q", latest.IdentityProviderConfigName)
+		case eks.ConfigStatusDeleting:
+			conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition, ekscontrolplanev1.EKSIdentityProviderDisassociatingReason, clusterv1.ConditionSeverityInfo,
+				"EKS is disassociating identity provider config %q", latest.IdentityProviderConfigName)
+		}
 	}
 
 	// don't patch if arn/status is the same
-	if latest.IdentityProviderConfigArn == s.scope.ControlPlane.Status.IdentityProviderStatus.ARN &&
-		latest.Status == s.scope.ControlPlane.Status.IdentityProviderStatus.Status {
+	if status == s.scope.ControlPlane.Status.IdentityProviderStatus {
 		return nil
 	}
 
 	// idp status has changed, patch the control plane
-	s.scope.ControlPlane.Status.IdentityProviderStatus = ekscontrolplanev1.IdentityProviderStatus{
-		ARN:    latest.IdentityProviderConfigArn,
-		Status: latest.Status,
-	}
+	s.scope.ControlPlane.Status.IdentityProviderStatus = status
 
 	if err := s.scope.PatchObject(); err != nil {
 		return errors.Wrap(err, "updating identity provider status")
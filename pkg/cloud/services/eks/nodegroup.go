@@ -19,9 +19,12 @@ package eks
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/iam"
@@ -49,18 +52,12 @@ func (s *NodegroupService) describeNodegroup() (*eks.Nodegroup, error) {
 		NodegroupName: aws.String(nodegroupName),
 	}
 
-	out, err := s.EKSClient.DescribeNodegroup(input)
+	out, err := s.EKSClient.DescribeNodegroupWithContext(aws.BackgroundContext(), input, readNodeRepairConfig(&s.nodeRepairConfig))
 	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			case eks.ErrCodeResourceNotFoundException:
-				return nil, nil
-			default:
-				return nil, errors.Wrap(err, "failed to describe nodegroup")
-			}
-		} else {
-			return nil, errors.Wrap(err, "failed to describe nodegroup")
+		if awserrors.IsNotFound(err) {
+			return nil, nil
 		}
+		return nil, errors.Wrap(err, "failed to describe nodegroup")
 	}
 
 	return out.Nodegroup, nil
@@ -189,13 +186,15 @@ func (s *NodegroupService) remoteAccess() (*eks.RemoteAccessConfig, error) {
 func (s *NodegroupService) createNodegroup() (*eks.Nodegroup, error) {
 	eksClusterName := s.scope.KubernetesClusterName()
 	nodegroupName := s.scope.NodegroupName()
-	additionalTags := s.scope.AdditionalTags()
 	roleArn, err := s.roleArn()
 	if err != nil {
 		return nil, err
 	}
 	managedPool := s.scope.ManagedMachinePool.Spec
-	tags := ngTags(s.scope.ClusterName(), additionalTags)
+	tags, err := s.nodegroupTags()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build nodegroup tags")
+	}
 
 	remoteAccess, err := s.remoteAccess()
 	if err != nil {
@@ -253,19 +252,13 @@ func (s *NodegroupService) createNodegroup() (*eks.Nodegroup, error) {
 		return nil, errors.Wrap(err, "created invalid CreateNodegroupInput")
 	}
 
-	out, err := s.EKSClient.CreateNodegroup(input)
+	opts := []request.Option{readNodeRepairConfig(&s.nodeRepairConfig)}
+	if cfg := nodeRepairConfigToAPI(managedPool.NodeRepairConfig); cfg != nil {
+		opts = append(opts, withNodeRepairConfig(cfg))
+	}
+	out, err := s.EKSClient.CreateNodegroupWithContext(aws.BackgroundContext(), input, opts...)
 	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			// TODO
-			case eks.ErrCodeResourceNotFoundException:
-				return nil, nil
-			default:
-				return nil, errors.Wrap(err, "failed to create nodegroup")
-			}
-		} else {
-			return nil, errors.Wrap(err, "failed to create nodegroup")
-		}
+		return nil, errors.Wrap(err, "failed to create nodegroup")
 	}
 
 	return out.Nodegroup, nil
@@ -299,17 +292,11 @@ func (s *NodegroupService) deleteNodegroupAndWait() (reterr error) {
 
 	_, err := s.EKSClient.DeleteNodegroup(input)
 	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			// TODO
-			case eks.ErrCodeResourceNotFoundException:
-				return nil
-			default:
-				return errors.Wrap(err, "failed to delete nodegroup")
-			}
-		} else {
-			return errors.Wrap(err, "failed to delete nodegroup")
+		// TODO
+		if awserrors.IsNotFound(err) {
+			return nil
 		}
+		return errors.Wrap(err, "failed to delete nodegroup")
 	}
 
 	waitInput := &eks.DescribeNodegroupInput{
@@ -391,11 +378,16 @@ func createLabelUpdate(specLabels map[string]string, ng *eks.Nodegroup) *eks.Upd
 			payload.AddOrUpdateLabels[k] = aws.String(v)
 		}
 	}
+	removed := []string{}
 	for k := range current {
 		if _, ok := specLabels[k]; !ok {
-			payload.RemoveLabels = append(payload.RemoveLabels, aws.String(k))
+			removed = append(removed, k)
 		}
 	}
+	if len(removed) > 0 {
+		sort.Strings(removed)
+		payload.RemoveLabels = aws.StringSlice(removed)
+	}
 	if len(payload.AddOrUpdateLabels) > 0 || len(payload.RemoveLabels) > 0 {
 		return &payload
 	}
@@ -421,7 +413,8 @@ func (s *NodegroupService) createTaintsUpdate(specTaints expinfrav1.Taints, ng *
 	}
 	for _, currentTaint := range current {
 		ct := currentTaint.DeepCopy()
-		if !specTaints.Contains(ct) {
+		// A taint whose value changed is updated in place, it must not be removed as well.
+		if !specTaints.ContainsKeyAndEffect(ct) {
 			sdkTaint, err := converters.TaintToSDK(*ct)
 			if err != nil {
 				return nil, fmt.Errorf("converting taint to sdk: %w", err)
@@ -442,6 +435,15 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 	eksClusterName := s.scope.KubernetesClusterName()
 	s.Debug("reconciling node group config", "cluster", eksClusterName, "name", *ng.NodegroupName)
 
+	inProgress, err := s.reconcileNodegroupConfigUpdateStatus()
+	if err != nil {
+		return err
+	}
+	if inProgress {
+		s.Debug("nodegroup config update in progress, not starting a new one", "cluster", eksClusterName, "name", *ng.NodegroupName)
+		return nil
+	}
+
 	managedPool := s.scope.ManagedMachinePool.Spec
 	input := &eks.UpdateNodegroupConfigInput{
 		ClusterName:   aws.String(eksClusterName),
@@ -485,6 +487,12 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 		input.UpdateConfig = s.updateConfig()
 		needsUpdate = true
 	}
+	var opts []request.Option
+	if nodeRepairConfigNeedsUpdate(managedPool.NodeRepairConfig, s.nodeRepairConfig) {
+		s.Debug("Nodegroup node repair configuration differs from spec, updating the nodegroup node repair config", "nodegroup", ng.NodegroupName)
+		opts = append(opts, withNodeRepairConfig(nodeRepairConfigToAPI(managedPool.NodeRepairConfig)))
+		needsUpdate = true
+	}
 	if !needsUpdate {
 		s.Debug("node group config update not needed", "cluster", eksClusterName, "name", *ng.NodegroupName)
 		return nil
@@ -493,14 +501,65 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 		return errors.Wrap(err, "created invalid UpdateNodegroupConfigInput")
 	}
 
-	_, err = s.EKSClient.UpdateNodegroupConfig(input)
+	out, err := s.EKSClient.UpdateNodegroupConfigWithContext(aws.BackgroundContext(), input, opts...)
 	if err != nil {
+		record.Warnf(s.scope.ManagedMachinePool, "FailedUpdateEKSNodegroupConfig", "Failed to update the config of EKS nodegroup %s: %v", *ng.NodegroupName, err)
 		return errors.Wrap(err, "failed to update nodegroup config")
 	}
+	s.scope.ManagedMachinePool.Status.ConfigUpdate = nodegroupUpdateStatus(out.Update)
+	record.Eventf(s.scope.ManagedMachinePool, "SuccessfulUpdateEKSNodegroupConfig", "Started update %s of the config of EKS nodegroup %s", aws.StringValue(out.Update.Id), *ng.NodegroupName)
 
 	return nil
 }
 
+// reconcileNodegroupConfigUpdateStatus refreshes the status of the last config update of the nodegroup while
+// it is in progress, and returns whether it is still in progress.
+func (s *NodegroupService) reconcileNodegroupConfigUpdateStatus() (bool, error) {
+	configUpdate := s.scope.ManagedMachinePool.Status.ConfigUpdate
+	if configUpdate == nil || configUpdate.Status != expinfrav1.NodegroupUpdateStatusInProgress {
+		return false, nil
+	}
+
+	out, err := s.EKSClient.DescribeUpdate(&eks.DescribeUpdateInput{
+		Name:          aws.String(s.scope.KubernetesClusterName()),
+		NodegroupName: aws.String(s.scope.NodegroupName()),
+		UpdateId:      aws.String(configUpdate.ID),
+	})
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			s.scope.ManagedMachinePool.Status.ConfigUpdate = nil
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to describe update %s of nodegroup", configUpdate.ID)
+	}
+
+	configUpdate = nodegroupUpdateStatus(out.Update)
+	s.scope.ManagedMachinePool.Status.ConfigUpdate = configUpdate
+	switch configUpdate.Status {
+	case expinfrav1.NodegroupUpdateStatusFailed, expinfrav1.NodegroupUpdateStatusCancelled:
+		record.Warnf(s.scope.ManagedMachinePool, "FailedUpdateEKSNodegroupConfig", "Update %s of the config of EKS nodegroup %s is %s: %s",
+			configUpdate.ID, s.scope.NodegroupName(), configUpdate.Status, strings.Join(configUpdate.Errors, "; "))
+	case expinfrav1.NodegroupUpdateStatusSuccessful:
+		record.Eventf(s.scope.ManagedMachinePool, "SuccessfulUpdateEKSNodegroupConfig", "Completed update %s of the config of EKS nodegroup %s", configUpdate.ID, s.scope.NodegroupName())
+	}
+
+	return configUpdate.Status == expinfrav1.NodegroupUpdateStatusInProgress, nil
+}
+
+func nodegroupUpdateStatus(update *eks.Update) *expinfrav1.NodegroupUpdateStatus {
+	if update == nil {
+		return nil
+	}
+	status := &expinfrav1.NodegroupUpdateStatus{
+		ID:     aws.StringValue(update.Id),
+		Status: expinfrav1.NodegroupUpdateStatusType(aws.StringValue(update.Status)),
+	}
+	for _, updateErr := range update.Errors {
+		status.Errors = append(status.Errors, fmt.Sprintf("%s: %s", aws.StringValue(updateErr.ErrorCode), aws.StringValue(updateErr.ErrorMessage)))
+	}
+	return status
+}
+
 func (s *NodegroupService) reconcileNodegroup(ctx context.Context) error {
 	ng, err := s.describeNodegroup()
 	if err != nil {
@@ -567,6 +626,10 @@ func (s *NodegroupService) reconcileNodegroup(ctx context.Context) error {
 		return errors.Wrapf(err, "failed to reconcile asg tags")
 	}
 
+	if err := s.reconcileCapacity(ctx); err != nil {
+		return errors.Wrap(err, "failed to reconcile capacity")
+	}
+
 	return nil
 }
 
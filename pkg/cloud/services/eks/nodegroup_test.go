@@ -0,0 +1,256 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestCreateLabelUpdate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    map[string]string
+		current map[string]*string
+		expect  *eks.UpdateLabelsPayload
+	}{
+		{
+			name:    "no changes",
+			spec:    map[string]string{"team": "a"},
+			current: map[string]*string{"team": aws.String("a")},
+		},
+		{
+			name:    "added, updated and removed labels",
+			spec:    map[string]string{"team": "b", "env": "prod"},
+			current: map[string]*string{"team": aws.String("a"), "zone": aws.String("z"), "app": aws.String("x")},
+			expect: &eks.UpdateLabelsPayload{
+				AddOrUpdateLabels: map[string]*string{"team": aws.String("b"), "env": aws.String("prod")},
+				RemoveLabels:      aws.StringSlice([]string{"app", "zone"}),
+			},
+		},
+		{
+			name:    "all labels removed",
+			current: map[string]*string{"team": aws.String("a")},
+			expect: &eks.UpdateLabelsPayload{
+				AddOrUpdateLabels: map[string]*string{},
+				RemoveLabels:      aws.StringSlice([]string{"team"}),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(createLabelUpdate(tc.spec, &eks.Nodegroup{Labels: tc.current})).To(Equal(tc.expect))
+		})
+	}
+}
+
+func TestCreateTaintsUpdate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    expinfrav1.Taints
+		current []*eks.Taint
+		expect  *eks.UpdateTaintsPayload
+	}{
+		{
+			name: "no changes",
+			spec: expinfrav1.Taints{{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoSchedule}},
+			current: []*eks.Taint{
+				{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoSchedule)},
+			},
+		},
+		{
+			name: "changed value is only updated",
+			spec: expinfrav1.Taints{{Key: "dedicated", Value: "cpu", Effect: expinfrav1.TaintEffectNoSchedule}},
+			current: []*eks.Taint{
+				{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoSchedule)},
+			},
+			expect: &eks.UpdateTaintsPayload{
+				AddOrUpdateTaints: []*eks.Taint{
+					{Key: aws.String("dedicated"), Value: aws.String("cpu"), Effect: aws.String(eks.TaintEffectNoSchedule)},
+				},
+			},
+		},
+		{
+			name: "changed effect replaces the taint",
+			spec: expinfrav1.Taints{{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoExecute}},
+			current: []*eks.Taint{
+				{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoSchedule)},
+			},
+			expect: &eks.UpdateTaintsPayload{
+				AddOrUpdateTaints: []*eks.Taint{
+					{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoExecute)},
+				},
+				RemoveTaints: []*eks.Taint{
+					{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoSchedule)},
+				},
+			},
+		},
+		{
+			name: "removed taint",
+			current: []*eks.Taint{
+				{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoSchedule)},
+			},
+			expect: &eks.UpdateTaintsPayload{
+				RemoveTaints: []*eks.Taint{
+					{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoSchedule)},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &NodegroupService{
+				IAMService: iam.IAMService{Wrapper: logger.NewLogger(logr.Discard())},
+			}
+			payload, err := s.createTaintsUpdate(tc.spec, &eks.Nodegroup{NodegroupName: aws.String("ng"), Taints: tc.current})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(payload).To(Equal(tc.expect))
+		})
+	}
+}
+
+func TestReconcileNodegroupConfigUpdateStatus(t *testing.T) {
+	testCases := []struct {
+		name             string
+		configUpdate     *expinfrav1.NodegroupUpdateStatus
+		expect           func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectInProgress bool
+		expectStatus     *expinfrav1.NodegroupUpdateStatus
+	}{
+		{
+			name:   "no update",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+		},
+		{
+			name:         "completed update is not described again",
+			configUpdate: &expinfrav1.NodegroupUpdateStatus{ID: "update-1", Status: expinfrav1.NodegroupUpdateStatusSuccessful},
+			expect:       func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectStatus: &expinfrav1.NodegroupUpdateStatus{ID: "update-1", Status: expinfrav1.NodegroupUpdateStatusSuccessful},
+		},
+		{
+			name:         "update still in progress",
+			configUpdate: &expinfrav1.NodegroupUpdateStatus{ID: "update-1", Status: expinfrav1.NodegroupUpdateStatusInProgress},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeUpdate(&eks.DescribeUpdateInput{
+					Name:          aws.String("cluster"),
+					NodegroupName: aws.String("ng"),
+					UpdateId:      aws.String("update-1"),
+				}).Return(&eks.DescribeUpdateOutput{
+					Update: &eks.Update{Id: aws.String("update-1"), Status: aws.String(eks.UpdateStatusInProgress)},
+				}, nil)
+			},
+			expectInProgress: true,
+			expectStatus:     &expinfrav1.NodegroupUpdateStatus{ID: "update-1", Status: expinfrav1.NodegroupUpdateStatusInProgress},
+		},
+		{
+			name:         "update failed",
+			configUpdate: &expinfrav1.NodegroupUpdateStatus{ID: "update-1", Status: expinfrav1.NodegroupUpdateStatusInProgress},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeUpdate(gomock.Any()).Return(&eks.DescribeUpdateOutput{
+					Update: &eks.Update{
+						Id:     aws.String("update-1"),
+						Status: aws.String(eks.UpdateStatusFailed),
+						Errors: []*eks.ErrorDetail{
+							{ErrorCode: aws.String(eks.ErrorCodeNodeCreationFailure), ErrorMessage: aws.String("instances failed to join")},
+						},
+					},
+				}, nil)
+			},
+			expectStatus: &expinfrav1.NodegroupUpdateStatus{
+				ID:     "update-1",
+				Status: expinfrav1.NodegroupUpdateStatusFailed,
+				Errors: []string{"NodeCreationFailure: instances failed to join"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+			tc.expect(eksMock.EXPECT())
+
+			s := &NodegroupService{
+				scope: &scope.ManagedMachinePoolScope{
+					ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+						Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster"},
+					},
+					ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+						ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+						Spec:       expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "ng"},
+						Status:     expinfrav1.AWSManagedMachinePoolStatus{ConfigUpdate: tc.configUpdate},
+					},
+				},
+				EKSClient: eksMock,
+			}
+			inProgress, err := s.reconcileNodegroupConfigUpdateStatus()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(inProgress).To(Equal(tc.expectInProgress))
+			g.Expect(s.scope.ManagedMachinePool.Status.ConfigUpdate).To(Equal(tc.expectStatus))
+		})
+	}
+}
+
+func TestInstanceTypeInfoIsCached(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().DescribeInstanceTypes(gomock.Any()).DoAndReturn(func(input *ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error) {
+		return &ec2.DescribeInstanceTypesOutput{InstanceTypes: []*ec2.InstanceTypeInfo{{InstanceType: input.InstanceTypes[0]}}}, nil
+	}).Times(2)
+
+	newService := func(region string) *NodegroupService {
+		return &NodegroupService{
+			scope: &scope.ManagedMachinePoolScope{
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{Region: region},
+				},
+				ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+					Spec: expinfrav1.AWSManagedMachinePoolSpec{InstanceType: aws.String("m5.large")},
+				},
+			},
+			EC2Client: ec2Mock,
+		}
+	}
+
+	// The instance type is described once per region.
+	for _, region := range []string{"cache-test-1", "cache-test-1", "cache-test-2"} {
+		info, err := newService(region).instanceTypeInfo()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(aws.StringValue(info.InstanceType)).To(Equal("m5.large"))
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+// nodeRepairConfig is the node auto repair configuration of a nodegroup as sent and
+// returned by the EKS API. The version of the AWS SDK in use does not model it, so it
+// is added to and read from the request and response bodies by request options.
+type nodeRepairConfig struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// nodeRepairConfigToAPI converts the node auto repair configuration of the spec.
+func nodeRepairConfigToAPI(cfg *expinfrav1.NodeRepairConfig) *nodeRepairConfig {
+	if cfg == nil {
+		return nil
+	}
+	return &nodeRepairConfig{Enabled: cfg.Enabled}
+}
+
+// nodeRepairConfigNeedsUpdate returns whether the node auto repair configuration of
+// the spec differs from the one of the nodegroup.
+func nodeRepairConfigNeedsUpdate(spec *expinfrav1.NodeRepairConfig, current *nodeRepairConfig) bool {
+	if spec == nil || spec.Enabled == nil {
+		return false
+	}
+	// Node auto repair is disabled when the nodegroup has no configuration for it.
+	var enabled bool
+	if current != nil {
+		enabled = aws.BoolValue(current.Enabled)
+	}
+	return enabled != *spec.Enabled
+}
+
+// withNodeRepairConfig returns a request option adding the node auto repair configuration
+// to the JSON body of a CreateNodegroup or UpdateNodegroupConfig request.
+func withNodeRepairConfig(cfg *nodeRepairConfig) request.Option {
+	return func(r *request.Request) {
+		r.Handlers.Build.PushBack(func(r *request.Request) {
+			if r.Error != nil {
+				return
+			}
+			body := map[string]json.RawMessage{}
+			if reader := r.GetBody(); reader != nil {
+				b, err := io.ReadAll(reader)
+				if err != nil {
+					r.Error = err
+					return
+				}
+				if len(b) > 0 {
+					if err := json.Unmarshal(b, &body); err != nil {
+						r.Error = err
+						return
+					}
+				}
+			}
+			raw, err := json.Marshal(cfg)
+			if err != nil {
+				r.Error = err
+				return
+			}
+			body["nodeRepairConfig"] = raw
+			b, err := json.Marshal(body)
+			if err != nil {
+				r.Error = err
+				return
+			}
+			r.SetBufferBody(b)
+		})
+	}
+}
+
+// readNodeRepairConfig returns a request option reading the node auto repair configuration
+// from the JSON body of a DescribeNodegroup response into out.
+func readNodeRepairConfig(out **nodeRepairConfig) request.Option {
+	return func(r *request.Request) {
+		r.Handlers.Unmarshal.PushFront(func(r *request.Request) {
+			if r.HTTPResponse == nil || r.HTTPResponse.Body == nil {
+				return
+			}
+			b, err := io.ReadAll(r.HTTPResponse.Body)
+			r.HTTPResponse.Body.Close()
+			r.HTTPResponse.Body = io.NopCloser(bytes.NewReader(b))
+			if err != nil {
+				r.Error = err
+				return
+			}
+			var body struct {
+				Nodegroup struct {
+					NodeRepairConfig *nodeRepairConfig `json:"nodeRepairConfig"`
+				} `json:"nodegroup"`
+			}
+			if err := json.Unmarshal(b, &body); err != nil {
+				// Leave the error to the unmarshaler of the SDK.
+				return
+			}
+			*out = body.Nodegroup.NodeRepairConfig
+		})
+	}
+}
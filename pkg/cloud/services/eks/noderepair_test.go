@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/gomega"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+func newNodeRepairTestClient(t *testing.T, handler http.HandlerFunc) *eks.EKS {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(srv.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		MaxRetries:  aws.Int(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return eks.New(sess)
+}
+
+func TestWithNodeRepairConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	var body map[string]interface{}
+	client := newNodeRepairTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		g.Expect(json.Unmarshal(b, &body)).To(Succeed())
+		_, _ = w.Write([]byte(`{"update":{"id":"1","status":"InProgress"}}`))
+	})
+
+	out, err := client.UpdateNodegroupConfigWithContext(aws.BackgroundContext(), &eks.UpdateNodegroupConfigInput{
+		ClusterName:   aws.String("cluster"),
+		NodegroupName: aws.String("nodegroup"),
+		Labels:        &eks.UpdateLabelsPayload{AddOrUpdateLabels: aws.StringMap(map[string]string{"team": "a"})},
+	}, withNodeRepairConfig(&nodeRepairConfig{Enabled: aws.Bool(true)}))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(aws.StringValue(out.Update.Id)).To(Equal("1"))
+	g.Expect(body).To(HaveKeyWithValue("nodeRepairConfig", map[string]interface{}{"enabled": true}))
+	g.Expect(body).To(HaveKeyWithValue("labels", map[string]interface{}{"addOrUpdateLabels": map[string]interface{}{"team": "a"}}))
+}
+
+func TestReadNodeRepairConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		response string
+		expect   *nodeRepairConfig
+	}{
+		{
+			name:     "node repair enabled",
+			response: `{"nodegroup":{"nodegroupName":"nodegroup","nodeRepairConfig":{"enabled":true}}}`,
+			expect:   &nodeRepairConfig{Enabled: aws.Bool(true)},
+		},
+		{
+			name:     "node repair not returned",
+			response: `{"nodegroup":{"nodegroupName":"nodegroup"}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			client := newNodeRepairTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tc.response))
+			})
+
+			var cfg *nodeRepairConfig
+			out, err := client.DescribeNodegroupWithContext(aws.BackgroundContext(), &eks.DescribeNodegroupInput{
+				ClusterName:   aws.String("cluster"),
+				NodegroupName: aws.String("nodegroup"),
+			}, readNodeRepairConfig(&cfg))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(aws.StringValue(out.Nodegroup.NodegroupName)).To(Equal("nodegroup"))
+			g.Expect(cfg).To(Equal(tc.expect))
+		})
+	}
+}
+
+func TestNodeRepairConfigNeedsUpdate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    *expinfrav1.NodeRepairConfig
+		current *nodeRepairConfig
+		expect  bool
+	}{
+		{
+			name:    "unset in spec",
+			current: &nodeRepairConfig{Enabled: aws.Bool(true)},
+		},
+		{
+			name:   "enabled in spec and not returned",
+			spec:   &expinfrav1.NodeRepairConfig{Enabled: aws.Bool(true)},
+			expect: true,
+		},
+		{
+			name: "disabled in spec and not returned",
+			spec: &expinfrav1.NodeRepairConfig{Enabled: aws.Bool(false)},
+		},
+		{
+			name:    "enabled in spec and disabled",
+			spec:    &expinfrav1.NodeRepairConfig{Enabled: aws.Bool(true)},
+			current: &nodeRepairConfig{Enabled: aws.Bool(false)},
+			expect:  true,
+		},
+		{
+			name:    "disabled in spec and enabled",
+			spec:    &expinfrav1.NodeRepairConfig{Enabled: aws.Bool(false)},
+			current: &nodeRepairConfig{Enabled: aws.Bool(true)},
+			expect:  true,
+		},
+		{
+			name:    "up to date",
+			spec:    &expinfrav1.NodeRepairConfig{Enabled: aws.Bool(true)},
+			current: &nodeRepairConfig{Enabled: aws.Bool(true)},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(nodeRepairConfigNeedsUpdate(tc.spec, tc.current)).To(Equal(tc.expect))
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+const (
+	// minClusterSubnetFreeIPs is the number of free IP addresses EKS requires in
+	// each subnet for the cross-account network interfaces of the control plane.
+	minClusterSubnetFreeIPs = 6
+
+	externalLoadBalancerRoleTag = "kubernetes.io/role/elb"
+	internalLoadBalancerRoleTag = "kubernetes.io/role/internal-elb"
+)
+
+// unsupportedClusterZoneIDs lists the availability zone IDs in which EKS
+// doesn't place control plane network interfaces.
+var unsupportedClusterZoneIDs = map[string]struct{}{
+	"use1-az3": {},
+	"usw1-az2": {},
+	"cac1-az3": {},
+}
+
+// validateClusterSubnets checks that the subnets of the cluster meet the
+// requirements EKS places on control plane subnets. Any violation is returned
+// wrapping ErrClusterSubnetsInvalid so that callers can report it as a
+// precondition failure rather than a generic reconciliation error.
+func (s *Service) validateClusterSubnets() error {
+	subnets := s.scope.ControlPlaneSubnets()
+	for _, id := range s.scope.ControlPlane.Spec.ControlPlaneSubnetIDs {
+		if subnets.FindByID(id) == nil {
+			return errors.Wrapf(ErrClusterSubnetsInvalid, "control plane subnet %s isn't a subnet of the network", id)
+		}
+	}
+	if len(subnets) < 2 {
+		return errors.Wrapf(ErrClusterSubnetsInvalid, "at least 2 subnets are required, found %d", len(subnets))
+	}
+	if zones := subnets.GetUniqueZones(); len(zones) < 2 {
+		return errors.Wrapf(ErrClusterSubnetsInvalid, "subnets in at least 2 different availability zones are required, found %v", zones)
+	}
+
+	ids := make([]*string, 0, len(subnets))
+	for i := range subnets {
+		if subnets[i].ID == "" {
+			return errors.Wrapf(ErrClusterSubnetsInvalid, "subnet in availability zone %q has no ID", subnets[i].AvailabilityZone)
+		}
+		ids = append(ids, aws.String(subnets[i].ID))
+	}
+
+	out, err := s.EC2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: ids})
+	if err != nil {
+		return errors.Wrap(err, "failed to describe cluster subnets")
+	}
+
+	problems := []string{}
+	vpcIDs := map[string]struct{}{}
+	untagged := []string{}
+	for _, sn := range out.Subnets {
+		id := aws.StringValue(sn.SubnetId)
+		vpcIDs[aws.StringValue(sn.VpcId)] = struct{}{}
+
+		if free := aws.Int64Value(sn.AvailableIpAddressCount); free < minClusterSubnetFreeIPs {
+			problems = append(problems, fmt.Sprintf("subnet %s has %d free IP addresses, at least %d are required", id, free, minClusterSubnetFreeIPs))
+		}
+		if _, ok := unsupportedClusterZoneIDs[aws.StringValue(sn.AvailabilityZoneId)]; ok {
+			problems = append(problems, fmt.Sprintf("subnet %s is in availability zone %s (%s) which EKS doesn't support for control planes",
+				id, aws.StringValue(sn.AvailabilityZone), aws.StringValue(sn.AvailabilityZoneId)))
+		}
+
+		roleTag := internalLoadBalancerRoleTag
+		if aws.BoolValue(sn.MapPublicIpOnLaunch) {
+			roleTag = externalLoadBalancerRoleTag
+		}
+		if !hasTag(sn.Tags, roleTag) {
+			untagged = append(untagged, fmt.Sprintf("%s (%s)", id, roleTag))
+		}
+	}
+
+	if len(vpcIDs) > 1 {
+		vpcs := make([]string, 0, len(vpcIDs))
+		for id := range vpcIDs {
+			vpcs = append(vpcs, id)
+		}
+		sort.Strings(vpcs)
+		problems = append(problems, fmt.Sprintf("subnets must all belong to the same VPC, found %s", strings.Join(vpcs, ", ")))
+	} else if vpcID := s.scope.VPC().ID; vpcID != "" && len(vpcIDs) == 1 {
+		if _, ok := vpcIDs[vpcID]; !ok {
+			problems = append(problems, fmt.Sprintf("subnets must belong to the VPC %s of the cluster", vpcID))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.Wrap(ErrClusterSubnetsInvalid, strings.Join(problems, "; "))
+	}
+
+	// Missing role tags don't stop the control plane from being created, but
+	// the cloud provider won't be able to place load balancers in the subnets.
+	if len(untagged) > 0 {
+		record.Warnf(s.scope.ControlPlane, "MissingSubnetRoleTags", "Subnets are missing load balancer role tags: %s", strings.Join(untagged, ", "))
+	}
+
+	return nil
+}
+
+// validateClusterSecurityGroups checks that the additional security groups of
+// the cluster exist in the VPC of the cluster, as EKS requires. Any violation
+// is returned wrapping ErrClusterSecurityGroupsInvalid.
+func (s *Service) validateClusterSecurityGroups() error {
+	ids := s.scope.ControlPlane.Spec.AdditionalSecurityGroupIDs
+	if len(ids) == 0 {
+		return nil
+	}
+
+	out, err := s.EC2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: aws.StringSlice(ids)})
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			return errors.Wrap(ErrClusterSecurityGroupsInvalid, awserrors.Message(err))
+		}
+		return errors.Wrap(err, "failed to describe cluster security groups")
+	}
+
+	found := map[string]struct{}{}
+	problems := []string{}
+	for _, sg := range out.SecurityGroups {
+		id := aws.StringValue(sg.GroupId)
+		found[id] = struct{}{}
+		if vpcID := s.scope.VPC().ID; vpcID != "" && aws.StringValue(sg.VpcId) != vpcID {
+			problems = append(problems, fmt.Sprintf("security group %s belongs to VPC %s instead of the VPC %s of the cluster", id, aws.StringValue(sg.VpcId), vpcID))
+		}
+	}
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			problems = append(problems, fmt.Sprintf("security group %s doesn't exist", id))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.Wrap(ErrClusterSecurityGroupsInvalid, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func hasTag(tags []*ec2.Tag, key string) bool {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestValidateClusterSubnets(t *testing.T) {
+	twoZones := []infrav1.SubnetSpec{
+		{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+		{ID: "subnet-2", AvailabilityZone: "us-east-1b"},
+	}
+	awsSubnet := func(id, vpc, zoneID string, free int64) *ec2.Subnet {
+		return &ec2.Subnet{
+			SubnetId:                aws.String(id),
+			VpcId:                   aws.String(vpc),
+			AvailabilityZoneId:      aws.String(zoneID),
+			AvailableIpAddressCount: aws.Int64(free),
+			Tags:                    []*ec2.Tag{{Key: aws.String(internalLoadBalancerRoleTag), Value: aws.String("1")}},
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		subnets               []infrav1.SubnetSpec
+		vpcID                 string
+		controlPlaneSubnetIDs []string
+		expect                func(m *mocks.MockEC2APIMockRecorder)
+		expectError           bool
+	}{
+		{
+			name:        "fails with a single subnet",
+			subnets:     twoZones[:1],
+			expectError: true,
+		},
+		{
+			name: "fails with subnets in a single availability zone",
+			subnets: []infrav1.SubnetSpec{
+				{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-2", AvailabilityZone: "us-east-1a"},
+			},
+			expectError: true,
+		},
+		{
+			name:    "succeeds with valid subnets",
+			subnets: twoZones,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{
+					awsSubnet("subnet-1", "vpc-1", "use1-az1", 100),
+					awsSubnet("subnet-2", "vpc-1", "use1-az2", 100),
+				}}, nil)
+			},
+		},
+		{
+			name:    "fails when a subnet has too few free IP addresses",
+			subnets: twoZones,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{
+					awsSubnet("subnet-1", "vpc-1", "use1-az1", 100),
+					awsSubnet("subnet-2", "vpc-1", "use1-az2", 2),
+				}}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:    "fails when a subnet is in an unsupported availability zone",
+			subnets: twoZones,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{
+					awsSubnet("subnet-1", "vpc-1", "use1-az1", 100),
+					awsSubnet("subnet-2", "vpc-1", "use1-az3", 100),
+				}}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name: "only validates the control plane subnets",
+			subnets: append([]infrav1.SubnetSpec{
+				{ID: "subnet-3", AvailabilityZone: "us-east-1a"},
+			}, twoZones...),
+			vpcID:                 "vpc-1",
+			controlPlaneSubnetIDs: []string{"subnet-1", "subnet-2"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{"subnet-1", "subnet-2"})}).Return(&ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{
+					awsSubnet("subnet-1", "vpc-1", "use1-az1", 100),
+					awsSubnet("subnet-2", "vpc-1", "use1-az2", 100),
+				}}, nil)
+			},
+		},
+		{
+			name:                  "fails when a control plane subnet isn't a subnet of the network",
+			subnets:               twoZones,
+			vpcID:                 "vpc-1",
+			controlPlaneSubnetIDs: []string{"subnet-1", "subnet-3"},
+			expectError:           true,
+		},
+		{
+			name:    "fails when subnets don't belong to the VPC of the cluster",
+			subnets: twoZones,
+			vpcID:   "vpc-1",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{
+					awsSubnet("subnet-1", "vpc-2", "use1-az1", 100),
+					awsSubnet("subnet-2", "vpc-2", "use1-az2", 100),
+				}}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:    "fails when subnets belong to different VPCs",
+			subnets: twoZones,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{
+					awsSubnet("subnet-1", "vpc-1", "use1-az1", 100),
+					awsSubnet("subnet-2", "vpc-2", "use1-az2", 100),
+				}}, nil)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockControl)
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-name",
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName: "cluster.default",
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC:     infrav1.VPCSpec{ID: tc.vpcID},
+							Subnets: tc.subnets,
+						},
+						ControlPlaneSubnetIDs: tc.controlPlaneSubnetIDs,
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.validateClusterSubnets()
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(errors.Is(err, ErrClusterSubnetsInvalid)).To(BeTrue())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
+
+func TestValidateClusterSecurityGroups(t *testing.T) {
+	tests := []struct {
+		name           string
+		securityGroups []string
+		expect         func(m *mocks.MockEC2APIMockRecorder)
+		expectError    bool
+	}{
+		{
+			name: "succeeds without additional security groups",
+		},
+		{
+			name:           "succeeds with security groups of the VPC",
+			securityGroups: []string{"sg-1"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: aws.StringSlice([]string{"sg-1"})}).Return(&ec2.DescribeSecurityGroupsOutput{
+					SecurityGroups: []*ec2.SecurityGroup{{GroupId: aws.String("sg-1"), VpcId: aws.String("vpc-1")}},
+				}, nil)
+			},
+		},
+		{
+			name:           "fails when a security group belongs to another VPC",
+			securityGroups: []string{"sg-1"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{
+					SecurityGroups: []*ec2.SecurityGroup{{GroupId: aws.String("sg-1"), VpcId: aws.String("vpc-2")}},
+				}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:           "fails when a security group doesn't exist",
+			securityGroups: []string{"sg-1"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(gomock.Any()).Return(nil, awserr.New("InvalidGroup.NotFound", "The security group 'sg-1' does not exist", nil))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockControl)
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-name",
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName:             "cluster.default",
+						NetworkSpec:                infrav1.NetworkSpec{VPC: infrav1.VPCSpec{ID: "vpc-1"}},
+						AdditionalSecurityGroupIDs: tc.securityGroups,
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.validateClusterSecurityGroups()
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(errors.Is(err, ErrClusterSecurityGroupsInvalid)).To(BeTrue())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
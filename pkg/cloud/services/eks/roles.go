@@ -18,14 +18,16 @@ package eks
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/pkg/errors"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
@@ -36,22 +38,27 @@ const (
 	maxIAMRoleNameLength = 64
 )
 
-// NodegroupRolePolicies gives the policies required for a nodegroup role.
-func NodegroupRolePolicies() []string {
+// NodegroupRolePolicies gives the policies required for a nodegroup role in the given partition.
+func NodegroupRolePolicies(partition string) []string {
 	return []string{
-		"arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy",
-		"arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy", //TODO: Can remove when CAPA supports provisioning of OIDC web identity federation with service account token volume projection
-		"arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly",
+		managedPolicyARN(partition, "AmazonEKSWorkerNodePolicy"),
+		managedPolicyARN(partition, "AmazonEKS_CNI_Policy"), //TODO: Can remove when CAPA supports provisioning of OIDC web identity federation with service account token volume projection
+		managedPolicyARN(partition, "AmazonEC2ContainerRegistryReadOnly"),
 	}
 }
 
-// FargateRolePolicies gives the policies required for a fargate role.
-func FargateRolePolicies() []string {
+// FargateRolePolicies gives the policies required for a fargate role in the given partition.
+func FargateRolePolicies(partition string) []string {
 	return []string{
-		"arn:aws:iam::aws:policy/AmazonEKSFargatePodExecutionRolePolicy",
+		managedPolicyARN(partition, "AmazonEKSFargatePodExecutionRolePolicy"),
 	}
 }
 
+// managedPolicyARN returns the ARN of an AWS managed policy in the given partition.
+func managedPolicyARN(partition, name string) string {
+	return fmt.Sprintf("arn:%s:iam::aws:policy/%s", partition, name)
+}
+
 func (s *Service) reconcileControlPlaneIAMRole() error {
 	s.scope.Debug("Reconciling EKS Control Plane IAM Role")
 
@@ -68,7 +75,7 @@ func (s *Service) reconcileControlPlaneIAMRole() error {
 
 	role, err := s.GetIAMRole(*s.scope.ControlPlane.Spec.RoleName)
 	if err != nil {
-		if !isNotFound(err) {
+		if !awserrors.IsNotFound(err) {
 			return err
 		}
 
@@ -94,7 +101,7 @@ func (s *Service) reconcileControlPlaneIAMRole() error {
 	//TODO: check tags and trust relationship to see if they need updating
 
 	policies := []*string{
-		aws.String("arn:aws:iam::aws:policy/AmazonEKSClusterPolicy"),
+		aws.String(managedPolicyARN(s.scope.Partition(), "AmazonEKSClusterPolicy")),
 	}
 	if s.scope.ControlPlane.Spec.RoleAdditionalPolicies != nil {
 		if !s.scope.AllowAdditionalRoles() && len(*s.scope.ControlPlane.Spec.RoleAdditionalPolicies) > 0 {
@@ -128,7 +135,7 @@ func (s *Service) deleteControlPlaneIAMRole() error {
 
 	role, err := s.GetIAMRole(roleName)
 	if err != nil {
-		if isNotFound(err) {
+		if awserrors.IsNotFound(err) {
 			s.Debug("EKS Control Plane IAM Role already deleted")
 			return nil
 		}
@@ -176,7 +183,7 @@ func (s *NodegroupService) reconcileNodegroupIAMRole() error {
 
 	role, err := s.GetIAMRole(s.scope.RoleName())
 	if err != nil {
-		if !isNotFound(err) {
+		if !awserrors.IsNotFound(err) {
 			return err
 		}
 
@@ -203,7 +210,7 @@ func (s *NodegroupService) reconcileNodegroupIAMRole() error {
 		return errors.Wrapf(err, "error ensuring tags and policy document are set on node role")
 	}
 
-	policies := NodegroupRolePolicies()
+	policies := NodegroupRolePolicies(s.scope.Partition())
 	if len(s.scope.ManagedMachinePool.Spec.RoleAdditionalPolicies) > 0 {
 		if !s.scope.AllowAdditionalRoles() {
 			return ErrCannotUseAdditionalRoles
@@ -246,7 +253,7 @@ func (s *NodegroupService) deleteNodegroupIAMRole() (reterr error) {
 
 	role, err := s.GetIAMRole(roleName)
 	if err != nil {
-		if isNotFound(err) {
+		if awserrors.IsNotFound(err) {
 			s.Debug("EKS Nodegroup IAM Role already deleted")
 			return nil
 		}
@@ -272,6 +279,18 @@ func (s *NodegroupService) deleteNodegroupIAMRole() (reterr error) {
 func (s *FargateService) reconcileFargateIAMRole() (requeue bool, err error) {
 	s.scope.Debug("Reconciling EKS Fargate IAM Role")
 
+	if roleARN := s.scope.PodExecutionRoleARN(); roleARN != "" {
+		// An existing role is used as is, we only check that it can be found.
+		roleName := roleARN[strings.LastIndex(roleARN, "/")+1:]
+		if _, err := s.GetIAMRole(roleName); err != nil {
+			if awserrors.IsNotFound(err) {
+				return false, ErrFargateRoleNotFound
+			}
+			return false, err
+		}
+		return false, nil
+	}
+
 	if s.scope.RoleName() == "" {
 		var roleName string
 		if !s.scope.EnableIAM() {
@@ -296,7 +315,7 @@ func (s *FargateService) reconcileFargateIAMRole() (requeue bool, err error) {
 
 	role, err := s.GetIAMRole(s.scope.RoleName())
 	if err != nil {
-		if !isNotFound(err) {
+		if !awserrors.IsNotFound(err) {
 			return false, err
 		}
 
@@ -319,7 +338,7 @@ func (s *FargateService) reconcileFargateIAMRole() (requeue bool, err error) {
 		return updatedRole, errors.Wrapf(err, "error ensuring tags and policy document are set on fargate role")
 	}
 
-	policies := FargateRolePolicies()
+	policies := FargateRolePolicies(s.scope.Partition())
 	updatedPolicies, err := s.EnsurePoliciesAttached(role, aws.StringSlice(policies))
 	if err != nil {
 		return updatedRole, errors.Wrapf(err, "error ensuring policies are attached: %v", policies)
@@ -350,11 +369,16 @@ func (s *FargateService) deleteFargateIAMRole() (reterr error) {
 		return nil
 	}
 
+	if s.scope.PodExecutionRoleARN() != "" {
+		s.scope.Debug("Existing pod execution role used, skipping deleting EKS fargate IAM Role")
+		return nil
+	}
+
 	s.scope.Debug("Deleting EKS fargate IAM Role")
 
 	_, err := s.GetIAMRole(roleName)
 	if err != nil {
-		if isNotFound(err) {
+		if awserrors.IsNotFound(err) {
 			s.Debug("EKS fargate IAM Role already deleted")
 			return nil
 		}
@@ -372,15 +396,26 @@ func (s *FargateService) deleteFargateIAMRole() (reterr error) {
 	return nil
 }
 
-func isNotFound(err error) bool {
-	if aerr, ok := err.(awserr.Error); ok {
-		switch aerr.Code() {
-		case iam.ErrCodeNoSuchEntityException:
-			return true
-		default:
-			return false
-		}
+// iamRoleReason returns the reason of the condition of an IAM role that failed to reconcile,
+// derived from the kind of the error returned by the IAM API.
+func iamRoleReason(err error) string {
+	switch {
+	case errors.Is(err, ErrClusterRoleNotFound), errors.Is(err, ErrNodegroupRoleNotFound), errors.Is(err, ErrFargateRoleNotFound):
+		return infrav1.IAMRoleNotFoundReason
+	case errors.Is(err, ErrCannotUseAdditionalRoles):
+		return infrav1.IAMAdditionalPoliciesNotAllowedReason
 	}
 
-	return false
+	switch {
+	case awserrors.IsNotFound(err):
+		return infrav1.IAMRoleNotFoundReason
+	case awserrors.IsPermissionDenied(err):
+		return infrav1.IAMAccessDeniedReason
+	case awserrors.IsQuotaExceeded(err):
+		return infrav1.IAMLimitExceededReason
+	}
+	if code, _ := awserrors.Code(err); code == iam.ErrCodeMalformedPolicyDocumentException {
+		return infrav1.IAMMalformedPolicyDocumentReason
+	}
+	return infrav1.IAMRoleReconciliationFailedReason
 }
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+func TestIAMRoleReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+	}{
+		{
+			name:   "role that must exist is missing",
+			err:    fmt.Errorf("getting role %s: %w", "eks-role", ErrClusterRoleNotFound),
+			reason: infrav1.IAMRoleNotFoundReason,
+		},
+		{
+			name:   "additional policies are not allowed",
+			err:    ErrCannotUseAdditionalRoles,
+			reason: infrav1.IAMAdditionalPoliciesNotAllowedReason,
+		},
+		{
+			name:   "access denied by the IAM API",
+			err:    fmt.Errorf("creating role %s: %w", "eks-role", errors.Wrap(awserr.New("AccessDenied", "not authorized to perform iam:CreateRole", nil), "failed to call CreateRole")),
+			reason: infrav1.IAMAccessDeniedReason,
+		},
+		{
+			name:   "role quota reached",
+			err:    errors.Wrap(awserr.New(iam.ErrCodeLimitExceededException, "cannot exceed quota for RolesPerAccount", nil), "failed to create role"),
+			reason: infrav1.IAMLimitExceededReason,
+		},
+		{
+			name:   "malformed trust relationship",
+			err:    awserr.New(iam.ErrCodeMalformedPolicyDocumentException, "invalid principal", nil),
+			reason: infrav1.IAMMalformedPolicyDocumentReason,
+		},
+		{
+			name:   "other IAM error",
+			err:    awserr.New(iam.ErrCodeServiceFailureException, "internal failure", nil),
+			reason: infrav1.IAMRoleReconciliationFailedReason,
+		},
+		{
+			name:   "error without IAM error code",
+			err:    errors.New("couldn't generate IAM role name"),
+			reason: infrav1.IAMRoleReconciliationFailedReason,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(iamRoleReason(tc.err)).To(Equal(tc.reason))
+		})
+	}
+}
@@ -70,9 +70,11 @@ func NewService(controlPlaneScope *scope.ManagedControlPlaneScope, opts ...Servi
 			EKSAPI: scope.NewEKSClient(controlPlaneScope, controlPlaneScope, controlPlaneScope, controlPlaneScope.ControlPlane),
 		},
 		IAMService: iam.IAMService{
-			Wrapper:   &controlPlaneScope.Logger,
-			IAMClient: scope.NewIAMClient(controlPlaneScope, controlPlaneScope, controlPlaneScope, controlPlaneScope.ControlPlane),
-			Client:    http.DefaultClient,
+			Wrapper:             &controlPlaneScope.Logger,
+			IAMClient:           scope.NewIAMClient(controlPlaneScope, controlPlaneScope, controlPlaneScope, controlPlaneScope.ControlPlane),
+			Client:              http.DefaultClient,
+			PermissionsBoundary: controlPlaneScope.IAMPermissionsBoundary(),
+			Path:                controlPlaneScope.IAMPath(),
 		},
 		STSClient: scope.NewSTSClient(controlPlaneScope, controlPlaneScope, controlPlaneScope, controlPlaneScope.ControlPlane),
 	}
@@ -90,9 +92,14 @@ func NewService(controlPlaneScope *scope.ManagedControlPlaneScope, opts ...Servi
 type NodegroupService struct {
 	scope             *scope.ManagedMachinePoolScope
 	AutoscalingClient autoscalingiface.AutoScalingAPI
+	EC2Client         ec2iface.EC2API
 	EKSClient         eksiface.EKSAPI
 	iam.IAMService
 	STSClient stsiface.STSAPI
+
+	// nodeRepairConfig is the node auto repair configuration of the nodegroup
+	// as last returned by the EKS API.
+	nodeRepairConfig *nodeRepairConfig
 }
 
 // NewNodegroupService returns a new service given the api clients.
@@ -100,10 +107,13 @@ func NewNodegroupService(machinePoolScope *scope.ManagedMachinePoolScope) *Nodeg
 	return &NodegroupService{
 		scope:             machinePoolScope,
 		AutoscalingClient: scope.NewASGClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
+		EC2Client:         scope.NewEC2Client(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
 		EKSClient:         scope.NewEKSClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
 		IAMService: iam.IAMService{
-			Wrapper:   &machinePoolScope.Logger,
-			IAMClient: scope.NewIAMClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
+			Wrapper:             &machinePoolScope.Logger,
+			IAMClient:           scope.NewIAMClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
+			PermissionsBoundary: machinePoolScope.IAMPermissionsBoundary(),
+			Path:                machinePoolScope.IAMPath(),
 		},
 		STSClient: scope.NewSTSClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
 	}
@@ -124,8 +134,10 @@ func NewFargateService(fargatePoolScope *scope.FargateProfileScope) *FargateServ
 		scope:     fargatePoolScope,
 		EKSClient: scope.NewEKSClient(fargatePoolScope, fargatePoolScope, fargatePoolScope, fargatePoolScope.FargateProfile),
 		IAMService: iam.IAMService{
-			Wrapper:   &fargatePoolScope.Logger,
-			IAMClient: scope.NewIAMClient(fargatePoolScope, fargatePoolScope, fargatePoolScope, fargatePoolScope.FargateProfile),
+			Wrapper:             &fargatePoolScope.Logger,
+			IAMClient:           scope.NewIAMClient(fargatePoolScope, fargatePoolScope, fargatePoolScope, fargatePoolScope.FargateProfile),
+			PermissionsBoundary: fargatePoolScope.IAMPermissionsBoundary(),
+			Path:                fargatePoolScope.IAMPath(),
 		},
 		STSClient: scope.NewSTSClient(fargatePoolScope, fargatePoolScope, fargatePoolScope, fargatePoolScope.FargateProfile),
 	}
@@ -18,15 +18,20 @@ package eks
 
 import (
 	"fmt"
+	"strconv"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
 )
@@ -35,6 +40,10 @@ const (
 	eksClusterNameTag              = "eks:cluster-name"
 	eksNodeGroupNameTag            = "eks:nodegroup-name"
 	eksClusterAutoscalerEnabledTag = "k8s.io/cluster-autoscaler/enabled"
+
+	// autoscalerNodeTemplateTagPrefix is the prefix of the tags cluster-autoscaler reads to build
+	// the node template of a node group that is scaled to zero.
+	autoscalerNodeTemplateTagPrefix = "k8s.io/cluster-autoscaler/node-template/"
 )
 
 func (s *Service) reconcileTags(cluster *eks.Cluster) error {
@@ -109,8 +118,129 @@ func getASGTagUpdates(clusterName string, currentTags map[string]string, tags ma
 	return tagsToDelete, tagsToAdd
 }
 
+// autoscalerNodeTemplateTags returns the cluster-autoscaler node template tags for the labels,
+// taints, ephemeral storage and instance type resources of the managed machine pool, so that
+// the autoscaler is able to scale the nodegroup up from zero. instanceType may be nil when the
+// instance type of the pool is not known.
+// See https://github.com/kubernetes/autoscaler/tree/master/cluster-autoscaler/cloudprovider/aws#auto-discovery-setup.
+func autoscalerNodeTemplateTags(pool *expinfrav1.AWSManagedMachinePoolSpec, instanceType *ec2.InstanceTypeInfo) (infrav1.Tags, error) {
+	tags := infrav1.Tags{}
+
+	for k, v := range pool.Labels {
+		tags[autoscalerNodeTemplateTagPrefix+"label/"+k] = v
+	}
+
+	for _, taint := range pool.Taints {
+		effect, err := converters.TaintEffectToKubernetes(taint.Effect)
+		if err != nil {
+			return nil, fmt.Errorf("converting taint %s: %w", taint.Key, err)
+		}
+		tags[autoscalerNodeTemplateTagPrefix+"taint/"+taint.Key] = fmt.Sprintf("%s:%s", taint.Value, effect)
+	}
+
+	if diskSize := rootVolumeSize(pool); diskSize > 0 {
+		tags[autoscalerNodeTemplateTagPrefix+"resources/"+string(corev1.ResourceEphemeralStorage)] = fmt.Sprintf("%dGi", diskSize)
+	}
+
+	if instanceType == nil {
+		return tags, nil
+	}
+	if instanceType.VCpuInfo != nil && aws.Int64Value(instanceType.VCpuInfo.DefaultVCpus) > 0 {
+		tags[autoscalerNodeTemplateTagPrefix+"resources/"+string(corev1.ResourceCPU)] = strconv.FormatInt(aws.Int64Value(instanceType.VCpuInfo.DefaultVCpus), 10)
+	}
+	if instanceType.MemoryInfo != nil && aws.Int64Value(instanceType.MemoryInfo.SizeInMiB) > 0 {
+		tags[autoscalerNodeTemplateTagPrefix+"resources/"+string(corev1.ResourceMemory)] = fmt.Sprintf("%dMi", aws.Int64Value(instanceType.MemoryInfo.SizeInMiB))
+	}
+	if instanceType.GpuInfo != nil {
+		gpus := map[string]int64{}
+		for _, gpu := range instanceType.GpuInfo.Gpus {
+			resource, ok := converters.GPUResourceNames[aws.StringValue(gpu.Manufacturer)]
+			if !ok {
+				continue
+			}
+			gpus[string(resource)] += aws.Int64Value(gpu.Count)
+		}
+		for resource, count := range gpus {
+			tags[autoscalerNodeTemplateTagPrefix+"resources/"+resource] = strconv.FormatInt(count, 10)
+		}
+	}
+
+	return tags, nil
+}
+
+// rootVolumeSize returns the size in GiB of the root volume of the nodes of the managed machine pool,
+// or 0 if it is left to the default of the nodegroup.
+func rootVolumeSize(pool *expinfrav1.AWSManagedMachinePoolSpec) int64 {
+	switch {
+	case pool.AWSLaunchTemplate != nil && pool.AWSLaunchTemplate.RootVolume != nil:
+		return pool.AWSLaunchTemplate.RootVolume.Size
+	case pool.DiskSize != nil:
+		return int64(*pool.DiskSize)
+	}
+	return 0
+}
+
+// instanceTypeInfoCache holds the descriptions of the instance types by region, shared by all managed machine
+// pools. The vCPUs, memory and GPUs of an instance type never change, so the descriptions don't expire.
+var instanceTypeInfoCache sync.Map
+
+// instanceTypeInfo describes the instance type of the managed machine pool. It returns
+// nil if the instance type is left to the default of the nodegroup.
+func (s *NodegroupService) instanceTypeInfo() (*ec2.InstanceTypeInfo, error) {
+	pool := s.scope.ManagedMachinePool.Spec
+	var instanceType string
+	switch {
+	case pool.InstanceType != nil:
+		instanceType = *pool.InstanceType
+	case pool.AWSLaunchTemplate != nil:
+		instanceType = pool.AWSLaunchTemplate.InstanceType
+	}
+	if instanceType == "" {
+		return nil, nil
+	}
+
+	key := s.scope.ControlPlane.Spec.Region + "/" + instanceType
+	if info, ok := instanceTypeInfoCache.Load(key); ok {
+		return info.(*ec2.InstanceTypeInfo), nil
+	}
+
+	out, err := s.EC2Client.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []*string{aws.String(instanceType)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instance type %q", instanceType)
+	}
+	if len(out.InstanceTypes) == 0 {
+		return nil, errors.Errorf("instance type %q not found", instanceType)
+	}
+	instanceTypeInfoCache.Store(key, out.InstanceTypes[0])
+	return out.InstanceTypes[0], nil
+}
+
+// autoscalerTags returns the cluster-autoscaler node template tags of the managed machine pool.
+func (s *NodegroupService) autoscalerTags() (infrav1.Tags, error) {
+	instanceType, err := s.instanceTypeInfo()
+	if err != nil {
+		return nil, err
+	}
+	return autoscalerNodeTemplateTags(&s.scope.ManagedMachinePool.Spec, instanceType)
+}
+
+// nodegroupTags returns the tags of the nodegroup, including the cluster-autoscaler node template tags.
+func (s *NodegroupService) nodegroupTags() (map[string]string, error) {
+	autoscalerTags, err := s.autoscalerTags()
+	if err != nil {
+		return nil, err
+	}
+	autoscalerTags.Merge(s.scope.AdditionalTags())
+	return ngTags(s.scope.ClusterName(), autoscalerTags), nil
+}
+
 func (s *NodegroupService) reconcileTags(ng *eks.Nodegroup) error {
-	tags := ngTags(s.scope.ClusterName(), s.scope.AdditionalTags())
+	tags, err := s.nodegroupTags()
+	if err != nil {
+		return err
+	}
 	return updateTags(s.EKSClient, ng.NodegroupArn, aws.StringValueMap(ng.Tags), tags)
 }
 
@@ -129,7 +259,15 @@ func (s *NodegroupService) reconcileASGTags(ng *eks.Nodegroup) error {
 		return errors.Wrap(err, "failed to describe ASG for nodegroup")
 	}
 
-	tagsToDelete, tagsToAdd := getASGTagUpdates(s.scope.ClusterName(), tagDescriptionsToMap(asg.Tags), s.scope.AdditionalTags())
+	// cluster-autoscaler reads the node template tags from the ASG rather than from the nodegroup.
+	autoscalerTags, err := s.autoscalerTags()
+	if err != nil {
+		return err
+	}
+
+	autoscalerTags.Merge(s.scope.AdditionalTags())
+
+	tagsToDelete, tagsToAdd := getASGTagUpdates(s.scope.ClusterName(), tagDescriptionsToMap(asg.Tags), autoscalerTags)
 	s.scope.Debug("Tags", "tagsToAdd", tagsToAdd, "tagsToDelete", tagsToDelete)
 
 	if len(tagsToAdd) > 0 {
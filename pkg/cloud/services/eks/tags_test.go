@@ -20,7 +20,13 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 )
 
 func TestGetTagUpdates(t *testing.T) {
@@ -52,3 +58,94 @@ func TestGetTagUpdates(t *testing.T) {
 		})
 	}
 }
+
+func TestAutoscalerNodeTemplateTags(t *testing.T) {
+	testCases := []struct {
+		name         string
+		pool         expinfrav1.AWSManagedMachinePoolSpec
+		instanceType *ec2.InstanceTypeInfo
+		expect       infrav1.Tags
+		expectErr    bool
+	}{
+		{
+			name:   "no labels, taints or disk size",
+			pool:   expinfrav1.AWSManagedMachinePoolSpec{},
+			expect: infrav1.Tags{},
+		},
+		{
+			name: "labels, taints and disk size",
+			pool: expinfrav1.AWSManagedMachinePoolSpec{
+				Labels: map[string]string{
+					"node-role.kubernetes.io/worker": "",
+					"team":                           "a",
+				},
+				Taints: expinfrav1.Taints{
+					{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoSchedule},
+					{Key: "spot", Value: "true", Effect: expinfrav1.TaintEffectPreferNoSchedule},
+				},
+				DiskSize: pointer.Int32(50),
+			},
+			expect: infrav1.Tags{
+				"k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/worker": "",
+				"k8s.io/cluster-autoscaler/node-template/label/team":                           "a",
+				"k8s.io/cluster-autoscaler/node-template/taint/dedicated":                      "gpu:NoSchedule",
+				"k8s.io/cluster-autoscaler/node-template/taint/spot":                           "true:PreferNoSchedule",
+				"k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage":          "50Gi",
+			},
+		},
+		{
+			name: "launch template root volume takes precedence over disk size",
+			pool: expinfrav1.AWSManagedMachinePoolSpec{
+				DiskSize: pointer.Int32(50),
+				AWSLaunchTemplate: &expinfrav1.AWSLaunchTemplate{
+					RootVolume: &infrav1.Volume{Size: 100},
+				},
+			},
+			expect: infrav1.Tags{
+				"k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage": "100Gi",
+			},
+		},
+		{
+			name: "instance type resources",
+			pool: expinfrav1.AWSManagedMachinePoolSpec{
+				InstanceType: pointer.String("g4dn.xlarge"),
+			},
+			instanceType: &ec2.InstanceTypeInfo{
+				InstanceType: aws.String("g4dn.xlarge"),
+				VCpuInfo:     &ec2.VCpuInfo{DefaultVCpus: aws.Int64(4)},
+				MemoryInfo:   &ec2.MemoryInfo{SizeInMiB: aws.Int64(16384)},
+				GpuInfo: &ec2.GpuInfo{
+					Gpus: []*ec2.GpuDeviceInfo{
+						{Manufacturer: aws.String("NVIDIA"), Count: aws.Int64(1)},
+					},
+				},
+			},
+			expect: infrav1.Tags{
+				"k8s.io/cluster-autoscaler/node-template/resources/cpu":            "4",
+				"k8s.io/cluster-autoscaler/node-template/resources/memory":         "16384Mi",
+				"k8s.io/cluster-autoscaler/node-template/resources/nvidia.com/gpu": "1",
+			},
+		},
+		{
+			name: "unknown taint effect",
+			pool: expinfrav1.AWSManagedMachinePoolSpec{
+				Taints: expinfrav1.Taints{
+					{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffect("unknown")},
+				},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			tags, err := autoscalerNodeTemplateTags(&tc.pool, tc.instanceType)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(tags).To(Equal(tc.expect))
+		})
+	}
+}
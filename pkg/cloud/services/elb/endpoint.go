@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// endpointRecordTTL is the TTL, in seconds, of the Route53 record of the control plane endpoint.
+const endpointRecordTTL = 60
+
+// describeEndpointRecord returns the DNS name the Route53 record of the control plane endpoint
+// resolves to, or an empty string if the record doesn't exist.
+func (s *Service) describeEndpointRecord() (string, error) {
+	r53 := s.scope.ControlPlaneLoadBalancer().Route53
+	recordName := strings.TrimSuffix(r53.RecordName, ".")
+
+	out, err := s.Route53Client.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(r53.HostedZoneID),
+		StartRecordName: aws.String(recordName),
+		StartRecordType: aws.String(route53.RRTypeCname),
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe record %q of hosted zone %q", recordName, r53.HostedZoneID)
+	}
+
+	for _, rrs := range out.ResourceRecordSets {
+		if !strings.EqualFold(strings.TrimSuffix(aws.StringValue(rrs.Name), "."), recordName) ||
+			aws.StringValue(rrs.Type) != route53.RRTypeCname || len(rrs.ResourceRecords) == 0 {
+			continue
+		}
+		return aws.StringValue(rrs.ResourceRecords[0].Value), nil
+	}
+	return "", nil
+}
+
+// reconcileEndpointRecord points the Route53 record of the control plane endpoint to the DNS name of a
+// load balancer. It returns true if the record was changed.
+func (s *Service) reconcileEndpointRecord(dnsName string) (bool, error) {
+	current, err := s.describeEndpointRecord()
+	if err != nil {
+		return false, err
+	}
+	if strings.EqualFold(strings.TrimSuffix(current, "."), dnsName) {
+		return false, nil
+	}
+
+	if err := s.changeEndpointRecord(route53.ChangeActionUpsert, dnsName); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedUpdateEndpointRecord", "Failed to point control plane endpoint record to %q: %v", dnsName, err)
+		return false, err
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulUpdateEndpointRecord", "Pointed control plane endpoint record to %q", dnsName)
+	return true, nil
+}
+
+// deleteEndpointRecord deletes the Route53 record of the control plane endpoint, if it exists.
+func (s *Service) deleteEndpointRecord() error {
+	current, err := s.describeEndpointRecord()
+	if err != nil || current == "" {
+		return err
+	}
+	return s.changeEndpointRecord(route53.ChangeActionDelete, current)
+}
+
+func (s *Service) changeEndpointRecord(action, dnsName string) error {
+	r53 := s.scope.ControlPlaneLoadBalancer().Route53
+	recordName := strings.TrimSuffix(r53.RecordName, ".")
+
+	_, err := s.Route53Client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(r53.HostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String("Control plane endpoint of cluster " + s.scope.Name()),
+			Changes: []*route53.Change{
+				{
+					Action: aws.String(action),
+					ResourceRecordSet: &route53.ResourceRecordSet{
+						Name:            aws.String(recordName),
+						Type:            aws.String(route53.RRTypeCname),
+						TTL:             aws.Int64(endpointRecordTTL),
+						ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(dnsName)}},
+					},
+				},
+			},
+		},
+	})
+	return errors.Wrapf(err, "failed to %s record %q of hosted zone %q", strings.ToLower(action), recordName, r53.HostedZoneID)
+}
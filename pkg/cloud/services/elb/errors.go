@@ -20,7 +20,6 @@ import (
 	"net/http"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/pkg/errors"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
@@ -56,27 +55,14 @@ func NewConflict(msg string) error {
 	}
 }
 
-// IsNotFound returns true if the error was created by NewNotFound.
+// IsNotFound returns true if the error was created by NewNotFound or is an AWS error of kind awserrors.KindNotFound.
 func IsNotFound(err error) bool {
-	if ReasonForError(err) == http.StatusNotFound {
-		return true
-	}
-	if code, ok := awserrors.Code(errors.Cause(err)); ok {
-		if code == elb.ErrCodeAccessPointNotFoundException {
-			return true
-		}
-	}
-	return false
+	return ReasonForError(err) == http.StatusNotFound || awserrors.IsNotFound(err)
 }
 
-// IsAccessDenied returns true if the error is AccessDenied.
+// IsAccessDenied returns true if the error is an AWS error of kind awserrors.KindPermissionDenied.
 func IsAccessDenied(err error) bool {
-	if code, ok := awserrors.Code(errors.Cause(err)); ok {
-		if code == "AccessDenied" {
-			return true
-		}
-	}
-	return false
+	return awserrors.IsPermissionDenied(err)
 }
 
 // IsConflict returns true if the error was created by NewConflict.
@@ -0,0 +1,181 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+const (
+	// apiServerHealthCheckPath is the path of HTTP and HTTPS health checks of the API server, which only
+	// succeeds once the API server is ready to serve requests.
+	apiServerHealthCheckPath = "/readyz"
+
+	classicELBHealthCheckInterval           = 10 * time.Second
+	classicELBHealthCheckTimeout            = 5 * time.Second
+	classicELBHealthCheckHealthyThreshold   = 5
+	classicELBHealthCheckUnhealthyThreshold = 3
+)
+
+// apiServerHealthCheck returns the health check configured for the API server, if any.
+func (s *Service) apiServerHealthCheck() *infrav1.TargetGroupHealthCheckSpec {
+	if s.scope.ControlPlaneLoadBalancer() == nil {
+		return nil
+	}
+	return s.scope.ControlPlaneLoadBalancer().HealthCheck
+}
+
+// getAPIServerTargetGroupHealthCheck returns the health check of the target group of the API server, which
+// checks the API server port over TCP by default.
+func (s *Service) getAPIServerTargetGroupHealthCheck() *infrav1.TargetGroupHealthCheck {
+	healthCheck := targetGroupHealthCheck(infrav1.DefaultAPIServerPort, s.apiServerHealthCheck())
+	if isHTTPHealthCheck(aws.StringValue(healthCheck.Protocol)) && healthCheck.Path == nil {
+		healthCheck.Path = aws.String(apiServerHealthCheckPath)
+	}
+	return healthCheck
+}
+
+// targetGroupHealthCheck returns the health check of a target group checking the given port over TCP,
+// overridden by the settings of the spec.
+func targetGroupHealthCheck(port int64, spec *infrav1.TargetGroupHealthCheckSpec) *infrav1.TargetGroupHealthCheck {
+	healthCheck := &infrav1.TargetGroupHealthCheck{
+		Protocol: aws.String(string(infrav1.ELBProtocolTCP)),
+		Port:     aws.String(strconv.FormatInt(port, 10)),
+	}
+	if spec == nil {
+		return healthCheck
+	}
+
+	if spec.Protocol != nil {
+		healthCheck.Protocol = spec.Protocol
+	}
+	if spec.Port != nil {
+		healthCheck.Port = aws.String(strconv.FormatInt(*spec.Port, 10))
+	}
+	healthCheck.Path = spec.Path
+	healthCheck.IntervalSeconds = spec.IntervalSeconds
+	healthCheck.TimeoutSeconds = spec.TimeoutSeconds
+	healthCheck.ThresholdCount = spec.ThresholdCount
+	healthCheck.UnhealthyThresholdCount = spec.ThresholdCount
+	if spec.UnhealthyThresholdCount != nil {
+		healthCheck.UnhealthyThresholdCount = spec.UnhealthyThresholdCount
+	}
+	return healthCheck
+}
+
+// getClassicELBHealthCheck returns the health check of the classic load balancer of the API server, which
+// checks the API server port over the health check protocol of the load balancer by default.
+func (s *Service) getClassicELBHealthCheck() *infrav1.ClassicELBHealthCheck {
+	protocol := string(*s.getHealthCheckELBProtocol())
+	port := int64(infrav1.DefaultAPIServerPort)
+	path := ""
+	healthCheck := &infrav1.ClassicELBHealthCheck{
+		Interval:           classicELBHealthCheckInterval,
+		Timeout:            classicELBHealthCheckTimeout,
+		HealthyThreshold:   classicELBHealthCheckHealthyThreshold,
+		UnhealthyThreshold: classicELBHealthCheckUnhealthyThreshold,
+	}
+
+	if spec := s.apiServerHealthCheck(); spec != nil {
+		if spec.Protocol != nil {
+			protocol = *spec.Protocol
+		}
+		if spec.Port != nil {
+			port = *spec.Port
+		}
+		path = aws.StringValue(spec.Path)
+		if spec.IntervalSeconds != nil {
+			healthCheck.Interval = time.Duration(*spec.IntervalSeconds) * time.Second
+		}
+		if spec.TimeoutSeconds != nil {
+			healthCheck.Timeout = time.Duration(*spec.TimeoutSeconds) * time.Second
+		}
+		if spec.ThresholdCount != nil {
+			healthCheck.HealthyThreshold = *spec.ThresholdCount
+			healthCheck.UnhealthyThreshold = *spec.ThresholdCount
+		}
+		if spec.UnhealthyThresholdCount != nil {
+			healthCheck.UnhealthyThreshold = *spec.UnhealthyThresholdCount
+		}
+	}
+
+	// Classic load balancers require a path for HTTP and HTTPS health checks.
+	if isHTTPHealthCheck(protocol) && path == "" {
+		path = apiServerHealthCheckPath
+	}
+	healthCheck.Target = fmt.Sprintf("%s:%d%s", protocol, port, path)
+	return healthCheck
+}
+
+// reconcileClassicELBHealthCheck updates the health check of the classic load balancer when it differs from
+// the spec.
+func (s *Service) reconcileClassicELBHealthCheck(lb, spec *infrav1.LoadBalancer) error {
+	if lb.HealthCheck == nil || spec.HealthCheck == nil || *lb.HealthCheck == *spec.HealthCheck {
+		return nil
+	}
+
+	if err := s.configureClassicELBHealthCheck(lb.Name, spec.HealthCheck); err != nil {
+		return err
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulConfigureHealthCheck", "Configured health check %q of load balancer %q", spec.HealthCheck.Target, lb.Name)
+	lb.HealthCheck = spec.HealthCheck.DeepCopy()
+	return nil
+}
+
+// configureClassicELBHealthCheck sets the health check of a classic load balancer.
+func (s *Service) configureClassicELBHealthCheck(name string, healthCheck *infrav1.ClassicELBHealthCheck) error {
+	if _, err := s.ELBClient.ConfigureHealthCheck(&elb.ConfigureHealthCheckInput{
+		LoadBalancerName: aws.String(name),
+		HealthCheck: &elb.HealthCheck{
+			Target:             aws.String(healthCheck.Target),
+			Interval:           aws.Int64(int64(healthCheck.Interval.Seconds())),
+			Timeout:            aws.Int64(int64(healthCheck.Timeout.Seconds())),
+			HealthyThreshold:   aws.Int64(healthCheck.HealthyThreshold),
+			UnhealthyThreshold: aws.Int64(healthCheck.UnhealthyThreshold),
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to configure health check of classic load balancer %q", name)
+	}
+	return nil
+}
+
+// fromSDKTypeToClassicELBHealthCheck converts the health check of a classic load balancer.
+func fromSDKTypeToClassicELBHealthCheck(v *elb.HealthCheck) *infrav1.ClassicELBHealthCheck {
+	if v == nil {
+		return nil
+	}
+	return &infrav1.ClassicELBHealthCheck{
+		Target:             aws.StringValue(v.Target),
+		Interval:           time.Duration(aws.Int64Value(v.Interval)) * time.Second,
+		Timeout:            time.Duration(aws.Int64Value(v.Timeout)) * time.Second,
+		HealthyThreshold:   aws.Int64Value(v.HealthyThreshold),
+		UnhealthyThreshold: aws.Int64Value(v.UnhealthyThreshold),
+	}
+}
+
+func isHTTPHealthCheck(protocol string) bool {
+	return protocol == string(infrav1.ELBProtocolHTTP) || protocol == string(infrav1.ELBProtocolHTTPS)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestReconcileClassicELBHealthCheck(t *testing.T) {
+	defaultHealthCheck := &infrav1.ClassicELBHealthCheck{
+		Target:             "SSL:6443",
+		Interval:           10 * time.Second,
+		Timeout:            5 * time.Second,
+		HealthyThreshold:   5,
+		UnhealthyThreshold: 3,
+	}
+
+	tests := []struct {
+		name        string
+		healthCheck *infrav1.TargetGroupHealthCheckSpec
+		existing    *infrav1.ClassicELBHealthCheck
+		expect      func(m *mocks.MockELBAPIMockRecorder)
+	}{
+		{
+			name: "should configure a health check differing from the spec",
+			healthCheck: &infrav1.TargetGroupHealthCheckSpec{
+				Protocol:        aws.String("HTTPS"),
+				Path:            aws.String("/livez"),
+				IntervalSeconds: aws.Int64(30),
+			},
+			existing: defaultHealthCheck,
+			expect: func(m *mocks.MockELBAPIMockRecorder) {
+				m.ConfigureHealthCheck(gomock.Eq(&elb.ConfigureHealthCheckInput{
+					LoadBalancerName: aws.String("bar-apiserver"),
+					HealthCheck: &elb.HealthCheck{
+						Target:             aws.String("HTTPS:6443/livez"),
+						Interval:           aws.Int64(30),
+						Timeout:            aws.Int64(5),
+						HealthyThreshold:   aws.Int64(5),
+						UnhealthyThreshold: aws.Int64(3),
+					},
+				})).Return(&elb.ConfigureHealthCheckOutput{}, nil)
+			},
+		},
+		{
+			name:     "should not configure a health check matching the spec",
+			existing: defaultHealthCheck,
+			expect:   func(m *mocks.MockELBAPIMockRecorder) {},
+		},
+		{
+			name:        "should not configure an unknown health check",
+			healthCheck: &infrav1.TargetGroupHealthCheckSpec{Protocol: aws.String("TCP")},
+			expect:      func(m *mocks.MockELBAPIMockRecorder) {},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbAPIMocks := mocks.NewMockELBAPI(mockCtrl)
+
+			clusterScope := listenersTestClusterScope(t, &infrav1.AWSLoadBalancerSpec{HealthCheck: tc.healthCheck})
+			tc.expect(elbAPIMocks.EXPECT())
+
+			s := &Service{
+				scope:     clusterScope,
+				ELBClient: elbAPIMocks,
+			}
+
+			spec, err := s.getAPIServerClassicELBSpec("bar-apiserver")
+			g.Expect(err).NotTo(HaveOccurred())
+			lb := &infrav1.LoadBalancer{Name: "bar-apiserver", HealthCheck: tc.existing}
+			g.Expect(s.reconcileClassicELBHealthCheck(lb, spec)).To(Succeed())
+			if tc.existing != nil {
+				g.Expect(lb.HealthCheck).To(Equal(spec.HealthCheck))
+			}
+		})
+	}
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// additionalListeners returns the additional listeners of the control plane load balancer, including the
+// listener forwarding the agent port of konnectivity.
+func (s *Service) additionalListeners() []infrav1.AdditionalListenerSpec {
+	if s.scope.ControlPlaneLoadBalancer() == nil {
+		return s.scope.Konnectivity().WithAgentListener(nil)
+	}
+	return s.scope.Konnectivity().WithAgentListener(s.scope.ControlPlaneLoadBalancer().AdditionalListeners)
+}
+
+// getAdditionalListenerSpec returns the listener forwarding the port of an additional listener to the same
+// port of the control plane instances. Target groups check the port of the listener over TCP by default.
+func (s *Service) getAdditionalListenerSpec(listener infrav1.AdditionalListenerSpec) infrav1.Listener {
+	protocol := listener.Protocol
+	if protocol == "" {
+		protocol = infrav1.ELBProtocolTCP
+	}
+
+	return infrav1.Listener{
+		Protocol: protocol,
+		Port:     listener.Port,
+		TargetGroup: infrav1.TargetGroupSpec{
+			Name:        fmt.Sprintf("additional-%d-%d", listener.Port, time.Now().Unix()),
+			Port:        listener.Port,
+			Protocol:    protocol,
+			VpcID:       s.scope.VPC().ID,
+			HealthCheck: targetGroupHealthCheck(listener.Port, listener.HealthCheck),
+		},
+	}
+}
+
+// createListener creates a listener of a load balancer, along with the target group it forwards to.
+func (s *Service) createListener(lbARN *string, ln infrav1.Listener, tags map[string]string) (*elbv2.TargetGroup, error) {
+	// create the target group first
+	targetGroupInput := &elbv2.CreateTargetGroupInput{
+		Name:     aws.String(ln.TargetGroup.Name),
+		Port:     aws.Int64(ln.TargetGroup.Port),
+		Protocol: aws.String(ln.TargetGroup.Protocol.String()),
+		VpcId:    aws.String(ln.TargetGroup.VpcID),
+	}
+	if s.scope.VPC().IsIPv6Enabled() {
+		targetGroupInput.IpAddressType = aws.String("ipv6")
+	}
+	if hc := ln.TargetGroup.HealthCheck; hc != nil {
+		targetGroupInput.HealthCheckEnabled = aws.Bool(true)
+		targetGroupInput.HealthCheckProtocol = hc.Protocol
+		targetGroupInput.HealthCheckPort = hc.Port
+		targetGroupInput.HealthCheckPath = hc.Path
+		targetGroupInput.HealthCheckIntervalSeconds = hc.IntervalSeconds
+		targetGroupInput.HealthCheckTimeoutSeconds = hc.TimeoutSeconds
+		targetGroupInput.HealthyThresholdCount = hc.ThresholdCount
+		targetGroupInput.UnhealthyThresholdCount = hc.UnhealthyThresholdCount
+	}
+	s.scope.Debug("creating target group", "group", targetGroupInput, "listener", ln)
+	group, err := s.ELBV2Client.CreateTargetGroup(targetGroupInput)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create target group for load balancer")
+	}
+	if len(group.TargetGroups) == 0 {
+		return nil, errors.New("no target group was created; the returned list is empty")
+	}
+
+	attributes := []*elbv2.TargetGroupAttribute{}
+	if !s.scope.ControlPlaneLoadBalancer().PreserveClientIP {
+		attributes = append(attributes, &elbv2.TargetGroupAttribute{
+			Key:   aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP),
+			Value: aws.String("false"),
+		})
+	}
+	if timeout := s.scope.ControlPlaneLoadBalancer().ConnectionDrainingTimeout; timeout != nil {
+		attributes = append(attributes, &elbv2.TargetGroupAttribute{
+			Key:   aws.String(infrav1.TargetGroupAttributeDeregistrationDelayTimeoutSeconds),
+			Value: aws.String(fmt.Sprintf("%d", int64(timeout.Seconds()))),
+		})
+	}
+	if len(attributes) > 0 {
+		targetGroupAttributeInput := &elbv2.ModifyTargetGroupAttributesInput{
+			TargetGroupArn: group.TargetGroups[0].TargetGroupArn,
+			Attributes:     attributes,
+		}
+		if _, err := s.ELBV2Client.ModifyTargetGroupAttributes(targetGroupAttributeInput); err != nil {
+			return nil, errors.Wrapf(err, "failed to modify target group attribute")
+		}
+	}
+
+	listenerInput := &elbv2.CreateListenerInput{
+		DefaultActions: []*elbv2.Action{
+			{
+				TargetGroupArn: group.TargetGroups[0].TargetGroupArn,
+				Type:           aws.String(elbv2.ActionTypeEnumForward),
+			},
+		},
+		LoadBalancerArn: lbARN,
+		Port:            aws.Int64(ln.Port),
+		Protocol:        aws.String(string(ln.Protocol)),
+		Tags:            converters.MapToV2Tags(tags),
+	}
+	if ln.Protocol == infrav1.ELBProtocolTLS {
+		if policy := s.scope.SecurityProfile().NLBTLSPolicy(); policy != "" {
+			listenerInput.SslPolicy = aws.String(policy)
+		}
+	}
+	// Create ClassicELBListeners
+	listener, err := s.ELBV2Client.CreateListener(listenerInput)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create listener")
+	}
+	if len(listener.Listeners) == 0 {
+		return nil, errors.New("no listener was created; the returned list is empty")
+	}
+
+	return group.TargetGroups[0], nil
+}
+
+// reconcileTLSPolicy sets the security policy required by the security profile of the cluster on an existing
+// TLS listener, so that setting the profile on an existing cluster also applies to its listeners.
+func (s *Service) reconcileTLSPolicy(listener *elbv2.Listener) error {
+	policy := s.scope.SecurityProfile().NLBTLSPolicy()
+	if policy == "" || aws.StringValue(listener.Protocol) != string(infrav1.ELBProtocolTLS) || aws.StringValue(listener.SslPolicy) == policy {
+		return nil
+	}
+
+	if _, err := s.ELBV2Client.ModifyListener(&elbv2.ModifyListenerInput{
+		ListenerArn: listener.ListenerArn,
+		SslPolicy:   aws.String(policy),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to set the TLS policy of listener on port %d", aws.Int64Value(listener.Port))
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulModifyListener", "Set TLS policy %q on listener on port %d", policy, aws.Int64Value(listener.Port))
+	return nil
+}
+
+// reconcilePreserveClientIP enables or disables the preservation of client IPs by a target group of a network
+// load balancer, so that changing it in the spec doesn't require replacing the target group.
+func (s *Service) reconcilePreserveClientIP(tg *elbv2.TargetGroup) error {
+	out, err := s.ELBV2Client.DescribeTargetGroupAttributes(&elbv2.DescribeTargetGroupAttributesInput{TargetGroupArn: tg.TargetGroupArn})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe the attributes of target group %q", aws.StringValue(tg.TargetGroupName))
+	}
+
+	desired := fmt.Sprintf("%t", s.scope.ControlPlaneLoadBalancer().PreserveClientIP)
+	for _, attr := range out.Attributes {
+		if aws.StringValue(attr.Key) == infrav1.TargetGroupAttributeEnablePreserveClientIP && aws.StringValue(attr.Value) == desired {
+			return nil
+		}
+	}
+
+	if _, err := s.ELBV2Client.ModifyTargetGroupAttributes(&elbv2.ModifyTargetGroupAttributesInput{
+		TargetGroupArn: tg.TargetGroupArn,
+		Attributes: []*elbv2.TargetGroupAttribute{{
+			Key:   aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP),
+			Value: aws.String(desired),
+		}},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to update the preservation of client IPs of target group %q", aws.StringValue(tg.TargetGroupName))
+	}
+	return nil
+}
+
+// reconcileV2LBListeners creates the listeners of the spec missing from the load balancer, updates the health
+// checks of the target groups of the existing ones, and deletes the listeners no longer in the spec along with
+// their target groups. The instances registered with the API server target group are registered with the
+// target groups of new listeners. The listeners of the load balancer are set on lb.
+func (s *Service) reconcileV2LBListeners(lb, spec *infrav1.LoadBalancer) error {
+	listeners, err := s.ELBV2Client.DescribeListeners(&elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(lb.ARN)})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe the listeners of load balancer %q", lb.Name)
+	}
+	targetGroups, err := s.ELBV2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(lb.ARN)})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe the target groups of load balancer %q", lb.Name)
+	}
+	targetGroupsByARN := make(map[string]*elbv2.TargetGroup, len(targetGroups.TargetGroups))
+	for _, tg := range targetGroups.TargetGroups {
+		targetGroupsByARN[aws.StringValue(tg.TargetGroupArn)] = tg
+	}
+	existing := make(map[int64]*elbv2.Listener, len(listeners.Listeners))
+	for _, listener := range listeners.Listeners {
+		existing[aws.Int64Value(listener.Port)] = listener
+	}
+
+	desired := make(map[int64]struct{}, len(spec.ELBListeners))
+	res := make([]infrav1.Listener, 0, len(spec.ELBListeners))
+	for _, ln := range spec.ELBListeners {
+		desired[ln.Port] = struct{}{}
+
+		listener, ok := existing[ln.Port]
+		if !ok {
+			tg, err := s.createListener(aws.String(lb.ARN), ln, spec.Tags)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create listener on port %d of load balancer %q", ln.Port, lb.Name)
+			}
+			record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateListener", "Created listener on port %d of load balancer %q", ln.Port, lb.Name)
+			if apiServerListener, ok := existing[infrav1.DefaultAPIServerPort]; ok {
+				if err := s.registerTargetsOf(ForwardTargetGroupARN(apiServerListener), tg); err != nil {
+					return err
+				}
+			}
+			res = append(res, ln)
+			continue
+		}
+
+		if err := s.reconcileTLSPolicy(listener); err != nil {
+			return err
+		}
+
+		tg, ok := targetGroupsByARN[ForwardTargetGroupARN(listener)]
+		if !ok {
+			res = append(res, infrav1.Listener{Protocol: infrav1.ELBProtocol(aws.StringValue(listener.Protocol)), Port: ln.Port})
+			continue
+		}
+		if hc := ln.TargetGroup.HealthCheck; hc != nil && targetGroupHealthCheckNeedsUpdate(tg, hc) {
+			if _, err := s.ELBV2Client.ModifyTargetGroup(&elbv2.ModifyTargetGroupInput{
+				TargetGroupArn:             tg.TargetGroupArn,
+				HealthCheckEnabled:         aws.Bool(true),
+				HealthCheckProtocol:        hc.Protocol,
+				HealthCheckPort:            hc.Port,
+				HealthCheckPath:            hc.Path,
+				HealthCheckIntervalSeconds: hc.IntervalSeconds,
+				HealthCheckTimeoutSeconds:  hc.TimeoutSeconds,
+				HealthyThresholdCount:      hc.ThresholdCount,
+				UnhealthyThresholdCount:    hc.UnhealthyThresholdCount,
+			}); err != nil {
+				return errors.Wrapf(err, "failed to update the health check of target group %q", aws.StringValue(tg.TargetGroupName))
+			}
+		}
+		if s.scope.ControlPlaneLoadBalancer().LoadBalancerType == infrav1.LoadBalancerTypeNLB {
+			if err := s.reconcilePreserveClientIP(tg); err != nil {
+				return err
+			}
+		}
+		res = append(res, infrav1.Listener{
+			Protocol: infrav1.ELBProtocol(aws.StringValue(listener.Protocol)),
+			Port:     aws.Int64Value(listener.Port),
+			TargetGroup: infrav1.TargetGroupSpec{
+				Name:        aws.StringValue(tg.TargetGroupName),
+				Port:        aws.Int64Value(tg.Port),
+				Protocol:    infrav1.ELBProtocol(aws.StringValue(tg.Protocol)),
+				VpcID:       aws.StringValue(tg.VpcId),
+				HealthCheck: ln.TargetGroup.HealthCheck,
+			},
+		})
+	}
+
+	stale := make([]int64, 0, len(existing))
+	for port := range existing {
+		if _, ok := desired[port]; !ok {
+			stale = append(stale, port)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i] < stale[j] })
+	for _, port := range stale {
+		listener := existing[port]
+		if _, err := s.ELBV2Client.DeleteListener(&elbv2.DeleteListenerInput{ListenerArn: listener.ListenerArn}); err != nil {
+			return errors.Wrapf(err, "failed to delete listener on port %d of load balancer %q", port, lb.Name)
+		}
+		if arn := ForwardTargetGroupARN(listener); arn != "" {
+			if _, err := s.ELBV2Client.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(arn)}); err != nil {
+				return errors.Wrapf(err, "failed to delete the target group of listener on port %d of load balancer %q", port, lb.Name)
+			}
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteListener", "Deleted listener on port %d of load balancer %q", port, lb.Name)
+	}
+
+	lb.ELBListeners = res
+	return nil
+}
+
+// registerTargetsOf registers the targets of a target group with another target group, on the port of the latter.
+func (s *Service) registerTargetsOf(sourceARN string, tg *elbv2.TargetGroup) error {
+	if sourceARN == "" {
+		return nil
+	}
+	health, err := s.ELBV2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(sourceARN)})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe the targets of target group %q", sourceARN)
+	}
+	if len(health.TargetHealthDescriptions) == 0 {
+		return nil
+	}
+
+	targets := make([]*elbv2.TargetDescription, 0, len(health.TargetHealthDescriptions))
+	for _, description := range health.TargetHealthDescriptions {
+		targets = append(targets, &elbv2.TargetDescription{Id: description.Target.Id, Port: tg.Port})
+	}
+	if _, err := s.ELBV2Client.RegisterTargets(&elbv2.RegisterTargetsInput{TargetGroupArn: tg.TargetGroupArn, Targets: targets}); err != nil {
+		return errors.Wrapf(err, "failed to register targets with target group %q", aws.StringValue(tg.TargetGroupName))
+	}
+	return nil
+}
+
+// ForwardTargetGroupARN returns the ARN of the target group a listener forwards to by default.
+func ForwardTargetGroupARN(listener *elbv2.Listener) string {
+	for _, action := range listener.DefaultActions {
+		if aws.StringValue(action.Type) == elbv2.ActionTypeEnumForward && action.TargetGroupArn != nil {
+			return aws.StringValue(action.TargetGroupArn)
+		}
+	}
+	return ""
+}
+
+// targetGroupHealthCheckNeedsUpdate returns true if any setting of the desired health check differs from the
+// health check of the target group. Settings which aren't set are left to their current value.
+func targetGroupHealthCheckNeedsUpdate(tg *elbv2.TargetGroup, hc *infrav1.TargetGroupHealthCheck) bool {
+	return (hc.Protocol != nil && *hc.Protocol != aws.StringValue(tg.HealthCheckProtocol)) ||
+		(hc.Port != nil && *hc.Port != aws.StringValue(tg.HealthCheckPort)) ||
+		(hc.Path != nil && *hc.Path != aws.StringValue(tg.HealthCheckPath)) ||
+		(hc.IntervalSeconds != nil && *hc.IntervalSeconds != aws.Int64Value(tg.HealthCheckIntervalSeconds)) ||
+		(hc.TimeoutSeconds != nil && *hc.TimeoutSeconds != aws.Int64Value(tg.HealthCheckTimeoutSeconds)) ||
+		(hc.ThresholdCount != nil && *hc.ThresholdCount != aws.Int64Value(tg.HealthyThresholdCount)) ||
+		(hc.UnhealthyThresholdCount != nil && *hc.UnhealthyThresholdCount != aws.Int64Value(tg.UnhealthyThresholdCount))
+}
+
+// reconcileClassicELBListeners creates the listeners of the spec missing from the classic load balancer and
+// deletes the listeners no longer in the spec.
+func (s *Service) reconcileClassicELBListeners(lb, spec *infrav1.LoadBalancer) error {
+	existing := make(map[int64]struct{}, len(lb.ClassicELBListeners))
+	for _, ln := range lb.ClassicELBListeners {
+		existing[ln.Port] = struct{}{}
+	}
+	desired := make(map[int64]struct{}, len(spec.ClassicELBListeners))
+	var missing []*elb.Listener
+	for _, ln := range spec.ClassicELBListeners {
+		desired[ln.Port] = struct{}{}
+		if _, ok := existing[ln.Port]; ok {
+			continue
+		}
+		missing = append(missing, &elb.Listener{
+			Protocol:         aws.String(string(ln.Protocol)),
+			LoadBalancerPort: aws.Int64(ln.Port),
+			InstanceProtocol: aws.String(string(ln.InstanceProtocol)),
+			InstancePort:     aws.Int64(ln.InstancePort),
+		})
+	}
+	var stale []*int64
+	for _, ln := range lb.ClassicELBListeners {
+		if _, ok := desired[ln.Port]; !ok {
+			stale = append(stale, aws.Int64(ln.Port))
+		}
+	}
+
+	if len(stale) > 0 {
+		if _, err := s.ELBClient.DeleteLoadBalancerListeners(&elb.DeleteLoadBalancerListenersInput{
+			LoadBalancerName:  aws.String(lb.Name),
+			LoadBalancerPorts: stale,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to delete listeners of classic load balancer %q", lb.Name)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteListener", "Deleted listeners on ports %v of load balancer %q", aws.Int64ValueSlice(stale), lb.Name)
+	}
+	if len(missing) > 0 {
+		if _, err := s.ELBClient.CreateLoadBalancerListeners(&elb.CreateLoadBalancerListenersInput{
+			LoadBalancerName: aws.String(lb.Name),
+			Listeners:        missing,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to create listeners of classic load balancer %q", lb.Name)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateListener", "Created %d listeners of load balancer %q", len(missing), lb.Name)
+	}
+
+	lb.ClassicELBListeners = spec.ClassicELBListeners
+	return nil
+}
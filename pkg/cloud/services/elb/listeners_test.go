@@ -0,0 +1,343 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	testLBARN           = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/bar-apiserver/50dc6c495c0c9188"
+	testAPIServerTGARN  = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/apiserver-target/73e2d6bc24d8a067"
+	testAdditionalTGARN = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/additional-8132/83e2d6bc24d8a067"
+)
+
+func testListener(port int64, targetGroupARN string) *elbv2.Listener {
+	return &elbv2.Listener{
+		ListenerArn: aws.String("listener-" + targetGroupARN),
+		Port:        aws.Int64(port),
+		Protocol:    aws.String("TCP"),
+		DefaultActions: []*elbv2.Action{{
+			Type:           aws.String(elbv2.ActionTypeEnumForward),
+			TargetGroupArn: aws.String(targetGroupARN),
+		}},
+	}
+}
+
+func testTargetGroup(name string, port int64, targetGroupARN string) *elbv2.TargetGroup {
+	return &elbv2.TargetGroup{
+		TargetGroupName:     aws.String(name),
+		TargetGroupArn:      aws.String(targetGroupARN),
+		Port:                aws.Int64(port),
+		Protocol:            aws.String("TCP"),
+		VpcId:               aws.String("vpc-1"),
+		HealthCheckProtocol: aws.String("TCP"),
+		HealthCheckPort:     aws.String(strconv.FormatInt(port, 10)),
+	}
+}
+
+func TestReconcileV2LBListeners(t *testing.T) {
+	tests := []struct {
+		name             string
+		listeners        []infrav1.AdditionalListenerSpec
+		preserveClientIP bool
+		securityProfile  infrav1.SecurityProfile
+		existing         []*elbv2.Listener
+		targetGroups     []*elbv2.TargetGroup
+		expect           func(m *mocks.MockELBV2APIMockRecorder)
+		wantListeners    []int64
+	}{
+		{
+			name:      "should create missing listeners and register the control plane instances",
+			listeners: []infrav1.AdditionalListenerSpec{{Port: 8132}},
+			existing:  []*elbv2.Listener{testListener(6443, testAPIServerTGARN)},
+			targetGroups: []*elbv2.TargetGroup{
+				testTargetGroup("apiserver-target", 6443, testAPIServerTGARN),
+			},
+			expect: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.CreateTargetGroup(gomock.Any()).DoAndReturn(func(input *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
+					g := NewWithT(t)
+					g.Expect(input.Port).To(Equal(aws.Int64(8132)))
+					g.Expect(input.HealthCheckPort).To(Equal(aws.String("8132")))
+					return &elbv2.CreateTargetGroupOutput{TargetGroups: []*elbv2.TargetGroup{testTargetGroup(*input.Name, 8132, testAdditionalTGARN)}}, nil
+				})
+				m.ModifyTargetGroupAttributes(gomock.Any()).Return(&elbv2.ModifyTargetGroupAttributesOutput{}, nil)
+				m.CreateListener(gomock.Any()).DoAndReturn(func(input *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
+					g := NewWithT(t)
+					g.Expect(input.Port).To(Equal(aws.Int64(8132)))
+					g.Expect(input.DefaultActions[0].TargetGroupArn).To(Equal(aws.String(testAdditionalTGARN)))
+					return &elbv2.CreateListenerOutput{Listeners: []*elbv2.Listener{testListener(8132, testAdditionalTGARN)}}, nil
+				})
+				m.DescribeTargetHealth(gomock.Eq(&elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(testAPIServerTGARN)})).
+					Return(&elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+						{Target: &elbv2.TargetDescription{Id: aws.String("i-1"), Port: aws.Int64(6443)}},
+					}}, nil)
+				m.RegisterTargets(gomock.Eq(&elbv2.RegisterTargetsInput{
+					TargetGroupArn: aws.String(testAdditionalTGARN),
+					Targets:        []*elbv2.TargetDescription{{Id: aws.String("i-1"), Port: aws.Int64(8132)}},
+				})).Return(&elbv2.RegisterTargetsOutput{}, nil)
+			},
+			wantListeners: []int64{6443, 8132},
+		},
+		{
+			name: "should delete listeners no longer in the spec with their target group",
+			existing: []*elbv2.Listener{
+				testListener(6443, testAPIServerTGARN),
+				testListener(8132, testAdditionalTGARN),
+			},
+			targetGroups: []*elbv2.TargetGroup{
+				testTargetGroup("apiserver-target", 6443, testAPIServerTGARN),
+				testTargetGroup("additional-8132", 8132, testAdditionalTGARN),
+			},
+			expect: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DeleteListener(gomock.Eq(&elbv2.DeleteListenerInput{ListenerArn: aws.String("listener-" + testAdditionalTGARN)})).
+					Return(&elbv2.DeleteListenerOutput{}, nil)
+				m.DeleteTargetGroup(gomock.Eq(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(testAdditionalTGARN)})).
+					Return(&elbv2.DeleteTargetGroupOutput{}, nil)
+			},
+			wantListeners: []int64{6443},
+		},
+		{
+			name: "should update the health check of existing listeners",
+			listeners: []infrav1.AdditionalListenerSpec{{
+				Port:        8132,
+				HealthCheck: &infrav1.TargetGroupHealthCheckSpec{IntervalSeconds: aws.Int64(10)},
+			}},
+			existing: []*elbv2.Listener{
+				testListener(6443, testAPIServerTGARN),
+				testListener(8132, testAdditionalTGARN),
+			},
+			targetGroups: []*elbv2.TargetGroup{
+				testTargetGroup("apiserver-target", 6443, testAPIServerTGARN),
+				func() *elbv2.TargetGroup {
+					tg := testTargetGroup("additional-8132", 8132, testAdditionalTGARN)
+					tg.HealthCheckIntervalSeconds = aws.Int64(30)
+					return tg
+				}(),
+			},
+			expect: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.ModifyTargetGroup(gomock.Eq(&elbv2.ModifyTargetGroupInput{
+					TargetGroupArn:             aws.String(testAdditionalTGARN),
+					HealthCheckEnabled:         aws.Bool(true),
+					HealthCheckProtocol:        aws.String("TCP"),
+					HealthCheckPort:            aws.String("8132"),
+					HealthCheckIntervalSeconds: aws.Int64(10),
+				})).Return(&elbv2.ModifyTargetGroupOutput{}, nil)
+			},
+			wantListeners: []int64{6443, 8132},
+		},
+		{
+			name:             "should enable the preservation of client IPs of existing target groups",
+			preserveClientIP: true,
+			existing:         []*elbv2.Listener{testListener(6443, testAPIServerTGARN)},
+			targetGroups: []*elbv2.TargetGroup{
+				testTargetGroup("apiserver-target", 6443, testAPIServerTGARN),
+			},
+			expect: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.ModifyTargetGroupAttributes(gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
+					TargetGroupArn: aws.String(testAPIServerTGARN),
+					Attributes: []*elbv2.TargetGroupAttribute{{
+						Key:   aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP),
+						Value: aws.String("true"),
+					}},
+				})).Return(&elbv2.ModifyTargetGroupAttributesOutput{}, nil)
+			},
+			wantListeners: []int64{6443},
+		},
+		{
+			name:            "should set the TLS policy of the security profile on existing TLS listeners",
+			listeners:       []infrav1.AdditionalListenerSpec{{Port: 8132, Protocol: infrav1.ELBProtocolTLS}},
+			securityProfile: infrav1.SecurityProfileBaseline,
+			existing: []*elbv2.Listener{
+				testListener(6443, testAPIServerTGARN),
+				func() *elbv2.Listener {
+					ln := testListener(8132, testAdditionalTGARN)
+					ln.Protocol = aws.String("TLS")
+					ln.SslPolicy = aws.String("ELBSecurityPolicy-2016-08")
+					return ln
+				}(),
+			},
+			targetGroups: []*elbv2.TargetGroup{
+				testTargetGroup("apiserver-target", 6443, testAPIServerTGARN),
+				testTargetGroup("additional-8132", 8132, testAdditionalTGARN),
+			},
+			expect: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.ModifyListener(gomock.Eq(&elbv2.ModifyListenerInput{
+					ListenerArn: aws.String("listener-" + testAdditionalTGARN),
+					SslPolicy:   aws.String(infrav1.SecurityProfileBaseline.NLBTLSPolicy()),
+				})).Return(&elbv2.ModifyListenerOutput{}, nil)
+			},
+			wantListeners: []int64{6443, 8132},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+
+			clusterScope := listenersTestClusterScope(t, &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType:    infrav1.LoadBalancerTypeNLB,
+				AdditionalListeners: tc.listeners,
+				PreserveClientIP:    tc.preserveClientIP,
+			})
+			clusterScope.AWSCluster.Spec.SecurityProfile = tc.securityProfile
+
+			elbV2APIMocks.EXPECT().DescribeListeners(gomock.Eq(&elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(testLBARN)})).
+				Return(&elbv2.DescribeListenersOutput{Listeners: tc.existing}, nil)
+			elbV2APIMocks.EXPECT().DescribeTargetGroups(gomock.Eq(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(testLBARN)})).
+				Return(&elbv2.DescribeTargetGroupsOutput{TargetGroups: tc.targetGroups}, nil)
+			elbV2APIMocks.EXPECT().DescribeTargetGroupAttributes(gomock.Any()).
+				Return(&elbv2.DescribeTargetGroupAttributesOutput{Attributes: []*elbv2.TargetGroupAttribute{{
+					Key:   aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP),
+					Value: aws.String("false"),
+				}}}, nil).AnyTimes()
+			tc.expect(elbV2APIMocks.EXPECT())
+
+			s := &Service{
+				scope:       clusterScope,
+				ELBV2Client: elbV2APIMocks,
+			}
+
+			spec, err := s.getAPIServerLBSpec("bar-apiserver")
+			g.Expect(err).NotTo(HaveOccurred())
+			lb := &infrav1.LoadBalancer{Name: "bar-apiserver", ARN: testLBARN}
+			g.Expect(s.reconcileV2LBListeners(lb, spec)).To(Succeed())
+
+			ports := make([]int64, 0, len(lb.ELBListeners))
+			for _, ln := range lb.ELBListeners {
+				ports = append(ports, ln.Port)
+			}
+			g.Expect(ports).To(Equal(tc.wantListeners))
+		})
+	}
+}
+
+func TestReconcileClassicELBListeners(t *testing.T) {
+	tests := []struct {
+		name      string
+		listeners []infrav1.AdditionalListenerSpec
+		existing  []infrav1.ClassicELBListener
+		expect    func(m *mocks.MockELBAPIMockRecorder)
+	}{
+		{
+			name:      "should create missing listeners",
+			listeners: []infrav1.AdditionalListenerSpec{{Port: 22623}},
+			existing: []infrav1.ClassicELBListener{
+				{Protocol: infrav1.ELBProtocolTCP, Port: 6443, InstanceProtocol: infrav1.ELBProtocolTCP, InstancePort: 6443},
+			},
+			expect: func(m *mocks.MockELBAPIMockRecorder) {
+				m.CreateLoadBalancerListeners(gomock.Eq(&elb.CreateLoadBalancerListenersInput{
+					LoadBalancerName: aws.String("bar-apiserver"),
+					Listeners: []*elb.Listener{{
+						Protocol:         aws.String("TCP"),
+						LoadBalancerPort: aws.Int64(22623),
+						InstanceProtocol: aws.String("TCP"),
+						InstancePort:     aws.Int64(22623),
+					}},
+				})).Return(&elb.CreateLoadBalancerListenersOutput{}, nil)
+			},
+		},
+		{
+			name: "should delete listeners no longer in the spec",
+			existing: []infrav1.ClassicELBListener{
+				{Protocol: infrav1.ELBProtocolTCP, Port: 6443, InstanceProtocol: infrav1.ELBProtocolTCP, InstancePort: 6443},
+				{Protocol: infrav1.ELBProtocolTCP, Port: 22623, InstanceProtocol: infrav1.ELBProtocolTCP, InstancePort: 22623},
+			},
+			expect: func(m *mocks.MockELBAPIMockRecorder) {
+				m.DeleteLoadBalancerListeners(gomock.Eq(&elb.DeleteLoadBalancerListenersInput{
+					LoadBalancerName:  aws.String("bar-apiserver"),
+					LoadBalancerPorts: aws.Int64Slice([]int64{22623}),
+				})).Return(&elb.DeleteLoadBalancerListenersOutput{}, nil)
+			},
+		},
+		{
+			name:      "should not change listeners in the spec",
+			listeners: []infrav1.AdditionalListenerSpec{{Port: 22623}},
+			existing: []infrav1.ClassicELBListener{
+				{Protocol: infrav1.ELBProtocolTCP, Port: 6443, InstanceProtocol: infrav1.ELBProtocolTCP, InstancePort: 6443},
+				{Protocol: infrav1.ELBProtocolTCP, Port: 22623, InstanceProtocol: infrav1.ELBProtocolTCP, InstancePort: 22623},
+			},
+			expect: func(m *mocks.MockELBAPIMockRecorder) {},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbAPIMocks := mocks.NewMockELBAPI(mockCtrl)
+
+			clusterScope := listenersTestClusterScope(t, &infrav1.AWSLoadBalancerSpec{AdditionalListeners: tc.listeners})
+			tc.expect(elbAPIMocks.EXPECT())
+
+			s := &Service{
+				scope:     clusterScope,
+				ELBClient: elbAPIMocks,
+			}
+
+			spec, err := s.getAPIServerClassicELBSpec("bar-apiserver")
+			g.Expect(err).NotTo(HaveOccurred())
+			lb := &infrav1.LoadBalancer{Name: "bar-apiserver", ClassicELBListeners: tc.existing}
+			g.Expect(s.reconcileClassicELBListeners(lb, spec)).To(Succeed())
+			g.Expect(lb.ClassicELBListeners).To(Equal(spec.ClassicELBListeners))
+		})
+	}
+}
+
+func listenersTestClusterScope(t *testing.T, lb *infrav1.AWSLoadBalancerSpec) *scope.ClusterScope {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+			Spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: lb,
+				NetworkSpec:              infrav1.NetworkSpec{VPC: infrav1.VPCSpec{ID: "vpc-1"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return clusterScope
+}
@@ -23,7 +23,6 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elbv2"
@@ -74,6 +73,15 @@ func (s *Service) reconcileV2LB() error {
 		return errors.Wrap(err, "failed to get control plane load balancer name")
 	}
 
+	// A load balancer of another scheme is replaced by one of the scheme of the spec.
+	var previous *infrav1.LoadBalancer
+	if s.scope.ControlPlaneLoadBalancer().IsReplaceable() {
+		name, previous, err = s.replaceableLBName(name)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Get default api server spec.
 	spec, err := s.getAPIServerLBSpec(name)
 	if err != nil {
@@ -81,7 +89,7 @@ func (s *Service) reconcileV2LB() error {
 	}
 	lb, err := s.describeLB(name)
 	switch {
-	case IsNotFound(err) && s.scope.ControlPlaneEndpoint().IsValid():
+	case IsNotFound(err) && s.scope.ControlPlaneEndpoint().IsValid() && previous == nil:
 		// if elb is not found and owner cluster ControlPlaneEndpoint is already populated, then we should not recreate the elb.
 		return errors.Wrapf(err, "no loadbalancer exists for the AWSCluster %s, the cluster has become unrecoverable and should be deleted manually", s.scope.InfraClusterName())
 	case IsNotFound(err):
@@ -95,6 +103,10 @@ func (s *Service) reconcileV2LB() error {
 	case err != nil:
 		// Failed to describe the classic ELB
 		return err
+	default:
+		if err := s.checkLBOwnership(lb); err != nil {
+			return err
+		}
 	}
 
 	// set up the type for later processing
@@ -110,6 +122,10 @@ func (s *Service) reconcileV2LB() error {
 			return errors.Wrapf(err, "failed to reconcile tags for apiserver load balancer %q", lb.Name)
 		}
 
+		if err := s.reconcileV2LBListeners(lb, spec); err != nil {
+			return errors.Wrapf(err, "failed to reconcile listeners for apiserver load balancer %q", lb.Name)
+		}
+
 		// Reconcile the subnets and availability zones from the spec
 		// and the ones currently attached to the load balancer.
 		if len(lb.SubnetIDs) != len(spec.SubnetIDs) {
@@ -135,10 +151,20 @@ func (s *Service) reconcileV2LB() error {
 				return errors.Wrapf(err, "failed to apply security groups to load balancer %q", lb.Name)
 			}
 		}
+
+		if s.scope.ControlPlaneLoadBalancer().LoadBalancerType == infrav1.LoadBalancerTypeALB {
+			if err := s.reconcileLBProtection(lb); err != nil {
+				return errors.Wrapf(err, "failed to reconcile protection of apiserver load balancer %q", lb.Name)
+			}
+		}
 	} else {
 		s.scope.Trace("Unmanaged control plane load balancer, skipping load balancer configuration", "api-server-elb", lb)
 	}
 	lb.DeepCopyInto(&s.scope.Network().APIServerELB)
+
+	if s.scope.ControlPlaneLoadBalancer().Route53 != nil {
+		return s.reconcileLBReplacement(lb, previous)
+	}
 	return nil
 }
 
@@ -159,22 +185,25 @@ func (s *Service) getAPIServerLBSpec(elbName string) (*infrav1.LoadBalancer, err
 				Protocol: infrav1.ELBProtocolTCP,
 				Port:     infrav1.DefaultAPIServerPort,
 				TargetGroup: infrav1.TargetGroupSpec{
-					Name:     fmt.Sprintf("apiserver-target-%d", time.Now().Unix()),
-					Port:     infrav1.DefaultAPIServerPort,
-					Protocol: infrav1.ELBProtocolTCP,
-					VpcID:    s.scope.VPC().ID,
-					HealthCheck: &infrav1.TargetGroupHealthCheck{
-						Protocol: aws.String(string(infrav1.ELBProtocolTCP)),
-						Port:     aws.String(infrav1.DefaultAPIServerPortString),
-					},
+					Name:        fmt.Sprintf("apiserver-target-%d", time.Now().Unix()),
+					Port:        infrav1.DefaultAPIServerPort,
+					Protocol:    infrav1.ELBProtocolTCP,
+					VpcID:       s.scope.VPC().ID,
+					HealthCheck: s.getAPIServerTargetGroupHealthCheck(),
 				},
 			},
 		},
 		SecurityGroupIDs: securityGroupIDs,
 	}
+	for _, listener := range s.additionalListeners() {
+		res.ELBListeners = append(res.ELBListeners, s.getAdditionalListenerSpec(listener))
+	}
 
 	if s.scope.ControlPlaneLoadBalancer() != nil && s.scope.ControlPlaneLoadBalancer().LoadBalancerType != infrav1.LoadBalancerTypeNLB {
 		res.ELBAttributes[infrav1.LoadBalancerAttributeIdleTimeTimeoutSeconds] = aws.String(infrav1.LoadBalancerAttributeIdleTimeDefaultTimeoutSecondsInSeconds)
+		if idleTimeout := s.scope.ControlPlaneLoadBalancer().IdleTimeout; idleTimeout != nil {
+			res.ELBAttributes[infrav1.LoadBalancerAttributeIdleTimeTimeoutSeconds] = aws.String(fmt.Sprintf("%d", int64(idleTimeout.Seconds())))
+		}
 	}
 
 	if s.scope.ControlPlaneLoadBalancer() != nil {
@@ -193,16 +222,13 @@ func (s *Service) getAPIServerLBSpec(elbName string) (*infrav1.LoadBalancer, err
 	if s.scope.ControlPlaneLoadBalancer() != nil && len(s.scope.ControlPlaneLoadBalancer().Subnets) > 0 {
 		// This set of subnets may not match the subnets specified on the Cluster, so we may not have already discovered them
 		// We need to call out to AWS to describe them just in case
-		input := &ec2.DescribeSubnetsInput{
-			SubnetIds: aws.StringSlice(s.scope.ControlPlaneLoadBalancer().Subnets),
-		}
-		out, err := s.EC2Client.DescribeSubnets(input)
+		subnets, err := s.getControlPlaneLoadBalancerSubnets()
 		if err != nil {
 			return nil, err
 		}
-		for _, sn := range out.Subnets {
-			res.AvailabilityZones = append(res.AvailabilityZones, *sn.AvailabilityZone)
-			res.SubnetIDs = append(res.SubnetIDs, *sn.SubnetId)
+		for _, sn := range subnets {
+			res.AvailabilityZones = append(res.AvailabilityZones, sn.AvailabilityZone)
+			res.SubnetIDs = append(res.SubnetIDs, sn.ID)
 		}
 	} else {
 		// The load balancer APIs require us to only attach one subnet for each AZ.
@@ -266,64 +292,8 @@ func (s *Service) createLB(spec *infrav1.LoadBalancer) (*infrav1.LoadBalancer, e
 	// TODO(Skarlso): Add options to set up SSL.
 	// https://github.com/kubernetes-sigs/cluster-api-provider-aws/issues/3899
 	for _, ln := range spec.ELBListeners {
-		// create the target group first
-		targetGroupInput := &elbv2.CreateTargetGroupInput{
-			Name:     aws.String(ln.TargetGroup.Name),
-			Port:     aws.Int64(ln.TargetGroup.Port),
-			Protocol: aws.String(ln.TargetGroup.Protocol.String()),
-			VpcId:    aws.String(ln.TargetGroup.VpcID),
-		}
-		if s.scope.VPC().IsIPv6Enabled() {
-			targetGroupInput.IpAddressType = aws.String("ipv6")
-		}
-		if ln.TargetGroup.HealthCheck != nil {
-			targetGroupInput.HealthCheckEnabled = aws.Bool(true)
-			targetGroupInput.HealthCheckProtocol = ln.TargetGroup.HealthCheck.Protocol
-			targetGroupInput.HealthCheckPort = ln.TargetGroup.HealthCheck.Port
-		}
-		s.scope.Debug("creating target group", "group", targetGroupInput, "listener", ln)
-		group, err := s.ELBV2Client.CreateTargetGroup(targetGroupInput)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to create target group for load balancer")
-		}
-		if len(group.TargetGroups) == 0 {
-			return nil, errors.New("no target group was created; the returned list is empty")
-		}
-
-		if !s.scope.ControlPlaneLoadBalancer().PreserveClientIP {
-			targetGroupAttributeInput := &elbv2.ModifyTargetGroupAttributesInput{
-				TargetGroupArn: group.TargetGroups[0].TargetGroupArn,
-				Attributes: []*elbv2.TargetGroupAttribute{
-					{
-						Key:   aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP),
-						Value: aws.String("false"),
-					},
-				},
-			}
-			if _, err := s.ELBV2Client.ModifyTargetGroupAttributes(targetGroupAttributeInput); err != nil {
-				return nil, errors.Wrapf(err, "failed to modify target group attribute")
-			}
-		}
-
-		listenerInput := &elbv2.CreateListenerInput{
-			DefaultActions: []*elbv2.Action{
-				{
-					TargetGroupArn: group.TargetGroups[0].TargetGroupArn,
-					Type:           aws.String(elbv2.ActionTypeEnumForward),
-				},
-			},
-			LoadBalancerArn: out.LoadBalancers[0].LoadBalancerArn,
-			Port:            aws.Int64(ln.Port),
-			Protocol:        aws.String(string(ln.Protocol)),
-			Tags:            converters.MapToV2Tags(spec.Tags),
-		}
-		// Create ClassicELBListeners
-		listener, err := s.ELBV2Client.CreateListener(listenerInput)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to create listener")
-		}
-		if len(listener.Listeners) == 0 {
-			return nil, errors.New("no listener was created; the returned list is empty")
+		if _, err := s.createListener(out.LoadBalancers[0].LoadBalancerArn, ln, spec.Tags); err != nil {
+			return nil, err
 		}
 	}
 
@@ -335,23 +305,40 @@ func (s *Service) createLB(spec *infrav1.LoadBalancer) (*infrav1.LoadBalancer, e
 	return res, nil
 }
 
+// checkLBOwnership returns an error if a load balancer found by its generated name isn't tagged for
+// the cluster, e.g. because another cluster generates the same name from its spec.resourceNaming.
+// Load balancers named by spec.controlPlaneLoadBalancer.name may be brought by the user and are not checked.
+func (s *Service) checkLBOwnership(lb *infrav1.LoadBalancer) error {
+	if s.scope.ControlPlaneLoadBalancerName() != nil {
+		return nil
+	}
+	if _, ok := lb.Tags[infrav1.ClusterTagKey(s.scope.Name())]; ok {
+		return nil
+	}
+	return errors.Errorf("load balancer %q already exists and isn't tagged for cluster %q, it may belong to another cluster", lb.Name, s.scope.Name())
+}
+
 func (s *Service) describeLB(name string) (*infrav1.LoadBalancer, error) {
+	return s.describeLBWithScheme(name, true)
+}
+
+// describeLBWithScheme describes a load balancer, returning an error if checkScheme is set and its
+// scheme differs from the spec, which means another load balancer already has its name.
+func (s *Service) describeLBWithScheme(name string, checkScheme bool) (*infrav1.LoadBalancer, error) {
 	input := &elbv2.DescribeLoadBalancersInput{
 		Names: aws.StringSlice([]string{name}),
 	}
 
 	out, err := s.ELBV2Client.DescribeLoadBalancers(input)
 	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			case elb.ErrCodeAccessPointNotFoundException:
-				return nil, NewNotFound(fmt.Sprintf("no load balancer found with name: %q", name))
-			case elb.ErrCodeDependencyThrottleException:
-				return nil, errors.Wrap(err, "too many requests made to the ELB service")
-			default:
-				return nil, errors.Wrap(err, "unexpected aws error")
-			}
-		} else {
+		switch {
+		case awserrors.IsNotFound(err):
+			return nil, NewNotFound(fmt.Sprintf("no load balancer found with name: %q", name))
+		case awserrors.IsThrottled(err):
+			return nil, errors.Wrap(err, "too many requests made to the ELB service")
+		case awserrors.IsSDKError(err):
+			return nil, errors.Wrap(err, "unexpected aws error")
+		default:
 			return nil, errors.Wrapf(err, "failed to describe load balancer: %s", name)
 		}
 	}
@@ -366,7 +353,7 @@ func (s *Service) describeLB(name string) (*infrav1.LoadBalancer, error) {
 			name, *out.LoadBalancers[0].VpcId)
 	}
 
-	if s.scope.ControlPlaneLoadBalancer() != nil &&
+	if checkScheme && s.scope.ControlPlaneLoadBalancer() != nil &&
 		s.scope.ControlPlaneLoadBalancer().Scheme != nil &&
 		string(*s.scope.ControlPlaneLoadBalancer().Scheme) != aws.StringValue(out.LoadBalancers[0].Scheme) {
 		return nil, errors.Errorf(
@@ -418,6 +405,10 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 	case err != nil:
 		// Failed to describe the classic ELB
 		return err
+	default:
+		if err := s.checkLBOwnership(apiELB); err != nil {
+			return err
+		}
 	}
 
 	if apiELB.IsManaged(s.scope.Name()) {
@@ -432,6 +423,14 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 			return errors.Wrapf(err, "failed to reconcile tags for apiserver load balancer %q", apiELB.Name)
 		}
 
+		if err := s.reconcileClassicELBListeners(apiELB, spec); err != nil {
+			return errors.Wrapf(err, "failed to reconcile listeners for apiserver load balancer %q", apiELB.Name)
+		}
+
+		if err := s.reconcileClassicELBHealthCheck(apiELB, spec); err != nil {
+			return errors.Wrapf(err, "failed to reconcile health check for apiserver load balancer %q", apiELB.Name)
+		}
+
 		// Reconcile the subnets and availability zones from the spec
 		// and the ones currently attached to the load balancer.
 		if len(apiELB.SubnetIDs) != len(spec.SubnetIDs) {
@@ -466,6 +465,12 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 	apiELB.DeepCopyInto(&s.scope.Network().APIServerELB)
 	s.scope.Trace("Control plane load balancer", "api-server-elb", apiELB)
 
+	if s.scope.ControlPlaneLoadBalancer() != nil && s.scope.ControlPlaneLoadBalancer().Route53 != nil {
+		if _, err := s.reconcileEndpointRecord(apiELB.DNSName); err != nil {
+			return err
+		}
+	}
+
 	s.scope.Debug("Reconcile load balancers completed successfully")
 	return nil
 }
@@ -567,6 +572,12 @@ func (s *Service) DeleteLoadbalancers() error {
 		return errors.Wrap(err, "failed to delete AWS cloud provider load balancer(s)")
 	}
 
+	if lb := s.scope.ControlPlaneLoadBalancer(); lb != nil && lb.Route53 != nil {
+		if err := s.deleteEndpointRecord(); err != nil {
+			return errors.Wrap(err, "failed to delete control plane endpoint record")
+		}
+	}
+
 	return nil
 }
 
@@ -575,6 +586,19 @@ func (s *Service) deleteExistingNLBs() error {
 	if err != nil {
 		return errors.Wrap(err, "failed to get control plane load balancer name")
 	}
+	// The load balancer being replaced after a change of scheme is deleted first.
+	if s.scope.ControlPlaneLoadBalancer().IsReplaceable() {
+		var previous *infrav1.LoadBalancer
+		name, previous, err = s.replaceableLBName(name)
+		if err != nil {
+			return err
+		}
+		if previous != nil {
+			if err := s.deleteReplacedLB(previous); err != nil {
+				return err
+			}
+		}
+	}
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 	if err := s.scope.PatchObject(); err != nil {
 		return err
@@ -592,6 +616,14 @@ func (s *Service) deleteExistingNLBs() error {
 		s.scope.Debug("Found unmanaged load balancer for apiserver, skipping deletion", "api-server-elb-name", lb.Name)
 		return nil
 	}
+	// Shield protections outlive the resources they protect.
+	if protectionID := s.scope.Network().APIServerELB.ShieldProtectionID; protectionID != "" {
+		if err := s.deleteShieldProtection(lb.Name, protectionID); err != nil {
+			return err
+		}
+		s.scope.Network().APIServerELB.ShieldProtectionID = ""
+	}
+
 	s.scope.Debug("deleting load balancer", "name", name)
 	if err := s.deleteLB(lb.ARN); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
@@ -642,9 +674,9 @@ func (s *Service) IsInstanceRegisteredWithAPIServerELB(i *infrav1.Instance) (boo
 
 // IsInstanceRegisteredWithAPIServerLB returns true if the instance is already registered with the APIServer LB.
 func (s *Service) IsInstanceRegisteredWithAPIServerLB(i *infrav1.Instance) (string, bool, error) {
-	name, err := LBName(s.scope)
+	name, err := s.apiServerLBName()
 	if err != nil {
-		return "", false, errors.Wrap(err, "failed to get control plane load balancer name")
+		return "", false, err
 	}
 
 	input := &elbv2.DescribeLoadBalancersInput{
@@ -736,36 +768,46 @@ func (s *Service) RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error {
 
 // RegisterInstanceWithAPIServerLB registers an instance with a LB.
 func (s *Service) RegisterInstanceWithAPIServerLB(instance *infrav1.Instance) error {
-	name, err := LBName(s.scope)
+	name, err := s.apiServerLBName()
 	if err != nil {
-		return errors.Wrap(err, "failed to get control plane load balancer name")
+		return err
 	}
 	out, err := s.describeLB(name)
 	if err != nil {
 		return err
 	}
 	s.scope.Debug("found load balancer with name", "name", out.Name)
+	return s.registerInstanceWithLB(out, instance.ID)
+}
+
+// registerInstanceWithLB registers an instance with all the target groups of a load balancer.
+func (s *Service) registerInstanceWithLB(lb *infrav1.LoadBalancer, instanceID string) error {
 	describeTargetGroupInput := &elbv2.DescribeTargetGroupsInput{
-		LoadBalancerArn: aws.String(out.ARN),
+		LoadBalancerArn: aws.String(lb.ARN),
 	}
 
 	targetGroups, err := s.ELBV2Client.DescribeTargetGroups(describeTargetGroupInput)
 	if err != nil {
-		return errors.Wrapf(err, "error describing ELB's target groups %q", name)
+		return errors.Wrapf(err, "error describing ELB's target groups %q", lb.Name)
 	}
 	if len(targetGroups.TargetGroups) == 0 {
-		return errors.New(fmt.Sprintf("no target groups found for load balancer with arn '%s'", out.ARN))
+		return errors.New(fmt.Sprintf("no target groups found for load balancer with arn '%s'", lb.ARN))
 	}
 	// Since TargetGroups and Listeners don't care, or are not aware, of subnets before registration, we ignore that check.
 	// Also, registering with AZ is not supported using the an InstanceID.
 	s.scope.Debug("found number of target groups", "target-groups", len(targetGroups.TargetGroups))
 	for _, tg := range targetGroups.TargetGroups {
+		// The target groups of additional listeners forward to the port of the listener.
+		port := aws.Int64Value(tg.Port)
+		if port == infrav1.DefaultAPIServerPort {
+			port = int64(s.scope.APIServerPort())
+		}
 		input := &elbv2.RegisterTargetsInput{
 			TargetGroupArn: tg.TargetGroupArn,
 			Targets: []*elbv2.TargetDescription{
 				{
-					Id:   aws.String(instance.ID),
-					Port: aws.Int64(int64(s.scope.APIServerPort())),
+					Id:   aws.String(instanceID),
+					Port: aws.Int64(port),
 				},
 			},
 		}
@@ -778,18 +820,41 @@ func (s *Service) RegisterInstanceWithAPIServerLB(instance *infrav1.Instance) er
 }
 
 // getControlPlaneLoadBalancerSubnets retrieves ControlPlaneLoadBalancer subnets information.
+// Subnets can be referenced by ID, or by ARN when they are shared from another account.
 func (s *Service) getControlPlaneLoadBalancerSubnets() (infrav1.Subnets, error) {
 	var subnets infrav1.Subnets
 
+	subnetIDs := make([]string, 0, len(s.scope.ControlPlaneLoadBalancer().Subnets))
+	owners := map[string]string{}
+	for _, subnet := range s.scope.ControlPlaneLoadBalancer().Subnets {
+		if !arn.IsARN(subnet) {
+			subnetIDs = append(subnetIDs, subnet)
+			continue
+		}
+		parsedARN, err := arn.Parse(subnet)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse subnet ARN %q", subnet)
+		}
+		subnetID := strings.TrimPrefix(parsedARN.Resource, "subnet/")
+		subnetIDs = append(subnetIDs, subnetID)
+		owners[subnetID] = parsedARN.AccountID
+	}
+
 	input := &ec2.DescribeSubnetsInput{
-		SubnetIds: aws.StringSlice(s.scope.ControlPlaneLoadBalancer().Subnets),
+		SubnetIds: aws.StringSlice(subnetIDs),
 	}
 	res, err := s.EC2Client.DescribeSubnets(input)
 	if err != nil {
+		if code, _ := awserrors.Code(err); len(owners) > 0 && code == awserrors.SubnetNotFound {
+			return nil, errors.Wrap(err, "failed to describe control plane load balancer subnets, make sure subnets referenced by ARN are shared with this account")
+		}
 		return nil, err
 	}
 
 	for _, sn := range res.Subnets {
+		if owner, ok := owners[aws.StringValue(sn.SubnetId)]; ok && owner != aws.StringValue(sn.OwnerId) {
+			return nil, errors.Errorf("subnet %q is owned by account %q, but was referenced as owned by account %q", aws.StringValue(sn.SubnetId), aws.StringValue(sn.OwnerId), owner)
+		}
 		lbSn := infrav1.SubnetSpec{
 			AvailabilityZone: *sn.AvailabilityZone,
 			ID:               *sn.SubnetId,
@@ -813,18 +878,11 @@ func (s *Service) DeregisterInstanceFromAPIServerELB(i *infrav1.Instance) error
 	}
 
 	_, err = s.ELBClient.DeregisterInstancesFromLoadBalancer(input)
-	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			case elb.ErrCodeAccessPointNotFoundException, elb.ErrCodeInvalidEndPointException:
-				// Ignoring LoadBalancerNotFound and InvalidInstance when deregistering
-				return nil
-			default:
-				return err
-			}
-		}
+	// Ignoring LoadBalancerNotFound and InvalidInstance when deregistering
+	if err != nil && !awserrors.IsNotFound(err) {
+		return err
 	}
-	return err
+	return nil
 }
 
 // DeregisterInstanceFromAPIServerLB de-registers an instance from a LB.
@@ -839,18 +897,11 @@ func (s *Service) DeregisterInstanceFromAPIServerLB(targetGroupArn string, i *in
 	}
 
 	_, err := s.ELBV2Client.DeregisterTargets(input)
-	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			case elb.ErrCodeAccessPointNotFoundException, elb.ErrCodeInvalidEndPointException:
-				// Ignoring LoadBalancerNotFound and InvalidInstance when deregistering
-				return nil
-			default:
-				return err
-			}
-		}
+	// Ignoring LoadBalancerNotFound and InvalidInstance when deregistering
+	if err != nil && !awserrors.IsNotFound(err) {
+		return err
 	}
-	return err
+	return nil
 }
 
 // ELBName returns the user-defined API Server ELB name, or a generated default if the user has not defined the ELB
@@ -859,7 +910,7 @@ func ELBName(s scope.ELBScope) (string, error) {
 	if userDefinedName := s.ControlPlaneLoadBalancerName(); userDefinedName != nil {
 		return *userDefinedName, nil
 	}
-	name, err := GenerateELBName(s.Name())
+	name, err := generateLBName(s.ResourceNaming().BaseNameOrDefault(s.Name()), s.ResourceNaming())
 	if err != nil {
 		return "", fmt.Errorf("failed to generate name: %w", err)
 	}
@@ -872,7 +923,8 @@ func LBName(s scope.ELBScope) (string, error) {
 	if userDefinedName := s.ControlPlaneLoadBalancerName(); userDefinedName != nil {
 		return *userDefinedName, nil
 	}
-	name, err := GenerateELBName(fmt.Sprintf("%s-%s", s.Namespace(), s.Name()))
+	baseName := s.ResourceNaming().BaseNameOrDefault(fmt.Sprintf("%s-%s", s.Namespace(), s.Name()))
+	name, err := generateLBName(baseName, s.ResourceNaming())
 	if err != nil {
 		return "", fmt.Errorf("failed to generate name: %w", err)
 	}
@@ -901,6 +953,20 @@ func GenerateELBName(clusterName string) (string, error) {
 	return elbName, nil
 }
 
+// generateLBName generates the name of the API Server load balancer from baseName. With the
+// stable naming scheme, names too long for AWS are an error rather than being hashed.
+func generateLBName(baseName string, naming *infrav1.ResourceNaming) (string, error) {
+	if !naming.IsStable() {
+		return GenerateELBName(baseName)
+	}
+
+	name := generateStandardELBName(baseName)
+	if len(name) > 32 {
+		return "", errors.Errorf("load balancer name %q is longer than 32 characters, set a shorter spec.resourceNaming.baseName", name)
+	}
+	return name, nil
+}
+
 // generateStandardELBName generates a formatted ELB name based on cluster
 // and ELB name.
 func generateStandardELBName(clusterName string) string {
@@ -939,21 +1005,29 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 				InstancePort:     infrav1.DefaultAPIServerPort,
 			},
 		},
-		HealthCheck: &infrav1.ClassicELBHealthCheck{
-			Target:             fmt.Sprintf("%v:%d", s.getHealthCheckELBProtocol(), infrav1.DefaultAPIServerPort),
-			Interval:           10 * time.Second,
-			Timeout:            5 * time.Second,
-			HealthyThreshold:   5,
-			UnhealthyThreshold: 3,
-		},
+		HealthCheck:      s.getClassicELBHealthCheck(),
 		SecurityGroupIDs: securityGroupIDs,
 		ClassicElbAttributes: infrav1.ClassicELBAttributes{
 			IdleTimeout: 10 * time.Minute,
 		},
 	}
+	for _, listener := range s.additionalListeners() {
+		res.ClassicELBListeners = append(res.ClassicELBListeners, infrav1.ClassicELBListener{
+			Protocol:         infrav1.ELBProtocolTCP,
+			Port:             listener.Port,
+			InstanceProtocol: infrav1.ELBProtocolTCP,
+			InstancePort:     listener.Port,
+		})
+	}
 
 	if s.scope.ControlPlaneLoadBalancer() != nil {
 		res.ClassicElbAttributes.CrossZoneLoadBalancing = s.scope.ControlPlaneLoadBalancer().CrossZoneLoadBalancing
+		if s.scope.ControlPlaneLoadBalancer().ConnectionDrainingTimeout != nil {
+			res.ClassicElbAttributes.ConnectionDrainingTimeout = s.scope.ControlPlaneLoadBalancer().ConnectionDrainingTimeout.Duration
+		}
+		if s.scope.ControlPlaneLoadBalancer().IdleTimeout != nil {
+			res.ClassicElbAttributes.IdleTimeout = s.scope.ControlPlaneLoadBalancer().IdleTimeout.Duration
+		}
 	}
 
 	res.Tags = infrav1.Build(infrav1.BuildParams{
@@ -968,16 +1042,13 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 	if s.scope.ControlPlaneLoadBalancer() != nil && len(s.scope.ControlPlaneLoadBalancer().Subnets) > 0 {
 		// This set of subnets may not match the subnets specified on the Cluster, so we may not have already discovered them
 		// We need to call out to AWS to describe them just in case
-		input := &ec2.DescribeSubnetsInput{
-			SubnetIds: aws.StringSlice(s.scope.ControlPlaneLoadBalancer().Subnets),
-		}
-		out, err := s.EC2Client.DescribeSubnets(input)
+		subnets, err := s.getControlPlaneLoadBalancerSubnets()
 		if err != nil {
 			return nil, err
 		}
-		for _, sn := range out.Subnets {
-			res.AvailabilityZones = append(res.AvailabilityZones, *sn.AvailabilityZone)
-			res.SubnetIDs = append(res.SubnetIDs, *sn.SubnetId)
+		for _, sn := range subnets {
+			res.AvailabilityZones = append(res.AvailabilityZones, sn.AvailabilityZone)
+			res.SubnetIDs = append(res.SubnetIDs, sn.ID)
 		}
 	} else {
 		// The load balancer APIs require us to only attach one subnet for each AZ.
@@ -1029,16 +1100,7 @@ func (s *Service) createClassicELB(spec *infrav1.LoadBalancer) (*infrav1.LoadBal
 
 	if spec.HealthCheck != nil {
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if _, err := s.ELBClient.ConfigureHealthCheck(&elb.ConfigureHealthCheckInput{
-				LoadBalancerName: aws.String(spec.Name),
-				HealthCheck: &elb.HealthCheck{
-					Target:             aws.String(spec.HealthCheck.Target),
-					Interval:           aws.Int64(int64(spec.HealthCheck.Interval.Seconds())),
-					Timeout:            aws.Int64(int64(spec.HealthCheck.Timeout.Seconds())),
-					HealthyThreshold:   aws.Int64(spec.HealthCheck.HealthyThreshold),
-					UnhealthyThreshold: aws.Int64(spec.HealthCheck.UnhealthyThreshold),
-				},
-			}); err != nil {
+			if err := s.configureClassicELBHealthCheck(spec.Name, spec.HealthCheck); err != nil {
 				return false, err
 			}
 			return true, nil
@@ -1061,9 +1123,18 @@ func (s *Service) configureAttributes(name string, attributes infrav1.ClassicELB
 			CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{
 				Enabled: aws.Bool(attributes.CrossZoneLoadBalancing),
 			},
+			// Always set connection draining so that removing the timeout
+			// from the spec disables it again.
+			ConnectionDraining: &elb.ConnectionDraining{
+				Enabled: aws.Bool(attributes.ConnectionDrainingTimeout > 0),
+			},
 		},
 	}
 
+	if attributes.ConnectionDrainingTimeout > 0 {
+		attrs.LoadBalancerAttributes.ConnectionDraining.Timeout = aws.Int64(int64(attributes.ConnectionDrainingTimeout.Seconds()))
+	}
+
 	if attributes.IdleTimeout > 0 {
 		attrs.LoadBalancerAttributes.ConnectionSettings = &elb.ConnectionSettings{
 			IdleTimeout: aws.Int64(int64(attributes.IdleTimeout.Seconds())),
@@ -1276,16 +1347,14 @@ func (s *Service) describeClassicELB(name string) (*infrav1.LoadBalancer, error)
 
 	out, err := s.ELBClient.DescribeLoadBalancers(input)
 	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			case elb.ErrCodeAccessPointNotFoundException:
-				return nil, NewNotFound(fmt.Sprintf("no classic load balancer found with name: %q", name))
-			case elb.ErrCodeDependencyThrottleException:
-				return nil, errors.Wrap(err, "too many requests made to the ELB service")
-			default:
-				return nil, errors.Wrap(err, "unexpected aws error")
-			}
-		} else {
+		switch {
+		case awserrors.IsNotFound(err):
+			return nil, NewNotFound(fmt.Sprintf("no classic load balancer found with name: %q", name))
+		case awserrors.IsThrottled(err):
+			return nil, errors.Wrap(err, "too many requests made to the ELB service")
+		case awserrors.IsSDKError(err):
+			return nil, errors.Wrap(err, "unexpected aws error")
+		default:
 			return nil, errors.Wrapf(err, "failed to describe classic load balancer: %s", name)
 		}
 	}
@@ -1450,14 +1519,31 @@ func fromSDKTypeToClassicELB(v *elb.LoadBalancerDescription, attrs *elb.LoadBala
 		DNSName:          aws.StringValue(v.DNSName),
 		Tags:             converters.ELBTagsToMap(tags),
 		LoadBalancerType: infrav1.LoadBalancerTypeClassic,
+		HealthCheck:      fromSDKTypeToClassicELBHealthCheck(v.HealthCheck),
 	}
 
 	if attrs.ConnectionSettings != nil && attrs.ConnectionSettings.IdleTimeout != nil {
 		res.ClassicElbAttributes.IdleTimeout = time.Duration(*attrs.ConnectionSettings.IdleTimeout) * time.Second
 	}
 
+	if attrs.ConnectionDraining != nil && aws.BoolValue(attrs.ConnectionDraining.Enabled) && attrs.ConnectionDraining.Timeout != nil {
+		res.ClassicElbAttributes.ConnectionDrainingTimeout = time.Duration(*attrs.ConnectionDraining.Timeout) * time.Second
+	}
+
 	res.ClassicElbAttributes.CrossZoneLoadBalancing = aws.BoolValue(attrs.CrossZoneLoadBalancing.Enabled)
 
+	for _, description := range v.ListenerDescriptions {
+		if description.Listener == nil {
+			continue
+		}
+		res.ClassicELBListeners = append(res.ClassicELBListeners, infrav1.ClassicELBListener{
+			Protocol:         infrav1.ELBProtocol(aws.StringValue(description.Listener.Protocol)),
+			Port:             aws.Int64Value(description.Listener.LoadBalancerPort),
+			InstanceProtocol: infrav1.ELBProtocol(aws.StringValue(description.Listener.InstanceProtocol)),
+			InstancePort:     aws.Int64Value(description.Listener.InstancePort),
+		})
+	}
+
 	return res
 }
 
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -37,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -45,9 +47,10 @@ import (
 
 func TestELBName(t *testing.T) {
 	tests := []struct {
-		name       string
-		awsCluster infrav1.AWSCluster
-		expected   string
+		name        string
+		awsCluster  infrav1.AWSCluster
+		expected    string
+		expectError bool
 	}{
 		{
 			name: "name is not defined by user, so generate the default",
@@ -74,6 +77,58 @@ func TestELBName(t *testing.T) {
 			},
 			expected: "myapiserver",
 		},
+		{
+			name: "base name is defined by user, so generate the name from it",
+			awsCluster: infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: infrav1.AWSClusterSpec{
+					ResourceNaming: &infrav1.ResourceNaming{BaseName: "shared"},
+				},
+			},
+			expected: "shared-apiserver",
+		},
+		{
+			name: "long base name is hashed by default",
+			awsCluster: infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: infrav1.AWSClusterSpec{
+					ResourceNaming: &infrav1.ResourceNaming{BaseName: "anotherverylongtoolongname"},
+				},
+			},
+			expected: "t8gnrbbifaaf5d0k4xmwui3xwvip-k8s",
+		},
+		{
+			name: "long name is not hashed with the stable scheme",
+			awsCluster: infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "anotherverylongtoolongname",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: infrav1.AWSClusterSpec{
+					ResourceNaming: &infrav1.ResourceNaming{Scheme: infrav1.ResourceNamingSchemeStable},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "short name is kept with the stable scheme",
+			awsCluster: infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example.com",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: infrav1.AWSClusterSpec{
+					ResourceNaming: &infrav1.ResourceNaming{Scheme: infrav1.ResourceNamingSchemeStable},
+				},
+			},
+			expected: "example-com-apiserver",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -96,6 +151,12 @@ func TestELBName(t *testing.T) {
 			}
 
 			elbName, err := ELBName(scope)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got name: %v", elbName)
+				}
+				return
+			}
 			if err != nil {
 				t.Fatalf("unable to get ELB name: %v", err)
 			}
@@ -106,6 +167,63 @@ func TestELBName(t *testing.T) {
 	}
 }
 
+func TestCheckLBOwnership(t *testing.T) {
+	tests := []struct {
+		name        string
+		lbName      *string
+		tags        map[string]string
+		expectError bool
+	}{
+		{
+			name: "load balancer tagged as owned by the cluster",
+			tags: map[string]string{infrav1.ClusterTagKey("example"): string(infrav1.ResourceLifecycleOwned)},
+		},
+		{
+			name: "load balancer tagged as shared with the cluster",
+			tags: map[string]string{infrav1.ClusterTagKey("example"): string(infrav1.ResourceLifecycleShared)},
+		},
+		{
+			name:        "load balancer tagged for another cluster",
+			tags:        map[string]string{infrav1.ClusterTagKey("other"): string(infrav1.ResourceLifecycleOwned)},
+			expectError: true,
+		},
+		{
+			name:   "untagged load balancer named by the user",
+			lbName: aws.String("myapiserver"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: metav1.NamespaceDefault},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: metav1.NamespaceDefault},
+					Spec: infrav1.AWSClusterSpec{
+						ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{Name: tt.lbName},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(clusterScope)
+			err = s.checkLBOwnership(&infrav1.LoadBalancer{Name: "example-apiserver", Tags: tt.tags})
+			if tt.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestGenerateELBName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -181,6 +299,26 @@ func TestGetAPIServerClassicELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "nil load balancer config has the default idle timeout",
+			lb:    nil,
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ClassicElbAttributes.IdleTimeout).To(Equal(10 * time.Minute))
+			},
+		},
+		{
+			name: "load balancer config with idle timeout",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				IdleTimeout: &metav1.Duration{Duration: time.Hour},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ClassicElbAttributes.IdleTimeout).To(Equal(time.Hour))
+			},
+		},
 		{
 			name: "load balancer config with subnets specified",
 			lb: &infrav1.AWSLoadBalancerSpec{
@@ -251,6 +389,46 @@ func TestGetAPIServerClassicELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				g.Expect(expectedTarget, res.HealthCheck.Target)
 			},
 		},
+		{
+			name: "load balancer config with additional listeners",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				AdditionalListeners: []infrav1.AdditionalListenerSpec{{Port: 8132}},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ClassicELBListeners).To(HaveLen(2))
+				g.Expect(res.ClassicELBListeners[1]).To(Equal(infrav1.ClassicELBListener{
+					Protocol:         infrav1.ELBProtocolTCP,
+					Port:             8132,
+					InstanceProtocol: infrav1.ELBProtocolTCP,
+					InstancePort:     8132,
+				}))
+			},
+		},
+		{
+			name: "load balancer config with an HTTPS health check",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				HealthCheck: &infrav1.TargetGroupHealthCheckSpec{
+					Protocol:                aws.String("HTTPS"),
+					IntervalSeconds:         aws.Int64(15),
+					TimeoutSeconds:          aws.Int64(3),
+					ThresholdCount:          aws.Int64(2),
+					UnhealthyThresholdCount: aws.Int64(4),
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.HealthCheck).To(Equal(&infrav1.ClassicELBHealthCheck{
+					Target:             "HTTPS:6443/readyz",
+					Interval:           15 * time.Second,
+					Timeout:            3 * time.Second,
+					HealthyThreshold:   2,
+					UnhealthyThreshold: 4,
+				}))
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -330,6 +508,18 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "application load balancer config with idle timeout",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeALB,
+				IdleTimeout:      &metav1.Duration{Duration: time.Hour},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeIdleTimeTimeoutSeconds, aws.String("3600")))
+			},
+		},
 		{
 			name: "load balancer config with subnets specified",
 			lb: &infrav1.AWSLoadBalancerSpec{
@@ -406,6 +596,62 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "additional listeners are set up for NLB",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				AdditionalListeners: []infrav1.AdditionalListenerSpec{
+					{Port: 8132},
+					{
+						Port: 22623,
+						HealthCheck: &infrav1.TargetGroupHealthCheckSpec{
+							Protocol: aws.String("HTTPS"),
+							Path:     aws.String("/healthz"),
+						},
+					},
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBListeners).To(HaveLen(3))
+				g.Expect(res.ELBListeners[1].Port).To(BeEquivalentTo(8132))
+				g.Expect(res.ELBListeners[1].TargetGroup.Port).To(BeEquivalentTo(8132))
+				g.Expect(res.ELBListeners[1].TargetGroup.HealthCheck).To(Equal(&infrav1.TargetGroupHealthCheck{
+					Protocol: aws.String("TCP"),
+					Port:     aws.String("8132"),
+				}))
+				g.Expect(res.ELBListeners[2].Port).To(BeEquivalentTo(22623))
+				g.Expect(res.ELBListeners[2].TargetGroup.HealthCheck).To(Equal(&infrav1.TargetGroupHealthCheck{
+					Protocol: aws.String("HTTPS"),
+					Port:     aws.String("22623"),
+					Path:     aws.String("/healthz"),
+				}))
+			},
+		},
+		{
+			name: "API server health check is set up for NLB",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				HealthCheck: &infrav1.TargetGroupHealthCheckSpec{
+					Protocol:        aws.String("HTTPS"),
+					IntervalSeconds: aws.Int64(10),
+					ThresholdCount:  aws.Int64(3),
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBListeners[0].TargetGroup.HealthCheck).To(Equal(&infrav1.TargetGroupHealthCheck{
+					Protocol:                aws.String("HTTPS"),
+					Port:                    aws.String("6443"),
+					Path:                    aws.String("/readyz"),
+					IntervalSeconds:         aws.Int64(10),
+					ThresholdCount:          aws.Int64(3),
+					UnhealthyThresholdCount: aws.Int64(3),
+				}))
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -454,6 +700,116 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 	}
 }
 
+func TestGetControlPlaneLoadBalancerSubnets(t *testing.T) {
+	tests := []struct {
+		name    string
+		subnets []string
+		mocks   func(m *mocks.MockEC2APIMockRecorder)
+		want    infrav1.Subnets
+		wantErr bool
+	}{
+		{
+			name:    "subnets referenced by ID and by shared subnet ARN",
+			subnets: []string{"subnet-1", "arn:aws:ec2:us-east-1:111111111111:subnet/subnet-2"},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Eq(&ec2.DescribeSubnetsInput{
+					SubnetIds: aws.StringSlice([]string{"subnet-1", "subnet-2"}),
+				})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								SubnetId:         aws.String("subnet-1"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								OwnerId:          aws.String("222222222222"),
+							},
+							{
+								SubnetId:         aws.String("subnet-2"),
+								AvailabilityZone: aws.String("us-east-1b"),
+								OwnerId:          aws.String("111111111111"),
+							},
+						},
+					}, nil)
+			},
+			want: infrav1.Subnets{
+				{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-2", AvailabilityZone: "us-east-1b"},
+			},
+		},
+		{
+			name:    "shared subnet owned by a different account than referenced in its ARN",
+			subnets: []string{"arn:aws:ec2:us-east-1:111111111111:subnet/subnet-2"},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								SubnetId:         aws.String("subnet-2"),
+								AvailabilityZone: aws.String("us-east-1b"),
+								OwnerId:          aws.String("333333333333"),
+							},
+						},
+					}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "shared subnet not shared with the account",
+			subnets: []string{"arn:aws:ec2:us-east-1:111111111111:subnet/subnet-2"},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).
+					Return(nil, awserr.New(awserrors.SubnetNotFound, "not found", nil))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+							Subnets: tc.subnets,
+						},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.mocks(ec2Mock.EXPECT())
+
+			s := &Service{
+				scope:     clusterScope,
+				EC2Client: ec2Mock,
+			}
+
+			subnets, err := s.getControlPlaneLoadBalancerSubnets()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(subnets).To(Equal(tc.want))
+		})
+	}
+}
+
 func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 	const (
 		namespace       = "foo"
@@ -1099,6 +1455,69 @@ func TestCreateNLB(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "TLS listener with a security profile uses the policy of the profile",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.ELBListeners[0].Protocol = infrav1.ELBProtocolTLS
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.SecurityProfile = infrav1.SecurityProfileBaseline
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.CreateLoadBalancer(gomock.Any()).Return(&elbv2.CreateLoadBalancerOutput{
+					LoadBalancers: []*elbv2.LoadBalancer{
+						{
+							LoadBalancerArn:  aws.String(elbArn),
+							LoadBalancerName: aws.String(elbName),
+							Scheme:           aws.String(string(infrav1.ELBSchemeInternetFacing)),
+							DNSName:          aws.String(dns),
+						},
+					},
+				}, nil)
+				m.CreateTargetGroup(gomock.Any()).Return(&elbv2.CreateTargetGroupOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn:  aws.String("target-group::arn"),
+							TargetGroupName: aws.String("name"),
+							VpcId:           aws.String(vpcID),
+						},
+					},
+				}, nil)
+				m.ModifyTargetGroupAttributes(gomock.Any()).Return(nil, nil)
+				m.CreateListener(gomock.Eq(&elbv2.CreateListenerInput{
+					DefaultActions: []*elbv2.Action{
+						{
+							TargetGroupArn: aws.String("target-group::arn"),
+							Type:           aws.String(elbv2.ActionTypeEnumForward),
+						},
+					},
+					LoadBalancerArn: aws.String(elbArn),
+					Port:            aws.Int64(infrav1.DefaultAPIServerPort),
+					Protocol:        aws.String("TLS"),
+					SslPolicy:       aws.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+					Tags: []*elbv2.Tag{
+						{
+							Key:   aws.String("test"),
+							Value: aws.String("tag"),
+						},
+					},
+				})).Return(&elbv2.CreateListenerOutput{
+					Listeners: []*elbv2.Listener{
+						{
+							ListenerArn: aws.String("listener::arn"),
+						},
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, lb *infrav1.LoadBalancer, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
 		{
 			name: "created with ipv6 vpc",
 			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
@@ -1408,6 +1827,101 @@ func TestCreateNLB(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "connection draining timeout sets the target group deregistration delay",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.ControlPlaneLoadBalancer.ConnectionDrainingTimeout = &metav1.Duration{Duration: 2 * time.Minute}
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.CreateLoadBalancer(gomock.Eq(&elbv2.CreateLoadBalancerInput{
+					Name:    aws.String(elbName),
+					Scheme:  aws.String("internet-facing"),
+					Type:    aws.String("network"),
+					Subnets: aws.StringSlice([]string{clusterSubnetID}),
+					Tags: []*elbv2.Tag{
+						{
+							Key:   aws.String("test"),
+							Value: aws.String("tag"),
+						},
+					},
+				})).Return(&elbv2.CreateLoadBalancerOutput{
+					LoadBalancers: []*elbv2.LoadBalancer{
+						{
+							LoadBalancerArn:  aws.String(elbArn),
+							LoadBalancerName: aws.String(elbName),
+							Scheme:           aws.String(string(infrav1.ELBSchemeInternetFacing)),
+							DNSName:          aws.String(dns),
+						},
+					},
+				}, nil)
+				m.CreateTargetGroup(gomock.Eq(&elbv2.CreateTargetGroupInput{
+					HealthCheckEnabled:  aws.Bool(true),
+					HealthCheckPort:     aws.String("infrav1.DefaultAPIServerPort"),
+					HealthCheckProtocol: aws.String("tcp"),
+					Name:                aws.String("name"),
+					Port:                aws.Int64(infrav1.DefaultAPIServerPort),
+					Protocol:            aws.String("TCP"),
+					VpcId:               aws.String(vpcID),
+				})).Return(&elbv2.CreateTargetGroupOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn:  aws.String("target-group::arn"),
+							TargetGroupName: aws.String("name"),
+							VpcId:           aws.String(vpcID),
+						},
+					},
+				}, nil)
+				m.ModifyTargetGroupAttributes(gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
+					TargetGroupArn: aws.String("target-group::arn"),
+					Attributes: []*elbv2.TargetGroupAttribute{
+						{
+							Key:   aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP),
+							Value: aws.String("false"),
+						},
+						{
+							Key:   aws.String(infrav1.TargetGroupAttributeDeregistrationDelayTimeoutSeconds),
+							Value: aws.String("120"),
+						},
+					},
+				})).Return(nil, nil)
+				m.CreateListener(gomock.Eq(&elbv2.CreateListenerInput{
+					DefaultActions: []*elbv2.Action{
+						{
+							TargetGroupArn: aws.String("target-group::arn"),
+							Type:           aws.String(elbv2.ActionTypeEnumForward),
+						},
+					},
+					LoadBalancerArn: aws.String(elbArn),
+					Port:            aws.Int64(infrav1.DefaultAPIServerPort),
+					Protocol:        aws.String("TCP"),
+					Tags: []*elbv2.Tag{
+						{
+							Key:   aws.String("test"),
+							Value: aws.String("tag"),
+						},
+					},
+				})).Return(&elbv2.CreateListenerOutput{
+					Listeners: []*elbv2.Listener{
+						{
+							ListenerArn: aws.String("listener::arn"),
+						},
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, lb *infrav1.LoadBalancer, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if lb.DNSName != dns {
+					t.Fatalf("DNSName did not equal expected value; was: '%s'", lb.DNSName)
+				}
+			},
+		},
 		{
 			name: "load balancer is not an NLB scope security groups will be added",
 			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
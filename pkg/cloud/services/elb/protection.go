@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// reconcileLBProtection associates the web ACL and the Shield Advanced protection requested
+// for the control plane load balancer with it. The ones the controller previously set up, as
+// recorded in the load balancer status, are removed once they are no longer requested. Web ACLs
+// and protections set up outside of the controller are left alone.
+func (s *Service) reconcileLBProtection(lb *infrav1.LoadBalancer) error {
+	spec := s.scope.ControlPlaneLoadBalancer()
+	previous := s.scope.Network().APIServerELB
+
+	webACLARN, err := s.reconcileWebACL(lb, aws.StringValue(spec.WebACLARN), previous.WebACLARN)
+	if err != nil {
+		return err
+	}
+	lb.WebACLARN = webACLARN
+
+	protectionID, err := s.reconcileShieldProtection(lb, spec.ShieldAdvanced, previous.ShieldProtectionID)
+	if err != nil {
+		return err
+	}
+	lb.ShieldProtectionID = protectionID
+
+	return nil
+}
+
+// reconcileWebACL returns the ARN of the web ACL the controller associated with the load balancer.
+func (s *Service) reconcileWebACL(lb *infrav1.LoadBalancer, desired, previous string) (string, error) {
+	if desired == "" && previous == "" {
+		return "", nil
+	}
+
+	out, err := s.WAFv2Client.GetWebACLForResource(&wafv2.GetWebACLForResourceInput{
+		ResourceArn: aws.String(lb.ARN),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get web ACL of load balancer %q", lb.Name)
+	}
+	associated := ""
+	if out.WebACL != nil {
+		associated = aws.StringValue(out.WebACL.ARN)
+	}
+
+	switch {
+	case desired != "" && associated != desired:
+		if _, err := s.WAFv2Client.AssociateWebACL(&wafv2.AssociateWebACLInput{
+			ResourceArn: aws.String(lb.ARN),
+			WebACLArn:   aws.String(desired),
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedAssociateWebACL", "Failed to associate web ACL %q with load balancer %q: %v", desired, lb.Name, err)
+			return "", errors.Wrapf(err, "failed to associate web ACL %q with load balancer %q", desired, lb.Name)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateWebACL", "Associated web ACL %q with load balancer %q", desired, lb.Name)
+	case desired == "" && associated != "" && associated == previous:
+		if _, err := s.WAFv2Client.DisassociateWebACL(&wafv2.DisassociateWebACLInput{
+			ResourceArn: aws.String(lb.ARN),
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDisassociateWebACL", "Failed to disassociate web ACL %q from load balancer %q: %v", associated, lb.Name, err)
+			return "", errors.Wrapf(err, "failed to disassociate web ACL %q from load balancer %q", associated, lb.Name)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDisassociateWebACL", "Disassociated web ACL %q from load balancer %q", associated, lb.Name)
+	}
+
+	return desired, nil
+}
+
+// reconcileShieldProtection returns the ID of the Shield Advanced protection the controller
+// created for the load balancer.
+func (s *Service) reconcileShieldProtection(lb *infrav1.LoadBalancer, desired bool, previous string) (string, error) {
+	if !desired && previous == "" {
+		return "", nil
+	}
+
+	protectionID := ""
+	out, err := s.ShieldClient.DescribeProtection(&shield.DescribeProtectionInput{
+		ResourceArn: aws.String(lb.ARN),
+	})
+	switch {
+	case err == nil && out.Protection != nil:
+		protectionID = aws.StringValue(out.Protection.Id)
+	case err != nil && !isShieldNotFound(err):
+		return "", errors.Wrapf(err, "failed to describe Shield protection of load balancer %q", lb.Name)
+	}
+
+	switch {
+	case desired && protectionID == "":
+		out, err := s.ShieldClient.CreateProtection(&shield.CreateProtectionInput{
+			Name:        aws.String(lb.Name),
+			ResourceArn: aws.String(lb.ARN),
+		})
+		if err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedCreateShieldProtection", "Failed to create Shield protection for load balancer %q: %v", lb.Name, err)
+			return "", errors.Wrapf(err, "failed to create Shield protection for load balancer %q", lb.Name)
+		}
+		protectionID = aws.StringValue(out.ProtectionId)
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateShieldProtection", "Created Shield protection %q for load balancer %q", protectionID, lb.Name)
+	case !desired && protectionID != "" && protectionID == previous:
+		if err := s.deleteShieldProtection(lb.Name, protectionID); err != nil {
+			return "", err
+		}
+	}
+
+	if !desired {
+		return "", nil
+	}
+	return protectionID, nil
+}
+
+func (s *Service) deleteShieldProtection(lbName, protectionID string) error {
+	if _, err := s.ShieldClient.DeleteProtection(&shield.DeleteProtectionInput{
+		ProtectionId: aws.String(protectionID),
+	}); err != nil && !isShieldNotFound(err) {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteShieldProtection", "Failed to delete Shield protection %q of load balancer %q: %v", protectionID, lbName, err)
+		return errors.Wrapf(err, "failed to delete Shield protection %q of load balancer %q", protectionID, lbName)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteShieldProtection", "Deleted Shield protection %q of load balancer %q", protectionID, lbName)
+	return nil
+}
+
+func isShieldNotFound(err error) bool {
+	return awserrors.IsNotFound(err)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileLBProtection(t *testing.T) {
+	const (
+		lbARN        = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/test-apiserver/abc"
+		webACLARN    = "arn:aws:wafv2:us-east-1:123456789012:regional/webacl/capa/1234"
+		otherACLARN  = "arn:aws:wafv2:us-east-1:123456789012:regional/webacl/other/5678"
+		protectionID = "protection-id"
+	)
+	notFound := awserr.New(shield.ErrCodeResourceNotFoundException, "not found", nil)
+
+	tests := []struct {
+		name               string
+		spec               infrav1.AWSLoadBalancerSpec
+		status             infrav1.LoadBalancer
+		wafMocks           func(m *mocks.MockWAFV2APIMockRecorder)
+		shieldMocks        func(m *mocks.MockShieldAPIMockRecorder)
+		expectedWebACLARN  string
+		expectedProtection string
+	}{
+		{
+			name: "does nothing when neither is requested nor was set up",
+		},
+		{
+			name: "associates web ACL and creates protection",
+			spec: infrav1.AWSLoadBalancerSpec{WebACLARN: aws.String(webACLARN), ShieldAdvanced: true},
+			wafMocks: func(m *mocks.MockWAFV2APIMockRecorder) {
+				m.GetWebACLForResource(&wafv2.GetWebACLForResourceInput{ResourceArn: aws.String(lbARN)}).
+					Return(&wafv2.GetWebACLForResourceOutput{}, nil)
+				m.AssociateWebACL(&wafv2.AssociateWebACLInput{ResourceArn: aws.String(lbARN), WebACLArn: aws.String(webACLARN)}).
+					Return(&wafv2.AssociateWebACLOutput{}, nil)
+			},
+			shieldMocks: func(m *mocks.MockShieldAPIMockRecorder) {
+				m.DescribeProtection(&shield.DescribeProtectionInput{ResourceArn: aws.String(lbARN)}).
+					Return(nil, notFound)
+				m.CreateProtection(&shield.CreateProtectionInput{Name: aws.String("test-apiserver"), ResourceArn: aws.String(lbARN)}).
+					Return(&shield.CreateProtectionOutput{ProtectionId: aws.String(protectionID)}, nil)
+			},
+			expectedWebACLARN:  webACLARN,
+			expectedProtection: protectionID,
+		},
+		{
+			name:   "leaves existing association and protection alone",
+			spec:   infrav1.AWSLoadBalancerSpec{WebACLARN: aws.String(webACLARN), ShieldAdvanced: true},
+			status: infrav1.LoadBalancer{WebACLARN: webACLARN, ShieldProtectionID: protectionID},
+			wafMocks: func(m *mocks.MockWAFV2APIMockRecorder) {
+				m.GetWebACLForResource(gomock.Any()).
+					Return(&wafv2.GetWebACLForResourceOutput{WebACL: &wafv2.WebACL{ARN: aws.String(webACLARN)}}, nil)
+			},
+			shieldMocks: func(m *mocks.MockShieldAPIMockRecorder) {
+				m.DescribeProtection(gomock.Any()).
+					Return(&shield.DescribeProtectionOutput{Protection: &shield.Protection{Id: aws.String(protectionID)}}, nil)
+			},
+			expectedWebACLARN:  webACLARN,
+			expectedProtection: protectionID,
+		},
+		{
+			name:   "removes association and protection no longer requested",
+			status: infrav1.LoadBalancer{WebACLARN: webACLARN, ShieldProtectionID: protectionID},
+			wafMocks: func(m *mocks.MockWAFV2APIMockRecorder) {
+				m.GetWebACLForResource(gomock.Any()).
+					Return(&wafv2.GetWebACLForResourceOutput{WebACL: &wafv2.WebACL{ARN: aws.String(webACLARN)}}, nil)
+				m.DisassociateWebACL(&wafv2.DisassociateWebACLInput{ResourceArn: aws.String(lbARN)}).
+					Return(&wafv2.DisassociateWebACLOutput{}, nil)
+			},
+			shieldMocks: func(m *mocks.MockShieldAPIMockRecorder) {
+				m.DescribeProtection(gomock.Any()).
+					Return(&shield.DescribeProtectionOutput{Protection: &shield.Protection{Id: aws.String(protectionID)}}, nil)
+				m.DeleteProtection(&shield.DeleteProtectionInput{ProtectionId: aws.String(protectionID)}).
+					Return(&shield.DeleteProtectionOutput{}, nil)
+			},
+		},
+		{
+			name:   "does not remove association and protection set up outside of the controller",
+			status: infrav1.LoadBalancer{WebACLARN: webACLARN, ShieldProtectionID: protectionID},
+			wafMocks: func(m *mocks.MockWAFV2APIMockRecorder) {
+				m.GetWebACLForResource(gomock.Any()).
+					Return(&wafv2.GetWebACLForResourceOutput{WebACL: &wafv2.WebACL{ARN: aws.String(otherACLARN)}}, nil)
+			},
+			shieldMocks: func(m *mocks.MockShieldAPIMockRecorder) {
+				m.DescribeProtection(gomock.Any()).
+					Return(&shield.DescribeProtectionOutput{Protection: &shield.Protection{Id: aws.String("other-protection")}}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			wafMock := mocks.NewMockWAFV2API(mockCtrl)
+			shieldMock := mocks.NewMockShieldAPI(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			spec := tc.spec
+			spec.LoadBalancerType = infrav1.LoadBalancerTypeALB
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       infrav1.AWSClusterSpec{ControlPlaneLoadBalancer: &spec},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{APIServerELB: tc.status},
+				},
+			}
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"}},
+				AWSCluster: awsCluster,
+				Client:     fake.NewClientBuilder().WithScheme(scheme).Build(),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if tc.wafMocks != nil {
+				tc.wafMocks(wafMock.EXPECT())
+			}
+			if tc.shieldMocks != nil {
+				tc.shieldMocks(shieldMock.EXPECT())
+			}
+
+			s := &Service{
+				scope:        clusterScope,
+				WAFv2Client:  wafMock,
+				ShieldClient: shieldMock,
+			}
+
+			lb := &infrav1.LoadBalancer{ARN: lbARN, Name: "test-apiserver"}
+			g.Expect(s.reconcileLBProtection(lb)).To(Succeed())
+			g.Expect(lb.WebACLARN).To(Equal(tc.expectedWebACLARN))
+			g.Expect(lb.ShieldProtectionID).To(Equal(tc.expectedProtection))
+		})
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// endpointPropagationDelay is how long a replaced load balancer is kept after the control plane endpoint
+// was moved to its replacement, so that clients which cached the record stop using it.
+const endpointPropagationDelay = 2 * endpointRecordTTL * time.Second
+
+// schemeLBName returns the name of a control plane load balancer of the given scheme, replacing a load
+// balancer of the other scheme which has the default name.
+func schemeLBName(s scope.ELBScope, scheme infrav1.ELBScheme) (string, error) {
+	suffix := "ext"
+	if scheme == infrav1.ELBSchemeInternal {
+		suffix = "int"
+	}
+	baseName := s.ResourceNaming().BaseNameOrDefault(fmt.Sprintf("%s-%s", s.Namespace(), s.Name()))
+	name, err := generateLBName(fmt.Sprintf("%s-%s", baseName, suffix), s.ResourceNaming())
+	if err != nil {
+		return "", fmt.Errorf("failed to generate name: %w", err)
+	}
+	return name, nil
+}
+
+// apiServerLBName returns the name of the control plane load balancer the control plane instances are
+// registered with.
+func (s *Service) apiServerLBName() (string, error) {
+	name, err := LBName(s.scope)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get control plane load balancer name")
+	}
+	if s.scope.ControlPlaneLoadBalancer().IsReplaceable() {
+		name, _, err = s.replaceableLBName(name)
+	}
+	return name, err
+}
+
+// replaceableLBName returns the name of the control plane load balancer of the scheme of the spec, and
+// the load balancer of the other scheme it replaces, if any. As both exist while the control plane endpoint
+// is moved, a replacement can't reuse the name of the load balancer it replaces, so load balancers alternate
+// between the default name and a name derived from their scheme.
+func (s *Service) replaceableLBName(defaultName string) (string, *infrav1.LoadBalancer, error) {
+	scheme := s.scope.ControlPlaneLoadBalancerScheme()
+	names := []string{defaultName}
+	for _, candidate := range []infrav1.ELBScheme{infrav1.ELBSchemeInternetFacing, infrav1.ELBSchemeInternal} {
+		name, err := schemeLBName(s.scope, candidate)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "failed to get control plane load balancer name")
+		}
+		names = append(names, name)
+	}
+
+	current := ""
+	var previous *infrav1.LoadBalancer
+	for _, name := range names {
+		lb, err := s.describeLBWithScheme(name, false)
+		if IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if err := s.checkLBOwnership(lb); err != nil {
+			return "", nil, err
+		}
+		switch {
+		case lb.Scheme == scheme && current == "":
+			current = lb.Name
+		case lb.Scheme != scheme && previous == nil:
+			previous = lb
+		}
+	}
+
+	switch {
+	case current != "":
+		return current, previous, nil
+	case previous != nil && previous.Name == defaultName:
+		name, err := schemeLBName(s.scope, scheme)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "failed to get control plane load balancer name")
+		}
+		return name, previous, nil
+	default:
+		return defaultName, previous, nil
+	}
+}
+
+// reconcileLBReplacement points the control plane endpoint record to the load balancer. When it replaces a
+// load balancer of another scheme, the control plane instances are first registered with it, the record is
+// only moved once they are healthy, and the replaced load balancer is deleted once clients stopped using it.
+func (s *Service) reconcileLBReplacement(lb, previous *infrav1.LoadBalancer) error {
+	if previous == nil {
+		if _, err := s.reconcileEndpointRecord(lb.DNSName); err != nil {
+			return err
+		}
+		if conditions.Has(s.scope.InfraCluster(), infrav1.LoadBalancerSchemeReadyCondition) {
+			conditions.MarkTrue(s.scope.InfraCluster(), infrav1.LoadBalancerSchemeReadyCondition)
+		}
+		return nil
+	}
+
+	if err := s.replaceLB(lb, previous); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerSchemeReadyCondition, infrav1.LoadBalancerReplacementFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return errors.Wrapf(err, "failed to replace load balancer %q with %q", previous.Name, lb.Name)
+	}
+	return nil
+}
+
+func (s *Service) replaceLB(lb, previous *infrav1.LoadBalancer) error {
+	infraCluster := s.scope.InfraCluster()
+
+	endpoint, err := s.describeEndpointRecord()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(strings.TrimSuffix(endpoint, "."), lb.DNSName) {
+		healthy, err := s.registerReplacementTargets(lb, previous)
+		if err != nil {
+			return err
+		}
+		if !healthy {
+			conditions.MarkFalse(infraCluster, infrav1.LoadBalancerSchemeReadyCondition, infrav1.WaitForReplacementTargetsReason, clusterv1.ConditionSeverityInfo,
+				"Waiting for the control plane instances to be healthy in load balancer %q", lb.Name)
+			return nil
+		}
+
+		if _, err := s.reconcileEndpointRecord(lb.DNSName); err != nil {
+			return err
+		}
+		// The deletion of the replaced load balancer is timed from the last transition of the condition.
+		conditions.Delete(infraCluster, infrav1.LoadBalancerSchemeReadyCondition)
+		conditions.MarkFalse(infraCluster, infrav1.LoadBalancerSchemeReadyCondition, infrav1.WaitForEndpointPropagationReason, clusterv1.ConditionSeverityInfo,
+			"Waiting for clients to stop using load balancer %q", previous.Name)
+		return nil
+	}
+
+	condition := conditions.Get(infraCluster, infrav1.LoadBalancerSchemeReadyCondition)
+	if condition == nil || conditions.IsTrue(infraCluster, infrav1.LoadBalancerSchemeReadyCondition) {
+		conditions.Delete(infraCluster, infrav1.LoadBalancerSchemeReadyCondition)
+		conditions.MarkFalse(infraCluster, infrav1.LoadBalancerSchemeReadyCondition, infrav1.WaitForEndpointPropagationReason, clusterv1.ConditionSeverityInfo,
+			"Waiting for clients to stop using load balancer %q", previous.Name)
+		return nil
+	}
+	if time.Since(condition.LastTransitionTime.Time) < endpointPropagationDelay {
+		conditions.MarkFalse(infraCluster, infrav1.LoadBalancerSchemeReadyCondition, infrav1.WaitForEndpointPropagationReason, clusterv1.ConditionSeverityInfo,
+			"Waiting for clients to stop using load balancer %q", previous.Name)
+		return nil
+	}
+
+	if err := s.deleteReplacedLB(previous); err != nil {
+		return err
+	}
+	conditions.MarkTrue(infraCluster, infrav1.LoadBalancerSchemeReadyCondition)
+	return nil
+}
+
+// registerReplacementTargets registers the control plane instances of the replaced load balancer with its
+// replacement. It returns true once at least as many of them are healthy in the replacement.
+func (s *Service) registerReplacementTargets(lb, previous *infrav1.LoadBalancer) (bool, error) {
+	previousTargets, err := s.describeAPIServerTargetHealth(previous)
+	if err != nil {
+		return false, err
+	}
+	targets, err := s.describeAPIServerTargetHealth(lb)
+	if err != nil {
+		return false, err
+	}
+
+	for id := range previousTargets {
+		if _, ok := targets[id]; ok {
+			continue
+		}
+		if err := s.registerInstanceWithLB(lb, id); err != nil {
+			return false, err
+		}
+		s.scope.Debug("Registered control plane instance with replacement load balancer", "instance-id", id, "api-server-elb-name", lb.Name)
+	}
+
+	return countHealthyTargets(targets) > 0 && countHealthyTargets(targets) >= countHealthyTargets(previousTargets), nil
+}
+
+// describeAPIServerTargetHealth returns the health of the targets of the API server target group of a
+// load balancer, keyed by instance ID.
+func (s *Service) describeAPIServerTargetHealth(lb *infrav1.LoadBalancer) (map[string]string, error) {
+	targetGroups, err := s.ELBV2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String(lb.ARN),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error describing ELB's target groups %q", lb.Name)
+	}
+
+	health := map[string]string{}
+	for _, tg := range targetGroups.TargetGroups {
+		if aws.Int64Value(tg.Port) != infrav1.DefaultAPIServerPort {
+			continue
+		}
+		out, err := s.ELBV2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: tg.TargetGroupArn,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "error describing ELB's target groups health %q", lb.Name)
+		}
+		for _, target := range out.TargetHealthDescriptions {
+			health[aws.StringValue(target.Target.Id)] = aws.StringValue(target.TargetHealth.State)
+		}
+	}
+	return health, nil
+}
+
+func countHealthyTargets(health map[string]string) int {
+	healthy := 0
+	for _, state := range health {
+		if state == elbv2.TargetHealthStateEnumHealthy {
+			healthy++
+		}
+	}
+	return healthy
+}
+
+// deleteReplacedLB deletes a load balancer replaced after a change of scheme, along with its Shield protection.
+func (s *Service) deleteReplacedLB(lb *infrav1.LoadBalancer) error {
+	if s.scope.ControlPlaneLoadBalancer().ShieldAdvanced {
+		out, err := s.ShieldClient.DescribeProtection(&shield.DescribeProtectionInput{
+			ResourceArn: aws.String(lb.ARN),
+		})
+		switch {
+		case err == nil && out.Protection != nil:
+			if err := s.deleteShieldProtection(lb.Name, aws.StringValue(out.Protection.Id)); err != nil {
+				return err
+			}
+		case err != nil && !isShieldNotFound(err):
+			return errors.Wrapf(err, "failed to describe Shield protection of load balancer %q", lb.Name)
+		}
+	}
+
+	if err := s.deleteLB(lb.ARN); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteReplacedLoadBalancer", "Failed to delete replaced load balancer %q: %v", lb.Name, err)
+		return errors.Wrapf(err, "failed to delete replaced load balancer %q", lb.Name)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteReplacedLoadBalancer", "Deleted load balancer %q replaced by a load balancer of scheme %q", lb.Name, s.scope.ControlPlaneLoadBalancerScheme())
+	return nil
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestSchemeLBName(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"}},
+		AWSCluster: &infrav1.AWSCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"}},
+		Client:     fake.NewClientBuilder().WithScheme(scheme).Build(),
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	defaultName, err := LBName(clusterScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	internal, err := schemeLBName(clusterScope, infrav1.ELBSchemeInternal)
+	g.Expect(err).NotTo(HaveOccurred())
+	internetFacing, err := schemeLBName(clusterScope, infrav1.ELBSchemeInternetFacing)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(internal).To(Equal("foo-bar-int-apiserver"))
+	g.Expect(internetFacing).To(Equal("foo-bar-ext-apiserver"))
+	g.Expect(defaultName).NotTo(BeElementOf(internal, internetFacing))
+}
+
+func TestReconcileLBReplacement(t *testing.T) {
+	const (
+		hostedZoneID = "Z0123456789"
+		recordName   = "api.cluster.example.com"
+		lbARN        = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/foo-bar-int-apiserver/abc"
+		lbDNSName    = "foo-bar-int-apiserver.elb.us-east-1.amazonaws.com"
+		prevARN      = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/foo-bar-apiserver/def"
+		prevDNSName  = "foo-bar-apiserver.elb.us-east-1.amazonaws.com"
+		lbTGARN      = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/new/abc"
+		prevTGARN    = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/old/def"
+	)
+
+	lb := &infrav1.LoadBalancer{ARN: lbARN, Name: "foo-bar-int-apiserver", DNSName: lbDNSName, Scheme: infrav1.ELBSchemeInternal}
+	previous := &infrav1.LoadBalancer{ARN: prevARN, Name: "foo-bar-apiserver", DNSName: prevDNSName, Scheme: infrav1.ELBSchemeInternetFacing}
+
+	recordPointingTo := func(dnsName string) *route53.ListResourceRecordSetsOutput {
+		return &route53.ListResourceRecordSetsOutput{
+			ResourceRecordSets: []*route53.ResourceRecordSet{
+				{
+					Name:            aws.String(recordName + "."),
+					Type:            aws.String(route53.RRTypeCname),
+					ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(dnsName)}},
+				},
+			},
+		}
+	}
+	upsertTo := func(dnsName string) *route53.ChangeResourceRecordSetsInput {
+		return &route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: aws.String(hostedZoneID),
+			ChangeBatch: &route53.ChangeBatch{
+				Comment: aws.String("Control plane endpoint of cluster bar"),
+				Changes: []*route53.Change{
+					{
+						Action: aws.String(route53.ChangeActionUpsert),
+						ResourceRecordSet: &route53.ResourceRecordSet{
+							Name:            aws.String(recordName),
+							Type:            aws.String(route53.RRTypeCname),
+							TTL:             aws.Int64(endpointRecordTTL),
+							ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(dnsName)}},
+						},
+					},
+				},
+			},
+		}
+	}
+	targetGroups := func(m *mocks.MockELBV2APIMockRecorder, lbARN, tgARN string) {
+		m.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(lbARN)}).
+			Return(&elbv2.DescribeTargetGroupsOutput{
+				TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String(tgARN), Port: aws.Int64(infrav1.DefaultAPIServerPort)}},
+			}, nil).AnyTimes()
+	}
+	targetHealth := func(m *mocks.MockELBV2APIMockRecorder, tgARN string, states map[string]string) {
+		out := &elbv2.DescribeTargetHealthOutput{}
+		for id, state := range states {
+			out.TargetHealthDescriptions = append(out.TargetHealthDescriptions, &elbv2.TargetHealthDescription{
+				Target:       &elbv2.TargetDescription{Id: aws.String(id)},
+				TargetHealth: &elbv2.TargetHealth{State: aws.String(state)},
+			})
+		}
+		m.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgARN)}).Return(out, nil)
+	}
+
+	tests := []struct {
+		name              string
+		previous          *infrav1.LoadBalancer
+		condition         *clusterv1.Condition
+		elbV2Mocks        func(m *mocks.MockELBV2APIMockRecorder)
+		route53Mocks      func(m *mocks.MockRoute53APIMockRecorder)
+		expectedCondition *clusterv1.Condition
+	}{
+		{
+			name: "points the endpoint record to the load balancer",
+			route53Mocks: func(m *mocks.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{}, nil)
+				m.ChangeResourceRecordSets(upsertTo(lbDNSName)).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+			},
+		},
+		{
+			name:     "registers the control plane instances with the replacement and waits for them to be healthy",
+			previous: previous,
+			elbV2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				targetGroups(m, prevARN, prevTGARN)
+				targetGroups(m, lbARN, lbTGARN)
+				targetHealth(m, prevTGARN, map[string]string{"i-1": elbv2.TargetHealthStateEnumHealthy})
+				targetHealth(m, lbTGARN, map[string]string{})
+				m.RegisterTargets(&elbv2.RegisterTargetsInput{
+					TargetGroupArn: aws.String(lbTGARN),
+					Targets:        []*elbv2.TargetDescription{{Id: aws.String("i-1"), Port: aws.Int64(infrav1.DefaultAPIServerPort)}},
+				}).Return(&elbv2.RegisterTargetsOutput{}, nil)
+			},
+			route53Mocks: func(m *mocks.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(recordPointingTo(prevDNSName), nil)
+			},
+			expectedCondition: conditions.FalseCondition(infrav1.LoadBalancerSchemeReadyCondition, infrav1.WaitForReplacementTargetsReason, clusterv1.ConditionSeverityInfo, ""),
+		},
+		{
+			name:     "moves the endpoint record once the control plane instances are healthy",
+			previous: previous,
+			elbV2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				targetGroups(m, prevARN, prevTGARN)
+				targetGroups(m, lbARN, lbTGARN)
+				targetHealth(m, prevTGARN, map[string]string{"i-1": elbv2.TargetHealthStateEnumHealthy})
+				targetHealth(m, lbTGARN, map[string]string{"i-1": elbv2.TargetHealthStateEnumHealthy})
+			},
+			route53Mocks: func(m *mocks.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(recordPointingTo(prevDNSName), nil).Times(2)
+				m.ChangeResourceRecordSets(upsertTo(lbDNSName)).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+			},
+			expectedCondition: conditions.FalseCondition(infrav1.LoadBalancerSchemeReadyCondition, infrav1.WaitForEndpointPropagationReason, clusterv1.ConditionSeverityInfo, ""),
+		},
+		{
+			name:      "keeps the replaced load balancer until the endpoint record propagated",
+			previous:  previous,
+			condition: conditions.FalseCondition(infrav1.LoadBalancerSchemeReadyCondition, infrav1.WaitForEndpointPropagationReason, clusterv1.ConditionSeverityInfo, ""),
+			route53Mocks: func(m *mocks.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(recordPointingTo(lbDNSName), nil)
+			},
+			expectedCondition: conditions.FalseCondition(infrav1.LoadBalancerSchemeReadyCondition, infrav1.WaitForEndpointPropagationReason, clusterv1.ConditionSeverityInfo, ""),
+		},
+		{
+			name:     "deletes the replaced load balancer once the endpoint record propagated",
+			previous: previous,
+			condition: &clusterv1.Condition{
+				Type:               infrav1.LoadBalancerSchemeReadyCondition,
+				Status:             "False",
+				Severity:           clusterv1.ConditionSeverityInfo,
+				Reason:             infrav1.WaitForEndpointPropagationReason,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-endpointPropagationDelay)),
+			},
+			elbV2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				targetGroups(m, prevARN, prevTGARN)
+				m.DescribeListeners(&elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(prevARN)}).
+					Return(&elbv2.DescribeListenersOutput{}, nil)
+				m.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(prevTGARN)}).
+					Return(&elbv2.DeleteTargetGroupOutput{}, nil)
+				m.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(prevARN)}).
+					Return(&elbv2.DeleteLoadBalancerOutput{}, nil)
+			},
+			route53Mocks: func(m *mocks.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(recordPointingTo(lbDNSName), nil)
+			},
+			expectedCondition: conditions.TrueCondition(infrav1.LoadBalancerSchemeReadyCondition),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbV2Mock := mocks.NewMockELBV2API(mockCtrl)
+			route53Mock := mocks.NewMockRoute53API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"},
+				Spec: infrav1.AWSClusterSpec{
+					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+						Scheme:           &infrav1.ELBSchemeInternal,
+						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+						Route53:          &infrav1.Route53EndpointSpec{HostedZoneID: hostedZoneID, RecordName: recordName},
+					},
+				},
+			}
+			if tc.condition != nil {
+				conditions.Set(awsCluster, tc.condition)
+			}
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"}},
+				AWSCluster: awsCluster,
+				Client:     fake.NewClientBuilder().WithScheme(scheme).Build(),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if tc.elbV2Mocks != nil {
+				tc.elbV2Mocks(elbV2Mock.EXPECT())
+			}
+			if tc.route53Mocks != nil {
+				tc.route53Mocks(route53Mock.EXPECT())
+			}
+
+			s := &Service{
+				scope:         clusterScope,
+				ELBV2Client:   elbV2Mock,
+				Route53Client: route53Mock,
+			}
+
+			g.Expect(s.reconcileLBReplacement(lb, tc.previous)).To(Succeed())
+			if tc.expectedCondition == nil {
+				g.Expect(conditions.Has(awsCluster, infrav1.LoadBalancerSchemeReadyCondition)).To(BeFalse())
+				return
+			}
+			condition := conditions.Get(awsCluster, infrav1.LoadBalancerSchemeReadyCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedCondition.Status))
+			g.Expect(condition.Reason).To(Equal(tc.expectedCondition.Reason))
+		})
+	}
+}
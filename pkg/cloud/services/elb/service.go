@@ -21,6 +21,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/shield/shieldiface"
+	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 )
@@ -34,6 +37,9 @@ type Service struct {
 	ELBClient             elbiface.ELBAPI
 	ELBV2Client           elbv2iface.ELBV2API
 	ResourceTaggingClient resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+	WAFv2Client           wafv2iface.WAFV2API
+	ShieldClient          shieldiface.ShieldAPI
+	Route53Client         route53iface.Route53API
 }
 
 // NewService returns a new service given the api clients.
@@ -44,5 +50,8 @@ func NewService(elbScope scope.ELBScope) *Service {
 		ELBClient:             scope.NewELBClient(elbScope, elbScope, elbScope, elbScope.InfraCluster()),
 		ELBV2Client:           scope.NewELBv2Client(elbScope, elbScope, elbScope, elbScope.InfraCluster()),
 		ResourceTaggingClient: scope.NewResourgeTaggingClient(elbScope, elbScope, elbScope, elbScope.InfraCluster()),
+		WAFv2Client:           scope.NewWAFv2Client(elbScope, elbScope, elbScope, elbScope.InfraCluster()),
+		ShieldClient:          scope.NewShieldClient(elbScope, elbScope, elbScope, elbScope.InfraCluster()),
+		Route53Client:         scope.NewRoute53Client(elbScope, elbScope, elbScope, elbScope.InfraCluster()),
 	}
 }
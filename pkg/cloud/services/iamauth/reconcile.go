@@ -39,7 +39,7 @@ import (
 func (s *Service) ReconcileIAMAuthenticator(ctx context.Context) error {
 	s.scope.Info("Reconciling aws-iam-authenticator configuration", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
 
-	remoteClient, err := s.scope.RemoteClient()
+	remoteClient, err := s.scope.RemoteClient(ctx)
 	if err != nil {
 		s.scope.Error(err, "getting client for remote cluster")
 		return fmt.Errorf("getting client for remote cluster: %w", err)
@@ -116,6 +116,9 @@ func (s *Service) getRolesForWorkers(ctx context.Context) (map[string]struct{},
 	if err := s.getRolesForMachinePools(ctx, allRoles); err != nil {
 		return nil, fmt.Errorf("failed to get roles from machine pools %w", err)
 	}
+	if karpenter := s.scope.KarpenterStatus(); karpenter != nil && karpenter.NodeRoleName != "" {
+		allRoles[karpenter.NodeRoleName] = struct{}{}
+	}
 	return allRoles, nil
 }
 
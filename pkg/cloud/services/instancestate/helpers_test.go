@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -42,3 +43,19 @@ func setupCluster(clusterName string) (*scope.ClusterScope, error) {
 		Client:     client,
 	})
 }
+
+func setupManagedControlPlane(clusterName string) (*scope.ManagedControlPlaneScope, error) {
+	scheme := runtime.NewScheme()
+	_ = ekscontrolplanev1.AddToScheme(scheme)
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).Build()
+	return scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		},
+		ControlPlane: controlPlane,
+		Client:       client,
+	})
+}
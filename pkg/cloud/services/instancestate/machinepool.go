@@ -0,0 +1,365 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/pkg/errors"
+
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+)
+
+const (
+	// AutoScalingTerminateLifecycleAction defines the notification an ASG sends when a terminate lifecycle hook fires.
+	AutoScalingTerminateLifecycleAction = "EC2 Instance-terminate Lifecycle Action"
+	// AutoScalingTerminateSuccessful defines the notification an ASG sends once it has terminated an instance.
+	AutoScalingTerminateSuccessful = "EC2 Instance Terminate Successful"
+	// Ec2SpotInterruptionWarning defines the notification EC2 sends two minutes before reclaiming a spot instance.
+	Ec2SpotInterruptionWarning = "EC2 Spot Instance Interruption Warning"
+	// Ec2InstanceRebalanceRecommendation defines the notification EC2 sends when a spot instance is at an
+	// elevated risk of being interrupted.
+	Ec2InstanceRebalanceRecommendation = "EC2 Instance Rebalance Recommendation"
+)
+
+// ReconcileMachinePoolEvents creates the rules forwarding ASG lifecycle, spot
+// interruption and rebalance recommendation events for machine pool instances to the cluster's queue. The rules
+// start disabled and are enabled as machine pools get added to their patterns.
+func (s Service) ReconcileMachinePoolEvents() error {
+	return s.reconcileEventRules(map[string]eventPattern{
+		s.getASGRuleName():  asgEventPattern(),
+		s.getSpotRuleName(): spotEventPattern(),
+	})
+}
+
+// ReconcileSpotInterruptionEvents creates the rule forwarding spot interruption and
+// rebalance recommendation events of spot instances to the cluster's queue. The rule starts disabled and is enabled
+// as spot instances get added to its pattern.
+func (s Service) ReconcileSpotInterruptionEvents() error {
+	return s.reconcileEventRules(map[string]eventPattern{
+		s.getSpotRuleName(): spotEventPattern(),
+	})
+}
+
+// DeleteSpotInterruptionEvents deletes the rule created by ReconcileSpotInterruptionEvents.
+func (s Service) DeleteSpotInterruptionEvents() error {
+	return s.deleteRule(s.getSpotRuleName())
+}
+
+// reconcileEventRules makes sure the rules exist, target the cluster's queue and are
+// allowed to send messages to it.
+func (s Service) reconcileEventRules(rules map[string]eventPattern) error {
+	queueName := GenerateQueueName(s.scope.Name())
+	queueURLResp, err := s.SQSClient.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName: aws.String(queueName),
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to get queue URL")
+	}
+	queueAttrs, err := s.SQSClient.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		AttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameQueueArn, sqs.QueueAttributeNamePolicy}),
+		QueueUrl:       queueURLResp.QueueUrl,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to get queue attributes")
+	}
+	queueArn := aws.StringValue(queueAttrs.Attributes[sqs.QueueAttributeNameQueueArn])
+	policy := aws.StringValue(queueAttrs.Attributes[sqs.QueueAttributeNamePolicy])
+
+	ruleNames := make([]string, 0, len(rules))
+	for ruleName := range rules {
+		ruleNames = append(ruleNames, ruleName)
+	}
+	sort.Strings(ruleNames)
+
+	updatedPolicy := policy
+	for _, ruleName := range ruleNames {
+		ruleArn, err := s.reconcileEventRule(ruleName, rules[ruleName], queueName, queueArn)
+		if err != nil {
+			return err
+		}
+		updatedPolicy, err = addRuleToQueuePolicy(updatedPolicy, ruleName, queueName, queueArn, ruleArn)
+		if err != nil {
+			return err
+		}
+	}
+
+	if updatedPolicy == policy {
+		return nil
+	}
+	_, err = s.SQSClient.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+		QueueUrl:   queueURLResp.QueueUrl,
+		Attributes: aws.StringMap(map[string]string{sqs.QueueAttributeNamePolicy: updatedPolicy}),
+	})
+	return errors.Wrap(err, "unable to update queue attributes")
+}
+
+func asgEventPattern() eventPattern {
+	return eventPattern{
+		Source:     []string{"aws.autoscaling"},
+		DetailType: []string{AutoScalingTerminateLifecycleAction, AutoScalingTerminateSuccessful},
+	}
+}
+
+func spotEventPattern() eventPattern {
+	return eventPattern{
+		Source:     []string{"aws.ec2"},
+		DetailType: []string{Ec2SpotInterruptionWarning, Ec2InstanceRebalanceRecommendation},
+	}
+}
+
+// DeleteMachinePoolEvents deletes the rules created by ReconcileMachinePoolEvents.
+func (s Service) DeleteMachinePoolEvents() error {
+	if err := s.deleteRule(s.getASGRuleName()); err != nil {
+		return err
+	}
+	return s.deleteRule(s.getSpotRuleName())
+}
+
+// AddAutoScalingGroupToEventPattern will add an ASG to the lifecycle event pattern.
+func (s Service) AddAutoScalingGroupToEventPattern(asgName string) error {
+	return s.updateEventPatternDetail(s.getASGRuleName(), func(d *eventDetail) bool {
+		for _, name := range d.AutoScalingGroupNames {
+			if name == asgName {
+				return false
+			}
+		}
+		d.AutoScalingGroupNames = append(d.AutoScalingGroupNames, asgName)
+		return true
+	})
+}
+
+// RemoveAutoScalingGroupFromEventPattern attempts a best effort update to the lifecycle
+// event rule to remove the ASG. Any errors encountered won't be blocking.
+func (s Service) RemoveAutoScalingGroupFromEventPattern(asgName string) {
+	_ = s.updateEventPatternDetail(s.getASGRuleName(), func(d *eventDetail) bool {
+		names, removed := removeValues(d.AutoScalingGroupNames, []string{asgName})
+		d.AutoScalingGroupNames = names
+		return removed
+	})
+}
+
+// UpdateSpotInstancesInEventPattern will add and remove instances from the spot
+// interruption event pattern.
+func (s Service) UpdateSpotInstancesInEventPattern(added, removed []string) error {
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+	return s.updateEventPatternDetail(s.getSpotRuleName(), func(d *eventDetail) bool {
+		ids, changed := removeValues(d.InstanceIDs, removed)
+		for _, id := range added {
+			if !containsValue(ids, id) {
+				ids = append(ids, id)
+				changed = true
+			}
+		}
+		d.InstanceIDs = ids
+		return changed
+	})
+}
+
+// reconcileEventRule makes sure the rule exists and targets the queue, and returns its ARN.
+func (s Service) reconcileEventRule(ruleName string, pattern eventPattern, queueName, queueArn string) (string, error) {
+	var ruleArn string
+	ruleResp, err := s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{
+		Name: aws.String(ruleName),
+	})
+	switch {
+	case err == nil:
+		ruleArn = aws.StringValue(ruleResp.Arn)
+		if err := s.updateEventPatternDetailTypes(ruleResp, pattern.DetailType); err != nil {
+			return "", err
+		}
+	case awserrors.IsNotFound(err):
+		data, err := json.Marshal(pattern)
+		if err != nil {
+			return "", err
+		}
+		putResp, err := s.EventBridgeClient.PutRule(&eventbridge.PutRuleInput{
+			Name:         aws.String(ruleName),
+			EventPattern: aws.String(string(data)),
+			State:        aws.String(eventbridge.RuleStateDisabled),
+		})
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to create rule %s", ruleName)
+		}
+		ruleArn = aws.StringValue(putResp.RuleArn)
+	default:
+		return "", errors.Wrapf(err, "unable to describe rule %s", ruleName)
+	}
+
+	targetsResp, err := s.EventBridgeClient.ListTargetsByRule(&eventbridge.ListTargetsByRuleInput{
+		Rule: aws.String(ruleName),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to list targets for rule %s", ruleName)
+	}
+	for _, target := range targetsResp.Targets {
+		if aws.StringValue(target.Id) == queueName && aws.StringValue(target.Arn) == queueArn {
+			return ruleArn, nil
+		}
+	}
+
+	_, err = s.EventBridgeClient.PutTargets(&eventbridge.PutTargetsInput{
+		Rule: aws.String(ruleName),
+		Targets: []*eventbridge.Target{{
+			Arn: aws.String(queueArn),
+			Id:  aws.String(queueName),
+		}},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to add SQS target %s to rule %s", queueName, ruleName)
+	}
+	return ruleArn, nil
+}
+
+// updateEventPatternDetailTypes makes sure an existing rule matches the detail types of its
+// pattern, e.g. when event types were added to the pattern after the rule was created. The
+// detail and the state of the rule are kept.
+func (s Service) updateEventPatternDetailTypes(rule *eventbridge.DescribeRuleOutput, detailTypes []string) error {
+	if rule.EventPattern == nil {
+		return nil
+	}
+	e := eventPattern{}
+	if err := json.Unmarshal([]byte(aws.StringValue(rule.EventPattern)), &e); err != nil {
+		return err
+	}
+	if reflect.DeepEqual(e.DetailType, detailTypes) {
+		return nil
+	}
+
+	e.DetailType = detailTypes
+	eventData, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = s.EventBridgeClient.PutRule(&eventbridge.PutRuleInput{
+		Name:         rule.Name,
+		EventPattern: aws.String(string(eventData)),
+		State:        rule.State,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to update rule %s", aws.StringValue(rule.Name))
+	}
+	return nil
+}
+
+// updateEventPatternDetail applies update to the detail of the rule's event pattern and
+// saves the rule if it changed. The rule is disabled once nothing is left to match on.
+func (s Service) updateEventPatternDetail(ruleName string, update func(d *eventDetail) bool) error {
+	ruleResp, err := s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{
+		Name: aws.String(ruleName),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to describe rule %s", ruleName)
+	}
+	e := eventPattern{}
+	if err := json.Unmarshal([]byte(aws.StringValue(ruleResp.EventPattern)), &e); err != nil {
+		return err
+	}
+	if e.EventDetail == nil {
+		e.EventDetail = &eventDetail{}
+	}
+	if !update(e.EventDetail) {
+		return nil
+	}
+
+	state := eventbridge.RuleStateEnabled
+	if len(e.EventDetail.InstanceIDs) == 0 && len(e.EventDetail.AutoScalingGroupNames) == 0 {
+		e.EventDetail = nil
+		state = eventbridge.RuleStateDisabled
+	}
+	eventData, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = s.EventBridgeClient.PutRule(&eventbridge.PutRuleInput{
+		Name:         aws.String(ruleName),
+		EventPattern: aws.String(string(eventData)),
+		State:        aws.String(state),
+	})
+	return err
+}
+
+func (s Service) getASGRuleName() string {
+	return fmt.Sprintf("%s-asg-rule", s.scope.Name())
+}
+
+func (s Service) getSpotRuleName() string {
+	return fmt.Sprintf("%s-spot-rule", s.scope.Name())
+}
+
+// addRuleToQueuePolicy returns the queue policy with a statement allowing the rule to
+// send messages to the queue, leaving the policy untouched if the statement already exists.
+func addRuleToQueuePolicy(policy, ruleName, queueName, queueArn, ruleArn string) (string, error) {
+	statement := ruleStatement(ruleName, queueName, queueArn, ruleArn)
+	if strings.Contains(policy, fmt.Sprintf("%q", statement.Sid)) {
+		return policy, nil
+	}
+
+	doc := map[string]interface{}{}
+	if policy != "" {
+		if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+			return "", errors.Wrap(err, "unable to JSON unmarshal queue policy")
+		}
+	} else {
+		doc["Version"] = iamv1.CurrentVersion
+		doc["Id"] = queueArn
+	}
+
+	var statements []interface{}
+	switch existing := doc["Statement"].(type) {
+	case []interface{}:
+		statements = existing
+	case map[string]interface{}:
+		statements = []interface{}{existing}
+	}
+	doc["Statement"] = append(statements, statement)
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to JSON marshal policy")
+	}
+	return string(data), nil
+}
+
+func removeValues(values, remove []string) ([]string, bool) {
+	kept := make([]string, 0, len(values))
+	for _, v := range values {
+		if !containsValue(remove, v) {
+			kept = append(kept, v)
+		}
+	}
+	return kept, len(kept) != len(values)
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,345 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_eventbridgeiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_sqsiface"
+)
+
+func TestReconcileMachinePoolEvents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	asgRuleName := "test-cluster-asg-rule"
+	spotRuleName := "test-cluster-spot-rule"
+	queueName := "test-cluster-queue"
+	existingPolicy := `{"Version":"2012-10-17","Id":"queue-arn","Statement":[{"Sid":"CAPAEvents_test-cluster-ec2-rule_test-cluster-queue"}]}`
+
+	testCases := []struct {
+		name              string
+		eventBridgeExpect func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder)
+		sqsExpect         func(m *mock_sqsiface.MockSQSAPIMockRecorder)
+		managed           bool
+		expectErr         bool
+	}{
+		{
+			name: "creates missing rules and allows them to send to the queue",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				for _, ruleName := range []string{asgRuleName, spotRuleName} {
+					m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+						Name: aws.String(ruleName),
+					})).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
+					m.PutRule(gomock.Any()).DoAndReturn(func(input *eventbridge.PutRuleInput) (*eventbridge.PutRuleOutput, error) {
+						if aws.StringValue(input.State) != eventbridge.RuleStateDisabled {
+							t.Fatalf("expected rule %s to be created disabled", aws.StringValue(input.Name))
+						}
+						return &eventbridge.PutRuleOutput{RuleArn: aws.String(aws.StringValue(input.Name) + "-arn")}, nil
+					})
+					m.ListTargetsByRule(gomock.Eq(&eventbridge.ListTargetsByRuleInput{
+						Rule: aws.String(ruleName),
+					})).Return(&eventbridge.ListTargetsByRuleOutput{}, nil)
+					m.PutTargets(gomock.Eq(&eventbridge.PutTargetsInput{
+						Rule: aws.String(ruleName),
+						Targets: []*eventbridge.Target{{
+							Arn: aws.String("queue-arn"),
+							Id:  aws.String(queueName),
+						}},
+					})).Return(&eventbridge.PutTargetsOutput{}, nil)
+				}
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.Any()).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("test-cluster-queue-url")}, nil)
+				m.GetQueueAttributes(gomock.Any()).Return(&sqs.GetQueueAttributesOutput{
+					Attributes: aws.StringMap(map[string]string{
+						sqs.QueueAttributeNameQueueArn: "queue-arn",
+						sqs.QueueAttributeNamePolicy:   existingPolicy,
+					}),
+				}, nil)
+				m.SetQueueAttributes(gomock.Any()).DoAndReturn(func(input *sqs.SetQueueAttributesInput) (*sqs.SetQueueAttributesOutput, error) {
+					policy := aws.StringValue(input.Attributes[sqs.QueueAttributeNamePolicy])
+					for _, sid := range []string{"CAPAEvents_test-cluster-ec2-rule", "CAPAEvents_" + asgRuleName, "CAPAEvents_" + spotRuleName} {
+						if !strings.Contains(policy, sid) {
+							t.Fatalf("expected policy to contain statement %s, got %s", sid, policy)
+						}
+					}
+					return &sqs.SetQueueAttributesOutput{}, nil
+				})
+			},
+		},
+		{
+			name: "does nothing when rules, targets and policy already exist",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				for _, ruleName := range []string{asgRuleName, spotRuleName} {
+					m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+						Name: aws.String(ruleName),
+					})).Return(&eventbridge.DescribeRuleOutput{Name: aws.String(ruleName), Arn: aws.String(ruleName + "-arn")}, nil)
+					m.ListTargetsByRule(gomock.Eq(&eventbridge.ListTargetsByRuleInput{
+						Rule: aws.String(ruleName),
+					})).Return(&eventbridge.ListTargetsByRuleOutput{Targets: []*eventbridge.Target{{
+						Arn: aws.String("queue-arn"),
+						Id:  aws.String(queueName),
+					}}}, nil)
+				}
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.Any()).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("test-cluster-queue-url")}, nil)
+				m.GetQueueAttributes(gomock.Any()).Return(&sqs.GetQueueAttributesOutput{
+					Attributes: aws.StringMap(map[string]string{
+						sqs.QueueAttributeNameQueueArn: "queue-arn",
+						sqs.QueueAttributeNamePolicy: `{"Statement":[` +
+							`{"Sid":"CAPAEvents_test-cluster-asg-rule_test-cluster-queue"},` +
+							`{"Sid":"CAPAEvents_test-cluster-spot-rule_test-cluster-queue"}]}`,
+					}),
+				}, nil)
+			},
+		},
+		{
+			name: "adds missing event types to existing rules",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+					Name: aws.String(asgRuleName),
+				})).Return(&eventbridge.DescribeRuleOutput{
+					Name:         aws.String(asgRuleName),
+					Arn:          aws.String(asgRuleName + "-arn"),
+					EventPattern: aws.String(`{"source":["aws.autoscaling"],"detail-type":["EC2 Instance-terminate Lifecycle Action","EC2 Instance Terminate Successful"]}`),
+					State:        aws.String(eventbridge.RuleStateDisabled),
+				}, nil)
+				m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+					Name: aws.String(spotRuleName),
+				})).Return(&eventbridge.DescribeRuleOutput{
+					Name:         aws.String(spotRuleName),
+					Arn:          aws.String(spotRuleName + "-arn"),
+					EventPattern: aws.String(`{"source":["aws.ec2"],"detail-type":["EC2 Spot Instance Interruption Warning"],"detail":{"instance-id":["i-1"]}}`),
+					State:        aws.String(eventbridge.RuleStateEnabled),
+				}, nil)
+				m.PutRule(gomock.Eq(&eventbridge.PutRuleInput{
+					Name:         aws.String(spotRuleName),
+					EventPattern: aws.String(`{"source":["aws.ec2"],"detail-type":["EC2 Spot Instance Interruption Warning","EC2 Instance Rebalance Recommendation"],"detail":{"instance-id":["i-1"]}}`),
+					State:        aws.String(eventbridge.RuleStateEnabled),
+				})).Return(&eventbridge.PutRuleOutput{}, nil)
+				for _, ruleName := range []string{asgRuleName, spotRuleName} {
+					m.ListTargetsByRule(gomock.Eq(&eventbridge.ListTargetsByRuleInput{
+						Rule: aws.String(ruleName),
+					})).Return(&eventbridge.ListTargetsByRuleOutput{Targets: []*eventbridge.Target{{
+						Arn: aws.String("queue-arn"),
+						Id:  aws.String(queueName),
+					}}}, nil)
+				}
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.Any()).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("test-cluster-queue-url")}, nil)
+				m.GetQueueAttributes(gomock.Any()).Return(&sqs.GetQueueAttributesOutput{
+					Attributes: aws.StringMap(map[string]string{
+						sqs.QueueAttributeNameQueueArn: "queue-arn",
+						sqs.QueueAttributeNamePolicy: `{"Statement":[` +
+							`{"Sid":"CAPAEvents_test-cluster-asg-rule_test-cluster-queue"},` +
+							`{"Sid":"CAPAEvents_test-cluster-spot-rule_test-cluster-queue"}]}`,
+					}),
+				}, nil)
+			},
+		},
+		{
+			name:    "creates the rules of an EKS cluster",
+			managed: true,
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				for _, ruleName := range []string{asgRuleName, spotRuleName} {
+					m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+						Name: aws.String(ruleName),
+					})).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
+					m.PutRule(gomock.Any()).Return(&eventbridge.PutRuleOutput{RuleArn: aws.String(ruleName + "-arn")}, nil)
+					m.ListTargetsByRule(gomock.Any()).Return(&eventbridge.ListTargetsByRuleOutput{}, nil)
+					m.PutTargets(gomock.Any()).Return(&eventbridge.PutTargetsOutput{}, nil)
+				}
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.Eq(&sqs.GetQueueUrlInput{QueueName: aws.String(queueName)})).
+					Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("test-cluster-queue-url")}, nil)
+				m.GetQueueAttributes(gomock.Any()).Return(&sqs.GetQueueAttributesOutput{
+					Attributes: aws.StringMap(map[string]string{
+						sqs.QueueAttributeNameQueueArn: "queue-arn",
+						sqs.QueueAttributeNamePolicy:   existingPolicy,
+					}),
+				}, nil)
+				m.SetQueueAttributes(gomock.Any()).Return(&sqs.SetQueueAttributesOutput{}, nil)
+			},
+		},
+		{
+			name: "fails when the queue doesn't exist",
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.Any()).Return(nil, awserr.New(sqs.ErrCodeQueueDoesNotExist, "", nil))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			sqsMock := mock_sqsiface.NewMockSQSAPI(mockCtrl)
+			eventBridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+			var clusterScope scope.EC2Scope
+			var err error
+			if tc.managed {
+				clusterScope, err = setupManagedControlPlane("test-cluster")
+			} else {
+				clusterScope, err = setupCluster("test-cluster")
+			}
+			g.Expect(err).To(Not(HaveOccurred()))
+			if tc.eventBridgeExpect != nil {
+				tc.eventBridgeExpect(eventBridgeMock.EXPECT())
+			}
+			tc.sqsExpect(sqsMock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EventBridgeClient = eventBridgeMock
+			s.SQSClient = sqsMock
+
+			err = s.ReconcileMachinePoolEvents()
+			if tc.expectErr {
+				g.Expect(err).NotTo(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestMachinePoolEventPatterns(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	patternFor := func(e eventPattern) *string {
+		data, err := json.Marshal(e)
+		if err != nil {
+			t.Fatalf("got an unexpected error: %v", err)
+		}
+		return aws.String(string(data))
+	}
+	asgPattern := eventPattern{
+		Source:     []string{"aws.autoscaling"},
+		DetailType: []string{AutoScalingTerminateLifecycleAction, AutoScalingTerminateSuccessful},
+	}
+	spotPattern := eventPattern{
+		Source:     []string{"aws.ec2"},
+		DetailType: []string{Ec2SpotInterruptionWarning},
+	}
+
+	t.Run("adding an ASG enables the lifecycle rule", func(t *testing.T) {
+		g := NewWithT(t)
+		eventBridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+		clusterScope, err := setupCluster("test-cluster")
+		g.Expect(err).To(Not(HaveOccurred()))
+
+		expected := asgPattern
+		expected.EventDetail = &eventDetail{AutoScalingGroupNames: []string{"pool-asg"}}
+		eventBridgeMock.EXPECT().DescribeRule(gomock.Any()).Return(&eventbridge.DescribeRuleOutput{EventPattern: patternFor(asgPattern)}, nil)
+		eventBridgeMock.EXPECT().PutRule(gomock.Eq(&eventbridge.PutRuleInput{
+			Name:         aws.String("test-cluster-asg-rule"),
+			EventPattern: patternFor(expected),
+			State:        aws.String(eventbridge.RuleStateEnabled),
+		}))
+
+		s := NewService(clusterScope)
+		s.EventBridgeClient = eventBridgeMock
+		g.Expect(s.AddAutoScalingGroupToEventPattern("pool-asg")).To(Succeed())
+	})
+
+	t.Run("adding an already tracked ASG is a no-op", func(t *testing.T) {
+		g := NewWithT(t)
+		eventBridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+		clusterScope, err := setupCluster("test-cluster")
+		g.Expect(err).To(Not(HaveOccurred()))
+
+		existing := asgPattern
+		existing.EventDetail = &eventDetail{AutoScalingGroupNames: []string{"pool-asg"}}
+		eventBridgeMock.EXPECT().DescribeRule(gomock.Any()).Return(&eventbridge.DescribeRuleOutput{EventPattern: patternFor(existing)}, nil)
+
+		s := NewService(clusterScope)
+		s.EventBridgeClient = eventBridgeMock
+		g.Expect(s.AddAutoScalingGroupToEventPattern("pool-asg")).To(Succeed())
+	})
+
+	t.Run("adding an ASG fails with a rule not found error when the rule doesn't exist", func(t *testing.T) {
+		g := NewWithT(t)
+		eventBridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+		clusterScope, err := setupCluster("test-cluster")
+		g.Expect(err).To(Not(HaveOccurred()))
+
+		eventBridgeMock.EXPECT().DescribeRule(gomock.Any()).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
+
+		s := NewService(clusterScope)
+		s.EventBridgeClient = eventBridgeMock
+		err = s.AddAutoScalingGroupToEventPattern("pool-asg")
+		g.Expect(IsRuleNotFound(err)).To(BeTrue())
+		g.Expect(IsRuleNotFound(errors.New("unexpected"))).To(BeFalse())
+	})
+
+	t.Run("removing the last spot instance disables the spot rule", func(t *testing.T) {
+		g := NewWithT(t)
+		eventBridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+		clusterScope, err := setupCluster("test-cluster")
+		g.Expect(err).To(Not(HaveOccurred()))
+
+		existing := spotPattern
+		existing.EventDetail = &eventDetail{InstanceIDs: []string{"i-1"}}
+		eventBridgeMock.EXPECT().DescribeRule(gomock.Any()).Return(&eventbridge.DescribeRuleOutput{EventPattern: patternFor(existing)}, nil)
+		eventBridgeMock.EXPECT().PutRule(gomock.Eq(&eventbridge.PutRuleInput{
+			Name:         aws.String("test-cluster-spot-rule"),
+			EventPattern: patternFor(spotPattern),
+			State:        aws.String(eventbridge.RuleStateDisabled),
+		}))
+
+		s := NewService(clusterScope)
+		s.EventBridgeClient = eventBridgeMock
+		g.Expect(s.UpdateSpotInstancesInEventPattern(nil, []string{"i-1"})).To(Succeed())
+	})
+
+	t.Run("replacing spot instances keeps the spot rule enabled", func(t *testing.T) {
+		g := NewWithT(t)
+		eventBridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+		clusterScope, err := setupCluster("test-cluster")
+		g.Expect(err).To(Not(HaveOccurred()))
+
+		existing := spotPattern
+		existing.EventDetail = &eventDetail{InstanceIDs: []string{"i-1", "i-2"}}
+		expected := spotPattern
+		expected.EventDetail = &eventDetail{InstanceIDs: []string{"i-2", "i-3"}}
+		eventBridgeMock.EXPECT().DescribeRule(gomock.Any()).Return(&eventbridge.DescribeRuleOutput{EventPattern: patternFor(existing)}, nil)
+		eventBridgeMock.EXPECT().PutRule(gomock.Eq(&eventbridge.PutRuleInput{
+			Name:         aws.String("test-cluster-spot-rule"),
+			EventPattern: patternFor(expected),
+			State:        aws.String(eventbridge.RuleStateEnabled),
+		}))
+
+		s := NewService(clusterScope)
+		s.EventBridgeClient = eventBridgeMock
+		g.Expect(s.UpdateSpotInstancesInEventPattern([]string{"i-3"}, []string{"i-1"})).To(Succeed())
+	})
+}
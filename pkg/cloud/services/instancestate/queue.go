@@ -22,11 +22,11 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/pkg/errors"
 
 	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 )
 
 func (s *Service) reconcileSQSQueue() error {
@@ -38,12 +38,8 @@ func (s *Service) reconcileSQSQueue() error {
 		Attributes: aws.StringMap(attrs),
 	})
 
-	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			if aerr.Code() == sqs.ErrCodeQueueNameExists {
-				return nil
-			}
-		}
+	if code, _ := awserrors.Code(err); code == sqs.ErrCodeQueueNameExists {
+		return nil
 	}
 	return errors.Wrap(err, "unable to create new queue")
 }
@@ -70,16 +66,7 @@ func (s *Service) createPolicyForRule(input *createPolicyForRuleInput) error {
 		Version: iamv1.CurrentVersion,
 		ID:      input.QueueArn,
 		Statement: iamv1.Statements{
-			iamv1.StatementEntry{
-				Sid:       fmt.Sprintf("CAPAEvents_%s_%s", s.getEC2RuleName(), GenerateQueueName(s.scope.Name())),
-				Effect:    iamv1.EffectAllow,
-				Principal: iamv1.Principals{iamv1.PrincipalService: iamv1.PrincipalID{"events.amazonaws.com"}},
-				Action:    iamv1.Actions{"sqs:SendMessage"},
-				Resource:  iamv1.Resources{input.QueueArn},
-				Condition: iamv1.Conditions{
-					"ArnEquals": map[string]string{"aws:SourceArn": input.RuleArn},
-				},
-			},
+			ruleStatement(s.getEC2RuleName(), GenerateQueueName(s.scope.Name()), input.QueueArn, input.RuleArn),
 		},
 	}
 	policyData, err := json.Marshal(policy)
@@ -96,6 +83,20 @@ func (s *Service) createPolicyForRule(input *createPolicyForRuleInput) error {
 	return errors.Wrap(err, "unable to update queue attributes")
 }
 
+// ruleStatement returns a queue policy statement allowing the rule to send messages to the queue.
+func ruleStatement(ruleName, queueName, queueArn, ruleArn string) iamv1.StatementEntry {
+	return iamv1.StatementEntry{
+		Sid:       fmt.Sprintf("CAPAEvents_%s_%s", ruleName, queueName),
+		Effect:    iamv1.EffectAllow,
+		Principal: iamv1.Principals{iamv1.PrincipalService: iamv1.PrincipalID{"events.amazonaws.com"}},
+		Action:    iamv1.Actions{"sqs:SendMessage"},
+		Resource:  iamv1.Resources{queueArn},
+		Condition: iamv1.Conditions{
+			"ArnEquals": map[string]string{"aws:SourceArn": ruleArn},
+		},
+	}
+}
+
 // GenerateQueueName will generate a queue name.
 func GenerateQueueName(clusterName string) string {
 	adjusted := strings.ReplaceAll(clusterName, ".", "-")
@@ -103,12 +104,7 @@ func GenerateQueueName(clusterName string) string {
 }
 
 func queueNotFoundError(err error) bool {
-	if aerr, ok := err.(awserr.Error); ok {
-		if aerr.Code() == sqs.ErrCodeQueueDoesNotExist {
-			return true
-		}
-	}
-	return false
+	return awserrors.IsNotFound(err)
 }
 
 type createPolicyForRuleInput struct {
@@ -21,12 +21,12 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/eventbridge"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/pkg/errors"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 )
 
 // Ec2StateChangeNotification defines the EC2 instance's state change notification.
@@ -39,7 +39,7 @@ func (s Service) reconcileRules() error {
 		Name: aws.String(s.getEC2RuleName()),
 	})
 	if err != nil {
-		if resourceNotFoundError(err) {
+		if awserrors.IsNotFound(err) {
 			ruleNotFound = true
 		} else {
 			return errors.Wrapf(err, "unable to describe rule %s", s.getEC2RuleName())
@@ -145,18 +145,22 @@ func (s Service) createRule() error {
 }
 
 func (s Service) deleteRules() error {
+	return s.deleteRule(s.getEC2RuleName())
+}
+
+func (s Service) deleteRule(ruleName string) error {
 	_, err := s.EventBridgeClient.RemoveTargets(&eventbridge.RemoveTargetsInput{
-		Rule: aws.String(s.getEC2RuleName()),
+		Rule: aws.String(ruleName),
 		Ids:  aws.StringSlice([]string{GenerateQueueName(s.scope.Name())}),
 	})
-	if err != nil && !resourceNotFoundError(err) {
-		return errors.Wrapf(err, "unable to remove target %s for rule %s", GenerateQueueName(s.scope.Name()), s.getEC2RuleName())
+	if err != nil && !awserrors.IsNotFound(err) {
+		return errors.Wrapf(err, "unable to remove target %s for rule %s", GenerateQueueName(s.scope.Name()), ruleName)
 	}
 	_, err = s.EventBridgeClient.DeleteRule(&eventbridge.DeleteRuleInput{
-		Name: aws.String(s.getEC2RuleName()),
+		Name: aws.String(ruleName),
 	})
 
-	if err != nil && resourceNotFoundError(err) {
+	if err != nil && awserrors.IsNotFound(err) {
 		return nil
 	}
 
@@ -245,11 +249,9 @@ func (s Service) getEC2RuleName() string {
 	return fmt.Sprintf("%s-ec2-rule", s.scope.Name())
 }
 
-func resourceNotFoundError(err error) bool {
-	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eventbridge.ErrCodeResourceNotFoundException {
-		return true
-	}
-	return false
+// IsRuleNotFound returns true if the error was returned because an Event Bridge rule doesn't exist.
+func IsRuleNotFound(err error) bool {
+	return awserrors.IsNotFound(err)
 }
 
 type eventPattern struct {
@@ -259,6 +261,7 @@ type eventPattern struct {
 }
 
 type eventDetail struct {
-	InstanceIDs []string                `json:"instance-id,omitempty"`
-	States      []infrav1.InstanceState `json:"state,omitempty"`
+	InstanceIDs           []string                `json:"instance-id,omitempty"`
+	States                []infrav1.InstanceState `json:"state,omitempty"`
+	AutoScalingGroupNames []string                `json:"AutoScalingGroupName,omitempty"`
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package services
 
 import (
+	corev1 "k8s.io/api/core/v1"
+
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
@@ -44,7 +46,14 @@ type ASGInterface interface {
 	DeleteASGAndWait(id string) error
 	SuspendProcesses(name string, processes []string) error
 	ResumeProcesses(name string, processes []string) error
+	EnableMetricsCollection(name, granularity string, metrics []string) error
+	DisableMetricsCollection(name string, metrics []string) error
 	SubnetIDs(scope *scope.MachinePoolScope) ([]string, error)
+	ReconcileSuspendedAvailabilityZones(scope *scope.MachinePoolScope) error
+	ReconcileScalingPolicies(scope *scope.MachinePoolScope) error
+	DeleteScalingPolicyAlarms(scope *scope.MachinePoolScope) error
+	OutdatedInstances(scope *scope.MachinePoolScope, asg *expinfrav1.AutoScalingGroup) ([]infrav1.Instance, error)
+	TerminateASGInstance(scope *scope.MachinePoolScope, instanceID string, decrementDesiredCapacity bool) error
 }
 
 // EC2Interface encapsulates the methods exposed to the machine
@@ -52,6 +61,10 @@ type ASGInterface interface {
 type EC2Interface interface {
 	InstanceIfExists(id *string) (*infrav1.Instance, error)
 	TerminateInstance(id string) error
+	HibernateInstance(id string) error
+	RetainNonRootVolumes(instanceID string, volumes []infrav1.Volume) error
+	SnapshotVolumes(scope *scope.MachineScope, instanceID string) error
+	ApplyCleanupPolicy(scope *scope.MachineScope, instanceID string) error
 	CreateInstance(scope *scope.MachineScope, userData []byte, userDataFormat string) (*infrav1.Instance, error)
 	GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.Instance, error)
 
@@ -59,8 +72,11 @@ type EC2Interface interface {
 	GetCoreSecurityGroups(machine *scope.MachineScope) ([]string, error)
 	GetInstanceSecurityGroups(instanceID string) (map[string][]string, error)
 	UpdateInstanceSecurityGroups(id string, securityGroups []string) error
+	CheckSecurityGroupsPerNetworkInterface(count int) error
 	UpdateResourceTags(resourceID *string, create, remove map[string]string) error
 	ModifyInstanceMetadataOptions(instanceID string, options *infrav1.InstanceMetadataOptions) error
+	InstanceTypeCapacity(instanceType string) (corev1.ResourceList, error)
+	ValidateCapacityReservation(reservationID, instanceType string, instanceCount int64) error
 
 	TerminateInstanceAndWait(instanceID string) error
 	DetachSecurityGroupsFromNetworkInterface(groups []string, interfaceID string) error
@@ -106,6 +122,7 @@ type ELBInterface interface {
 // controller.
 type NetworkInterface interface {
 	DeleteNetwork() error
+	ObserveNetwork() (infrav1.Subnets, error)
 	ReconcileNetwork() error
 }
 
@@ -0,0 +1,231 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karpenter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+const (
+	maxIAMRoleNameLength = 64
+	nodeRoleSuffix       = "-karpenter-node"
+)
+
+var (
+	// ErrNodeRoleNotFound is returned when the Karpenter node role doesn't exist and can't be created.
+	ErrNodeRoleNotFound = errors.New("the Karpenter node role doesn't exist and creating it requires the EKSEnableIAM feature gate")
+	// ErrInstanceProfileNotFound is returned when the Karpenter instance profile doesn't exist and can't be created.
+	ErrInstanceProfileNotFound = errors.New("the Karpenter instance profile doesn't exist and creating it requires the EKSEnableIAM feature gate")
+)
+
+// NodeRolePolicies gives the policies attached to the Karpenter node role in the given partition.
+func NodeRolePolicies(partition string) []string {
+	return append(eks.NodegroupRolePolicies(partition),
+		fmt.Sprintf("arn:%s:iam::aws:policy/AmazonSSMManagedInstanceCore", partition),
+	)
+}
+
+// nodeRoleName returns the name of the Karpenter node role, defaulting it in the spec.
+func (s *Service) nodeRoleName() (string, error) {
+	spec := s.scope.ControlPlane.Spec.Karpenter
+	if spec.NodeRoleName == "" {
+		name, err := resourceName(s.scope.KubernetesClusterName(), nodeRoleSuffix, maxIAMRoleNameLength)
+		if err != nil {
+			return "", fmt.Errorf("generating Karpenter node role name: %w", err)
+		}
+		s.scope.Info("no Karpenter node role specified, using role based on cluster name", "role-name", name)
+		spec.NodeRoleName = name
+	}
+	return spec.NodeRoleName, nil
+}
+
+func (s *Service) reconcileNodeRole(roleName string) (*iam.Role, error) {
+	s.scope.Debug("Reconciling Karpenter node IAM role", "role-name", roleName)
+
+	role, err := s.GetIAMRole(roleName)
+	if err != nil {
+		if !awserrors.IsNotFound(err) {
+			return nil, fmt.Errorf("getting role %s: %w", roleName, err)
+		}
+
+		// If the disable IAM flag is used then the role must exist
+		if !s.scope.EnableIAM() {
+			return nil, fmt.Errorf("getting role %s: %w", roleName, ErrNodeRoleNotFound)
+		}
+
+		role, err = s.CreateRole(roleName, s.scope.Name(), eksiam.NodegroupTrustRelationship(), s.scope.AdditionalTags())
+		if err != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedIAMRoleCreation", "Failed to create Karpenter node IAM role %q: %v", roleName, err)
+			return nil, fmt.Errorf("creating role %s: %w", roleName, err)
+		}
+		record.Eventf(s.scope.ControlPlane, "SuccessfulIAMRoleCreation", "Created Karpenter node IAM role %q", roleName)
+	}
+
+	if s.IsUnmanaged(role, s.scope.Name()) {
+		s.scope.Debug("Skipping, Karpenter node role policy assignment as role is unmanaged")
+		return role, nil
+	}
+
+	if _, err := s.EnsurePoliciesAttached(role, aws.StringSlice(NodeRolePolicies(s.scope.Partition()))); err != nil {
+		return nil, fmt.Errorf("ensuring policies are attached to role %s: %w", roleName, err)
+	}
+
+	return role, nil
+}
+
+// reconcileInstanceProfile ensures the instance profile of the Karpenter nodes, named
+// after their role, exists and holds the role.
+func (s *Service) reconcileInstanceProfile(role *iam.Role) error {
+	name := aws.StringValue(role.RoleName)
+	s.scope.Debug("Reconciling Karpenter node instance profile", "instance-profile", name)
+
+	var profile *iam.InstanceProfile
+	out, err := s.IAMClient.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(name)})
+	switch {
+	case err == nil:
+		profile = out.InstanceProfile
+	case !awserrors.IsNotFound(err):
+		return fmt.Errorf("getting instance profile %s: %w", name, err)
+	case !s.scope.EnableIAM():
+		return fmt.Errorf("getting instance profile %s: %w", name, ErrInstanceProfileNotFound)
+	default:
+		input := &iam.CreateInstanceProfileInput{
+			InstanceProfileName: aws.String(name),
+			Tags:                eksiam.RoleTags(s.scope.Name(), s.scope.AdditionalTags()),
+		}
+		if s.Path != "" {
+			input.Path = aws.String(s.Path)
+		}
+		created, err := s.IAMClient.CreateInstanceProfile(input)
+		if err != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedIAMInstanceProfileCreation", "Failed to create Karpenter node instance profile %q: %v", name, err)
+			return fmt.Errorf("creating instance profile %s: %w", name, err)
+		}
+		record.Eventf(s.scope.ControlPlane, "SuccessfulIAMInstanceProfileCreation", "Created Karpenter node instance profile %q", name)
+		profile = created.InstanceProfile
+	}
+
+	for _, r := range profile.Roles {
+		if aws.StringValue(r.RoleName) == name {
+			return nil
+		}
+	}
+	if len(profile.Roles) > 0 {
+		// An instance profile holds a single role.
+		return fmt.Errorf("instance profile %s holds role %s instead of %s", name, aws.StringValue(profile.Roles[0].RoleName), name)
+	}
+
+	if _, err := s.IAMClient.AddRoleToInstanceProfile(&iam.AddRoleToInstanceProfileInput{
+		InstanceProfileName: aws.String(name),
+		RoleName:            aws.String(name),
+	}); err != nil {
+		return fmt.Errorf("adding role %s to instance profile %s: %w", name, name, err)
+	}
+
+	return nil
+}
+
+// deleteNodeRole deletes the Karpenter node role and instance profile, unless they
+// weren't created for the cluster.
+func (s *Service) deleteNodeRole(roleName string) error {
+	if roleName == "" {
+		return nil
+	}
+	if !s.scope.EnableIAM() {
+		s.scope.Debug("EKS IAM disabled, skipping deleting Karpenter node IAM role")
+		return nil
+	}
+
+	if err := s.deleteInstanceProfile(roleName); err != nil {
+		return err
+	}
+
+	s.scope.Debug("Deleting Karpenter node IAM role", "role-name", roleName)
+	role, err := s.GetIAMRole(roleName)
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			s.scope.Debug("Karpenter node IAM role already deleted")
+			return nil
+		}
+		return fmt.Errorf("getting role %s: %w", roleName, err)
+	}
+
+	if s.IsUnmanaged(role, s.scope.Name()) {
+		s.scope.Debug("Skipping, Karpenter node IAM role deletion as role is unmanaged")
+		return nil
+	}
+
+	if err := s.DeleteRole(roleName); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedIAMRoleDeletion", "Failed to delete Karpenter node IAM role %q: %v", roleName, err)
+		return err
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulIAMRoleDeletion", "Deleted Karpenter node IAM role %q", roleName)
+
+	return nil
+}
+
+func (s *Service) deleteInstanceProfile(name string) error {
+	out, err := s.IAMClient.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(name)})
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("getting instance profile %s: %w", name, err)
+	}
+	if !isOwned(out.InstanceProfile.Tags, s.scope.Name()) {
+		s.scope.Debug("Skipping, Karpenter node instance profile deletion as instance profile is unmanaged")
+		return nil
+	}
+
+	for _, r := range out.InstanceProfile.Roles {
+		if _, err := s.IAMClient.RemoveRoleFromInstanceProfile(&iam.RemoveRoleFromInstanceProfileInput{
+			InstanceProfileName: aws.String(name),
+			RoleName:            r.RoleName,
+		}); err != nil && !awserrors.IsNotFound(err) {
+			return fmt.Errorf("removing role %s from instance profile %s: %w", aws.StringValue(r.RoleName), name, err)
+		}
+	}
+
+	if _, err := s.IAMClient.DeleteInstanceProfile(&iam.DeleteInstanceProfileInput{InstanceProfileName: aws.String(name)}); err != nil && !awserrors.IsNotFound(err) {
+		record.Warnf(s.scope.ControlPlane, "FailedIAMInstanceProfileDeletion", "Failed to delete Karpenter node instance profile %q: %v", name, err)
+		return fmt.Errorf("deleting instance profile %s: %w", name, err)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulIAMInstanceProfileDeletion", "Deleted Karpenter node instance profile %q", name)
+
+	return nil
+}
+
+// isOwned returns whether the IAM tags mark a resource as owned by the cluster.
+func isOwned(tags []*iam.Tag, clusterName string) bool {
+	key := infrav1.ClusterAWSCloudProviderTagKey(clusterName)
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key && aws.StringValue(tag.Value) == string(infrav1.ResourceLifecycleOwned) {
+			return true
+		}
+	}
+	return false
+}
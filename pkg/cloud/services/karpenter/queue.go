@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karpenter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+)
+
+const (
+	maxQueueNameLength = 80
+	queueSuffix        = "-karpenter"
+
+	// queueMessageRetentionPeriod is the retention period of the interruption messages in seconds,
+	// after which they are of no use to Karpenter.
+	queueMessageRetentionPeriod = "300"
+)
+
+// queueName returns the name of the interruption queue of the cluster.
+func (s *Service) queueName() (string, error) {
+	return resourceName(s.scope.KubernetesClusterName(), queueSuffix, maxQueueNameLength)
+}
+
+// reconcileQueue ensures the interruption queue exists and that the EventBridge rules can send
+// messages to it, and returns its ARN.
+func (s *Service) reconcileQueue(queueName string) (string, error) {
+	s.scope.Debug("Reconciling Karpenter interruption queue", "queue", queueName)
+
+	var queueURL *string
+	out, err := s.SQSClient.CreateQueue(&sqs.CreateQueueInput{
+		QueueName: aws.String(queueName),
+		Attributes: aws.StringMap(map[string]string{
+			sqs.QueueAttributeNameMessageRetentionPeriod: queueMessageRetentionPeriod,
+			sqs.QueueAttributeNameSqsManagedSseEnabled:   "true",
+		}),
+		Tags: aws.StringMap(s.tags()),
+	})
+	switch code, _ := awserrors.Code(err); {
+	case err == nil:
+		queueURL = out.QueueUrl
+	case code == sqs.ErrCodeQueueNameExists:
+		// The queue exists with different attributes, e.g. edited by the user.
+		urlOut, err := s.SQSClient.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String(queueName)})
+		if err != nil {
+			return "", fmt.Errorf("getting URL of queue %s: %w", queueName, err)
+		}
+		queueURL = urlOut.QueueUrl
+	default:
+		return "", fmt.Errorf("creating queue %s: %w", queueName, err)
+	}
+
+	attrs, err := s.SQSClient.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		QueueUrl:       queueURL,
+		AttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameQueueArn, sqs.QueueAttributeNamePolicy}),
+	})
+	if err != nil {
+		return "", fmt.Errorf("getting attributes of queue %s: %w", queueName, err)
+	}
+	queueARN := aws.StringValue(attrs.Attributes[sqs.QueueAttributeNameQueueArn])
+
+	if attrs.Attributes[sqs.QueueAttributeNamePolicy] == nil {
+		policy, err := json.Marshal(queuePolicy(queueARN))
+		if err != nil {
+			return "", fmt.Errorf("marshalling policy of queue %s: %w", queueName, err)
+		}
+		if _, err := s.SQSClient.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+			QueueUrl:   queueURL,
+			Attributes: aws.StringMap(map[string]string{sqs.QueueAttributeNamePolicy: string(policy)}),
+		}); err != nil {
+			return "", fmt.Errorf("setting policy of queue %s: %w", queueName, err)
+		}
+	}
+
+	return queueARN, nil
+}
+
+func (s *Service) deleteQueue(queueName string) error {
+	s.scope.Debug("Deleting Karpenter interruption queue", "queue", queueName)
+
+	out, err := s.SQSClient.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String(queueName)})
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("getting URL of queue %s: %w", queueName, err)
+	}
+	if _, err := s.SQSClient.DeleteQueue(&sqs.DeleteQueueInput{QueueUrl: out.QueueUrl}); err != nil && !awserrors.IsNotFound(err) {
+		return fmt.Errorf("deleting queue %s: %w", queueName, err)
+	}
+
+	return nil
+}
+
+// queuePolicy returns the policy of the interruption queue, allowing EventBridge to send
+// messages to it and denying unencrypted connections.
+func queuePolicy(queueARN string) iamv1.PolicyDocument {
+	return iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		ID:      queueARN,
+		Statement: iamv1.Statements{
+			{
+				Sid:       "EC2InterruptionPolicy",
+				Effect:    iamv1.EffectAllow,
+				Principal: iamv1.Principals{iamv1.PrincipalService: iamv1.PrincipalID{"events.amazonaws.com", "sqs.amazonaws.com"}},
+				Action:    iamv1.Actions{"sqs:SendMessage"},
+				Resource:  iamv1.Resources{queueARN},
+			},
+			{
+				Sid:       "DenyHTTP",
+				Effect:    iamv1.EffectDeny,
+				Principal: iamv1.Principals{iamv1.PrincipalAWS: iamv1.PrincipalID{iamv1.Any}},
+				Action:    iamv1.Actions{"sqs:*"},
+				Resource:  iamv1.Resources{queueARN},
+				Condition: iamv1.Conditions{
+					"Bool": map[string]bool{"aws:SecureTransport": false},
+				},
+			},
+		},
+	}
+}
+
+// tags returns the tags of the interruption queue and rules.
+func (s *Service) tags() map[string]string {
+	tags := map[string]string{}
+	for k, v := range s.scope.AdditionalTags() {
+		tags[k] = v
+	}
+	tags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())] = string(infrav1.ResourceLifecycleOwned)
+	return tags
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karpenter
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+)
+
+// ReconcileKarpenter creates the AWS resources needed to install Karpenter into the cluster and
+// reports their identifiers in the status of the control plane. The resources are deleted when
+// Karpenter is disabled.
+func (s *Service) ReconcileKarpenter() error {
+	if s.scope.ControlPlane.Spec.Karpenter == nil {
+		if s.scope.ControlPlane.Status.Karpenter == nil {
+			return nil
+		}
+		s.scope.Info("Karpenter disabled, deleting its AWS resources")
+		return s.DeleteKarpenter()
+	}
+
+	s.scope.Debug("Reconciling Karpenter AWS resources")
+
+	roleName, err := s.nodeRoleName()
+	if err != nil {
+		return err
+	}
+	role, err := s.reconcileNodeRole(roleName)
+	if err != nil {
+		return err
+	}
+	if err := s.reconcileInstanceProfile(role); err != nil {
+		return err
+	}
+
+	status := &ekscontrolplanev1.KarpenterStatus{
+		NodeRoleName:        roleName,
+		NodeRoleARN:         aws.StringValue(role.Arn),
+		InstanceProfileName: roleName,
+		SubnetIDs:           s.scope.Subnets().FilterPrivate().IDs(),
+	}
+	if sg, ok := s.scope.ControlPlane.Status.Network.SecurityGroups[ekscontrolplanev1.SecurityGroupCluster]; ok && sg.ID != "" {
+		status.SecurityGroupIDs = []string{sg.ID}
+	}
+
+	if s.scope.ControlPlane.Spec.Karpenter.DisableInterruptionQueue {
+		if current := s.scope.ControlPlane.Status.Karpenter; current != nil && current.InterruptionQueueName != "" {
+			if err := s.deleteInterruptionQueue(current.InterruptionQueueName); err != nil {
+				return err
+			}
+		}
+	} else {
+		queueName, err := s.queueName()
+		if err != nil {
+			return err
+		}
+		queueARN, err := s.reconcileQueue(queueName)
+		if err != nil {
+			return err
+		}
+		if err := s.reconcileRules(queueARN); err != nil {
+			return err
+		}
+		status.InterruptionQueueName = queueName
+	}
+
+	s.scope.ControlPlane.Status.Karpenter = status
+	return nil
+}
+
+// DeleteKarpenter deletes the AWS resources created for Karpenter.
+func (s *Service) DeleteKarpenter() error {
+	status := s.scope.ControlPlane.Status.Karpenter
+	if status == nil {
+		return nil
+	}
+
+	if status.InterruptionQueueName != "" {
+		if err := s.deleteInterruptionQueue(status.InterruptionQueueName); err != nil {
+			return err
+		}
+	}
+	if err := s.deleteNodeRole(status.NodeRoleName); err != nil {
+		return err
+	}
+
+	s.scope.ControlPlane.Status.Karpenter = nil
+	return nil
+}
+
+func (s *Service) deleteInterruptionQueue(queueName string) error {
+	if err := s.deleteRules(); err != nil {
+		return err
+	}
+	if err := s.deleteQueue(queueName); err != nil {
+		return err
+	}
+	s.scope.ControlPlane.Status.Karpenter.InterruptionQueueName = ""
+	return nil
+}
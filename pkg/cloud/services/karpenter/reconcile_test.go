@@ -0,0 +1,330 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karpenter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_eventbridgeiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_sqsiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	testNodeRoleName = "test-cluster-karpenter-node"
+	testQueueName    = "test-cluster-karpenter"
+	testQueueURL     = "https://sqs.us-east-1.amazonaws.com/123456789012/test-cluster-karpenter"
+	testQueueARN     = "arn:aws:sqs:us-east-1:123456789012:test-cluster-karpenter"
+)
+
+var ownedTags = []*iam.Tag{{Key: aws.String(infrav1.ClusterAWSCloudProviderTagKey("test")), Value: aws.String(string(infrav1.ResourceLifecycleOwned))}}
+
+func TestResourceName(t *testing.T) {
+	g := NewWithT(t)
+
+	name, err := resourceName("test-cluster", nodeRoleSuffix, maxIAMRoleNameLength)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(name).To(Equal(testNodeRoleName))
+
+	longClusterName := strings.Repeat("a", 60)
+	name, err = resourceName(longClusterName, nodeRoleSuffix, maxIAMRoleNameLength)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(name).To(HaveLen(maxIAMRoleNameLength))
+	g.Expect(name).To(HavePrefix("aaaa"))
+	g.Expect(name).To(HaveSuffix(nodeRoleSuffix))
+
+	other, err := resourceName(longClusterName+"b", nodeRoleSuffix, maxIAMRoleNameLength)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(other).NotTo(Equal(name))
+}
+
+func TestReconcileKarpenter(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          *ekscontrolplanev1.KarpenterSpec
+		status        *ekscontrolplanev1.KarpenterStatus
+		enableIAM     bool
+		expectIAM     func(m *mock_iamauth.MockIAMAPIMockRecorder)
+		expectSQS     func(m *mock_sqsiface.MockSQSAPIMockRecorder)
+		expectEvents  func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder)
+		expectErr     error
+		expectStatus  *ekscontrolplanev1.KarpenterStatus
+		expectRoleSet bool
+	}{
+		{
+			name:      "disabled",
+			enableIAM: true,
+		},
+		{
+			name:      "creates the resources",
+			spec:      &ekscontrolplanev1.KarpenterSpec{},
+			enableIAM: true,
+			expectIAM: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(testNodeRoleName)}).
+					Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "", nil))
+				m.CreateRole(gomock.Any()).DoAndReturn(func(input *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
+					if aws.StringValue(input.RoleName) != testNodeRoleName {
+						t.Fatalf("unexpected role name %s", aws.StringValue(input.RoleName))
+					}
+					return &iam.CreateRoleOutput{Role: &iam.Role{
+						RoleName: input.RoleName,
+						Arn:      aws.String("arn:aws:iam::123456789012:role/" + testNodeRoleName),
+						Tags:     input.Tags,
+					}}, nil
+				})
+				attached := []*iam.AttachedPolicy{}
+				for _, p := range NodeRolePolicies("aws") {
+					attached = append(attached, &iam.AttachedPolicy{PolicyArn: aws.String(p)})
+				}
+				m.ListAttachedRolePolicies(&iam.ListAttachedRolePoliciesInput{RoleName: aws.String(testNodeRoleName)}).
+					Return(&iam.ListAttachedRolePoliciesOutput{AttachedPolicies: attached}, nil)
+				m.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(testNodeRoleName)}).
+					Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "", nil))
+				m.CreateInstanceProfile(gomock.Any()).
+					Return(&iam.CreateInstanceProfileOutput{InstanceProfile: &iam.InstanceProfile{InstanceProfileName: aws.String(testNodeRoleName)}}, nil)
+				m.AddRoleToInstanceProfile(&iam.AddRoleToInstanceProfileInput{
+					InstanceProfileName: aws.String(testNodeRoleName),
+					RoleName:            aws.String(testNodeRoleName),
+				}).Return(&iam.AddRoleToInstanceProfileOutput{}, nil)
+			},
+			expectSQS: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.CreateQueue(gomock.Any()).Return(&sqs.CreateQueueOutput{QueueUrl: aws.String(testQueueURL)}, nil)
+				m.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+					QueueUrl:       aws.String(testQueueURL),
+					AttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameQueueArn, sqs.QueueAttributeNamePolicy}),
+				}).Return(&sqs.GetQueueAttributesOutput{Attributes: map[string]*string{sqs.QueueAttributeNameQueueArn: aws.String(testQueueARN)}}, nil)
+				m.SetQueueAttributes(gomock.Any()).Return(&sqs.SetQueueAttributesOutput{}, nil)
+			},
+			expectEvents: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.DescribeRule(gomock.Any()).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil)).Times(len(interruptionRules))
+				m.PutRule(gomock.Any()).Return(&eventbridge.PutRuleOutput{}, nil).Times(len(interruptionRules))
+				m.ListTargetsByRule(gomock.Any()).Return(&eventbridge.ListTargetsByRuleOutput{}, nil).Times(len(interruptionRules))
+				m.PutTargets(gomock.Any()).Return(&eventbridge.PutTargetsOutput{}, nil).Times(len(interruptionRules))
+			},
+			expectStatus: &ekscontrolplanev1.KarpenterStatus{
+				NodeRoleName:          testNodeRoleName,
+				NodeRoleARN:           "arn:aws:iam::123456789012:role/" + testNodeRoleName,
+				InstanceProfileName:   testNodeRoleName,
+				InterruptionQueueName: testQueueName,
+				SubnetIDs:             []string{"subnet-private"},
+				SecurityGroupIDs:      []string{"sg-cluster"},
+			},
+			expectRoleSet: true,
+		},
+		{
+			name:      "existing node role without EKSEnableIAM",
+			spec:      &ekscontrolplanev1.KarpenterSpec{NodeRoleName: "karpenter-node", DisableInterruptionQueue: true},
+			enableIAM: false,
+			expectIAM: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String("karpenter-node")}).
+					Return(&iam.GetRoleOutput{Role: &iam.Role{RoleName: aws.String("karpenter-node"), Arn: aws.String("arn:aws:iam::123456789012:role/karpenter-node")}}, nil)
+				m.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String("karpenter-node")}).
+					Return(&iam.GetInstanceProfileOutput{InstanceProfile: &iam.InstanceProfile{
+						InstanceProfileName: aws.String("karpenter-node"),
+						Roles:               []*iam.Role{{RoleName: aws.String("karpenter-node")}},
+					}}, nil)
+			},
+			expectStatus: &ekscontrolplanev1.KarpenterStatus{
+				NodeRoleName:        "karpenter-node",
+				NodeRoleARN:         "arn:aws:iam::123456789012:role/karpenter-node",
+				InstanceProfileName: "karpenter-node",
+				SubnetIDs:           []string{"subnet-private"},
+				SecurityGroupIDs:    []string{"sg-cluster"},
+			},
+		},
+		{
+			name:      "missing node role without EKSEnableIAM",
+			spec:      &ekscontrolplanev1.KarpenterSpec{},
+			enableIAM: false,
+			expectIAM: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(testNodeRoleName)}).
+					Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "", nil))
+			},
+			expectErr: ErrNodeRoleNotFound,
+		},
+		{
+			name:      "interruption queue disabled after it was created",
+			spec:      &ekscontrolplanev1.KarpenterSpec{NodeRoleName: testNodeRoleName, DisableInterruptionQueue: true},
+			status:    &ekscontrolplanev1.KarpenterStatus{NodeRoleName: testNodeRoleName, InterruptionQueueName: testQueueName},
+			enableIAM: true,
+			expectIAM: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(testNodeRoleName)}).
+					Return(&iam.GetRoleOutput{Role: &iam.Role{RoleName: aws.String(testNodeRoleName), Arn: aws.String("arn:aws:iam::123456789012:role/" + testNodeRoleName)}}, nil)
+				m.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(testNodeRoleName)}).
+					Return(&iam.GetInstanceProfileOutput{InstanceProfile: &iam.InstanceProfile{
+						InstanceProfileName: aws.String(testNodeRoleName),
+						Roles:               []*iam.Role{{RoleName: aws.String(testNodeRoleName)}},
+					}}, nil)
+			},
+			expectSQS: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String(testQueueName)}).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String(testQueueURL)}, nil)
+				m.DeleteQueue(&sqs.DeleteQueueInput{QueueUrl: aws.String(testQueueURL)}).Return(&sqs.DeleteQueueOutput{}, nil)
+			},
+			expectEvents: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.RemoveTargets(gomock.Any()).Return(&eventbridge.RemoveTargetsOutput{}, nil).Times(len(interruptionRules))
+				m.DeleteRule(gomock.Any()).Return(&eventbridge.DeleteRuleOutput{}, nil).Times(len(interruptionRules))
+			},
+			expectStatus: &ekscontrolplanev1.KarpenterStatus{
+				NodeRoleName:        testNodeRoleName,
+				NodeRoleARN:         "arn:aws:iam::123456789012:role/" + testNodeRoleName,
+				InstanceProfileName: testNodeRoleName,
+				SubnetIDs:           []string{"subnet-private"},
+				SecurityGroupIDs:    []string{"sg-cluster"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			s, controlPlane := newTestService(t, mockCtrl, tc.spec, tc.status, tc.enableIAM)
+			if tc.expectIAM != nil {
+				tc.expectIAM(s.IAMClient.(*mock_iamauth.MockIAMAPI).EXPECT())
+			}
+			if tc.expectSQS != nil {
+				tc.expectSQS(s.SQSClient.(*mock_sqsiface.MockSQSAPI).EXPECT())
+			}
+			if tc.expectEvents != nil {
+				tc.expectEvents(s.EventBridgeClient.(*mock_eventbridgeiface.MockEventBridgeAPI).EXPECT())
+			}
+
+			err := s.ReconcileKarpenter()
+			if tc.expectErr != nil {
+				g.Expect(errors.Is(err, tc.expectErr)).To(BeTrue(), "unexpected error %v", err)
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(controlPlane.Status.Karpenter).To(Equal(tc.expectStatus))
+			if tc.expectRoleSet {
+				g.Expect(controlPlane.Spec.Karpenter.NodeRoleName).To(Equal(testNodeRoleName))
+			}
+		})
+	}
+}
+
+func TestDeleteKarpenter(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	s, controlPlane := newTestService(t, mockCtrl, nil, &ekscontrolplanev1.KarpenterStatus{
+		NodeRoleName:          testNodeRoleName,
+		InstanceProfileName:   testNodeRoleName,
+		InterruptionQueueName: testQueueName,
+	}, true)
+
+	events := s.EventBridgeClient.(*mock_eventbridgeiface.MockEventBridgeAPI).EXPECT()
+	events.RemoveTargets(gomock.Any()).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil)).Times(len(interruptionRules))
+	events.DeleteRule(gomock.Any()).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil)).Times(len(interruptionRules))
+
+	queues := s.SQSClient.(*mock_sqsiface.MockSQSAPI).EXPECT()
+	queues.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String(testQueueName)}).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String(testQueueURL)}, nil)
+	queues.DeleteQueue(&sqs.DeleteQueueInput{QueueUrl: aws.String(testQueueURL)}).Return(&sqs.DeleteQueueOutput{}, nil)
+
+	roles := s.IAMClient.(*mock_iamauth.MockIAMAPI).EXPECT()
+	roles.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(testNodeRoleName)}).
+		Return(&iam.GetInstanceProfileOutput{InstanceProfile: &iam.InstanceProfile{
+			InstanceProfileName: aws.String(testNodeRoleName),
+			Roles:               []*iam.Role{{RoleName: aws.String(testNodeRoleName)}},
+			Tags:                ownedTags,
+		}}, nil)
+	roles.RemoveRoleFromInstanceProfile(&iam.RemoveRoleFromInstanceProfileInput{
+		InstanceProfileName: aws.String(testNodeRoleName),
+		RoleName:            aws.String(testNodeRoleName),
+	}).Return(&iam.RemoveRoleFromInstanceProfileOutput{}, nil)
+	roles.DeleteInstanceProfile(&iam.DeleteInstanceProfileInput{InstanceProfileName: aws.String(testNodeRoleName)}).
+		Return(&iam.DeleteInstanceProfileOutput{}, nil)
+	roles.GetRole(&iam.GetRoleInput{RoleName: aws.String(testNodeRoleName)}).
+		Return(&iam.GetRoleOutput{Role: &iam.Role{RoleName: aws.String(testNodeRoleName), Tags: ownedTags}}, nil)
+	roles.ListAttachedRolePolicies(&iam.ListAttachedRolePoliciesInput{RoleName: aws.String(testNodeRoleName)}).
+		Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+	roles.DeleteRole(&iam.DeleteRoleInput{RoleName: aws.String(testNodeRoleName)}).Return(&iam.DeleteRoleOutput{}, nil)
+
+	g.Expect(s.ReconcileKarpenter()).To(Succeed())
+	g.Expect(controlPlane.Status.Karpenter).To(BeNil())
+}
+
+func newTestService(t *testing.T, mockCtrl *gomock.Controller, spec *ekscontrolplanev1.KarpenterSpec, status *ekscontrolplanev1.KarpenterStatus, enableIAM bool) (*Service, *ekscontrolplanev1.AWSManagedControlPlane) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = ekscontrolplanev1.AddToScheme(scheme)
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "test"},
+		Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+			EKSClusterName: "test-cluster",
+			Region:         "us-east-1",
+			NetworkSpec: infrav1.NetworkSpec{
+				Subnets: infrav1.Subnets{
+					{ID: "subnet-private", AvailabilityZone: "us-east-1a"},
+					{ID: "subnet-public", AvailabilityZone: "us-east-1a", IsPublic: true},
+				},
+			},
+			Karpenter: spec,
+		},
+		Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+			Network: infrav1.NetworkStatus{
+				SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+					ekscontrolplanev1.SecurityGroupCluster: {ID: "sg-cluster"},
+				},
+			},
+			Karpenter: status,
+		},
+	}
+	managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "test"},
+		},
+		ControlPlane: controlPlane,
+		Client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).Build(),
+		EnableIAM:    enableIAM,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Service{
+		scope: managedScope,
+		IAMService: eksiam.IAMService{
+			Wrapper:   &managedScope.Logger,
+			IAMClient: mock_iamauth.NewMockIAMAPI(mockCtrl),
+		},
+		EventBridgeClient: mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl),
+		SQSClient:         mock_sqsiface.NewMockSQSAPI(mockCtrl),
+	}, controlPlane
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karpenter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+)
+
+const (
+	maxRuleNameLength = 64
+
+	// queueTargetID is the ID of the interruption queue in the targets of the rules.
+	queueTargetID = "KarpenterInterruptionQueueTarget"
+)
+
+// eventPattern is the pattern of the events matched by an EventBridge rule.
+type eventPattern struct {
+	Source     []string `json:"source"`
+	DetailType []string `json:"detail-type"`
+}
+
+// interruptionRule is a rule sending events Karpenter handles to the interruption queue.
+type interruptionRule struct {
+	suffix  string
+	pattern eventPattern
+}
+
+// interruptionRules are the rules sending the events Karpenter handles to the interruption queue.
+var interruptionRules = []interruptionRule{
+	{
+		suffix:  "-karpenter-scheduled-change",
+		pattern: eventPattern{Source: []string{"aws.health"}, DetailType: []string{"AWS Health Event"}},
+	},
+	{
+		suffix:  "-karpenter-spot-interruption",
+		pattern: eventPattern{Source: []string{"aws.ec2"}, DetailType: []string{"EC2 Spot Instance Interruption Warning"}},
+	},
+	{
+		suffix:  "-karpenter-rebalance",
+		pattern: eventPattern{Source: []string{"aws.ec2"}, DetailType: []string{"EC2 Instance Rebalance Recommendation"}},
+	},
+	{
+		suffix:  "-karpenter-instance-state-change",
+		pattern: eventPattern{Source: []string{"aws.ec2"}, DetailType: []string{"EC2 Instance State-change Notification"}},
+	},
+}
+
+// reconcileRules ensures the interruption rules exist and target the interruption queue.
+func (s *Service) reconcileRules(queueARN string) error {
+	for _, rule := range interruptionRules {
+		name, err := resourceName(s.scope.KubernetesClusterName(), rule.suffix, maxRuleNameLength)
+		if err != nil {
+			return fmt.Errorf("generating rule name: %w", err)
+		}
+		if err := s.reconcileRule(name, rule.pattern, queueARN); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) reconcileRule(name string, pattern eventPattern, queueARN string) error {
+	s.scope.Debug("Reconciling Karpenter interruption rule", "rule", name)
+
+	if _, err := s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{Name: aws.String(name)}); err != nil {
+		if !awserrors.IsNotFound(err) {
+			return fmt.Errorf("describing rule %s: %w", name, err)
+		}
+
+		data, err := json.Marshal(pattern)
+		if err != nil {
+			return fmt.Errorf("marshalling event pattern of rule %s: %w", name, err)
+		}
+		tags := make([]*eventbridge.Tag, 0, len(s.tags()))
+		for k, v := range s.tags() {
+			tags = append(tags, &eventbridge.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+		if _, err := s.EventBridgeClient.PutRule(&eventbridge.PutRuleInput{
+			Name:         aws.String(name),
+			EventPattern: aws.String(string(data)),
+			State:        aws.String(eventbridge.RuleStateEnabled),
+			Tags:         tags,
+		}); err != nil {
+			return fmt.Errorf("creating rule %s: %w", name, err)
+		}
+	}
+
+	targets, err := s.EventBridgeClient.ListTargetsByRule(&eventbridge.ListTargetsByRuleInput{Rule: aws.String(name)})
+	if err != nil {
+		return fmt.Errorf("listing targets of rule %s: %w", name, err)
+	}
+	for _, target := range targets.Targets {
+		if aws.StringValue(target.Id) == queueTargetID && aws.StringValue(target.Arn) == queueARN {
+			return nil
+		}
+	}
+
+	if _, err := s.EventBridgeClient.PutTargets(&eventbridge.PutTargetsInput{
+		Rule:    aws.String(name),
+		Targets: []*eventbridge.Target{{Id: aws.String(queueTargetID), Arn: aws.String(queueARN)}},
+	}); err != nil {
+		return fmt.Errorf("adding queue target to rule %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// deleteRules deletes the interruption rules.
+func (s *Service) deleteRules() error {
+	for _, rule := range interruptionRules {
+		name, err := resourceName(s.scope.KubernetesClusterName(), rule.suffix, maxRuleNameLength)
+		if err != nil {
+			return fmt.Errorf("generating rule name: %w", err)
+		}
+		s.scope.Debug("Deleting Karpenter interruption rule", "rule", name)
+
+		if _, err := s.EventBridgeClient.RemoveTargets(&eventbridge.RemoveTargetsInput{
+			Rule: aws.String(name),
+			Ids:  aws.StringSlice([]string{queueTargetID}),
+		}); err != nil && !awserrors.IsNotFound(err) {
+			return fmt.Errorf("removing queue target from rule %s: %w", name, err)
+		}
+		if _, err := s.EventBridgeClient.DeleteRule(&eventbridge.DeleteRuleInput{Name: aws.String(name)}); err != nil && !awserrors.IsNotFound(err) {
+			return fmt.Errorf("deleting rule %s: %w", name, err)
+		}
+	}
+	return nil
+}
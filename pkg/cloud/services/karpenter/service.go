@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package karpenter creates the AWS resources needed to install Karpenter into an EKS cluster.
+package karpenter
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
+)
+
+// Service defines the specs for a service.
+type Service struct {
+	scope *scope.ManagedControlPlaneScope
+	iam.IAMService
+	EventBridgeClient eventbridgeiface.EventBridgeAPI
+	SQSClient         sqsiface.SQSAPI
+}
+
+// NewService returns a new service given the api clients.
+func NewService(controlPlaneScope *scope.ManagedControlPlaneScope) *Service {
+	return &Service{
+		scope: controlPlaneScope,
+		IAMService: iam.IAMService{
+			Wrapper:             &controlPlaneScope.Logger,
+			IAMClient:           scope.NewIAMClient(controlPlaneScope, controlPlaneScope, controlPlaneScope, controlPlaneScope.ControlPlane),
+			Client:              http.DefaultClient,
+			PermissionsBoundary: controlPlaneScope.IAMPermissionsBoundary(),
+			Path:                controlPlaneScope.IAMPath(),
+		},
+		EventBridgeClient: scope.NewEventBridgeClient(controlPlaneScope, controlPlaneScope, controlPlaneScope.ControlPlane),
+		SQSClient:         scope.NewSQSClient(controlPlaneScope, controlPlaneScope, controlPlaneScope.ControlPlane),
+	}
+}
+
+// resourceName returns the name of a resource of the cluster made of the name of the EKS
+// cluster and the suffix. The name of the EKS cluster is shortened, and made unique with a
+// hash, when the name would be longer than maxLength.
+func resourceName(clusterName, suffix string, maxLength int) (string, error) {
+	name := clusterName + suffix
+	if len(name) <= maxLength {
+		return name, nil
+	}
+
+	const hashLength = 8
+	hashed, err := hash.Base36TruncatedHash(clusterName, hashLength)
+	if err != nil {
+		return "", fmt.Errorf("hashing cluster name: %w", err)
+	}
+	return fmt.Sprintf("%s-%s%s", clusterName[:maxLength-len(suffix)-hashLength-1], hashed, suffix), nil
+}
@@ -38,7 +38,7 @@ const (
 func (s *Service) ReconcileKubeProxy(ctx context.Context) error {
 	s.scope.Info("Reconciling kube-proxy DaemonSet in cluster", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
 
-	remoteClient, err := s.scope.RemoteClient()
+	remoteClient, err := s.scope.RemoteClient(ctx)
 	if err != nil {
 		s.scope.Error(err, "getting client for remote cluster")
 		return fmt.Errorf("getting client for remote cluster: %w", err)
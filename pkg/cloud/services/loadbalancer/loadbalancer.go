@@ -0,0 +1,276 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package loadbalancer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// LoadBalancerTagKey is the tag set on the AWS resources of an AWSLoadBalancer to its namespaced name.
+const LoadBalancerTagKey = infrav1.NameAWSProviderPrefix + "awsloadbalancer"
+
+// GenerateName returns the name of the load balancer of an AWSLoadBalancer. Load balancer names are
+// limited to 32 characters, so the name is a hash of the namespaced name of the AWSLoadBalancer.
+func GenerateName(namespace, name string) (string, error) {
+	// hashSize = 32 - length of "-lb" = 29
+	shortName, err := hash.Base36TruncatedHash(fmt.Sprintf("%s/%s", namespace, name), 29)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create load balancer name")
+	}
+	return fmt.Sprintf("%s-lb", shortName), nil
+}
+
+// ReconcileLoadBalancer reconciles the load balancer of the AWSLoadBalancer, its security group, listeners and
+// target groups, and the attachments of the target groups to the auto scaling groups of the machine pools.
+func (s *Service) ReconcileLoadBalancer() error {
+	s.scope.Debug("Reconciling load balancer")
+
+	awsLB := s.scope.AWSLoadBalancer
+	name, err := GenerateName(awsLB.Namespace, awsLB.Name)
+	if err != nil {
+		return err
+	}
+	awsLB.Status.Name = name
+
+	var securityGroupIDs []string
+	if awsLB.Spec.LoadBalancerType == infrav1.LoadBalancerTypeALB {
+		sgID, err := s.reconcileSecurityGroup(name)
+		if err != nil {
+			return err
+		}
+		awsLB.Status.SecurityGroupID = sgID
+		securityGroupIDs = append([]string{sgID}, awsLB.Spec.AdditionalSecurityGroups...)
+	}
+
+	lb, err := s.describeLB(name)
+	if err != nil {
+		return err
+	}
+	if lb == nil {
+		if lb, err = s.createLB(name, securityGroupIDs); err != nil {
+			return err
+		}
+	} else if awsLB.Spec.LoadBalancerType == infrav1.LoadBalancerTypeALB &&
+		!sets.NewString(aws.StringValueSlice(lb.SecurityGroups)...).Equal(sets.NewString(securityGroupIDs...)) {
+		if _, err := s.ELBV2Client.SetSecurityGroups(&elbv2.SetSecurityGroupsInput{
+			LoadBalancerArn: lb.LoadBalancerArn,
+			SecurityGroups:  aws.StringSlice(securityGroupIDs),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to set the security groups of load balancer %q", name)
+		}
+	}
+	awsLB.Status.ARN = aws.StringValue(lb.LoadBalancerArn)
+	awsLB.Status.DNSName = aws.StringValue(lb.DNSName)
+
+	targetGroups, missingPools, err := s.reconcileListeners(lb)
+	if err != nil {
+		return err
+	}
+	awsLB.Status.TargetGroups = targetGroups
+
+	if len(missingPools) > 0 {
+		awsLB.Status.Ready = false
+		conditions.MarkFalse(awsLB, expinfrav1.AWSLoadBalancerReadyCondition, expinfrav1.MachinePoolTargetsNotFoundReason,
+			clusterv1.ConditionSeverityInfo, "auto scaling groups of machine pools %v not found", missingPools)
+		return nil
+	}
+
+	awsLB.Status.Ready = true
+	conditions.MarkTrue(awsLB, expinfrav1.AWSLoadBalancerReadyCondition)
+	return nil
+}
+
+// DeleteLoadBalancer detaches the target groups of the load balancer of the AWSLoadBalancer from the auto
+// scaling groups of the cluster and deletes its listeners, target groups, the load balancer and its security group.
+func (s *Service) DeleteLoadBalancer() error {
+	s.scope.Debug("Deleting load balancer")
+
+	awsLB := s.scope.AWSLoadBalancer
+	name, err := GenerateName(awsLB.Namespace, awsLB.Name)
+	if err != nil {
+		return err
+	}
+
+	lb, err := s.describeLB(name)
+	if err != nil {
+		return err
+	}
+
+	targetGroupARNs := sets.NewString()
+	for _, tg := range awsLB.Status.TargetGroups {
+		targetGroupARNs.Insert(tg.ARN)
+	}
+	if lb != nil {
+		out, err := s.ELBV2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: lb.LoadBalancerArn})
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe the target groups of load balancer %q", name)
+		}
+		for _, tg := range out.TargetGroups {
+			targetGroupARNs.Insert(aws.StringValue(tg.TargetGroupArn))
+		}
+	}
+
+	if _, err := s.reconcileAttachments(nil, targetGroupARNs); err != nil {
+		return err
+	}
+
+	if lb != nil {
+		// Deleting the load balancer deletes its listeners, which must be gone before the target groups can be deleted.
+		if _, err := s.ELBV2Client.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: lb.LoadBalancerArn}); err != nil {
+			return errors.Wrapf(err, "failed to delete load balancer %q", name)
+		}
+		record.Eventf(awsLB, "SuccessfulDeleteLoadBalancer", "Deleted load balancer %q", name)
+		s.scope.Info("Deleted load balancer", "name", name)
+	}
+
+	if err := s.deleteTargetGroups(targetGroupARNs.List()); err != nil {
+		return err
+	}
+
+	if awsLB.Spec.LoadBalancerType == infrav1.LoadBalancerTypeALB {
+		if err := s.deleteSecurityGroup(name); err != nil {
+			return err
+		}
+	}
+
+	awsLB.Status.TargetGroups = nil
+	awsLB.Status.Ready = false
+	return nil
+}
+
+// describeLB returns the load balancer with the name, or nil if there is none. An error is returned
+// if the load balancer doesn't belong to the AWSLoadBalancer.
+func (s *Service) describeLB(name string) (*elbv2.LoadBalancer, error) {
+	out, err := s.ELBV2Client.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{Names: aws.StringSlice([]string{name})})
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to describe load balancer %q", name)
+	}
+	if len(out.LoadBalancers) == 0 {
+		return nil, nil
+	}
+	lb := out.LoadBalancers[0]
+
+	tags, err := s.ELBV2Client.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{lb.LoadBalancerArn}})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe the tags of load balancer %q", name)
+	}
+	owner := ""
+	if len(tags.TagDescriptions) > 0 {
+		owner = converters.V2TagsToMap(tags.TagDescriptions[0].Tags)[LoadBalancerTagKey]
+	}
+	if owner != s.namespacedName() {
+		return nil, errors.Errorf("load balancer %q already exists and isn't tagged for AWSLoadBalancer %q", name, s.namespacedName())
+	}
+
+	return lb, nil
+}
+
+func (s *Service) createLB(name string, securityGroupIDs []string) (*elbv2.LoadBalancer, error) {
+	subnetIDs, err := s.subnetIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	input := &elbv2.CreateLoadBalancerInput{
+		Name:    aws.String(name),
+		Subnets: aws.StringSlice(subnetIDs),
+		Scheme:  aws.String(string(*s.scope.AWSLoadBalancer.Spec.Scheme)),
+		Tags:    converters.MapToV2Tags(infrav1.Build(s.tagParams(name))),
+	}
+	switch s.scope.AWSLoadBalancer.Spec.LoadBalancerType {
+	case infrav1.LoadBalancerTypeALB:
+		input.Type = aws.String(elbv2.LoadBalancerTypeEnumApplication)
+		input.SecurityGroups = aws.StringSlice(securityGroupIDs)
+	case infrav1.LoadBalancerTypeNLB:
+		input.Type = aws.String(elbv2.LoadBalancerTypeEnumNetwork)
+	}
+	if s.scope.VPC().IsIPv6Enabled() {
+		input.IpAddressType = aws.String(elbv2.IpAddressTypeDualstack)
+	}
+
+	out, err := s.ELBV2Client.CreateLoadBalancer(input)
+	if err != nil {
+		record.Warnf(s.scope.AWSLoadBalancer, "FailedCreateLoadBalancer", "Failed to create load balancer %q: %v", name, err)
+		return nil, errors.Wrapf(err, "failed to create load balancer %q", name)
+	}
+	if len(out.LoadBalancers) == 0 {
+		return nil, errors.New("no load balancer was created; the returned list is empty")
+	}
+
+	record.Eventf(s.scope.AWSLoadBalancer, "SuccessfulCreateLoadBalancer", "Created load balancer %q", name)
+	s.scope.Info("Created load balancer", "name", name, "dns-name", aws.StringValue(out.LoadBalancers[0].DNSName))
+	return out.LoadBalancers[0], nil
+}
+
+// subnetIDs returns the subnets of the spec, or a public or private subnet of the cluster per availability
+// zone, depending on the scheme of the load balancer.
+func (s *Service) subnetIDs() ([]string, error) {
+	if len(s.scope.AWSLoadBalancer.Spec.Subnets) > 0 {
+		return s.scope.AWSLoadBalancer.Spec.Subnets, nil
+	}
+
+	subnets := s.scope.Subnets().FilterByOutpost("")
+	if *s.scope.AWSLoadBalancer.Spec.Scheme == infrav1.ELBSchemeInternal {
+		subnets = subnets.FilterPrivate()
+	} else {
+		subnets = subnets.FilterPublic()
+	}
+
+	ids := []string{}
+	for _, zone := range subnets.GetUniqueZones() {
+		ids = append(ids, subnets.FilterByZone(zone)[0].ID)
+	}
+	if len(ids) == 0 {
+		return nil, errors.Errorf("no subnets found for a load balancer with scheme %q in the VPC of cluster %q",
+			*s.scope.AWSLoadBalancer.Spec.Scheme, s.scope.ClusterName())
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *Service) namespacedName() string {
+	return fmt.Sprintf("%s/%s", s.scope.Namespace(), s.scope.Name())
+}
+
+// tagParams returns the parameters of the tags of the AWS resources of the load balancer.
+func (s *Service) tagParams(name string) infrav1.BuildParams {
+	additional := s.scope.AdditionalTags()
+	additional[LoadBalancerTagKey] = s.namespacedName()
+	return infrav1.BuildParams{
+		ClusterName: s.scope.ClusterName(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Additional:  additional,
+	}
+}
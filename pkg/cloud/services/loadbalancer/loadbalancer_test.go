@@ -0,0 +1,338 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package loadbalancer
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	testLBARN     = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/test/1"
+	testTGARN     = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/new/1"
+	testOldTGARN  = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/old/1"
+	testSGID      = "sg-lb"
+	testVPCID     = "vpc-1"
+	testClusterNS = "default"
+)
+
+func TestGenerateName(t *testing.T) {
+	g := NewWithT(t)
+
+	name, err := GenerateName("default", "ingress")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(name).To(HaveLen(32))
+	g.Expect(name).To(HaveSuffix("-lb"))
+
+	again, err := GenerateName("default", "ingress")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(again).To(Equal(name))
+
+	other, err := GenerateName("other", "ingress")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(other).NotTo(Equal(name))
+}
+
+func TestReconcileLoadBalancer(t *testing.T) {
+	tests := []struct {
+		name         string
+		machinePools []string
+		expect       func(e *mocks.MockEC2APIMockRecorder, m *mocks.MockELBV2APIMockRecorder, a *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		check        func(g *WithT, lb *expinfrav1.AWSLoadBalancer)
+	}{
+		{
+			name:         "creates the security group, load balancer, listener and target group and attaches it to the machine pool",
+			machinePools: []string{"pool-1"},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mocks.MockELBV2APIMockRecorder, a *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				e.DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+				e.CreateSecurityGroup(gomock.Any()).Return(&ec2.CreateSecurityGroupOutput{GroupId: aws.String(testSGID)}, nil)
+				e.AuthorizeSecurityGroupIngress(gomock.Any()).DoAndReturn(func(input *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+					if len(input.IpPermissions) != 1 || aws.Int64Value(input.IpPermissions[0].FromPort) != 80 ||
+						aws.StringValue(input.IpPermissions[0].IpRanges[0].CidrIp) != "0.0.0.0/0" {
+						t.Errorf("unexpected ingress permissions: %v", input.IpPermissions)
+					}
+					return &ec2.AuthorizeSecurityGroupIngressOutput{}, nil
+				})
+				m.DescribeLoadBalancers(gomock.Any()).Return(nil, awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found", nil))
+				m.CreateLoadBalancer(gomock.Any()).DoAndReturn(func(input *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error) {
+					if aws.StringValue(input.Type) != elbv2.LoadBalancerTypeEnumApplication {
+						t.Errorf("expected an application load balancer, got %q", aws.StringValue(input.Type))
+					}
+					if subnets := aws.StringValueSlice(input.Subnets); len(subnets) != 2 || subnets[0] != "subnet-public-a" || subnets[1] != "subnet-public-b" {
+						t.Errorf("expected a public subnet per zone, got %v", subnets)
+					}
+					if sgs := aws.StringValueSlice(input.SecurityGroups); len(sgs) != 1 || sgs[0] != testSGID {
+						t.Errorf("expected the load balancer security group, got %v", sgs)
+					}
+					return &elbv2.CreateLoadBalancerOutput{LoadBalancers: []*elbv2.LoadBalancer{testLB()}}, nil
+				})
+				m.DescribeListeners(gomock.Any()).Return(&elbv2.DescribeListenersOutput{}, nil)
+				m.DescribeTargetGroups(gomock.Any()).Return(nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil))
+				m.CreateTargetGroup(gomock.Any()).Return(&elbv2.CreateTargetGroupOutput{TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String(testTGARN)}}}, nil)
+				m.CreateListener(gomock.Any()).Return(&elbv2.CreateListenerOutput{}, nil)
+				expectASGs(a, &autoscaling.Group{AutoScalingGroupName: aws.String("pool-1")})
+				a.AttachLoadBalancerTargetGroups(&autoscaling.AttachLoadBalancerTargetGroupsInput{
+					AutoScalingGroupName: aws.String("pool-1"),
+					TargetGroupARNs:      aws.StringSlice([]string{testTGARN}),
+				}).Return(&autoscaling.AttachLoadBalancerTargetGroupsOutput{}, nil)
+			},
+			check: func(g *WithT, lb *expinfrav1.AWSLoadBalancer) {
+				g.Expect(lb.Status.Ready).To(BeTrue())
+				g.Expect(lb.Status.ARN).To(Equal(testLBARN))
+				g.Expect(lb.Status.DNSName).To(Equal("test.elb.amazonaws.com"))
+				g.Expect(lb.Status.SecurityGroupID).To(Equal(testSGID))
+				g.Expect(lb.Status.TargetGroups).To(Equal([]expinfrav1.AWSLoadBalancerTargetGroupStatus{{ListenerPort: 80, ARN: testTGARN}}))
+				g.Expect(conditions.IsTrue(lb, expinfrav1.AWSLoadBalancerReadyCondition)).To(BeTrue())
+			},
+		},
+		{
+			name: "replaces the target group when the target port changes and reports missing machine pools",
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mocks.MockELBV2APIMockRecorder, a *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				e.DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{{
+					GroupId: aws.String(testSGID),
+					IpPermissions: []*ec2.IpPermission{
+						{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(80), ToPort: aws.Int64(80), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}},
+						{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(8080), ToPort: aws.Int64(8080), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}},
+					},
+				}}}, nil)
+				e.RevokeSecurityGroupIngress(gomock.Any()).DoAndReturn(func(input *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+					if len(input.IpPermissions) != 1 || aws.Int64Value(input.IpPermissions[0].FromPort) != 8080 {
+						t.Errorf("expected the permission of port 8080 to be revoked, got %v", input.IpPermissions)
+					}
+					return &ec2.RevokeSecurityGroupIngressOutput{}, nil
+				})
+				expectExistingLB(m)
+				m.DescribeListeners(gomock.Any()).Return(&elbv2.DescribeListenersOutput{Listeners: []*elbv2.Listener{
+					{ListenerArn: aws.String("listener-80"), Port: aws.Int64(80), Protocol: aws.String("HTTP"), DefaultActions: forwardActions(testOldTGARN)},
+					{ListenerArn: aws.String("listener-81"), Port: aws.Int64(81), Protocol: aws.String("HTTP"), DefaultActions: forwardActions(testOldTGARN)},
+				}}, nil)
+				m.DescribeTargetGroups(gomock.Any()).Return(nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil))
+				m.CreateTargetGroup(gomock.Any()).Return(&elbv2.CreateTargetGroupOutput{TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String(testTGARN)}}}, nil)
+				m.ModifyListener(gomock.Any()).DoAndReturn(func(input *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
+					if aws.StringValue(input.ListenerArn) != "listener-80" || aws.StringValue(input.DefaultActions[0].TargetGroupArn) != testTGARN {
+						t.Errorf("expected listener-80 to forward to the new target group, got %v", input)
+					}
+					return &elbv2.ModifyListenerOutput{}, nil
+				})
+				m.DeleteListener(&elbv2.DeleteListenerInput{ListenerArn: aws.String("listener-81")}).Return(&elbv2.DeleteListenerOutput{}, nil)
+				expectASGs(a, &autoscaling.Group{AutoScalingGroupName: aws.String("pool-1"), TargetGroupARNs: aws.StringSlice([]string{testOldTGARN, "unmanaged"})})
+				a.DetachLoadBalancerTargetGroups(&autoscaling.DetachLoadBalancerTargetGroupsInput{
+					AutoScalingGroupName: aws.String("pool-1"),
+					TargetGroupARNs:      aws.StringSlice([]string{testOldTGARN}),
+				}).Return(&autoscaling.DetachLoadBalancerTargetGroupsOutput{}, nil)
+				m.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(testOldTGARN)}).Return(&elbv2.DeleteTargetGroupOutput{}, nil)
+			},
+			check: func(g *WithT, lb *expinfrav1.AWSLoadBalancer) {
+				g.Expect(lb.Status.Ready).To(BeFalse())
+				g.Expect(lb.Status.TargetGroups).To(Equal([]expinfrav1.AWSLoadBalancerTargetGroupStatus{{ListenerPort: 80, ARN: testTGARN}}))
+				g.Expect(conditions.GetReason(lb, expinfrav1.AWSLoadBalancerReadyCondition)).To(Equal(expinfrav1.MachinePoolTargetsNotFoundReason))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			elbv2Mock := mocks.NewMockELBV2API(mockCtrl)
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+
+			lb := testAWSLoadBalancer(tt.machinePools)
+			if tt.machinePools == nil {
+				lb.Spec.Listeners[0].TargetGroup.MachinePools = []string{"pool-2"}
+			}
+			s := newTestService(t, lb, ec2Mock, elbv2Mock, asgMock)
+			tt.expect(ec2Mock.EXPECT(), elbv2Mock.EXPECT(), asgMock.EXPECT())
+
+			g.Expect(s.ReconcileLoadBalancer()).To(Succeed())
+			tt.check(g, lb)
+		})
+	}
+}
+
+func TestDeleteLoadBalancer(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	elbv2Mock := mocks.NewMockELBV2API(mockCtrl)
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+
+	lb := testAWSLoadBalancer([]string{"pool-1"})
+	lb.Status.TargetGroups = []expinfrav1.AWSLoadBalancerTargetGroupStatus{{ListenerPort: 80, ARN: testOldTGARN}}
+	s := newTestService(t, lb, ec2Mock, elbv2Mock, asgMock)
+
+	m := elbv2Mock.EXPECT()
+	expectExistingLB(m)
+	m.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(testLBARN)}).
+		Return(&elbv2.DescribeTargetGroupsOutput{TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String(testTGARN)}}}, nil)
+	expectASGs(asgMock.EXPECT(), &autoscaling.Group{AutoScalingGroupName: aws.String("pool-1"), TargetGroupARNs: aws.StringSlice([]string{testTGARN})})
+	asgMock.EXPECT().DetachLoadBalancerTargetGroups(&autoscaling.DetachLoadBalancerTargetGroupsInput{
+		AutoScalingGroupName: aws.String("pool-1"),
+		TargetGroupARNs:      aws.StringSlice([]string{testTGARN}),
+	}).Return(&autoscaling.DetachLoadBalancerTargetGroupsOutput{}, nil)
+	m.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(testLBARN)}).Return(&elbv2.DeleteLoadBalancerOutput{}, nil)
+	m.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(testTGARN)}).Return(&elbv2.DeleteTargetGroupOutput{}, nil)
+	m.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(testOldTGARN)}).
+		Return(nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil))
+	ec2Mock.EXPECT().DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{{GroupId: aws.String(testSGID)}}}, nil)
+	ec2Mock.EXPECT().DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: aws.String(testSGID)}).Return(&ec2.DeleteSecurityGroupOutput{}, nil)
+
+	g.Expect(s.DeleteLoadBalancer()).To(Succeed())
+	g.Expect(lb.Status.TargetGroups).To(BeEmpty())
+}
+
+func TestDescribeLBOwnership(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	elbv2Mock := mocks.NewMockELBV2API(mockCtrl)
+
+	s := newTestService(t, testAWSLoadBalancer(nil), mocks.NewMockEC2API(mockCtrl), elbv2Mock, mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl))
+	elbv2Mock.EXPECT().DescribeLoadBalancers(gomock.Any()).Return(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{testLB()}}, nil)
+	elbv2Mock.EXPECT().DescribeTags(gomock.Any()).Return(&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{{
+		Tags: []*elbv2.Tag{{Key: aws.String(LoadBalancerTagKey), Value: aws.String("other/ingress")}},
+	}}}, nil)
+
+	_, err := s.describeLB("test")
+	g.Expect(err).To(MatchError(ContainSubstring("isn't tagged for AWSLoadBalancer")))
+}
+
+func testAWSLoadBalancer(machinePools []string) *expinfrav1.AWSLoadBalancer {
+	scheme := infrav1.ELBSchemeInternetFacing
+	return &expinfrav1.AWSLoadBalancer{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: testClusterNS},
+		Spec: expinfrav1.AWSLoadBalancerSpec{
+			ClusterName:       "test-cluster",
+			LoadBalancerType:  infrav1.LoadBalancerTypeALB,
+			Scheme:            &scheme,
+			IngressCIDRBlocks: []string{"0.0.0.0/0"},
+			Listeners: []expinfrav1.AWSLoadBalancerListener{
+				{
+					Port:     80,
+					Protocol: infrav1.ELBProtocolHTTP,
+					TargetGroup: expinfrav1.AWSLoadBalancerTargetGroup{
+						Port:         30080,
+						MachinePools: machinePools,
+					},
+				},
+			},
+		},
+	}
+}
+
+func testLB() *elbv2.LoadBalancer {
+	return &elbv2.LoadBalancer{
+		LoadBalancerArn:  aws.String(testLBARN),
+		LoadBalancerName: aws.String("test"),
+		DNSName:          aws.String("test.elb.amazonaws.com"),
+		VpcId:            aws.String(testVPCID),
+		SecurityGroups:   aws.StringSlice([]string{testSGID}),
+	}
+}
+
+func expectExistingLB(m *mocks.MockELBV2APIMockRecorder) {
+	m.DescribeLoadBalancers(gomock.Any()).Return(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{testLB()}}, nil)
+	m.DescribeTags(gomock.Any()).Return(&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{{
+		Tags: []*elbv2.Tag{{Key: aws.String(LoadBalancerTagKey), Value: aws.String(testClusterNS + "/ingress")}},
+	}}}, nil)
+}
+
+func expectASGs(a *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, groups ...*autoscaling.Group) {
+	a.DescribeAutoScalingGroupsPages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *autoscaling.DescribeAutoScalingGroupsInput, fn func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool) error {
+			fn(&autoscaling.DescribeAutoScalingGroupsOutput{AutoScalingGroups: groups}, true)
+			return nil
+		})
+}
+
+func newTestService(t *testing.T, lb *expinfrav1.AWSLoadBalancer, ec2Mock *mocks.MockEC2API, elbv2Mock *mocks.MockELBV2API, asgMock *mock_autoscalingiface.MockAutoScalingAPI) *Service {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = expinfrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(lb).Build()
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: testClusterNS}}
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:  client,
+		Cluster: cluster,
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: testClusterNS},
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{ID: testVPCID},
+					Subnets: infrav1.Subnets{
+						{ID: "subnet-public-b", AvailabilityZone: "us-east-1b", IsPublic: true},
+						{ID: "subnet-public-a", AvailabilityZone: "us-east-1a", IsPublic: true},
+						{ID: "subnet-public-a2", AvailabilityZone: "us-east-1a", IsPublic: true},
+						{ID: "subnet-private-a", AvailabilityZone: "us-east-1a"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create cluster scope: %v", err)
+	}
+
+	machinePools := map[string]*expinfrav1.AWSMachinePool{}
+	for _, ln := range lb.Spec.Listeners {
+		for _, name := range ln.TargetGroup.MachinePools {
+			if name == "pool-1" {
+				machinePools[name] = &expinfrav1.AWSMachinePool{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testClusterNS}}
+			}
+		}
+	}
+
+	lbScope, err := scope.NewAWSLoadBalancerScope(scope.AWSLoadBalancerScopeParams{
+		Client:          client,
+		Cluster:         cluster,
+		InfraCluster:    clusterScope,
+		AWSLoadBalancer: lb,
+		MachinePools:    machinePools,
+	})
+	if err != nil {
+		t.Fatalf("failed to create load balancer scope: %v", err)
+	}
+
+	return &Service{
+		scope:       lbScope,
+		EC2Client:   ec2Mock,
+		ELBV2Client: elbv2Mock,
+		ASGClient:   asgMock,
+	}
+}
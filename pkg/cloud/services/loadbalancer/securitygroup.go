@@ -0,0 +1,174 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package loadbalancer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// ingressPermission is a TCP port open to a CIDR block in the security group of an application load balancer.
+type ingressPermission struct {
+	port int64
+	cidr string
+}
+
+// reconcileSecurityGroup creates the security group of an application load balancer if it doesn't exist,
+// and opens the ports of its listeners to the ingress CIDR blocks, revoking any other ingress permission.
+func (s *Service) reconcileSecurityGroup(name string) (string, error) {
+	sg, err := s.describeSecurityGroup(name)
+	if err != nil {
+		return "", err
+	}
+	if sg == nil {
+		out, err := s.EC2Client.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
+			VpcId:       aws.String(s.scope.VPC().ID),
+			GroupName:   aws.String(name),
+			Description: aws.String(fmt.Sprintf("Load balancer %s of Kubernetes cluster %s", s.namespacedName(), s.scope.ClusterName())),
+			TagSpecifications: []*ec2.TagSpecification{
+				tags.BuildParamsToTagSpecification(ec2.ResourceTypeSecurityGroup, s.tagParams(name)),
+			},
+		})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to create security group %q in vpc %q", name, s.scope.VPC().ID)
+		}
+		record.Eventf(s.scope.AWSLoadBalancer, "SuccessfulCreateSecurityGroup", "Created security group %q", aws.StringValue(out.GroupId))
+		s.scope.Info("Created security group for load balancer", "security-group", aws.StringValue(out.GroupId))
+		sg = &ec2.SecurityGroup{GroupId: out.GroupId}
+	}
+
+	desired := map[ingressPermission]bool{}
+	for _, ln := range s.scope.AWSLoadBalancer.Spec.Listeners {
+		for _, cidr := range s.scope.AWSLoadBalancer.Spec.IngressCIDRBlocks {
+			desired[ingressPermission{port: ln.Port, cidr: cidr}] = true
+		}
+	}
+
+	var revoke []ingressPermission
+	for _, perm := range sg.IpPermissions {
+		for _, cidr := range append(ipRangesCIDRs(perm.IpRanges), ipv6RangesCIDRs(perm.Ipv6Ranges)...) {
+			p := ingressPermission{port: aws.Int64Value(perm.FromPort), cidr: cidr}
+			if aws.StringValue(perm.IpProtocol) == "tcp" && aws.Int64Value(perm.FromPort) == aws.Int64Value(perm.ToPort) && desired[p] {
+				delete(desired, p)
+				continue
+			}
+			revoke = append(revoke, p)
+		}
+	}
+
+	if len(revoke) > 0 {
+		if _, err := s.EC2Client.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       sg.GroupId,
+			IpPermissions: toIPPermissions(revoke),
+		}); err != nil {
+			return "", errors.Wrapf(err, "failed to revoke ingress rules from security group %q", aws.StringValue(sg.GroupId))
+		}
+	}
+
+	if len(desired) > 0 {
+		authorize := make([]ingressPermission, 0, len(desired))
+		for p := range desired {
+			authorize = append(authorize, p)
+		}
+		if _, err := s.EC2Client.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId:       sg.GroupId,
+			IpPermissions: toIPPermissions(authorize),
+		}); err != nil {
+			return "", errors.Wrapf(err, "failed to authorize ingress rules on security group %q", aws.StringValue(sg.GroupId))
+		}
+	}
+
+	return aws.StringValue(sg.GroupId), nil
+}
+
+func (s *Service) deleteSecurityGroup(name string) error {
+	sg, err := s.describeSecurityGroup(name)
+	if err != nil || sg == nil {
+		return err
+	}
+
+	if _, err := s.EC2Client.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: sg.GroupId}); err != nil {
+		if awserrors.IsConflict(err) {
+			// The network interfaces of the load balancer are released a while after it is deleted.
+			return errors.Wrapf(err, "security group %q is still in use by the load balancer", aws.StringValue(sg.GroupId))
+		}
+		return errors.Wrapf(err, "failed to delete security group %q", aws.StringValue(sg.GroupId))
+	}
+
+	record.Eventf(s.scope.AWSLoadBalancer, "SuccessfulDeleteSecurityGroup", "Deleted security group %q", aws.StringValue(sg.GroupId))
+	s.scope.Info("Deleted security group of load balancer", "security-group", aws.StringValue(sg.GroupId))
+	return nil
+}
+
+// describeSecurityGroup returns the security group with the name in the VPC of the cluster, or nil if there is none.
+func (s *Service) describeSecurityGroup(name string) (*ec2.SecurityGroup, error) {
+	out, err := s.EC2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			{Name: aws.String("group-name"), Values: aws.StringSlice([]string{name})},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe security group %q", name)
+	}
+	if len(out.SecurityGroups) == 0 {
+		return nil, nil
+	}
+	return out.SecurityGroups[0], nil
+}
+
+func toIPPermissions(perms []ingressPermission) []*ec2.IpPermission {
+	res := make([]*ec2.IpPermission, 0, len(perms))
+	for _, p := range perms {
+		perm := &ec2.IpPermission{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(p.port),
+			ToPort:     aws.Int64(p.port),
+		}
+		if strings.Contains(p.cidr, ":") {
+			perm.Ipv6Ranges = []*ec2.Ipv6Range{{CidrIpv6: aws.String(p.cidr)}}
+		} else {
+			perm.IpRanges = []*ec2.IpRange{{CidrIp: aws.String(p.cidr)}}
+		}
+		res = append(res, perm)
+	}
+	return res
+}
+
+func ipRangesCIDRs(ranges []*ec2.IpRange) []string {
+	res := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		res = append(res, aws.StringValue(r.CidrIp))
+	}
+	return res
+}
+
+func ipv6RangesCIDRs(ranges []*ec2.Ipv6Range) []string {
+	res := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		res = append(res, aws.StringValue(r.CidrIpv6))
+	}
+	return res
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package loadbalancer provides a service to manage the application and network load balancers
+// declared by AWSLoadBalancers.
+package loadbalancer
+
+import (
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	scope       *scope.AWSLoadBalancerScope
+	EC2Client   ec2iface.EC2API
+	ELBV2Client elbv2iface.ELBV2API
+	ASGClient   autoscalingiface.AutoScalingAPI
+}
+
+// NewService returns a new service given the api clients.
+func NewService(lbScope *scope.AWSLoadBalancerScope) *Service {
+	return &Service{
+		scope:       lbScope,
+		EC2Client:   scope.NewEC2Client(lbScope, lbScope, lbScope, lbScope.AWSLoadBalancer),
+		ELBV2Client: scope.NewELBv2Client(lbScope, lbScope, lbScope, lbScope.AWSLoadBalancer),
+		ASGClient:   scope.NewASGClient(lbScope, lbScope, lbScope, lbScope.AWSLoadBalancer),
+	}
+}
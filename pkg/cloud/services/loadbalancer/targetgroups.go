@@ -0,0 +1,359 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package loadbalancer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/elb"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
+)
+
+// targetGroupName returns the name of the target group of a listener. The port and protocol of the targets
+// of a target group can't be updated, so the name changes with them and a new target group replaces the
+// previous one when they change.
+func targetGroupName(lbName string, ln *expinfrav1.AWSLoadBalancerListener) (string, error) {
+	name, err := hash.Base36TruncatedHash(fmt.Sprintf("%s/%d/%d/%s", lbName, ln.Port, ln.TargetGroup.Port, ln.TargetProtocol()), 32)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create target group name")
+	}
+	return name, nil
+}
+
+// reconcileListeners creates or updates a listener and its target group for each listener of the spec,
+// deletes the other listeners of the load balancer, and attaches the target groups to the auto scaling
+// groups of their machine pools. The target groups no longer used are detached and deleted. It returns
+// the target groups of the listeners, and the machine pools whose auto scaling groups were not found.
+func (s *Service) reconcileListeners(lb *elbv2.LoadBalancer) ([]expinfrav1.AWSLoadBalancerTargetGroupStatus, []string, error) {
+	out, err := s.ELBV2Client.DescribeListeners(&elbv2.DescribeListenersInput{LoadBalancerArn: lb.LoadBalancerArn})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to describe the listeners of load balancer %q", aws.StringValue(lb.LoadBalancerName))
+	}
+
+	// The target groups of the previous reconciliation, and the ones forwarded to by the current listeners,
+	// are stale unless a listener of the spec still forwards to them.
+	stale := sets.NewString()
+	for _, tg := range s.scope.AWSLoadBalancer.Status.TargetGroups {
+		stale.Insert(tg.ARN)
+	}
+	existing := map[int64]*elbv2.Listener{}
+	for _, l := range out.Listeners {
+		existing[aws.Int64Value(l.Port)] = l
+		if arn := elb.ForwardTargetGroupARN(l); arn != "" {
+			stale.Insert(arn)
+		}
+	}
+
+	statuses := []expinfrav1.AWSLoadBalancerTargetGroupStatus{}
+	machinePools := map[string][]string{}
+	for i := range s.scope.AWSLoadBalancer.Spec.Listeners {
+		ln := &s.scope.AWSLoadBalancer.Spec.Listeners[i]
+
+		arn, err := s.reconcileTargetGroup(lb, ln)
+		if err != nil {
+			return nil, nil, err
+		}
+		stale.Delete(arn)
+
+		if l, ok := existing[ln.Port]; ok {
+			delete(existing, ln.Port)
+			if err := s.updateListener(l, ln, arn); err != nil {
+				return nil, nil, err
+			}
+		} else if err := s.createListener(lb, ln, arn); err != nil {
+			return nil, nil, err
+		}
+
+		statuses = append(statuses, expinfrav1.AWSLoadBalancerTargetGroupStatus{ListenerPort: ln.Port, ARN: arn})
+		machinePools[arn] = ln.TargetGroup.MachinePools
+	}
+
+	for port, l := range existing {
+		if _, err := s.ELBV2Client.DeleteListener(&elbv2.DeleteListenerInput{ListenerArn: l.ListenerArn}); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to delete listener on port %d", port)
+		}
+		s.scope.Info("Deleted listener", "port", port)
+	}
+
+	missing, err := s.reconcileAttachments(machinePools, stale)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.deleteTargetGroups(stale.List()); err != nil {
+		return nil, nil, err
+	}
+
+	return statuses, missing, nil
+}
+
+// reconcileTargetGroup creates the target group of the listener if it doesn't exist and updates its health check.
+func (s *Service) reconcileTargetGroup(lb *elbv2.LoadBalancer, ln *expinfrav1.AWSLoadBalancerListener) (string, error) {
+	name, err := targetGroupName(aws.StringValue(lb.LoadBalancerName), ln)
+	if err != nil {
+		return "", err
+	}
+	hc := ln.TargetGroup.HealthCheck
+
+	out, err := s.ELBV2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{Names: aws.StringSlice([]string{name})})
+	if err != nil && !awserrors.IsNotFound(err) {
+		return "", errors.Wrapf(err, "failed to describe target group %q", name)
+	}
+	if err == nil && len(out.TargetGroups) > 0 {
+		tg := out.TargetGroups[0]
+		if hc != nil && healthCheckNeedsUpdate(tg, hc) {
+			if _, err := s.ELBV2Client.ModifyTargetGroup(&elbv2.ModifyTargetGroupInput{
+				TargetGroupArn:             tg.TargetGroupArn,
+				HealthCheckProtocol:        hc.Protocol,
+				HealthCheckPath:            hc.Path,
+				HealthCheckPort:            healthCheckPort(hc),
+				HealthCheckIntervalSeconds: hc.IntervalSeconds,
+				HealthCheckTimeoutSeconds:  hc.TimeoutSeconds,
+				HealthyThresholdCount:      hc.ThresholdCount,
+				UnhealthyThresholdCount:    hc.UnhealthyThresholdCount,
+			}); err != nil {
+				return "", errors.Wrapf(err, "failed to modify the health check of target group %q", name)
+			}
+		}
+		return aws.StringValue(tg.TargetGroupArn), nil
+	}
+
+	input := &elbv2.CreateTargetGroupInput{
+		Name:       aws.String(name),
+		Port:       aws.Int64(ln.TargetGroup.Port),
+		Protocol:   aws.String(string(ln.TargetProtocol())),
+		VpcId:      lb.VpcId,
+		TargetType: aws.String(elbv2.TargetTypeEnumInstance),
+		Tags:       converters.MapToV2Tags(infrav1.Build(s.tagParams(name))),
+	}
+	if hc != nil {
+		input.HealthCheckEnabled = aws.Bool(true)
+		input.HealthCheckProtocol = hc.Protocol
+		input.HealthCheckPath = hc.Path
+		input.HealthCheckPort = healthCheckPort(hc)
+		input.HealthCheckIntervalSeconds = hc.IntervalSeconds
+		input.HealthCheckTimeoutSeconds = hc.TimeoutSeconds
+		input.HealthyThresholdCount = hc.ThresholdCount
+		input.UnhealthyThresholdCount = hc.UnhealthyThresholdCount
+	}
+	created, err := s.ELBV2Client.CreateTargetGroup(input)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create target group %q", name)
+	}
+	if len(created.TargetGroups) == 0 {
+		return "", errors.New("no target group was created; the returned list is empty")
+	}
+
+	s.scope.Info("Created target group", "name", name, "listener-port", ln.Port)
+	return aws.StringValue(created.TargetGroups[0].TargetGroupArn), nil
+}
+
+func (s *Service) createListener(lb *elbv2.LoadBalancer, ln *expinfrav1.AWSLoadBalancerListener, targetGroupARN string) error {
+	input := &elbv2.CreateListenerInput{
+		LoadBalancerArn: lb.LoadBalancerArn,
+		Port:            aws.Int64(ln.Port),
+		Protocol:        aws.String(string(ln.Protocol)),
+		DefaultActions:  forwardActions(targetGroupARN),
+		Tags:            converters.MapToV2Tags(infrav1.Build(s.tagParams(aws.StringValue(lb.LoadBalancerName)))),
+	}
+	if ln.CertificateARN != "" {
+		input.Certificates = []*elbv2.Certificate{{CertificateArn: aws.String(ln.CertificateARN)}}
+	}
+	if ln.SSLPolicy != "" {
+		input.SslPolicy = aws.String(ln.SSLPolicy)
+	}
+
+	if _, err := s.ELBV2Client.CreateListener(input); err != nil {
+		return errors.Wrapf(err, "failed to create listener on port %d", ln.Port)
+	}
+	s.scope.Info("Created listener", "port", ln.Port)
+	return nil
+}
+
+// updateListener modifies the protocol, certificate, security policy and target group of the listener
+// when they differ from the spec.
+func (s *Service) updateListener(l *elbv2.Listener, ln *expinfrav1.AWSLoadBalancerListener, targetGroupARN string) error {
+	certificateARN := ""
+	if len(l.Certificates) > 0 {
+		certificateARN = aws.StringValue(l.Certificates[0].CertificateArn)
+	}
+	if aws.StringValue(l.Protocol) == string(ln.Protocol) &&
+		certificateARN == ln.CertificateARN &&
+		(ln.SSLPolicy == "" || aws.StringValue(l.SslPolicy) == ln.SSLPolicy) &&
+		elb.ForwardTargetGroupARN(l) == targetGroupARN {
+		return nil
+	}
+
+	input := &elbv2.ModifyListenerInput{
+		ListenerArn:    l.ListenerArn,
+		Protocol:       aws.String(string(ln.Protocol)),
+		DefaultActions: forwardActions(targetGroupARN),
+	}
+	if ln.CertificateARN != "" {
+		input.Certificates = []*elbv2.Certificate{{CertificateArn: aws.String(ln.CertificateARN)}}
+	}
+	if ln.SSLPolicy != "" {
+		input.SslPolicy = aws.String(ln.SSLPolicy)
+	}
+
+	if _, err := s.ELBV2Client.ModifyListener(input); err != nil {
+		return errors.Wrapf(err, "failed to modify listener on port %d", ln.Port)
+	}
+	s.scope.Info("Modified listener", "port", ln.Port)
+	return nil
+}
+
+// reconcileAttachments attaches the target groups to the auto scaling groups of their machine pools, and detaches
+// the target groups, as well as the stale ones, from the other auto scaling groups of the cluster. It returns the
+// machine pools whose auto scaling groups were not found.
+func (s *Service) reconcileAttachments(machinePools map[string][]string, stale sets.String) ([]string, error) {
+	managed := sets.NewString(stale.UnsortedList()...)
+	for arn := range machinePools {
+		managed.Insert(arn)
+	}
+	if managed.Len() == 0 {
+		return nil, nil
+	}
+
+	groups := map[string]*autoscaling.Group{}
+	err := s.ASGClient.DescribeAutoScalingGroupsPages(&autoscaling.DescribeAutoScalingGroupsInput{
+		Filters: []*autoscaling.Filter{
+			{
+				Name:   aws.String("tag:" + infrav1.ClusterTagKey(s.scope.InfraCluster.KubernetesClusterName())),
+				Values: aws.StringSlice([]string{string(infrav1.ResourceLifecycleOwned)}),
+			},
+		},
+	}, func(page *autoscaling.DescribeAutoScalingGroupsOutput, lastPage bool) bool {
+		for _, group := range page.AutoScalingGroups {
+			groups[aws.StringValue(group.AutoScalingGroupName)] = group
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe the auto scaling groups of the cluster")
+	}
+
+	// The auto scaling group of an AWSMachinePool is named after it.
+	missing := sets.NewString()
+	desired := map[string]sets.String{}
+	for arn, pools := range machinePools {
+		for _, pool := range pools {
+			if _, ok := s.scope.MachinePools[pool]; !ok || groups[pool] == nil {
+				missing.Insert(pool)
+				continue
+			}
+			if desired[pool] == nil {
+				desired[pool] = sets.NewString()
+			}
+			desired[pool].Insert(arn)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		current := managed.Intersection(sets.NewString(aws.StringValueSlice(groups[name].TargetGroupARNs)...))
+		want := desired[name]
+		if want == nil {
+			want = sets.NewString()
+		}
+
+		if attach := want.Difference(current); attach.Len() > 0 {
+			if _, err := s.ASGClient.AttachLoadBalancerTargetGroups(&autoscaling.AttachLoadBalancerTargetGroupsInput{
+				AutoScalingGroupName: aws.String(name),
+				TargetGroupARNs:      aws.StringSlice(attach.List()),
+			}); err != nil {
+				return nil, errors.Wrapf(err, "failed to attach target groups to auto scaling group %q", name)
+			}
+			s.scope.Info("Attached target groups to auto scaling group", "auto-scaling-group", name, "target-groups", attach.List())
+		}
+		if detach := current.Difference(want); detach.Len() > 0 {
+			if _, err := s.ASGClient.DetachLoadBalancerTargetGroups(&autoscaling.DetachLoadBalancerTargetGroupsInput{
+				AutoScalingGroupName: aws.String(name),
+				TargetGroupARNs:      aws.StringSlice(detach.List()),
+			}); err != nil {
+				return nil, errors.Wrapf(err, "failed to detach target groups from auto scaling group %q", name)
+			}
+			s.scope.Info("Detached target groups from auto scaling group", "auto-scaling-group", name, "target-groups", detach.List())
+		}
+	}
+
+	return missing.List(), nil
+}
+
+func (s *Service) deleteTargetGroups(arns []string) error {
+	for _, arn := range arns {
+		if _, err := s.ELBV2Client.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(arn)}); err != nil {
+			if awserrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "failed to delete target group %q", arn)
+		}
+		s.scope.Info("Deleted target group", "arn", arn)
+	}
+	return nil
+}
+
+func forwardActions(targetGroupARN string) []*elbv2.Action {
+	return []*elbv2.Action{
+		{
+			Type:           aws.String(elbv2.ActionTypeEnumForward),
+			TargetGroupArn: aws.String(targetGroupARN),
+		},
+	}
+}
+
+func healthCheckPort(hc *infrav1.TargetGroupHealthCheckSpec) *string {
+	if hc.Port == nil {
+		return nil
+	}
+	return aws.String(fmt.Sprintf("%d", *hc.Port))
+}
+
+// healthCheckNeedsUpdate returns true if a field set in the health check differs from the target group.
+func healthCheckNeedsUpdate(tg *elbv2.TargetGroup, hc *infrav1.TargetGroupHealthCheckSpec) bool {
+	switch {
+	case hc.Protocol != nil && aws.StringValue(hc.Protocol) != aws.StringValue(tg.HealthCheckProtocol):
+		return true
+	case hc.Path != nil && aws.StringValue(hc.Path) != aws.StringValue(tg.HealthCheckPath):
+		return true
+	case hc.Port != nil && aws.StringValue(healthCheckPort(hc)) != aws.StringValue(tg.HealthCheckPort):
+		return true
+	case hc.IntervalSeconds != nil && aws.Int64Value(hc.IntervalSeconds) != aws.Int64Value(tg.HealthCheckIntervalSeconds):
+		return true
+	case hc.TimeoutSeconds != nil && aws.Int64Value(hc.TimeoutSeconds) != aws.Int64Value(tg.HealthCheckTimeoutSeconds):
+		return true
+	case hc.ThresholdCount != nil && aws.Int64Value(hc.ThresholdCount) != aws.Int64Value(tg.HealthyThresholdCount):
+		return true
+	case hc.UnhealthyThresholdCount != nil && aws.Int64Value(hc.UnhealthyThresholdCount) != aws.Int64Value(tg.UnhealthyThresholdCount):
+		return true
+	}
+	return false
+}
@@ -24,7 +24,8 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-	v1beta2 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	v1beta2 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	v1beta20 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	scope "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 )
 
@@ -52,10 +53,10 @@ func (m *MockASGInterface) EXPECT() *MockASGInterfaceMockRecorder {
 }
 
 // ASGIfExists mocks base method.
-func (m *MockASGInterface) ASGIfExists(arg0 *string) (*v1beta2.AutoScalingGroup, error) {
+func (m *MockASGInterface) ASGIfExists(arg0 *string) (*v1beta20.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ASGIfExists", arg0)
-	ret0, _ := ret[0].(*v1beta2.AutoScalingGroup)
+	ret0, _ := ret[0].(*v1beta20.AutoScalingGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -82,10 +83,10 @@ func (mr *MockASGInterfaceMockRecorder) CanStartASGInstanceRefresh(arg0 interfac
 }
 
 // CreateASG mocks base method.
-func (m *MockASGInterface) CreateASG(arg0 *scope.MachinePoolScope) (*v1beta2.AutoScalingGroup, error) {
+func (m *MockASGInterface) CreateASG(arg0 *scope.MachinePoolScope) (*v1beta20.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateASG", arg0)
-	ret0, _ := ret[0].(*v1beta2.AutoScalingGroup)
+	ret0, _ := ret[0].(*v1beta20.AutoScalingGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -110,11 +111,53 @@ func (mr *MockASGInterfaceMockRecorder) DeleteASGAndWait(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteASGAndWait", reflect.TypeOf((*MockASGInterface)(nil).DeleteASGAndWait), arg0)
 }
 
+// DeleteScalingPolicyAlarms mocks base method.
+func (m *MockASGInterface) DeleteScalingPolicyAlarms(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScalingPolicyAlarms", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScalingPolicyAlarms indicates an expected call of DeleteScalingPolicyAlarms.
+func (mr *MockASGInterfaceMockRecorder) DeleteScalingPolicyAlarms(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScalingPolicyAlarms", reflect.TypeOf((*MockASGInterface)(nil).DeleteScalingPolicyAlarms), arg0)
+}
+
+// DisableMetricsCollection mocks base method.
+func (m *MockASGInterface) DisableMetricsCollection(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMetricsCollection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMetricsCollection indicates an expected call of DisableMetricsCollection.
+func (mr *MockASGInterfaceMockRecorder) DisableMetricsCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMetricsCollection", reflect.TypeOf((*MockASGInterface)(nil).DisableMetricsCollection), arg0, arg1)
+}
+
+// EnableMetricsCollection mocks base method.
+func (m *MockASGInterface) EnableMetricsCollection(arg0, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMetricsCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableMetricsCollection indicates an expected call of EnableMetricsCollection.
+func (mr *MockASGInterfaceMockRecorder) EnableMetricsCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMetricsCollection", reflect.TypeOf((*MockASGInterface)(nil).EnableMetricsCollection), arg0, arg1, arg2)
+}
+
 // GetASGByName mocks base method.
-func (m *MockASGInterface) GetASGByName(arg0 *scope.MachinePoolScope) (*v1beta2.AutoScalingGroup, error) {
+func (m *MockASGInterface) GetASGByName(arg0 *scope.MachinePoolScope) (*v1beta20.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetASGByName", arg0)
-	ret0, _ := ret[0].(*v1beta2.AutoScalingGroup)
+	ret0, _ := ret[0].(*v1beta20.AutoScalingGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -125,6 +168,49 @@ func (mr *MockASGInterfaceMockRecorder) GetASGByName(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetASGByName", reflect.TypeOf((*MockASGInterface)(nil).GetASGByName), arg0)
 }
 
+// OutdatedInstances mocks base method.
+func (m *MockASGInterface) OutdatedInstances(arg0 *scope.MachinePoolScope, arg1 *v1beta20.AutoScalingGroup) ([]v1beta2.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutdatedInstances", arg0, arg1)
+	ret0, _ := ret[0].([]v1beta2.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OutdatedInstances indicates an expected call of OutdatedInstances.
+func (mr *MockASGInterfaceMockRecorder) OutdatedInstances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutdatedInstances", reflect.TypeOf((*MockASGInterface)(nil).OutdatedInstances), arg0, arg1)
+}
+
+// ReconcileScalingPolicies mocks base method.
+func (m *MockASGInterface) ReconcileScalingPolicies(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileScalingPolicies", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileScalingPolicies indicates an expected call of ReconcileScalingPolicies.
+func (mr *MockASGInterfaceMockRecorder) ReconcileScalingPolicies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileScalingPolicies", reflect.TypeOf((*MockASGInterface)(nil).ReconcileScalingPolicies), arg0)
+}
+
+// ReconcileSuspendedAvailabilityZones mocks base method.
+func (m *MockASGInterface) ReconcileSuspendedAvailabilityZones(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileSuspendedAvailabilityZones", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileSuspendedAvailabilityZones indicates an expected call of ReconcileSuspendedAvailabilityZones.
+func (mr *MockASGInterfaceMockRecorder) ReconcileSuspendedAvailabilityZones(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileSuspendedAvailabilityZones", reflect.TypeOf((*MockASGInterface)(nil).ReconcileSuspendedAvailabilityZones), arg0)
+}
+
 // ResumeProcesses mocks base method.
 func (m *MockASGInterface) ResumeProcesses(arg0 string, arg1 []string) error {
 	m.ctrl.T.Helper()
@@ -182,6 +268,20 @@ func (mr *MockASGInterfaceMockRecorder) SuspendProcesses(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendProcesses", reflect.TypeOf((*MockASGInterface)(nil).SuspendProcesses), arg0, arg1)
 }
 
+// TerminateASGInstance mocks base method.
+func (m *MockASGInterface) TerminateASGInstance(arg0 *scope.MachinePoolScope, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateASGInstance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TerminateASGInstance indicates an expected call of TerminateASGInstance.
+func (mr *MockASGInterfaceMockRecorder) TerminateASGInstance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateASGInstance", reflect.TypeOf((*MockASGInterface)(nil).TerminateASGInstance), arg0, arg1, arg2)
+}
+
 // UpdateASG mocks base method.
 func (m *MockASGInterface) UpdateASG(arg0 *scope.MachinePoolScope) error {
 	m.ctrl.T.Helper()
@@ -24,6 +24,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 	v1beta2 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	v1beta20 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	scope "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
@@ -52,6 +53,34 @@ func (m *MockEC2Interface) EXPECT() *MockEC2InterfaceMockRecorder {
 	return m.recorder
 }
 
+// ApplyCleanupPolicy mocks base method.
+func (m *MockEC2Interface) ApplyCleanupPolicy(arg0 *scope.MachineScope, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyCleanupPolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyCleanupPolicy indicates an expected call of ApplyCleanupPolicy.
+func (mr *MockEC2InterfaceMockRecorder) ApplyCleanupPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCleanupPolicy", reflect.TypeOf((*MockEC2Interface)(nil).ApplyCleanupPolicy), arg0, arg1)
+}
+
+// CheckSecurityGroupsPerNetworkInterface mocks base method.
+func (m *MockEC2Interface) CheckSecurityGroupsPerNetworkInterface(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSecurityGroupsPerNetworkInterface", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSecurityGroupsPerNetworkInterface indicates an expected call of CheckSecurityGroupsPerNetworkInterface.
+func (mr *MockEC2InterfaceMockRecorder) CheckSecurityGroupsPerNetworkInterface(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSecurityGroupsPerNetworkInterface", reflect.TypeOf((*MockEC2Interface)(nil).CheckSecurityGroupsPerNetworkInterface), arg0)
+}
+
 // CreateInstance mocks base method.
 func (m *MockEC2Interface) CreateInstance(arg0 *scope.MachineScope, arg1 []byte, arg2 string) (*v1beta2.Instance, error) {
 	m.ctrl.T.Helper()
@@ -259,6 +288,20 @@ func (mr *MockEC2InterfaceMockRecorder) GetRunningInstanceByTags(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningInstanceByTags", reflect.TypeOf((*MockEC2Interface)(nil).GetRunningInstanceByTags), arg0)
 }
 
+// HibernateInstance mocks base method.
+func (m *MockEC2Interface) HibernateInstance(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HibernateInstance", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HibernateInstance indicates an expected call of HibernateInstance.
+func (mr *MockEC2InterfaceMockRecorder) HibernateInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HibernateInstance", reflect.TypeOf((*MockEC2Interface)(nil).HibernateInstance), arg0)
+}
+
 // InstanceIfExists mocks base method.
 func (m *MockEC2Interface) InstanceIfExists(arg0 *string) (*v1beta2.Instance, error) {
 	m.ctrl.T.Helper()
@@ -274,6 +317,21 @@ func (mr *MockEC2InterfaceMockRecorder) InstanceIfExists(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceIfExists", reflect.TypeOf((*MockEC2Interface)(nil).InstanceIfExists), arg0)
 }
 
+// InstanceTypeCapacity mocks base method.
+func (m *MockEC2Interface) InstanceTypeCapacity(arg0 string) (v1.ResourceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstanceTypeCapacity", arg0)
+	ret0, _ := ret[0].(v1.ResourceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstanceTypeCapacity indicates an expected call of InstanceTypeCapacity.
+func (mr *MockEC2InterfaceMockRecorder) InstanceTypeCapacity(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceTypeCapacity", reflect.TypeOf((*MockEC2Interface)(nil).InstanceTypeCapacity), arg0)
+}
+
 // LaunchTemplateNeedsUpdate mocks base method.
 func (m *MockEC2Interface) LaunchTemplateNeedsUpdate(arg0 scope.LaunchTemplateScope, arg1, arg2 *v1beta20.AWSLaunchTemplate) (bool, error) {
 	m.ctrl.T.Helper()
@@ -359,6 +417,34 @@ func (mr *MockEC2InterfaceMockRecorder) ReconcileTags(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileTags", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileTags), arg0, arg1)
 }
 
+// RetainNonRootVolumes mocks base method.
+func (m *MockEC2Interface) RetainNonRootVolumes(arg0 string, arg1 []v1beta2.Volume) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetainNonRootVolumes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetainNonRootVolumes indicates an expected call of RetainNonRootVolumes.
+func (mr *MockEC2InterfaceMockRecorder) RetainNonRootVolumes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetainNonRootVolumes", reflect.TypeOf((*MockEC2Interface)(nil).RetainNonRootVolumes), arg0, arg1)
+}
+
+// SnapshotVolumes mocks base method.
+func (m *MockEC2Interface) SnapshotVolumes(arg0 *scope.MachineScope, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotVolumes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SnapshotVolumes indicates an expected call of SnapshotVolumes.
+func (mr *MockEC2InterfaceMockRecorder) SnapshotVolumes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotVolumes", reflect.TypeOf((*MockEC2Interface)(nil).SnapshotVolumes), arg0, arg1)
+}
+
 // TerminateInstance mocks base method.
 func (m *MockEC2Interface) TerminateInstance(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -414,3 +500,17 @@ func (mr *MockEC2InterfaceMockRecorder) UpdateResourceTags(arg0, arg1, arg2 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResourceTags", reflect.TypeOf((*MockEC2Interface)(nil).UpdateResourceTags), arg0, arg1, arg2)
 }
+
+// ValidateCapacityReservation mocks base method.
+func (m *MockEC2Interface) ValidateCapacityReservation(arg0, arg1 string, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCapacityReservation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateCapacityReservation indicates an expected call of ValidateCapacityReservation.
+func (mr *MockEC2InterfaceMockRecorder) ValidateCapacityReservation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCapacityReservation", reflect.TypeOf((*MockEC2Interface)(nil).ValidateCapacityReservation), arg0, arg1, arg2)
+}
@@ -24,6 +24,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	v1beta2 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 )
 
 // MockNetworkInterface is a mock of NetworkInterface interface.
@@ -63,6 +64,21 @@ func (mr *MockNetworkInterfaceMockRecorder) DeleteNetwork() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetwork", reflect.TypeOf((*MockNetworkInterface)(nil).DeleteNetwork))
 }
 
+// ObserveNetwork mocks base method.
+func (m *MockNetworkInterface) ObserveNetwork() (v1beta2.Subnets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ObserveNetwork")
+	ret0, _ := ret[0].(v1beta2.Subnets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObserveNetwork indicates an expected call of ObserveNetwork.
+func (mr *MockNetworkInterfaceMockRecorder) ObserveNetwork() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveNetwork", reflect.TypeOf((*MockNetworkInterface)(nil).ObserveNetwork))
+}
+
 // ReconcileNetwork mocks base method.
 func (m *MockNetworkInterface) ReconcileNetwork() error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,397 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	rgapi "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+const (
+	// alarmResourceType is the type of CloudWatch alarms in the resource groups tagging API.
+	alarmResourceType = "cloudwatch:alarm"
+
+	// maxAlarmsPerRequest is the maximum number of alarms that can be described or deleted at once.
+	maxAlarmsPerRequest = 100
+
+	// monitoringRoleTagValue is the value of the role tag of the alarms.
+	monitoringRoleTagValue = "monitoring"
+)
+
+// ReconcileAlarms creates or updates the CloudWatch alarms of the NAT gateways, of the control plane
+// load balancer and of the bastion host of the cluster, and deletes the alarms of resources which no
+// longer exist. All the alarms of the cluster are deleted when monitoring is disabled.
+func (s *Service) ReconcileAlarms() error {
+	if s.scope.Monitoring() == nil {
+		return s.DeleteAlarms()
+	}
+
+	s.scope.Debug("Reconciling CloudWatch alarms")
+
+	desired, err := s.desiredAlarms()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(desired))
+	for _, alarm := range desired {
+		names = append(names, aws.StringValue(alarm.AlarmName))
+	}
+	existing, err := s.describeAlarms(names)
+	if err != nil {
+		return err
+	}
+
+	for _, alarm := range desired {
+		name := aws.StringValue(alarm.AlarmName)
+		current, ok := existing[name]
+		if ok && !alarmNeedsUpdate(current, alarm) {
+			continue
+		}
+		if _, err := s.CloudWatchClient.PutMetricAlarm(alarm); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedPutAlarm", "Failed to create or update CloudWatch alarm %q: %v", name, err)
+			return errors.Wrapf(err, "failed to create or update CloudWatch alarm %q", name)
+		}
+		if ok {
+			record.Eventf(s.scope.InfraCluster(), "SuccessfulUpdateAlarm", "Updated CloudWatch alarm %q", name)
+		} else {
+			record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateAlarm", "Created CloudWatch alarm %q", name)
+		}
+	}
+
+	owned, err := s.ownedAlarmNames()
+	if err != nil {
+		return err
+	}
+	stale := owned.Difference(sets.NewString(names...))
+	if stale.Len() == 0 {
+		return nil
+	}
+	return s.deleteAlarms(stale.List())
+}
+
+// DeleteAlarms deletes all the CloudWatch alarms of the cluster.
+func (s *Service) DeleteAlarms() error {
+	s.scope.Debug("Deleting CloudWatch alarms")
+
+	// Alarms created recently may not be returned by the resource groups tagging API yet,
+	// so the alarms of the resources of the cluster are deleted by name too.
+	names, err := s.ownedAlarmNames()
+	if err != nil {
+		return err
+	}
+	names.Insert(s.alarmNames()...)
+
+	return s.deleteAlarms(names.List())
+}
+
+// desiredAlarms returns the alarms of the resources of the cluster.
+func (s *Service) desiredAlarms() ([]*cloudwatch.PutMetricAlarmInput, error) {
+	var alarms []*cloudwatch.PutMetricAlarmInput
+
+	for _, sn := range s.scope.Subnets().FilterPublic() {
+		if sn.NatGatewayID == nil {
+			continue
+		}
+		alarms = append(alarms, s.newAlarm(&cloudwatch.PutMetricAlarmInput{
+			AlarmName:          aws.String(s.natGatewayAlarmName(*sn.NatGatewayID)),
+			AlarmDescription:   aws.String(fmt.Sprintf("NAT gateway %s of cluster %s failed to allocate source ports", *sn.NatGatewayID, s.scope.Name())),
+			Namespace:          aws.String("AWS/NATGateway"),
+			MetricName:         aws.String("ErrorPortAllocation"),
+			Dimensions:         []*cloudwatch.Dimension{{Name: aws.String("NatGatewayId"), Value: sn.NatGatewayID}},
+			Statistic:          aws.String(cloudwatch.StatisticSum),
+			Period:             aws.Int64(300),
+			EvaluationPeriods:  aws.Int64(1),
+			Threshold:          aws.Float64(0),
+			ComparisonOperator: aws.String(cloudwatch.ComparisonOperatorGreaterThanThreshold),
+		}))
+	}
+
+	loadBalancerAlarms, err := s.loadBalancerAlarms()
+	if err != nil {
+		return nil, err
+	}
+	alarms = append(alarms, loadBalancerAlarms...)
+
+	if bastion := s.scope.BastionInstance(); bastion != nil && bastion.ID != "" {
+		alarms = append(alarms, s.newAlarm(&cloudwatch.PutMetricAlarmInput{
+			AlarmName:          aws.String(s.bastionAlarmName()),
+			AlarmDescription:   aws.String(fmt.Sprintf("Bastion host %s of cluster %s failed its status checks", bastion.ID, s.scope.Name())),
+			Namespace:          aws.String("AWS/EC2"),
+			MetricName:         aws.String("StatusCheckFailed"),
+			Dimensions:         []*cloudwatch.Dimension{{Name: aws.String("InstanceId"), Value: aws.String(bastion.ID)}},
+			Statistic:          aws.String(cloudwatch.StatisticMaximum),
+			Period:             aws.Int64(60),
+			EvaluationPeriods:  aws.Int64(2),
+			Threshold:          aws.Float64(1),
+			ComparisonOperator: aws.String(cloudwatch.ComparisonOperatorGreaterThanOrEqualToThreshold),
+		}))
+	}
+
+	return alarms, nil
+}
+
+// loadBalancerAlarms returns the alarms of the unhealthy hosts behind the control plane load balancer.
+// Load balancers of type nlb, alb and elb report unhealthy hosts by target group.
+func (s *Service) loadBalancerAlarms() ([]*cloudwatch.PutMetricAlarmInput, error) {
+	lb := s.scope.Network().APIServerELB
+
+	if lb.ARN == "" {
+		if lb.Name == "" {
+			return nil, nil
+		}
+		return []*cloudwatch.PutMetricAlarmInput{s.newLoadBalancerAlarm(s.loadBalancerAlarmName(""), "AWS/ELB",
+			[]*cloudwatch.Dimension{{Name: aws.String("LoadBalancerName"), Value: aws.String(lb.Name)}})}, nil
+	}
+
+	parsed, err := arn.Parse(lb.ARN)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the ARN of load balancer %q", lb.Name)
+	}
+	loadBalancer := strings.TrimPrefix(parsed.Resource, "loadbalancer/")
+	var namespace string
+	switch {
+	case strings.HasPrefix(loadBalancer, "net/"):
+		namespace = "AWS/NetworkELB"
+	case strings.HasPrefix(loadBalancer, "app/"):
+		namespace = "AWS/ApplicationELB"
+	case strings.HasPrefix(loadBalancer, "gwy/"):
+		namespace = "AWS/GatewayELB"
+	default:
+		return nil, errors.Errorf("unsupported type of load balancer %q", lb.ARN)
+	}
+
+	out, err := s.ELBV2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(lb.ARN)})
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to describe the target groups of load balancer %q", lb.Name)
+	}
+	targetGroups := map[string]string{}
+	for _, tg := range out.TargetGroups {
+		tgARN, err := arn.Parse(aws.StringValue(tg.TargetGroupArn))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the ARN of target group %q", aws.StringValue(tg.TargetGroupName))
+		}
+		targetGroups[aws.StringValue(tg.TargetGroupName)] = tgARN.Resource
+	}
+
+	var alarms []*cloudwatch.PutMetricAlarmInput
+	for _, listener := range lb.ELBListeners {
+		targetGroup, ok := targetGroups[listener.TargetGroup.Name]
+		if !ok {
+			continue
+		}
+		alarms = append(alarms, s.newLoadBalancerAlarm(s.loadBalancerAlarmName(listener.TargetGroup.Name), namespace, []*cloudwatch.Dimension{
+			{Name: aws.String("LoadBalancer"), Value: aws.String(loadBalancer)},
+			{Name: aws.String("TargetGroup"), Value: aws.String(targetGroup)},
+		}))
+	}
+	return alarms, nil
+}
+
+func (s *Service) newLoadBalancerAlarm(name, namespace string, dimensions []*cloudwatch.Dimension) *cloudwatch.PutMetricAlarmInput {
+	return s.newAlarm(&cloudwatch.PutMetricAlarmInput{
+		AlarmName:          aws.String(name),
+		AlarmDescription:   aws.String(fmt.Sprintf("Hosts behind the control plane load balancer of cluster %s are unhealthy", s.scope.Name())),
+		Namespace:          aws.String(namespace),
+		MetricName:         aws.String("UnHealthyHostCount"),
+		Dimensions:         dimensions,
+		Statistic:          aws.String(cloudwatch.StatisticMaximum),
+		Period:             aws.Int64(60),
+		EvaluationPeriods:  aws.Int64(5),
+		Threshold:          aws.Float64(0),
+		ComparisonOperator: aws.String(cloudwatch.ComparisonOperatorGreaterThanThreshold),
+	})
+}
+
+// newAlarm sets the actions and the tags of the monitoring configuration on the alarm.
+func (s *Service) newAlarm(alarm *cloudwatch.PutMetricAlarmInput) *cloudwatch.PutMetricAlarmInput {
+	monitoring := s.scope.Monitoring()
+	alarm.ActionsEnabled = aws.Bool(true)
+	alarm.AlarmActions = aws.StringSlice(monitoring.AlarmActions)
+	alarm.OKActions = aws.StringSlice(monitoring.OKActions)
+	alarm.TreatMissingData = aws.String("notBreaching")
+
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        alarm.AlarmName,
+		Role:        aws.String(monitoringRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	})
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		alarm.Tags = append(alarm.Tags, &cloudwatch.Tag{Key: aws.String(key), Value: aws.String(tags[key])})
+	}
+
+	return alarm
+}
+
+// alarmNames returns the names of the alarms of the resources of the cluster, without calling AWS.
+func (s *Service) alarmNames() []string {
+	var names []string
+	for _, sn := range s.scope.Subnets().FilterPublic() {
+		if sn.NatGatewayID != nil {
+			names = append(names, s.natGatewayAlarmName(*sn.NatGatewayID))
+		}
+	}
+	names = append(names, s.loadBalancerAlarmName(""))
+	for _, listener := range s.scope.Network().APIServerELB.ELBListeners {
+		names = append(names, s.loadBalancerAlarmName(listener.TargetGroup.Name))
+	}
+	return append(names, s.bastionAlarmName())
+}
+
+func (s *Service) natGatewayAlarmName(id string) string {
+	return fmt.Sprintf("%s-nat-gateway-%s-port-allocation-errors", s.scope.Name(), id)
+}
+
+func (s *Service) loadBalancerAlarmName(targetGroup string) string {
+	if targetGroup == "" {
+		return fmt.Sprintf("%s-apiserver-unhealthy-hosts", s.scope.Name())
+	}
+	return fmt.Sprintf("%s-apiserver-%s-unhealthy-hosts", s.scope.Name(), targetGroup)
+}
+
+func (s *Service) bastionAlarmName() string {
+	return fmt.Sprintf("%s-bastion-status-check-failed", s.scope.Name())
+}
+
+// ownedAlarmNames returns the names of the alarms tagged as owned by the cluster.
+func (s *Service) ownedAlarmNames() (sets.String, error) {
+	names := sets.NewString()
+	err := s.ResourceTaggingClient.GetResourcesPages(&rgapi.GetResourcesInput{
+		ResourceTypeFilters: aws.StringSlice([]string{alarmResourceType}),
+		TagFilters: []*rgapi.TagFilter{
+			{
+				Key:    aws.String(infrav1.ClusterTagKey(s.scope.Name())),
+				Values: aws.StringSlice([]string{string(infrav1.ResourceLifecycleOwned)}),
+			},
+		},
+	}, func(page *rgapi.GetResourcesOutput, lastPage bool) bool {
+		for _, mapping := range page.ResourceTagMappingList {
+			parsed, err := arn.Parse(aws.StringValue(mapping.ResourceARN))
+			if err != nil {
+				continue
+			}
+			names.Insert(strings.TrimPrefix(parsed.Resource, "alarm:"))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the CloudWatch alarms of the cluster")
+	}
+	return names, nil
+}
+
+// describeAlarms returns the existing metric alarms among the named ones, by name.
+func (s *Service) describeAlarms(names []string) (map[string]*cloudwatch.MetricAlarm, error) {
+	alarms := map[string]*cloudwatch.MetricAlarm{}
+	for start := 0; start < len(names); start += maxAlarmsPerRequest {
+		end := start + maxAlarmsPerRequest
+		if end > len(names) {
+			end = len(names)
+		}
+		out, err := s.CloudWatchClient.DescribeAlarms(&cloudwatch.DescribeAlarmsInput{
+			AlarmNames: aws.StringSlice(names[start:end]),
+			AlarmTypes: aws.StringSlice([]string{cloudwatch.AlarmTypeMetricAlarm}),
+			MaxRecords: aws.Int64(maxAlarmsPerRequest),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to describe CloudWatch alarms")
+		}
+		for _, alarm := range out.MetricAlarms {
+			alarms[aws.StringValue(alarm.AlarmName)] = alarm
+		}
+	}
+	return alarms, nil
+}
+
+// deleteAlarms deletes the named alarms which exist, as no alarm is deleted if any of them doesn't.
+func (s *Service) deleteAlarms(names []string) error {
+	existing, err := s.describeAlarms(names)
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+
+	toDelete := make([]string, 0, len(existing))
+	for name := range existing {
+		toDelete = append(toDelete, name)
+	}
+	sort.Strings(toDelete)
+
+	for start := 0; start < len(toDelete); start += maxAlarmsPerRequest {
+		end := start + maxAlarmsPerRequest
+		if end > len(toDelete) {
+			end = len(toDelete)
+		}
+		if _, err := s.CloudWatchClient.DeleteAlarms(&cloudwatch.DeleteAlarmsInput{AlarmNames: aws.StringSlice(toDelete[start:end])}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteAlarms", "Failed to delete CloudWatch alarms %v: %v", toDelete[start:end], err)
+			return errors.Wrapf(err, "failed to delete CloudWatch alarms %v", toDelete[start:end])
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteAlarms", "Deleted CloudWatch alarms %v", toDelete[start:end])
+	}
+	return nil
+}
+
+// alarmNeedsUpdate returns true if the configuration of the existing alarm differs from the desired one.
+// Tags are only set when alarms are created.
+func alarmNeedsUpdate(existing *cloudwatch.MetricAlarm, desired *cloudwatch.PutMetricAlarmInput) bool {
+	return aws.StringValue(existing.AlarmDescription) != aws.StringValue(desired.AlarmDescription) ||
+		aws.StringValue(existing.Namespace) != aws.StringValue(desired.Namespace) ||
+		aws.StringValue(existing.MetricName) != aws.StringValue(desired.MetricName) ||
+		aws.StringValue(existing.Statistic) != aws.StringValue(desired.Statistic) ||
+		aws.Int64Value(existing.Period) != aws.Int64Value(desired.Period) ||
+		aws.Int64Value(existing.EvaluationPeriods) != aws.Int64Value(desired.EvaluationPeriods) ||
+		aws.Float64Value(existing.Threshold) != aws.Float64Value(desired.Threshold) ||
+		aws.StringValue(existing.ComparisonOperator) != aws.StringValue(desired.ComparisonOperator) ||
+		aws.StringValue(existing.TreatMissingData) != aws.StringValue(desired.TreatMissingData) ||
+		aws.BoolValue(existing.ActionsEnabled) != aws.BoolValue(desired.ActionsEnabled) ||
+		!sets.NewString(aws.StringValueSlice(existing.AlarmActions)...).Equal(sets.NewString(aws.StringValueSlice(desired.AlarmActions)...)) ||
+		!sets.NewString(aws.StringValueSlice(existing.OKActions)...).Equal(sets.NewString(aws.StringValueSlice(desired.OKActions)...)) ||
+		!sets.NewString(dimensionStrings(existing.Dimensions)...).Equal(sets.NewString(dimensionStrings(desired.Dimensions)...))
+}
+
+func dimensionStrings(dimensions []*cloudwatch.Dimension) []string {
+	res := make([]string, 0, len(dimensions))
+	for _, d := range dimensions {
+		res = append(res, aws.StringValue(d.Name)+"="+aws.StringValue(d.Value))
+	}
+	return res
+}
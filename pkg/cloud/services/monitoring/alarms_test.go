@@ -0,0 +1,303 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	rgapi "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const alarmARNPrefix = "arn:aws:cloudwatch:us-east-1:123456789012:alarm:"
+
+func TestServiceReconcileAlarms(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		monitoring     *infrav1.MonitoringSpec
+		network        infrav1.NetworkStatus
+		bastion        *infrav1.Instance
+		existing       []*cloudwatch.MetricAlarm
+		owned          []string
+		expect         func(m *mocks.MockELBV2APIMockRecorder)
+		wantPut        []string
+		wantDeleted    []string
+		wantDimensions map[string][]string
+	}{
+		{
+			name:       "should create the alarms of the NAT gateways, the classic load balancer and the bastion",
+			monitoring: &infrav1.MonitoringSpec{AlarmActions: []string{"arn:aws:sns:us-east-1:123456789012:alarms"}},
+			network:    infrav1.NetworkStatus{APIServerELB: infrav1.LoadBalancer{Name: "test-cluster-apiserver"}},
+			bastion:    &infrav1.Instance{ID: "i-bastion"},
+			wantPut: []string{
+				"test-cluster-apiserver-unhealthy-hosts",
+				"test-cluster-bastion-status-check-failed",
+				"test-cluster-nat-gateway-nat-1-port-allocation-errors",
+			},
+			wantDimensions: map[string][]string{
+				"test-cluster-apiserver-unhealthy-hosts":                {"LoadBalancerName=test-cluster-apiserver"},
+				"test-cluster-bastion-status-check-failed":              {"InstanceId=i-bastion"},
+				"test-cluster-nat-gateway-nat-1-port-allocation-errors": {"NatGatewayId=nat-1"},
+			},
+		},
+		{
+			name:       "should create an alarm per target group of a network load balancer",
+			monitoring: &infrav1.MonitoringSpec{},
+			network: infrav1.NetworkStatus{APIServerELB: infrav1.LoadBalancer{
+				Name:         "test-cluster-apiserver",
+				ARN:          "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/test-cluster-apiserver/50dc6c495c0c9188",
+				ELBListeners: []infrav1.Listener{{TargetGroup: infrav1.TargetGroupSpec{Name: "apiserver-target"}}},
+			}},
+			expect: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Eq(&elbv2.DescribeTargetGroupsInput{
+					LoadBalancerArn: aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/test-cluster-apiserver/50dc6c495c0c9188"),
+				})).Return(&elbv2.DescribeTargetGroupsOutput{TargetGroups: []*elbv2.TargetGroup{{
+					TargetGroupName: aws.String("apiserver-target"),
+					TargetGroupArn:  aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/apiserver-target/73e2d6bc24d8a067"),
+				}}}, nil)
+			},
+			wantPut: []string{
+				"test-cluster-apiserver-apiserver-target-unhealthy-hosts",
+				"test-cluster-nat-gateway-nat-1-port-allocation-errors",
+			},
+			wantDimensions: map[string][]string{
+				"test-cluster-apiserver-apiserver-target-unhealthy-hosts": {
+					"LoadBalancer=net/test-cluster-apiserver/50dc6c495c0c9188",
+					"TargetGroup=targetgroup/apiserver-target/73e2d6bc24d8a067",
+				},
+				"test-cluster-nat-gateway-nat-1-port-allocation-errors": {"NatGatewayId=nat-1"},
+			},
+		},
+		{
+			name:       "should not update up to date alarms and delete stale alarms",
+			monitoring: &infrav1.MonitoringSpec{},
+			existing: []*cloudwatch.MetricAlarm{{
+				AlarmName:          aws.String("test-cluster-nat-gateway-nat-1-port-allocation-errors"),
+				AlarmDescription:   aws.String("NAT gateway nat-1 of cluster test-cluster failed to allocate source ports"),
+				Namespace:          aws.String("AWS/NATGateway"),
+				MetricName:         aws.String("ErrorPortAllocation"),
+				Dimensions:         []*cloudwatch.Dimension{{Name: aws.String("NatGatewayId"), Value: aws.String("nat-1")}},
+				Statistic:          aws.String(cloudwatch.StatisticSum),
+				Period:             aws.Int64(300),
+				EvaluationPeriods:  aws.Int64(1),
+				Threshold:          aws.Float64(0),
+				ComparisonOperator: aws.String(cloudwatch.ComparisonOperatorGreaterThanThreshold),
+				TreatMissingData:   aws.String("notBreaching"),
+				ActionsEnabled:     aws.Bool(true),
+			}, {
+				AlarmName: aws.String("test-cluster-bastion-status-check-failed"),
+			}},
+			owned: []string{
+				"test-cluster-nat-gateway-nat-1-port-allocation-errors",
+				"test-cluster-bastion-status-check-failed",
+			},
+			wantDeleted: []string{"test-cluster-bastion-status-check-failed"},
+		},
+		{
+			name:    "should delete all the alarms when monitoring is disabled",
+			bastion: &infrav1.Instance{ID: "i-bastion"},
+			existing: []*cloudwatch.MetricAlarm{
+				{AlarmName: aws.String("test-cluster-bastion-status-check-failed")},
+				{AlarmName: aws.String("test-cluster-nat-gateway-nat-1-port-allocation-errors")},
+			},
+			owned: []string{"test-cluster-nat-gateway-nat-1-port-allocation-errors"},
+			wantDeleted: []string{
+				"test-cluster-bastion-status-check-failed",
+				"test-cluster-nat-gateway-nat-1-port-allocation-errors",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cloudWatchMock := mocks.NewMockCloudWatchAPI(mockCtrl)
+			elbv2Mock := mocks.NewMockELBV2API(mockCtrl)
+			taggingMock := mocks.NewMockResourceGroupsTaggingAPIAPI(mockCtrl)
+
+			existing := map[string]*cloudwatch.MetricAlarm{}
+			for _, alarm := range tt.existing {
+				existing[aws.StringValue(alarm.AlarmName)] = alarm
+			}
+			cloudWatchMock.EXPECT().DescribeAlarms(gomock.Any()).DoAndReturn(func(input *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+				out := &cloudwatch.DescribeAlarmsOutput{}
+				for _, name := range aws.StringValueSlice(input.AlarmNames) {
+					if alarm, ok := existing[name]; ok {
+						out.MetricAlarms = append(out.MetricAlarms, alarm)
+					}
+				}
+				return out, nil
+			}).AnyTimes()
+			taggingMock.EXPECT().GetResourcesPages(gomock.Eq(&rgapi.GetResourcesInput{
+				ResourceTypeFilters: aws.StringSlice([]string{"cloudwatch:alarm"}),
+				TagFilters: []*rgapi.TagFilter{{
+					Key:    aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+					Values: aws.StringSlice([]string{"owned"}),
+				}},
+			}), gomock.Any()).DoAndReturn(func(_ *rgapi.GetResourcesInput, fn func(*rgapi.GetResourcesOutput, bool) bool) error {
+				out := &rgapi.GetResourcesOutput{}
+				for _, name := range tt.owned {
+					out.ResourceTagMappingList = append(out.ResourceTagMappingList, &rgapi.ResourceTagMapping{ResourceARN: aws.String(alarmARNPrefix + name)})
+				}
+				fn(out, true)
+				return nil
+			})
+
+			var put []string
+			dimensions := map[string][]string{}
+			cloudWatchMock.EXPECT().PutMetricAlarm(gomock.Any()).DoAndReturn(func(input *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error) {
+				name := aws.StringValue(input.AlarmName)
+				put = append(put, name)
+				dimensions[name] = dimensionStrings(input.Dimensions)
+				g.Expect(input.AlarmActions).To(Equal(aws.StringSlice(tt.monitoring.AlarmActions)))
+				g.Expect(input.Tags).To(ContainElement(&cloudwatch.Tag{
+					Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+					Value: aws.String("owned"),
+				}))
+				return &cloudwatch.PutMetricAlarmOutput{}, nil
+			}).AnyTimes()
+			var deleted []string
+			cloudWatchMock.EXPECT().DeleteAlarms(gomock.Any()).DoAndReturn(func(input *cloudwatch.DeleteAlarmsInput) (*cloudwatch.DeleteAlarmsOutput, error) {
+				deleted = append(deleted, aws.StringValueSlice(input.AlarmNames)...)
+				return &cloudwatch.DeleteAlarmsOutput{}, nil
+			}).AnyTimes()
+			if tt.expect != nil {
+				tt.expect(elbv2Mock.EXPECT())
+			}
+
+			s := NewService(getClusterScope(t, tt.monitoring, tt.network, tt.bastion))
+			s.CloudWatchClient = cloudWatchMock
+			s.ELBV2Client = elbv2Mock
+			s.ResourceTaggingClient = taggingMock
+
+			g.Expect(s.ReconcileAlarms()).To(Succeed())
+
+			sort.Strings(put)
+			g.Expect(put).To(Equal(tt.wantPut))
+			g.Expect(deleted).To(Equal(tt.wantDeleted))
+			if tt.wantDimensions != nil {
+				g.Expect(dimensions).To(Equal(tt.wantDimensions))
+			}
+		})
+	}
+}
+
+func TestAlarmNeedsUpdate(t *testing.T) {
+	desired := &cloudwatch.PutMetricAlarmInput{
+		AlarmName:    aws.String("alarm"),
+		Namespace:    aws.String("AWS/EC2"),
+		MetricName:   aws.String("StatusCheckFailed"),
+		Dimensions:   []*cloudwatch.Dimension{{Name: aws.String("InstanceId"), Value: aws.String("i-1")}},
+		AlarmActions: aws.StringSlice([]string{"arn:aws:sns:us-east-1:123456789012:a", "arn:aws:sns:us-east-1:123456789012:b"}),
+	}
+
+	tests := []struct {
+		name     string
+		existing *cloudwatch.MetricAlarm
+		want     bool
+	}{
+		{
+			name: "should not update an alarm with the same configuration",
+			existing: &cloudwatch.MetricAlarm{
+				AlarmName:    aws.String("alarm"),
+				Namespace:    aws.String("AWS/EC2"),
+				MetricName:   aws.String("StatusCheckFailed"),
+				Dimensions:   []*cloudwatch.Dimension{{Name: aws.String("InstanceId"), Value: aws.String("i-1")}},
+				AlarmActions: aws.StringSlice([]string{"arn:aws:sns:us-east-1:123456789012:b", "arn:aws:sns:us-east-1:123456789012:a"}),
+			},
+		},
+		{
+			name: "should update an alarm of another dimension",
+			existing: &cloudwatch.MetricAlarm{
+				AlarmName:    aws.String("alarm"),
+				Namespace:    aws.String("AWS/EC2"),
+				MetricName:   aws.String("StatusCheckFailed"),
+				Dimensions:   []*cloudwatch.Dimension{{Name: aws.String("InstanceId"), Value: aws.String("i-2")}},
+				AlarmActions: aws.StringSlice([]string{"arn:aws:sns:us-east-1:123456789012:a", "arn:aws:sns:us-east-1:123456789012:b"}),
+			},
+			want: true,
+		},
+		{
+			name: "should update an alarm with other actions",
+			existing: &cloudwatch.MetricAlarm{
+				AlarmName:    aws.String("alarm"),
+				Namespace:    aws.String("AWS/EC2"),
+				MetricName:   aws.String("StatusCheckFailed"),
+				Dimensions:   []*cloudwatch.Dimension{{Name: aws.String("InstanceId"), Value: aws.String("i-1")}},
+				AlarmActions: aws.StringSlice([]string{"arn:aws:sns:us-east-1:123456789012:a"}),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(alarmNeedsUpdate(tt.existing, desired)).To(Equal(tt.want))
+		})
+	}
+}
+
+func getClusterScope(t *testing.T, monitoring *infrav1.MonitoringSpec, network infrav1.NetworkStatus, bastion *infrav1.Instance) *scope.ClusterScope {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "test-namespace"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "test-namespace"},
+			Spec: infrav1.AWSClusterSpec{
+				Monitoring: monitoring,
+				NetworkSpec: infrav1.NetworkSpec{
+					Subnets: infrav1.Subnets{
+						{ID: "subnet-public", IsPublic: true, NatGatewayID: aws.String("nat-1")},
+						{ID: "subnet-private"},
+					},
+				},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: network,
+				Bastion: bastion,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	return clusterScope
+}
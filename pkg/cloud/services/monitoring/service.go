@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package monitoring manages the CloudWatch alarms of the infrastructure of a cluster.
+package monitoring
+
+import (
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	scope                 scope.MonitoringScope
+	CloudWatchClient      cloudwatchiface.CloudWatchAPI
+	ELBV2Client           elbv2iface.ELBV2API
+	ResourceTaggingClient resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+}
+
+// NewService returns a new service given the monitoring scope.
+func NewService(monitoringScope scope.MonitoringScope) *Service {
+	return &Service{
+		scope:                 monitoringScope,
+		CloudWatchClient:      scope.NewCloudWatchClient(monitoringScope, monitoringScope, monitoringScope, monitoringScope.InfraCluster()),
+		ELBV2Client:           scope.NewELBv2Client(monitoringScope, monitoringScope, monitoringScope, monitoringScope.InfraCluster()),
+		ResourceTaggingClient: scope.NewResourgeTaggingClient(monitoringScope, monitoringScope, monitoringScope, monitoringScope.InfraCluster()),
+	}
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
 
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
@@ -46,3 +48,28 @@ func (s *Service) getAvailableZones() ([]string, error) {
 	sort.Strings(zones)
 	return zones, nil
 }
+
+// checkQuota verifies that the requested number of resources can be created without exceeding the quota.
+// Failures to look up the quota are not fatal, as they shouldn't prevent reconciliation.
+func (s *Service) checkQuota(quota servicequotas.Quota, requested int) error {
+	return s.checkQuotaInZone(quota, "", requested)
+}
+
+// checkQuotaInZone is like checkQuota, for quotas that apply per availability zone.
+func (s *Service) checkQuotaInZone(quota servicequotas.Quota, zone string, requested int) error {
+	if s.QuotaService == nil || requested <= 0 {
+		return nil
+	}
+
+	err := s.QuotaService.CheckInZone(quota, zone, requested)
+	switch {
+	case err == nil:
+		return nil
+	case awserrors.IsQuotaExceeded(err):
+		record.Warnf(s.scope.InfraCluster(), "QuotaExceeded", "Unable to create resources: %v", err)
+		return err
+	default:
+		s.scope.Error(err, "non-fatal: failed to verify service quota", "quota", quota.Name)
+		return nil
+	}
+}
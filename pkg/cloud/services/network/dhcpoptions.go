@@ -0,0 +1,210 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// defaultDHCPOptionsID associates a VPC with the default DHCP options set of the region.
+const defaultDHCPOptionsID = "default"
+
+// reconcileDHCPOptions makes sure the VPC is associated with a DHCP options set matching the spec.
+// DHCP options sets cannot be modified, so a new one replaces the existing one when the spec changes.
+func (s *Service) reconcileDHCPOptions() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping DHCP options reconcile in unmanaged mode")
+		return nil
+	}
+
+	options := s.scope.VPC().DHCPOptions
+	if options == nil {
+		return nil
+	}
+
+	s.scope.Debug("Reconciling DHCP options")
+
+	existing, err := s.describeClusterDHCPOptions()
+	if err != nil {
+		return err
+	}
+
+	var current *ec2.DhcpOptions
+	stale := []*ec2.DhcpOptions{}
+	for _, set := range existing {
+		if current == nil && cmp.Equal(dhcpConfigurations(set), options.Configurations()) {
+			current = set
+			continue
+		}
+		stale = append(stale, set)
+	}
+
+	if current == nil {
+		current, err = s.createDHCPOptions(options)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.associateDHCPOptions(aws.StringValue(current.DhcpOptionsId)); err != nil {
+		return err
+	}
+
+	return s.deleteDHCPOptionsSets(stale)
+}
+
+// deleteDHCPOptions associates the VPC with the default DHCP options set again, and deletes
+// the DHCP options sets created for the cluster.
+func (s *Service) deleteDHCPOptions() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping DHCP options deletion in unmanaged mode")
+		return nil
+	}
+
+	if s.scope.VPC().DHCPOptions == nil || s.scope.VPC().ID == "" {
+		return nil
+	}
+
+	existing, err := s.describeClusterDHCPOptions()
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+
+	if err := s.associateDHCPOptions(defaultDHCPOptionsID); err != nil {
+		return err
+	}
+
+	return s.deleteDHCPOptionsSets(existing)
+}
+
+func (s *Service) describeClusterDHCPOptions() ([]*ec2.DhcpOptions, error) {
+	out, err := s.EC2Client.DescribeDhcpOptions(&ec2.DescribeDhcpOptionsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderOwned(s.scope.Name()),
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeDHCPOptions", "Failed to describe DHCP options sets: %v", err)
+		return nil, errors.Wrap(err, "failed to describe DHCP options sets")
+	}
+
+	return out.DhcpOptions, nil
+}
+
+func (s *Service) createDHCPOptions(options *infrav1.DHCPOptions) (*ec2.DhcpOptions, error) {
+	input := &ec2.CreateDhcpOptionsInput{
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeDhcpOptions, s.getDHCPOptionsTagParams(services.TemporaryResourceID)),
+		},
+	}
+	configurations := options.Configurations()
+	for _, key := range []string{infrav1.DHCPOptionsDomainNameKey, infrav1.DHCPOptionsDomainNameServersKey, infrav1.DHCPOptionsNTPServersKey} {
+		if values, ok := configurations[key]; ok {
+			input.DhcpConfigurations = append(input.DhcpConfigurations, &ec2.NewDhcpConfiguration{
+				Key:    aws.String(key),
+				Values: aws.StringSlice(values),
+			})
+		}
+	}
+
+	out, err := s.EC2Client.CreateDhcpOptions(input)
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateDHCPOptions", "Failed to create DHCP options set: %v", err)
+		return nil, errors.Wrap(err, "failed to create DHCP options set")
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateDHCPOptions", "Created new DHCP options set %q", aws.StringValue(out.DhcpOptions.DhcpOptionsId))
+	s.scope.Info("Created DHCP options set", "dhcp-options-id", aws.StringValue(out.DhcpOptions.DhcpOptionsId))
+
+	return out.DhcpOptions, nil
+}
+
+// associateDHCPOptions associates the VPC with the DHCP options set, unless it already is.
+func (s *Service) associateDHCPOptions(id string) error {
+	out, err := s.EC2Client.DescribeVpcs(&ec2.DescribeVpcsInput{
+		VpcIds: []*string{aws.String(s.scope.VPC().ID)},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe vpc %q", s.scope.VPC().ID)
+	}
+	if len(out.Vpcs) > 0 && aws.StringValue(out.Vpcs[0].DhcpOptionsId) == id {
+		return nil
+	}
+
+	if _, err := s.EC2Client.AssociateDhcpOptions(&ec2.AssociateDhcpOptionsInput{
+		DhcpOptionsId: aws.String(id),
+		VpcId:         aws.String(s.scope.VPC().ID),
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAssociateDHCPOptions", "Failed to associate DHCP options set %q with VPC %q: %v", id, s.scope.VPC().ID, err)
+		return errors.Wrapf(err, "failed to associate DHCP options set %q with vpc %q", id, s.scope.VPC().ID)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateDHCPOptions", "Associated DHCP options set %q with VPC %q", id, s.scope.VPC().ID)
+
+	return nil
+}
+
+func (s *Service) deleteDHCPOptionsSets(sets []*ec2.DhcpOptions) error {
+	for _, set := range sets {
+		id := aws.StringValue(set.DhcpOptionsId)
+		if _, err := s.EC2Client.DeleteDhcpOptions(&ec2.DeleteDhcpOptionsInput{DhcpOptionsId: set.DhcpOptionsId}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteDHCPOptions", "Failed to delete DHCP options set %q: %v", id, err)
+			return errors.Wrapf(err, "failed to delete DHCP options set %q", id)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteDHCPOptions", "Deleted DHCP options set %q", id)
+		s.scope.Info("Deleted DHCP options set", "dhcp-options-id", id)
+	}
+
+	return nil
+}
+
+// dhcpConfigurations returns the values of a DHCP options set by key.
+func dhcpConfigurations(set *ec2.DhcpOptions) map[string][]string {
+	configurations := map[string][]string{}
+	for _, c := range set.DhcpConfigurations {
+		for _, v := range c.Values {
+			key := aws.StringValue(c.Key)
+			configurations[key] = append(configurations[key], aws.StringValue(v.Value))
+		}
+	}
+	return configurations
+}
+
+func (s *Service) getDHCPOptionsTagParams(id string) infrav1.BuildParams {
+	name := fmt.Sprintf("%s-dhcp-options", s.scope.Name())
+
+	return infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		ResourceID:  id,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(infrav1.CommonRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileDHCPOptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	options := &infrav1.DHCPOptions{
+		DomainName:        aws.String("corp.example.com"),
+		DomainNameServers: []string{"10.0.0.10", "10.0.0.11"},
+	}
+	matching := &ec2.DhcpOptions{
+		DhcpOptionsId: aws.String("dopt-1"),
+		DhcpConfigurations: []*ec2.DhcpConfiguration{
+			{Key: aws.String("domain-name"), Values: []*ec2.AttributeValue{{Value: aws.String("corp.example.com")}}},
+			{Key: aws.String("domain-name-servers"), Values: []*ec2.AttributeValue{{Value: aws.String("10.0.0.10")}, {Value: aws.String("10.0.0.11")}}},
+		},
+	}
+	outdated := &ec2.DhcpOptions{
+		DhcpOptionsId: aws.String("dopt-0"),
+		DhcpConfigurations: []*ec2.DhcpConfiguration{
+			{Key: aws.String("domain-name"), Values: []*ec2.AttributeValue{{Value: aws.String("corp.example.com")}}},
+			{Key: aws.String("domain-name-servers"), Values: []*ec2.AttributeValue{{Value: aws.String("10.0.0.10")}}},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		vpc    infrav1.VPCSpec
+		expect func(m *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name: "unmanaged vpc",
+			vpc:  infrav1.VPCSpec{ID: "vpc-dhcp", DHCPOptions: options},
+		},
+		{
+			name: "no dhcp options",
+			vpc: infrav1.VPCSpec{
+				ID:   "vpc-dhcp",
+				Tags: infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+			},
+		},
+		{
+			name: "creates and associates dhcp options",
+			vpc: infrav1.VPCSpec{
+				ID:          "vpc-dhcp",
+				Tags:        infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				DHCPOptions: options,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeDhcpOptions(gomock.AssignableToTypeOf(&ec2.DescribeDhcpOptionsInput{})).
+					Return(&ec2.DescribeDhcpOptionsOutput{}, nil)
+				m.CreateDhcpOptions(gomock.AssignableToTypeOf(&ec2.CreateDhcpOptionsInput{})).
+					DoAndReturn(func(input *ec2.CreateDhcpOptionsInput) (*ec2.CreateDhcpOptionsOutput, error) {
+						g := NewWithT(t)
+						g.Expect(input.DhcpConfigurations).To(Equal([]*ec2.NewDhcpConfiguration{
+							{Key: aws.String("domain-name"), Values: aws.StringSlice([]string{"corp.example.com"})},
+							{Key: aws.String("domain-name-servers"), Values: aws.StringSlice([]string{"10.0.0.10", "10.0.0.11"})},
+						}))
+						g.Expect(aws.StringValue(input.TagSpecifications[0].ResourceType)).To(Equal(ec2.ResourceTypeDhcpOptions))
+						return &ec2.CreateDhcpOptionsOutput{DhcpOptions: matching}, nil
+					})
+				m.DescribeVpcs(gomock.Eq(&ec2.DescribeVpcsInput{VpcIds: aws.StringSlice([]string{"vpc-dhcp"})})).
+					Return(&ec2.DescribeVpcsOutput{Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String("dopt-default")}}}, nil)
+				m.AssociateDhcpOptions(gomock.Eq(&ec2.AssociateDhcpOptionsInput{
+					DhcpOptionsId: aws.String("dopt-1"),
+					VpcId:         aws.String("vpc-dhcp"),
+				})).Return(&ec2.AssociateDhcpOptionsOutput{}, nil)
+			},
+		},
+		{
+			name: "dhcp options already associated",
+			vpc: infrav1.VPCSpec{
+				ID:          "vpc-dhcp",
+				Tags:        infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				DHCPOptions: options,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeDhcpOptions(gomock.AssignableToTypeOf(&ec2.DescribeDhcpOptionsInput{})).
+					Return(&ec2.DescribeDhcpOptionsOutput{DhcpOptions: []*ec2.DhcpOptions{matching}}, nil)
+				m.DescribeVpcs(gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).
+					Return(&ec2.DescribeVpcsOutput{Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String("dopt-1")}}}, nil)
+			},
+		},
+		{
+			name: "replaces outdated dhcp options",
+			vpc: infrav1.VPCSpec{
+				ID:          "vpc-dhcp",
+				Tags:        infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				DHCPOptions: options,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeDhcpOptions(gomock.AssignableToTypeOf(&ec2.DescribeDhcpOptionsInput{})).
+					Return(&ec2.DescribeDhcpOptionsOutput{DhcpOptions: []*ec2.DhcpOptions{outdated}}, nil)
+				m.CreateDhcpOptions(gomock.AssignableToTypeOf(&ec2.CreateDhcpOptionsInput{})).
+					Return(&ec2.CreateDhcpOptionsOutput{DhcpOptions: matching}, nil)
+				m.DescribeVpcs(gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).
+					Return(&ec2.DescribeVpcsOutput{Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String("dopt-0")}}}, nil)
+				m.AssociateDhcpOptions(gomock.Eq(&ec2.AssociateDhcpOptionsInput{
+					DhcpOptionsId: aws.String("dopt-1"),
+					VpcId:         aws.String("vpc-dhcp"),
+				})).Return(&ec2.AssociateDhcpOptionsOutput{}, nil)
+				m.DeleteDhcpOptions(gomock.Eq(&ec2.DeleteDhcpOptionsInput{DhcpOptionsId: aws.String("dopt-0")})).
+					Return(&ec2.DeleteDhcpOptionsOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := NewService(newDHCPOptionsTestScope(t, tc.vpc))
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.reconcileDHCPOptions()).To(Succeed())
+		})
+	}
+}
+
+func TestDeleteDHCPOptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name   string
+		vpc    infrav1.VPCSpec
+		expect func(m *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name: "no dhcp options",
+			vpc: infrav1.VPCSpec{
+				ID:   "vpc-dhcp",
+				Tags: infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+			},
+		},
+		{
+			name: "restores the default dhcp options",
+			vpc: infrav1.VPCSpec{
+				ID:          "vpc-dhcp",
+				Tags:        infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				DHCPOptions: &infrav1.DHCPOptions{DomainName: aws.String("corp.example.com")},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeDhcpOptions(gomock.AssignableToTypeOf(&ec2.DescribeDhcpOptionsInput{})).
+					Return(&ec2.DescribeDhcpOptionsOutput{DhcpOptions: []*ec2.DhcpOptions{{DhcpOptionsId: aws.String("dopt-1")}}}, nil)
+				m.DescribeVpcs(gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).
+					Return(&ec2.DescribeVpcsOutput{Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String("dopt-1")}}}, nil)
+				m.AssociateDhcpOptions(gomock.Eq(&ec2.AssociateDhcpOptionsInput{
+					DhcpOptionsId: aws.String("default"),
+					VpcId:         aws.String("vpc-dhcp"),
+				})).Return(&ec2.AssociateDhcpOptionsOutput{}, nil)
+				m.DeleteDhcpOptions(gomock.Eq(&ec2.DeleteDhcpOptionsInput{DhcpOptionsId: aws.String("dopt-1")})).
+					Return(&ec2.DeleteDhcpOptionsOutput{}, nil)
+			},
+		},
+		{
+			name: "dhcp options already deleted",
+			vpc: infrav1.VPCSpec{
+				ID:          "vpc-dhcp",
+				Tags:        infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				DHCPOptions: &infrav1.DHCPOptions{DomainName: aws.String("corp.example.com")},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeDhcpOptions(gomock.AssignableToTypeOf(&ec2.DescribeDhcpOptionsInput{})).
+					Return(&ec2.DescribeDhcpOptionsOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := NewService(newDHCPOptionsTestScope(t, tc.vpc))
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.deleteDHCPOptions()).To(Succeed())
+		})
+	}
+}
+
+func newDHCPOptionsTestScope(t *testing.T, vpc infrav1.VPCSpec) *scope.ClusterScope {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{VPC: vpc},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	return clusterScope
+}
@@ -76,6 +76,12 @@ func (s *Service) reconcileEgressOnlyInternetGateways() error {
 	return nil
 }
 
+// isPrivateIPv6EgressOnly returns whether the private subnets of an IPv6 VPC without public subnets reach the
+// internet through its egress only internet gateway alone.
+func (s *Service) isPrivateIPv6EgressOnly() bool {
+	return s.scope.PrivateIPv6Egress() && s.scope.VPC().IsIPv6Enabled() && len(s.scope.Subnets().FilterPublic()) == 0
+}
+
 func (s *Service) deleteEgressOnlyInternetGateways() error {
 	if !s.scope.VPC().IsIPv6Enabled() {
 		s.scope.Trace("Skipping egress only internet gateway deletion in none ipv6 mode")
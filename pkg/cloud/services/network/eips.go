@@ -26,6 +26,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
@@ -44,6 +45,10 @@ func (s *Service) getOrAllocateAddresses(num int, role string) (eips []string, e
 		}
 	}
 
+	if err := s.checkQuota(servicequotas.ElasticIPsPerRegion, num-len(eips)); err != nil {
+		return nil, err
+	}
+
 	for len(eips) < num {
 		ip, err := s.allocateAddress(role)
 		if err != nil {
@@ -55,6 +60,53 @@ func (s *Service) getOrAllocateAddresses(num int, role string) (eips []string, e
 	return eips, nil
 }
 
+// getAddressesFromPool returns the allocation IDs of num unassociated Elastic IPs of the pool.
+// Elastic IPs of a pool are never allocated, so it fails if the pool doesn't have enough of them.
+func (s *Service) getAddressesFromPool(pool *infrav1.ElasticIPPool, num int) ([]string, error) {
+	out, err := s.EC2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: filter.EC2.ElasticIPPool(pool),
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeAddresses", "Failed to query addresses of Elastic IP pool: %v", err)
+		return nil, errors.Wrap(err, "failed to query addresses of Elastic IP pool")
+	}
+
+	eips := []string{}
+	for _, address := range out.Addresses {
+		if address.AssociationId == nil {
+			eips = append(eips, aws.StringValue(address.AllocationId))
+		}
+	}
+	if len(eips) < num {
+		record.Warnf(s.scope.InfraCluster(), "InsufficientElasticIPPool", "Elastic IP pool has %d unassociated addresses, %d are needed", len(eips), num)
+		return nil, errors.Errorf("Elastic IP pool has %d unassociated addresses, %d are needed", len(eips), num)
+	}
+	pool.SortAllocationIDs(eips)
+
+	return eips[:num], nil
+}
+
+// getPoolAllocationIDs returns the allocation IDs of all the Elastic IPs selected by the
+// pools of the cluster, which must be left alone when releasing the addresses of the cluster.
+func (s *Service) getPoolAllocationIDs() (map[string]struct{}, error) {
+	ids := map[string]struct{}{}
+	for _, pool := range []*infrav1.ElasticIPPool{s.scope.VPC().NATGatewayElasticIPPool, s.scope.Bastion().ElasticIPPool} {
+		if pool == nil {
+			continue
+		}
+		out, err := s.EC2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+			Filters: filter.EC2.ElasticIPPool(pool),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query addresses of Elastic IP pool")
+		}
+		for _, address := range out.Addresses {
+			ids[aws.StringValue(address.AllocationId)] = struct{}{}
+		}
+	}
+	return ids, nil
+}
+
 func (s *Service) allocateAddress(role string) (string, error) {
 	tagSpecifications := tags.BuildParamsToTagSpecification(ec2.ResourceTypeElasticIp, s.getEIPTagParams(role))
 	out, err := s.EC2Client.AllocateAddress(&ec2.AllocateAddressInput{
@@ -112,8 +164,16 @@ func (s *Service) releaseAddresses() error {
 	if out == nil {
 		return nil
 	}
+	poolIDs, err := s.getPoolAllocationIDs()
+	if err != nil {
+		return err
+	}
 	for i := range out.Addresses {
 		ip := out.Addresses[i]
+		if _, ok := poolIDs[aws.StringValue(ip.AllocationId)]; ok {
+			s.scope.Debug("Skipping release of Elastic IP from pool", "allocation-id", aws.StringValue(ip.AllocationId))
+			continue
+		}
 		if ip.AssociationId != nil {
 			if _, err := s.EC2Client.DisassociateAddress(&ec2.DisassociateAddressInput{
 				AssociationId: ip.AssociationId,
@@ -40,9 +40,10 @@ func TestServiceReleaseAddresses(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	tests := []struct {
-		name    string
-		expect  func(m *mocks.MockEC2APIMockRecorder)
-		wantErr bool
+		name          string
+		elasticIPPool *infrav1.ElasticIPPool
+		expect        func(m *mocks.MockEC2APIMockRecorder)
+		wantErr       bool
 	}{
 		{
 			name: "Should return error if failed to describe IP addresses",
@@ -140,6 +141,40 @@ func TestServiceReleaseAddresses(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:          "Should not release IP addresses of the Elastic IP pool",
+			elasticIPPool: &infrav1.ElasticIPPool{Tags: infrav1.Tags{"egress": "partners"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddresses(gomock.Eq(&ec2.DescribeAddressesInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("tag:egress"),
+							Values: aws.StringSlice([]string{"partners"}),
+						},
+					},
+				})).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{
+						{
+							PublicIp:     aws.String("public-ip-2"),
+							AllocationId: aws.String("allocation-id-2"),
+						},
+					},
+				}, nil)
+				m.DescribeAddresses(gomock.AssignableToTypeOf(&ec2.DescribeAddressesInput{})).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{
+						{
+							PublicIp:     aws.String("public-ip-1"),
+							AllocationId: aws.String("allocation-id-1"),
+						},
+						{
+							PublicIp:     aws.String("public-ip-2"),
+							AllocationId: aws.String("allocation-id-2"),
+						},
+					},
+				}, nil)
+				m.ReleaseAddress(gomock.Eq(&ec2.ReleaseAddressInput{AllocationId: aws.String("allocation-id-1")})).Return(nil, nil)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -153,9 +188,15 @@ func TestServiceReleaseAddresses(t *testing.T) {
 			ec2Mock := mocks.NewMockEC2API(mockCtrl)
 
 			cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
-				Client:     client,
-				Cluster:    &clusterv1.Cluster{},
-				AWSCluster: &infrav1.AWSCluster{},
+				Client:  client,
+				Cluster: &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC: infrav1.VPCSpec{NATGatewayElasticIPPool: tt.elasticIPPool},
+						},
+					},
+				},
 			})
 			g.Expect(err).NotTo(HaveOccurred())
 
@@ -0,0 +1,208 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// defaultFlowLogsMaxAggregationInterval is the maximum aggregation interval of flow logs, in seconds, when it is not set.
+const defaultFlowLogsMaxAggregationInterval = 600
+
+// reconcileFlowLogs makes sure the VPC has a flow log matching the spec. Flow logs cannot be modified,
+// so a new one replaces the existing one when the spec changes.
+func (s *Service) reconcileFlowLogs() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping flow logs reconcile in unmanaged mode")
+		return nil
+	}
+
+	flowLogs := s.scope.VPC().FlowLogs
+	if flowLogs == nil {
+		return nil
+	}
+
+	s.scope.Debug("Reconciling flow logs")
+
+	existing, err := s.describeClusterFlowLogs()
+	if err != nil {
+		return err
+	}
+
+	var current *ec2.FlowLog
+	stale := []*ec2.FlowLog{}
+	for _, flowLog := range existing {
+		if current == nil && flowLogMatches(flowLog, flowLogs) {
+			current = flowLog
+			continue
+		}
+		stale = append(stale, flowLog)
+	}
+
+	if current == nil {
+		if err := s.createFlowLog(flowLogs); err != nil {
+			return err
+		}
+	}
+
+	return s.deleteFlowLogs(stale)
+}
+
+// deleteClusterFlowLogs deletes the flow logs created for the cluster.
+func (s *Service) deleteClusterFlowLogs() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping flow logs deletion in unmanaged mode")
+		return nil
+	}
+
+	if s.scope.VPC().FlowLogs == nil || s.scope.VPC().ID == "" {
+		return nil
+	}
+
+	existing, err := s.describeClusterFlowLogs()
+	if err != nil {
+		return err
+	}
+
+	return s.deleteFlowLogs(existing)
+}
+
+func (s *Service) describeClusterFlowLogs() ([]*ec2.FlowLog, error) {
+	out, err := s.EC2Client.DescribeFlowLogs(&ec2.DescribeFlowLogsInput{
+		Filter: []*ec2.Filter{
+			filter.EC2.ProviderOwned(s.scope.Name()),
+			{
+				Name:   aws.String("resource-id"),
+				Values: aws.StringSlice([]string{s.scope.VPC().ID}),
+			},
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeFlowLogs", "Failed to describe flow logs of VPC %q: %v", s.scope.VPC().ID, err)
+		return nil, errors.Wrapf(err, "failed to describe flow logs of vpc %q", s.scope.VPC().ID)
+	}
+
+	return out.FlowLogs, nil
+}
+
+func (s *Service) createFlowLog(flowLogs *infrav1.VPCFlowLogs) error {
+	input := &ec2.CreateFlowLogsInput{
+		ResourceIds:              aws.StringSlice([]string{s.scope.VPC().ID}),
+		ResourceType:             aws.String(ec2.FlowLogsResourceTypeVpc),
+		LogDestinationType:       aws.String(string(flowLogs.DestinationType)),
+		LogDestination:           aws.String(flowLogs.DestinationARN),
+		DeliverLogsPermissionArn: flowLogs.DeliverLogsPermissionARN,
+		TrafficType:              aws.String(string(flowLogsTrafficType(flowLogs))),
+		MaxAggregationInterval:   aws.Int64(flowLogsMaxAggregationInterval(flowLogs)),
+		LogFormat:                flowLogs.LogFormat,
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeVpcFlowLog, s.getFlowLogTagParams(services.TemporaryResourceID)),
+		},
+	}
+
+	out, err := s.EC2Client.CreateFlowLogs(input)
+	if err == nil && len(out.Unsuccessful) > 0 {
+		err = unsuccessfulFlowLogsError(out.Unsuccessful)
+	}
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateFlowLog", "Failed to create flow log for VPC %q: %v", s.scope.VPC().ID, err)
+		return errors.Wrapf(err, "failed to create flow log for vpc %q", s.scope.VPC().ID)
+	}
+
+	id := strings.Join(aws.StringValueSlice(out.FlowLogIds), ",")
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateFlowLog", "Created new flow log %q for VPC %q", id, s.scope.VPC().ID)
+	s.scope.Info("Created flow log", "flow-log-id", id, "vpc-id", s.scope.VPC().ID)
+
+	return nil
+}
+
+func (s *Service) deleteFlowLogs(flowLogs []*ec2.FlowLog) error {
+	for _, flowLog := range flowLogs {
+		id := aws.StringValue(flowLog.FlowLogId)
+		out, err := s.EC2Client.DeleteFlowLogs(&ec2.DeleteFlowLogsInput{FlowLogIds: []*string{flowLog.FlowLogId}})
+		if err == nil && len(out.Unsuccessful) > 0 {
+			err = unsuccessfulFlowLogsError(out.Unsuccessful)
+		}
+		if err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteFlowLog", "Failed to delete flow log %q: %v", id, err)
+			return errors.Wrapf(err, "failed to delete flow log %q", id)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteFlowLog", "Deleted flow log %q", id)
+		s.scope.Info("Deleted flow log", "flow-log-id", id)
+	}
+
+	return nil
+}
+
+// flowLogMatches returns whether an existing flow log matches the spec. The log format is only compared
+// when it is set, as flow logs report the default format otherwise.
+func flowLogMatches(flowLog *ec2.FlowLog, flowLogs *infrav1.VPCFlowLogs) bool {
+	return aws.StringValue(flowLog.LogDestinationType) == string(flowLogs.DestinationType) &&
+		aws.StringValue(flowLog.LogDestination) == flowLogs.DestinationARN &&
+		aws.StringValue(flowLog.DeliverLogsPermissionArn) == aws.StringValue(flowLogs.DeliverLogsPermissionARN) &&
+		aws.StringValue(flowLog.TrafficType) == string(flowLogsTrafficType(flowLogs)) &&
+		aws.Int64Value(flowLog.MaxAggregationInterval) == flowLogsMaxAggregationInterval(flowLogs) &&
+		(flowLogs.LogFormat == nil || aws.StringValue(flowLog.LogFormat) == *flowLogs.LogFormat)
+}
+
+func flowLogsTrafficType(flowLogs *infrav1.VPCFlowLogs) infrav1.FlowLogsTrafficType {
+	if flowLogs.TrafficType == "" {
+		return infrav1.FlowLogsTrafficTypeAll
+	}
+	return flowLogs.TrafficType
+}
+
+func flowLogsMaxAggregationInterval(flowLogs *infrav1.VPCFlowLogs) int64 {
+	if flowLogs.MaxAggregationInterval == nil {
+		return defaultFlowLogsMaxAggregationInterval
+	}
+	return *flowLogs.MaxAggregationInterval
+}
+
+func unsuccessfulFlowLogsError(items []*ec2.UnsuccessfulItem) error {
+	messages := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.Error != nil {
+			messages = append(messages, fmt.Sprintf("%s: %s", aws.StringValue(item.Error.Code), aws.StringValue(item.Error.Message)))
+		}
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+func (s *Service) getFlowLogTagParams(id string) infrav1.BuildParams {
+	name := fmt.Sprintf("%s-flow-log", s.scope.Name())
+
+	return infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		ResourceID:  id,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(infrav1.CommonRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	}
+}
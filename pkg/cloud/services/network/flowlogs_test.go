@@ -0,0 +1,230 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestReconcileFlowLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	flowLogs := &infrav1.VPCFlowLogs{
+		DestinationType:          infrav1.FlowLogsDestinationTypeCloudWatchLogs,
+		DestinationARN:           "arn:aws:logs:us-east-1:123456789012:log-group:flow-logs",
+		DeliverLogsPermissionARN: aws.String("arn:aws:iam::123456789012:role/flow-logs"),
+		TrafficType:              infrav1.FlowLogsTrafficTypeReject,
+	}
+	matching := &ec2.FlowLog{
+		FlowLogId:                aws.String("fl-1"),
+		LogDestinationType:       aws.String("cloud-watch-logs"),
+		LogDestination:           aws.String("arn:aws:logs:us-east-1:123456789012:log-group:flow-logs"),
+		DeliverLogsPermissionArn: aws.String("arn:aws:iam::123456789012:role/flow-logs"),
+		TrafficType:              aws.String("REJECT"),
+		MaxAggregationInterval:   aws.Int64(600),
+		LogFormat:                aws.String("${version} ${account-id} ${interface-id}"),
+	}
+	outdated := &ec2.FlowLog{
+		FlowLogId:                aws.String("fl-0"),
+		LogDestinationType:       aws.String("cloud-watch-logs"),
+		LogDestination:           aws.String("arn:aws:logs:us-east-1:123456789012:log-group:flow-logs"),
+		DeliverLogsPermissionArn: aws.String("arn:aws:iam::123456789012:role/flow-logs"),
+		TrafficType:              aws.String("ALL"),
+		MaxAggregationInterval:   aws.Int64(600),
+	}
+
+	testCases := []struct {
+		name   string
+		vpc    infrav1.VPCSpec
+		expect func(m *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name: "unmanaged vpc",
+			vpc:  infrav1.VPCSpec{ID: "vpc-flow-logs", FlowLogs: flowLogs},
+		},
+		{
+			name: "no flow logs",
+			vpc: infrav1.VPCSpec{
+				ID:   "vpc-flow-logs",
+				Tags: infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+			},
+		},
+		{
+			name: "creates flow log",
+			vpc: infrav1.VPCSpec{
+				ID:       "vpc-flow-logs",
+				Tags:     infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				FlowLogs: flowLogs,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeFlowLogs(gomock.AssignableToTypeOf(&ec2.DescribeFlowLogsInput{})).
+					Return(&ec2.DescribeFlowLogsOutput{}, nil)
+				m.CreateFlowLogs(gomock.AssignableToTypeOf(&ec2.CreateFlowLogsInput{})).
+					DoAndReturn(func(input *ec2.CreateFlowLogsInput) (*ec2.CreateFlowLogsOutput, error) {
+						g := NewWithT(t)
+						g.Expect(input.ResourceIds).To(Equal(aws.StringSlice([]string{"vpc-flow-logs"})))
+						g.Expect(aws.StringValue(input.ResourceType)).To(Equal("VPC"))
+						g.Expect(aws.StringValue(input.LogDestinationType)).To(Equal("cloud-watch-logs"))
+						g.Expect(aws.StringValue(input.LogDestination)).To(Equal("arn:aws:logs:us-east-1:123456789012:log-group:flow-logs"))
+						g.Expect(aws.StringValue(input.DeliverLogsPermissionArn)).To(Equal("arn:aws:iam::123456789012:role/flow-logs"))
+						g.Expect(aws.StringValue(input.TrafficType)).To(Equal("REJECT"))
+						g.Expect(aws.Int64Value(input.MaxAggregationInterval)).To(Equal(int64(600)))
+						g.Expect(input.LogFormat).To(BeNil())
+						g.Expect(aws.StringValue(input.TagSpecifications[0].ResourceType)).To(Equal(ec2.ResourceTypeVpcFlowLog))
+						return &ec2.CreateFlowLogsOutput{FlowLogIds: aws.StringSlice([]string{"fl-1"})}, nil
+					})
+			},
+		},
+		{
+			name: "flow log already exists",
+			vpc: infrav1.VPCSpec{
+				ID:       "vpc-flow-logs",
+				Tags:     infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				FlowLogs: flowLogs,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeFlowLogs(gomock.AssignableToTypeOf(&ec2.DescribeFlowLogsInput{})).
+					Return(&ec2.DescribeFlowLogsOutput{FlowLogs: []*ec2.FlowLog{matching}}, nil)
+			},
+		},
+		{
+			name: "replaces outdated flow log",
+			vpc: infrav1.VPCSpec{
+				ID:       "vpc-flow-logs",
+				Tags:     infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				FlowLogs: flowLogs,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeFlowLogs(gomock.AssignableToTypeOf(&ec2.DescribeFlowLogsInput{})).
+					Return(&ec2.DescribeFlowLogsOutput{FlowLogs: []*ec2.FlowLog{outdated}}, nil)
+				m.CreateFlowLogs(gomock.AssignableToTypeOf(&ec2.CreateFlowLogsInput{})).
+					Return(&ec2.CreateFlowLogsOutput{FlowLogIds: aws.StringSlice([]string{"fl-1"})}, nil)
+				m.DeleteFlowLogs(gomock.Eq(&ec2.DeleteFlowLogsInput{FlowLogIds: aws.StringSlice([]string{"fl-0"})})).
+					Return(&ec2.DeleteFlowLogsOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := NewService(newDHCPOptionsTestScope(t, tc.vpc))
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.reconcileFlowLogs()).To(Succeed())
+		})
+	}
+}
+
+func TestReconcileFlowLogsUnsuccessful(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().DescribeFlowLogs(gomock.AssignableToTypeOf(&ec2.DescribeFlowLogsInput{})).
+		Return(&ec2.DescribeFlowLogsOutput{}, nil)
+	ec2Mock.EXPECT().CreateFlowLogs(gomock.AssignableToTypeOf(&ec2.CreateFlowLogsInput{})).
+		Return(&ec2.CreateFlowLogsOutput{
+			Unsuccessful: []*ec2.UnsuccessfulItem{{
+				ResourceId: aws.String("vpc-flow-logs"),
+				Error: &ec2.UnsuccessfulItemError{
+					Code:    aws.String("400"),
+					Message: aws.String("Access Denied for LogDestination: flow-logs"),
+				},
+			}},
+		}, nil)
+
+	s := NewService(newDHCPOptionsTestScope(t, infrav1.VPCSpec{
+		ID:   "vpc-flow-logs",
+		Tags: infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+		FlowLogs: &infrav1.VPCFlowLogs{
+			DestinationType: infrav1.FlowLogsDestinationTypeS3,
+			DestinationARN:  "arn:aws:s3:::flow-logs",
+		},
+	}))
+	s.EC2Client = ec2Mock
+
+	err := s.reconcileFlowLogs()
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("Access Denied for LogDestination"))
+}
+
+func TestDeleteClusterFlowLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name   string
+		vpc    infrav1.VPCSpec
+		expect func(m *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name: "no flow logs",
+			vpc: infrav1.VPCSpec{
+				ID:   "vpc-flow-logs",
+				Tags: infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+			},
+		},
+		{
+			name: "deletes flow logs",
+			vpc: infrav1.VPCSpec{
+				ID:   "vpc-flow-logs",
+				Tags: infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				FlowLogs: &infrav1.VPCFlowLogs{
+					DestinationType: infrav1.FlowLogsDestinationTypeS3,
+					DestinationARN:  "arn:aws:s3:::flow-logs",
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeFlowLogs(gomock.AssignableToTypeOf(&ec2.DescribeFlowLogsInput{})).
+					Return(&ec2.DescribeFlowLogsOutput{FlowLogs: []*ec2.FlowLog{{FlowLogId: aws.String("fl-1")}}}, nil)
+				m.DeleteFlowLogs(gomock.Eq(&ec2.DeleteFlowLogsInput{FlowLogIds: aws.StringSlice([]string{"fl-1"})})).
+					Return(&ec2.DeleteFlowLogsOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := NewService(newDHCPOptionsTestScope(t, tc.vpc))
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.deleteClusterFlowLogs()).To(Succeed())
+		})
+	}
+}
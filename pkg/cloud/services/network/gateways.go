@@ -28,12 +28,16 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
+// internetGatewayAttachmentStateAvailable is the state AWS reports for the attachment of an attached internet gateway.
+const internetGatewayAttachmentStateAvailable = "available"
+
 func (s *Service) reconcileInternetGateways() error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping internet gateways reconcile in unmanaged mode")
@@ -48,6 +52,21 @@ func (s *Service) reconcileInternetGateways() error {
 			return errors.Errorf("failed to validate network: no internet gateways found in VPC %q", s.scope.VPC().ID)
 		}
 
+		// An internet gateway created outside of the provider is adopted and attached to the VPC.
+		if id := s.scope.VPC().InternetGatewayID; id != nil {
+			ig, err := s.describeInternetGateway(*id)
+			if err != nil {
+				return err
+			}
+			if !s.isManagedInternetGateway(ig) {
+				return s.attachUnmanagedInternetGateway(ig)
+			}
+		}
+
+		if err := s.checkQuota(servicequotas.InternetGatewaysPerRegion, 1); err != nil {
+			return err
+		}
+
 		ig, err := s.createInternetGateway()
 		if err != nil {
 			return err
@@ -58,7 +77,25 @@ func (s *Service) reconcileInternetGateways() error {
 	}
 
 	gateway := igs[0]
+	if id := s.scope.VPC().InternetGatewayID; id != nil && *id != *gateway.InternetGatewayId {
+		ig, err := s.describeInternetGateway(*id)
+		if err != nil && !awserrors.IsNotFound(err) {
+			return err
+		}
+		if ig != nil && !s.isManagedInternetGateway(ig) {
+			return errors.Errorf("failed to validate network: internet gateway %q is set, but internet gateway %q is attached to VPC %q", *id, *gateway.InternetGatewayId, s.scope.VPC().ID)
+		}
+	}
+	managed := s.isManagedInternetGateway(gateway)
 	s.scope.VPC().InternetGatewayID = gateway.InternetGatewayId
+	s.observed.internetGateway = gateway
+
+	// Internet gateways created outside of the provider are left as they are.
+	if !managed {
+		s.scope.Debug("Using unmanaged internet gateway", "internet-gateway-id", *gateway.InternetGatewayId, "vpc-id", s.scope.VPC().ID)
+		conditions.MarkTrue(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition)
+		return nil
+	}
 
 	// Make sure tags are up-to-date.
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
@@ -90,6 +127,7 @@ func (s *Service) deleteInternetGateways() error {
 	}
 
 	for _, ig := range igs {
+		// The VPC can only be deleted once all internet gateways are detached, including unmanaged ones.
 		detachReq := &ec2.DetachInternetGatewayInput{
 			InternetGatewayId: ig.InternetGatewayId,
 			VpcId:             aws.String(s.scope.VPC().ID),
@@ -103,6 +141,11 @@ func (s *Service) deleteInternetGateways() error {
 		record.Eventf(s.scope.InfraCluster(), "SuccessfulDetachInternetGateway", "Detached Internet Gateway %q from VPC %q", *ig.InternetGatewayId, s.scope.VPC().ID)
 		s.scope.Debug("Detached internet gateway from VPC", "internet-gateway-id", *ig.InternetGatewayId, "vpc-id", s.scope.VPC().ID)
 
+		if !s.isManagedInternetGateway(ig) {
+			s.scope.Info("Retaining unmanaged internet gateway", "internet-gateway-id", *ig.InternetGatewayId)
+			continue
+		}
+
 		deleteReq := &ec2.DeleteInternetGatewayInput{
 			InternetGatewayId: ig.InternetGatewayId,
 		}
@@ -147,9 +190,80 @@ func (s *Service) createInternetGateway() (*ec2.InternetGateway, error) {
 	record.Eventf(s.scope.InfraCluster(), "SuccessfulAttachInternetGateway", "Internet Gateway %q attached to VPC %q", *ig.InternetGateway.InternetGatewayId, s.scope.VPC().ID)
 	s.scope.Debug("attached internet gateway to VPC", "internet-gateway-id", *ig.InternetGateway.InternetGatewayId, "vpc-id", s.scope.VPC().ID)
 
+	// The created internet gateway doesn't report the attachment yet. AWS describes
+	// the attachment of an attached internet gateway as available.
+	ig.InternetGateway.Attachments = []*ec2.InternetGatewayAttachment{
+		{
+			State: aws.String(internetGatewayAttachmentStateAvailable),
+			VpcId: aws.String(s.scope.VPC().ID),
+		},
+	}
+
 	return ig.InternetGateway, nil
 }
 
+// attachUnmanagedInternetGateway attaches an internet gateway created outside of the provider to the VPC.
+func (s *Service) attachUnmanagedInternetGateway(ig *ec2.InternetGateway) error {
+	for _, attachment := range ig.Attachments {
+		if aws.StringValue(attachment.VpcId) != s.scope.VPC().ID {
+			record.Warnf(s.scope.InfraCluster(), "FailedAttachInternetGateway", "Unmanaged Internet Gateway %q is attached to VPC %q", *ig.InternetGatewayId, aws.StringValue(attachment.VpcId))
+			return errors.Errorf("failed to validate network: internet gateway %q is attached to VPC %q", *ig.InternetGatewayId, aws.StringValue(attachment.VpcId))
+		}
+	}
+
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if _, err := s.EC2Client.AttachInternetGateway(&ec2.AttachInternetGatewayInput{
+			InternetGatewayId: ig.InternetGatewayId,
+			VpcId:             aws.String(s.scope.VPC().ID),
+		}); err != nil {
+			return false, err
+		}
+		return true, nil
+	}, awserrors.InternetGatewayNotFound); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAttachInternetGateway", "Failed to attach unmanaged Internet Gateway %q to vpc %q: %v", *ig.InternetGatewayId, s.scope.VPC().ID, err)
+		return errors.Wrapf(err, "failed to attach internet gateway %q to vpc %q", *ig.InternetGatewayId, s.scope.VPC().ID)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulAttachInternetGateway", "Unmanaged Internet Gateway %q attached to VPC %q", *ig.InternetGatewayId, s.scope.VPC().ID)
+
+	ig.Attachments = []*ec2.InternetGatewayAttachment{
+		{
+			State: aws.String(internetGatewayAttachmentStateAvailable),
+			VpcId: aws.String(s.scope.VPC().ID),
+		},
+	}
+	s.observed.internetGateway = ig
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition)
+	return nil
+}
+
+// isManagedInternetGateway returns false if the internet gateway is the one set in the spec and was
+// created outside of the provider. Internet gateways found attached to a managed VPC are adopted.
+func (s *Service) isManagedInternetGateway(ig *ec2.InternetGateway) bool {
+	id := s.scope.VPC().InternetGatewayID
+	if id == nil || *id != aws.StringValue(ig.InternetGatewayId) {
+		return true
+	}
+	return infrav1.Tags(converters.TagsToMap(ig.Tags)).HasOwned(s.scope.Name())
+}
+
+func (s *Service) describeInternetGateway(id string) (*ec2.InternetGateway, error) {
+	out, err := s.EC2Client.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{
+		InternetGatewayIds: aws.StringSlice([]string{id}),
+	})
+	if code, ok := awserrors.Code(err); ok && code == awserrors.InternetGatewayNotFound {
+		return nil, awserrors.NewNotFound(fmt.Sprintf("internet gateway %q not found", id))
+	} else if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeInternetGateway", "Failed to describe internet gateway %q: %v", id, err)
+		return nil, errors.Wrapf(err, "failed to describe internet gateway %q", id)
+	}
+
+	if len(out.InternetGateways) == 0 {
+		return nil, awserrors.NewNotFound(fmt.Sprintf("internet gateway %q not found", id))
+	}
+
+	return out.InternetGateways[0], nil
+}
+
 func (s *Service) describeVpcInternetGateways() ([]*ec2.InternetGateway, error) {
 	out, err := s.EC2Client.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{
 		Filters: []*ec2.Filter{
@@ -38,9 +38,10 @@ func TestReconcileInternetGateways(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	testCases := []struct {
-		name   string
-		input  *infrav1.NetworkSpec
-		expect func(m *mocks.MockEC2APIMockRecorder)
+		name    string
+		input   *infrav1.NetworkSpec
+		expect  func(m *mocks.MockEC2APIMockRecorder)
+		wantErr bool
 	}{
 		{
 			name: "has igw",
@@ -114,6 +115,104 @@ func TestReconcileInternetGateways(t *testing.T) {
 					Return(&ec2.AttachInternetGatewayOutput{}, nil)
 			},
 		},
+		{
+			name: "no igw attached, attaches the unmanaged igw",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-gateways",
+					InternetGatewayID: aws.String("igw-unmanaged"),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInternetGateways(gomock.Eq(&ec2.DescribeInternetGatewaysInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("attachment.vpc-id"),
+							Values: aws.StringSlice([]string{"vpc-gateways"}),
+						},
+					},
+				})).Return(&ec2.DescribeInternetGatewaysOutput{}, nil)
+
+				m.DescribeInternetGateways(gomock.Eq(&ec2.DescribeInternetGatewaysInput{
+					InternetGatewayIds: aws.StringSlice([]string{"igw-unmanaged"}),
+				})).Return(&ec2.DescribeInternetGatewaysOutput{
+					InternetGateways: []*ec2.InternetGateway{
+						{
+							InternetGatewayId: aws.String("igw-unmanaged"),
+						},
+					},
+				}, nil)
+
+				m.AttachInternetGateway(gomock.Eq(&ec2.AttachInternetGatewayInput{
+					InternetGatewayId: aws.String("igw-unmanaged"),
+					VpcId:             aws.String("vpc-gateways"),
+				})).
+					Return(&ec2.AttachInternetGatewayOutput{}, nil)
+			},
+		},
+		{
+			name: "unmanaged igw attached, doesn't tag it",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-gateways",
+					InternetGatewayID: aws.String("igw-unmanaged"),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInternetGateways(gomock.AssignableToTypeOf(&ec2.DescribeInternetGatewaysInput{})).
+					Return(&ec2.DescribeInternetGatewaysOutput{
+						InternetGateways: []*ec2.InternetGateway{
+							{
+								InternetGatewayId: aws.String("igw-unmanaged"),
+								Attachments: []*ec2.InternetGatewayAttachment{
+									{
+										State: aws.String(internetGatewayAttachmentStateAvailable),
+										VpcId: aws.String("vpc-gateways"),
+									},
+								},
+							},
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "unmanaged igw attached to another vpc, fails",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-gateways",
+					InternetGatewayID: aws.String("igw-unmanaged"),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInternetGateways(gomock.AssignableToTypeOf(&ec2.DescribeInternetGatewaysInput{})).
+					Return(&ec2.DescribeInternetGatewaysOutput{}, nil)
+
+				m.DescribeInternetGateways(gomock.AssignableToTypeOf(&ec2.DescribeInternetGatewaysInput{})).
+					Return(&ec2.DescribeInternetGatewaysOutput{
+						InternetGateways: []*ec2.InternetGateway{
+							{
+								InternetGatewayId: aws.String("igw-unmanaged"),
+								Attachments: []*ec2.InternetGatewayAttachment{
+									{
+										State: aws.String(internetGatewayAttachmentStateAvailable),
+										VpcId: aws.String("vpc-other"),
+									},
+								},
+							},
+						},
+					}, nil)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -144,7 +243,14 @@ func TestReconcileInternetGateways(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			if err := s.reconcileInternetGateways(); err != nil {
+			err = s.reconcileInternetGateways()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
 				t.Fatalf("got an unexpected error: %v", err)
 			}
 		})
@@ -225,6 +331,38 @@ func TestDeleteInternetGateways(t *testing.T) {
 				}).Return(&ec2.DeleteInternetGatewayOutput{}, nil)
 			},
 		},
+		{
+			name: "Should detach but not delete the unmanaged internet gateway",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-gateways",
+					InternetGatewayID: aws.String("igw-unmanaged"),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInternetGateways(gomock.AssignableToTypeOf(&ec2.DescribeInternetGatewaysInput{})).
+					Return(&ec2.DescribeInternetGatewaysOutput{
+						InternetGateways: []*ec2.InternetGateway{
+							{
+								InternetGatewayId: aws.String("igw-unmanaged"),
+								Attachments: []*ec2.InternetGatewayAttachment{
+									{
+										State: aws.String(ec2.AttachmentStatusAttached),
+										VpcId: aws.String("vpc-gateways"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.DetachInternetGateway(&ec2.DetachInternetGatewayInput{
+					InternetGatewayId: aws.String("igw-unmanaged"),
+					VpcId:             aws.String("vpc-gateways"),
+				}).Return(&ec2.DetachInternetGatewayOutput{}, nil)
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
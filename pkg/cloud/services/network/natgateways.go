@@ -18,6 +18,7 @@ package network
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -29,6 +30,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
@@ -53,6 +55,10 @@ func (s *Service) reconcileNatGateways() error {
 			clusterv1.ConditionSeverityWarning,
 			"No private subnets available, skipping NAT gateways")
 		return nil
+	} else if s.isPrivateIPv6EgressOnly() {
+		s.scope.Debug("No public subnets available, private subnets reach the internet over IPv6 through the egress only internet gateway")
+		conditions.MarkTrue(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition)
+		return nil
 	} else if len(s.scope.Subnets().FilterPublic()) == 0 {
 		s.scope.Debug("No public subnets available. Cannot create NAT gateways for private subnets, this might be a configuration error.")
 		conditions.MarkFalse(
@@ -64,14 +70,34 @@ func (s *Service) reconcileNatGateways() error {
 		return nil
 	}
 
+	if s.scope.VPC().GetNATStrategy() == infrav1.NATStrategyNATInstance {
+		return s.reconcileNatInstances()
+	}
+
 	existing, err := s.describeNatGatewaysBySubnet()
 	if err != nil {
 		return err
 	}
+	for _, ngw := range existing {
+		s.observed.natGateways = append(s.observed.natGateways, ngw)
+	}
 
 	subnetIDs := []string{}
 
-	for _, sn := range s.scope.Subnets().FilterPublic() {
+	// NAT gateways are not supported on Outposts, they are created in the public subnets of the region.
+	publicSubnets := s.scope.Subnets().FilterPublic().FilterByOutpost("")
+	placement := s.scope.VPC().NATGatewayPlacement
+	if len(publicSubnets.FilterPlacement(placement)) == 0 {
+		conditions.MarkFalse(
+			s.scope.InfraCluster(),
+			infrav1.NatGatewaysReadyCondition,
+			infrav1.NatGatewaysReconciliationFailedReason,
+			clusterv1.ConditionSeverityWarning,
+			"No public subnets match the NAT gateway placement")
+		return errors.New("failed to reconcile NAT gateways, no public subnets match the NAT gateway placement")
+	}
+
+	for _, sn := range publicSubnets {
 		if sn.ID == "" {
 			continue
 		}
@@ -93,6 +119,10 @@ func (s *Service) reconcileNatGateways() error {
 			continue
 		}
 
+		if !placement.Matches(&sn) {
+			continue
+		}
+
 		subnetIDs = append(subnetIDs, sn.ID)
 	}
 
@@ -111,6 +141,7 @@ func (s *Service) reconcileNatGateways() error {
 			subnet := s.scope.Subnets().FindByID(*ng.SubnetId)
 			subnet.NatGatewayID = ng.NatGatewayId
 		}
+		s.observed.natGateways = append(s.observed.natGateways, ngws...)
 
 		if err != nil {
 			return err
@@ -127,6 +158,10 @@ func (s *Service) deleteNatGateways() error {
 		return nil
 	}
 
+	if s.scope.VPC().GetNATStrategy() == infrav1.NATStrategyNATInstance {
+		return s.deleteNatInstances()
+	}
+
 	if len(s.scope.Subnets().FilterPrivate()) == 0 {
 		s.scope.Debug("No private subnets available, skipping NAT gateways")
 		return nil
@@ -210,7 +245,24 @@ func (s *Service) getNatGatewayTagParams(id string) infrav1.BuildParams {
 }
 
 func (s *Service) createNatGateways(subnetIDs []string) (natgateways []*ec2.NatGateway, err error) {
-	eips, err := s.getOrAllocateAddresses(len(subnetIDs), infrav1.APIServerRoleTagValue)
+	perZone := map[string]int{}
+	for _, id := range subnetIDs {
+		if sn := s.scope.Subnets().FindByID(id); sn != nil {
+			perZone[sn.AvailabilityZone]++
+		}
+	}
+	for zone, requested := range perZone {
+		if err := s.checkQuotaInZone(servicequotas.NATGatewaysPerAvailabilityZone, zone, requested); err != nil {
+			return nil, err
+		}
+	}
+
+	var eips []string
+	if pool := s.scope.VPC().NATGatewayElasticIPPool; pool != nil {
+		eips, err = s.getAddressesFromPool(pool, len(subnetIDs))
+	} else {
+		eips, err = s.getOrAllocateAddresses(len(subnetIDs), infrav1.APIServerRoleTagValue)
+	}
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create one or more IP addresses for NAT gateways")
 	}
@@ -314,19 +366,36 @@ func (s *Service) getNatGatewayForSubnet(sn *infrav1.SubnetSpec) (string, error)
 	if sn.IsPublic {
 		return "", errors.Errorf("cannot get NAT gateway for a public subnet, got id %q", sn.ID)
 	}
+	return s.getNatForSubnet(sn, "nat gateways", func(psn *infrav1.SubnetSpec) *string { return psn.NatGatewayID })
+}
 
+// getNatForSubnet returns the NAT gateway or instance, read from the public subnets with natID, that the private
+// subnet sn routes its traffic through.
+func (s *Service) getNatForSubnet(sn *infrav1.SubnetSpec, kind string, natID func(*infrav1.SubnetSpec) *string) (string, error) {
 	azGateways := make(map[string][]string)
 	for _, psn := range s.scope.Subnets().FilterPublic() {
-		if psn.NatGatewayID == nil {
+		psn := psn
+		id := natID(&psn)
+		if id == nil {
 			continue
 		}
 
-		azGateways[psn.AvailabilityZone] = append(azGateways[psn.AvailabilityZone], *psn.NatGatewayID)
+		azGateways[psn.AvailabilityZone] = append(azGateways[psn.AvailabilityZone], *id)
 	}
 
 	if gws, ok := azGateways[sn.AvailabilityZone]; ok && len(gws) > 0 {
 		return gws[0], nil
 	}
 
-	return "", errors.Errorf("no nat gateways available in %q for private subnet %q, current state: %+v", sn.AvailabilityZone, sn.ID, azGateways)
+	// With a NAT gateway placement, availability zones without NAT gateways use the one of the first zone that has one.
+	if s.scope.VPC().NATGatewayPlacement != nil && len(azGateways) > 0 {
+		zones := make([]string, 0, len(azGateways))
+		for zone := range azGateways {
+			zones = append(zones, zone)
+		}
+		sort.Strings(zones)
+		return azGateways[zones[0]][0], nil
+	}
+
+	return "", errors.Errorf("no %s available in %q for private subnet %q, current state: %+v", kind, sn.AvailabilityZone, sn.ID, azGateways)
 }
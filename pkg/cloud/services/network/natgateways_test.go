@@ -44,9 +44,11 @@ func TestReconcileNatGateways(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	testCases := []struct {
-		name   string
-		input  []infrav1.SubnetSpec
-		expect func(m *mocks.MockEC2APIMockRecorder)
+		name          string
+		input         []infrav1.SubnetSpec
+		elasticIPPool *infrav1.ElasticIPPool
+		placement     *infrav1.SubnetPlacement
+		expect        func(m *mocks.MockEC2APIMockRecorder)
 	}{
 		{
 			name: "single private subnet exists, should create no NAT gateway",
@@ -172,6 +174,169 @@ func TestReconcileNatGateways(t *testing.T) {
 				}).Return(nil)
 			},
 		},
+		{
+			name: "public subnets in two zones with a NAT gateway placement, should create 1 NAT gateway in the placed zone",
+			input: []infrav1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+				{
+					ID:               "subnet-3",
+					AvailabilityZone: "us-east-1-bos-1a",
+					CidrBlock:        "10.0.14.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-4",
+					AvailabilityZone: "us-east-1-bos-1a",
+					CidrBlock:        "10.0.16.0/24",
+					IsPublic:         false,
+				},
+			},
+			placement: &infrav1.SubnetPlacement{
+				AvailabilityZones: []string{"us-east-1a"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPages(
+					gomock.Eq(&ec2.DescribeNatGatewaysInput{
+						Filter: []*ec2.Filter{
+							{
+								Name:   aws.String("vpc-id"),
+								Values: []*string{aws.String(subnetsVPCID)},
+							},
+							{
+								Name:   aws.String("state"),
+								Values: []*string{aws.String("pending"), aws.String("available")},
+							},
+						},
+					}),
+					gomock.Any()).Return(nil)
+
+				m.DescribeAddresses(gomock.Any()).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+
+				m.AllocateAddress(&ec2.AllocateAddressInput{
+					Domain: aws.String("vpc"),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String("elastic-ip"),
+							Tags: []*ec2.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("test-cluster-eip-apiserver"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+									Value: aws.String("apiserver"),
+								},
+							},
+						},
+					},
+				}).Return(&ec2.AllocateAddressOutput{
+					AllocationId: aws.String(ElasticIPAllocationID),
+				}, nil)
+
+				m.CreateNatGateway(&ec2.CreateNatGatewayInput{
+					AllocationId: aws.String(ElasticIPAllocationID),
+					SubnetId:     aws.String("subnet-1"),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String("natgateway"),
+							Tags: []*ec2.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("test-cluster-nat"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+									Value: aws.String("common"),
+								},
+							},
+						},
+					},
+				},
+				).Return(&ec2.CreateNatGatewayOutput{
+					NatGateway: &ec2.NatGateway{
+						NatGatewayId: aws.String("natgateway"),
+						SubnetId:     aws.String("subnet-1"),
+					},
+				}, nil)
+
+				m.WaitUntilNatGatewayAvailable(&ec2.DescribeNatGatewaysInput{
+					NatGatewayIds: []*string{aws.String("natgateway")},
+				}).Return(nil)
+			},
+		},
+		{
+			name: "public & private subnet exists with an Elastic IP pool, should create 1 NAT gateway using the pool",
+			input: []infrav1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+			},
+			elasticIPPool: &infrav1.ElasticIPPool{AllocationIDs: []string{"eipalloc-b", "eipalloc-a"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPages(gomock.Any(), gomock.Any()).Return(nil)
+
+				m.DescribeAddresses(&ec2.DescribeAddressesInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("allocation-id"),
+							Values: aws.StringSlice([]string{"eipalloc-b", "eipalloc-a"}),
+						},
+					},
+				}).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{
+						{AllocationId: aws.String("eipalloc-a")},
+						{AllocationId: aws.String("eipalloc-b")},
+					},
+				}, nil)
+				m.AllocateAddress(gomock.Any()).Times(0)
+
+				m.CreateNatGateway(gomock.AssignableToTypeOf(&ec2.CreateNatGatewayInput{})).
+					DoAndReturn(func(input *ec2.CreateNatGatewayInput) (*ec2.CreateNatGatewayOutput, error) {
+						if aws.StringValue(input.AllocationId) != "eipalloc-b" {
+							t.Errorf("expected NAT gateway to use eipalloc-b, got %q", aws.StringValue(input.AllocationId))
+						}
+						return &ec2.CreateNatGatewayOutput{
+							NatGateway: &ec2.NatGateway{
+								NatGatewayId: aws.String("natgateway"),
+								SubnetId:     aws.String("subnet-1"),
+							},
+						}, nil
+					})
+
+				m.WaitUntilNatGatewayAvailable(&ec2.DescribeNatGatewaysInput{
+					NatGatewayIds: []*string{aws.String("natgateway")},
+				}).Return(nil)
+			},
+		},
 		{
 			name: "two public & 1 private subnet, and one NAT gateway exists",
 			input: []infrav1.SubnetSpec{
@@ -376,6 +541,8 @@ func TestReconcileNatGateways(t *testing.T) {
 							Tags: infrav1.Tags{
 								infrav1.ClusterTagKey("test-cluster"): "owned",
 							},
+							NATGatewayElasticIPPool: tc.elasticIPPool,
+							NATGatewayPlacement:     tc.placement,
 						},
 						Subnets: tc.input,
 					},
@@ -721,6 +888,89 @@ func TestDeleteNatGateways(t *testing.T) {
 	}
 }
 
+func TestGetNatGatewayForSubnet(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{
+			ID:               "subnet-public-1b",
+			AvailabilityZone: "us-east-1b",
+			IsPublic:         true,
+			NatGatewayID:     aws.String("nat-1b"),
+		},
+		{
+			ID:               "subnet-public-1a",
+			AvailabilityZone: "us-east-1a",
+			IsPublic:         true,
+			NatGatewayID:     aws.String("nat-1a"),
+		},
+		{
+			ID:               "subnet-public-bos",
+			AvailabilityZone: "us-east-1-bos-1a",
+			IsPublic:         true,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		subnet    infrav1.SubnetSpec
+		placement *infrav1.SubnetPlacement
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:   "Should use the NAT gateway of the availability zone of the subnet",
+			subnet: infrav1.SubnetSpec{ID: "subnet-private-1b", AvailabilityZone: "us-east-1b"},
+			want:   "nat-1b",
+		},
+		{
+			name:    "Should fail without a NAT gateway in the availability zone of the subnet",
+			subnet:  infrav1.SubnetSpec{ID: "subnet-private-bos", AvailabilityZone: "us-east-1-bos-1a"},
+			wantErr: true,
+		},
+		{
+			name:      "Should use the NAT gateway of the first availability zone with a NAT gateway placement",
+			subnet:    infrav1.SubnetSpec{ID: "subnet-private-bos", AvailabilityZone: "us-east-1-bos-1a"},
+			placement: &infrav1.SubnetPlacement{AvailabilityZones: []string{"us-east-1a", "us-east-1b"}},
+			want:      "nat-1a",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC: infrav1.VPCSpec{
+								ID:                  "managed-vpc",
+								NATGatewayPlacement: tc.placement,
+							},
+							Subnets: append(subnets.DeepCopy(), tc.subnet),
+						},
+					},
+				},
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(clusterScope)
+			got, err := s.getNatGatewayForSubnet(&tc.subnet)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
+
 var mockDescribeNatGatewaysOutput = func(_, y interface{}) {
 	funct := y.(func(page *ec2.DescribeNatGatewaysOutput, lastPage bool) bool)
 	funct(&ec2.DescribeNatGatewaysOutput{NatGateways: []*ec2.NatGateway{{
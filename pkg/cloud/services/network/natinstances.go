@@ -0,0 +1,610 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	ec2service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// defaultNatInstanceType is the instance type of the NAT instances when none is set.
+	defaultNatInstanceType = "t3.micro"
+
+	// amazonOwnerAlias is the owner of the Amazon Linux images. The alias resolves to the Amazon account of
+	// the partition of the region, unlike the account ID of the images of the aws partition.
+	amazonOwnerAlias = "amazon"
+
+	// amazonLinux2ImageName matches the names of the Amazon Linux 2 images, which come with iptables.
+	amazonLinux2ImageName = "amzn2-ami-kernel-5.10-hvm-*-gp2"
+)
+
+// natInstanceUserData makes an instance forward the traffic it receives and masquerade it behind its own address,
+// from a systemd unit so that it is configured again when the instance reboots.
+const natInstanceUserData = `#!/bin/bash
+cat > /usr/local/sbin/capa-nat <<'EOF'
+#!/bin/bash
+set -e
+sysctl -q -w net.ipv4.ip_forward=1
+iface=$(ip -o route show default | awk '{print $5; exit}')
+iptables -t nat -C POSTROUTING -o "$iface" -j MASQUERADE 2>/dev/null || iptables -t nat -A POSTROUTING -o "$iface" -j MASQUERADE
+EOF
+chmod 0755 /usr/local/sbin/capa-nat
+cat > /etc/systemd/system/capa-nat.service <<'EOF'
+[Unit]
+Description=Forward and masquerade the traffic of the VPC
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+Type=oneshot
+ExecStart=/usr/local/sbin/capa-nat
+RemainAfterExit=yes
+
+[Install]
+WantedBy=multi-user.target
+EOF
+systemctl daemon-reload
+systemctl enable --now capa-nat.service
+`
+
+// reconcileNatInstances ensures there is a NAT instance in every public subnet matching the NAT gateway placement,
+// for the private subnets to route their traffic through when the NAT strategy of the VPC is nat-instance.
+// NAT instances are created without waiting for them to run: while some are pending, the NAT gateways ready
+// condition is false with the NatInstancesPending reason and the reconciliation of the cluster is requeued.
+func (s *Service) reconcileNatInstances() error {
+	existing, duplicates, err := s.describeNatInstancesBySubnet()
+	if err != nil {
+		return err
+	}
+	if err := s.terminateDuplicateNatInstances(duplicates); err != nil {
+		return err
+	}
+
+	// NAT instances are placed like NAT gateways, in the public subnets of the region.
+	publicSubnets := s.scope.Subnets().FilterPublic().FilterByOutpost("")
+	placement := s.scope.VPC().NATGatewayPlacement
+	if len(publicSubnets.FilterPlacement(placement)) == 0 {
+		conditions.MarkFalse(
+			s.scope.InfraCluster(),
+			infrav1.NatGatewaysReadyCondition,
+			infrav1.NatGatewaysReconciliationFailedReason,
+			clusterv1.ConditionSeverityWarning,
+			"No public subnets match the NAT gateway placement")
+		return errors.New("failed to reconcile NAT instances, no public subnets match the NAT gateway placement")
+	}
+
+	// The ingress of the security group is reconciled even when no NAT instance is created, for the instances
+	// to forward the traffic of the CIDR blocks added to the VPC after they were created.
+	securityGroupID, err := s.reconcileNatInstanceSecurityGroup()
+	if err != nil {
+		return err
+	}
+
+	subnetIDs := []string{}
+	running := map[string]*ec2.Instance{}
+	var pending, stopped []string
+	for _, sn := range publicSubnets {
+		if sn.ID == "" {
+			continue
+		}
+		instance, ok := existing[sn.ID]
+		if !ok {
+			if placement.Matches(&sn) {
+				subnetIDs = append(subnetIDs, sn.ID)
+			}
+			continue
+		}
+
+		switch instanceID := aws.StringValue(instance.InstanceId); natInstanceState(instance) {
+		case ec2.InstanceStateNameRunning:
+			running[sn.ID] = instance
+		case ec2.InstanceStateNamePending:
+			pending = append(pending, instanceID)
+		default:
+			// Stopped NAT instances don't forward any traffic, the private subnets mustn't route through them.
+			stopped = append(stopped, instanceID)
+			s.setNatInstanceID(sn.ID, nil)
+		}
+	}
+
+	if len(subnetIDs) > 0 {
+		// set NatGatewayCreationStarted if the condition has never been set before
+		if !conditions.Has(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition) {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, infrav1.NatGatewaysCreationStartedReason, clusterv1.ConditionSeverityInfo, "")
+			if err := s.scope.PatchObject(); err != nil {
+				return errors.Wrap(err, "failed to patch conditions")
+			}
+		}
+
+		instanceType, imageID, err := s.getNatInstanceTypeAndImage()
+		if err != nil {
+			return err
+		}
+		for _, subnetID := range subnetIDs {
+			instanceID, err := s.createNatInstance(subnetID, securityGroupID, instanceType, imageID)
+			if err != nil {
+				return err
+			}
+			pending = append(pending, instanceID)
+		}
+	}
+
+	// The configuration of the running NAT instances is checked at every reconciliation, so that an instance
+	// whose configuration was interrupted is repaired.
+	if err := s.configureNatInstances(running); err != nil {
+		return err
+	}
+	for subnetID, instance := range running {
+		s.setNatInstanceID(subnetID, instance.InstanceId)
+	}
+
+	switch {
+	case len(pending) > 0:
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, infrav1.NatInstancesPendingReason, clusterv1.ConditionSeverityInfo,
+			"Waiting for NAT instances %v to be running", pending)
+	case len(stopped) > 0:
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, infrav1.NatInstancesStoppedReason, clusterv1.ConditionSeverityWarning,
+			"NAT instances %v are stopped and don't forward traffic", stopped)
+	default:
+		conditions.MarkTrue(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition)
+	}
+	return nil
+}
+
+// NatInstancesPending reports whether NAT instances of the cluster are still starting, in which case the routes
+// of the private subnets aren't reconciled yet and the reconciliation of the cluster should be requeued.
+func NatInstancesPending(obj conditions.Getter) bool {
+	return conditions.GetReason(obj, infrav1.NatGatewaysReadyCondition) == infrav1.NatInstancesPendingReason
+}
+
+func natInstanceState(instance *ec2.Instance) string {
+	if instance.State == nil {
+		return ""
+	}
+	return aws.StringValue(instance.State.Name)
+}
+
+// setNatInstanceID sets the NAT instance of a public subnet, for the routes of the private subnets.
+func (s *Service) setNatInstanceID(subnetID string, instanceID *string) {
+	subnets := s.scope.Subnets()
+	for i := range subnets {
+		if subnets[i].ID == subnetID {
+			subnets[i].NatInstanceID = instanceID
+		}
+	}
+}
+
+// configureNatInstances disables the source/destination check of the running NAT instances, and associates an
+// Elastic IP with the ones that don't have one yet.
+func (s *Service) configureNatInstances(instances map[string]*ec2.Instance) error {
+	if len(instances) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		instanceID := aws.StringValue(instance.InstanceId)
+		ids = append(ids, instanceID)
+		if instance.SourceDestCheck != nil && !aws.BoolValue(instance.SourceDestCheck) {
+			continue
+		}
+
+		// A NAT instance forwards traffic it is neither the source nor the destination of.
+		if _, err := s.EC2Client.ModifyInstanceAttribute(&ec2.ModifyInstanceAttributeInput{
+			InstanceId:      aws.String(instanceID),
+			SourceDestCheck: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+		}); err != nil {
+			return errors.Wrapf(err, "failed to disable the source/destination check of NAT instance %q", instanceID)
+		}
+	}
+	sort.Strings(ids)
+
+	out, err := s.EC2Client.DescribeAddresses(&ec2.DescribeAddressesInput{Filters: []*ec2.Filter{filter.EC2.InstanceIDs(ids...)}})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeAddresses", "Failed to query addresses of NAT instances: %v", err)
+		return errors.Wrap(err, "failed to query addresses of NAT instances")
+	}
+	associated := map[string]bool{}
+	for _, address := range out.Addresses {
+		associated[aws.StringValue(address.InstanceId)] = true
+	}
+	unassociated := []string{}
+	for _, instanceID := range ids {
+		if !associated[instanceID] {
+			unassociated = append(unassociated, instanceID)
+		}
+	}
+	if len(unassociated) == 0 {
+		return nil
+	}
+
+	var eips []string
+	if pool := s.scope.VPC().NATGatewayElasticIPPool; pool != nil {
+		eips, err = s.getAddressesFromPool(pool, len(unassociated))
+	} else {
+		eips, err = s.getOrAllocateAddresses(len(unassociated), infrav1.NATInstanceRoleTagValue)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to create one or more IP addresses for NAT instances")
+	}
+
+	for i, instanceID := range unassociated {
+		if _, err := s.EC2Client.AssociateAddress(&ec2.AssociateAddressInput{
+			AllocationId: aws.String(eips[i]),
+			InstanceId:   aws.String(instanceID),
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedAssociateEIP", "Failed to associate Elastic IP %q with NAT instance %q: %v", eips[i], instanceID, err)
+			return errors.Wrapf(err, "failed to associate Elastic IP %q with NAT instance %q", eips[i], instanceID)
+		}
+		s.scope.Info("Associated Elastic IP with NAT instance", "instance-id", instanceID, "allocation-id", eips[i])
+	}
+	return nil
+}
+
+// describeNatInstancesBySubnet returns the NAT instances of the cluster that aren't terminated, by subnet. A
+// subnet has more than one NAT instance when the instance created by a reconciliation wasn't found by the next
+// one: the instance kept for the subnet is returned by subnet, and the others are returned as duplicates.
+func (s *Service) describeNatInstancesBySubnet() (map[string]*ec2.Instance, []*ec2.Instance, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.ProviderRole(infrav1.NATInstanceRoleTagValue),
+			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning, ec2.InstanceStateNameStopping, ec2.InstanceStateNameStopped),
+		},
+	}
+
+	instances := make(map[string]*ec2.Instance)
+	var duplicates []*ec2.Instance
+	err := s.EC2Client.DescribeInstancesPages(input, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				subnetID := aws.StringValue(instance.SubnetId)
+				kept, ok := instances[subnetID]
+				switch {
+				case !ok:
+					instances[subnetID] = instance
+				case preferNatInstance(instance, kept):
+					instances[subnetID] = instance
+					duplicates = append(duplicates, kept)
+				default:
+					duplicates = append(duplicates, instance)
+				}
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeNATInstances", "Failed to describe NAT instances with VPC ID %q: %v", s.scope.VPC().ID, err)
+		return nil, nil, errors.Wrapf(err, "failed to describe NAT instances with VPC ID %q", s.scope.VPC().ID)
+	}
+
+	return instances, duplicates, nil
+}
+
+// preferNatInstance reports whether instance a should be kept for its subnet rather than instance b: running
+// instances are preferred to pending ones, themselves preferred to stopped ones, then the oldest instance is kept.
+func preferNatInstance(a, b *ec2.Instance) bool {
+	rank := func(instance *ec2.Instance) int {
+		switch natInstanceState(instance) {
+		case ec2.InstanceStateNameRunning:
+			return 0
+		case ec2.InstanceStateNamePending:
+			return 1
+		default:
+			return 2
+		}
+	}
+	if rank(a) != rank(b) {
+		return rank(a) < rank(b)
+	}
+	if launchA, launchB := aws.TimeValue(a.LaunchTime), aws.TimeValue(b.LaunchTime); !launchA.Equal(launchB) {
+		return launchA.Before(launchB)
+	}
+	return aws.StringValue(a.InstanceId) < aws.StringValue(b.InstanceId)
+}
+
+// terminateDuplicateNatInstances terminates the NAT instances of the subnets that already have one, without
+// waiting for them to terminate.
+func (s *Service) terminateDuplicateNatInstances(duplicates []*ec2.Instance) error {
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(duplicates))
+	for _, instance := range duplicates {
+		ids = append(ids, aws.StringValue(instance.InstanceId))
+	}
+	sort.Strings(ids)
+	if _, err := s.EC2Client.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: aws.StringSlice(ids)}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedTerminateNATInstances", "Failed to terminate duplicate NAT instances %v: %v", ids, err)
+		return errors.Wrapf(err, "failed to terminate duplicate NAT instances %v", ids)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulTerminateNATInstances", "Terminated duplicate NAT instances %v", ids)
+	s.scope.Info("Terminated duplicate NAT instances", "instance-ids", ids)
+	return nil
+}
+
+// getNatInstanceTypeAndImage returns the instance type and the AMI of the NAT instances, looking up the latest
+// Amazon Linux 2 image for the architecture of the instance type when no AMI is set.
+func (s *Service) getNatInstanceTypeAndImage() (string, string, error) {
+	instanceType, imageID := defaultNatInstanceType, ""
+	if spec := s.scope.VPC().NATInstance; spec != nil {
+		if spec.InstanceType != "" {
+			instanceType = spec.InstanceType
+		}
+		imageID = spec.AMI
+	}
+	if imageID != "" {
+		return instanceType, imageID, nil
+	}
+
+	types, err := s.EC2Client.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{InstanceTypes: aws.StringSlice([]string{instanceType})})
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to describe instance type %q", instanceType)
+	}
+	if len(types.InstanceTypes) == 0 || types.InstanceTypes[0].ProcessorInfo == nil {
+		return "", "", errors.Errorf("instance type %q not found", instanceType)
+	}
+	architecture := ec2service.Amd64ArchitectureTag
+	for _, a := range types.InstanceTypes[0].ProcessorInfo.SupportedArchitectures {
+		if aws.StringValue(a) == ec2service.Arm64ArchitectureTag {
+			architecture = ec2service.Arm64ArchitectureTag
+		}
+	}
+
+	out, err := s.EC2Client.DescribeImages(&ec2.DescribeImagesInput{
+		Owners: aws.StringSlice([]string{amazonOwnerAlias}),
+		Filters: []*ec2.Filter{
+			{Name: aws.String("name"), Values: aws.StringSlice([]string{amazonLinux2ImageName})},
+			{Name: aws.String("architecture"), Values: aws.StringSlice([]string{architecture})},
+			{Name: aws.String("state"), Values: aws.StringSlice([]string{"available"})},
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeImages", "Failed to find Amazon Linux 2 AMI for NAT instances with architecture %s: %v", architecture, err)
+		return "", "", errors.Wrapf(err, "failed to describe Amazon Linux 2 images for architecture %q", architecture)
+	}
+	if len(out.Images) == 0 {
+		return "", "", errors.Errorf("found no Amazon Linux 2 images for architecture %q in region %q", architecture, s.scope.Region())
+	}
+	image, err := ec2service.GetLatestImage(out.Images)
+	if err != nil {
+		return "", "", err
+	}
+	return instanceType, aws.StringValue(image.ImageId), nil
+}
+
+// createNatInstance launches the NAT instance of a public subnet, without waiting for it to run. It is configured
+// by configureNatInstances once it is running.
+func (s *Service) createNatInstance(subnetID, securityGroupID, instanceType, imageID string) (string, error) {
+	out, err := s.EC2Client.RunInstances(&ec2.RunInstancesInput{
+		ImageId:          aws.String(imageID),
+		InstanceType:     aws.String(instanceType),
+		SubnetId:         aws.String(subnetID),
+		SecurityGroupIds: aws.StringSlice([]string{securityGroupID}),
+		MinCount:         aws.Int64(1),
+		MaxCount:         aws.Int64(1),
+		UserData:         aws.String(base64.StdEncoding.EncodeToString([]byte(natInstanceUserData))),
+		MetadataOptions: &ec2.InstanceMetadataOptionsRequest{
+			HttpEndpoint: aws.String(ec2.InstanceMetadataEndpointStateEnabled),
+			HttpTokens:   aws.String(ec2.HttpTokensStateRequired),
+		},
+		TagSpecifications: []*ec2.TagSpecification{tags.BuildParamsToTagSpecification(ec2.ResourceTypeInstance, s.getNatInstanceTagParams(services.TemporaryResourceID))},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateNATInstance", "Failed to create new NAT instance: %v", err)
+		return "", errors.Wrapf(err, "failed to create NAT instance for subnet ID %q", subnetID)
+	}
+	if len(out.Instances) == 0 {
+		return "", errors.Errorf("no instance returned creating NAT instance for subnet ID %q", subnetID)
+	}
+	instanceID := aws.StringValue(out.Instances[0].InstanceId)
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateNATInstance", "Created new NAT instance %q", instanceID)
+	s.scope.Info("Created NAT instance for subnet", "instance-id", instanceID, "subnet-id", subnetID)
+	return instanceID, nil
+}
+
+// reconcileNatInstanceSecurityGroup returns the ID of the security group of the NAT instances, creating it
+// if needed. It allows all the traffic from the CIDR blocks of the VPC, and all the traffic to the internet:
+// the missing CIDR blocks are authorized and the ones no longer in the VPC are revoked.
+func (s *Service) reconcileNatInstanceSecurityGroup() (string, error) {
+	sg, err := s.describeNatInstanceSecurityGroup()
+	if err != nil {
+		return "", err
+	}
+	if sg == nil {
+		sg, err = s.createNatInstanceSecurityGroup()
+		if err != nil {
+			return "", err
+		}
+	}
+	groupID := aws.StringValue(sg.GroupId)
+
+	ranges := []*ec2.IpRange{{CidrIp: aws.String(s.scope.VPC().CidrBlock), Description: aws.String("VPC")}}
+	if cidr := s.scope.SecondaryCidrBlock(); cidr != nil {
+		ranges = append(ranges, &ec2.IpRange{CidrIp: cidr, Description: aws.String("VPC secondary CIDR block")})
+	}
+	authorized := map[string]bool{}
+	for _, permission := range sg.IpPermissions {
+		if aws.StringValue(permission.IpProtocol) != "-1" {
+			continue
+		}
+		for _, r := range permission.IpRanges {
+			authorized[aws.StringValue(r.CidrIp)] = true
+		}
+	}
+
+	var missing []*ec2.IpRange
+	for _, r := range ranges {
+		if !authorized[aws.StringValue(r.CidrIp)] {
+			missing = append(missing, r)
+		}
+		delete(authorized, aws.StringValue(r.CidrIp))
+	}
+	if len(missing) > 0 {
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if _, err := s.EC2Client.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+				GroupId:       aws.String(groupID),
+				IpPermissions: []*ec2.IpPermission{{IpProtocol: aws.String("-1"), IpRanges: missing}},
+			}); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.GroupNotFound); err != nil {
+			return "", errors.Wrapf(err, "failed to authorize the ingress of NAT instance security group %q", groupID)
+		}
+	}
+
+	if len(authorized) > 0 {
+		stale := make([]string, 0, len(authorized))
+		for cidr := range authorized {
+			stale = append(stale, cidr)
+		}
+		sort.Strings(stale)
+		revoked := make([]*ec2.IpRange, 0, len(stale))
+		for _, cidr := range stale {
+			revoked = append(revoked, &ec2.IpRange{CidrIp: aws.String(cidr)})
+		}
+		if _, err := s.EC2Client.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       aws.String(groupID),
+			IpPermissions: []*ec2.IpPermission{{IpProtocol: aws.String("-1"), IpRanges: revoked}},
+		}); err != nil {
+			return "", errors.Wrapf(err, "failed to revoke the ingress of NAT instance security group %q", groupID)
+		}
+	}
+
+	return groupID, nil
+}
+
+func (s *Service) createNatInstanceSecurityGroup() (*ec2.SecurityGroup, error) {
+	name := fmt.Sprintf("%s-%s", s.scope.Name(), infrav1.NATInstanceRoleTagValue)
+	out, err := s.EC2Client.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
+		VpcId:             aws.String(s.scope.VPC().ID),
+		GroupName:         aws.String(name),
+		Description:       aws.String(fmt.Sprintf("Kubernetes cluster %s: NAT instances", s.scope.Name())),
+		TagSpecifications: []*ec2.TagSpecification{tags.BuildParamsToTagSpecification(ec2.ResourceTypeSecurityGroup, s.getNatInstanceTagParams(services.TemporaryResourceID))},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateSecurityGroup", "Failed to create NAT instance SecurityGroup %q: %v", name, err)
+		return nil, errors.Wrapf(err, "failed to create NAT instance security group %q", name)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateSecurityGroup", "Created NAT instance SecurityGroup %q", aws.StringValue(out.GroupId))
+	return &ec2.SecurityGroup{GroupId: out.GroupId}, nil
+}
+
+func (s *Service) describeNatInstanceSecurityGroup() (*ec2.SecurityGroup, error) {
+	out, err := s.EC2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.ProviderRole(infrav1.NATInstanceRoleTagValue),
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe NAT instance security group in vpc %q", s.scope.VPC().ID)
+	}
+	if len(out.SecurityGroups) == 0 {
+		return nil, nil
+	}
+	return out.SecurityGroups[0], nil
+}
+
+// deleteNatInstances terminates the NAT instances of the cluster, then deletes their security group.
+// Their Elastic IPs are released with the other addresses of the cluster.
+func (s *Service) deleteNatInstances() error {
+	existing, duplicates, err := s.describeNatInstancesBySubnet()
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(existing)+len(duplicates))
+	for _, instance := range existing {
+		ids = append(ids, aws.StringValue(instance.InstanceId))
+	}
+	for _, instance := range duplicates {
+		ids = append(ids, aws.StringValue(instance.InstanceId))
+	}
+	sort.Strings(ids)
+	if len(ids) > 0 {
+		if _, err := s.EC2Client.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: aws.StringSlice(ids)}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedTerminateNATInstances", "Failed to terminate NAT instances %v: %v", ids, err)
+			return errors.Wrapf(err, "failed to terminate NAT instances %v", ids)
+		}
+		if err := s.EC2Client.WaitUntilInstanceTerminated(&ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(ids)}); err != nil {
+			return errors.Wrapf(err, "failed to wait for the termination of NAT instances %v", ids)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulTerminateNATInstances", "Terminated NAT instances %v", ids)
+		s.scope.Info("Terminated NAT instances", "instance-ids", ids)
+	}
+
+	sg, err := s.describeNatInstanceSecurityGroup()
+	if err != nil || sg == nil {
+		return err
+	}
+	if _, err := s.EC2Client.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: sg.GroupId}); awserrors.IsIgnorableSecurityGroupError(err) != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteSecurityGroup", "Failed to delete NAT instance SecurityGroup %q: %v", aws.StringValue(sg.GroupId), err)
+		return errors.Wrapf(err, "failed to delete NAT instance security group %q", aws.StringValue(sg.GroupId))
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteSecurityGroup", "Deleted NAT instance SecurityGroup %q", aws.StringValue(sg.GroupId))
+	return nil
+}
+
+// getNatInstanceForSubnet returns the ID of the NAT instance private subnet sn routes its traffic through.
+func (s *Service) getNatInstanceForSubnet(sn *infrav1.SubnetSpec) (string, error) {
+	if sn.IsPublic {
+		return "", errors.Errorf("cannot get NAT instance for a public subnet, got id %q", sn.ID)
+	}
+	return s.getNatForSubnet(sn, "nat instances", func(psn *infrav1.SubnetSpec) *string { return psn.NatInstanceID })
+}
+
+func (s *Service) getNatInstancePrivateRoute(instanceID string) *ec2.Route {
+	return &ec2.Route{
+		DestinationCidrBlock: aws.String(services.AnyIPv4CidrBlock),
+		InstanceId:           aws.String(instanceID),
+	}
+}
+
+func (s *Service) getNatInstanceTagParams(id string) infrav1.BuildParams {
+	name := fmt.Sprintf("%s-%s", s.scope.Name(), infrav1.NATInstanceRoleTagValue)
+
+	return infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		ResourceID:  id,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(infrav1.NATInstanceRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	}
+}
@@ -0,0 +1,430 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func natInstanceSubnets() []infrav1.SubnetSpec {
+	return []infrav1.SubnetSpec{
+		{
+			ID:               "subnet-1",
+			AvailabilityZone: "us-east-1a",
+			CidrBlock:        "10.0.10.0/24",
+			IsPublic:         true,
+		},
+		{
+			ID:               "subnet-2",
+			AvailabilityZone: "us-east-1a",
+			CidrBlock:        "10.0.12.0/24",
+			IsPublic:         false,
+		},
+	}
+}
+
+func describeNatInstances(m *mocks.MockEC2APIMockRecorder, instances ...*ec2.Instance) {
+	m.DescribeInstancesPages(gomock.Eq(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{subnetsVPCID})},
+			{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Values: aws.StringSlice([]string{"owned"})},
+			{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/role"), Values: aws.StringSlice([]string{"nat-instance"})},
+			{Name: aws.String("instance-state-name"), Values: aws.StringSlice([]string{"pending", "running", "stopping", "stopped"})},
+		},
+	}), gomock.Any()).Do(func(_ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) {
+		fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: instances}}}, true)
+	}).Return(nil)
+}
+
+func describeNatInstanceSecurityGroup(m *mocks.MockEC2APIMockRecorder, cidrs ...string) {
+	ranges := []*ec2.IpRange{}
+	for _, cidr := range cidrs {
+		ranges = append(ranges, &ec2.IpRange{CidrIp: aws.String(cidr)})
+	}
+	m.DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{
+		SecurityGroups: []*ec2.SecurityGroup{{
+			GroupId:       aws.String("sg-nat"),
+			IpPermissions: []*ec2.IpPermission{{IpProtocol: aws.String("-1"), IpRanges: ranges}},
+		}},
+	}, nil)
+}
+
+func newNatInstanceService(t *testing.T, ec2Mock *mocks.MockEC2API, natInstance *infrav1.NATInstanceSpec) (*Service, *scope.ClusterScope) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: infrav1.AWSClusterSpec{
+			NetworkSpec: infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:        subnetsVPCID,
+					CidrBlock: "10.0.0.0/16",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+					NATStrategy: infrav1.NATStrategyNATInstance,
+					NATInstance: natInstance,
+				},
+				Subnets: natInstanceSubnets(),
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	_ = client.Create(context.TODO(), awsCluster)
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: awsCluster,
+		Client:     client,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+	return s, clusterScope
+}
+
+func TestReconcileNatInstances(t *testing.T) {
+	testCases := []struct {
+		name        string
+		natInstance *infrav1.NATInstanceSpec
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		wantID      string
+		wantReason  string
+	}{
+		{
+			name: "creates a NAT instance with the latest Amazon Linux 2 image for the default instance type without waiting for it",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNatInstances(m)
+				m.DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+				m.CreateSecurityGroup(gomock.Any()).DoAndReturn(func(input *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
+					g := NewWithT(t)
+					g.Expect(aws.StringValue(input.GroupName)).To(Equal("test-cluster-nat-instance"))
+					g.Expect(aws.StringValue(input.VpcId)).To(Equal(subnetsVPCID))
+					return &ec2.CreateSecurityGroupOutput{GroupId: aws.String("sg-nat")}, nil
+				})
+				m.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+					GroupId: aws.String("sg-nat"),
+					IpPermissions: []*ec2.IpPermission{{
+						IpProtocol: aws.String("-1"),
+						IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16"), Description: aws.String("VPC")}},
+					}},
+				}).Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
+				m.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{InstanceTypes: aws.StringSlice([]string{"t3.micro"})}).
+					Return(&ec2.DescribeInstanceTypesOutput{InstanceTypes: []*ec2.InstanceTypeInfo{{
+						ProcessorInfo: &ec2.ProcessorInfo{SupportedArchitectures: aws.StringSlice([]string{"x86_64"})},
+					}}}, nil)
+				m.DescribeImages(gomock.Any()).DoAndReturn(func(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+					NewWithT(t).Expect(aws.StringValueSlice(input.Owners)).To(Equal([]string{amazonOwnerAlias}))
+					return &ec2.DescribeImagesOutput{Images: []*ec2.Image{
+						{ImageId: aws.String("ami-old"), CreationDate: aws.String("2023-01-01T00:00:00.000Z")},
+						{ImageId: aws.String("ami-new"), CreationDate: aws.String("2023-03-01T00:00:00.000Z")},
+					}}, nil
+				})
+				m.RunInstances(gomock.Any()).DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+					g := NewWithT(t)
+					g.Expect(aws.StringValue(input.ImageId)).To(Equal("ami-new"))
+					g.Expect(aws.StringValue(input.InstanceType)).To(Equal("t3.micro"))
+					g.Expect(aws.StringValue(input.SubnetId)).To(Equal("subnet-1"))
+					g.Expect(aws.StringValueSlice(input.SecurityGroupIds)).To(Equal([]string{"sg-nat"}))
+					g.Expect(aws.StringValue(input.UserData)).NotTo(BeEmpty())
+					return &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: aws.String("i-nat")}}}, nil
+				})
+				m.WaitUntilInstanceRunning(gomock.Any()).Times(0)
+			},
+			wantReason: infrav1.NatInstancesPendingReason,
+		},
+		{
+			name:        "creates a NAT instance with the instance type and AMI of the spec",
+			natInstance: &infrav1.NATInstanceSpec{InstanceType: "t4g.nano", AMI: "ami-custom"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNatInstances(m)
+				describeNatInstanceSecurityGroup(m, "10.0.0.0/16")
+				m.RunInstances(gomock.Any()).DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+					g := NewWithT(t)
+					g.Expect(aws.StringValue(input.ImageId)).To(Equal("ami-custom"))
+					g.Expect(aws.StringValue(input.InstanceType)).To(Equal("t4g.nano"))
+					return &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: aws.String("i-nat")}}}, nil
+				})
+			},
+			wantReason: infrav1.NatInstancesPendingReason,
+		},
+		{
+			name: "waits for a pending NAT instance",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNatInstances(m, &ec2.Instance{
+					InstanceId: aws.String("i-nat"),
+					SubnetId:   aws.String("subnet-1"),
+					State:      &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNamePending)},
+				})
+				describeNatInstanceSecurityGroup(m, "10.0.0.0/16")
+				m.RunInstances(gomock.Any()).Times(0)
+				m.ModifyInstanceAttribute(gomock.Any()).Times(0)
+				m.AssociateAddress(gomock.Any()).Times(0)
+			},
+			wantReason: infrav1.NatInstancesPendingReason,
+		},
+		{
+			name: "disables the source/destination check and associates an Elastic IP once the NAT instance is running",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNatInstances(m, &ec2.Instance{
+					InstanceId:      aws.String("i-nat"),
+					SubnetId:        aws.String("subnet-1"),
+					State:           &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+					SourceDestCheck: aws.Bool(true),
+				})
+				describeNatInstanceSecurityGroup(m, "10.0.0.0/16")
+				m.ModifyInstanceAttribute(&ec2.ModifyInstanceAttributeInput{
+					InstanceId:      aws.String("i-nat"),
+					SourceDestCheck: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+				}).Return(&ec2.ModifyInstanceAttributeOutput{}, nil)
+				m.DescribeAddresses(&ec2.DescribeAddressesInput{Filters: []*ec2.Filter{
+					{Name: aws.String("instance-id"), Values: aws.StringSlice([]string{"i-nat"})},
+				}}).Return(&ec2.DescribeAddressesOutput{}, nil)
+				m.DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{}, nil)
+				m.AllocateAddress(gomock.Any()).Return(&ec2.AllocateAddressOutput{AllocationId: aws.String(ElasticIPAllocationID)}, nil)
+				m.AssociateAddress(&ec2.AssociateAddressInput{
+					AllocationId: aws.String(ElasticIPAllocationID),
+					InstanceId:   aws.String("i-nat"),
+				}).Return(&ec2.AssociateAddressOutput{}, nil)
+			},
+			wantID: "i-nat",
+		},
+		{
+			name: "reuses the existing NAT instance of the subnet once it is configured",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNatInstances(m, &ec2.Instance{
+					InstanceId:      aws.String("i-existing"),
+					SubnetId:        aws.String("subnet-1"),
+					State:           &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+					SourceDestCheck: aws.Bool(false),
+				})
+				describeNatInstanceSecurityGroup(m, "10.0.0.0/16")
+				m.DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{{AllocationId: aws.String(ElasticIPAllocationID), InstanceId: aws.String("i-existing")}},
+				}, nil)
+				m.RunInstances(gomock.Any()).Times(0)
+				m.ModifyInstanceAttribute(gomock.Any()).Times(0)
+				m.AssociateAddress(gomock.Any()).Times(0)
+			},
+			wantID: "i-existing",
+		},
+		{
+			name: "doesn't route through a stopped NAT instance",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNatInstances(m, &ec2.Instance{
+					InstanceId: aws.String("i-stopped"),
+					SubnetId:   aws.String("subnet-1"),
+					State:      &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameStopped)},
+				})
+				describeNatInstanceSecurityGroup(m, "10.0.0.0/16")
+				m.RunInstances(gomock.Any()).Times(0)
+			},
+			wantReason: infrav1.NatInstancesStoppedReason,
+		},
+		{
+			name: "keeps the running NAT instance of a subnet and terminates the others",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNatInstances(m,
+					&ec2.Instance{
+						InstanceId: aws.String("i-pending"),
+						SubnetId:   aws.String("subnet-1"),
+						State:      &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNamePending)},
+					},
+					&ec2.Instance{
+						InstanceId:      aws.String("i-running"),
+						SubnetId:        aws.String("subnet-1"),
+						State:           &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+						SourceDestCheck: aws.Bool(false),
+					},
+					&ec2.Instance{
+						InstanceId: aws.String("i-stopped"),
+						SubnetId:   aws.String("subnet-1"),
+						State:      &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameStopped)},
+					},
+				)
+				m.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: aws.StringSlice([]string{"i-pending", "i-stopped"})}).
+					Return(&ec2.TerminateInstancesOutput{}, nil)
+				m.WaitUntilInstanceTerminated(gomock.Any()).Times(0)
+				describeNatInstanceSecurityGroup(m, "10.0.0.0/16")
+				m.DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{{AllocationId: aws.String(ElasticIPAllocationID), InstanceId: aws.String("i-running")}},
+				}, nil)
+				m.RunInstances(gomock.Any()).Times(0)
+			},
+			wantID: "i-running",
+		},
+		{
+			name: "authorizes the missing CIDR blocks of the VPC and revokes the others on the existing security group",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNatInstances(m, &ec2.Instance{
+					InstanceId:      aws.String("i-existing"),
+					SubnetId:        aws.String("subnet-1"),
+					State:           &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+					SourceDestCheck: aws.Bool(false),
+				})
+				describeNatInstanceSecurityGroup(m, "10.1.0.0/16")
+				m.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+					GroupId: aws.String("sg-nat"),
+					IpPermissions: []*ec2.IpPermission{{
+						IpProtocol: aws.String("-1"),
+						IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16"), Description: aws.String("VPC")}},
+					}},
+				}).Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
+				m.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+					GroupId: aws.String("sg-nat"),
+					IpPermissions: []*ec2.IpPermission{{
+						IpProtocol: aws.String("-1"),
+						IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.1.0.0/16")}},
+					}},
+				}).Return(&ec2.RevokeSecurityGroupIngressOutput{}, nil)
+				m.DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{{AllocationId: aws.String(ElasticIPAllocationID), InstanceId: aws.String("i-existing")}},
+				}, nil)
+			},
+			wantID: "i-existing",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			s, clusterScope := newNatInstanceService(t, ec2Mock, tc.natInstance)
+			g.Expect(s.reconcileNatGateways()).To(Succeed())
+			g.Expect(aws.StringValue(clusterScope.Subnets().FindByID("subnet-1").NatInstanceID)).To(Equal(tc.wantID))
+			g.Expect(NatInstancesPending(clusterScope.AWSCluster)).To(Equal(tc.wantReason == infrav1.NatInstancesPendingReason))
+
+			if tc.wantReason != "" {
+				g.Expect(conditions.GetReason(clusterScope.AWSCluster, infrav1.NatGatewaysReadyCondition)).To(Equal(tc.wantReason))
+				return
+			}
+			g.Expect(conditions.IsTrue(clusterScope.AWSCluster, infrav1.NatGatewaysReadyCondition)).To(BeTrue())
+			natInstanceID, err := s.getNatInstanceForSubnet(clusterScope.Subnets().FindByID("subnet-2"))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(natInstanceID).To(Equal(tc.wantID))
+		})
+	}
+}
+
+func TestGetNatInstanceTypeAndImageInPartitions(t *testing.T) {
+	for _, region := range []string{"us-east-1", "us-gov-west-1", "cn-north-1"} {
+		t.Run(region, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			m := ec2Mock.EXPECT()
+			m.DescribeInstanceTypes(gomock.Any()).Return(&ec2.DescribeInstanceTypesOutput{InstanceTypes: []*ec2.InstanceTypeInfo{{
+				ProcessorInfo: &ec2.ProcessorInfo{SupportedArchitectures: aws.StringSlice([]string{"arm64"})},
+			}}}, nil)
+			// The Amazon Linux images are owned by a different account in each partition.
+			m.DescribeImages(gomock.Any()).DoAndReturn(func(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+				g.Expect(aws.StringValueSlice(input.Owners)).To(Equal([]string{"amazon"}))
+				return &ec2.DescribeImagesOutput{Images: []*ec2.Image{
+					{ImageId: aws.String("ami-nat"), CreationDate: aws.String("2023-01-01T00:00:00.000Z")},
+				}}, nil
+			})
+
+			s, clusterScope := newNatInstanceService(t, ec2Mock, nil)
+			clusterScope.AWSCluster.Spec.Region = region
+			instanceType, imageID, err := s.getNatInstanceTypeAndImage()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(instanceType).To(Equal(defaultNatInstanceType))
+			g.Expect(imageID).To(Equal("ami-nat"))
+		})
+	}
+}
+
+func TestDeleteNatInstances(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	m := ec2Mock.EXPECT()
+
+	describeNatInstances(m,
+		&ec2.Instance{InstanceId: aws.String("i-nat"), SubnetId: aws.String("subnet-1")},
+		&ec2.Instance{InstanceId: aws.String("i-duplicate"), SubnetId: aws.String("subnet-1")},
+	)
+	m.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: aws.StringSlice([]string{"i-duplicate", "i-nat"})}).
+		Return(&ec2.TerminateInstancesOutput{}, nil)
+	m.WaitUntilInstanceTerminated(&ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-duplicate", "i-nat"})}).Return(nil)
+	m.DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{
+		SecurityGroups: []*ec2.SecurityGroup{{GroupId: aws.String("sg-nat")}},
+	}, nil)
+	m.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: aws.String("sg-nat")}).
+		Return(&ec2.DeleteSecurityGroupOutput{}, nil)
+	m.DescribeNatGatewaysPages(gomock.Any(), gomock.Any()).Times(0)
+
+	s, _ := newNatInstanceService(t, ec2Mock, nil)
+	g.Expect(s.deleteNatGateways()).To(Succeed())
+}
+
+func TestReconcileRouteTablesWithNatInstances(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	m := ec2Mock.EXPECT()
+
+	m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+		Return(&ec2.DescribeRouteTablesOutput{RouteTables: []*ec2.RouteTable{
+			{
+				RouteTableId: aws.String("rtb-public"),
+				Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-1")}},
+				Routes:       []*ec2.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")}},
+			},
+			{
+				RouteTableId: aws.String("rtb-private"),
+				Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-2")}},
+				Routes:       []*ec2.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), InstanceId: aws.String("i-replaced")}},
+			},
+		}}, nil)
+	m.ReplaceRoute(&ec2.ReplaceRouteInput{
+		RouteTableId:         aws.String("rtb-private"),
+		DestinationCidrBlock: aws.String("0.0.0.0/0"),
+		InstanceId:           aws.String("i-nat"),
+	}).Return(&ec2.ReplaceRouteOutput{}, nil)
+	m.CreateTags(gomock.Any()).Return(&ec2.CreateTagsOutput{}, nil).AnyTimes()
+
+	s, clusterScope := newNatInstanceService(t, ec2Mock, nil)
+	clusterScope.VPC().InternetGatewayID = aws.String("igw-1")
+	s.setNatInstanceID("subnet-1", aws.String("i-nat"))
+	g.Expect(s.reconcileRouteTables()).To(Succeed())
+}
@@ -29,10 +29,11 @@ import (
 // ReconcileNetwork reconciles the network of the given cluster.
 func (s *Service) ReconcileNetwork() (err error) {
 	s.scope.Debug("Reconciling network for cluster", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+	s.observed = observedNetwork{}
 
 	// VPC.
 	if err := s.reconcileVPC(); err != nil {
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, infrav1.VpcReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, failureReason(err, infrav1.VpcReconciliationFailedReason), infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
 	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.VpcReadyCondition)
@@ -42,6 +43,21 @@ func (s *Service) ReconcileNetwork() (err error) {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SecondaryCidrsReadyCondition, infrav1.SecondaryCidrReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
+	if s.scope.SecondaryCidrBlock() != nil {
+		conditions.MarkTrue(s.scope.InfraCluster(), infrav1.SecondaryCidrsReadyCondition)
+	}
+
+	// DHCP options.
+	if err := s.reconcileDHCPOptions(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, infrav1.DHCPOptionsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
+		return err
+	}
+
+	// Flow logs.
+	if err := s.reconcileFlowLogs(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, infrav1.FlowLogsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
+		return err
+	}
 
 	// Subnets.
 	if err := s.reconcileSubnets(); err != nil {
@@ -51,7 +67,7 @@ func (s *Service) ReconcileNetwork() (err error) {
 
 	// Internet Gateways.
 	if err := s.reconcileInternetGateways(); err != nil {
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition, infrav1.InternetGatewayFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition, failureReason(err, infrav1.InternetGatewayFailedReason), infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
 
@@ -63,20 +79,40 @@ func (s *Service) ReconcileNetwork() (err error) {
 
 	// NAT Gateways.
 	if err := s.reconcileNatGateways(); err != nil {
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, infrav1.NatGatewaysReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, failureReason(err, infrav1.NatGatewaysReconciliationFailedReason), infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
 
+	// The private subnets are routed through the NAT instances once they are running.
+	if NatInstancesPending(s.scope.InfraCluster()) {
+		s.scope.Info("Waiting for NAT instances to be running before reconciling route tables")
+		return nil
+	}
+
 	// Routing tables.
 	if err := s.reconcileRouteTables(); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition, infrav1.RouteTableReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
 
+	// Network status is informational only, failing to update it must not block the reconciliation.
+	if err := s.reconcileNetworkStatus(); err != nil {
+		s.scope.Error(err, "non-fatal: failed to reconcile network status")
+	}
+
 	s.scope.Debug("Reconcile network completed successfully")
 	return nil
 }
 
+// failureReason returns the condition reason to report for a reconciliation error,
+// surfacing exceeded service quotas instead of the generic reason.
+func failureReason(err error, reason string) string {
+	if awserrors.IsQuotaExceeded(err) {
+		return infrav1.QuotaExceededReason
+	}
+	return reason
+}
+
 // DeleteNetwork deletes the network of the given cluster.
 func (s *Service) DeleteNetwork() (err error) {
 	s.scope.Debug("Deleting network")
@@ -97,6 +133,10 @@ func (s *Service) DeleteNetwork() (err error) {
 		s.scope.Error(err, "non-fatal: VPC ID is missing, ")
 	}
 
+	// Keep the configuration of the VPC that describing it doesn't return.
+	vpc.NATGatewayElasticIPPool = s.scope.VPC().NATGatewayElasticIPPool
+	vpc.DHCPOptions = s.scope.VPC().DHCPOptions
+	vpc.FlowLogs = s.scope.VPC().FlowLogs
 	vpc.DeepCopyInto(s.scope.VPC())
 
 	// Routing tables.
@@ -171,6 +211,16 @@ func (s *Service) DeleteNetwork() (err error) {
 		return err
 	}
 
+	// DHCP options.
+	if err := s.deleteDHCPOptions(); err != nil {
+		return err
+	}
+
+	// Flow logs.
+	if err := s.deleteClusterFlowLogs(); err != nil {
+		return err
+	}
+
 	// VPC.
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 	if err := s.scope.PatchObject(); err != nil {
@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
+)
+
+func TestFailureReason(t *testing.T) {
+	quotaErr := &servicequotas.QuotaExceededError{
+		Usage:     servicequotas.Usage{Quota: servicequotas.VPCsPerRegion, Used: 5, Limit: 5},
+		Requested: 1,
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+		want   string
+	}{
+		{
+			name:   "Should return the given reason for other errors",
+			err:    errors.New("some error"),
+			reason: infrav1.VpcReconciliationFailedReason,
+			want:   infrav1.VpcReconciliationFailedReason,
+		},
+		{
+			name:   "Should return QuotaExceeded for quota errors",
+			err:    quotaErr,
+			reason: infrav1.VpcReconciliationFailedReason,
+			want:   infrav1.QuotaExceededReason,
+		},
+		{
+			name:   "Should return QuotaExceeded for wrapped quota errors",
+			err:    errors.Wrap(quotaErr, "failed to create new vpc"),
+			reason: infrav1.NatGatewaysReconciliationFailedReason,
+			want:   infrav1.QuotaExceededReason,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(failureReason(tc.err, tc.reason)).To(Equal(tc.want))
+		})
+	}
+}
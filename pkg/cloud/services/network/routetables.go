@@ -50,10 +50,21 @@ func (s *Service) reconcileRouteTables() error {
 	if err != nil {
 		return err
 	}
+	s.observed.routeTables = subnetRouteMap
 
 	subnets := s.scope.Subnets()
 	for i := range subnets {
 		sn := subnets[i]
+		// Route tables created outside of the provider are associated with their subnet, but never modified.
+		if s.isUnmanagedRouteTable(&sn, subnetRouteMap) {
+			rt, err := s.associateUnmanagedRouteTable(&sn)
+			if err != nil {
+				return err
+			}
+			subnetRouteMap[sn.ID] = rt
+			continue
+		}
+
 		// We need to compile the minimum routes for this subnet first, so we can compare it or create them.
 		var routes []*ec2.Route
 		if sn.IsPublic {
@@ -65,12 +76,24 @@ func (s *Service) reconcileRouteTables() error {
 				routes = append(routes, s.getGatewayPublicIPv6Route())
 			}
 		} else {
-			natGatewayID, err := s.getNatGatewayForSubnet(&sn)
-			if err != nil {
-				return err
+			privateIPv6EgressOnly := s.isPrivateIPv6EgressOnly()
+			switch {
+			case privateIPv6EgressOnly:
+				// Without public subnets there are no NAT gateways, the egress only internet gateway is the only route out.
+			case s.scope.VPC().GetNATStrategy() == infrav1.NATStrategyNATInstance:
+				natInstanceID, err := s.getNatInstanceForSubnet(&sn)
+				if err != nil {
+					return err
+				}
+				routes = append(routes, s.getNatInstancePrivateRoute(natInstanceID))
+			default:
+				natGatewayID, err := s.getNatGatewayForSubnet(&sn)
+				if err != nil {
+					return err
+				}
+				routes = append(routes, s.getNatGatewayPrivateRoute(natGatewayID))
 			}
-			routes = append(routes, s.getNatGatewayPrivateRoute(natGatewayID))
-			if sn.IsIPv6 {
+			if sn.IsIPv6 || privateIPv6EgressOnly {
 				if !s.scope.VPC().IsIPv6Enabled() {
 					// Safety net because EgressOnlyInternetGateway needs the ID from the ipv6 block.
 					// if, for whatever reason by this point that is not available, we don't want to
@@ -134,6 +157,7 @@ func (s *Service) reconcileRouteTables() error {
 
 		s.scope.Debug("Subnet has been associated with route table", "subnet-id", sn.ID, "route-table-id", rt.ID)
 		sn.RouteTableID = aws.String(rt.ID)
+		subnetRouteMap[sn.ID] = &ec2.RouteTable{RouteTableId: aws.String(rt.ID), Routes: routes}
 	}
 	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition)
 	return nil
@@ -145,12 +169,14 @@ func (s *Service) fixMismatchedRouting(specRoute *ec2.Route, currentRoute *ec2.R
 		if (currentRoute.DestinationCidrBlock != nil &&
 			*currentRoute.DestinationCidrBlock == *specRoute.DestinationCidrBlock) &&
 			((currentRoute.GatewayId != nil && *currentRoute.GatewayId != *specRoute.GatewayId) ||
-				(currentRoute.NatGatewayId != nil && *currentRoute.NatGatewayId != *specRoute.NatGatewayId)) {
+				(currentRoute.NatGatewayId != nil && *currentRoute.NatGatewayId != aws.StringValue(specRoute.NatGatewayId)) ||
+				(currentRoute.InstanceId != nil && *currentRoute.InstanceId != aws.StringValue(specRoute.InstanceId))) {
 			input = &ec2.ReplaceRouteInput{
 				RouteTableId:         rt.RouteTableId,
 				DestinationCidrBlock: specRoute.DestinationCidrBlock,
 				GatewayId:            specRoute.GatewayId,
 				NatGatewayId:         specRoute.NatGatewayId,
+				InstanceId:           specRoute.InstanceId,
 			}
 		}
 	}
@@ -179,10 +205,26 @@ func (s *Service) fixMismatchedRouting(specRoute *ec2.Route, currentRoute *ec2.R
 			record.Warnf(s.scope.InfraCluster(), "FailedReplaceRoute", "Failed to replace outdated route on managed RouteTable %q: %v", *rt.RouteTableId, err)
 			return errors.Wrapf(err, "failed to replace outdated route on route table %q", *rt.RouteTableId)
 		}
+		replacedRoute(currentRoute, input)
 	}
 	return nil
 }
 
+// replacedRoute updates a route of an observed route table to the target it was replaced with, so that the
+// route table status reports the route as it is after the replacement.
+func replacedRoute(route *ec2.Route, input *ec2.ReplaceRouteInput) {
+	route.GatewayId = input.GatewayId
+	route.NatGatewayId = input.NatGatewayId
+	route.InstanceId = input.InstanceId
+	route.EgressOnlyInternetGatewayId = input.EgressOnlyInternetGatewayId
+	route.TransitGatewayId = nil
+	route.NetworkInterfaceId = nil
+	route.VpcPeeringConnectionId = nil
+	route.CarrierGatewayId = nil
+	route.LocalGatewayId = nil
+	route.State = aws.String(ec2.RouteStateActive)
+}
+
 func (s *Service) describeVpcRouteTablesBySubnet() (map[string]*ec2.RouteTable, error) {
 	rts, err := s.describeVpcRouteTables()
 	if err != nil {
@@ -220,6 +262,11 @@ func (s *Service) deleteRouteTables() error {
 	}
 
 	for _, rt := range rts {
+		if s.isUnmanagedRouteTableID(aws.StringValue(rt.RouteTableId), rt.Tags) {
+			s.scope.Info("Retaining unmanaged route table", "route-table-id", *rt.RouteTableId)
+			continue
+		}
+
 		for _, as := range rt.Associations {
 			if as.SubnetId == nil {
 				continue
@@ -323,6 +370,65 @@ func (s *Service) associateRouteTable(rt *infrav1.RouteTable, subnetID string) e
 	return nil
 }
 
+// isUnmanagedRouteTable returns true if the subnet uses a route table created outside of the provider,
+// i.e. a route table is set for the subnet and the subnet isn't associated with a managed route table.
+func (s *Service) isUnmanagedRouteTable(sn *infrav1.SubnetSpec, subnetRouteMap map[string]*ec2.RouteTable) bool {
+	if sn.RouteTableID == nil {
+		return false
+	}
+	rt, ok := subnetRouteMap[sn.ID]
+	return !ok || !infrav1.Tags(converters.TagsToMap(rt.Tags)).HasOwned(s.scope.Name())
+}
+
+// isUnmanagedRouteTableID returns true if the route table is set for one of the subnets and
+// was created outside of the provider.
+func (s *Service) isUnmanagedRouteTableID(id string, rtTags []*ec2.Tag) bool {
+	for _, sn := range s.scope.Subnets() {
+		if aws.StringValue(sn.RouteTableID) == id {
+			return !infrav1.Tags(converters.TagsToMap(rtTags)).HasOwned(s.scope.Name())
+		}
+	}
+	return false
+}
+
+// associateUnmanagedRouteTable validates that the route table set for the subnet belongs to the VPC
+// and associates it with the subnet.
+func (s *Service) associateUnmanagedRouteTable(sn *infrav1.SubnetSpec) (*ec2.RouteTable, error) {
+	out, err := s.EC2Client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+		RouteTableIds: []*string{sn.RouteTableID},
+	})
+	if code, ok := awserrors.Code(err); (ok && code == awserrors.RouteTableNotFound) || (err == nil && len(out.RouteTables) == 0) {
+		record.Warnf(s.scope.InfraCluster(), "FailedDescribeRouteTable", "Unmanaged RouteTable %q of subnet %q not found", *sn.RouteTableID, sn.ID)
+		return nil, errors.Errorf("failed to validate network: route table %q of subnet %q not found", *sn.RouteTableID, sn.ID)
+	} else if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeRouteTable", "Failed to describe route table %q: %v", *sn.RouteTableID, err)
+		return nil, errors.Wrapf(err, "failed to describe route table %q", *sn.RouteTableID)
+	}
+
+	rt := out.RouteTables[0]
+	if aws.StringValue(rt.VpcId) != s.scope.VPC().ID {
+		record.Warnf(s.scope.InfraCluster(), "FailedAssociateRouteTable", "Unmanaged RouteTable %q of subnet %q belongs to VPC %q", *sn.RouteTableID, sn.ID, aws.StringValue(rt.VpcId))
+		return nil, errors.Errorf("failed to validate network: route table %q of subnet %q belongs to VPC %q", *sn.RouteTableID, sn.ID, aws.StringValue(rt.VpcId))
+	}
+
+	for _, as := range rt.Associations {
+		if aws.StringValue(as.SubnetId) == sn.ID {
+			return rt, nil
+		}
+	}
+
+	if _, err := s.EC2Client.AssociateRouteTable(&ec2.AssociateRouteTableInput{
+		RouteTableId: rt.RouteTableId,
+		SubnetId:     aws.String(sn.ID),
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAssociateRouteTable", "Failed to associate unmanaged RouteTable %q with Subnet %q: %v", *rt.RouteTableId, sn.ID, err)
+		return nil, errors.Wrapf(err, "failed to associate route table %q to subnet %q", *rt.RouteTableId, sn.ID)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateRouteTable", "Associated unmanaged RouteTable %q with subnet %q", *rt.RouteTableId, sn.ID)
+	return rt, nil
+}
+
 func (s *Service) getNatGatewayPrivateRoute(natGatewayID string) *ec2.Route {
 	return &ec2.Route{
 		NatGatewayId:         aws.String(natGatewayID),
@@ -46,6 +46,8 @@ func TestReconcileRouteTables(t *testing.T) {
 		input  *infrav1.NetworkSpec
 		expect func(m *mocks.MockEC2APIMockRecorder)
 		err    error
+		// expectTargets are the targets of the observed routes of each subnet, if set.
+		expectTargets map[string][]string
 	}{
 		{
 			name: "no routes existing, single private and single public, same AZ",
@@ -282,6 +284,85 @@ func TestReconcileRouteTables(t *testing.T) {
 					After(publicRouteTable)
 			},
 		},
+		{
+			name: "no routes existing, single private IPv6 enabled subnet with private IPv6 egress, routes through the Egress only IWG",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-routetables",
+					InternetGatewayID: aws.String("igw-01"),
+					IPv6: &infrav1.IPv6{
+						CidrBlock:                   "2001:db8:1234::/56",
+						PoolID:                      "my-pool",
+						EgressOnlyInternetGatewayID: aws.String("eigw-01"),
+					},
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: infrav1.Subnets{
+					infrav1.SubnetSpec{
+						ID:               "subnet-routetables-private",
+						IsPublic:         false,
+						IsIPv6:           true,
+						IPv6CidrBlock:    "2001:db8:1234:1::/64",
+						AvailabilityZone: "us-east-1a",
+					},
+				},
+				PrivateIPv6Egress: true,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				privateRouteTable := m.CreateRouteTable(matchRouteTableInput(&ec2.CreateRouteTableInput{VpcId: aws.String("vpc-routetables")})).
+					Return(&ec2.CreateRouteTableOutput{RouteTable: &ec2.RouteTable{RouteTableId: aws.String("rt-1")}}, nil)
+
+				m.CreateRoute(gomock.Eq(&ec2.CreateRouteInput{
+					DestinationIpv6CidrBlock:    aws.String("::/0"),
+					EgressOnlyInternetGatewayId: aws.String("eigw-01"),
+					RouteTableId:                aws.String("rt-1"),
+				})).
+					After(privateRouteTable)
+
+				m.AssociateRouteTable(gomock.Eq(&ec2.AssociateRouteTableInput{
+					RouteTableId: aws.String("rt-1"),
+					SubnetId:     aws.String("subnet-routetables-private"),
+				})).
+					Return(&ec2.AssociateRouteTableOutput{}, nil).
+					After(privateRouteTable)
+			},
+		},
+		{
+			name: "single private IPv6 enabled subnet without private IPv6 egress, returns error",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-routetables",
+					InternetGatewayID: aws.String("igw-01"),
+					IPv6: &infrav1.IPv6{
+						CidrBlock:                   "2001:db8:1234::/56",
+						PoolID:                      "my-pool",
+						EgressOnlyInternetGatewayID: aws.String("eigw-01"),
+					},
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: infrav1.Subnets{
+					infrav1.SubnetSpec{
+						ID:               "subnet-routetables-private",
+						IsPublic:         false,
+						IsIPv6:           true,
+						IPv6CidrBlock:    "2001:db8:1234:1::/64",
+						AvailabilityZone: "us-east-1a",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+			},
+			err: errors.New(`no nat gateways available in "us-east-1a"`),
+		},
 		{
 			name: "subnets in different availability zones, returns error",
 			input: &infrav1.NetworkSpec{
@@ -417,6 +498,10 @@ func TestReconcileRouteTables(t *testing.T) {
 				)).
 					Return(nil, nil)
 			},
+			expectTargets: map[string][]string{
+				"subnet-routetables-private": {"nat-01"},
+				"subnet-routetables-public":  {"igw-01"},
+			},
 		},
 		{
 			name: "extra routes exist, do nothing",
@@ -518,6 +603,83 @@ func TestReconcileRouteTables(t *testing.T) {
 					}, nil)
 			},
 		},
+		{
+			name: "unmanaged route table set, associates it without creating routes",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-routetables",
+					InternetGatewayID: aws.String("igw-01"),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: infrav1.Subnets{
+					infrav1.SubnetSpec{
+						ID:               "subnet-routetables-public",
+						IsPublic:         true,
+						AvailabilityZone: "us-east-1a",
+						RouteTableID:     aws.String("route-table-unmanaged"),
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeRouteTables(gomock.Eq(&ec2.DescribeRouteTablesInput{
+					RouteTableIds: aws.StringSlice([]string{"route-table-unmanaged"}),
+				})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("route-table-unmanaged"),
+								VpcId:        aws.String("vpc-routetables"),
+							},
+						},
+					}, nil)
+
+				m.AssociateRouteTable(gomock.Eq(&ec2.AssociateRouteTableInput{
+					RouteTableId: aws.String("route-table-unmanaged"),
+					SubnetId:     aws.String("subnet-routetables-public"),
+				})).
+					Return(&ec2.AssociateRouteTableOutput{}, nil)
+			},
+		},
+		{
+			name: "unmanaged route table set in another vpc, fails",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-routetables",
+					InternetGatewayID: aws.String("igw-01"),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: infrav1.Subnets{
+					infrav1.SubnetSpec{
+						ID:               "subnet-routetables-public",
+						IsPublic:         true,
+						AvailabilityZone: "us-east-1a",
+						RouteTableID:     aws.String("route-table-unmanaged"),
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("route-table-unmanaged"),
+								VpcId:        aws.String("vpc-other"),
+							},
+						},
+					}, nil)
+			},
+			err: errors.New(`route table "route-table-unmanaged" of subnet "subnet-routetables-public" belongs to VPC "vpc-other"`),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -555,6 +717,14 @@ func TestReconcileRouteTables(t *testing.T) {
 			} else if err != nil {
 				t.Fatalf("got an unexpected error: %v", err)
 			}
+
+			for subnetID, targets := range tc.expectTargets {
+				var observed []string
+				for _, route := range s.observed.routeTables[subnetID].Routes {
+					observed = append(observed, routeTargetID(route))
+				}
+				NewWithT(t).Expect(observed).To(Equal(targets), "routes of subnet %q", subnetID)
+			}
 		})
 	}
 }
@@ -650,6 +820,29 @@ func TestDeleteRouteTables(t *testing.T) {
 				})).Return(&ec2.DeleteRouteTableOutput{}, nil)
 			},
 		},
+		{
+			name: "Should retain unmanaged route table",
+			input: &infrav1.NetworkSpec{
+				Subnets: infrav1.Subnets{
+					infrav1.SubnetSpec{
+						ID:           "subnet-routetables-private",
+						RouteTableID: aws.String("route-table-private"),
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(describeRouteTableOutput, nil)
+
+				m.DisassociateRouteTable(gomock.Eq(&ec2.DisassociateRouteTableInput{
+					AssociationId: aws.String("route-table-public"),
+				})).Return(&ec2.DisassociateRouteTableOutput{}, nil)
+
+				m.DeleteRouteTable(gomock.Eq(&ec2.DeleteRouteTableInput{
+					RouteTableId: aws.String("route-table-public"),
+				})).Return(&ec2.DeleteRouteTableOutput{}, nil)
+			},
+		},
 		{
 			name:  "Should return error if describe route table fails",
 			input: &infrav1.NetworkSpec{},
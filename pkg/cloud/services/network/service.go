@@ -17,9 +17,11 @@ limitations under the License.
 package network
 
 import (
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
 )
 
 // Service holds a collection of interfaces.
@@ -28,6 +30,21 @@ import (
 type Service struct {
 	scope     scope.NetworkScope
 	EC2Client ec2iface.EC2API
+
+	// QuotaService, when set, is used to verify service quotas before creating resources.
+	QuotaService *servicequotas.Service
+
+	// observed holds the resources described while reconciling the network.
+	observed observedNetwork
+}
+
+// observedNetwork holds the resources described or created while reconciling the network,
+// so that the network status can be reported without describing them again.
+type observedNetwork struct {
+	internetGateway *ec2.InternetGateway
+	natGateways     []*ec2.NatGateway
+	// routeTables maps subnet IDs to their route table.
+	routeTables map[string]*ec2.RouteTable
 }
 
 // NewService returns a new service given the ec2 api client.
@@ -0,0 +1,268 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileNetworkStatus reports the route tables, gateways and VPC endpoints
+// of the managed VPC in the network status. Route tables and gateways are reported
+// as observed by their reconcilers, so it must run after them.
+func (s *Service) reconcileNetworkStatus() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping network status reconcile in unmanaged mode")
+		return nil
+	}
+
+	s.scope.Debug("Reconciling network status")
+
+	vpcEndpoints, err := s.getVPCEndpointsStatus()
+	if err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition, infrav1.VpcEndpointsReconciliationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+	markVPCEndpointsCondition(s.scope.InfraCluster(), vpcEndpoints)
+
+	status := s.scope.Network()
+	status.InternetGatewayID = s.scope.VPC().InternetGatewayID
+	status.InternetGatewayAttachmentState = s.getInternetGatewayAttachmentState()
+	status.EgressOnlyInternetGatewayID = nil
+	if s.scope.VPC().IsIPv6Enabled() {
+		status.EgressOnlyInternetGatewayID = s.scope.VPC().IPv6.EgressOnlyInternetGatewayID
+	}
+	status.RouteTables = s.getRouteTablesStatus(s.scope.Subnets())
+	status.NatGateways = s.getNatGatewaysStatus()
+	status.VPCEndpoints = vpcEndpoints
+
+	return nil
+}
+
+// ObserveNetwork reports the route tables, gateways and VPC endpoints of the VPC of an
+// externally managed cluster in the network status, without modifying any AWS resource.
+// It returns the subnets of the VPC, restricted to the subnets of the spec if any are set.
+func (s *Service) ObserveNetwork() (infrav1.Subnets, error) {
+	if s.scope.VPC().ID == "" {
+		s.scope.Trace("Skipping network observation, the VPC id is not set")
+		return nil, nil
+	}
+
+	s.scope.Debug("Observing network for cluster", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+	s.observed = observedNetwork{}
+
+	subnets, err := s.describeVpcSubnets()
+	if err != nil {
+		return nil, err
+	}
+	if specSubnets := s.scope.Subnets(); len(specSubnets) > 0 {
+		var res infrav1.Subnets
+		for _, sn := range subnets {
+			if specSubnets.FindByID(sn.ID) != nil {
+				res = append(res, sn)
+			}
+		}
+		subnets = res
+	}
+
+	igs, err := s.describeVpcInternetGateways()
+	if err != nil && !awserrors.IsNotFound(err) {
+		return nil, err
+	}
+	if len(igs) > 0 {
+		s.observed.internetGateway = igs[0]
+	}
+
+	if s.observed.routeTables, err = s.describeVpcRouteTablesBySubnet(); err != nil {
+		return nil, err
+	}
+
+	natGateways, err := s.describeNatGatewaysBySubnet()
+	if err != nil {
+		return nil, err
+	}
+	for _, ngw := range natGateways {
+		s.observed.natGateways = append(s.observed.natGateways, ngw)
+	}
+
+	vpcEndpoints, err := s.getVPCEndpointsStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	status := s.scope.Network()
+	status.InternetGatewayID = nil
+	if s.observed.internetGateway != nil {
+		status.InternetGatewayID = s.observed.internetGateway.InternetGatewayId
+	}
+	status.InternetGatewayAttachmentState = s.getInternetGatewayAttachmentState()
+	status.RouteTables = s.getRouteTablesStatus(subnets)
+	status.NatGateways = s.getNatGatewaysStatus()
+	status.VPCEndpoints = vpcEndpoints
+
+	return subnets, nil
+}
+
+func (s *Service) getInternetGatewayAttachmentState() string {
+	if s.observed.internetGateway == nil {
+		return ""
+	}
+	for _, attachment := range s.observed.internetGateway.Attachments {
+		if aws.StringValue(attachment.VpcId) == s.scope.VPC().ID {
+			return aws.StringValue(attachment.State)
+		}
+	}
+	return ""
+}
+
+func (s *Service) getRouteTablesStatus(subnets infrav1.Subnets) []infrav1.RouteTableStatus {
+	var res []infrav1.RouteTableStatus
+	for _, sn := range subnets {
+		rt, ok := s.observed.routeTables[sn.ID]
+		if !ok {
+			continue
+		}
+
+		status := infrav1.RouteTableStatus{
+			ID:       aws.StringValue(rt.RouteTableId),
+			SubnetID: sn.ID,
+		}
+		for _, route := range rt.Routes {
+			status.Routes = append(status.Routes, infrav1.RouteStatus{
+				DestinationCIDRBlock:     aws.StringValue(route.DestinationCidrBlock),
+				DestinationIPv6CIDRBlock: aws.StringValue(route.DestinationIpv6CidrBlock),
+				TargetID:                 routeTargetID(route),
+				State:                    aws.StringValue(route.State),
+			})
+		}
+		res = append(res, status)
+	}
+
+	return res
+}
+
+// routeTargetID returns the id of the resource the route sends traffic to.
+func routeTargetID(route *ec2.Route) string {
+	for _, id := range []*string{
+		route.GatewayId,
+		route.NatGatewayId,
+		route.EgressOnlyInternetGatewayId,
+		route.TransitGatewayId,
+		route.NetworkInterfaceId,
+		route.VpcPeeringConnectionId,
+		route.InstanceId,
+		route.CarrierGatewayId,
+		route.LocalGatewayId,
+	} {
+		if id != nil {
+			return *id
+		}
+	}
+	return ""
+}
+
+func (s *Service) getNatGatewaysStatus() []infrav1.NatGatewayStatus {
+	var res []infrav1.NatGatewayStatus
+	for _, ngw := range s.observed.natGateways {
+		status := infrav1.NatGatewayStatus{
+			ID:             aws.StringValue(ngw.NatGatewayId),
+			SubnetID:       aws.StringValue(ngw.SubnetId),
+			State:          aws.StringValue(ngw.State),
+			FailureMessage: aws.StringValue(ngw.FailureMessage),
+		}
+		if len(ngw.NatGatewayAddresses) > 0 {
+			status.PublicIP = aws.StringValue(ngw.NatGatewayAddresses[0].PublicIp)
+			status.AllocationID = aws.StringValue(ngw.NatGatewayAddresses[0].AllocationId)
+		}
+		res = append(res, status)
+	}
+
+	// Keep a stable order to avoid needless status updates.
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res
+}
+
+// markVPCEndpointsCondition reports whether the VPC endpoints are available. Endpoints being deleted are ignored,
+// and endpoints which failed, were rejected or expired are reported with a warning severity.
+func markVPCEndpointsCondition(to conditions.Setter, endpoints []infrav1.VPCEndpointStatus) {
+	var notAvailable []string
+	severity := clusterv1.ConditionSeverityInfo
+	for _, endpoint := range endpoints {
+		switch strings.ToLower(endpoint.State) {
+		case strings.ToLower(ec2.StateAvailable), strings.ToLower(ec2.StateDeleting), strings.ToLower(ec2.StateDeleted):
+			continue
+		case strings.ToLower(ec2.StateFailed), strings.ToLower(ec2.StateRejected), strings.ToLower(ec2.StateExpired):
+			severity = clusterv1.ConditionSeverityWarning
+		}
+		notAvailable = append(notAvailable, fmt.Sprintf("%s (%s): %s", endpoint.ID, endpoint.ServiceName, endpoint.State))
+	}
+
+	if len(notAvailable) > 0 {
+		conditions.MarkFalse(to, infrav1.VpcEndpointsReadyCondition, infrav1.VpcEndpointsNotAvailableReason, severity,
+			"VPC endpoints not available: %s", strings.Join(notAvailable, ", "))
+		return
+	}
+	conditions.MarkTrue(to, infrav1.VpcEndpointsReadyCondition)
+}
+
+func (s *Service) getVPCEndpointsStatus() ([]infrav1.VPCEndpointStatus, error) {
+	input := &ec2.DescribeVpcEndpointsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+		},
+	}
+
+	var res []infrav1.VPCEndpointStatus
+	err := s.EC2Client.DescribeVpcEndpointsPages(input,
+		func(page *ec2.DescribeVpcEndpointsOutput, lastPage bool) bool {
+			for _, endpoint := range page.VpcEndpoints {
+				res = append(res, infrav1.VPCEndpointStatus{
+					ID:          aws.StringValue(endpoint.VpcEndpointId),
+					ServiceName: aws.StringValue(endpoint.ServiceName),
+					Type:        aws.StringValue(endpoint.VpcEndpointType),
+					State:       aws.StringValue(endpoint.State),
+				})
+			}
+			return !lastPage
+		})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeVPCEndpoints", "Failed to describe VPC endpoints in vpc %q: %v", s.scope.VPC().ID, err)
+		return nil, errors.Wrapf(err, "failed to describe VPC endpoints in vpc %q", s.scope.VPC().ID)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res, nil
+}
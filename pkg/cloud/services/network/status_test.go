@@ -0,0 +1,460 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileNetworkStatus(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name     string
+		vpc      infrav1.VPCSpec
+		observed observedNetwork
+		expect   func(m *mocks.MockEC2APIMockRecorder)
+		want     infrav1.NetworkStatus
+		wantErr  bool
+	}{
+		{
+			name: "managed vpc, should report the observed gateways and route tables, and VPC endpoints",
+			vpc: infrav1.VPCSpec{
+				ID:                "vpc-status",
+				InternetGatewayID: aws.String("igw-1"),
+				Tags: infrav1.Tags{
+					infrav1.ClusterTagKey("test-cluster"): "owned",
+				},
+			},
+			observed: observedNetwork{
+				internetGateway: &ec2.InternetGateway{
+					InternetGatewayId: aws.String("igw-1"),
+					Attachments: []*ec2.InternetGatewayAttachment{
+						{
+							State: aws.String("available"),
+							VpcId: aws.String("vpc-status"),
+						},
+					},
+				},
+				routeTables: map[string]*ec2.RouteTable{
+					"subnet-public": {
+						RouteTableId: aws.String("rtb-public"),
+						Routes: []*ec2.Route{
+							{
+								DestinationCidrBlock: aws.String("10.0.0.0/16"),
+								GatewayId:            aws.String("local"),
+								State:                aws.String(ec2.RouteStateActive),
+							},
+							{
+								DestinationCidrBlock: aws.String("0.0.0.0/0"),
+								GatewayId:            aws.String("igw-1"),
+								State:                aws.String(ec2.RouteStateActive),
+							},
+						},
+					},
+					"subnet-private": {
+						RouteTableId: aws.String("rtb-private"),
+						Routes: []*ec2.Route{
+							{
+								DestinationCidrBlock: aws.String("0.0.0.0/0"),
+								NatGatewayId:         aws.String("nat-1"),
+								State:                aws.String(ec2.RouteStateBlackhole),
+							},
+						},
+					},
+				},
+				natGateways: []*ec2.NatGateway{
+					{
+						NatGatewayId: aws.String("nat-2"),
+						SubnetId:     aws.String("subnet-public"),
+						State:        aws.String(ec2.NatGatewayStatePending),
+					},
+					{
+						NatGatewayId: aws.String("nat-1"),
+						SubnetId:     aws.String("subnet-public"),
+						State:        aws.String(ec2.NatGatewayStateAvailable),
+						NatGatewayAddresses: []*ec2.NatGatewayAddress{
+							{
+								AllocationId: aws.String("eipalloc-1"),
+								PublicIp:     aws.String("1.2.3.4"),
+							},
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVpcEndpointsPages(gomock.AssignableToTypeOf(&ec2.DescribeVpcEndpointsInput{}), gomock.Any()).
+					DoAndReturn(func(_ *ec2.DescribeVpcEndpointsInput, fn func(*ec2.DescribeVpcEndpointsOutput, bool) bool) error {
+						fn(&ec2.DescribeVpcEndpointsOutput{
+							VpcEndpoints: []*ec2.VpcEndpoint{
+								{
+									VpcEndpointId:   aws.String("vpce-1"),
+									ServiceName:     aws.String("com.amazonaws.us-east-1.s3"),
+									VpcEndpointType: aws.String(ec2.VpcEndpointTypeGateway),
+									State:           aws.String("available"),
+								},
+							},
+						}, true)
+						return nil
+					})
+			},
+			want: infrav1.NetworkStatus{
+				InternetGatewayID:              aws.String("igw-1"),
+				InternetGatewayAttachmentState: "available",
+				RouteTables: []infrav1.RouteTableStatus{
+					{
+						ID:       "rtb-public",
+						SubnetID: "subnet-public",
+						Routes: []infrav1.RouteStatus{
+							{DestinationCIDRBlock: "10.0.0.0/16", TargetID: "local", State: ec2.RouteStateActive},
+							{DestinationCIDRBlock: "0.0.0.0/0", TargetID: "igw-1", State: ec2.RouteStateActive},
+						},
+					},
+					{
+						ID:       "rtb-private",
+						SubnetID: "subnet-private",
+						Routes: []infrav1.RouteStatus{
+							{DestinationCIDRBlock: "0.0.0.0/0", TargetID: "nat-1", State: ec2.RouteStateBlackhole},
+						},
+					},
+				},
+				NatGateways: []infrav1.NatGatewayStatus{
+					{
+						ID:           "nat-1",
+						SubnetID:     "subnet-public",
+						State:        ec2.NatGatewayStateAvailable,
+						PublicIP:     "1.2.3.4",
+						AllocationID: "eipalloc-1",
+					},
+					{
+						ID:       "nat-2",
+						SubnetID: "subnet-public",
+						State:    ec2.NatGatewayStatePending,
+					},
+				},
+				VPCEndpoints: []infrav1.VPCEndpointStatus{
+					{
+						ID:          "vpce-1",
+						ServiceName: "com.amazonaws.us-east-1.s3",
+						Type:        ec2.VpcEndpointTypeGateway,
+						State:       "available",
+					},
+				},
+			},
+		},
+		{
+			name: "unmanaged vpc, should not report anything",
+			vpc: infrav1.VPCSpec{
+				ID: "vpc-status",
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVpcEndpointsPages(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name: "failing to describe VPC endpoints, should return an error",
+			vpc: infrav1.VPCSpec{
+				ID: "vpc-status",
+				Tags: infrav1.Tags{
+					infrav1.ClusterTagKey("test-cluster"): "owned",
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVpcEndpointsPages(gomock.AssignableToTypeOf(&ec2.DescribeVpcEndpointsInput{}), gomock.Any()).
+					Return(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: tc.vpc,
+						Subnets: infrav1.Subnets{
+							{ID: "subnet-public", IsPublic: true},
+							{ID: "subnet-private"},
+						},
+					},
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			ctx := context.TODO()
+			client.Create(ctx, awsCluster)
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: awsCluster,
+				Client:     client,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+			s.observed = tc.observed
+
+			err = s.reconcileNetworkStatus()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(*clusterScope.Network()).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestMarkVPCEndpointsCondition(t *testing.T) {
+	testCases := []struct {
+		name         string
+		endpoints    []infrav1.VPCEndpointStatus
+		wantStatus   corev1.ConditionStatus
+		wantSeverity clusterv1.ConditionSeverity
+	}{
+		{
+			name:       "no VPC endpoints",
+			wantStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "available and deleted VPC endpoints",
+			endpoints: []infrav1.VPCEndpointStatus{
+				{ID: "vpce-1", ServiceName: "com.amazonaws.us-east-1.s3", State: "available"},
+				{ID: "vpce-2", ServiceName: "com.amazonaws.us-east-1.ecr.api", State: "deleted"},
+			},
+			wantStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "pending VPC endpoint",
+			endpoints: []infrav1.VPCEndpointStatus{
+				{ID: "vpce-1", ServiceName: "com.amazonaws.us-east-1.s3", State: "available"},
+				{ID: "vpce-2", ServiceName: "com.amazonaws.us-east-1.ecr.api", State: "pending"},
+			},
+			wantStatus:   corev1.ConditionFalse,
+			wantSeverity: clusterv1.ConditionSeverityInfo,
+		},
+		{
+			name: "failed VPC endpoint",
+			endpoints: []infrav1.VPCEndpointStatus{
+				{ID: "vpce-1", ServiceName: "com.amazonaws.us-east-1.s3", State: "pending"},
+				{ID: "vpce-2", ServiceName: "com.amazonaws.us-east-1.ecr.api", State: "failed"},
+			},
+			wantStatus:   corev1.ConditionFalse,
+			wantSeverity: clusterv1.ConditionSeverityWarning,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			awsCluster := &infrav1.AWSCluster{}
+
+			markVPCEndpointsCondition(awsCluster, tc.endpoints)
+
+			condition := conditions.Get(awsCluster, infrav1.VpcEndpointsReadyCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.wantStatus))
+			g.Expect(condition.Severity).To(Equal(tc.wantSeverity))
+			if tc.wantStatus == corev1.ConditionFalse {
+				g.Expect(condition.Reason).To(Equal(infrav1.VpcEndpointsNotAvailableReason))
+				g.Expect(condition.Message).To(ContainSubstring("vpce-2"))
+			}
+		})
+	}
+}
+
+func TestObserveNetwork(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name        string
+		vpc         infrav1.VPCSpec
+		subnets     infrav1.Subnets
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		wantSubnets infrav1.Subnets
+		want        infrav1.NetworkStatus
+		wantErr     bool
+	}{
+		{
+			name: "should report the network of the vpc, restricted to the subnets of the spec",
+			vpc:  infrav1.VPCSpec{ID: "vpc-external"},
+			subnets: infrav1.Subnets{
+				{ID: "subnet-public"},
+				{ID: "subnet-private"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{SubnetId: aws.String("subnet-public"), AvailabilityZone: aws.String("us-east-1a"), CidrBlock: aws.String("10.0.0.0/24")},
+							{SubnetId: aws.String("subnet-private"), AvailabilityZone: aws.String("us-east-1b"), CidrBlock: aws.String("10.0.1.0/24")},
+							{SubnetId: aws.String("subnet-other"), AvailabilityZone: aws.String("us-east-1c"), CidrBlock: aws.String("10.0.2.0/24")},
+						},
+					}, nil)
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("rtb-public"),
+								Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-public")}},
+								Routes: []*ec2.Route{
+									{
+										DestinationCidrBlock: aws.String("0.0.0.0/0"),
+										GatewayId:            aws.String("igw-external"),
+										State:                aws.String(ec2.RouteStateActive),
+									},
+								},
+							},
+						},
+					}, nil).Times(2)
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil).Times(2)
+				m.DescribeInternetGateways(gomock.AssignableToTypeOf(&ec2.DescribeInternetGatewaysInput{})).
+					Return(&ec2.DescribeInternetGatewaysOutput{
+						InternetGateways: []*ec2.InternetGateway{
+							{
+								InternetGatewayId: aws.String("igw-external"),
+								Attachments: []*ec2.InternetGatewayAttachment{
+									{
+										State: aws.String("available"),
+										VpcId: aws.String("vpc-external"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.DescribeVpcEndpointsPages(gomock.AssignableToTypeOf(&ec2.DescribeVpcEndpointsInput{}), gomock.Any()).
+					Return(nil)
+			},
+			wantSubnets: infrav1.Subnets{
+				{
+					ID:               "subnet-public",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.0.0/24",
+					IsPublic:         true,
+					RouteTableID:     aws.String("rtb-public"),
+					Tags:             infrav1.Tags{},
+				},
+				{
+					ID:               "subnet-private",
+					AvailabilityZone: "us-east-1b",
+					CidrBlock:        "10.0.1.0/24",
+					Tags:             infrav1.Tags{},
+				},
+			},
+			want: infrav1.NetworkStatus{
+				InternetGatewayID:              aws.String("igw-external"),
+				InternetGatewayAttachmentState: "available",
+				RouteTables: []infrav1.RouteTableStatus{
+					{
+						ID:       "rtb-public",
+						SubnetID: "subnet-public",
+						Routes: []infrav1.RouteStatus{
+							{DestinationCIDRBlock: "0.0.0.0/0", TargetID: "igw-external", State: ec2.RouteStateActive},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "without a vpc id, should not describe anything",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Any()).Times(0)
+			},
+		},
+		{
+			name: "failing to describe subnets, should return an error",
+			vpc:  infrav1.VPCSpec{ID: "vpc-external"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(nil, errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC:     tc.vpc,
+						Subnets: tc.subnets,
+					},
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			ctx := context.TODO()
+			client.Create(ctx, awsCluster)
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: awsCluster,
+				Client:     client,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			subnets, err := s.ObserveNetwork()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(subnets).To(Equal(tc.wantSubnets))
+			g.Expect(*clusterScope.Network()).To(Equal(tc.want))
+			g.Expect(clusterScope.Subnets()).To(Equal(tc.subnets))
+		})
+	}
+}
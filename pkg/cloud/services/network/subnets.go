@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
@@ -42,7 +43,9 @@ import (
 const (
 	internalLoadBalancerTag = "kubernetes.io/role/internal-elb"
 	externalLoadBalancerTag = "kubernetes.io/role/elb"
+	cniSubnetTag            = "kubernetes.io/role/cni"
 	defaultMaxNumAZs        = 3
+	awsReservedTagPrefix    = "aws:"
 )
 
 func (s *Service) reconcileSubnets() error {
@@ -84,12 +87,20 @@ func (s *Service) reconcileSubnets() error {
 	}
 
 	if s.scope.SecondaryCidrBlock() != nil {
-		subnetCIDRs, err := cidr.SplitIntoSubnetsIPv4(*s.scope.SecondaryCidrBlock(), *s.scope.VPC().AvailabilityZoneUsageLimit)
+		zones, err := s.getAvailableZones()
 		if err != nil {
 			return err
 		}
 
-		zones, err := s.getAvailableZones()
+		numZones := *s.scope.VPC().AvailabilityZoneUsageLimit
+		if s.scope.VPC().AvailabilityZoneSelection != nil && *s.scope.VPC().AvailabilityZoneSelection == infrav1.AZSelectionSchemeExplicit {
+			if zones, err = s.selectZones(zones); err != nil {
+				return err
+			}
+			numZones = len(zones)
+		}
+
+		subnetCIDRs, err := cidr.SplitIntoSubnetsIPv4(*s.scope.SecondaryCidrBlock(), numZones)
 		if err != nil {
 			return err
 		}
@@ -117,7 +128,7 @@ func (s *Service) reconcileSubnets() error {
 			subnetTags := sub.Tags
 			// Make sure tags are up-to-date.
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-				buildParams := s.getSubnetTagParams(unmanagedVPC, existingSubnet.ID, existingSubnet.IsPublic, existingSubnet.AvailabilityZone, subnetTags)
+				buildParams := s.getSubnetTagParams(unmanagedVPC, existingSubnet.ID, existingSubnet.IsPublic, existingSubnet.AvailabilityZone, subnetTags, existingSubnet.Tags)
 				tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
 				if err := tagsBuilder.Ensure(existingSubnet.Tags); err != nil {
 					return false, err
@@ -129,15 +140,19 @@ func (s *Service) reconcileSubnets() error {
 					return errors.Wrapf(err, "failed to ensure tags on subnet %q", existingSubnet.ID)
 				} else {
 					// We may not have a permission to tag unmanaged subnets.
-					// When tagging unmanaged subnet fails, record an event and proceed.
+					// When tagging unmanaged subnet fails, record an event and proceed with the other subnets.
 					record.Warnf(s.scope.InfraCluster(), "FailedTagSubnet", "Failed tagging unmanaged Subnet %q: %v", existingSubnet.ID, err)
-					break
 				}
 			}
 
 			// Update subnet spec with the existing subnet details
 			// TODO(vincepri): check if subnet needs to be updated.
+			routeTableID := sub.RouteTableID
 			existingSubnet.DeepCopyInto(sub)
+			// Route tables created outside of the provider are not discovered in managed VPCs.
+			if sub.RouteTableID == nil {
+				sub.RouteTableID = routeTableID
+			}
 		} else if unmanagedVPC {
 			// If there is no existing subnet and we have an umanaged vpc report an error
 			record.Warnf(s.scope.InfraCluster(), "FailedMatchSubnet", "Using unmanaged VPC and failed to find existing subnet for specified subnet id %d, cidr %q", sub.ID, sub.CidrBlock)
@@ -174,6 +189,7 @@ func (s *Service) reconcileSubnets() error {
 			if err != nil {
 				return err
 			}
+			nsn.RouteTableID = subnet.RouteTableID
 			nsn.DeepCopyInto(subnet)
 		}
 	}
@@ -183,20 +199,30 @@ func (s *Service) reconcileSubnets() error {
 	return nil
 }
 
-func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
-	zones, err := s.getAvailableZones()
-	if err != nil {
-		return nil, err
+// selectZones returns the availability zones of the default subnets among the available zones of the region,
+// following the availability zone selection scheme of the VPC.
+func (s *Service) selectZones(zones []string) ([]string, error) {
+	selectionScheme := infrav1.AZSelectionSchemeOrdered
+	if s.scope.VPC().AvailabilityZoneSelection != nil {
+		selectionScheme = *s.scope.VPC().AvailabilityZoneSelection
+	}
+
+	if selectionScheme == infrav1.AZSelectionSchemeExplicit {
+		available := sets.NewString(zones...)
+		for _, zone := range s.scope.VPC().AvailabilityZones {
+			if !available.Has(zone) {
+				record.Warnf(s.scope.InfraCluster(), "FailedSelectAvailabilityZones", "Availability zone %q is not available in region %q", zone, s.scope.Region())
+				return nil, errors.Errorf("availability zone %q is not available in region %q", zone, s.scope.Region())
+			}
+		}
+		s.scope.Debug("zones selected", "region", s.scope.Region(), "zones", s.scope.VPC().AvailabilityZones)
+		return append([]string{}, s.scope.VPC().AvailabilityZones...), nil
 	}
 
 	maxZones := defaultMaxNumAZs
 	if s.scope.VPC().AvailabilityZoneUsageLimit != nil {
 		maxZones = *s.scope.VPC().AvailabilityZoneUsageLimit
 	}
-	selectionScheme := infrav1.AZSelectionSchemeOrdered
-	if s.scope.VPC().AvailabilityZoneSelection != nil {
-		selectionScheme = *s.scope.VPC().AvailabilityZoneSelection
-	}
 
 	if len(zones) > maxZones {
 		s.scope.Debug("region has more than AvailabilityZoneUsageLimit availability zones, picking zones to use", "region", s.scope.Region(), "AvailabilityZoneUsageLimit", maxZones)
@@ -211,6 +237,31 @@ func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
 		zones = zones[:maxZones]
 		s.scope.Debug("zones selected", "region", s.scope.Region(), "zones", zones)
 	}
+	return zones, nil
+}
+
+func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
+	if s.scope.VPC().SubnetSchema != nil && len(s.scope.Network().ComputedSubnets) > 0 {
+		s.scope.Debug("using the subnets previously computed from the subnet schema")
+		return s.getComputedSubnets()
+	}
+
+	zones, err := s.getAvailableZones()
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err = s.selectZones(zones)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.scope.VPC().SubnetSchema != nil {
+		if err := s.computeSubnets(zones); err != nil {
+			return nil, err
+		}
+		return s.getComputedSubnets()
+	}
 
 	// 1 private subnet for each AZ plus 1 other subnet that will be further sub-divided for the public subnets
 	// All subnets will have an ipv4 address for now as well. We aren't supporting ipv6-only yet.
@@ -274,6 +325,73 @@ func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
 	return subnets, nil
 }
 
+// computeSubnets computes the CIDR blocks of the subnets described by the subnet schema of the VPC in the
+// zones, and records them in the network status. The public subnets of the zones, then their private subnets,
+// are allocated in alphabetical order of the zones, largest subnets first.
+func (s *Service) computeSubnets(zones []string) error {
+	schema := s.scope.VPC().SubnetSchema
+	zones = append([]string{}, zones...)
+	sort.Strings(zones)
+
+	var (
+		computed      []infrav1.ComputedSubnet
+		prefixLengths []int
+	)
+	for _, zone := range zones {
+		for i := 0; i < schema.GetPublicSubnetsPerZone(); i++ {
+			computed = append(computed, infrav1.ComputedSubnet{AvailabilityZone: zone, IsPublic: true})
+			prefixLengths = append(prefixLengths, schema.PublicSubnetPrefixLength)
+		}
+	}
+	for _, zone := range zones {
+		for i := 0; i < schema.GetPrivateSubnetsPerZone(); i++ {
+			computed = append(computed, infrav1.ComputedSubnet{AvailabilityZone: zone})
+			prefixLengths = append(prefixLengths, schema.PrivateSubnetPrefixLength)
+		}
+	}
+
+	subnetCIDRs, err := cidr.AllocateIPv4(s.scope.VPC().CidrBlock, prefixLengths)
+	if err != nil {
+		return errors.Wrapf(err, "failed computing the subnets of the subnet schema in VPC CIDR %q", s.scope.VPC().CidrBlock)
+	}
+	for i := range computed {
+		computed[i].CidrBlock = subnetCIDRs[i].String()
+	}
+
+	s.scope.Network().ComputedSubnets = computed
+	s.scope.Debug("computed subnets from the subnet schema", "subnets", computed)
+	return nil
+}
+
+// getComputedSubnets returns the subnets computed from the subnet schema of the VPC.
+func (s *Service) getComputedSubnets() (infrav1.Subnets, error) {
+	computed := s.scope.Network().ComputedSubnets
+
+	var ipv6SubnetCIDRs []*net.IPNet
+	if s.scope.VPC().IsIPv6Enabled() {
+		var err error
+		ipv6SubnetCIDRs, err = cidr.SplitIntoSubnetsIPv6(s.scope.VPC().IPv6.CidrBlock, len(computed))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed splitting IPv6 VPC CIDR %q into subnets", s.scope.VPC().IPv6.CidrBlock)
+		}
+	}
+
+	subnets := make(infrav1.Subnets, 0, len(computed))
+	for i, c := range computed {
+		subnet := infrav1.SubnetSpec{
+			CidrBlock:        c.CidrBlock,
+			AvailabilityZone: c.AvailabilityZone,
+			IsPublic:         c.IsPublic,
+		}
+		if ipv6SubnetCIDRs != nil {
+			subnet.IPv6CidrBlock = ipv6SubnetCIDRs[i].String()
+			subnet.IsIPv6 = true
+		}
+		subnets = append(subnets, subnet)
+	}
+	return subnets, nil
+}
+
 func (s *Service) deleteSubnets() error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping subnets deletion in unmanaged mode")
@@ -318,6 +436,7 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 		spec := infrav1.SubnetSpec{
 			ID:               *ec2sn.SubnetId,
 			AvailabilityZone: *ec2sn.AvailabilityZone,
+			OutpostARN:       aws.StringValue(ec2sn.OutpostArn),
 			Tags:             converters.TagsToMap(ec2sn.Tags),
 		}
 		// For IPv6 subnets, both, ipv4 and 6 have to be defined so pods can have ipv6 cidr ranges.
@@ -387,7 +506,7 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		TagSpecifications: []*ec2.TagSpecification{
 			tags.BuildParamsToTagSpecification(
 				ec2.ResourceTypeSubnet,
-				s.getSubnetTagParams(false, services.TemporaryResourceID, sn.IsPublic, sn.AvailabilityZone, sn.Tags),
+				s.getSubnetTagParams(false, services.TemporaryResourceID, sn.IsPublic, sn.AvailabilityZone, sn.Tags, nil),
 			),
 		},
 	}
@@ -395,6 +514,9 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		input.Ipv6CidrBlock = aws.String(sn.IPv6CidrBlock)
 		sn.IsIPv6 = true
 	}
+	if sn.OutpostARN != "" {
+		input.OutpostArn = aws.String(sn.OutpostARN)
+	}
 	out, err := s.EC2Client.CreateSubnet(input)
 	if err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedCreateSubnet", "Failed creating new managed Subnet %v", err)
@@ -452,6 +574,7 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 	subnet := &infrav1.SubnetSpec{
 		ID:               *out.Subnet.SubnetId,
 		AvailabilityZone: *out.Subnet.AvailabilityZone,
+		OutpostARN:       aws.StringValue(out.Subnet.OutpostArn),
 		CidrBlock:        *out.Subnet.CidrBlock, // TODO: this will panic in case of IPv6 only subnets...
 		IsPublic:         sn.IsPublic,
 	}
@@ -486,7 +609,10 @@ func (s *Service) deleteSubnet(id string) error {
 	return nil
 }
 
-func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool, zone string, manualTags infrav1.Tags) infrav1.BuildParams {
+// getSubnetTagParams returns the tags of a subnet. Both managed and unmanaged subnets get the role tag used
+// by load balancer controllers to discover them and the tags of the subnet spec. Role tags which are already
+// set on the subnet keep their value, as controllers accept any value.
+func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool, zone string, manualTags, currentTags infrav1.Tags) infrav1.BuildParams {
 	var role string
 	additionalTags := make(map[string]string)
 
@@ -494,21 +620,34 @@ func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool,
 		additionalTags = s.scope.AdditionalTags()
 	}
 
+	roleTag := internalLoadBalancerTag
+	role = infrav1.PrivateRoleTagValue
 	if public {
+		roleTag = externalLoadBalancerTag
 		role = infrav1.PublicRoleTagValue
-		additionalTags[externalLoadBalancerTag] = "1"
-	} else {
-		role = infrav1.PrivateRoleTagValue
-		additionalTags[internalLoadBalancerTag] = "1"
+	}
+	additionalTags[roleTag] = "1"
+	if value, ok := currentTags[roleTag]; ok {
+		additionalTags[roleTag] = value
 	}
 
 	// Add tag needed for Service type=LoadBalancer
 	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName())] = string(infrav1.ResourceLifecycleShared)
 
-	if !unmanagedVPC {
-		for k, v := range manualTags {
-			additionalTags[k] = v
+	// Secondary subnets are discovered by the VPC CNI for the IPs of the pods.
+	if manualTags[infrav1.NameAWSSubnetAssociation] == infrav1.SecondarySubnetTagValue {
+		additionalTags[cniSubnetTag] = "1"
+	}
+
+	for k, v := range manualTags {
+		// Tags with the aws: prefix are reserved, and can't be set on resources.
+		if strings.HasPrefix(k, awsReservedTagPrefix) {
+			continue
 		}
+		additionalTags[k] = v
+	}
+
+	if !unmanagedVPC {
 
 		// Prefer `Name` tag if given, else generate a name
 		var name strings.Builder
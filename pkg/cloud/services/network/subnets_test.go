@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -306,7 +307,7 @@ func TestReconcileSubnets(t *testing.T) {
 				Subnets: []infrav1.SubnetSpec{
 					{
 						ID:   "subnet-1",
-						Tags: map[string]string{"foo": "bar"}, // adding additional tag here which won't be added in unmanaged subnet hence not present in expect calls
+						Tags: map[string]string{"foo": "bar"},
 					},
 					{
 						ID: "subnet-2",
@@ -366,6 +367,10 @@ func TestReconcileSubnets(t *testing.T) {
 				m.CreateTags(gomock.Eq(&ec2.CreateTagsInput{
 					Resources: aws.StringSlice([]string{"subnet-1"}),
 					Tags: []*ec2.Tag{
+						{
+							Key:   aws.String("foo"),
+							Value: aws.String("bar"),
+						},
 						{
 							Key:   aws.String("kubernetes.io/cluster/test-cluster"),
 							Value: aws.String("shared"),
@@ -491,6 +496,97 @@ func TestReconcileSubnets(t *testing.T) {
 					},
 				})).
 					Return(&ec2.CreateTagsOutput{}, fmt.Errorf("tagging failed"))
+
+				m.CreateTags(gomock.Eq(&ec2.CreateTagsInput{
+					Resources: aws.StringSlice([]string{"subnet-2"}),
+					Tags: []*ec2.Tag{
+						{
+							Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+							Value: aws.String("shared"),
+						},
+						{
+							Key:   aws.String("kubernetes.io/role/internal-elb"),
+							Value: aws.String("1"),
+						},
+					},
+				})).
+					Return(&ec2.CreateTagsOutput{}, fmt.Errorf("tagging failed"))
+			},
+		},
+		{
+			name: "Unmanaged VPC, existing subnet with custom tags in spec, should add custom tags and keep existing role tag",
+			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+				},
+				Subnets: []infrav1.SubnetSpec{
+					{
+						ID: "subnet-1",
+						Tags: infrav1.Tags{
+							"team":                          "platform",
+							"aws:cloudformation:stack-name": "network",
+						},
+					},
+				},
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.Eq(&ec2.DescribeSubnetsInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("state"),
+							Values: []*string{aws.String("pending"), aws.String("available")},
+						},
+						{
+							Name:   aws.String("vpc-id"),
+							Values: []*string{aws.String(subnetsVPCID)},
+						},
+					},
+				})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String("subnet-1"),
+								AvailabilityZone:    aws.String("us-east-1a"),
+								CidrBlock:           aws.String("10.0.10.0/24"),
+								MapPublicIpOnLaunch: aws.Bool(false),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("aws:cloudformation:stack-name"),
+										Value: aws.String("network"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/role/internal-elb"),
+										Value: aws.String(""),
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPages(gomock.Any(), gomock.Any()).Return(nil)
+
+				m.CreateTags(gomock.Eq(&ec2.CreateTagsInput{
+					Resources: aws.StringSlice([]string{"subnet-1"}),
+					Tags: []*ec2.Tag{
+						{
+							Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+							Value: aws.String("shared"),
+						},
+						{
+							Key:   aws.String("kubernetes.io/role/internal-elb"),
+							Value: aws.String(""),
+						},
+						{
+							Key:   aws.String("team"),
+							Value: aws.String("platform"),
+						},
+					},
+				})).
+					Return(&ec2.CreateTagsOutput{}, nil)
 			},
 		},
 		{
@@ -2252,6 +2348,121 @@ func TestReconcileSubnets(t *testing.T) {
 	}
 }
 
+func TestSelectZones(t *testing.T) {
+	zones := []string{"us-east-1a", "us-east-1b", "us-east-1c", "us-east-1d"}
+	testCases := []struct {
+		name          string
+		vpc           infrav1.VPCSpec
+		expected      []string
+		errorExpected bool
+	}{
+		{
+			name:     "ordered zones up to the usage limit",
+			vpc:      infrav1.VPCSpec{AvailabilityZoneUsageLimit: aws.Int(2), AvailabilityZoneSelection: &infrav1.AZSelectionSchemeOrdered},
+			expected: []string{"us-east-1a", "us-east-1b"},
+		},
+		{
+			name:     "all zones below the usage limit",
+			vpc:      infrav1.VPCSpec{AvailabilityZoneUsageLimit: aws.Int(5)},
+			expected: zones,
+		},
+		{
+			name: "explicit zones in the given order",
+			vpc: infrav1.VPCSpec{
+				AvailabilityZoneUsageLimit: aws.Int(3),
+				AvailabilityZoneSelection:  &infrav1.AZSelectionSchemeExplicit,
+				AvailabilityZones:          []string{"us-east-1d", "us-east-1b"},
+			},
+			expected: []string{"us-east-1d", "us-east-1b"},
+		},
+		{
+			name: "explicit zone not available",
+			vpc: infrav1.VPCSpec{
+				AvailabilityZoneSelection: &infrav1.AZSelectionSchemeExplicit,
+				AvailabilityZones:         []string{"us-east-1e"},
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scope, err := NewClusterScope().WithNetwork(&infrav1.NetworkSpec{VPC: tc.vpc}).Build()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(scope)
+			selected, err := s.selectZones(append([]string{}, zones...))
+			if tc.errorExpected {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(selected).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestGetDefaultSubnetsWithSubnetSchema(t *testing.T) {
+	schema := &infrav1.SubnetSchema{
+		PublicSubnetPrefixLength:  24,
+		PrivateSubnetPrefixLength: 20,
+		PrivateSubnetsPerZone:     2,
+	}
+
+	t.Run("computes the subnets from the VPC CIDR block", func(t *testing.T) {
+		g := NewWithT(t)
+		scope, err := NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+			VPC: infrav1.VPCSpec{CidrBlock: "10.0.0.0/16", SubnetSchema: schema},
+		}).Build()
+		g.Expect(err).NotTo(HaveOccurred())
+
+		s := NewService(scope)
+		g.Expect(s.computeSubnets([]string{"us-east-1b", "us-east-1a"})).To(Succeed())
+		g.Expect(scope.Network().ComputedSubnets).To(Equal([]infrav1.ComputedSubnet{
+			{AvailabilityZone: "us-east-1a", CidrBlock: "10.0.64.0/24", IsPublic: true},
+			{AvailabilityZone: "us-east-1b", CidrBlock: "10.0.65.0/24", IsPublic: true},
+			{AvailabilityZone: "us-east-1a", CidrBlock: "10.0.0.0/20"},
+			{AvailabilityZone: "us-east-1a", CidrBlock: "10.0.16.0/20"},
+			{AvailabilityZone: "us-east-1b", CidrBlock: "10.0.32.0/20"},
+			{AvailabilityZone: "us-east-1b", CidrBlock: "10.0.48.0/20"},
+		}))
+	})
+
+	t.Run("fails when the subnets don't fit in the VPC CIDR block", func(t *testing.T) {
+		g := NewWithT(t)
+		scope, err := NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+			VPC: infrav1.VPCSpec{CidrBlock: "10.0.0.0/20", SubnetSchema: schema},
+		}).Build()
+		g.Expect(err).NotTo(HaveOccurred())
+
+		s := NewService(scope)
+		g.Expect(s.computeSubnets([]string{"us-east-1a"})).NotTo(Succeed())
+		g.Expect(scope.Network().ComputedSubnets).To(BeEmpty())
+	})
+
+	t.Run("reuses the subnets computed before", func(t *testing.T) {
+		g := NewWithT(t)
+		scope, err := NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+			VPC: infrav1.VPCSpec{CidrBlock: "10.0.0.0/16", SubnetSchema: schema},
+		}).Build()
+		g.Expect(err).NotTo(HaveOccurred())
+		scope.Network().ComputedSubnets = []infrav1.ComputedSubnet{
+			{AvailabilityZone: "us-east-1c", CidrBlock: "10.0.64.0/24", IsPublic: true},
+			{AvailabilityZone: "us-east-1c", CidrBlock: "10.0.0.0/20"},
+		}
+
+		// The availability zones of the region aren't described again.
+		s := NewService(scope)
+		subnets, err := s.getDefaultSubnets()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(subnets).To(Equal(infrav1.Subnets{
+			{AvailabilityZone: "us-east-1c", CidrBlock: "10.0.64.0/24", IsPublic: true},
+			{AvailabilityZone: "us-east-1c", CidrBlock: "10.0.0.0/20"},
+		}))
+	})
+}
+
 func TestDiscoverSubnets(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
@@ -100,6 +101,9 @@ func (s *Service) reconcileVPC() error {
 			return errors.Wrap(err, "failed to patch conditions")
 		}
 	}
+	if err := s.checkQuota(servicequotas.VPCsPerRegion, 1); err != nil {
+		return errors.Wrap(err, "failed to create new vpc")
+	}
 	vpc, err := s.createVPC()
 	if err != nil {
 		return errors.Wrap(err, "failed to create new vpc")
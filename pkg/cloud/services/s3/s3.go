@@ -24,7 +24,6 @@ import (
 	"path"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sts"
@@ -32,6 +31,7 @@ import (
 	"github.com/pkg/errors"
 
 	iam "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 )
 
@@ -83,6 +83,11 @@ func (s *Service) DeleteBucket() error {
 
 	log := s.scope.WithValues("name", bucketName)
 
+	if s.scope.ResourceRetentionPolicy().RetainsS3Bucket() {
+		log.Info("Retaining S3 Bucket as set by the resource retention policy")
+		return nil
+	}
+
 	log.Info("Deleting S3 Bucket")
 
 	_, err := s.S3Client.DeleteBucket(&s3.DeleteBucketInput{
@@ -92,18 +97,13 @@ func (s *Service) DeleteBucket() error {
 		return nil
 	}
 
-	aerr, ok := err.(awserr.Error)
-	if !ok {
-		return errors.Wrap(err, "deleting S3 bucket")
-	}
-
-	switch aerr.Code() {
-	case s3.ErrCodeNoSuchBucket:
+	switch {
+	case awserrors.IsNotFound(err):
 		log.Info("Bucket already removed")
-	case "BucketNotEmpty":
+	case awserrors.IsBucketNotEmpty(err):
 		log.Info("Bucket not empty, skipping removal")
 	default:
-		return errors.Wrap(aerr, "deleting S3 bucket")
+		return errors.Wrap(err, "deleting S3 bucket")
 	}
 
 	return nil
@@ -167,18 +167,10 @@ func (s *Service) Delete(m *scope.MachineScope) error {
 		return nil
 	}
 
-	aerr, ok := err.(awserr.Error)
-	if !ok {
-		return errors.Wrap(err, "deleting S3 object")
-	}
-
-	switch aerr.Code() {
-	case s3.ErrCodeNoSuchBucket:
-	default:
-		return errors.Wrap(aerr, "deleting S3 object")
+	if awserrors.IsNotFound(err) {
+		return nil
 	}
-
-	return nil
+	return errors.Wrap(err, "deleting S3 object")
 }
 
 func (s *Service) createBucketIfNotExist(bucketName string) error {
@@ -193,20 +185,13 @@ func (s *Service) createBucketIfNotExist(bucketName string) error {
 		return nil
 	}
 
-	aerr, ok := err.(awserr.Error)
-	if !ok {
-		return errors.Wrap(err, "creating S3 bucket")
-	}
-
-	switch aerr.Code() {
 	// If bucket already exists, all good.
 	//
 	// TODO: This will fail if bucket is shared with other cluster.
-	case s3.ErrCodeBucketAlreadyOwnedByYou:
+	if awserrors.IsBucketAlreadyOwnedByYou(err) {
 		return nil
-	default:
-		return errors.Wrap(aerr, "creating S3 bucket")
 	}
+	return errors.Wrap(err, "creating S3 bucket")
 }
 
 func (s *Service) ensureBucketPolicy(bucketName string) error {
@@ -236,16 +221,17 @@ func (s *Service) bucketPolicy(bucketName string) (string, error) {
 	}
 
 	bucket := s.scope.Bucket()
+	partition := s.scope.Partition()
 
 	statements := []iam.StatementEntry{
 		{
 			Sid:    "control-plane",
 			Effect: iam.EffectAllow,
 			Principal: map[iam.PrincipalType]iam.PrincipalID{
-				iam.PrincipalAWS: []string{fmt.Sprintf("arn:aws:iam::%s:role/%s", *accountID.Account, bucket.ControlPlaneIAMInstanceProfile)},
+				iam.PrincipalAWS: []string{fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, *accountID.Account, bucket.ControlPlaneIAMInstanceProfile)},
 			},
 			Action:   []string{"s3:GetObject"},
-			Resource: []string{fmt.Sprintf("arn:aws:s3:::%s/control-plane/*", bucketName)},
+			Resource: []string{fmt.Sprintf("arn:%s:s3:::%s/control-plane/*", partition, bucketName)},
 		},
 	}
 
@@ -254,10 +240,10 @@ func (s *Service) bucketPolicy(bucketName string) (string, error) {
 			Sid:    iamInstanceProfile,
 			Effect: iam.EffectAllow,
 			Principal: map[iam.PrincipalType]iam.PrincipalID{
-				iam.PrincipalAWS: []string{fmt.Sprintf("arn:aws:iam::%s:role/%s", *accountID.Account, iamInstanceProfile)},
+				iam.PrincipalAWS: []string{fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, *accountID.Account, iamInstanceProfile)},
 			},
 			Action:   []string{"s3:GetObject"},
-			Resource: []string{fmt.Sprintf("arn:aws:s3:::%s/node/*", bucketName)},
+			Resource: []string{fmt.Sprintf("arn:%s:s3:::%s/node/*", partition, bucketName)},
 		})
 	}
 
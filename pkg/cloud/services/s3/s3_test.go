@@ -179,6 +179,37 @@ func TestReconcileBucket(t *testing.T) {
 		}
 	})
 
+	t.Run("creates_bucket_with_policy_using_the_partition_of_the_cluster_region", func(t *testing.T) {
+		t.Parallel()
+
+		bucketName := "bar"
+
+		svc, s3Mock := testServiceWithSpec(t, infrav1.AWSClusterSpec{
+			Region: "us-gov-west-1",
+			S3Bucket: &infrav1.S3Bucket{
+				Name:                           bucketName,
+				ControlPlaneIAMInstanceProfile: fmt.Sprintf("control-plane%s", iamv1.DefaultNameSuffix),
+			},
+		})
+
+		s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Do(func(input *s3svc.PutBucketPolicyInput) {
+			policy := aws.StringValue(input.Policy)
+
+			if !strings.Contains(policy, fmt.Sprintf("arn:aws-us-gov:iam::foo:role/control-plane%s", iamv1.DefaultNameSuffix)) {
+				t.Errorf("Policy should reference the control-plane role in the aws-us-gov partition, got: %v", policy)
+			}
+
+			if !strings.Contains(policy, fmt.Sprintf("arn:aws-us-gov:s3:::%s/control-plane/*", bucketName)) {
+				t.Errorf("Policy should reference the bucket in the aws-us-gov partition, got: %v", policy)
+			}
+		}).Return(nil, nil).Times(1)
+
+		if err := svc.ReconcileBucket(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
 	t.Run("is_idempotent", func(t *testing.T) {
 		t.Parallel()
 
@@ -303,6 +334,19 @@ func TestDeleteBucket(t *testing.T) {
 		}
 	})
 
+	t.Run("retains_bucket_when_set_by_the_resource_retention_policy", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _ := testServiceWithSpec(t, infrav1.AWSClusterSpec{
+			S3Bucket:                &infrav1.S3Bucket{Name: bucketName},
+			ResourceRetentionPolicy: &infrav1.ResourceRetentionPolicy{S3Bucket: infrav1.RetentionPolicyRetain},
+		})
+
+		if err := svc.DeleteBucket(); err != nil {
+			t.Fatalf("Unexpected error, got: %v", err)
+		}
+	})
+
 	t.Run("returns_error_when_bucket_removal_returns", func(t *testing.T) {
 		t.Parallel()
 		t.Run("unexpected_error", func(t *testing.T) {
@@ -328,6 +372,18 @@ func TestDeleteBucket(t *testing.T) {
 				t.Fatalf("Expected error")
 			}
 		})
+
+		t.Run("conflict_other_than_bucket_not_empty", func(t *testing.T) {
+			t.Parallel()
+
+			svc, s3Mock := testService(t, &infrav1.S3Bucket{})
+
+			s3Mock.EXPECT().DeleteBucket(gomock.Any()).Return(nil, awserr.New(s3svc.ErrCodeBucketAlreadyOwnedByYou, "", nil)).Times(1)
+
+			if err := svc.DeleteBucket(); err == nil {
+				t.Fatalf("Expected error")
+			}
+		})
 	})
 
 	t.Run("ignores_when_bucket_has_already_been_removed", func(t *testing.T) {
@@ -725,6 +781,12 @@ func TestDeleteObject(t *testing.T) {
 func testService(t *testing.T, bucket *infrav1.S3Bucket) (*s3.Service, *mock_s3iface.MockS3API) {
 	t.Helper()
 
+	return testServiceWithSpec(t, infrav1.AWSClusterSpec{S3Bucket: bucket})
+}
+
+func testServiceWithSpec(t *testing.T, spec infrav1.AWSClusterSpec) (*s3.Service, *mock_s3iface.MockS3API) {
+	t.Helper()
+
 	mockCtrl := gomock.NewController(t)
 	s3Mock := mock_s3iface.NewMockS3API(mockCtrl)
 	stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
@@ -745,9 +807,7 @@ func testService(t *testing.T, bucket *infrav1.S3Bucket) (*s3.Service, *mock_s3i
 			},
 		},
 		AWSCluster: &infrav1.AWSCluster{
-			Spec: infrav1.AWSClusterSpec{
-				S3Bucket: bucket,
-			},
+			Spec: spec,
 		},
 	})
 	if err != nil {
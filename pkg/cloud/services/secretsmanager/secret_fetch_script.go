@@ -40,7 +40,15 @@ set -o pipefail
 
 umask 006
 
+# Use the proxy settings written by the proxy boothook of the instance, if any.
+if [ -f /etc/capa-proxy.env ]; then
+  set -o allexport
+  . /etc/capa-proxy.env
+  set +o allexport
+fi
+
 REGION="{{.Region}}"
+DNS_SUFFIX="{{.DNSSuffix}}"
 if [ "{{.Endpoint}}" != "" ]; then
   ENDPOINT="--endpoint-url {{.Endpoint}}"
 fi
@@ -115,6 +123,32 @@ check_aws_command() {
     ;;
   esac
 }
+
+# Read a path of the instance metadata service, using an IMDSv2 token.
+# Args:
+#   $1 The path to read below /latest/meta-data/
+imds::get() {
+  local token
+  token=$(curl -sSf -X PUT "http://169.254.169.254/latest/api/token" -H "X-aws-ec2-metadata-token-ttl-seconds: 60")
+  curl -sSf -H "X-aws-ec2-metadata-token: ${token}" "http://169.254.169.254/latest/meta-data/${1}"
+}
+
+# Fill in the region and the DNS suffix of its partition from the instance
+# metadata service when they were not rendered into the script, and point
+# the AWS CLI at the endpoint of the partition outside of the aws partition.
+discover_endpoint() {
+  if [ "${REGION}" = "" ]; then
+    REGION=$(imds::get placement/region) || log::error_exit "could not discover the region from the instance metadata service" 5
+    log::info "discovered region ${REGION} from the instance metadata service"
+  fi
+  if [ "${DNS_SUFFIX}" = "" ]; then
+    DNS_SUFFIX=$(imds::get services/domain) || log::error_exit "could not discover the DNS suffix from the instance metadata service" 5
+    log::info "discovered DNS suffix ${DNS_SUFFIX} from the instance metadata service"
+  fi
+  if [ "${ENDPOINT:-}" = "" ] && [ "${DNS_SUFFIX}" != "amazonaws.com" ]; then
+    ENDPOINT="--endpoint-url https://secretsmanager.${REGION}.${DNS_SUFFIX}"
+  fi
+}
 delete_secret_value() {
   local id="${SECRET_PREFIX}-${1}"
   local out
@@ -180,6 +214,8 @@ if test -f "${FILE}"; then
   log::success_exit
 fi
 
+discover_endpoint
+
 for i in $(seq 0 "${FINAL_INDEX}"); do
   get_secret_value "$i"
 done
@@ -185,6 +185,12 @@ func (s *Service) ReconcileSecurityGroups() error {
 
 			s.scope.Debug("Authorized ingress rules in security group", "authorized-ingress-rules", toAuthorize, "security-group-id", sg.ID)
 		}
+
+		if role == infrav1.SecurityGroupNode && len(s.scope.NodeEgressRules()) > 0 {
+			if err := s.reconcileSecurityGroupEgressRules(sg.ID, s.scope.NodeEgressRules()); err != nil {
+				return err
+			}
+		}
 	}
 	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.ClusterSecurityGroupsReadyCondition)
 	return nil
@@ -327,8 +333,12 @@ func (s *Service) describeClusterOwnedSecurityGroups() ([]infrav1.SecurityGroup,
 
 	err := s.EC2Client.DescribeSecurityGroupsPages(input, func(out *ec2.DescribeSecurityGroupsOutput, last bool) bool {
 		for _, group := range out.SecurityGroups {
-			if group != nil {
-				groups = append(groups, makeInfraSecurityGroup(group))
+			if group == nil {
+				continue
+			}
+			// The security group of the NAT instances is deleted by the network service, once the NAT instances are terminated.
+			if sg := makeInfraSecurityGroup(group); sg.Tags.GetRole() != infrav1.NATInstanceRoleTagValue {
+				groups = append(groups, sg)
 			}
 		}
 		return true
@@ -424,6 +434,93 @@ func (s *Service) revokeSecurityGroupIngressRules(id string, rules infrav1.Ingre
 	return nil
 }
 
+// reconcileSecurityGroupEgressRules replaces the egress rules of the security group, including the default
+// rule allowing all outbound traffic, with the given rules.
+func (s *Service) reconcileSecurityGroupEgressRules(id string, want infrav1.EgressRules) error {
+	current, err := s.describeSecurityGroupEgressRules(id)
+	if err != nil {
+		return err
+	}
+
+	toRevoke := current.Difference(want)
+	if len(toRevoke) > 0 {
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if err := s.revokeSecurityGroupEgressRules(id, toRevoke); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.GroupNotFound); err != nil {
+			return errors.Wrapf(err, "failed to revoke security group egress rules for %q", id)
+		}
+
+		s.scope.Debug("Revoked egress rules from security group", "revoked-egress-rules", toRevoke, "security-group-id", id)
+	}
+
+	toAuthorize := want.Difference(current)
+	if len(toAuthorize) > 0 {
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if err := s.authorizeSecurityGroupEgressRules(id, toAuthorize); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.GroupNotFound); err != nil {
+			return err
+		}
+
+		s.scope.Debug("Authorized egress rules in security group", "authorized-egress-rules", toAuthorize, "security-group-id", id)
+	}
+
+	return nil
+}
+
+func (s *Service) describeSecurityGroupEgressRules(id string) (infrav1.EgressRules, error) {
+	out, err := s.EC2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: []*string{aws.String(id)}})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe security group %q", id)
+	}
+
+	rules := infrav1.EgressRules{}
+	for _, sg := range out.SecurityGroups {
+		for _, permission := range sg.IpPermissionsEgress {
+			for _, rule := range ingressRulesFromSDKType(permission) {
+				rules = append(rules, egressRuleFromIngressRule(rule))
+			}
+		}
+	}
+	return rules, nil
+}
+
+func (s *Service) authorizeSecurityGroupEgressRules(id string, rules infrav1.EgressRules) error {
+	input := &ec2.AuthorizeSecurityGroupEgressInput{GroupId: aws.String(id)}
+	for i := range rules {
+		rule := ingressRuleFromEgressRule(rules[i])
+		input.IpPermissions = append(input.IpPermissions, ingressRuleToSDKType(s.scope, &rule))
+	}
+	if _, err := s.EC2Client.AuthorizeSecurityGroupEgress(input); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAuthorizeSecurityGroupEgressRules", "Failed to authorize security group egress rules %v for SecurityGroup %q: %v", rules, id, err)
+		return errors.Wrapf(err, "failed to authorize security group %q egress rules: %v", id, rules)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulAuthorizeSecurityGroupEgressRules", "Authorized security group egress rules %v for SecurityGroup %q", rules, id)
+	return nil
+}
+
+func (s *Service) revokeSecurityGroupEgressRules(id string, rules infrav1.EgressRules) error {
+	input := &ec2.RevokeSecurityGroupEgressInput{GroupId: aws.String(id)}
+	for i := range rules {
+		rule := ingressRuleFromEgressRule(rules[i])
+		input.IpPermissions = append(input.IpPermissions, ingressRuleToSDKType(s.scope, &rule))
+	}
+
+	if _, err := s.EC2Client.RevokeSecurityGroupEgress(input); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedRevokeSecurityGroupEgressRules", "Failed to revoke security group egress rules %v for SecurityGroup %q: %v", rules, id, err)
+		return errors.Wrapf(err, "failed to revoke security group %q egress rules: %v", id, rules)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulRevokeSecurityGroupEgressRules", "Revoked security group egress rules %v for SecurityGroup %q", rules, id)
+	return nil
+}
+
 func (s *Service) revokeAllSecurityGroupIngressRules(id string) error {
 	describeInput := &ec2.DescribeSecurityGroupsInput{GroupIds: []*string{aws.String(id)}}
 
@@ -463,9 +560,9 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 	// Set source of CNI ingress rules to be control plane and node security groups
 	s.scope.Debug("getting security group ingress rules", "role", role)
 
-	cniRules := make(infrav1.IngressRules, len(s.scope.CNIIngressRules()))
-	for i, r := range s.scope.CNIIngressRules() {
-		cniRules[i] = infrav1.IngressRule{
+	cniRules := make(infrav1.IngressRules, 0, len(s.scope.CNIIngressRules()))
+	for _, r := range s.scope.CNIIngressRules() {
+		cniRules = append(cniRules, infrav1.IngressRule{
 			Description: r.Description,
 			Protocol:    r.Protocol,
 			FromPort:    r.FromPort,
@@ -474,20 +571,46 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 				s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID,
 				s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
 			},
+		})
+		// Prefix lists are described by AWS as a separate permission, so they get their own rule
+		// to keep the comparison with the existing rules stable.
+		if len(r.SourcePrefixListIDs) > 0 {
+			cniRules = append(cniRules, infrav1.IngressRule{
+				Description:         r.Description,
+				Protocol:            r.Protocol,
+				FromPort:            r.FromPort,
+				ToPort:              r.ToPort,
+				SourcePrefixListIDs: r.SourcePrefixListIDs,
+			})
 		}
 	}
 	cidrBlocks := []string{services.AnyIPv4CidrBlock}
 	switch role {
 	case infrav1.SecurityGroupBastion:
-		return infrav1.IngressRules{
-			{
+		rules := infrav1.IngressRules{}
+		if s.scope.Bastion().IsInstanceConnectEndpoint() {
+			// EC2 Instance Connect Endpoints open connections to the instances but don't accept any.
+			return rules, nil
+		}
+		if len(s.scope.Bastion().AllowedCIDRBlocks) > 0 || len(s.scope.Bastion().AllowedPrefixListIDs) == 0 {
+			rules = append(rules, infrav1.IngressRule{
 				Description: "SSH",
 				Protocol:    infrav1.SecurityGroupProtocolTCP,
 				FromPort:    22,
 				ToPort:      22,
 				CidrBlocks:  s.scope.Bastion().AllowedCIDRBlocks,
-			},
-		}, nil
+			})
+		}
+		if len(s.scope.Bastion().AllowedPrefixListIDs) > 0 {
+			rules = append(rules, infrav1.IngressRule{
+				Description:         "SSH",
+				Protocol:            infrav1.SecurityGroupProtocolTCP,
+				FromPort:            22,
+				ToPort:              22,
+				SourcePrefixListIDs: s.scope.Bastion().AllowedPrefixListIDs,
+			})
+		}
+		return rules, nil
 	case infrav1.SecurityGroupControlPlane:
 		rules := infrav1.IngressRules{
 			{
@@ -516,9 +639,20 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 				SourceSecurityGroupIDs: []string{s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID},
 			},
 		}
+		for _, listener := range s.additionalListeners() {
+			rules = append(rules, infrav1.IngressRule{
+				Description:            fmt.Sprintf("Control plane load balancer listener %d", listener.Port),
+				Protocol:               infrav1.SecurityGroupProtocolTCP,
+				FromPort:               listener.Port,
+				ToPort:                 listener.Port,
+				SourceSecurityGroupIDs: []string{s.scope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB].ID},
+			})
+		}
 		if s.scope.Bastion().Enabled {
 			rules = append(rules, s.defaultSSHIngressRule(s.scope.SecurityGroups()[infrav1.SecurityGroupBastion].ID))
 		}
+		rules = append(rules, s.konnectivityControlPlaneIngressRules()...)
+		rules = append(rules, s.scope.AdditionalControlPlaneIngressRules()...)
 		return append(cniRules, rules...), nil
 
 	case infrav1.SecurityGroupNode:
@@ -554,6 +688,8 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 				IPv6CidrBlocks: []string{services.AnyIPv6CidrBlock},
 			})
 		}
+		rules = append(rules, s.konnectivityNodeIngressRules()...)
+		rules = append(rules, s.scope.AdditionalNodeIngressRules()...)
 		return append(cniRules, rules...), nil
 	case infrav1.SecurityGroupEKSNodeAdditional:
 		if s.scope.Bastion().Enabled {
@@ -581,6 +717,19 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 				IPv6CidrBlocks: []string{services.AnyIPv6CidrBlock},
 			})
 		}
+		for _, listener := range s.additionalListeners() {
+			rule := infrav1.IngressRule{
+				Description: fmt.Sprintf("Load balancer listener %d", listener.Port),
+				Protocol:    infrav1.SecurityGroupProtocolTCP,
+				FromPort:    listener.Port,
+				ToPort:      listener.Port,
+				CidrBlocks:  cidrBlocks,
+			}
+			if s.scope.VPC().IsIPv6Enabled() {
+				rule.IPv6CidrBlocks = []string{services.AnyIPv6CidrBlock}
+			}
+			rules = append(rules, rule)
+		}
 		return rules, nil
 	case infrav1.SecurityGroupLB:
 		// We hand this group off to the in-cluster cloud provider, so these rules aren't used
@@ -613,6 +762,16 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 					IPv6CidrBlocks: ipv6CidrBlocks,
 				},
 			}
+			for _, listener := range s.additionalListeners() {
+				rules = append(rules, infrav1.IngressRule{
+					Description:    fmt.Sprintf("Allow NLB traffic to the control plane instances on port %d.", listener.Port),
+					Protocol:       infrav1.SecurityGroupProtocolTCP,
+					FromPort:       listener.Port,
+					ToPort:         listener.Port,
+					CidrBlocks:     ipv4CidrBlocks,
+					IPv6CidrBlocks: ipv6CidrBlocks,
+				})
+			}
 			return rules, nil
 		}
 		return infrav1.IngressRules{}, nil
@@ -621,6 +780,67 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 	return nil, errors.Errorf("Cannot determine ingress rules for unknown security group role %q", role)
 }
 
+// konnectivityControlPlaneIngressRules returns the rules of the control plane security group allowing the
+// konnectivity agents of the nodes and control plane instances, and the API servers, to reach the
+// konnectivity servers.
+func (s *Service) konnectivityControlPlaneIngressRules() infrav1.IngressRules {
+	konnectivity := s.scope.Konnectivity()
+	if konnectivity == nil {
+		return nil
+	}
+	rules := infrav1.IngressRules{
+		{
+			Description: "Konnectivity agents",
+			Protocol:    infrav1.SecurityGroupProtocolTCP,
+			FromPort:    konnectivity.GetAgentPort(),
+			ToPort:      konnectivity.GetAgentPort(),
+			SourceSecurityGroupIDs: []string{
+				s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID,
+				s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
+			},
+		},
+	}
+	if konnectivity.ServerPort != 0 {
+		rules = append(rules, infrav1.IngressRule{
+			Description:            "Konnectivity server",
+			Protocol:               infrav1.SecurityGroupProtocolTCP,
+			FromPort:               konnectivity.ServerPort,
+			ToPort:                 konnectivity.ServerPort,
+			SourceSecurityGroupIDs: []string{s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID},
+		})
+	}
+	return rules
+}
+
+// konnectivityNodeIngressRules returns the rules of the node security group allowing the control plane
+// instances to reach the health and admin ports of the konnectivity agents.
+func (s *Service) konnectivityNodeIngressRules() infrav1.IngressRules {
+	konnectivity := s.scope.Konnectivity()
+	if konnectivity == nil {
+		return nil
+	}
+	rules := make(infrav1.IngressRules, 0, len(konnectivity.AgentHealthPorts))
+	for _, port := range konnectivity.AgentHealthPorts {
+		rules = append(rules, infrav1.IngressRule{
+			Description:            fmt.Sprintf("Konnectivity agent port %d", port),
+			Protocol:               infrav1.SecurityGroupProtocolTCP,
+			FromPort:               port,
+			ToPort:                 port,
+			SourceSecurityGroupIDs: []string{s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID},
+		})
+	}
+	return rules
+}
+
+// additionalListeners returns the additional listeners of the control plane load balancer, including the
+// listener forwarding the agent port of konnectivity.
+func (s *Service) additionalListeners() []infrav1.AdditionalListenerSpec {
+	if s.scope.ControlPlaneLoadBalancer() == nil {
+		return s.scope.Konnectivity().WithAgentListener(nil)
+	}
+	return s.scope.Konnectivity().WithAgentListener(s.scope.ControlPlaneLoadBalancer().AdditionalListeners)
+}
+
 func (s *Service) getSecurityGroupName(clusterName string, role infrav1.SecurityGroupRole) string {
 	groupPrefix := clusterName
 	if strings.HasPrefix(clusterName, "sg-") {
@@ -630,7 +850,7 @@ func (s *Service) getSecurityGroupName(clusterName string, role infrav1.Security
 }
 
 func (s *Service) getDefaultSecurityGroup(role infrav1.SecurityGroupRole) *ec2.SecurityGroup {
-	name := s.getSecurityGroupName(s.scope.Name(), role)
+	name := s.getSecurityGroupName(s.scope.ResourceNaming().BaseNameOrDefault(s.scope.Name()), role)
 
 	return &ec2.SecurityGroup{
 		GroupName: aws.String(name),
@@ -718,6 +938,18 @@ func ingressRuleToSDKType(scope scope.SGScope, i *infrav1.IngressRule) (res *ec2
 		res.UserIdGroupPairs = append(res.UserIdGroupPairs, userIDGroupPair)
 	}
 
+	for _, prefixListID := range i.SourcePrefixListIDs {
+		prefixListID := &ec2.PrefixListId{
+			PrefixListId: aws.String(prefixListID),
+		}
+
+		if i.Description != "" {
+			prefixListID.Description = aws.String(i.Description)
+		}
+
+		res.PrefixListIds = append(res.PrefixListIds, prefixListID)
+	}
+
 	return res
 }
 
@@ -783,5 +1015,48 @@ func ingressRulesFromSDKType(v *ec2.IpPermission) (res infrav1.IngressRules) {
 		res = append(res, r2)
 	}
 
+	if len(v.PrefixListIds) > 0 {
+		r3 := ir
+		for _, prefixList := range v.PrefixListIds {
+			if prefixList.PrefixListId == nil {
+				continue
+			}
+
+			if prefixList.Description != nil && *prefixList.Description != "" {
+				r3.Description = *prefixList.Description
+			}
+
+			r3.SourcePrefixListIDs = append(r3.SourcePrefixListIDs, *prefixList.PrefixListId)
+		}
+		res = append(res, r3)
+	}
+
 	return res
 }
+
+// EC2 describes egress permissions like ingress permissions, with the destinations in place of the sources.
+func ingressRuleFromEgressRule(e infrav1.EgressRule) infrav1.IngressRule {
+	return infrav1.IngressRule{
+		Description:            e.Description,
+		Protocol:               e.Protocol,
+		FromPort:               e.FromPort,
+		ToPort:                 e.ToPort,
+		CidrBlocks:             e.CidrBlocks,
+		IPv6CidrBlocks:         e.IPv6CidrBlocks,
+		SourceSecurityGroupIDs: e.DestinationSecurityGroupIDs,
+		SourcePrefixListIDs:    e.DestinationPrefixListIDs,
+	}
+}
+
+func egressRuleFromIngressRule(i infrav1.IngressRule) infrav1.EgressRule {
+	return infrav1.EgressRule{
+		Description:                 i.Description,
+		Protocol:                    i.Protocol,
+		FromPort:                    i.FromPort,
+		ToPort:                      i.ToPort,
+		CidrBlocks:                  i.CidrBlocks,
+		IPv6CidrBlocks:              i.IPv6CidrBlocks,
+		DestinationSecurityGroupIDs: i.SourceSecurityGroupIDs,
+		DestinationPrefixListIDs:    i.SourcePrefixListIDs,
+	}
+}
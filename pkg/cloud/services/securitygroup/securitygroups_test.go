@@ -612,6 +612,392 @@ func TestControlPlaneSecurityGroupNotOpenToAnyCIDR(t *testing.T) {
 	}
 }
 
+func TestDefaultSecurityGroupName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		naming   *infrav1.ResourceNaming
+		expected string
+	}{
+		{
+			name:     "named after the cluster by default",
+			expected: "test-cluster-node",
+		},
+		{
+			name:     "named after the base name when set",
+			naming:   &infrav1.ResourceNaming{BaseName: "shared"},
+			expected: "shared-node",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{ResourceNaming: tc.naming},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(cs, testSecurityGroupRoles)
+			sg := s.getDefaultSecurityGroup(infrav1.SecurityGroupNode)
+			g.Expect(aws.StringValue(sg.GroupName)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestSecurityGroupIngressRulesWithPrefixLists(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				Bastion: infrav1.Bastion{
+					AllowedPrefixListIDs: []string{"pl-bastion"},
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					CNI: &infrav1.CNISpec{
+						CNIIngressRules: infrav1.CNIIngressRules{
+							{
+								Description:         "bgp (calico)",
+								Protocol:            infrav1.SecurityGroupProtocolTCP,
+								FromPort:            179,
+								ToPort:              179,
+								SourcePrefixListIDs: []string{"pl-cni"},
+							},
+						},
+					},
+					AdditionalControlPlaneIngressRules: infrav1.IngressRules{
+						{
+							Description:         "Kubernetes API (corporate network)",
+							Protocol:            infrav1.SecurityGroupProtocolTCP,
+							FromPort:            6443,
+							ToPort:              6443,
+							SourcePrefixListIDs: []string{"pl-corp"},
+						},
+					},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(cs, testSecurityGroupRoles)
+
+	rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupBastion)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(Equal(infrav1.IngressRules{
+		{
+			Description:         "SSH",
+			Protocol:            infrav1.SecurityGroupProtocolTCP,
+			FromPort:            22,
+			ToPort:              22,
+			SourcePrefixListIDs: []string{"pl-bastion"},
+		},
+	}))
+
+	rules, err = s.getSecurityGroupIngressRules(infrav1.SecurityGroupNode)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(ContainElement(infrav1.IngressRule{
+		Description:         "bgp (calico)",
+		Protocol:            infrav1.SecurityGroupProtocolTCP,
+		FromPort:            179,
+		ToPort:              179,
+		SourcePrefixListIDs: []string{"pl-cni"},
+	}))
+
+	// Rules are split per source the same way AWS describes them, so that reconciling is idempotent.
+	for _, r := range rules {
+		g.Expect(ingressRulesFromSDKType(ingressRuleToSDKType(cs, &r))).To(Equal(infrav1.IngressRules{r}))
+	}
+
+	rules, err = s.getSecurityGroupIngressRules(infrav1.SecurityGroupControlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(ContainElement(infrav1.IngressRule{
+		Description:         "Kubernetes API (corporate network)",
+		Protocol:            infrav1.SecurityGroupProtocolTCP,
+		FromPort:            6443,
+		ToPort:              6443,
+		SourcePrefixListIDs: []string{"pl-corp"},
+	}))
+}
+
+func TestInstanceConnectEndpointSecurityGroupRules(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				Bastion: infrav1.Bastion{
+					Enabled:           true,
+					Type:              infrav1.BastionTypeInstanceConnectEndpoint,
+					AllowedCIDRBlocks: []string{"0.0.0.0/0"},
+				},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: infrav1.NetworkStatus{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+						infrav1.SecurityGroupBastion: {ID: "sg-bastion"},
+					},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(cs, testSecurityGroupRoles)
+
+	rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupBastion)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(BeEmpty())
+
+	rules, err = s.getSecurityGroupIngressRules(infrav1.SecurityGroupNode)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(ContainElement(s.defaultSSHIngressRule("sg-bastion")))
+}
+
+func TestAdditionalListenerSecurityGroupRules(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType:    infrav1.LoadBalancerTypeNLB,
+					AdditionalListeners: []infrav1.AdditionalListenerSpec{{Port: 8132}},
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{CidrBlock: "10.0.0.0/16"},
+				},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: infrav1.NetworkStatus{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+						infrav1.SecurityGroupAPIServerLB: {ID: "sg-apiserver-lb"},
+					},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(cs, testSecurityGroupRoles)
+
+	rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupControlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(ContainElement(infrav1.IngressRule{
+		Description:            "Control plane load balancer listener 8132",
+		Protocol:               infrav1.SecurityGroupProtocolTCP,
+		FromPort:               8132,
+		ToPort:                 8132,
+		SourceSecurityGroupIDs: []string{"sg-apiserver-lb"},
+	}))
+
+	rules, err = s.getSecurityGroupIngressRules(infrav1.SecurityGroupAPIServerLB)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(ContainElement(infrav1.IngressRule{
+		Description: "Load balancer listener 8132",
+		Protocol:    infrav1.SecurityGroupProtocolTCP,
+		FromPort:    8132,
+		ToPort:      8132,
+		CidrBlocks:  []string{services.AnyIPv4CidrBlock},
+	}))
+
+	rules, err = s.getSecurityGroupIngressRules(infrav1.SecurityGroupLB)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(ContainElement(infrav1.IngressRule{
+		Description: "Allow NLB traffic to the control plane instances on port 8132.",
+		Protocol:    infrav1.SecurityGroupProtocolTCP,
+		FromPort:    8132,
+		ToPort:      8132,
+		CidrBlocks:  []string{"10.0.0.0/16"},
+	}))
+}
+
+func TestKonnectivitySecurityGroupRules(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				},
+				Konnectivity: &infrav1.KonnectivitySpec{
+					ServerPort:       8131,
+					AgentHealthPorts: []int64{8093},
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{CidrBlock: "10.0.0.0/16"},
+				},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: infrav1.NetworkStatus{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+						infrav1.SecurityGroupControlPlane: {ID: "sg-control-plane"},
+						infrav1.SecurityGroupNode:         {ID: "sg-node"},
+					},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(cs, testSecurityGroupRoles)
+
+	rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupControlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(ContainElements(
+		infrav1.IngressRule{
+			Description:            "Konnectivity agents",
+			Protocol:               infrav1.SecurityGroupProtocolTCP,
+			FromPort:               8132,
+			ToPort:                 8132,
+			SourceSecurityGroupIDs: []string{"sg-control-plane", "sg-node"},
+		},
+		infrav1.IngressRule{
+			Description:            "Konnectivity server",
+			Protocol:               infrav1.SecurityGroupProtocolTCP,
+			FromPort:               8131,
+			ToPort:                 8131,
+			SourceSecurityGroupIDs: []string{"sg-control-plane"},
+		},
+	))
+
+	rules, err = s.getSecurityGroupIngressRules(infrav1.SecurityGroupNode)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(ContainElement(infrav1.IngressRule{
+		Description:            "Konnectivity agent port 8093",
+		Protocol:               infrav1.SecurityGroupProtocolTCP,
+		FromPort:               8093,
+		ToPort:                 8093,
+		SourceSecurityGroupIDs: []string{"sg-control-plane"},
+	}))
+
+	rules, err = s.getSecurityGroupIngressRules(infrav1.SecurityGroupLB)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(ContainElement(infrav1.IngressRule{
+		Description: "Allow NLB traffic to the control plane instances on port 8132.",
+		Protocol:    infrav1.SecurityGroupProtocolTCP,
+		FromPort:    8132,
+		ToPort:      8132,
+		CidrBlocks:  []string{"10.0.0.0/16"},
+	}))
+}
+
+func TestNodeSecurityGroupRules(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					AdditionalNodeIngressRules: infrav1.IngressRules{
+						{
+							Description:         "Monitoring",
+							Protocol:            infrav1.SecurityGroupProtocolTCP,
+							FromPort:            9100,
+							ToPort:              9100,
+							SourcePrefixListIDs: []string{"pl-monitoring"},
+						},
+					},
+					NodeEgressRules: infrav1.EgressRules{
+						{
+							Description: "HTTPS",
+							Protocol:    infrav1.SecurityGroupProtocolTCP,
+							FromPort:    443,
+							ToPort:      443,
+							CidrBlocks:  []string{"10.0.0.0/8"},
+						},
+					},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(cs, testSecurityGroupRoles)
+
+	rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupNode)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(ContainElement(cs.AdditionalNodeIngressRules()[0]))
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().DescribeSecurityGroups(gomock.Eq(&ec2.DescribeSecurityGroupsInput{GroupIds: aws.StringSlice([]string{"sg-node"})})).
+		Return(&ec2.DescribeSecurityGroupsOutput{
+			SecurityGroups: []*ec2.SecurityGroup{
+				{
+					GroupId: aws.String("sg-node"),
+					IpPermissionsEgress: []*ec2.IpPermission{
+						{
+							IpProtocol: aws.String("-1"),
+							IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(services.AnyIPv4CidrBlock)}},
+						},
+						{
+							IpProtocol: aws.String("tcp"),
+							FromPort:   aws.Int64(443),
+							ToPort:     aws.Int64(443),
+							IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8"), Description: aws.String("HTTPS")}},
+						},
+					},
+				},
+			},
+		}, nil)
+	ec2Mock.EXPECT().RevokeSecurityGroupEgress(gomock.Eq(&ec2.RevokeSecurityGroupEgressInput{
+		GroupId: aws.String("sg-node"),
+		IpPermissions: []*ec2.IpPermission{
+			{
+				IpProtocol: aws.String("-1"),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(services.AnyIPv4CidrBlock)}},
+			},
+		},
+	})).Return(&ec2.RevokeSecurityGroupEgressOutput{}, nil)
+	s.EC2Client = ec2Mock
+
+	// The rule that is already authorized is left untouched, the default rule allowing all outbound traffic is revoked.
+	g.Expect(s.reconcileSecurityGroupEgressRules("sg-node", cs.NodeEgressRules())).To(Succeed())
+}
+
 func TestDeleteSecurityGroups(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -871,6 +1257,47 @@ func TestIngressRulesFromSDKType(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Mix of group pairs and prefix lists",
+			input: &ec2.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(179),
+				ToPort:     aws.Int64(179),
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{
+					{
+						UserId:      aws.String("aws-user-id-1"),
+						GroupId:     aws.String("sg-source-1"),
+						Description: aws.String("bgp (calico)"),
+					},
+				},
+				PrefixListIds: []*ec2.PrefixListId{
+					{
+						PrefixListId: aws.String("pl-1"),
+						Description:  aws.String("bgp (calico)"),
+					},
+					{
+						PrefixListId: aws.String("pl-2"),
+						Description:  aws.String("bgp (calico)"),
+					},
+				},
+			},
+			expected: infrav1.IngressRules{
+				{
+					Description:            "bgp (calico)",
+					Protocol:               "tcp",
+					FromPort:               179,
+					ToPort:                 179,
+					SourceSecurityGroupIDs: []string{"sg-source-1"},
+				},
+				{
+					Description:         "bgp (calico)",
+					Protocol:            "tcp",
+					FromPort:            179,
+					ToPort:              179,
+					SourcePrefixListIDs: []string{"pl-1", "pl-2"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,427 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicequotas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+)
+
+// Quota describes a regional AWS service quota consumed by CAPA managed infrastructure.
+type Quota struct {
+	// Name is the human readable name of the quota, as shown in the Service Quotas console.
+	Name string `json:"name"`
+	// ServiceCode is the Service Quotas code of the AWS service owning the quota.
+	ServiceCode string `json:"serviceCode"`
+	// QuotaCode is the Service Quotas code of the quota.
+	QuotaCode string `json:"quotaCode"`
+	// AccountAttribute is the EC2 account attribute holding the limit. When set it is
+	// preferred over Service Quotas as it doesn't require additional permissions.
+	AccountAttribute string `json:"accountAttribute,omitempty"`
+	// PerAvailabilityZone is true when the quota applies to each availability zone
+	// of the region rather than to the region as a whole.
+	PerAvailabilityZone bool `json:"perAvailabilityZone,omitempty"`
+
+	// usage returns the resources consuming the quota. zone is only set for
+	// quotas that apply per availability zone.
+	usage func(s *Service, zone string) (int, error)
+	// reuseUsage is true for quotas whose usage is counted by describing every resource of the region,
+	// which is reused for UsageTTL by the services with a usage cache.
+	reuseUsage bool
+}
+
+var (
+	// VPCsPerRegion is the quota for the number of VPCs per region.
+	VPCsPerRegion = Quota{
+		Name:        "VPCs per Region",
+		ServiceCode: "vpc",
+		QuotaCode:   "L-F678F1CE",
+		usage:       (*Service).countVPCs,
+	}
+
+	// InternetGatewaysPerRegion is the quota for the number of internet gateways per region.
+	InternetGatewaysPerRegion = Quota{
+		Name:        "Internet gateways per Region",
+		ServiceCode: "vpc",
+		QuotaCode:   "L-A4707A72",
+		usage:       (*Service).countInternetGateways,
+	}
+
+	// ElasticIPsPerRegion is the quota for the number of VPC Elastic IP addresses per region.
+	ElasticIPsPerRegion = Quota{
+		Name:             "EC2-VPC Elastic IPs",
+		ServiceCode:      "ec2",
+		QuotaCode:        "L-0263D0A3",
+		AccountAttribute: "vpc-max-elastic-ips",
+		usage:            (*Service).countElasticIPs,
+	}
+
+	// NATGatewaysPerAvailabilityZone is the quota for the number of NAT gateways per availability zone.
+	NATGatewaysPerAvailabilityZone = Quota{
+		Name:                "NAT gateways per Availability Zone",
+		ServiceCode:         "vpc",
+		QuotaCode:           "L-FE5A380F",
+		PerAvailabilityZone: true,
+		usage:               (*Service).countNATGateways,
+	}
+
+	// SecurityGroupsPerNetworkInterface is the quota for the number of security groups of a network
+	// interface. It limits each network interface rather than the resources of the region, so it has
+	// no usage and only its limit can be looked up, see GetLimit.
+	SecurityGroupsPerNetworkInterface = Quota{
+		Name:        "Security groups per network interface",
+		ServiceCode: "vpc",
+		QuotaCode:   "L-2AFB9258",
+	}
+
+	// OnDemandStandardInstanceVCPUs is the quota for the number of vCPUs of running On-Demand
+	// instances of the standard instance families, see IsStandardInstanceType.
+	OnDemandStandardInstanceVCPUs = Quota{
+		Name:        "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
+		ServiceCode: "ec2",
+		QuotaCode:   "L-1216C47A",
+		usage:       (*Service).countOnDemandStandardVCPUs,
+		reuseUsage:  true,
+	}
+)
+
+// standardInstanceFamilies are the instance family prefixes counted by OnDemandStandardInstanceVCPUs.
+var standardInstanceFamilies = map[string]bool{
+	"a": true, "c": true, "d": true, "h": true, "i": true, "im": true, "is": true, "m": true, "r": true, "t": true, "z": true,
+}
+
+// Quotas lists all quotas verified by the pre-flight checks.
+var Quotas = []Quota{
+	VPCsPerRegion,
+	InternetGatewaysPerRegion,
+	ElasticIPsPerRegion,
+	NATGatewaysPerAvailabilityZone,
+	OnDemandStandardInstanceVCPUs,
+}
+
+// Usage reports the usage and limit of a quota in the current region.
+type Usage struct {
+	Quota Quota `json:"quota"`
+	// Zone is the availability zone the usage applies to, for quotas that apply per availability zone.
+	Zone  string `json:"zone,omitempty"`
+	Used  int    `json:"used"`
+	Limit int    `json:"limit"`
+}
+
+// Available returns the number of resources that can still be created before the quota is reached.
+func (u *Usage) Available() int {
+	if u.Used >= u.Limit {
+		return 0
+	}
+	return u.Limit - u.Used
+}
+
+// QuotaExceededError is returned when creating resources would exceed a service quota.
+type QuotaExceededError struct {
+	Usage
+	Requested int
+}
+
+// Error implements the Error interface.
+func (e *QuotaExceededError) Error() string {
+	if e.Zone != "" {
+		return fmt.Sprintf("service quota %q (%s/%s) exceeded in %s: %d of %d in use, %d more requested",
+			e.Quota.Name, e.Quota.ServiceCode, e.Quota.QuotaCode, e.Zone, e.Used, e.Limit, e.Requested)
+	}
+	return fmt.Sprintf("service quota %q (%s/%s) exceeded: %d of %d in use, %d more requested",
+		e.Quota.Name, e.Quota.ServiceCode, e.Quota.QuotaCode, e.Used, e.Limit, e.Requested)
+}
+
+// Kind classifies the error as an exceeded service quota, for awserrors.IsQuotaExceeded.
+func (e *QuotaExceededError) Kind() awserrors.Kind {
+	return awserrors.KindQuotaExceeded
+}
+
+// Check verifies that the requested number of resources can be created without
+// exceeding the given quota, returning a *QuotaExceededError if they cannot.
+func (s *Service) Check(quota Quota, requested int) error {
+	return s.CheckInZone(quota, "", requested)
+}
+
+// CheckInZone is like Check, for quotas that apply per availability zone.
+func (s *Service) CheckInZone(quota Quota, zone string, requested int) error {
+	usage, err := s.GetUsageInZone(quota, zone)
+	if err != nil {
+		return err
+	}
+
+	if usage.Available() < requested {
+		return &QuotaExceededError{Usage: *usage, Requested: requested}
+	}
+	return nil
+}
+
+// Reserve is like Check, for resources that are about to be created. For the quotas whose usage is reused,
+// such as OnDemandStandardInstanceVCPUs, the requested resources are counted by the checks of the services
+// of the same AWS principal and region until the returned reservation is committed, once they were created,
+// or released. The checks of such a quota run one at a time. The reservation is nil for the other quotas.
+func (s *Service) Reserve(quota Quota, requested int) (*Reservation, error) {
+	if !quota.reuseUsage || s.usageCache == nil {
+		return nil, s.Check(quota, requested)
+	}
+
+	limit, err := s.getLimit(quota)
+	if err != nil {
+		return nil, err
+	}
+
+	count := func() (int, error) {
+		used, err := quota.usage(s, "")
+		return used, errors.Wrapf(err, "failed to get usage of service quota %q", quota.Name)
+	}
+	return s.usageCache.reserve(quota, "", requested, count, func(used int) error {
+		usage := Usage{Quota: quota, Used: used, Limit: limit}
+		if usage.Available() < requested {
+			return &QuotaExceededError{Usage: usage, Requested: requested}
+		}
+		return nil
+	})
+}
+
+// GetUsage returns the current usage and limit of the given quota.
+func (s *Service) GetUsage(quota Quota) (*Usage, error) {
+	return s.GetUsageInZone(quota, "")
+}
+
+// GetUsageInZone returns the current usage and limit of the given quota in an availability zone.
+// The zone is required for quotas that apply per availability zone, and ignored otherwise.
+func (s *Service) GetUsageInZone(quota Quota, zone string) (*Usage, error) {
+	if !quota.PerAvailabilityZone {
+		zone = ""
+	} else if zone == "" {
+		return nil, errors.Errorf("service quota %q applies per availability zone, but no zone was given", quota.Name)
+	}
+
+	if quota.usage == nil {
+		return nil, errors.Errorf("service quota %q doesn't limit the resources of the region and has no usage", quota.Name)
+	}
+
+	limit, err := s.getLimit(quota)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.getUsed(quota, zone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get usage of service quota %q", quota.Name)
+	}
+
+	return &Usage{Quota: quota, Zone: zone, Used: used, Limit: limit}, nil
+}
+
+// GetLimit returns the limit of the given quota for the account.
+func (s *Service) GetLimit(quota Quota) (int, error) {
+	return s.getLimit(quota)
+}
+
+// InstanceTypeVCPUs returns the number of default vCPUs of an instance type.
+func (s *Service) InstanceTypeVCPUs(instanceType string) (int, error) {
+	out, err := s.EC2Client.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+		InstanceTypes: aws.StringSlice([]string{instanceType}),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to describe instance type %q", instanceType)
+	}
+	if len(out.InstanceTypes) == 0 || out.InstanceTypes[0].VCpuInfo == nil {
+		return 0, errors.Errorf("instance type %q not found", instanceType)
+	}
+	return int(aws.Int64Value(out.InstanceTypes[0].VCpuInfo.DefaultVCpus)), nil
+}
+
+// IsStandardInstanceType returns true if the instance type belongs to one of the standard
+// families counted by the OnDemandStandardInstanceVCPUs quota.
+func IsStandardInstanceType(instanceType string) bool {
+	// The family is the prefix before the generation, e.g. "m" for "m5.large" or "im" for "im4gn.large".
+	name := strings.SplitN(instanceType, ".", 2)[0]
+	generation := strings.IndexFunc(name, unicode.IsDigit)
+	if generation < 0 {
+		return false
+	}
+	return standardInstanceFamilies[name[:generation]]
+}
+
+func (s *Service) getUsed(quota Quota, zone string) (int, error) {
+	if !quota.reuseUsage || s.usageCache == nil {
+		return quota.usage(s, zone)
+	}
+
+	var used int
+	_, err := s.usageCache.reserve(quota, zone, 0, func() (int, error) { return quota.usage(s, zone) }, func(u int) error {
+		used = u
+		return nil
+	})
+	return used, err
+}
+
+func (s *Service) getLimit(quota Quota) (int, error) {
+	if quota.AccountAttribute != "" {
+		return s.getAccountAttributeLimit(quota)
+	}
+
+	out, err := s.ServiceQuotasClient.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(quota.ServiceCode),
+		QuotaCode:   aws.String(quota.QuotaCode),
+	})
+	if err == nil {
+		return int(aws.Float64Value(out.Quota.Value)), nil
+	}
+
+	// Quotas that were never adjusted for the account may only be available as AWS defaults.
+	if code, _ := awserrors.Code(err); code != servicequotas.ErrCodeNoSuchResourceException {
+		return 0, errors.Wrapf(err, "failed to get service quota %q", quota.Name)
+	}
+
+	defaultOut, err := s.ServiceQuotasClient.GetAWSDefaultServiceQuota(&servicequotas.GetAWSDefaultServiceQuotaInput{
+		ServiceCode: aws.String(quota.ServiceCode),
+		QuotaCode:   aws.String(quota.QuotaCode),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get default service quota %q", quota.Name)
+	}
+	return int(aws.Float64Value(defaultOut.Quota.Value)), nil
+}
+
+func (s *Service) getAccountAttributeLimit(quota Quota) (int, error) {
+	out, err := s.EC2Client.DescribeAccountAttributes(&ec2.DescribeAccountAttributesInput{
+		AttributeNames: aws.StringSlice([]string{quota.AccountAttribute}),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to describe account attribute %q", quota.AccountAttribute)
+	}
+
+	for _, attr := range out.AccountAttributes {
+		if aws.StringValue(attr.AttributeName) != quota.AccountAttribute || len(attr.AttributeValues) == 0 {
+			continue
+		}
+		limit, err := strconv.Atoi(aws.StringValue(attr.AttributeValues[0].AttributeValue))
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to parse account attribute %q", quota.AccountAttribute)
+		}
+		return limit, nil
+	}
+
+	return 0, errors.Errorf("account attribute %q not found", quota.AccountAttribute)
+}
+
+func (s *Service) countVPCs(_ string) (int, error) {
+	count := 0
+	err := s.EC2Client.DescribeVpcsPages(&ec2.DescribeVpcsInput{}, func(page *ec2.DescribeVpcsOutput, lastPage bool) bool {
+		count += len(page.Vpcs)
+		return !lastPage
+	})
+	return count, err
+}
+
+func (s *Service) countInternetGateways(_ string) (int, error) {
+	count := 0
+	err := s.EC2Client.DescribeInternetGatewaysPages(&ec2.DescribeInternetGatewaysInput{}, func(page *ec2.DescribeInternetGatewaysOutput, lastPage bool) bool {
+		count += len(page.InternetGateways)
+		return !lastPage
+	})
+	return count, err
+}
+
+func (s *Service) countElasticIPs(_ string) (int, error) {
+	out, err := s.EC2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("domain"),
+				Values: aws.StringSlice([]string{ec2.DomainTypeVpc}),
+			},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(out.Addresses), nil
+}
+
+func (s *Service) countNATGateways(zone string) (int, error) {
+	subnetIDs := []*string{}
+	err := s.EC2Client.DescribeNatGatewaysPages(&ec2.DescribeNatGatewaysInput{
+		Filter: []*ec2.Filter{
+			{
+				Name:   aws.String("state"),
+				Values: aws.StringSlice([]string{ec2.NatGatewayStatePending, ec2.NatGatewayStateAvailable}),
+			},
+		},
+	}, func(page *ec2.DescribeNatGatewaysOutput, lastPage bool) bool {
+		for _, ngw := range page.NatGateways {
+			subnetIDs = append(subnetIDs, ngw.SubnetId)
+		}
+		return !lastPage
+	})
+	if err != nil || len(subnetIDs) == 0 {
+		return 0, err
+	}
+
+	// NAT gateways only reference their subnet, which holds the availability zone.
+	out, err := s.EC2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: subnetIDs})
+	if err != nil {
+		return 0, err
+	}
+	zones := map[string]string{}
+	for _, sn := range out.Subnets {
+		zones[aws.StringValue(sn.SubnetId)] = aws.StringValue(sn.AvailabilityZone)
+	}
+
+	count := 0
+	for _, id := range subnetIDs {
+		if zones[aws.StringValue(id)] == zone {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *Service) countOnDemandStandardVCPUs(_ string) (int, error) {
+	count := 0
+	err := s.EC2Client.DescribeInstancesPages(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: aws.StringSlice([]string{ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning}),
+			},
+		},
+	}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, r := range page.Reservations {
+			for _, i := range r.Instances {
+				// Spot instances are subject to a separate quota.
+				if i.InstanceLifecycle != nil || !IsStandardInstanceType(aws.StringValue(i.InstanceType)) || i.CpuOptions == nil {
+					continue
+				}
+				count += int(aws.Int64Value(i.CpuOptions.CoreCount) * aws.Int64Value(i.CpuOptions.ThreadsPerCore))
+			}
+		}
+		return !lastPage
+	})
+	return count, err
+}
@@ -0,0 +1,399 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicequotas
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/servicequotas/mock_servicequotasiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestServiceCheck(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeVpcs := func(m *mocks.MockEC2APIMockRecorder, count int) {
+		m.DescribeVpcsPages(gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{}), gomock.Any()).
+			DoAndReturn(func(_ *ec2.DescribeVpcsInput, fn func(*ec2.DescribeVpcsOutput, bool) bool) error {
+				vpcs := make([]*ec2.Vpc, count)
+				fn(&ec2.DescribeVpcsOutput{Vpcs: vpcs}, true)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name               string
+		quota              Quota
+		zone               string
+		requested          int
+		expectEC2          func(m *mocks.MockEC2APIMockRecorder)
+		expectQuotas       func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder)
+		wantErr            bool
+		wantQuotaExceeded  bool
+		wantQuotaErrString string
+	}{
+		{
+			name:      "Should succeed when there is room left within the applied quota",
+			quota:     VPCsPerRegion,
+			requested: 1,
+			expectQuotas: func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder) {
+				m.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+					ServiceCode: aws.String("vpc"),
+					QuotaCode:   aws.String("L-F678F1CE"),
+				}).Return(&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(10)}}, nil)
+			},
+			expectEC2: func(m *mocks.MockEC2APIMockRecorder) {
+				describeVpcs(m, 9)
+			},
+		},
+		{
+			name:      "Should return a quota exceeded error when the applied quota is reached",
+			quota:     VPCsPerRegion,
+			requested: 1,
+			expectQuotas: func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder) {
+				m.GetServiceQuota(gomock.AssignableToTypeOf(&servicequotas.GetServiceQuotaInput{})).
+					Return(&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(5)}}, nil)
+			},
+			expectEC2: func(m *mocks.MockEC2APIMockRecorder) {
+				describeVpcs(m, 5)
+			},
+			wantErr:            true,
+			wantQuotaExceeded:  true,
+			wantQuotaErrString: `service quota "VPCs per Region" (vpc/L-F678F1CE) exceeded: 5 of 5 in use, 1 more requested`,
+		},
+		{
+			name:      "Should fall back to the AWS default quota when the quota was never adjusted",
+			quota:     VPCsPerRegion,
+			requested: 1,
+			expectQuotas: func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder) {
+				m.GetServiceQuota(gomock.AssignableToTypeOf(&servicequotas.GetServiceQuotaInput{})).
+					Return(nil, awserr.New(servicequotas.ErrCodeNoSuchResourceException, "not found", nil))
+				m.GetAWSDefaultServiceQuota(gomock.AssignableToTypeOf(&servicequotas.GetAWSDefaultServiceQuotaInput{})).
+					Return(&servicequotas.GetAWSDefaultServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(5)}}, nil)
+			},
+			expectEC2: func(m *mocks.MockEC2APIMockRecorder) {
+				describeVpcs(m, 5)
+			},
+			wantErr:           true,
+			wantQuotaExceeded: true,
+		},
+		{
+			name:      "Should return a non quota error when the quota can't be retrieved",
+			quota:     VPCsPerRegion,
+			requested: 1,
+			expectQuotas: func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder) {
+				m.GetServiceQuota(gomock.AssignableToTypeOf(&servicequotas.GetServiceQuotaInput{})).
+					Return(nil, awserr.New("AccessDeniedException", "access denied", nil))
+			},
+			wantErr: true,
+		},
+		{
+			name:      "Should use the account attribute for elastic IPs",
+			quota:     ElasticIPsPerRegion,
+			requested: 2,
+			expectEC2: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAccountAttributes(&ec2.DescribeAccountAttributesInput{
+					AttributeNames: aws.StringSlice([]string{"vpc-max-elastic-ips"}),
+				}).Return(&ec2.DescribeAccountAttributesOutput{
+					AccountAttributes: []*ec2.AccountAttribute{
+						{
+							AttributeName: aws.String("vpc-max-elastic-ips"),
+							AttributeValues: []*ec2.AccountAttributeValue{
+								{AttributeValue: aws.String("5")},
+							},
+						},
+					},
+				}, nil)
+				m.DescribeAddresses(gomock.AssignableToTypeOf(&ec2.DescribeAddressesInput{})).
+					Return(&ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{}, {}, {}, {}}}, nil)
+			},
+			wantErr:           true,
+			wantQuotaExceeded: true,
+		},
+		{
+			name:      "Should return an error when usage can't be retrieved",
+			quota:     InternetGatewaysPerRegion,
+			requested: 1,
+			expectQuotas: func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder) {
+				m.GetServiceQuota(gomock.AssignableToTypeOf(&servicequotas.GetServiceQuotaInput{})).
+					Return(&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(5)}}, nil)
+			},
+			expectEC2: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInternetGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeInternetGatewaysInput{}), gomock.Any()).
+					Return(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:      "Should count NAT gateways in the given availability zone only",
+			quota:     NATGatewaysPerAvailabilityZone,
+			zone:      "us-east-1a",
+			requested: 1,
+			expectQuotas: func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder) {
+				m.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+					ServiceCode: aws.String("vpc"),
+					QuotaCode:   aws.String("L-FE5A380F"),
+				}).Return(&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(2)}}, nil)
+			},
+			expectEC2: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					DoAndReturn(func(_ *ec2.DescribeNatGatewaysInput, fn func(*ec2.DescribeNatGatewaysOutput, bool) bool) error {
+						fn(&ec2.DescribeNatGatewaysOutput{NatGateways: []*ec2.NatGateway{
+							{SubnetId: aws.String("subnet-1")},
+							{SubnetId: aws.String("subnet-2")},
+						}}, true)
+						return nil
+					})
+				m.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{"subnet-1", "subnet-2"})}).
+					Return(&ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{
+						{SubnetId: aws.String("subnet-1"), AvailabilityZone: aws.String("us-east-1a")},
+						{SubnetId: aws.String("subnet-2"), AvailabilityZone: aws.String("us-east-1b")},
+					}}, nil)
+			},
+		},
+		{
+			name:      "Should return an error when no zone is given for a per availability zone quota",
+			quota:     NATGatewaysPerAvailabilityZone,
+			requested: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "Should return an error for a quota without usage",
+			quota:     SecurityGroupsPerNetworkInterface,
+			requested: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "Should count the vCPUs of running On-Demand standard instances",
+			quota:     OnDemandStandardInstanceVCPUs,
+			requested: 8,
+			expectQuotas: func(m *mock_servicequotasiface.MockServiceQuotasAPIMockRecorder) {
+				m.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+					ServiceCode: aws.String("ec2"),
+					QuotaCode:   aws.String("L-1216C47A"),
+				}).Return(&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(8)}}, nil)
+			},
+			expectEC2: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+					DoAndReturn(func(_ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+						fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+							{InstanceType: aws.String("m5.large"), CpuOptions: &ec2.CpuOptions{CoreCount: aws.Int64(1), ThreadsPerCore: aws.Int64(2)}},
+							{InstanceType: aws.String("t3.large"), CpuOptions: &ec2.CpuOptions{CoreCount: aws.Int64(1), ThreadsPerCore: aws.Int64(2)}},
+							{InstanceType: aws.String("m5.xlarge"), InstanceLifecycle: aws.String(ec2.InstanceLifecycleTypeSpot), CpuOptions: &ec2.CpuOptions{CoreCount: aws.Int64(2), ThreadsPerCore: aws.Int64(2)}},
+							{InstanceType: aws.String("p3.2xlarge"), CpuOptions: &ec2.CpuOptions{CoreCount: aws.Int64(4), ThreadsPerCore: aws.Int64(2)}},
+						}}}}, true)
+						return nil
+					})
+			},
+			wantErr:            true,
+			wantQuotaExceeded:  true,
+			wantQuotaErrString: `service quota "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances" (ec2/L-1216C47A) exceeded: 4 of 8 in use, 8 more requested`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			quotasMock := mock_servicequotasiface.NewMockServiceQuotasAPI(mockCtrl)
+			if tc.expectEC2 != nil {
+				tc.expectEC2(ec2Mock.EXPECT())
+			}
+			if tc.expectQuotas != nil {
+				tc.expectQuotas(quotasMock.EXPECT())
+			}
+
+			s := &Service{
+				EC2Client:           ec2Mock,
+				ServiceQuotasClient: quotasMock,
+			}
+
+			err := s.CheckInZone(tc.quota, tc.zone, tc.requested)
+			if !tc.wantErr {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(awserrors.IsQuotaExceeded(err)).To(Equal(tc.wantQuotaExceeded))
+			if tc.wantQuotaErrString != "" {
+				g.Expect(err.Error()).To(Equal(tc.wantQuotaErrString))
+			}
+		})
+	}
+}
+
+func TestServiceReserveReusesUsage(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	quotasMock := mock_servicequotasiface.NewMockServiceQuotasAPI(mockCtrl)
+	quotasMock.EXPECT().GetServiceQuota(gomock.Any()).
+		Return(&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(8)}}, nil).AnyTimes()
+	ec2Mock.EXPECT().DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+		DoAndReturn(func(_ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+			fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+				{InstanceType: aws.String("m5.xlarge"), CpuOptions: &ec2.CpuOptions{CoreCount: aws.Int64(2), ThreadsPerCore: aws.Int64(2)}},
+			}}}}, true)
+			return nil
+		}).Times(2)
+
+	now := time.Now()
+	cache := newUsageCache()
+	cache.now = func() time.Time { return now }
+	s := &Service{EC2Client: ec2Mock, ServiceQuotasClient: quotasMock, usageCache: cache}
+
+	// The instances are described once, and the vCPUs reserved by the first check are counted by the next ones.
+	reservation, err := s.Reserve(OnDemandStandardInstanceVCPUs, 2)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = s.Reserve(OnDemandStandardInstanceVCPUs, 4)
+	g.Expect(awserrors.IsQuotaExceeded(err)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("6 of 8 in use, 4 more requested"))
+
+	// Released vCPUs are no longer counted, committed ones are added to the usage.
+	reservation.Release()
+	reservation, err = s.Reserve(OnDemandStandardInstanceVCPUs, 4)
+	g.Expect(err).NotTo(HaveOccurred())
+	reservation.Commit()
+	reservation.Release()
+	usage, err := s.GetUsage(OnDemandStandardInstanceVCPUs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(usage.Used).To(Equal(8))
+
+	// Check doesn't reserve anything.
+	g.Expect(cache.idle()).To(BeFalse())
+	g.Expect(s.Check(OnDemandStandardInstanceVCPUs, 0)).To(Succeed())
+
+	// The instances are described again once the usage expired, and the expired cache is idle.
+	now = now.Add(UsageTTL + time.Second)
+	g.Expect(cache.idle()).To(BeTrue())
+	reservation, err = s.Reserve(OnDemandStandardInstanceVCPUs, 4)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reservation).NotTo(BeNil())
+}
+
+func TestServiceReserveIsAtomic(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	quotasMock := mock_servicequotasiface.NewMockServiceQuotasAPI(mockCtrl)
+	quotasMock.EXPECT().GetServiceQuota(gomock.Any()).
+		Return(&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(8)}}, nil).AnyTimes()
+	ec2Mock.EXPECT().DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).Return(nil).Times(1)
+
+	s := &Service{EC2Client: ec2Mock, ServiceQuotasClient: quotasMock, usageCache: newUsageCache()}
+
+	// Concurrent checks count the usage once, and allow no more than the limit.
+	var wg sync.WaitGroup
+	var allowed int32
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.Reserve(OnDemandStandardInstanceVCPUs, 2); err == nil {
+				atomic.AddInt32(&allowed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	g.Expect(allowed).To(Equal(int32(4)))
+}
+
+type principalKeyScope struct {
+	principalKey string
+}
+
+func (s principalKeyScope) Session() client.ConfigProvider {
+	return nil
+}
+
+func (s principalKeyScope) ServiceLimiter(string) *throttle.ServiceLimiter {
+	return nil
+}
+
+func (s principalKeyScope) PrincipalKey() string {
+	return s.principalKey
+}
+
+func TestUsageCacheFor(t *testing.T) {
+	g := NewWithT(t)
+	defer usageCaches.Delete("account/111111111111/us-east-1")
+
+	// The scopes of the same principal and region share their usage cache.
+	cache := usageCacheFor(principalKeyScope{principalKey: "account/111111111111/us-east-1"})
+	g.Expect(cache).NotTo(BeNil())
+	g.Expect(usageCacheFor(principalKeyScope{principalKey: "account/111111111111/us-east-1"})).To(BeIdenticalTo(cache))
+	g.Expect(usageCacheFor(principalKeyScope{principalKey: "account/111111111111/us-west-2"})).NotTo(BeIdenticalTo(cache))
+	g.Expect(usageCacheFor(principalKeyScope{})).To(BeNil())
+}
+
+func TestEvictIdleUsageCaches(t *testing.T) {
+	g := NewWithT(t)
+
+	idle, inUse := newUsageCache(), newUsageCache()
+	inUse.usage(OnDemandStandardInstanceVCPUs, "").reserved = 2
+	usageCaches.Store("idle", idle)
+	usageCaches.Store("in-use", inUse)
+	defer usageCaches.Delete("in-use")
+
+	evictIdleUsageCaches()
+	_, ok := usageCaches.Load("idle")
+	g.Expect(ok).To(BeFalse())
+	_, ok = usageCaches.Load("in-use")
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestIsStandardInstanceType(t *testing.T) {
+	tests := []struct {
+		instanceType string
+		want         bool
+	}{
+		{instanceType: "m5.large", want: true},
+		{instanceType: "t3a.medium", want: true},
+		{instanceType: "im4gn.xlarge", want: true},
+		{instanceType: "m5zn.2xlarge", want: true},
+		{instanceType: "p3.2xlarge", want: false},
+		{instanceType: "inf1.xlarge", want: false},
+		{instanceType: "u-6tb1.metal", want: false},
+		{instanceType: "", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.instanceType, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(IsStandardInstanceType(tc.instanceType)).To(Equal(tc.want))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicequotas
+
+import (
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service holds the clients used to look up quota limits and current usage.
+// It does not depend on a scope so it can be reused outside of the controllers,
+// e.g. by clusterawsadm.
+type Service struct {
+	EC2Client           ec2iface.EC2API
+	ServiceQuotasClient servicequotasiface.ServiceQuotasAPI
+
+	// usageCache, when set, holds the usage of the quotas shared with the other services of the same AWS
+	// principal and region.
+	usageCache *usageCache
+}
+
+// NewService returns a new service given the cluster scope. The usage of the quotas counted by describing
+// every resource of the region is shared with the services of the same AWS principal and region, see UsageTTL.
+func NewService(clusterScope cloud.ClusterScoper) *Service {
+	return &Service{
+		EC2Client:           scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		ServiceQuotasClient: scope.NewServiceQuotasClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		usageCache:          usageCacheFor(clusterScope),
+	}
+}
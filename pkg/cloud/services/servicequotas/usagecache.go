@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicequotas
+
+import (
+	"sync"
+	"time"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+)
+
+// UsageTTL is how long the usage of the quotas counted by describing every resource of the region, such as
+// OnDemandStandardInstanceVCPUs, is reused by the checks made with the same AWS principal and region.
+var UsageTTL = time.Minute
+
+// usageCaches holds the usage cache of each AWS principal and region, so that the services created for every
+// reconciliation of the clusters sharing the quotas of an account share the usage counted by the previous ones.
+// The caches whose usage expired are evicted when a new one is added, see evictIdleUsageCaches.
+var usageCaches sync.Map
+
+// usageCache holds the usage of quotas for UsageTTL. The resources allowed by a check are reserved until
+// they are created, when they are added to the cached usage, or until their creation fails.
+type usageCache struct {
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]*cachedUsage
+}
+
+type cachedUsage struct {
+	// mu serializes the checks of the quota, from counting its usage to reserving the requested
+	// resources, so that concurrent checks can't allow the same resources twice.
+	mu       sync.Mutex
+	used     int
+	reserved int
+	expires  time.Time
+}
+
+func newUsageCache() *usageCache {
+	return &usageCache{now: time.Now, entries: map[string]*cachedUsage{}}
+}
+
+// principalKeyProvider is implemented by the scopes whose session identifies its AWS principal and region.
+type principalKeyProvider interface {
+	PrincipalKey() string
+}
+
+// usageCacheFor returns the usage cache of the AWS principal and region of the session of scope, or nil if
+// they are unknown.
+func usageCacheFor(scope cloud.Session) *usageCache {
+	p, ok := scope.(principalKeyProvider)
+	if !ok || p.PrincipalKey() == "" {
+		return nil
+	}
+	if c, ok := usageCaches.Load(p.PrincipalKey()); ok {
+		return c.(*usageCache)
+	}
+	evictIdleUsageCaches()
+	c, _ := usageCaches.LoadOrStore(p.PrincipalKey(), newUsageCache())
+	return c.(*usageCache)
+}
+
+// evictIdleUsageCaches drops the usage caches holding no usage that is still valid and no reservation,
+// such as the caches of the accounts no longer used by any cluster.
+func evictIdleUsageCaches() {
+	usageCaches.Range(func(key, c any) bool {
+		if c.(*usageCache).idle() {
+			usageCaches.Delete(key)
+		}
+		return true
+	})
+}
+
+func usageKey(quota Quota, zone string) string {
+	return quota.ServiceCode + "/" + quota.QuotaCode + "/" + zone
+}
+
+// usage returns the cached usage of a quota, adding it if needed.
+func (c *usageCache) usage(quota Quota, zone string) *cachedUsage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := usageKey(quota, zone)
+	u, ok := c.entries[key]
+	if !ok {
+		u = &cachedUsage{}
+		c.entries[key] = u
+	}
+	return u
+}
+
+// idle returns true if none of the usage of the cache is still valid or reserved.
+func (c *usageCache) idle() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for _, u := range c.entries {
+		u.mu.Lock()
+		inUse := u.reserved > 0 || !now.After(u.expires)
+		u.mu.Unlock()
+		if inUse {
+			return false
+		}
+	}
+	return true
+}
+
+// reserve reserves requested resources of a quota if check allows them, given the usage of the quota
+// including the resources reserved by the previous checks. The usage is counted again with count once
+// it expired.
+func (c *usageCache) reserve(quota Quota, zone string, requested int, count func() (int, error), check func(used int) error) (*Reservation, error) {
+	u := c.usage(quota, zone)
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if now := c.now(); now.After(u.expires) {
+		used, err := count()
+		if err != nil {
+			return nil, err
+		}
+		u.used, u.expires = used, now.Add(UsageTTL)
+	}
+
+	if err := check(u.used + u.reserved); err != nil {
+		return nil, err
+	}
+	u.reserved += requested
+	return &Reservation{usage: u, requested: requested}, nil
+}
+
+// Reservation holds the resources allowed by Service.Reserve until they are created. A nil Reservation
+// holds nothing, and its methods do nothing.
+type Reservation struct {
+	usage     *cachedUsage
+	requested int
+	done      bool
+}
+
+// Commit adds the reserved resources to the cached usage of the quota, once they were created.
+func (r *Reservation) Commit() {
+	r.finish(true)
+}
+
+// Release drops the reserved resources, when they couldn't be created.
+func (r *Reservation) Release() {
+	r.finish(false)
+}
+
+func (r *Reservation) finish(created bool) {
+	if r == nil {
+		return
+	}
+	r.usage.mu.Lock()
+	defer r.usage.mu.Unlock()
+
+	if r.done {
+		return
+	}
+	r.done = true
+	r.usage.reserved -= r.requested
+	if created {
+		r.usage.used += r.requested
+	}
+}
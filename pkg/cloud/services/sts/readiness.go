@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sts provides checks of the AWS credentials of the controller.
+package sts
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+// CredentialsChecker checks that the default credentials of the controller can be used,
+// by calling STS GetCallerIdentity with them.
+type CredentialsChecker struct {
+	client   stsiface.STSAPI
+	interval time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	lastCheck time.Time
+	lastErr   error
+}
+
+// NewCredentialsChecker returns a CredentialsChecker calling STS with the given client. The result
+// of a call is reused for the given interval, so that frequent probes don't call STS each time.
+func NewCredentialsChecker(client stsiface.STSAPI, interval time.Duration) *CredentialsChecker {
+	return &CredentialsChecker{
+		client:   client,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Check implements healthz.Checker. It returns an error when the credentials of the
+// controller were rejected by STS, or when STS couldn't be reached.
+func (c *CredentialsChecker) Check(req *http.Request) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.lastCheck.IsZero() && c.now().Sub(c.lastCheck) < c.interval {
+		return c.lastErr
+	}
+
+	c.lastErr = nil
+	if _, err := c.client.GetCallerIdentityWithContext(req.Context(), &sts.GetCallerIdentityInput{}); err != nil {
+		c.lastErr = fmt.Errorf("verifying AWS credentials with STS GetCallerIdentity: %w", err)
+	}
+	c.lastCheck = c.now()
+
+	return c.lastErr
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sts
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/sts/mock_stsiface"
+)
+
+func TestCredentialsCheckerCheck(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+	gomock.InOrder(
+		stsMock.EXPECT().GetCallerIdentityWithContext(gomock.Any(), gomock.Eq(&sts.GetCallerIdentityInput{})).
+			Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil),
+		stsMock.EXPECT().GetCallerIdentityWithContext(gomock.Any(), gomock.Eq(&sts.GetCallerIdentityInput{})).
+			Return(nil, awserr.New("ExpiredToken", "The security token included in the request is expired", nil)),
+	)
+
+	now := time.Now()
+	checker := NewCredentialsChecker(stsMock, time.Minute)
+	checker.now = func() time.Time { return now }
+	req := httptest.NewRequest("GET", "/readyz", nil)
+
+	g.Expect(checker.Check(req)).To(Succeed())
+
+	// The result is reused within the interval.
+	now = now.Add(30 * time.Second)
+	g.Expect(checker.Check(req)).To(Succeed())
+
+	now = now.Add(time.Minute)
+	err := checker.Check(req)
+	g.Expect(err).To(MatchError(ContainSubstring("ExpiredToken")))
+
+	// Failures are reused within the interval as well.
+	now = now.Add(30 * time.Second)
+	g.Expect(checker.Check(req)).To(MatchError(err))
+}
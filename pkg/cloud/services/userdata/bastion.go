@@ -16,11 +16,19 @@ limitations under the License.
 
 package userdata
 
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
 const (
 	bastionBashScript = `{{.Header}}
 
 BASTION_BOOTSTRAP_FILE=bastion_bootstrap.sh
-BASTION_BOOTSTRAP=https://s3.amazonaws.com/aws-quickstart/quickstart-linux-bastion/scripts/bastion_bootstrap.sh
+BASTION_BOOTSTRAP=https://{{.S3Host}}/aws-quickstart/quickstart-linux-bastion/scripts/bastion_bootstrap.sh
 
 curl -s -o $BASTION_BOOTSTRAP_FILE $BASTION_BOOTSTRAP
 chmod +x $BASTION_BOOTSTRAP_FILE
@@ -36,10 +44,30 @@ pip install --upgrade pip &> /dev/null
 // BastionInput defines the context to generate a bastion instance user data.
 type BastionInput struct {
 	baseUserData
+
+	// Region is the region of the bastion instance. It selects the S3 endpoint
+	// of its partition the bootstrap script is downloaded from.
+	Region string
+
+	// S3Host is the S3 endpoint the bootstrap script is downloaded from. It is
+	// set by NewBastion.
+	S3Host string
 }
 
 // NewBastion returns the user data string to be used on a bastion instance.
 func NewBastion(input *BastionInput) (string, error) {
 	input.Header = defaultHeader
+	input.S3Host = s3Host(input.Region)
 	return generate("bastion", bastionBashScript, input)
 }
+
+// s3Host returns the S3 endpoint of the partition of a region. The global
+// endpoint only exists in the aws partition, other partitions use the
+// regional endpoint with their own DNS suffix.
+func s3Host(region string) string {
+	suffix := infrav1.DNSSuffixForRegion(region)
+	if suffix == "" || infrav1.PartitionForRegion(region) == endpoints.AwsPartitionID {
+		return "s3.amazonaws.com"
+	}
+	return fmt.Sprintf("s3.%s.%s", region, suffix)
+}
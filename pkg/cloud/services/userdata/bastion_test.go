@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewBastion(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		want   string
+	}{
+		{
+			name: "no region",
+			want: "BASTION_BOOTSTRAP=https://s3.amazonaws.com/aws-quickstart/",
+		},
+		{
+			name:   "commercial region",
+			region: "eu-west-1",
+			want:   "BASTION_BOOTSTRAP=https://s3.amazonaws.com/aws-quickstart/",
+		},
+		{
+			name:   "GovCloud region",
+			region: "us-gov-west-1",
+			want:   "BASTION_BOOTSTRAP=https://s3.us-gov-west-1.amazonaws.com/aws-quickstart/",
+		},
+		{
+			name:   "China region",
+			region: "cn-north-1",
+			want:   "BASTION_BOOTSTRAP=https://s3.cn-north-1.amazonaws.com.cn/aws-quickstart/",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			out, err := NewBastion(&BastionInput{Region: tc.region})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(out).To(ContainSubstring(tc.want))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/mime"
+)
+
+const (
+	// prependMergeHow merges the commands of a cloud config before the commands of the bootstrap data.
+	prependMergeHow = "list(prepend)+dict(no_replace,recurse_list)+str()"
+	// appendMergeHow merges the commands of a cloud config after the commands of the bootstrap data.
+	appendMergeHow = "list(append)+dict(no_replace,recurse_list)+str()"
+)
+
+type commandsCloudConfig struct {
+	MergeHow string   `json:"merge_how"`
+	RunCmd   []string `json:"runcmd"`
+}
+
+// newCommandsCloudConfig returns a cloud config running the commands, merged into the cloud config
+// of the bootstrap data as set by mergeHow.
+func newCommandsCloudConfig(commands []string, mergeHow string) ([]byte, error) {
+	out, err := yaml.Marshal(commandsCloudConfig{MergeHow: mergeHow, RunCmd: commands})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal bootstrap commands")
+	}
+	return append([]byte("#cloud-config\n"), out...), nil
+}
+
+// isCloudConfig returns whether the user data is a cloud config, possibly rendered as a jinja
+// template, or a multi-part MIME document which cloud-init merges cloud configs into.
+func isCloudConfig(userData []byte) bool {
+	data := bytes.TrimPrefix(userData, []byte("## template: jinja\n"))
+	return bytes.HasPrefix(data, []byte("#cloud-config")) ||
+		bytes.HasPrefix(userData, []byte("MIME-Version:")) ||
+		bytes.HasPrefix(userData, []byte("Content-Type:"))
+}
+
+// WithBootstrapCommands returns user data running the pre bootstrap commands before, and the post
+// bootstrap commands after, the commands of the given cloud-init bootstrap data. The user data is
+// returned unchanged when there are no commands. The output is stable for a given input, so that it
+// can be compared through its hash.
+func WithBootstrapCommands(userData []byte, preBootstrapCommands, postBootstrapCommands []string) ([]byte, error) {
+	if len(preBootstrapCommands) == 0 && len(postBootstrapCommands) == 0 {
+		return userData, nil
+	}
+	if !isCloudConfig(userData) {
+		return nil, errors.New("bootstrap commands can only be added to cloud-init cloud config bootstrap data")
+	}
+
+	var cloudConfigs [][]byte
+	if len(preBootstrapCommands) > 0 {
+		cloudConfig, err := newCommandsCloudConfig(preBootstrapCommands, prependMergeHow)
+		if err != nil {
+			return nil, err
+		}
+		cloudConfigs = append(cloudConfigs, cloudConfig)
+	}
+	if len(postBootstrapCommands) > 0 {
+		cloudConfig, err := newCommandsCloudConfig(postBootstrapCommands, appendMergeHow)
+		if err != nil {
+			return nil, err
+		}
+		cloudConfigs = append(cloudConfigs, cloudConfig)
+	}
+
+	out, err := mime.AppendCloudConfigs(userData, cloudConfigs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add bootstrap commands to user data")
+	}
+	return out, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWithBootstrapCommands(t *testing.T) {
+	tests := []struct {
+		name        string
+		userData    string
+		pre         []string
+		post        []string
+		expectErr   bool
+		unchanged   bool
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:      "no commands",
+			userData:  "#!/bin/bash\necho bootstrap\n",
+			unchanged: true,
+		},
+		{
+			name:     "pre and post commands",
+			userData: "## template: jinja\n#cloud-config\nruncmd:\n- kubeadm init\n",
+			pre:      []string{"update-ca-certificates"},
+			post:     []string{"systemctl enable --now agent"},
+			contains: []string{
+				"merge_how: list(prepend)+dict(no_replace,recurse_list)+str()\nruncmd:\n- update-ca-certificates\n",
+				"merge_how: list(append)+dict(no_replace,recurse_list)+str()\nruncmd:\n- systemctl enable --now agent\n",
+				"## template: jinja\n#cloud-config\nruncmd:\n- kubeadm init\n",
+			},
+		},
+		{
+			name:        "post commands only",
+			userData:    "#cloud-config\nruncmd:\n- kubeadm join\n",
+			post:        []string{"echo done"},
+			contains:    []string{"list(append)"},
+			notContains: []string{"list(prepend)"},
+		},
+		{
+			name:      "shell script bootstrap data",
+			userData:  "#!/bin/bash\necho bootstrap\n",
+			pre:       []string{"echo pre"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			out, err := WithBootstrapCommands([]byte(tc.userData), tc.pre, tc.post)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.unchanged {
+				g.Expect(string(out)).To(Equal(tc.userData))
+				return
+			}
+			g.Expect(string(out)).To(HavePrefix("MIME-Version: 1.0\n"))
+			for _, s := range tc.contains {
+				g.Expect(strings.ReplaceAll(string(out), "\r\n", "\n")).To(ContainSubstring(s))
+			}
+			for _, s := range tc.notContains {
+				g.Expect(string(out)).NotTo(ContainSubstring(s))
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+const (
+	// BottlerocketBootstrapContainerName is the name of the bootstrap container running the bootstrap data.
+	BottlerocketBootstrapContainerName = "capa-bootstrap"
+
+	bottlerocketTOML = `[settings.bootstrap-containers.{{ .BootstrapContainerName }}]
+source = {{ Quote .Settings.BootstrapContainerSource }}
+mode = "once"
+essential = true
+user-data = {{ Quote .BootstrapData }}
+{{- with .Settings.AdminContainer }}
+
+[settings.host-containers.admin]
+enabled = {{ .Enabled }}
+{{- if .Source }}
+source = {{ Quote .Source }}
+{{- end }}
+{{- end }}
+{{- if .KernelSysctls }}
+
+[settings.kernel.sysctl]
+{{- range .KernelSysctls }}
+{{ Quote .Name }} = {{ Quote .Value }}
+{{- end }}
+{{- end }}
+{{- range .Settings.RegistryMirrors }}
+
+[[settings.container-registry.mirrors]]
+registry = {{ Quote .Registry }}
+endpoint = [{{ range $i, $e := .Endpoints }}{{ if $i }}, {{ end }}{{ Quote $e }}{{ end }}]
+{{- end }}
+{{- if .HTTPSProxy }}
+
+[settings.network]
+https-proxy = {{ Quote .HTTPSProxy }}
+{{- if .NoProxy }}
+no-proxy = [{{ range $i, $e := .NoProxy }}{{ if $i }}, {{ end }}{{ Quote $e }}{{ end }}]
+{{- end }}
+{{- end }}
+{{- if .TrustedCA }}
+
+[settings.pki.capa-proxy-ca]
+data = {{ Quote .TrustedCA }}
+trusted = true
+{{- end }}
+`
+)
+
+// BottlerocketInput defines the context to generate the user data of a Bottlerocket instance.
+type BottlerocketInput struct {
+	// Settings are the Bottlerocket options of the machine.
+	Settings infrav1.Bottlerocket
+	// BootstrapData is passed to the bootstrap container as its user data.
+	BootstrapData []byte
+	// Proxy are the optional proxy settings of the instance. Bottlerocket has a single proxy setting,
+	// the HTTPS proxy is used if it is set and the HTTP proxy otherwise.
+	Proxy *ProxyInput
+}
+
+type bottlerocketSysctl struct {
+	Name  string
+	Value string
+}
+
+// NewBottlerocket returns the TOML settings to be used as the user data of a Bottlerocket instance.
+// The output is stable for a given input, so that it can be compared through its hash.
+func NewBottlerocket(input *BottlerocketInput) ([]byte, error) {
+	sysctls := make([]bottlerocketSysctl, 0, len(input.Settings.KernelSysctls))
+	for name, value := range input.Settings.KernelSysctls {
+		sysctls = append(sysctls, bottlerocketSysctl{Name: name, Value: value})
+	}
+	sort.Slice(sysctls, func(i, j int) bool { return sysctls[i].Name < sysctls[j].Name })
+
+	tm, err := template.New("bottlerocket").Funcs(template.FuncMap{"Quote": tomlQuote}).Parse(bottlerocketTOML)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse bottlerocket template")
+	}
+
+	var httpsProxy, trustedCA string
+	var noProxy []string
+	if input.Proxy != nil {
+		httpsProxy = input.Proxy.Proxy.HTTPSProxy
+		if httpsProxy == "" {
+			httpsProxy = input.Proxy.Proxy.HTTPProxy
+		}
+		noProxy = input.Proxy.Proxy.NoProxy
+		if len(input.Proxy.TrustedCA) > 0 {
+			trustedCA = base64.StdEncoding.EncodeToString(input.Proxy.TrustedCA)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := tm.Execute(&out, struct {
+		Settings               infrav1.Bottlerocket
+		BootstrapContainerName string
+		BootstrapData          string
+		KernelSysctls          []bottlerocketSysctl
+		HTTPSProxy             string
+		NoProxy                []string
+		TrustedCA              string
+	}{
+		Settings:               input.Settings,
+		BootstrapContainerName: BottlerocketBootstrapContainerName,
+		BootstrapData:          base64.StdEncoding.EncodeToString(input.BootstrapData),
+		KernelSysctls:          sysctls,
+		HTTPSProxy:             httpsProxy,
+		NoProxy:                noProxy,
+		TrustedCA:              trustedCA,
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to generate bottlerocket template")
+	}
+
+	return out.Bytes(), nil
+}
+
+// tomlQuote returns s as a TOML basic string. The escape sequences produced by
+// JSON encoding are a subset of the ones TOML basic strings support.
+func tomlQuote(s string) (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+func TestNewBottlerocket(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *BottlerocketInput
+		expected string
+	}{
+		{
+			name: "only bootstrap container",
+			input: &BottlerocketInput{
+				Settings:      infrav1.Bottlerocket{BootstrapContainerSource: "example.com/bootstrap:v1"},
+				BootstrapData: []byte("#cloud-config\n"),
+			},
+			expected: `[settings.bootstrap-containers.capa-bootstrap]
+source = "example.com/bootstrap:v1"
+mode = "once"
+essential = true
+user-data = "I2Nsb3VkLWNvbmZpZwo="
+`,
+		},
+		{
+			name: "all settings",
+			input: &BottlerocketInput{
+				Settings: infrav1.Bottlerocket{
+					BootstrapContainerSource: "example.com/bootstrap:v1",
+					AdminContainer:           &infrav1.BottlerocketHostContainer{Enabled: true, Source: "example.com/admin:v1"},
+					KernelSysctls: map[string]string{
+						"vm.max_map_count":    "262144",
+						"net.ipv4.ip_forward": "1",
+					},
+					RegistryMirrors: []infrav1.BottlerocketRegistryMirror{
+						{Registry: "docker.io", Endpoints: []string{"https://mirror-a.example.com", "https://mirror-b.example.com"}},
+					},
+				},
+				BootstrapData: []byte("#cloud-config\n"),
+			},
+			expected: `[settings.bootstrap-containers.capa-bootstrap]
+source = "example.com/bootstrap:v1"
+mode = "once"
+essential = true
+user-data = "I2Nsb3VkLWNvbmZpZwo="
+
+[settings.host-containers.admin]
+enabled = true
+source = "example.com/admin:v1"
+
+[settings.kernel.sysctl]
+"net.ipv4.ip_forward" = "1"
+"vm.max_map_count" = "262144"
+
+[[settings.container-registry.mirrors]]
+registry = "docker.io"
+endpoint = ["https://mirror-a.example.com", "https://mirror-b.example.com"]
+`,
+		},
+		{
+			name: "disabled admin container and quoted values",
+			input: &BottlerocketInput{
+				Settings: infrav1.Bottlerocket{
+					BootstrapContainerSource: "example.com/bootstrap:v1",
+					AdminContainer:           &infrav1.BottlerocketHostContainer{},
+					KernelSysctls:            map[string]string{"kernel.core_pattern": `"|/bin/false"`},
+				},
+			},
+			expected: `[settings.bootstrap-containers.capa-bootstrap]
+source = "example.com/bootstrap:v1"
+mode = "once"
+essential = true
+user-data = ""
+
+[settings.host-containers.admin]
+enabled = false
+
+[settings.kernel.sysctl]
+"kernel.core_pattern" = "\"|/bin/false\""
+`,
+		},
+		{
+			name: "proxy",
+			input: &BottlerocketInput{
+				Settings:      infrav1.Bottlerocket{BootstrapContainerSource: "example.com/bootstrap:v1"},
+				BootstrapData: []byte("#cloud-config\n"),
+				Proxy: &ProxyInput{
+					Proxy: infrav1.ProxySpec{
+						HTTPProxy: "http://proxy.example.com:3128",
+						NoProxy:   []string{"10.0.0.0/16", "169.254.169.254"},
+					},
+					TrustedCA: []byte("ca"),
+				},
+			},
+			expected: `[settings.bootstrap-containers.capa-bootstrap]
+source = "example.com/bootstrap:v1"
+mode = "once"
+essential = true
+user-data = "I2Nsb3VkLWNvbmZpZwo="
+
+[settings.network]
+https-proxy = "http://proxy.example.com:3128"
+no-proxy = ["10.0.0.0/16", "169.254.169.254"]
+
+[settings.pki.capa-proxy-ca]
+data = "Y2E="
+trusted = true
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			out, err := NewBottlerocket(tc.input)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(out)).To(Equal(tc.expected))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/mime"
+)
+
+const (
+	// ProxyEnvironmentFile is the file in which the proxy boothook writes the proxy environment variables.
+	// The scripts fetching the bootstrap data from a secure secrets backend read it when it exists.
+	ProxyEnvironmentFile = "/etc/capa-proxy.env"
+
+	proxyBoothook = `#cloud-boothook
+#!/bin/bash
+set -o errexit
+set -o nounset
+set -o pipefail
+
+cat > {{ .EnvironmentFile }} <<'CAPA_PROXY_ENV_EOF'
+{{- range .Environment }}
+{{ .Name }}='{{ .Value }}'
+{{- end }}
+CAPA_PROXY_ENV_EOF
+
+touch /etc/environment
+sed -i -E '/^({{ range $i, $e := .Environment }}{{ if $i }}|{{ end }}{{ $e.Name }}{{ end }})=/d' /etc/environment
+cat {{ .EnvironmentFile }} >> /etc/environment
+
+for unit in containerd docker kubelet; do
+  mkdir -p "/etc/systemd/system/${unit}.service.d"
+  printf '[Service]\nEnvironmentFile={{ .EnvironmentFile }}\n' > "/etc/systemd/system/${unit}.service.d/capa-proxy.conf"
+done
+systemctl daemon-reload || true
+{{- if .TrustedCA }}
+
+if command -v update-ca-certificates > /dev/null; then
+  CA_FILE=/usr/local/share/ca-certificates/capa-proxy-ca.crt
+else
+  CA_FILE=/etc/pki/ca-trust/source/anchors/capa-proxy-ca.crt
+fi
+mkdir -p "$(dirname "${CA_FILE}")"
+cat > "${CA_FILE}" <<'CAPA_PROXY_CA_EOF'
+{{ .TrustedCA }}
+CAPA_PROXY_CA_EOF
+if command -v update-ca-certificates > /dev/null; then
+  update-ca-certificates
+else
+  update-ca-trust extract
+fi
+{{- end }}
+`
+)
+
+// ProxyInput defines the context to render the proxy settings of an instance.
+type ProxyInput struct {
+	// Proxy are the proxy settings of the cluster.
+	Proxy infrav1.ProxySpec
+	// TrustedCA is the PEM encoded CA bundle to add to the trusted certificates of the instance.
+	TrustedCA []byte
+}
+
+type proxyEnvVar struct {
+	Name  string
+	Value string
+}
+
+// environment returns the environment variables configuring the proxy, in both the upper and
+// lower case forms used by different tools.
+func (p *ProxyInput) environment() []proxyEnvVar {
+	var env []proxyEnvVar
+	add := func(name, value string) {
+		if value == "" {
+			return
+		}
+		env = append(env, proxyEnvVar{Name: strings.ToUpper(name), Value: value}, proxyEnvVar{Name: name, Value: value})
+	}
+	add("http_proxy", p.Proxy.HTTPProxy)
+	add("https_proxy", p.Proxy.HTTPSProxy)
+	add("no_proxy", strings.Join(p.Proxy.NoProxy, ","))
+	return env
+}
+
+// NewProxyBoothook returns a cloud-init boothook writing the proxy settings to the environment of
+// the instance and of the container runtime and kubelet, and adding the trusted CA bundle to the
+// certificates of the instance. Boothooks run on every boot, before the other parts of the user data.
+func NewProxyBoothook(input *ProxyInput) ([]byte, error) {
+	tm, err := template.New("proxy").Parse(proxyBoothook)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse proxy boothook template")
+	}
+
+	var out bytes.Buffer
+	if err := tm.Execute(&out, struct {
+		EnvironmentFile string
+		Environment     []proxyEnvVar
+		TrustedCA       string
+	}{
+		EnvironmentFile: ProxyEnvironmentFile,
+		Environment:     input.environment(),
+		TrustedCA:       strings.TrimSpace(string(input.TrustedCA)),
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to generate proxy boothook template")
+	}
+
+	return out.Bytes(), nil
+}
+
+// WithProxy returns user data running the proxy boothook before the given cloud-init user data.
+// The output is stable for a given input, so that it can be compared through its hash.
+func WithProxy(userData []byte, input *ProxyInput) ([]byte, error) {
+	boothook, err := NewProxyBoothook(input)
+	if err != nil {
+		return nil, err
+	}
+	out, err := mime.PrependBoothook(userData, boothook)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add proxy boothook to user data")
+	}
+	return out, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+func TestNewProxyBoothook(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       *ProxyInput
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "proxy without trusted CA",
+			input: &ProxyInput{
+				Proxy: infrav1.ProxySpec{
+					HTTPSProxy: "http://proxy.example.com:3128",
+					NoProxy:    []string{"10.0.0.0/16", ".internal"},
+				},
+			},
+			contains: []string{
+				"HTTPS_PROXY='http://proxy.example.com:3128'\nhttps_proxy='http://proxy.example.com:3128'\n",
+				"NO_PROXY='10.0.0.0/16,.internal'\nno_proxy='10.0.0.0/16,.internal'\n",
+				"sed -i -E '/^(HTTPS_PROXY|https_proxy|NO_PROXY|no_proxy)=/d' /etc/environment",
+				"EnvironmentFile=/etc/capa-proxy.env",
+			},
+			notContains: []string{"HTTP_PROXY", "capa-proxy-ca.crt"},
+		},
+		{
+			name: "proxy with trusted CA",
+			input: &ProxyInput{
+				Proxy: infrav1.ProxySpec{
+					HTTPProxy: "http://proxy.example.com:3128",
+				},
+				TrustedCA: []byte("-----BEGIN CERTIFICATE-----\nabc\n-----END CERTIFICATE-----\n"),
+			},
+			contains: []string{
+				"HTTP_PROXY='http://proxy.example.com:3128'\n",
+				"<<'CAPA_PROXY_CA_EOF'\n-----BEGIN CERTIFICATE-----\nabc\n-----END CERTIFICATE-----\nCAPA_PROXY_CA_EOF\n",
+			},
+			notContains: []string{"HTTPS_PROXY", "NO_PROXY"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			out, err := NewProxyBoothook(tc.input)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(out)).To(HavePrefix("#cloud-boothook\n"))
+			for _, s := range tc.contains {
+				g.Expect(string(out)).To(ContainSubstring(s))
+			}
+			for _, s := range tc.notContains {
+				g.Expect(string(out)).NotTo(ContainSubstring(s))
+			}
+		})
+	}
+}
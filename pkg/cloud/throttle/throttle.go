@@ -19,6 +19,7 @@ package throttle
 import (
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws/request"
 
@@ -42,8 +43,13 @@ type OperationLimiter struct {
 	Operation  string
 	RefillRate rate.Limit
 	Burst      int
-	regexp     *regexp.Regexp
-	limiter    *rate.Limiter
+
+	// The limiters of an account are shared by the clusters using it, so they are initialized once.
+	regexpOnce  sync.Once
+	regexp      *regexp.Regexp
+	regexpErr   error
+	limiterOnce sync.Once
+	limiter     *rate.Limiter
 }
 
 // Wait will wait on a request.
@@ -53,12 +59,11 @@ func (o *OperationLimiter) Wait(r *request.Request) error {
 
 // Match will match a request.
 func (o *OperationLimiter) Match(r *request.Request) (bool, error) {
-	if o.regexp == nil {
-		var err error
-		o.regexp, err = regexp.Compile("^" + o.Operation)
-		if err != nil {
-			return false, err
-		}
+	o.regexpOnce.Do(func() {
+		o.regexp, o.regexpErr = regexp.Compile("^" + o.Operation)
+	})
+	if o.regexpErr != nil {
+		return false, o.regexpErr
 	}
 	return o.regexp.Match([]byte(r.Operation.Name)), nil
 }
@@ -71,22 +76,17 @@ func (s ServiceLimiter) LimitRequest(r *request.Request) {
 }
 
 func (o *OperationLimiter) getLimiter() *rate.Limiter {
-	if o.limiter == nil {
+	o.limiterOnce.Do(func() {
 		o.limiter = rate.NewLimiter(o.RefillRate, o.Burst)
-	}
+	})
 	return o.limiter
 }
 
 // ReviewResponse will review the limits of a Request's response.
 func (s ServiceLimiter) ReviewResponse(r *request.Request) {
-	if r.Error != nil {
-		if errorCode, ok := awserrors.Code(r.Error); ok {
-			switch errorCode {
-			case "Throttling", "RequestLimitExceeded":
-				if ol, ok := s.matchRequest(r); ok {
-					ol.limiter.ResetTokens()
-				}
-			}
+	if awserrors.IsThrottled(r.Error) {
+		if ol, ok := s.matchRequest(r); ok {
+			ol.getLimiter().ResetTokens()
 		}
 	}
 }
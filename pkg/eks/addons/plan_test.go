@@ -239,6 +239,59 @@ func TestEKSAddonPlan(t *testing.T) {
 			expectCreateError: false,
 			expectDoError:     false,
 		},
+		{
+			name: "1 installed and 1 desired - configuration update",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					UpdateAddon(gomock.Eq(&eks.UpdateAddonInput{
+						AddonName:           aws.String(addon1Name),
+						AddonVersion:        aws.String(addon1version),
+						ClusterName:         aws.String(clusterName),
+						ResolveConflicts:    aws.String(eks.ResolveConflictsOverwrite),
+						ConfigurationValues: aws.String(`{"env":{"KEY":"value"}}`),
+					})).
+					Return(&eks.UpdateAddonOutput{
+						Update: &eks.Update{
+							CreatedAt: &created,
+							Id:        aws.String("someid"),
+							Status:    aws.String(addonStatusUpdating),
+							Type:      aws.String(eks.UpdateTypeAddonUpdate),
+						},
+					}, nil)
+
+				out := &eks.DescribeAddonOutput{
+					Addon: &eks.Addon{
+						Status: aws.String(eks.AddonStatusActive),
+					},
+				}
+				m.DescribeAddon(gomock.Eq(&eks.DescribeAddonInput{
+					AddonName:   aws.String(addon1Name),
+					ClusterName: aws.String(clusterName),
+				})).Return(out, nil)
+			},
+			desiredAddons: []*EKSAddon{
+				createDesiredAddonWithConfiguration(addon1Name, addon1version, `{"env":{"KEY":"value"}}`),
+			},
+			installedAddons: []*EKSAddon{
+				createInstalledAddon(addon1Name, addon1version, addonARN, addonStatusActive),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name: "1 installed and 1 desired - configuration not managed",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				// Do nothing
+			},
+			desiredAddons: []*EKSAddon{
+				createDesiredAddon(addon1Name, addon1version),
+			},
+			installedAddons: []*EKSAddon{
+				createInstalledAddonWithConfiguration(addon1Name, addon1version, addonARN, addonStatusActive, `{"env":{"KEY":"value"}}`),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
 		{
 			name: "1 installed and 1 desired - version upgrade in progress",
 			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
@@ -440,6 +493,20 @@ func createDesiredAddonExtraTag(name, version string) *EKSAddon {
 	}
 }
 
+func createDesiredAddonWithConfiguration(name, version, configuration string) *EKSAddon {
+	desired := createDesiredAddon(name, version)
+	desired.Configuration = &configuration
+
+	return desired
+}
+
+func createInstalledAddonWithConfiguration(name, version, arn, status, configuration string) *EKSAddon {
+	installed := createInstalledAddon(name, version, arn, status)
+	installed.Configuration = &configuration
+
+	return installed
+}
+
 func createInstalledAddon(name, version, arn, status string) *EKSAddon {
 	desired := createDesiredAddon(name, version)
 	desired.ARN = &arn
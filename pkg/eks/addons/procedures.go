@@ -81,6 +81,7 @@ func (p *UpdateAddonProcedure) Do(ctx context.Context) error {
 		ClusterName:           &p.plan.clusterName,
 		ResolveConflicts:      desired.ResolveConflict,
 		ServiceAccountRoleArn: desired.ServiceAccountRoleARN,
+		ConfigurationValues:   desired.Configuration,
 	}
 
 	if _, err := p.plan.eksClient.UpdateAddon(input); err != nil {
@@ -148,6 +149,7 @@ func (p *CreateAddonProcedure) Do(ctx context.Context) error {
 		AddonVersion:          desired.Version,
 		ClusterName:           &p.plan.clusterName,
 		ServiceAccountRoleArn: desired.ServiceAccountRoleARN,
+		ConfigurationValues:   desired.Configuration,
 		ResolveConflicts:      desired.ResolveConflict,
 		Tags:                  convertTags(desired.Tags),
 	}
@@ -27,6 +27,7 @@ type EKSAddon struct {
 	Name                  *string
 	Version               *string
 	ServiceAccountRoleARN *string
+	Configuration         *string
 	Tags                  infrav1.Tags
 	ResolveConflict       *string
 	ARN                   *string
@@ -45,6 +46,11 @@ func (e *EKSAddon) IsEqual(other *EKSAddon, includeTags bool) bool {
 	if !cmp.Equal(e.ServiceAccountRoleARN, other.ServiceAccountRoleARN) {
 		return false
 	}
+	// The configuration is only compared when set, so that a configuration managed outside
+	// of CAPA is left as is.
+	if e.Configuration != nil && !cmp.Equal(e.Configuration, other.Configuration) {
+		return false
+	}
 
 	if includeTags {
 		diffTags := e.Tags.Difference(other.Tags)
@@ -18,6 +18,7 @@ package identityprovider
 
 import (
 	"context"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
@@ -70,13 +71,21 @@ func (p *plan) Create(ctx context.Context) ([]planner.Procedure, error) {
 		return procedures, nil
 	}
 
+	if p.currentIdentityProvider.Status == eks.ConfigStatusDeleting {
+		// the previous config is being disassociated, the desired one can only be associated once it is gone
+		procedures = append(procedures,
+			&WaitIdentityProviderDisassociatedProcedure{plan: p},
+			&AssociateIdentityProviderProcedure{plan: p},
+		)
+		return procedures, nil
+	}
+
 	if p.currentIdentityProvider.IsEqual(p.desiredIdentityProvider) {
-		tagsDiff := p.desiredIdentityProvider.Tags.Difference(p.currentIdentityProvider.Tags)
-		if len(tagsDiff) > 0 {
+		if len(p.desiredIdentityProvider.Tags.Difference(p.currentIdentityProvider.Tags)) > 0 {
 			procedures = append(procedures, &UpdatedIdentityProviderTagsProcedure{plan: p})
 		}
 
-		if len(p.desiredIdentityProvider.Tags) == 0 && len(p.currentIdentityProvider.Tags) != 0 {
+		if len(p.removedTagKeys()) > 0 {
 			procedures = append(procedures, &RemoveIdentityProviderTagsProcedure{plan: p})
 		}
 		switch p.currentIdentityProvider.Status {
@@ -88,8 +97,29 @@ func (p *plan) Create(ctx context.Context) ([]planner.Procedure, error) {
 			procedures = append(procedures, &WaitIdentityProviderAssociatedProcedure{plan: p})
 		}
 	} else {
-		procedures = append(procedures, &DisassociateIdentityProviderConfig{plan: p})
+		// EKS can't update an identity provider config, so a changed config is replaced. A config
+		// being associated can only be disassociated once it is active.
+		if p.currentIdentityProvider.Status == eks.ConfigStatusCreating {
+			procedures = append(procedures, &WaitIdentityProviderAssociatedProcedure{plan: p})
+		}
+		procedures = append(procedures,
+			&DisassociateIdentityProviderConfig{plan: p},
+			&WaitIdentityProviderDisassociatedProcedure{plan: p},
+			&AssociateIdentityProviderProcedure{plan: p},
+		)
 	}
 
 	return procedures, nil
 }
+
+// removedTagKeys returns the keys of the tags of the current config that aren't desired anymore.
+func (p *plan) removedTagKeys() []string {
+	keys := []string{}
+	for key := range p.currentIdentityProvider.Tags {
+		if _, ok := p.desiredIdentityProvider.Tags[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
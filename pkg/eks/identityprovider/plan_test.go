@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -160,7 +161,7 @@ func TestEKSAddonPlan(t *testing.T) {
 		},
 
 		{
-			name:                    "1 installed and desired client id changed - installed provider is removed",
+			name:                    "1 installed and desired client id changed - installed provider is replaced",
 			currentIdentityProvider: createCurrentIdentityProvider(idnetityProviderName, identityProviderARN, eks.ConfigStatusActive, createTags()),
 			desiredIdentityProvider: createDesiredIdentityProviderWithDifferentClientID(idnetityProviderName, createTags()),
 			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
@@ -172,6 +173,51 @@ func TestEKSAddonPlan(t *testing.T) {
 							Type: oidcType,
 						},
 					}))
+				expectDisassociated(m, clusterName)
+				request := createDesiredIdentityProviderRequest(aws.String(idnetityProviderName))
+				request.ClientId = aws.String("clientId2")
+				m.AssociateIdentityProviderConfigWithContext(gomock.Eq(context.TODO()), gomock.Eq(&eks.AssociateIdentityProviderConfigInput{
+					ClusterName: aws.String(clusterName),
+					Oidc:        request,
+					Tags:        aws.StringMap(createTags()),
+				}))
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name:                    "1 installed and 1 desired - installed is deleting",
+			currentIdentityProvider: createCurrentIdentityProvider(idnetityProviderName, identityProviderARN, eks.ConfigStatusDeleting, createTags()),
+			desiredIdentityProvider: createDesiredIdentityProvider(idnetityProviderName, createTags()),
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				expectDisassociated(m, clusterName)
+				m.AssociateIdentityProviderConfigWithContext(gomock.Eq(context.TODO()), gomock.Eq(&eks.AssociateIdentityProviderConfigInput{
+					ClusterName: aws.String(clusterName),
+					Oidc:        createDesiredIdentityProviderRequest(aws.String(idnetityProviderName)),
+					Tags:        aws.StringMap(createTags()),
+				}))
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name:                    "1 installed and 1 desired - both same and installed is active, and one of the tags removed",
+			currentIdentityProvider: createCurrentIdentityProvider(idnetityProviderName, identityProviderARN, eks.ConfigStatusActive, changeTags(createTags())),
+			desiredIdentityProvider: createDesiredIdentityProvider(idnetityProviderName, createTags()),
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UntagResource(gomock.Eq(&eks.UntagResourceInput{
+					ResourceArn: aws.String(identityProviderARN),
+					TagKeys:     []*string{aws.String("key2")},
+				}))
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name:                    "1 installed and 0 desired - installed is creating",
+			currentIdentityProvider: createCurrentIdentityProvider(idnetityProviderName, identityProviderARN, eks.ConfigStatusCreating, createTags()),
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				// Do nothing until the association completes
 			},
 			expectCreateError: false,
 			expectDoError:     false,
@@ -211,6 +257,18 @@ func TestEKSAddonPlan(t *testing.T) {
 	}
 }
 
+func expectDisassociated(m *mock_eksiface.MockEKSAPIMockRecorder, clusterName string) {
+	m.DescribeIdentityProviderConfigWithContext(gomock.Eq(context.TODO()),
+		gomock.Eq(&eks.DescribeIdentityProviderConfigInput{
+			ClusterName: aws.String(clusterName),
+			IdentityProviderConfig: &eks.IdentityProviderConfig{
+				Name: aws.String("IdentityProviderConfigName"),
+				Type: oidcType,
+			},
+		})).
+		Return(nil, awserr.New(eks.ErrCodeResourceNotFoundException, "not found", nil))
+}
+
 func createTags() infrav1.Tags {
 	tags := infrav1.Tags{}
 	tags["key1"] = "value1"
@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/pkg/errors"
 
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 )
 
@@ -62,6 +63,39 @@ func (w *WaitIdentityProviderAssociatedProcedure) Do(ctx context.Context) error
 	return nil
 }
 
+type WaitIdentityProviderDisassociatedProcedure struct {
+	plan *plan
+}
+
+func (w *WaitIdentityProviderDisassociatedProcedure) Name() string {
+	return "wait_identity_provider_disassociation"
+}
+
+func (w *WaitIdentityProviderDisassociatedProcedure) Do(ctx context.Context) error {
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		_, err := w.plan.eksClient.DescribeIdentityProviderConfigWithContext(ctx, &eks.DescribeIdentityProviderConfigInput{
+			ClusterName: aws.String(w.plan.clusterName),
+			IdentityProviderConfig: &eks.IdentityProviderConfig{
+				Name: aws.String(w.plan.currentIdentityProvider.IdentityProviderConfigName),
+				Type: oidcType,
+			},
+		})
+
+		if err != nil {
+			if awserrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+
+		return false, nil
+	}); err != nil {
+		return errors.Wrap(err, "failed waiting for identity provider disassociation")
+	}
+
+	return nil
+}
+
 type DisassociateIdentityProviderConfig struct {
 	plan *plan
 }
@@ -159,11 +193,7 @@ func (r *RemoveIdentityProviderTagsProcedure) Name() string {
 }
 
 func (r *RemoveIdentityProviderTagsProcedure) Do(ctx context.Context) error {
-	keys := make([]*string, 0, len(r.plan.currentIdentityProvider.Tags))
-
-	for key := range r.plan.currentIdentityProvider.Tags {
-		keys = append(keys, aws.String(key))
-	}
+	keys := aws.StringSlice(r.plan.removedTagKeys())
 
 	arn := r.plan.currentIdentityProvider.IdentityProviderConfigArn
 	_, err := r.plan.eksClient.UntagResource(&eks.UntagResourceInput{
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"sort"
 
 	"github.com/pkg/errors"
 )
@@ -65,6 +66,56 @@ func SplitIntoSubnetsIPv4(cidrBlock string, numSubnets int) ([]*net.IPNet, error
 	return subnets, nil
 }
 
+// AllocateIPv4 allocates non-overlapping subnets with the given prefix lengths in a IPv4 CIDR.
+// The subnets are allocated from the start of the CIDR, largest first so that each of them is
+// aligned on its size without gaps, and subnets of the same size in the order they are requested.
+// The allocation only depends on its inputs, and the subnets are returned in the order of the
+// prefix lengths.
+func AllocateIPv4(cidrBlock string, prefixLengths []int) ([]*net.IPNet, error) {
+	_, parent, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse CIDR")
+	}
+	ip4 := parent.IP.To4()
+	if ip4 == nil {
+		return nil, errors.Errorf("unexpected IP address type: %s", parent)
+	}
+	parentLen, _ := parent.Mask.Size()
+
+	order := make([]int, len(prefixLengths))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return prefixLengths[order[i]] < prefixLengths[order[j]]
+	})
+
+	start := uint64(binary.BigEndian.Uint32(ip4))
+	end := start + uint64(1)<<uint(32-parentLen)
+	next := start
+	subnets := make([]*net.IPNet, len(prefixLengths))
+	for _, i := range order {
+		prefixLength := prefixLengths[i]
+		if prefixLength < parentLen || prefixLength > 32 {
+			return nil, errors.Errorf("cidr %s cannot accommodate a /%d subnet", cidrBlock, prefixLength)
+		}
+		size := uint64(1) << uint(32-prefixLength)
+		if next+size > end {
+			return nil, errors.Errorf("cidr %s cannot accommodate %d subnets of the requested sizes", cidrBlock, len(prefixLengths))
+		}
+
+		subnetIP := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(subnetIP, uint32(next))
+		subnets[i] = &net.IPNet{
+			IP:   subnetIP,
+			Mask: net.CIDRMask(prefixLength, 32),
+		}
+		next += size
+	}
+
+	return subnets, nil
+}
+
 const subnetIDLocation = 7
 
 // SplitIntoSubnetsIPv6 splits a IPv6 address into a specified number of subnets.
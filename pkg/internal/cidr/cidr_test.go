@@ -266,3 +266,60 @@ func TestSplitIntoSubnetsIPv6InvalidCIDR(t *testing.T) {
 	_, err := SplitIntoSubnetsIPv6("2001:db8:cad::", 60)
 	Expect(err).To(MatchError(ContainSubstring("failed to parse cidr block 2001:db8:cad:: with error: invalid CIDR address: 2001:db8:cad::")))
 }
+
+func TestAllocateIPv4(t *testing.T) {
+	tests := []struct {
+		name          string
+		cidrBlock     string
+		prefixLengths []int
+		expected      []string
+		expectErr     bool
+	}{
+		{
+			name:          "public /24 and private /20 subnets in 3 zones",
+			cidrBlock:     "10.0.0.0/16",
+			prefixLengths: []int{24, 20, 24, 20, 24, 20},
+			expected:      []string{"10.0.48.0/24", "10.0.0.0/20", "10.0.49.0/24", "10.0.16.0/20", "10.0.50.0/24", "10.0.32.0/20"},
+		},
+		{
+			name:          "subnets filling the cidr",
+			cidrBlock:     "192.168.0.0/24",
+			prefixLengths: []int{26, 25, 26},
+			expected:      []string{"192.168.0.128/26", "192.168.0.0/25", "192.168.0.192/26"},
+		},
+		{
+			name:          "subnets exceeding the cidr",
+			cidrBlock:     "192.168.0.0/24",
+			prefixLengths: []int{25, 25, 26},
+			expectErr:     true,
+		},
+		{
+			name:          "subnet larger than the cidr",
+			cidrBlock:     "10.0.0.0/16",
+			prefixLengths: []int{15},
+			expectErr:     true,
+		},
+		{
+			name:          "invalid cidr",
+			cidrBlock:     "10.0.0.0",
+			prefixLengths: []int{24},
+			expectErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			subnets, err := AllocateIPv4(tt.cidrBlock, tt.prefixLengths)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			actual := make([]string, 0, len(subnets))
+			for _, subnet := range subnets {
+				actual = append(actual, subnet.String())
+			}
+			g.Expect(actual).To(Equal(tt.expected))
+		})
+	}
+}
@@ -18,11 +18,17 @@ package mime
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"html/template"
+	"io"
+	"mime"
 	"mime/multipart"
+	"net/mail"
 	"net/textproto"
 	"strings"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 )
 
 const (
@@ -38,6 +44,10 @@ var (
 		"content-type": {"text/cloud-boothook"},
 	}
 
+	cloudConfigType = textproto.MIMEHeader{
+		"content-type": {"text/cloud-config"},
+	}
+
 	multipartHeader = strings.Join([]string{
 		"MIME-Version: 1.0",
 		"Content-Type: multipart/mixed; boundary=\"%s\"",
@@ -49,6 +59,7 @@ type scriptVariables struct {
 	SecretPrefix string
 	Chunks       int32
 	Region       string
+	DNSSuffix    string
 	Endpoint     string
 }
 
@@ -72,6 +83,11 @@ func GenerateInitDocument(secretPrefix string, chunks int32, region string, endp
 		Region:       region,
 		Endpoint:     endpoint,
 	}
+	// The script discovers the region and the DNS suffix of its partition from
+	// the instance metadata service when they are left empty.
+	if region != "" {
+		scriptVariables.DNSSuffix = infrav1.DNSSuffixForRegion(region)
+	}
 
 	var scriptBuf bytes.Buffer
 	if err := secretFetchTemplate.Execute(&scriptBuf, scriptVariables); err != nil {
@@ -98,3 +114,148 @@ func GenerateInitDocument(secretPrefix string, chunks int32, region string, endp
 
 	return buf.Bytes(), nil
 }
+
+// contentTypesByPrefix maps the first line of user data to the content type cloud-init processes it as.
+var contentTypesByPrefix = []struct {
+	prefix      string
+	contentType string
+}{
+	{prefix: "#cloud-config", contentType: "text/cloud-config"},
+	{prefix: "#cloud-boothook", contentType: "text/cloud-boothook"},
+	{prefix: "#include", contentType: "text/x-include-url"},
+	{prefix: "#!", contentType: "text/x-shellscript"},
+}
+
+// PrependBoothook returns a multi-part MIME document running the boothook before the user data.
+// The parts of user data which is a multi-part MIME document are copied into the new document,
+// other user data is added as a single part. The boundary of the document is derived from its
+// content, so that the output is stable for a given input.
+func PrependBoothook(userData []byte, boothook []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	mpWriter := multipart.NewWriter(&buf)
+	if err := mpWriter.SetBoundary(fmt.Sprintf("%x", sha256.Sum256(append(append([]byte{}, boothook...), userData...)))[:32]); err != nil {
+		return []byte{}, err
+	}
+	buf.WriteString(fmt.Sprintf(multipartHeader, mpWriter.Boundary()))
+
+	boothookWriter, err := mpWriter.CreatePart(boothookType)
+	if err != nil {
+		return []byte{}, err
+	}
+	if _, err := boothookWriter.Write(boothook); err != nil {
+		return []byte{}, err
+	}
+
+	if err := copyParts(mpWriter, userData); err != nil {
+		return []byte{}, err
+	}
+
+	if err := mpWriter.Close(); err != nil {
+		return []byte{}, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// AppendCloudConfigs returns a multi-part MIME document adding the cloud configs after the user data.
+// cloud-init merges the cloud configs into the cloud config of the user data in order, as set by their
+// merge_how keys. The parts of user data which is a multi-part MIME document are copied into the new
+// document, other user data is added as a single part. The boundary of the document is derived from
+// its content, so that the output is stable for a given input.
+func AppendCloudConfigs(userData []byte, cloudConfigs ...[]byte) ([]byte, error) {
+	content := append([]byte{}, userData...)
+	for _, cloudConfig := range cloudConfigs {
+		content = append(content, cloudConfig...)
+	}
+
+	var buf bytes.Buffer
+	mpWriter := multipart.NewWriter(&buf)
+	if err := mpWriter.SetBoundary(fmt.Sprintf("%x", sha256.Sum256(content))[:32]); err != nil {
+		return []byte{}, err
+	}
+	buf.WriteString(fmt.Sprintf(multipartHeader, mpWriter.Boundary()))
+
+	if err := copyParts(mpWriter, userData); err != nil {
+		return []byte{}, err
+	}
+
+	for _, cloudConfig := range cloudConfigs {
+		partWriter, err := mpWriter.CreatePart(cloudConfigType)
+		if err != nil {
+			return []byte{}, err
+		}
+		if _, err := partWriter.Write(cloudConfig); err != nil {
+			return []byte{}, err
+		}
+	}
+
+	if err := mpWriter.Close(); err != nil {
+		return []byte{}, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// copyParts writes the parts of user data which is a multi-part MIME document to the writer, or
+// other user data as a single part.
+func copyParts(mpWriter *multipart.Writer, userData []byte) error {
+	parts, err := multipartParts(userData)
+	if err != nil {
+		return err
+	}
+	if parts == nil {
+		partWriter, err := mpWriter.CreatePart(textproto.MIMEHeader{"content-type": {contentTypeOf(userData)}})
+		if err != nil {
+			return err
+		}
+		_, err = partWriter.Write(userData)
+		return err
+	}
+	for {
+		part, err := parts.NextRawPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		partWriter, err := mpWriter.CreatePart(part.Header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(partWriter, part); err != nil {
+			return err
+		}
+	}
+}
+
+// multipartParts returns a reader of the parts of user data which is a multi-part MIME document,
+// or nil for other user data.
+func multipartParts(userData []byte) (*multipart.Reader, error) {
+	if !bytes.HasPrefix(userData, []byte("MIME-Version:")) && !bytes.HasPrefix(userData, []byte("Content-Type:")) {
+		return nil, nil
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(userData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse MIME user data: %w", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the content type of MIME user data: %w", err)
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, fmt.Errorf("unsupported content type %q of MIME user data", mediaType)
+	}
+	return multipart.NewReader(msg.Body, params["boundary"]), nil
+}
+
+// contentTypeOf returns the content type of user data which is not a MIME document. cloud-init
+// infers the type of parts of type text/x-not-multipart from their first line.
+func contentTypeOf(userData []byte) string {
+	for _, t := range contentTypesByPrefix {
+		if bytes.HasPrefix(userData, []byte(t.prefix)) {
+			return t.contentType
+		}
+	}
+	return "text/x-not-multipart"
+}
@@ -18,7 +18,10 @@ package mime
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"net/mail"
+	"strings"
 	"testing"
 )
 
@@ -30,3 +33,188 @@ func TestGenerateInitDocument(t *testing.T) {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
 	}
 }
+
+func TestGenerateInitDocumentDNSSuffix(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		want   string
+	}{
+		{
+			name:   "commercial region",
+			region: "eu-west-1",
+			want:   "amazonaws.com",
+		},
+		{
+			name:   "China region",
+			region: "cn-northwest-1",
+			want:   "amazonaws.com.cn",
+		},
+		{
+			name:   "region left to the instance metadata service",
+			region: "",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := GenerateInitDocument("secretARN", 1, tt.region, "", "REGION={{.Region}} DNS_SUFFIX={{.DNSSuffix}}")
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := fmt.Sprintf("REGION=%s DNS_SUFFIX=%s", tt.region, tt.want)
+			if !strings.Contains(string(doc), want) {
+				t.Errorf("expected the init document to contain %q, got:\n%s", want, string(doc))
+			}
+		})
+	}
+}
+
+func TestPrependBoothook(t *testing.T) {
+	boothook := []byte("#cloud-boothook\n#!/bin/bash\necho proxy\n")
+	initDocument, err := GenerateInitDocument("secretARN", 1, "eu-west-1", "", "#cloud-boothook\necho fetch\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		userData     []byte
+		contentTypes []string
+	}{
+		{
+			name:         "cloud-config",
+			userData:     []byte("#cloud-config\nruncmd: []\n"),
+			contentTypes: []string{"text/cloud-boothook", "text/cloud-config"},
+		},
+		{
+			name:         "shell script",
+			userData:     []byte("#!/bin/bash\necho hello\n"),
+			contentTypes: []string{"text/cloud-boothook", "text/x-shellscript"},
+		},
+		{
+			name:         "multi-part MIME document",
+			userData:     initDocument,
+			contentTypes: []string{"text/cloud-boothook", "text/cloud-boothook", "text/x-include-url"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := PrependBoothook(tt.userData, boothook)
+			if err != nil {
+				t.Fatal(err)
+			}
+			again, err := PrependBoothook(tt.userData, boothook)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(doc, again) {
+				t.Fatalf("Output is not stable:\n%s\n%s", doc, again)
+			}
+
+			parts, err := multipartParts(doc)
+			if err != nil {
+				t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
+			}
+			var contentTypes []string
+			var first []byte
+			for {
+				part, err := parts.NextRawPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, err := io.ReadAll(part)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if first == nil {
+					first = body
+				}
+				contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+			}
+			if !bytes.Equal(first, boothook) {
+				t.Errorf("First part is %q, expected the boothook", first)
+			}
+			if strings.Join(contentTypes, ",") != strings.Join(tt.contentTypes, ",") {
+				t.Errorf("Parts have content types %v, expected %v", contentTypes, tt.contentTypes)
+			}
+		})
+	}
+}
+
+func TestAppendCloudConfigs(t *testing.T) {
+	pre := []byte("#cloud-config\nruncmd:\n- echo pre\n")
+	post := []byte("#cloud-config\nruncmd:\n- echo post\n")
+	initDocument, err := GenerateInitDocument("secretARN", 1, "eu-west-1", "", "#cloud-boothook\necho fetch\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		userData     []byte
+		contentTypes []string
+	}{
+		{
+			name:         "cloud-config",
+			userData:     []byte("#cloud-config\nruncmd: []\n"),
+			contentTypes: []string{"text/cloud-config", "text/cloud-config", "text/cloud-config"},
+		},
+		{
+			name:         "jinja template",
+			userData:     []byte("## template: jinja\n#cloud-config\nruncmd: []\n"),
+			contentTypes: []string{"text/x-not-multipart", "text/cloud-config", "text/cloud-config"},
+		},
+		{
+			name:         "multi-part MIME document",
+			userData:     initDocument,
+			contentTypes: []string{"text/cloud-boothook", "text/x-include-url", "text/cloud-config", "text/cloud-config"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := AppendCloudConfigs(tt.userData, pre, post)
+			if err != nil {
+				t.Fatal(err)
+			}
+			again, err := AppendCloudConfigs(tt.userData, pre, post)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(doc, again) {
+				t.Fatalf("Output is not stable:\n%s\n%s", doc, again)
+			}
+
+			parts, err := multipartParts(doc)
+			if err != nil {
+				t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
+			}
+			var contentTypes []string
+			var bodies [][]byte
+			for {
+				part, err := parts.NextRawPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, err := io.ReadAll(part)
+				if err != nil {
+					t.Fatal(err)
+				}
+				bodies = append(bodies, body)
+				contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+			}
+			if strings.Join(contentTypes, ",") != strings.Join(tt.contentTypes, ",") {
+				t.Fatalf("Parts have content types %v, expected %v", contentTypes, tt.contentTypes)
+			}
+			if !bytes.Equal(bodies[len(bodies)-2], pre) || !bytes.Equal(bodies[len(bodies)-1], post) {
+				t.Errorf("Last parts are %q and %q, expected the cloud configs", bodies[len(bodies)-2], bodies[len(bodies)-1])
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rosa provides a minimal client for the OpenShift Cluster Manager (OCM)
+// API used to provision Red Hat OpenShift Service on AWS clusters.
+package rosa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultOCMAPIURL is the production OCM API endpoint.
+	DefaultOCMAPIURL = "https://api.openshift.com"
+	// DefaultTokenURL is the SSO endpoint used to exchange an offline OCM token for an access token.
+	DefaultTokenURL = "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token" //nolint:gosec
+
+	clientID     = "cloud-services"
+	clustersPath = "/api/clusters_mgmt/v1/clusters"
+
+	// tokenExpiryLeeway is subtracted from the access token lifetime so that
+	// requests never race the token expiry.
+	tokenExpiryLeeway = 30 * time.Second
+)
+
+// ErrNotFound is returned when the requested OCM object does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Client is the subset of the OCM API used to manage ROSA clusters and node pools.
+type Client interface {
+	GetCluster(ctx context.Context, name string) (*Cluster, error)
+	CreateCluster(ctx context.Context, cluster *Cluster) (*Cluster, error)
+	DeleteCluster(ctx context.Context, clusterID string) error
+	GetKubeconfig(ctx context.Context, clusterID string) (string, error)
+
+	GetNodePool(ctx context.Context, clusterID, nodePoolID string) (*NodePool, error)
+	CreateNodePool(ctx context.Context, clusterID string, nodePool *NodePool) (*NodePool, error)
+	UpdateNodePool(ctx context.Context, clusterID string, nodePool *NodePool) (*NodePool, error)
+	DeleteNodePool(ctx context.Context, clusterID, nodePoolID string) error
+}
+
+// ClientParams defines the input parameters used to create a new Client.
+type ClientParams struct {
+	// Token is the offline OCM token, as issued on console.redhat.com.
+	Token string
+	// APIURL defaults to DefaultOCMAPIURL.
+	APIURL string
+	// TokenURL defaults to DefaultTokenURL.
+	TokenURL string
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+type client struct {
+	token      string
+	apiURL     string
+	tokenURL   string
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewClient creates a new OCM client from the given parameters.
+func NewClient(params ClientParams) (Client, error) {
+	if params.Token == "" {
+		return nil, errors.New("failed to generate new OCM client: token is required")
+	}
+
+	c := &client{
+		token:      params.Token,
+		apiURL:     strings.TrimSuffix(params.APIURL, "/"),
+		tokenURL:   params.TokenURL,
+		httpClient: params.HTTPClient,
+	}
+	if c.apiURL == "" {
+		c.apiURL = DefaultOCMAPIURL
+	}
+	if c.tokenURL == "" {
+		c.tokenURL = DefaultTokenURL
+	}
+	if c.httpClient == nil {
+		c.httpClient = http.DefaultClient
+	}
+
+	return c, nil
+}
+
+// GetCluster returns the cluster with the given name, or nil if there is no such cluster.
+func (c *client) GetCluster(ctx context.Context, name string) (*Cluster, error) {
+	query := url.Values{}
+	query.Set("search", fmt.Sprintf("name = '%s'", name))
+
+	list := &clusterList{}
+	if err := c.do(ctx, http.MethodGet, clustersPath+"?"+query.Encode(), nil, list); err != nil {
+		return nil, errors.Wrapf(err, "failed to get cluster %q", name)
+	}
+
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+
+	return &list.Items[0], nil
+}
+
+// CreateCluster creates a new cluster and returns it as stored by OCM.
+func (c *client) CreateCluster(ctx context.Context, cluster *Cluster) (*Cluster, error) {
+	created := &Cluster{}
+	if err := c.do(ctx, http.MethodPost, clustersPath, cluster, created); err != nil {
+		return nil, errors.Wrapf(err, "failed to create cluster %q", cluster.Name)
+	}
+
+	return created, nil
+}
+
+// DeleteCluster starts the uninstallation of the cluster with the given id.
+func (c *client) DeleteCluster(ctx context.Context, clusterID string) error {
+	if err := c.do(ctx, http.MethodDelete, clusterPath(clusterID), nil, nil); err != nil {
+		return errors.Wrapf(err, "failed to delete cluster %q", clusterID)
+	}
+
+	return nil
+}
+
+// GetKubeconfig returns the admin kubeconfig of the cluster with the given id.
+func (c *client) GetKubeconfig(ctx context.Context, clusterID string) (string, error) {
+	credentials := &ClusterCredentials{}
+	if err := c.do(ctx, http.MethodGet, clusterPath(clusterID)+"/credentials", nil, credentials); err != nil {
+		return "", errors.Wrapf(err, "failed to get credentials of cluster %q", clusterID)
+	}
+
+	return credentials.Kubeconfig, nil
+}
+
+// GetNodePool returns the node pool with the given id, or nil if there is no such node pool.
+func (c *client) GetNodePool(ctx context.Context, clusterID, nodePoolID string) (*NodePool, error) {
+	nodePool := &NodePool{}
+	if err := c.do(ctx, http.MethodGet, nodePoolPath(clusterID, nodePoolID), nil, nodePool); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get node pool %q", nodePoolID)
+	}
+
+	return nodePool, nil
+}
+
+// CreateNodePool creates a new node pool in the cluster with the given id.
+func (c *client) CreateNodePool(ctx context.Context, clusterID string, nodePool *NodePool) (*NodePool, error) {
+	created := &NodePool{}
+	if err := c.do(ctx, http.MethodPost, clusterPath(clusterID)+"/node_pools", nodePool, created); err != nil {
+		return nil, errors.Wrapf(err, "failed to create node pool %q", nodePool.ID)
+	}
+
+	return created, nil
+}
+
+// UpdateNodePool patches the mutable fields of an existing node pool.
+func (c *client) UpdateNodePool(ctx context.Context, clusterID string, nodePool *NodePool) (*NodePool, error) {
+	updated := &NodePool{}
+	if err := c.do(ctx, http.MethodPatch, nodePoolPath(clusterID, nodePool.ID), nodePool, updated); err != nil {
+		return nil, errors.Wrapf(err, "failed to update node pool %q", nodePool.ID)
+	}
+
+	return updated, nil
+}
+
+// DeleteNodePool deletes the node pool with the given id.
+func (c *client) DeleteNodePool(ctx context.Context, clusterID, nodePoolID string) error {
+	if err := c.do(ctx, http.MethodDelete, nodePoolPath(clusterID, nodePoolID), nil, nil); err != nil {
+		return errors.Wrapf(err, "failed to delete node pool %q", nodePoolID)
+	}
+
+	return nil
+}
+
+func clusterPath(clusterID string) string {
+	return clustersPath + "/" + url.PathEscape(clusterID)
+}
+
+func nodePoolPath(clusterID, nodePoolID string) string {
+	return clusterPath(clusterID) + "/node_pools/" + url.PathEscape(nodePoolID)
+}
+
+func (c *client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal request")
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return responseError(resp.StatusCode, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+
+	return errors.Wrap(json.Unmarshal(data, out), "failed to unmarshal response")
+}
+
+func responseError(statusCode int, data []byte) error {
+	reason := strings.TrimSpace(string(data))
+	apiErr := &apiError{}
+	if err := json.Unmarshal(data, apiErr); err == nil && apiErr.Reason != "" {
+		reason = apiErr.Reason
+	}
+
+	if statusCode == http.StatusNotFound {
+		return errors.Wrap(ErrNotFound, reason)
+	}
+
+	return errors.Errorf("OCM API returned status %d: %s", statusCode, reason)
+}
+
+func (c *client) getAccessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken != "" && time.Now().Before(c.expiresAt) {
+		return c.accessToken, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", clientID)
+	form.Set("refresh_token", c.token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to exchange OCM token")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("failed to exchange OCM token: status %d", resp.StatusCode)
+	}
+
+	token := &tokenResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(token); err != nil {
+		return "", errors.Wrap(err, "failed to decode OCM token response")
+	}
+
+	c.accessToken = token.AccessToken
+	c.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryLeeway)
+
+	return c.accessToken, nil
+}
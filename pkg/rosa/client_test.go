@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func newTestServer(t *testing.T, tokenRequests *int, handler http.HandlerFunc) (*httptest.Server, Client) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		*tokenRequests++
+		if err := r.ParseForm(); err != nil || r.Form.Get("refresh_token") != "offline-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(tokenResponse{AccessToken: "access-token", ExpiresIn: 300})
+	})
+	mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler(w, r)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(ClientParams{
+		Token:    "offline-token",
+		APIURL:   server.URL,
+		TokenURL: server.URL + "/token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, c
+}
+
+func TestGetCluster(t *testing.T) {
+	testCases := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    *Cluster
+		wantErr bool
+	}{
+		{
+			name: "cluster exists, should return it",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("search") != "name = 'test-cluster'" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				_, _ = w.Write([]byte(`{"items":[{"id":"abc","name":"test-cluster","state":"ready","api":{"url":"https://api.test:6443"}}]}`))
+			},
+			want: &Cluster{
+				ID:    "abc",
+				Name:  "test-cluster",
+				State: ClusterStateReady,
+				API:   &ClusterAPI{URL: "https://api.test:6443"},
+			},
+		},
+		{
+			name: "cluster does not exist, should return nil",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"items":[]}`))
+			},
+		},
+		{
+			name: "OCM returns an error, should return the reason",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"kind":"Error","reason":"something went wrong"}`))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			tokenRequests := 0
+			_, c := newTestServer(t, &tokenRequests, tc.handler)
+
+			cluster, err := c.GetCluster(context.TODO(), "test-cluster")
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("something went wrong"))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(cluster).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestNodePools(t *testing.T) {
+	g := NewWithT(t)
+
+	var created NodePool
+	tokenRequests := 0
+	_, c := newTestServer(t, &tokenRequests, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/clusters_mgmt/v1/clusters/abc/node_pools":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			created.Status = &NodePoolStatus{CurrentReplicas: 0}
+			_ = json.NewEncoder(w).Encode(created)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/clusters_mgmt/v1/clusters/abc/node_pools/workers":
+			_ = json.NewEncoder(w).Encode(created)
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"kind":"Error","reason":"Node pool 'missing' not found"}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	replicas := 2
+	nodePool, err := c.CreateNodePool(context.TODO(), "abc", &NodePool{
+		ID:          "workers",
+		Replicas:    &replicas,
+		AWSNodePool: &AWSNodePool{InstanceType: "m5.xlarge"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(nodePool.ID).To(Equal("workers"))
+	g.Expect(nodePool.AWSNodePool.InstanceType).To(Equal("m5.xlarge"))
+
+	nodePool, err = c.GetNodePool(context.TODO(), "abc", "workers")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*nodePool.Replicas).To(Equal(2))
+
+	nodePool, err = c.GetNodePool(context.TODO(), "abc", "missing")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(nodePool).To(BeNil())
+
+	g.Expect(c.DeleteNodePool(context.TODO(), "abc", "workers")).To(Succeed())
+
+	_, err = c.UpdateNodePool(context.TODO(), "abc", &NodePool{ID: "workers"})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(errors.Is(err, ErrNotFound)).To(BeFalse())
+
+	// The access token is cached across requests.
+	g.Expect(tokenRequests).To(Equal(1))
+}
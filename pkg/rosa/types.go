@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rosa
+
+// ClusterState is the provisioning state reported by OCM for a cluster.
+type ClusterState string
+
+const (
+	// ClusterStateInstalling is reported while the cluster is being installed.
+	ClusterStateInstalling = ClusterState("installing")
+	// ClusterStateReady is reported once the cluster is installed and serving.
+	ClusterStateReady = ClusterState("ready")
+	// ClusterStateError is reported when the installation has failed.
+	ClusterStateError = ClusterState("error")
+	// ClusterStateUninstalling is reported while the cluster is being deleted.
+	ClusterStateUninstalling = ClusterState("uninstalling")
+)
+
+// ObjectRef references another OCM object by id.
+type ObjectRef struct {
+	ID string `json:"id,omitempty"`
+}
+
+// Cluster is the subset of the OCM clusters_mgmt cluster object used by the provider.
+type Cluster struct {
+	ID            string          `json:"id,omitempty"`
+	Name          string          `json:"name,omitempty"`
+	State         ClusterState    `json:"state,omitempty"`
+	Product       *ObjectRef      `json:"product,omitempty"`
+	CloudProvider *ObjectRef      `json:"cloud_provider,omitempty"`
+	Region        *ObjectRef      `json:"region,omitempty"`
+	Version       *ObjectRef      `json:"version,omitempty"`
+	MultiAZ       bool            `json:"multi_az,omitempty"`
+	CCS           *CCS            `json:"ccs,omitempty"`
+	Hypershift    *Hypershift     `json:"hypershift,omitempty"`
+	AWS           *ClusterAWS     `json:"aws,omitempty"`
+	Nodes         *ClusterNodes   `json:"nodes,omitempty"`
+	API           *ClusterAPI     `json:"api,omitempty"`
+	Console       *ClusterConsole `json:"console,omitempty"`
+	Status        *ClusterStatus  `json:"status,omitempty"`
+}
+
+// CCS configures a customer cloud subscription cluster.
+type CCS struct {
+	Enabled bool `json:"enabled"`
+}
+
+// Hypershift configures a hosted control plane cluster.
+type Hypershift struct {
+	Enabled bool `json:"enabled"`
+}
+
+// ClusterAWS holds the AWS specific settings of a cluster.
+type ClusterAWS struct {
+	AccountID string   `json:"account_id,omitempty"`
+	SubnetIDs []string `json:"subnet_ids,omitempty"`
+	STS       *STS     `json:"sts,omitempty"`
+}
+
+// STS holds the IAM roles the cluster assumes through AWS STS.
+type STS struct {
+	RoleARN          string            `json:"role_arn,omitempty"`
+	SupportRoleARN   string            `json:"support_role_arn,omitempty"`
+	InstanceIAMRoles *InstanceIAMRoles `json:"instance_iam_roles,omitempty"`
+	OperatorIAMRoles []OperatorIAMRole `json:"operator_iam_roles,omitempty"`
+	OIDCConfig       *ObjectRef        `json:"oidc_config,omitempty"`
+}
+
+// InstanceIAMRoles holds the roles attached to the cluster instances.
+type InstanceIAMRoles struct {
+	WorkerRoleARN string `json:"worker_role_arn,omitempty"`
+}
+
+// OperatorIAMRole maps the service account of a cluster operator to an IAM role.
+type OperatorIAMRole struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	RoleARN   string `json:"role_arn"`
+}
+
+// ClusterNodes holds the settings of the default compute nodes.
+type ClusterNodes struct {
+	Compute            int        `json:"compute,omitempty"`
+	ComputeMachineType *ObjectRef `json:"compute_machine_type,omitempty"`
+	AvailabilityZones  []string   `json:"availability_zones,omitempty"`
+}
+
+// ClusterAPI holds the API server details of a cluster.
+type ClusterAPI struct {
+	URL string `json:"url,omitempty"`
+}
+
+// ClusterConsole holds the web console details of a cluster.
+type ClusterConsole struct {
+	URL string `json:"url,omitempty"`
+}
+
+// ClusterStatus holds additional status information of a cluster.
+type ClusterStatus struct {
+	Description           string `json:"description,omitempty"`
+	ProvisionErrorMessage string `json:"provision_error_message,omitempty"`
+}
+
+// ClusterCredentials holds the admin kubeconfig of a cluster.
+type ClusterCredentials struct {
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+}
+
+// NodePool is the subset of the OCM clusters_mgmt node pool object used by the provider.
+type NodePool struct {
+	ID               string               `json:"id,omitempty"`
+	Replicas         *int                 `json:"replicas,omitempty"`
+	Autoscaling      *NodePoolAutoscaling `json:"autoscaling,omitempty"`
+	AWSNodePool      *AWSNodePool         `json:"aws_node_pool,omitempty"`
+	AvailabilityZone string               `json:"availability_zone,omitempty"`
+	Subnet           string               `json:"subnet,omitempty"`
+	Labels           map[string]string    `json:"labels,omitempty"`
+	Taints           []NodePoolTaint      `json:"taints,omitempty"`
+	AutoRepair       *bool                `json:"auto_repair,omitempty"`
+	Status           *NodePoolStatus      `json:"status,omitempty"`
+}
+
+// NodePoolAutoscaling holds the replica bounds of an autoscaled node pool.
+type NodePoolAutoscaling struct {
+	MinReplica int `json:"min_replica"`
+	MaxReplica int `json:"max_replica"`
+}
+
+// AWSNodePool holds the AWS specific settings of a node pool.
+type AWSNodePool struct {
+	InstanceType string `json:"instance_type,omitempty"`
+}
+
+// NodePoolTaint is a taint applied to the nodes of a node pool.
+type NodePoolTaint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
+// NodePoolStatus holds the observed state of a node pool.
+type NodePoolStatus struct {
+	CurrentReplicas int    `json:"current_replicas,omitempty"`
+	Message         string `json:"message,omitempty"`
+}
+
+type clusterList struct {
+	Items []Cluster `json:"items"`
+}
+
+type apiError struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
@@ -0,0 +1,128 @@
+//go:build localstack
+// +build localstack
+
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package localstack runs the reconcile paths of the AWS services against a LocalStack
+// or moto server, see "make test-localstack".
+package localstack
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/endpoints"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/elb"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+var securityGroupRoles = []infrav1.SecurityGroupRole{
+	infrav1.SecurityGroupAPIServerLB,
+	infrav1.SecurityGroupLB,
+	infrav1.SecurityGroupControlPlane,
+	infrav1.SecurityGroupNode,
+}
+
+// newClusterScope returns the scope of a cluster whose AWS requests are all sent to the
+// server at LOCALSTACK_ENDPOINT. The test is skipped when it isn't set.
+func newClusterScope(t *testing.T, awsCluster *infrav1.AWSCluster) *scope.ClusterScope {
+	t.Helper()
+
+	localStackEndpoint := os.Getenv("LOCALSTACK_ENDPOINT")
+	if localStackEndpoint == "" {
+		t.Skip("LOCALSTACK_ENDPOINT is not set")
+	}
+	serviceEndpoints, err := endpoints.LocalStack(localStackEndpoint, nil)
+	if err != nil {
+		t.Fatalf("failed to parse LOCALSTACK_ENDPOINT: %v", err)
+	}
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).Build()
+
+	awsCluster.Default()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: awsCluster.Name, Namespace: awsCluster.Namespace},
+		},
+		AWSCluster: awsCluster,
+		Endpoints:  serviceEndpoints,
+	})
+	if err != nil {
+		t.Fatalf("failed to create cluster scope: %v", err)
+	}
+	return clusterScope
+}
+
+func TestReconcileClusterInfrastructure(t *testing.T) {
+	g := NewWithT(t)
+
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "localstack", Namespace: metav1.NamespaceDefault},
+		Spec: infrav1.AWSClusterSpec{
+			Region: "us-east-1",
+			ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+			},
+		},
+	}
+	clusterScope := newClusterScope(t, awsCluster)
+
+	networkSvc := network.NewService(clusterScope)
+	sgSvc := securitygroup.NewService(clusterScope, securityGroupRoles)
+	elbSvc := elb.NewService(clusterScope)
+
+	// Clean up in the same order as the AWSCluster controller, even when reconciling fails half way.
+	t.Cleanup(func() {
+		g := NewWithT(t)
+		g.Expect(elbSvc.DeleteLoadbalancers()).To(Succeed())
+		g.Expect(sgSvc.DeleteSecurityGroups()).To(Succeed())
+		g.Expect(networkSvc.DeleteNetwork()).To(Succeed())
+	})
+
+	g.Expect(networkSvc.ReconcileNetwork()).To(Succeed())
+	g.Expect(clusterScope.VPC().ID).NotTo(BeEmpty())
+	g.Expect(clusterScope.Subnets().FilterPrivate()).NotTo(BeEmpty())
+	g.Expect(clusterScope.Subnets().FilterPublic()).NotTo(BeEmpty())
+
+	g.Expect(sgSvc.ReconcileSecurityGroups()).To(Succeed())
+	for _, role := range securityGroupRoles {
+		g.Expect(clusterScope.SecurityGroups()[role].ID).NotTo(BeEmpty(), "missing security group for role %s", role)
+	}
+
+	g.Expect(elbSvc.ReconcileLoadbalancers()).To(Succeed())
+	g.Expect(awsCluster.Status.Network.APIServerELB.DNSName).NotTo(BeEmpty())
+
+	// A second reconcile must find the resources created by the first one.
+	vpcID := clusterScope.VPC().ID
+	g.Expect(networkSvc.ReconcileNetwork()).To(Succeed())
+	g.Expect(sgSvc.ReconcileSecurityGroups()).To(Succeed())
+	g.Expect(elbSvc.ReconcileLoadbalancers()).To(Succeed())
+	g.Expect(clusterScope.VPC().ID).To(Equal(vpcID))
+}
@@ -290,6 +290,20 @@ func (mr *MockClusterScoperMockRecorder) Namespace() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Namespace", reflect.TypeOf((*MockClusterScoper)(nil).Namespace))
 }
 
+// Partition mocks base method.
+func (m *MockClusterScoper) Partition() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Partition")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Partition indicates an expected call of Partition.
+func (mr *MockClusterScoperMockRecorder) Partition() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Partition", reflect.TypeOf((*MockClusterScoper)(nil).Partition))
+}
+
 // PatchObject mocks base method.
 func (m *MockClusterScoper) PatchObject() error {
 	m.ctrl.T.Helper()
@@ -304,6 +318,20 @@ func (mr *MockClusterScoperMockRecorder) PatchObject() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchObject", reflect.TypeOf((*MockClusterScoper)(nil).PatchObject))
 }
 
+// Proxy mocks base method.
+func (m *MockClusterScoper) Proxy() *v1beta2.ProxySpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Proxy")
+	ret0, _ := ret[0].(*v1beta2.ProxySpec)
+	return ret0
+}
+
+// Proxy indicates an expected call of Proxy.
+func (mr *MockClusterScoperMockRecorder) Proxy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Proxy", reflect.TypeOf((*MockClusterScoper)(nil).Proxy))
+}
+
 // Region mocks base method.
 func (m *MockClusterScoper) Region() string {
 	m.ctrl.T.Helper()
@@ -26,4 +26,21 @@ limitations under the License.
 //go:generate ../../hack/tools/bin/mockgen -destination aws_ec2api_mock.go -package mocks github.com/aws/aws-sdk-go/service/ec2/ec2iface EC2API
 //go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt aws_ec2api_mock.go > _aws_ec2api_mock.go && mv _aws_ec2api_mock.go aws_ec2api_mock.go"
 
+//go:generate ../../hack/tools/bin/mockgen -destination aws_wafv2_mock.go -package mocks github.com/aws/aws-sdk-go/service/wafv2/wafv2iface WAFV2API
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt aws_wafv2_mock.go > _aws_wafv2_mock.go && mv _aws_wafv2_mock.go aws_wafv2_mock.go"
+
+//go:generate ../../hack/tools/bin/mockgen -destination aws_shield_mock.go -package mocks github.com/aws/aws-sdk-go/service/shield/shieldiface ShieldAPI
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt aws_shield_mock.go > _aws_shield_mock.go && mv _aws_shield_mock.go aws_shield_mock.go"
+
+//go:generate ../../hack/tools/bin/mockgen -destination aws_route53_mock.go -package mocks github.com/aws/aws-sdk-go/service/route53/route53iface Route53API
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt aws_route53_mock.go > _aws_route53_mock.go && mv _aws_route53_mock.go aws_route53_mock.go"
+
+//go:generate ../../hack/tools/bin/mockgen -destination aws_outposts_mock.go -package mocks github.com/aws/aws-sdk-go/service/outposts/outpostsiface OutpostsAPI
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt aws_outposts_mock.go > _aws_outposts_mock.go && mv _aws_outposts_mock.go aws_outposts_mock.go"
+
+//go:generate ../../hack/tools/bin/mockgen -destination aws_cloudwatch_mock.go -package mocks github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface CloudWatchAPI
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt aws_cloudwatch_mock.go > _aws_cloudwatch_mock.go && mv _aws_cloudwatch_mock.go aws_cloudwatch_mock.go"
+//go:generate ../../hack/tools/bin/mockgen -destination aws_pricing_mock.go -package mocks github.com/aws/aws-sdk-go/service/pricing/pricingiface PricingAPI
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt aws_pricing_mock.go > _aws_pricing_mock.go && mv _aws_pricing_mock.go aws_pricing_mock.go"
+
 package mocks